@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// session is the authenticated state of one tenant, shared by all workers.
+type session struct {
+	tenant Tenant
+
+	mu    sync.RWMutex
+	token string
+
+	// Reference data resolved at setup and used to build documents.
+	organizationID string
+	counterpartyID string
+	warehouseID    string
+	vatRateID      string
+	products       []product
+}
+
+type product struct {
+	ID     string
+	UnitID string
+}
+
+func (s *session) accessToken() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.token
+}
+
+// client wraps net/http with tenant headers and latency recording.
+type client struct {
+	cfg   *Config
+	http  *http.Client
+	stats *Stats
+}
+
+func newClient(cfg *Config, stats *Stats) *client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = cfg.Concurrency
+	return &client{
+		cfg:   cfg,
+		http:  &http.Client{Timeout: cfg.Timeout, Transport: transport},
+		stats: stats,
+	}
+}
+
+// errStatus is returned for non-2xx responses.
+type errStatus struct {
+	Status int
+	Code   string
+}
+
+func (e *errStatus) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("HTTP %d %s", e.Status, e.Code)
+	}
+	return fmt.Sprintf("HTTP %d", e.Status)
+}
+
+// call performs one API request recorded under op. in is JSON-encoded when
+// non-nil; out receives the decoded 2xx body when non-nil. A 401 triggers a
+// single re-login and retry (tokens expire during long runs).
+func (c *client) call(ctx context.Context, s *session, op, method, path string, in, out any, headers ...string) error {
+	err := c.do(ctx, s, op, method, path, in, out, headers...)
+	var se *errStatus
+	if errors.As(err, &se) && se.Status == http.StatusUnauthorized && op != "auth.login" {
+		if lerr := c.login(ctx, s); lerr != nil {
+			return lerr
+		}
+		err = c.do(ctx, s, op, method, path, in, out, headers...)
+	}
+	return err
+}
+
+func (c *client) do(ctx context.Context, s *session, op, method, path string, in, out any, headers ...string) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.cfg.BaseURL+"/api/v1"+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("X-Tenant-ID", s.tenant.ID)
+	if token := s.accessToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+
+	start := time.Now()
+	resp, err := c.http.Do(req)
+	if err != nil {
+		// Requests cut off by the end of the run are not failures of the target.
+		if ctx.Err() == nil {
+			c.stats.Record(op, time.Since(start), 0, true)
+		}
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	elapsed := time.Since(start)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.stats.Record(op, elapsed, 0, true)
+		}
+		return err
+	}
+
+	failed := resp.StatusCode >= 400
+	c.stats.Record(op, elapsed, resp.StatusCode, failed)
+	if failed {
+		var envelope struct {
+			Code string `json:"code"`
+		}
+		_ = json.Unmarshal(data, &envelope)
+		return &errStatus{Status: resp.StatusCode, Code: envelope.Code}
+	}
+	if out != nil && len(data) > 0 {
+		return json.Unmarshal(data, out)
+	}
+	return nil
+}
+
+// login authenticates the tenant session and stores the access token.
+func (c *client) login(ctx context.Context, s *session) error {
+	var resp struct {
+		Tokens struct {
+			AccessToken string `json:"accessToken"`
+		} `json:"tokens"`
+	}
+	err := c.do(ctx, s, "auth.login", http.MethodPost, "/auth/login",
+		map[string]string{"email": s.tenant.Email, "password": s.tenant.Password}, &resp)
+	if err != nil {
+		return err
+	}
+	if resp.Tokens.AccessToken == "" {
+		return errors.New("login response has no access token")
+	}
+	s.mu.Lock()
+	s.token = resp.Tokens.AccessToken
+	s.mu.Unlock()
+	return nil
+}
+
+type listItem struct {
+	ID         string  `json:"id"`
+	IsFolder   bool    `json:"isFolder"`
+	BaseUnitID *string `json:"baseUnitId"`
+}
+
+type listPage struct {
+	Items []listItem `json:"items"`
+}
+
+// loadFixtures resolves the references needed to create goods receipts.
+// The tenant must already contain at least one organization, supplier,
+// warehouse, VAT rate, unit and goods item (e.g. from cmd/seed).
+func (c *client) loadFixtures(ctx context.Context, s *session) error {
+	first := func(prefix string) (string, error) {
+		var page listPage
+		if err := c.call(ctx, s, "setup", http.MethodGet, "/catalog/"+prefix+"?limit=50", nil, &page); err != nil {
+			return "", fmt.Errorf("list %s: %w", prefix, err)
+		}
+		for _, it := range page.Items {
+			if !it.IsFolder {
+				return it.ID, nil
+			}
+		}
+		return "", fmt.Errorf("tenant has no %s; seed reference data first", prefix)
+	}
+
+	var err error
+	if s.organizationID, err = first("organizations"); err != nil {
+		return err
+	}
+	if s.counterpartyID, err = first("counterparties"); err != nil {
+		return err
+	}
+	if s.warehouseID, err = first("warehouses"); err != nil {
+		return err
+	}
+	if s.vatRateID, err = first("vat-rates"); err != nil {
+		return err
+	}
+	defaultUnit, err := first("units")
+	if err != nil {
+		return err
+	}
+
+	var page listPage
+	if err := c.call(ctx, s, "setup", http.MethodGet, "/catalog/nomenclatures?limit=200", nil, &page); err != nil {
+		return fmt.Errorf("list nomenclatures: %w", err)
+	}
+	for _, it := range page.Items {
+		if it.IsFolder {
+			continue
+		}
+		p := product{ID: it.ID, UnitID: defaultUnit}
+		if it.BaseUnitID != nil && *it.BaseUnitID != "" {
+			p.UnitID = *it.BaseUnitID
+		}
+		s.products = append(s.products, p)
+	}
+	if len(s.products) == 0 {
+		return errors.New("tenant has no nomenclatures; seed reference data first")
+	}
+	return nil
+}
+
+// newIdempotencyKey returns a random key for X-Idempotency-Key.
+func newIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the load generator settings.
+type Config struct {
+	BaseURL      string
+	Tenants      []Tenant
+	Concurrency  int
+	Duration     time.Duration
+	Iterations   int
+	Think        time.Duration
+	Timeout      time.Duration
+	Mix          Mix
+	MaxLines     int
+	JSON         bool
+	MaxErrorRate float64
+}
+
+// Tenant is one entry of the tenant mix.
+type Tenant struct {
+	ID       string `json:"id"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Weight   int    `json:"weight"`
+}
+
+func parseFlags(args []string) (*Config, error) {
+	fs := flag.NewFlagSet("loadgen", flag.ContinueOnError)
+	cfg := &Config{}
+
+	var tenants, tenantsFile, email, password, mix string
+	fs.StringVar(&cfg.BaseURL, "url", envOr("LOADGEN_URL", "http://localhost:8080"), "target base URL (without /api/v1)")
+	fs.StringVar(&tenants, "tenants", os.Getenv("LOADGEN_TENANTS"), "comma-separated tenant IDs with optional weight: id[:weight],...")
+	fs.StringVar(&tenantsFile, "tenants-file", "", "JSON file with [{\"id\",\"email\",\"password\",\"weight\"}] (overrides -tenants)")
+	fs.StringVar(&email, "email", envOr("LOADGEN_EMAIL", "admin@metapus.io"), "login email for -tenants")
+	fs.StringVar(&password, "password", os.Getenv("LOADGEN_PASSWORD"), "login password for -tenants")
+	fs.IntVar(&cfg.Concurrency, "c", 8, "number of concurrent workers")
+	fs.DurationVar(&cfg.Duration, "duration", time.Minute, "test duration (0 = until -n iterations or Ctrl+C)")
+	fs.IntVar(&cfg.Iterations, "n", 0, "total scenario iterations across all workers (0 = unlimited)")
+	fs.DurationVar(&cfg.Think, "think", 0, "pause between iterations of a worker")
+	fs.DurationVar(&cfg.Timeout, "timeout", 30*time.Second, "per-request timeout")
+	fs.StringVar(&mix, "mix", "receipt=5,browse=4,report=1", "scenario weights: "+strings.Join(scenarioNames(), ", "))
+	fs.IntVar(&cfg.MaxLines, "lines", 10, "max lines per created document")
+	fs.BoolVar(&cfg.JSON, "json", false, "print the report as JSON")
+	fs.Float64Var(&cfg.MaxErrorRate, "max-error-rate", -1, "exit non-zero when the error rate exceeds this fraction (e.g. 0.01)")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if tenantsFile != "" {
+		cfg.Tenants, err = loadTenantsFile(tenantsFile)
+	} else {
+		cfg.Tenants, err = parseTenants(tenants, email, password)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Mix, err = parseMix(mix); err != nil {
+		return nil, err
+	}
+
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	if cfg.Concurrency < 1 {
+		return nil, errors.New("-c must be at least 1")
+	}
+	if cfg.MaxLines < 1 {
+		cfg.MaxLines = 1
+	}
+	if cfg.Duration == 0 && cfg.Iterations == 0 {
+		fmt.Fprintln(os.Stderr, "loadgen: no -duration or -n given, running until interrupted")
+	}
+	return cfg, nil
+}
+
+func parseTenants(spec, email, password string) ([]Tenant, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, errors.New("no tenants: set -tenants or -tenants-file")
+	}
+	var out []Tenant
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		t := Tenant{ID: part, Email: email, Password: password, Weight: 1}
+		if tenantID, w, ok := strings.Cut(part, ":"); ok {
+			weight, err := strconv.Atoi(w)
+			if err != nil || weight < 0 {
+				return nil, fmt.Errorf("invalid tenant weight %q", part)
+			}
+			t.ID, t.Weight = tenantID, weight
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func loadTenantsFile(path string) ([]Tenant, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var tenants []Tenant
+	if err := json.Unmarshal(data, &tenants); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(tenants) == 0 {
+		return nil, fmt.Errorf("%s: no tenants", path)
+	}
+	for i := range tenants {
+		if tenants[i].Weight == 0 {
+			tenants[i].Weight = 1
+		}
+	}
+	return tenants, nil
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
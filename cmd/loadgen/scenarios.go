@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scenario is one user journey. Run records every request it makes; errors
+// end the iteration early and are visible in the report, not returned.
+type Scenario struct {
+	Name   string
+	Weight int
+	Run    func(ctx context.Context, c *client, s *session, rnd *rand.Rand)
+}
+
+// Mix is the weighted set of scenarios workers pick from.
+type Mix []Scenario
+
+func (m Mix) String() string {
+	parts := make([]string, len(m))
+	for i, sc := range m {
+		parts[i] = fmt.Sprintf("%s=%d", sc.Name, sc.Weight)
+	}
+	return strings.Join(parts, ",")
+}
+
+var scenarios = map[string]func(ctx context.Context, c *client, s *session, rnd *rand.Rand){
+	// login: fresh authentication (bcrypt + session issue) per iteration.
+	"login": func(ctx context.Context, c *client, s *session, _ *rand.Rand) {
+		_ = c.login(ctx, s)
+	},
+
+	// browse: journal page, document form, catalog search.
+	"browse": func(ctx context.Context, c *client, s *session, rnd *rand.Rand) {
+		var page listPage
+		if err := c.call(ctx, s, "goods-receipt.list", http.MethodGet, "/document/goods-receipt?limit=50", nil, &page); err != nil {
+			return
+		}
+		if len(page.Items) > 0 {
+			docID := page.Items[rnd.IntN(len(page.Items))].ID
+			_ = c.call(ctx, s, "goods-receipt.get", http.MethodGet, "/document/goods-receipt/"+docID, nil, nil)
+		}
+		search := string(rune('a' + rnd.IntN(26)))
+		_ = c.call(ctx, s, "nomenclature.search", http.MethodGet, "/catalog/nomenclatures?limit=20&search="+search, nil, nil)
+	},
+
+	// receipt: create a goods receipt, then post it (stock + cost movements).
+	"receipt": func(ctx context.Context, c *client, s *session, rnd *rand.Rand) {
+		lines := make([]map[string]any, 1+rnd.IntN(c.cfg.MaxLines))
+		for i := range lines {
+			p := s.products[rnd.IntN(len(s.products))]
+			lines[i] = map[string]any{
+				"nomenclatureId": p.ID,
+				"unitId":         p.UnitID,
+				"coefficient":    "1",
+				"quantity":       strconv.Itoa(1 + rnd.IntN(20)),
+				"unitPrice":      strconv.Itoa(100 * (1 + rnd.IntN(1000))),
+				"vatRateId":      s.vatRateID,
+			}
+		}
+		body := map[string]any{
+			"date":           time.Now().UTC().Format(time.RFC3339),
+			"organizationId": s.organizationID,
+			"counterpartyId": s.counterpartyID,
+			"warehouseId":    s.warehouseID,
+			"description":    "loadgen",
+			"lines":          lines,
+		}
+
+		var created struct {
+			ID string `json:"id"`
+		}
+		if err := c.call(ctx, s, "goods-receipt.create", http.MethodPost, "/document/goods-receipt", body, &created,
+			"X-Idempotency-Key", newIdempotencyKey()); err != nil || created.ID == "" {
+			return
+		}
+		_ = c.call(ctx, s, "goods-receipt.post", http.MethodPost, "/document/goods-receipt/"+created.ID+"/post", nil, nil)
+	},
+
+	// report: stock balance and month-to-date turnover.
+	"report": func(ctx context.Context, c *client, s *session, _ *rand.Rand) {
+		now := time.Now().UTC()
+		_ = c.call(ctx, s, "report.stock-balance", http.MethodPost, "/reports/stock-balance",
+			map[string]any{"dataset": "stock-balance", "limit": 500}, nil)
+		_ = c.call(ctx, s, "report.stock-turnover", http.MethodPost, "/reports/stock-turnover",
+			map[string]any{
+				"dataset": "stock-turnover",
+				"limit":   500,
+				"filters": map[string]any{
+					"from_date": now.AddDate(0, 0, 1-now.Day()).Format(time.DateOnly),
+					"to_date":   now.Format(time.DateOnly),
+				},
+			}, nil)
+	},
+}
+
+func scenarioNames() []string {
+	names := make([]string, 0, len(scenarios))
+	for name := range scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseMix parses "receipt=5,browse=4,report=1".
+func parseMix(spec string) (Mix, error) {
+	var mix Mix
+	total := 0
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, w, ok := strings.Cut(part, "=")
+		weight := 1
+		if ok {
+			var err error
+			if weight, err = strconv.Atoi(w); err != nil || weight < 0 {
+				return nil, fmt.Errorf("invalid scenario weight %q", part)
+			}
+		}
+		run, known := scenarios[name]
+		if !known {
+			return nil, fmt.Errorf("unknown scenario %q (available: %s)", name, strings.Join(scenarioNames(), ", "))
+		}
+		mix = append(mix, Scenario{Name: name, Weight: weight, Run: run})
+		total += weight
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("scenario mix %q has no positive weights", spec)
+	}
+	return mix, nil
+}
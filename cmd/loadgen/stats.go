@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Stats collects per-operation latencies and outcomes. Safe for concurrent use.
+type Stats struct {
+	mu  sync.Mutex
+	ops map[string]*opStats
+}
+
+type opStats struct {
+	latencies []time.Duration
+	errors    int
+	byStatus  map[int]int // HTTP status → count; 0 = transport error
+}
+
+// NewStats creates an empty collector.
+func NewStats() *Stats {
+	return &Stats{ops: make(map[string]*opStats)}
+}
+
+// Record stores one request outcome. status is the HTTP status code or 0
+// when the request failed before a response was received.
+func (s *Stats) Record(op string, d time.Duration, status int, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.ops[op]
+	if o == nil {
+		o = &opStats{byStatus: make(map[int]int)}
+		s.ops[op] = o
+	}
+	o.latencies = append(o.latencies, d)
+	o.byStatus[status]++
+	if failed {
+		o.errors++
+	}
+}
+
+// Reset discards everything recorded so far (used after setup).
+func (s *Stats) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ops = make(map[string]*opStats)
+}
+
+// Report is the summary of a run.
+type Report struct {
+	Elapsed    time.Duration `json:"elapsedNs"`
+	Requests   int           `json:"requests"`
+	Errors     int           `json:"errors"`
+	ErrorRate  float64       `json:"errorRate"`
+	Throughput float64       `json:"requestsPerSecond"`
+	Operations []OpReport    `json:"operations"`
+}
+
+// OpReport summarizes one operation. Latencies are in milliseconds.
+type OpReport struct {
+	Name      string         `json:"name"`
+	Count     int            `json:"count"`
+	Errors    int            `json:"errors"`
+	ErrorRate float64        `json:"errorRate"`
+	RPS       float64        `json:"rps"`
+	P50       float64        `json:"p50Ms"`
+	P90       float64        `json:"p90Ms"`
+	P95       float64        `json:"p95Ms"`
+	P99       float64        `json:"p99Ms"`
+	Max       float64        `json:"maxMs"`
+	ByStatus  map[string]int `json:"byStatus"`
+}
+
+// Report builds the summary for a run that lasted elapsed.
+func (s *Stats) Report(elapsed time.Duration) Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := Report{Elapsed: elapsed}
+	secs := elapsed.Seconds()
+	names := make([]string, 0, len(s.ops))
+	for name := range s.ops {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		o := s.ops[name]
+		lat := slices.Clone(o.latencies)
+		slices.Sort(lat)
+
+		op := OpReport{
+			Name:     name,
+			Count:    len(lat),
+			Errors:   o.errors,
+			P50:      ms(percentile(lat, 0.50)),
+			P90:      ms(percentile(lat, 0.90)),
+			P95:      ms(percentile(lat, 0.95)),
+			P99:      ms(percentile(lat, 0.99)),
+			Max:      ms(percentile(lat, 1)),
+			ByStatus: make(map[string]int, len(o.byStatus)),
+		}
+		if op.Count > 0 {
+			op.ErrorRate = float64(op.Errors) / float64(op.Count)
+		}
+		if secs > 0 {
+			op.RPS = float64(op.Count) / secs
+		}
+		for status, n := range o.byStatus {
+			key := "transport"
+			if status != 0 {
+				key = fmt.Sprint(status)
+			}
+			op.ByStatus[key] = n
+		}
+
+		r.Requests += op.Count
+		r.Errors += op.Errors
+		r.Operations = append(r.Operations, op)
+	}
+	if r.Requests > 0 {
+		r.ErrorRate = float64(r.Errors) / float64(r.Requests)
+	}
+	if secs > 0 {
+		r.Throughput = float64(r.Requests) / secs
+	}
+	return r
+}
+
+// Print writes the report as an aligned table.
+func (r Report) Print(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(tw, "operation\tcount\trps\terrors\tp50 ms\tp90 ms\tp95 ms\tp99 ms\tmax ms\tstatuses\t")
+	for _, op := range r.Operations {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%.1f\t%.2f%%\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t%s\t\n",
+			op.Name, op.Count, op.RPS, op.ErrorRate*100, op.P50, op.P90, op.P95, op.P99, op.Max, formatStatuses(op.ByStatus))
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintf(w, "\n%d requests in %s, %.1f req/s, %d errors (%.2f%%)\n",
+		r.Requests, r.Elapsed.Round(time.Millisecond), r.Throughput, r.Errors, r.ErrorRate*100)
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	idx = max(0, min(idx, len(sorted)-1))
+	return sorted[idx]
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func formatStatuses(m map[string]int) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := ""
+	for i, k := range keys {
+		if i > 0 {
+			out += " "
+		}
+		out += fmt.Sprintf("%s:%d", k, m[k])
+	}
+	return out
+}
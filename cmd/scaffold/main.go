@@ -440,6 +440,7 @@ func (r *{{.PascalName}}Registration) Build(deps v1.CatalogDeps) v1.CatalogRoute
 	service := NewService(repo, deps.Numerator)
 	service.SetPolicyEngine(deps.PolicyEngine)
 	domain.NewEventLogCatalogService(service.CatalogService, "{{.SnakeName}}", deps.EventWriter)
+	domain.RegisterCatalogEvents(service.CatalogService, "{{.SnakeName}}", deps.EventPublisher)
 
 	config := handlers.CatalogHandlerConfig[
 		*{{.PascalName}},
@@ -5,29 +5,56 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc"
 
 	"metapus/internal/content"
+	"metapus/internal/core/id"
+	"metapus/internal/core/jobs"
 	"metapus/internal/core/security"
 	"metapus/internal/core/tenant"
+	"metapus/internal/domain/accountexport"
+	"metapus/internal/domain/analytics"
+	"metapus/internal/domain/artifact"
+	"metapus/internal/domain/attachment"
 	"metapus/internal/domain/auth"
+	"metapus/internal/domain/cascadedelete"
+	"metapus/internal/domain/catalogs/counterparty"
 	"metapus/internal/domain/catalogs/wallet"
 	"metapus/internal/domain/documents/crypto_invoice"
+	"metapus/internal/domain/modules"
+	"metapus/internal/domain/notifications"
+	"metapus/internal/domain/registers/stock"
+	"metapus/internal/domain/search"
 	"metapus/internal/domain/security_profile"
+	"metapus/internal/domain/settings"
+	"metapus/internal/infrastructure/analyticssink"
+	"metapus/internal/infrastructure/blobstore"
+	"metapus/internal/infrastructure/cache"
+	"metapus/internal/infrastructure/grpcapi"
 	v1 "metapus/internal/infrastructure/http/v1"
+	"metapus/internal/infrastructure/http/v1/middleware"
+	"metapus/internal/infrastructure/mail"
 	"metapus/internal/infrastructure/numerator"
+	"metapus/internal/infrastructure/requisites"
+	"metapus/internal/infrastructure/searchindex"
+	"metapus/internal/infrastructure/storage/postgres"
 	"metapus/internal/infrastructure/storage/postgres/auth_repo"
 	"metapus/internal/infrastructure/storage/postgres/catalog_repo"
 	"metapus/internal/infrastructure/storage/postgres/document_repo"
 	"metapus/internal/infrastructure/storage/postgres/migration"
 	"metapus/internal/infrastructure/storage/postgres/portal_repo"
+	"metapus/internal/infrastructure/storage/postgres/register_repo"
 	"metapus/internal/infrastructure/storage/postgres/security_repo"
+	"metapus/internal/infrastructure/telemetry"
 	"metapus/pkg/logger"
 )
 
@@ -57,6 +84,16 @@ func main() {
 		"mode", "multi-tenant",
 	)
 
+	// --- ID generation strategy (uuidv7 | uuidv4 | ulid) ---
+	idStrategy, err := id.ParseStrategy(getEnv("ID_STRATEGY", ""))
+	if err != nil {
+		log.Fatalw("invalid ID_STRATEGY", "error", err)
+	}
+	if err := id.SetStrategy(idStrategy); err != nil {
+		log.Fatalw("failed to set ID strategy", "error", err)
+	}
+	log.Infow("id strategy configured", "strategy", idStrategy)
+
 	// --- Meta-database connection ---
 	metaDSN := mustEnv("META_DATABASE_URL")
 	metaPool, err := pgxpool.New(ctx, metaDSN)
@@ -71,9 +108,42 @@ func main() {
 	log.Info("meta database connection established")
 
 	// --- Tenant Registry and Manager ---
-	registry := tenant.NewPostgresRegistry(metaPool)
+	// Cached in memory and invalidated via LISTEN/NOTIFY on the tenants table.
+	metaRegistry := tenant.NewPostgresRegistry(metaPool)
+	cachedRegistry := tenant.NewCachedRegistry(metaRegistry, metaPool)
+	cachedRegistry.Start(ctx)
+	defer cachedRegistry.Stop()
+
+	// Last-known-good snapshot of active tenants, served while the meta DB is down.
+	fallbackCfg := tenant.DefaultFallbackConfig()
+	fallbackCfg.SnapshotPath = getEnv("TENANT_SNAPSHOT_PATH", "")
+	registry := tenant.NewFallbackRegistry(cachedRegistry, fallbackCfg)
+	registry.Start(ctx)
+	defer registry.Stop()
+
+	// --- Startup self-check ---
+	// Config sanity, insecure defaults, meta schema and tenant schema versions.
+	runSelfCheck(ctx, log, metaRegistry, registry)
+
+	// --- Tracing (OpenTelemetry, OTLP/HTTP exporter) ---
+	tracingCfg := telemetry.ConfigFromEnv("metapus-server", Version)
+	shutdownTracing, err := telemetry.Setup(ctx, tracingCfg)
+	if err != nil {
+		log.Fatalw("failed to set up tracing", "error", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			log.Warnw("failed to flush traces", "error", err)
+		}
+	}()
+	if tracingCfg.Enabled {
+		log.Infow("tracing enabled", "endpoint", tracingCfg.Endpoint, "sample_ratio", tracingCfg.SampleRatio)
+	}
 
 	managerCfg := tenant.DefaultManagerConfig()
+	managerCfg.QueryTracer = telemetry.NewQueryTracer()
 	managerCfg.DBUser = mustEnv("TENANT_DB_USER")
 	managerCfg.DBPassword = mustEnv("TENANT_DB_PASSWORD")
 
@@ -87,20 +157,46 @@ func main() {
 	if maxPools := getEnvInt("TENANT_MAX_POOLS", 100); maxPools > 0 {
 		managerCfg.MaxTotalPools = maxPools
 	}
+	// At the pool limit the least recently used idle pool is evicted; if all
+	// pools serve requests, a new tenant waits this long for one to close.
+	managerCfg.PoolSlotTimeout = getEnvDuration("TENANT_POOL_SLOT_TIMEOUT", managerCfg.PoolSlotTimeout)
 	if maxConns := getEnvInt("TENANT_MAX_CONNS_PER_POOL", 10); maxConns > 0 {
 		managerCfg.MaxConnsPerTenant = int32(maxConns)
 	}
 	if idleTimeout := getEnvDuration("TENANT_POOL_IDLE_TIMEOUT", 30*time.Minute); idleTimeout > 0 {
 		managerCfg.PoolIdleTimeout = idleTimeout
 	}
+	// Pool exhaustion: fail with 503 TENANT_BUSY after waiting this long for a
+	// connection ("0s" waits for the whole request), and optionally shed
+	// requests per plan while too many already wait, e.g. "standard=20,premium=50".
+	managerCfg.AcquireTimeout = getEnvDuration("TENANT_ACQUIRE_TIMEOUT", managerCfg.AcquireTimeout)
+	if shed := getEnv("TENANT_SHED_QUEUE", ""); shed != "" {
+		parsed, err := tenant.ParsePlanThresholds(shed)
+		if err != nil {
+			log.Fatalw("invalid TENANT_SHED_QUEUE", "error", err)
+		}
+		managerCfg.ShedQueueLength = parsed
+	}
+
+	// Multi-region: per-region DB endpoints, e.g. "eu=pg-eu.internal:5432,us=pg-us.internal".
+	if regions := getEnv("TENANT_REGIONS", ""); regions != "" {
+		parsed, err := tenant.ParseRegions(regions)
+		if err != nil {
+			log.Fatalw("invalid TENANT_REGIONS", "error", err)
+		}
+		managerCfg.Regions = parsed
+		log.Infow("multi-region routing enabled", "regions", len(parsed))
+	}
 
 	tenantManager := tenant.NewManager(managerCfg, registry, log)
 	defer tenantManager.Close()
 
 	log.Infow("tenant manager initialized",
 		"max_pools", managerCfg.MaxTotalPools,
+		"pool_slot_timeout", managerCfg.PoolSlotTimeout,
 		"max_conns_per_tenant", managerCfg.MaxConnsPerTenant,
 		"idle_timeout", managerCfg.PoolIdleTimeout,
+		"acquire_timeout", managerCfg.AcquireTimeout,
 	)
 
 	// Optional: Prewarm pools for known tenants
@@ -118,6 +214,13 @@ func main() {
 		log.Fatalw("failed to ensure migration state table", "error", err)
 	}
 
+	// --- Tenant Audit Log ---
+	// Meta-level trail of admin tenant actions (API and tenant CLI).
+	tenantAudit := tenant.NewPostgresAuditLog(metaPool)
+	if err := tenantAudit.EnsureTable(ctx); err != nil {
+		log.Fatalw("failed to ensure tenant audit table", "error", err)
+	}
+
 	// Recover tenants stuck in "updating" from a previous crash.
 	migration.RecoverStuckTenants(ctx, registry, log)
 
@@ -141,6 +244,7 @@ func main() {
 	merchantUserRepo := catalog_repo.NewMerchantUserRepo()
 
 	authConfig := auth.DefaultServiceConfig()
+	authConfig.EmailChangeConfirmURL = getEnv("EMAIL_CHANGE_CONFIRM_URL", "")
 	authSvc := auth.NewService(
 		userRepo,
 		roleRepo,
@@ -153,9 +257,51 @@ func main() {
 		jwtSvc,
 		authConfig,
 	)
+	authSvc.SetEmailChangeRepository(auth_repo.NewEmailChangeRepo())
+	authSvc.SetEventPublisher(postgres.NewEventPublisher())
+
+	// Transactional mail: tenants may configure their own provider in
+	// sys_settings.email; the platform SMTP sender is the fallback.
+	var platformMailer auth.Mailer
+	if smtpCfg, ok := mail.SMTPConfigFromEnv(); ok {
+		platformMailer = mail.NewSMTPMailer(smtpCfg)
+	} else {
+		log.Warn("SMTP_HOST/SMTP_FROM not set: no platform default sender, only tenant-configured email providers will work")
+	}
+	tenantMailer := mail.NewTenantMailer(postgres.NewSettingsRepo(), platformMailer)
+	authSvc.SetMailer(tenantMailer)
+
+	// Notification emails are queued as sys_jobs and sent by the worker;
+	// APP_PUBLIC_URL (the frontend address) makes their links absolute.
+	notificationEmails := notifications.NewEmails(jobs.NewQueue(postgres.NewJobRepo()), userRepo, getEnv("APP_PUBLIC_URL", ""))
+	authSvc.SetInviter(notificationEmails)
+
+	// In-app notifications are pushed to /notifications/stream clients via
+	// LISTEN/NOTIFY, so notifications created by the worker are streamed too.
+	notificationInbox := notifications.NewInbox(postgres.NewNotificationRepo(), userRepo)
+	notificationBroker := notifications.NewBroker()
+	notificationListener := cache.NewNotificationListener(tenantManager, notificationBroker)
+	notificationListener.Start(ctx)
+	defer notificationListener.Stop()
+
+	// --- Scoped Settings ---
+	// Per-tenant cache of sys_setting_values, invalidated via LISTEN/NOTIFY.
+	settingsResolver := settings.NewResolver(postgres.NewSettingValuesRepo())
+	settingsListener := cache.NewSettingsListener(tenantManager, settingsResolver)
+	settingsListener.Start(ctx)
+	defer settingsListener.Stop()
+
+	// --- Modules ---
+	// Per-tenant module switches (module.* feature flags), invalidated via LISTEN/NOTIFY.
+	moduleResolver := modules.NewResolver(postgres.NewModuleFlagsRepo())
+	moduleListener := cache.NewModuleListener(tenantManager, moduleResolver)
+	moduleListener.Start(ctx)
+	defer moduleListener.Stop()
 
 	// --- Numerator Service ---
 	numeratorSvc := numerator.New()
+	// Persist cached range remainders that can't be returned on shutdown (avoids gaps after restart).
+	numeratorSvc.SetPersistRanges(getEnv("NUMERATOR_PERSIST_RANGES", "false") == "true")
 
 	// --- Security Profile Provider (cached) ---
 	profileRepo := security_repo.NewProfileRepo()
@@ -214,6 +360,143 @@ func main() {
 		return nil
 	})
 
+	// --- Attachments ---
+	// ATTACHMENTS_BACKEND: local (default), s3 (AWS S3 / MinIO) or none (disabled).
+	attachmentLimits := attachment.DefaultLimits()
+	if maxMB := getEnvInt("ATTACHMENTS_MAX_SIZE_MB", 0); maxMB > 0 {
+		attachmentLimits.MaxSize = int64(maxMB) << 20
+	}
+	if types := getEnv("ATTACHMENTS_ALLOWED_TYPES", ""); types != "" {
+		attachmentLimits.AllowedTypes = nil
+		for _, t := range strings.Split(types, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				attachmentLimits.AllowedTypes = append(attachmentLimits.AllowedTypes, t)
+			}
+		}
+	}
+
+	var attachmentStore attachment.BlobStore
+	switch backend := getEnv("ATTACHMENTS_BACKEND", "local"); backend {
+	case "local":
+		localStore, err := blobstore.NewLocalStore(getEnv("ATTACHMENTS_DIR", "./data/attachments"))
+		if err != nil {
+			log.Fatalw("failed to init local attachment store", "error", err)
+		}
+		attachmentStore = localStore
+	case "s3":
+		s3Store, err := blobstore.NewS3Store(blobstore.S3Config{
+			Endpoint:  getEnv("S3_ENDPOINT", ""),
+			Region:    getEnv("S3_REGION", "us-east-1"),
+			Bucket:    getEnv("S3_BUCKET", ""),
+			AccessKey: getEnv("S3_ACCESS_KEY", ""),
+			SecretKey: getEnv("S3_SECRET_KEY", ""),
+		})
+		if err != nil {
+			log.Fatalw("failed to init s3 attachment store", "error", err)
+		}
+		attachmentStore = s3Store
+	case "none":
+		log.Info("attachments disabled")
+	default:
+		log.Fatalw("invalid ATTACHMENTS_BACKEND", "backend", backend)
+	}
+
+	// --- Artifacts (report exports, backups) ---
+	// ARTIFACTS_BACKEND: local (default), s3 or none (disabled). The s3 backend
+	// shares the S3_* connection settings; ARTIFACTS_S3_BUCKET overrides the bucket.
+	var artifactStore artifact.BlobStore
+	switch backend := getEnv("ARTIFACTS_BACKEND", "local"); backend {
+	case "local":
+		localStore, err := blobstore.NewLocalStore(getEnv("ARTIFACTS_DIR", "./data/artifacts"))
+		if err != nil {
+			log.Fatalw("failed to init local artifact store", "error", err)
+		}
+		artifactStore = localStore
+	case "s3":
+		s3Store, err := blobstore.NewS3Store(blobstore.S3Config{
+			Endpoint:  getEnv("S3_ENDPOINT", ""),
+			Region:    getEnv("S3_REGION", "us-east-1"),
+			Bucket:    getEnv("ARTIFACTS_S3_BUCKET", getEnv("S3_BUCKET", "")),
+			AccessKey: getEnv("S3_ACCESS_KEY", ""),
+			SecretKey: getEnv("S3_SECRET_KEY", ""),
+		})
+		if err != nil {
+			log.Fatalw("failed to init s3 artifact store", "error", err)
+		}
+		artifactStore = s3Store
+	case "none":
+		log.Info("artifacts disabled")
+	default:
+		log.Fatalw("invalid ARTIFACTS_BACKEND", "backend", backend)
+	}
+
+	// --- Search index ---
+	// OPENSEARCH_URL enables the external index for tenants with search.backend = opensearch.
+	var searchIndex search.Index
+	if osURL := getEnv("OPENSEARCH_URL", ""); osURL != "" {
+		osIndex, err := searchindex.NewOpenSearch(searchindex.OpenSearchConfig{
+			URL:         osURL,
+			Username:    getEnv("OPENSEARCH_USERNAME", ""),
+			Password:    getEnv("OPENSEARCH_PASSWORD", ""),
+			IndexPrefix: getEnv("OPENSEARCH_INDEX_PREFIX", "metapus"),
+		})
+		if err != nil {
+			log.Fatalw("failed to init opensearch index", "error", err)
+		}
+		searchIndex = osIndex
+	}
+
+	// --- Requisites lookup ---
+	// DADATA_API_KEY enables filling counterparties by INN.
+	var requisitesProvider counterparty.RequisitesProvider
+	if apiKey := getEnv("DADATA_API_KEY", ""); apiKey != "" {
+		dadata, err := requisites.NewDaData(requisites.DaDataConfig{
+			APIKey: apiKey,
+			URL:    getEnv("DADATA_URL", ""),
+		})
+		if err != nil {
+			log.Fatalw("failed to init dadata requisites provider", "error", err)
+		}
+		requisitesProvider = dadata
+	}
+
+	// --- Product analytics ---
+	// ANALYTICS_SINK: none (default), http (Segment-compatible batch API) or
+	// table (analytics_events in the meta database). Tenants opt out with the
+	// analytics.enabled setting.
+	analyticsSink, err := analyticssink.Open(ctx, analyticssink.Config{
+		Kind: getEnv("ANALYTICS_SINK", analyticssink.KindNone),
+		HTTP: analyticssink.HTTPConfig{
+			URL:      getEnv("ANALYTICS_HTTP_URL", ""),
+			WriteKey: getEnv("ANALYTICS_WRITE_KEY", ""),
+		},
+	}, metaPool)
+	if err != nil {
+		log.Fatalw("failed to init analytics sink", "error", err)
+	}
+	var analyticsEmitter *analytics.Emitter
+	if analyticsSink != nil {
+		analyticsEmitter = analytics.NewEmitter(analyticsSink, []byte(mustEnv("ANALYTICS_SALT")), log)
+	}
+	analyticsCtx, stopAnalytics := context.WithCancel(ctx)
+	analyticsDone := make(chan struct{})
+	go func() {
+		defer close(analyticsDone)
+		analyticsEmitter.Run(analyticsCtx)
+	}()
+
+	// Global body limit; raised when attachment uploads need more room.
+	bodyLimit := int64(10 << 20) // 10 MiB
+	if attachmentStore != nil && attachmentLimits.MaxSize+(1<<20) > bodyLimit {
+		bodyLimit = attachmentLimits.MaxSize + (1 << 20)
+	}
+
+	// Control-plane API: enabled by its own admin token secret, never JWT_SECRET.
+	var adminTokens middleware.AdminTokenValidator
+	if secret := getEnv("ADMIN_JWT_SECRET", ""); secret != "" {
+		adminTokens = auth.NewAdminTokenService(secret)
+	}
+
 	// --- Router ---
 	router := v1.NewRouter(v1.RouterConfig{
 		TenantManager:       tenantManager,
@@ -229,18 +512,40 @@ func main() {
 		Version:             Version,
 		BuildTime:           BuildTime,
 		MigrationStateStore: migrationStateStore,
+		TenantAudit:         tenantAudit,
+		AdminTokens:         adminTokens,
+		TenantDefaults: tenant.Placement{
+			DBHost: getEnv("TENANT_DB_HOST", "localhost"),
+			DBPort: getEnvInt("TENANT_DB_PORT", 5432),
+		},
 		WSTicketStore:       wsTicketStore,
+		Mailer:              tenantMailer,
+		NotificationEmails:  notificationEmails,
+		NotificationInbox:   notificationInbox,
+		NotificationBroker:  notificationBroker,
 		MerchantAPIKeyRepo:  merchantAPIKeyRepo,
 		MerchantUserRepo:    merchantUserRepo,
 		MerchantInvoiceSvc:  merchantInvoiceSvc,
 		PortalDashboardRepo: portal_repo.NewDashboardRepo(),
+		SettingsResolver:    settingsResolver,
+		Modules:             moduleResolver,
+		AccountExportSigner: accountexport.NewURLSigner([]byte(getEnv("ACCOUNT_EXPORT_SIGNING_KEY", jwtSecret))),
+		AttachmentStore:     attachmentStore,
+		AttachmentLimits:    attachmentLimits,
+		UploadSigner:        attachment.NewUploadSigner([]byte(getEnv("UPLOAD_SIGNING_KEY", jwtSecret))),
+		ArtifactStore:       artifactStore,
+		ArtifactSigner:      artifact.NewURLSigner([]byte(getEnv("ARTIFACT_SIGNING_KEY", jwtSecret))),
+		CascadeDeleteSigner: cascadedelete.NewTokenSigner([]byte(getEnv("CASCADE_DELETE_SIGNING_KEY", jwtSecret))),
+		SearchIndex:         searchIndex,
+		Analytics:           analyticsEmitter,
+		RequisitesProvider:  requisitesProvider,
 	})
 
 	// --- HTTP Server ---
 	port := getEnv("APP_PORT", "8080")
 	server := &http.Server{
 		Addr:           ":" + port,
-		Handler:        http.MaxBytesHandler(router, 10<<20), // 10 MiB global body limit
+		Handler:        http.MaxBytesHandler(router, bodyLimit),
 		ReadTimeout:    15 * time.Second,
 		WriteTimeout:   30 * time.Second,
 		IdleTimeout:    60 * time.Second,
@@ -255,6 +560,30 @@ func main() {
 		}
 	}()
 
+	// --- gRPC integration API (optional) ---
+	// GRPC_PORT enables catalog and stock access for internal services;
+	// they authenticate with the shared GRPC_API_KEY.
+	var grpcServer *grpc.Server
+	if grpcPort := getEnv("GRPC_PORT", ""); grpcPort != "" {
+		grpcServer = grpcapi.NewServer(grpcapi.Config{
+			TenantManager: tenantManager,
+			APIKey:        mustEnv("GRPC_API_KEY"),
+			Registry:      v1.BuildMetadataRegistry(factoryReg),
+			Catalogs:      postgres.NewCatalogRowReader(),
+			Stock:         stock.NewService(register_repo.NewStockRepo()),
+		})
+		listener, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			log.Fatalw("failed to listen for grpc", "port", grpcPort, "error", err)
+		}
+		go func() {
+			log.Infow("grpc server starting", "port", grpcPort)
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalw("grpc server failed", "error", err)
+			}
+		}()
+	}
+
 	// --- Graceful shutdown ---
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -269,6 +598,18 @@ func main() {
 	if err := server.Shutdown(shutdownCtx); err != nil {
 		log.Fatalw("server forced to shutdown", "error", err)
 	}
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+
+	// Return unused cached numerator ranges while tenant pools are still open.
+	if err := numeratorSvc.ReleaseRanges(shutdownCtx, numerator.ManagerPools(tenantManager)); err != nil {
+		log.Warnw("failed to release numerator ranges", "error", err)
+	}
+
+	// Send the analytics events collected since the last flush.
+	stopAnalytics()
+	<-analyticsDone
 
 	log.Info("server stopped")
 }
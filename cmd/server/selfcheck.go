@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"metapus/internal/core/tenant"
+	"metapus/internal/core/version"
+	"metapus/pkg/logger"
+)
+
+// Startup self-check: runs before the server accepts traffic and fails fast
+// with actionable messages instead of surfacing misconfiguration as runtime
+// errors on the first requests.
+//
+// Severity:
+//   - config errors and an incompatible meta schema always refuse startup;
+//   - insecure defaults refuse startup in production (APP_ENV=production),
+//     otherwise they are logged as warnings;
+//   - outdated tenant schemas are warnings unless STARTUP_SCHEMA_GATE=true.
+
+// startupFinding is a single self-check problem.
+type startupFinding struct {
+	check string
+	msg   string
+	hint  string
+	fatal bool
+}
+
+// insecureSecrets are well-known placeholder values from examples and docs.
+var insecureSecrets = map[string]bool{
+	"secret":                              true,
+	"changeme":                            true,
+	"change-me":                           true,
+	"change-me-to-a-strong-random-secret": true,
+	"jwt-secret":                          true,
+	"metapus":                             true,
+	"postgres":                            true,
+	"password":                            true,
+}
+
+// minJWTSecretLen is the minimum HS256 key length (256 bits).
+const minJWTSecretLen = 32
+
+// runSelfCheck validates configuration, the meta-database schema and tenant
+// schema versions. Logs every finding and exits if any of them is fatal.
+func runSelfCheck(ctx context.Context, log *logger.Logger, metaRegistry *tenant.PostgresRegistry, registry tenant.Registry) {
+	production := getEnv("APP_ENV", "development") == "production"
+
+	var findings []startupFinding
+	findings = append(findings, checkConfig()...)
+	findings = append(findings, checkInsecureDefaults(production)...)
+	findings = append(findings, checkMetaSchema(ctx, metaRegistry)...)
+	findings = append(findings, checkTenantSchemas(ctx, registry, getEnv("STARTUP_SCHEMA_GATE", "false") == "true")...)
+
+	fatal := 0
+	for _, f := range findings {
+		if f.fatal {
+			fatal++
+			log.Errorw("startup check failed", "check", f.check, "problem", f.msg, "fix", f.hint)
+		} else {
+			log.Warnw("startup check warning", "check", f.check, "problem", f.msg, "fix", f.hint)
+		}
+	}
+	if fatal > 0 {
+		log.Fatalw("refusing to start: fix the problems above", "failed_checks", fatal, "production", production)
+	}
+	log.Infow("startup self-check passed", "warnings", len(findings), "production", production)
+}
+
+// checkConfig reports environment values that are set but cannot be parsed.
+// getEnvInt/getEnvDuration silently fall back to defaults on such values.
+func checkConfig() []startupFinding {
+	var findings []startupFinding
+
+	if port, err := strconv.Atoi(getEnv("APP_PORT", "8080")); err != nil || port < 1 || port > 65535 {
+		findings = append(findings, startupFinding{
+			check: "config", msg: fmt.Sprintf("APP_PORT=%q is not a valid port", os.Getenv("APP_PORT")),
+			hint: "set APP_PORT to a number between 1 and 65535", fatal: true,
+		})
+	}
+
+	for _, key := range []string{"TENANT_MAX_POOLS", "TENANT_MAX_CONNS_PER_POOL"} {
+		if raw := os.Getenv(key); raw != "" {
+			if n, err := strconv.Atoi(raw); err != nil || n < 0 {
+				findings = append(findings, startupFinding{
+					check: "config", msg: fmt.Sprintf("%s=%q is not a non-negative integer", key, raw),
+					hint: "fix or unset " + key, fatal: true,
+				})
+			}
+		}
+	}
+
+	for _, key := range []string{"AUTH_STATE_CACHE_TTL", "SECURITY_PROFILE_CACHE_TTL", "TENANT_POOL_IDLE_TIMEOUT", "TENANT_ACQUIRE_TIMEOUT"} {
+		if raw := os.Getenv(key); raw != "" {
+			if _, err := time.ParseDuration(raw); err != nil {
+				findings = append(findings, startupFinding{
+					check: "config", msg: fmt.Sprintf("%s=%q is not a duration", key, raw),
+					hint: "use Go duration syntax, e.g. 5m or 30s", fatal: true,
+				})
+			}
+		}
+	}
+
+	if key := os.Getenv("AUTOMATION_ENCRYPTION_KEY"); key != "" && len(key) != 32 {
+		findings = append(findings, startupFinding{
+			check: "config", msg: fmt.Sprintf("AUTOMATION_ENCRYPTION_KEY must be 32 bytes, got %d", len(key)),
+			hint: "generate one with: openssl rand -hex 16", fatal: true,
+		})
+	}
+
+	return findings
+}
+
+// checkInsecureDefaults reports placeholder secrets and passwords.
+func checkInsecureDefaults(production bool) []startupFinding {
+	var findings []startupFinding
+	insecure := func(msg, hint string) {
+		findings = append(findings, startupFinding{check: "security", msg: msg, hint: hint, fatal: production})
+	}
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	switch {
+	case insecureSecrets[strings.ToLower(jwtSecret)]:
+		insecure("JWT_SECRET is a well-known placeholder", "generate a secret with: openssl rand -base64 48")
+	case len(jwtSecret) < minJWTSecretLen:
+		insecure(fmt.Sprintf("JWT_SECRET is shorter than %d bytes", minJWTSecretLen), "generate a secret with: openssl rand -base64 48")
+	}
+
+	if adminSecret := os.Getenv("ADMIN_JWT_SECRET"); adminSecret != "" {
+		switch {
+		case adminSecret == jwtSecret:
+			insecure("ADMIN_JWT_SECRET equals JWT_SECRET", "the control-plane API needs its own secret: openssl rand -base64 48")
+		case insecureSecrets[strings.ToLower(adminSecret)]:
+			insecure("ADMIN_JWT_SECRET is a well-known placeholder", "generate a secret with: openssl rand -base64 48")
+		case len(adminSecret) < minJWTSecretLen:
+			insecure(fmt.Sprintf("ADMIN_JWT_SECRET is shorter than %d bytes", minJWTSecretLen), "generate a secret with: openssl rand -base64 48")
+		}
+	}
+
+	if insecureSecrets[strings.ToLower(os.Getenv("TENANT_DB_PASSWORD"))] {
+		insecure("TENANT_DB_PASSWORD is a default password", "set a strong password for the tenant database role")
+	}
+	if u, err := url.Parse(os.Getenv("META_DATABASE_URL")); err == nil {
+		if pw, ok := u.User.Password(); ok && insecureSecrets[strings.ToLower(pw)] {
+			insecure("META_DATABASE_URL uses a default password", "set a strong password for the meta database role")
+		}
+	}
+
+	if os.Getenv("ACCOUNT_EXPORT_SIGNING_KEY") == "" {
+		findings = append(findings, startupFinding{
+			check: "security", msg: "ACCOUNT_EXPORT_SIGNING_KEY is not set, export links are signed with JWT_SECRET",
+			hint: "set a dedicated ACCOUNT_EXPORT_SIGNING_KEY",
+		})
+	}
+	if os.Getenv("AUTOMATION_ENCRYPTION_KEY") == "" {
+		findings = append(findings, startupFinding{
+			check: "config", msg: "AUTOMATION_ENCRYPTION_KEY is not set, automation credentials and email provider secrets cannot be stored",
+			hint: "generate one with: openssl rand -hex 16",
+		})
+	}
+
+	return findings
+}
+
+// checkMetaSchema verifies the meta-database has the columns the registry reads.
+func checkMetaSchema(ctx context.Context, metaRegistry *tenant.PostgresRegistry) []startupFinding {
+	if err := metaRegistry.CheckSchema(ctx); err != nil {
+		return []startupFinding{{
+			check: "meta_schema", msg: "meta database schema is missing or outdated: " + err.Error(),
+			hint: "run: tenant init-meta", fatal: true,
+		}}
+	}
+	return nil
+}
+
+// checkTenantSchemas compares tenant schema versions with the version this
+// binary ships. With gate=true outdated tenants refuse startup.
+func checkTenantSchemas(ctx context.Context, registry tenant.Registry, gate bool) []startupFinding {
+	var (
+		tenants []*tenant.Tenant
+		err     error
+	)
+	if vg := getEnv("VERSION_GROUP", ""); vg != "" {
+		tenants, err = registry.ListByVersionGroup(ctx, vg)
+	} else {
+		tenants, err = registry.ListActive(ctx)
+	}
+	if err != nil {
+		return []startupFinding{{
+			check: "tenant_schema", msg: "cannot list tenants: " + err.Error(),
+			hint: "check META_DATABASE_URL and meta database availability",
+		}}
+	}
+
+	var outdated, newer []string
+	for _, t := range tenants {
+		switch {
+		case t.SchemaVersion < version.ExpectedSchemaVersion:
+			outdated = append(outdated, fmt.Sprintf("%s(v%d)", t.Slug, t.SchemaVersion))
+		case t.SchemaVersion > version.ExpectedSchemaVersion:
+			newer = append(newer, fmt.Sprintf("%s(v%d)", t.Slug, t.SchemaVersion))
+		}
+	}
+
+	var findings []startupFinding
+	if len(outdated) > 0 {
+		findings = append(findings, startupFinding{
+			check: "tenant_schema",
+			msg: fmt.Sprintf("%d tenant(s) below schema v%d: %s",
+				len(outdated), version.ExpectedSchemaVersion, summarize(outdated, 10)),
+			hint:  "run: tenant migrate --all (or POST /api/v1/admin/tenants/{id}/update)",
+			fatal: gate,
+		})
+	}
+	if len(newer) > 0 {
+		findings = append(findings, startupFinding{
+			check: "tenant_schema",
+			msg: fmt.Sprintf("%d tenant(s) ahead of schema v%d: %s",
+				len(newer), version.ExpectedSchemaVersion, summarize(newer, 10)),
+			hint:  "this binary is older than the tenant schema; deploy the matching version",
+			fatal: gate,
+		})
+	}
+	return findings
+}
+
+// summarize joins up to limit items, noting how many were omitted.
+func summarize(items []string, limit int) string {
+	if len(items) <= limit {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(items[:limit], ", "), len(items)-limit)
+}
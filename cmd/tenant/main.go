@@ -4,20 +4,37 @@
 //	tenant list
 //	tenant migrate --all
 //	tenant suspend <tenant-id>
+//	tenant move --id <tenant-id> --region eu --host pg-eu.internal
+//	tenant backup <tenant-id>
+//	tenant restore <tenant-id> --file <backup-file>
+//	tenant audit --id <tenant-id>
+//	tenant repost <tenant-id> --from 2026-01-01 --to 2026-03-31 [--wait]
+//	tenant delete <tenant-id> --confirm <slug>
 package main
 
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/url"
 	"os"
+	"os/user"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"metapus/internal/content"
+	"metapus/internal/core/jobs"
 	"metapus/internal/core/tenant"
 	"metapus/internal/core/version"
+	"metapus/internal/domain/auth"
+	"metapus/internal/domain/reposting"
+	v1 "metapus/internal/infrastructure/http/v1"
+	"metapus/internal/infrastructure/storage/postgres"
 	"metapus/internal/infrastructure/storage/postgres/migration"
 )
 
@@ -38,12 +55,40 @@ func main() {
 		initMeta(ctx)
 	case "migrate":
 		migrateTenants(ctx)
+	case "verify":
+		verifyTenants(ctx)
 	case "promote":
 		promoteTenant(ctx)
+	case "move":
+		moveTenant(ctx)
+	case "backup":
+		backupTenant(ctx)
+	case "restore":
+		restoreTenant(ctx)
+	case "export":
+		exportTenant(ctx)
+	case "exports":
+		listExports(ctx)
 	case "suspend":
 		suspendTenant(ctx)
 	case "activate":
 		activateTenant(ctx)
+	case "clock":
+		setTenantClock(ctx)
+	case "features":
+		tenantFeatures(ctx)
+	case "read-only":
+		setTenantReadOnly(ctx)
+	case "replica":
+		setTenantReplica(ctx)
+	case "repost":
+		repostTenant(ctx)
+	case "audit":
+		listAudit(ctx)
+	case "delete":
+		deleteTenant(ctx)
+	case "admin-token":
+		issueAdminToken()
 	case "help", "--help", "-h":
 		printUsage()
 	default:
@@ -64,9 +109,23 @@ Commands:
   create    Create a new tenant
   list      List all tenants
   migrate   Run migrations for tenant(s)
+  verify    Detect (and repair) schema drift of tenant databases
   promote   Assign tenant to a version group (cloud mode)
+  move      Move tenant database to another region/cluster
+  backup    Back up a tenant database (pg_dump) and register the backup
+  restore   Restore a tenant database from a registered backup
+  export    Export all tenant data to a zip of CSV/JSONL files
+  exports   List the data exports of a tenant with their progress
   suspend   Suspend a tenant
   activate  Activate a suspended tenant
+  clock     Freeze or shift the business clock of a demo tenant
+  features  Show or override the plan features of a tenant
+  read-only Switch the emergency read-only mode of a tenant
+  replica   Register or remove the read replica of a tenant
+  repost    Re-post the posted documents of a date range (queued for the worker)
+  audit     Show the audit trail of admin actions on tenants
+  delete    Schedule, cancel or complete the deletion of a tenant
+  admin-token Issue a token for the control-plane HTTP API
   help      Show this help
 
 Environment Variables:
@@ -74,15 +133,41 @@ Environment Variables:
   TENANT_DB_USER       Username for tenant databases (required)
   TENANT_DB_PASSWORD   Password for tenant databases (required)
   POSTGRES_ADMIN_URL   Admin connection for creating databases
+  TENANT_BACKUP_DIR    Directory for backup files (default ./data/backups)
+  TENANT_EXPORT_DIR    Directory for data exports (default ./data/exports)
+  TENANT_DELETION_GRACE Grace period before a deleted tenant can be purged (default 720h)
+  TENANT_MIGRATE_CONCURRENCY Tenants migrated at a time by migrate --all (default 4)
+  ADMIN_JWT_SECRET     Secret of control-plane admin tokens (admin-token)
 
 Examples:
   tenant create --slug acme --name "ACME Corporation"
+  tenant create --slug tiny --name "Tiny LLC" --shared-db mt_shared
   tenant list
-  tenant migrate --all
+  tenant migrate --all [--concurrency 4]
   tenant migrate --id <tenant-uuid>
+  tenant verify --all [--reference <tenant-uuid>] [--repair] [--concurrency 4]
+  tenant verify --id <tenant-uuid> [--repair]
   tenant promote --id <tenant-uuid> --to v1.3.0
+  tenant move --id <tenant-uuid> --region eu-central --host pg-eu.internal [--port 5432] [--cluster c1] [--yes]
+  tenant backup <tenant-uuid> [--dir /var/backups/metapus]
+  tenant restore <tenant-uuid> --file <backup-file> [--force] [--yes]
+  tenant export <tenant-uuid> [--format csv|jsonl] [--dir /var/exports/metapus] [--async]
+  tenant exports <tenant-uuid>
   tenant suspend <tenant-uuid>
-  tenant activate <tenant-uuid>`)
+  tenant activate <tenant-uuid>
+  tenant clock --id <tenant-uuid> --frozen-at 2025-01-31T18:00:00Z
+  tenant clock --id <tenant-uuid> --offset -720h
+  tenant clock --id <tenant-uuid> --reset
+  tenant features <tenant-uuid> [--enable graphql] [--disable automations] [--reset graphql]
+  tenant read-only <tenant-uuid> --on --reason "billing suspension"
+  tenant read-only <tenant-uuid> --off
+  tenant replica <tenant-uuid> --host pg-replica.internal [--port 5432]
+  tenant replica <tenant-uuid> --off
+  tenant audit [--id <tenant-uuid>] [--action suspended] [--actor cli:ops] [--limit 50]
+  tenant delete <tenant-uuid> --confirm <slug> [--grace 720h]
+  tenant delete <tenant-uuid> --cancel
+  tenant delete <tenant-uuid> --purge --confirm <slug> [--dir /var/backups/metapus] [--yes]
+  tenant admin-token [--operator ops] [--ttl 1h]`)
 }
 
 func getMetaPool(ctx context.Context) *pgxpool.Pool {
@@ -107,13 +192,15 @@ CREATE TABLE IF NOT EXISTS tenants (
     id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     slug            VARCHAR(63) NOT NULL,
     display_name    VARCHAR(255) NOT NULL,
-    db_name         VARCHAR(63) NOT NULL UNIQUE,
+    db_name         VARCHAR(63) NOT NULL,
     db_host         VARCHAR(255) NOT NULL DEFAULT 'localhost',
     db_port         INT NOT NULL DEFAULT 5432,
     status          VARCHAR(20) NOT NULL DEFAULT 'active',
     plan            VARCHAR(50) NOT NULL DEFAULT 'standard',
     schema_version  INT NOT NULL DEFAULT 0,
     version_group   VARCHAR(20) NOT NULL DEFAULT '',
+    region          VARCHAR(63) NOT NULL DEFAULT '',
+    cluster         VARCHAR(63) NOT NULL DEFAULT '',
     created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     settings        JSONB NOT NULL DEFAULT '{}'
@@ -125,6 +212,16 @@ CREATE INDEX IF NOT EXISTS idx_tenants_status ON tenants(status);
 CREATE INDEX IF NOT EXISTS idx_tenants_status_slug ON tenants(status, slug) WHERE status = 'active';
 CREATE INDEX IF NOT EXISTS idx_tenants_version_group ON tenants(version_group, status) WHERE status = 'active';
 
+-- Placement columns for meta databases created before multi-region support.
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS region VARCHAR(63) NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS cluster VARCHAR(63) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_tenants_region ON tenants(region, cluster);
+
+-- Shared-schema tenants share a database, each in its own schema.
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS db_schema VARCHAR(63) NOT NULL DEFAULT '';
+ALTER TABLE tenants DROP CONSTRAINT IF EXISTS tenants_db_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_tenants_db_name_schema ON tenants (db_name, db_schema);
+
 CREATE TABLE IF NOT EXISTS tenant_migrations (
     id          SERIAL PRIMARY KEY,
     tenant_id   UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
@@ -145,6 +242,8 @@ CREATE TABLE IF NOT EXISTS tenant_audit (
     details     JSONB DEFAULT '{}',
     created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
+ALTER TABLE tenant_audit ADD COLUMN IF NOT EXISTS before_values JSONB;
+ALTER TABLE tenant_audit ADD COLUMN IF NOT EXISTS after_values JSONB;
 CREATE INDEX IF NOT EXISTS idx_tenant_audit_tenant ON tenant_audit(tenant_id);
 CREATE INDEX IF NOT EXISTS idx_tenant_audit_created ON tenant_audit(created_at DESC);
 
@@ -224,7 +323,7 @@ func initMeta(ctx context.Context) {
 }
 
 func createTenant(ctx context.Context) {
-	var slug, name, plan string
+	var slug, name, plan, sharedDB string
 
 	// Parse arguments
 	for i := 2; i < len(os.Args); i++ {
@@ -244,12 +343,17 @@ func createTenant(ctx context.Context) {
 				plan = os.Args[i+1]
 				i++
 			}
+		case "--shared-db":
+			if i+1 < len(os.Args) {
+				sharedDB = os.Args[i+1]
+				i++
+			}
 		}
 	}
 
 	if slug == "" || name == "" {
 		fmt.Println("Error: --slug and --name are required")
-		fmt.Println("Usage: tenant create --slug <slug> --name <name> [--plan standard|premium|enterprise]")
+		fmt.Println("Usage: tenant create --slug <slug> --name <name> [--plan standard|premium|enterprise] [--shared-db <database>]")
 		os.Exit(1)
 	}
 
@@ -262,15 +366,21 @@ func createTenant(ctx context.Context) {
 
 	registry := tenant.NewPostgresRegistry(metaPool)
 
-	// Generate database name
+	// Generate database name; a shared-schema tenant gets a schema of the
+	// shared database instead.
 	dbName := "mt_" + strings.ToLower(slug)
+	var dbSchema string
+	if sharedDB != "" {
+		dbName, dbSchema = sharedDB, tenant.SchemaNameFor(slug)
+	}
 
 	fmt.Printf("Creating tenant '%s'...\n", slug)
 
-	// 1. Create database
+	// 1. Create database (shared databases and schemas are created with the
+	// migrations below)
 	adminDSN := getAdminDSN()
 
-	{
+	if dbSchema == "" {
 		fmt.Printf("  Creating database %s...\n", dbName)
 		adminPool, err := pgxpool.New(ctx, adminDSN)
 		if err != nil {
@@ -296,12 +406,24 @@ func createTenant(ctx context.Context) {
 	dbPassword := os.Getenv("TENANT_DB_PASSWORD")
 	if dbUser != "" && dbPassword != "" {
 		fmt.Println("  Running migrations...")
-		dbHost := getEnvDefault("TENANT_DB_HOST", "localhost")
-		dbPort := getEnvDefault("TENANT_DB_PORT", "5432")
-		tenantDSN := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
-			dbUser, dbPassword, dbHost, dbPort, dbName)
-
-		if err := runAllMigrations(tenantDSN); err != nil {
+		target := &tenant.Tenant{
+			DBName:   dbName,
+			DBSchema: dbSchema,
+			DBHost:   getEnvDefault("TENANT_DB_HOST", "localhost"),
+			DBPort:   getEnvIntDefault("TENANT_DB_PORT", 5432),
+		}
+		tenantDSN := target.DSN(dbUser, dbPassword)
+
+		var err error
+		if dbSchema != "" {
+			fmt.Printf("  Creating schema %s in %s...\n", dbSchema, dbName)
+			var output string
+			output, err = migration.ProvisionDatabase(ctx, tenantDSN)
+			fmt.Print(output)
+		} else {
+			err = runAllMigrations(tenantDSN)
+		}
+		if err != nil {
 			fmt.Printf("  Warning: Migrations failed: %v\n", err)
 			fmt.Println("  You may need to run migrations manually.")
 		} else {
@@ -316,6 +438,7 @@ func createTenant(ctx context.Context) {
 		Slug:        slug,
 		DisplayName: name,
 		DBName:      dbName,
+		DBSchema:    dbSchema,
 		DBHost:      getEnvDefault("TENANT_DB_HOST", "localhost"),
 		DBPort:      getEnvIntDefault("TENANT_DB_PORT", 5432),
 		Status:      tenant.StatusActive,
@@ -326,10 +449,18 @@ func createTenant(ctx context.Context) {
 		fmt.Printf("Error registering tenant: %v\n", err)
 		os.Exit(1)
 	}
+	recordAudit(ctx, metaPool, tenant.AuditEntry{
+		TenantID: t.ID,
+		Action:   tenant.AuditCreated,
+		After:    map[string]any{"slug": slug, "dbName": dbName, "dbSchema": dbSchema, "plan": plan, "status": string(tenant.StatusActive)},
+	})
 
 	fmt.Printf("\n✓ Tenant '%s' created successfully!\n", slug)
 	fmt.Printf("  Tenant ID: %s\n", t.ID)
 	fmt.Printf("  Database: %s\n", dbName)
+	if dbSchema != "" {
+		fmt.Printf("  Schema: %s\n", dbSchema)
+	}
 	fmt.Printf("  Status: active\n")
 	fmt.Printf("  Plan: %s\n", plan)
 }
@@ -350,21 +481,30 @@ func listTenants(ctx context.Context) {
 		return
 	}
 
-	fmt.Printf("%-36s %-20s %-30s %-15s %-6s %-12s %-10s\n", "TENANT_ID", "SLUG", "NAME", "DATABASE", "SCHEMA", "VERSION_GRP", "STATUS")
-	fmt.Println(strings.Repeat("-", 155))
+	fmt.Printf("%-36s %-20s %-30s %-15s %-6s %-12s %-12s %-10s\n", "TENANT_ID", "SLUG", "NAME", "DATABASE", "SCHEMA", "VERSION_GRP", "REGION", "STATUS")
+	fmt.Println(strings.Repeat("-", 168))
 
 	for _, t := range tenants {
 		vg := t.VersionGroup
 		if vg == "" {
 			vg = "-"
 		}
-		fmt.Printf("%-36s %-20s %-30s %-15s %-6s %-12s %-10s\n",
+		region := t.Region
+		if region == "" {
+			region = "-"
+		}
+		database := t.DBName
+		if t.SharedSchema() {
+			database += "." + t.DBSchema
+		}
+		fmt.Printf("%-36s %-20s %-30s %-15s %-6s %-12s %-12s %-10s\n",
 			truncate(t.ID, 36),
 			truncate(t.Slug, 20),
 			truncate(t.DisplayName, 30),
-			truncate(t.DBName, 15),
+			truncate(database, 15),
 			strconv.Itoa(t.SchemaVersion),
 			vg,
+			truncate(region, 12),
 			t.Status,
 		)
 	}
@@ -373,6 +513,7 @@ func listTenants(ctx context.Context) {
 func migrateTenants(ctx context.Context) {
 	var targetID string
 	var all bool
+	concurrency := getEnvIntDefault("TENANT_MIGRATE_CONCURRENCY", tenant.DefaultMigrationConcurrency)
 
 	for i := 2; i < len(os.Args); i++ {
 		switch os.Args[i] {
@@ -383,6 +524,16 @@ func migrateTenants(ctx context.Context) {
 			}
 		case "--all":
 			all = true
+		case "--concurrency":
+			if i+1 < len(os.Args) {
+				n, err := strconv.Atoi(os.Args[i+1])
+				if err != nil || n < 1 {
+					fmt.Println("Error: --concurrency must be a positive number")
+					os.Exit(1)
+				}
+				concurrency = n
+				i++
+			}
 		}
 	}
 
@@ -424,86 +575,420 @@ func migrateTenants(ctx context.Context) {
 		os.Exit(1)
 	}
 
-	for _, t := range tenants {
-		fmt.Printf("Migrating %s (%s)...\n", t.Slug, t.DBName)
-
-		dsn := t.DSN(dbUser, dbPassword)
-		if err := runAllMigrations(dsn); err != nil {
-			fmt.Printf("  ✗ Failed: %v\n", err)
-		} else {
-			// Update schema_version in meta-database after successful migration.
-			if svErr := registry.UpdateSchemaVersion(ctx, t.ID, version.ExpectedSchemaVersion); svErr != nil {
-				fmt.Printf("  ⚠ Migrated but failed to update schema_version: %v\n", svErr)
-			} else {
-				fmt.Printf("  ✓ Done (schema_version=%d)\n", version.ExpectedSchemaVersion)
-			}
+	migrator := tenant.NewMigrator(registry, func(t *tenant.Tenant) string {
+		return t.DSN(dbUser, dbPassword)
+	}, migration.Migrate)
+
+	fmt.Printf("Migrating %d tenant(s), %d at a time...\n", len(tenants), concurrency)
+	results := migrator.MigrateAll(ctx, tenants, concurrency, func(res tenant.MigrationResult) {
+		t := res.Tenant
+		fmt.Printf("%s (%s):\n", t.Slug, t.DBName)
+		fmt.Print(res.Output)
+		if res.Err != nil {
+			fmt.Printf("  ✗ Failed: %v\n", res.Err)
+			return
+		}
+		fmt.Printf("  ✓ Done (schema_version=%d)\n", res.ToVersion)
+		recordAudit(ctx, metaPool, tenant.AuditEntry{
+			TenantID: t.ID,
+			Action:   tenant.AuditMigrated,
+			Before:   map[string]any{"schemaVersion": res.FromVersion},
+			After:    map[string]any{"schemaVersion": res.ToVersion},
+		})
+	})
+
+	failed := 0
+	for _, res := range results {
+		if res.Err != nil {
+			failed++
 		}
 	}
+	if failed > 0 {
+		fmt.Printf("%d of %d tenant(s) failed\n", failed, len(results))
+		os.Exit(1)
+	}
 }
 
-func suspendTenant(ctx context.Context) {
-	if len(os.Args) < 3 {
-		fmt.Println("Usage: tenant suspend <tenant-uuid>")
+// backupTenant dumps a tenant database into the backup directory and
+// registers the file in the meta database with its size and checksum.
+// Usage: tenant backup <uuid> [--dir <path>]
+func backupTenant(ctx context.Context) {
+	if len(os.Args) < 3 || strings.HasPrefix(os.Args[2], "-") {
+		fmt.Println("Usage: tenant backup <tenant-uuid> [--dir <path>]")
 		os.Exit(1)
 	}
-
 	tenantID := os.Args[2]
+	dir := getEnvDefault("TENANT_BACKUP_DIR", "./data/backups")
+
+	for i := 3; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case "--dir":
+			if i+1 < len(os.Args) {
+				dir = os.Args[i+1]
+				i++
+			}
+		}
+	}
+
+	dbUser := os.Getenv("TENANT_DB_USER")
+	dbPassword := os.Getenv("TENANT_DB_PASSWORD")
+	if dbUser == "" || dbPassword == "" {
+		fmt.Println("Error: TENANT_DB_USER and TENANT_DB_PASSWORD are required")
+		os.Exit(1)
+	}
 
 	metaPool := getMetaPool(ctx)
 	defer metaPool.Close()
 
 	registry := tenant.NewPostgresRegistry(metaPool)
-	if err := registry.UpdateStatusByID(ctx, tenantID, tenant.StatusSuspended); err != nil {
+	store := tenant.NewPostgresBackupStore(metaPool)
+	if err := store.EnsureTable(ctx); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("✓ Tenant '%s' suspended\n", tenantID)
-}
+	t, err := registry.GetByID(ctx, tenantID)
+	if err != nil {
+		fmt.Printf("Error: tenant '%s' not found: %v\n", tenantID, err)
+		os.Exit(1)
+	}
 
-func activateTenant(ctx context.Context) {
-	if len(os.Args) < 3 {
-		fmt.Println("Usage: tenant activate <tenant-uuid>")
+	fmt.Printf("Backing up %s (%s)...\n", t.Slug, t.DBName)
+	b, err := migration.BackupTenant(ctx, store, t, t.DSN(dbUser, dbPassword), dir, tenant.BackupManual)
+	if err != nil {
+		fmt.Printf("  ✗ Failed: %v\n", err)
 		os.Exit(1)
 	}
 
+	recordAudit(ctx, metaPool, tenant.AuditEntry{
+		TenantID: t.ID,
+		Action:   tenant.AuditBackupCreated,
+		Details:  map[string]any{"file": b.FilePath, "checksum": b.Checksum, "schemaVersion": b.SchemaVersion},
+	})
+
+	fmt.Printf("✓ Backup of tenant '%s' created\n", t.Slug)
+	fmt.Printf("  File: %s\n", b.FilePath)
+	fmt.Printf("  Size: %d bytes\n", b.SizeBytes)
+	fmt.Printf("  SHA-256: %s\n", b.Checksum)
+	fmt.Printf("  Schema version: %d\n", b.SchemaVersion)
+}
+
+// exportTenant writes all data of a tenant (catalogs, documents, registers,
+// users and roles without credentials) to a zip of CSV or JSONL files, for
+// GDPR requests and offboarding. With --async the export is queued for the
+// worker (which writes into its own TENANT_EXPORT_DIR); follow it with
+// `tenant exports`.
+// Usage: tenant export <uuid> [--format csv|jsonl] [--dir <path>] [--async]
+func exportTenant(ctx context.Context) {
+	if len(os.Args) < 3 || strings.HasPrefix(os.Args[2], "-") {
+		fmt.Println("Usage: tenant export <tenant-uuid> [--format csv|jsonl] [--dir <path>] [--async]")
+		os.Exit(1)
+	}
 	tenantID := os.Args[2]
+	format := tenant.ExportJSONL
+	dir := getEnvDefault("TENANT_EXPORT_DIR", "./data/exports")
+	async := false
+
+	for i := 3; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case "--format":
+			if i+1 < len(os.Args) {
+				format = tenant.ExportFormat(os.Args[i+1])
+				i++
+			}
+		case "--dir":
+			if i+1 < len(os.Args) {
+				dir = os.Args[i+1]
+				i++
+			}
+		case "--async":
+			async = true
+		}
+	}
+	if !format.Valid() {
+		fmt.Printf("Error: unknown format '%s' (csv or jsonl)\n", format)
+		os.Exit(1)
+	}
+
+	dbUser := os.Getenv("TENANT_DB_USER")
+	dbPassword := os.Getenv("TENANT_DB_PASSWORD")
+	if !async && (dbUser == "" || dbPassword == "") {
+		fmt.Println("Error: TENANT_DB_USER and TENANT_DB_PASSWORD are required")
+		os.Exit(1)
+	}
 
 	metaPool := getMetaPool(ctx)
 	defer metaPool.Close()
 
 	registry := tenant.NewPostgresRegistry(metaPool)
-	if err := registry.UpdateStatusByID(ctx, tenantID, tenant.StatusActive); err != nil {
+	store := tenant.NewPostgresExportStore(metaPool)
+	if err := store.EnsureTable(ctx); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("✓ Tenant '%s' activated\n", tenantID)
+	t, err := registry.GetByID(ctx, tenantID)
+	if err != nil {
+		fmt.Printf("Error: tenant '%s' not found: %v\n", tenantID, err)
+		os.Exit(1)
+	}
+	if t.Status == tenant.StatusDeleted {
+		fmt.Printf("Error: tenant '%s' is deleted, its database no longer exists\n", t.Slug)
+		os.Exit(1)
+	}
+
+	e := &tenant.Export{
+		TenantID:    t.ID,
+		Format:      format,
+		Status:      tenant.ExportPending,
+		RequestedBy: cliActor(),
+	}
+	if !async {
+		e.Status = tenant.ExportRunning
+	}
+	if err := store.Create(ctx, e); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if async {
+		recordAudit(ctx, metaPool, tenant.AuditEntry{
+			TenantID: t.ID,
+			Action:   tenant.AuditExportRequested,
+			Details:  map[string]any{"export": e.ID, "format": string(format)},
+		})
+		fmt.Printf("✓ Export of tenant '%s' queued for the worker\n", t.Slug)
+		fmt.Printf("  Export ID: %s\n", e.ID)
+		fmt.Printf("  Follow progress with: tenant exports %s\n", t.ID)
+		return
+	}
+
+	fmt.Printf("Exporting %s (%s) as %s...\n", t.Slug, t.DBName, format)
+	err = migration.ExportTenant(ctx, store, e, t, t.DSN(dbUser, dbPassword), dir, func(e *tenant.Export) {
+		fmt.Printf("  [%d/%d] %-40s %d rows total\n", e.TablesDone, e.TablesTotal, e.CurrentTable, e.RowsExported)
+	})
+	if err != nil {
+		fmt.Printf("  ✗ Failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	recordAudit(ctx, metaPool, tenant.AuditEntry{
+		TenantID: t.ID,
+		Action:   tenant.AuditExported,
+		Details:  map[string]any{"export": e.ID, "format": string(format), "file": e.FilePath, "checksum": e.Checksum},
+	})
+
+	fmt.Printf("✓ Export of tenant '%s' created\n", t.Slug)
+	fmt.Printf("  File: %s\n", e.FilePath)
+	fmt.Printf("  Size: %d bytes\n", e.SizeBytes)
+	fmt.Printf("  SHA-256: %s\n", e.Checksum)
+	fmt.Printf("  Tables: %d, rows: %d\n", e.TablesDone, e.RowsExported)
 }
 
-// promoteTenant assigns a tenant to a version group (cloud mode).
-// Usage: tenant promote --id <uuid> --to <version_group>
-func promoteTenant(ctx context.Context) {
-	var targetID, targetGroup string
+// listExports prints the data exports of a tenant, newest first.
+// Usage: tenant exports <uuid>
+func listExports(ctx context.Context) {
+	if len(os.Args) < 3 || strings.HasPrefix(os.Args[2], "-") {
+		fmt.Println("Usage: tenant exports <tenant-uuid>")
+		os.Exit(1)
+	}
 
-	for i := 2; i < len(os.Args); i++ {
+	metaPool := getMetaPool(ctx)
+	defer metaPool.Close()
+
+	store := tenant.NewPostgresExportStore(metaPool)
+	if err := store.EnsureTable(ctx); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	exports, err := store.ListByTenant(ctx, os.Args[2])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(exports) == 0 {
+		fmt.Println("No exports found")
+		return
+	}
+
+	fmt.Printf("%-36s %-20s %-6s %-10s %-9s %-12s %s\n", "EXPORT_ID", "CREATED", "FORMAT", "STATUS", "TABLES", "ROWS", "FILE / ERROR")
+	fmt.Println(strings.Repeat("-", 140))
+
+	for _, e := range exports {
+		result := e.FilePath
+		if e.Status == tenant.ExportFailed {
+			result = e.Error
+		} else if e.Status == tenant.ExportRunning {
+			result = e.CurrentTable
+		}
+		fmt.Printf("%-36s %-20s %-6s %-10s %-9s %-12d %s\n",
+			e.ID,
+			e.CreatedAt.UTC().Format("2006-01-02 15:04:05"),
+			e.Format,
+			e.Status,
+			fmt.Sprintf("%d/%d", e.TablesDone, e.TablesTotal),
+			e.RowsExported,
+			orDash(result),
+		)
+	}
+}
+
+// repostTenant queues the re-posting of the posted documents of a tenant
+// dated in a range; the worker runs it. --wait follows the progress.
+// Usage: tenant repost <uuid> --from <YYYY-MM-DD> --to <YYYY-MM-DD> [--types goods_issue,goods_receipt] [--wait]
+func repostTenant(ctx context.Context) {
+	usage := "Usage: tenant repost <tenant-uuid> --from <YYYY-MM-DD> --to <YYYY-MM-DD> [--types <type,...>] [--wait]"
+	if len(os.Args) < 3 || strings.HasPrefix(os.Args[2], "-") {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+	tenantID := os.Args[2]
+	var from, to, types string
+	wait := false
+	for i := 3; i < len(os.Args); i++ {
 		switch os.Args[i] {
-		case "--id":
+		case "--from", "--to", "--types":
 			if i+1 < len(os.Args) {
-				targetID = os.Args[i+1]
+				switch os.Args[i] {
+				case "--from":
+					from = os.Args[i+1]
+				case "--to":
+					to = os.Args[i+1]
+				default:
+					types = os.Args[i+1]
+				}
 				i++
 			}
-		case "--to":
+		case "--wait":
+			wait = true
+		}
+	}
+	dateFrom, errFrom := time.Parse(time.DateOnly, from)
+	dateTo, errTo := time.Parse(time.DateOnly, to)
+	if errFrom != nil || errTo != nil {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+	req := reposting.Request{DateFrom: dateFrom, DateTo: dateTo}
+	if types != "" {
+		req.DocumentTypes = strings.Split(types, ",")
+	}
+
+	dbUser := os.Getenv("TENANT_DB_USER")
+	dbPassword := os.Getenv("TENANT_DB_PASSWORD")
+	if dbUser == "" || dbPassword == "" {
+		fmt.Println("Error: TENANT_DB_USER and TENANT_DB_PASSWORD are required")
+		os.Exit(1)
+	}
+
+	metaPool := getMetaPool(ctx)
+	defer metaPool.Close()
+
+	t, err := tenant.NewPostgresRegistry(metaPool).GetByID(ctx, tenantID)
+	if err != nil {
+		fmt.Printf("Error: tenant '%s' not found: %v\n", tenantID, err)
+		os.Exit(1)
+	}
+	if t.Status == tenant.StatusDeleted {
+		fmt.Printf("Error: tenant '%s' is deleted, its database no longer exists\n", t.Slug)
+		os.Exit(1)
+	}
+
+	pool, err := pgxpool.New(ctx, t.DSN(dbUser, dbPassword))
+	if err != nil {
+		fmt.Printf("Error: connect to tenant database: %v\n", err)
+		os.Exit(1)
+	}
+	defer pool.Close()
+	tenantCtx := tenant.WithTxManager(tenant.WithTenant(ctx, t), postgres.NewTxManagerFromRawPool(pool))
+
+	factoryReg := v1.NewFactoryRegistry()
+	content.RegisterDefaults(factoryReg)
+	svc := reposting.NewService(postgres.NewRepostRunRepo(),
+		postgres.NewRepostDocumentSource(v1.BuildMetadataRegistry(factoryReg)), jobs.NewQueue(postgres.NewJobRepo()))
+
+	run, err := svc.Start(tenantCtx, req)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	recordAudit(ctx, metaPool, tenant.AuditEntry{
+		TenantID: t.ID,
+		Action:   tenant.AuditRepostRequested,
+		Details:  map[string]any{"run": run.ID, "from": from, "to": to, "types": req.DocumentTypes},
+	})
+	fmt.Printf("✓ Re-posting of tenant '%s' from %s to %s queued for the worker\n", t.Slug, from, to)
+	fmt.Printf("  Run ID: %s\n", run.ID)
+	if !wait {
+		fmt.Println("  Follow progress with: GET /api/v1/system/repost-runs/" + run.ID.String())
+		return
+	}
+
+	for {
+		time.Sleep(2 * time.Second)
+		run, err = svc.Get(tenantCtx, run.ID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("  [%s] %d/%d documents, %d failed\n", run.Status, run.Processed, run.Total, run.Failed)
+		switch run.Status {
+		case reposting.StatusCompleted:
+			for _, e := range run.Errors {
+				fmt.Printf("  ✗ %s %s of %s: %s\n", e.DocumentType, e.Number, e.Date.Format(time.DateOnly), e.Message)
+			}
+			fmt.Println("✓ Re-posting completed")
+			return
+		case reposting.StatusFailed:
+			fmt.Printf("  ✗ Failed: %s\n", run.ErrorMessage)
+			os.Exit(1)
+		}
+	}
+}
+
+// restoreTenant restores a tenant database from a backup file. The file must
+// be a registered backup of the tenant with an unchanged checksum, made at
+// the tenant's current schema version; --force skips these checks. The
+// tenant is put into "updating" status while the restore runs.
+// Usage: tenant restore <uuid> --file <path> [--force] [--yes]
+func restoreTenant(ctx context.Context) {
+	if len(os.Args) < 3 || strings.HasPrefix(os.Args[2], "-") {
+		fmt.Println("Usage: tenant restore <tenant-uuid> --file <backup-file> [--force] [--yes]")
+		os.Exit(1)
+	}
+	tenantID := os.Args[2]
+	var file string
+	var force, yes bool
+
+	for i := 3; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case "--file":
 			if i+1 < len(os.Args) {
-				targetGroup = os.Args[i+1]
+				file = os.Args[i+1]
 				i++
 			}
+		case "--force":
+			force = true
+		case "--yes", "-y":
+			yes = true
 		}
 	}
 
-	if targetID == "" || targetGroup == "" {
-		fmt.Println("Usage: tenant promote --id <tenant-uuid> --to <version_group>")
-		fmt.Println("Example: tenant promote --id 550e8400-... --to v1.3.0")
+	if file == "" {
+		fmt.Println("Usage: tenant restore <tenant-uuid> --file <backup-file> [--force] [--yes]")
+		os.Exit(1)
+	}
+	path, err := filepath.Abs(file)
+	if err != nil {
+		fmt.Printf("Error: invalid --file: %v\n", err)
+		os.Exit(1)
+	}
+
+	dbUser := os.Getenv("TENANT_DB_USER")
+	dbPassword := os.Getenv("TENANT_DB_PASSWORD")
+	if dbUser == "" || dbPassword == "" {
+		fmt.Println("Error: TENANT_DB_USER and TENANT_DB_PASSWORD are required")
 		os.Exit(1)
 	}
 
@@ -511,27 +996,1274 @@ func promoteTenant(ctx context.Context) {
 	defer metaPool.Close()
 
 	registry := tenant.NewPostgresRegistry(metaPool)
+	store := tenant.NewPostgresBackupStore(metaPool)
+	if err := store.EnsureTable(ctx); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
 
-	// Verify tenant exists
-	t, err := registry.GetByID(ctx, targetID)
+	t, err := registry.GetByID(ctx, tenantID)
 	if err != nil {
-		fmt.Printf("Error: tenant '%s' not found: %v\n", targetID, err)
+		fmt.Printf("Error: tenant '%s' not found: %v\n", tenantID, err)
+		os.Exit(1)
+	}
+	if t.Status != tenant.StatusActive && t.Status != tenant.StatusSuspended {
+		fmt.Printf("Error: tenant status is %q (must be active or suspended)\n", t.Status)
 		os.Exit(1)
 	}
 
-	oldGroup := t.VersionGroup
-	if oldGroup == "" {
-		oldGroup = "(none)"
+	b, err := store.GetByFile(ctx, t.ID, path)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	switch {
+	case b == nil && !force:
+		fmt.Printf("Error: %s is not a registered backup of tenant '%s' (use --force to restore it unverified)\n", path, t.Slug)
+		os.Exit(1)
+	case b == nil:
+		fmt.Println("  ⚠ Unregistered file: checksum not verified")
+	default:
+		if err := migration.VerifyBackup(b); err != nil {
+			if !force {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("  ⚠ %v\n", err)
+		}
+		if b.SchemaVersion != t.SchemaVersion {
+			if !force {
+				fmt.Printf("Error: backup is at schema version %d, tenant at %d; objects of newer migrations would not be removed (use --force to restore anyway)\n",
+					b.SchemaVersion, t.SchemaVersion)
+				os.Exit(1)
+			}
+			fmt.Printf("  ⚠ Backup schema version %d differs from the tenant's %d\n", b.SchemaVersion, t.SchemaVersion)
+		}
 	}
 
-	if err := registry.UpdateVersionGroup(ctx, targetID, targetGroup); err != nil {
+	fmt.Printf("Restoring tenant '%s' (%s) from %s\n", t.Slug, t.DBName, path)
+	if b != nil {
+		fmt.Printf("  Backup taken: %s (schema version %d)\n", b.CreatedAt.Format(time.RFC3339), b.SchemaVersion)
+	}
+	fmt.Println("  All data written since the backup will be lost.")
+
+	if !yes {
+		fmt.Print("Proceed? [y/N]: ")
+		var answer string
+		_, _ = fmt.Scanln(&answer)
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			fmt.Println("Aborted")
+			return
+		}
+	}
+
+	fmt.Println("  [1/3] Setting status to updating...")
+	if err := registry.UpdateStatusByID(ctx, t.ID, tenant.StatusUpdating); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("✓ Tenant '%s' (%s) promoted: %s → %s\n", t.Slug, targetID, oldGroup, targetGroup)
-	fmt.Println("  Note: requests for this tenant will now be served by the server instance")
-	fmt.Printf("  running with VERSION_GROUP=%s\n", targetGroup)
+	fmt.Println("  [2/3] Restoring database...")
+	restoreErr := migration.RestoreDatabase(ctx, t.DSN(dbUser, dbPassword), path)
+	if restoreErr == nil && b != nil && b.SchemaVersion != t.SchemaVersion {
+		if err := registry.UpdateSchemaVersion(ctx, t.ID, b.SchemaVersion); err != nil {
+			fmt.Printf("  ⚠ Restored but failed to update schema_version: %v\n", err)
+		}
+	}
+
+	fmt.Printf("  [3/3] Setting status back to %s...\n", t.Status)
+	if err := registry.UpdateStatusByID(ctx, t.ID, t.Status); err != nil {
+		fmt.Printf("Error: failed to restore status %s: %v\n", t.Status, err)
+		os.Exit(1)
+	}
+
+	if restoreErr != nil {
+		fmt.Printf("  ✗ Restore failed, database unchanged: %v\n", restoreErr)
+		os.Exit(1)
+	}
+	restored := tenant.AuditEntry{
+		TenantID: t.ID,
+		Action:   tenant.AuditRestored,
+		Details:  map[string]any{"file": path, "verified": b != nil, "force": force},
+	}
+	if b != nil && b.SchemaVersion != t.SchemaVersion {
+		restored.Before = map[string]any{"schemaVersion": t.SchemaVersion}
+		restored.After = map[string]any{"schemaVersion": b.SchemaVersion}
+	}
+	recordAudit(ctx, metaPool, restored)
+	fmt.Printf("✓ Tenant '%s' restored\n", t.Slug)
+	if b != nil && b.SchemaVersion < version.ExpectedSchemaVersion {
+		fmt.Printf("  Run 'tenant migrate --id %s' to bring it to schema version %d.\n", t.ID, version.ExpectedSchemaVersion)
+	}
+}
+
+func suspendTenant(ctx context.Context) {
+	if len(os.Args) < 3 {
+		fmt.Println("Usage: tenant suspend <tenant-uuid>")
+		os.Exit(1)
+	}
+
+	tenantID := os.Args[2]
+
+	metaPool := getMetaPool(ctx)
+	defer metaPool.Close()
+
+	registry := tenant.NewPostgresRegistry(metaPool)
+	t, err := registry.GetByID(ctx, tenantID)
+	if err != nil {
+		fmt.Printf("Error: tenant '%s' not found: %v\n", tenantID, err)
+		os.Exit(1)
+	}
+	if t.Status == tenant.StatusPendingDeletion || t.Status == tenant.StatusDeleted {
+		fmt.Printf("Error: tenant status is %q\n", t.Status)
+		os.Exit(1)
+	}
+	if err := registry.UpdateStatusByID(ctx, tenantID, tenant.StatusSuspended); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	recordAudit(ctx, metaPool, tenant.AuditEntry{
+		TenantID: tenantID,
+		Action:   tenant.AuditSuspended,
+		Before:   map[string]any{"status": string(t.Status)},
+		After:    map[string]any{"status": string(tenant.StatusSuspended)},
+	})
+
+	fmt.Printf("✓ Tenant '%s' suspended\n", tenantID)
+}
+
+func activateTenant(ctx context.Context) {
+	if len(os.Args) < 3 {
+		fmt.Println("Usage: tenant activate <tenant-uuid>")
+		os.Exit(1)
+	}
+
+	tenantID := os.Args[2]
+
+	metaPool := getMetaPool(ctx)
+	defer metaPool.Close()
+
+	registry := tenant.NewPostgresRegistry(metaPool)
+	t, err := registry.GetByID(ctx, tenantID)
+	if err != nil {
+		fmt.Printf("Error: tenant '%s' not found: %v\n", tenantID, err)
+		os.Exit(1)
+	}
+	switch t.Status {
+	case tenant.StatusPendingDeletion:
+		fmt.Println("Error: tenant is scheduled for deletion; run 'tenant delete <tenant-uuid> --cancel' first")
+		os.Exit(1)
+	case tenant.StatusDeleted:
+		fmt.Println("Error: tenant is deleted; restore its archive into a new tenant instead")
+		os.Exit(1)
+	}
+	if err := registry.UpdateStatusByID(ctx, tenantID, tenant.StatusActive); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	recordAudit(ctx, metaPool, tenant.AuditEntry{
+		TenantID: tenantID,
+		Action:   tenant.AuditActivated,
+		Before:   map[string]any{"status": string(t.Status)},
+		After:    map[string]any{"status": string(tenant.StatusActive)},
+	})
+
+	fmt.Printf("✓ Tenant '%s' activated\n", tenantID)
+}
+
+// setTenantClock moves a tenant's business clock (demo/training tenants).
+// Usage: tenant clock --id <uuid> (--frozen-at <RFC3339> | --offset <duration> | --reset)
+func setTenantClock(ctx context.Context) {
+	var targetID, frozenAt, offset string
+	var reset bool
+
+	for i := 2; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case "--id":
+			if i+1 < len(os.Args) {
+				targetID = os.Args[i+1]
+				i++
+			}
+		case "--frozen-at":
+			if i+1 < len(os.Args) {
+				frozenAt = os.Args[i+1]
+				i++
+			}
+		case "--offset":
+			if i+1 < len(os.Args) {
+				offset = os.Args[i+1]
+				i++
+			}
+		case "--reset":
+			reset = true
+		}
+	}
+
+	modes := 0
+	for _, set := range []bool{frozenAt != "", offset != "", reset} {
+		if set {
+			modes++
+		}
+	}
+	if targetID == "" || modes != 1 {
+		fmt.Println("Usage: tenant clock --id <tenant-uuid> (--frozen-at <RFC3339> | --offset <duration> | --reset)")
+		os.Exit(1)
+	}
+
+	set := map[string]any{}
+	unset := []string{tenant.SettingClockFrozenAt, tenant.SettingClockOffset}
+	switch {
+	case frozenAt != "":
+		if _, err := time.Parse(time.RFC3339, frozenAt); err != nil {
+			fmt.Printf("Error: invalid --frozen-at: %v\n", err)
+			os.Exit(1)
+		}
+		set[tenant.SettingClockFrozenAt] = frozenAt
+	case offset != "":
+		if _, err := time.ParseDuration(offset); err != nil {
+			fmt.Printf("Error: invalid --offset: %v\n", err)
+			os.Exit(1)
+		}
+		set[tenant.SettingClockOffset] = offset
+	}
+
+	metaPool := getMetaPool(ctx)
+	defer metaPool.Close()
+
+	registry := tenant.NewPostgresRegistry(metaPool)
+	if err := registry.MergeSettings(ctx, targetID, set, unset); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	recordAudit(ctx, metaPool, tenant.AuditEntry{
+		TenantID: targetID,
+		Action:   tenant.AuditClockChanged,
+		After:    set,
+	})
+
+	switch {
+	case reset:
+		fmt.Printf("✓ Tenant '%s' clock reset to real time\n", targetID)
+	case frozenAt != "":
+		fmt.Printf("✓ Tenant '%s' clock frozen at %s\n", targetID, frozenAt)
+	default:
+		fmt.Printf("✓ Tenant '%s' clock shifted by %s\n", targetID, offset)
+	}
+	fmt.Println("  Takes effect when the tenant's connection pool is next opened (idle eviction or server restart).")
+}
+
+// tenantFeatures prints the features of a tenant and changes their
+// overrides in the tenant settings. --enable and --disable override the
+// plan; --reset drops the override. Each flag may be repeated.
+// Usage: tenant features <uuid> [--enable <feature>] [--disable <feature>] [--reset <feature>]
+func tenantFeatures(ctx context.Context) {
+	if len(os.Args) < 3 || strings.HasPrefix(os.Args[2], "--") {
+		fmt.Println("Usage: tenant features <tenant-uuid> [--enable <feature>] [--disable <feature>] [--reset <feature>]")
+		os.Exit(1)
+	}
+	tenantID := os.Args[2]
+
+	changes := map[tenant.Feature]string{} // feature → option
+	for i := 3; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case "--enable", "--disable", "--reset":
+		default:
+			fmt.Printf("Error: unknown option %s\n", os.Args[i])
+			os.Exit(1)
+		}
+		if i+1 >= len(os.Args) || !tenant.IsFeature(os.Args[i+1]) {
+			fmt.Printf("Error: %s requires one of: %s\n", os.Args[i], featureNames())
+			os.Exit(1)
+		}
+		changes[tenant.Feature(os.Args[i+1])] = os.Args[i]
+		i++
+	}
+
+	metaPool := getMetaPool(ctx)
+	defer metaPool.Close()
+
+	registry := tenant.NewPostgresRegistry(metaPool)
+	t, err := registry.GetByID(ctx, tenantID)
+	if err != nil {
+		fmt.Printf("Error: tenant '%s' not found: %v\n", tenantID, err)
+		os.Exit(1)
+	}
+
+	if len(changes) > 0 {
+		before := map[string]any{}
+		for f, enabled := range t.FeatureOverrides() {
+			before[string(f)] = enabled
+		}
+		after := maps.Clone(before)
+		for f, opt := range changes {
+			if opt == "--reset" {
+				delete(after, string(f))
+			} else {
+				after[string(f)] = opt == "--enable"
+			}
+		}
+
+		set, unset := map[string]any{tenant.SettingFeatures: after}, []string(nil)
+		if len(after) == 0 {
+			set, unset = nil, []string{tenant.SettingFeatures}
+		}
+		if err := registry.MergeSettings(ctx, tenantID, set, unset); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		recordAudit(ctx, metaPool, tenant.AuditEntry{
+			TenantID: tenantID,
+			Action:   tenant.AuditFeaturesChanged,
+			Before:   map[string]any{tenant.SettingFeatures: before},
+			After:    map[string]any{tenant.SettingFeatures: after},
+		})
+		if t.Settings == nil {
+			t.Settings = map[string]any{}
+		}
+		t.Settings[tenant.SettingFeatures] = after
+		fmt.Printf("✓ Tenant '%s' feature overrides updated\n", tenantID)
+		fmt.Println("  Takes effect when the tenant's connection pool is next opened (idle eviction or server restart).")
+	}
+
+	fmt.Printf("Plan: %s\n", t.Plan)
+	for _, st := range t.FeatureStates() {
+		state := "disabled"
+		if st.Enabled {
+			state = "enabled"
+		}
+		fmt.Printf("  %-16s %-9s (%s)\n", st.Feature, state, st.Source)
+	}
+}
+
+// setTenantReadOnly switches the emergency read-only mode of a tenant: users
+// can still sign in and read, but changes are rejected and background jobs
+// pause. Without --on/--off it shows the current mode.
+// Usage: tenant read-only <uuid> [--on --reason <text> | --off]
+func setTenantReadOnly(ctx context.Context) {
+	const usage = "Usage: tenant read-only <tenant-uuid> [--on --reason <text> | --off]"
+	if len(os.Args) < 3 || strings.HasPrefix(os.Args[2], "--") {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+	tenantID := os.Args[2]
+
+	var on, off bool
+	var reason string
+	for i := 3; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case "--on":
+			on = true
+		case "--off":
+			off = true
+		case "--reason":
+			if i+1 < len(os.Args) {
+				reason = strings.TrimSpace(os.Args[i+1])
+				i++
+			}
+		default:
+			fmt.Printf("Error: unknown option %s\n", os.Args[i])
+			os.Exit(1)
+		}
+	}
+	if on && off {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+	if on && reason == "" {
+		fmt.Println("Error: --on requires --reason")
+		os.Exit(1)
+	}
+
+	metaPool := getMetaPool(ctx)
+	defer metaPool.Close()
+
+	registry := tenant.NewPostgresRegistry(metaPool)
+	t, err := registry.GetByID(ctx, tenantID)
+	if err != nil {
+		fmt.Printf("Error: tenant '%s' not found: %v\n", tenantID, err)
+		os.Exit(1)
+	}
+	before, wasReadOnly := t.ReadOnly()
+
+	if !on && !off {
+		if !wasReadOnly {
+			fmt.Printf("Tenant '%s' is writable\n", tenantID)
+			return
+		}
+		fmt.Printf("Tenant '%s' is read-only since %s (by %s)\n", tenantID, before.Since.Format(time.RFC3339), before.By)
+		fmt.Printf("  Reason: %s\n", before.Reason)
+		return
+	}
+
+	beforeAudit := map[string]any{"read_only": wasReadOnly}
+	if wasReadOnly {
+		beforeAudit["reason"] = before.Reason
+	}
+	if on {
+		mode := tenant.ReadOnlyMode{Reason: reason, Since: time.Now(), By: cliActor()}
+		if wasReadOnly {
+			mode.Since = before.Since
+		}
+		err = registry.MergeSettings(ctx, tenantID, map[string]any{tenant.SettingReadOnly: mode.Setting()}, nil)
+	} else {
+		err = registry.MergeSettings(ctx, tenantID, nil, []string{tenant.SettingReadOnly})
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if on {
+		recordAudit(ctx, metaPool, tenant.AuditEntry{
+			TenantID: tenantID,
+			Action:   tenant.AuditReadOnlyEnabled,
+			Before:   beforeAudit,
+			After:    map[string]any{"read_only": true, "reason": reason},
+		})
+		fmt.Printf("✓ Tenant '%s' is now read-only: %s\n", tenantID, reason)
+	} else {
+		recordAudit(ctx, metaPool, tenant.AuditEntry{
+			TenantID: tenantID,
+			Action:   tenant.AuditReadOnlyDisabled,
+			Before:   beforeAudit,
+			After:    map[string]any{"read_only": false},
+		})
+		fmt.Printf("✓ Tenant '%s' is writable again\n", tenantID)
+	}
+}
+
+// setTenantReplica registers or removes the read replica of a tenant: a
+// streaming replica of the tenant database that serves list, card and report
+// reads. Running servers pick it up when they reopen the tenant pool. Without
+// --host/--off it shows the current replica.
+// Usage: tenant replica <uuid> [--host <host> [--port <port>] | --off]
+func setTenantReplica(ctx context.Context) {
+	const usage = "Usage: tenant replica <tenant-uuid> [--host <host> [--port <port>] | --off]"
+	if len(os.Args) < 3 || strings.HasPrefix(os.Args[2], "--") {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+	tenantID := os.Args[2]
+
+	var host string
+	var port int
+	var off bool
+	for i := 3; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case "--host":
+			if i+1 < len(os.Args) {
+				host = strings.TrimSpace(os.Args[i+1])
+				i++
+			}
+		case "--port":
+			if i+1 < len(os.Args) {
+				p, err := strconv.Atoi(os.Args[i+1])
+				if err != nil || p <= 0 || p > 65535 {
+					fmt.Printf("Error: invalid port %q\n", os.Args[i+1])
+					os.Exit(1)
+				}
+				port = p
+				i++
+			}
+		case "--off":
+			off = true
+		default:
+			fmt.Printf("Error: unknown option %s\n", os.Args[i])
+			os.Exit(1)
+		}
+	}
+	if off && host != "" {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+
+	metaPool := getMetaPool(ctx)
+	defer metaPool.Close()
+
+	registry := tenant.NewPostgresRegistry(metaPool)
+	t, err := registry.GetByID(ctx, tenantID)
+	if err != nil {
+		fmt.Printf("Error: tenant '%s' not found: %v\n", tenantID, err)
+		os.Exit(1)
+	}
+	oldHost, oldPort, hadReplica := t.ReadReplica()
+
+	if host == "" && !off {
+		if !hadReplica {
+			fmt.Printf("Tenant '%s' has no read replica\n", tenantID)
+			return
+		}
+		fmt.Printf("Tenant '%s' reads from replica %s:%d\n", tenantID, oldHost, oldPort)
+		return
+	}
+
+	before := map[string]any{}
+	if hadReplica {
+		before = tenant.ReplicaSetting(oldHost, oldPort)
+	}
+	after := map[string]any{}
+	if off {
+		err = registry.MergeSettings(ctx, tenantID, nil, []string{tenant.SettingReadReplica})
+	} else {
+		if port == 0 {
+			port = t.DBPort
+		}
+		after = tenant.ReplicaSetting(host, port)
+		err = registry.MergeSettings(ctx, tenantID, map[string]any{tenant.SettingReadReplica: after}, nil)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	recordAudit(ctx, metaPool, tenant.AuditEntry{
+		TenantID: tenantID,
+		Action:   tenant.AuditReplicaChanged,
+		Before:   before,
+		After:    after,
+	})
+	if off {
+		fmt.Printf("✓ Tenant '%s' reads from the primary only\n", tenantID)
+	} else {
+		fmt.Printf("✓ Tenant '%s' read replica set to %s:%d\n", tenantID, host, port)
+	}
+	fmt.Println("  Running servers apply it when they reopen the tenant pool (idle eviction or restart);")
+	fmt.Println("  PUT /control-plane/tenants/:id/read-replica applies it at once.")
+}
+
+// featureNames lists the known features for usage messages.
+func featureNames() string {
+	names := make([]string, len(tenant.Features))
+	for i, f := range tenant.Features {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
+
+// verifyTenants compares tenant databases against the expected schema:
+// applied and missing core migrations, the schema version in the registry
+// and the column checksums of critical tables (against a reference tenant or
+// the checksums most tenants share). The drift is recorded in the tenant
+// settings; --repair re-applies missing migrations and verifies again.
+// Usage: tenant verify (--id <uuid> | --all) [--reference <uuid>] [--repair] [--concurrency N]
+func verifyTenants(ctx context.Context) {
+	var targetID, referenceID string
+	var all, repair bool
+	concurrency := getEnvIntDefault("TENANT_MIGRATE_CONCURRENCY", tenant.DefaultMigrationConcurrency)
+
+	for i := 2; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case "--id":
+			if i+1 < len(os.Args) {
+				targetID = os.Args[i+1]
+				i++
+			}
+		case "--reference":
+			if i+1 < len(os.Args) {
+				referenceID = os.Args[i+1]
+				i++
+			}
+		case "--all":
+			all = true
+		case "--repair":
+			repair = true
+		case "--concurrency":
+			if i+1 < len(os.Args) {
+				n, err := strconv.Atoi(os.Args[i+1])
+				if err != nil || n < 1 {
+					fmt.Println("Error: --concurrency must be a positive number")
+					os.Exit(1)
+				}
+				concurrency = n
+				i++
+			}
+		}
+	}
+
+	if !all && targetID == "" {
+		fmt.Println("Error: specify --id <tenant-uuid> or --all")
+		os.Exit(1)
+	}
+
+	metaPool := getMetaPool(ctx)
+	defer metaPool.Close()
+
+	registry := tenant.NewPostgresRegistry(metaPool)
+
+	var tenants []*tenant.Tenant
+	if all {
+		var err error
+		if tenants, err = registry.ListActive(ctx); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		t, err := registry.GetByID(ctx, targetID)
+		if err != nil {
+			fmt.Printf("Error: tenant '%s' not found\n", targetID)
+			os.Exit(1)
+		}
+		tenants = []*tenant.Tenant{t}
+	}
+
+	dbUser := os.Getenv("TENANT_DB_USER")
+	dbPassword := os.Getenv("TENANT_DB_PASSWORD")
+	if dbUser == "" || dbPassword == "" {
+		fmt.Println("Error: TENANT_DB_USER and TENANT_DB_PASSWORD are required")
+		os.Exit(1)
+	}
+	dsn := func(t *tenant.Tenant) string { return t.DSN(dbUser, dbPassword) }
+	verifier := tenant.NewVerifier(registry, dsn, migration.Inspect, migration.CriticalTables)
+
+	var baseline map[string]string
+	if referenceID != "" {
+		ref, err := registry.GetByID(ctx, referenceID)
+		if err != nil {
+			fmt.Printf("Error: reference tenant '%s' not found\n", referenceID)
+			os.Exit(1)
+		}
+		state, err := verifier.Inspect(ctx, ref)
+		if err != nil {
+			fmt.Printf("Error: inspect reference tenant: %v\n", err)
+			os.Exit(1)
+		}
+		baseline = state.Tables
+	}
+
+	fmt.Printf("Verifying %d tenant(s) against %d critical tables...\n", len(tenants), len(migration.CriticalTables))
+	results := verifier.VerifyAll(ctx, tenants, concurrency, baseline)
+	if baseline == nil {
+		baseline = tenant.MajorityChecksums(results)
+	}
+
+	if repair {
+		var repairable []*tenant.Tenant
+		for _, res := range results {
+			if res.Repairable() {
+				repairable = append(repairable, res.Tenant)
+			}
+		}
+		if len(repairable) > 0 {
+			fmt.Printf("Re-applying missing migrations to %d tenant(s)...\n", len(repairable))
+			migrator := tenant.NewMigrator(registry, dsn, migration.Migrate)
+			migrator.MigrateAll(ctx, repairable, concurrency, func(res tenant.MigrationResult) {
+				if res.Err != nil {
+					fmt.Printf("  ✗ %s: %v\n", res.Tenant.Slug, res.Err)
+					return
+				}
+				fmt.Printf("  ✓ %s: schema_version=%d\n", res.Tenant.Slug, res.ToVersion)
+				recordAudit(ctx, metaPool, tenant.AuditEntry{
+					TenantID: res.Tenant.ID,
+					Action:   tenant.AuditMigrated,
+					Before:   map[string]any{"schemaVersion": res.FromVersion},
+					After:    map[string]any{"schemaVersion": res.ToVersion},
+				})
+			})
+			results = verifier.VerifyAll(ctx, tenants, concurrency, baseline)
+		}
+	}
+
+	drifted := 0
+	for _, res := range results {
+		t := res.Tenant
+		switch {
+		case res.Err != nil:
+			drifted++
+			fmt.Printf("  ✗ %s (%s): %v\n", t.Slug, t.DBName, res.Err)
+		case res.Drifted():
+			drifted++
+			fmt.Printf("  ✗ %s (%s): drift\n", t.Slug, t.DBName)
+			for _, issue := range res.Issues() {
+				fmt.Printf("      %s\n", issue)
+			}
+		default:
+			fmt.Printf("  ✓ %s (%s): schema_version=%d\n", t.Slug, t.DBName, res.State.AppliedVersion)
+		}
+	}
+	if drifted > 0 {
+		fmt.Printf("%d of %d tenant(s) drifted or could not be verified\n", drifted, len(results))
+		if !repair {
+			fmt.Println("  Run with --repair to re-apply missing migrations.")
+		}
+		os.Exit(1)
+	}
+}
+
+// promoteTenant assigns a tenant to a version group (cloud mode).
+// Usage: tenant promote --id <uuid> --to <version_group>
+func promoteTenant(ctx context.Context) {
+	var targetID, targetGroup string
+
+	for i := 2; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case "--id":
+			if i+1 < len(os.Args) {
+				targetID = os.Args[i+1]
+				i++
+			}
+		case "--to":
+			if i+1 < len(os.Args) {
+				targetGroup = os.Args[i+1]
+				i++
+			}
+		}
+	}
+
+	if targetID == "" || targetGroup == "" {
+		fmt.Println("Usage: tenant promote --id <tenant-uuid> --to <version_group>")
+		fmt.Println("Example: tenant promote --id 550e8400-... --to v1.3.0")
+		os.Exit(1)
+	}
+
+	metaPool := getMetaPool(ctx)
+	defer metaPool.Close()
+
+	registry := tenant.NewPostgresRegistry(metaPool)
+
+	// Verify tenant exists
+	t, err := registry.GetByID(ctx, targetID)
+	if err != nil {
+		fmt.Printf("Error: tenant '%s' not found: %v\n", targetID, err)
+		os.Exit(1)
+	}
+
+	oldGroup := t.VersionGroup
+	if oldGroup == "" {
+		oldGroup = "(none)"
+	}
+
+	if err := registry.UpdateVersionGroup(ctx, targetID, targetGroup); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	recordAudit(ctx, metaPool, tenant.AuditEntry{
+		TenantID: targetID,
+		Action:   tenant.AuditVersionGroupChanged,
+		Before:   map[string]any{"versionGroup": t.VersionGroup},
+		After:    map[string]any{"versionGroup": targetGroup},
+	})
+
+	fmt.Printf("✓ Tenant '%s' (%s) promoted: %s → %s\n", t.Slug, targetID, oldGroup, targetGroup)
+	fmt.Println("  Note: requests for this tenant will now be served by the server instance")
+	fmt.Printf("  running with VERSION_GROUP=%s\n", targetGroup)
+}
+
+// moveTenant copies a tenant database to another region/cluster and switches
+// its placement. The tenant is put into "updating" status for the duration
+// of the copy; the source database is left untouched.
+// Usage: tenant move --id <uuid> --region <region> --host <db_host> [--port 5432] [--cluster <c>] [--yes]
+func moveTenant(ctx context.Context) {
+	var targetID string
+	target := tenant.Placement{DBPort: 5432}
+	var yes bool
+
+	for i := 2; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case "--id":
+			if i+1 < len(os.Args) {
+				targetID = os.Args[i+1]
+				i++
+			}
+		case "--region":
+			if i+1 < len(os.Args) {
+				target.Region = os.Args[i+1]
+				i++
+			}
+		case "--cluster":
+			if i+1 < len(os.Args) {
+				target.Cluster = os.Args[i+1]
+				i++
+			}
+		case "--host":
+			if i+1 < len(os.Args) {
+				target.DBHost = os.Args[i+1]
+				i++
+			}
+		case "--port":
+			if i+1 < len(os.Args) {
+				target.DBPort, _ = strconv.Atoi(os.Args[i+1])
+				i++
+			}
+		case "--yes", "-y":
+			yes = true
+		}
+	}
+
+	if targetID == "" {
+		fmt.Println("Usage: tenant move --id <tenant-uuid> --region <region> --host <db_host> [--port 5432] [--cluster <cluster>] [--yes]")
+		os.Exit(1)
+	}
+	if err := target.Validate(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	dbUser := os.Getenv("TENANT_DB_USER")
+	dbPassword := os.Getenv("TENANT_DB_PASSWORD")
+	if dbUser == "" || dbPassword == "" {
+		fmt.Println("Error: TENANT_DB_USER and TENANT_DB_PASSWORD are required")
+		os.Exit(1)
+	}
+
+	metaPool := getMetaPool(ctx)
+	defer metaPool.Close()
+
+	registry := tenant.NewPostgresRegistry(metaPool)
+
+	t, err := registry.GetByID(ctx, targetID)
+	if err != nil {
+		fmt.Printf("Error: tenant '%s' not found: %v\n", targetID, err)
+		os.Exit(1)
+	}
+	if t.Status != tenant.StatusActive {
+		fmt.Printf("Error: tenant status is %q (must be active)\n", t.Status)
+		os.Exit(1)
+	}
+
+	moved := *t
+	moved.DBHost, moved.DBPort = target.DBHost, target.DBPort
+	srcDSN := t.DSN(dbUser, dbPassword)
+	dstDSN := moved.DSN(dbUser, dbPassword)
+	copyNeeded := !migration.SameDatabase(srcDSN, dstDSN)
+
+	fmt.Printf("Moving tenant '%s' (%s)\n", t.Slug, t.ID)
+	fmt.Printf("  From: region=%s cluster=%s %s:%d/%s\n", orDash(t.Region), orDash(t.Cluster), t.DBHost, t.DBPort, t.DBName)
+	fmt.Printf("  To:   region=%s cluster=%s %s:%d/%s\n", target.Region, orDash(target.Cluster), target.DBHost, target.DBPort, t.DBName)
+	fmt.Println("  Steps:")
+	fmt.Println("    1. Set status to 'updating' (business requests are blocked)")
+	if copyNeeded {
+		fmt.Println("    2. Copy database with pg_dump | pg_restore (target database must not exist)")
+	} else {
+		fmt.Println("    2. Same database — no copy needed")
+	}
+	fmt.Println("    3. Switch placement in the meta database")
+	fmt.Println("    4. Set status back to 'active'")
+	fmt.Println("  The source database is not modified; drop it manually once the move is verified.")
+
+	if !yes {
+		fmt.Print("Proceed? [y/N]: ")
+		var answer string
+		_, _ = fmt.Scanln(&answer)
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			fmt.Println("Aborted")
+			return
+		}
+	}
+
+	fmt.Println("  [1/4] Setting status to updating...")
+	if err := registry.UpdateStatusByID(ctx, t.ID, tenant.StatusUpdating); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// reactivate restores the tenant at its old placement after a failed step.
+	reactivate := func(step string, err error) {
+		fmt.Printf("  ✗ %s failed: %v\n", step, err)
+		if serr := registry.UpdateStatusByID(ctx, t.ID, tenant.StatusActive); serr != nil {
+			fmt.Printf("  ⚠ Failed to restore active status: %v\n", serr)
+		} else {
+			fmt.Println("  Tenant re-activated at its old placement")
+		}
+		os.Exit(1)
+	}
+
+	if copyNeeded {
+		fmt.Println("  [2/4] Copying database...")
+		if err := migration.CopyDatabase(ctx, srcDSN, dstDSN); err != nil {
+			reactivate("Database copy", err)
+		}
+	} else {
+		fmt.Println("  [2/4] Skipping copy")
+	}
+
+	fmt.Println("  [3/4] Switching placement...")
+	if err := registry.UpdatePlacement(ctx, t.ID, target); err != nil {
+		reactivate("Placement switch", err)
+	}
+
+	fmt.Println("  [4/4] Activating...")
+	if err := registry.UpdateStatusByID(ctx, t.ID, tenant.StatusActive); err != nil {
+		fmt.Printf("Error: placement switched but failed to activate: %v\n", err)
+		os.Exit(1)
+	}
+
+	recordAudit(ctx, metaPool, tenant.AuditEntry{
+		TenantID: t.ID,
+		Action:   tenant.AuditMoved,
+		Before:   map[string]any{"region": t.Region, "cluster": t.Cluster, "dbHost": t.DBHost, "dbPort": t.DBPort},
+		After:    map[string]any{"region": target.Region, "cluster": target.Cluster, "dbHost": target.DBHost, "dbPort": target.DBPort},
+		Details:  map[string]any{"copied": copyNeeded},
+	})
+
+	fmt.Printf("✓ Tenant '%s' moved to region %s\n", t.Slug, target.Region)
+}
+
+// deleteTenant runs the staged tenant deletion workflow:
+//
+//  1. schedule: the tenant is suspended as pending_deletion for a grace
+//     period; the deletion can still be cancelled;
+//  2. purge (after the grace period): the database is archived with pg_dump,
+//     the archive verified, the database dropped and the registry record
+//     marked deleted.
+//
+// Both destructive steps require --confirm with the tenant's slug.
+// Usage: tenant delete <uuid> (--confirm <slug> [--grace <duration>] | --cancel | --purge --confirm <slug> [--dir <path>] [--yes])
+func deleteTenant(ctx context.Context) {
+	const usage = "Usage: tenant delete <tenant-uuid> (--confirm <slug> [--grace 720h] | --cancel | --purge --confirm <slug> [--dir <path>] [--yes])"
+	if len(os.Args) < 3 || strings.HasPrefix(os.Args[2], "-") {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+	tenantID := os.Args[2]
+	var confirm string
+	var cancel, purge, yes bool
+	grace := getEnvDefault("TENANT_DELETION_GRACE", "720h")
+	dir := getEnvDefault("TENANT_BACKUP_DIR", "./data/backups")
+
+	for i := 3; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case "--confirm":
+			if i+1 < len(os.Args) {
+				confirm = os.Args[i+1]
+				i++
+			}
+		case "--grace":
+			if i+1 < len(os.Args) {
+				grace = os.Args[i+1]
+				i++
+			}
+		case "--dir":
+			if i+1 < len(os.Args) {
+				dir = os.Args[i+1]
+				i++
+			}
+		case "--cancel":
+			cancel = true
+		case "--purge":
+			purge = true
+		case "--yes", "-y":
+			yes = true
+		}
+	}
+	if cancel && purge {
+		fmt.Println(usage)
+		os.Exit(1)
+	}
+
+	metaPool := getMetaPool(ctx)
+	defer metaPool.Close()
+
+	registry := tenant.NewPostgresRegistry(metaPool)
+	t, err := registry.GetByID(ctx, tenantID)
+	if err != nil {
+		fmt.Printf("Error: tenant '%s' not found: %v\n", tenantID, err)
+		os.Exit(1)
+	}
+	if !cancel && confirm != t.Slug {
+		fmt.Printf("Error: pass --confirm %s to confirm the deletion of this tenant\n", t.Slug)
+		os.Exit(1)
+	}
+
+	switch {
+	case cancel:
+		cancelTenantDeletion(ctx, metaPool, registry, t)
+	case purge:
+		purgeTenant(ctx, metaPool, registry, t, dir, yes)
+	default:
+		scheduleTenantDeletion(ctx, metaPool, registry, t, grace)
+	}
+}
+
+// scheduleTenantDeletion suspends the tenant as pending_deletion until the
+// grace period ends.
+func scheduleTenantDeletion(ctx context.Context, metaPool *pgxpool.Pool, registry *tenant.PostgresRegistry, t *tenant.Tenant, grace string) {
+	if t.Status != tenant.StatusActive && t.Status != tenant.StatusSuspended {
+		fmt.Printf("Error: tenant status is %q (must be active or suspended)\n", t.Status)
+		os.Exit(1)
+	}
+	d, err := time.ParseDuration(grace)
+	if err != nil || d < 0 {
+		fmt.Printf("Error: invalid grace period %q\n", grace)
+		os.Exit(1)
+	}
+	dueAt := time.Now().UTC().Add(d).Format(time.RFC3339)
+
+	if err := registry.MergeSettings(ctx, t.ID, map[string]any{tenant.SettingDeletionDueAt: dueAt}, nil); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := registry.UpdateStatusByID(ctx, t.ID, tenant.StatusPendingDeletion); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	recordAudit(ctx, metaPool, tenant.AuditEntry{
+		TenantID: t.ID,
+		Action:   tenant.AuditDeletionScheduled,
+		Before:   map[string]any{"status": string(t.Status)},
+		After:    map[string]any{"status": string(tenant.StatusPendingDeletion)},
+		Details:  map[string]any{"dueAt": dueAt},
+	})
+
+	fmt.Printf("✓ Tenant '%s' suspended and scheduled for deletion\n", t.Slug)
+	fmt.Printf("  Grace period ends: %s\n", dueAt)
+	fmt.Printf("  Cancel:   tenant delete %s --cancel\n", t.ID)
+	fmt.Printf("  Complete: tenant delete %s --purge --confirm %s (after the grace period)\n", t.ID, t.Slug)
+}
+
+// cancelTenantDeletion returns a pending_deletion tenant to suspended.
+func cancelTenantDeletion(ctx context.Context, metaPool *pgxpool.Pool, registry *tenant.PostgresRegistry, t *tenant.Tenant) {
+	if t.Status != tenant.StatusPendingDeletion {
+		fmt.Printf("Error: tenant status is %q (no deletion to cancel)\n", t.Status)
+		os.Exit(1)
+	}
+	if err := registry.MergeSettings(ctx, t.ID, nil, []string{tenant.SettingDeletionDueAt}); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := registry.UpdateStatusByID(ctx, t.ID, tenant.StatusSuspended); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	recordAudit(ctx, metaPool, tenant.AuditEntry{
+		TenantID: t.ID,
+		Action:   tenant.AuditDeletionCancelled,
+		Before:   map[string]any{"status": string(t.Status)},
+		After:    map[string]any{"status": string(tenant.StatusSuspended)},
+	})
+
+	fmt.Printf("✓ Deletion of tenant '%s' cancelled; the tenant stays suspended\n", t.Slug)
+	fmt.Printf("  Run 'tenant activate %s' to resume service.\n", t.ID)
+}
+
+// purgeTenant archives and drops the database of a pending_deletion tenant
+// whose grace period has ended, then marks the registry record deleted.
+// The database is only dropped after the archive has been verified.
+func purgeTenant(ctx context.Context, metaPool *pgxpool.Pool, registry *tenant.PostgresRegistry, t *tenant.Tenant, dir string, yes bool) {
+	if t.Status != tenant.StatusPendingDeletion {
+		fmt.Printf("Error: tenant status is %q (schedule the deletion first)\n", t.Status)
+		os.Exit(1)
+	}
+	dueAt, ok := t.DeletionDueAt()
+	if !ok {
+		fmt.Printf("Error: tenant has no valid %s setting; cancel and schedule the deletion again\n", tenant.SettingDeletionDueAt)
+		os.Exit(1)
+	}
+	if time.Now().Before(dueAt) {
+		fmt.Printf("Error: grace period ends %s\n", dueAt.Format(time.RFC3339))
+		os.Exit(1)
+	}
+
+	dbUser := os.Getenv("TENANT_DB_USER")
+	dbPassword := os.Getenv("TENANT_DB_PASSWORD")
+	if dbUser == "" || dbPassword == "" {
+		fmt.Println("Error: TENANT_DB_USER and TENANT_DB_PASSWORD are required")
+		os.Exit(1)
+	}
+	store := tenant.NewPostgresBackupStore(metaPool)
+	if err := store.EnsureTable(ctx); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Purging tenant '%s' (%s)\n", t.Slug, t.ID)
+	if t.SharedSchema() {
+		fmt.Printf("  Schema %s of shared database %s on %s:%d will be archived to %s and DROPPED.\n", t.DBSchema, t.DBName, t.DBHost, t.DBPort, dir)
+	} else {
+		fmt.Printf("  Database %s on %s:%d will be archived to %s and DROPPED.\n", t.DBName, t.DBHost, t.DBPort, dir)
+	}
+
+	if !yes {
+		fmt.Print("Proceed? [y/N]: ")
+		var answer string
+		_, _ = fmt.Scanln(&answer)
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			fmt.Println("Aborted")
+			return
+		}
+	}
+
+	dsn := t.DSN(dbUser, dbPassword)
+
+	fmt.Println("  [1/3] Archiving database...")
+	b, err := migration.BackupTenant(ctx, store, t, dsn, dir, tenant.BackupArchive)
+	if err != nil {
+		fmt.Printf("  ✗ Archive failed, database kept: %v\n", err)
+		os.Exit(1)
+	}
+	if err := migration.VerifyBackup(b); err != nil {
+		fmt.Printf("  ✗ Archive verification failed, database kept: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("  Archive: %s (%d bytes, SHA-256 %s)\n", b.FilePath, b.SizeBytes, b.Checksum)
+
+	fmt.Println("  [2/3] Dropping database...")
+	if err := migration.DropDatabase(ctx, dsn); err != nil {
+		fmt.Printf("  ✗ Drop failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("  [3/3] Marking tenant deleted...")
+	deletedAt := time.Now().UTC().Format(time.RFC3339)
+	set := map[string]any{tenant.SettingDeletedAt: deletedAt, tenant.SettingDeletionArchive: b.FilePath}
+	if err := registry.MergeSettings(ctx, t.ID, set, []string{tenant.SettingDeletionDueAt}); err != nil {
+		fmt.Printf("Error: database dropped but failed to update settings: %v\n", err)
+		os.Exit(1)
+	}
+	if err := registry.UpdateStatusByID(ctx, t.ID, tenant.StatusDeleted); err != nil {
+		fmt.Printf("Error: database dropped but failed to mark tenant deleted: %v\n", err)
+		os.Exit(1)
+	}
+	recordAudit(ctx, metaPool, tenant.AuditEntry{
+		TenantID: t.ID,
+		Action:   tenant.AuditDeleted,
+		Before:   map[string]any{"status": string(t.Status)},
+		After:    map[string]any{"status": string(tenant.StatusDeleted)},
+		Details:  map[string]any{"archive": b.FilePath, "checksum": b.Checksum, "dbName": t.DBName},
+	})
+
+	fmt.Printf("✓ Tenant '%s' deleted\n", t.Slug)
+	fmt.Printf("  Archive kept at %s\n", b.FilePath)
+}
+
+// listAudit prints the audit trail of admin actions, newest first.
+// Usage: tenant audit [--id <uuid>] [--action <action>] [--actor <actor>] [--limit <n>]
+func listAudit(ctx context.Context) {
+	var filter tenant.AuditFilter
+
+	for i := 2; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case "--id":
+			if i+1 < len(os.Args) {
+				filter.TenantID = os.Args[i+1]
+				i++
+			}
+		case "--action":
+			if i+1 < len(os.Args) {
+				filter.Action = os.Args[i+1]
+				i++
+			}
+		case "--actor":
+			if i+1 < len(os.Args) {
+				filter.Actor = os.Args[i+1]
+				i++
+			}
+		case "--limit":
+			if i+1 < len(os.Args) {
+				filter.Limit, _ = strconv.Atoi(os.Args[i+1])
+				i++
+			}
+		}
+	}
+
+	metaPool := getMetaPool(ctx)
+	defer metaPool.Close()
+
+	audit := tenant.NewPostgresAuditLog(metaPool)
+	if err := audit.EnsureTable(ctx); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	entries, err := audit.List(ctx, filter)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No audit entries found")
+		return
+	}
+
+	fmt.Printf("%-20s %-36s %-24s %-30s %s\n", "TIME", "TENANT_ID", "ACTION", "ACTOR", "CHANGE")
+	fmt.Println(strings.Repeat("-", 140))
+
+	for _, e := range entries {
+		var change []string
+		for _, k := range slices.Sorted(maps.Keys(e.After)) {
+			change = append(change, fmt.Sprintf("%s: %v → %v", k, e.Before[k], e.After[k]))
+		}
+		for _, k := range slices.Sorted(maps.Keys(e.Details)) {
+			change = append(change, fmt.Sprintf("%s=%v", k, e.Details[k]))
+		}
+		fmt.Printf("%-20s %-36s %-24s %-30s %s\n",
+			e.CreatedAt.UTC().Format("2006-01-02 15:04:05"),
+			orDash(e.TenantID),
+			e.Action,
+			truncate(e.Actor, 30),
+			strings.Join(change, ", "),
+		)
+	}
+}
+
+// recordAudit appends a CLI action to the tenant audit trail. The action has
+// already happened, so a failed write only prints a warning.
+func recordAudit(ctx context.Context, metaPool *pgxpool.Pool, e tenant.AuditEntry) {
+	audit := tenant.NewPostgresAuditLog(metaPool)
+	e.Actor = cliActor()
+	err := audit.EnsureTable(ctx)
+	if err == nil {
+		err = audit.Record(ctx, &e)
+	}
+	if err != nil {
+		fmt.Printf("  ⚠ Failed to record audit entry: %v\n", err)
+	}
+}
+
+// issueAdminToken prints a control-plane admin token signed with
+// ADMIN_JWT_SECRET. The operator (default: the OS user) is the audit actor
+// of everything done with the token.
+// Usage: tenant admin-token [--operator <name>] [--ttl <duration>]
+func issueAdminToken() {
+	secret := os.Getenv("ADMIN_JWT_SECRET")
+	if secret == "" {
+		fmt.Println("Error: ADMIN_JWT_SECRET is required")
+		os.Exit(1)
+	}
+
+	operator := strings.TrimPrefix(cliActor(), "cli:")
+	ttl := time.Hour
+	for i := 2; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case "--operator":
+			if i+1 < len(os.Args) {
+				operator = os.Args[i+1]
+				i++
+			}
+		case "--ttl":
+			if i+1 < len(os.Args) {
+				d, err := time.ParseDuration(os.Args[i+1])
+				if err != nil || d <= 0 {
+					fmt.Printf("Error: invalid --ttl %q\n", os.Args[i+1])
+					os.Exit(1)
+				}
+				ttl = d
+				i++
+			}
+		}
+	}
+
+	token, expiresAt, err := auth.NewAdminTokenService(secret).Issue(operator, ttl)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "Admin token for %q, expires %s\n", operator, expiresAt.Format(time.RFC3339))
+	fmt.Println(token)
+}
+
+// cliActor identifies the operating-system user running the CLI.
+func cliActor() string {
+	if u, err := user.Current(); err == nil {
+		return "cli:" + u.Username
+	}
+	return "cli:" + getEnvDefault("USER", "unknown")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
 }
 
 func truncate(s string, maxLen int) string {
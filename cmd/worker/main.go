@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -15,20 +17,50 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"metapus/internal/content"
+	"metapus/internal/core/apperror"
 	"metapus/internal/core/automation"
 	"metapus/internal/core/automation/adapters"
+	"metapus/internal/core/clock"
+	"metapus/internal/core/events"
 	"metapus/internal/core/id"
+	"metapus/internal/core/jobs"
 	"metapus/internal/core/tenant"
 	"metapus/internal/core/workerjob"
+	"metapus/internal/domain/analytics"
+	"metapus/internal/domain/artifact"
+	"metapus/internal/domain/attachment"
+	"metapus/internal/domain/auth"
+	"metapus/internal/domain/crypto"
+	"metapus/internal/domain/deltasync"
+	"metapus/internal/domain/housekeeping"
+	"metapus/internal/domain/modules"
+	"metapus/internal/domain/notifications"
+	"metapus/internal/domain/recurring"
 	"metapus/internal/domain/registers/exchange_rate"
+	"metapus/internal/domain/registers/stock"
 	"metapus/internal/domain/reports/compiler"
+	"metapus/internal/domain/reposting"
+	"metapus/internal/domain/search"
 	"metapus/internal/domain/settings"
+	"metapus/internal/domain/webhooks"
+	"metapus/internal/infrastructure/analyticssink"
+	"metapus/internal/infrastructure/blobstore"
+	"metapus/internal/infrastructure/cache"
 	"metapus/internal/infrastructure/crypto_worker"
+	"metapus/internal/infrastructure/eventsink"
+	v1 "metapus/internal/infrastructure/http/v1"
+	"metapus/internal/infrastructure/mail"
+	"metapus/internal/infrastructure/numerator"
 	"metapus/internal/infrastructure/rate_feed"
+	"metapus/internal/infrastructure/searchindex"
 	"metapus/internal/infrastructure/storage/postgres"
 	"metapus/internal/infrastructure/storage/postgres/auth_repo"
+	"metapus/internal/infrastructure/storage/postgres/catalog_repo"
+	"metapus/internal/infrastructure/storage/postgres/migration"
 	"metapus/internal/infrastructure/storage/postgres/register_repo"
+	"metapus/internal/infrastructure/telemetry"
 	ws "metapus/internal/infrastructure/websocket"
+	"metapus/internal/metadata"
 	"metapus/pkg/logger"
 )
 
@@ -56,6 +88,16 @@ func main() {
 		"build_time", BuildTime,
 	)
 
+	// --- ID generation strategy (uuidv7 | uuidv4 | ulid) ---
+	idStrategy, err := id.ParseStrategy(getEnv("ID_STRATEGY", ""))
+	if err != nil {
+		log.Fatalw("invalid ID_STRATEGY", "error", err)
+	}
+	if err := id.SetStrategy(idStrategy); err != nil {
+		log.Fatalw("failed to set ID strategy", "error", err)
+	}
+	log.Infow("id strategy configured", "strategy", idStrategy)
+
 	// Connect to meta-database
 	metaPool, err := pgxpool.New(ctx, mustEnv("META_DATABASE_URL"))
 	if err != nil {
@@ -64,13 +106,49 @@ func main() {
 	defer metaPool.Close()
 
 	// Create tenant registry and manager
-	registry := tenant.NewPostgresRegistry(metaPool)
+	// Cached in memory: the worker refresh lists all tenants every minute.
+	cachedRegistry := tenant.NewCachedRegistry(tenant.NewPostgresRegistry(metaPool), metaPool)
+	cachedRegistry.Start(ctx)
+	defer cachedRegistry.Stop()
+
+	// Last-known-good snapshot of active tenants, served while the meta DB is down.
+	fallbackCfg := tenant.DefaultFallbackConfig()
+	fallbackCfg.SnapshotPath = getEnv("TENANT_SNAPSHOT_PATH", "")
+	registry := tenant.NewFallbackRegistry(cachedRegistry, fallbackCfg)
+	registry.Start(ctx)
+	defer registry.Stop()
+
+	// --- Tracing (OpenTelemetry, OTLP/HTTP exporter) ---
+	tracingCfg := telemetry.ConfigFromEnv("metapus-worker", Version)
+	shutdownTracing, err := telemetry.Setup(ctx, tracingCfg)
+	if err != nil {
+		log.Fatalw("failed to set up tracing", "error", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			log.Warnw("failed to flush traces", "error", err)
+		}
+	}()
+	if tracingCfg.Enabled {
+		log.Infow("tracing enabled", "endpoint", tracingCfg.Endpoint, "sample_ratio", tracingCfg.SampleRatio)
+	}
 
 	managerCfg := tenant.DefaultManagerConfig()
+	managerCfg.QueryTracer = telemetry.NewQueryTracer()
 	managerCfg.DBUser = mustEnv("TENANT_DB_USER")
 	managerCfg.DBPassword = mustEnv("TENANT_DB_PASSWORD")
 	managerCfg.PoolIdleTimeout = 10 * time.Minute // Shorter for worker
 
+	if regions := getEnv("TENANT_REGIONS", ""); regions != "" {
+		parsed, err := tenant.ParseRegions(regions)
+		if err != nil {
+			log.Fatalw("invalid TENANT_REGIONS", "error", err)
+		}
+		managerCfg.Regions = parsed
+	}
+
 	// Cloud mode: restrict worker to process only tenants in this version group.
 	versionGroup := getEnv("VERSION_GROUP", "")
 	if versionGroup != "" {
@@ -81,13 +159,232 @@ func main() {
 	manager := tenant.NewManager(managerCfg, registry, log)
 	defer manager.Close()
 
+	// Scoped settings for tenant jobs (e.g. numbering of generated documents).
+	settingsResolver := settings.NewResolver(postgres.NewSettingValuesRepo())
+	settingsListener := cache.NewSettingsListener(manager, settingsResolver)
+	settingsListener.Start(ctx)
+	defer settingsListener.Stop()
+
+	// Module flags: jobs and recurring documents of disabled modules are skipped.
+	moduleResolver := modules.NewResolver(postgres.NewModuleFlagsRepo())
+	moduleListener := cache.NewModuleListener(manager, moduleResolver)
+	moduleListener.Start(ctx)
+	defer moduleListener.Stop()
+
+	// Recurring documents are created through the same document factories
+	// (hooks, numbering, posting) as the API.
+	numeratorSvc := numerator.New()
+	numeratorSvc.SetPersistRanges(getEnv("NUMERATOR_PERSIST_RANGES", "false") == "true")
+	factoryReg := v1.NewFactoryRegistry()
+	content.RegisterDefaults(factoryReg)
+	docCreator := v1.NewDocumentCreator(v1.DocumentCreatorConfig{
+		Registry:  factoryReg,
+		Numerator: numeratorSvc,
+		Modules:   moduleResolver,
+	})
+
+	// External search index (optional): the indexer applies search_index
+	// outbox messages for tenants with search.backend = opensearch.
+	var searchIndexer *search.Indexer
+	if osURL := getEnv("OPENSEARCH_URL", ""); osURL != "" {
+		osIndex, err := searchindex.NewOpenSearch(searchindex.OpenSearchConfig{
+			URL:         osURL,
+			Username:    getEnv("OPENSEARCH_USERNAME", ""),
+			Password:    getEnv("OPENSEARCH_PASSWORD", ""),
+			IndexPrefix: getEnv("OPENSEARCH_INDEX_PREFIX", "metapus"),
+		})
+		if err != nil {
+			log.Fatalw("failed to init opensearch index", "error", err)
+		}
+		searchSvc := search.NewService(v1.BuildMetadataRegistry(factoryReg))
+		searchIndexer = search.NewIndexer(searchSvc, osIndex)
+	}
+
+	// Artifact storage (same ARTIFACTS_* settings as the server): the worker
+	// removes expired report exports and backups from it.
+	var artifactStore artifact.BlobStore
+	switch backend := getEnv("ARTIFACTS_BACKEND", "local"); backend {
+	case "local":
+		localStore, err := blobstore.NewLocalStore(getEnv("ARTIFACTS_DIR", "./data/artifacts"))
+		if err != nil {
+			log.Fatalw("failed to init local artifact store", "error", err)
+		}
+		artifactStore = localStore
+	case "s3":
+		s3Store, err := blobstore.NewS3Store(blobstore.S3Config{
+			Endpoint:  getEnv("S3_ENDPOINT", ""),
+			Region:    getEnv("S3_REGION", "us-east-1"),
+			Bucket:    getEnv("ARTIFACTS_S3_BUCKET", getEnv("S3_BUCKET", "")),
+			AccessKey: getEnv("S3_ACCESS_KEY", ""),
+			SecretKey: getEnv("S3_SECRET_KEY", ""),
+		})
+		if err != nil {
+			log.Fatalw("failed to init s3 artifact store", "error", err)
+		}
+		artifactStore = s3Store
+	case "none":
+	default:
+		log.Fatalw("invalid ARTIFACTS_BACKEND", "backend", backend)
+	}
+
+	// Attachment storage (same ATTACHMENTS_* settings as the server): the
+	// worker removes files of expired upload sessions from it.
+	var attachmentStore attachment.BlobStore
+	switch backend := getEnv("ATTACHMENTS_BACKEND", "local"); backend {
+	case "local":
+		localStore, err := blobstore.NewLocalStore(getEnv("ATTACHMENTS_DIR", "./data/attachments"))
+		if err != nil {
+			log.Fatalw("failed to init local attachment store", "error", err)
+		}
+		attachmentStore = localStore
+	case "s3":
+		s3Store, err := blobstore.NewS3Store(blobstore.S3Config{
+			Endpoint:  getEnv("S3_ENDPOINT", ""),
+			Region:    getEnv("S3_REGION", "us-east-1"),
+			Bucket:    getEnv("S3_BUCKET", ""),
+			AccessKey: getEnv("S3_ACCESS_KEY", ""),
+			SecretKey: getEnv("S3_SECRET_KEY", ""),
+		})
+		if err != nil {
+			log.Fatalw("failed to init s3 attachment store", "error", err)
+		}
+		attachmentStore = s3Store
+	case "none":
+	default:
+		log.Fatalw("invalid ATTACHMENTS_BACKEND", "backend", backend)
+	}
+
+	// Product analytics (same ANALYTICS_* settings as the server): the worker
+	// reports daily document counts by type.
+	analyticsSink, err := analyticssink.Open(ctx, analyticssink.Config{
+		Kind: getEnv("ANALYTICS_SINK", analyticssink.KindNone),
+		HTTP: analyticssink.HTTPConfig{
+			URL:      getEnv("ANALYTICS_HTTP_URL", ""),
+			WriteKey: getEnv("ANALYTICS_WRITE_KEY", ""),
+		},
+	}, metaPool)
+	if err != nil {
+		log.Fatalw("failed to init analytics sink", "error", err)
+	}
+	var analyticsEmitter *analytics.Emitter
+	if analyticsSink != nil {
+		analyticsEmitter = analytics.NewEmitter(analyticsSink, []byte(mustEnv("ANALYTICS_SALT")), log)
+	}
+
+	// Scheduled tenant backups (optional): every TENANT_BACKUP_INTERVAL each
+	// tenant database is dumped into TENANT_BACKUP_DIR and registered in the
+	// meta database; the newest TENANT_BACKUP_KEEP scheduled backups are kept.
+	var backupScheduler *migration.BackupScheduler
+	if v := getEnv("TENANT_BACKUP_INTERVAL", ""); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			log.Fatalw("invalid TENANT_BACKUP_INTERVAL", "value", v)
+		}
+		keep, err := strconv.Atoi(getEnv("TENANT_BACKUP_KEEP", "7"))
+		if err != nil || keep < 0 {
+			log.Fatalw("invalid TENANT_BACKUP_KEEP", "value", getEnv("TENANT_BACKUP_KEEP", ""))
+		}
+		backupStore := tenant.NewPostgresBackupStore(metaPool)
+		if err := backupStore.EnsureTable(ctx); err != nil {
+			log.Fatalw("failed to init tenant backup store", "error", err)
+		}
+		backupScheduler = migration.NewBackupScheduler(backupStore, getEnv("TENANT_BACKUP_DIR", "./data/backups"), interval, keep)
+		log.Infow("scheduled tenant backups enabled", "interval", interval, "keep", keep)
+	}
+
+	// Tenant data exports queued with `tenant export --async` are written
+	// into TENANT_EXPORT_DIR; progress is reported in the meta database.
+	exportStore := tenant.NewPostgresExportStore(metaPool)
+	if err := exportStore.EnsureTable(ctx); err != nil {
+		log.Fatalw("failed to init tenant export store", "error", err)
+	}
+	exportRunner := migration.NewExportRunner(exportStore, manager.GetRegistry(), manager.TenantDSN,
+		getEnv("TENANT_EXPORT_DIR", "./data/exports"))
+
+	// Background job queue (sys_jobs): domain modules register the handlers
+	// of the job types they enqueue here.
+	jobRegistry := jobs.NewRegistry()
+
+	// Notification emails: rendered in the tenant language and sent through
+	// the tenant's email provider, or the platform SMTP sender as fallback.
+	var platformMailer auth.Mailer
+	if smtpCfg, ok := mail.SMTPConfigFromEnv(); ok {
+		platformMailer = mail.NewSMTPMailer(smtpCfg)
+	}
+	emailSender, err := notifications.NewEmailSender(mail.NewTenantMailer(postgres.NewSettingsRepo(), platformMailer), postgres.NewSettingsRepo())
+	if err != nil {
+		log.Fatalw("failed to load email templates", "error", err)
+	}
+	emailSender.Register(jobRegistry)
+
+	// Document re-posting: runs queued from /system/repost-runs or the tenant
+	// CLI re-post documents through the same factories as recurring documents.
+	repostService := reposting.NewService(postgres.NewRepostRunRepo(),
+		postgres.NewRepostDocumentSource(v1.BuildMetadataRegistry(factoryReg)), jobs.NewQueue(postgres.NewJobRepo()))
+	repostService.Register(jobRegistry, docCreator)
+
+	// Stock balance rebuilds queued from /registers/stock/balances/recalculate
+	// (async) or by a job schedule.
+	stock.NewRecalculationService(register_repo.NewStockRepo()).Register(jobRegistry)
+
+	// Domain event sinks: EVENT_SINKS lists the sinks domain events of the
+	// outbox are delivered to, each optionally limited to event types,
+	// e.g. "log:document.*|user.registered,kafka:stock.moved". Broker topics
+	// (Kafka) and subjects (NATS) are named per tenant by EVENT_TOPIC_TEMPLATE.
+	topicTemplate := getEnv("EVENT_TOPIC_TEMPLATE", eventsink.DefaultTopicTemplate)
+	eventSinks, err := buildEventSinks(getEnv("EVENT_SINKS", ""), eventsink.Config{
+		Kafka: eventsink.KafkaConfig{
+			Brokers:       getEnvList("KAFKA_BROKERS"),
+			Username:      getEnv("KAFKA_USERNAME", ""),
+			Password:      getEnv("KAFKA_PASSWORD", ""),
+			TLS:           getEnv("KAFKA_TLS", "false") == "true",
+			TopicTemplate: topicTemplate,
+		},
+		NATS: eventsink.NATSConfig{
+			URL:             getEnv("NATS_URL", ""),
+			Token:           getEnv("NATS_TOKEN", ""),
+			SubjectTemplate: topicTemplate,
+			JetStream:       getEnv("NATS_JETSTREAM", "true") == "true",
+		},
+	})
+	if err != nil {
+		log.Fatalw("invalid EVENT_SINKS", "error", err)
+	}
+
 	// Start multi-tenant worker
-	worker := NewMultiTenantWorker(manager, log)
+	worker := NewMultiTenantWorker(manager, settingsResolver, docCreator, searchIndexer, artifactStore, log)
+	worker.jobs = jobRegistry
+	worker.events = eventSinks
+	worker.modules = moduleResolver
+	worker.analytics = analyticsEmitter
+	worker.documentTypes = analyticsDocumentTypes(factoryReg)
+	worker.housekeepingTypes = housekeepingDocumentTypes(factoryReg)
+	worker.backups = backupScheduler
+	worker.exports = exportRunner
+	// Low-stock alerts go to the holders of stock_level.alerts by email
+	// (APP_PUBLIC_URL makes the link absolute) and in-app.
+	userRepo := auth_repo.NewUserRepo()
+	worker.lowStock = stock.NewLevelService(register_repo.NewStockLevelRepo())
+	worker.lowStock.SetNotifier(&lowStockNotifier{
+		emails: notifications.NewEmails(jobs.NewQueue(postgres.NewJobRepo()), userRepo, getEnv("APP_PUBLIC_URL", "")),
+		inbox:  notifications.NewInbox(postgres.NewNotificationRepo(), userRepo),
+	})
+	if attachmentStore != nil {
+		worker.uploadSessions = attachment.NewSessionService(
+			attachment.NewService(postgres.NewAttachmentRepo(), attachmentStore, attachment.DefaultLimits()),
+			postgres.NewUploadSessionRepo(), nil)
+	}
 
 	var wg sync.WaitGroup
 	wg.Go(func() {
 		worker.Run(ctx)
 	})
+	wg.Go(func() {
+		worker.runExports(ctx)
+	})
+	wg.Go(func() {
+		analyticsEmitter.Run(ctx) // flushes once more on shutdown
+	})
 
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
@@ -98,19 +395,61 @@ func main() {
 	cancel()
 
 	wg.Wait()
+
+	// Return unused cached number ranges while tenant pools are still open.
+	releaseCtx, releaseCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer releaseCancel()
+	if err := numeratorSvc.ReleaseRanges(releaseCtx, numerator.ManagerPools(manager)); err != nil {
+		log.Warnw("failed to release numerator ranges", "error", err)
+	}
+
 	log.Info("worker stopped")
 }
 
 // MultiTenantWorker processes background jobs for all tenants.
 type MultiTenantWorker struct {
-	manager *tenant.Manager
-	log     *logger.Logger
+	manager       *tenant.Manager
+	settings      *settings.Resolver
+	modules       *modules.Resolver // nil runs module jobs unconditionally
+	docCreator    recurring.DocumentCreator
+	searchIndexer *search.Indexer    // nil when no external search index is configured
+	artifacts     artifact.BlobStore // nil when artifacts are disabled
+	log           *logger.Logger
+
+	// Product analytics: nil emitter disables the daily document counts.
+	analytics     *analytics.Emitter
+	documentTypes []analytics.DocumentType
+
+	// Document types checked for forgotten drafts by the housekeeping analyzer.
+	housekeepingTypes []housekeeping.DocumentType
+
+	// Raises low-stock alerts of balances below their minimum level.
+	lowStock *stock.LevelService
+
+	// Purges expired attachment upload sessions; nil when attachments are disabled.
+	uploadSessions *attachment.SessionService
+
+	// Makes scheduled tenant backups; nil when they are disabled.
+	backups *migration.BackupScheduler
+
+	// Runs queued tenant data exports.
+	exports *migration.ExportRunner
+
+	// Handlers of the background job queue; nil runs no queued jobs.
+	jobs *jobs.Registry
+
+	// Delivers domain events of the outbox to the configured sinks.
+	events *events.Dispatcher
 }
 
-func NewMultiTenantWorker(manager *tenant.Manager, log *logger.Logger) *MultiTenantWorker {
+func NewMultiTenantWorker(manager *tenant.Manager, resolver *settings.Resolver, docCreator recurring.DocumentCreator, searchIndexer *search.Indexer, artifacts artifact.BlobStore, log *logger.Logger) *MultiTenantWorker {
 	return &MultiTenantWorker{
-		manager: manager,
-		log:     log.WithComponent("worker"),
+		manager:       manager,
+		settings:      resolver,
+		docCreator:    docCreator,
+		searchIndexer: searchIndexer,
+		artifacts:     artifacts,
+		log:           log.WithComponent("worker"),
 	}
 }
 
@@ -151,8 +490,15 @@ func (w *MultiTenantWorker) refreshTenants(ctx context.Context, wg *sync.WaitGro
 		return
 	}
 
+	// Background jobs of read-only tenants pause: their workers stop and
+	// start again on the first refresh after the mode is switched off.
 	activeTenants := make(map[string]*tenant.Tenant, len(tenants))
+	readOnly := make(map[string]bool)
 	for _, t := range tenants {
+		if t.IsReadOnly() {
+			readOnly[t.ID] = true
+			continue
+		}
 		activeTenants[t.ID] = t
 	}
 
@@ -163,11 +509,18 @@ func (w *MultiTenantWorker) refreshTenants(ctx context.Context, wg *sync.WaitGro
 		if _, active := activeTenants[tenantID]; !active {
 			cancel()
 			delete(tenantContexts, tenantID)
-			w.log.Infow("stopped worker for inactive tenant", "tenant_id", tenantID)
+			if readOnly[tenantID] {
+				w.log.Infow("paused worker for read-only tenant", "tenant_id", tenantID)
+			} else {
+				w.log.Infow("stopped worker for inactive tenant", "tenant_id", tenantID)
+			}
 		}
 	}
 
 	for _, t := range tenants {
+		if readOnly[t.ID] {
+			continue
+		}
 		if _, exists := tenantContexts[t.ID]; !exists {
 			tenantCtx, tenantCancel := context.WithCancel(ctx)
 			tenantContexts[t.ID] = tenantCancel
@@ -211,7 +564,12 @@ func (w *MultiTenantWorker) runTenantWorker(ctx context.Context, t *tenant.Tenan
 		return
 	}
 
-	handler := &automationOutboxHandler{engine: engine, log: w.log}
+	// Webhook subscriptions: outbox events are fanned out into deliveries by
+	// the relay; due deliveries are sent (and retried) on the jobs tick.
+	webhookDispatcher := webhooks.NewDispatcher(postgres.NewWebhookDeliveryRepo(),
+		catalog_repo.NewWebhookSubscriptionRepo(), crypto.NewWebhookDispatcher(), postgres.WebhookBackoff())
+
+	handler := &automationOutboxHandler{engine: engine, searchIndexer: w.searchIndexer, events: w.events, webhooks: webhookDispatcher, log: w.log}
 	relay := postgres.NewOutboxRelay(mp.Pool(), 100, handler)
 
 	pollInterval := 500 * time.Millisecond
@@ -221,9 +579,35 @@ func (w *MultiTenantWorker) runTenantWorker(ctx context.Context, t *tenant.Tenan
 	cleanupTicker := time.NewTicker(1 * time.Hour)
 	defer cleanupTicker.Stop()
 
+	// Recurring documents: due schedules are checked once a minute.
+	recurringRunner := recurring.NewRunner(postgres.NewRecurringRepo(), postgres.NewDocTemplateRepo(), w.docCreator)
+	recurringTicker := time.NewTicker(1 * time.Minute)
+	defer recurringTicker.Stop()
+
+	// Background job queue: due schedules enqueue their jobs and due jobs
+	// are claimed every few seconds.
+	queueRepo := postgres.NewJobRepo()
+	jobScheduler := jobs.NewScheduler(postgres.NewJobScheduleRepo(), jobs.NewQueue(queueRepo))
+	var jobRunner *jobs.Runner
+	if w.jobs != nil {
+		jobRunner = jobs.NewRunner(queueRepo, w.jobs)
+	}
+	jobsTicker := time.NewTicker(2 * time.Second)
+	defer jobsTicker.Stop()
+
+	// Housekeeping: anomalies are checked on the hourly cleanup tick; the
+	// analyzer remembers what it reported, so admins are alerted once.
+	analyzer := housekeeping.NewAnalyzer(postgres.NewHousekeepingRepo(), postgres.NewNotificationRepo(), w.housekeepingTypes)
+
+	// Document counts are reported once a day, checked on the hourly cleanup tick.
+	var lastDocumentCounts time.Time
+
 	// Enrich context with Pool and TxManager so that repos can access them.
 	ctx = tenant.WithPool(ctx, mp.Pool())
 	ctx = tenant.WithTxManager(ctx, txManager)
+	ctx = tenant.WithTenant(ctx, t)
+	ctx = clock.WithClock(ctx, t.Clock())
+	ctx = settings.WithResolver(ctx, w.settings)
 
 	// subsWg tracks goroutines (scheduler, crypto processor) that use the
 	// tenant pool. We must wait for them to exit BEFORE defer mp.ReleaseRef()
@@ -247,7 +631,7 @@ func (w *MultiTenantWorker) runTenantWorker(ctx context.Context, t *tenant.Tenan
 			JobRecorder: recorder,
 		}, w.log)
 		subsWg.Go(func() {
-			cp.Start(ctx) // blocks until ctx is cancelled
+			w.runModuleJob(ctx, modules.Crypto, cp.Start) // blocks until ctx is cancelled
 		})
 	}
 
@@ -268,7 +652,7 @@ func (w *MultiTenantWorker) runTenantWorker(ctx context.Context, t *tenant.Tenan
 			}, rateSvc, recorder, w.log)
 
 			subsWg.Go(func() {
-				rfWorker.Start(ctx) // blocks until ctx is cancelled
+				w.runModuleJob(ctx, modules.Crypto, rfWorker.Start) // blocks until ctx is cancelled
 			})
 			w.log.Infow("rate feed started",
 				"tenant_id", t.ID,
@@ -281,6 +665,30 @@ func (w *MultiTenantWorker) runTenantWorker(ctx context.Context, t *tenant.Tenan
 		}
 	}
 
+	// ── Official Rates (CBR / ECB) ─────────────────────────────────────
+	// Optional: one feed per active rate source of type cbr/ecb.
+	if sources, err := rate_feed.LoadOfficialSources(ctx); err != nil {
+		w.log.Warnw("failed to load official rate sources, official rate feed disabled",
+			"tenant_id", t.ID, "error", err,
+		)
+	} else {
+		rateSvc := exchange_rate.NewService(register_repo.NewExchangeRateRepo())
+		for _, src := range sources {
+			ow, err := rate_feed.NewOfficialWorker(src, 0, rateSvc, recorder, w.log)
+			if err != nil {
+				w.log.Warnw("official rate feed skipped", "tenant_id", t.ID, "error", err)
+				continue
+			}
+			subsWg.Go(func() {
+				ow.Start(ctx) // blocks until ctx is cancelled
+			})
+			w.log.Infow("official rate feed started",
+				"tenant_id", t.ID,
+				"source", src.SourceType,
+			)
+		}
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -296,6 +704,16 @@ func (w *MultiTenantWorker) runTenantWorker(ctx context.Context, t *tenant.Tenan
 			recorder.RecordIfWork(ctx, "outbox.relay", "outbox", func(ctx context.Context) (int, error) {
 				return relay.ProcessBatch(ctx)
 			})
+		case <-recurringTicker.C:
+			mp.Touch()
+			recorder.RecordIfWork(ctx, "recurring.documents", "recurring", recurringRunner.RunDue)
+		case <-jobsTicker.C:
+			mp.Touch()
+			recorder.RecordIfWork(ctx, "jobs.schedule", "jobs", jobScheduler.RunDue)
+			if jobRunner != nil {
+				recorder.RecordIfWork(ctx, "jobs.run", "jobs", jobRunner.RunDue)
+			}
+			recorder.RecordIfWork(ctx, "webhooks.deliver", "webhooks", webhookDispatcher.DeliverDue)
 		case <-cleanupTicker.C:
 			mp.Touch()
 			// Recover outbox messages stuck in 'processing' (worker crash, OOM).
@@ -304,11 +722,12 @@ func (w *MultiTenantWorker) runTenantWorker(ctx context.Context, t *tenant.Tenan
 				stuck, err := relay.RecoverStuck(ctx, postgres.DefaultStuckTimeout())
 				return int(stuck), err
 			})
+			retention := w.loadRetention(ctx, t.ID)
 			recorder.Record(ctx, "cleanup.sessions", "cleanup", func(ctx context.Context) (int, error) {
-				return w.cleanupSessions(ctx, mp.Pool(), t.ID)
+				return w.cleanupSessions(ctx, mp.Pool(), t.ID, retention.RefreshTokenTTL())
 			})
 			recorder.Record(ctx, "cleanup.idempotency", "cleanup", func(ctx context.Context) (int, error) {
-				return w.cleanupIdempotency(ctx, mp.Pool(), t.ID)
+				return w.cleanupIdempotency(ctx, mp.Pool(), t.ID, retention.IdempotencyKeyTTL())
 			})
 			recorder.Record(ctx, "cleanup.automation_history", "cleanup", func(ctx context.Context) (int, error) {
 				return w.cleanupAutomationHistory(ctx, mp.Pool(), t.ID)
@@ -316,6 +735,24 @@ func (w *MultiTenantWorker) runTenantWorker(ctx context.Context, t *tenant.Tenan
 			recorder.Record(ctx, "cleanup.automation_files", "cleanup", func(ctx context.Context) (int, error) {
 				return w.cleanupAutomationFiles(ctx, mp.Pool(), t.ID)
 			})
+			recorder.Record(ctx, "cleanup.account_exports", "cleanup", func(ctx context.Context) (int, error) {
+				return w.cleanupAccountExports(ctx, mp.Pool(), t.ID)
+			})
+			if w.artifacts != nil {
+				recorder.Record(ctx, "cleanup.artifacts", "cleanup", func(ctx context.Context) (int, error) {
+					return w.cleanupArtifacts(ctx, mp.Pool(), t.ID, retention.ArtifactTTL())
+				})
+			}
+			if w.uploadSessions != nil {
+				recorder.Record(ctx, "cleanup.upload_sessions", "cleanup", w.uploadSessions.PurgeExpired)
+			}
+			if w.analytics != nil && time.Since(lastDocumentCounts) >= 24*time.Hour {
+				lastDocumentCounts = time.Now()
+				recorder.Record(ctx, "analytics.document_counts", "analytics", func(ctx context.Context) (int, error) {
+					return w.analytics.TrackDocumentCounts(ctx, postgres.NewAnalyticsRepo(), w.documentTypes)
+				})
+			}
+			recorder.Record(ctx, "housekeeping.anomalies", "housekeeping", analyzer.Run)
 			recorder.Record(ctx, "cleanup.notifications", "cleanup", func(ctx context.Context) (int, error) {
 				return w.cleanupNotifications(ctx, mp.Pool(), t.ID)
 			})
@@ -323,12 +760,105 @@ func (w *MultiTenantWorker) runTenantWorker(ctx context.Context, t *tenant.Tenan
 				n, err := jobRepo.CleanupOld(ctx, 7*24*time.Hour)
 				return int(n), err
 			})
+			// Finished queued jobs are kept for a week; dead ones stay until
+			// an administrator requeues them.
+			recorder.RecordIfWork(ctx, "cleanup.jobs", "cleanup", func(ctx context.Context) (int, error) {
+				n, err := postgres.NewJobRepo().PurgeFinished(ctx, time.Now().Add(-7*24*time.Hour))
+				return int(n), err
+			})
+			// Tombstones outlive the oldest sync token still accepted.
+			recorder.Record(ctx, "cleanup.sync_tombstones", "cleanup", func(ctx context.Context) (int, error) {
+				n, err := postgres.NewSyncRepo().PurgeTombstones(ctx, time.Now().Add(-deltasync.TombstoneRetention))
+				return int(n), err
+			})
+			if w.backups != nil {
+				recorder.RecordIfWork(ctx, "tenant.backup", "backup", func(ctx context.Context) (int, error) {
+					return w.backups.RunDue(ctx, t, w.manager.TenantDSN(t))
+				})
+			}
+			// Month-end stock snapshots for as-of-date balances: work only
+			// once a month has ended (back-dated postings update them in place).
+			recorder.RecordIfWork(ctx, "stock.snapshots", "stock", func(ctx context.Context) (int, error) {
+				return register_repo.NewStockRepo().RefreshSnapshots(ctx, time.Now())
+			})
+			// Low-stock alerts: work only when a balance newly fell below its
+			// minimum (each shortage is notified once).
+			recorder.RecordIfWork(ctx, "stock.low_stock_alerts", "stock", w.lowStock.CheckAlerts)
 			// Refresh scheduler jobs (picks up new/deactivated scheduled rules)
 			scheduler.Refresh(ctx)
 		}
 	}
 }
 
+// runExports runs queued tenant data exports every 30 seconds. Exports are
+// meta-level jobs: they run for any tenant status (offboarding tenants are
+// usually suspended), outside the per-tenant workers. Blocks until ctx is
+// cancelled.
+func (w *MultiTenantWorker) runExports(ctx context.Context) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		n, err := w.exports.RunPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			w.log.Errorw("tenant exports failed", "error", err)
+		} else if n > 0 {
+			w.log.Infow("tenant exports finished", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runModuleJob runs job while module is enabled for the tenant in ctx. The
+// flag is checked every minute: job is stopped when the module is switched
+// off and started again when it is switched on. Blocks until ctx is cancelled.
+func (w *MultiTenantWorker) runModuleJob(ctx context.Context, module string, job func(ctx context.Context)) {
+	if w.modules == nil {
+		job(ctx)
+		return
+	}
+
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	var stop context.CancelFunc
+	var done chan struct{}
+	stopJob := func() {
+		stop()
+		<-done
+		stop = nil
+	}
+	for {
+		enabled := w.modules.Enabled(ctx, module)
+		switch {
+		case enabled && stop == nil:
+			jobCtx, cancel := context.WithCancel(ctx)
+			stop, done = cancel, make(chan struct{})
+			go func(done chan struct{}) {
+				defer close(done)
+				job(jobCtx)
+			}(done)
+		case !enabled && stop != nil:
+			stopJob()
+			w.log.Infow("module disabled, job stopped", "tenant_id", tenant.GetTenantID(ctx), "module", module)
+		}
+
+		select {
+		case <-ctx.Done():
+			if stop != nil {
+				stopJob()
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // buildAutomationEngine creates a reusable Engine with all adapters.
 func (w *MultiTenantWorker) buildAutomationEngine() (*automation.Engine, error) {
 	ruleRepo := postgres.NewAutomationRuleRepo()
@@ -374,6 +904,30 @@ func (w *MultiTenantWorker) buildAutomationEngine() (*automation.Engine, error)
 	return engine, nil
 }
 
+// lowStockNotifier sends the low-stock alerts raised by the worker by email
+// and in-app, linking to the replenishment suggestions report.
+type lowStockNotifier struct {
+	emails *notifications.Emails
+	inbox  *notifications.Inbox
+}
+
+func (n *lowStockNotifier) NotifyLowStock(ctx context.Context, alerts []stock.LowStockAlert) error {
+	data := notifications.LowStockAlert{Link: "/reports/stock-replenishment"}
+	for _, a := range alerts {
+		data.Items = append(data.Items, notifications.LowStockItem{
+			Nomenclature: a.NomenclatureName,
+			Warehouse:    a.WarehouseName,
+			Quantity:     a.Quantity,
+			MinQuantity:  a.MinQuantity,
+		})
+	}
+	if err := n.inbox.LowStockAlert(ctx, stock.PermissionLowStockAlerts, data); err != nil {
+		return err
+	}
+	data.Link = n.emails.URL(data.Link)
+	return n.emails.LowStockAlert(ctx, stock.PermissionLowStockAlerts, data)
+}
+
 // settingsLoaderAdapter bridges postgres.SettingsRepo (Get) → automation.SettingsLoader (GetSettings).
 type settingsLoaderAdapter struct {
 	repo *postgres.SettingsRepo
@@ -384,11 +938,41 @@ func (a *settingsLoaderAdapter) GetSettings(ctx context.Context) (*settings.Sett
 }
 
 type automationOutboxHandler struct {
-	engine *automation.Engine
-	log    *logger.Logger
+	engine        *automation.Engine
+	searchIndexer *search.Indexer
+	events        *events.Dispatcher
+	webhooks      *webhooks.Dispatcher
+	log           *logger.Logger
 }
 
 func (h *automationOutboxHandler) Handle(ctx context.Context, msg *postgres.OutboxMessage) error {
+	// Search index updates are not automation events.
+	if msg.AggregateType == postgres.SearchIndexAggregate {
+		if h.searchIndexer == nil {
+			h.log.Warnw("search index message without configured index, dropped", "msg_id", msg.ID)
+			return nil
+		}
+		return h.searchIndexer.Handle(ctx, msg)
+	}
+
+	// Domain events go to the event sinks; without sinks they are dropped.
+	if msg.AggregateType == postgres.DomainEventAggregate {
+		if h.events == nil || h.events.Len() == 0 {
+			return nil
+		}
+		env, err := postgres.DecodeDomainEvent(msg)
+		if err != nil {
+			h.log.Errorw("failed to decode domain event", "error", err, "msg_id", msg.ID)
+			return err
+		}
+		// Sinks that received the event on an earlier attempt are skipped;
+		// the relay saves msg.Deliveries with the message.
+		if msg.Deliveries == nil {
+			msg.Deliveries = events.Deliveries{}
+		}
+		return h.events.Dispatch(ctx, env, msg.Deliveries)
+	}
+
 	var payload map[string]any
 	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
 		h.log.Errorw("failed to unmarshal outbox payload", "error", err, "msg_id", msg.ID)
@@ -413,14 +997,43 @@ func (h *automationOutboxHandler) Handle(ctx context.Context, msg *postgres.Outb
 		return nil
 	}
 
+	// Enqueue webhook deliveries before running automations: a fanout
+	// error retries the message, and Fanout skips subscriptions that
+	// already have a delivery of it.
+	if _, err := h.webhooks.Fanout(ctx, webhooks.Event{
+		OutboxID:   msg.ID,
+		Type:       msg.EventType,
+		Payload:    msg.Payload,
+		OccurredAt: msg.CreatedAt,
+	}); err != nil {
+		h.log.Errorw("failed to fan out webhook deliveries", "error", err, "msg_id", msg.ID)
+		return err
+	}
+
 	return h.engine.HandleEvent(ctx, msg.EventType, payload)
 }
 
-func (w *MultiTenantWorker) cleanupSessions(ctx context.Context, pool *pgxpool.Pool, tenantID string) (int, error) {
+// loadRetention reads the tenant's retention settings, falling back to defaults
+// so that a broken settings row never stops cleanup.
+func (w *MultiTenantWorker) loadRetention(ctx context.Context, tenantID string) settings.RetentionSettings {
+	s, err := postgres.NewSettingsRepo().Get(ctx)
+	if err != nil {
+		w.log.Warnw("failed to load retention settings, using defaults", "tenant_id", tenantID, "error", err)
+		return settings.DefaultRetention()
+	}
+	if err := s.Retention.Validate(); err != nil {
+		w.log.Warnw("invalid retention settings, using defaults", "tenant_id", tenantID, "error", err)
+		return settings.DefaultRetention()
+	}
+	return s.Retention
+}
+
+func (w *MultiTenantWorker) cleanupSessions(ctx context.Context, pool *pgxpool.Pool, tenantID string, retention time.Duration) (int, error) {
+	cutoff := time.Now().Add(-retention)
 	result, err := pool.Exec(ctx, `
 		DELETE FROM refresh_tokens 
-		WHERE expires_at < NOW() OR revoked = true
-	`)
+		WHERE expires_at < $1 OR revoked_at < $1
+	`, cutoff)
 	if err != nil {
 		return 0, err
 	}
@@ -431,11 +1044,12 @@ func (w *MultiTenantWorker) cleanupSessions(ctx context.Context, pool *pgxpool.P
 	return n, nil
 }
 
-func (w *MultiTenantWorker) cleanupIdempotency(ctx context.Context, pool *pgxpool.Pool, tenantID string) (int, error) {
+func (w *MultiTenantWorker) cleanupIdempotency(ctx context.Context, pool *pgxpool.Pool, tenantID string, retention time.Duration) (int, error) {
+	cutoff := time.Now().Add(-retention)
 	result, err := pool.Exec(ctx, `
 		DELETE FROM sys_idempotency 
-		WHERE created_at < NOW() - INTERVAL '24 hours'
-	`)
+		WHERE created_at < $1
+	`, cutoff)
 	if err != nil {
 		return 0, err
 	}
@@ -491,6 +1105,164 @@ func (w *MultiTenantWorker) cleanupAutomationFiles(ctx context.Context, pool *pg
 	return n, nil
 }
 
+func (w *MultiTenantWorker) cleanupAccountExports(ctx context.Context, pool *pgxpool.Pool, tenantID string) (int, error) {
+	result, err := pool.Exec(ctx, `
+		DELETE FROM sys_account_exports
+		WHERE expires_at < NOW()
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("cleanup account exports: %w", err)
+	}
+	n := int(result.RowsAffected())
+	if n > 0 {
+		w.log.Infow("cleaned up expired account exports", "tenant_id", tenantID, "count", n)
+	}
+	return n, nil
+}
+
+// buildEventSinks parses EVENT_SINKS: a comma-separated list of sinks, each
+// "name" or "name:pattern|pattern" to receive only some event types.
+// Names are log, kafka and nats (see eventsink.Open). An empty list
+// delivers domain events nowhere.
+func buildEventSinks(spec string, cfg eventsink.Config) (*events.Dispatcher, error) {
+	d := events.NewDispatcher()
+	for entry := range strings.SplitSeq(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, patterns, _ := strings.Cut(entry, ":")
+		sink, err := eventsink.Open(name, cfg)
+		if err != nil {
+			return nil, err
+		}
+		var types []string
+		if patterns != "" {
+			types = strings.Split(patterns, "|")
+		}
+		d.Subscribe(sink, types...)
+	}
+	return d, nil
+}
+
+// analyticsDocumentTypes lists the registered document types with their tables.
+func analyticsDocumentTypes(factoryReg *v1.FactoryRegistry) []analytics.DocumentType {
+	var types []analytics.DocumentType
+	for _, def := range v1.BuildMetadataRegistry(factoryReg).List() {
+		if def.Type == metadata.TypeDocument {
+			types = append(types, analytics.DocumentType{Key: def.Key, Table: def.TableName})
+		}
+	}
+	return types
+}
+
+// housekeepingDocumentTypes lists the registered document types with their
+// tables and frontend routes.
+func housekeepingDocumentTypes(factoryReg *v1.FactoryRegistry) []housekeeping.DocumentType {
+	var types []housekeeping.DocumentType
+	for _, def := range v1.BuildMetadataRegistry(factoryReg).List() {
+		if def.Type != metadata.TypeDocument {
+			continue
+		}
+		label := def.Presentation.Plural
+		if label == "" {
+			label = def.Name
+		}
+		dt := housekeeping.DocumentType{
+			Key: def.Key, Label: label, Table: def.TableName, RoutePrefix: def.RoutePrefix,
+		}
+		if totals := documentTotalColumns(def); len(totals) > 0 {
+			// Lines tables follow doc_{entityKey}_lines (e.g. doc_goods_receipt_lines).
+			dt.LinesTable = strings.TrimSuffix(def.TableName, "s") + "_lines"
+			dt.Totals = totals
+		}
+		types = append(types, dt)
+	}
+	return types
+}
+
+// documentTotalColumns returns the header totals of def kept from its lines
+// (header column → line column), found by the column names in
+// housekeeping.TotalColumns.
+func documentTotalColumns(def metadata.EntityDef) map[string]string {
+	lineCols := map[string]bool{}
+	for _, tp := range def.TableParts {
+		if tp.Name != "lines" {
+			continue
+		}
+		for _, c := range tp.Columns {
+			lineCols[c.Column] = true
+		}
+	}
+	totals := map[string]string{}
+	for _, f := range def.Fields {
+		if lineCol, ok := housekeeping.TotalColumns[f.Column]; ok && lineCols[lineCol] {
+			totals[f.Column] = lineCol
+		}
+	}
+	return totals
+}
+
+// _artifactCleanupBatch bounds the artifacts removed per tenant and run;
+// the rest is picked up by the next hourly run.
+const _artifactCleanupBatch = 500
+
+// cleanupArtifacts removes artifacts past their explicit expiry, or older than
+// the tenant retention when none was set. Blobs are deleted first; an artifact
+// whose blob cannot be deleted keeps its row and is retried next run.
+func (w *MultiTenantWorker) cleanupArtifacts(ctx context.Context, pool *pgxpool.Pool, tenantID string, retention time.Duration) (int, error) {
+	cutoff := time.Now().Add(-retention)
+	rows, err := pool.Query(ctx, `
+		SELECT id, storage_key FROM sys_artifacts
+		WHERE expires_at < NOW()
+		   OR (expires_at IS NULL AND created_at < $1)
+		ORDER BY created_at
+		LIMIT $2
+	`, cutoff, _artifactCleanupBatch)
+	if err != nil {
+		return 0, fmt.Errorf("cleanup artifacts: %w", err)
+	}
+	type expired struct {
+		id  id.ID
+		key string
+	}
+	var batch []expired
+	for rows.Next() {
+		var e expired
+		if err := rows.Scan(&e.id, &e.key); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("cleanup artifacts: scan: %w", err)
+		}
+		batch = append(batch, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("cleanup artifacts: %w", err)
+	}
+
+	ids := make([]id.ID, 0, len(batch))
+	for _, e := range batch {
+		if err := w.artifacts.Delete(ctx, e.key); err != nil && !apperror.IsNotFound(err) {
+			w.log.Warnw("failed to delete artifact blob", "tenant_id", tenantID, "key", e.key, "error", err)
+			continue
+		}
+		ids = append(ids, e.id)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result, err := pool.Exec(ctx, `DELETE FROM sys_artifacts WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return 0, fmt.Errorf("cleanup artifacts: %w", err)
+	}
+	n := int(result.RowsAffected())
+	if n > 0 {
+		w.log.Infow("cleaned up expired artifacts", "tenant_id", tenantID, "count", n)
+	}
+	return n, nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -498,6 +1270,17 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvList returns the comma-separated values of key, without blanks.
+func getEnvList(key string) []string {
+	var values []string
+	for value := range strings.SplitSeq(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func mustEnv(key string) string {
 	value := os.Getenv(key)
 	if value == "" {
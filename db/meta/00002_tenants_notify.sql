@@ -0,0 +1,21 @@
+-- +goose Up
+-- Notify registry caches (tenant.CachedRegistry) about tenant changes
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_tenants_changed()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('tenants_changed', COALESCE(NEW.id, OLD.id)::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS trigger_tenants_notify ON tenants;
+CREATE TRIGGER trigger_tenants_notify
+    AFTER INSERT OR UPDATE OR DELETE ON tenants
+    FOR EACH ROW
+    EXECUTE FUNCTION notify_tenants_changed();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trigger_tenants_notify ON tenants;
+DROP FUNCTION IF EXISTS notify_tenants_changed();
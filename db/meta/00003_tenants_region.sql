@@ -0,0 +1,10 @@
+-- +goose Up
+-- Tenant placement: region and DB cluster within the region
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS region VARCHAR(63) NOT NULL DEFAULT '';  -- Placement region (eu-central, us-east)
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS cluster VARCHAR(63) NOT NULL DEFAULT ''; -- DB cluster within the region
+CREATE INDEX IF NOT EXISTS idx_tenants_region ON tenants(region, cluster);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tenants_region;
+ALTER TABLE tenants DROP COLUMN IF EXISTS cluster;
+ALTER TABLE tenants DROP COLUMN IF EXISTS region;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Before/after values of audited admin actions (tenant audit trail)
+ALTER TABLE tenant_audit ADD COLUMN IF NOT EXISTS before_values JSONB;
+ALTER TABLE tenant_audit ADD COLUMN IF NOT EXISTS after_values JSONB;
+
+-- +goose Down
+ALTER TABLE tenant_audit DROP COLUMN IF EXISTS after_values;
+ALTER TABLE tenant_audit DROP COLUMN IF EXISTS before_values;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Shared-schema tenants: several tenants live in one database, each in its own
+-- schema. db_schema = '' keeps a tenant in its own database.
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS db_schema VARCHAR(63) NOT NULL DEFAULT '';
+ALTER TABLE tenants DROP CONSTRAINT IF EXISTS tenants_db_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_tenants_db_name_schema ON tenants (db_name, db_schema);
+
+-- +goose Down
+DROP INDEX IF EXISTS uq_tenants_db_name_schema;
+ALTER TABLE tenants ADD CONSTRAINT tenants_db_name_key UNIQUE (db_name);
+ALTER TABLE tenants DROP COLUMN IF EXISTS db_schema;
@@ -0,0 +1,11 @@
+// Package db holds the SQL schema of the tenant databases. The core
+// migrations are embedded, so the binaries migrate tenant databases without
+// a repository checkout or the goose CLI.
+package db
+
+import "embed"
+
+// Migrations holds the core tenant migrations under migrations/.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
@@ -0,0 +1,32 @@
+-- +goose Up
+-- Description: Full account exports (portable dump of tenant business data).
+-- Archives are stored inline and removed by the worker after expires_at.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+-- ── sys_account_exports ────────────────────────────────────────────────────
+CREATE TABLE sys_account_exports (
+    id              UUID          PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    status          VARCHAR(20)   NOT NULL DEFAULT 'pending',  -- pending | running | completed | failed
+    requested_by    VARCHAR(64),
+    file_name       VARCHAR(255),
+    file_data       BYTEA,
+    file_size       BIGINT        NOT NULL DEFAULT 0,
+    manifest        JSONB,
+    error_message   TEXT,
+    expires_at      TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    finished_at     TIMESTAMPTZ
+);
+
+CREATE INDEX idx_sys_account_exports_created ON sys_account_exports (created_at DESC);
+CREATE INDEX idx_sys_account_exports_expires ON sys_account_exports (expires_at) WHERE expires_at IS NOT NULL;
+
+COMMENT ON TABLE  sys_account_exports           IS 'Account export jobs; archive kept until expires_at';
+COMMENT ON COLUMN sys_account_exports.file_data IS 'Zip archive: manifest.json + data/<table>.ndjson';
+COMMENT ON COLUMN sys_account_exports.manifest  IS 'Copy of manifest.json for listing without loading the archive';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+DROP TABLE IF EXISTS sys_account_exports;
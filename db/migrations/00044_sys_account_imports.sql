@@ -0,0 +1,36 @@
+-- +goose Up
+-- Description: Account imports (load an account export archive into this tenant).
+-- Progress is committed per table together with the data, so a failed or
+-- interrupted import resumes from the first unfinished table.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+-- ── sys_account_imports ────────────────────────────────────────────────────
+CREATE TABLE sys_account_imports (
+    id                UUID          PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    status            VARCHAR(20)   NOT NULL DEFAULT 'validated',  -- validated | invalid | running | completed | failed
+    requested_by      VARCHAR(64),
+    source_tenant_id  VARCHAR(64),
+    file_data         BYTEA         NOT NULL,
+    file_size         BIGINT        NOT NULL DEFAULT 0,
+    manifest          JSONB         NOT NULL,
+    report            JSONB         NOT NULL DEFAULT '{}',
+    completed_tables  TEXT[]        NOT NULL DEFAULT '{}',
+    id_map            JSONB         NOT NULL DEFAULT '{}',
+    error_message     TEXT,
+    created_at        TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    started_at        TIMESTAMPTZ,
+    finished_at       TIMESTAMPTZ
+);
+
+CREATE INDEX idx_sys_account_imports_created ON sys_account_imports (created_at DESC);
+
+COMMENT ON TABLE  sys_account_imports                  IS 'Account import jobs (archives produced by sys_account_exports)';
+COMMENT ON COLUMN sys_account_imports.report           IS 'Validation report and per-table import results';
+COMMENT ON COLUMN sys_account_imports.completed_tables IS 'Tables already imported; skipped on resume';
+COMMENT ON COLUMN sys_account_imports.id_map           IS 'Archive ID -> existing ID for rows matched by natural key';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+DROP TABLE IF EXISTS sys_account_imports;
@@ -0,0 +1,149 @@
+-- +goose Up
+-- Description: Manual Adjustment document (Документ "Корректировка регистров")
+-- Arbitrary register movements entered by privileged users with a reason and
+-- a second user's approval. Movements carry recorder_type = 'ManualAdjustment'.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+-- ── Header ─────────────────────────────────────────────────────────────────
+CREATE TABLE doc_manual_adjustments (
+    -- Base fields
+    id            UUID        PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    deletion_mark BOOLEAN     NOT NULL DEFAULT FALSE,
+    version       INTEGER     NOT NULL DEFAULT 1,
+    attributes    JSONB       DEFAULT '{}',
+
+    -- CDC
+    _deleted_at TIMESTAMPTZ,
+    _txid       BIGINT DEFAULT txid_current(),
+
+    -- Audit fields
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by UUID        NOT NULL,
+    updated_by UUID        NOT NULL,
+
+    -- Document fields
+    number          VARCHAR(50)  NOT NULL,
+    date            TIMESTAMPTZ  NOT NULL,
+    posted          BOOLEAN      NOT NULL DEFAULT FALSE,
+    posted_version  INTEGER      NOT NULL DEFAULT 0,
+    organization_id UUID         NOT NULL REFERENCES cat_organizations(id),
+    description     TEXT         DEFAULT '',
+    basis_type      TEXT         NOT NULL DEFAULT '',
+    basis_id        UUID,
+
+    -- ManualAdjustment-specific fields
+    reason      TEXT        NOT NULL,
+    currency_id UUID        NOT NULL REFERENCES cat_currencies(id),
+    approved_by UUID        REFERENCES users(id),
+    approved_at TIMESTAMPTZ,
+
+    CONSTRAINT uq_manual_adjustment_number      UNIQUE (organization_id, number),
+    CONSTRAINT chk_manual_adjustment_reason     CHECK (btrim(reason) <> ''),
+    CONSTRAINT chk_manual_adjustment_approval   CHECK ((approved_by IS NULL) = (approved_at IS NULL)),
+    CONSTRAINT fk_manual_adjustments_created_by FOREIGN KEY (created_by) REFERENCES users(id),
+    CONSTRAINT fk_manual_adjustments_updated_by FOREIGN KEY (updated_by) REFERENCES users(id)
+);
+
+-- ── Lines ──────────────────────────────────────────────────────────────────
+CREATE TABLE doc_manual_adjustment_lines (
+    line_id     UUID    PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    document_id UUID    NOT NULL REFERENCES doc_manual_adjustments(id) ON DELETE CASCADE,
+    line_no     INTEGER NOT NULL,
+
+    register    VARCHAR(20) NOT NULL,
+    record_type VARCHAR(10) NOT NULL,
+
+    warehouse_id    UUID REFERENCES cat_warehouses(id),
+    nomenclature_id UUID REFERENCES cat_nomenclatures(id),
+    counterparty_id UUID REFERENCES cat_counterparties(id),
+    contract_id     UUID REFERENCES cat_contracts(id),
+
+    quantity BIGINT NOT NULL DEFAULT 0,
+    amount   BIGINT NOT NULL DEFAULT 0,
+
+    CONSTRAINT chk_ma_register        CHECK (register IN ('stock', 'cost', 'settlement')),
+    CONSTRAINT chk_ma_record_type     CHECK (record_type IN ('receipt', 'expense')),
+    CONSTRAINT chk_ma_quantity        CHECK (quantity >= 0),
+    CONSTRAINT chk_ma_amount          CHECK (amount >= 0),
+    CONSTRAINT chk_ma_stock_dims      CHECK (register = 'settlement' OR (warehouse_id IS NOT NULL AND nomenclature_id IS NOT NULL)),
+    CONSTRAINT chk_ma_settlement_dims CHECK (register <> 'settlement' OR counterparty_id IS NOT NULL),
+    CONSTRAINT uq_manual_adjustment_line UNIQUE (document_id, line_no)
+);
+
+-- Header indexes
+CREATE INDEX idx_manual_adjustments_date        ON doc_manual_adjustments (date DESC);
+CREATE INDEX idx_manual_adjustments_currency_id ON doc_manual_adjustments (currency_id);
+CREATE INDEX idx_manual_adjustments_posted      ON doc_manual_adjustments (posted) WHERE posted = FALSE;
+CREATE INDEX idx_manual_adjustments_created_by  ON doc_manual_adjustments (created_by);
+CREATE INDEX idx_manual_adjustments_updated_by  ON doc_manual_adjustments (updated_by);
+CREATE INDEX idx_manual_adjustments_created_at  ON doc_manual_adjustments (created_at DESC);
+CREATE INDEX idx_manual_adjustments_number_trgm ON doc_manual_adjustments USING gin (number gin_trgm_ops);
+CREATE INDEX idx_manual_adjustments_basis
+    ON doc_manual_adjustments (basis_type, basis_id)
+    WHERE basis_id IS NOT NULL;
+
+-- CDC indexes & triggers
+CREATE INDEX idx_doc_manual_adjustments_txid ON doc_manual_adjustments (_txid) WHERE _deleted_at IS NULL;
+
+CREATE TRIGGER trg_doc_manual_adjustments_txid
+    BEFORE UPDATE ON doc_manual_adjustments
+    FOR EACH ROW EXECUTE FUNCTION update_txid_column();
+
+CREATE TRIGGER trg_doc_manual_adjustments_soft_delete
+    BEFORE UPDATE OF deletion_mark ON doc_manual_adjustments
+    FOR EACH ROW EXECUTE FUNCTION soft_delete_with_timestamp();
+
+-- Line indexes
+CREATE INDEX idx_manual_adjustment_lines_doc          ON doc_manual_adjustment_lines (document_id);
+CREATE INDEX idx_manual_adjustment_lines_nomenclature ON doc_manual_adjustment_lines (nomenclature_id) WHERE nomenclature_id IS NOT NULL;
+CREATE INDEX idx_manual_adjustment_lines_counterparty ON doc_manual_adjustment_lines (counterparty_id) WHERE counterparty_id IS NOT NULL;
+
+-- Keyset pagination
+CREATE INDEX idx_doc_manual_adjustments_date_id    ON doc_manual_adjustments (date DESC, id DESC);
+CREATE INDEX idx_doc_manual_adjustments_created_id ON doc_manual_adjustments (created_at DESC, id DESC);
+
+COMMENT ON TABLE doc_manual_adjustments IS 'Документ Корректировка регистров (ручные движения)';
+COMMENT ON TABLE doc_manual_adjustment_lines IS 'Табличная часть Движения документа Корректировка регистров';
+COMMENT ON COLUMN doc_manual_adjustments.reason IS 'Основание корректировки (обязательно)';
+COMMENT ON COLUMN doc_manual_adjustments.approved_by IS 'Утвердивший пользователь (не автор документа); сбрасывается при изменении';
+COMMENT ON COLUMN doc_manual_adjustment_lines.register IS 'Регистр: stock | cost | settlement';
+
+-- ── Permissions ────────────────────────────────────────────────────────────
+INSERT INTO permissions (code, name, description, resource, action) VALUES
+    ('manual_adjustment.read',    'Чтение корректировок регистров',     'View manual adjustments', 'manual_adjustment', 'read'),
+    ('manual_adjustment.create',  'Создание корректировок регистров',   'Create manual adjustments', 'manual_adjustment', 'create'),
+    ('manual_adjustment.update',  'Изменение корректировок регистров',  'Update manual adjustments', 'manual_adjustment', 'update'),
+    ('manual_adjustment.delete',  'Удаление корректировок регистров',   'Delete manual adjustments', 'manual_adjustment', 'delete'),
+    ('manual_adjustment.post',    'Проведение корректировок регистров', 'Post manual adjustments', 'manual_adjustment', 'post'),
+    ('manual_adjustment.unpost',  'Отмена проведения корректировок',    'Unpost manual adjustments', 'manual_adjustment', 'unpost'),
+    ('manual_adjustment.approve', 'Утверждение корректировок регистров', 'Approve manual adjustments', 'manual_adjustment', 'approve')
+ON CONFLICT (code) DO NOTHING;
+
+-- Grant all manual adjustment permissions to Admin role
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'b0000000-0000-0000-0000-000000000001', id FROM permissions
+WHERE resource = 'manual_adjustment'
+ON CONFLICT DO NOTHING;
+
+-- Accountant may prepare and view adjustments; approval and posting stay with Admin
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'b0000000-0000-0000-0000-000000000002', id FROM permissions
+WHERE resource = 'manual_adjustment' AND action IN ('read', 'create', 'update')
+ON CONFLICT DO NOTHING;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DELETE FROM role_permissions WHERE permission_id IN (SELECT id FROM permissions WHERE resource = 'manual_adjustment');
+DELETE FROM permissions WHERE resource = 'manual_adjustment';
+
+DROP TRIGGER IF EXISTS trg_doc_manual_adjustments_soft_delete ON doc_manual_adjustments;
+DROP TRIGGER IF EXISTS trg_doc_manual_adjustments_txid ON doc_manual_adjustments;
+DROP TABLE IF EXISTS doc_manual_adjustment_lines;
+DROP TABLE IF EXISTS doc_manual_adjustments;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
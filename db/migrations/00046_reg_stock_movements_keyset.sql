@@ -0,0 +1,22 @@
+-- +goose Up
+-- Description: Keyset index for stock movement history
+-- GetMovementHistory pages by (period, created_at, line_id) DESC per nomenclature.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE INDEX idx_reg_stock_movements_history_keyset
+    ON reg_stock_movements (nomenclature_id, period DESC, created_at DESC, line_id DESC);
+
+-- Superseded by the keyset index (same leading columns)
+DROP INDEX IF EXISTS idx_reg_stock_movements_product;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE INDEX IF NOT EXISTS idx_reg_stock_movements_product
+    ON reg_stock_movements (nomenclature_id, period DESC);
+DROP INDEX IF EXISTS idx_reg_stock_movements_history_keyset;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Description: Per-tenant retention for transient records (worker cleanup)
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+ALTER TABLE sys_settings
+    ADD COLUMN retention JSONB NOT NULL DEFAULT '{"idempotencyKeyHours": 24, "refreshTokenDays": 7}';
+
+COMMENT ON COLUMN sys_settings.retention IS 'Worker cleanup retention: idempotencyKeyHours, refreshTokenDays';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+ALTER TABLE sys_settings DROP COLUMN IF EXISTS retention;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
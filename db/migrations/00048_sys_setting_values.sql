@@ -0,0 +1,43 @@
+-- +goose Up
+-- Description: Scoped setting values (user → organization → tenant) with change notifications
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE sys_setting_values (
+    key        VARCHAR(100) NOT NULL,
+    scope      VARCHAR(20)  NOT NULL CHECK (scope IN ('user', 'organization', 'tenant')),
+    -- Nil UUID for scope = 'tenant' (keeps the primary key non-nullable)
+    scope_id   UUID         NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000',
+    value      JSONB        NOT NULL,
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_by UUID,
+
+    PRIMARY KEY (key, scope, scope_id)
+);
+
+COMMENT ON TABLE sys_setting_values IS 'Scoped overrides of typed setting keys; resolved user → organization → tenant → default';
+
+-- Application nodes cache setting values and drop the cache on notification.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_setting_values_changed()
+RETURNS TRIGGER AS $func$
+BEGIN
+    PERFORM pg_notify('setting_values_changed', COALESCE(NEW.key, OLD.key));
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_sys_setting_values_notify
+    AFTER INSERT OR UPDATE OR DELETE ON sys_setting_values
+    FOR EACH ROW EXECUTE FUNCTION notify_setting_values_changed();
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DROP TABLE IF EXISTS sys_setting_values;
+DROP FUNCTION IF EXISTS notify_setting_values_changed();
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
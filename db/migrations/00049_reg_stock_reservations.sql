@@ -0,0 +1,266 @@
+-- +goose Up
+-- Description: Stock reservation register (Регистр накопления "Резервы товаров") and
+-- Sales Order document (Документ "Заказ покупателя").
+-- A posted sales order reserves goods; a goods issue based on the order releases
+-- the reservation. Available stock = reg_stock_balances - reserved quantity.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+-- ── Movements ──────────────────────────────────────────────────────────────
+CREATE TABLE reg_stock_reservation_movements (
+    line_id          UUID         PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    recorder_id      UUID         NOT NULL,
+    recorder_type    VARCHAR(50)  NOT NULL,
+    recorder_version INT          NOT NULL DEFAULT 1,
+    period           TIMESTAMPTZ  NOT NULL,
+    record_type      VARCHAR(10)  NOT NULL,
+    order_id         UUID         NOT NULL,
+    warehouse_id     UUID         NOT NULL,
+    nomenclature_id  UUID         NOT NULL,
+    quantity         BIGINT       NOT NULL,
+    created_at       TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_stock_reservation_record_type       CHECK (record_type IN ('receipt', 'expense')),
+    CONSTRAINT chk_stock_reservation_quantity_positive CHECK (quantity > 0)
+);
+
+COMMENT ON TABLE reg_stock_reservation_movements IS 'Регистр резервов товаров — движения';
+COMMENT ON COLUMN reg_stock_reservation_movements.order_id IS 'Sales order the goods are reserved for';
+COMMENT ON COLUMN reg_stock_reservation_movements.record_type IS 'receipt = reserve, expense = release (shipment)';
+
+CREATE INDEX idx_reg_stock_reservation_movements_recorder
+    ON reg_stock_reservation_movements (recorder_id, recorder_version);
+CREATE INDEX idx_reg_stock_reservation_movements_order
+    ON reg_stock_reservation_movements (order_id);
+
+-- ── Balances ───────────────────────────────────────────────────────────────
+CREATE TABLE reg_stock_reservation_balances (
+    order_id         UUID        NOT NULL,
+    warehouse_id     UUID        NOT NULL,
+    nomenclature_id  UUID        NOT NULL,
+    quantity         BIGINT      NOT NULL DEFAULT 0,
+    last_movement_at TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (order_id, warehouse_id, nomenclature_id)
+);
+
+COMMENT ON TABLE reg_stock_reservation_balances IS 'Регистр резервов товаров — текущие резервы по заказам';
+
+-- Availability lookups sum reservations of all orders per warehouse+product.
+CREATE INDEX idx_reg_stock_reservation_balances_stock
+    ON reg_stock_reservation_balances (warehouse_id, nomenclature_id) WHERE quantity > 0;
+
+-- ── Statement-level balance triggers (same scheme as reg_stock, see 00021) ──
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_stock_reservation_balance_on_insert()
+RETURNS TRIGGER AS $func$
+BEGIN
+    INSERT INTO reg_stock_reservation_balances (order_id, warehouse_id, nomenclature_id, quantity, last_movement_at, updated_at)
+    SELECT
+        order_id,
+        warehouse_id,
+        nomenclature_id,
+        SUM(CASE WHEN record_type = 'receipt' THEN quantity ELSE -quantity END),
+        MAX(period),
+        NOW()
+    FROM new_rows
+    GROUP BY order_id, warehouse_id, nomenclature_id
+    ON CONFLICT (order_id, warehouse_id, nomenclature_id) DO UPDATE SET
+        quantity = reg_stock_reservation_balances.quantity + EXCLUDED.quantity,
+        last_movement_at = GREATEST(reg_stock_reservation_balances.last_movement_at, EXCLUDED.last_movement_at),
+        updated_at = NOW();
+
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_stock_reservation_movements_balance_insert
+    AFTER INSERT ON reg_stock_reservation_movements
+    REFERENCING NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION update_stock_reservation_balance_on_insert();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_stock_reservation_balance_on_delete()
+RETURNS TRIGGER AS $func$
+BEGIN
+    INSERT INTO reg_stock_reservation_balances (order_id, warehouse_id, nomenclature_id, quantity, last_movement_at, updated_at)
+    SELECT
+        order_id,
+        warehouse_id,
+        nomenclature_id,
+        SUM(CASE WHEN record_type = 'receipt' THEN -quantity ELSE quantity END),
+        NOW(),
+        NOW()
+    FROM old_rows
+    GROUP BY order_id, warehouse_id, nomenclature_id
+    ON CONFLICT (order_id, warehouse_id, nomenclature_id) DO UPDATE SET
+        quantity = reg_stock_reservation_balances.quantity + EXCLUDED.quantity,
+        updated_at = NOW();
+
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_stock_reservation_movements_balance_delete
+    AFTER DELETE ON reg_stock_reservation_movements
+    REFERENCING OLD TABLE AS old_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION update_stock_reservation_balance_on_delete();
+
+-- ── Sales Order: header ────────────────────────────────────────────────────
+CREATE TABLE doc_sales_orders (
+    -- Base fields
+    id            UUID        PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    deletion_mark BOOLEAN     NOT NULL DEFAULT FALSE,
+    version       INTEGER     NOT NULL DEFAULT 1,
+    attributes    JSONB       DEFAULT '{}',
+
+    -- CDC
+    _deleted_at TIMESTAMPTZ,
+    _txid       BIGINT DEFAULT txid_current(),
+
+    -- Audit fields
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by UUID        NOT NULL,
+    updated_by UUID        NOT NULL,
+
+    -- Document fields
+    number          VARCHAR(50)  NOT NULL,
+    date            TIMESTAMPTZ  NOT NULL,
+    posted          BOOLEAN      NOT NULL DEFAULT FALSE,
+    posted_version  INTEGER      NOT NULL DEFAULT 0,
+    organization_id UUID         NOT NULL REFERENCES cat_organizations(id),
+    description     TEXT         DEFAULT '',
+    basis_type      TEXT         NOT NULL DEFAULT '',
+    basis_id        UUID,
+
+    -- SalesOrder-specific fields
+    counterparty_id UUID NOT NULL REFERENCES cat_counterparties(id),
+    contract_id     UUID REFERENCES cat_contracts(id),
+    warehouse_id    UUID NOT NULL REFERENCES cat_warehouses(id),
+    shipment_date   TIMESTAMPTZ,
+
+    -- Currency and totals
+    currency_id         UUID    NOT NULL REFERENCES cat_currencies(id),
+    amount_includes_vat BOOLEAN NOT NULL DEFAULT FALSE,
+    total_quantity      BIGINT  NOT NULL DEFAULT 0,
+    total_amount        BIGINT  NOT NULL DEFAULT 0,
+    total_vat           BIGINT  NOT NULL DEFAULT 0,
+
+    CONSTRAINT uq_sales_order_number      UNIQUE (organization_id, number),
+    CONSTRAINT fk_sales_orders_created_by FOREIGN KEY (created_by) REFERENCES users(id),
+    CONSTRAINT fk_sales_orders_updated_by FOREIGN KEY (updated_by) REFERENCES users(id)
+);
+
+-- ── Sales Order: lines ─────────────────────────────────────────────────────
+CREATE TABLE doc_sales_order_lines (
+    line_id     UUID    PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    document_id UUID    NOT NULL REFERENCES doc_sales_orders(id) ON DELETE CASCADE,
+    line_no     INTEGER NOT NULL,
+
+    nomenclature_id UUID NOT NULL REFERENCES cat_nomenclatures(id),
+    unit_id         UUID,
+    coefficient     NUMERIC(15,6) NOT NULL DEFAULT 1,
+
+    quantity         BIGINT       NOT NULL,
+    unit_price       BIGINT       NOT NULL,
+    discount_percent NUMERIC(5,2) NOT NULL DEFAULT 0,
+    discount_amount  BIGINT       NOT NULL DEFAULT 0,
+
+    vat_rate_id UUID   NOT NULL REFERENCES cat_vat_rates(id),
+    vat_amount  BIGINT NOT NULL DEFAULT 0,
+    amount      BIGINT NOT NULL DEFAULT 0,
+
+    CONSTRAINT chk_so_quantity_positive    CHECK (quantity > 0),
+    CONSTRAINT chk_so_unit_price_positive  CHECK (unit_price >= 0),
+    CONSTRAINT chk_so_coefficient_positive CHECK (coefficient > 0),
+    CONSTRAINT chk_so_discount_percent     CHECK (discount_percent >= 0 AND discount_percent <= 100),
+    CONSTRAINT chk_so_discount_amount      CHECK (discount_amount >= 0),
+    CONSTRAINT uq_sales_order_line         UNIQUE (document_id, line_no)
+);
+
+-- Header indexes
+CREATE INDEX idx_sales_orders_date         ON doc_sales_orders (date DESC);
+CREATE INDEX idx_sales_orders_counterparty ON doc_sales_orders (counterparty_id);
+CREATE INDEX idx_sales_orders_contract     ON doc_sales_orders (contract_id) WHERE contract_id IS NOT NULL;
+CREATE INDEX idx_sales_orders_warehouse    ON doc_sales_orders (warehouse_id);
+CREATE INDEX idx_doc_sales_orders_currency_id ON doc_sales_orders (currency_id);
+CREATE INDEX idx_sales_orders_posted       ON doc_sales_orders (posted) WHERE posted = FALSE;
+CREATE INDEX idx_sales_orders_created_by   ON doc_sales_orders (created_by);
+CREATE INDEX idx_sales_orders_updated_by   ON doc_sales_orders (updated_by);
+CREATE INDEX idx_sales_orders_created_at   ON doc_sales_orders (created_at DESC);
+CREATE INDEX idx_sales_orders_number_trgm  ON doc_sales_orders USING gin (number gin_trgm_ops);
+CREATE INDEX idx_sales_orders_basis
+    ON doc_sales_orders (basis_type, basis_id)
+    WHERE basis_id IS NOT NULL;
+
+-- CDC indexes & triggers
+CREATE INDEX idx_doc_sales_orders_txid ON doc_sales_orders (_txid) WHERE _deleted_at IS NULL;
+
+CREATE TRIGGER trg_doc_sales_orders_txid
+    BEFORE UPDATE ON doc_sales_orders
+    FOR EACH ROW EXECUTE FUNCTION update_txid_column();
+
+CREATE TRIGGER trg_doc_sales_orders_soft_delete
+    BEFORE UPDATE OF deletion_mark ON doc_sales_orders
+    FOR EACH ROW EXECUTE FUNCTION soft_delete_with_timestamp();
+
+-- Line indexes
+CREATE INDEX idx_sales_order_lines_doc          ON doc_sales_order_lines (document_id);
+CREATE INDEX idx_sales_order_lines_nomenclature ON doc_sales_order_lines (nomenclature_id);
+CREATE INDEX idx_sales_order_lines_vat_rate     ON doc_sales_order_lines (vat_rate_id);
+
+-- Keyset pagination
+CREATE INDEX idx_doc_sales_orders_date_id    ON doc_sales_orders (date DESC, id DESC);
+CREATE INDEX idx_doc_sales_orders_created_id ON doc_sales_orders (created_at DESC, id DESC);
+
+COMMENT ON TABLE doc_sales_orders IS 'Документ Заказ покупателя (резервирует товары при проведении)';
+COMMENT ON TABLE doc_sales_order_lines IS 'Табличная часть Товары документа Заказ покупателя';
+COMMENT ON COLUMN doc_sales_orders.shipment_date IS 'Желаемая дата отгрузки';
+
+-- ── Permissions ────────────────────────────────────────────────────────────
+INSERT INTO permissions (code, name, description, resource, action) VALUES
+    ('sales_order.read',   'Чтение заказов покупателей',          'View sales orders',   'sales_order', 'read'),
+    ('sales_order.create', 'Создание заказов покупателей',        'Create sales orders', 'sales_order', 'create'),
+    ('sales_order.update', 'Изменение заказов покупателей',       'Update sales orders', 'sales_order', 'update'),
+    ('sales_order.delete', 'Удаление заказов покупателей',        'Delete sales orders', 'sales_order', 'delete'),
+    ('sales_order.post',   'Проведение заказов покупателей',      'Post sales orders',   'sales_order', 'post'),
+    ('sales_order.unpost', 'Отмена проведения заказов покупателей', 'Unpost sales orders', 'sales_order', 'unpost')
+ON CONFLICT (code) DO NOTHING;
+
+-- Admin and accountant: full access; manager: prepare and view orders
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.role_id, p.id FROM permissions p
+CROSS JOIN (VALUES ('b0000000-0000-0000-0000-000000000001'::uuid), ('b0000000-0000-0000-0000-000000000002'::uuid)) AS r(role_id)
+WHERE p.resource = 'sales_order'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'b0000000-0000-0000-0000-000000000003', id FROM permissions
+WHERE resource = 'sales_order' AND action IN ('read', 'create', 'update')
+ON CONFLICT DO NOTHING;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DELETE FROM role_permissions WHERE permission_id IN (SELECT id FROM permissions WHERE resource = 'sales_order');
+DELETE FROM permissions WHERE resource = 'sales_order';
+
+DROP TRIGGER IF EXISTS trg_doc_sales_orders_soft_delete ON doc_sales_orders;
+DROP TRIGGER IF EXISTS trg_doc_sales_orders_txid ON doc_sales_orders;
+DROP TABLE IF EXISTS doc_sales_order_lines;
+DROP TABLE IF EXISTS doc_sales_orders;
+
+DROP TRIGGER IF EXISTS trg_stock_reservation_movements_balance_insert ON reg_stock_reservation_movements;
+DROP TRIGGER IF EXISTS trg_stock_reservation_movements_balance_delete ON reg_stock_reservation_movements;
+DROP FUNCTION IF EXISTS update_stock_reservation_balance_on_insert();
+DROP FUNCTION IF EXISTS update_stock_reservation_balance_on_delete();
+DROP TABLE IF EXISTS reg_stock_reservation_balances;
+DROP TABLE IF EXISTS reg_stock_reservation_movements;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
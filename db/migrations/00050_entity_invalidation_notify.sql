@@ -0,0 +1,95 @@
+-- +goose Up
+-- Description: Invalidation notifications (entity + id + version) on every catalog write
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+-- Application nodes and external consumers LISTEN on entity_invalidated and
+-- drop cached rows. The payload is small JSON; NOTIFY is delivered on commit,
+-- so rolled back writes never invalidate anything.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_entity_invalidated()
+RETURNS TRIGGER AS $func$
+DECLARE
+    v_row RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        v_row := OLD;
+    ELSE
+        v_row := NEW;
+    END IF;
+
+    PERFORM pg_notify('entity_invalidated', json_build_object(
+        'entity',  TG_TABLE_NAME,
+        'id',      v_row.id,
+        'version', v_row.version,
+        'op',      lower(TG_OP)
+    )::text);
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- Attaches the notification trigger to a table with id and version columns.
+-- Catalog migrations added later call it for their own tables.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION attach_entity_invalidation(p_table TEXT)
+RETURNS void AS $func$
+BEGIN
+    EXECUTE format('DROP TRIGGER IF EXISTS trg_%s_invalidate ON %I', p_table, p_table);
+    EXECUTE format(
+        'CREATE TRIGGER trg_%s_invalidate AFTER INSERT OR UPDATE OR DELETE ON %I '
+        'FOR EACH ROW EXECUTE FUNCTION notify_entity_invalidated()',
+        p_table, p_table);
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DO $do$
+DECLARE
+    v_table TEXT;
+BEGIN
+    FOR v_table IN
+        SELECT t.table_name
+        FROM information_schema.tables t
+        WHERE t.table_schema = current_schema()
+          AND t.table_type = 'BASE TABLE'
+          AND t.table_name LIKE 'cat\_%'
+          AND EXISTS (SELECT 1 FROM information_schema.columns c
+                      WHERE c.table_schema = t.table_schema AND c.table_name = t.table_name
+                        AND c.column_name = 'id')
+          AND EXISTS (SELECT 1 FROM information_schema.columns c
+                      WHERE c.table_schema = t.table_schema AND c.table_name = t.table_name
+                        AND c.column_name = 'version')
+    LOOP
+        PERFORM attach_entity_invalidation(v_table);
+    END LOOP;
+END;
+$do$;
+-- +goose StatementEnd
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+-- +goose StatementBegin
+DO $do$
+DECLARE
+    v_trigger RECORD;
+BEGIN
+    FOR v_trigger IN
+        SELECT tgname, tgrelid::regclass AS tbl
+        FROM pg_trigger
+        WHERE tgfoid = 'notify_entity_invalidated'::regproc
+    LOOP
+        EXECUTE format('DROP TRIGGER IF EXISTS %I ON %s', v_trigger.tgname, v_trigger.tbl);
+    END LOOP;
+END;
+$do$;
+-- +goose StatementEnd
+
+DROP FUNCTION IF EXISTS attach_entity_invalidation(TEXT);
+DROP FUNCTION IF EXISTS notify_entity_invalidated();
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
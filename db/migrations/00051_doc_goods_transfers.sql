@@ -0,0 +1,144 @@
+-- +goose Up
+-- Description: Goods Transfer document (Документ "Перемещение товаров").
+-- Moves goods between warehouses; goods on the way are kept in a warehouse
+-- of type 'transit' until the transfer is received.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+-- ── Goods Transfer: header ─────────────────────────────────────────────────
+CREATE TABLE doc_goods_transfers (
+    -- Base fields
+    id            UUID        PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    deletion_mark BOOLEAN     NOT NULL DEFAULT FALSE,
+    version       INTEGER     NOT NULL DEFAULT 1,
+    attributes    JSONB       DEFAULT '{}',
+
+    -- CDC
+    _deleted_at TIMESTAMPTZ,
+    _txid       BIGINT DEFAULT txid_current(),
+
+    -- Audit fields
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by UUID        NOT NULL,
+    updated_by UUID        NOT NULL,
+
+    -- Document fields
+    number          VARCHAR(50)  NOT NULL,
+    date            TIMESTAMPTZ  NOT NULL,
+    posted          BOOLEAN      NOT NULL DEFAULT FALSE,
+    posted_version  INTEGER      NOT NULL DEFAULT 0,
+    organization_id UUID         NOT NULL REFERENCES cat_organizations(id),
+    description     TEXT         DEFAULT '',
+    basis_type      TEXT         NOT NULL DEFAULT '',
+    basis_id        UUID,
+
+    -- GoodsTransfer-specific fields
+    source_warehouse_id      UUID NOT NULL REFERENCES cat_warehouses(id),
+    destination_warehouse_id UUID NOT NULL REFERENCES cat_warehouses(id),
+    transit_warehouse_id     UUID REFERENCES cat_warehouses(id),
+    received_at              TIMESTAMPTZ,
+
+    -- Totals
+    total_quantity BIGINT NOT NULL DEFAULT 0,
+
+    CONSTRAINT uq_goods_transfer_number      UNIQUE (organization_id, number),
+    CONSTRAINT chk_gt_warehouses_differ      CHECK (source_warehouse_id <> destination_warehouse_id),
+    CONSTRAINT chk_gt_transit_differs        CHECK (transit_warehouse_id IS NULL
+                                                    OR transit_warehouse_id NOT IN (source_warehouse_id, destination_warehouse_id)),
+    CONSTRAINT chk_gt_received_needs_transit CHECK (received_at IS NULL OR transit_warehouse_id IS NOT NULL),
+    CONSTRAINT fk_goods_transfers_created_by FOREIGN KEY (created_by) REFERENCES users(id),
+    CONSTRAINT fk_goods_transfers_updated_by FOREIGN KEY (updated_by) REFERENCES users(id)
+);
+
+-- ── Goods Transfer: lines ──────────────────────────────────────────────────
+CREATE TABLE doc_goods_transfer_lines (
+    line_id     UUID    PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    document_id UUID    NOT NULL REFERENCES doc_goods_transfers(id) ON DELETE CASCADE,
+    line_no     INTEGER NOT NULL,
+
+    nomenclature_id UUID NOT NULL REFERENCES cat_nomenclatures(id),
+    unit_id         UUID,
+    coefficient     NUMERIC(15,6) NOT NULL DEFAULT 1,
+    quantity        BIGINT        NOT NULL,
+
+    CONSTRAINT chk_gt_quantity_positive    CHECK (quantity > 0),
+    CONSTRAINT chk_gt_coefficient_positive CHECK (coefficient > 0),
+    CONSTRAINT uq_goods_transfer_line      UNIQUE (document_id, line_no)
+);
+
+-- Header indexes
+CREATE INDEX idx_goods_transfers_date        ON doc_goods_transfers (date DESC);
+CREATE INDEX idx_goods_transfers_source      ON doc_goods_transfers (source_warehouse_id);
+CREATE INDEX idx_goods_transfers_destination ON doc_goods_transfers (destination_warehouse_id);
+CREATE INDEX idx_goods_transfers_in_transit  ON doc_goods_transfers (transit_warehouse_id)
+    WHERE transit_warehouse_id IS NOT NULL AND received_at IS NULL;
+CREATE INDEX idx_goods_transfers_posted      ON doc_goods_transfers (posted) WHERE posted = FALSE;
+CREATE INDEX idx_goods_transfers_created_by  ON doc_goods_transfers (created_by);
+CREATE INDEX idx_goods_transfers_updated_by  ON doc_goods_transfers (updated_by);
+CREATE INDEX idx_goods_transfers_created_at  ON doc_goods_transfers (created_at DESC);
+CREATE INDEX idx_goods_transfers_number_trgm ON doc_goods_transfers USING gin (number gin_trgm_ops);
+CREATE INDEX idx_goods_transfers_basis
+    ON doc_goods_transfers (basis_type, basis_id)
+    WHERE basis_id IS NOT NULL;
+
+-- CDC indexes & triggers
+CREATE INDEX idx_doc_goods_transfers_txid ON doc_goods_transfers (_txid) WHERE _deleted_at IS NULL;
+
+CREATE TRIGGER trg_doc_goods_transfers_txid
+    BEFORE UPDATE ON doc_goods_transfers
+    FOR EACH ROW EXECUTE FUNCTION update_txid_column();
+
+CREATE TRIGGER trg_doc_goods_transfers_soft_delete
+    BEFORE UPDATE OF deletion_mark ON doc_goods_transfers
+    FOR EACH ROW EXECUTE FUNCTION soft_delete_with_timestamp();
+
+-- Line indexes
+CREATE INDEX idx_goods_transfer_lines_doc          ON doc_goods_transfer_lines (document_id);
+CREATE INDEX idx_goods_transfer_lines_nomenclature ON doc_goods_transfer_lines (nomenclature_id);
+
+-- Keyset pagination
+CREATE INDEX idx_doc_goods_transfers_date_id    ON doc_goods_transfers (date DESC, id DESC);
+CREATE INDEX idx_doc_goods_transfers_created_id ON doc_goods_transfers (created_at DESC, id DESC);
+
+COMMENT ON TABLE doc_goods_transfers IS 'Документ Перемещение товаров между складами';
+COMMENT ON TABLE doc_goods_transfer_lines IS 'Табличная часть Товары документа Перемещение товаров';
+COMMENT ON COLUMN doc_goods_transfers.transit_warehouse_id IS 'Склад типа transit, на котором числятся товары в пути';
+COMMENT ON COLUMN doc_goods_transfers.received_at IS 'Дата поступления на склад-получатель (для перемещений через транзит)';
+
+-- ── Permissions ────────────────────────────────────────────────────────────
+INSERT INTO permissions (code, name, description, resource, action) VALUES
+    ('goods_transfer.read',   'Чтение перемещений товаров',             'View goods transfers',   'goods_transfer', 'read'),
+    ('goods_transfer.create', 'Создание перемещений товаров',           'Create goods transfers', 'goods_transfer', 'create'),
+    ('goods_transfer.update', 'Изменение перемещений товаров',          'Update goods transfers', 'goods_transfer', 'update'),
+    ('goods_transfer.delete', 'Удаление перемещений товаров',           'Delete goods transfers', 'goods_transfer', 'delete'),
+    ('goods_transfer.post',   'Проведение перемещений товаров',         'Post goods transfers',   'goods_transfer', 'post'),
+    ('goods_transfer.unpost', 'Отмена проведения перемещений товаров',  'Unpost goods transfers', 'goods_transfer', 'unpost')
+ON CONFLICT (code) DO NOTHING;
+
+-- Admin and accountant: full access; manager: prepare and view transfers
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.role_id, p.id FROM permissions p
+CROSS JOIN (VALUES ('b0000000-0000-0000-0000-000000000001'::uuid), ('b0000000-0000-0000-0000-000000000002'::uuid)) AS r(role_id)
+WHERE p.resource = 'goods_transfer'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'b0000000-0000-0000-0000-000000000003', id FROM permissions
+WHERE resource = 'goods_transfer' AND action IN ('read', 'create', 'update')
+ON CONFLICT DO NOTHING;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DELETE FROM role_permissions WHERE permission_id IN (SELECT id FROM permissions WHERE resource = 'goods_transfer');
+DELETE FROM permissions WHERE resource = 'goods_transfer';
+
+DROP TRIGGER IF EXISTS trg_doc_goods_transfers_soft_delete ON doc_goods_transfers;
+DROP TRIGGER IF EXISTS trg_doc_goods_transfers_txid ON doc_goods_transfers;
+DROP TABLE IF EXISTS doc_goods_transfer_lines;
+DROP TABLE IF EXISTS doc_goods_transfers;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,61 @@
+-- +goose Up
+-- Description: Inventory counts of the stock register (системный документ "Инвентаризация").
+-- While a count is in progress, stock movements in its scope are frozen
+-- according to the tenant setting stock.inventoryFreeze.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE reg_stock_inventory_counts (
+    id               UUID        PRIMARY KEY,
+    warehouse_id     UUID        NOT NULL REFERENCES cat_warehouses(id),
+    nomenclature_ids UUID[]      NOT NULL DEFAULT '{}',
+    status           TEXT        NOT NULL DEFAULT 'in_progress',
+    comment          TEXT        NOT NULL DEFAULT '',
+    started_by       UUID,
+    started_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at      TIMESTAMPTZ,
+    CONSTRAINT chk_stock_inventory_count_status
+        CHECK (status IN ('in_progress', 'completed', 'cancelled')),
+    CONSTRAINT chk_stock_inventory_count_finished
+        CHECK ((status = 'in_progress') = (finished_at IS NULL))
+);
+
+COMMENT ON TABLE reg_stock_inventory_counts IS 'Регистр остатков товаров — инвентаризации (системный документ)';
+COMMENT ON COLUMN reg_stock_inventory_counts.nomenclature_ids IS 'Counted nomenclature; empty = whole warehouse';
+
+-- Freeze check on every posting: in-progress counts by warehouse
+CREATE INDEX idx_reg_stock_inventory_counts_active
+    ON reg_stock_inventory_counts (warehouse_id)
+    WHERE status = 'in_progress';
+
+CREATE INDEX idx_reg_stock_inventory_counts_started
+    ON reg_stock_inventory_counts (started_at DESC);
+
+-- ── Permissions ────────────────────────────────────────────────────────────
+INSERT INTO permissions (code, name, description, resource, action) VALUES
+    ('inventory_count.manage',          'Проведение инвентаризаций',                  'Start, complete and cancel inventory counts',          'inventory_count', 'manage'),
+    ('inventory_count.override_freeze', 'Движения товаров во время инвентаризации', 'Post stock movements into a scope being counted', 'inventory_count', 'override_freeze')
+ON CONFLICT (code) DO NOTHING;
+
+-- Admin: full access; warehouse keeper: runs counts
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'b0000000-0000-0000-0000-000000000001', id FROM permissions
+WHERE resource = 'inventory_count'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'b0000000-0000-0000-0000-000000000004', id FROM permissions
+WHERE code = 'inventory_count.manage'
+ON CONFLICT DO NOTHING;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DELETE FROM role_permissions WHERE permission_id IN (SELECT id FROM permissions WHERE resource = 'inventory_count');
+DELETE FROM permissions WHERE resource = 'inventory_count';
+
+DROP TABLE IF EXISTS reg_stock_inventory_counts;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,283 @@
+-- +goose Up
+-- Description: Purchase order register (Регистр накопления "Заказы поставщикам") and
+-- Purchase Order document (Документ "Заказ поставщику").
+-- A posted purchase order records ordered goods; goods receipt lines linked to
+-- order lines record received goods. The balance of an order line is the
+-- quantity still expected from the supplier.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+-- ── Movements ──────────────────────────────────────────────────────────────
+CREATE TABLE reg_purchase_order_movements (
+    line_id          UUID         PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    recorder_id      UUID         NOT NULL,
+    recorder_type    VARCHAR(50)  NOT NULL,
+    recorder_version INT          NOT NULL DEFAULT 1,
+    period           TIMESTAMPTZ  NOT NULL,
+    record_type      VARCHAR(10)  NOT NULL,
+    order_id         UUID         NOT NULL,
+    order_line_id    UUID         NOT NULL,
+    nomenclature_id  UUID         NOT NULL,
+    quantity         BIGINT       NOT NULL,
+    created_at       TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_purchase_order_record_type       CHECK (record_type IN ('receipt', 'expense')),
+    CONSTRAINT chk_purchase_order_quantity_positive CHECK (quantity > 0)
+);
+
+COMMENT ON TABLE reg_purchase_order_movements IS 'Регистр заказов поставщикам — движения';
+COMMENT ON COLUMN reg_purchase_order_movements.order_line_id IS 'Line of the purchase order (doc_purchase_order_lines.line_id)';
+COMMENT ON COLUMN reg_purchase_order_movements.record_type IS 'receipt = ordered, expense = received (goods receipt)';
+
+CREATE INDEX idx_reg_purchase_order_movements_recorder
+    ON reg_purchase_order_movements (recorder_id, recorder_version);
+CREATE INDEX idx_reg_purchase_order_movements_order
+    ON reg_purchase_order_movements (order_id, order_line_id);
+
+-- ── Balances ───────────────────────────────────────────────────────────────
+CREATE TABLE reg_purchase_order_balances (
+    order_id         UUID        NOT NULL,
+    order_line_id    UUID        NOT NULL,
+    quantity         BIGINT      NOT NULL DEFAULT 0,
+    last_movement_at TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (order_id, order_line_id)
+);
+
+COMMENT ON TABLE reg_purchase_order_balances IS 'Регистр заказов поставщикам — ожидаемое поступление по строкам заказов';
+
+-- ── Statement-level balance triggers (same scheme as reg_stock, see 00021) ──
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_purchase_order_balance_on_insert()
+RETURNS TRIGGER AS $func$
+BEGIN
+    INSERT INTO reg_purchase_order_balances (order_id, order_line_id, quantity, last_movement_at, updated_at)
+    SELECT
+        order_id,
+        order_line_id,
+        SUM(CASE WHEN record_type = 'receipt' THEN quantity ELSE -quantity END),
+        MAX(period),
+        NOW()
+    FROM new_rows
+    GROUP BY order_id, order_line_id
+    ON CONFLICT (order_id, order_line_id) DO UPDATE SET
+        quantity = reg_purchase_order_balances.quantity + EXCLUDED.quantity,
+        last_movement_at = GREATEST(reg_purchase_order_balances.last_movement_at, EXCLUDED.last_movement_at),
+        updated_at = NOW();
+
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_purchase_order_movements_balance_insert
+    AFTER INSERT ON reg_purchase_order_movements
+    REFERENCING NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION update_purchase_order_balance_on_insert();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_purchase_order_balance_on_delete()
+RETURNS TRIGGER AS $func$
+BEGIN
+    INSERT INTO reg_purchase_order_balances (order_id, order_line_id, quantity, last_movement_at, updated_at)
+    SELECT
+        order_id,
+        order_line_id,
+        SUM(CASE WHEN record_type = 'receipt' THEN -quantity ELSE quantity END),
+        NOW(),
+        NOW()
+    FROM old_rows
+    GROUP BY order_id, order_line_id
+    ON CONFLICT (order_id, order_line_id) DO UPDATE SET
+        quantity = reg_purchase_order_balances.quantity + EXCLUDED.quantity,
+        updated_at = NOW();
+
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_purchase_order_movements_balance_delete
+    AFTER DELETE ON reg_purchase_order_movements
+    REFERENCING OLD TABLE AS old_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION update_purchase_order_balance_on_delete();
+
+-- ── Purchase Order: header ─────────────────────────────────────────────────
+CREATE TABLE doc_purchase_orders (
+    -- Base fields
+    id            UUID        PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    deletion_mark BOOLEAN     NOT NULL DEFAULT FALSE,
+    version       INTEGER     NOT NULL DEFAULT 1,
+    attributes    JSONB       DEFAULT '{}',
+
+    -- CDC
+    _deleted_at TIMESTAMPTZ,
+    _txid       BIGINT DEFAULT txid_current(),
+
+    -- Audit fields
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by UUID        NOT NULL,
+    updated_by UUID        NOT NULL,
+
+    -- Document fields
+    number          VARCHAR(50)  NOT NULL,
+    date            TIMESTAMPTZ  NOT NULL,
+    posted          BOOLEAN      NOT NULL DEFAULT FALSE,
+    posted_version  INTEGER      NOT NULL DEFAULT 0,
+    organization_id UUID         NOT NULL REFERENCES cat_organizations(id),
+    description     TEXT         DEFAULT '',
+    basis_type      TEXT         NOT NULL DEFAULT '',
+    basis_id        UUID,
+
+    -- PurchaseOrder-specific fields
+    counterparty_id    UUID        NOT NULL REFERENCES cat_counterparties(id),
+    contract_id        UUID        REFERENCES cat_contracts(id),
+    warehouse_id       UUID        NOT NULL REFERENCES cat_warehouses(id),
+    expected_date      TIMESTAMPTZ,
+    fulfillment_status VARCHAR(20) NOT NULL DEFAULT 'open',
+
+    -- Currency and totals
+    currency_id         UUID    NOT NULL REFERENCES cat_currencies(id),
+    amount_includes_vat BOOLEAN NOT NULL DEFAULT FALSE,
+    total_quantity      BIGINT  NOT NULL DEFAULT 0,
+    total_amount        BIGINT  NOT NULL DEFAULT 0,
+    total_vat           BIGINT  NOT NULL DEFAULT 0,
+
+    CONSTRAINT uq_purchase_order_number      UNIQUE (organization_id, number),
+    CONSTRAINT chk_po_fulfillment_status     CHECK (fulfillment_status IN ('open', 'partially_received', 'received')),
+    CONSTRAINT fk_purchase_orders_created_by FOREIGN KEY (created_by) REFERENCES users(id),
+    CONSTRAINT fk_purchase_orders_updated_by FOREIGN KEY (updated_by) REFERENCES users(id)
+);
+
+-- ── Purchase Order: lines ──────────────────────────────────────────────────
+CREATE TABLE doc_purchase_order_lines (
+    line_id     UUID    PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    document_id UUID    NOT NULL REFERENCES doc_purchase_orders(id) ON DELETE CASCADE,
+    line_no     INTEGER NOT NULL,
+
+    nomenclature_id UUID NOT NULL REFERENCES cat_nomenclatures(id),
+    unit_id         UUID,
+    coefficient     NUMERIC(15,6) NOT NULL DEFAULT 1,
+
+    quantity         BIGINT       NOT NULL,
+    expected_date    TIMESTAMPTZ,
+    unit_price       BIGINT       NOT NULL,
+    discount_percent NUMERIC(5,2) NOT NULL DEFAULT 0,
+    discount_amount  BIGINT       NOT NULL DEFAULT 0,
+
+    vat_rate_id UUID   NOT NULL REFERENCES cat_vat_rates(id),
+    vat_amount  BIGINT NOT NULL DEFAULT 0,
+    amount      BIGINT NOT NULL DEFAULT 0,
+
+    CONSTRAINT chk_po_quantity_positive    CHECK (quantity > 0),
+    CONSTRAINT chk_po_unit_price_positive  CHECK (unit_price >= 0),
+    CONSTRAINT chk_po_coefficient_positive CHECK (coefficient > 0),
+    CONSTRAINT chk_po_discount_percent     CHECK (discount_percent >= 0 AND discount_percent <= 100),
+    CONSTRAINT chk_po_discount_amount      CHECK (discount_amount >= 0),
+    CONSTRAINT uq_purchase_order_line      UNIQUE (document_id, line_no)
+);
+
+-- Header indexes
+CREATE INDEX idx_purchase_orders_date         ON doc_purchase_orders (date DESC);
+CREATE INDEX idx_purchase_orders_counterparty ON doc_purchase_orders (counterparty_id);
+CREATE INDEX idx_purchase_orders_contract     ON doc_purchase_orders (contract_id) WHERE contract_id IS NOT NULL;
+CREATE INDEX idx_purchase_orders_warehouse    ON doc_purchase_orders (warehouse_id);
+CREATE INDEX idx_doc_purchase_orders_currency_id ON doc_purchase_orders (currency_id);
+CREATE INDEX idx_purchase_orders_open         ON doc_purchase_orders (counterparty_id)
+    WHERE posted = TRUE AND fulfillment_status <> 'received';
+CREATE INDEX idx_purchase_orders_posted       ON doc_purchase_orders (posted) WHERE posted = FALSE;
+CREATE INDEX idx_purchase_orders_created_by   ON doc_purchase_orders (created_by);
+CREATE INDEX idx_purchase_orders_updated_by   ON doc_purchase_orders (updated_by);
+CREATE INDEX idx_purchase_orders_created_at   ON doc_purchase_orders (created_at DESC);
+CREATE INDEX idx_purchase_orders_number_trgm  ON doc_purchase_orders USING gin (number gin_trgm_ops);
+CREATE INDEX idx_purchase_orders_basis
+    ON doc_purchase_orders (basis_type, basis_id)
+    WHERE basis_id IS NOT NULL;
+
+-- CDC indexes & triggers
+CREATE INDEX idx_doc_purchase_orders_txid ON doc_purchase_orders (_txid) WHERE _deleted_at IS NULL;
+
+CREATE TRIGGER trg_doc_purchase_orders_txid
+    BEFORE UPDATE ON doc_purchase_orders
+    FOR EACH ROW EXECUTE FUNCTION update_txid_column();
+
+CREATE TRIGGER trg_doc_purchase_orders_soft_delete
+    BEFORE UPDATE OF deletion_mark ON doc_purchase_orders
+    FOR EACH ROW EXECUTE FUNCTION soft_delete_with_timestamp();
+
+-- Line indexes
+CREATE INDEX idx_purchase_order_lines_doc          ON doc_purchase_order_lines (document_id);
+CREATE INDEX idx_purchase_order_lines_nomenclature ON doc_purchase_order_lines (nomenclature_id);
+CREATE INDEX idx_purchase_order_lines_vat_rate     ON doc_purchase_order_lines (vat_rate_id);
+
+-- Keyset pagination
+CREATE INDEX idx_doc_purchase_orders_date_id    ON doc_purchase_orders (date DESC, id DESC);
+CREATE INDEX idx_doc_purchase_orders_created_id ON doc_purchase_orders (created_at DESC, id DESC);
+
+COMMENT ON TABLE doc_purchase_orders IS 'Документ Заказ поставщику (ожидаемое поступление товаров)';
+COMMENT ON TABLE doc_purchase_order_lines IS 'Табличная часть Товары документа Заказ поставщику';
+COMMENT ON COLUMN doc_purchase_orders.expected_date IS 'Ожидаемая дата поступления (по умолчанию для строк)';
+COMMENT ON COLUMN doc_purchase_orders.fulfillment_status IS 'Состояние поступления; обновляется при проведении поступлений товаров';
+
+-- ── Goods Receipt: link to purchase order lines ────────────────────────────
+-- No FK: purchase order lines are rewritten on every save, their IDs are kept.
+ALTER TABLE doc_goods_receipt_lines ADD COLUMN order_line_id UUID;
+
+CREATE INDEX idx_goods_receipt_lines_order_line
+    ON doc_goods_receipt_lines (order_line_id) WHERE order_line_id IS NOT NULL;
+
+COMMENT ON COLUMN doc_goods_receipt_lines.order_line_id IS 'Строка заказа поставщику (документ-основание)';
+
+-- ── Permissions ────────────────────────────────────────────────────────────
+INSERT INTO permissions (code, name, description, resource, action) VALUES
+    ('purchase_order.read',   'Чтение заказов поставщикам',            'View purchase orders',   'purchase_order', 'read'),
+    ('purchase_order.create', 'Создание заказов поставщикам',          'Create purchase orders', 'purchase_order', 'create'),
+    ('purchase_order.update', 'Изменение заказов поставщикам',         'Update purchase orders', 'purchase_order', 'update'),
+    ('purchase_order.delete', 'Удаление заказов поставщикам',          'Delete purchase orders', 'purchase_order', 'delete'),
+    ('purchase_order.post',   'Проведение заказов поставщикам',        'Post purchase orders',   'purchase_order', 'post'),
+    ('purchase_order.unpost', 'Отмена проведения заказов поставщикам', 'Unpost purchase orders', 'purchase_order', 'unpost')
+ON CONFLICT (code) DO NOTHING;
+
+-- Admin and accountant: full access; manager and warehouse keeper: view orders
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.role_id, p.id FROM permissions p
+CROSS JOIN (VALUES ('b0000000-0000-0000-0000-000000000001'::uuid), ('b0000000-0000-0000-0000-000000000002'::uuid)) AS r(role_id)
+WHERE p.resource = 'purchase_order'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'b0000000-0000-0000-0000-000000000003', id FROM permissions
+WHERE resource = 'purchase_order' AND action IN ('read', 'create', 'update')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'b0000000-0000-0000-0000-000000000004', id FROM permissions
+WHERE resource = 'purchase_order' AND action = 'read'
+ON CONFLICT DO NOTHING;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DELETE FROM role_permissions WHERE permission_id IN (SELECT id FROM permissions WHERE resource = 'purchase_order');
+DELETE FROM permissions WHERE resource = 'purchase_order';
+
+DROP INDEX IF EXISTS idx_goods_receipt_lines_order_line;
+ALTER TABLE doc_goods_receipt_lines DROP COLUMN IF EXISTS order_line_id;
+
+DROP TRIGGER IF EXISTS trg_doc_purchase_orders_soft_delete ON doc_purchase_orders;
+DROP TRIGGER IF EXISTS trg_doc_purchase_orders_txid ON doc_purchase_orders;
+DROP TABLE IF EXISTS doc_purchase_order_lines;
+DROP TABLE IF EXISTS doc_purchase_orders;
+
+DROP TRIGGER IF EXISTS trg_purchase_order_movements_balance_insert ON reg_purchase_order_movements;
+DROP TRIGGER IF EXISTS trg_purchase_order_movements_balance_delete ON reg_purchase_order_movements;
+DROP FUNCTION IF EXISTS update_purchase_order_balance_on_insert();
+DROP FUNCTION IF EXISTS update_purchase_order_balance_on_delete();
+DROP TABLE IF EXISTS reg_purchase_order_balances;
+DROP TABLE IF EXISTS reg_purchase_order_movements;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
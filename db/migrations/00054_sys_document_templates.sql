@@ -0,0 +1,51 @@
+-- +goose Up
+-- Description: Document templates (frequently used document skeletons).
+-- A template stores the create request of a document type without the number,
+-- date and line quantities; new documents are created from it.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE sys_document_templates (
+    id            UUID         PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    document_type VARCHAR(100) NOT NULL,
+    name          VARCHAR(255) NOT NULL,
+    author_id     UUID         REFERENCES users(id) ON DELETE SET NULL,
+    visibility    VARCHAR(20)  NOT NULL DEFAULT 'personal',
+    payload       JSONB        NOT NULL DEFAULT '{}'::jsonb,
+
+    -- CDC
+    deletion_mark BOOLEAN     NOT NULL DEFAULT FALSE,
+    _deleted_at   TIMESTAMPTZ,
+    _txid         BIGINT DEFAULT txid_current(),
+    version       INT         NOT NULL DEFAULT 1,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_sys_document_templates_visibility CHECK (visibility IN ('personal', 'shared'))
+);
+
+CREATE INDEX idx_sys_document_templates_type
+    ON sys_document_templates (document_type) WHERE deletion_mark = FALSE;
+CREATE INDEX idx_sys_document_templates_author_id ON sys_document_templates (author_id);
+
+CREATE TRIGGER trg_sys_document_templates_update_txid
+    BEFORE UPDATE ON sys_document_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_txid_column();
+
+CREATE TRIGGER trg_sys_document_templates_soft_delete
+    BEFORE UPDATE OF deletion_mark ON sys_document_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION soft_delete_with_timestamp();
+
+COMMENT ON TABLE sys_document_templates IS 'Шаблоны документов (заготовки часто используемых документов)';
+COMMENT ON COLUMN sys_document_templates.payload IS 'Create request of the document type without number, date and line quantities';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DROP TABLE IF EXISTS sys_document_templates;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,163 @@
+-- +goose Up
+-- Description: Sales order register (Регистр накопления "Заказы покупателей"),
+-- sales order statuses and optional auto-reservation.
+-- A posted (confirmed) sales order records ordered goods; goods issue lines
+-- linked to order lines record shipped goods, closing the order releases the
+-- rest. The balance of an order line is its open quantity.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+-- ── Movements ──────────────────────────────────────────────────────────────
+CREATE TABLE reg_sales_order_movements (
+    line_id          UUID         PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    recorder_id      UUID         NOT NULL,
+    recorder_type    VARCHAR(50)  NOT NULL,
+    recorder_version INT          NOT NULL DEFAULT 1,
+    period           TIMESTAMPTZ  NOT NULL,
+    record_type      VARCHAR(10)  NOT NULL,
+    order_id         UUID         NOT NULL,
+    order_line_id    UUID         NOT NULL,
+    nomenclature_id  UUID         NOT NULL,
+    quantity         BIGINT       NOT NULL,
+    created_at       TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_sales_order_record_type       CHECK (record_type IN ('receipt', 'expense')),
+    CONSTRAINT chk_sales_order_quantity_positive CHECK (quantity > 0)
+);
+
+COMMENT ON TABLE reg_sales_order_movements IS 'Регистр заказов покупателей — движения';
+COMMENT ON COLUMN reg_sales_order_movements.order_line_id IS 'Line of the sales order (doc_sales_order_lines.line_id)';
+COMMENT ON COLUMN reg_sales_order_movements.record_type IS 'receipt = ordered, expense = shipped (goods issue) or released (order closed)';
+
+CREATE INDEX idx_reg_sales_order_movements_recorder
+    ON reg_sales_order_movements (recorder_id, recorder_version);
+CREATE INDEX idx_reg_sales_order_movements_order
+    ON reg_sales_order_movements (order_id, order_line_id);
+
+-- ── Balances ───────────────────────────────────────────────────────────────
+CREATE TABLE reg_sales_order_balances (
+    order_id         UUID        NOT NULL,
+    order_line_id    UUID        NOT NULL,
+    quantity         BIGINT      NOT NULL DEFAULT 0,
+    last_movement_at TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (order_id, order_line_id)
+);
+
+COMMENT ON TABLE reg_sales_order_balances IS 'Регистр заказов покупателей — неотгруженный остаток по строкам заказов';
+
+-- ── Statement-level balance triggers (same scheme as reg_stock, see 00021) ──
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_sales_order_balance_on_insert()
+RETURNS TRIGGER AS $func$
+BEGIN
+    INSERT INTO reg_sales_order_balances (order_id, order_line_id, quantity, last_movement_at, updated_at)
+    SELECT
+        order_id,
+        order_line_id,
+        SUM(CASE WHEN record_type = 'receipt' THEN quantity ELSE -quantity END),
+        MAX(period),
+        NOW()
+    FROM new_rows
+    GROUP BY order_id, order_line_id
+    ON CONFLICT (order_id, order_line_id) DO UPDATE SET
+        quantity = reg_sales_order_balances.quantity + EXCLUDED.quantity,
+        last_movement_at = GREATEST(reg_sales_order_balances.last_movement_at, EXCLUDED.last_movement_at),
+        updated_at = NOW();
+
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_sales_order_movements_balance_insert
+    AFTER INSERT ON reg_sales_order_movements
+    REFERENCING NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION update_sales_order_balance_on_insert();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_sales_order_balance_on_delete()
+RETURNS TRIGGER AS $func$
+BEGIN
+    INSERT INTO reg_sales_order_balances (order_id, order_line_id, quantity, last_movement_at, updated_at)
+    SELECT
+        order_id,
+        order_line_id,
+        SUM(CASE WHEN record_type = 'receipt' THEN -quantity ELSE quantity END),
+        NOW(),
+        NOW()
+    FROM old_rows
+    GROUP BY order_id, order_line_id
+    ON CONFLICT (order_id, order_line_id) DO UPDATE SET
+        quantity = reg_sales_order_balances.quantity + EXCLUDED.quantity,
+        updated_at = NOW();
+
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_sales_order_movements_balance_delete
+    AFTER DELETE ON reg_sales_order_movements
+    REFERENCING OLD TABLE AS old_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION update_sales_order_balance_on_delete();
+
+-- ── Sales Order: status and reservation flags ──────────────────────────────
+ALTER TABLE doc_sales_orders
+    ADD COLUMN auto_reserve BOOLEAN     NOT NULL DEFAULT TRUE,
+    ADD COLUMN closed       BOOLEAN     NOT NULL DEFAULT FALSE,
+    ADD COLUMN status       VARCHAR(20) NOT NULL DEFAULT 'draft',
+    ADD CONSTRAINT chk_so_status CHECK (status IN ('draft', 'confirmed', 'shipped', 'closed'));
+
+COMMENT ON COLUMN doc_sales_orders.auto_reserve IS 'Резервировать товары при проведении (подтверждении) заказа';
+COMMENT ON COLUMN doc_sales_orders.closed IS 'Заказ закрыт: неотгруженный остаток и резерв сняты';
+COMMENT ON COLUMN doc_sales_orders.status IS 'Состояние заказа; обновляется при проведении заказа и реализаций';
+
+CREATE INDEX idx_sales_orders_open ON doc_sales_orders (counterparty_id)
+    WHERE status = 'confirmed';
+
+-- Orders posted before this migration: record what they ordered.
+INSERT INTO reg_sales_order_movements
+    (recorder_id, recorder_type, recorder_version, period, record_type,
+     order_id, order_line_id, nomenclature_id, quantity)
+SELECT o.id, 'SalesOrder', o.posted_version, o.date, 'receipt',
+       o.id, l.line_id, l.nomenclature_id, TRUNC(l.quantity * l.coefficient)::BIGINT
+FROM doc_sales_orders o
+JOIN doc_sales_order_lines l ON l.document_id = o.id
+WHERE o.posted = TRUE AND TRUNC(l.quantity * l.coefficient) > 0;
+
+UPDATE doc_sales_orders SET status = 'confirmed' WHERE posted = TRUE;
+
+-- ── Goods Issue: link to sales order lines ─────────────────────────────────
+-- No FK: sales order lines are rewritten on every save, their IDs are kept.
+ALTER TABLE doc_goods_issue_lines ADD COLUMN order_line_id UUID;
+
+CREATE INDEX idx_goods_issue_lines_order_line
+    ON doc_goods_issue_lines (order_line_id) WHERE order_line_id IS NOT NULL;
+
+COMMENT ON COLUMN doc_goods_issue_lines.order_line_id IS 'Строка заказа покупателя (документ-основание)';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DROP INDEX IF EXISTS idx_goods_issue_lines_order_line;
+ALTER TABLE doc_goods_issue_lines DROP COLUMN IF EXISTS order_line_id;
+
+DROP INDEX IF EXISTS idx_sales_orders_open;
+ALTER TABLE doc_sales_orders
+    DROP CONSTRAINT IF EXISTS chk_so_status,
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS closed,
+    DROP COLUMN IF EXISTS auto_reserve;
+
+DROP TRIGGER IF EXISTS trg_sales_order_movements_balance_insert ON reg_sales_order_movements;
+DROP TRIGGER IF EXISTS trg_sales_order_movements_balance_delete ON reg_sales_order_movements;
+DROP FUNCTION IF EXISTS update_sales_order_balance_on_insert();
+DROP FUNCTION IF EXISTS update_sales_order_balance_on_delete();
+DROP TABLE IF EXISTS reg_sales_order_balances;
+DROP TABLE IF EXISTS reg_sales_order_movements;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,39 @@
+-- +goose Up
+-- Description: File attachments of documents and catalog items.
+-- Only metadata is stored here; file contents live in the configured blob
+-- storage (local directory or S3) under storage_key.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE sys_attachments (
+    id           UUID          PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    entity_type  VARCHAR(100)  NOT NULL,
+    entity_id    UUID          NOT NULL,
+    file_name    VARCHAR(255)  NOT NULL,
+    content_type VARCHAR(255)  NOT NULL,
+    size         BIGINT        NOT NULL,
+    checksum     VARCHAR(64)   NOT NULL,
+    storage_key  VARCHAR(500)  NOT NULL,
+    uploaded_by  UUID          REFERENCES users(id) ON DELETE SET NULL,
+    created_at   TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_sys_attachments_size CHECK (size > 0),
+    CONSTRAINT uq_sys_attachments_storage_key UNIQUE (storage_key)
+);
+
+CREATE INDEX idx_sys_attachments_entity ON sys_attachments (entity_type, entity_id, created_at DESC);
+CREATE INDEX idx_sys_attachments_uploaded_by ON sys_attachments (uploaded_by);
+
+COMMENT ON TABLE sys_attachments IS 'Присоединённые файлы документов и справочников';
+COMMENT ON COLUMN sys_attachments.entity_type IS 'Entity name of the owner, e.g. goods_receipt or counterparty';
+COMMENT ON COLUMN sys_attachments.checksum IS 'SHA-256 of the file contents, hex';
+COMMENT ON COLUMN sys_attachments.storage_key IS 'Blob key: tenant/entity_type/entity_id/attachment_id';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DROP TABLE IF EXISTS sys_attachments;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
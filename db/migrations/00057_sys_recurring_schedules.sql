@@ -0,0 +1,83 @@
+-- +goose Up
+-- Description: Recurring document generation.
+-- A schedule creates documents from a document template on a CRON schedule;
+-- the worker executes due schedules and records every run.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE sys_recurring_schedules (
+    id             UUID         PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    document_type  VARCHAR(100) NOT NULL,
+    name           VARCHAR(255) NOT NULL,
+    template_id    UUID         NOT NULL REFERENCES sys_document_templates(id),
+    cron_expr      VARCHAR(100) NOT NULL,
+    timezone       VARCHAR(64)  NOT NULL DEFAULT 'UTC',
+    quantities     JSONB        NOT NULL DEFAULT '[]'::jsonb,
+    auto_post      BOOLEAN      NOT NULL DEFAULT FALSE,
+    failure_policy VARCHAR(20)  NOT NULL DEFAULT 'skip',
+    active         BOOLEAN      NOT NULL DEFAULT TRUE,
+    next_run_at    TIMESTAMPTZ,
+    last_run_at    TIMESTAMPTZ,
+    pending_count  INT          NOT NULL DEFAULT 0,
+    locked_until   TIMESTAMPTZ,
+    author_id      UUID         REFERENCES users(id) ON DELETE SET NULL,
+
+    -- CDC
+    deletion_mark BOOLEAN     NOT NULL DEFAULT FALSE,
+    _deleted_at   TIMESTAMPTZ,
+    _txid         BIGINT DEFAULT txid_current(),
+    version       INT         NOT NULL DEFAULT 1,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_sys_recurring_schedules_policy CHECK (failure_policy IN ('skip', 'merge')),
+    CONSTRAINT chk_sys_recurring_schedules_pending CHECK (pending_count >= 0)
+);
+
+CREATE INDEX idx_sys_recurring_schedules_type
+    ON sys_recurring_schedules (document_type) WHERE deletion_mark = FALSE;
+CREATE INDEX idx_sys_recurring_schedules_due
+    ON sys_recurring_schedules (next_run_at) WHERE active = TRUE AND deletion_mark = FALSE;
+CREATE INDEX idx_sys_recurring_schedules_template_id ON sys_recurring_schedules (template_id);
+
+CREATE TRIGGER trg_sys_recurring_schedules_update_txid
+    BEFORE UPDATE ON sys_recurring_schedules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_txid_column();
+
+CREATE TRIGGER trg_sys_recurring_schedules_soft_delete
+    BEFORE UPDATE OF deletion_mark ON sys_recurring_schedules
+    FOR EACH ROW
+    EXECUTE FUNCTION soft_delete_with_timestamp();
+
+CREATE TABLE sys_recurring_runs (
+    id            UUID        PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    schedule_id   UUID        NOT NULL REFERENCES sys_recurring_schedules(id) ON DELETE CASCADE,
+    scheduled_for TIMESTAMPTZ NOT NULL,
+    occurrences   INT         NOT NULL DEFAULT 1,
+    status        VARCHAR(20) NOT NULL,
+    document_id   UUID,
+    error         TEXT        NOT NULL DEFAULT '',
+    started_at    TIMESTAMPTZ NOT NULL,
+    finished_at   TIMESTAMPTZ NOT NULL,
+
+    CONSTRAINT chk_sys_recurring_runs_status CHECK (status IN ('success', 'failed', 'skipped'))
+);
+
+CREATE INDEX idx_sys_recurring_runs_schedule ON sys_recurring_runs (schedule_id, started_at DESC);
+
+COMMENT ON TABLE sys_recurring_schedules IS 'Расписания автоматического создания документов по шаблону';
+COMMENT ON COLUMN sys_recurring_schedules.cron_expr IS '6-field CRON (sec min hour dom month dow) or descriptor, e.g. @monthly';
+COMMENT ON COLUMN sys_recurring_schedules.pending_count IS 'Failed or missed occurrences carried over to the next run (merge policy)';
+COMMENT ON COLUMN sys_recurring_schedules.locked_until IS 'Worker lease while a run is in progress';
+COMMENT ON TABLE sys_recurring_runs IS 'История запусков расписаний документов';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DROP TABLE IF EXISTS sys_recurring_runs;
+DROP TABLE IF EXISTS sys_recurring_schedules;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,25 @@
+-- +goose Up
+-- Description: Audit trail of all entity changes.
+-- Base catalog and document repositories record every create, update, delete
+-- and post into sys_audit; entries carry the request trace ID and are
+-- queried by entity, user and date range.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+ALTER TABLE sys_audit ADD COLUMN trace_id VARCHAR(64);
+
+CREATE INDEX idx_audit_created_at ON sys_audit (created_at DESC);
+CREATE INDEX idx_audit_trace      ON sys_audit (trace_id) WHERE trace_id IS NOT NULL;
+
+COMMENT ON COLUMN sys_audit.trace_id IS 'Trace ID of the request that made the change';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DROP INDEX IF EXISTS idx_audit_trace;
+DROP INDEX IF EXISTS idx_audit_created_at;
+ALTER TABLE sys_audit DROP COLUMN IF EXISTS trace_id;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
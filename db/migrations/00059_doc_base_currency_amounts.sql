@@ -0,0 +1,40 @@
+-- +goose Up
+-- Description: Document totals in base currency.
+-- Documents in a foreign currency store their total converted at the rate of
+-- reg_exchange_rates effective on the document date (see exchange_rate.Converter).
+-- Documents in the base currency store the total as is.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+ALTER TABLE doc_goods_receipts  ADD COLUMN total_amount_base BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE doc_goods_issues    ADD COLUMN total_amount_base BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE doc_sales_orders    ADD COLUMN total_amount_base BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE doc_purchase_orders ADD COLUMN total_amount_base BIGINT NOT NULL DEFAULT 0;
+
+-- Existing documents: base-currency documents keep their total, foreign ones
+-- are recalculated on the next save.
+UPDATE doc_goods_receipts d SET total_amount_base = d.total_amount
+    FROM cat_currencies c WHERE c.id = d.currency_id AND c.is_base;
+UPDATE doc_goods_issues d SET total_amount_base = d.total_amount
+    FROM cat_currencies c WHERE c.id = d.currency_id AND c.is_base;
+UPDATE doc_sales_orders d SET total_amount_base = d.total_amount
+    FROM cat_currencies c WHERE c.id = d.currency_id AND c.is_base;
+UPDATE doc_purchase_orders d SET total_amount_base = d.total_amount
+    FROM cat_currencies c WHERE c.id = d.currency_id AND c.is_base;
+
+COMMENT ON COLUMN doc_goods_receipts.total_amount_base  IS 'Сумма итого в базовой валюте';
+COMMENT ON COLUMN doc_goods_issues.total_amount_base    IS 'Сумма итого в базовой валюте';
+COMMENT ON COLUMN doc_sales_orders.total_amount_base    IS 'Сумма итого в базовой валюте';
+COMMENT ON COLUMN doc_purchase_orders.total_amount_base IS 'Сумма итого в базовой валюте';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+ALTER TABLE doc_purchase_orders DROP COLUMN IF EXISTS total_amount_base;
+ALTER TABLE doc_sales_orders    DROP COLUMN IF EXISTS total_amount_base;
+ALTER TABLE doc_goods_issues    DROP COLUMN IF EXISTS total_amount_base;
+ALTER TABLE doc_goods_receipts  DROP COLUMN IF EXISTS total_amount_base;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
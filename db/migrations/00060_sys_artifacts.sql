@@ -0,0 +1,58 @@
+-- +goose Up
+-- Description: Generated files (async report exports, backups).
+-- Only metadata is stored here; contents live in the configured artifact
+-- storage (local directory or S3) under storage_key. The worker removes
+-- artifacts past expires_at or older than the tenant retention setting.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE sys_artifacts (
+    id           UUID          PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    kind         VARCHAR(50)   NOT NULL,                -- report_export | backup
+    file_name    VARCHAR(255)  NOT NULL,
+    content_type VARCHAR(255)  NOT NULL,
+    size         BIGINT        NOT NULL,
+    checksum     VARCHAR(64)   NOT NULL,
+    storage_key  VARCHAR(500)  NOT NULL,
+    created_by   VARCHAR(64),
+    expires_at   TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_sys_artifacts_size CHECK (size >= 0),
+    CONSTRAINT uq_sys_artifacts_storage_key UNIQUE (storage_key)
+);
+
+CREATE INDEX idx_sys_artifacts_created ON sys_artifacts (created_at DESC);
+CREATE INDEX idx_sys_artifacts_kind ON sys_artifacts (kind, created_at DESC);
+CREATE INDEX idx_sys_artifacts_expires ON sys_artifacts (expires_at) WHERE expires_at IS NOT NULL;
+
+COMMENT ON TABLE  sys_artifacts             IS 'Сформированные файлы: выгрузки отчётов, резервные копии';
+COMMENT ON COLUMN sys_artifacts.checksum    IS 'SHA-256 of the file contents, hex';
+COMMENT ON COLUMN sys_artifacts.storage_key IS 'Blob key: tenant/artifacts/kind/artifact_id';
+COMMENT ON COLUMN sys_artifacts.expires_at  IS 'Explicit expiry set by the producer; NULL means the tenant retention applies';
+
+-- Artifact retention (days) joins the worker cleanup settings.
+ALTER TABLE sys_settings
+    ALTER COLUMN retention SET DEFAULT '{"idempotencyKeyHours": 24, "refreshTokenDays": 7, "artifactDays": 30}';
+
+UPDATE sys_settings
+SET retention = retention || '{"artifactDays": 30}'
+WHERE NOT retention ? 'artifactDays';
+
+COMMENT ON COLUMN sys_settings.retention IS 'Worker cleanup retention: idempotencyKeyHours, refreshTokenDays, artifactDays';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+UPDATE sys_settings SET retention = retention - 'artifactDays';
+
+ALTER TABLE sys_settings
+    ALTER COLUMN retention SET DEFAULT '{"idempotencyKeyHours": 24, "refreshTokenDays": 7}';
+
+COMMENT ON COLUMN sys_settings.retention IS 'Worker cleanup retention: idempotencyKeyHours, refreshTokenDays';
+
+DROP TABLE IF EXISTS sys_artifacts;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,24 @@
+-- +goose Up
+-- Description: Assign security profiles to roles.
+-- A user without a directly assigned profile gets the profile of one of
+-- their roles, so field-level security (e.g. hiding purchase prices) can be
+-- configured per role instead of per user.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE role_security_profiles (
+    role_id    UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    profile_id UUID NOT NULL REFERENCES security_profiles(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, profile_id)
+);
+
+CREATE INDEX idx_rsp_profile ON role_security_profiles (profile_id);
+
+COMMENT ON TABLE role_security_profiles IS 'Role ↔ Security Profile; used when the user has no directly assigned profile';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+DROP TABLE IF EXISTS role_security_profiles;
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,69 @@
+-- +goose Up
+-- Description: Exchange rate frozen on posting.
+-- Foreign-currency documents store the rate their base-currency total was
+-- computed at when posted; reposts reuse it, so later edits of
+-- reg_exchange_rates do not change posted totals. NULL for drafts and for
+-- documents in the base currency.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+ALTER TABLE doc_goods_receipts  ADD COLUMN exchange_rate DECIMAL(24,12), ADD COLUMN exchange_rate_multiplier INT;
+ALTER TABLE doc_goods_issues    ADD COLUMN exchange_rate DECIMAL(24,12), ADD COLUMN exchange_rate_multiplier INT;
+ALTER TABLE doc_sales_orders    ADD COLUMN exchange_rate DECIMAL(24,12), ADD COLUMN exchange_rate_multiplier INT;
+ALTER TABLE doc_purchase_orders ADD COLUMN exchange_rate DECIMAL(24,12), ADD COLUMN exchange_rate_multiplier INT;
+
+-- Already posted foreign-currency documents are frozen at the rate currently
+-- in effect on their date (same source priority as exchange_rate.Converter).
+UPDATE doc_goods_receipts d SET (exchange_rate, exchange_rate_multiplier) = (
+        SELECT r.rate, r.multiplier
+        FROM reg_exchange_rates r
+        JOIN cat_rate_sources rs ON rs.id = r.rate_source_id
+        WHERE r.currency_id = d.currency_id AND r.date <= d.date
+          AND rs.is_active = TRUE AND rs.deletion_mark = FALSE AND rs._deleted_at IS NULL
+        ORDER BY rs.priority, r.date DESC
+        LIMIT 1)
+    FROM cat_currencies c WHERE c.id = d.currency_id AND NOT c.is_base AND d.posted;
+UPDATE doc_goods_issues d SET (exchange_rate, exchange_rate_multiplier) = (
+        SELECT r.rate, r.multiplier
+        FROM reg_exchange_rates r
+        JOIN cat_rate_sources rs ON rs.id = r.rate_source_id
+        WHERE r.currency_id = d.currency_id AND r.date <= d.date
+          AND rs.is_active = TRUE AND rs.deletion_mark = FALSE AND rs._deleted_at IS NULL
+        ORDER BY rs.priority, r.date DESC
+        LIMIT 1)
+    FROM cat_currencies c WHERE c.id = d.currency_id AND NOT c.is_base AND d.posted;
+UPDATE doc_sales_orders d SET (exchange_rate, exchange_rate_multiplier) = (
+        SELECT r.rate, r.multiplier
+        FROM reg_exchange_rates r
+        JOIN cat_rate_sources rs ON rs.id = r.rate_source_id
+        WHERE r.currency_id = d.currency_id AND r.date <= d.date
+          AND rs.is_active = TRUE AND rs.deletion_mark = FALSE AND rs._deleted_at IS NULL
+        ORDER BY rs.priority, r.date DESC
+        LIMIT 1)
+    FROM cat_currencies c WHERE c.id = d.currency_id AND NOT c.is_base AND d.posted;
+UPDATE doc_purchase_orders d SET (exchange_rate, exchange_rate_multiplier) = (
+        SELECT r.rate, r.multiplier
+        FROM reg_exchange_rates r
+        JOIN cat_rate_sources rs ON rs.id = r.rate_source_id
+        WHERE r.currency_id = d.currency_id AND r.date <= d.date
+          AND rs.is_active = TRUE AND rs.deletion_mark = FALSE AND rs._deleted_at IS NULL
+        ORDER BY rs.priority, r.date DESC
+        LIMIT 1)
+    FROM cat_currencies c WHERE c.id = d.currency_id AND NOT c.is_base AND d.posted;
+
+COMMENT ON COLUMN doc_goods_receipts.exchange_rate  IS 'Курс, зафиксированный при проведении';
+COMMENT ON COLUMN doc_goods_issues.exchange_rate    IS 'Курс, зафиксированный при проведении';
+COMMENT ON COLUMN doc_sales_orders.exchange_rate    IS 'Курс, зафиксированный при проведении';
+COMMENT ON COLUMN doc_purchase_orders.exchange_rate IS 'Курс, зафиксированный при проведении';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+ALTER TABLE doc_purchase_orders DROP COLUMN IF EXISTS exchange_rate, DROP COLUMN IF EXISTS exchange_rate_multiplier;
+ALTER TABLE doc_sales_orders    DROP COLUMN IF EXISTS exchange_rate, DROP COLUMN IF EXISTS exchange_rate_multiplier;
+ALTER TABLE doc_goods_issues    DROP COLUMN IF EXISTS exchange_rate, DROP COLUMN IF EXISTS exchange_rate_multiplier;
+ALTER TABLE doc_goods_receipts  DROP COLUMN IF EXISTS exchange_rate, DROP COLUMN IF EXISTS exchange_rate_multiplier;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,31 @@
+-- +goose Up
+-- Description: Intercompany transfers.
+-- A transfer between two organizations of the tenant is a mirrored pair of
+-- documents: a goods issue from the sending organization and a goods receipt
+-- into the receiving one (based on the issue). Both carry the same transfer
+-- reference, one document of each kind per reference.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+ALTER TABLE doc_goods_issues   ADD COLUMN intercompany_ref VARCHAR(50);
+ALTER TABLE doc_goods_receipts ADD COLUMN intercompany_ref VARCHAR(50);
+
+CREATE UNIQUE INDEX idx_doc_goods_issues_intercompany_ref
+    ON doc_goods_issues (intercompany_ref) WHERE intercompany_ref IS NOT NULL;
+CREATE UNIQUE INDEX idx_doc_goods_receipts_intercompany_ref
+    ON doc_goods_receipts (intercompany_ref) WHERE intercompany_ref IS NOT NULL;
+
+COMMENT ON COLUMN doc_goods_issues.intercompany_ref   IS 'Номер межфирменной передачи';
+COMMENT ON COLUMN doc_goods_receipts.intercompany_ref IS 'Номер межфирменной передачи';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DROP INDEX IF EXISTS idx_doc_goods_receipts_intercompany_ref;
+DROP INDEX IF EXISTS idx_doc_goods_issues_intercompany_ref;
+ALTER TABLE doc_goods_receipts DROP COLUMN IF EXISTS intercompany_ref;
+ALTER TABLE doc_goods_issues   DROP COLUMN IF EXISTS intercompany_ref;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
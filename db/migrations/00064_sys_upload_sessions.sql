@@ -0,0 +1,55 @@
+-- +goose Up
+-- Description: Bulk attachment upload sessions.
+-- A session issues pre-signed upload URLs for a batch of files of one
+-- document or catalog item; clients PUT the contents straight to storage and
+-- finalize the session, which validates every file and attaches them all in
+-- one transaction. The worker removes expired open sessions and their blobs.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE sys_upload_sessions (
+    id           UUID          PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    entity_type  VARCHAR(100)  NOT NULL,
+    entity_id    UUID          NOT NULL,
+    status       VARCHAR(20)   NOT NULL DEFAULT 'open',   -- open | finalized
+    created_by   UUID          REFERENCES users(id) ON DELETE SET NULL,
+    expires_at   TIMESTAMPTZ   NOT NULL,
+    finalized_at TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_sys_upload_sessions_status CHECK (status IN ('open', 'finalized'))
+);
+
+CREATE INDEX idx_sys_upload_sessions_entity ON sys_upload_sessions (entity_type, entity_id);
+CREATE INDEX idx_sys_upload_sessions_expires ON sys_upload_sessions (expires_at) WHERE status = 'open';
+
+CREATE TABLE sys_upload_session_files (
+    id           UUID          PRIMARY KEY,             -- becomes the attachment id
+    session_id   UUID          NOT NULL REFERENCES sys_upload_sessions(id) ON DELETE CASCADE,
+    file_name    VARCHAR(255)  NOT NULL,
+    content_type VARCHAR(255)  NOT NULL,
+    size         BIGINT        NOT NULL,
+    storage_key  VARCHAR(500)  NOT NULL,
+    created_at   TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_sys_upload_session_files_size CHECK (size > 0),
+    CONSTRAINT uq_sys_upload_session_files_storage_key UNIQUE (storage_key)
+);
+
+CREATE INDEX idx_sys_upload_session_files_session ON sys_upload_session_files (session_id, created_at);
+
+COMMENT ON TABLE  sys_upload_sessions               IS 'Сессии пакетной загрузки присоединённых файлов';
+COMMENT ON COLUMN sys_upload_sessions.entity_type   IS 'Entity name of the owner, e.g. goods_receipt or counterparty';
+COMMENT ON TABLE  sys_upload_session_files          IS 'Файлы сессии загрузки, ожидающие подтверждения';
+COMMENT ON COLUMN sys_upload_session_files.size     IS 'Declared size; checked against the stored blob on finalize';
+COMMENT ON COLUMN sys_upload_session_files.storage_key IS 'Blob key of the future attachment: tenant/entity_type/entity_id/file_id';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DROP TABLE IF EXISTS sys_upload_session_files;
+DROP TABLE IF EXISTS sys_upload_sessions;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,99 @@
+-- +goose Up
+-- Description: Tombstones of hard-deleted catalog items and documents for
+-- the differential sync API. Changed rows are found by their _txid; rows
+-- removed for good leave a tombstone here so offline clients learn about
+-- the deletion. The worker purges tombstones older than a sync token may be.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE sys_sync_tombstones (
+    table_name VARCHAR(100) NOT NULL,
+    entity_id  UUID         NOT NULL,
+    _txid      BIGINT       NOT NULL DEFAULT txid_current(),
+    deleted_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_sys_sync_tombstones_changes ON sys_sync_tombstones (table_name, _txid, entity_id);
+CREATE INDEX idx_sys_sync_tombstones_deleted_at ON sys_sync_tombstones (deleted_at);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_sync_tombstone()
+RETURNS TRIGGER AS $func$
+BEGIN
+    INSERT INTO sys_sync_tombstones (table_name, entity_id) VALUES (TG_TABLE_NAME, OLD.id);
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- Attaches the tombstone trigger to an entity table with id and _txid columns.
+-- Catalog and document migrations added later call it for their own tables.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION attach_sync_tombstone(p_table TEXT)
+RETURNS void AS $func$
+BEGIN
+    EXECUTE format('DROP TRIGGER IF EXISTS trg_%s_sync_tombstone ON %I', p_table, p_table);
+    EXECUTE format(
+        'CREATE TRIGGER trg_%s_sync_tombstone AFTER DELETE ON %I '
+        'FOR EACH ROW EXECUTE FUNCTION record_sync_tombstone()',
+        p_table, p_table);
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- Catalog and document header tables: table parts have no deletion mark and
+-- are synced with their document.
+-- +goose StatementBegin
+DO $do$
+DECLARE
+    v_table TEXT;
+BEGIN
+    FOR v_table IN
+        SELECT t.table_name
+        FROM information_schema.tables t
+        WHERE t.table_schema = current_schema()
+          AND t.table_type = 'BASE TABLE'
+          AND (t.table_name LIKE 'cat\_%' OR t.table_name LIKE 'doc\_%')
+          AND EXISTS (SELECT 1 FROM information_schema.columns c
+                      WHERE c.table_schema = t.table_schema AND c.table_name = t.table_name
+                        AND c.column_name = '_txid')
+          AND EXISTS (SELECT 1 FROM information_schema.columns c
+                      WHERE c.table_schema = t.table_schema AND c.table_name = t.table_name
+                        AND c.column_name = 'deletion_mark')
+    LOOP
+        PERFORM attach_sync_tombstone(v_table);
+    END LOOP;
+END;
+$do$;
+-- +goose StatementEnd
+
+COMMENT ON TABLE  sys_sync_tombstones            IS 'Удалённые записи справочников и документов для дифференциальной синхронизации';
+COMMENT ON COLUMN sys_sync_tombstones.table_name IS 'Entity table of the deleted row, e.g. cat_counterparties';
+COMMENT ON COLUMN sys_sync_tombstones._txid      IS 'Transaction of the deletion, compared with sync tokens like row _txid';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+-- +goose StatementBegin
+DO $do$
+DECLARE
+    v_trigger RECORD;
+BEGIN
+    FOR v_trigger IN
+        SELECT tgname, tgrelid::regclass AS tbl
+        FROM pg_trigger
+        WHERE tgfoid = 'record_sync_tombstone'::regproc
+    LOOP
+        EXECUTE format('DROP TRIGGER IF EXISTS %I ON %s', v_trigger.tgname, v_trigger.tbl);
+    END LOOP;
+END;
+$do$;
+-- +goose StatementEnd
+
+DROP FUNCTION IF EXISTS attach_sync_tombstone(TEXT);
+DROP FUNCTION IF EXISTS record_sync_tombstone();
+DROP TABLE IF EXISTS sys_sync_tombstones;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
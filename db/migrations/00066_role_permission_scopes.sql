@@ -0,0 +1,18 @@
+-- +goose Up
+-- Description: Scoped permission grants.
+-- A role permission may be limited to a scope, e.g. "document:goods_issue:post"
+-- only for goods issues of warehouse X. The scope maps RLS dimension names to
+-- allowed IDs; NULL = the permission is granted unrestricted.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+ALTER TABLE role_permissions ADD COLUMN IF NOT EXISTS scope JSONB;
+
+COMMENT ON COLUMN role_permissions.scope IS 'Grant scope: {"dimension": ["id", ...]}; NULL = unrestricted';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+ALTER TABLE role_permissions DROP COLUMN IF EXISTS scope;
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,115 @@
+-- +goose Up
+-- Description: Month-end stock balance snapshots for as-of-date queries.
+-- A snapshot row holds the balance of a warehouse+product before period_end
+-- (the first instant of the next month): the sum of all movements with
+-- period < period_end. As-of-date balances read the nearest snapshot and add
+-- the movements after it, instead of summing the whole history.
+--
+-- Invariant: for every period in reg_stock_snapshot_periods, each
+-- warehouse+product with movements before it has a row with the exact
+-- balance. The worker builds new periods; back-dated postings and unpostings
+-- keep existing rows exact through the triggers below.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE reg_stock_snapshot_periods (
+    period_end TIMESTAMPTZ PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE reg_stock_snapshot_periods IS 'Регистр остатков товаров — построенные периоды снимков остатков';
+
+CREATE TABLE reg_stock_snapshots (
+    period_end      TIMESTAMPTZ NOT NULL REFERENCES reg_stock_snapshot_periods (period_end) ON DELETE CASCADE,
+    warehouse_id    UUID        NOT NULL,
+    nomenclature_id UUID        NOT NULL,
+    quantity        BIGINT      NOT NULL,
+    PRIMARY KEY (warehouse_id, nomenclature_id, period_end)
+);
+
+COMMENT ON TABLE reg_stock_snapshots IS 'Регистр остатков товаров — снимки остатков на конец месяца';
+COMMENT ON COLUMN reg_stock_snapshots.period_end IS 'Exclusive end of the month: the balance covers movements with period < period_end';
+
+CREATE INDEX idx_reg_stock_snapshots_period
+    ON reg_stock_snapshots (period_end);
+
+-- Incremental movements after a snapshot, per warehouse+product.
+CREATE INDEX idx_reg_stock_movements_key_period
+    ON reg_stock_movements (warehouse_id, nomenclature_id, period);
+
+-- ── INSERT trigger: add back-dated movements to later snapshots ─────────────
+-- Movements of the current month match no period: the common case is a
+-- lookup in the (small) periods table.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_stock_snapshots_on_insert()
+RETURNS TRIGGER AS $func$
+BEGIN
+    INSERT INTO reg_stock_snapshots (period_end, warehouse_id, nomenclature_id, quantity)
+    SELECT
+        p.period_end,
+        n.warehouse_id,
+        n.nomenclature_id,
+        SUM(CASE WHEN n.record_type = 'receipt' THEN n.quantity ELSE -n.quantity END)
+    FROM new_rows n
+    JOIN reg_stock_snapshot_periods p ON p.period_end > n.period
+    GROUP BY p.period_end, n.warehouse_id, n.nomenclature_id
+    ON CONFLICT (warehouse_id, nomenclature_id, period_end) DO UPDATE SET
+        quantity = reg_stock_snapshots.quantity + EXCLUDED.quantity;
+
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_stock_movements_snapshots_insert
+    AFTER INSERT ON reg_stock_movements
+    REFERENCING NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION update_stock_snapshots_on_insert();
+
+-- ── DELETE trigger: reverse deleted movements in later snapshots ────────────
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION update_stock_snapshots_on_delete()
+RETURNS TRIGGER AS $func$
+BEGIN
+    UPDATE reg_stock_snapshots s SET
+        quantity = s.quantity - d.quantity
+    FROM (
+        SELECT
+            p.period_end,
+            o.warehouse_id,
+            o.nomenclature_id,
+            SUM(CASE WHEN o.record_type = 'receipt' THEN o.quantity ELSE -o.quantity END) AS quantity
+        FROM old_rows o
+        JOIN reg_stock_snapshot_periods p ON p.period_end > o.period
+        GROUP BY p.period_end, o.warehouse_id, o.nomenclature_id
+    ) d
+    WHERE s.period_end = d.period_end
+      AND s.warehouse_id = d.warehouse_id
+      AND s.nomenclature_id = d.nomenclature_id;
+
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_stock_movements_snapshots_delete
+    AFTER DELETE ON reg_stock_movements
+    REFERENCING OLD TABLE AS old_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION update_stock_snapshots_on_delete();
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DROP TRIGGER IF EXISTS trg_stock_movements_snapshots_insert ON reg_stock_movements;
+DROP TRIGGER IF EXISTS trg_stock_movements_snapshots_delete ON reg_stock_movements;
+DROP FUNCTION IF EXISTS update_stock_snapshots_on_insert();
+DROP FUNCTION IF EXISTS update_stock_snapshots_on_delete();
+DROP INDEX IF EXISTS idx_reg_stock_movements_key_period;
+DROP TABLE IF EXISTS reg_stock_snapshots;
+DROP TABLE IF EXISTS reg_stock_snapshot_periods;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,50 @@
+-- +goose Up
+-- Description: Declarative uniqueness rules (e.g. "counterparty INN+KPP
+-- unique"). Each rule owns a partial unique index on the entity table,
+-- created at runtime by the system API (uq_rule_<table>_<name>); a violation
+-- of that index is reported as DUPLICATE_ENTRY referencing the rule.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE sys_unique_rules (
+    id               UUID         PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    entity_type      VARCHAR(50)  NOT NULL,
+    name             VARCHAR(40)  NOT NULL,
+    fields           TEXT[]       NOT NULL,
+    case_insensitive BOOLEAN      NOT NULL DEFAULT FALSE,
+    message          TEXT,
+    index_name       VARCHAR(63)  NOT NULL,
+    created_at       TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_unique_rule UNIQUE (entity_type, name),
+    CONSTRAINT uq_unique_rule_index UNIQUE (index_name),
+    CONSTRAINT chk_unique_rule_fields CHECK (cardinality(fields) > 0)
+);
+
+CREATE TRIGGER trg_sys_unique_rules_updated_at
+    BEFORE UPDATE ON sys_unique_rules
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE sys_unique_rules IS 'Правила уникальности реквизитов (частичные уникальные индексы)';
+COMMENT ON COLUMN sys_unique_rules.fields IS 'JSON field names; custom fields as attributes.<name>';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+-- +goose StatementBegin
+DO $$
+DECLARE
+    idx TEXT;
+BEGIN
+    FOR idx IN SELECT index_name FROM sys_unique_rules LOOP
+        EXECUTE format('DROP INDEX IF EXISTS %I', idx);
+    END LOOP;
+END;
+$$;
+-- +goose StatementEnd
+
+DROP TABLE IF EXISTS sys_unique_rules;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
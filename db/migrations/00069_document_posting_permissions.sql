@@ -0,0 +1,50 @@
+-- +goose Up
+-- Description: Posting and unposting become permissions of their own per
+-- document type, in the "document:<type>:<action>" form the routes and
+-- document services check. The legacy "<type>.post" / "<type>.unpost" codes
+-- are renamed in place, so existing role grants (and their scopes) carry
+-- over; document types without posting codes get new ones, granted to Admin.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+UPDATE permissions
+SET code = 'document:' || resource || ':' || action
+WHERE action IN ('post', 'unpost')
+  AND code = resource || '.' || action
+  AND resource IN ('goods_receipt', 'goods_issue', 'sales_order', 'purchase_order',
+                   'goods_transfer', 'manual_adjustment');
+
+INSERT INTO permissions (code, name, description, resource, action) VALUES
+    ('document:crypto_invoice:post',      'Проведение крипто-счетов',               'Post crypto invoices',      'crypto_invoice', 'post'),
+    ('document:crypto_invoice:unpost',    'Отмена проведения крипто-счетов',        'Unpost crypto invoices',    'crypto_invoice', 'unpost'),
+    ('document:crypto_payment:post',      'Проведение крипто-платежей',             'Post crypto payments',      'crypto_payment', 'post'),
+    ('document:crypto_payment:unpost',    'Отмена проведения крипто-платежей',      'Unpost crypto payments',    'crypto_payment', 'unpost'),
+    ('document:crypto_withdrawal:post',   'Проведение крипто-выводов',              'Post crypto withdrawals',   'crypto_withdrawal', 'post'),
+    ('document:crypto_withdrawal:unpost', 'Отмена проведения крипто-выводов',       'Unpost crypto withdrawals', 'crypto_withdrawal', 'unpost'),
+    ('document:crypto_sweep:post',        'Проведение свипов',                      'Post crypto sweeps',        'crypto_sweep', 'post'),
+    ('document:crypto_sweep:unpost',      'Отмена проведения свипов',               'Unpost crypto sweeps',      'crypto_sweep', 'unpost')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'b0000000-0000-0000-0000-000000000001', id FROM permissions
+WHERE resource IN ('crypto_invoice', 'crypto_payment', 'crypto_withdrawal', 'crypto_sweep')
+  AND action IN ('post', 'unpost')
+ON CONFLICT DO NOTHING;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DELETE FROM permissions
+WHERE code IN ('document:crypto_invoice:post', 'document:crypto_invoice:unpost',
+               'document:crypto_payment:post', 'document:crypto_payment:unpost',
+               'document:crypto_withdrawal:post', 'document:crypto_withdrawal:unpost',
+               'document:crypto_sweep:post', 'document:crypto_sweep:unpost');
+
+UPDATE permissions
+SET code = resource || '.' || action
+WHERE action IN ('post', 'unpost')
+  AND code = 'document:' || resource || ':' || action;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
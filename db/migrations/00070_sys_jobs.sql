@@ -0,0 +1,45 @@
+-- +goose Up
+-- Description: Background job queue.
+-- Domain code enqueues typed jobs (report generation, imports, webhook
+-- delivery) in its own transaction; the worker claims due jobs with
+-- FOR UPDATE SKIP LOCKED, retries failures with backoff and moves jobs that
+-- exhausted their attempts to the dead status for an administrator.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE sys_jobs (
+    id           UUID          PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    job_type     VARCHAR(100)  NOT NULL,
+    payload      JSONB         NOT NULL DEFAULT '{}'::jsonb,
+    status       VARCHAR(20)   NOT NULL DEFAULT 'pending',  -- pending | running | done | dead
+    attempts     INT           NOT NULL DEFAULT 0,
+    max_attempts INT           NOT NULL DEFAULT 5,
+    run_at       TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMPTZ,
+    last_error   TEXT          NOT NULL DEFAULT '',
+    created_by   UUID          REFERENCES users(id) ON DELETE SET NULL,
+    created_at   TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    finished_at  TIMESTAMPTZ,
+
+    CONSTRAINT chk_sys_jobs_status CHECK (status IN ('pending', 'running', 'done', 'dead')),
+    CONSTRAINT chk_sys_jobs_attempts CHECK (attempts >= 0 AND max_attempts > 0)
+);
+
+CREATE INDEX idx_sys_jobs_due ON sys_jobs (run_at) WHERE status = 'pending';
+CREATE INDEX idx_sys_jobs_running ON sys_jobs (locked_until) WHERE status = 'running';
+CREATE INDEX idx_sys_jobs_status ON sys_jobs (status, created_at DESC);
+
+COMMENT ON TABLE  sys_jobs              IS 'Очередь фоновых заданий';
+COMMENT ON COLUMN sys_jobs.job_type     IS 'Registered handler name, e.g. reports.generate';
+COMMENT ON COLUMN sys_jobs.run_at       IS 'Earliest time of the next attempt (backoff after a failure)';
+COMMENT ON COLUMN sys_jobs.locked_until IS 'Worker lease of a running job; an expired lease makes it due again';
+COMMENT ON COLUMN sys_jobs.last_error   IS 'Error of the last failed attempt';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DROP TABLE IF EXISTS sys_jobs;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
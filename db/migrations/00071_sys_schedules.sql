@@ -0,0 +1,46 @@
+-- +goose Up
+-- Description: Scheduled background jobs.
+-- A schedule enqueues a job of a type with fixed params into sys_jobs on a
+-- CRON schedule; the worker checks due schedules every few seconds and
+-- applies the misfire policy to occurrences it missed while it was down.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE sys_schedules (
+    id             UUID          PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    name           VARCHAR(255)  NOT NULL,
+    job_type       VARCHAR(100)  NOT NULL,
+    params         JSONB         NOT NULL DEFAULT '{}'::jsonb,
+    cron_expr      VARCHAR(100)  NOT NULL,
+    timezone       VARCHAR(64)   NOT NULL DEFAULT 'UTC',
+    misfire_policy VARCHAR(20)   NOT NULL DEFAULT 'run_once',
+    max_attempts   INT           NOT NULL DEFAULT 5,
+    active         BOOLEAN       NOT NULL DEFAULT TRUE,
+    next_run_at    TIMESTAMPTZ,
+    last_run_at    TIMESTAMPTZ,
+    locked_until   TIMESTAMPTZ,
+    author_id      UUID          REFERENCES users(id) ON DELETE SET NULL,
+    version        INT           NOT NULL DEFAULT 1,
+    created_at     TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_sys_schedules_misfire CHECK (misfire_policy IN ('run_once', 'catch_up', 'skip')),
+    CONSTRAINT chk_sys_schedules_max_attempts CHECK (max_attempts > 0)
+);
+
+CREATE INDEX idx_sys_schedules_due ON sys_schedules (next_run_at) WHERE active = TRUE;
+
+COMMENT ON TABLE  sys_schedules                IS 'Расписания фоновых заданий';
+COMMENT ON COLUMN sys_schedules.cron_expr      IS '6-field CRON (sec min hour dom month dow) or descriptor, e.g. @daily';
+COMMENT ON COLUMN sys_schedules.params         IS 'Payload of every enqueued job';
+COMMENT ON COLUMN sys_schedules.misfire_policy IS 'Missed occurrences: run_once (one job), catch_up (a job each), skip (none)';
+COMMENT ON COLUMN sys_schedules.locked_until   IS 'Worker lease while occurrences are being enqueued';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DROP TABLE IF EXISTS sys_schedules;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
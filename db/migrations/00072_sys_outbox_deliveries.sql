@@ -0,0 +1,20 @@
+-- +goose Up
+-- Description: Per-sink delivery tracking of outbox messages.
+-- Domain events are delivered to several sinks (log, Kafka, NATS); a failure
+-- of one sink retries the message, and the sinks listed in deliveries are
+-- skipped on the retry so they do not receive the event again.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+ALTER TABLE sys_outbox ADD COLUMN deliveries JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+COMMENT ON COLUMN sys_outbox.deliveries IS 'Sinks that received the message: {"<sink>": "<delivered at>"}';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+ALTER TABLE sys_outbox DROP COLUMN IF EXISTS deliveries;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
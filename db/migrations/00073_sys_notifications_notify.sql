@@ -0,0 +1,64 @@
+-- +goose Up
+-- Description: NOTIFY on sys_notifications changes for the notification stream.
+-- The API server LISTENs on notifications_changed and pushes new notifications
+-- and unread counters to the SSE clients of the user, whichever process
+-- (server, worker) created the notification.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+-- One notification per inserted row: the stream loads and sends the row.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_sys_notification_created()
+RETURNS TRIGGER AS $func$
+BEGIN
+    PERFORM pg_notify('notifications_changed', json_build_object(
+        'op',     'created',
+        'id',     NEW.id,
+        'userId', NEW.user_id
+    )::text);
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- One notification per user and statement: "mark all as read" updates many
+-- rows, the stream only needs to refresh the unread counter once.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_sys_notifications_updated()
+RETURNS TRIGGER AS $func$
+DECLARE
+    v_user_id UUID;
+BEGIN
+    FOR v_user_id IN SELECT DISTINCT user_id FROM changed_rows LOOP
+        PERFORM pg_notify('notifications_changed', json_build_object(
+            'op',     'updated',
+            'userId', v_user_id
+        )::text);
+    END LOOP;
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_sys_notifications_created
+    AFTER INSERT ON sys_notifications
+    FOR EACH ROW
+    EXECUTE FUNCTION notify_sys_notification_created();
+
+CREATE TRIGGER trg_sys_notifications_updated
+    AFTER UPDATE ON sys_notifications
+    REFERENCING NEW TABLE AS changed_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION notify_sys_notifications_updated();
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DROP TRIGGER IF EXISTS trg_sys_notifications_updated ON sys_notifications;
+DROP TRIGGER IF EXISTS trg_sys_notifications_created ON sys_notifications;
+DROP FUNCTION IF EXISTS notify_sys_notifications_updated();
+DROP FUNCTION IF EXISTS notify_sys_notification_created();
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,34 @@
+-- +goose Up
+-- Description: Closed accounting periods per organization. Documents of an
+-- organization dated before closed_until cannot be posted, unposted, changed
+-- or deleted, unless the user holds period:override. The tenant-wide
+-- period.closedUntil setting still applies to every organization.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE IF NOT EXISTS sys_period_locks (
+    organization_id UUID PRIMARY KEY REFERENCES cat_organizations(id) ON DELETE CASCADE,
+    closed_until    DATE NOT NULL,
+    comment         TEXT NOT NULL DEFAULT '',
+    closed_by       UUID REFERENCES users(id) ON DELETE SET NULL,
+    closed_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO permissions (code, name, description, resource, action) VALUES
+    ('period:override', 'Изменение документов закрытого периода', 'Change documents dated in closed periods', 'period', 'override')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'b0000000-0000-0000-0000-000000000001', id FROM permissions
+WHERE code = 'period:override'
+ON CONFLICT DO NOTHING;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DELETE FROM permissions WHERE code = 'period:override';
+DROP TABLE IF EXISTS sys_period_locks;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
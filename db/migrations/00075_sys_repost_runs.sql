@@ -0,0 +1,35 @@
+-- +goose Up
+-- Description: Runs of the document re-posting tool. A run re-posts every
+-- posted document dated in [date_from, date_to] in chronological order; the
+-- worker executes it as a posting.repost job and stores its progress here.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE IF NOT EXISTS sys_repost_runs (
+    id                 UUID PRIMARY KEY,
+    status             VARCHAR(20) NOT NULL DEFAULT 'pending',
+    date_from          DATE        NOT NULL,
+    date_to            DATE        NOT NULL,
+    document_types     TEXT[]      NOT NULL DEFAULT '{}',
+    total              INTEGER     NOT NULL DEFAULT 0,
+    processed          INTEGER     NOT NULL DEFAULT 0,
+    failed             INTEGER     NOT NULL DEFAULT 0,
+    last_document_date TIMESTAMPTZ,
+    errors             JSONB       NOT NULL DEFAULT '[]',
+    error_message      TEXT        NOT NULL DEFAULT '',
+    requested_by       UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    started_at         TIMESTAMPTZ,
+    finished_at        TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_sys_repost_runs_created ON sys_repost_runs (created_at DESC);
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DROP TABLE IF EXISTS sys_repost_runs;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
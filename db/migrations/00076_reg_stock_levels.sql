@@ -0,0 +1,68 @@
+-- +goose Up
+-- Description: Minimum/maximum stock levels per warehouse and nomenclature
+-- (регистр сведений "Нормы запасов") and the active low-stock alerts raised
+-- by the worker when a balance falls below its minimum.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE reg_stock_levels (
+    warehouse_id    UUID        NOT NULL REFERENCES cat_warehouses(id) ON DELETE CASCADE,
+    nomenclature_id UUID        NOT NULL REFERENCES cat_nomenclatures(id) ON DELETE CASCADE,
+    min_quantity    BIGINT      NOT NULL DEFAULT 0,
+    max_quantity    BIGINT,
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (warehouse_id, nomenclature_id),
+    CONSTRAINT chk_stock_level_min CHECK (min_quantity >= 0),
+    CONSTRAINT chk_stock_level_max CHECK (max_quantity IS NULL OR max_quantity >= min_quantity)
+);
+
+COMMENT ON TABLE reg_stock_levels IS 'Нормы запасов — минимальный и максимальный остаток товара на складе';
+COMMENT ON COLUMN reg_stock_levels.min_quantity IS 'Quantity in minor units; a balance below it raises a low-stock alert';
+COMMENT ON COLUMN reg_stock_levels.max_quantity IS 'Quantity in minor units replenished up to; NULL = up to the minimum';
+
+CREATE INDEX idx_reg_stock_levels_nomenclature
+    ON reg_stock_levels (nomenclature_id);
+
+-- One row per key below its minimum; removed when the balance recovers, so
+-- a shortage is notified once.
+CREATE TABLE reg_stock_level_alerts (
+    warehouse_id    UUID        NOT NULL,
+    nomenclature_id UUID        NOT NULL,
+    quantity        BIGINT      NOT NULL,
+    min_quantity    BIGINT      NOT NULL,
+    raised_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (warehouse_id, nomenclature_id)
+);
+
+COMMENT ON TABLE reg_stock_level_alerts IS 'Нормы запасов — активные уведомления о низком остатке';
+
+-- ── Permissions ────────────────────────────────────────────────────────────
+INSERT INTO permissions (code, name, description, resource, action) VALUES
+    ('stock_level.manage', 'Нормы запасов',                    'Set minimum and maximum stock levels',      'stock_level', 'manage'),
+    ('stock_level.alerts', 'Уведомления о низких остатках', 'Receive low-stock alerts (email, in-app)', 'stock_level', 'alerts')
+ON CONFLICT (code) DO NOTHING;
+
+-- Admin: full access; warehouse keeper: manages levels and gets the alerts
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'b0000000-0000-0000-0000-000000000001', id FROM permissions
+WHERE resource = 'stock_level'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT 'b0000000-0000-0000-0000-000000000004', id FROM permissions
+WHERE resource = 'stock_level'
+ON CONFLICT DO NOTHING;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DELETE FROM role_permissions WHERE permission_id IN (SELECT id FROM permissions WHERE resource = 'stock_level');
+DELETE FROM permissions WHERE resource = 'stock_level';
+
+DROP TABLE IF EXISTS reg_stock_level_alerts;
+DROP TABLE IF EXISTS reg_stock_levels;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,25 @@
+-- +goose Up
+-- Description: Packaging units of a nomenclature (табличная часть "Упаковки"):
+-- how many base units one unit (box, pallet) holds. Document lines entered in
+-- such a unit are converted to base units on posting.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE cat_nomenclature_units (
+    nomenclature_id UUID           NOT NULL REFERENCES cat_nomenclatures(id) ON DELETE CASCADE,
+    unit_id         UUID           NOT NULL REFERENCES cat_units(id),
+    coefficient     NUMERIC(15,6)  NOT NULL,
+    line_no         INT            NOT NULL DEFAULT 1,
+    PRIMARY KEY (nomenclature_id, unit_id),
+    CONSTRAINT chk_nomenclature_unit_coefficient CHECK (coefficient > 0)
+);
+
+COMMENT ON TABLE cat_nomenclature_units IS 'Номенклатура — упаковки (единицы, кратные базовой)';
+COMMENT ON COLUMN cat_nomenclature_units.coefficient IS 'Base units of the nomenclature in one unit_id';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+DROP TABLE IF EXISTS cat_nomenclature_units;
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
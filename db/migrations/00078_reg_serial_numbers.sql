@@ -0,0 +1,64 @@
+-- +goose Up
+-- Description: Serial numbers of goods tracked per unit (track_serial):
+-- serials captured on goods receipt and goods issue lines, the serial
+-- movements recorded on posting and the serial pool with the current state
+-- of every serial (in stock or not, warehouse of the last receipt).
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+ALTER TABLE doc_goods_receipt_lines ADD COLUMN serials TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE doc_goods_issue_lines   ADD COLUMN serials TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN doc_goods_receipt_lines.serials IS 'Serial numbers received (nomenclature with track_serial)';
+COMMENT ON COLUMN doc_goods_issue_lines.serials IS 'Serial numbers issued (nomenclature with track_serial)';
+
+CREATE TABLE reg_serial_movements (
+    line_id          UUID         PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    recorder_id      UUID         NOT NULL,
+    recorder_type    VARCHAR(50)  NOT NULL,
+    recorder_version INT          NOT NULL DEFAULT 1,
+    period           TIMESTAMPTZ  NOT NULL,
+    record_type      VARCHAR(10)  NOT NULL,
+    warehouse_id     UUID         NOT NULL,
+    nomenclature_id  UUID         NOT NULL,
+    serial_number    VARCHAR(100) NOT NULL,
+    created_at       TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_serial_record_type CHECK (record_type IN ('receipt', 'expense')),
+    CONSTRAINT chk_serial_number_set  CHECK (serial_number <> '')
+);
+
+COMMENT ON TABLE reg_serial_movements IS 'Регистр серийных номеров — движения';
+
+CREATE INDEX idx_reg_serial_movements_recorder
+    ON reg_serial_movements (recorder_id, recorder_version);
+CREATE INDEX idx_reg_serial_movements_serial
+    ON reg_serial_movements (nomenclature_id, serial_number, period);
+
+-- State of every serial ever received, refreshed from the movements.
+CREATE TABLE reg_serial_numbers (
+    nomenclature_id UUID         NOT NULL,
+    serial_number   VARCHAR(100) NOT NULL,
+    warehouse_id    UUID,
+    balance         INT          NOT NULL DEFAULT 0,
+    updated_at      TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (nomenclature_id, serial_number)
+);
+
+COMMENT ON TABLE reg_serial_numbers IS 'Регистр серийных номеров — пул серийных номеров';
+COMMENT ON COLUMN reg_serial_numbers.balance IS 'Receipts minus expenses: 1 = in stock, 0 = issued';
+COMMENT ON COLUMN reg_serial_numbers.warehouse_id IS 'Warehouse of the last receipt';
+
+CREATE INDEX idx_reg_serial_numbers_in_stock
+    ON reg_serial_numbers (nomenclature_id, warehouse_id) WHERE balance > 0;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DROP TABLE IF EXISTS reg_serial_numbers;
+DROP TABLE IF EXISTS reg_serial_movements;
+ALTER TABLE doc_goods_issue_lines   DROP COLUMN IF EXISTS serials;
+ALTER TABLE doc_goods_receipt_lines DROP COLUMN IF EXISTS serials;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
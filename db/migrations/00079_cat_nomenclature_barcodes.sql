@@ -0,0 +1,25 @@
+-- +goose Up
+-- Description: Additional barcodes of a nomenclature (табличная часть
+-- "Штрихкоды"), e.g. the barcodes of its packages or of other suppliers.
+-- The primary barcode stays in cat_nomenclatures.barcode; lookup by barcode
+-- searches both.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE cat_nomenclature_barcodes (
+    barcode         VARCHAR(50) PRIMARY KEY,
+    nomenclature_id UUID        NOT NULL REFERENCES cat_nomenclatures(id) ON DELETE CASCADE,
+    line_no         INT         NOT NULL DEFAULT 1,
+    CONSTRAINT chk_nomenclature_barcode_set CHECK (barcode <> '')
+);
+
+COMMENT ON TABLE cat_nomenclature_barcodes IS 'Номенклатура — дополнительные штрихкоды';
+
+CREATE INDEX idx_cat_nomenclature_barcodes_item ON cat_nomenclature_barcodes (nomenclature_id, line_no);
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+DROP TABLE IF EXISTS cat_nomenclature_barcodes;
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,44 @@
+-- +goose Up
+-- Description: Image galleries of catalog items (nomenclature photos,
+-- counterparty logos). Only metadata is stored here; the original and its
+-- thumbnail live in the attachment blob storage under storage_key and
+-- thumbnail_key.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE sys_images (
+    id            UUID          PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    entity_type   VARCHAR(100)  NOT NULL,
+    entity_id     UUID          NOT NULL,
+    file_name     VARCHAR(255)  NOT NULL,
+    content_type  VARCHAR(255)  NOT NULL,
+    size          BIGINT        NOT NULL,
+    width         INT           NOT NULL,
+    height        INT           NOT NULL,
+    storage_key   VARCHAR(500)  NOT NULL,
+    thumbnail_key VARCHAR(500)  NOT NULL,
+    sort_order    INT           NOT NULL DEFAULT 0,
+    is_primary    BOOLEAN       NOT NULL DEFAULT FALSE,
+    uploaded_by   UUID          REFERENCES users(id) ON DELETE SET NULL,
+    created_at    TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_sys_images_size CHECK (size > 0),
+    CONSTRAINT chk_sys_images_dimensions CHECK (width > 0 AND height > 0),
+    CONSTRAINT uq_sys_images_storage_key UNIQUE (storage_key)
+);
+
+CREATE INDEX idx_sys_images_entity ON sys_images (entity_type, entity_id, sort_order);
+
+COMMENT ON TABLE sys_images IS 'Изображения элементов справочников (фото номенклатуры, логотипы контрагентов)';
+COMMENT ON COLUMN sys_images.entity_type IS 'Entity name of the owner, e.g. nomenclature or counterparty';
+COMMENT ON COLUMN sys_images.sort_order IS 'Position in the gallery, ascending';
+COMMENT ON COLUMN sys_images.is_primary IS 'Main image of the item; the first uploaded image until changed';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+DROP TABLE IF EXISTS sys_images;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
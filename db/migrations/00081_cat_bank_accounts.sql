@@ -0,0 +1,83 @@
+-- +goose Up
+-- Description: Counterparty bank accounts catalog (Справочник "Банковские счета контрагентов")
+-- +goose StatementBegin
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE cat_bank_accounts (
+    -- Base fields
+    id            UUID        PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    deletion_mark BOOLEAN     NOT NULL DEFAULT FALSE,
+    version       INT         NOT NULL DEFAULT 1,
+    attributes    JSONB       DEFAULT '{}',
+
+    -- CDC
+    _deleted_at TIMESTAMPTZ,
+    _txid       BIGINT DEFAULT txid_current(),
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    -- Catalog fields
+    code      VARCHAR(20)  NOT NULL,
+    name      VARCHAR(255) NOT NULL,
+    parent_id UUID,
+    is_folder BOOLEAN      NOT NULL DEFAULT FALSE,
+
+    -- Bank account fields
+    counterparty_id UUID         NOT NULL REFERENCES cat_counterparties(id),
+    account_number  VARCHAR(20)  NOT NULL,
+    bik             VARCHAR(9)   NOT NULL,
+    bank_name       VARCHAR(255) NOT NULL,
+    corr_account    VARCHAR(20),
+    currency_id     UUID REFERENCES cat_currencies(id),
+    is_default      BOOLEAN      NOT NULL DEFAULT FALSE,
+
+    CONSTRAINT chk_bank_account_number CHECK (account_number ~ '^[0-9]{20}$'),
+    CONSTRAINT chk_bank_account_bik    CHECK (bik ~ '^[0-9]{9}$'),
+    CONSTRAINT chk_bank_account_corr   CHECK (corr_account IS NULL OR corr_account ~ '^[0-9]{20}$')
+);
+
+-- Unique indexes
+CREATE UNIQUE INDEX uq_cat_bank_accounts_code ON cat_bank_accounts (code) WHERE deletion_mark = FALSE;
+CREATE UNIQUE INDEX uq_cat_bank_accounts_number
+    ON cat_bank_accounts (counterparty_id, bik, account_number) WHERE deletion_mark = FALSE;
+-- At most one default account per counterparty
+CREATE UNIQUE INDEX uq_cat_bank_accounts_default
+    ON cat_bank_accounts (counterparty_id) WHERE is_default AND deletion_mark = FALSE;
+
+-- Search / filter indexes
+CREATE INDEX idx_cat_bank_accounts_counterparty ON cat_bank_accounts (counterparty_id);
+CREATE INDEX idx_cat_bank_accounts_currency     ON cat_bank_accounts (currency_id) WHERE currency_id IS NOT NULL;
+CREATE INDEX idx_cat_bank_accounts_name         ON cat_bank_accounts USING gin (name gin_trgm_ops);
+CREATE INDEX idx_cat_bank_accounts_code_trgm    ON cat_bank_accounts USING gin (code gin_trgm_ops);
+
+-- CDC indexes & triggers
+CREATE INDEX idx_cat_bank_accounts_txid ON cat_bank_accounts (_txid) WHERE _deleted_at IS NULL;
+
+CREATE TRIGGER trg_cat_bank_accounts_txid
+    BEFORE UPDATE ON cat_bank_accounts
+    FOR EACH ROW EXECUTE FUNCTION update_txid_column();
+
+CREATE TRIGGER trg_cat_bank_accounts_soft_delete
+    BEFORE UPDATE OF deletion_mark ON cat_bank_accounts
+    FOR EACH ROW EXECUTE FUNCTION soft_delete_with_timestamp();
+
+CREATE TRIGGER trg_cat_bank_accounts_updated_at
+    BEFORE UPDATE ON cat_bank_accounts
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Keyset pagination
+CREATE INDEX idx_cat_bank_accounts_name_id ON cat_bank_accounts (name ASC, id ASC);
+
+COMMENT ON TABLE cat_bank_accounts IS 'Справочник Банковские счета контрагентов';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+DROP TABLE IF EXISTS cat_bank_accounts CASCADE;
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- Description: Contact persons of a counterparty (табличная часть
+-- "Контактные лица"). Contacts keep their id across saves so they can be
+-- edited through nested endpoints; search by phone compares the last 10
+-- digits, ignoring formatting and the +7/8 prefix.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE cat_counterparty_contacts (
+    id              UUID         PRIMARY KEY,
+    counterparty_id UUID         NOT NULL REFERENCES cat_counterparties(id) ON DELETE CASCADE,
+    line_no         INT          NOT NULL DEFAULT 1,
+    name            VARCHAR(255) NOT NULL,
+    role            VARCHAR(100),
+    phone           VARCHAR(50),
+    email           VARCHAR(255),
+    CONSTRAINT chk_counterparty_contact_name CHECK (name <> '')
+);
+
+COMMENT ON TABLE cat_counterparty_contacts IS 'Контрагенты — контактные лица';
+
+CREATE INDEX idx_cat_counterparty_contacts_cp ON cat_counterparty_contacts (counterparty_id, line_no);
+CREATE INDEX idx_cat_counterparty_contacts_phone
+    ON cat_counterparty_contacts (right(regexp_replace(phone, '\D', '', 'g'), 10)) WHERE phone IS NOT NULL;
+CREATE INDEX idx_cat_counterparty_contacts_email
+    ON cat_counterparty_contacts (lower(email)) WHERE email IS NOT NULL;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+DROP TABLE IF EXISTS cat_counterparty_contacts;
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,30 @@
+-- +goose Up
+-- Description: Pending email changes awaiting confirmation from the new
+-- address (change-email flow). Only the SHA-256 hash of the verification
+-- token is stored. IF NOT EXISTS: development databases may already have the
+-- table from an earlier revision of 00003_auth.sql.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE IF NOT EXISTS email_change_requests (
+    id           UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    old_email    VARCHAR(255) NOT NULL,
+    new_email    VARCHAR(255) NOT NULL,
+    token_hash   VARCHAR(255) NOT NULL UNIQUE,
+    expires_at   TIMESTAMPTZ  NOT NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    confirmed_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_change_requests_user_pending
+    ON email_change_requests (user_id)
+    WHERE confirmed_at IS NULL AND cancelled_at IS NULL;
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+DROP TABLE IF EXISTS email_change_requests;
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
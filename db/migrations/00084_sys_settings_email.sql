@@ -0,0 +1,22 @@
+-- +goose Up
+-- Description: Outgoing email provider settings on sys_settings. The provider
+-- config is stored without secrets; the SMTP password or API key lives
+-- encrypted in email_secret.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+ALTER TABLE sys_settings
+    ADD COLUMN IF NOT EXISTS email        JSONB NOT NULL DEFAULT '{"provider": "platform"}',
+    ADD COLUMN IF NOT EXISTS email_secret BYTEA;
+
+COMMENT ON COLUMN sys_settings.email        IS 'Outgoing email provider: platform | smtp | sendgrid (no secrets)';
+COMMENT ON COLUMN sys_settings.email_secret IS 'AES-256-GCM encrypted SMTP password or provider API key';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+ALTER TABLE sys_settings
+    DROP COLUMN IF EXISTS email_secret,
+    DROP COLUMN IF EXISTS email;
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,35 @@
+-- +goose Up
+-- Description: Stock register corrections (system document). Admin bulk
+-- corrections (wrong warehouse, merged products) record adjustment movements
+-- in reg_stock_movements with recorder_id = id and
+-- recorder_type = 'stock_correction'; original movements are never modified.
+-- IF NOT EXISTS: development databases may already have the table from an
+-- earlier revision of 00014_reg_stock.sql.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE IF NOT EXISTS reg_stock_corrections (
+    id                     UUID        PRIMARY KEY,
+    reason                 TEXT        NOT NULL,
+    filter                 JSONB       NOT NULL,
+    target_warehouse_id    UUID,
+    target_nomenclature_id UUID,
+    movement_count         INT         NOT NULL DEFAULT 0,
+    created_by             UUID,
+    created_at             TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_stock_correction_target
+        CHECK (target_warehouse_id IS NOT NULL OR target_nomenclature_id IS NOT NULL)
+);
+
+COMMENT ON TABLE reg_stock_corrections IS 'Регистр остатков товаров — корректировки движений (системный документ)';
+COMMENT ON COLUMN reg_stock_corrections.filter IS 'Source selection: recorderIds, optional warehouseId/nomenclatureId';
+
+CREATE INDEX IF NOT EXISTS idx_reg_stock_corrections_created
+    ON reg_stock_corrections (created_at DESC);
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+DROP TABLE IF EXISTS reg_stock_corrections;
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
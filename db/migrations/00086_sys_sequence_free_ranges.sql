@@ -0,0 +1,26 @@
+-- +goose Up
+-- Description: Unused remainders of cached numerator ranges released on
+-- shutdown that could not be returned to sys_sequences. Claimed before new
+-- ranges. IF NOT EXISTS: development databases may already have the table
+-- from an earlier revision of 00002_sys_core.sql.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE IF NOT EXISTS sys_sequence_free_ranges (
+    id         BIGSERIAL    PRIMARY KEY,
+    key        VARCHAR(100) NOT NULL,
+    next_val   BIGINT       NOT NULL,
+    max_val    BIGINT       NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_sys_sequence_free_ranges_bounds CHECK (next_val <= max_val)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sys_sequence_free_ranges_key
+    ON sys_sequence_free_ranges (key, next_val);
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+DROP TABLE IF EXISTS sys_sequence_free_ranges;
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
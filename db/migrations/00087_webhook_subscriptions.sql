@@ -0,0 +1,107 @@
+-- +goose Up
+-- Description: Webhook subscriptions catalog (Справочник "Подписки на вебхуки")
+-- and the webhook delivery log. Outbox events are fanned out to the active
+-- subscriptions whose event filters match; each (event, subscription) pair is
+-- one delivery, retried with exponential backoff until it succeeds or runs
+-- out of attempts.
+-- +goose StatementBegin
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE cat_webhook_subscriptions (
+    -- Base fields
+    id            UUID        PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    deletion_mark BOOLEAN     NOT NULL DEFAULT FALSE,
+    version       INT         NOT NULL DEFAULT 1,
+    attributes    JSONB       DEFAULT '{}',
+
+    -- CDC
+    _deleted_at TIMESTAMPTZ,
+    _txid       BIGINT DEFAULT txid_current(),
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    -- Catalog fields
+    code      VARCHAR(20)  NOT NULL,
+    name      VARCHAR(255) NOT NULL,
+    parent_id UUID,
+    is_folder BOOLEAN      NOT NULL DEFAULT FALSE,
+
+    -- Subscription fields
+    url         TEXT         NOT NULL,
+    secret      VARCHAR(255) NOT NULL,
+    event_types TEXT[]       NOT NULL DEFAULT '{}',
+    is_active   BOOLEAN      NOT NULL DEFAULT TRUE
+);
+
+-- Unique indexes
+CREATE UNIQUE INDEX uq_cat_webhook_subscriptions_code ON cat_webhook_subscriptions (code) WHERE deletion_mark = FALSE;
+
+-- Search / filter indexes
+CREATE INDEX idx_cat_webhook_subscriptions_active ON cat_webhook_subscriptions (id) WHERE is_active AND deletion_mark = FALSE;
+CREATE INDEX idx_cat_webhook_subscriptions_name   ON cat_webhook_subscriptions USING gin (name gin_trgm_ops);
+
+-- CDC indexes & triggers
+CREATE INDEX idx_cat_webhook_subscriptions_txid ON cat_webhook_subscriptions (_txid) WHERE _deleted_at IS NULL;
+
+CREATE TRIGGER trg_cat_webhook_subscriptions_txid
+    BEFORE UPDATE ON cat_webhook_subscriptions
+    FOR EACH ROW EXECUTE FUNCTION update_txid_column();
+
+CREATE TRIGGER trg_cat_webhook_subscriptions_soft_delete
+    BEFORE UPDATE OF deletion_mark ON cat_webhook_subscriptions
+    FOR EACH ROW EXECUTE FUNCTION soft_delete_with_timestamp();
+
+CREATE TRIGGER trg_cat_webhook_subscriptions_updated_at
+    BEFORE UPDATE ON cat_webhook_subscriptions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Keyset pagination
+CREATE INDEX idx_cat_webhook_subscriptions_name_id ON cat_webhook_subscriptions (name ASC, id ASC);
+
+COMMENT ON TABLE cat_webhook_subscriptions IS 'Справочник Подписки на вебхуки';
+COMMENT ON COLUMN cat_webhook_subscriptions.secret IS 'HMAC-SHA256 signing key (X-Metapus-Signature)';
+COMMENT ON COLUMN cat_webhook_subscriptions.event_types IS 'Event filters: exact type, prefix.* or *; empty = all events';
+
+-- ── Delivery log ──────────────────────────────────────────────────────────
+CREATE TABLE sys_webhook_deliveries (
+    id               UUID        PRIMARY KEY DEFAULT gen_random_uuid_v7(),
+    subscription_id  UUID        NOT NULL REFERENCES cat_webhook_subscriptions(id) ON DELETE CASCADE,
+    outbox_id        UUID        NOT NULL,
+    event_type       VARCHAR(100) NOT NULL,
+    payload          JSONB       NOT NULL,
+    status           VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts         INT         NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_status_code INT,
+    last_error       TEXT,
+    response_time_ms INT,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    delivered_at     TIMESTAMPTZ,
+
+    CONSTRAINT chk_webhook_delivery_status CHECK (status IN ('pending', 'delivered', 'failed')),
+    -- Outbox retries must not fan an event out twice
+    CONSTRAINT uq_webhook_delivery_event UNIQUE (outbox_id, subscription_id)
+);
+
+CREATE INDEX idx_sys_webhook_deliveries_due
+    ON sys_webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_sys_webhook_deliveries_subscription
+    ON sys_webhook_deliveries (subscription_id, created_at DESC);
+CREATE INDEX idx_sys_webhook_deliveries_created
+    ON sys_webhook_deliveries (created_at DESC);
+
+COMMENT ON TABLE sys_webhook_deliveries IS 'Webhook delivery log: one row per event and subscription';
+COMMENT ON COLUMN sys_webhook_deliveries.id IS 'Also sent as X-Metapus-Delivery-ID, stable across retries';
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+DROP TABLE IF EXISTS sys_webhook_deliveries;
+DROP TABLE IF EXISTS cat_webhook_subscriptions CASCADE;
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+-- +goose StatementEnd
@@ -0,0 +1,200 @@
+-- +goose Up
+-- Description: Scope change notifications to the tenant schema.
+-- NOTIFY channels are database-wide: in a database shared by several tenants
+-- (one schema each) every tenant's listeners would receive every tenant's
+-- notifications. tenant_notify prefixes the payload with the schema
+-- ("t_tiny:<payload>") unless it is public, the schema of a tenant with its
+-- own database, so those payloads are unchanged. Listeners drop payloads of
+-- other schemas (tenant.Tenant.NotifyPayload).
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION tenant_notify(p_channel TEXT, p_payload TEXT)
+RETURNS void AS $func$
+BEGIN
+    IF current_schema() = 'public' THEN
+        PERFORM pg_notify(p_channel, p_payload);
+    ELSE
+        PERFORM pg_notify(p_channel, current_schema() || ':' || p_payload);
+    END IF;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_schema_change()
+RETURNS TRIGGER AS $func$
+BEGIN
+    PERFORM tenant_notify('schema_changed', COALESCE(NEW.entity_type, OLD.entity_type));
+    RETURN COALESCE(NEW, OLD);
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_feature_flags_change()
+RETURNS TRIGGER AS $func$
+BEGIN
+    PERFORM tenant_notify('feature_flags_changed', COALESCE(NEW.flag_name, OLD.flag_name));
+    RETURN COALESCE(NEW, OLD);
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_setting_values_changed()
+RETURNS TRIGGER AS $func$
+BEGIN
+    PERFORM tenant_notify('setting_values_changed', COALESCE(NEW.key, OLD.key));
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_entity_invalidated()
+RETURNS TRIGGER AS $func$
+DECLARE
+    v_row RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        v_row := OLD;
+    ELSE
+        v_row := NEW;
+    END IF;
+
+    PERFORM tenant_notify('entity_invalidated', json_build_object(
+        'entity',  TG_TABLE_NAME,
+        'id',      v_row.id,
+        'version', v_row.version,
+        'op',      lower(TG_OP)
+    )::text);
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_sys_notification_created()
+RETURNS TRIGGER AS $func$
+BEGIN
+    PERFORM tenant_notify('notifications_changed', json_build_object(
+        'op',     'created',
+        'id',     NEW.id,
+        'userId', NEW.user_id
+    )::text);
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_sys_notifications_updated()
+RETURNS TRIGGER AS $func$
+DECLARE
+    v_user_id UUID;
+BEGIN
+    FOR v_user_id IN SELECT DISTINCT user_id FROM changed_rows LOOP
+        PERFORM tenant_notify('notifications_changed', json_build_object(
+            'op',     'updated',
+            'userId', v_user_id
+        )::text);
+    END LOOP;
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_schema_change()
+RETURNS TRIGGER AS $func$
+BEGIN
+    PERFORM pg_notify('schema_changed', COALESCE(NEW.entity_type, OLD.entity_type));
+    RETURN COALESCE(NEW, OLD);
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_feature_flags_change()
+RETURNS TRIGGER AS $func$
+BEGIN
+    PERFORM pg_notify('feature_flags_changed', COALESCE(NEW.flag_name, OLD.flag_name));
+    RETURN COALESCE(NEW, OLD);
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_setting_values_changed()
+RETURNS TRIGGER AS $func$
+BEGIN
+    PERFORM pg_notify('setting_values_changed', COALESCE(NEW.key, OLD.key));
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_entity_invalidated()
+RETURNS TRIGGER AS $func$
+DECLARE
+    v_row RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        v_row := OLD;
+    ELSE
+        v_row := NEW;
+    END IF;
+
+    PERFORM pg_notify('entity_invalidated', json_build_object(
+        'entity',  TG_TABLE_NAME,
+        'id',      v_row.id,
+        'version', v_row.version,
+        'op',      lower(TG_OP)
+    )::text);
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_sys_notification_created()
+RETURNS TRIGGER AS $func$
+BEGIN
+    PERFORM pg_notify('notifications_changed', json_build_object(
+        'op',     'created',
+        'id',     NEW.id,
+        'userId', NEW.user_id
+    )::text);
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_sys_notifications_updated()
+RETURNS TRIGGER AS $func$
+DECLARE
+    v_user_id UUID;
+BEGIN
+    FOR v_user_id IN SELECT DISTINCT user_id FROM changed_rows LOOP
+        PERFORM pg_notify('notifications_changed', json_build_object(
+            'op',     'updated',
+            'userId', v_user_id
+        )::text);
+    END LOOP;
+    RETURN NULL;
+END;
+$func$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP FUNCTION IF EXISTS tenant_notify(TEXT, TEXT);
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -0,0 +1,29 @@
+-- +goose Up
+-- Description: Source movements moved by stock corrections. A movement is
+-- corrected at most once (primary key), so applying the same correction
+-- twice or re-targeting it does not reverse the original again. The foreign
+-- key keeps corrected movements from being deleted: unposting or re-posting
+-- the source document would leave the correction's adjustments behind.
+
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+
+CREATE TABLE reg_stock_correction_lines (
+    line_id       UUID PRIMARY KEY REFERENCES reg_stock_movements (line_id),
+    correction_id UUID NOT NULL REFERENCES reg_stock_corrections (id) ON DELETE CASCADE,
+    recorder_id   UUID NOT NULL
+);
+
+COMMENT ON TABLE reg_stock_correction_lines IS 'Регистр остатков товаров — движения, перенесённые корректировками';
+COMMENT ON COLUMN reg_stock_correction_lines.recorder_id IS 'Document of the corrected movement';
+
+CREATE INDEX idx_reg_stock_correction_lines_recorder
+    ON reg_stock_correction_lines (recorder_id);
+CREATE INDEX idx_reg_stock_correction_lines_correction
+    ON reg_stock_correction_lines (correction_id);
+
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
+
+-- +goose Down
+SELECT pg_advisory_lock(hashtext('metapus_migrations'));
+DROP TABLE IF EXISTS reg_stock_correction_lines;
+SELECT pg_advisory_unlock(hashtext('metapus_migrations'));
@@ -83,6 +83,7 @@ export function getAccountConfigFields(accountType: AccountType): ConfigField[]
           options: [
             { value: "bearer", label: "Bearer Token" },
             { value: "header", label: "Custom Header" },
+            { value: "hmac", label: "HMAC Signature" },
           ] },
         { key: "header_name", label: "Header Name", placeholder: "X-Webhook-Secret", type: "text" },
       ]
@@ -11,6 +11,7 @@ import (
 
 	"metapus/internal/core/tenant"
 	"metapus/internal/domain"
+	"metapus/internal/domain/catalogs/bank_account"
 	"metapus/internal/domain/catalogs/blockchain_network"
 	"metapus/internal/domain/catalogs/contract"
 	"metapus/internal/domain/catalogs/counterparty"
@@ -24,6 +25,8 @@ import (
 	"metapus/internal/domain/catalogs/vat_rate"
 	"metapus/internal/domain/catalogs/wallet"
 	"metapus/internal/domain/catalogs/warehouse"
+	"metapus/internal/domain/catalogs/webhook_subscription"
+	"metapus/internal/domain/modules"
 	v1 "metapus/internal/infrastructure/http/v1"
 	"metapus/internal/infrastructure/http/v1/dto"
 	"metapus/internal/infrastructure/http/v1/handlers"
@@ -140,6 +143,8 @@ func init() {
 		{Value: "coingecko", Label: "CoinGecko"},
 		{Value: "binance", Label: "Binance"},
 		{Value: "coinmarketcap", Label: "CoinMarketCap"},
+		{Value: "cbr", Label: "ЦБ РФ"},
+		{Value: "ecb", Label: "ЕЦБ"},
 		{Value: "manual", Label: "Ручной ввод"},
 	})
 }
@@ -177,7 +182,8 @@ func (r *CounterpartyRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHan
 	service := counterparty.NewService(repo, deps.Numerator)
 	service.SetPolicyEngine(deps.PolicyEngine)
 	domain.NewEventLogCatalogService(service.CatalogService, "counterparty", deps.EventWriter)
-	return handlers.NewCatalogHandler(deps.BaseHandler, handlers.CatalogHandlerConfig[
+	domain.RegisterCatalogEvents(service.CatalogService, "counterparty", deps.EventPublisher)
+	catalogHandler := handlers.NewCatalogHandler(deps.BaseHandler, handlers.CatalogHandlerConfig[
 		*counterparty.Counterparty,
 		dto.CreateCounterpartyRequest,
 		dto.UpdateCounterpartyRequest,
@@ -191,6 +197,8 @@ func (r *CounterpartyRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHan
 		},
 		MapToDTO: func(entity *counterparty.Counterparty) any { return dto.FromCounterparty(entity) },
 	})
+	service.SetRequisitesProvider(deps.RequisitesProvider)
+	return handlers.NewCounterpartyHandler(handlers.NewCatalogImageHandler(catalogHandler, r.RoutePrefix()), service)
 }
 
 // ---------------------------------------------------------------------------
@@ -219,7 +227,8 @@ func (r *NomenclatureRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHan
 	service := nomenclature.NewService(repo, deps.Numerator)
 	service.SetPolicyEngine(deps.PolicyEngine)
 	domain.NewEventLogCatalogService(service.CatalogService, "nomenclature", deps.EventWriter)
-	return handlers.NewCatalogHandler(deps.BaseHandler, handlers.CatalogHandlerConfig[
+	domain.RegisterCatalogEvents(service.CatalogService, "nomenclature", deps.EventPublisher)
+	catalogHandler := handlers.NewCatalogHandler(deps.BaseHandler, handlers.CatalogHandlerConfig[
 		*nomenclature.Nomenclature,
 		dto.CreateNomenclatureRequest,
 		dto.UpdateNomenclatureRequest,
@@ -237,6 +246,7 @@ func (r *NomenclatureRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHan
 			return dto.FromNomenclature(entity, refs.(postgres.ResolvedRefs))
 		},
 	})
+	return handlers.NewNomenclatureHandler(handlers.NewCatalogImageHandler(catalogHandler, r.RoutePrefix()), service)
 }
 
 // ---------------------------------------------------------------------------
@@ -260,11 +270,16 @@ func (r *WarehouseRegistration) EntityPresentation() metadata.Presentation {
 }
 func (r *WarehouseRegistration) EntityStruct() any { return warehouse.Warehouse{} }
 
+func (r *WarehouseRegistration) RLSDimensions() map[string]string {
+	return map[string]string{"organization": "organization_id", "warehouse": "id"}
+}
+
 func (r *WarehouseRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHandler {
 	repo := catalog_repo.NewWarehouseRepo()
 	service := warehouse.NewService(repo, deps.Numerator)
 	service.SetPolicyEngine(deps.PolicyEngine)
 	domain.NewEventLogCatalogService(service.CatalogService, "warehouse", deps.EventWriter)
+	domain.RegisterCatalogEvents(service.CatalogService, "warehouse", deps.EventPublisher)
 	return handlers.NewCatalogHandler(deps.BaseHandler, handlers.CatalogHandlerConfig[
 		*warehouse.Warehouse,
 		dto.CreateWarehouseRequest,
@@ -311,6 +326,7 @@ func (r *UnitRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHandler {
 	service := unit.NewService(repo, deps.Numerator)
 	service.SetPolicyEngine(deps.PolicyEngine)
 	domain.NewEventLogCatalogService(service.CatalogService, "unit", deps.EventWriter)
+	domain.RegisterCatalogEvents(service.CatalogService, "unit", deps.EventPublisher)
 	return handlers.NewCatalogHandler(deps.BaseHandler, handlers.CatalogHandlerConfig[
 		*unit.Unit,
 		dto.CreateUnitRequest,
@@ -357,6 +373,7 @@ func (r *CurrencyRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHandler
 	service := currency.NewService(repo, deps.Numerator)
 	service.SetPolicyEngine(deps.PolicyEngine)
 	domain.NewEventLogCatalogService(service.CatalogService, "currency", deps.EventWriter)
+	domain.RegisterCatalogEvents(service.CatalogService, "currency", deps.EventPublisher)
 	return handlers.NewCatalogHandler(deps.BaseHandler, handlers.CatalogHandlerConfig[
 		*currency.Currency,
 		dto.CreateCurrencyRequest,
@@ -394,11 +411,16 @@ func (r *OrganizationRegistration) EntityPresentation() metadata.Presentation {
 }
 func (r *OrganizationRegistration) EntityStruct() any { return organization.Organization{} }
 
+func (r *OrganizationRegistration) RLSDimensions() map[string]string {
+	return map[string]string{"organization": "id"}
+}
+
 func (r *OrganizationRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHandler {
 	repo := catalog_repo.NewOrganizationRepo()
 	service := organization.NewService(repo, deps.Numerator)
 	service.SetPolicyEngine(deps.PolicyEngine)
 	domain.NewEventLogCatalogService(service.CatalogService, "organization", deps.EventWriter)
+	domain.RegisterCatalogEvents(service.CatalogService, "organization", deps.EventPublisher)
 
 	// Invalidate CurrencyResolver cache when org's base currency changes
 	if deps.CurrencyCacheInvalidator != nil {
@@ -456,6 +478,7 @@ func (r *VATRateRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHandler
 	service := vat_rate.NewService(repo, deps.Numerator)
 	service.SetPolicyEngine(deps.PolicyEngine)
 	domain.NewEventLogCatalogService(service.CatalogService, "vat_rate", deps.EventWriter)
+	domain.RegisterCatalogEvents(service.CatalogService, "vat_rate", deps.EventPublisher)
 	return handlers.NewCatalogHandler(deps.BaseHandler, handlers.CatalogHandlerConfig[
 		*vat_rate.VATRate,
 		dto.CreateVATRateRequest,
@@ -498,6 +521,7 @@ func (r *ContractRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHandler
 	service := contract.NewService(repo, deps.Numerator)
 	service.SetPolicyEngine(deps.PolicyEngine)
 	domain.NewEventLogCatalogService(service.CatalogService, "contract", deps.EventWriter)
+	domain.RegisterCatalogEvents(service.CatalogService, "contract", deps.EventPublisher)
 
 	// Invalidate CurrencyResolver cache when contract's currency changes
 	if deps.CurrencyCacheInvalidator != nil {
@@ -528,6 +552,55 @@ func (r *ContractRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHandler
 	})
 }
 
+// ---------------------------------------------------------------------------
+// BankAccount
+// ---------------------------------------------------------------------------
+
+type BankAccountRegistration struct{}
+
+func (r *BankAccountRegistration) RoutePrefix() string      { return "bank-accounts" }
+func (r *BankAccountRegistration) Permission() string       { return "catalog:bank_account" }
+func (r *BankAccountRegistration) ReferenceTypes() []string { return []string{"bank_account"} }
+func (r *BankAccountRegistration) EntityName() string       { return "BankAccount" }
+func (r *BankAccountRegistration) EntityLabel() string      { return "Банковские счета" }
+func (r *BankAccountRegistration) EntityPresentation() metadata.Presentation {
+	return metadata.Presentation{
+		Singular: "Банковский счёт",
+		Plural:   "Банковские счета",
+		NewLabel: "Новый банковский счёт",
+		Genitive: "банковского счёта",
+	}
+}
+func (r *BankAccountRegistration) EntityStruct() any { return bank_account.BankAccount{} }
+
+func (r *BankAccountRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHandler {
+	repo := catalog_repo.NewBankAccountRepo()
+	service := bank_account.NewService(repo, deps.Numerator)
+	service.SetPolicyEngine(deps.PolicyEngine)
+	domain.NewEventLogCatalogService(service.CatalogService, "bank_account", deps.EventWriter)
+	domain.RegisterCatalogEvents(service.CatalogService, "bank_account", deps.EventPublisher)
+
+	catalogHandler := handlers.NewCatalogHandler(deps.BaseHandler, handlers.CatalogHandlerConfig[
+		*bank_account.BankAccount,
+		dto.CreateBankAccountRequest,
+		dto.UpdateBankAccountRequest,
+	]{
+		Service:      service.CatalogService,
+		EntityName:   "bank_account",
+		MapCreateDTO: func(req dto.CreateBankAccountRequest) *bank_account.BankAccount { return req.ToEntity() },
+		MapUpdateDTO: func(req dto.UpdateBankAccountRequest, existing *bank_account.BankAccount) *bank_account.BankAccount {
+			req.ApplyTo(existing)
+			return existing
+		},
+		MapToDTO:    func(entity *bank_account.BankAccount) any { return dto.FromBankAccount(entity) },
+		ResolveRefs: resolveBankAccountRefs,
+		MapToDTOWithRefs: func(entity *bank_account.BankAccount, refs any) any {
+			return dto.FromBankAccount(entity, refs.(postgres.ResolvedRefs))
+		},
+	})
+	return handlers.NewBankAccountHandler(catalogHandler, service)
+}
+
 // ── ResolveRefs callbacks for catalogs with FK references ───────────────
 
 func resolveCatalogRefs[T any](ctx context.Context, collect func(*postgres.ReferenceResolver, T), entities ...T) (any, error) {
@@ -559,6 +632,10 @@ func resolveContractRefs(ctx context.Context, entities ...*contract.Contract) (a
 	return resolveCatalogRefs(ctx, dto.CollectContractRefs, entities...)
 }
 
+func resolveBankAccountRefs(ctx context.Context, entities ...*bank_account.BankAccount) (any, error) {
+	return resolveCatalogRefs(ctx, dto.CollectBankAccountRefs, entities...)
+}
+
 // ---------------------------------------------------------------------------
 // BlockchainNetwork
 // ---------------------------------------------------------------------------
@@ -567,6 +644,7 @@ type BlockchainNetworkRegistration struct{}
 
 func (r *BlockchainNetworkRegistration) RoutePrefix() string { return "blockchain-networks" }
 func (r *BlockchainNetworkRegistration) Permission() string  { return "catalog:blockchain_network" }
+func (r *BlockchainNetworkRegistration) Module() string      { return modules.Crypto }
 func (r *BlockchainNetworkRegistration) ReferenceTypes() []string {
 	return []string{"blockchain_network"}
 }
@@ -589,6 +667,7 @@ func (r *BlockchainNetworkRegistration) Build(deps v1.CatalogDeps) v1.CatalogRou
 	service := blockchain_network.NewService(repo, deps.Numerator)
 	service.SetPolicyEngine(deps.PolicyEngine)
 	domain.NewEventLogCatalogService(service.CatalogService, "blockchain_network", deps.EventWriter)
+	domain.RegisterCatalogEvents(service.CatalogService, "blockchain_network", deps.EventPublisher)
 	return handlers.NewCatalogHandler(deps.BaseHandler, handlers.CatalogHandlerConfig[
 		*blockchain_network.BlockchainNetwork,
 		dto.CreateBlockchainNetworkRequest,
@@ -617,6 +696,7 @@ type TokenRegistration struct{}
 
 func (r *TokenRegistration) RoutePrefix() string      { return "tokens" }
 func (r *TokenRegistration) Permission() string       { return "catalog:token" }
+func (r *TokenRegistration) Module() string           { return modules.Crypto }
 func (r *TokenRegistration) ReferenceTypes() []string { return []string{"token"} }
 func (r *TokenRegistration) EntityName() string       { return "Token" }
 func (r *TokenRegistration) EntityLabel() string      { return "Токены" }
@@ -635,6 +715,7 @@ func (r *TokenRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHandler {
 	service := token.NewService(repo, deps.Numerator)
 	service.SetPolicyEngine(deps.PolicyEngine)
 	domain.NewEventLogCatalogService(service.CatalogService, "token", deps.EventWriter)
+	domain.RegisterCatalogEvents(service.CatalogService, "token", deps.EventPublisher)
 	return handlers.NewCatalogHandler(deps.BaseHandler, handlers.CatalogHandlerConfig[
 		*token.Token,
 		dto.CreateTokenRequest,
@@ -667,6 +748,7 @@ type MerchantRegistration struct{}
 
 func (r *MerchantRegistration) RoutePrefix() string      { return "merchants" }
 func (r *MerchantRegistration) Permission() string       { return "catalog:merchant" }
+func (r *MerchantRegistration) Module() string           { return modules.Crypto }
 func (r *MerchantRegistration) ReferenceTypes() []string { return []string{"merchant"} }
 func (r *MerchantRegistration) EntityName() string       { return "Merchant" }
 func (r *MerchantRegistration) EntityLabel() string      { return "Мерчанты" }
@@ -685,6 +767,7 @@ func (r *MerchantRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHandler
 	service := merchant.NewService(repo, deps.Numerator)
 	service.SetPolicyEngine(deps.PolicyEngine)
 	domain.NewEventLogCatalogService(service.CatalogService, "merchant", deps.EventWriter)
+	domain.RegisterCatalogEvents(service.CatalogService, "merchant", deps.EventPublisher)
 	return handlers.NewCatalogHandler(deps.BaseHandler, handlers.CatalogHandlerConfig[
 		*merchant.Merchant,
 		dto.CreateMerchantRequest,
@@ -709,6 +792,7 @@ type WalletRegistration struct{}
 
 func (r *WalletRegistration) RoutePrefix() string      { return "wallets" }
 func (r *WalletRegistration) Permission() string       { return "catalog:wallet" }
+func (r *WalletRegistration) Module() string           { return modules.Crypto }
 func (r *WalletRegistration) ReferenceTypes() []string { return []string{"wallet"} }
 func (r *WalletRegistration) EntityName() string       { return "Wallet" }
 func (r *WalletRegistration) EntityLabel() string      { return "Кошельки" }
@@ -727,6 +811,7 @@ func (r *WalletRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHandler {
 	service := wallet.NewService(repo, deps.Numerator)
 	service.SetPolicyEngine(deps.PolicyEngine)
 	domain.NewEventLogCatalogService(service.CatalogService, "wallet", deps.EventWriter)
+	domain.RegisterCatalogEvents(service.CatalogService, "wallet", deps.EventPublisher)
 	return handlers.NewCatalogHandler(deps.BaseHandler, handlers.CatalogHandlerConfig[
 		*wallet.Wallet,
 		dto.CreateWalletRequest,
@@ -759,6 +844,7 @@ type RateSourceRegistration struct{}
 
 func (r *RateSourceRegistration) RoutePrefix() string      { return "rate-sources" }
 func (r *RateSourceRegistration) Permission() string       { return "catalog:rate_source" }
+func (r *RateSourceRegistration) Module() string           { return modules.Crypto }
 func (r *RateSourceRegistration) ReferenceTypes() []string { return []string{"rate_source"} }
 func (r *RateSourceRegistration) EntityName() string       { return "RateSource" }
 func (r *RateSourceRegistration) EntityLabel() string      { return "Источники курсов" }
@@ -777,6 +863,7 @@ func (r *RateSourceRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHandl
 	service := rate_source.NewService(repo, deps.Numerator)
 	service.SetPolicyEngine(deps.PolicyEngine)
 	domain.NewEventLogCatalogService(service.CatalogService, "rate_source", deps.EventWriter)
+	domain.RegisterCatalogEvents(service.CatalogService, "rate_source", deps.EventPublisher)
 	return handlers.NewCatalogHandler(deps.BaseHandler, handlers.CatalogHandlerConfig[
 		*rate_source.RateSource,
 		dto.CreateRateSourceRequest,
@@ -792,3 +879,55 @@ func (r *RateSourceRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHandl
 		MapToDTO: func(entity *rate_source.RateSource) any { return dto.FromRateSource(entity) },
 	})
 }
+
+// ---------------------------------------------------------------------------
+// WebhookSubscription
+// ---------------------------------------------------------------------------
+
+type WebhookSubscriptionRegistration struct{}
+
+func (r *WebhookSubscriptionRegistration) RoutePrefix() string { return "webhook-subscriptions" }
+func (r *WebhookSubscriptionRegistration) Permission() string  { return "catalog:webhook_subscription" }
+func (r *WebhookSubscriptionRegistration) ReferenceTypes() []string {
+	return []string{"webhook_subscription"}
+}
+func (r *WebhookSubscriptionRegistration) EntityName() string { return "WebhookSubscription" }
+func (r *WebhookSubscriptionRegistration) EntityLabel() string {
+	return "Подписки на вебхуки"
+}
+func (r *WebhookSubscriptionRegistration) EntityPresentation() metadata.Presentation {
+	return metadata.Presentation{
+		Singular: "Подписка на вебхуки",
+		Plural:   "Подписки на вебхуки",
+		NewLabel: "Новая подписка на вебхуки",
+		Genitive: "подписки на вебхуки",
+	}
+}
+func (r *WebhookSubscriptionRegistration) EntityStruct() any {
+	return webhook_subscription.WebhookSubscription{}
+}
+
+func (r *WebhookSubscriptionRegistration) Build(deps v1.CatalogDeps) v1.CatalogRouteHandler {
+	repo := catalog_repo.NewWebhookSubscriptionRepo()
+	service := webhook_subscription.NewService(repo, deps.Numerator)
+	service.SetPolicyEngine(deps.PolicyEngine)
+	domain.NewEventLogCatalogService(service.CatalogService, "webhook_subscription", deps.EventWriter)
+	return handlers.NewCatalogHandler(deps.BaseHandler, handlers.CatalogHandlerConfig[
+		*webhook_subscription.WebhookSubscription,
+		dto.CreateWebhookSubscriptionRequest,
+		dto.UpdateWebhookSubscriptionRequest,
+	]{
+		Service:    service.CatalogService,
+		EntityName: "webhook_subscription",
+		MapCreateDTO: func(req dto.CreateWebhookSubscriptionRequest) *webhook_subscription.WebhookSubscription {
+			return req.ToEntity()
+		},
+		MapUpdateDTO: func(req dto.UpdateWebhookSubscriptionRequest, existing *webhook_subscription.WebhookSubscription) *webhook_subscription.WebhookSubscription {
+			req.ApplyTo(existing)
+			return existing
+		},
+		MapToDTO: func(entity *webhook_subscription.WebhookSubscription) any {
+			return dto.FromWebhookSubscription(entity)
+		},
+	})
+}
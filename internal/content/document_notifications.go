@@ -0,0 +1,38 @@
+package content
+
+import (
+	"context"
+	"time"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain"
+	"metapus/internal/domain/notifications"
+)
+
+// documentLink returns the frontend page of a document.
+func documentLink(routePrefix string, docID id.ID) string {
+	return "/documents/" + routePrefix + "s/" + docID.String()
+}
+
+// registerPostedNotification notifies the author of a document when another
+// user posts it, in the posting transaction. If inbox is nil, this is a no-op.
+func registerPostedNotification[T domain.EventDocument](hooks *domain.HookRegistry[T], label, routePrefix string, inbox *notifications.Inbox) {
+	if inbox == nil {
+		return
+	}
+	hooks.On(domain.PostInTx, func(ctx context.Context, doc T) error {
+		authored, ok := any(doc).(interface{ GetCreatedBy() id.ID })
+		if !ok {
+			return nil
+		}
+		data := notifications.DocumentPosted{
+			DocumentLabel: label,
+			Number:        doc.GetNumber(),
+			Link:          documentLink(routePrefix, doc.GetID()),
+		}
+		if dated, ok := any(doc).(interface{ GetDate() time.Time }); ok {
+			data.Date = dated.GetDate()
+		}
+		return inbox.DocumentPosted(ctx, authored.GetCreatedBy(), data)
+	})
+}
@@ -4,9 +4,16 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
 	"metapus/internal/core/numerator"
+	"metapus/internal/core/types"
 	"metapus/internal/domain"
 	"metapus/internal/domain/audit"
+	"metapus/internal/domain/basedon"
+	"metapus/internal/domain/board"
 	"metapus/internal/domain/catalogs/wallet"
 	"metapus/internal/domain/documents/crypto_invoice"
 	"metapus/internal/domain/documents/crypto_payment"
@@ -14,14 +21,36 @@ import (
 	"metapus/internal/domain/documents/crypto_withdrawal"
 	"metapus/internal/domain/documents/goods_issue"
 	"metapus/internal/domain/documents/goods_receipt"
+	"metapus/internal/domain/documents/goods_transfer"
+	"metapus/internal/domain/documents/manual_adjustment"
+	"metapus/internal/domain/documents/purchase_order"
+	"metapus/internal/domain/documents/sales_order"
+	"metapus/internal/domain/modules"
+	"metapus/internal/domain/notifications"
+	"metapus/internal/domain/registers/customer_order"
+	"metapus/internal/domain/registers/supplier_order"
 	v1 "metapus/internal/infrastructure/http/v1"
 	"metapus/internal/infrastructure/http/v1/handlers"
 	"metapus/internal/infrastructure/storage/postgres/catalog_repo"
 	"metapus/internal/infrastructure/storage/postgres/document_repo"
+	"metapus/internal/infrastructure/storage/postgres/register_repo"
 	"metapus/internal/metadata"
 )
 
 func init() {
+	// ManualAdjustment Register
+	metadata.RegisterEnum[manual_adjustment.Register]([]metadata.EnumValue{
+		{Value: "stock", Label: "Товары на складах"},
+		{Value: "cost", Label: "Себестоимость товаров"},
+		{Value: "settlement", Label: "Взаиморасчёты"},
+	})
+
+	// Register movement direction
+	metadata.RegisterEnum[entity.RecordType]([]metadata.EnumValue{
+		{Value: "receipt", Label: "Приход"},
+		{Value: "expense", Label: "Расход"},
+	})
+
 	// CryptoInvoice Status
 	metadata.RegisterEnum[crypto_invoice.InvoiceStatus]([]metadata.EnumValue{
 		{Value: "created", Label: "Создан"},
@@ -83,22 +112,46 @@ func (r *GoodsReceiptRegistration) EntityPresentation() metadata.Presentation {
 }
 func (r *GoodsReceiptRegistration) EntityStruct() any { return goods_receipt.GoodsReceipt{} }
 func (r *GoodsReceiptRegistration) RLSDimensions() map[string]string {
-	return map[string]string{"organization": "organization_id"}
+	return map[string]string{"organization": "organization_id", "warehouse": "warehouse_id"}
 }
 
 func (r *GoodsReceiptRegistration) Build(deps v1.DocumentDeps) v1.DocumentRouteHandler {
 	repo := document_repo.NewGoodsReceiptRepo()
 	service := goods_receipt.NewService(repo, deps.PostingEngine, deps.Numerator, nil, deps.CurrencyResolver)
 	service.SetPolicyEngine(deps.PolicyEngine)
+	service.SetCurrencyConverter(deps.CurrencyConverter)
+
+	// Lines linked to a purchase order must match the order.
+	orderRepo := document_repo.NewPurchaseOrderRepo()
+	checkOrderLines := func(ctx context.Context, doc *goods_receipt.GoodsReceipt) error {
+		if doc.BasisType != purchase_order.DocumentType || doc.BasisID == nil {
+			return nil
+		}
+		order, err := orderRepo.GetByID(ctx, *doc.BasisID)
+		if err != nil {
+			return err
+		}
+		if order.Lines, err = orderRepo.GetLines(ctx, order.ID); err != nil {
+			return err
+		}
+		return doc.ValidateOrderLines(order)
+	}
 
 	service.Hooks().OnBeforeCreate(func(ctx context.Context, doc *goods_receipt.GoodsReceipt) error {
 		audit.EnrichCreatedByDirect(ctx, &doc.CreatedBy, &doc.UpdatedBy)
-		return nil
+		return checkOrderLines(ctx, doc)
 	})
 	service.Hooks().OnBeforeUpdate(func(ctx context.Context, doc *goods_receipt.GoodsReceipt) error {
 		audit.EnrichUpdatedByDirect(ctx, &doc.UpdatedBy)
-		return nil
+		return checkOrderLines(ctx, doc)
+	})
+	registerLineUnitConversion(service.Hooks(), newUnitConverter(), func(line *goods_receipt.GoodsReceiptLine, coefficient decimal.Decimal) {
+		line.Coefficient = coefficient
 	})
+	registerLineSerialCheck[*goods_receipt.GoodsReceipt, goods_receipt.GoodsReceiptLine](service.Hooks())
+
+	domain.RegisterDocumentEvents(service.Hooks(), "goods_receipt", deps.EventPublisher)
+	registerPostedNotification(service.Hooks(), r.EntityLabel(), r.RoutePrefix(), deps.NotificationInbox)
 
 	decorated := domain.Chain[*goods_receipt.GoodsReceipt](
 		domain.WithLogging[*goods_receipt.GoodsReceipt]("goods-receipt"),
@@ -106,7 +159,33 @@ func (r *GoodsReceiptRegistration) Build(deps v1.DocumentDeps) v1.DocumentRouteH
 		domain.WithOutboxEvents[*goods_receipt.GoodsReceipt]("goods_receipt", deps.OutboxPublisher, deps.CurrencyMetadataResolver),
 	)(service)
 
-	return handlers.NewGoodsReceiptHandler(deps.BaseHandler, decorated, deps.PrintRegistry, deps.PrintRenderer, deps.RelatedDocFinder, deps.MovementProviders, deps.MovementRefResolver, deps.SettingsRepo)
+	h := handlers.NewGoodsReceiptHandler(deps.BaseHandler, decorated, deps.PrintRegistry, deps.PrintRenderer, deps.RelatedDocFinder, deps.MovementProviders, deps.MovementRefResolver, deps.SettingsRepo)
+
+	// A receipt based on a purchase order takes what is still expected.
+	fulfillment := supplier_order.NewService(register_repo.NewSupplierOrderRepo())
+	h.SetBasedOn(basedon.NewMappings(basedon.Mapping[*goods_receipt.GoodsReceipt]{
+		BasisType:  purchase_order.DocumentType,
+		Permission: "document:purchase_order:read",
+		Build: func(ctx context.Context, basisID id.ID) (*goods_receipt.GoodsReceipt, error) {
+			order, err := orderRepo.GetByID(ctx, basisID)
+			if err != nil {
+				return nil, err
+			}
+			if order.Lines, err = orderRepo.GetLines(ctx, order.ID); err != nil {
+				return nil, err
+			}
+			lines, err := fulfillment.GetLineFulfillment(ctx, order.ID)
+			if err != nil {
+				return nil, err
+			}
+			remaining := make(map[id.ID]types.Quantity, len(lines))
+			for _, l := range lines {
+				remaining[l.OrderLineID] = l.Remaining()
+			}
+			return goods_receipt.FromPurchaseOrder(order, remaining)
+		},
+	}))
+	return h
 }
 
 // ---------------------------------------------------------------------------
@@ -129,22 +208,46 @@ func (r *GoodsIssueRegistration) EntityPresentation() metadata.Presentation {
 }
 func (r *GoodsIssueRegistration) EntityStruct() any { return goods_issue.GoodsIssue{} }
 func (r *GoodsIssueRegistration) RLSDimensions() map[string]string {
-	return map[string]string{"organization": "organization_id"}
+	return map[string]string{"organization": "organization_id", "warehouse": "warehouse_id"}
 }
 
 func (r *GoodsIssueRegistration) Build(deps v1.DocumentDeps) v1.DocumentRouteHandler {
 	repo := document_repo.NewGoodsIssueRepo()
 	service := goods_issue.NewService(repo, deps.PostingEngine, deps.Numerator, nil, deps.CurrencyResolver)
 	service.SetPolicyEngine(deps.PolicyEngine)
+	service.SetCurrencyConverter(deps.CurrencyConverter)
+
+	// Lines linked to a sales order must match the order.
+	orderRepo := document_repo.NewSalesOrderRepo()
+	checkOrderLines := func(ctx context.Context, doc *goods_issue.GoodsIssue) error {
+		if doc.BasisType != sales_order.DocumentType || doc.BasisID == nil {
+			return nil
+		}
+		order, err := orderRepo.GetByID(ctx, *doc.BasisID)
+		if err != nil {
+			return err
+		}
+		if order.Lines, err = orderRepo.GetLines(ctx, order.ID); err != nil {
+			return err
+		}
+		return doc.ValidateOrderLines(order)
+	}
 
 	service.Hooks().OnBeforeCreate(func(ctx context.Context, doc *goods_issue.GoodsIssue) error {
 		audit.EnrichCreatedByDirect(ctx, &doc.CreatedBy, &doc.UpdatedBy)
-		return nil
+		return checkOrderLines(ctx, doc)
 	})
 	service.Hooks().OnBeforeUpdate(func(ctx context.Context, doc *goods_issue.GoodsIssue) error {
 		audit.EnrichUpdatedByDirect(ctx, &doc.UpdatedBy)
-		return nil
+		return checkOrderLines(ctx, doc)
 	})
+	registerLineUnitConversion(service.Hooks(), newUnitConverter(), func(line *goods_issue.GoodsIssueLine, coefficient decimal.Decimal) {
+		line.Coefficient = coefficient
+	})
+	registerLineSerialCheck[*goods_issue.GoodsIssue, goods_issue.GoodsIssueLine](service.Hooks())
+
+	domain.RegisterDocumentEvents(service.Hooks(), "goods_issue", deps.EventPublisher)
+	registerPostedNotification(service.Hooks(), r.EntityLabel(), r.RoutePrefix(), deps.NotificationInbox)
 
 	decorated := domain.Chain[*goods_issue.GoodsIssue](
 		domain.WithLogging[*goods_issue.GoodsIssue]("goods-issue"),
@@ -152,7 +255,278 @@ func (r *GoodsIssueRegistration) Build(deps v1.DocumentDeps) v1.DocumentRouteHan
 		domain.WithOutboxEvents[*goods_issue.GoodsIssue]("goods_issue", deps.OutboxPublisher, deps.CurrencyMetadataResolver),
 	)(service)
 
-	return handlers.NewGoodsIssueHandler(deps.BaseHandler, decorated, deps.PrintRegistry, deps.PrintRenderer, deps.RelatedDocFinder, deps.MovementProviders, deps.MovementRefResolver, deps.SettingsRepo)
+	h := handlers.NewGoodsIssueHandler(deps.BaseHandler, decorated, deps.PrintRegistry, deps.PrintRenderer, deps.RelatedDocFinder, deps.MovementProviders, deps.MovementRefResolver, deps.SettingsRepo)
+
+	// An issue based on a sales order ships what is still open.
+	fulfillment := customer_order.NewService(register_repo.NewCustomerOrderRepo())
+	h.SetBasedOn(basedon.NewMappings(basedon.Mapping[*goods_issue.GoodsIssue]{
+		BasisType:  sales_order.DocumentType,
+		Permission: "document:sales_order:read",
+		Build: func(ctx context.Context, basisID id.ID) (*goods_issue.GoodsIssue, error) {
+			order, err := orderRepo.GetByID(ctx, basisID)
+			if err != nil {
+				return nil, err
+			}
+			if order.Lines, err = orderRepo.GetLines(ctx, order.ID); err != nil {
+				return nil, err
+			}
+			lines, err := fulfillment.GetLineFulfillment(ctx, order.ID)
+			if err != nil {
+				return nil, err
+			}
+			open := make(map[id.ID]types.Quantity, len(lines))
+			for _, l := range lines {
+				open[l.OrderLineID] = l.Open()
+			}
+			return goods_issue.FromSalesOrder(order, open)
+		},
+	}))
+	return h
+}
+
+// ---------------------------------------------------------------------------
+// SalesOrder
+// ---------------------------------------------------------------------------
+
+type SalesOrderRegistration struct{}
+
+func (r *SalesOrderRegistration) RoutePrefix() string { return "sales-order" }
+func (r *SalesOrderRegistration) Permission() string  { return "document:sales_order" }
+func (r *SalesOrderRegistration) Module() string      { return modules.Orders }
+func (r *SalesOrderRegistration) EntityName() string  { return "SalesOrder" }
+func (r *SalesOrderRegistration) EntityLabel() string { return "Заказ покупателя" }
+func (r *SalesOrderRegistration) EntityPresentation() metadata.Presentation {
+	return metadata.Presentation{
+		Singular: "Заказ покупателя",
+		Plural:   "Заказы покупателей",
+		NewLabel: "Новый заказ",
+		Genitive: "заказа покупателя",
+	}
+}
+func (r *SalesOrderRegistration) EntityStruct() any { return sales_order.SalesOrder{} }
+func (r *SalesOrderRegistration) RLSDimensions() map[string]string {
+	return map[string]string{"organization": "organization_id", "warehouse": "warehouse_id"}
+}
+
+func (r *SalesOrderRegistration) Build(deps v1.DocumentDeps) v1.DocumentRouteHandler {
+	repo := document_repo.NewSalesOrderRepo()
+	service := sales_order.NewService(repo, deps.PostingEngine, deps.Numerator, nil, deps.CurrencyResolver)
+	service.SetPolicyEngine(deps.PolicyEngine)
+	service.SetCurrencyConverter(deps.CurrencyConverter)
+
+	service.Hooks().OnBeforeCreate(func(ctx context.Context, doc *sales_order.SalesOrder) error {
+		audit.EnrichCreatedByDirect(ctx, &doc.CreatedBy, &doc.UpdatedBy)
+		return nil
+	})
+	service.Hooks().OnBeforeUpdate(func(ctx context.Context, doc *sales_order.SalesOrder) error {
+		audit.EnrichUpdatedByDirect(ctx, &doc.UpdatedBy)
+		return nil
+	})
+	registerLineUnitConversion(service.Hooks(), newUnitConverter(), func(line *sales_order.SalesOrderLine, coefficient decimal.Decimal) {
+		line.Coefficient = coefficient
+	})
+
+	domain.RegisterDocumentEvents(service.Hooks(), "sales_order", deps.EventPublisher)
+	registerPostedNotification(service.Hooks(), r.EntityLabel(), r.RoutePrefix(), deps.NotificationInbox)
+
+	decorated := domain.Chain[*sales_order.SalesOrder](
+		domain.WithLogging[*sales_order.SalesOrder]("sales-order"),
+		domain.WithEventLog[*sales_order.SalesOrder]("sales_order", deps.EventWriter),
+		domain.WithOutboxEvents[*sales_order.SalesOrder]("sales_order", deps.OutboxPublisher, deps.CurrencyMetadataResolver),
+	)(service)
+
+	fulfillment := customer_order.NewService(register_repo.NewCustomerOrderRepo())
+	h := handlers.NewSalesOrderHandler(deps.BaseHandler, decorated, fulfillment, deps.RelatedDocFinder, deps.MovementProviders, deps.MovementRefResolver, deps.SettingsRepo)
+	h.SetBoard(board.NewService(repo, sales_order.BoardWorkflow()))
+	return h
+}
+
+// ---------------------------------------------------------------------------
+// PurchaseOrder
+// ---------------------------------------------------------------------------
+
+type PurchaseOrderRegistration struct{}
+
+func (r *PurchaseOrderRegistration) RoutePrefix() string { return "purchase-order" }
+func (r *PurchaseOrderRegistration) Permission() string  { return "document:purchase_order" }
+func (r *PurchaseOrderRegistration) Module() string      { return modules.Orders }
+func (r *PurchaseOrderRegistration) EntityName() string  { return "PurchaseOrder" }
+func (r *PurchaseOrderRegistration) EntityLabel() string { return "Заказ поставщику" }
+func (r *PurchaseOrderRegistration) EntityPresentation() metadata.Presentation {
+	return metadata.Presentation{
+		Singular: "Заказ поставщику",
+		Plural:   "Заказы поставщикам",
+		NewLabel: "Новый заказ",
+		Genitive: "заказа поставщику",
+	}
+}
+func (r *PurchaseOrderRegistration) EntityStruct() any { return purchase_order.PurchaseOrder{} }
+func (r *PurchaseOrderRegistration) RLSDimensions() map[string]string {
+	return map[string]string{"organization": "organization_id", "warehouse": "warehouse_id"}
+}
+
+func (r *PurchaseOrderRegistration) Build(deps v1.DocumentDeps) v1.DocumentRouteHandler {
+	repo := document_repo.NewPurchaseOrderRepo()
+	service := purchase_order.NewService(repo, deps.PostingEngine, deps.Numerator, nil, deps.CurrencyResolver)
+	service.SetPolicyEngine(deps.PolicyEngine)
+	service.SetCurrencyConverter(deps.CurrencyConverter)
+
+	service.Hooks().OnBeforeCreate(func(ctx context.Context, doc *purchase_order.PurchaseOrder) error {
+		audit.EnrichCreatedByDirect(ctx, &doc.CreatedBy, &doc.UpdatedBy)
+		return nil
+	})
+	service.Hooks().OnBeforeUpdate(func(ctx context.Context, doc *purchase_order.PurchaseOrder) error {
+		audit.EnrichUpdatedByDirect(ctx, &doc.UpdatedBy)
+		return nil
+	})
+	registerLineUnitConversion(service.Hooks(), newUnitConverter(), func(line *purchase_order.PurchaseOrderLine, coefficient decimal.Decimal) {
+		line.Coefficient = coefficient
+	})
+
+	domain.RegisterDocumentEvents(service.Hooks(), "purchase_order", deps.EventPublisher)
+	registerPostedNotification(service.Hooks(), r.EntityLabel(), r.RoutePrefix(), deps.NotificationInbox)
+
+	decorated := domain.Chain[*purchase_order.PurchaseOrder](
+		domain.WithLogging[*purchase_order.PurchaseOrder]("purchase-order"),
+		domain.WithEventLog[*purchase_order.PurchaseOrder]("purchase_order", deps.EventWriter),
+		domain.WithOutboxEvents[*purchase_order.PurchaseOrder]("purchase_order", deps.OutboxPublisher, deps.CurrencyMetadataResolver),
+	)(service)
+
+	fulfillment := supplier_order.NewService(register_repo.NewSupplierOrderRepo())
+	h := handlers.NewPurchaseOrderHandler(deps.BaseHandler, decorated, fulfillment, deps.RelatedDocFinder, deps.MovementProviders, deps.MovementRefResolver, deps.SettingsRepo)
+	h.SetBoard(board.NewService(repo, purchase_order.BoardWorkflow()))
+	return h
+}
+
+// ---------------------------------------------------------------------------
+// GoodsTransfer
+// ---------------------------------------------------------------------------
+
+type GoodsTransferRegistration struct{}
+
+func (r *GoodsTransferRegistration) RoutePrefix() string { return "goods-transfer" }
+func (r *GoodsTransferRegistration) Permission() string  { return "document:goods_transfer" }
+func (r *GoodsTransferRegistration) EntityName() string  { return "GoodsTransfer" }
+func (r *GoodsTransferRegistration) EntityLabel() string { return "Перемещение товаров" }
+func (r *GoodsTransferRegistration) EntityPresentation() metadata.Presentation {
+	return metadata.Presentation{
+		Singular: "Перемещение товаров",
+		Plural:   "Перемещения товаров",
+		NewLabel: "Новое перемещение",
+		Genitive: "перемещения товаров",
+	}
+}
+func (r *GoodsTransferRegistration) EntityStruct() any { return goods_transfer.GoodsTransfer{} }
+func (r *GoodsTransferRegistration) RLSDimensions() map[string]string {
+	return map[string]string{"organization": "organization_id"}
+}
+
+func (r *GoodsTransferRegistration) Build(deps v1.DocumentDeps) v1.DocumentRouteHandler {
+	repo := document_repo.NewGoodsTransferRepo()
+	service := goods_transfer.NewService(repo, deps.PostingEngine, deps.Numerator, nil, catalog_repo.NewWarehouseRepo())
+	service.SetPolicyEngine(deps.PolicyEngine)
+
+	service.Hooks().OnBeforeCreate(func(ctx context.Context, doc *goods_transfer.GoodsTransfer) error {
+		audit.EnrichCreatedByDirect(ctx, &doc.CreatedBy, &doc.UpdatedBy)
+		return nil
+	})
+	service.Hooks().OnBeforeUpdate(func(ctx context.Context, doc *goods_transfer.GoodsTransfer) error {
+		audit.EnrichUpdatedByDirect(ctx, &doc.UpdatedBy)
+		return nil
+	})
+	registerLineUnitConversion(service.Hooks(), newUnitConverter(), func(line *goods_transfer.GoodsTransferLine, coefficient decimal.Decimal) {
+		line.Coefficient = coefficient
+	})
+
+	domain.RegisterDocumentEvents(service.Hooks(), "goods_transfer", deps.EventPublisher)
+	registerPostedNotification(service.Hooks(), r.EntityLabel(), r.RoutePrefix(), deps.NotificationInbox)
+
+	decorated := domain.Chain[*goods_transfer.GoodsTransfer](
+		domain.WithLogging[*goods_transfer.GoodsTransfer]("goods-transfer"),
+		domain.WithEventLog[*goods_transfer.GoodsTransfer]("goods_transfer", deps.EventWriter),
+		domain.WithOutboxEvents[*goods_transfer.GoodsTransfer]("goods_transfer", deps.OutboxPublisher, deps.CurrencyMetadataResolver),
+	)(service)
+
+	return handlers.NewGoodsTransferHandler(deps.BaseHandler, decorated, deps.RelatedDocFinder, deps.MovementProviders, deps.MovementRefResolver, deps.SettingsRepo)
+}
+
+// ---------------------------------------------------------------------------
+// ManualAdjustment
+// ---------------------------------------------------------------------------
+
+type ManualAdjustmentRegistration struct{}
+
+func (r *ManualAdjustmentRegistration) RoutePrefix() string { return "manual-adjustment" }
+func (r *ManualAdjustmentRegistration) Permission() string  { return "document:manual_adjustment" }
+func (r *ManualAdjustmentRegistration) EntityName() string  { return "ManualAdjustment" }
+func (r *ManualAdjustmentRegistration) EntityLabel() string {
+	return "Корректировка регистров"
+}
+func (r *ManualAdjustmentRegistration) EntityPresentation() metadata.Presentation {
+	return metadata.Presentation{
+		Singular: "Корректировка регистров",
+		Plural:   "Корректировки регистров",
+		NewLabel: "Новая корректировка",
+		Genitive: "корректировки регистров",
+	}
+}
+func (r *ManualAdjustmentRegistration) EntityStruct() any {
+	return manual_adjustment.ManualAdjustment{}
+}
+func (r *ManualAdjustmentRegistration) RLSDimensions() map[string]string {
+	return map[string]string{"organization": "organization_id"}
+}
+
+func (r *ManualAdjustmentRegistration) Build(deps v1.DocumentDeps) v1.DocumentRouteHandler {
+	repo := document_repo.NewManualAdjustmentRepo()
+	service := manual_adjustment.NewService(repo, deps.PostingEngine, deps.Numerator, nil, deps.CurrencyResolver)
+	service.SetPolicyEngine(deps.PolicyEngine)
+
+	service.Hooks().OnBeforeCreate(func(ctx context.Context, doc *manual_adjustment.ManualAdjustment) error {
+		audit.EnrichCreatedByDirect(ctx, &doc.CreatedBy, &doc.UpdatedBy)
+		return nil
+	})
+	service.Hooks().OnBeforeUpdate(func(ctx context.Context, doc *manual_adjustment.ManualAdjustment) error {
+		audit.EnrichUpdatedByDirect(ctx, &doc.UpdatedBy)
+		return nil
+	})
+
+	domain.RegisterDocumentEvents(service.Hooks(), "manual_adjustment", deps.EventPublisher)
+	registerPostedNotification(service.Hooks(), r.EntityLabel(), r.RoutePrefix(), deps.NotificationInbox)
+
+	// A new adjustment needs four-eyes approval: notify and email the approvers.
+	if deps.NotificationInbox != nil || deps.NotificationEmails != nil {
+		service.Hooks().On(domain.CreateInTx, func(ctx context.Context, doc *manual_adjustment.ManualAdjustment) error {
+			permission := r.Permission() + ":approve"
+			data := notifications.DocumentAwaitingApproval{
+				DocumentLabel: r.EntityLabel(),
+				Number:        doc.Number,
+				Date:          doc.Date,
+				Comment:       doc.Reason,
+				Link:          documentLink(r.RoutePrefix(), doc.ID),
+			}
+			if inbox := deps.NotificationInbox; inbox != nil {
+				if err := inbox.DocumentAwaitingApproval(ctx, permission, data); err != nil {
+					return err
+				}
+			}
+			if emails := deps.NotificationEmails; emails != nil {
+				data.Link = emails.URL(data.Link)
+				return emails.DocumentAwaitingApproval(ctx, permission, data)
+			}
+			return nil
+		})
+	}
+
+	decorated := domain.Chain[*manual_adjustment.ManualAdjustment](
+		domain.WithLogging[*manual_adjustment.ManualAdjustment]("manual-adjustment"),
+		domain.WithEventLog[*manual_adjustment.ManualAdjustment]("manual_adjustment", deps.EventWriter),
+		domain.WithOutboxEvents[*manual_adjustment.ManualAdjustment]("manual_adjustment", deps.OutboxPublisher, deps.CurrencyMetadataResolver),
+	)(service)
+
+	h := handlers.NewManualAdjustmentHandler(deps.BaseHandler, decorated, service, deps.MovementProviders, deps.MovementRefResolver, deps.SettingsRepo)
+	h.SetBoard(board.NewService(repo, manual_adjustment.BoardWorkflow()))
+	return h
 }
 
 // ---------------------------------------------------------------------------
@@ -163,6 +537,7 @@ type CryptoInvoiceRegistration struct{}
 
 func (r *CryptoInvoiceRegistration) RoutePrefix() string { return "crypto-invoice" }
 func (r *CryptoInvoiceRegistration) Permission() string  { return "document:crypto_invoice" }
+func (r *CryptoInvoiceRegistration) Module() string      { return modules.Crypto }
 func (r *CryptoInvoiceRegistration) EntityName() string  { return "CryptoInvoice" }
 func (r *CryptoInvoiceRegistration) EntityLabel() string { return "Крипто-инвойс" }
 func (r *CryptoInvoiceRegistration) EntityPresentation() metadata.Presentation {
@@ -213,6 +588,9 @@ func (r *CryptoInvoiceRegistration) Build(deps v1.DocumentDeps) v1.DocumentRoute
 		return nil
 	})
 
+	domain.RegisterDocumentEvents(service.Hooks(), "crypto_invoice", deps.EventPublisher)
+	registerPostedNotification(service.Hooks(), r.EntityLabel(), r.RoutePrefix(), deps.NotificationInbox)
+
 	decorated := domain.Chain[*crypto_invoice.CryptoInvoice](
 		domain.WithLogging[*crypto_invoice.CryptoInvoice]("crypto-invoice"),
 		domain.WithEventLog[*crypto_invoice.CryptoInvoice]("crypto_invoice", deps.EventWriter),
@@ -230,6 +608,7 @@ type CryptoPaymentRegistration struct{}
 
 func (r *CryptoPaymentRegistration) RoutePrefix() string { return "crypto-payment" }
 func (r *CryptoPaymentRegistration) Permission() string  { return "document:crypto_payment" }
+func (r *CryptoPaymentRegistration) Module() string      { return modules.Crypto }
 func (r *CryptoPaymentRegistration) EntityName() string  { return "CryptoPayment" }
 func (r *CryptoPaymentRegistration) EntityLabel() string { return "Крипто-платёж" }
 func (r *CryptoPaymentRegistration) EntityPresentation() metadata.Presentation {
@@ -261,6 +640,9 @@ func (r *CryptoPaymentRegistration) Build(deps v1.DocumentDeps) v1.DocumentRoute
 		return nil
 	})
 
+	domain.RegisterDocumentEvents(service.Hooks(), "crypto_payment", deps.EventPublisher)
+	registerPostedNotification(service.Hooks(), r.EntityLabel(), r.RoutePrefix(), deps.NotificationInbox)
+
 	decorated := domain.Chain[*crypto_payment.CryptoPayment](
 		domain.WithLogging[*crypto_payment.CryptoPayment]("crypto-payment"),
 		domain.WithEventLog[*crypto_payment.CryptoPayment]("crypto_payment", deps.EventWriter),
@@ -278,6 +660,7 @@ type CryptoWithdrawalRegistration struct{}
 
 func (r *CryptoWithdrawalRegistration) RoutePrefix() string { return "crypto-withdrawal" }
 func (r *CryptoWithdrawalRegistration) Permission() string  { return "document:crypto_withdrawal" }
+func (r *CryptoWithdrawalRegistration) Module() string      { return modules.Crypto }
 func (r *CryptoWithdrawalRegistration) EntityName() string  { return "CryptoWithdrawal" }
 func (r *CryptoWithdrawalRegistration) EntityLabel() string { return "Крипто-вывод" }
 func (r *CryptoWithdrawalRegistration) EntityPresentation() metadata.Presentation {
@@ -309,6 +692,9 @@ func (r *CryptoWithdrawalRegistration) Build(deps v1.DocumentDeps) v1.DocumentRo
 		return nil
 	})
 
+	domain.RegisterDocumentEvents(service.Hooks(), "crypto_withdrawal", deps.EventPublisher)
+	registerPostedNotification(service.Hooks(), r.EntityLabel(), r.RoutePrefix(), deps.NotificationInbox)
+
 	decorated := domain.Chain[*crypto_withdrawal.CryptoWithdrawal](
 		domain.WithLogging[*crypto_withdrawal.CryptoWithdrawal]("crypto-withdrawal"),
 		domain.WithEventLog[*crypto_withdrawal.CryptoWithdrawal]("crypto_withdrawal", deps.EventWriter),
@@ -326,6 +712,7 @@ type CryptoSweepRegistration struct{}
 
 func (r *CryptoSweepRegistration) RoutePrefix() string { return "crypto-sweep" }
 func (r *CryptoSweepRegistration) Permission() string  { return "document:crypto_sweep" }
+func (r *CryptoSweepRegistration) Module() string      { return modules.Crypto }
 func (r *CryptoSweepRegistration) EntityName() string  { return "CryptoSweep" }
 func (r *CryptoSweepRegistration) EntityLabel() string { return "Крипто-свип" }
 func (r *CryptoSweepRegistration) EntityPresentation() metadata.Presentation {
@@ -357,6 +744,9 @@ func (r *CryptoSweepRegistration) Build(deps v1.DocumentDeps) v1.DocumentRouteHa
 		return nil
 	})
 
+	domain.RegisterDocumentEvents(service.Hooks(), "crypto_sweep", deps.EventPublisher)
+	registerPostedNotification(service.Hooks(), r.EntityLabel(), r.RoutePrefix(), deps.NotificationInbox)
+
 	decorated := domain.Chain[*crypto_sweep.CryptoSweep](
 		domain.WithLogging[*crypto_sweep.CryptoSweep]("crypto-sweep"),
 		domain.WithEventLog[*crypto_sweep.CryptoSweep]("crypto_sweep", deps.EventWriter),
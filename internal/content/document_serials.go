@@ -0,0 +1,36 @@
+package content
+
+import (
+	"context"
+
+	"metapus/internal/core/types"
+	"metapus/internal/domain"
+	"metapus/internal/domain/catalogs/nomenclature"
+	"metapus/internal/infrastructure/storage/postgres/catalog_repo"
+)
+
+// serialLine is a goods line that carries serial numbers.
+type serialLine interface {
+	domain.SerialLine
+	BaseQuantity() types.Quantity
+}
+
+// registerLineSerialCheck checks on posting that every line of an item
+// tracked by serial number has one serial per unit. Drafts may be saved
+// with serials still missing; the serials themselves are checked against
+// the pool by the serial number register in the same transaction.
+func registerLineSerialCheck[T linesDocument[L], L serialLine](hooks *domain.HookRegistry[T]) {
+	items := catalog_repo.NewNomenclatureRepo()
+	hooks.On(domain.PostInTx, func(ctx context.Context, doc T) error {
+		lines := doc.GetLines()
+		checks := make([]nomenclature.LineSerials, len(lines))
+		for i, line := range lines {
+			checks[i] = nomenclature.LineSerials{
+				NomenclatureID: line.GetNomenclatureID(),
+				BaseQuantity:   line.BaseQuantity(),
+				Serials:        line.GetSerials(),
+			}
+		}
+		return nomenclature.CheckLineSerials(ctx, items, checks)
+	})
+}
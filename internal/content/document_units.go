@@ -0,0 +1,59 @@
+package content
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain"
+	"metapus/internal/domain/catalogs/nomenclature"
+	"metapus/internal/infrastructure/storage/postgres/catalog_repo"
+)
+
+// linesDocument is a document whose lines are entered in units of measure.
+type linesDocument[L any] interface {
+	GetLines() []L
+	SetLines(lines []L)
+}
+
+// newUnitConverter creates the converter of document line units.
+func newUnitConverter() *nomenclature.UnitConverter {
+	return nomenclature.NewUnitConverter(catalog_repo.NewNomenclatureRepo(), catalog_repo.NewUnitRepo())
+}
+
+// registerLineUnitConversion sets the coefficient of every line from the
+// catalogs before the document is saved, so register movements get the
+// quantity in base units whatever unit the line is entered in. Lines without
+// a nomenclature or unit are left to validation.
+func registerLineUnitConversion[T linesDocument[L], L domain.ValidatableStockLine](hooks *domain.HookRegistry[T], conv *nomenclature.UnitConverter, setCoefficient func(line *L, coefficient decimal.Decimal)) {
+	convert := func(ctx context.Context, doc T) error {
+		lines := doc.GetLines()
+		units := make([]nomenclature.LineUnit, 0, len(lines))
+		indexes := make([]int, 0, len(lines))
+		for i, line := range lines {
+			if id.IsNil(line.GetNomenclatureID()) || id.IsNil(line.GetUnitID()) {
+				continue
+			}
+			units = append(units, nomenclature.LineUnit{NomenclatureID: line.GetNomenclatureID(), UnitID: line.GetUnitID(), LineNo: i + 1})
+			indexes = append(indexes, i)
+		}
+		if len(units) == 0 {
+			return nil
+		}
+
+		coefficients, ok, err := conv.Coefficients(ctx, units)
+		if err != nil {
+			return err
+		}
+		for j, i := range indexes {
+			if ok[j] {
+				setCoefficient(&lines[i], coefficients[j])
+			}
+		}
+		doc.SetLines(lines)
+		return nil
+	}
+	hooks.OnBeforeCreate(convert)
+	hooks.OnBeforeUpdate(convert)
+}
@@ -24,6 +24,8 @@ func RegisterDefaults(reg *v1.FactoryRegistry) {
 	reg.RegisterCatalog(&OrganizationRegistration{})
 	reg.RegisterCatalog(&VATRateRegistration{})
 	reg.RegisterCatalog(&ContractRegistration{})
+	reg.RegisterCatalog(&BankAccountRegistration{})
+	reg.RegisterCatalog(&WebhookSubscriptionRegistration{})
 
 	// Crypto catalogs
 	reg.RegisterCatalog(&BlockchainNetworkRegistration{})
@@ -35,6 +37,10 @@ func RegisterDefaults(reg *v1.FactoryRegistry) {
 	// Documents
 	reg.RegisterDocument(&GoodsReceiptRegistration{})
 	reg.RegisterDocument(&GoodsIssueRegistration{})
+	reg.RegisterDocument(&SalesOrderRegistration{})
+	reg.RegisterDocument(&PurchaseOrderRegistration{})
+	reg.RegisterDocument(&GoodsTransferRegistration{})
+	reg.RegisterDocument(&ManualAdjustmentRegistration{})
 	reg.RegisterDocument(&CryptoInvoiceRegistration{})
 	reg.RegisterDocument(&CryptoPaymentRegistration{})
 	reg.RegisterDocument(&CryptoWithdrawalRegistration{})
@@ -42,6 +48,8 @@ func RegisterDefaults(reg *v1.FactoryRegistry) {
 
 	// Registers
 	reg.RegisterRegister(&StockRegisterRegistration{})
+	reg.RegisterRegister(&SerialNumberRegisterRegistration{})
+	reg.RegisterRegister(&ExchangeRateRegisterRegistration{})
 
 	// Datasets — declarative, metadata-driven reports (replaces legacy RegisterTypedReport)
 	for _, ds := range AllDatasets() {
@@ -56,7 +64,12 @@ func AllDatasets() []*schema.Dataset {
 	return []*schema.Dataset{
 		&StockBalanceDataset,
 		&StockTurnoverDataset,
+		&StockTurnoverBalanceDataset,
+		&CostTurnoverBalanceDataset,
 		&DocumentJournalDataset,
+		&PurchaseOrdersOpenDataset,
+		&StockReplenishmentDataset,
+		&DocumentRateDiscrepancyDataset,
 	}
 }
 
@@ -3,11 +3,16 @@ package content
 import (
 	"github.com/gin-gonic/gin"
 
+	"metapus/internal/core/jobs"
 	v1 "metapus/internal/infrastructure/http/v1"
 	"metapus/internal/infrastructure/http/v1/handlers"
 	"metapus/internal/infrastructure/http/v1/middleware"
+	"metapus/internal/infrastructure/storage/postgres"
+	"metapus/internal/infrastructure/storage/postgres/catalog_repo"
 	"metapus/internal/infrastructure/storage/postgres/register_repo"
 
+	"metapus/internal/domain/registers/exchange_rate"
+	"metapus/internal/domain/registers/serial_number"
 	"metapus/internal/domain/registers/stock"
 )
 
@@ -29,4 +34,71 @@ func (r *StockRegisterRegistration) RegisterRoutes(group *gin.RouterGroup, cfg v
 	group.GET("/movements", middleware.RequirePermission("register:stock:read"), stockHandler.GetMovements)
 	group.GET("/turnovers", middleware.RequirePermission("register:stock:read"), stockHandler.GetTurnovers)
 	group.GET("/availability/:nomenclatureId", middleware.RequirePermission("register:stock:read"), stockHandler.GetNomenclatureAvailability)
+
+	// Admin bulk correction of movements (wrong warehouse, merged products).
+	correctionSvc := stock.NewCorrectionService(stockRepo, register_repo.NewStockCorrectionRepo())
+	correctionHandler := handlers.NewStockCorrectionHandler(baseHandler, correctionSvc)
+	corrections := group.Group("/corrections", middleware.RequireRole("admin"))
+	{
+		corrections.GET("", correctionHandler.List)
+		corrections.POST("/preview", middleware.ReadsOnly(), correctionHandler.Preview)
+		corrections.POST("", correctionHandler.Apply)
+	}
+
+	// Admin rebuild of balances from movements (sync, or queued with async).
+	recalculationHandler := handlers.NewStockRecalculationHandler(baseHandler,
+		stock.NewRecalculationService(stockRepo), jobs.NewQueue(postgres.NewJobRepo()))
+	group.POST("/balances/recalculate", middleware.RequireRole("admin"), recalculationHandler.Recalculate)
+
+	// Minimum/maximum stock levels; the worker raises low-stock alerts from them.
+	levelHandler := handlers.NewStockLevelHandler(baseHandler, stock.NewLevelService(register_repo.NewStockLevelRepo()))
+	levels := group.Group("/levels")
+	{
+		levels.GET("", middleware.RequirePermission("register:stock:read"), levelHandler.List)
+		levels.PUT("", middleware.RequirePermission("stock_level.manage"), levelHandler.Upsert)
+		levels.DELETE("", middleware.RequirePermission("stock_level.manage"), levelHandler.Delete)
+	}
+	group.GET("/low-stock-alerts", middleware.RequirePermission("register:stock:read"), levelHandler.Alerts)
+
+	// Inventory counts: freeze stock movements in the counted scope while in progress.
+	inventorySvc := stock.NewInventoryService(register_repo.NewStockInventoryRepo())
+	inventoryHandler := handlers.NewStockInventoryHandler(baseHandler, inventorySvc)
+	inventory := group.Group("/inventory-counts")
+	{
+		inventory.GET("", middleware.RequirePermission("register:stock:read"), inventoryHandler.List)
+		inventory.POST("", middleware.RequirePermission("inventory_count.manage"), inventoryHandler.Start)
+		inventory.POST("/:id/complete", middleware.RequirePermission("inventory_count.manage"), inventoryHandler.Complete)
+		inventory.POST("/:id/cancel", middleware.RequirePermission("inventory_count.manage"), inventoryHandler.Cancel)
+	}
+}
+
+type SerialNumberRegisterRegistration struct{}
+
+func (r *SerialNumberRegisterRegistration) RoutePrefix() string { return "serial-numbers" }
+
+func (r *SerialNumberRegisterRegistration) RegisterRoutes(group *gin.RouterGroup, cfg v1.RouterConfig) {
+	handler := handlers.NewSerialNumberHandler(handlers.NewBaseHandler(),
+		serial_number.NewService(register_repo.NewSerialNumberRepo()))
+
+	group.GET("", middleware.RequirePermission("register:stock:read"), handler.List)
+	group.GET("/history", middleware.RequirePermission("register:stock:read"), handler.History)
+}
+
+// ---------------------------------------------------------------------------
+// Information Registers
+// ---------------------------------------------------------------------------
+
+type ExchangeRateRegisterRegistration struct{}
+
+func (r *ExchangeRateRegisterRegistration) RoutePrefix() string { return "exchange-rates" }
+
+func (r *ExchangeRateRegisterRegistration) RegisterRoutes(group *gin.RouterGroup, cfg v1.RouterConfig) {
+	rateRepo := register_repo.NewExchangeRateRepo()
+	converter := exchange_rate.NewConverter(rateRepo, catalog_repo.NewCurrencyRepo())
+	handler := handlers.NewExchangeRateHandler(handlers.NewBaseHandler(), exchange_rate.NewService(rateRepo), converter)
+
+	group.GET("", middleware.RequirePermission("register:exchange_rate:read"), handler.List)
+	group.GET("/convert", middleware.RequirePermission("register:exchange_rate:read"), handler.Convert)
+	group.PUT("", middleware.RequirePermission("register:exchange_rate:write"), handler.Upsert)
+	group.DELETE("", middleware.RequirePermission("register:exchange_rate:write"), handler.Delete)
 }
@@ -9,12 +9,42 @@ import (
 	"github.com/Masterminds/squirrel"
 
 	"metapus/internal/core/types"
+	"metapus/internal/domain/documents/manual_adjustment"
 	"metapus/internal/domain/reports/schema"
 )
 
 // qtyScale is the SQL fragment for dividing stored quantity by the scale constant.
 var qtyScale = fmt.Sprintf("::float8 / %d.0", types.QuantityScale)
 
+// lastStockSnapshotSQL selects the latest month-end stock snapshot not after
+// a date (NULL when there is none).
+const lastStockSnapshotSQL = "(SELECT MAX(period_end) FROM reg_stock_snapshot_periods WHERE period_end <= ?)"
+
+// stockQuantitiesAsOf selects signed stock quantities (warehouse_id,
+// nomenclature_id, quantity) that sum up to the balances at date: the latest
+// month-end snapshot plus the movements after it, so that old history is not
+// scanned. Movements at date itself count only when inclusive.
+func stockQuantitiesAsOf(date time.Time, inclusive bool) squirrel.SelectBuilder {
+	var upTo squirrel.Sqlizer = squirrel.Lt{"period": date}
+	if inclusive {
+		upTo = squirrel.LtOrEq{"period": date}
+	}
+	// Nested builders keep "?" placeholders; the outer query numbers them.
+	movements, movementArgs, _ := squirrel.Select(
+		"warehouse_id",
+		"nomenclature_id",
+		"CASE WHEN record_type = 'receipt' THEN quantity ELSE -quantity END AS quantity",
+	).From("reg_stock_movements").
+		Where(squirrel.Expr("period >= COALESCE("+lastStockSnapshotSQL+", '-infinity')", date)).
+		Where(upTo).
+		ToSql()
+
+	return squirrel.Select("warehouse_id", "nomenclature_id", "quantity").
+		From("reg_stock_snapshots").
+		Where(squirrel.Expr("period_end = "+lastStockSnapshotSQL, date)).
+		Suffix("UNION ALL "+movements, movementArgs...)
+}
+
 // ---------------------------------------------------------------------------
 // Stock Balance Dataset
 // ---------------------------------------------------------------------------
@@ -29,6 +59,7 @@ var StockBalanceDataset = schema.Dataset{
 		{Name: "warehouse_id", Label: "Склад", Kind: schema.FieldDimension, Type: schema.TypeRef, RefEntity: "warehouse", Sortable: true},
 		{Name: "nomenclature_id", Label: "Товар", Kind: schema.FieldDimension, Type: schema.TypeRef, RefEntity: "nomenclature", Sortable: true},
 		{Name: "quantity", Label: "Остаток", Kind: schema.FieldMeasure, Type: schema.TypeQuantity, Agg: schema.AggSum, Sortable: true, Scale: 4},
+		{Name: "total_cost", Label: "Себестоимость", Kind: schema.FieldMeasure, Type: schema.TypeMoney, Agg: schema.AggSum, Sortable: true, Scale: 2},
 	},
 	Filters: []schema.FilterDef{
 		{Key: "as_of_date", Label: "Дата остатков", Type: schema.FilterDate},
@@ -124,10 +155,18 @@ func (e *stockBalanceExecutor) BuildQuery(ctx context.Context, params map[string
 			builder.Select(
 				"m.warehouse_id",
 				"m.nomenclature_id",
-				"SUM(CASE WHEN m.record_type = 'receipt' THEN m.quantity ELSE -m.quantity END)"+qtyScale+" as quantity",
+				"SUM(m.quantity)"+qtyScale+" as quantity",
 			).
-				From("reg_stock_movements m").
-				Where(squirrel.LtOrEq{"m.period": asOfDate}).
+				// Remaining FIFO cost from the cost register. Amounts of all
+				// currencies are summed; use cost-turnover-balance for a split.
+				Column(squirrel.Expr(`COALESCE((
+					SELECT SUM(CASE WHEN c.record_type = 'receipt' THEN c.amount ELSE -c.amount END)
+					FROM reg_cost_movements c
+					WHERE c.warehouse_id = m.warehouse_id
+						AND c.nomenclature_id = m.nomenclature_id
+						AND c.period <= ?
+				), 0) as total_cost`, asOfDate)).
+				FromSelect(stockQuantitiesAsOf(asOfDate, true), "m").
 				GroupBy("m.warehouse_id", "m.nomenclature_id"),
 			"base",
 		)
@@ -165,6 +204,7 @@ var StockTurnoverDataset = schema.Dataset{
 		{Name: "opening_balance", Label: "Нач. остаток", Kind: schema.FieldMeasure, Type: schema.TypeQuantity, Agg: schema.AggSum, Sortable: true, Scale: 4},
 		{Name: "receipt", Label: "Приход", Kind: schema.FieldMeasure, Type: schema.TypeQuantity, Agg: schema.AggSum, Sortable: true, Scale: 4},
 		{Name: "expense", Label: "Расход", Kind: schema.FieldMeasure, Type: schema.TypeQuantity, Agg: schema.AggSum, Sortable: true, Scale: 4},
+		{Name: "adjustment", Label: "Ручные корректировки", Kind: schema.FieldMeasure, Type: schema.TypeQuantity, Agg: schema.AggSum, Sortable: true, Scale: 4},
 		{Name: "closing_balance", Label: "Кон. остаток", Kind: schema.FieldMeasure, Type: schema.TypeQuantity, Agg: schema.AggSum, Sortable: true, Scale: 4},
 	},
 	Filters: []schema.FilterDef{
@@ -194,16 +234,16 @@ func (e *stockTurnoverExecutor) BuildQuery(ctx context.Context, params map[strin
 	openingSub := builder.Select(
 		"m.warehouse_id",
 		"m.nomenclature_id",
-		"SUM(CASE WHEN m.record_type = 'receipt' THEN m.quantity ELSE -m.quantity END)"+qtyScale+" as opening_qty",
-	).From("reg_stock_movements m").
-		Where(squirrel.Lt{"m.period": fromDate}).
+		"SUM(m.quantity)"+qtyScale+" as opening_qty",
+	).FromSelect(stockQuantitiesAsOf(fromDate, false), "m").
 		GroupBy("m.warehouse_id", "m.nomenclature_id")
 
 	mainSub := builder.Select(
 		"m.warehouse_id",
 		"m.nomenclature_id",
-		"SUM(CASE WHEN m.record_type = 'receipt' THEN m.quantity ELSE 0 END)"+qtyScale+" as receipt",
-		"SUM(CASE WHEN m.record_type = 'expense' THEN m.quantity ELSE 0 END)"+qtyScale+" as expense",
+		"SUM(CASE WHEN m.record_type = 'receipt' AND m.recorder_type <> '"+manual_adjustment.DocumentType+"' THEN m.quantity ELSE 0 END)"+qtyScale+" as receipt",
+		"SUM(CASE WHEN m.record_type = 'expense' AND m.recorder_type <> '"+manual_adjustment.DocumentType+"' THEN m.quantity ELSE 0 END)"+qtyScale+" as expense",
+		"SUM(CASE WHEN m.recorder_type <> '"+manual_adjustment.DocumentType+"' THEN 0 WHEN m.record_type = 'receipt' THEN m.quantity ELSE -m.quantity END)"+qtyScale+" as adjustment",
 	).From("reg_stock_movements m").
 		Where(squirrel.And{
 			squirrel.GtOrEq{"m.period": fromDate},
@@ -230,7 +270,8 @@ func (e *stockTurnoverExecutor) BuildQuery(ctx context.Context, params map[strin
 			COALESCE(o.opening_qty, 0) as opening_balance,
 			COALESCE(t.receipt, 0) as receipt,
 			COALESCE(t.expense, 0) as expense,
-			COALESCE(o.opening_qty, 0) + COALESCE(t.receipt, 0) - COALESCE(t.expense, 0) as closing_balance
+			COALESCE(t.adjustment, 0) as adjustment,
+			COALESCE(o.opening_qty, 0) + COALESCE(t.receipt, 0) - COALESCE(t.expense, 0) + COALESCE(t.adjustment, 0) as closing_balance
 		FROM (%s) t
 		FULL OUTER JOIN (%s) o
 			ON t.warehouse_id = o.warehouse_id AND t.nomenclature_id = o.nomenclature_id`,
@@ -277,11 +318,13 @@ var DocumentJournalDataset = schema.Dataset{
 		{Name: "total_amount", Label: "Сумма", Kind: schema.FieldMeasure, Type: schema.TypeMoney, Agg: schema.AggSum, Sortable: true, Scale: 2},
 		{Name: "currency", Label: "Валюта", Kind: schema.FieldAttribute, Type: schema.TypeString},
 		{Name: "description", Label: "Комментарий", Kind: schema.FieldAttribute, Type: schema.TypeString, Hidden: true},
+		{Name: "is_adjustment", Label: "Ручная корректировка", Kind: schema.FieldAttribute, Type: schema.TypeBoolean, Sortable: true},
 	},
 	Filters: []schema.FilterDef{
 		{Key: "from_date", Label: "Начало периода", Type: schema.FilterDate},
 		{Key: "to_date", Label: "Конец периода", Type: schema.FilterDate},
 		{Key: "posted", Label: "Проведённые", Type: schema.FilterBoolean},
+		{Key: "exclude_adjustments", Label: "Без ручных корректировок", Type: schema.FilterBoolean},
 	},
 	DefaultSort:   &schema.SortDef{Column: "date", Direction: "desc"},
 	ExportFormats: []string{"csv", "xlsx"},
@@ -324,36 +367,251 @@ func (e *documentJournalExecutor) BuildQuery(ctx context.Context, params map[str
 		LeftJoin("cat_counterparties cp ON d.counterparty_id = cp.id").
 		Where("d.deletion_mark = false")
 
-	// Apply date filters
-	if fromDate, ok := extractOptionalDate(params, "from_date"); ok {
-		grQuery = grQuery.Where(squirrel.GtOrEq{"d.date": fromDate})
-		giQuery = giQuery.Where(squirrel.GtOrEq{"d.date": fromDate})
+	// Manual Adjustment — register corrections, flagged via is_adjustment
+	// and shown with their mandatory reason instead of a counterparty.
+	maQuery := builder.Select(
+		"d.id", "'manual_adjustment' as document_type", "d.number", "d.date",
+		"d.posted",
+		"'' as counterparty_name",
+		"'' as warehouse_name",
+		"COALESCE((SELECT SUM(amount) FROM doc_manual_adjustment_lines WHERE document_id = d.id), 0) as total_amount",
+		"COALESCE(cur.iso_code, '') as currency",
+		"d.reason as description",
+	).From("doc_manual_adjustments d").
+		LeftJoin("cat_currencies cur ON d.currency_id = cur.id").
+		Where("d.deletion_mark = false")
+
+	parts := []squirrel.SelectBuilder{
+		grQuery.Column("false as is_adjustment"),
+		giQuery.Column("false as is_adjustment"),
+		maQuery.Column("true as is_adjustment"),
+	}
+
+	// Apply filters to every part
+	for i := range parts {
+		if fromDate, ok := extractOptionalDate(params, "from_date"); ok {
+			parts[i] = parts[i].Where(squirrel.GtOrEq{"d.date": fromDate})
+		}
+		if toDate, ok := extractOptionalDate(params, "to_date"); ok {
+			parts[i] = parts[i].Where(squirrel.Lt{"d.date": toDate})
+		}
+		if posted, ok := params["posted"]; ok {
+			if b, ok := posted.(bool); ok {
+				parts[i] = parts[i].Where(squirrel.Eq{"d.posted": b})
+			}
+		}
 	}
-	if toDate, ok := extractOptionalDate(params, "to_date"); ok {
-		grQuery = grQuery.Where(squirrel.Lt{"d.date": toDate})
-		giQuery = giQuery.Where(squirrel.Lt{"d.date": toDate})
+	if exclude, ok := params["exclude_adjustments"].(bool); ok && exclude {
+		parts = parts[:len(parts)-1]
 	}
-	if posted, ok := params["posted"]; ok {
-		if b, ok := posted.(bool); ok {
-			grQuery = grQuery.Where(squirrel.Eq{"d.posted": b})
-			giQuery = giQuery.Where(squirrel.Eq{"d.posted": b})
+
+	// Build UNION ALL, shifting each part's placeholders past the previous ones
+	var (
+		unionParts []string
+		allArgs    []any
+	)
+	for _, part := range parts {
+		partSQL, partArgs, err := part.ToSql()
+		if err != nil {
+			return squirrel.SelectBuilder{}, err
 		}
+		unionParts = append(unionParts, reNumberPlaceholders(partSQL, len(allArgs)))
+		allArgs = append(allArgs, partArgs...)
 	}
 
-	// Build UNION ALL
-	grSQL, grArgs, _ := grQuery.ToSql()
-	giSQL, giArgs, _ := giQuery.ToSql()
+	// Carry the union args through squirrel the same way as stockTurnoverExecutor.
+	innerBuilder := builder.
+		Select("*").
+		From("(" + strings.Join(unionParts, " UNION ALL ") + ") AS _inner").
+		Where(squirrel.Expr("1=1", allArgs...))
 
-	reNumberedGI := reNumberPlaceholders(giSQL, len(grArgs))
-	allArgs := append(grArgs, giArgs...)
+	qb := builder.Select().FromSelect(innerBuilder, "base")
+	return qb, nil
+}
 
-	unionSQL := fmt.Sprintf("(%s UNION ALL %s) AS base", grSQL, reNumberedGI)
+// ---------------------------------------------------------------------------
+// Open Purchase Orders Dataset
+// ---------------------------------------------------------------------------
 
-	// Wrap in outer select
-	qb := squirrel.Select().From(unionSQL).PlaceholderFormat(squirrel.Dollar)
+// PurchaseOrdersOpenDataset defines the "Открытые заказы поставщикам" report:
+// lines of posted purchase orders that are not fully received yet.
+var PurchaseOrdersOpenDataset = schema.Dataset{
+	Key:         "purchase-orders-open",
+	Name:        "Открытые заказы поставщикам",
+	Description: "Строки заказов поставщикам, ожидающие поступления",
+	Permission:  "report:purchase-order:read",
+	Fields: []schema.Field{
+		{Name: "order_id", Label: "ID заказа", Kind: schema.FieldAttribute, Type: schema.TypeString, Hidden: true},
+		{Name: "order_number", Label: "Заказ", Kind: schema.FieldDimension, Type: schema.TypeString, Sortable: true},
+		{Name: "order_date", Label: "Дата заказа", Kind: schema.FieldAttribute, Type: schema.TypeDate, Sortable: true},
+		{Name: "counterparty_id", Label: "Поставщик", Kind: schema.FieldDimension, Type: schema.TypeRef, RefEntity: "counterparty", Sortable: true},
+		{Name: "warehouse_id", Label: "Склад", Kind: schema.FieldDimension, Type: schema.TypeRef, RefEntity: "warehouse", Sortable: true},
+		{Name: "nomenclature_id", Label: "Товар", Kind: schema.FieldDimension, Type: schema.TypeRef, RefEntity: "nomenclature", Sortable: true},
+		{Name: "expected_date", Label: "Дата поступления", Kind: schema.FieldAttribute, Type: schema.TypeDate, Sortable: true},
+		{Name: "ordered", Label: "Заказано", Kind: schema.FieldMeasure, Type: schema.TypeQuantity, Agg: schema.AggSum, Sortable: true, Scale: 4},
+		{Name: "received", Label: "Поступило", Kind: schema.FieldMeasure, Type: schema.TypeQuantity, Agg: schema.AggSum, Sortable: true, Scale: 4},
+		{Name: "remaining", Label: "Ожидается", Kind: schema.FieldMeasure, Type: schema.TypeQuantity, Agg: schema.AggSum, Sortable: true, Scale: 4},
+	},
+	Filters: []schema.FilterDef{
+		{Key: "expected_to", Label: "Ожидается до", Type: schema.FilterDate},
+	},
+	DefaultSort:   &schema.SortDef{Column: "expected_date", Direction: "asc"},
+	ExportFormats: []string{"csv", "xlsx"},
+	Executor:      &purchaseOrdersOpenExecutor{},
+}
 
-	_ = allArgs // args will be handled by squirrel
-	return qb, nil
+type purchaseOrdersOpenExecutor struct{}
+
+func (e *purchaseOrdersOpenExecutor) BuildQuery(ctx context.Context, params map[string]any) (squirrel.SelectBuilder, error) {
+	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	// Ordered and received quantities per order line come from the register;
+	// the balance (ordered - received) is what is still expected.
+	inner := builder.Select(
+		"o.id AS order_id",
+		"o.number AS order_number",
+		"o.date AS order_date",
+		"o.counterparty_id",
+		"o.warehouse_id",
+		"l.nomenclature_id",
+		"COALESCE(l.expected_date, o.expected_date) AS expected_date",
+		"t.ordered"+qtyScale+" AS ordered",
+		"t.received"+qtyScale+" AS received",
+		"(t.ordered - t.received)"+qtyScale+" AS remaining",
+	).
+		From("doc_purchase_orders o").
+		Join("doc_purchase_order_lines l ON l.document_id = o.id").
+		Join(`(
+			SELECT order_id, order_line_id,
+				COALESCE(SUM(quantity) FILTER (WHERE record_type = 'receipt'), 0) AS ordered,
+				COALESCE(SUM(quantity) FILTER (WHERE record_type = 'expense'), 0) AS received
+			FROM reg_purchase_order_movements
+			GROUP BY order_id, order_line_id
+		) t ON t.order_id = o.id AND t.order_line_id = l.line_id`).
+		Where(squirrel.Eq{"o.posted": true, "o.deletion_mark": false}).
+		Where("t.ordered > t.received")
+
+	if counterpartyIDs, ok := extractIDSlice(params, "counterparty_id"); ok {
+		inner = inner.Where(squirrel.Eq{"o.counterparty_id": counterpartyIDs})
+	}
+	if warehouseIDs, ok := extractIDSlice(params, "warehouse_id"); ok {
+		inner = inner.Where(squirrel.Eq{"o.warehouse_id": warehouseIDs})
+	}
+	if nomenclatureIDs, ok := extractIDSlice(params, "nomenclature_id"); ok {
+		inner = inner.Where(squirrel.Eq{"l.nomenclature_id": nomenclatureIDs})
+	}
+	if expectedTo, ok := extractOptionalDate(params, "expected_to"); ok {
+		inner = inner.Where(squirrel.LtOrEq{"COALESCE(l.expected_date, o.expected_date)": expectedTo})
+	}
+
+	return builder.Select().FromSelect(inner, "base"), nil
+}
+
+// ---------------------------------------------------------------------------
+// Document Rate Discrepancies Dataset
+// ---------------------------------------------------------------------------
+
+// DocumentRateDiscrepancyDataset defines the "Расхождения курсов документов"
+// report: posted foreign-currency documents whose rate frozen on posting
+// differs from the rate the register currently gives for the document date
+// (e.g. the register was corrected retroactively).
+var DocumentRateDiscrepancyDataset = schema.Dataset{
+	Key:         "document-rate-discrepancies",
+	Name:        "Расхождения курсов документов",
+	Description: "Проведённые документы, курс которых отличается от курса в регистре на дату документа",
+	Permission:  "report:exchange-rate:read",
+	Fields: []schema.Field{
+		{Name: "id", Label: "ID", Kind: schema.FieldAttribute, Type: schema.TypeString, Hidden: true},
+		{Name: "document_type", Label: "Тип документа", Kind: schema.FieldDimension, Type: schema.TypeString, Sortable: true},
+		{Name: "number", Label: "Номер", Kind: schema.FieldAttribute, Type: schema.TypeString, Sortable: true},
+		{Name: "date", Label: "Дата", Kind: schema.FieldDimension, Type: schema.TypeDate, Sortable: true},
+		{Name: "currency", Label: "Валюта", Kind: schema.FieldDimension, Type: schema.TypeString, Sortable: true},
+		{Name: "total_amount", Label: "Сумма", Kind: schema.FieldMeasure, Type: schema.TypeMoney, Agg: schema.AggSum, Sortable: true, Scale: 2},
+		{Name: "exchange_rate", Label: "Курс документа", Kind: schema.FieldAttribute, Type: schema.TypeNumber, Sortable: true},
+		{Name: "exchange_rate_multiplier", Label: "Кратность документа", Kind: schema.FieldAttribute, Type: schema.TypeInteger},
+		{Name: "register_rate", Label: "Курс регистра", Kind: schema.FieldAttribute, Type: schema.TypeNumber, Sortable: true},
+		{Name: "register_multiplier", Label: "Кратность регистра", Kind: schema.FieldAttribute, Type: schema.TypeInteger},
+		{Name: "total_amount_base", Label: "Сумма в базовой валюте", Kind: schema.FieldMeasure, Type: schema.TypeMoney, Agg: schema.AggSum, Sortable: true, Scale: 2},
+		{Name: "register_amount_base", Label: "Сумма по курсу регистра", Kind: schema.FieldMeasure, Type: schema.TypeMoney, Agg: schema.AggSum, Sortable: true, Scale: 2},
+	},
+	Filters: []schema.FilterDef{
+		{Key: "from_date", Label: "Начало периода", Type: schema.FilterDate},
+		{Key: "to_date", Label: "Конец периода", Type: schema.FilterDate},
+	},
+	DefaultSort:   &schema.SortDef{Column: "date", Direction: "desc"},
+	ExportFormats: []string{"csv", "xlsx"},
+	Executor:      &documentRateDiscrepancyExecutor{},
+}
+
+// rateDocumentTables lists the document tables storing a rate frozen on posting.
+var rateDocumentTables = []struct{ table, documentType string }{
+	{"doc_goods_receipts", "goods_receipt"},
+	{"doc_goods_issues", "goods_issue"},
+	{"doc_sales_orders", "sales_order"},
+	{"doc_purchase_orders", "purchase_order"},
+}
+
+type documentRateDiscrepancyExecutor struct{}
+
+func (e *documentRateDiscrepancyExecutor) BuildQuery(ctx context.Context, params map[string]any) (squirrel.SelectBuilder, error) {
+	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	var (
+		unionParts []string
+		allArgs    []any
+	)
+	for _, t := range rateDocumentTables {
+		// The register rate is picked the same way as exchange_rate.Converter
+		// does: latest rate on the date, best source priority first.
+		part := builder.Select(
+			"d.id", "'"+t.documentType+"' AS document_type", "d.number", "d.date",
+			"cur.iso_code AS currency",
+			"d.total_amount",
+			"d.exchange_rate::float8 AS exchange_rate",
+			"d.exchange_rate_multiplier",
+			"er.rate::float8 AS register_rate",
+			"er.multiplier AS register_multiplier",
+			"d.total_amount_base",
+			`ROUND(d.total_amount::numeric / power(10, cur.decimal_places)
+				* er.rate / er.multiplier * power(10, base.decimal_places))::bigint AS register_amount_base`,
+		).
+			From(t.table + " d").
+			Join("cat_currencies cur ON cur.id = d.currency_id").
+			LeftJoin("cat_currencies base ON base.is_base AND base.deletion_mark = FALSE").
+			LeftJoin(`LATERAL (
+				SELECT r.rate, r.multiplier
+				FROM reg_exchange_rates r
+				JOIN cat_rate_sources rs ON rs.id = r.rate_source_id
+				WHERE r.currency_id = d.currency_id AND r.date <= d.date
+				  AND rs.is_active = TRUE AND rs.deletion_mark = FALSE AND rs._deleted_at IS NULL
+				ORDER BY rs.priority, r.date DESC
+				LIMIT 1
+			) er ON TRUE`).
+			Where(squirrel.Eq{"d.posted": true, "d.deletion_mark": false}).
+			Where("d.exchange_rate IS NOT NULL").
+			Where("(er.rate IS NULL OR er.rate / er.multiplier <> d.exchange_rate / d.exchange_rate_multiplier)")
+
+		if fromDate, ok := extractOptionalDate(params, "from_date"); ok {
+			part = part.Where(squirrel.GtOrEq{"d.date": fromDate})
+		}
+		if toDate, ok := extractOptionalDate(params, "to_date"); ok {
+			part = part.Where(squirrel.Lt{"d.date": toDate})
+		}
+
+		partSQL, partArgs, err := part.ToSql()
+		if err != nil {
+			return squirrel.SelectBuilder{}, err
+		}
+		unionParts = append(unionParts, reNumberPlaceholders(partSQL, len(allArgs)))
+		allArgs = append(allArgs, partArgs...)
+	}
+
+	innerBuilder := builder.
+		Select("*").
+		From("(" + strings.Join(unionParts, " UNION ALL ") + ") AS _inner").
+		Where(squirrel.Expr("1=1", allArgs...))
+
+	return builder.Select().FromSelect(innerBuilder, "base"), nil
 }
 
 // ---------------------------------------------------------------------------
@@ -14,7 +14,7 @@ import (
 func BuildReportRegistry() *metadata.Registry {
 	reg := metadata.NewRegistry()
 
-	// Warehouse — referenced by stock-balance, stock-turnover datasets.
+	// Warehouse — referenced by stock-balance, stock-turnover, *-turnover-balance datasets.
 	reg.RegisterReferenceMapping("warehouse", "Warehouse")
 	reg.Register(metadata.EntityDef{
 		Name:      "Warehouse",
@@ -27,7 +27,7 @@ func BuildReportRegistry() *metadata.Registry {
 		},
 	})
 
-	// Nomenclature — referenced by stock-balance, stock-turnover datasets.
+	// Nomenclature — referenced by stock-balance, stock-turnover, *-turnover-balance datasets.
 	reg.RegisterReferenceMapping("nomenclature", "Nomenclature")
 	reg.Register(metadata.EntityDef{
 		Name:      "Nomenclature",
@@ -66,5 +66,18 @@ func BuildReportRegistry() *metadata.Registry {
 		},
 	})
 
+	// Currency — referenced by cost-turnover-balance dataset.
+	reg.RegisterReferenceMapping("currency", "Currency")
+	reg.Register(metadata.EntityDef{
+		Name:      "Currency",
+		Key:       "currency",
+		Type:      metadata.TypeCatalog,
+		TableName: "cat_currencies",
+		Fields: []metadata.FieldDef{
+			{Name: "name", Label: "Наименование", Type: metadata.TypeString},
+			{Name: "iso_code", Label: "Код ISO", Type: metadata.TypeString},
+		},
+	})
+
 	return reg
 }
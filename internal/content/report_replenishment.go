@@ -0,0 +1,98 @@
+package content
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+
+	"metapus/internal/domain/reports/schema"
+)
+
+// ---------------------------------------------------------------------------
+// Stock Replenishment Dataset
+// ---------------------------------------------------------------------------
+
+// StockReplenishmentDataset defines the "Потребность в пополнении" report:
+// the keys of reg_stock_levels whose balance plus the quantity still
+// expected from open purchase orders is below the minimum, with the
+// quantity to order to reach the maximum (the minimum when unset).
+var StockReplenishmentDataset = schema.Dataset{
+	Key:         "stock-replenishment",
+	Name:        "Потребность в пополнении",
+	Description: "Товары с остатком ниже минимума и рекомендуемое количество к заказу",
+	Permission:  "report:stock:read",
+	Fields: []schema.Field{
+		{Name: "warehouse_id", Label: "Склад", Kind: schema.FieldDimension, Type: schema.TypeRef, RefEntity: "warehouse", Sortable: true},
+		{Name: "nomenclature_id", Label: "Товар", Kind: schema.FieldDimension, Type: schema.TypeRef, RefEntity: "nomenclature", Sortable: true},
+		{Name: "quantity", Label: "Остаток", Kind: schema.FieldMeasure, Type: schema.TypeQuantity, Agg: schema.AggSum, Sortable: true, Scale: 4},
+		{Name: "on_order", Label: "Ожидается", Kind: schema.FieldMeasure, Type: schema.TypeQuantity, Agg: schema.AggSum, Sortable: true, Scale: 4},
+		{Name: "min_quantity", Label: "Минимум", Kind: schema.FieldMeasure, Type: schema.TypeQuantity, Agg: schema.AggSum, Sortable: true, Scale: 4},
+		{Name: "max_quantity", Label: "Максимум", Kind: schema.FieldMeasure, Type: schema.TypeQuantity, Agg: schema.AggSum, Sortable: true, Scale: 4},
+		{Name: "suggested", Label: "К заказу", Kind: schema.FieldMeasure, Type: schema.TypeQuantity, Agg: schema.AggSum, Sortable: true, Scale: 4},
+	},
+	Filters: []schema.FilterDef{
+		{Key: "ignore_on_order", Label: "Без учёта заказов поставщикам", Type: schema.FilterBoolean, Default: false},
+	},
+	ScopeDimensions: []string{"warehouse"},
+	DefaultSort:     &schema.SortDef{Column: "suggested", Direction: "desc"},
+	ExportFormats:   []string{"csv", "xlsx"},
+	Executor:        &stockReplenishmentExecutor{},
+}
+
+type stockReplenishmentExecutor struct{}
+
+func (e *stockReplenishmentExecutor) BuildQuery(ctx context.Context, params map[string]any) (squirrel.SelectBuilder, error) {
+	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	// Quantity still expected per warehouse+nomenclature: ordered minus
+	// received over the open lines of posted purchase orders.
+	onOrder := "0"
+	if !extractBool(params, "ignore_on_order", false) {
+		onOrder = `COALESCE((
+			SELECT SUM(t.ordered - t.received)
+			FROM doc_purchase_orders o
+			JOIN doc_purchase_order_lines pl ON pl.document_id = o.id
+			JOIN (
+				SELECT order_id, order_line_id,
+					COALESCE(SUM(quantity) FILTER (WHERE record_type = 'receipt'), 0) AS ordered,
+					COALESCE(SUM(quantity) FILTER (WHERE record_type = 'expense'), 0) AS received
+				FROM reg_purchase_order_movements
+				GROUP BY order_id, order_line_id
+			) t ON t.order_id = o.id AND t.order_line_id = pl.line_id
+			WHERE o.posted AND NOT o.deletion_mark AND t.ordered > t.received
+				AND o.warehouse_id = l.warehouse_id AND pl.nomenclature_id = l.nomenclature_id
+		), 0)`
+	}
+
+	levels := builder.Select(
+		"l.warehouse_id",
+		"l.nomenclature_id",
+		"COALESCE(b.quantity, 0) AS quantity",
+		onOrder+" AS on_order",
+		"l.min_quantity",
+		"COALESCE(l.max_quantity, l.min_quantity) AS max_quantity",
+	).
+		From("reg_stock_levels l").
+		LeftJoin("reg_stock_balances b ON b.warehouse_id = l.warehouse_id AND b.nomenclature_id = l.nomenclature_id")
+
+	if warehouseIDs, ok := extractIDSlice(params, "warehouse_id"); ok {
+		levels = levels.Where(squirrel.Eq{"l.warehouse_id": warehouseIDs})
+	}
+	if nomenclatureIDs, ok := extractIDSlice(params, "nomenclature_id"); ok {
+		levels = levels.Where(squirrel.Eq{"l.nomenclature_id": nomenclatureIDs})
+	}
+
+	inner := builder.Select(
+		"s.warehouse_id",
+		"s.nomenclature_id",
+		"s.quantity"+qtyScale+" AS quantity",
+		"s.on_order"+qtyScale+" AS on_order",
+		"s.min_quantity"+qtyScale+" AS min_quantity",
+		"s.max_quantity"+qtyScale+" AS max_quantity",
+		"(s.max_quantity - s.quantity - s.on_order)"+qtyScale+" AS suggested",
+	).
+		FromSelect(levels, "s").
+		Where("s.quantity + s.on_order < s.min_quantity")
+
+	return builder.Select().FromSelect(inner, "base"), nil
+}
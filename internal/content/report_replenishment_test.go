@@ -0,0 +1,31 @@
+package content
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestStockReplenishmentQuery(t *testing.T) {
+	params := map[string]any{"warehouse_id": []any{"018f0000-0000-7000-8000-000000000001"}}
+	qb, err := StockReplenishmentDataset.Executor.BuildQuery(context.Background(), params)
+	if err != nil {
+		t.Fatalf("BuildQuery: %v", err)
+	}
+	sql, args, err := qb.Columns("base.*").ToSql()
+	if err != nil {
+		t.Fatalf("ToSql: %v", err)
+	}
+	if !strings.Contains(sql, "l.warehouse_id IN ($1)") || len(args) != 1 {
+		t.Errorf("warehouse filter must be numbered from $1 (args %v):\n%s", args, sql)
+	}
+	if !strings.Contains(sql, "reg_purchase_order_movements") {
+		t.Errorf("open purchase orders must count as expected stock:\n%s", sql)
+	}
+
+	params["ignore_on_order"] = true
+	qb, _ = StockReplenishmentDataset.Executor.BuildQuery(context.Background(), params)
+	if sql, _, _ = qb.Columns("base.*").ToSql(); strings.Contains(sql, "reg_purchase_order_movements") {
+		t.Errorf("ignore_on_order must leave purchase orders out:\n%s", sql)
+	}
+}
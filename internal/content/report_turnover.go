@@ -0,0 +1,291 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+
+	"metapus/internal/domain/reports/schema"
+)
+
+// ---------------------------------------------------------------------------
+// Turnover Balance (ОСВ) — generic report over any receipt/expense register
+// ---------------------------------------------------------------------------
+
+// TurnoverRegister describes an accumulation register for the turnover-balance report.
+// The movements table must have period, record_type ('receipt' | 'expense') and
+// one column per dimension and resource.
+type TurnoverRegister struct {
+	// Table is the movements table, e.g. "reg_stock_movements".
+	Table string
+
+	// Dimensions are the columns the user may group by (Kind is forced to dimension).
+	Dimensions []schema.Field
+
+	// AlwaysGroupBy lists dimension names that are grouped by regardless of the
+	// chosen dimension set, e.g. "currency_id" — amounts in different currencies
+	// must never be summed together.
+	AlwaysGroupBy []string
+
+	// Resources are the numeric columns to balance, e.g. quantity, amount.
+	Resources []TurnoverResource
+}
+
+// TurnoverResource is a numeric register column reported as opening, debit, credit and closing.
+type TurnoverResource struct {
+	// Column is the SQL column name, e.g. "quantity".
+	Column string
+
+	// Label prefixes the generated field labels when the register has several resources.
+	Label string
+
+	// Type is TypeQuantity or TypeMoney.
+	Type schema.FieldType
+
+	// Scale is the number of decimal places for display.
+	Scale int
+
+	// Divisor is an SQL suffix applied to each sum, e.g. qtyScale. Empty for raw values.
+	Divisor string
+}
+
+// turnoverParts are the per-resource columns produced by the report, in display order.
+var turnoverParts = []struct{ suffix, label string }{
+	{"opening", "Нач. остаток"},
+	{"debit", "Оборот Дт"},
+	{"credit", "Оборот Кт"},
+	{"closing", "Кон. остаток"},
+}
+
+// NewTurnoverBalanceDataset builds a turnover-balance dataset for reg.
+//
+// For each combination of the chosen dimensions the report returns the opening
+// balance at from_date, receipt (debit) and expense (credit) turnover within
+// [from_date, to_date) and the closing balance at to_date. All resource columns
+// are summable measures, so grouped requests get subtotals from the compiler.
+func NewTurnoverBalanceDataset(key, name, description, permission string, reg TurnoverRegister, scopeDimensions []string) schema.Dataset {
+	fields := make([]schema.Field, 0, len(reg.Dimensions)+len(reg.Resources)*len(turnoverParts))
+	dimOptions := make([]schema.EnumValue, 0, len(reg.Dimensions))
+	for _, dim := range reg.Dimensions {
+		dim.Kind = schema.FieldDimension
+		dim.Sortable = true
+		fields = append(fields, dim)
+		if !slices.Contains(reg.AlwaysGroupBy, dim.Name) {
+			dimOptions = append(dimOptions, schema.EnumValue{Value: dim.Name, Label: dim.Label})
+		}
+	}
+
+	for _, res := range reg.Resources {
+		for _, part := range turnoverParts {
+			label := part.label
+			if len(reg.Resources) > 1 {
+				label = res.Label + ": " + part.label
+			}
+			fields = append(fields, schema.Field{
+				Name:     res.Column + "_" + part.suffix,
+				Label:    label,
+				Kind:     schema.FieldMeasure,
+				Type:     res.Type,
+				Agg:      schema.AggSum,
+				Sortable: true,
+				Scale:    res.Scale,
+			})
+		}
+	}
+
+	return schema.Dataset{
+		Key:         key,
+		Name:        name,
+		Description: description,
+		Permission:  permission,
+		Fields:      fields,
+		Filters: []schema.FilterDef{
+			{Key: "from_date", Label: "Начало периода", Type: schema.FilterDate, Required: true},
+			{Key: "to_date", Label: "Конец периода", Type: schema.FilterDate, Required: true},
+			{Key: "dimensions", Label: "Измерения", Type: schema.FilterEnum, Multi: true, Options: dimOptions},
+			{Key: "exclude_zero", Label: "Исключить нулевые", Type: schema.FilterBoolean, Default: true},
+		},
+		ScopeDimensions: scopeDimensions,
+		ExportFormats:   []string{"csv", "xlsx"},
+		Executor:        &turnoverBalanceExecutor{reg: reg},
+	}
+}
+
+type turnoverBalanceExecutor struct {
+	reg TurnoverRegister
+}
+
+func (e *turnoverBalanceExecutor) BuildQuery(ctx context.Context, params map[string]any) (squirrel.SelectBuilder, error) {
+	fromDate, err := extractRequiredDate(params, "from_date")
+	if err != nil {
+		return squirrel.SelectBuilder{}, err
+	}
+	toDate, err := extractRequiredDate(params, "to_date")
+	if err != nil {
+		return squirrel.SelectBuilder{}, err
+	}
+	if !fromDate.Before(toDate) {
+		return squirrel.SelectBuilder{}, fmt.Errorf("from_date must be before to_date")
+	}
+
+	chosen, err := e.chosenDimensions(params)
+	if err != nil {
+		return squirrel.SelectBuilder{}, err
+	}
+
+	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	inner := builder.Select().From(e.reg.Table + " m")
+
+	// Unchosen dimensions stay in the result as typed NULLs so the column set
+	// is stable for the compiler (SELECT base.<dim>, LEFT JOINs on refs).
+	groupBy := make([]string, 0, len(chosen))
+	for _, dim := range e.reg.Dimensions {
+		if slices.Contains(chosen, dim.Name) {
+			inner = inner.Column("m." + dim.Name)
+			groupBy = append(groupBy, "m."+dim.Name)
+			continue
+		}
+		nullType := "text"
+		if dim.Type == schema.TypeRef {
+			nullType = "uuid"
+		}
+		inner = inner.Column(fmt.Sprintf("NULL::%s AS %s", nullType, dim.Name))
+	}
+
+	nonZero := make([]string, 0, len(e.reg.Resources)*2)
+	for _, res := range e.reg.Resources {
+		signed := fmt.Sprintf("CASE WHEN m.record_type = 'receipt' THEN m.%[1]s ELSE -m.%[1]s END", res.Column)
+		inner = inner.
+			Column(fmt.Sprintf("SUM(CASE WHEN m.period < ? THEN %s ELSE 0 END)%s AS %s_opening", signed, res.Divisor, res.Column), fromDate).
+			Column(fmt.Sprintf("SUM(CASE WHEN m.period >= ? AND m.record_type = 'receipt' THEN m.%s ELSE 0 END)%s AS %s_debit", res.Column, res.Divisor, res.Column), fromDate).
+			Column(fmt.Sprintf("SUM(CASE WHEN m.period >= ? AND m.record_type = 'expense' THEN m.%s ELSE 0 END)%s AS %s_credit", res.Column, res.Divisor, res.Column), fromDate).
+			Column(fmt.Sprintf("SUM(%s)%s AS %s_closing", signed, res.Divisor, res.Column))
+		nonZero = append(nonZero,
+			fmt.Sprintf("SUM(%s) <> 0", signed),
+			fmt.Sprintf("SUM(CASE WHEN m.period >= ? THEN m.%s ELSE 0 END) <> 0", res.Column),
+		)
+	}
+
+	inner = inner.Where(squirrel.Lt{"m.period": toDate})
+
+	// Dimension value filters, e.g. {"warehouse_id": ["uuid1"]}
+	for _, dim := range e.reg.Dimensions {
+		if ids, ok := extractIDSlice(params, dim.Name); ok {
+			inner = inner.Where(squirrel.Eq{"m." + dim.Name: ids})
+		}
+	}
+
+	if len(groupBy) > 0 {
+		inner = inner.GroupBy(groupBy...)
+	}
+
+	// Rows without opening balance and without turnover carry no information.
+	if extractBool(params, "exclude_zero", true) {
+		having := "(" + strings.Join(nonZero, " OR ") + ")"
+		havingArgs := make([]any, 0, len(e.reg.Resources))
+		for range e.reg.Resources {
+			havingArgs = append(havingArgs, fromDate)
+		}
+		inner = inner.Having(having, havingArgs...)
+	}
+
+	return builder.Select().FromSelect(inner, "base"), nil
+}
+
+// chosenDimensions returns the dimensions to group by: the "dimensions" filter
+// (all dimensions when omitted) plus the register's AlwaysGroupBy.
+func (e *turnoverBalanceExecutor) chosenDimensions(params map[string]any) ([]string, error) {
+	requested, ok := extractStringSlice(params, "dimensions")
+	if !ok {
+		all := make([]string, 0, len(e.reg.Dimensions))
+		for _, dim := range e.reg.Dimensions {
+			all = append(all, dim.Name)
+		}
+		return all, nil
+	}
+
+	chosen := slices.Clone(e.reg.AlwaysGroupBy)
+	for _, name := range requested {
+		if !slices.ContainsFunc(e.reg.Dimensions, func(f schema.Field) bool { return f.Name == name }) {
+			return nil, fmt.Errorf("unknown dimension %q", name)
+		}
+		if !slices.Contains(chosen, name) {
+			chosen = append(chosen, name)
+		}
+	}
+	return chosen, nil
+}
+
+// extractStringSlice extracts a []string from params (JSON arrays arrive as []any).
+func extractStringSlice(params map[string]any, key string) ([]string, bool) {
+	switch v := params[key].(type) {
+	case []string:
+		return v, true
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result, true
+	}
+	return nil, false
+}
+
+// extractBool reads a boolean param, falling back to def when absent.
+func extractBool(params map[string]any, key string, def bool) bool {
+	if v, ok := params[key].(bool); ok {
+		return v
+	}
+	return def
+}
+
+// ---------------------------------------------------------------------------
+// Register instances
+// ---------------------------------------------------------------------------
+
+// StockTurnoverBalanceDataset is the turnover-balance sheet over the stock register.
+var StockTurnoverBalanceDataset = NewTurnoverBalanceDataset(
+	"stock-turnover-balance",
+	"ОСВ по товарам",
+	"Остатки и обороты регистра товаров по выбранным измерениям",
+	"report:stock:read",
+	TurnoverRegister{
+		Table: "reg_stock_movements",
+		Dimensions: []schema.Field{
+			{Name: "warehouse_id", Label: "Склад", Type: schema.TypeRef, RefEntity: "warehouse"},
+			{Name: "nomenclature_id", Label: "Товар", Type: schema.TypeRef, RefEntity: "nomenclature"},
+		},
+		Resources: []TurnoverResource{
+			{Column: "quantity", Label: "Количество", Type: schema.TypeQuantity, Scale: 4, Divisor: qtyScale},
+		},
+	},
+	[]string{"warehouse"},
+)
+
+// CostTurnoverBalanceDataset is the turnover-balance sheet over the cost register.
+// Amounts are always split by currency.
+var CostTurnoverBalanceDataset = NewTurnoverBalanceDataset(
+	"cost-turnover-balance",
+	"ОСВ по себестоимости",
+	"Остатки и обороты регистра себестоимости по выбранным измерениям",
+	"report:stock:read",
+	TurnoverRegister{
+		Table: "reg_cost_movements",
+		Dimensions: []schema.Field{
+			{Name: "warehouse_id", Label: "Склад", Type: schema.TypeRef, RefEntity: "warehouse"},
+			{Name: "nomenclature_id", Label: "Товар", Type: schema.TypeRef, RefEntity: "nomenclature"},
+			{Name: "currency_id", Label: "Валюта", Type: schema.TypeRef, RefEntity: "currency"},
+		},
+		AlwaysGroupBy: []string{"currency_id"},
+		Resources: []TurnoverResource{
+			{Column: "quantity", Label: "Количество", Type: schema.TypeQuantity, Scale: 4, Divisor: qtyScale},
+			{Column: "amount", Label: "Сумма", Type: schema.TypeMoney, Scale: 2},
+		},
+	},
+	[]string{"warehouse"},
+)
@@ -0,0 +1,53 @@
+package content
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTurnoverBalanceFieldsPerResource(t *testing.T) {
+	ds := CostTurnoverBalanceDataset
+	for _, name := range []string{"quantity_opening", "quantity_debit", "amount_credit", "amount_closing"} {
+		if ds.FindField(name) == nil {
+			t.Errorf("field %q missing", name)
+		}
+	}
+	for _, f := range ds.Filters {
+		if f.Key == "dimensions" && len(f.Options) != 2 {
+			t.Errorf("dimension options = %v, want warehouse_id and nomenclature_id (currency is always grouped)", f.Options)
+		}
+	}
+}
+
+func TestTurnoverBalanceGroupsByChosenDimensions(t *testing.T) {
+	params := map[string]any{
+		"from_date":  "2026-01-01",
+		"to_date":    "2026-02-01",
+		"dimensions": []any{"nomenclature_id"},
+	}
+	qb, err := CostTurnoverBalanceDataset.Executor.BuildQuery(context.Background(), params)
+	if err != nil {
+		t.Fatalf("BuildQuery: %v", err)
+	}
+	sql, args, err := qb.Columns("base.*").ToSql()
+	if err != nil {
+		t.Fatalf("ToSql: %v", err)
+	}
+
+	if !strings.Contains(sql, "GROUP BY m.nomenclature_id, m.currency_id") {
+		t.Errorf("GROUP BY must cover chosen dimensions plus currency:\n%s", sql)
+	}
+	if !strings.Contains(sql, "NULL::uuid AS warehouse_id") {
+		t.Errorf("unchosen dimension must be selected as NULL:\n%s", sql)
+	}
+	// 3 turnover columns × 2 resources + to_date + 2 HAVING (exclude_zero defaults to true)
+	if len(args) != 9 {
+		t.Errorf("len(args) = %d, want 9", len(args))
+	}
+
+	params["dimensions"] = []any{"contract_id"}
+	if _, err := CostTurnoverBalanceDataset.Executor.BuildQuery(context.Background(), params); err == nil {
+		t.Error("unknown dimension accepted")
+	}
+}
@@ -0,0 +1,66 @@
+package content
+
+import (
+	"testing"
+
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/accountexport"
+	"metapus/internal/domain/artifact"
+	"metapus/internal/domain/attachment"
+	"metapus/internal/domain/auth"
+	"metapus/internal/domain/cascadedelete"
+	"metapus/internal/domain/security_profile"
+	"metapus/internal/infrastructure/blobstore"
+	v1 "metapus/internal/infrastructure/http/v1"
+	"metapus/internal/infrastructure/http/v1/middleware"
+	"metapus/internal/infrastructure/storage/postgres/catalog_repo"
+	"metapus/internal/infrastructure/storage/postgres/portal_repo"
+	"metapus/pkg/logger"
+)
+
+type nopProfiles struct {
+	security_profile.ProfileProvider
+}
+
+// NewRouter panics when a mutating route declares no authorization; building
+// the default router keeps new routes honest without starting the server.
+func TestDefaultRoutesDeclareAuthorization(t *testing.T) {
+	log, err := logger.New(logger.Config{Level: "error"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := blobstore.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg := v1.NewFactoryRegistry()
+	RegisterDefaults(reg)
+	router := v1.NewRouter(v1.RouterConfig{
+		TenantManager:   tenant.NewManager(tenant.ManagerConfig{}, nil, log),
+		Logger:          log,
+		Registry:        reg,
+		ProfileProvider: nopProfiles{},
+		// Optional route groups.
+		AuthSvc:             &auth.Service{},
+		AdminTokens:         auth.NewAdminTokenService("test"),
+		WSTicketStore:       &auth.WSTicketStore{},
+		AccountExportSigner: &accountexport.URLSigner{},
+		AttachmentStore:     store,
+		UploadSigner:        &attachment.UploadSigner{},
+		ArtifactStore:       store,
+		ArtifactSigner:      &artifact.URLSigner{},
+		CascadeDeleteSigner: &cascadedelete.TokenSigner{},
+		MerchantAPIKeyRepo:  catalog_repo.NewMerchantAPIKeyRepo(),
+		PortalDashboardRepo: &portal_repo.DashboardRepo{},
+	})
+
+	var mutating int
+	for _, route := range middleware.DescribeRoutes(router) {
+		if route.Mutating() {
+			mutating++
+		}
+	}
+	if mutating == 0 {
+		t.Fatal("no mutating routes described")
+	}
+}
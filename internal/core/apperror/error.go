@@ -5,7 +5,9 @@ package apperror
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"time"
 )
 
 // Error codes following domain-driven design
@@ -40,6 +42,21 @@ const (
 	CodeConflict    = "CONFLICT"
 	CodeDuplicate   = "DUPLICATE_ENTRY"
 	CodeIdempotency = "IDEMPOTENCY_CONFLICT"
+
+	// Too many requests (429)
+	CodeTooManyRequests = "TOO_MANY_REQUESTS"
+
+	// Payment required (402)
+	CodePaymentRequired = "PAYMENT_REQUIRED"
+
+	// Service unavailable (503)
+	CodeTenantBusy = "TENANT_BUSY"
+
+	// Locked (423)
+	CodeTenantReadOnly = "TENANT_READ_ONLY"
+
+	// Not implemented (501)
+	CodeNotImplemented = "NOT_IMPLEMENTED"
 )
 
 // AppError is the standard error type for the platform.
@@ -189,6 +206,15 @@ func NewForbidden(message string) *AppError {
 	}
 }
 
+// NewPaymentRequired creates an error for a feature the tenant has not paid for (402)
+func NewPaymentRequired(message string) *AppError {
+	return &AppError{
+		Code:       CodePaymentRequired,
+		Message:    message,
+		HTTPStatus: http.StatusPaymentRequired,
+	}
+}
+
 // NewIdempotencyConflict creates error when operation is already in progress
 func NewIdempotencyConflict(key string) *AppError {
 	return &AppError{
@@ -239,6 +265,58 @@ func NewDuplicate(entity, field, value string) *AppError {
 	}
 }
 
+// NewTooManyRequests creates a throttling error (429).
+// retryAfter is sent to the client in the Retry-After header.
+func NewTooManyRequests(message string, retryAfter time.Duration) *AppError {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return &AppError{
+		Code:       CodeTooManyRequests,
+		Message:    message,
+		HTTPStatus: http.StatusTooManyRequests,
+		Details:    map[string]any{"retryAfter": seconds},
+	}
+}
+
+// NewTenantBusy creates an error (503) for a request rejected because the
+// tenant's database connections are exhausted. retryAfter is sent to the
+// client in the Retry-After header.
+func NewTenantBusy(retryAfter time.Duration) *AppError {
+	err := NewTooManyRequests("tenant database is busy, retry later", retryAfter)
+	err.Code = CodeTenantBusy
+	err.HTTPStatus = http.StatusServiceUnavailable
+	return err
+}
+
+// NewTenantReadOnly creates an error (423) for a change rejected because the
+// tenant is in read-only mode.
+func NewTenantReadOnly(reason string) *AppError {
+	return &AppError{
+		Code:       CodeTenantReadOnly,
+		Message:    "tenant is in read-only mode",
+		HTTPStatus: http.StatusLocked,
+		Details:    map[string]any{"reason": reason},
+	}
+}
+
+// NewNotImplemented creates an error (501) for an endpoint whose feature is
+// not enabled in this deployment.
+func NewNotImplemented(message string) *AppError {
+	return &AppError{
+		Code:       CodeNotImplemented,
+		Message:    message,
+		HTTPStatus: http.StatusNotImplemented,
+	}
+}
+
+// RetryAfter returns the Retry-After value in seconds of a throttling error.
+func (e *AppError) RetryAfter() (int, bool) {
+	seconds, ok := e.Details["retryAfter"].(int)
+	return seconds, ok
+}
+
 // --- Helper functions ---
 
 // IsAppError checks if error is AppError
@@ -17,6 +17,7 @@ import (
 	"strings"
 	"time"
 
+	"metapus/internal/core/crypto"
 	"metapus/pkg/logger"
 )
 
@@ -60,10 +61,15 @@ func (a *WebhookAdapter) Deliver(ctx context.Context, destination map[string]any
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "Metapus-AutomationEngine/2.0")
 
-	// Credentials: Bearer token or API secret
+	// Credentials: Bearer token, API secret or HMAC signing key
 	if secret := string(credentials); secret != "" {
 		authType, _ := accountConfig["auth_type"].(string)
 		switch authType {
+		case "hmac":
+			// Same scheme as merchant webhooks: HMAC-SHA256(timestamp + "." + body, secret).
+			timestamp := time.Now().UTC().Format(time.RFC3339)
+			req.Header.Set("X-Metapus-Timestamp", timestamp)
+			req.Header.Set("X-Metapus-Signature", crypto.SignWebhook([]byte(payload), secret, timestamp))
 		case "header":
 			headerName, _ := accountConfig["header_name"].(string)
 			if headerName == "" {
@@ -268,7 +274,7 @@ func NewEmailAdapter() *EmailAdapter {
 }
 
 // Deliver sends an email message.
-// accountConfig: {"smtp_host": "...", "smtp_port": "587", "from": "noreply@example.com"}
+// accountConfig: {"smtp_host": "...", "smtp_port": "587", "from": "noreply@example.com", "username": "optional"}
 // credentials: SMTP password
 // destination: {"to": "user@example.com"} or {"to": ["a@x.com", "b@x.com"]}
 // payload: email body (subject extracted via --- separator or from first line)
@@ -369,7 +375,11 @@ func (a *EmailAdapter) Deliver(ctx context.Context, destination map[string]any,
 	var auth smtp.Auth
 	password := string(credentials)
 	if password != "" {
-		auth = smtp.PlainAuth("", from, password, smtpHost)
+		username, _ := accountConfig["username"].(string)
+		if username == "" {
+			username = from
+		}
+		auth = smtp.PlainAuth("", username, password, smtpHost)
 	}
 
 	addr := net.JoinHostPort(smtpHost, smtpPort)
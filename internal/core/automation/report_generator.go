@@ -114,8 +114,8 @@ func (g *ReportGenerator) GenerateAndStore(
 		return nil, fmt.Errorf("build query request: %w", err)
 	}
 
-	// 5. Execute query via Compiler
-	result, err := g.compiler.Execute(ctx, req)
+	// 5. Execute query via Compiler (queued, never throttled: delivery is async)
+	result, err := g.compiler.Execute(compiler.WithBackgroundExecution(ctx), req)
 	if err != nil {
 		return nil, fmt.Errorf("execute report %q: %w", config.DatasetKey, err)
 	}
@@ -0,0 +1,50 @@
+package clock
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFromContextDefaultsToReal(t *testing.T) {
+	if c := FromContext(context.Background()); c != Real {
+		t.Fatalf("FromContext without clock = %T, want Real", c)
+	}
+}
+
+func TestMockFreezesAndAdvances(t *testing.T) {
+	t0 := time.Date(2025, 1, 31, 23, 0, 0, 0, time.UTC)
+	m := NewMock(t0)
+	ctx := WithClock(context.Background(), m)
+
+	if got := Now(ctx); !got.Equal(t0) {
+		t.Fatalf("Now = %v, want %v", got, t0)
+	}
+	m.Advance(2 * time.Hour)
+	if got := Now(ctx); got.Month() != time.February || got.Day() != 1 {
+		t.Fatalf("after Advance Now = %v, want Feb 1", got)
+	}
+}
+
+func TestOffset(t *testing.T) {
+	t0 := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	c := Offset(NewMock(t0), -24*time.Hour)
+	if got := c.Now(); !got.Equal(t0.Add(-24 * time.Hour)) {
+		t.Fatalf("Offset Now = %v", got)
+	}
+
+	target := time.Now().AddDate(0, -1, 0)
+	if d := StartingAt(target).Now().Sub(target); d < 0 || d > time.Second {
+		t.Fatalf("StartingAt drift = %v", d)
+	}
+}
+
+func TestDateOf(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*3600)
+	if got := DateOf(time.Date(2025, 3, 1, 1, 30, 0, 0, msk)); !got.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("DateOf = %v, want the local calendar date at UTC midnight", got)
+	}
+	if got := DateOf(time.Time{}); !got.IsZero() {
+		t.Fatalf("DateOf(zero) = %v", got)
+	}
+}
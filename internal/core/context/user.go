@@ -17,6 +17,11 @@ type UserContext struct {
 	SessionID     string
 	MerchantIDs   []string       // UUID strings; empty = no portal access
 	MerchantRoles map[string]int // merchant UUID string -> 1=Owner 2=Manager 3=Viewer
+
+	// PermissionScopes holds permissions granted only within a scope:
+	// permission -> dimension -> allowed IDs (e.g. posting goods issues of one
+	// warehouse). Such permissions are not listed in Permissions.
+	PermissionScopes map[string]map[string][]string
 }
 
 type userContextKey struct{}
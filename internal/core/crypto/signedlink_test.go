@@ -0,0 +1,68 @@
+package crypto
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLinkSigner_SignedPathRoundTrip(t *testing.T) {
+	s := NewLinkSigner([]byte("k"), "artifact")
+	expires := time.Now().Add(time.Hour)
+
+	path := s.SignedPath("/api/v1/artifacts/42/download", "acme", "42", expires)
+	base, rawQuery, ok := strings.Cut(path, "?")
+	if !ok || base != "/api/v1/artifacts/42/download" {
+		t.Fatalf("path = %q", path)
+	}
+	q, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Get("tenant") != "acme" || q.Get("expires") != strconv.FormatInt(expires.Unix(), 10) {
+		t.Fatalf("query = %v", q)
+	}
+
+	sig := q.Get("sig")
+	if !s.VerifyPath("acme", "42", expires.Unix(), sig) {
+		t.Fatal("signed path must verify")
+	}
+	if s.VerifyPath("other", "42", expires.Unix(), sig) {
+		t.Error("signature must be bound to the tenant")
+	}
+	if s.VerifyPath("acme", "43", expires.Unix(), sig) {
+		t.Error("signature must be bound to the object")
+	}
+	if s.VerifyPath("acme", "42", expires.Unix()+60, sig) {
+		t.Error("signature must be bound to the expiry")
+	}
+}
+
+func TestLinkSigner_Expired(t *testing.T) {
+	s := NewLinkSigner([]byte("k"), "upload")
+	expires := time.Now().Add(-time.Second).Unix()
+
+	sig := s.Sign("acme", "42", strconv.FormatInt(expires, 10))
+	if s.VerifyPath("acme", "42", expires, sig) {
+		t.Error("expired link must not verify")
+	}
+}
+
+func TestLinkSigner_PurposeSeparation(t *testing.T) {
+	key := []byte("shared")
+	artifact := NewLinkSigner(key, "artifact")
+	export := NewLinkSigner(key, "account-export")
+
+	sig := artifact.Sign("acme", "42", "1700000000")
+	if export.Verify(sig, "acme", "42", "1700000000") {
+		t.Error("a signature for one purpose must not verify for another")
+	}
+	if !artifact.Verify(sig, "acme", "42", "1700000000") {
+		t.Error("signature must verify for its own purpose")
+	}
+	if NewLinkSigner([]byte("other"), "artifact").Verify(sig, "acme", "42", "1700000000") {
+		t.Error("signature must be bound to the key")
+	}
+}
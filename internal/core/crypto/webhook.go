@@ -0,0 +1,22 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// SignWebhook returns the hex HMAC-SHA256 of timestamp + "." + payload keyed
+// by secret (Stripe pattern). The timestamp is signed so receivers can reject
+// replays. Used by every outgoing webhook: merchant notifications, automation
+// webhook channels and webhook subscriptions.
+//
+// Receiver verification: HMAC-SHA256(X-Metapus-Timestamp + "." + body, secret)
+// compared with X-Metapus-Signature.
+func SignWebhook(payload []byte, secret, timestamp string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,48 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestSignWebhook_IncludesTimestamp(t *testing.T) {
+	payload := []byte(`{"event":"invoice.paid","data":{}}`)
+	secret := "merchant-webhook-secret-key"
+
+	sig1 := SignWebhook(payload, secret, "2026-05-06T12:00:00Z")
+	sig2 := SignWebhook(payload, secret, "2026-05-06T12:01:00Z")
+
+	// Same payload + different timestamp → different HMAC (replay-resistant)
+	if sig1 == sig2 {
+		t.Error("SignWebhook() should produce different signatures for different timestamps (replay protection)")
+	}
+
+	// Same inputs → deterministic
+	sig3 := SignWebhook(payload, secret, "2026-05-06T12:00:00Z")
+	if sig1 != sig3 {
+		t.Error("SignWebhook() should be deterministic for same inputs")
+	}
+}
+
+func TestSignWebhook_ReceiverVerification(t *testing.T) {
+	// Simulate what a receiver would do to verify our webhook
+	payload := []byte(`{"event":"invoice.confirmed","data":{"invoiceId":"abc-123"}}`)
+	secret := "merchant-secret"
+	timestamp := "2026-05-06T15:30:00Z"
+
+	// Metapus generates signature
+	signature := SignWebhook(payload, secret, timestamp)
+
+	// Receiver verifies: HMAC-SHA256(timestamp + "." + body, secret)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if signature != expected {
+		t.Errorf("receiver verification failed: got %s, want %s", signature, expected)
+	}
+}
@@ -164,6 +164,11 @@ func (b *BaseDocument) SetUpdatedAt(t time.Time) {
 	b.UpdatedAt = t
 }
 
+// GetCreatedBy returns the author of the document (nil ID if unknown).
+func (b *BaseDocument) GetCreatedBy() id.ID {
+	return b.CreatedBy
+}
+
 //////////////
 // Catalogs //
 //////////////
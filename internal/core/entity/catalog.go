@@ -88,3 +88,8 @@ func (c *Catalog) GetParentID() *id.ID {
 func (c *Catalog) GetIsFolder() bool {
 	return c.IsFolder
 }
+
+// GetCode returns the catalog code (used by bulk import to detect duplicates).
+func (c *Catalog) GetCode() string {
+	return c.Code
+}
@@ -124,6 +124,164 @@ type StockBalance struct {
 	UpdatedAt      time.Time `db:"updated_at" json:"updatedAt"`
 }
 
+// ---------------------------------------------------------------------------
+// Stock reservation accumulation register (Reserved Stock Register)
+// ---------------------------------------------------------------------------
+
+// StockReservationMovement represents a movement in the stock reservation register.
+// Receipt reserves goods for a sales order, expense releases the reservation
+// (shipment or cancellation). Reservations are soft: physical stock is not moved.
+type StockReservationMovement struct {
+	MovementBase
+
+	// Dimensions
+	OrderID        id.ID `db:"order_id" json:"orderId"`
+	WarehouseID    id.ID `db:"warehouse_id" json:"warehouseId"`
+	NomenclatureID id.ID `db:"nomenclature_id" json:"nomenclatureId"`
+
+	// Resources
+	Quantity types.Quantity `db:"quantity" json:"quantity"`
+}
+
+// NewStockReservationMovement creates a new stock reservation movement.
+func NewStockReservationMovement(
+	recorderID id.ID,
+	recorderType string,
+	recorderVersion int,
+	period time.Time,
+	recordType RecordType,
+	orderID, warehouseID, nomenclatureID id.ID,
+	quantity types.Quantity,
+) StockReservationMovement {
+	return StockReservationMovement{
+		MovementBase:   NewMovementBase(recorderID, recorderType, recorderVersion, period, recordType),
+		OrderID:        orderID,
+		WarehouseID:    warehouseID,
+		NomenclatureID: nomenclatureID,
+		Quantity:       quantity,
+	}
+}
+
+// StockReservationBalance represents the quantity still reserved for an order.
+type StockReservationBalance struct {
+	// Dimensions
+	OrderID        id.ID `db:"order_id" json:"orderId"`
+	WarehouseID    id.ID `db:"warehouse_id" json:"warehouseId"`
+	NomenclatureID id.ID `db:"nomenclature_id" json:"nomenclatureId"`
+
+	// Balances
+	Quantity types.Quantity `db:"quantity" json:"quantity"`
+
+	// Metadata
+	LastMovementAt time.Time `db:"last_movement_at" json:"lastMovementAt"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// ---------------------------------------------------------------------------
+// Purchase order accumulation register (Goods Ordered from Suppliers)
+// ---------------------------------------------------------------------------
+
+// PurchaseOrderMovement represents a movement in the purchase order register.
+// Receipt records goods ordered from a supplier, expense records goods received
+// against an order line. The balance is the quantity still expected.
+type PurchaseOrderMovement struct {
+	MovementBase
+
+	// Dimensions
+	OrderID        id.ID `db:"order_id" json:"orderId"`
+	OrderLineID    id.ID `db:"order_line_id" json:"orderLineId"`
+	NomenclatureID id.ID `db:"nomenclature_id" json:"nomenclatureId"`
+
+	// Resources
+	Quantity types.Quantity `db:"quantity" json:"quantity"`
+}
+
+// NewPurchaseOrderMovement creates a new purchase order movement.
+func NewPurchaseOrderMovement(
+	recorderID id.ID,
+	recorderType string,
+	recorderVersion int,
+	period time.Time,
+	recordType RecordType,
+	orderID, orderLineID, nomenclatureID id.ID,
+	quantity types.Quantity,
+) PurchaseOrderMovement {
+	return PurchaseOrderMovement{
+		MovementBase:   NewMovementBase(recorderID, recorderType, recorderVersion, period, recordType),
+		OrderID:        orderID,
+		OrderLineID:    orderLineID,
+		NomenclatureID: nomenclatureID,
+		Quantity:       quantity,
+	}
+}
+
+// PurchaseOrderBalance represents the quantity of an order line still expected.
+type PurchaseOrderBalance struct {
+	// Dimensions
+	OrderID     id.ID `db:"order_id" json:"orderId"`
+	OrderLineID id.ID `db:"order_line_id" json:"orderLineId"`
+
+	// Balances
+	Quantity types.Quantity `db:"quantity" json:"quantity"`
+
+	// Metadata
+	LastMovementAt time.Time `db:"last_movement_at" json:"lastMovementAt"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// ---------------------------------------------------------------------------
+// Sales order accumulation register (Goods Ordered by Customers)
+// ---------------------------------------------------------------------------
+
+// SalesOrderMovement represents a movement in the sales order register.
+// Receipt records goods ordered by a customer, expense records goods shipped
+// against an order line (or released when the order is closed).
+// The balance is the open quantity of the line.
+type SalesOrderMovement struct {
+	MovementBase
+
+	// Dimensions
+	OrderID        id.ID `db:"order_id" json:"orderId"`
+	OrderLineID    id.ID `db:"order_line_id" json:"orderLineId"`
+	NomenclatureID id.ID `db:"nomenclature_id" json:"nomenclatureId"`
+
+	// Resources
+	Quantity types.Quantity `db:"quantity" json:"quantity"`
+}
+
+// NewSalesOrderMovement creates a new sales order movement.
+func NewSalesOrderMovement(
+	recorderID id.ID,
+	recorderType string,
+	recorderVersion int,
+	period time.Time,
+	recordType RecordType,
+	orderID, orderLineID, nomenclatureID id.ID,
+	quantity types.Quantity,
+) SalesOrderMovement {
+	return SalesOrderMovement{
+		MovementBase:   NewMovementBase(recorderID, recorderType, recorderVersion, period, recordType),
+		OrderID:        orderID,
+		OrderLineID:    orderLineID,
+		NomenclatureID: nomenclatureID,
+		Quantity:       quantity,
+	}
+}
+
+// SalesOrderBalance represents the open (not yet shipped) quantity of an order line.
+type SalesOrderBalance struct {
+	// Dimensions
+	OrderID     id.ID `db:"order_id" json:"orderId"`
+	OrderLineID id.ID `db:"order_line_id" json:"orderLineId"`
+
+	// Balances
+	Quantity types.Quantity `db:"quantity" json:"quantity"`
+
+	// Metadata
+	LastMovementAt time.Time `db:"last_movement_at" json:"lastMovementAt"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updatedAt"`
+}
+
 // ---------------------------------------------------------------------------
 // Cost accumulation register (Stock Cost Register)
 // ---------------------------------------------------------------------------
@@ -150,20 +308,43 @@ func NewCostMovement(
 	recorderVersion int,
 	period time.Time,
 	recordType RecordType,
-	warehouseID, nomenclatureID, currencyID id.ID,
+	warehouseID, nomenclatureID id.ID,
 	quantity types.Quantity,
-	amount types.MinorUnits,
+	amount types.Money,
 ) CostMovement {
 	return CostMovement{
 		MovementBase:   NewMovementBase(recorderID, recorderType, recorderVersion, period, recordType),
 		WarehouseID:    warehouseID,
 		NomenclatureID: nomenclatureID,
-		CurrencyID:     currencyID,
+		CurrencyID:     amount.CurrencyID,
+		Quantity:       quantity,
+		Amount:         amount.Amount,
+	}
+}
+
+// NewCostWriteOff creates an unpriced expense cost movement.
+// The cost register assigns currency and amount from FIFO layers when recording.
+func NewCostWriteOff(
+	recorderID id.ID,
+	recorderType string,
+	recorderVersion int,
+	period time.Time,
+	warehouseID, nomenclatureID id.ID,
+	quantity types.Quantity,
+) CostMovement {
+	return CostMovement{
+		MovementBase:   NewMovementBase(recorderID, recorderType, recorderVersion, period, RecordTypeExpense),
+		WarehouseID:    warehouseID,
+		NomenclatureID: nomenclatureID,
 		Quantity:       quantity,
-		Amount:         amount,
 	}
 }
 
+// IsUnpriced reports whether the movement is a write-off still awaiting FIFO pricing.
+func (m *CostMovement) IsUnpriced() bool {
+	return m.RecordType == RecordTypeExpense && m.Amount.IsZero() && id.IsNil(m.CurrencyID)
+}
+
 // SignedAmount returns amount with sign based on record type.
 func (m *CostMovement) SignedAmount() types.MinorUnits {
 	if m.RecordType == RecordTypeExpense {
@@ -172,6 +353,11 @@ func (m *CostMovement) SignedAmount() types.MinorUnits {
 	return m.Amount
 }
 
+// Money returns the movement amount with its currency.
+func (m *CostMovement) Money() types.Money {
+	return types.NewMoney(m.Amount, m.CurrencyID)
+}
+
 // CostBalance represents current balance in the cost register.
 type CostBalance struct {
 	// Dimensions
@@ -215,15 +401,14 @@ func NewSettlementMovement(
 	recordType RecordType,
 	counterpartyID id.ID,
 	contractID *id.ID,
-	currencyID id.ID,
-	amount types.MinorUnits,
+	amount types.Money,
 ) SettlementMovement {
 	return SettlementMovement{
 		MovementBase:   NewMovementBase(recorderID, recorderType, recorderVersion, period, recordType),
 		CounterpartyID: counterpartyID,
 		ContractID:     contractID,
-		CurrencyID:     currencyID,
-		Amount:         amount,
+		CurrencyID:     amount.CurrencyID,
+		Amount:         amount.Amount,
 	}
 }
 
@@ -235,6 +420,11 @@ func (m *SettlementMovement) SignedAmount() types.MinorUnits {
 	return m.Amount
 }
 
+// Money returns the movement amount with its currency.
+func (m *SettlementMovement) Money() types.Money {
+	return types.NewMoney(m.Amount, m.CurrencyID)
+}
+
 // SettlementBalance represents current balance in the settlement register.
 type SettlementBalance struct {
 	// Dimensions
@@ -250,6 +440,45 @@ type SettlementBalance struct {
 	UpdatedAt      time.Time `db:"updated_at" json:"updatedAt"`
 }
 
+// Money returns the balance with its currency.
+func (b *SettlementBalance) Money() types.Money {
+	return types.NewMoney(b.Amount, b.CurrencyID)
+}
+
+// ---------------------------------------------------------------------------
+// Serial number accumulation register (Serial Numbers of Goods)
+// ---------------------------------------------------------------------------
+
+// SerialNumberMovement represents a movement of one unit of goods tracked by
+// serial number. Receipt brings the serial into stock, expense issues it.
+// The balance of a serial is 1 while it is in stock and 0 once issued.
+type SerialNumberMovement struct {
+	MovementBase
+
+	// Dimensions
+	WarehouseID    id.ID  `db:"warehouse_id" json:"warehouseId"`
+	NomenclatureID id.ID  `db:"nomenclature_id" json:"nomenclatureId"`
+	SerialNumber   string `db:"serial_number" json:"serialNumber"`
+}
+
+// NewSerialNumberMovement creates a new serial number movement.
+func NewSerialNumberMovement(
+	recorderID id.ID,
+	recorderType string,
+	recorderVersion int,
+	period time.Time,
+	recordType RecordType,
+	warehouseID, nomenclatureID id.ID,
+	serialNumber string,
+) SerialNumberMovement {
+	return SerialNumberMovement{
+		MovementBase:   NewMovementBase(recorderID, recorderType, recorderVersion, period, recordType),
+		WarehouseID:    warehouseID,
+		NomenclatureID: nomenclatureID,
+		SerialNumber:   serialNumber,
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Generic Document Movements (Cross-Register Abstraction)
 // ---------------------------------------------------------------------------
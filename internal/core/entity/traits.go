@@ -2,6 +2,9 @@ package entity
 
 import (
 	"context"
+
+	"github.com/shopspring/decimal"
+
 	"metapus/internal/core/apperror"
 	"metapus/internal/core/id"
 )
@@ -37,3 +40,33 @@ type ICurrencyAware interface {
 	GetCurrencyID() id.ID
 	ValidateCurrency(ctx context.Context) error
 }
+
+// PostedRate is a trait for foreign-currency documents that freeze the
+// exchange rate on posting: the rate used for the base-currency total is
+// stored on the document and reused on reposts until it is unposted, so
+// later edits of the exchange rates register do not change posted totals.
+// Both fields are nil for drafts and for documents in the base currency.
+type PostedRate struct {
+	ExchangeRate           *decimal.Decimal `db:"exchange_rate" json:"exchangeRate,omitempty" meta:"label:Курс"`
+	ExchangeRateMultiplier *int             `db:"exchange_rate_multiplier" json:"exchangeRateMultiplier,omitempty" meta:"label:Кратность курса"`
+}
+
+// GetPostedRate returns the frozen rate; ok is false when none is stored.
+func (p *PostedRate) GetPostedRate() (rate decimal.Decimal, multiplier int, ok bool) {
+	if p.ExchangeRate == nil || p.ExchangeRateMultiplier == nil {
+		return decimal.Zero, 0, false
+	}
+	return *p.ExchangeRate, *p.ExchangeRateMultiplier, true
+}
+
+// SetPostedRate stores the rate the document is posted at.
+func (p *PostedRate) SetPostedRate(rate decimal.Decimal, multiplier int) {
+	p.ExchangeRate = &rate
+	p.ExchangeRateMultiplier = &multiplier
+}
+
+// ClearPostedRate releases the frozen rate (document unposted).
+func (p *PostedRate) ClearPostedRate() {
+	p.ExchangeRate = nil
+	p.ExchangeRateMultiplier = nil
+}
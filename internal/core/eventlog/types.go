@@ -51,9 +51,13 @@ const (
 	EventSessionLogin       EventType = "session.login"
 	EventSessionLoginFailed EventType = "session.login_failed"
 	EventSessionLogout      EventType = "session.logout"
+	EventSessionRevoked     EventType = "session.revoked"
 	EventSessionRefresh     EventType = "session.token_refresh"
 	EventSessionImpersonate EventType = "session.impersonate"
 	EventSessionBruteForce  EventType = "session.brute_force"
+
+	EventSessionEmailChangeRequested EventType = "session.email_change_requested"
+	EventSessionEmailChanged         EventType = "session.email_changed"
 )
 
 // Data events — documents
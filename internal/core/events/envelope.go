@@ -0,0 +1,92 @@
+// Package events defines the domain events the platform publishes through
+// the transactional outbox and the sinks the worker delivers them to.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corectx "metapus/internal/core/context"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+)
+
+// EnvelopeVersion is the version of the Envelope format.
+const EnvelopeVersion = 1
+
+// Envelope is a domain event as it is stored in the outbox and delivered to
+// sinks. Data holds the typed payload of the event type.
+type Envelope struct {
+	ID            id.ID           `json:"id"`
+	Type          string          `json:"type"`
+	Version       int             `json:"version"`
+	TenantID      string          `json:"tenantId"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   id.ID           `json:"aggregateId"`
+	ActorID       string          `json:"actorId,omitempty"`
+	TraceID       string          `json:"traceId,omitempty"`
+	OccurredAt    time.Time       `json:"occurredAt"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// Type is a kind of domain event together with the Go type of its payload,
+// e.g. Type[DocumentEvent]("document.posted").
+type Type[P any] string
+
+// New builds an envelope of event type t about an aggregate. The tenant,
+// the current user and the trace are taken from ctx.
+func (t Type[P]) New(ctx context.Context, aggregateType string, aggregateID id.ID, data P) (Envelope, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("encode %s event: %w", t, err)
+	}
+	env := Envelope{
+		ID:            id.New(),
+		Type:          string(t),
+		Version:       EnvelopeVersion,
+		TenantID:      tenant.GetTenantID(ctx),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		ActorID:       corectx.GetUserID(ctx),
+		OccurredAt:    time.Now().UTC(),
+		Data:          raw,
+	}
+	if trace := corectx.GetTrace(ctx); trace != nil {
+		env.TraceID = trace.TraceID
+	}
+	return env, nil
+}
+
+// Decode returns the payload of an envelope of event type t.
+func (t Type[P]) Decode(env Envelope) (P, error) {
+	var data P
+	if env.Type != string(t) {
+		return data, fmt.Errorf("event %s is not %s", env.Type, t)
+	}
+	if err := json.Unmarshal(env.Data, &data); err != nil {
+		return data, fmt.Errorf("decode %s event: %w", t, err)
+	}
+	return data, nil
+}
+
+// Publish builds an event of type t and writes it through p. It is a no-op
+// when p is nil, so that optional publishers need no checks at call sites.
+func (t Type[P]) Publish(ctx context.Context, p Publisher, aggregateType string, aggregateID id.ID, data P) error {
+	if p == nil {
+		return nil
+	}
+	env, err := t.New(ctx, aggregateType, aggregateID, data)
+	if err != nil {
+		return err
+	}
+	return p.Publish(ctx, env)
+}
+
+// Publisher writes events to the transactional outbox. Publish must be
+// called inside the transaction of the change the event describes: the event
+// is delivered only if that transaction commits.
+type Publisher interface {
+	Publish(ctx context.Context, env Envelope) error
+}
@@ -0,0 +1,123 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corectx "metapus/internal/core/context"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+)
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), &tenant.Tenant{ID: "t-1"})
+	ctx = corectx.WithUser(ctx, &corectx.UserContext{UserID: "u-1"})
+	docID := id.New()
+
+	env, err := DocumentPosted.New(ctx, AggregateDocument, docID, DocumentEvent{
+		DocumentType: "goods_receipt",
+		DocumentID:   docID,
+		Number:       "GR-001",
+		Posted:       true,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if env.Type != "document.posted" || env.TenantID != "t-1" || env.ActorID != "u-1" || env.AggregateID != docID {
+		t.Errorf("envelope = %+v", env)
+	}
+
+	data, err := DocumentPosted.Decode(env)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if data.Number != "GR-001" || !data.Posted {
+		t.Errorf("data = %+v", data)
+	}
+	if _, err := DocumentCreated.Decode(env); err == nil {
+		t.Error("decoding as another event type should fail")
+	}
+}
+
+type recordingSink struct {
+	name string
+	err  error
+	got  []string
+}
+
+func (s *recordingSink) Name() string { return s.name }
+
+func (s *recordingSink) Deliver(_ context.Context, env Envelope) error {
+	s.got = append(s.got, env.Type)
+	return s.err
+}
+
+func TestDispatcherRoutesByPattern(t *testing.T) {
+	all := &recordingSink{name: "all"}
+	docs := &recordingSink{name: "docs"}
+	users := &recordingSink{name: "users"}
+
+	d := NewDispatcher()
+	d.Subscribe(all)
+	d.Subscribe(docs, "document.*")
+	d.Subscribe(users, "user.registered")
+
+	for _, typ := range []string{"document.posted", "catalog.changed", "user.registered"} {
+		if err := d.Dispatch(context.Background(), Envelope{Type: typ}, nil); err != nil {
+			t.Fatalf("Dispatch %s: %v", typ, err)
+		}
+	}
+	if len(all.got) != 3 {
+		t.Errorf("all got %v", all.got)
+	}
+	if len(docs.got) != 1 || docs.got[0] != "document.posted" {
+		t.Errorf("docs got %v", docs.got)
+	}
+	if len(users.got) != 1 || users.got[0] != "user.registered" {
+		t.Errorf("users got %v", users.got)
+	}
+}
+
+func TestDispatcherTriesAllSinks(t *testing.T) {
+	failing := &recordingSink{name: "broker", err: errors.New("unavailable")}
+	healthy := &recordingSink{name: "log"}
+
+	d := NewDispatcher()
+	d.Subscribe(failing)
+	d.Subscribe(healthy)
+
+	err := d.Dispatch(context.Background(), Envelope{Type: "document.created"}, nil)
+	if err == nil {
+		t.Fatal("expected the sink failure to be reported")
+	}
+	if len(healthy.got) != 1 {
+		t.Error("a failing sink must not stop delivery to the others")
+	}
+}
+
+func TestDispatcherSkipsDeliveredSinks(t *testing.T) {
+	broker := &recordingSink{name: "broker", err: errors.New("unavailable")}
+	log := &recordingSink{name: "log"}
+
+	d := NewDispatcher()
+	d.Subscribe(broker)
+	d.Subscribe(log)
+
+	delivered := Deliveries{}
+	if err := d.Dispatch(context.Background(), Envelope{Type: "stock.moved"}, delivered); err == nil {
+		t.Fatal("expected the broker failure to be reported")
+	}
+	if _, ok := delivered["log"]; !ok || len(delivered) != 1 {
+		t.Fatalf("delivered = %v, want only log", delivered)
+	}
+
+	// The retry goes to the failed sink only.
+	broker.err = nil
+	if err := d.Dispatch(context.Background(), Envelope{Type: "stock.moved"}, delivered); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if len(log.got) != 1 || len(broker.got) != 2 || len(delivered) != 2 {
+		t.Errorf("log got %v, broker got %v, delivered %v", log.got, broker.got, delivered)
+	}
+}
@@ -0,0 +1,112 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"metapus/pkg/logger"
+)
+
+// Sink delivers events outside the tenant database (a log, a message
+// broker). Deliver may be called again for an event it already delivered:
+// the outbox guarantees at-least-once delivery, so sinks or their consumers
+// deduplicate by Envelope.ID.
+type Sink interface {
+	Name() string
+	Deliver(ctx context.Context, env Envelope) error
+}
+
+// route is a sink with the event types it receives.
+type route struct {
+	sink     Sink
+	patterns []string
+}
+
+// Dispatcher delivers events to the sinks subscribed to their type.
+type Dispatcher struct {
+	routes []route
+}
+
+// NewDispatcher creates a dispatcher without sinks.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{}
+}
+
+// Subscribe delivers events matching any of the patterns to sink. A pattern
+// is an event type, a prefix ending in ".*" (document.*) or "*"; no patterns
+// means all events. Sink names must be unique: deliveries are tracked by name.
+func (d *Dispatcher) Subscribe(sink Sink, patterns ...string) {
+	d.routes = append(d.routes, route{sink: sink, patterns: patterns})
+}
+
+// Len returns the number of subscribed sinks.
+func (d *Dispatcher) Len() int {
+	return len(d.routes)
+}
+
+// Deliveries records when each sink (by name) received an event. The outbox
+// keeps it with the event so a retry skips the sinks that already have it.
+type Deliveries map[string]time.Time
+
+// Dispatch delivers env to every subscribed sink. All sinks are tried; the
+// error joins the failures, and the outbox retries the event. Sinks listed
+// in delivered are skipped and the ones that succeed are added to it; a nil
+// delivered delivers to all sinks without tracking.
+func (d *Dispatcher) Dispatch(ctx context.Context, env Envelope, delivered Deliveries) error {
+	var errs []error
+	for _, r := range d.routes {
+		if !matchesAny(r.patterns, env.Type) {
+			continue
+		}
+		name := r.sink.Name()
+		if _, ok := delivered[name]; ok {
+			continue
+		}
+		if err := r.sink.Deliver(ctx, env); err != nil {
+			errs = append(errs, fmt.Errorf("sink %s: %w", name, err))
+			continue
+		}
+		if delivered != nil {
+			delivered[name] = time.Now().UTC()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// matchesAny reports whether eventType matches one of the patterns.
+func matchesAny(patterns []string, eventType string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		switch {
+		case p == "*" || p == eventType:
+			return true
+		case strings.HasSuffix(p, ".*") && strings.HasPrefix(eventType, p[:len(p)-1]):
+			return true
+		}
+	}
+	return false
+}
+
+// LogSink writes events to the application log. Useful in development and
+// as an audit trail of what the other sinks received.
+type LogSink struct{}
+
+// Name implements Sink.
+func (LogSink) Name() string { return "log" }
+
+// Deliver implements Sink.
+func (LogSink) Deliver(ctx context.Context, env Envelope) error {
+	logger.Info(ctx, "domain event",
+		"event_id", env.ID,
+		"event_type", env.Type,
+		"tenant_id", env.TenantID,
+		"aggregate_type", env.AggregateType,
+		"aggregate_id", env.AggregateID,
+		"actor_id", env.ActorID)
+	return nil
+}
@@ -0,0 +1,84 @@
+package events
+
+import (
+	"time"
+
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+)
+
+// Aggregate types of the built-in events.
+const (
+	AggregateDocument = "document"
+	AggregateCatalog  = "catalog"
+	AggregateUser     = "user"
+)
+
+// Document events. The aggregate is the document.
+var (
+	DocumentCreated  = Type[DocumentEvent]("document.created")
+	DocumentPosted   = Type[DocumentEvent]("document.posted")
+	DocumentUnposted = Type[DocumentEvent]("document.unposted")
+)
+
+// DocumentEvent is the payload of document events.
+type DocumentEvent struct {
+	DocumentType string `json:"documentType"` // e.g. goods_receipt
+	DocumentID   id.ID  `json:"documentId"`
+	Number       string `json:"number,omitempty"`
+	Posted       bool   `json:"posted"`
+	Version      int    `json:"version"`
+}
+
+// CatalogChanged is published when a catalog item is created, updated or
+// deleted. The aggregate is the item.
+var CatalogChanged = Type[CatalogEvent]("catalog.changed")
+
+// Catalog change actions.
+const (
+	CatalogCreated = "created"
+	CatalogUpdated = "updated"
+	CatalogDeleted = "deleted"
+)
+
+// CatalogEvent is the payload of CatalogChanged.
+type CatalogEvent struct {
+	CatalogType string `json:"catalogType"` // e.g. counterparty
+	ItemID      id.ID  `json:"itemId"`
+	Action      string `json:"action"`
+	Code        string `json:"code,omitempty"`
+}
+
+// UserRegistered is published when a user registers. The aggregate is the user.
+var UserRegistered = Type[UserEvent]("user.registered")
+
+// UserEvent is the payload of user events.
+type UserEvent struct {
+	UserID    id.ID  `json:"userId"`
+	Email     string `json:"email"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+}
+
+// StockMoved is published when posting records stock movements. It carries
+// all stock movements of the document's posting version and replaces those
+// of earlier versions; unposting is signalled by DocumentUnposted. The
+// aggregate is the document.
+var StockMoved = Type[StockMovementEvent]("stock.moved")
+
+// StockMovementEvent is the payload of StockMoved.
+type StockMovementEvent struct {
+	DocumentType string              `json:"documentType"` // recorder type
+	DocumentID   id.ID               `json:"documentId"`
+	Version      int                 `json:"version"`
+	Movements    []StockMovementLine `json:"movements"`
+}
+
+// StockMovementLine is one stock register movement.
+type StockMovementLine struct {
+	Period         time.Time      `json:"period"`
+	RecordType     string         `json:"recordType"` // receipt or expense
+	WarehouseID    id.ID          `json:"warehouseId"`
+	NomenclatureID id.ID          `json:"nomenclatureId"`
+	Quantity       types.Quantity `json:"quantity"`
+}
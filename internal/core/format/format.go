@@ -0,0 +1,221 @@
+// Package format renders quantities, amounts and dates as locale-aware strings
+// for print forms and export files, including amounts in words.
+//
+// A Formatter is cheap and immutable; obtain one per request from the tenant
+// locale (settings.GeneralSettings.Locale) via ForLocale.
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/types"
+)
+
+// Locale identifies a supported formatting locale.
+type Locale string
+
+const (
+	// LocaleRU: "1 234,56", dates as 02.01.2006.
+	LocaleRU Locale = "ru"
+	// LocaleEN: "1,234.56", dates as 01/02/2006.
+	LocaleEN Locale = "en"
+)
+
+// DefaultLocale is used when the tenant has not configured one.
+const DefaultLocale = LocaleRU
+
+// ParseLocale accepts a language tag ("ru", "en-US", "ru_RU") and returns the
+// supported locale for its language.
+func ParseLocale(s string) (Locale, error) {
+	lang := strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	switch Locale(lang) {
+	case LocaleRU, LocaleEN:
+		return Locale(lang), nil
+	}
+	return "", fmt.Errorf("unsupported locale %q", s)
+}
+
+// Formatter formats values for one locale.
+type Formatter struct {
+	locale     Locale
+	group      string
+	decimal    string
+	dateLayout string
+}
+
+// New returns a Formatter for locale. Unknown locales fall back to DefaultLocale.
+func New(locale Locale) *Formatter {
+	switch locale {
+	case LocaleEN:
+		return &Formatter{locale: LocaleEN, group: ",", decimal: ".", dateLayout: "01/02/2006"}
+	default:
+		// Non-breaking space keeps grouped numbers on one line in print forms.
+		return &Formatter{locale: LocaleRU, group: "\u00a0", decimal: ",", dateLayout: "02.01.2006"}
+	}
+}
+
+// ForLocale parses a tenant locale setting; empty or invalid values fall back
+// to DefaultLocale.
+func ForLocale(s string) *Formatter {
+	l, err := ParseLocale(s)
+	if err != nil {
+		l = DefaultLocale
+	}
+	return New(l)
+}
+
+// Locale returns the formatter's locale.
+func (f *Formatter) Locale() Locale { return f.locale }
+
+// Int formats an integer with thousands separators: 1234567 → "1 234 567".
+func (f *Formatter) Int(n int64) string {
+	neg, abs := splitSign(n)
+	return neg + f.groupDigits(strconv.FormatUint(abs, 10))
+}
+
+// Money formats minor units with dp decimal places: (123456, 2) → "1 234,56".
+// A negative dp is treated as 2.
+func (f *Formatter) Money(v types.MinorUnits, dp int) string {
+	if dp < 0 {
+		dp = 2
+	}
+	return f.scaled(int64(v), dp, dp)
+}
+
+// MoneyWithSymbol formats an amount with its currency symbol placed the way
+// the locale writes it: "1 234,56 ₽" (ru), "$1,234.56" / "USD 1,234.56" (en).
+func (f *Formatter) MoneyWithSymbol(v types.MinorUnits, dp int, symbol string) string {
+	s := f.Money(v, dp)
+	switch {
+	case symbol == "":
+		return s
+	case f.locale == LocaleEN:
+		sign := ""
+		if strings.HasPrefix(s, "-") {
+			sign, s = "-", s[1:]
+		}
+		sep := ""
+		if r := []rune(symbol); isLetter(r[len(r)-1]) {
+			sep = " "
+		}
+		return sign + symbol + sep + s
+	default:
+		return s + " " + symbol
+	}
+}
+
+// Quantity formats a quantity keeping at least 3 decimal places and trimming
+// the rest: Quantity(15000) → "1,500" (ru), "1.500" (en).
+func (f *Formatter) Quantity(q types.Quantity) string {
+	return f.scaled(int64(q), 4, 3)
+}
+
+// Decimal formats d rounded to places decimal places.
+func (f *Formatter) Decimal(d decimal.Decimal, places int) string {
+	if places < 0 {
+		places = 0
+	}
+	s := d.StringFixed(int32(places))
+	neg := ""
+	if strings.HasPrefix(s, "-") {
+		neg, s = "-", s[1:]
+	}
+	intPart, frac, _ := strings.Cut(s, ".")
+	out := neg + f.groupDigits(intPart)
+	if frac != "" {
+		out += f.decimal + frac
+	}
+	return out
+}
+
+// Date formats t as a date; the zero time yields "".
+func (f *Formatter) Date(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(f.dateLayout)
+}
+
+// DateTime formats t as date and time (24h); the zero time yields "".
+func (f *Formatter) DateTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(f.dateLayout + " 15:04")
+}
+
+// Bool formats a flag as a localized yes/no.
+func (f *Formatter) Bool(b bool) string {
+	switch {
+	case f.locale == LocaleEN && b:
+		return "Yes"
+	case f.locale == LocaleEN:
+		return "No"
+	case b:
+		return "Да"
+	}
+	return "Нет"
+}
+
+// scaled formats an integer holding `scale` implied decimal places, showing
+// at least minFrac of them (trailing zeros beyond minFrac are trimmed).
+func (f *Formatter) scaled(v int64, scale, minFrac int) string {
+	neg, abs := splitSign(v)
+	div := uint64(1)
+	for range scale {
+		div *= 10
+	}
+
+	out := neg + f.groupDigits(strconv.FormatUint(abs/div, 10))
+	if scale == 0 {
+		return out
+	}
+	frac := fmt.Sprintf("%0*d", scale, abs%div)
+	for len(frac) > minFrac && frac[len(frac)-1] == '0' {
+		frac = frac[:len(frac)-1]
+	}
+	if frac == "" {
+		return out
+	}
+	return out + f.decimal + frac
+}
+
+// groupDigits inserts the group separator every three digits from the right.
+func (f *Formatter) groupDigits(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var sb strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		sb.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if sb.Len() > 0 {
+			sb.WriteString(f.group)
+		}
+		sb.WriteString(digits[i : i+3])
+	}
+	return sb.String()
+}
+
+// splitSign returns the sign prefix and absolute value; safe for math.MinInt64.
+func splitSign(n int64) (string, uint64) {
+	if n < 0 {
+		return "-", uint64(-(n + 1)) + 1
+	}
+	return "", uint64(n)
+}
+
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') ||
+		(r >= 'а' && r <= 'я') || (r >= 'А' && r <= 'Я') || r == '.'
+}
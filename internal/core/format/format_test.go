@@ -0,0 +1,159 @@
+package format
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/types"
+)
+
+// nbsp is the Russian group separator.
+const nbsp = "\u00a0"
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Locale
+		wantErr bool
+	}{
+		{"ru", LocaleRU, false},
+		{"ru-RU", LocaleRU, false},
+		{"EN_us", LocaleEN, false},
+		{" en ", LocaleEN, false},
+		{"de", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLocale(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseLocale(%q) = %q, %v; want %q, err=%v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+	if ForLocale("xx").Locale() != DefaultLocale {
+		t.Error("ForLocale should fall back to DefaultLocale")
+	}
+}
+
+func TestFormatter_Numbers(t *testing.T) {
+	ru, en := New(LocaleRU), New(LocaleEN)
+
+	tests := []struct {
+		name   string
+		ru, en string
+		fn     func(f *Formatter) string
+	}{
+		{"money", "1" + nbsp + "234,56", "1,234.56", func(f *Formatter) string { return f.Money(123456, 2) }},
+		{"money negative", "-1" + nbsp + "000" + nbsp + "000,05", "-1,000,000.05", func(f *Formatter) string { return f.Money(-100000005, 2) }},
+		{"money dp0", "1" + nbsp + "234", "1,234", func(f *Formatter) string { return f.Money(1234, 0) }},
+		{"money small", "0,07", "0.07", func(f *Formatter) string { return f.Money(7, 2) }},
+		{"qty trims to 3", "1,500", "1.500", func(f *Formatter) string { return f.Quantity(15000) }},
+		{"qty keeps 4", "12" + nbsp + "345,6789", "12,345.6789", func(f *Formatter) string { return f.Quantity(123456789) }},
+		{"int", "1" + nbsp + "234" + nbsp + "567", "1,234,567", func(f *Formatter) string { return f.Int(1234567) }},
+		{"int min", "-9" + nbsp + "223" + nbsp + "372" + nbsp + "036" + nbsp + "854" + nbsp + "775" + nbsp + "808",
+			"-9,223,372,036,854,775,808", func(f *Formatter) string { return f.Int(math.MinInt64) }},
+		{"decimal", "1" + nbsp + "234,57", "1,234.57", func(f *Formatter) string { return f.Decimal(decimal.RequireFromString("1234.567"), 2) }},
+		{"symbol", "1" + nbsp + "234,56 ₽", "₽1,234.56", func(f *Formatter) string { return f.MoneyWithSymbol(123456, 2, "₽") }},
+		{"symbol letters", "-5,00 USD", "-USD 5.00", func(f *Formatter) string { return f.MoneyWithSymbol(-500, 2, "USD") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(ru); got != tt.ru {
+				t.Errorf("ru = %q, want %q", got, tt.ru)
+			}
+			if got := tt.fn(en); got != tt.en {
+				t.Errorf("en = %q, want %q", got, tt.en)
+			}
+		})
+	}
+}
+
+func TestFormatter_Date(t *testing.T) {
+	d := time.Date(2026, 3, 19, 14, 5, 0, 0, time.UTC)
+	if got := New(LocaleRU).Date(d); got != "19.03.2026" {
+		t.Errorf("ru date = %q", got)
+	}
+	if got := New(LocaleEN).DateTime(d); got != "03/19/2026 14:05" {
+		t.Errorf("en datetime = %q", got)
+	}
+	if got := New(LocaleRU).Date(time.Time{}); got != "" {
+		t.Errorf("zero date = %q, want empty", got)
+	}
+}
+
+func TestAmountInWords_RU(t *testing.T) {
+	f := New(LocaleRU)
+	tests := []struct {
+		v    types.MinorUnits
+		dp   int
+		code string
+		want string
+	}{
+		{123456, 2, "RUB", "Одна тысяча двести тридцать четыре рубля 56 копеек"},
+		{100, 2, "RUB", "Один рубль 00 копеек"},
+		{0, 2, "RUB", "Ноль рублей 00 копеек"},
+		{1101, 2, "RUB", "Одиннадцать рублей 01 копейка"},
+		{2200000022, 2, "RUB", "Двадцать два миллиона рублей 22 копейки"},
+		{200100000, 2, "RUB", "Два миллиона одна тысяча рублей 00 копеек"},
+		{2100, 2, "USD", "Двадцать один доллар 00 центов"},
+		{-500, 2, "EUR", "Минус пять евро 00 центов"},
+		{12, 0, "KZT", "Двенадцать тенге"},
+		{300, 2, "USDT", "Три USDT 00"},
+	}
+	for _, tt := range tests {
+		if got := f.AmountInWords(tt.v, tt.dp, tt.code); got != tt.want {
+			t.Errorf("AmountInWords(%d, %d, %s) = %q, want %q", tt.v, tt.dp, tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestAmountInWords_EN(t *testing.T) {
+	f := New(LocaleEN)
+	tests := []struct {
+		v    types.MinorUnits
+		code string
+		want string
+	}{
+		{123456, "RUB", "One thousand two hundred thirty-four rubles 56 kopecks"},
+		{101, "USD", "One dollar 01 cent"},
+		{100000000, "USD", "One million dollars 00 cents"},
+		{1200, "EUR", "Twelve euros 00 cents"},
+		{9000, "", "Ninety 00"},
+	}
+	for _, tt := range tests {
+		if got := f.AmountInWords(tt.v, 2, tt.code); got != tt.want {
+			t.Errorf("AmountInWords(%d, %s) = %q, want %q", tt.v, tt.code, got, tt.want)
+		}
+	}
+
+	// Largest representable amount must not overflow the scale tables.
+	if got := f.AmountInWords(math.MaxInt64, 0, "USD"); !strings.HasPrefix(got, "Nine quintillion") {
+		t.Errorf("MaxInt64 = %q", got)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"exact", 5, "exact"},
+		{"truncated", 5, "trunc"},
+		{"ошибка", 5, "ош"}, // 2-byte letters: byte 5 is inside "и"
+		{"ошибка", 6, "оши"},
+		{"a€b", 2, "a"},
+		{"abc", 0, ""},
+	}
+	for _, tt := range tests {
+		got := Truncate(tt.s, tt.n)
+		if got != tt.want || !utf8.ValidString(got) {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package format
+
+import "unicode/utf8"
+
+// Truncate shortens s to at most n bytes without splitting a UTF-8
+// character, e.g. an error message stored in a TEXT column, which rejects
+// invalid UTF-8.
+func Truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	if n <= 0 {
+		return ""
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,281 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"metapus/internal/core/types"
+)
+
+// currencyWords holds the unit names used when writing an amount in words.
+// Russian forms are [one, few, many] (рубль, рубля, рублей); English forms
+// are [singular, plural].
+type currencyWords struct {
+	ruMajor    [3]string
+	ruMajorFem bool
+	ruMinor    [3]string
+	ruMinorFem bool
+	enMajor    [2]string
+	enMinor    [2]string
+}
+
+// currencies is keyed by ISO 4217 alphabetic code.
+var currencies = map[string]currencyWords{
+	"RUB": {
+		ruMajor: [3]string{"рубль", "рубля", "рублей"},
+		ruMinor: [3]string{"копейка", "копейки", "копеек"}, ruMinorFem: true,
+		enMajor: [2]string{"ruble", "rubles"},
+		enMinor: [2]string{"kopeck", "kopecks"},
+	},
+	"USD": {
+		ruMajor: [3]string{"доллар", "доллара", "долларов"},
+		ruMinor: [3]string{"цент", "цента", "центов"},
+		enMajor: [2]string{"dollar", "dollars"},
+		enMinor: [2]string{"cent", "cents"},
+	},
+	"EUR": {
+		ruMajor: [3]string{"евро", "евро", "евро"},
+		ruMinor: [3]string{"цент", "цента", "центов"},
+		enMajor: [2]string{"euro", "euros"},
+		enMinor: [2]string{"cent", "cents"},
+	},
+	"CNY": {
+		ruMajor: [3]string{"юань", "юаня", "юаней"},
+		ruMinor: [3]string{"фэнь", "фэня", "фэней"},
+		enMajor: [2]string{"yuan", "yuan"},
+		enMinor: [2]string{"fen", "fen"},
+	},
+	"KZT": {
+		ruMajor: [3]string{"тенге", "тенге", "тенге"},
+		ruMinor: [3]string{"тиын", "тиына", "тиынов"},
+		enMajor: [2]string{"tenge", "tenge"},
+		enMinor: [2]string{"tiyn", "tiyn"},
+	},
+	"BYN": {
+		ruMajor: [3]string{"белорусский рубль", "белорусских рубля", "белорусских рублей"},
+		ruMinor: [3]string{"копейка", "копейки", "копеек"}, ruMinorFem: true,
+		enMajor: [2]string{"Belarusian ruble", "Belarusian rubles"},
+		enMinor: [2]string{"kopeck", "kopecks"},
+	},
+}
+
+// AmountInWords writes an amount for the "sum in words" line of print forms:
+//
+//	ru: "Одна тысяча двести тридцать четыре рубля 56 копеек"
+//	en: "One thousand two hundred thirty-four rubles 56 kopecks"
+//
+// The major part is spelled out, the minor part stays numeric (accounting
+// convention). currencyCode is the ISO code; for currencies without known
+// unit names the code is written after the major part.
+func (f *Formatter) AmountInWords(v types.MinorUnits, dp int, currencyCode string) string {
+	if dp < 0 {
+		dp = 2
+	}
+	neg, abs := splitSign(int64(v))
+	div := uint64(1)
+	for range dp {
+		div *= 10
+	}
+	major, minor := abs/div, abs%div
+
+	cw, known := currencies[strings.ToUpper(currencyCode)]
+
+	var parts []string
+	if neg != "" {
+		parts = append(parts, f.pick("минус", "minus"))
+	}
+	if f.locale == LocaleEN {
+		parts = append(parts, enNumber(major))
+	} else {
+		parts = append(parts, ruNumber(major, known && cw.ruMajorFem))
+	}
+
+	switch {
+	case known && f.locale == LocaleEN:
+		parts = append(parts, enPlural(major, cw.enMajor))
+	case known:
+		parts = append(parts, ruPlural(major, cw.ruMajor))
+	case currencyCode != "":
+		parts = append(parts, strings.ToUpper(currencyCode))
+	}
+
+	if dp > 0 {
+		parts = append(parts, fmt.Sprintf("%0*d", dp, minor))
+		if known && f.locale == LocaleEN {
+			parts = append(parts, enPlural(minor, cw.enMinor))
+		} else if known {
+			parts = append(parts, ruPlural(minor, cw.ruMinor))
+		}
+	}
+
+	return capitalize(strings.Join(parts, " "))
+}
+
+func (f *Formatter) pick(ru, en string) string {
+	if f.locale == LocaleEN {
+		return en
+	}
+	return ru
+}
+
+// ── Russian ─────────────────────────────────────────────────────────────
+
+var (
+	ruOnesMasc = [...]string{"", "один", "два", "три", "четыре", "пять", "шесть", "семь", "восемь", "девять"}
+	ruOnesFem  = [...]string{"", "одна", "две", "три", "четыре", "пять", "шесть", "семь", "восемь", "девять"}
+	ruTeens    = [...]string{"десять", "одиннадцать", "двенадцать", "тринадцать", "четырнадцать",
+		"пятнадцать", "шестнадцать", "семнадцать", "восемнадцать", "девятнадцать"}
+	ruTens = [...]string{"", "", "двадцать", "тридцать", "сорок", "пятьдесят",
+		"шестьдесят", "семьдесят", "восемьдесят", "девяносто"}
+	ruHundreds = [...]string{"", "сто", "двести", "триста", "четыреста", "пятьсот",
+		"шестьсот", "семьсот", "восемьсот", "девятьсот"}
+
+	// ruScales lists thousand powers with their forms and gender.
+	ruScales = [...]struct {
+		forms [3]string
+		fem   bool
+	}{
+		{forms: [3]string{"тысяча", "тысячи", "тысяч"}, fem: true},
+		{forms: [3]string{"миллион", "миллиона", "миллионов"}},
+		{forms: [3]string{"миллиард", "миллиарда", "миллиардов"}},
+		{forms: [3]string{"триллион", "триллиона", "триллионов"}},
+		{forms: [3]string{"квадриллион", "квадриллиона", "квадриллионов"}},
+		{forms: [3]string{"квинтиллион", "квинтиллиона", "квинтиллионов"}},
+	}
+)
+
+// ruNumber spells n in Russian; fem selects feminine "одна/две" for the units.
+func ruNumber(n uint64, fem bool) string {
+	if n == 0 {
+		return "ноль"
+	}
+	groups := splitThousands(n)
+	var words []string
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		if g == 0 {
+			continue
+		}
+		if i == 0 {
+			words = append(words, ruTriad(g, fem)...)
+			continue
+		}
+		scale := ruScales[i-1]
+		words = append(words, ruTriad(g, scale.fem)...)
+		words = append(words, ruPlural(g, scale.forms))
+	}
+	return strings.Join(words, " ")
+}
+
+func ruTriad(n uint64, fem bool) []string {
+	var words []string
+	if h := n / 100; h > 0 {
+		words = append(words, ruHundreds[h])
+	}
+	rest := n % 100
+	switch {
+	case rest >= 10 && rest < 20:
+		words = append(words, ruTeens[rest-10])
+	default:
+		if t := rest / 10; t > 0 {
+			words = append(words, ruTens[t])
+		}
+		if u := rest % 10; u > 0 {
+			if fem {
+				words = append(words, ruOnesFem[u])
+			} else {
+				words = append(words, ruOnesMasc[u])
+			}
+		}
+	}
+	return words
+}
+
+// ruPlural picks the noun form agreeing with n: 1 рубль, 2 рубля, 5 рублей.
+func ruPlural(n uint64, forms [3]string) string {
+	switch n100 := n % 100; {
+	case n100 >= 11 && n100 <= 14:
+		return forms[2]
+	case n100%10 == 1:
+		return forms[0]
+	case n100%10 >= 2 && n100%10 <= 4:
+		return forms[1]
+	}
+	return forms[2]
+}
+
+// ── English ─────────────────────────────────────────────────────────────
+
+var (
+	enOnes = [...]string{"", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	enTens   = [...]string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	enScales = [...]string{"thousand", "million", "billion", "trillion", "quadrillion", "quintillion"}
+)
+
+// enNumber spells n in English: 1234 → "one thousand two hundred thirty-four".
+func enNumber(n uint64) string {
+	if n == 0 {
+		return "zero"
+	}
+	groups := splitThousands(n)
+	var words []string
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		if g == 0 {
+			continue
+		}
+		words = append(words, enTriad(g)...)
+		if i > 0 {
+			words = append(words, enScales[i-1])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func enTriad(n uint64) []string {
+	var words []string
+	if h := n / 100; h > 0 {
+		words = append(words, enOnes[h], "hundred")
+	}
+	rest := n % 100
+	switch {
+	case rest == 0:
+	case rest < 20:
+		words = append(words, enOnes[rest])
+	case rest%10 == 0:
+		words = append(words, enTens[rest/10])
+	default:
+		words = append(words, enTens[rest/10]+"-"+enOnes[rest%10])
+	}
+	return words
+}
+
+func enPlural(n uint64, forms [2]string) string {
+	if n == 1 {
+		return forms[0]
+	}
+	return forms[1]
+}
+
+// ── Helpers ─────────────────────────────────────────────────────────────
+
+// splitThousands returns n's base-1000 digits, least significant first.
+func splitThousands(n uint64) []uint64 {
+	var groups []uint64
+	for n > 0 {
+		groups = append(groups, n%1000)
+		n /= 1000
+	}
+	return groups
+}
+
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
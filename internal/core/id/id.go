@@ -1,5 +1,8 @@
-// Package id provides UUIDv7 generation for all platform entities.
-// UUIDv7 is time-ordered, allowing natural sorting by creation time.
+// Package id provides ID generation for all platform entities.
+// IDs are 128-bit values stored in PostgreSQL UUID columns; the generation
+// strategy (UUIDv7 by default, UUIDv4 or ULID) is selected per deployment,
+// see SetStrategy. Parsing accepts every format regardless of the strategy,
+// so existing UUIDv4 data keeps working after a switch.
 package id
 
 import (
@@ -9,30 +12,32 @@ import (
 // ID is a type alias for UUID, used across all entities.
 type ID = uuid.UUID
 
-// New generates a new UUIDv7 (time-ordered UUID).
+// New generates a new ID using the configured strategy (UUIDv7 by default).
 // UUIDv7 embeds Unix timestamp in first 48 bits, enabling:
 // - Natural chronological ordering
 // - No need for separate created_at index for sorting
 // - Better B-tree locality in PostgreSQL
 func New() ID {
-	// uuid.NewV7() returns UUIDv7 per RFC 9562
-	id, err := uuid.NewV7()
-	if err != nil {
-		// Fallback to V4 if V7 fails (should never happen)
-		return uuid.New()
-	}
-	return id
+	return current.Load().New()
 }
 
 // Parse converts string to ID with validation.
+// Accepts canonical UUID forms (any version) and 26-character ULID strings.
 func Parse(s string) (ID, error) {
+	if len(s) == ulidEncodedLen {
+		return ParseULID(s)
+	}
 	return uuid.Parse(s)
 }
 
 // MustParse converts string to ID, panics on error.
 // Use only for constants and tests.
 func MustParse(s string) ID {
-	return uuid.MustParse(s)
+	parsed, err := Parse(s)
+	if err != nil {
+		panic(`id: Parse(` + s + `): ` + err.Error())
+	}
+	return parsed
 }
 
 // Nil returns zero-value UUID.
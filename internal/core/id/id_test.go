@@ -0,0 +1,119 @@
+package id
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParse_Compatibility(t *testing.T) {
+	v4 := uuid.New()
+	v7 := newUUIDv7()
+	ulid := newULIDGenerator().New()
+
+	cases := map[string]struct {
+		in   string
+		want ID
+	}{
+		"uuidv4":          {v4.String(), v4},
+		"uuidv7":          {v7.String(), v7},
+		"ulid":            {FormatULID(ulid), ulid},
+		"ulid lowercase":  {string(bytes.ToLower([]byte(FormatULID(ulid)))), ulid},
+		"ulid of uuidv4":  {FormatULID(v4), v4},
+		"uuid of ulid":    {ulid.String(), ulid},
+		"seeded constant": {"b0000000-0000-0000-0000-000000000001", uuid.MustParse("b0000000-0000-0000-0000-000000000001")},
+	}
+	for name, tc := range cases {
+		got, err := Parse(tc.in)
+		if err != nil {
+			t.Errorf("%s: Parse(%q) error: %v", name, tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: Parse(%q) = %s, want %s", name, tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParseULID_Invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"01ARZ3NDEKTSV4RRFFQ69G5FA",  // 25 chars
+		"81ARZ3NDEKTSV4RRFFQ69G5FAV", // overflows 128 bits
+		"01ARZ3NDEKTSV4RRFFQ69G5FAU", // U is not in the alphabet
+	} {
+		if _, err := ParseULID(s); err == nil {
+			t.Errorf("ParseULID(%q) succeeded, want error", s)
+		}
+	}
+}
+
+func TestULID_KnownValue(t *testing.T) {
+	// Max ULID per spec.
+	got, err := ParseULID("7ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, b := range got {
+		if b != 0xFF {
+			t.Fatalf("byte %d = %#x, want 0xff", i, b)
+		}
+	}
+	if s := FormatULID(got); s != "7ZZZZZZZZZZZZZZZZZZZZZZZZZ" {
+		t.Errorf("FormatULID = %s", s)
+	}
+}
+
+func TestULIDGenerator_Monotonic(t *testing.T) {
+	g := newULIDGenerator()
+	prev := g.New()
+	for i := 0; i < 10000; i++ {
+		next := g.New()
+		if bytes.Compare(prev[:], next[:]) >= 0 {
+			t.Fatalf("ULID %d not increasing: %s >= %s", i, FormatULID(prev), FormatULID(next))
+		}
+		prev = next
+	}
+}
+
+func TestSetStrategy(t *testing.T) {
+	t.Cleanup(func() { _ = SetStrategy(StrategyUUIDv7) })
+
+	for _, tc := range []struct {
+		name    string
+		version uuid.Version
+	}{
+		{"uuidv4", 4},
+		{"V7", 7},
+	} {
+		s, err := ParseStrategy(tc.name)
+		if err != nil {
+			t.Fatalf("ParseStrategy(%q): %v", tc.name, err)
+		}
+		if err := SetStrategy(s); err != nil {
+			t.Fatal(err)
+		}
+		if v := New().Version(); v != tc.version {
+			t.Errorf("%s: New().Version() = %d, want %d", tc.name, v, tc.version)
+		}
+	}
+
+	if _, err := ParseStrategy("snowflake"); err == nil {
+		t.Error("ParseStrategy(snowflake) succeeded, want error")
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	for _, s := range []Strategy{StrategyUUIDv7, StrategyUUIDv4, StrategyULID} {
+		g, err := NewGenerator(s)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(string(s), func(b *testing.B) {
+			for b.Loop() {
+				_ = g.New()
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package id
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// Strategy names an ID generation scheme.
+type Strategy string
+
+const (
+	// StrategyUUIDv7 generates time-ordered UUIDs (RFC 9562). Default.
+	StrategyUUIDv7 Strategy = "uuidv7"
+	// StrategyUUIDv4 generates random UUIDs. Worst index locality; kept for
+	// deployments that must not leak creation time through IDs.
+	StrategyUUIDv4 Strategy = "uuidv4"
+	// StrategyULID generates monotonic ULIDs stored as UUID bytes: time-ordered
+	// like UUIDv7 and strictly increasing within a millisecond.
+	StrategyULID Strategy = "ulid"
+)
+
+// Generator produces new IDs.
+type Generator interface {
+	New() ID
+}
+
+// GeneratorFunc adapts a function to Generator.
+type GeneratorFunc func() ID
+
+// New calls f.
+func (f GeneratorFunc) New() ID { return f() }
+
+type strategyGenerator struct {
+	Generator
+	strategy Strategy
+}
+
+var current atomic.Pointer[strategyGenerator]
+
+func init() {
+	current.Store(&strategyGenerator{Generator: GeneratorFunc(newUUIDv7), strategy: StrategyUUIDv7})
+}
+
+// ParseStrategy parses a strategy name (case-insensitive). Empty means the default.
+func ParseStrategy(s string) (Strategy, error) {
+	switch Strategy(strings.ToLower(strings.TrimSpace(s))) {
+	case "", StrategyUUIDv7, "v7":
+		return StrategyUUIDv7, nil
+	case StrategyUUIDv4, "v4":
+		return StrategyUUIDv4, nil
+	case StrategyULID:
+		return StrategyULID, nil
+	}
+	return "", fmt.Errorf("unknown ID strategy %q (expected uuidv7, uuidv4 or ulid)", s)
+}
+
+// NewGenerator returns the generator for a strategy.
+func NewGenerator(s Strategy) (Generator, error) {
+	switch s {
+	case StrategyUUIDv7:
+		return GeneratorFunc(newUUIDv7), nil
+	case StrategyUUIDv4:
+		return GeneratorFunc(uuid.New), nil
+	case StrategyULID:
+		return newULIDGenerator(), nil
+	}
+	return nil, fmt.Errorf("unknown ID strategy %q", s)
+}
+
+// SetStrategy selects the strategy used by New. Call once at startup,
+// before any IDs are generated (e.g. from ID_STRATEGY).
+func SetStrategy(s Strategy) error {
+	g, err := NewGenerator(s)
+	if err != nil {
+		return err
+	}
+	current.Store(&strategyGenerator{Generator: g, strategy: s})
+	return nil
+}
+
+// CurrentStrategy returns the strategy used by New.
+func CurrentStrategy() Strategy {
+	return current.Load().strategy
+}
+
+func newUUIDv7() ID {
+	// uuid.NewV7() returns UUIDv7 per RFC 9562
+	id, err := uuid.NewV7()
+	if err != nil {
+		// Fallback to V4 if V7 fails (should never happen)
+		return uuid.New()
+	}
+	return id
+}
@@ -0,0 +1,128 @@
+package id
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ULID layout (https://github.com/ulid/spec): 48-bit big-endian Unix
+// milliseconds followed by 80 random bits, encoded as 26 Crockford base32
+// characters. The 16 bytes are stored as-is in UUID columns.
+const (
+	ulidEncodedLen = 26
+	crockford      = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// ErrInvalidULID is returned for malformed ULID strings.
+var ErrInvalidULID = errors.New("id: invalid ULID")
+
+var crockfordDec = func() [256]byte {
+	var dec [256]byte
+	for i := range dec {
+		dec[i] = 0xFF
+	}
+	for i := 0; i < len(crockford); i++ {
+		dec[crockford[i]] = byte(i)
+		dec[crockford[i]|0x20] = byte(i) // lowercase
+	}
+	// Crockford aliases
+	for _, c := range []byte("oO") {
+		dec[c] = 0
+	}
+	for _, c := range []byte("iIlL") {
+		dec[c] = 1
+	}
+	return dec
+}()
+
+// ulidGenerator produces monotonic ULIDs: within the same millisecond the
+// random part is incremented instead of redrawn, so IDs stay strictly ordered.
+type ulidGenerator struct {
+	mu      sync.Mutex
+	lastMs  uint64
+	lastRnd [10]byte
+}
+
+func newULIDGenerator() *ulidGenerator {
+	return &ulidGenerator{}
+}
+
+// New returns the next ULID.
+func (g *ulidGenerator) New() ID {
+	ms := uint64(time.Now().UnixMilli())
+
+	g.mu.Lock()
+	if ms <= g.lastMs {
+		// Same millisecond (or clock went backwards): increment the random part.
+		ms = g.lastMs
+		if !incrementBytes(g.lastRnd[:]) {
+			// 80-bit overflow within one millisecond: move to the next one.
+			ms++
+			_, _ = rand.Read(g.lastRnd[:])
+		}
+	} else {
+		_, _ = rand.Read(g.lastRnd[:])
+	}
+	g.lastMs = ms
+	rnd := g.lastRnd
+	g.mu.Unlock()
+
+	var id ID
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], ms)
+	copy(id[:6], ts[2:])
+	copy(id[6:], rnd[:])
+	return id
+}
+
+// incrementBytes adds one to a big-endian number; false on overflow.
+func incrementBytes(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatULID encodes an ID as a 26-character ULID string.
+func FormatULID(id ID) string {
+	var out [ulidEncodedLen]byte
+	// 128 bits → 26 × 5 bits (130), the first character carries 3 bits.
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	for i := ulidEncodedLen - 1; i >= 0; i-- {
+		out[i] = crockford[lo&0x1F]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// ParseULID decodes a 26-character ULID string.
+func ParseULID(s string) (ID, error) {
+	var id ID
+	if len(s) != ulidEncodedLen {
+		return id, ErrInvalidULID
+	}
+	// The first character holds the top 3 bits; larger values overflow 128 bits.
+	if v := crockfordDec[s[0]]; v == 0xFF || v > 7 {
+		return id, ErrInvalidULID
+	}
+	var hi, lo uint64
+	for i := 0; i < ulidEncodedLen; i++ {
+		v := crockfordDec[s[i]]
+		if v == 0xFF {
+			return id, ErrInvalidULID
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id, nil
+}
@@ -0,0 +1,87 @@
+// Package jobs is the background job queue of a tenant. Domain code enqueues
+// typed jobs (report generation, imports, webhook delivery) in its own
+// transaction; the worker claims due jobs from sys_jobs, runs them through
+// the handlers of a Registry, retries failures with backoff and moves jobs
+// that exhausted their attempts to the dead status.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status of a queued job.
+type Status string
+
+const (
+	StatusPending Status = "pending" // waiting for run_at
+	StatusRunning Status = "running" // claimed by a worker until locked_until
+	StatusDone    Status = "done"
+	StatusDead    Status = "dead" // attempts exhausted or permanent failure
+)
+
+// Valid reports whether s is a known status.
+func (s Status) Valid() bool {
+	switch s {
+	case StatusPending, StatusRunning, StatusDone, StatusDead:
+		return true
+	}
+	return false
+}
+
+// Job is one queued unit of background work.
+type Job struct {
+	ID          uuid.UUID
+	Type        string
+	Payload     json.RawMessage
+	Status      Status
+	Attempts    int // attempts started so far, including the running one
+	MaxAttempts int
+	RunAt       time.Time
+	LockedUntil *time.Time
+	LastError   string
+	CreatedBy   *uuid.UUID
+	CreatedAt   time.Time
+	FinishedAt  *time.Time
+}
+
+// Filter selects jobs for the administration list. Zero fields do not filter.
+type Filter struct {
+	Type   string
+	Status Status
+	Limit  int
+}
+
+// Repository stores the job queue of the tenant in ctx.
+type Repository interface {
+	// Insert enqueues a job, inside the transaction of ctx if there is one.
+	Insert(ctx context.Context, j *Job) error
+
+	// Claim locks up to limit due jobs of the given types until leaseUntil
+	// and counts the attempt. Due are pending jobs with run_at <= now and
+	// running jobs whose lease expired (the worker died). SKIP LOCKED keeps
+	// concurrent workers from claiming the same job.
+	Claim(ctx context.Context, types []string, now, leaseUntil time.Time, limit int) ([]*Job, error)
+
+	// Complete marks a claimed job done.
+	Complete(ctx context.Context, id uuid.UUID) error
+
+	// Fail records the error of a claimed job and schedules it for retryAt,
+	// or moves it to the dead status if retryAt is nil.
+	Fail(ctx context.Context, id uuid.UUID, lastError string, retryAt *time.Time) error
+
+	// GetByID returns a single job.
+	GetByID(ctx context.Context, id uuid.UUID) (*Job, error)
+
+	// List returns jobs matching the filter, newest first.
+	List(ctx context.Context, f Filter) ([]*Job, error)
+
+	// Requeue makes a dead job pending again with a fresh set of attempts.
+	Requeue(ctx context.Context, id uuid.UUID) error
+
+	// PurgeFinished deletes done jobs finished before the cutoff.
+	PurgeFinished(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,77 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	corectx "metapus/internal/core/context"
+	"metapus/internal/core/id"
+)
+
+// DefaultMaxAttempts is the number of attempts of a job enqueued without
+// MaxAttempts.
+const DefaultMaxAttempts = 5
+
+// EnqueueOption configures an enqueued job.
+type EnqueueOption func(*Job)
+
+// RunAt delays the first attempt until t.
+func RunAt(t time.Time) EnqueueOption {
+	return func(j *Job) { j.RunAt = t }
+}
+
+// MaxAttempts sets how many attempts the job gets before it goes dead.
+func MaxAttempts(n int) EnqueueOption {
+	return func(j *Job) {
+		if n > 0 {
+			j.MaxAttempts = n
+		}
+	}
+}
+
+// Queue enqueues jobs into the job queue of the tenant in ctx.
+type Queue struct {
+	repo Repository
+	now  func() time.Time
+}
+
+// NewQueue creates a job queue.
+func NewQueue(repo Repository) *Queue {
+	return &Queue{repo: repo, now: time.Now}
+}
+
+// Enqueue adds a job of type t. Called inside a transaction, the job is
+// committed (and run) only together with the caller's changes. The current
+// user becomes the author of the job; its handler runs on their behalf.
+func (t Type[P]) Enqueue(ctx context.Context, q *Queue, payload P, opts ...EnqueueOption) (uuid.UUID, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("encode %s payload: %w", t, err)
+	}
+
+	j := &Job{
+		ID:          id.New(),
+		Type:        string(t),
+		Payload:     raw,
+		Status:      StatusPending,
+		MaxAttempts: DefaultMaxAttempts,
+		RunAt:       q.now(),
+	}
+	if user := corectx.GetUser(ctx); user != nil {
+		if userID, err := uuid.Parse(user.UserID); err == nil {
+			j.CreatedBy = &userID
+		}
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+
+	if err := q.repo.Insert(ctx, j); err != nil {
+		return uuid.Nil, err
+	}
+	return j.ID, nil
+}
@@ -0,0 +1,151 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Type names a job kind and fixes the Go type of its payload, so enqueuing
+// and handling the same kind cannot disagree on the payload shape:
+//
+//	var GenerateReport = jobs.Type[ReportRequest]("reports.generate")
+type Type[P any] string
+
+// Backoff returns the delay before the next attempt after attempt (1-based)
+// failed.
+type Backoff func(attempt int) time.Duration
+
+// ExponentialBackoff doubles the delay from base with every attempt, up to
+// max.
+func ExponentialBackoff(base, max time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		return min(d, max)
+	}
+}
+
+// DefaultBackoff is used by handlers registered without WithBackoff:
+// 30s → 1min → 2min → … up to 1h.
+var DefaultBackoff = ExponentialBackoff(30*time.Second, time.Hour)
+
+// DefaultTimeout bounds one attempt of handlers registered without
+// WithTimeout.
+const DefaultTimeout = 5 * time.Minute
+
+// HandlerOption configures a registered handler.
+type HandlerOption func(*handler)
+
+// WithBackoff sets the retry delays of the handler.
+func WithBackoff(b Backoff) HandlerOption {
+	return func(h *handler) { h.backoff = b }
+}
+
+// WithTimeout bounds one attempt of the handler. It also sets the worker
+// lease, so it must exceed the longest expected run.
+func WithTimeout(d time.Duration) HandlerOption {
+	return func(h *handler) { h.timeout = d }
+}
+
+type handler struct {
+	run     func(ctx context.Context, payload json.RawMessage) error
+	backoff Backoff
+	timeout time.Duration
+}
+
+// Registry maps job types to their handlers. Domain modules register their
+// handlers when the worker starts; the worker only claims registered types.
+type Registry struct {
+	mu       sync.RWMutex
+	handlers map[string]*handler
+}
+
+// NewRegistry creates an empty handler registry.
+func NewRegistry() *Registry {
+	return &Registry{handlers: map[string]*handler{}}
+}
+
+// Handle registers fn as the handler of t. A payload that does not decode
+// into P fails the job permanently. It panics if t already has a handler.
+func Handle[P any](r *Registry, t Type[P], fn func(ctx context.Context, payload P) error, opts ...HandlerOption) {
+	h := &handler{
+		run: func(ctx context.Context, raw json.RawMessage) error {
+			var payload P
+			if err := json.Unmarshal(raw, &payload); err != nil {
+				return Permanent(fmt.Errorf("decode %s payload: %w", t, err))
+			}
+			return fn(ctx, payload)
+		},
+		backoff: DefaultBackoff,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.handlers[string(t)]; ok {
+		panic(fmt.Sprintf("jobs: handler for %q already registered", t))
+	}
+	r.handlers[string(t)] = h
+}
+
+// Types returns the registered job types, sorted.
+func (r *Registry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]string, 0, len(r.handlers))
+	for name := range r.handlers {
+		types = append(types, name)
+	}
+	slices.Sort(types)
+	return types
+}
+
+func (r *Registry) lookup(jobType string) (*handler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	h, ok := r.handlers[jobType]
+	return h, ok
+}
+
+// maxTimeout is the longest attempt timeout of the registered handlers; it
+// is the lease of a claimed batch.
+func (r *Registry) maxTimeout() time.Duration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var d time.Duration
+	for _, h := range r.handlers {
+		d = max(d, h.timeout)
+	}
+	return d
+}
+
+// permanentError marks an error that retrying cannot fix.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so the job goes to the dead status at once instead of
+// being retried (invalid payload, deleted target document).
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent.
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
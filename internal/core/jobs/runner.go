@@ -0,0 +1,119 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corectx "metapus/internal/core/context"
+	"metapus/internal/core/format"
+	"metapus/internal/core/tenant"
+	"metapus/pkg/logger"
+)
+
+const (
+	// maxJobsPerRun bounds the work done by a single RunDue call.
+	maxJobsPerRun = 100
+	// leaseMargin is added to the handler timeout for the worker lease, so a
+	// job is not claimed again while its timed-out attempt is being recorded.
+	leaseMargin = time.Minute
+	// maxErrorLength bounds the error text stored with a job.
+	maxErrorLength = 2000
+)
+
+// Runner executes due jobs through the handlers of a Registry. It is driven
+// by the worker; ctx must carry the tenant, pool and TxManager.
+type Runner struct {
+	repo     Repository
+	registry *Registry
+	now      func() time.Time
+}
+
+// NewRunner creates a job runner.
+func NewRunner(repo Repository, registry *Registry) *Runner {
+	return &Runner{repo: repo, registry: registry, now: time.Now}
+}
+
+// RunDue runs the due jobs of the registered types one at a time and returns
+// how many were run. Failed attempts count: they are recorded on the job.
+// Only storage errors are returned.
+func (r *Runner) RunDue(ctx context.Context) (int, error) {
+	types := r.registry.Types()
+	if len(types) == 0 {
+		return 0, nil
+	}
+	lease := r.registry.maxTimeout() + leaseMargin
+
+	processed := 0
+	for processed < maxJobsPerRun {
+		now := r.now()
+		claimed, err := r.repo.Claim(ctx, types, now, now.Add(lease), 1)
+		if err != nil {
+			return processed, err
+		}
+		if len(claimed) == 0 {
+			return processed, nil
+		}
+		if err := r.run(ctx, claimed[0]); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// run executes one claimed job and records the outcome.
+func (r *Runner) run(ctx context.Context, j *Job) error {
+	h, ok := r.registry.lookup(j.Type)
+	if !ok {
+		// Cannot happen: only registered types are claimed.
+		return r.repo.Fail(ctx, j.ID, "no handler registered for "+j.Type, nil)
+	}
+	if j.Attempts > j.MaxAttempts {
+		// The last attempt's worker died and its lease expired.
+		return r.repo.Fail(ctx, j.ID, format.Truncate("worker lease expired; "+j.LastError, maxErrorLength), nil)
+	}
+
+	err := r.execute(ctx, h, j)
+	if err == nil {
+		return r.repo.Complete(ctx, j.ID)
+	}
+	if ctx.Err() != nil {
+		// The worker is stopping: leave the job running, the expired lease
+		// makes it due again.
+		return ctx.Err()
+	}
+
+	msg := format.Truncate(err.Error(), maxErrorLength)
+	if IsPermanent(err) || j.Attempts >= j.MaxAttempts {
+		logger.Error(ctx, "job failed, moved to dead",
+			"job_id", j.ID, "job_type", j.Type, "attempt", j.Attempts, "error", err)
+		return r.repo.Fail(ctx, j.ID, msg, nil)
+	}
+
+	logger.Warn(ctx, "job failed, will retry",
+		"job_id", j.ID, "job_type", j.Type, "attempt", j.Attempts, "error", err)
+	retryAt := r.now().Add(h.backoff(j.Attempts))
+	return r.repo.Fail(ctx, j.ID, msg, &retryAt)
+}
+
+// execute runs the handler within its timeout, on behalf of the job author.
+// A panicking handler fails the attempt instead of the worker.
+func (r *Runner) execute(ctx context.Context, h *handler, j *Job) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+
+	if j.CreatedBy != nil {
+		ctx = corectx.WithUser(ctx, &corectx.UserContext{
+			UserID:   j.CreatedBy.String(),
+			TenantID: tenant.GetTenantID(ctx),
+		})
+	}
+	return h.run(ctx, j.Payload)
+}
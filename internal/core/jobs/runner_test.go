@@ -0,0 +1,188 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type fakeRepo struct {
+	Repository
+	due      []*Job
+	done     []uuid.UUID
+	failed   map[uuid.UUID]string
+	retryAt  map[uuid.UUID]*time.Time
+	claimErr error
+}
+
+func newFakeRepo(due ...*Job) *fakeRepo {
+	return &fakeRepo{due: due, failed: map[uuid.UUID]string{}, retryAt: map[uuid.UUID]*time.Time{}}
+}
+
+func (f *fakeRepo) Claim(_ context.Context, _ []string, _, _ time.Time, limit int) ([]*Job, error) {
+	if f.claimErr != nil {
+		return nil, f.claimErr
+	}
+	n := min(limit, len(f.due))
+	claimed := f.due[:n]
+	f.due = f.due[n:]
+	for _, j := range claimed {
+		j.Attempts++
+	}
+	return claimed, nil
+}
+
+func (f *fakeRepo) Complete(_ context.Context, id uuid.UUID) error {
+	f.done = append(f.done, id)
+	return nil
+}
+
+func (f *fakeRepo) Fail(_ context.Context, id uuid.UUID, lastError string, retryAt *time.Time) error {
+	f.failed[id] = lastError
+	f.retryAt[id] = retryAt
+	return nil
+}
+
+type greeting struct {
+	Name string `json:"name"`
+}
+
+var greet = Type[greeting]("test.greet")
+
+func newJob(payload string, attempts, maxAttempts int) *Job {
+	return &Job{ID: uuid.New(), Type: string(greet), Payload: []byte(payload), Status: StatusPending,
+		Attempts: attempts, MaxAttempts: maxAttempts}
+}
+
+func TestRunnerCompletesJobsWithDecodedPayload(t *testing.T) {
+	reg := NewRegistry()
+	var got []string
+	Handle(reg, greet, func(_ context.Context, p greeting) error {
+		got = append(got, p.Name)
+		return nil
+	})
+	repo := newFakeRepo(newJob(`{"name":"ann"}`, 0, 5), newJob(`{"name":"bob"}`, 0, 5))
+
+	n, err := NewRunner(repo, reg).RunDue(context.Background())
+	if err != nil {
+		t.Fatalf("RunDue: %v", err)
+	}
+	if n != 2 || len(repo.done) != 2 {
+		t.Fatalf("processed %d, done %d; want 2, 2", n, len(repo.done))
+	}
+	if len(got) != 2 || got[0] != "ann" || got[1] != "bob" {
+		t.Errorf("payloads = %v", got)
+	}
+}
+
+func TestRunnerRetriesWithBackoffThenDeadLetters(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	reg := NewRegistry()
+	Handle(reg, greet, func(context.Context, greeting) error { return errors.New("smtp down") },
+		WithBackoff(ExponentialBackoff(time.Minute, time.Hour)))
+
+	retry := newJob(`{}`, 1, 3) // second attempt
+	last := newJob(`{}`, 2, 3)  // third and last attempt
+	repo := newFakeRepo(retry, last)
+	runner := NewRunner(repo, reg)
+	runner.now = func() time.Time { return now }
+
+	if _, err := runner.RunDue(context.Background()); err != nil {
+		t.Fatalf("RunDue: %v", err)
+	}
+	if repo.failed[retry.ID] != "smtp down" {
+		t.Errorf("retry error = %q", repo.failed[retry.ID])
+	}
+	if at := repo.retryAt[retry.ID]; at == nil || !at.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("retry at = %v, want %v", at, now.Add(2*time.Minute))
+	}
+	if _, ok := repo.failed[last.ID]; !ok || repo.retryAt[last.ID] != nil {
+		t.Errorf("last attempt should go dead, retryAt = %v", repo.retryAt[last.ID])
+	}
+}
+
+func TestRunnerDeadLettersPermanentAndUndecodableJobs(t *testing.T) {
+	reg := NewRegistry()
+	Handle(reg, greet, func(_ context.Context, p greeting) error {
+		if p.Name == "gone" {
+			return Permanent(errors.New("document deleted"))
+		}
+		return nil
+	})
+	permanent := newJob(`{"name":"gone"}`, 0, 5)
+	broken := newJob(`[1,2]`, 0, 5)
+	repo := newFakeRepo(permanent, broken)
+
+	if _, err := NewRunner(repo, reg).RunDue(context.Background()); err != nil {
+		t.Fatalf("RunDue: %v", err)
+	}
+	for _, j := range []*Job{permanent, broken} {
+		if _, ok := repo.failed[j.ID]; !ok || repo.retryAt[j.ID] != nil {
+			t.Errorf("job %s: failed=%v retryAt=%v, want dead", j.Payload, ok, repo.retryAt[j.ID])
+		}
+	}
+}
+
+func TestRunnerRecoversHandlerPanic(t *testing.T) {
+	reg := NewRegistry()
+	Handle(reg, greet, func(context.Context, greeting) error { panic("boom") })
+	j := newJob(`{}`, 0, 5)
+	repo := newFakeRepo(j)
+
+	if _, err := NewRunner(repo, reg).RunDue(context.Background()); err != nil {
+		t.Fatalf("RunDue: %v", err)
+	}
+	if repo.failed[j.ID] != "panic: boom" || repo.retryAt[j.ID] == nil {
+		t.Errorf("failed = %q, retryAt = %v; want retried panic", repo.failed[j.ID], repo.retryAt[j.ID])
+	}
+}
+
+func TestRunnerDeadLettersExpiredLastAttempt(t *testing.T) {
+	reg := NewRegistry()
+	called := false
+	Handle(reg, greet, func(context.Context, greeting) error { called = true; return nil })
+	j := newJob(`{}`, 3, 3) // lease of the last attempt expired; Claim counts a fourth
+	repo := newFakeRepo(j)
+
+	if _, err := NewRunner(repo, reg).RunDue(context.Background()); err != nil {
+		t.Fatalf("RunDue: %v", err)
+	}
+	if called {
+		t.Error("handler ran beyond MaxAttempts")
+	}
+	if _, ok := repo.failed[j.ID]; !ok || repo.retryAt[j.ID] != nil {
+		t.Error("job should go dead")
+	}
+}
+
+func TestRunnerSkipsClaimWithoutHandlers(t *testing.T) {
+	repo := newFakeRepo()
+	repo.claimErr = errors.New("must not claim")
+	if n, err := NewRunner(repo, NewRegistry()).RunDue(context.Background()); n != 0 || err != nil {
+		t.Errorf("RunDue = %d, %v", n, err)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := ExponentialBackoff(30*time.Second, 3*time.Minute)
+	want := []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute}
+	for i, w := range want {
+		if got := b(i + 1); got != w {
+			t.Errorf("attempt %d: %s, want %s", i+1, got, w)
+		}
+	}
+}
+
+func TestHandleRejectsDuplicateType(t *testing.T) {
+	reg := NewRegistry()
+	Handle(reg, greet, func(context.Context, greeting) error { return nil })
+	defer func() {
+		if recover() == nil {
+			t.Error("second Handle did not panic")
+		}
+	}()
+	Handle(reg, greet, func(context.Context, greeting) error { return nil })
+}
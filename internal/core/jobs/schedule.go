@@ -0,0 +1,188 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+
+	"metapus/internal/core/apperror"
+)
+
+// MisfirePolicy defines what a schedule does with occurrences the worker
+// missed (it was down or the tenant was paused).
+type MisfirePolicy string
+
+const (
+	// MisfireRunOnce enqueues a single job for the latest missed occurrence.
+	MisfireRunOnce MisfirePolicy = "run_once"
+	// MisfireCatchUp enqueues a job for every missed occurrence, oldest
+	// first, up to maxCatchUp of the most recent ones.
+	MisfireCatchUp MisfirePolicy = "catch_up"
+	// MisfireSkip drops missed occurrences: a job is enqueued only for an
+	// occurrence at most misfireGrace old.
+	MisfireSkip MisfirePolicy = "skip"
+)
+
+const (
+	// maxCatchUp caps the jobs a catch_up schedule enqueues in one go.
+	maxCatchUp = 100
+	// maxOccurrenceScan caps the occurrences walked after a long outage of
+	// a frequent schedule.
+	maxOccurrenceScan = 10000
+	// misfireGrace is how late an occurrence may be and still count as on
+	// time (the worker checks schedules every few seconds).
+	misfireGrace = time.Minute
+)
+
+// cronParser accepts the same 6-field format as scheduled automation rules
+// and recurring documents (sec min hour dom month dow) plus descriptors
+// such as @daily.
+var cronParser = cron.NewParser(
+	cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+// jobTypePattern is the shape of job type names, e.g. reports.generate.
+var jobTypePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)*$`)
+
+// Schedule enqueues a job of JobType with Params on a CRON schedule, on
+// behalf of its author.
+type Schedule struct {
+	ID            uuid.UUID       `json:"id" db:"id"`
+	Name          string          `json:"name" db:"name"`
+	JobType       string          `json:"jobType" db:"job_type"`
+	Params        json.RawMessage `json:"params" db:"params"` // payload of every job
+	CronExpr      string          `json:"cronExpr" db:"cron_expr"`
+	Timezone      string          `json:"timezone" db:"timezone"`
+	MisfirePolicy MisfirePolicy   `json:"misfirePolicy" db:"misfire_policy"`
+	MaxAttempts   int             `json:"maxAttempts" db:"max_attempts"`
+	Active        bool            `json:"active" db:"active"`
+	NextRunAt     *time.Time      `json:"nextRunAt" db:"next_run_at"`
+	LastRunAt     *time.Time      `json:"lastRunAt" db:"last_run_at"`
+	AuthorID      *uuid.UUID      `json:"authorId" db:"author_id"`
+	Version       int             `json:"version" db:"version"`
+	CreatedAt     time.Time       `json:"createdAt" db:"created_at"`
+	UpdatedAt     time.Time       `json:"updatedAt" db:"updated_at"`
+}
+
+// Validate checks basic integrity of the schedule. Pure function, no DB calls.
+func (s *Schedule) Validate(_ context.Context) error {
+	if s.Name == "" {
+		return apperror.NewValidation("validation failed").WithDetail("name", "required")
+	}
+	if !jobTypePattern.MatchString(s.JobType) {
+		return apperror.NewValidation("validation failed").WithDetail("jobType", "must look like module.action")
+	}
+	if _, err := s.cronSchedule(); err != nil {
+		return apperror.NewValidation("validation failed").WithDetail("cronExpr", err.Error())
+	}
+	switch s.MisfirePolicy {
+	case MisfireRunOnce, MisfireCatchUp, MisfireSkip:
+	default:
+		return apperror.NewValidation("validation failed").WithDetail("misfirePolicy", "must be run_once, catch_up or skip")
+	}
+	if s.MaxAttempts <= 0 {
+		return apperror.NewValidation("validation failed").WithDetail("maxAttempts", "must be positive")
+	}
+	if len(s.Params) > 0 && !json.Valid(s.Params) {
+		return apperror.NewValidation("validation failed").WithDetail("params", "must be valid JSON")
+	}
+	return nil
+}
+
+// cronSchedule parses the CRON expression in the schedule's time zone.
+func (s *Schedule) cronSchedule() (cron.Schedule, error) {
+	if s.CronExpr == "" {
+		return nil, fmt.Errorf("required")
+	}
+	tz := s.Timezone
+	if tz == "" {
+		tz = "UTC"
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", tz)
+	}
+	return cronParser.Parse("CRON_TZ=" + tz + " " + s.CronExpr)
+}
+
+// Next returns the first occurrence strictly after t.
+func (s *Schedule) Next(t time.Time) (time.Time, error) {
+	sched, err := s.cronSchedule()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return sched.Next(t), nil
+}
+
+// Due returns the occurrences to enqueue at now under the misfire policy,
+// oldest first, and the first occurrence after now. runs is empty if the
+// schedule is not due yet or the policy skipped every missed occurrence.
+func (s *Schedule) Due(now time.Time) (runs []time.Time, next time.Time, err error) {
+	sched, err := s.cronSchedule()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if s.NextRunAt == nil || s.NextRunAt.After(now) {
+		return nil, sched.Next(now), nil
+	}
+
+	// Walk the due occurrences, keeping only the most recent maxCatchUp.
+	var due []time.Time
+	next = *s.NextRunAt
+	for scanned := 0; !next.After(now); scanned++ {
+		if scanned == maxOccurrenceScan {
+			// Long outage of a frequent schedule: stop walking.
+			next = sched.Next(now)
+			break
+		}
+		due = append(due, next)
+		if len(due) > maxCatchUp {
+			due = due[1:]
+		}
+		next = sched.Next(next)
+	}
+
+	latest := due[len(due)-1]
+	switch s.MisfirePolicy {
+	case MisfireCatchUp:
+		return due, next, nil
+	case MisfireSkip:
+		if now.Sub(latest) > misfireGrace {
+			return nil, next, nil
+		}
+		return []time.Time{latest}, next, nil
+	default:
+		return []time.Time{latest}, next, nil
+	}
+}
+
+// ScheduleRepository stores the job schedules of the tenant in ctx.
+type ScheduleRepository interface {
+	// Create inserts a new schedule.
+	Create(ctx context.Context, s *Schedule) error
+
+	// Update modifies a schedule. Uses optimistic locking (version).
+	Update(ctx context.Context, s *Schedule) error
+
+	// Delete removes a schedule by ID.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// GetByID returns a single schedule.
+	GetByID(ctx context.Context, id uuid.UUID) (*Schedule, error)
+
+	// List returns all schedules.
+	List(ctx context.Context) ([]*Schedule, error)
+
+	// ClaimDue locks one active schedule with next_run_at <= now until
+	// leaseUntil, so that concurrent workers do not enqueue it twice.
+	// Returns nil if nothing is due.
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time) (*Schedule, error)
+
+	// Advance stores next_run_at, last_run_at and active of a claimed
+	// schedule and releases its lease.
+	Advance(ctx context.Context, s *Schedule) error
+}
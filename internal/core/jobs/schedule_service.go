@@ -0,0 +1,111 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/core/apperror"
+	corectx "metapus/internal/core/context"
+	"metapus/internal/core/id"
+)
+
+// ScheduleService manages job schedules.
+type ScheduleService struct {
+	repo  ScheduleRepository
+	queue *Queue
+	now   func() time.Time
+}
+
+// NewScheduleService creates a job schedule service.
+func NewScheduleService(repo ScheduleRepository, queue *Queue) *ScheduleService {
+	return &ScheduleService{repo: repo, queue: queue, now: time.Now}
+}
+
+// Create saves a new schedule; the current user becomes its author.
+func (s *ScheduleService) Create(ctx context.Context, sch *Schedule) error {
+	if user := corectx.GetUser(ctx); user != nil {
+		if userID, err := uuid.Parse(user.UserID); err == nil {
+			sch.AuthorID = &userID
+		}
+	}
+	if sch.ID == uuid.Nil {
+		sch.ID = id.New()
+	}
+	if err := s.prepare(ctx, sch); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, sch)
+}
+
+// Update changes a schedule. Changing the timing re-plans the next run from
+// now.
+func (s *ScheduleService) Update(ctx context.Context, sch *Schedule) error {
+	existing, err := s.repo.GetByID(ctx, sch.ID)
+	if err != nil {
+		return err
+	}
+	sch.AuthorID = existing.AuthorID
+	sch.LastRunAt = existing.LastRunAt
+
+	if err := s.prepare(ctx, sch); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, sch)
+}
+
+// Delete removes a schedule. Jobs it already enqueued stay in the queue.
+func (s *ScheduleService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// Get returns a schedule.
+func (s *ScheduleService) Get(ctx context.Context, id uuid.UUID) (*Schedule, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// List returns all schedules.
+func (s *ScheduleService) List(ctx context.Context) ([]*Schedule, error) {
+	return s.repo.List(ctx)
+}
+
+// RunNow enqueues a job of the schedule at once, outside its timing, and
+// returns the job ID. The next planned run is not affected.
+func (s *ScheduleService) RunNow(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	sch, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return Type[json.RawMessage](sch.JobType).Enqueue(ctx, s.queue, sch.Params, MaxAttempts(sch.MaxAttempts))
+}
+
+// prepare fills defaults, validates the schedule and plans the next run.
+func (s *ScheduleService) prepare(ctx context.Context, sch *Schedule) error {
+	if sch.Timezone == "" {
+		sch.Timezone = "UTC"
+	}
+	if sch.MisfirePolicy == "" {
+		sch.MisfirePolicy = MisfireRunOnce
+	}
+	if sch.MaxAttempts == 0 {
+		sch.MaxAttempts = DefaultMaxAttempts
+	}
+	if len(sch.Params) == 0 || string(sch.Params) == "null" {
+		sch.Params = json.RawMessage("{}")
+	}
+	if err := sch.Validate(ctx); err != nil {
+		return err
+	}
+
+	sch.NextRunAt = nil
+	if sch.Active {
+		next, err := sch.Next(s.now())
+		if err != nil {
+			return apperror.NewValidation("validation failed").WithDetail("cronExpr", err.Error())
+		}
+		sch.NextRunAt = &next
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// hourlySchedule is due at the top of every hour; NextRunAt is 09:00, so at
+// 12:00:30 four occurrences (09, 10, 11 and 12 o'clock) are due.
+func hourlySchedule(policy MisfirePolicy) *Schedule {
+	next := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	return &Schedule{
+		ID: uuid.New(), Name: "Hourly sync", JobType: "test.sync", CronExpr: "0 0 * * * *",
+		Timezone: "UTC", MisfirePolicy: policy, MaxAttempts: 3, Active: true, NextRunAt: &next,
+	}
+}
+
+var noonish = time.Date(2026, 5, 1, 12, 0, 30, 0, time.UTC)
+
+func TestScheduleDueMisfirePolicies(t *testing.T) {
+	noon := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	wantNext := time.Date(2026, 5, 1, 13, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		policy MisfirePolicy
+		now    time.Time
+		want   int
+	}{
+		{MisfireRunOnce, noonish, 1},
+		{MisfireCatchUp, noonish, 4},
+		{MisfireSkip, noonish, 1},                      // 12:00 is within the grace period
+		{MisfireSkip, noonish.Add(5 * time.Minute), 0}, // 12:00 is a misfire
+	}
+	for _, tt := range tests {
+		runs, next, err := hourlySchedule(tt.policy).Due(tt.now)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.policy, err)
+		}
+		if len(runs) != tt.want {
+			t.Errorf("%s at %s: %d runs, want %d", tt.policy, tt.now.Format(time.TimeOnly), len(runs), tt.want)
+		}
+		if !next.Equal(wantNext) {
+			t.Errorf("%s: next = %s, want %s", tt.policy, next, wantNext)
+		}
+		if len(runs) > 0 && !runs[len(runs)-1].Equal(noon) {
+			t.Errorf("%s: latest run = %s, want %s", tt.policy, runs[len(runs)-1], noon)
+		}
+	}
+}
+
+func TestScheduleDueCatchUpIsCapped(t *testing.T) {
+	sch := hourlySchedule(MisfireCatchUp)
+	runs, _, err := sch.Due(noonish.Add(30 * 24 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != maxCatchUp {
+		t.Fatalf("%d runs, want %d", len(runs), maxCatchUp)
+	}
+	if !runs[0].Before(runs[len(runs)-1]) {
+		t.Error("runs are not oldest first")
+	}
+}
+
+func TestScheduleNotDueYet(t *testing.T) {
+	runs, next, err := hourlySchedule(MisfireRunOnce).Due(time.Date(2026, 5, 1, 8, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 0 || next.Hour() != 9 {
+		t.Errorf("runs = %v, next = %s", runs, next)
+	}
+}
+
+func TestScheduleValidate(t *testing.T) {
+	ctx := context.Background()
+	if err := hourlySchedule(MisfireRunOnce).Validate(ctx); err != nil {
+		t.Fatalf("valid schedule: %v", err)
+	}
+	invalid := map[string]func(*Schedule){
+		"job type":  func(s *Schedule) { s.JobType = "Reports Generate" },
+		"cron":      func(s *Schedule) { s.CronExpr = "every hour" },
+		"time zone": func(s *Schedule) { s.Timezone = "Mars/Olympus" },
+		"misfire":   func(s *Schedule) { s.MisfirePolicy = "later" },
+		"params":    func(s *Schedule) { s.Params = []byte("{") },
+	}
+	for name, mutate := range invalid {
+		sch := hourlySchedule(MisfireRunOnce)
+		mutate(sch)
+		if err := sch.Validate(ctx); err == nil {
+			t.Errorf("%s: invalid schedule accepted", name)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	corectx "metapus/internal/core/context"
+	"metapus/internal/core/tenant"
+	"metapus/internal/core/tx"
+	"metapus/pkg/logger"
+)
+
+const (
+	// scheduleLease is how long a claimed schedule stays locked for other
+	// workers while its jobs are enqueued.
+	scheduleLease = time.Minute
+	// maxSchedulesPerRun bounds the work done by a single Scheduler.RunDue call.
+	maxSchedulesPerRun = 100
+)
+
+// Scheduler enqueues the jobs of due schedules. It is driven by the worker;
+// ctx must carry the tenant, pool and TxManager.
+type Scheduler struct {
+	repo  ScheduleRepository
+	queue *Queue
+	now   func() time.Time
+}
+
+// NewScheduler creates a schedule scheduler.
+func NewScheduler(repo ScheduleRepository, queue *Queue) *Scheduler {
+	return &Scheduler{repo: repo, queue: queue, now: time.Now}
+}
+
+// RunDue enqueues the jobs of all due schedules and returns how many jobs
+// were enqueued.
+func (s *Scheduler) RunDue(ctx context.Context) (int, error) {
+	txm, err := tenant.GetTxManager(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	enqueued := 0
+	for range maxSchedulesPerRun {
+		now := s.now()
+		sch, err := s.repo.ClaimDue(ctx, now, now.Add(scheduleLease))
+		if err != nil {
+			return enqueued, err
+		}
+		if sch == nil {
+			return enqueued, nil
+		}
+		n, err := s.fire(ctx, txm, sch, now)
+		if err != nil {
+			return enqueued, err
+		}
+		enqueued += n
+	}
+	return enqueued, nil
+}
+
+// fire enqueues the due occurrences of a claimed schedule and advances it,
+// in one transaction: an occurrence is enqueued exactly once.
+func (s *Scheduler) fire(ctx context.Context, txm tx.Manager, sch *Schedule, now time.Time) (int, error) {
+	runs, next, err := sch.Due(now)
+	if err != nil {
+		// Cannot happen for a validated schedule; stop it rather than retry forever.
+		logger.Error(ctx, "jobs: invalid schedule, deactivating", "schedule_id", sch.ID, "error", err)
+		sch.Active = false
+		sch.NextRunAt = nil
+		return 0, s.repo.Advance(ctx, sch)
+	}
+	sch.NextRunAt = &next
+	if len(runs) == 0 {
+		logger.Info(ctx, "jobs: missed occurrences skipped", "schedule_id", sch.ID, "job_type", sch.JobType)
+		return 0, s.repo.Advance(ctx, sch)
+	}
+
+	params := sch.Params
+	if len(params) == 0 {
+		params = json.RawMessage("{}")
+	}
+	if sch.AuthorID != nil {
+		ctx = corectx.WithUser(ctx, &corectx.UserContext{
+			UserID:   sch.AuthorID.String(),
+			TenantID: tenant.GetTenantID(ctx),
+		})
+	}
+
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		jobType := Type[json.RawMessage](sch.JobType)
+		for _, at := range runs {
+			if _, err := jobType.Enqueue(ctx, s.queue, params, RunAt(at), MaxAttempts(sch.MaxAttempts)); err != nil {
+				return err
+			}
+		}
+		sch.LastRunAt = &now
+		return s.repo.Advance(ctx, sch)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(runs), nil
+}
@@ -0,0 +1,103 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	corectx "metapus/internal/core/context"
+	"metapus/internal/core/tenant"
+)
+
+type passTxManager struct{}
+
+func (passTxManager) RunInTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeScheduleRepo struct {
+	ScheduleRepository
+	due      *Schedule
+	advanced []*Schedule
+}
+
+func (f *fakeScheduleRepo) ClaimDue(_ context.Context, _, _ time.Time) (*Schedule, error) {
+	s := f.due
+	f.due = nil
+	return s, nil
+}
+
+func (f *fakeScheduleRepo) Advance(_ context.Context, s *Schedule) error {
+	f.advanced = append(f.advanced, s)
+	return nil
+}
+
+type recordingJobRepo struct {
+	Repository
+	inserted []*Job
+	users    []string
+}
+
+func (r *recordingJobRepo) Insert(ctx context.Context, j *Job) error {
+	r.inserted = append(r.inserted, j)
+	if u := corectx.GetUser(ctx); u != nil {
+		r.users = append(r.users, u.UserID)
+	}
+	return nil
+}
+
+func TestSchedulerEnqueuesCatchUpJobsAndAdvances(t *testing.T) {
+	author := uuid.New()
+	sch := hourlySchedule(MisfireCatchUp)
+	sch.AuthorID = &author
+	sch.Params = []byte(`{"warehouse":"main"}`)
+
+	jobRepo := &recordingJobRepo{}
+	schedules := &fakeScheduleRepo{due: sch}
+	scheduler := NewScheduler(schedules, NewQueue(jobRepo))
+	scheduler.now = func() time.Time { return noonish }
+
+	ctx := tenant.WithTxManager(context.Background(), passTxManager{})
+	n, err := scheduler.RunDue(ctx)
+	if err != nil {
+		t.Fatalf("RunDue: %v", err)
+	}
+	if n != 4 || len(jobRepo.inserted) != 4 {
+		t.Fatalf("enqueued %d (%d inserted), want 4", n, len(jobRepo.inserted))
+	}
+	first := jobRepo.inserted[0]
+	if first.Type != "test.sync" || string(first.Payload) != `{"warehouse":"main"}` || first.MaxAttempts != 3 {
+		t.Errorf("job = %+v", first)
+	}
+	if want := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC); !first.RunAt.Equal(want) {
+		t.Errorf("first run at %s, want %s", first.RunAt, want)
+	}
+	if first.CreatedBy == nil || *first.CreatedBy != author {
+		t.Errorf("created by = %v, want schedule author", first.CreatedBy)
+	}
+
+	if len(schedules.advanced) != 1 {
+		t.Fatalf("advanced %d times", len(schedules.advanced))
+	}
+	adv := schedules.advanced[0]
+	if adv.NextRunAt == nil || adv.NextRunAt.Hour() != 13 || adv.LastRunAt == nil {
+		t.Errorf("next = %v, last = %v", adv.NextRunAt, adv.LastRunAt)
+	}
+}
+
+func TestSchedulerAdvancesSkippedMisfires(t *testing.T) {
+	jobRepo := &recordingJobRepo{}
+	schedules := &fakeScheduleRepo{due: hourlySchedule(MisfireSkip)}
+	scheduler := NewScheduler(schedules, NewQueue(jobRepo))
+	scheduler.now = func() time.Time { return noonish.Add(10 * time.Minute) }
+
+	n, err := scheduler.RunDue(tenant.WithTxManager(context.Background(), passTxManager{}))
+	if err != nil || n != 0 || len(jobRepo.inserted) != 0 {
+		t.Fatalf("RunDue = %d, %v; inserted %d", n, err, len(jobRepo.inserted))
+	}
+	if len(schedules.advanced) != 1 || schedules.advanced[0].LastRunAt != nil {
+		t.Error("skipped schedule should advance without a run")
+	}
+}
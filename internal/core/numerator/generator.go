@@ -21,4 +21,8 @@ type Generator interface {
 
 	// SetNextNumber sets the next number value (for migration purposes).
 	SetNextNumber(ctx context.Context, cfg Config, period time.Time, value int64) error
+
+	// PeekNextNumbers returns up to count upcoming numbers without consuming
+	// the sequence. Used to suggest a free number for manual entry.
+	PeekNextNumbers(ctx context.Context, cfg Config, period time.Time, count int) ([]string, error)
 }
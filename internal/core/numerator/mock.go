@@ -9,8 +9,9 @@ import (
 // MockGenerator is a test implementation of Generator.
 // Use in unit tests to avoid database dependencies.
 type MockGenerator struct {
-	GetNextNumberFunc func(ctx context.Context, cfg Config, opts *Options, period time.Time) (string, error)
-	SetNextNumberFunc func(ctx context.Context, cfg Config, period time.Time, value int64) error
+	GetNextNumberFunc   func(ctx context.Context, cfg Config, opts *Options, period time.Time) (string, error)
+	SetNextNumberFunc   func(ctx context.Context, cfg Config, period time.Time, value int64) error
+	PeekNextNumbersFunc func(ctx context.Context, cfg Config, period time.Time, count int) ([]string, error)
 }
 
 // GetNextNumber implements Generator.
@@ -30,5 +31,13 @@ func (m *MockGenerator) SetNextNumber(ctx context.Context, cfg Config, period ti
 	return nil
 }
 
+// PeekNextNumbers implements Generator.
+func (m *MockGenerator) PeekNextNumbers(ctx context.Context, cfg Config, period time.Time, count int) ([]string, error) {
+	if m.PeekNextNumbersFunc != nil {
+		return m.PeekNextNumbersFunc(ctx, cfg, period, count)
+	}
+	return []string{"MOCK-2026-00001"}, nil
+}
+
 // Ensure compile-time interface compliance.
 var _ Generator = (*MockGenerator)(nil)
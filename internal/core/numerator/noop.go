@@ -22,3 +22,7 @@ func (noopGenerator) GetNextNumber(_ context.Context, _ Config, _ *Options, _ ti
 func (noopGenerator) SetNextNumber(_ context.Context, _ Config, _ time.Time, _ int64) error {
 	panic("numerator.Noop: SetNextNumber called — this indicates a bug")
 }
+
+func (noopGenerator) PeekNextNumbers(_ context.Context, _ Config, _ time.Time, _ int) ([]string, error) {
+	panic("numerator.Noop: PeekNextNumbers called — this indicates a bug")
+}
@@ -7,17 +7,20 @@ import (
 )
 
 // CheckRLSAccess verifies that the current DataScope allows access to the entity.
-// If the entity implements RLSDimensionable, its dimensions are checked against the scope.
+// If the entity implements RLSDimensionable, its dimensions are checked against the scope;
+// otherwise it is only accessible when no scoped grant restricts the entity.
 // Returns nil if access is allowed, apperror.Forbidden otherwise.
 func CheckRLSAccess(ctx context.Context, entityName string, entity any) error {
 	scope := GetDataScope(ctx)
 	if scope == nil || scope.IsAdmin {
 		return nil
 	}
+	var dimensions map[string]string
 	if dimensionable, ok := entity.(RLSDimensionable); ok {
-		if !scope.CanAccessRecord(entityName, dimensionable.GetRLSDimensions()) {
-			return apperror.NewForbidden("access denied by row-level security")
-		}
+		dimensions = dimensionable.GetRLSDimensions()
+	}
+	if !scope.CanAccessRecord(entityName, dimensions) {
+		return apperror.NewForbidden("access denied by row-level security")
 	}
 	return nil
 }
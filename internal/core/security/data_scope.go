@@ -44,6 +44,11 @@ type DataScope struct {
 
 	// ReadOnly prevents any mutations (create/update/delete/post/unpost).
 	ReadOnly bool
+
+	// GrantDimensions lists, per entity name, the dimensions of scoped permission
+	// grants the request relies on (see Grant). Unlike profile dimensions, a
+	// record without a value for such a dimension is out of scope.
+	GrantDimensions map[string][]string
 }
 
 // Standard dimension names used across the system.
@@ -84,6 +89,68 @@ func (ds *DataScope) SetDimension(name string, ids []string) {
 	ds.Dimensions[name] = ids
 }
 
+// Grant returns a copy of the scope narrowed to a scoped permission grant
+// on entityName (e.g. "goods_issue" limited to {"warehouse": [...]}).
+// Each granted dimension is restricted to the granted IDs, within those the
+// scope already allows. Other entities are not affected.
+func (ds *DataScope) Grant(entityName string, dimensions map[string][]string) *DataScope {
+	if ds == nil {
+		ds = &DataScope{}
+	}
+	if ds.IsAdmin || len(dimensions) == 0 {
+		return ds
+	}
+	effective := ds.EffectiveDimensions(entityName)
+	entityDims := maps.Clone(ds.EntityDimensions[entityName])
+	if entityDims == nil {
+		entityDims = make(map[string][]string, len(dimensions))
+	}
+	required := slices.Clone(ds.GrantDimensions[entityName])
+	for dimName, granted := range dimensions {
+		allowed := granted
+		if current, restricted := effective[dimName]; restricted {
+			allowed = make([]string, 0, len(granted))
+			for _, v := range granted {
+				if slices.Contains(current, v) {
+					allowed = append(allowed, v)
+				}
+			}
+		}
+		entityDims[dimName] = allowed
+		if !slices.Contains(required, dimName) {
+			required = append(required, dimName)
+		}
+	}
+
+	narrowed := *ds
+	narrowed.EntityDimensions = maps.Clone(ds.EntityDimensions)
+	if narrowed.EntityDimensions == nil {
+		narrowed.EntityDimensions = make(map[string]map[string][]string, 1)
+	}
+	narrowed.EntityDimensions[entityName] = entityDims
+	narrowed.GrantDimensions = maps.Clone(ds.GrantDimensions)
+	if narrowed.GrantDimensions == nil {
+		narrowed.GrantDimensions = make(map[string][]string, 1)
+	}
+	narrowed.GrantDimensions[entityName] = required
+	return &narrowed
+}
+
+// GrantsCovered reports whether an entity with the given dimension columns
+// carries every dimension a scoped grant restricts it to. An entity that
+// does not is out of the grant: none of its rows are accessible.
+func (ds *DataScope) GrantsCovered(entityName string, dimColumns map[string]string) bool {
+	if ds == nil || ds.IsAdmin {
+		return true
+	}
+	for _, dimName := range ds.GrantDimensions[entityName] {
+		if _, ok := dimColumns[dimName]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // ApplyConditions returns squirrel WHERE conditions for RLS filtering.
 //
 // entityName identifies the current entity (e.g., "goods_receipt").
@@ -102,7 +169,13 @@ func (ds *DataScope) SetDimension(name string, ids []string) {
 //
 //	WHERE organization_id IN ('org-1','org-2')
 func (ds *DataScope) ApplyConditions(entityName string, dimColumns map[string]string) []squirrel.Sqlizer {
-	if ds == nil || ds.IsAdmin || len(dimColumns) == 0 {
+	if ds == nil || ds.IsAdmin {
+		return nil
+	}
+	if !ds.GrantsCovered(entityName, dimColumns) {
+		return []squirrel.Sqlizer{squirrel.Expr("FALSE")}
+	}
+	if len(dimColumns) == 0 {
 		return nil
 	}
 
@@ -173,6 +246,13 @@ func (ds *DataScope) CanAccessRecord(entityName string, recordDimensions map[str
 		return true
 	}
 
+	for _, dimName := range ds.GrantDimensions[entityName] {
+		if recordDimensions[dimName] == "" {
+			// Scoped grant on a dimension the record lacks — deny
+			return false
+		}
+	}
+
 	effective := ds.EffectiveDimensions(entityName)
 
 	for dimName, recordValue := range recordDimensions {
@@ -223,3 +223,44 @@ func TestDataScope_SetDimension(t *testing.T) {
 	scope.SetDimension("organization", []string{"org-new"})
 	assert.Equal(t, []string{"org-new"}, scope.Dimensions["organization"])
 }
+
+func TestDataScope_Grant(t *testing.T) {
+	base := &DataScope{Dimensions: map[string][]string{
+		"warehouse": {"wh-1", "wh-2"},
+	}}
+	scope := base.Grant("goods_issue", map[string][]string{"warehouse": {"wh-2", "wh-3"}})
+
+	t.Run("narrows the granted entity within the profile scope", func(t *testing.T) {
+		assert.Equal(t, []string{"wh-2"}, scope.EffectiveDimensions("goods_issue")["warehouse"])
+		assert.True(t, scope.CanAccessRecord("goods_issue", map[string]string{"warehouse": "wh-2"}))
+		assert.False(t, scope.CanAccessRecord("goods_issue", map[string]string{"warehouse": "wh-1"}))
+	})
+
+	t.Run("other entities and the original scope are unaffected", func(t *testing.T) {
+		assert.True(t, scope.CanAccessRecord("goods_receipt", map[string]string{"warehouse": "wh-1"}))
+		assert.True(t, scope.CanAccessRecord("counterparty", nil))
+		assert.Nil(t, base.EntityDimensions)
+		assert.Nil(t, base.GrantDimensions)
+	})
+
+	t.Run("records without the granted dimension are out of the grant", func(t *testing.T) {
+		assert.False(t, scope.CanAccessRecord("goods_issue", map[string]string{"organization": "org-1"}))
+		assert.False(t, scope.GrantsCovered("goods_issue", dimCols("organization", "organization_id")))
+		conditions := scope.ApplyConditions("goods_issue", dimCols("organization", "organization_id"))
+		assert.Len(t, conditions, 1) // impossible WHERE condition
+	})
+
+	t.Run("list conditions use the granted values", func(t *testing.T) {
+		conditions := scope.ApplyConditions("goods_issue", dimCols("warehouse", "warehouse_id"))
+		assert.Len(t, conditions, 1)
+		sql, args, err := conditions[0].ToSql()
+		assert.NoError(t, err)
+		assert.Equal(t, "warehouse_id IN (?)", sql)
+		assert.Equal(t, []any{"wh-2"}, args)
+	})
+
+	t.Run("admin is not narrowed", func(t *testing.T) {
+		admin := (&DataScope{IsAdmin: true}).Grant("goods_issue", map[string][]string{"warehouse": {"wh-3"}})
+		assert.True(t, admin.CanAccessRecord("goods_issue", map[string]string{"warehouse": "wh-1"}))
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 
 	"metapus/internal/core/apperror"
 	appctx "metapus/internal/core/context"
@@ -54,6 +55,10 @@ type AccessScope struct {
 
 	// Permissions available to user
 	Permissions map[string][]Permission
+
+	// Grants holds permissions granted within a scope only:
+	// entity -> permission -> dimension -> allowed IDs.
+	Grants map[string]map[Permission]map[string][]string
 }
 
 // NewAccessScope creates AccessScope from context.
@@ -70,6 +75,52 @@ func NewAccessScope(ctx context.Context) *AccessScope {
 	}
 }
 
+// NewUserAccessScope creates the AccessScope of an authenticated user,
+// including the entity permissions of its permission codes
+// ("document:goods_issue:post"). Codes of another form are skipped.
+func NewUserAccessScope(user *appctx.UserContext) *AccessScope {
+	s := &AccessScope{
+		TenantID:    user.TenantID,
+		UserID:      user.UserID,
+		IsAdmin:     user.IsAdmin,
+		Permissions: make(map[string][]Permission),
+		Grants:      make(map[string]map[Permission]map[string][]string),
+	}
+	for _, code := range user.Permissions {
+		if entity, perm, ok := ParsePermissionCode(code); ok {
+			s.Permissions[entity] = append(s.Permissions[entity], perm)
+		}
+	}
+	for code, dims := range user.PermissionScopes {
+		entity, perm, ok := ParsePermissionCode(code)
+		if !ok {
+			continue
+		}
+		if s.Grants[entity] == nil {
+			s.Grants[entity] = make(map[Permission]map[string][]string)
+		}
+		s.Grants[entity][perm] = dims
+	}
+	return s
+}
+
+// ParsePermissionCode splits an entity permission code
+// ("catalog:warehouse:read", "document:goods_issue:post") into the entity
+// and the permission.
+func ParsePermissionCode(code string) (entity string, perm Permission, ok bool) {
+	parts := strings.Split(code, ":")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[1], Permission(parts[2]), true
+}
+
+// DocumentPermission returns the permission code of perm on a document
+// type, e.g. "document:goods_issue:post".
+func DocumentPermission(entity string, perm Permission) string {
+	return "document:" + entity + ":" + string(perm)
+}
+
 // HasPermission checks if user has permission on entity.
 func (s *AccessScope) HasPermission(entity string, perm Permission) bool {
 	if s.IsAdmin {
@@ -102,6 +153,22 @@ func WithScope(ctx context.Context, scope *AccessScope) context.Context {
 	return context.WithValue(ctx, scopeKey{}, scope)
 }
 
+// Authorize checks perm on entity against the AccessScope stored in ctx by
+// the auth middleware. A permission granted within a scope only passes too,
+// with the DataScope of the returned context narrowed to the grant. Contexts
+// without a stored AccessScope (background jobs, the merchant API) were
+// authorized by their callers and pass unchanged.
+func Authorize(ctx context.Context, entity string, perm Permission) (context.Context, error) {
+	s, ok := ctx.Value(scopeKey{}).(*AccessScope)
+	if !ok || s.HasPermission(entity, perm) {
+		return ctx, nil
+	}
+	if dims, ok := s.Grants[entity][perm]; ok {
+		return WithDataScope(ctx, GetDataScope(ctx).Grant(entity, dims)), nil
+	}
+	return ctx, s.RequirePermission(entity, perm)
+}
+
 // GetScope returns AccessScope from context.
 func GetScope(ctx context.Context) *AccessScope {
 	if v, ok := ctx.Value(scopeKey{}).(*AccessScope); ok {
@@ -0,0 +1,51 @@
+package security
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appctx "metapus/internal/core/context"
+)
+
+func TestAuthorize(t *testing.T) {
+	clerk := &appctx.UserContext{
+		UserID:      "u-1",
+		Permissions: []string{"document:goods_issue:create", "document:goods_issue:update", "goods_issue.read"},
+		PermissionScopes: map[string]map[string][]string{
+			"document:goods_issue:unpost": {"warehouse": {"wh-1"}},
+		},
+	}
+	ctx := WithScope(context.Background(), NewUserAccessScope(clerk))
+
+	_, err := Authorize(ctx, "goods_issue", PermissionCreate)
+	assert.NoError(t, err)
+
+	_, err = Authorize(ctx, "goods_issue", PermissionPost)
+	assert.Error(t, err, "creating does not imply posting")
+
+	narrowed, err := Authorize(ctx, "goods_issue", PermissionUnpost)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wh-1"}, GetDataScope(narrowed).EffectiveDimensions("goods_issue")["warehouse"])
+
+	admin := WithScope(context.Background(), NewUserAccessScope(&appctx.UserContext{IsAdmin: true}))
+	_, err = Authorize(admin, "goods_issue", PermissionPost)
+	assert.NoError(t, err)
+
+	// Background callers carry no AccessScope and were authorized upstream
+	_, err = Authorize(context.Background(), "goods_issue", PermissionPost)
+	assert.NoError(t, err)
+}
+
+func TestParsePermissionCode(t *testing.T) {
+	entity, perm, ok := ParsePermissionCode("document:goods_issue:post")
+	assert.True(t, ok)
+	assert.Equal(t, "goods_issue", entity)
+	assert.Equal(t, PermissionPost, perm)
+
+	_, _, ok = ParsePermissionCode("goods_issue.post")
+	assert.False(t, ok)
+	assert.Equal(t, "document:goods_issue:unpost", DocumentPermission("goods_issue", PermissionUnpost))
+}
@@ -0,0 +1,87 @@
+// Package tenant — AuditLog interface for the meta-level audit trail.
+// Administrative actions on the meta-database (tenant registry, placement,
+// schema updates, backups) are recorded with the actor and the values
+// before and after the change, for operational audits.
+package tenant
+
+import (
+	"context"
+	"time"
+)
+
+// Audit actions.
+const (
+	AuditCreated              = "created"
+	AuditSuspended            = "suspended"
+	AuditActivated            = "activated"
+	AuditVersionGroupChanged  = "version_group_changed"
+	AuditSchemaVersionChanged = "schema_version_changed"
+	AuditMigrated             = "migrated"
+	AuditUpdateStarted        = "update_started"
+	AuditUpdateRetried        = "update_retried"
+	AuditRollbackStarted      = "rollback_started"
+	AuditMoveStarted          = "move_started"
+	AuditMoved                = "moved"
+	AuditClockChanged         = "clock_changed"
+	AuditFeaturesChanged      = "features_changed"
+	AuditPlanChanged          = "plan_changed"
+	AuditReadOnlyEnabled      = "read_only_enabled"
+	AuditReadOnlyDisabled     = "read_only_disabled"
+	AuditReplicaChanged       = "replica_changed"
+	AuditBackupCreated        = "backup_created"
+	AuditRestored             = "restored"
+	AuditExportRequested      = "export_requested"
+	AuditExported             = "exported"
+	AuditRepostRequested      = "repost_requested"
+	AuditDeletionScheduled    = "deletion_scheduled"
+	AuditDeletionCancelled    = "deletion_cancelled"
+	AuditDeleted              = "deleted"
+)
+
+// AuditEntry is one administrative action on the meta layer.
+type AuditEntry struct {
+	ID       int64  `json:"id"`
+	TenantID string `json:"tenantId,omitempty"` // empty for a deleted tenant
+	Action   string `json:"action"`
+
+	// Actor identifies who acted: "user:<tenant>/<user> <email>" for the
+	// admin API, "admin:<operator>" for the control-plane API, "cli:<os user>"
+	// for the tenant CLI, "updater-agent" for internal endpoints.
+	Actor string `json:"actor"`
+
+	// Before and After are the changed values (e.g. {"status": "active"});
+	// nil for actions that only start a job.
+	Before map[string]any `json:"before,omitempty"`
+	After  map[string]any `json:"after,omitempty"`
+
+	// Details holds further context of the action (e.g. a backup file).
+	Details map[string]any `json:"details,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AuditFilter selects audit entries. Zero fields do not filter.
+type AuditFilter struct {
+	TenantID string
+	Action   string
+	Actor    string
+	From     *time.Time
+	To       *time.Time
+
+	// BeforeID pages backwards: entries with a smaller ID than this one.
+	BeforeID int64
+	Limit    int
+}
+
+// AuditLog records and queries meta-level audit entries.
+// Implementations must be safe for concurrent use.
+type AuditLog interface {
+	// EnsureTable creates or upgrades the tenant_audit table. Idempotent.
+	EnsureTable(ctx context.Context) error
+
+	// Record appends an entry. ID and CreatedAt are set on e.
+	Record(ctx context.Context, e *AuditEntry) error
+
+	// List returns matching entries, newest first.
+	List(ctx context.Context, filter AuditFilter) ([]*AuditEntry, error)
+}
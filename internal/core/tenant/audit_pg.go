@@ -0,0 +1,172 @@
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Default and maximum page size of AuditLog.List.
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// PostgresAuditLog implements AuditLog using the meta-database table
+// tenant_audit (created by init-meta; upgraded by EnsureTable).
+type PostgresAuditLog struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresAuditLog creates a new audit log backed by meta-database.
+func NewPostgresAuditLog(pool *pgxpool.Pool) *PostgresAuditLog {
+	return &PostgresAuditLog{pool: pool}
+}
+
+// EnsureTable creates the tenant_audit table if it does not exist and adds
+// the before/after columns to tables created by older init-meta runs.
+// Safe to call on every startup — fully idempotent.
+func (l *PostgresAuditLog) EnsureTable(ctx context.Context) error {
+	_, err := l.pool.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS tenant_audit (
+			id          SERIAL PRIMARY KEY,
+			tenant_id   UUID REFERENCES tenants(id) ON DELETE SET NULL,
+			action      VARCHAR(50) NOT NULL,
+			actor       VARCHAR(255),
+			details     JSONB DEFAULT '{}',
+			created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+		ALTER TABLE tenant_audit ADD COLUMN IF NOT EXISTS before_values JSONB;
+		ALTER TABLE tenant_audit ADD COLUMN IF NOT EXISTS after_values JSONB;
+		CREATE INDEX IF NOT EXISTS idx_tenant_audit_tenant ON tenant_audit(tenant_id);
+		CREATE INDEX IF NOT EXISTS idx_tenant_audit_created ON tenant_audit(created_at DESC);
+	`)
+	if err != nil {
+		return fmt.Errorf("ensure tenant_audit table: %w", err)
+	}
+	return nil
+}
+
+func (l *PostgresAuditLog) Record(ctx context.Context, e *AuditEntry) error {
+	before, err := marshalAuditValues(e.Before)
+	if err != nil {
+		return err
+	}
+	after, err := marshalAuditValues(e.After)
+	if err != nil {
+		return err
+	}
+	details, err := json.Marshal(e.Details)
+	if err != nil || e.Details == nil {
+		details = []byte("{}")
+	}
+
+	var tenantID *string
+	if e.TenantID != "" {
+		tenantID = &e.TenantID
+	}
+
+	err = l.pool.QueryRow(ctx, `
+		INSERT INTO tenant_audit (tenant_id, action, actor, before_values, after_values, details)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`, tenantID, e.Action, e.Actor, before, after, details).Scan(&e.ID, &e.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("record audit %s: %w", e.Action, err)
+	}
+	return nil
+}
+
+func (l *PostgresAuditLog) List(ctx context.Context, filter AuditFilter) ([]*AuditEntry, error) {
+	var conds []string
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if filter.TenantID != "" {
+		add("tenant_id = $%d", filter.TenantID)
+	}
+	if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+	if filter.Actor != "" {
+		add("actor = $%d", filter.Actor)
+	}
+	if filter.From != nil {
+		add("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("created_at < $%d", *filter.To)
+	}
+	if filter.BeforeID > 0 {
+		add("id < $%d", filter.BeforeID)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	limit = min(limit, maxAuditLimit)
+
+	sql := `
+		SELECT id, COALESCE(tenant_id::text, ''), action, COALESCE(actor, ''),
+		       before_values, after_values, details, created_at
+		FROM tenant_audit`
+	if len(conds) > 0 {
+		sql += " WHERE " + strings.Join(conds, " AND ")
+	}
+	sql += fmt.Sprintf(" ORDER BY id DESC LIMIT %d", limit)
+
+	rows, err := l.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*AuditEntry
+	for rows.Next() {
+		var e AuditEntry
+		var before, after, details []byte
+		if err := rows.Scan(&e.ID, &e.TenantID, &e.Action, &e.Actor, &before, &after, &details, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan audit entry: %w", err)
+		}
+		for _, v := range []struct {
+			raw []byte
+			dst *map[string]any
+		}{{before, &e.Before}, {after, &e.After}, {details, &e.Details}} {
+			if len(v.raw) == 0 {
+				continue
+			}
+			if err := json.Unmarshal(v.raw, v.dst); err != nil {
+				return nil, fmt.Errorf("unmarshal audit entry %d: %w", e.ID, err)
+			}
+		}
+		if len(e.Details) == 0 {
+			e.Details = nil
+		}
+		entries = append(entries, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list audit entries: %w", err)
+	}
+	return entries, nil
+}
+
+// marshalAuditValues encodes before/after values; nil stays SQL NULL.
+func marshalAuditValues(v map[string]any) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal audit values: %w", err)
+	}
+	return b, nil
+}
+
+// Compile-time interface check.
+var _ AuditLog = (*PostgresAuditLog)(nil)
@@ -0,0 +1,56 @@
+// Package tenant — BackupStore interface for the registry of tenant database backups.
+// Backups are dump files of a single tenant database; the meta-database keeps
+// where each file is, its size and checksum, so a restore can verify the file.
+package tenant
+
+import (
+	"context"
+	"time"
+)
+
+// BackupSource tells how a backup was made.
+type BackupSource string
+
+const (
+	// BackupManual is a backup made with `tenant backup`.
+	BackupManual BackupSource = "manual"
+	// BackupScheduled is a backup made by the worker's backup schedule.
+	// Only scheduled backups are pruned automatically.
+	BackupScheduled BackupSource = "scheduled"
+	// BackupArchive is the final dump taken by `tenant delete --purge`
+	// before the tenant database is dropped. Never pruned.
+	BackupArchive BackupSource = "archive"
+)
+
+// Backup is a dump of a tenant database registered in the meta-database.
+type Backup struct {
+	ID            string       `db:"id"`
+	TenantID      string       `db:"tenant_id"`
+	FilePath      string       `db:"file_path"`
+	SizeBytes     int64        `db:"size_bytes"`
+	Checksum      string       `db:"checksum"` // SHA-256 of the file, hex
+	SchemaVersion int          `db:"schema_version"`
+	Source        BackupSource `db:"source"`
+	CreatedAt     time.Time    `db:"created_at"`
+}
+
+// BackupStore manages backup records in the meta-database.
+// Implementations must be safe for concurrent use.
+type BackupStore interface {
+	// EnsureTable creates the tenant_backups table if not exists. Idempotent.
+	EnsureTable(ctx context.Context) error
+
+	// Create registers a completed backup. ID and CreatedAt are set on b.
+	Create(ctx context.Context, b *Backup) error
+
+	// GetByFile returns the backup of tenantID stored at filePath.
+	// Returns nil if the file is not a registered backup of the tenant.
+	GetByFile(ctx context.Context, tenantID, filePath string) (*Backup, error)
+
+	// ListByTenant returns the backups of a tenant, newest first.
+	// An empty source lists backups of every source.
+	ListByTenant(ctx context.Context, tenantID string, source BackupSource) ([]*Backup, error)
+
+	// Delete removes a backup record (not the file).
+	Delete(ctx context.Context, id string) error
+}
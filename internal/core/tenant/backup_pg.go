@@ -0,0 +1,102 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresBackupStore implements BackupStore using the meta-database.
+// Table tenant_backups is created automatically on first use (EnsureTable).
+type PostgresBackupStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresBackupStore creates a new store backed by meta-database.
+func NewPostgresBackupStore(pool *pgxpool.Pool) *PostgresBackupStore {
+	return &PostgresBackupStore{pool: pool}
+}
+
+// EnsureTable creates the tenant_backups table if it does not exist.
+// Safe to call on every startup — fully idempotent.
+func (s *PostgresBackupStore) EnsureTable(ctx context.Context) error {
+	_, err := s.pool.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS tenant_backups (
+			id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			tenant_id      UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			file_path      TEXT NOT NULL,
+			size_bytes     BIGINT NOT NULL,
+			checksum       VARCHAR(64) NOT NULL,
+			schema_version INT NOT NULL DEFAULT 0,
+			source         VARCHAR(20) NOT NULL DEFAULT 'manual',
+			created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			UNIQUE (tenant_id, file_path)
+		);
+		CREATE INDEX IF NOT EXISTS idx_tenant_backups_tenant
+			ON tenant_backups (tenant_id, created_at DESC);
+	`)
+	if err != nil {
+		return fmt.Errorf("ensure tenant_backups table: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresBackupStore) Create(ctx context.Context, b *Backup) error {
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO tenant_backups (tenant_id, file_path, size_bytes, checksum, schema_version, source)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`, b.TenantID, b.FilePath, b.SizeBytes, b.Checksum, b.SchemaVersion, b.Source).Scan(&b.ID, &b.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("register backup for %s: %w", b.TenantID, err)
+	}
+	return nil
+}
+
+const backupColumns = `id, tenant_id, file_path, size_bytes, checksum, schema_version, source, created_at`
+
+func (s *PostgresBackupStore) GetByFile(ctx context.Context, tenantID, filePath string) (*Backup, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+backupColumns+` FROM tenant_backups WHERE tenant_id = $1 AND file_path = $2
+	`, tenantID, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("get backup %s: %w", filePath, err)
+	}
+	b, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[Backup])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get backup %s: %w", filePath, err)
+	}
+	return b, nil
+}
+
+func (s *PostgresBackupStore) ListByTenant(ctx context.Context, tenantID string, source BackupSource) ([]*Backup, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+backupColumns+` FROM tenant_backups
+		WHERE tenant_id = $1 AND ($2 = '' OR source = $2)
+		ORDER BY created_at DESC
+	`, tenantID, string(source))
+	if err != nil {
+		return nil, fmt.Errorf("list backups for %s: %w", tenantID, err)
+	}
+	backups, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[Backup])
+	if err != nil {
+		return nil, fmt.Errorf("list backups for %s: %w", tenantID, err)
+	}
+	return backups, nil
+}
+
+func (s *PostgresBackupStore) Delete(ctx context.Context, id string) error {
+	if _, err := s.pool.Exec(ctx, `DELETE FROM tenant_backups WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("delete backup %s: %w", id, err)
+	}
+	return nil
+}
+
+// Compile-time interface check.
+var _ BackupStore = (*PostgresBackupStore)(nil)
@@ -20,4 +20,9 @@ var (
 	// version group than the current server instance (cloud mode).
 	// The reverse proxy should route this tenant to the correct instance.
 	ErrTenantVersionMismatch = errors.New("tenant version group mismatch")
+
+	// ErrTenantBusy is returned when no connection of the tenant pool could be
+	// acquired within the acquisition timeout, or the request was shed because
+	// too many requests already wait for one.
+	ErrTenantBusy = errors.New("tenant database is busy")
 )
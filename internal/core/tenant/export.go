@@ -0,0 +1,92 @@
+// Package tenant — ExportStore interface for tenant data exports.
+// An export is a zip of all catalogs, documents and registers of one tenant
+// (CSV or JSONL files), made for GDPR requests and offboarding. The
+// meta-database tracks each export job and its progress.
+package tenant
+
+import (
+	"context"
+	"time"
+)
+
+// ExportFormat is the file format of the tables in an export.
+type ExportFormat string
+
+const (
+	// ExportCSV writes one CSV file with a header row per table.
+	ExportCSV ExportFormat = "csv"
+	// ExportJSONL writes one file per table with a JSON object per line.
+	ExportJSONL ExportFormat = "jsonl"
+)
+
+// Valid reports whether f is a supported export format.
+func (f ExportFormat) Valid() bool {
+	return f == ExportCSV || f == ExportJSONL
+}
+
+// ExportStatus is the state of an export job.
+type ExportStatus string
+
+const (
+	ExportPending   ExportStatus = "pending"   // queued for the worker
+	ExportRunning   ExportStatus = "running"   // being written
+	ExportCompleted ExportStatus = "completed" // file is ready
+	ExportFailed    ExportStatus = "failed"    // see Error
+)
+
+// Export is a tenant data export job registered in the meta-database.
+type Export struct {
+	ID          string       `db:"id"`
+	TenantID    string       `db:"tenant_id"`
+	Format      ExportFormat `db:"format"`
+	Status      ExportStatus `db:"status"`
+	RequestedBy string       `db:"requested_by"`
+
+	// Progress: tables written so far out of TablesTotal, and their rows.
+	TablesTotal  int    `db:"tables_total"`
+	TablesDone   int    `db:"tables_done"`
+	RowsExported int64  `db:"rows_exported"`
+	CurrentTable string `db:"current_table"`
+
+	// Result of a completed export.
+	FilePath  string `db:"file_path"`
+	SizeBytes int64  `db:"size_bytes"`
+	Checksum  string `db:"checksum"` // SHA-256 of the file, hex
+
+	Error      string     `db:"error"`
+	CreatedAt  time.Time  `db:"created_at"`
+	StartedAt  *time.Time `db:"started_at"`
+	FinishedAt *time.Time `db:"finished_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+}
+
+// ExportStore manages export jobs in the meta-database.
+// Implementations must be safe for concurrent use.
+type ExportStore interface {
+	// EnsureTable creates the tenant_exports table if not exists. Idempotent.
+	EnsureTable(ctx context.Context) error
+
+	// Create registers a job in e.Status (pending for the worker, running
+	// for an export made in place). ID and timestamps are set on e.
+	Create(ctx context.Context, e *Export) error
+
+	// Get returns an export by ID, or nil if it does not exist.
+	Get(ctx context.Context, id string) (*Export, error)
+
+	// ListByTenant returns the exports of a tenant, newest first.
+	ListByTenant(ctx context.Context, tenantID string) ([]*Export, error)
+
+	// ClaimNext marks the oldest pending export as running and returns it,
+	// or nil when none is pending. Running exports without progress for
+	// staleAfter (a crashed worker) are claimed again.
+	ClaimNext(ctx context.Context, staleAfter time.Duration) (*Export, error)
+
+	// UpdateProgress stores the progress of a running export.
+	UpdateProgress(ctx context.Context, e *Export) error
+
+	// Complete marks an export as completed with its file.
+	Complete(ctx context.Context, e *Export) error
+
+	// Fail marks an export as failed with the error message.
+	Fail(ctx context.Context, id string, cause error) error
+}
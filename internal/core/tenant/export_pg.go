@@ -0,0 +1,175 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresExportStore implements ExportStore using the meta-database.
+// Table tenant_exports is created automatically on first use (EnsureTable).
+type PostgresExportStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresExportStore creates a new store backed by meta-database.
+func NewPostgresExportStore(pool *pgxpool.Pool) *PostgresExportStore {
+	return &PostgresExportStore{pool: pool}
+}
+
+// EnsureTable creates the tenant_exports table if it does not exist.
+// Safe to call on every startup — fully idempotent.
+func (s *PostgresExportStore) EnsureTable(ctx context.Context) error {
+	_, err := s.pool.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS tenant_exports (
+			id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			tenant_id     UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			format        VARCHAR(10) NOT NULL,
+			status        VARCHAR(20) NOT NULL DEFAULT 'pending',
+			requested_by  VARCHAR(255) NOT NULL DEFAULT '',
+			tables_total  INT NOT NULL DEFAULT 0,
+			tables_done   INT NOT NULL DEFAULT 0,
+			rows_exported BIGINT NOT NULL DEFAULT 0,
+			current_table VARCHAR(63) NOT NULL DEFAULT '',
+			file_path     TEXT NOT NULL DEFAULT '',
+			size_bytes    BIGINT NOT NULL DEFAULT 0,
+			checksum      VARCHAR(64) NOT NULL DEFAULT '',
+			error         TEXT NOT NULL DEFAULT '',
+			created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			started_at    TIMESTAMPTZ,
+			finished_at   TIMESTAMPTZ,
+			updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+		CREATE INDEX IF NOT EXISTS idx_tenant_exports_tenant
+			ON tenant_exports (tenant_id, created_at DESC);
+		CREATE INDEX IF NOT EXISTS idx_tenant_exports_queue
+			ON tenant_exports (created_at) WHERE status IN ('pending', 'running');
+	`)
+	if err != nil {
+		return fmt.Errorf("ensure tenant_exports table: %w", err)
+	}
+	return nil
+}
+
+const exportColumns = `id, tenant_id, format, status, requested_by, tables_total, tables_done,
+	rows_exported, current_table, file_path, size_bytes, checksum, error,
+	created_at, started_at, finished_at, updated_at`
+
+func (s *PostgresExportStore) Create(ctx context.Context, e *Export) error {
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO tenant_exports (tenant_id, format, status, requested_by, started_at)
+		VALUES ($1, $2, $3, $4, CASE WHEN $3 = 'running' THEN NOW() END)
+		RETURNING id, created_at, started_at, updated_at
+	`, e.TenantID, e.Format, e.Status, e.RequestedBy).Scan(&e.ID, &e.CreatedAt, &e.StartedAt, &e.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("register export for %s: %w", e.TenantID, err)
+	}
+	return nil
+}
+
+func (s *PostgresExportStore) Get(ctx context.Context, id string) (*Export, error) {
+	rows, err := s.pool.Query(ctx, `SELECT `+exportColumns+` FROM tenant_exports WHERE id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("get export %s: %w", id, err)
+	}
+	e, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[Export])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get export %s: %w", id, err)
+	}
+	return e, nil
+}
+
+func (s *PostgresExportStore) ListByTenant(ctx context.Context, tenantID string) ([]*Export, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+exportColumns+` FROM tenant_exports
+		WHERE tenant_id = $1
+		ORDER BY created_at DESC
+	`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list exports for %s: %w", tenantID, err)
+	}
+	exports, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[Export])
+	if err != nil {
+		return nil, fmt.Errorf("list exports for %s: %w", tenantID, err)
+	}
+	return exports, nil
+}
+
+func (s *PostgresExportStore) ClaimNext(ctx context.Context, staleAfter time.Duration) (*Export, error) {
+	rows, err := s.pool.Query(ctx, `
+		UPDATE tenant_exports SET
+			status = 'running', started_at = NOW(), updated_at = NOW(),
+			tables_done = 0, rows_exported = 0, current_table = '', error = ''
+		WHERE id = (
+			SELECT id FROM tenant_exports
+			WHERE status = 'pending'
+			   OR (status = 'running' AND updated_at < NOW() - make_interval(secs => $1))
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+exportColumns, staleAfter.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("claim export: %w", err)
+	}
+	e, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[Export])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("claim export: %w", err)
+	}
+	return e, nil
+}
+
+func (s *PostgresExportStore) UpdateProgress(ctx context.Context, e *Export) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE tenant_exports SET
+			tables_total = $2, tables_done = $3, rows_exported = $4, current_table = $5,
+			updated_at = NOW()
+		WHERE id = $1
+	`, e.ID, e.TablesTotal, e.TablesDone, e.RowsExported, e.CurrentTable)
+	if err != nil {
+		return fmt.Errorf("update export %s progress: %w", e.ID, err)
+	}
+	return nil
+}
+
+func (s *PostgresExportStore) Complete(ctx context.Context, e *Export) error {
+	err := s.pool.QueryRow(ctx, `
+		UPDATE tenant_exports SET
+			status = 'completed', tables_total = $2, tables_done = $3, rows_exported = $4,
+			current_table = '', file_path = $5, size_bytes = $6, checksum = $7,
+			finished_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+		RETURNING finished_at
+	`, e.ID, e.TablesTotal, e.TablesDone, e.RowsExported, e.FilePath, e.SizeBytes, e.Checksum).Scan(&e.FinishedAt)
+	if err != nil {
+		return fmt.Errorf("complete export %s: %w", e.ID, err)
+	}
+	e.Status = ExportCompleted
+	e.CurrentTable = ""
+	return nil
+}
+
+func (s *PostgresExportStore) Fail(ctx context.Context, id string, cause error) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE tenant_exports SET
+			status = 'failed', error = $2, finished_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, id, cause.Error())
+	if err != nil {
+		return fmt.Errorf("fail export %s: %w", id, err)
+	}
+	return nil
+}
+
+// Compile-time interface check.
+var _ ExportStore = (*PostgresExportStore)(nil)
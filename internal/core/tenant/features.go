@@ -0,0 +1,102 @@
+package tenant
+
+import "slices"
+
+// Feature is a capability a tenant gets from its subscription plan.
+type Feature string
+
+// Plan-dependent features. Routes of a feature answer 402 for tenants
+// without it.
+const (
+	FeatureCustomFields  Feature = "custom_fields"  // /system/custom-fields
+	FeatureAccountExport Feature = "account_export" // /system/account-export
+	FeatureUniqueRules   Feature = "unique_rules"   // /system/unique-rules
+	FeatureAutomations   Feature = "automations"    // /system/automation-*
+	FeatureGraphQL       Feature = "graphql"        // /graphql
+)
+
+// Features lists all plan-dependent features.
+var Features = []Feature{
+	FeatureCustomFields,
+	FeatureAccountExport,
+	FeatureUniqueRules,
+	FeatureAutomations,
+	FeatureGraphQL,
+}
+
+// PlanFeatures maps each plan to the features it includes. Plans missing
+// from the map get the features of the standard plan.
+var PlanFeatures = map[Plan][]Feature{
+	PlanStandard:   {FeatureCustomFields, FeatureAccountExport},
+	PlanPremium:    {FeatureCustomFields, FeatureAccountExport, FeatureUniqueRules, FeatureAutomations, FeatureGraphQL},
+	PlanEnterprise: Features,
+}
+
+// SettingFeatures is the tenant settings key of per-tenant feature
+// overrides: an object of feature name → bool that grants a feature outside
+// the plan (true) or withdraws one of the plan (false), e.g.
+// {"graphql": true}.
+const SettingFeatures = "features"
+
+// FeatureSource tells where the state of a tenant's feature comes from.
+type FeatureSource string
+
+const (
+	FeatureSourcePlan     FeatureSource = "plan"
+	FeatureSourceOverride FeatureSource = "override"
+)
+
+// FeatureState is the resolved state of a feature for a tenant.
+type FeatureState struct {
+	Feature Feature       `json:"name"`
+	Enabled bool          `json:"enabled"`
+	Source  FeatureSource `json:"source"`
+}
+
+// FeatureOverrides returns the feature overrides of the tenant settings.
+// Entries that are not booleans are ignored.
+func (t *Tenant) FeatureOverrides() map[Feature]bool {
+	raw, ok := t.Settings[SettingFeatures].(map[string]any)
+	if !ok {
+		return nil
+	}
+	overrides := make(map[Feature]bool, len(raw))
+	for name, v := range raw {
+		if enabled, ok := v.(bool); ok {
+			overrides[Feature(name)] = enabled
+		}
+	}
+	return overrides
+}
+
+// FeatureState resolves f for the tenant: the override of the tenant
+// settings if there is one, otherwise whether the plan includes it.
+func (t *Tenant) FeatureState(f Feature) FeatureState {
+	if enabled, ok := t.FeatureOverrides()[f]; ok {
+		return FeatureState{Feature: f, Enabled: enabled, Source: FeatureSourceOverride}
+	}
+	planFeatures, ok := PlanFeatures[t.Plan]
+	if !ok {
+		planFeatures = PlanFeatures[PlanStandard]
+	}
+	return FeatureState{Feature: f, Enabled: slices.Contains(planFeatures, f), Source: FeatureSourcePlan}
+}
+
+// HasFeature reports whether the tenant may use f.
+func (t *Tenant) HasFeature(f Feature) bool {
+	return t.FeatureState(f).Enabled
+}
+
+// FeatureStates resolves all features for the tenant, in Features order.
+func (t *Tenant) FeatureStates() []FeatureState {
+	states := make([]FeatureState, len(Features))
+	for i, f := range Features {
+		states[i] = t.FeatureState(f)
+	}
+	return states
+}
+
+// IsFeature reports whether name is a known feature.
+func IsFeature(name string) bool {
+	return slices.Contains(Features, Feature(name))
+}
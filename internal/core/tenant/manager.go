@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/sync/singleflight"
 
@@ -27,8 +30,19 @@ type ManagerConfig struct {
 	// Connection settings
 	ConnectTimeout time.Duration
 
+	// AcquireTimeout bounds how long a request waits for a free connection of
+	// a saturated tenant pool before failing with ErrTenantBusy
+	// (0 = wait until the request context ends).
+	AcquireTimeout time.Duration
+
+	// ShedQueueLength sheds new requests of a tenant (ErrTenantBusy) while at
+	// least this many requests wait for one of its connections, per plan.
+	// Plans not listed (or 0) are never shed.
+	ShedQueueLength map[Plan]int
+
 	// Lifecycle settings
 	MaxTotalPools     int           // Max simultaneous pools (0 = unlimited)
+	PoolSlotTimeout   time.Duration // Wait for a free pool slot at MaxTotalPools (0 = fail at once)
 	PoolIdleTimeout   time.Duration // Close pool after inactivity (0 = never)
 	HealthCheckPeriod time.Duration // How often to check pool health
 
@@ -36,6 +50,69 @@ type ManagerConfig struct {
 	// version_group value. Empty string means no filtering (self-hosted mode).
 	// In cloud mode, set to the binary version (e.g. "v1.3.0").
 	VersionGroup string
+
+	// Regions maps a tenant region to the DB endpoint reachable from this
+	// instance (e.g. a regional PgBouncer). Tenants whose region is not listed
+	// connect to their own db_host/db_port.
+	Regions map[string]RegionConfig
+
+	// QueryTracer, when set, is installed on every tenant pool's connections
+	// (query spans for OpenTelemetry).
+	QueryTracer pgx.QueryTracer
+}
+
+// RegionConfig overrides how tenant databases in a region are reached.
+type RegionConfig struct {
+	DBHost string
+	DBPort int // 0 = keep tenant db_port
+}
+
+// ParseRegions parses a region map in the form
+// "eu-central=pg-eu.internal:5432,us-east=pg-us.internal".
+func ParseRegions(s string) (map[string]RegionConfig, error) {
+	regions := map[string]RegionConfig{}
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, endpoint, ok := strings.Cut(entry, "=")
+		if !ok || name == "" || endpoint == "" {
+			return nil, fmt.Errorf("invalid region entry %q (want region=host[:port])", entry)
+		}
+		rc := RegionConfig{DBHost: endpoint}
+		if host, port, found := strings.Cut(endpoint, ":"); found {
+			n, err := strconv.Atoi(port)
+			if err != nil || n <= 0 || n > 65535 {
+				return nil, fmt.Errorf("invalid port in region entry %q", entry)
+			}
+			rc = RegionConfig{DBHost: host, DBPort: n}
+		}
+		regions[name] = rc
+	}
+	return regions, nil
+}
+
+// ParsePlanThresholds parses per-plan thresholds in the form
+// "standard=20,premium=50".
+func ParsePlanThresholds(s string) (map[Plan]int, error) {
+	thresholds := map[Plan]int{}
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		plan, value, ok := strings.Cut(entry, "=")
+		if !ok || plan == "" {
+			return nil, fmt.Errorf("invalid plan threshold %q (want plan=n)", entry)
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid value in plan threshold %q", entry)
+		}
+		thresholds[Plan(plan)] = n
+	}
+	return thresholds, nil
 }
 
 // DefaultManagerConfig returns production-safe defaults.
@@ -44,7 +121,9 @@ func DefaultManagerConfig() ManagerConfig {
 		MaxConnsPerTenant: 10,
 		MinConnsPerTenant: 2,
 		ConnectTimeout:    10 * time.Second,
+		AcquireTimeout:    5 * time.Second,
 		MaxTotalPools:     100,
+		PoolSlotTimeout:   2 * time.Second,
 		PoolIdleTimeout:   30 * time.Minute,
 		HealthCheckPeriod: 1 * time.Minute,
 	}
@@ -58,6 +137,17 @@ type ManagedPool struct {
 	refCount atomic.Int32 // Active requests using this pool
 	// unhealthySince is set when health check fails (unix timestamp). 0 means healthy/unknown.
 	unhealthySince atomic.Int64
+
+	acquireTimeout time.Duration
+	shedAt         int32        // shed requests at this queue length (0 = never)
+	waiting        atomic.Int32 // acquisitions waiting for a connection
+	busyRejections atomic.Int64 // requests failed with ErrTenantBusy
+
+	// replica is the read pool of the tenant's read replica (nil = none);
+	// replicaDownSince is set (unix timestamp) while it is unreachable, so
+	// hinted reads go to the primary until a health check succeeds again.
+	replica          *pgxpool.Pool
+	replicaDownSince atomic.Int64
 }
 
 // Touch updates last used timestamp.
@@ -75,6 +165,42 @@ func (mp *ManagedPool) Tenant() *Tenant {
 	return mp.tenant
 }
 
+// ReplicaPool returns the read pool of the tenant's read replica, or nil if
+// the tenant has none or it is down.
+func (mp *ManagedPool) ReplicaPool() *pgxpool.Pool {
+	if mp.replica == nil || mp.replicaDownSince.Load() != 0 {
+		return nil
+	}
+	return mp.replica
+}
+
+// MarkReplicaDown routes reads back to the primary after a failed replica
+// acquisition, until the next successful health check.
+func (mp *ManagedPool) MarkReplicaDown() {
+	mp.replicaDownSince.CompareAndSwap(0, time.Now().Unix())
+}
+
+// ReplicaState describes the read replica for stats: "" (none), "healthy"
+// or "down".
+func (mp *ManagedPool) ReplicaState() string {
+	switch {
+	case mp.replica == nil:
+		return ""
+	case mp.replicaDownSince.Load() != 0:
+		return "down"
+	default:
+		return "healthy"
+	}
+}
+
+// close closes the primary and the read pool.
+func (mp *ManagedPool) close() {
+	mp.pool.Close()
+	if mp.replica != nil {
+		mp.replica.Close()
+	}
+}
+
 // AcquireRef increments reference count (for tracking active requests).
 func (mp *ManagedPool) AcquireRef() {
 	mp.refCount.Add(1)
@@ -85,6 +211,40 @@ func (mp *ManagedPool) ReleaseRef() {
 	mp.refCount.Add(-1)
 }
 
+// Acquire takes a connection from the pool, waiting at most the configured
+// acquisition timeout for a saturated pool. The caller must Release it.
+func (mp *ManagedPool) Acquire(ctx context.Context) (*pgxpool.Conn, error) {
+	mp.waiting.Add(1)
+	defer mp.waiting.Add(-1)
+
+	acquireCtx := ctx
+	if mp.acquireTimeout > 0 {
+		var cancel context.CancelFunc
+		acquireCtx, cancel = context.WithTimeout(ctx, mp.acquireTimeout)
+		defer cancel()
+	}
+
+	conn, err := mp.pool.Acquire(acquireCtx)
+	if err != nil {
+		if ctx.Err() == nil && acquireCtx.Err() != nil {
+			mp.busyRejections.Add(1)
+			return nil, fmt.Errorf("%w: no free connection within %s", ErrTenantBusy, mp.acquireTimeout)
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Shed reports whether a new request should be rejected with ErrTenantBusy
+// because the tenant's plan threshold of waiting acquisitions is reached.
+func (mp *ManagedPool) Shed() bool {
+	if mp.shedAt <= 0 || mp.waiting.Load() < mp.shedAt {
+		return false
+	}
+	mp.busyRejections.Add(1)
+	return true
+}
+
 // Manager manages database connections for multiple tenants.
 // Thread-safe for concurrent access.
 type Manager struct {
@@ -94,6 +254,17 @@ type Manager struct {
 	pools     sync.Map // map[tenantID]*ManagedPool
 	poolCount atomic.Int32
 
+	// slotFreed is closed (and replaced) whenever a pool is closed, waking
+	// tenants that wait for a slot at MaxTotalPools.
+	slotMu    sync.Mutex
+	slotFreed chan struct{}
+
+	// Eviction and pool limit counters since start (see ManagerStats).
+	idleEvictions     atomic.Int64
+	pressureEvictions atomic.Int64
+	slotWaits         atomic.Int64
+	slotTimeouts      atomic.Int64
+
 	sf singleflight.Group
 
 	ctx    context.Context
@@ -112,11 +283,12 @@ func NewManager(cfg ManagerConfig, registry Registry, log *logger.Logger) *Manag
 	ctx, cancel := context.WithCancel(context.Background())
 
 	m := &Manager{
-		config:   cfg,
-		registry: registry,
-		ctx:      ctx,
-		cancel:   cancel,
-		log:      log.WithComponent("tenant-manager"),
+		config:    cfg,
+		registry:  registry,
+		slotFreed: make(chan struct{}),
+		ctx:       ctx,
+		cancel:    cancel,
+		log:       log.WithComponent("tenant-manager"),
 	}
 
 	// Start background workers
@@ -155,28 +327,9 @@ func (m *Manager) GetPool(ctx context.Context, tenantID string) (*ManagedPool, e
 // createPool creates a new connection pool for tenant.
 func (m *Manager) createPool(ctx context.Context, tenantID string) (*ManagedPool, error) {
 	v, err, _ := m.sf.Do(tenantID, func() (any, error) {
-		// Reserve pool slot (pre-increment to prevent TOCTOU race).
-		// If we fail to create the pool or another goroutine wins LoadOrStore,
-		// we rollback by decrementing.
-		if m.config.MaxTotalPools > 0 {
-			newCount := m.poolCount.Add(1)
-			if int(newCount) > m.config.MaxTotalPools {
-				m.poolCount.Add(-1)
-				return nil, fmt.Errorf("%w (%d)", ErrMaxPoolLimit, m.config.MaxTotalPools)
-			}
-		}
-
-		// rollbackSlot decrements poolCount if we reserved a slot but failed to use it.
-		rollbackSlot := func() {
-			if m.config.MaxTotalPools > 0 {
-				m.poolCount.Add(-1)
-			}
-		}
-
 		// Get tenant info from registry
 		tenant, err := m.registry.GetByID(ctx, tenantID)
 		if err != nil {
-			rollbackSlot()
 			if errors.Is(err, ErrTenantNotFound) {
 				return nil, err
 			}
@@ -184,19 +337,25 @@ func (m *Manager) createPool(ctx context.Context, tenantID string) (*ManagedPool
 		}
 
 		if !tenant.CanCreatePool() {
-			rollbackSlot()
 			return nil, fmt.Errorf("%w: status=%s", ErrTenantNotActive, tenant.Status)
 		}
 
 		// Version gate: in cloud mode, reject tenants from other version groups.
 		if m.config.VersionGroup != "" && tenant.VersionGroup != m.config.VersionGroup {
-			rollbackSlot()
 			return nil, fmt.Errorf("%w: server=%s, tenant=%s",
 				ErrTenantVersionMismatch, m.config.VersionGroup, tenant.VersionGroup)
 		}
 
-		// Build DSN and create pool config
-		dsn := tenant.DSN(m.config.DBUser, m.config.DBPassword)
+		// Reserve a pool slot only for a tenant that may get a pool, so
+		// unknown or inactive tenants never evict anyone. If we fail to create
+		// the pool or another goroutine wins LoadOrStore, we give it back.
+		if err := m.reserveSlot(ctx); err != nil {
+			return nil, err
+		}
+		rollbackSlot := m.releaseSlot
+
+		// Build DSN (honouring region routing) and create pool config
+		dsn := m.TenantDSN(tenant)
 
 		poolCfg, err := pgxpool.ParseConfig(dsn)
 		if err != nil {
@@ -208,6 +367,12 @@ func (m *Manager) createPool(ctx context.Context, tenantID string) (*ManagedPool
 		poolCfg.MinConns = m.config.MinConnsPerTenant
 		poolCfg.HealthCheckPeriod = m.config.HealthCheckPeriod
 		poolCfg.ConnConfig.ConnectTimeout = m.config.ConnectTimeout
+		if m.config.QueryTracer != nil {
+			poolCfg.ConnConfig.Tracer = m.config.QueryTracer
+		}
+		// A shared-schema tenant's connections start with its search_path
+		// (the DSN's startup options, no extra round trip), and the pool
+		// serves that one schema: connections never move between tenants.
 
 		// Create pool with timeout
 		createCtx, cancel := context.WithTimeout(ctx, m.config.ConnectTimeout)
@@ -227,17 +392,20 @@ func (m *Manager) createPool(ctx context.Context, tenantID string) (*ManagedPool
 		}
 
 		mp := &ManagedPool{
-			pool:   pool,
-			tenant: tenant,
+			pool:           pool,
+			tenant:         tenant,
+			acquireTimeout: m.config.AcquireTimeout,
+			shedAt:         int32(m.config.ShedQueueLength[tenant.Plan]),
 		}
 		mp.Touch()
+		m.openReplica(createCtx, mp, poolCfg)
 
 		// Store (handle race condition — another goroutine might have created it
 		// just before we entered singleflight, or if singleflight is bypassed)
 		actual, loaded := m.pools.LoadOrStore(tenantID, mp)
 		if loaded {
 			// Another goroutine created pool first, close ours and return theirs
-			pool.Close()
+			mp.close()
 			rollbackSlot() // release our reserved slot
 			return actual.(*ManagedPool), nil
 		}
@@ -245,6 +413,7 @@ func (m *Manager) createPool(ctx context.Context, tenantID string) (*ManagedPool
 		m.log.Info("created pool for tenant",
 			"tenant_id", tenantID,
 			"db_name", tenant.DBName,
+			"db_schema", tenant.DBSchema,
 			"total_pools", m.poolCount.Load(),
 		)
 
@@ -257,6 +426,154 @@ func (m *Manager) createPool(ctx context.Context, tenantID string) (*ManagedPool
 	return v.(*ManagedPool), nil
 }
 
+// reserveSlot takes one of the MaxTotalPools pool slots (pre-increment to
+// prevent a TOCTOU race). At the limit it evicts the least recently used
+// pool without active requests; if every pool is in use it waits up to
+// PoolSlotTimeout for one to close before failing with ErrMaxPoolLimit.
+func (m *Manager) reserveSlot(ctx context.Context) error {
+	limit := int32(m.config.MaxTotalPools)
+	if limit <= 0 {
+		m.poolCount.Add(1)
+		return nil
+	}
+
+	var deadline <-chan time.Time
+	waited := false
+	for {
+		// Take the channel before checking, so a pool closed in between
+		// still wakes us.
+		freed := m.slotReleased()
+
+		if n := m.poolCount.Load(); n < limit {
+			if m.poolCount.CompareAndSwap(n, n+1) {
+				return nil
+			}
+			continue
+		}
+		if m.evictLRU() {
+			continue
+		}
+
+		if !waited {
+			waited = true
+			m.slotWaits.Add(1)
+			if m.config.PoolSlotTimeout <= 0 {
+				m.slotTimeouts.Add(1)
+				return fmt.Errorf("%w (%d)", ErrMaxPoolLimit, limit)
+			}
+			timer := time.NewTimer(m.config.PoolSlotTimeout)
+			defer timer.Stop()
+			deadline = timer.C
+		}
+		select {
+		case <-freed:
+		case <-deadline:
+			m.slotTimeouts.Add(1)
+			return fmt.Errorf("%w (%d): no pool released within %s", ErrMaxPoolLimit, limit, m.config.PoolSlotTimeout)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// releaseSlot gives a pool slot back and wakes tenants waiting for one.
+func (m *Manager) releaseSlot() {
+	m.poolCount.Add(-1)
+
+	m.slotMu.Lock()
+	close(m.slotFreed)
+	m.slotFreed = make(chan struct{})
+	m.slotMu.Unlock()
+}
+
+// slotReleased returns a channel closed the next time a pool slot is
+// released.
+func (m *Manager) slotReleased() <-chan struct{} {
+	m.slotMu.Lock()
+	defer m.slotMu.Unlock()
+	return m.slotFreed
+}
+
+// evictLRU closes the least recently used pool that serves no request, to
+// make room for a new tenant at MaxTotalPools. Pools touched within the last
+// second are spared: their request may not have taken its reference yet.
+// It reports whether a pool was closed.
+func (m *Manager) evictLRU() bool {
+	var (
+		victimID string
+		victim   *ManagedPool
+	)
+	recent := time.Now().Unix() - 1
+	m.pools.Range(func(key, value any) bool {
+		mp := value.(*ManagedPool)
+		if mp.refCount.Load() > 0 || mp.waiting.Load() > 0 || mp.lastUsed.Load() >= recent {
+			return true
+		}
+		if victim == nil || mp.lastUsed.Load() < victim.lastUsed.Load() {
+			victimID, victim = key.(string), mp
+		}
+		return true
+	})
+	if victim == nil || !m.closePool(victimID, victim, "pool limit pressure") {
+		return false
+	}
+	m.pressureEvictions.Add(1)
+	return true
+}
+
+// openReplica opens the read pool of the tenant's read replica, configured
+// like the primary pool. An unreachable replica does not fail the primary
+// pool: it is marked down and picked up by the next successful health check.
+func (m *Manager) openReplica(ctx context.Context, mp *ManagedPool, primary *pgxpool.Config) {
+	t := mp.tenant
+	dsn, err := t.ReplicaDSN(m.config.DBUser, m.config.DBPassword)
+	if err != nil {
+		return // no replica registered
+	}
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		m.log.Warn("invalid read replica of tenant", "tenant_id", t.ID, "error", err)
+		return
+	}
+	cfg.MaxConns = primary.MaxConns
+	cfg.MinConns = 0
+	cfg.HealthCheckPeriod = primary.HealthCheckPeriod
+	cfg.ConnConfig.ConnectTimeout = primary.ConnConfig.ConnectTimeout
+	cfg.ConnConfig.Tracer = primary.ConnConfig.Tracer
+
+	replica, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		m.log.Warn("create read replica pool failed", "tenant_id", t.ID, "error", err)
+		return
+	}
+	mp.replica = replica
+	if err := replica.Ping(ctx); err != nil {
+		mp.MarkReplicaDown()
+		m.log.Warn("read replica unreachable, reads go to the primary", "tenant_id", t.ID, "error", err)
+	}
+}
+
+// TenantDSN builds the connection string for a tenant database, routing
+// through the configured endpoint for the tenant's region when present.
+func (m *Manager) TenantDSN(t *Tenant) string {
+	return m.PlacementDSN(t, t.Placement())
+}
+
+// PlacementDSN builds the connection string for the tenant database at p.
+// Used by the region move job to address the target copy before the
+// registry is switched over.
+func (m *Manager) PlacementDSN(t *Tenant, p Placement) string {
+	target := *t
+	target.DBHost, target.DBPort = p.DBHost, p.DBPort
+	if rc, ok := m.config.Regions[p.Region]; ok && rc.DBHost != "" {
+		target.DBHost = rc.DBHost
+		if rc.DBPort > 0 {
+			target.DBPort = rc.DBPort
+		}
+	}
+	return target.DSN(m.config.DBUser, m.config.DBPassword)
+}
+
 // evictionLoop closes idle pools periodically.
 func (m *Manager) evictionLoop() {
 	defer m.wg.Done()
@@ -293,8 +610,8 @@ func (m *Manager) evictIdlePools() {
 			return true
 		}
 
-		if mp.lastUsed.Load() < threshold {
-			m.closePool(tenantID, mp, "idle timeout")
+		if mp.lastUsed.Load() < threshold && m.closePool(tenantID, mp, "idle timeout") {
+			m.idleEvictions.Add(1)
 		}
 
 		return true
@@ -348,21 +665,39 @@ func (m *Manager) checkPoolsHealth() {
 		if mp.unhealthySince.Load() != 0 {
 			mp.unhealthySince.Store(0)
 		}
+
+		if mp.replica != nil {
+			if err := mp.replica.Ping(ctx); err != nil {
+				if mp.replicaDownSince.Load() == 0 {
+					m.log.Warn("read replica health check failed, reads go to the primary",
+						"tenant_id", tenantID,
+						"error", err,
+					)
+				}
+				mp.MarkReplicaDown()
+			} else if mp.replicaDownSince.Swap(0) != 0 {
+				m.log.Info("read replica healthy again", "tenant_id", tenantID)
+			}
+		}
 		return true
 	})
 }
 
-// closePool safely closes a managed pool.
-func (m *Manager) closePool(tenantID string, mp *ManagedPool, reason string) {
-	m.pools.Delete(tenantID)
-	mp.pool.Close()
-	m.poolCount.Add(-1)
+// closePool safely closes a managed pool. It reports false if the pool was
+// already closed by a concurrent eviction.
+func (m *Manager) closePool(tenantID string, mp *ManagedPool, reason string) bool {
+	if !m.pools.CompareAndDelete(tenantID, mp) {
+		return false
+	}
+	mp.close()
+	m.releaseSlot()
 
 	m.log.Info("closed pool",
 		"tenant_id", tenantID,
 		"reason", reason,
 		"total_pools", m.poolCount.Load(),
 	)
+	return true
 }
 
 // Close shuts down manager and all pools gracefully.
@@ -377,7 +712,7 @@ func (m *Manager) Close() {
 	var poolsClosed int
 	m.pools.Range(func(key, value any) bool {
 		mp := value.(*ManagedPool)
-		mp.pool.Close()
+		mp.close()
 		m.pools.Delete(key)
 		poolsClosed++
 		return true
@@ -391,6 +726,11 @@ func (m *Manager) Close() {
 func (m *Manager) Stats() ManagerStats {
 	var stats ManagerStats
 	stats.TotalPools = int(m.poolCount.Load())
+	stats.MaxPools = m.config.MaxTotalPools
+	stats.IdleEvictions = m.idleEvictions.Load()
+	stats.PressureEvictions = m.pressureEvictions.Load()
+	stats.SlotWaits = m.slotWaits.Load()
+	stats.SlotTimeouts = m.slotTimeouts.Load()
 
 	m.pools.Range(func(key, value any) bool {
 		mp := value.(*ManagedPool)
@@ -399,15 +739,19 @@ func (m *Manager) Stats() ManagerStats {
 		stats.TotalConns += int(poolStats.TotalConns())
 		stats.IdleConns += int(poolStats.IdleConns())
 		stats.AcquiredConns += int(poolStats.AcquiredConns())
+		stats.QueueLength += int(mp.waiting.Load())
 
 		stats.Tenants = append(stats.Tenants, TenantPoolStats{
-			TenantID:      key.(string),
-			DBName:        mp.tenant.DBName,
-			TotalConns:    int(poolStats.TotalConns()),
-			IdleConns:     int(poolStats.IdleConns()),
-			AcquiredConns: int(poolStats.AcquiredConns()),
-			ActiveRefs:    int(mp.refCount.Load()),
-			LastUsed:      time.Unix(mp.lastUsed.Load(), 0),
+			TenantID:       key.(string),
+			DBName:         mp.tenant.DBName,
+			TotalConns:     int(poolStats.TotalConns()),
+			IdleConns:      int(poolStats.IdleConns()),
+			AcquiredConns:  int(poolStats.AcquiredConns()),
+			ActiveRefs:     int(mp.refCount.Load()),
+			QueueLength:    int(mp.waiting.Load()),
+			BusyRejections: mp.busyRejections.Load(),
+			LastUsed:       time.Unix(mp.lastUsed.Load(), 0),
+			Replica:        mp.ReplicaState(),
 		})
 		return true
 	})
@@ -421,7 +765,19 @@ type ManagerStats struct {
 	TotalConns    int
 	IdleConns     int
 	AcquiredConns int
+	QueueLength   int
 	Tenants       []TenantPoolStats
+
+	// MaxPools is the configured pool limit (0 = unlimited). Since start,
+	// IdleEvictions and PressureEvictions count pools closed after the idle
+	// timeout and to make room at the limit; SlotWaits counts tenants that
+	// found every pool in use and had to wait for a slot, SlotTimeouts those
+	// that gave up with ErrMaxPoolLimit.
+	MaxPools          int
+	IdleEvictions     int64
+	PressureEvictions int64
+	SlotWaits         int64
+	SlotTimeouts      int64
 }
 
 // TenantPoolStats contains per-tenant pool statistics.
@@ -433,6 +789,16 @@ type TenantPoolStats struct {
 	AcquiredConns int
 	ActiveRefs    int
 	LastUsed      time.Time
+
+	// QueueLength is the number of acquisitions waiting for a connection;
+	// BusyRejections counts requests failed with ErrTenantBusy since the
+	// pool was opened.
+	QueueLength    int
+	BusyRejections int64
+
+	// Replica is the state of the tenant's read replica: "" (none),
+	// "healthy" or "down".
+	Replica string
 }
 
 // GetActiveTenants returns list of all active tenants from registry.
@@ -0,0 +1,91 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// MigrateFunc applies the schema migrations to the database at dsn and
+// returns the schema version the database is at afterwards.
+type MigrateFunc func(ctx context.Context, dsn string) (schemaVersion int, output string, err error)
+
+// DefaultMigrationConcurrency is the number of tenant databases MigrateAll
+// migrates at a time unless told otherwise.
+const DefaultMigrationConcurrency = 4
+
+// MigrationResult is the outcome of migrating one tenant database.
+type MigrationResult struct {
+	Tenant      *Tenant
+	FromVersion int
+	ToVersion   int
+	Output      string
+	Err         error
+}
+
+// Migrator migrates tenant databases and tracks the schema version applied
+// to each of them in the registry.
+type Migrator struct {
+	registry Registry
+	dsn      func(*Tenant) string
+	migrate  MigrateFunc
+}
+
+// NewMigrator creates a Migrator reaching tenant databases via dsn
+// (e.g. Manager.TenantDSN) and migrating them with migrate.
+func NewMigrator(registry Registry, dsn func(*Tenant) string, migrate MigrateFunc) *Migrator {
+	return &Migrator{registry: registry, dsn: dsn, migrate: migrate}
+}
+
+// MigrateTenant migrates the database of t and records the resulting schema
+// version in the registry and in t.
+func (m *Migrator) MigrateTenant(ctx context.Context, t *Tenant) MigrationResult {
+	res := MigrationResult{Tenant: t, FromVersion: t.SchemaVersion}
+	ver, output, err := m.migrate(ctx, m.dsn(t))
+	res.Output = output
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	res.ToVersion = ver
+	if err := m.registry.UpdateSchemaVersion(ctx, t.ID, ver); err != nil {
+		res.Err = fmt.Errorf("migrated, but record schema version: %w", err)
+		return res
+	}
+	t.SchemaVersion = ver
+	return res
+}
+
+// MigrateAll migrates the databases of tenants, at most concurrency at a
+// time (DefaultMigrationConcurrency if not positive). A failed tenant does
+// not stop the others. done, if set, is called as each tenant finishes,
+// never concurrently. The results are returned in tenants order.
+func (m *Migrator) MigrateAll(ctx context.Context, tenants []*Tenant, concurrency int, done func(MigrationResult)) []MigrationResult {
+	if concurrency <= 0 {
+		concurrency = DefaultMigrationConcurrency
+	}
+	results := make([]MigrationResult, len(tenants))
+	var mu sync.Mutex
+
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+	for i, t := range tenants {
+		g.Go(func() error {
+			res := MigrationResult{Tenant: t, FromVersion: t.SchemaVersion, Err: ctx.Err()}
+			if res.Err == nil {
+				res = m.MigrateTenant(ctx, t)
+			}
+			results[i] = res
+			if done != nil {
+				mu.Lock()
+				done(res)
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return results
+}
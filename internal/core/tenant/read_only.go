@@ -0,0 +1,46 @@
+package tenant
+
+import "time"
+
+// SettingReadOnly is the tenant settings key of the emergency read-only
+// mode: an object {"reason", "since", "by"} while the mode is on. Short of a
+// full suspend, users can still sign in and read, but mutating API requests
+// are rejected and background jobs of the tenant pause.
+const SettingReadOnly = "read_only"
+
+// ReadOnlyMode describes why and since when a tenant is read-only.
+type ReadOnlyMode struct {
+	Reason string    `json:"reason"`
+	Since  time.Time `json:"since"`
+	By     string    `json:"by,omitempty"`
+}
+
+// Setting returns the tenant settings value of the mode.
+func (m ReadOnlyMode) Setting() map[string]any {
+	return map[string]any{
+		"reason": m.Reason,
+		"since":  m.Since.UTC().Format(time.RFC3339),
+		"by":     m.By,
+	}
+}
+
+// ReadOnly returns the read-only mode of the tenant, if it is on.
+func (t *Tenant) ReadOnly() (ReadOnlyMode, bool) {
+	raw, ok := t.Settings[SettingReadOnly].(map[string]any)
+	if !ok {
+		return ReadOnlyMode{}, false
+	}
+	var m ReadOnlyMode
+	m.Reason, _ = raw["reason"].(string)
+	m.By, _ = raw["by"].(string)
+	if s, ok := raw["since"].(string); ok {
+		m.Since, _ = time.Parse(time.RFC3339, s)
+	}
+	return m, true
+}
+
+// IsReadOnly reports whether the tenant is in read-only mode.
+func (t *Tenant) IsReadOnly() bool {
+	_, ok := t.ReadOnly()
+	return ok
+}
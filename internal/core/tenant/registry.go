@@ -10,8 +10,9 @@ import (
 
 // tenantColumns is the shared SELECT column list for all tenant queries.
 // Update this constant when adding new columns to the tenants table.
-const tenantColumns = `id, slug, display_name, db_name, db_host, db_port,
-	       status, plan, schema_version, version_group, created_at, updated_at, settings`
+const tenantColumns = `id, slug, display_name, db_name, db_host, db_port, db_schema,
+	       status, plan, schema_version, version_group, region, cluster,
+	       created_at, updated_at, settings`
 
 // Registry provides access to tenant metadata stored in meta-database.
 type Registry interface {
@@ -39,6 +40,17 @@ type Registry interface {
 
 	// UpdateVersionGroup assigns a tenant to a version group (cloud mode).
 	UpdateVersionGroup(ctx context.Context, tenantID string, group string) error
+
+	// UpdatePlacement moves a tenant to another region/cluster/DB host.
+	// Used by the region move job after the database has been copied.
+	UpdatePlacement(ctx context.Context, tenantID string, p Placement) error
+
+	// UpdatePlan changes a tenant's subscription plan.
+	UpdatePlan(ctx context.Context, tenantID string, plan Plan) error
+
+	// MergeSettings merges set into the tenant's settings and removes the
+	// keys listed in unset.
+	MergeSettings(ctx context.Context, tenantID string, set map[string]any, unset []string) error
 }
 
 // PostgresRegistry implements Registry using meta-database PostgreSQL.
@@ -50,6 +62,19 @@ func NewPostgresRegistry(pool *pgxpool.Pool) *PostgresRegistry {
 	return &PostgresRegistry{pool: pool}
 }
 
+// CheckSchema verifies that the meta-database has the tables and columns this
+// binary reads. Used by the server startup self-check; a failure means the
+// meta schema must be (re)initialized with `tenant init-meta`.
+func (r *PostgresRegistry) CheckSchema(ctx context.Context) error {
+	if _, err := r.pool.Exec(ctx, `SELECT `+tenantColumns+` FROM tenants LIMIT 0`); err != nil {
+		return fmt.Errorf("tenants table: %w", err)
+	}
+	if _, err := r.pool.Exec(ctx, `SELECT tenant_id, version FROM tenant_migrations LIMIT 0`); err != nil {
+		return fmt.Errorf("tenant_migrations table: %w", err)
+	}
+	return nil
+}
+
 func (r *PostgresRegistry) GetByID(ctx context.Context, tenantID string) (*Tenant, error) {
 	var t Tenant
 	err := pgxscan.Get(ctx, r.pool, &t, `
@@ -125,10 +150,10 @@ func (r *PostgresRegistry) Create(ctx context.Context, t *Tenant) error {
 
 	// Return generated UUID.
 	err := r.pool.QueryRow(ctx, `
-		INSERT INTO tenants (slug, display_name, db_name, db_host, db_port, status, plan, settings)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO tenants (slug, display_name, db_name, db_host, db_port, db_schema, status, plan, region, cluster, settings)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id
-	`, t.Slug, t.DisplayName, t.DBName, t.DBHost, t.DBPort, t.Status, t.Plan, t.Settings).Scan(&t.ID)
+	`, t.Slug, t.DisplayName, t.DBName, t.DBHost, t.DBPort, t.DBSchema, t.Status, t.Plan, t.Region, t.Cluster, t.Settings).Scan(&t.ID)
 	if err != nil {
 		return fmt.Errorf("create tenant: %w", err)
 	}
@@ -180,4 +205,57 @@ func (r *PostgresRegistry) UpdateVersionGroup(ctx context.Context, tenantID stri
 	return nil
 }
 
+func (r *PostgresRegistry) UpdatePlacement(ctx context.Context, tenantID string, p Placement) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE tenants
+		SET region = $2, cluster = $3, db_host = $4, db_port = $5
+		WHERE id = $1
+	`, tenantID, p.Region, p.Cluster, p.DBHost, p.DBPort)
+	if err != nil {
+		return fmt.Errorf("update placement: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTenantNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRegistry) UpdatePlan(ctx context.Context, tenantID string, plan Plan) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE tenants
+		SET plan = $2
+		WHERE id = $1
+	`, tenantID, plan)
+	if err != nil {
+		return fmt.Errorf("update plan: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTenantNotFound
+	}
+	return nil
+}
+
+// MergeSettings merges set into the tenant's settings JSON and removes the
+// keys listed in unset.
+func (r *PostgresRegistry) MergeSettings(ctx context.Context, tenantID string, set map[string]any, unset []string) error {
+	if set == nil {
+		set = map[string]any{}
+	}
+	if unset == nil {
+		unset = []string{}
+	}
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE tenants
+		SET settings = (COALESCE(settings, '{}'::jsonb) - $3::text[]) || $2::jsonb
+		WHERE id = $1
+	`, tenantID, set, unset)
+	if err != nil {
+		return fmt.Errorf("update settings: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTenantNotFound
+	}
+	return nil
+}
+
 var _ Registry = (*PostgresRegistry)(nil)
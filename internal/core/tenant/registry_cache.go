@@ -0,0 +1,345 @@
+package tenant
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"metapus/pkg/logger"
+)
+
+// TenantsChangedChannel is the NOTIFY channel fired by the tenants table trigger
+// in the meta-database. Payload is the changed tenant ID.
+const TenantsChangedChannel = "tenants_changed"
+
+// CachedRegistry decorates a Registry with an in-memory snapshot of the tenants
+// table, invalidated via PostgreSQL LISTEN/NOTIFY on the meta-database.
+//
+// The whole table is cached as one snapshot (tenant counts are small), so
+// GetByID, ListActive and ListByVersionGroup are served by a single meta query.
+// The cache is bypassed while the LISTEN connection is down, because missed
+// notifications would otherwise leave it stale.
+type CachedRegistry struct {
+	next Registry
+	pool *pgxpool.Pool
+
+	mu        sync.RWMutex
+	snapshot  []*Tenant
+	byID      map[string]*Tenant
+	loaded    bool
+	listening bool
+	// generation is bumped on every invalidation so that a load racing
+	// with a notification does not store a stale snapshot.
+	generation uint64
+
+	// Lifecycle
+	lifecycleMu sync.Mutex
+	ctx         context.Context
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+	started     bool
+}
+
+// Compile-time interface check.
+var _ Registry = (*CachedRegistry)(nil)
+
+// NewCachedRegistry wraps next with a snapshot cache. pool must point to the
+// meta-database; it is used for the dedicated LISTEN connection.
+// Call Start to enable caching; until then all calls go to next.
+func NewCachedRegistry(next Registry, pool *pgxpool.Pool) *CachedRegistry {
+	return &CachedRegistry{next: next, pool: pool}
+}
+
+// Start begins listening for tenant change notifications.
+func (r *CachedRegistry) Start(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	r.lifecycleMu.Lock()
+	defer r.lifecycleMu.Unlock()
+	if r.started {
+		return
+	}
+	r.ctx, r.cancel = context.WithCancel(ctx)
+	r.started = true
+
+	r.wg.Add(1)
+	go r.listenLoop()
+}
+
+// Stop stops the listener and disables caching.
+func (r *CachedRegistry) Stop() {
+	r.lifecycleMu.Lock()
+	if !r.started {
+		r.lifecycleMu.Unlock()
+		return
+	}
+	cancel := r.cancel
+	r.started = false
+	r.cancel = nil
+	r.lifecycleMu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	r.wg.Wait()
+}
+
+// Invalidate drops the cached snapshot.
+func (r *CachedRegistry) Invalidate() {
+	r.mu.Lock()
+	r.invalidateLocked()
+	r.mu.Unlock()
+}
+
+func (r *CachedRegistry) invalidateLocked() {
+	r.snapshot = nil
+	r.byID = nil
+	r.loaded = false
+	r.generation++
+}
+
+func (r *CachedRegistry) setListening(v bool) {
+	r.mu.Lock()
+	r.listening = v
+	// Notifications may have been missed while disconnected.
+	r.invalidateLocked()
+	r.mu.Unlock()
+}
+
+// listenLoop keeps a dedicated LISTEN connection open, reconnecting on failure.
+func (r *CachedRegistry) listenLoop() {
+	defer r.wg.Done()
+	defer r.setListening(false)
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		default:
+		}
+
+		conn, err := r.pool.Acquire(r.ctx)
+		if err != nil {
+			if r.ctx.Err() != nil {
+				return
+			}
+			logger.Error(r.ctx, "failed to acquire connection for tenant LISTEN", "error", err)
+			if !r.sleep(time.Second) {
+				return
+			}
+			continue
+		}
+
+		if _, err := conn.Exec(r.ctx, "LISTEN "+TenantsChangedChannel); err != nil {
+			logger.Error(r.ctx, "failed to LISTEN for tenant changes", "error", err)
+			conn.Release()
+			if !r.sleep(time.Second) {
+				return
+			}
+			continue
+		}
+
+		r.setListening(true)
+		logger.Info(r.ctx, "tenant registry cache listening for changes")
+
+		r.waitForNotifications(conn)
+		r.setListening(false)
+		// The connection may be broken; don't return it to the pool.
+		conn.Conn().Close(context.Background())
+		conn.Release()
+	}
+}
+
+// sleep waits for d before a reconnect attempt. It returns false if the
+// registry was closed meanwhile.
+func (r *CachedRegistry) sleep(d time.Duration) bool {
+	select {
+	case <-r.ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// waitForNotifications blocks until the context is cancelled or the connection fails.
+func (r *CachedRegistry) waitForNotifications(conn *pgxpool.Conn) {
+	for {
+		notification, err := conn.Conn().WaitForNotification(r.ctx)
+		if err != nil {
+			if r.ctx.Err() == nil {
+				logger.Warn(r.ctx, "tenant LISTEN connection lost", "error", err)
+			}
+			return
+		}
+
+		logger.Debug(r.ctx, "tenant changed", "tenant_id", notification.Payload)
+		r.Invalidate()
+	}
+}
+
+// load returns the current snapshot, loading it from next when needed.
+// ok is false when caching is not active and the caller must go to next.
+func (r *CachedRegistry) load(ctx context.Context) (snapshot []*Tenant, byID map[string]*Tenant, ok bool, err error) {
+	r.mu.RLock()
+	if !r.listening {
+		r.mu.RUnlock()
+		return nil, nil, false, nil
+	}
+	if r.loaded {
+		snapshot, byID = r.snapshot, r.byID
+		r.mu.RUnlock()
+		return snapshot, byID, true, nil
+	}
+	gen := r.generation
+	r.mu.RUnlock()
+
+	tenants, err := r.next.ListAll(ctx)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	byID = make(map[string]*Tenant, len(tenants))
+	for _, t := range tenants {
+		byID[t.ID] = t
+	}
+
+	r.mu.Lock()
+	if r.listening && r.generation == gen {
+		r.snapshot, r.byID, r.loaded = tenants, byID, true
+	}
+	r.mu.Unlock()
+
+	return tenants, byID, true, nil
+}
+
+// filterTenants returns copies of snapshot entries matching keep, preserving order (by slug).
+func filterTenants(snapshot []*Tenant, keep func(*Tenant) bool) []*Tenant {
+	var result []*Tenant
+	for _, t := range snapshot {
+		if keep(t) {
+			result = append(result, copyTenant(t))
+		}
+	}
+	return result
+}
+
+// copyTenant returns a copy of a snapshot entry the caller may modify
+// without affecting the cache, including the nested settings values.
+func copyTenant(t *Tenant) *Tenant {
+	cp := *t
+	if t.Settings != nil {
+		cp.Settings = copySettingsValue(t.Settings).(map[string]any)
+	}
+	return &cp
+}
+
+// copySettingsValue deep-copies a value decoded from the settings JSONB.
+func copySettingsValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, val := range v {
+			m[k] = copySettingsValue(val)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v))
+		for i, val := range v {
+			s[i] = copySettingsValue(val)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+func (r *CachedRegistry) GetByID(ctx context.Context, tenantID string) (*Tenant, error) {
+	_, byID, ok, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if t, found := byID[tenantID]; found {
+			return copyTenant(t), nil
+		}
+	}
+	// Misses go to the database: the ID may be malformed (let next report it)
+	// or the tenant was created after the snapshot and its NOTIFY is in flight.
+	return r.next.GetByID(ctx, tenantID)
+}
+
+func (r *CachedRegistry) ListActive(ctx context.Context) ([]*Tenant, error) {
+	snapshot, _, ok, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return r.next.ListActive(ctx)
+	}
+	return filterTenants(snapshot, func(t *Tenant) bool { return t.Status == StatusActive }), nil
+}
+
+func (r *CachedRegistry) ListAll(ctx context.Context) ([]*Tenant, error) {
+	snapshot, _, ok, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return r.next.ListAll(ctx)
+	}
+	return filterTenants(snapshot, func(*Tenant) bool { return true }), nil
+}
+
+func (r *CachedRegistry) ListByVersionGroup(ctx context.Context, group string) ([]*Tenant, error) {
+	snapshot, _, ok, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return r.next.ListByVersionGroup(ctx, group)
+	}
+	return filterTenants(snapshot, func(t *Tenant) bool {
+		return t.Status == StatusActive && t.VersionGroup == group
+	}), nil
+}
+
+// Write methods go straight to next and drop the local snapshot immediately,
+// without waiting for the NOTIFY round-trip (read-your-writes).
+
+func (r *CachedRegistry) Create(ctx context.Context, t *Tenant) error {
+	defer r.Invalidate()
+	return r.next.Create(ctx, t)
+}
+
+func (r *CachedRegistry) UpdateStatusByID(ctx context.Context, tenantID string, status Status) error {
+	defer r.Invalidate()
+	return r.next.UpdateStatusByID(ctx, tenantID, status)
+}
+
+func (r *CachedRegistry) UpdateSchemaVersion(ctx context.Context, tenantID string, version int) error {
+	defer r.Invalidate()
+	return r.next.UpdateSchemaVersion(ctx, tenantID, version)
+}
+
+func (r *CachedRegistry) UpdateVersionGroup(ctx context.Context, tenantID string, group string) error {
+	defer r.Invalidate()
+	return r.next.UpdateVersionGroup(ctx, tenantID, group)
+}
+
+func (r *CachedRegistry) UpdatePlacement(ctx context.Context, tenantID string, p Placement) error {
+	defer r.Invalidate()
+	return r.next.UpdatePlacement(ctx, tenantID, p)
+}
+
+func (r *CachedRegistry) UpdatePlan(ctx context.Context, tenantID string, plan Plan) error {
+	defer r.Invalidate()
+	return r.next.UpdatePlan(ctx, tenantID, plan)
+}
+
+func (r *CachedRegistry) MergeSettings(ctx context.Context, tenantID string, set map[string]any, unset []string) error {
+	defer r.Invalidate()
+	return r.next.MergeSettings(ctx, tenantID, set, unset)
+}
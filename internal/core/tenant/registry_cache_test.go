@@ -0,0 +1,43 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCopyTenantDoesNotShareSettings(t *testing.T) {
+	cached := &Tenant{ID: "t1", Settings: map[string]any{
+		SettingClockOffset: "-24h",
+		"modules":          map[string]any{"crypto": true},
+		"regions":          []any{"eu"},
+	}}
+
+	cp := copyTenant(cached)
+	cp.Settings[SettingClockOffset] = "0s"
+	cp.Settings["modules"].(map[string]any)["crypto"] = false
+	cp.Settings["regions"].([]any)[0] = "us"
+
+	if cached.Settings[SettingClockOffset] != "-24h" ||
+		cached.Settings["modules"].(map[string]any)["crypto"] != true ||
+		cached.Settings["regions"].([]any)[0] != "eu" {
+		t.Errorf("changing the copy changed the cached tenant: %v", cached.Settings)
+	}
+	if copyTenant(&Tenant{}).Settings != nil {
+		t.Error("nil settings copied as an empty map")
+	}
+}
+
+func TestCachedRegistrySleepStopsOnClose(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &CachedRegistry{ctx: ctx}
+	cancel()
+
+	start := time.Now()
+	if r.sleep(time.Minute) {
+		t.Error("sleep returned true after close")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("sleep took %v after close", elapsed)
+	}
+}
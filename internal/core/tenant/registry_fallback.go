@@ -0,0 +1,339 @@
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"metapus/pkg/logger"
+)
+
+// FallbackConfig configures FallbackRegistry behavior.
+type FallbackConfig struct {
+	// RefreshPeriod is how often the snapshot of active tenants is refreshed
+	// from the meta-database (and persisted to SnapshotPath).
+	RefreshPeriod time.Duration
+
+	// MaxStaleness limits how old a snapshot may be to still be served while
+	// the meta-database is down (0 = no limit).
+	MaxStaleness time.Duration
+
+	// SnapshotPath is an optional file the snapshot is persisted to, so that a
+	// restarted instance can serve existing tenants before the meta-database
+	// comes back. Empty means in-memory only.
+	SnapshotPath string
+}
+
+// DefaultFallbackConfig returns production-safe defaults.
+func DefaultFallbackConfig() FallbackConfig {
+	return FallbackConfig{
+		RefreshPeriod: 1 * time.Minute,
+		MaxStaleness:  24 * time.Hour,
+	}
+}
+
+// fallbackSnapshot is the persisted form of the active tenants snapshot.
+type fallbackSnapshot struct {
+	TakenAt time.Time `json:"takenAt"`
+	Tenants []*Tenant `json:"tenants"`
+}
+
+// FallbackRegistry decorates a Registry with a last-known-good snapshot of
+// active tenants. When the meta-database is unavailable, read methods are
+// answered from the snapshot so that existing tenants keep working
+// (degraded mode). Write methods always go to next and fail as usual.
+//
+// Only active tenants are snapshotted: suspended or deleted tenants must not
+// become reachable because the meta-database is down.
+type FallbackRegistry struct {
+	next   Registry
+	config FallbackConfig
+
+	mu       sync.RWMutex
+	snapshot *fallbackSnapshot
+	byID     map[string]*Tenant
+	degraded bool
+
+	// Lifecycle
+	lifecycleMu sync.Mutex
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+// Compile-time interface check.
+var _ Registry = (*FallbackRegistry)(nil)
+
+// NewFallbackRegistry wraps next with a fallback snapshot.
+// If cfg.SnapshotPath points to an existing snapshot, it is loaded immediately.
+func NewFallbackRegistry(next Registry, cfg FallbackConfig) *FallbackRegistry {
+	r := &FallbackRegistry{next: next, config: cfg}
+	if cfg.SnapshotPath != "" {
+		if err := r.loadFile(); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.Warn(context.Background(), "failed to load tenant registry snapshot",
+				"path", cfg.SnapshotPath, "error", err)
+		}
+	}
+	return r
+}
+
+// Start begins refreshing the snapshot periodically.
+func (r *FallbackRegistry) Start(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	r.lifecycleMu.Lock()
+	defer r.lifecycleMu.Unlock()
+	if r.cancel != nil {
+		return
+	}
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	r.wg.Add(1)
+	go r.refreshLoop(ctx)
+}
+
+// Stop stops the refresh loop.
+func (r *FallbackRegistry) Stop() {
+	r.lifecycleMu.Lock()
+	cancel := r.cancel
+	r.cancel = nil
+	r.lifecycleMu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	r.wg.Wait()
+}
+
+// Degraded reports whether the registry is currently serving from the snapshot.
+func (r *FallbackRegistry) Degraded() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.degraded
+}
+
+func (r *FallbackRegistry) refreshLoop(ctx context.Context) {
+	defer r.wg.Done()
+
+	period := r.config.RefreshPeriod
+	if period <= 0 {
+		period = DefaultFallbackConfig().RefreshPeriod
+	}
+
+	r.refresh(ctx)
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.refresh(ctx)
+		}
+	}
+}
+
+// refresh reloads the snapshot from next. ListActive records the snapshot itself.
+func (r *FallbackRegistry) refresh(ctx context.Context) {
+	if _, err := r.ListActive(ctx); err != nil && ctx.Err() == nil {
+		logger.Warn(ctx, "tenant registry snapshot refresh failed", "error", err)
+	}
+}
+
+// store replaces the snapshot with a fresh list of active tenants.
+func (r *FallbackRegistry) store(ctx context.Context, tenants []*Tenant) {
+	snap := &fallbackSnapshot{TakenAt: time.Now(), Tenants: make([]*Tenant, 0, len(tenants))}
+	byID := make(map[string]*Tenant, len(tenants))
+	for _, t := range tenants {
+		cp := *t
+		snap.Tenants = append(snap.Tenants, &cp)
+		byID[cp.ID] = &cp
+	}
+
+	r.mu.Lock()
+	r.snapshot, r.byID = snap, byID
+	r.mu.Unlock()
+
+	r.recovered(ctx)
+
+	if r.config.SnapshotPath != "" {
+		if err := r.saveFile(snap); err != nil {
+			logger.Warn(ctx, "failed to persist tenant registry snapshot",
+				"path", r.config.SnapshotPath, "error", err)
+		}
+	}
+}
+
+// recovered leaves degraded mode after a successful meta-database call.
+func (r *FallbackRegistry) recovered(ctx context.Context) {
+	r.mu.Lock()
+	was := r.degraded
+	r.degraded = false
+	r.mu.Unlock()
+
+	if was {
+		logger.Info(ctx, "meta database available again, tenant registry left degraded mode")
+	}
+}
+
+// fallback returns the snapshot to serve after next failed with cause,
+// or nil if there is no usable snapshot.
+func (r *FallbackRegistry) fallback(ctx context.Context, cause error) *fallbackSnapshot {
+	r.mu.Lock()
+	snap := r.snapshot
+	if snap == nil || (r.config.MaxStaleness > 0 && time.Since(snap.TakenAt) > r.config.MaxStaleness) {
+		r.mu.Unlock()
+		return nil
+	}
+	was := r.degraded
+	r.degraded = true
+	r.mu.Unlock()
+
+	if !was {
+		logger.Error(ctx, "meta database unavailable, tenant registry serving from snapshot (degraded mode)",
+			"error", cause,
+			"snapshot_age", time.Since(snap.TakenAt).Round(time.Second).String(),
+			"tenant_count", len(snap.Tenants),
+		)
+	}
+	return snap
+}
+
+func (r *FallbackRegistry) loadFile() error {
+	data, err := os.ReadFile(r.config.SnapshotPath)
+	if err != nil {
+		return err
+	}
+	var snap fallbackSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("decode snapshot: %w", err)
+	}
+
+	byID := make(map[string]*Tenant, len(snap.Tenants))
+	for _, t := range snap.Tenants {
+		byID[t.ID] = t
+	}
+
+	r.mu.Lock()
+	r.snapshot, r.byID = &snap, byID
+	r.mu.Unlock()
+	return nil
+}
+
+// saveFile writes the snapshot atomically (temp file + rename).
+func (r *FallbackRegistry) saveFile(snap *fallbackSnapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("encode snapshot: %w", err)
+	}
+
+	dir := filepath.Dir(r.config.SnapshotPath)
+	tmp, err := os.CreateTemp(dir, ".tenants-snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), r.config.SnapshotPath)
+}
+
+func (r *FallbackRegistry) GetByID(ctx context.Context, tenantID string) (*Tenant, error) {
+	t, err := r.next.GetByID(ctx, tenantID)
+	if err == nil || errors.Is(err, ErrTenantNotFound) || ctx.Err() != nil {
+		if err == nil {
+			r.recovered(ctx)
+		}
+		return t, err
+	}
+
+	if snap := r.fallback(ctx, err); snap != nil {
+		r.mu.RLock()
+		cached, ok := r.byID[tenantID]
+		r.mu.RUnlock()
+		if ok {
+			cp := *cached
+			return &cp, nil
+		}
+	}
+	return nil, err
+}
+
+func (r *FallbackRegistry) ListActive(ctx context.Context) ([]*Tenant, error) {
+	tenants, err := r.next.ListActive(ctx)
+	if err == nil {
+		r.store(ctx, tenants)
+		return tenants, nil
+	}
+	if ctx.Err() != nil {
+		return nil, err
+	}
+
+	if snap := r.fallback(ctx, err); snap != nil {
+		return filterTenants(snap.Tenants, func(*Tenant) bool { return true }), nil
+	}
+	return nil, err
+}
+
+// ListAll has no fallback: the snapshot holds active tenants only, and
+// callers of ListAll (admin tooling) expect the complete list.
+func (r *FallbackRegistry) ListAll(ctx context.Context) ([]*Tenant, error) {
+	return r.next.ListAll(ctx)
+}
+
+func (r *FallbackRegistry) ListByVersionGroup(ctx context.Context, group string) ([]*Tenant, error) {
+	tenants, err := r.next.ListByVersionGroup(ctx, group)
+	if err == nil || ctx.Err() != nil {
+		if err == nil {
+			r.recovered(ctx)
+		}
+		return tenants, err
+	}
+
+	if snap := r.fallback(ctx, err); snap != nil {
+		return filterTenants(snap.Tenants, func(t *Tenant) bool { return t.VersionGroup == group }), nil
+	}
+	return nil, err
+}
+
+func (r *FallbackRegistry) Create(ctx context.Context, t *Tenant) error {
+	return r.next.Create(ctx, t)
+}
+
+func (r *FallbackRegistry) UpdateStatusByID(ctx context.Context, tenantID string, status Status) error {
+	return r.next.UpdateStatusByID(ctx, tenantID, status)
+}
+
+func (r *FallbackRegistry) UpdateSchemaVersion(ctx context.Context, tenantID string, version int) error {
+	return r.next.UpdateSchemaVersion(ctx, tenantID, version)
+}
+
+func (r *FallbackRegistry) UpdateVersionGroup(ctx context.Context, tenantID string, group string) error {
+	return r.next.UpdateVersionGroup(ctx, tenantID, group)
+}
+
+func (r *FallbackRegistry) UpdatePlacement(ctx context.Context, tenantID string, p Placement) error {
+	return r.next.UpdatePlacement(ctx, tenantID, p)
+}
+
+func (r *FallbackRegistry) UpdatePlan(ctx context.Context, tenantID string, plan Plan) error {
+	return r.next.UpdatePlan(ctx, tenantID, plan)
+}
+
+func (r *FallbackRegistry) MergeSettings(ctx context.Context, tenantID string, set map[string]any, unset []string) error {
+	return r.next.MergeSettings(ctx, tenantID, set, unset)
+}
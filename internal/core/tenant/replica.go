@@ -0,0 +1,55 @@
+package tenant
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// SettingReadReplica is the tenant settings key of the tenant's read
+// replica: an object {"db_host", "db_port"} of a streaming replica of the
+// tenant database, reached with the same database name and credentials.
+// The Manager opens a separate read pool for it; reads hinted with
+// tx.PreferReplica go there while it is healthy.
+const SettingReadReplica = "read_replica"
+
+// ReadReplica returns the read replica endpoint registered for the tenant.
+// A missing port means the port of the primary.
+func (t *Tenant) ReadReplica() (host string, port int, ok bool) {
+	raw, ok := t.Settings[SettingReadReplica].(map[string]any)
+	if !ok {
+		return "", 0, false
+	}
+	host, _ = raw["db_host"].(string)
+	if host == "" {
+		return "", 0, false
+	}
+	port = t.DBPort
+	switch p := raw["db_port"].(type) {
+	case float64: // JSON number
+		port = int(p)
+	case int:
+		port = p
+	case string:
+		if n, err := strconv.Atoi(p); err == nil {
+			port = n
+		}
+	}
+	return host, port, true
+}
+
+// ReplicaSetting returns the tenant settings value registering the read
+// replica at host:port.
+func ReplicaSetting(host string, port int) map[string]any {
+	return map[string]any{"db_host": host, "db_port": port}
+}
+
+// ReplicaDSN builds the connection string for the tenant's read replica.
+func (t *Tenant) ReplicaDSN(user, password string) (string, error) {
+	host, port, ok := t.ReadReplica()
+	if !ok {
+		return "", fmt.Errorf("tenant %s has no read replica", t.ID)
+	}
+	replica := *t
+	replica.DBHost, replica.DBPort = host, port
+	return replica.DSN(user, password), nil
+}
@@ -1,11 +1,16 @@
 // Package tenant provides multi-tenant database management for Database-per-Tenant architecture.
-// Each tenant has their own isolated PostgreSQL database.
+// Each tenant has their own isolated PostgreSQL database, or, in the shared-schema
+// mode for small tenants, their own schema within a database shared with other tenants.
 package tenant
 
 import (
 	"fmt"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
+
+	"metapus/internal/core/clock"
 )
 
 // Status represents tenant lifecycle state.
@@ -27,7 +32,13 @@ const (
 	// Blocks: all business requests.
 	StatusMigrationFailed Status = "migration_failed"
 
-	// StatusDeleted - tenant is marked for deletion
+	// StatusPendingDeletion - tenant is suspended and scheduled for deletion
+	// (see `tenant delete`). The database is kept until the grace period in
+	// settings deletion_due_at ends, so the deletion can still be cancelled.
+	StatusPendingDeletion Status = "pending_deletion"
+
+	// StatusDeleted - tenant database was archived and dropped; the registry
+	// record is kept for audit and to locate the archive.
 	StatusDeleted Status = "deleted"
 )
 
@@ -40,6 +51,14 @@ const (
 	PlanEnterprise Plan = "enterprise"
 )
 
+// Plans lists the known subscription plans.
+var Plans = []Plan{PlanStandard, PlanPremium, PlanEnterprise}
+
+// Valid reports whether p is a known plan.
+func (p Plan) Valid() bool {
+	return slices.Contains(Plans, p)
+}
+
 // Tenant represents a tenant record from meta-database.
 type Tenant struct {
 	ID             string         `db:"id"`
@@ -48,10 +67,13 @@ type Tenant struct {
 	DBName         string         `db:"db_name"`        // Database name
 	DBHost         string         `db:"db_host"`        // Database host
 	DBPort         int            `db:"db_port"`        // Database port
+	DBSchema       string         `db:"db_schema"`      // Schema in a shared database ("" = own database)
 	Status         Status         `db:"status"`
 	Plan           Plan           `db:"plan"`
 	SchemaVersion  int            `db:"schema_version"` // Highest applied migration number
 	VersionGroup   string         `db:"version_group"`  // Server version group (cloud mode)
+	Region         string         `db:"region"`         // Placement region (empty = default)
+	Cluster        string         `db:"cluster"`        // DB cluster within the region
 	CreatedAt      time.Time      `db:"created_at"`
 	UpdatedAt      time.Time      `db:"updated_at"`
 	Settings       map[string]any `db:"settings"` // Additional settings (JSONB)
@@ -74,29 +96,150 @@ func (t *Tenant) CanAcceptAdminRequests() bool {
 }
 
 // CanCreatePool returns true if a connection pool can be created for this tenant.
-// Pool creation is blocked only for suspended and deleted tenants.
+// Pool creation is blocked only for suspended and (pending) deleted tenants.
 // For migration_failed/updating, the database still exists and is reachable —
 // login, admin operations, and retry/rollback all need a working pool.
 func (t *Tenant) CanCreatePool() bool {
-	return t.Status != StatusSuspended && t.Status != StatusDeleted
+	return t.Status != StatusSuspended && t.Status != StatusPendingDeletion && t.Status != StatusDeleted
 }
 
 // DSN builds PostgreSQL connection string for this tenant's database.
+// Connections of a shared-schema tenant start with its search_path, so
+// pools, migrations and tools reach the tenant's schema unchanged.
 func (t *Tenant) DSN(user, password string) string {
 	return fmt.Sprintf(
-		"postgres://%s:%s@%s:%d/%s?sslmode=disable",
-		user, password, t.DBHost, t.DBPort, t.DBName,
+		"postgres://%s:%s@%s:%d/%s?sslmode=disable%s",
+		user, password, t.DBHost, t.DBPort, t.DBName, t.searchPathParam(),
 	)
 }
 
 // DSNWithSSL builds PostgreSQL connection string with SSL enabled.
 func (t *Tenant) DSNWithSSL(user, password, sslMode string) string {
 	return fmt.Sprintf(
-		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		user, password, t.DBHost, t.DBPort, t.DBName, sslMode,
+		"postgres://%s:%s@%s:%d/%s?sslmode=%s%s",
+		user, password, t.DBHost, t.DBPort, t.DBName, sslMode, t.searchPathParam(),
 	)
 }
 
+// SharedSchema reports whether the tenant lives in a schema of a shared
+// database rather than in its own database.
+func (t *Tenant) SharedSchema() bool {
+	return t.DBSchema != ""
+}
+
+// SearchPath returns the search_path of the tenant's connections: its
+// schema, then public for the extensions shared by all tenants of the
+// database. Empty for a tenant with its own database.
+func (t *Tenant) SearchPath() string {
+	if !t.SharedSchema() {
+		return ""
+	}
+	return t.DBSchema + ",public"
+}
+
+// searchPathParam is the DSN parameter that sets SearchPath on connect.
+func (t *Tenant) searchPathParam() string {
+	if !t.SharedSchema() {
+		return ""
+	}
+	return "&options=" + url.QueryEscape("-csearch_path="+t.SearchPath())
+}
+
+// NotifyPayload returns the payload of a NOTIFY received on a connection of
+// the tenant's database, and false when it belongs to another tenant. NOTIFY
+// channels are database-wide, so in a shared database the tenant_notify
+// trigger helper prefixes payloads with the schema ("t_tiny:<payload>");
+// payloads of a tenant with its own database are unprefixed.
+func (t *Tenant) NotifyPayload(payload string) (string, bool) {
+	if !t.SharedSchema() {
+		return payload, true
+	}
+	rest, ok := strings.CutPrefix(payload, t.DBSchema+":")
+	if !ok {
+		return "", false
+	}
+	return rest, true
+}
+
+// SchemaNameFor returns the schema of a shared-schema tenant with slug.
+func SchemaNameFor(slug string) string {
+	return "t_" + strings.ReplaceAll(strings.ToLower(slug), "-", "_")
+}
+
+// Placement returns the tenant's current placement.
+func (t *Tenant) Placement() Placement {
+	return Placement{Region: t.Region, Cluster: t.Cluster, DBHost: t.DBHost, DBPort: t.DBPort}
+}
+
+// Tenant settings keys that move the tenant's business clock (demo and
+// training tenants only). clock_frozen_at is an RFC 3339 timestamp the clock
+// stands still at; clock_offset is a Go duration added to real time
+// (e.g. "-720h" to rehearse last month's closing).
+const (
+	SettingClockFrozenAt = "clock_frozen_at"
+	SettingClockOffset   = "clock_offset"
+)
+
+// Tenant settings keys of the deletion workflow. deletion_due_at is the
+// RFC 3339 end of the grace period of a pending_deletion tenant;
+// deletion_archive is the final dump of a deleted tenant's database.
+const (
+	SettingDeletionDueAt   = "deletion_due_at"
+	SettingDeletionArchive = "deletion_archive"
+	SettingDeletedAt       = "deleted_at"
+)
+
+// DeletionDueAt returns when the grace period of a pending deletion ends.
+func (t *Tenant) DeletionDueAt() (time.Time, bool) {
+	s, ok := t.Settings[SettingDeletionDueAt].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	at, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return at, true
+}
+
+// Clock returns the business clock configured in tenant settings,
+// or clock.Real when none is set or the value is malformed.
+func (t *Tenant) Clock() clock.Clock {
+	if s, ok := t.Settings[SettingClockFrozenAt].(string); ok && s != "" {
+		if at, err := time.Parse(time.RFC3339, s); err == nil {
+			return clock.NewMock(at)
+		}
+	}
+	if s, ok := t.Settings[SettingClockOffset].(string); ok && s != "" {
+		if d, err := time.ParseDuration(s); err == nil && d != 0 {
+			return clock.Offset(clock.Real, d)
+		}
+	}
+	return clock.Real
+}
+
+// Placement describes where a tenant database physically lives.
+type Placement struct {
+	Region  string
+	Cluster string
+	DBHost  string
+	DBPort  int
+}
+
+// Validate checks if placement is complete.
+func (p Placement) Validate() error {
+	if p.Region == "" {
+		return fmt.Errorf("region is required")
+	}
+	if p.DBHost == "" {
+		return fmt.Errorf("db_host is required")
+	}
+	if p.DBPort <= 0 || p.DBPort > 65535 {
+		return fmt.Errorf("db_port must be between 1 and 65535")
+	}
+	return nil
+}
+
 // CreateTenantInput contains data for creating a new tenant.
 type CreateTenantInput struct {
 	Slug        string
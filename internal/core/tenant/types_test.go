@@ -0,0 +1,58 @@
+package tenant
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestTenantNotifyPayload(t *testing.T) {
+	own := &Tenant{DBName: "mt_acme"}
+	shared := &Tenant{DBName: "mt_shared", DBSchema: "t_tiny"}
+
+	tests := []struct {
+		name    string
+		tenant  *Tenant
+		payload string
+		want    string
+		ok      bool
+	}{
+		{"own database", own, `{"op":"created"}`, `{"op":"created"}`, true},
+		{"shared, own schema", shared, `t_tiny:{"op":"created"}`, `{"op":"created"}`, true},
+		{"shared, empty payload", shared, "t_tiny:", "", true},
+		{"shared, other schema", shared, `t_other:{"op":"created"}`, "", false},
+		{"shared, schema prefix of another", shared, "t_tiny2:key", "", false},
+		{"shared, unprefixed", shared, "key", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.tenant.NotifyPayload(tt.payload)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("NotifyPayload(%q) = %q, %v; want %q, %v", tt.payload, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTenantDSNStartsWithSearchPath(t *testing.T) {
+	shared := &Tenant{DBHost: "db", DBPort: 5432, DBName: "mt_shared", DBSchema: "t_tiny"}
+	cfg, err := pgxpool.ParseConfig(shared.DSN("app", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.ConnConfig.RuntimeParams["options"]; got != "-csearch_path=t_tiny,public" {
+		t.Errorf("startup options = %q", got)
+	}
+	if cfg.ConnConfig.Database != "mt_shared" {
+		t.Errorf("database = %q", cfg.ConnConfig.Database)
+	}
+
+	own := &Tenant{DBHost: "db", DBPort: 5432, DBName: "mt_acme"}
+	cfg, err = pgxpool.ParseConfig(own.DSN("app", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := cfg.ConnConfig.RuntimeParams["options"]; ok {
+		t.Errorf("own database: startup options = %q", got)
+	}
+}
@@ -0,0 +1,235 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// SettingSchemaDrift is the tenant settings key of the last detected schema
+// drift: an object {"checked_at", "applied_version", "issues"}. It is removed
+// when a verification finds the schema in order.
+const SettingSchemaDrift = "schema_drift"
+
+// SchemaState is what a tenant database reports about its schema.
+type SchemaState struct {
+	// AppliedVersion is the highest core migration applied to the database;
+	// LatestVersion is the highest core migration shipped with the binary.
+	AppliedVersion int
+	LatestVersion  int
+	// Pending lists the core migrations not applied to the database,
+	// including gaps below AppliedVersion.
+	Pending []int64
+	// Tables maps each critical table to the checksum of its columns; tables
+	// missing from the database are absent.
+	Tables map[string]string
+}
+
+// InspectFunc reads the schema state of the database at dsn.
+type InspectFunc func(ctx context.Context, dsn string) (SchemaState, error)
+
+// SchemaDrift is the outcome of verifying one tenant database.
+type SchemaDrift struct {
+	Tenant *Tenant
+	State  SchemaState
+	// MissingTables and ChangedTables are the critical tables absent from
+	// the database or whose checksum differs from the baseline.
+	MissingTables []string
+	ChangedTables []string
+	Err           error
+}
+
+// Drifted reports whether the schema differs from the expected one or the
+// registry records a wrong schema version.
+func (d SchemaDrift) Drifted() bool {
+	return len(d.Issues()) > 0
+}
+
+// Repairable reports whether re-applying migrations can fix the drift:
+// migrations are missing or the registry records a wrong schema version.
+func (d SchemaDrift) Repairable() bool {
+	return d.Err == nil && (len(d.State.Pending) > 0 || d.Tenant.SchemaVersion != d.State.AppliedVersion)
+}
+
+// Issues describes the drift, one line per problem.
+func (d SchemaDrift) Issues() []string {
+	if d.Err != nil {
+		return nil
+	}
+	var issues []string
+	if d.State.AppliedVersion != d.State.LatestVersion {
+		issues = append(issues, fmt.Sprintf("schema version %d, expected %d", d.State.AppliedVersion, d.State.LatestVersion))
+	}
+	if d.Tenant.SchemaVersion != d.State.AppliedVersion {
+		issues = append(issues, fmt.Sprintf("registry records schema version %d, database is at %d", d.Tenant.SchemaVersion, d.State.AppliedVersion))
+	}
+	if len(d.State.Pending) > 0 {
+		issues = append(issues, fmt.Sprintf("missing migrations %v", d.State.Pending))
+	}
+	for _, table := range d.MissingTables {
+		issues = append(issues, "missing table "+table)
+	}
+	for _, table := range d.ChangedTables {
+		issues = append(issues, "columns of "+table+" differ from the baseline")
+	}
+	return issues
+}
+
+// Verifier detects schema drift of tenant databases and records it in the
+// registry.
+type Verifier struct {
+	registry Registry
+	dsn      func(*Tenant) string
+	inspect  InspectFunc
+	tables   []string
+}
+
+// NewVerifier creates a Verifier reaching tenant databases via dsn and
+// reading their schema with inspect. tables are the critical tables whose
+// checksums are compared.
+func NewVerifier(registry Registry, dsn func(*Tenant) string, inspect InspectFunc, tables []string) *Verifier {
+	return &Verifier{registry: registry, dsn: dsn, inspect: inspect, tables: tables}
+}
+
+// VerifyAll inspects the databases of tenants, at most concurrency at a time
+// (DefaultMigrationConcurrency if not positive), and compares them against
+// the expected schema. Table checksums are compared against baseline, or,
+// if it is nil, against the checksum most tenants at the latest version
+// share. The drift of each tenant is recorded in its settings. The results
+// are returned in tenants order.
+func (v *Verifier) VerifyAll(ctx context.Context, tenants []*Tenant, concurrency int, baseline map[string]string) []SchemaDrift {
+	if concurrency <= 0 {
+		concurrency = DefaultMigrationConcurrency
+	}
+	results := make([]SchemaDrift, len(tenants))
+
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+	for i, t := range tenants {
+		g.Go(func() error {
+			res := SchemaDrift{Tenant: t, Err: ctx.Err()}
+			if res.Err == nil {
+				res.State, res.Err = v.inspect(ctx, v.dsn(t))
+			}
+			results[i] = res
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	if baseline == nil {
+		baseline = MajorityChecksums(results)
+	}
+	for i := range results {
+		v.compare(&results[i], baseline)
+		if results[i].Err == nil {
+			if err := v.record(ctx, results[i]); err != nil {
+				results[i].Err = fmt.Errorf("record drift: %w", err)
+			}
+		}
+	}
+	return results
+}
+
+// Inspect reads the schema state of the database of t. It is used to take
+// the baseline checksums from a reference tenant.
+func (v *Verifier) Inspect(ctx context.Context, t *Tenant) (SchemaState, error) {
+	return v.inspect(ctx, v.dsn(t))
+}
+
+// compare fills the table drift of res against baseline.
+func (v *Verifier) compare(res *SchemaDrift, baseline map[string]string) {
+	if res.Err != nil {
+		return
+	}
+	for _, table := range v.tables {
+		sum, ok := res.State.Tables[table]
+		switch {
+		case !ok:
+			res.MissingTables = append(res.MissingTables, table)
+		case baseline[table] != "" && sum != baseline[table]:
+			res.ChangedTables = append(res.ChangedTables, table)
+		}
+	}
+}
+
+// record stores the drift of res in the tenant settings, or clears a
+// previously recorded one, in the registry and in res.Tenant.
+func (v *Verifier) record(ctx context.Context, res SchemaDrift) error {
+	t := res.Tenant
+	if !res.Drifted() {
+		if _, ok := t.Settings[SettingSchemaDrift]; !ok {
+			return nil
+		}
+		if err := v.registry.MergeSettings(ctx, t.ID, nil, []string{SettingSchemaDrift}); err != nil {
+			return err
+		}
+		delete(t.Settings, SettingSchemaDrift)
+		return nil
+	}
+	drift := map[string]any{
+		"checked_at":      time.Now().UTC().Format(time.RFC3339),
+		"applied_version": res.State.AppliedVersion,
+		"issues":          res.Issues(),
+	}
+	if err := v.registry.MergeSettings(ctx, t.ID, map[string]any{SettingSchemaDrift: drift}, nil); err != nil {
+		return err
+	}
+	if t.Settings == nil {
+		t.Settings = map[string]any{}
+	}
+	t.Settings[SettingSchemaDrift] = drift
+	return nil
+}
+
+// MajorityChecksums returns, per table, the checksum most of the inspected
+// tenants at the latest schema version share.
+func MajorityChecksums(results []SchemaDrift) map[string]string {
+	counts := map[string]map[string]int{} // table → checksum → tenants
+	for _, res := range results {
+		if res.Err != nil || res.State.AppliedVersion != res.State.LatestVersion {
+			continue
+		}
+		for table, sum := range res.State.Tables {
+			if counts[table] == nil {
+				counts[table] = map[string]int{}
+			}
+			counts[table][sum]++
+		}
+	}
+	baseline := make(map[string]string, len(counts))
+	for table, sums := range counts {
+		// Sorted so that ties resolve the same way on every run.
+		for _, sum := range slices.Sorted(maps.Keys(sums)) {
+			if sums[sum] > sums[baseline[table]] {
+				baseline[table] = sum
+			}
+		}
+	}
+	return baseline
+}
+
+// RecordedSchemaDrift returns the issues of the drift last recorded for the
+// tenant, if any.
+func (t *Tenant) RecordedSchemaDrift() ([]string, bool) {
+	raw, ok := t.Settings[SettingSchemaDrift].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	var issues []string
+	switch list := raw["issues"].(type) {
+	case []string:
+		issues = list
+	case []any:
+		for _, issue := range list {
+			if s, ok := issue.(string); ok {
+				issues = append(issues, s)
+			}
+		}
+	}
+	return issues, true
+}
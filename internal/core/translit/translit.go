@@ -0,0 +1,83 @@
+// Package translit converts Cyrillic text to Latin and builds catalog codes
+// from names ("ООО Ромашка" → "OOO-ROMASHKA").
+//
+// Russian letters follow the passport transliteration (ICAO Doc 9303, used in
+// Russian passports since 2014): ж → zh, х → kh, ц → ts, щ → shch, ю → iu,
+// я → ia, ъ → ie, ь is dropped. Ukrainian and Belarusian letters outside the
+// Russian alphabet use their common Latin forms.
+package translit
+
+import (
+	"strings"
+	"unicode"
+)
+
+var letters = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "ie", 'ы': "y", 'ь': "", 'э': "e", 'ю': "iu", 'я': "ia",
+
+	// Ukrainian, Belarusian
+	'і': "i", 'ї': "i", 'є': "ie", 'ґ': "g", 'ў': "u",
+}
+
+// Latin transliterates Cyrillic letters of s, keeping the case of the first
+// letter of each replacement ("Щука" → "Shchuka"); other characters are kept.
+func Latin(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		lat, ok := letters[unicode.ToLower(r)]
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+		if unicode.IsUpper(r) && lat != "" {
+			b.WriteString(strings.ToUpper(lat[:1]))
+			b.WriteString(lat[1:])
+			continue
+		}
+		b.WriteString(lat)
+	}
+	return b.String()
+}
+
+// Code builds an upper-case code of at most maxLen characters from name:
+// transliterated letters and digits, every other run of characters replaced
+// by a single '-'. Cut at a word boundary when a whole word fits. Returns ""
+// when name has no letters or digits.
+func Code(name string, maxLen int) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToUpper(Latin(name)) {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+		default:
+			dash = true
+		}
+	}
+	return Truncate(b.String(), maxLen)
+}
+
+// Truncate cuts code to at most maxLen bytes, preferring the last '-' in the
+// kept part when it leaves at least half of maxLen, and never ending with
+// '-'. code must be ASCII (as built by Code).
+func Truncate(code string, maxLen int) string {
+	if maxLen <= 0 || len(code) <= maxLen {
+		return code
+	}
+	cut := code[:maxLen]
+	if code[maxLen] != '-' {
+		if i := strings.LastIndexByte(cut, '-'); i >= maxLen/2 {
+			cut = cut[:i]
+		}
+	}
+	return strings.TrimRight(cut, "-")
+}
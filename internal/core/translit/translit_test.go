@@ -0,0 +1,58 @@
+package translit
+
+import "testing"
+
+func TestLatin(t *testing.T) {
+	tests := map[string]string{
+		"Щука":                  "Shchuka",
+		"Юлия Хабарова":         "Iuliia Khabarova",
+		"подъезд, объём":        "podieezd, obieem",
+		"Соль йодированная 1кг": "Sol iodirovannaia 1kg",
+		"Київ":                  "Kiiv",
+		"ACME Ltd.":             "ACME Ltd.",
+	}
+	for in, want := range tests {
+		if got := Latin(in); got != want {
+			t.Errorf("Latin(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		maxLen int
+		want   string
+	}{
+		{`ООО "Ромашка"`, 20, "OOO-ROMASHKA"},
+		{"  Молоко 3,2% 1 л ", 20, "MOLOKO-3-2-1-L"},
+		{"Жёлтый щит", 50, "ZHELTYI-SHCHIT"},
+		{"Общество с ограниченной ответственностью", 20, "OBSHCHESTVO-S"},
+		{"Электродрельпрофессиональная", 10, "ELEKTRODRE"},
+		{"—", 20, ""},
+	}
+	for _, tt := range tests {
+		if got := Code(tt.name, tt.maxLen); got != tt.want {
+			t.Errorf("Code(%q, %d) = %q, want %q", tt.name, tt.maxLen, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		code   string
+		maxLen int
+		want   string
+	}{
+		{"OOO-ROMASHKA", 20, "OOO-ROMASHKA"},
+		{"OOO-ROMASHKA", 4, "OOO"},
+		{"OOO-ROMASHKA", 3, "OOO"},
+		{"AB-CDEFGHIJ", 8, "AB-CDEFG"},
+		{"ABCDEF-GH", 8, "ABCDEF"},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.code, tt.maxLen); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.code, tt.maxLen, got, tt.want)
+		}
+	}
+}
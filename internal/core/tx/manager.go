@@ -30,3 +30,21 @@ type ReadOnlyManager interface {
 	// Attempts to modify data will fail.
 	ReadOnly(ctx context.Context, fn func(ctx context.Context) error) error
 }
+
+// replicaKey is the context key of the read replica hint.
+type replicaKey struct{}
+
+// PreferReplica hints that the reads made with ctx may be served by the
+// tenant's read replica, if it has a healthy one. Only reads outside a
+// transaction and read-only transactions follow the hint; the replica may
+// lag behind the primary, so use it for listings, lookups and reports, not
+// for reads a change is based on.
+func PreferReplica(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaKey{}, true)
+}
+
+// PrefersReplica reports whether ctx carries the PreferReplica hint.
+func PrefersReplica(ctx context.Context) bool {
+	v, _ := ctx.Value(replicaKey{}).(bool)
+	return v
+}
@@ -10,39 +10,9 @@ import (
 	"strings"
 
 	"github.com/shopspring/decimal"
+	"github.com/ugorji/go/codec"
 )
 
-// Money represents a monetary value with full precision.
-// Uses decimal.Decimal to avoid floating-point errors.
-type Money = decimal.Decimal
-
-// NewMoney creates a Money value from a float.
-// WARNING: Use NewMoneyFromString for precise values.
-func NewMoney(f float64) Money {
-	return decimal.NewFromFloat(f)
-}
-
-// NewMoneyFromString creates a Money value from a string.
-// This is the preferred method for monetary values.
-func NewMoneyFromString(s string) (Money, error) {
-	return decimal.NewFromString(s)
-}
-
-// MustMoney creates a Money value from a string, panics on error.
-// Use only for constants.
-func MustMoney(s string) Money {
-	d, err := decimal.NewFromString(s)
-	if err != nil {
-		panic(err)
-	}
-	return d
-}
-
-// Zero returns zero Money value.
-func Zero() Money {
-	return decimal.Zero
-}
-
 // Quantity is a fixed-point quantity with 4 decimal places (scale = 1e4).
 //
 // Rationale:
@@ -78,6 +48,13 @@ func (q Quantity) Abs() Quantity {
 	return q
 }
 
+// MulCoefficient converts a quantity entered in a unit holding coefficient
+// base units (e.g. 12 for a box of 12 pcs) to base units, truncated to the
+// quantity precision.
+func (q Quantity) MulCoefficient(coefficient decimal.Decimal) Quantity {
+	return Quantity(decimal.NewFromInt(int64(q)).Mul(coefficient).IntPart())
+}
+
 // String returns a decimal string with 4 fractional digits.
 func (q Quantity) String() string {
 	neg := q < 0
@@ -129,6 +106,44 @@ func (q *Quantity) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// CodecEncodeSelf encodes Quantity as a MessagePack number, like MarshalJSON
+// (implements codec.Selfer; the binary form would be the scaled integer).
+func (q Quantity) CodecEncodeSelf(e *codec.Encoder) {
+	e.MustEncode(q.Float64())
+}
+
+// CodecDecodeSelf decodes Quantity from a MessagePack number or string.
+func (q *Quantity) CodecDecodeSelf(d *codec.Decoder) {
+	var v any
+	d.MustDecode(&v)
+	switch v := v.(type) {
+	case float64:
+		*q = NewQuantityFromFloat64(v)
+	case float32:
+		*q = NewQuantityFromFloat64(float64(v))
+	case int64:
+		*q = Quantity(v * QuantityScale)
+	case uint64:
+		*q = Quantity(int64(v) * QuantityScale)
+	case []byte:
+		parsed, err := parseQuantityString(string(v))
+		if err != nil {
+			panic(err)
+		}
+		*q = parsed
+	case string:
+		parsed, err := parseQuantityString(v)
+		if err != nil {
+			panic(err)
+		}
+		*q = parsed
+	case nil:
+		*q = 0
+	default:
+		panic(fmt.Errorf("parse quantity: unsupported value %T", v))
+	}
+}
+
 func parseQuantityString(s string) (Quantity, error) {
 	s = strings.TrimSpace(s)
 	if s == "" {
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ugorji/go/codec"
+)
+
+func TestQuantity_MsgpackRoundTrip(t *testing.T) {
+	h := &codec.MsgpackHandle{}
+	for _, q := range []Quantity{0, NewQuantityFromFloat64(1.5), NewQuantityFromFloat64(-2.125), Quantity(123_456_789_0000)} {
+		var body []byte
+		if err := codec.NewEncoderBytes(&body, h).Encode(q); err != nil {
+			t.Fatalf("encode %v: %v", q, err)
+		}
+		var got Quantity
+		if err := codec.NewDecoderBytes(body, h).Decode(&got); err != nil {
+			t.Fatalf("decode %v: %v", q, err)
+		}
+		if got != q {
+			t.Errorf("round trip %v = %v", q, got)
+		}
+	}
+
+	// Integers and numeric strings from other encoders are accepted too.
+	for _, tc := range []struct {
+		in   any
+		want Quantity
+	}{
+		{int64(7), NewQuantityFromFloat64(7)},
+		{"3.25", NewQuantityFromFloat64(3.25)},
+	} {
+		var body []byte
+		if err := codec.NewEncoderBytes(&body, h).Encode(tc.in); err != nil {
+			t.Fatal(err)
+		}
+		var got Quantity
+		if err := codec.NewDecoderBytes(body, h).Decode(&got); err != nil {
+			t.Fatalf("decode %#v: %v", tc.in, err)
+		}
+		if got != tc.want {
+			t.Errorf("decode %#v = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+
+	var bad Quantity
+	body := []byte{0x80} // empty map
+	if err := codec.NewDecoderBytes(body, h).Decode(&bad); err == nil {
+		t.Error("decode of a map succeeded")
+	}
+}
@@ -0,0 +1,195 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/id"
+)
+
+// ErrCurrencyMismatch is returned when an operation combines amounts in different currencies.
+var ErrCurrencyMismatch = errors.New("money: currency mismatch")
+
+// Money is an amount in minor units with its currency attached.
+//
+// Arithmetic refuses to combine different currencies. A zero amount without a
+// currency (the zero value) is the neutral element and adopts the currency of
+// the other operand, so `var total Money` works as an accumulator.
+//
+// Storage stays as separate columns (amount BIGINT + currency_id UUID);
+// Money is assembled by models, e.g. GoodsReceipt.Total().
+type Money struct {
+	Amount     MinorUnits
+	CurrencyID id.ID
+}
+
+// NewMoney creates Money from minor units and a currency.
+func NewMoney(amount MinorUnits, currencyID id.ID) Money {
+	return Money{Amount: amount, CurrencyID: currencyID}
+}
+
+// ZeroMoney returns a zero amount in the given currency.
+func ZeroMoney(currencyID id.ID) Money {
+	return Money{CurrencyID: currencyID}
+}
+
+// SameCurrency reports whether m and o can be combined.
+func (m Money) SameCurrency(o Money) bool {
+	return m.CurrencyID == o.CurrencyID || m.isNeutral() || o.isNeutral()
+}
+
+// isNeutral reports a currency-less zero.
+func (m Money) isNeutral() bool {
+	return m.Amount == 0 && id.IsNil(m.CurrencyID)
+}
+
+// currencyWith returns the currency of the result of combining m and o.
+func (m Money) currencyWith(o Money) (id.ID, error) {
+	switch {
+	case m.CurrencyID == o.CurrencyID:
+		return m.CurrencyID, nil
+	case m.isNeutral():
+		return o.CurrencyID, nil
+	case o.isNeutral():
+		return m.CurrencyID, nil
+	}
+	return id.Nil(), fmt.Errorf("%w: %s vs %s", ErrCurrencyMismatch, m.CurrencyID, o.CurrencyID)
+}
+
+// --- Arithmetic ---
+
+// Add returns m + o. Fails with ErrCurrencyMismatch for different currencies.
+func (m Money) Add(o Money) (Money, error) {
+	cur, err := m.currencyWith(o)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount + o.Amount, CurrencyID: cur}, nil
+}
+
+// Sub returns m - o. Fails with ErrCurrencyMismatch for different currencies.
+func (m Money) Sub(o Money) (Money, error) {
+	cur, err := m.currencyWith(o)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount - o.Amount, CurrencyID: cur}, nil
+}
+
+// MustAdd returns m + o. Panics on currency mismatch (financial invariant);
+// use where both operands come from the same document.
+func (m Money) MustAdd(o Money) Money {
+	r, err := m.Add(o)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// MustSub returns m - o. Panics on currency mismatch (financial invariant).
+func (m Money) MustSub(o Money) Money {
+	r, err := m.Sub(o)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Sum adds amounts that must share one currency.
+// Returns a currency-less zero for an empty list.
+func Sum(amounts ...Money) (Money, error) {
+	var total Money
+	for _, a := range amounts {
+		var err error
+		if total, err = total.Add(a); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money { return Money{Amount: m.Amount.Neg(), CurrencyID: m.CurrencyID} }
+
+// Abs returns |m|.
+func (m Money) Abs() Money { return Money{Amount: m.Amount.Abs(), CurrencyID: m.CurrencyID} }
+
+func (m Money) IsZero() bool     { return m.Amount.IsZero() }
+func (m Money) IsPositive() bool { return m.Amount.IsPositive() }
+func (m Money) IsNegative() bool { return m.Amount.IsNegative() }
+
+// --- Comparison ---
+
+// Cmp compares m and o: -1 if m < o, 0 if equal, +1 if m > o.
+// Fails with ErrCurrencyMismatch for different currencies.
+func (m Money) Cmp(o Money) (int, error) {
+	if _, err := m.currencyWith(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// --- Conversion ---
+
+// ToDecimal converts to major units; decimalPlaces come from the currency catalog.
+func (m Money) ToDecimal(decimalPlaces int) decimal.Decimal {
+	return m.Amount.ToDecimal(decimalPlaces)
+}
+
+// String returns "<minor units> <currency id>" for logs and errors.
+func (m Money) String() string {
+	return fmt.Sprintf("%d %s", int64(m.Amount), m.CurrencyID)
+}
+
+// --- JSON ---
+
+type moneyJSON struct {
+	Amount     MinorUnits `json:"amount"`
+	CurrencyID *id.ID     `json:"currencyId,omitempty"`
+}
+
+// MarshalJSON encodes Money as {"amount": <minor units>, "currencyId": "<uuid>"}.
+// The amount uses the same number encoding as MinorUnits in existing DTOs.
+func (m Money) MarshalJSON() ([]byte, error) {
+	out := moneyJSON{Amount: m.Amount}
+	if !id.IsNil(m.CurrencyID) {
+		out.CurrencyID = &m.CurrencyID
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes the object form or, for compatibility with DTO fields
+// that carry the amount alone (currency in a sibling currencyId field), a bare
+// number or numeric string. The bare form leaves CurrencyID unset.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var in moneyJSON
+		if err := json.Unmarshal(data, &in); err != nil {
+			return fmt.Errorf("parse Money: %w", err)
+		}
+		m.Amount = in.Amount
+		m.CurrencyID = id.Nil()
+		if in.CurrencyID != nil {
+			m.CurrencyID = *in.CurrencyID
+		}
+		return nil
+	}
+
+	var amount MinorUnits
+	if err := amount.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*m = Money{Amount: amount}
+	return nil
+}
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"metapus/internal/core/id"
+)
+
+func TestMoney_Arithmetic(t *testing.T) {
+	rub := id.New()
+	usd := id.New()
+
+	a := NewMoney(10_000, rub)
+	b := NewMoney(2_500, rub)
+
+	sum, err := a.Add(b)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if sum != NewMoney(12_500, rub) {
+		t.Errorf("Add = %v, want 12500 %s", sum, rub)
+	}
+
+	diff, err := b.Sub(a)
+	if err != nil {
+		t.Fatalf("Sub: %v", err)
+	}
+	if diff.Amount != -7_500 || !diff.IsNegative() {
+		t.Errorf("Sub = %v, want -7500", diff)
+	}
+
+	if _, err := a.Add(NewMoney(1, usd)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add across currencies: err = %v, want ErrCurrencyMismatch", err)
+	}
+	if _, err := a.Cmp(NewMoney(1, usd)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Cmp across currencies: err = %v, want ErrCurrencyMismatch", err)
+	}
+}
+
+func TestMoney_ZeroValueIsNeutral(t *testing.T) {
+	rub := id.New()
+
+	var total Money
+	total = total.MustAdd(NewMoney(100, rub))
+	if total.CurrencyID != rub || total.Amount != 100 {
+		t.Errorf("accumulated = %v, want 100 %s", total, rub)
+	}
+
+	// A currency-less non-zero amount is not neutral.
+	if _, err := NewMoney(100, rub).Add(Money{Amount: 1}); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("err = %v, want ErrCurrencyMismatch", err)
+	}
+}
+
+func TestSum(t *testing.T) {
+	rub := id.New()
+	got, err := Sum(NewMoney(1, rub), NewMoney(2, rub), ZeroMoney(rub))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != NewMoney(3, rub) {
+		t.Errorf("Sum = %v", got)
+	}
+	if _, err := Sum(NewMoney(1, rub), NewMoney(1, id.New())); err == nil {
+		t.Error("Sum across currencies succeeded")
+	}
+}
+
+func TestMustAdd_PanicsOnMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	NewMoney(1, id.New()).MustAdd(NewMoney(1, id.New()))
+}
+
+func TestMoney_JSON(t *testing.T) {
+	rub := id.MustParse("0190a7c2-3b4d-7e8f-9a0b-1c2d3e4f5a6b")
+	m := NewMoney(12345, rub)
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"amount":12345,"currencyId":"0190a7c2-3b4d-7e8f-9a0b-1c2d3e4f5a6b"}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var back Money
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back != m {
+		t.Errorf("round trip = %v, want %v", back, m)
+	}
+
+	// Bare amounts (current DTO encoding) are accepted without a currency.
+	for _, in := range []string{`12345`, `"12345"`} {
+		var bare Money
+		if err := json.Unmarshal([]byte(in), &bare); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", in, err)
+		}
+		if bare.Amount != 12345 || !id.IsNil(bare.CurrencyID) {
+			t.Errorf("Unmarshal(%s) = %v", in, bare)
+		}
+	}
+}
@@ -5,8 +5,8 @@ package version
 
 // ExpectedSchemaVersion is the highest goose migration number shipped with this binary.
 // It MUST be updated whenever a new migration file is added to db/migrations/.
-// Current: 00020_doc_basis_fields.sql
-const ExpectedSchemaVersion = 20
+// Current: 00089_reg_stock_correction_lines.sql (guarded by TestExpectedSchemaVersionMatchesMigrations)
+const ExpectedSchemaVersion = 89
 
 // CompatibleSchema returns true when the tenant's schema is at the expected version.
 // In future this could allow a range (e.g. ExpectedSchemaVersion-1..ExpectedSchemaVersion),
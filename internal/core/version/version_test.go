@@ -0,0 +1,29 @@
+package version
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestExpectedSchemaVersionMatchesMigrations(t *testing.T) {
+	entries, err := os.ReadDir("../../../db/migrations")
+	if err != nil {
+		t.Fatalf("read migrations: %v", err)
+	}
+
+	latest := 0
+	for _, e := range entries {
+		prefix, _, ok := strings.Cut(e.Name(), "_")
+		if !ok || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+		if n, err := strconv.Atoi(prefix); err == nil && n > latest {
+			latest = n
+		}
+	}
+	if latest != ExpectedSchemaVersion {
+		t.Fatalf("ExpectedSchemaVersion = %d, latest core migration is %d", ExpectedSchemaVersion, latest)
+	}
+}
@@ -0,0 +1,197 @@
+package accountexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// FileFormat is the encoding of the table files in an archive.
+type FileFormat string
+
+const (
+	// FormatNDJSON writes one JSON object per line (JSONL). The default,
+	// and the only format accountimport reads.
+	FormatNDJSON FileFormat = "ndjson"
+	// FormatCSV writes a header row and one row per record. Scalars are
+	// written as text, JSON values (attributes, arrays) as JSON; NULL is empty.
+	FormatCSV FileFormat = "csv"
+)
+
+// Progress is reported after each written table.
+type Progress struct {
+	TablesTotal int
+	TablesDone  int
+	Rows        int64  // rows written so far, all tables
+	Table       string // the table just written
+}
+
+// ArchiveOptions configures WriteArchive.
+type ArchiveOptions struct {
+	Format FileFormat // empty means FormatNDJSON
+
+	// Progress, when set, is called after each table; an error aborts the
+	// export.
+	Progress func(ctx context.Context, p Progress) error
+}
+
+// WriteArchive streams the archive of the tenant database behind source to
+// w: every exported table from one consistent snapshot, then manifest.json.
+// Rows are not buffered, so w may be a file of any size.
+func WriteArchive(ctx context.Context, source DataSource, w io.Writer, tenantID string, opts ArchiveOptions) (*Manifest, error) {
+	if opts.Format == "" {
+		opts.Format = FormatNDJSON
+	}
+	if opts.Format != FormatNDJSON && opts.Format != FormatCSV {
+		return nil, fmt.Errorf("unsupported export format %q", opts.Format)
+	}
+
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		TenantID:      tenantID,
+		CreatedAt:     time.Now().UTC(),
+		Format:        opts.Format,
+	}
+	zw := zip.NewWriter(w)
+
+	err := source.Snapshot(ctx, func(ctx context.Context) error {
+		all, err := source.ListTables(ctx)
+		if err != nil {
+			return fmt.Errorf("list tables: %w", err)
+		}
+		tables := make([]TableInfo, 0, len(all))
+		for _, t := range all {
+			if _, ok := TableKind(t.Name); ok {
+				tables = append(tables, t)
+			}
+		}
+		sort.Slice(tables, func(i, j int) bool { return tables[i].Name < tables[j].Name })
+
+		var rows int64
+		for i, t := range tables {
+			kind, _ := TableKind(t.Name)
+			entry, err := exportTable(ctx, source, zw, t, kind, opts.Format)
+			if err != nil {
+				return err
+			}
+			manifest.Tables = append(manifest.Tables, entry)
+			rows += int64(entry.Rows)
+
+			if opts.Progress != nil {
+				p := Progress{TablesTotal: len(tables), TablesDone: i + 1, Rows: rows, Table: t.Name}
+				if err := opts.Progress(ctx, p); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mw, err := zw.Create(ManifestFileName)
+	if err != nil {
+		return nil, fmt.Errorf("write manifest: %w", err)
+	}
+	enc := json.NewEncoder(mw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return nil, fmt.Errorf("encode manifest: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("close archive: %w", err)
+	}
+	return manifest, nil
+}
+
+// exportTable writes one table as data/<table>.ndjson or data/<table>.csv.
+func exportTable(ctx context.Context, source DataSource, zw *zip.Writer, t TableInfo, kind string, format FileFormat) (ManifestTable, error) {
+	omit := OmittedColumns(t.Name)
+	entry := ManifestTable{
+		Name:           t.Name,
+		File:           "data/" + t.Name + "." + string(format),
+		Kind:           kind,
+		Columns:        withoutColumns(t.Columns, omit),
+		OmittedColumns: omit,
+		Derived:        IsDerived(t.Name),
+	}
+
+	w, err := zw.Create(entry.File)
+	if err != nil {
+		return entry, fmt.Errorf("create %s: %w", entry.File, err)
+	}
+
+	var write func(row []byte) error
+	var flush func() error
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(entry.Columns); err != nil {
+			return entry, fmt.Errorf("write %s header: %w", entry.File, err)
+		}
+		record := make([]string, len(entry.Columns))
+		write = func(row []byte) error {
+			if err := csvRecord(row, entry.Columns, record); err != nil {
+				return err
+			}
+			return cw.Write(record)
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	default:
+		newline := []byte{'\n'}
+		write = func(row []byte) error {
+			if _, err := w.Write(row); err != nil {
+				return err
+			}
+			_, err := w.Write(newline)
+			return err
+		}
+		flush = func() error { return nil }
+	}
+
+	rows, err := source.StreamRows(ctx, t.Name, omit, write)
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		return entry, fmt.Errorf("export %s: %w", t.Name, err)
+	}
+	entry.Rows = rows
+	return entry, nil
+}
+
+// csvRecord fills record with the columns of a JSON row: strings unquoted,
+// NULL empty, numbers, booleans and nested JSON as their JSON text.
+func csvRecord(row []byte, columns []string, record []string) error {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(row, &values); err != nil {
+		return fmt.Errorf("decode row: %w", err)
+	}
+	for i, col := range columns {
+		raw := bytes.TrimSpace(values[col])
+		switch {
+		case len(raw) == 0 || bytes.Equal(raw, []byte("null")):
+			record[i] = ""
+		case raw[0] == '"':
+			var s string
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return fmt.Errorf("decode %s: %w", col, err)
+			}
+			record[i] = s
+		default:
+			record[i] = string(raw)
+		}
+	}
+	return nil
+}
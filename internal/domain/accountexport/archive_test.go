@@ -0,0 +1,112 @@
+package accountexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource serves fixed tables of JSON rows.
+type fakeSource struct {
+	tables []TableInfo
+	rows   map[string][]string
+}
+
+func (s fakeSource) Snapshot(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (s fakeSource) ListTables(context.Context) ([]TableInfo, error) { return s.tables, nil }
+
+func (s fakeSource) StreamRows(_ context.Context, table string, _ []string, fn func(row []byte) error) (int, error) {
+	for _, r := range s.rows[table] {
+		if err := fn([]byte(r)); err != nil {
+			return 0, err
+		}
+	}
+	return len(s.rows[table]), nil
+}
+
+func TestWriteArchiveCSV(t *testing.T) {
+	src := fakeSource{
+		tables: []TableInfo{
+			{Name: "sys_settings", Columns: []string{"key"}},
+			{Name: "doc_invoices", Columns: []string{"id", "number"}},
+			{Name: "cat_counterparties", Columns: []string{"id", "name", "attributes", "deletion_mark", "inn"}},
+		},
+		rows: map[string][]string{
+			"cat_counterparties": {
+				`{"id":"1","name":"ООО \"Ромашка\", Москва","attributes":{"kpp":"7701"},"deletion_mark":false,"inn":null}`,
+				`{"id":"2","name":"ИП Иванов","attributes":{},"deletion_mark":true,"inn":"500100732259"}`,
+			},
+			"doc_invoices": {`{"id":"3","number":42}`},
+		},
+	}
+
+	var progress []Progress
+	var buf bytes.Buffer
+	m, err := WriteArchive(context.Background(), src, &buf, "t1", ArchiveOptions{
+		Format: FormatCSV,
+		Progress: func(_ context.Context, p Progress) error {
+			progress = append(progress, p)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, FormatCSV, m.Format)
+	require.Len(t, m.Tables, 2, "sys_ tables are not exported")
+	assert.Equal(t, "data/cat_counterparties.csv", m.Tables[0].File)
+	assert.Equal(t, []Progress{
+		{TablesTotal: 2, TablesDone: 1, Rows: 2, Table: "cat_counterparties"},
+		{TablesTotal: 2, TablesDone: 2, Rows: 3, Table: "doc_invoices"},
+	}, progress)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		files[f.Name], err = io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(files["data/cat_counterparties.csv"])).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"id", "name", "attributes", "deletion_mark", "inn"},
+		{"1", `ООО "Ромашка", Москва`, `{"kpp":"7701"}`, "false", ""},
+		{"2", "ИП Иванов", "{}", "true", "500100732259"},
+	}, records)
+
+	var stored Manifest
+	require.NoError(t, json.Unmarshal(files[ManifestFileName], &stored))
+	assert.Equal(t, FormatCSV, stored.Format)
+	assert.Equal(t, 1, stored.Tables[1].Rows)
+}
+
+func TestWriteArchiveNDJSONDefault(t *testing.T) {
+	src := fakeSource{
+		tables: []TableInfo{{Name: "reg_stock_movements", Columns: []string{"id"}}},
+		rows:   map[string][]string{"reg_stock_movements": {`{"id":"1"}`, `{"id":"2"}`}},
+	}
+
+	var buf bytes.Buffer
+	m, err := WriteArchive(context.Background(), src, &buf, "t1", ArchiveOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, FormatNDJSON, m.Format)
+	assert.Equal(t, "data/reg_stock_movements.ndjson", m.Tables[0].File)
+	assert.Equal(t, 2, m.Tables[0].Rows)
+
+	_, err = WriteArchive(context.Background(), src, io.Discard, "t1", ArchiveOptions{Format: "xml"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,152 @@
+// Package accountexport produces a portable dump of one tenant's business data
+// (catalogs, documents, register movements, users and roles) as a zip archive
+// of NDJSON files described by a manifest.
+package accountexport
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"metapus/internal/core/id"
+)
+
+// FormatVersion is the archive format produced by this package.
+// Bump it on incompatible changes to the manifest or file layout.
+const FormatVersion = 1
+
+// ManifestFileName is the name of the manifest entry inside the archive.
+const ManifestFileName = "manifest.json"
+
+// Status represents the export job lifecycle.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// Export is one account export job stored in sys_account_exports.
+// The archive itself is loaded separately (see Repository.GetFile).
+type Export struct {
+	ID           id.ID      `db:"id" json:"id"`
+	Status       Status     `db:"status" json:"status"`
+	RequestedBy  string     `db:"requested_by" json:"requestedBy,omitempty"`
+	FileName     string     `db:"file_name" json:"fileName,omitempty"`
+	FileSize     int64      `db:"file_size" json:"fileSize"`
+	Manifest     *Manifest  `db:"manifest" json:"manifest,omitempty"`
+	ErrorMessage string     `db:"error_message" json:"errorMessage,omitempty"`
+	ExpiresAt    *time.Time `db:"expires_at" json:"expiresAt,omitempty"`
+	CreatedAt    time.Time  `db:"created_at" json:"createdAt"`
+	FinishedAt   *time.Time `db:"finished_at" json:"finishedAt,omitempty"`
+}
+
+// Manifest describes the archive contents. Stored as manifest.json in the
+// archive and as JSONB next to the export record.
+type Manifest struct {
+	FormatVersion int             `json:"formatVersion"`
+	TenantID      string          `json:"tenantId"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	Format        FileFormat      `json:"format,omitempty"` // empty in older archives: ndjson
+	Tables        []ManifestTable `json:"tables"`
+}
+
+// ManifestTable describes one exported table.
+type ManifestTable struct {
+	Name    string   `json:"name"`
+	File    string   `json:"file"`
+	Kind    string   `json:"kind"`
+	Rows    int      `json:"rows"`
+	Columns []string `json:"columns"`
+	// OmittedColumns were deliberately left out (credentials, key hashes).
+	OmittedColumns []string `json:"omittedColumns,omitempty"`
+	// Derived tables (register balances) are maintained by triggers from
+	// movements and are exported for auditing only; importers skip them.
+	Derived bool `json:"derived,omitempty"`
+}
+
+// Table kinds recorded in the manifest.
+const (
+	KindCatalog  = "catalog"
+	KindDocument = "document"
+	KindRegister = "register"
+	KindAuth     = "auth"
+)
+
+// authTables are the non-prefixed tables included in an export.
+var authTables = map[string]bool{
+	"users":      true,
+	"roles":      true,
+	"user_roles": true,
+}
+
+// omittedColumns lists secrets that never leave the tenant database.
+var omittedColumns = map[string][]string{
+	"users":                 {"password_hash"},
+	"cat_merchant_api_keys": {"key_hash"},
+}
+
+// TableKind classifies a table name; ok is false for tables outside the export.
+func TableKind(table string) (kind string, ok bool) {
+	switch {
+	case strings.HasPrefix(table, "cat_"):
+		return KindCatalog, true
+	case strings.HasPrefix(table, "doc_"):
+		return KindDocument, true
+	case strings.HasPrefix(table, "reg_"):
+		return KindRegister, true
+	case authTables[table]:
+		return KindAuth, true
+	}
+	return "", false
+}
+
+// OmittedColumns returns the columns excluded from the export of table.
+func OmittedColumns(table string) []string {
+	return omittedColumns[table]
+}
+
+// IsDerived reports whether table is derived from other exported data.
+func IsDerived(table string) bool {
+	return strings.HasPrefix(table, "reg_") && strings.HasSuffix(table, "_balances")
+}
+
+// TableInfo describes a table available for export.
+type TableInfo struct {
+	Name    string
+	Columns []string
+}
+
+// Repository persists export jobs and archives.
+type Repository interface {
+	// Create inserts a pending export. ID and CreatedAt are set by the database.
+	Create(ctx context.Context, e *Export) error
+	// MarkRunning transitions a pending export to running.
+	MarkRunning(ctx context.Context, exportID id.ID) error
+	// Complete stores the archive and manifest and marks the export completed.
+	Complete(ctx context.Context, exportID id.ID, fileName string, data []byte, manifest *Manifest, expiresAt time.Time) error
+	// Fail marks the export failed with a message.
+	Fail(ctx context.Context, exportID id.ID, errMsg string) error
+	// GetByID returns export metadata (without the archive).
+	GetByID(ctx context.Context, exportID id.ID) (*Export, error)
+	// GetFile returns the archive of a completed, non-expired export.
+	GetFile(ctx context.Context, exportID id.ID) (fileName string, data []byte, err error)
+	// List returns the most recent exports (without archives).
+	List(ctx context.Context, limit int) ([]Export, error)
+	// DeleteExpired removes exports past their expiration time.
+	DeleteExpired(ctx context.Context) (int, error)
+}
+
+// DataSource reads tenant tables for export.
+type DataSource interface {
+	// Snapshot runs fn inside a read-only transaction with a single consistent
+	// snapshot, so all tables are exported as of the same moment.
+	Snapshot(ctx context.Context, fn func(ctx context.Context) error) error
+	// ListTables returns the tables of the tenant database with their columns.
+	ListTables(ctx context.Context) ([]TableInfo, error)
+	// StreamRows calls fn with each row of table encoded as a JSON object,
+	// without the omitted columns. Returns the number of rows streamed.
+	StreamRows(ctx context.Context, table string, omit []string, fn func(row []byte) error) (int, error)
+}
@@ -0,0 +1,157 @@
+package accountexport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"metapus/internal/core/apperror"
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/pkg/logger"
+)
+
+const (
+	// DefaultRetention is how long a finished archive is kept.
+	DefaultRetention = 7 * 24 * time.Hour
+
+	// DefaultLinkTTL is the lifetime of a signed download link.
+	DefaultLinkTTL = 1 * time.Hour
+)
+
+// Service runs account exports and serves their archives.
+type Service struct {
+	repo      Repository
+	source    DataSource
+	signer    *URLSigner
+	retention time.Duration
+	linkTTL   time.Duration
+}
+
+// NewService creates an export service.
+func NewService(repo Repository, source DataSource, signer *URLSigner) *Service {
+	return &Service{
+		repo:      repo,
+		source:    source,
+		signer:    signer,
+		retention: DefaultRetention,
+		linkTTL:   DefaultLinkTTL,
+	}
+}
+
+// Start creates an export job and runs it in the background.
+// The returned export is pending; poll Get for completion.
+func (s *Service) Start(ctx context.Context) (*Export, error) {
+	tenantID := tenant.GetTenantID(ctx)
+	if tenantID == "" {
+		return nil, apperror.NewValidation("tenant is required")
+	}
+
+	e := &Export{
+		Status:      StatusPending,
+		RequestedBy: appctx.GetUserID(ctx),
+	}
+	if err := s.repo.Create(ctx, e); err != nil {
+		return nil, fmt.Errorf("create export: %w", err)
+	}
+
+	// The job outlives the request: keep tenant pool/TxManager values, drop cancellation.
+	jobCtx := context.WithoutCancel(ctx)
+	go s.run(jobCtx, tenantID, e.ID)
+
+	return e, nil
+}
+
+// run builds the archive and records the outcome.
+func (s *Service) run(ctx context.Context, tenantID string, exportID id.ID) {
+	if err := s.repo.MarkRunning(ctx, exportID); err != nil {
+		logger.Error(ctx, "account export: failed to mark running", "export_id", exportID, "error", err)
+		return
+	}
+
+	data, manifest, err := s.Build(ctx, tenantID)
+	if err != nil {
+		logger.Error(ctx, "account export failed", "export_id", exportID, "error", err)
+		if ferr := s.repo.Fail(ctx, exportID, err.Error()); ferr != nil {
+			logger.Error(ctx, "account export: failed to record failure", "export_id", exportID, "error", ferr)
+		}
+		return
+	}
+
+	fileName := fmt.Sprintf("account-export-%s.zip", manifest.CreatedAt.Format("20060102-150405"))
+	expiresAt := time.Now().Add(s.retention)
+	if err := s.repo.Complete(ctx, exportID, fileName, data, manifest, expiresAt); err != nil {
+		logger.Error(ctx, "account export: failed to store archive", "export_id", exportID, "error", err)
+		_ = s.repo.Fail(ctx, exportID, err.Error())
+		return
+	}
+
+	logger.Info(ctx, "account export completed",
+		"export_id", exportID,
+		"tables", len(manifest.Tables),
+		"size", len(data),
+	)
+}
+
+// Build produces the archive and its manifest from a consistent snapshot.
+func (s *Service) Build(ctx context.Context, tenantID string) ([]byte, *Manifest, error) {
+	var buf bytes.Buffer
+	manifest, err := WriteArchive(ctx, s.source, &buf, tenantID, ArchiveOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), manifest, nil
+}
+
+func withoutColumns(columns, omit []string) []string {
+	if len(omit) == 0 {
+		return columns
+	}
+	skip := make(map[string]bool, len(omit))
+	for _, c := range omit {
+		skip[c] = true
+	}
+	result := make([]string, 0, len(columns))
+	for _, c := range columns {
+		if !skip[c] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// Get returns an export by ID.
+func (s *Service) Get(ctx context.Context, exportID id.ID) (*Export, error) {
+	return s.repo.GetByID(ctx, exportID)
+}
+
+// List returns recent exports.
+func (s *Service) List(ctx context.Context, limit int) ([]Export, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	return s.repo.List(ctx, limit)
+}
+
+// DownloadURL returns a signed download path for a completed export,
+// or "" if the export is not downloadable.
+func (s *Service) DownloadURL(ctx context.Context, e *Export) (string, time.Time) {
+	if e.Status != StatusCompleted || (e.ExpiresAt != nil && time.Now().After(*e.ExpiresAt)) {
+		return "", time.Time{}
+	}
+	expires := time.Now().Add(s.linkTTL)
+	if e.ExpiresAt != nil && e.ExpiresAt.Before(expires) {
+		expires = *e.ExpiresAt
+	}
+	return s.signer.DownloadPath(tenant.GetTenantID(ctx), e.ID, expires), expires
+}
+
+// Download verifies a signed link and returns the archive.
+func (s *Service) Download(ctx context.Context, exportID id.ID, expires int64, sig string) (string, []byte, error) {
+	if !s.signer.Verify(tenant.GetTenantID(ctx), exportID, expires, sig) {
+		return "", nil, apperror.NewForbidden("download link is invalid or expired")
+	}
+	return s.repo.GetFile(ctx, exportID)
+}
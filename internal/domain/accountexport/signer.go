@@ -0,0 +1,32 @@
+package accountexport
+
+import (
+	"fmt"
+	"time"
+
+	"metapus/internal/core/crypto"
+	"metapus/internal/core/id"
+)
+
+// URLSigner issues and verifies signed download links for export archives.
+// A link is bound to tenant, export and expiry, so it can be shared without
+// a JWT (e.g. pasted into a browser or handed to a download manager).
+type URLSigner struct {
+	links *crypto.LinkSigner
+}
+
+// NewURLSigner creates a signer with the given HMAC key.
+func NewURLSigner(key []byte) *URLSigner {
+	return &URLSigner{links: crypto.NewLinkSigner(key, "account-export")}
+}
+
+// DownloadPath returns the signed download path (relative to the API root).
+func (s *URLSigner) DownloadPath(tenantID string, exportID id.ID, expires time.Time) string {
+	return s.links.SignedPath(fmt.Sprintf("/api/v1/account-export/%s/download", exportID),
+		tenantID, exportID.String(), expires)
+}
+
+// Verify checks a signature and its expiry.
+func (s *URLSigner) Verify(tenantID string, exportID id.ID, expires int64, sig string) bool {
+	return s.links.VerifyPath(tenantID, exportID.String(), expires, sig)
+}
@@ -0,0 +1,108 @@
+package accountimport
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/domain/accountexport"
+)
+
+// maxRowSize bounds a single NDJSON line (documents with large JSONB payloads).
+const maxRowSize = 64 << 20
+
+// Archive is an opened account export archive.
+type Archive struct {
+	Manifest *accountexport.Manifest
+	files    map[string]*zip.File
+}
+
+// OpenArchive parses a zip archive produced by accountexport and reads its manifest.
+func OpenArchive(data []byte) (*Archive, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, apperror.NewValidation("archive is not a valid zip file")
+	}
+
+	a := &Archive{files: make(map[string]*zip.File, len(zr.File))}
+	for _, f := range zr.File {
+		a.files[f.Name] = f
+	}
+
+	mf, ok := a.files[accountexport.ManifestFileName]
+	if !ok {
+		return nil, apperror.NewValidation("archive has no " + accountexport.ManifestFileName)
+	}
+	rc, err := mf.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open manifest: %w", err)
+	}
+	defer rc.Close()
+
+	var m accountexport.Manifest
+	if err := json.NewDecoder(rc).Decode(&m); err != nil {
+		return nil, apperror.NewValidation("manifest is not valid JSON: " + err.Error())
+	}
+	if m.Format != "" && m.Format != accountexport.FormatNDJSON {
+		return nil, apperror.NewValidation("only ndjson archives can be imported, this one is " + string(m.Format))
+	}
+	a.Manifest = &m
+	return a, nil
+}
+
+// Table returns the manifest entry for name.
+func (a *Archive) Table(name string) (accountexport.ManifestTable, bool) {
+	for _, t := range a.Manifest.Tables {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return accountexport.ManifestTable{}, false
+}
+
+// HasFile reports whether the archive contains the file of t.
+func (a *Archive) HasFile(t accountexport.ManifestTable) bool {
+	_, ok := a.files[t.File]
+	return ok
+}
+
+// Rows calls fn with each row of t decoded into a map. Numbers are kept as
+// json.Number so decimal amounts round-trip without float conversion.
+func (a *Archive) Rows(t accountexport.ManifestTable, fn func(row map[string]any) error) error {
+	f, ok := a.files[t.File]
+	if !ok {
+		return fmt.Errorf("archive has no %s", t.File)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("open %s: %w", t.File, err)
+	}
+	defer rc.Close()
+
+	sc := bufio.NewScanner(rc)
+	sc.Buffer(make([]byte, 0, 64*1024), maxRowSize)
+	line := 0
+	for sc.Scan() {
+		line++
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		dec := json.NewDecoder(bytes.NewReader(sc.Bytes()))
+		dec.UseNumber()
+		var row map[string]any
+		if err := dec.Decode(&row); err != nil {
+			return fmt.Errorf("%s line %d: %w", t.File, line, err)
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	if err := sc.Err(); err != nil && err != io.EOF {
+		return fmt.Errorf("read %s: %w", t.File, err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Package accountimport loads an account export archive (see accountexport)
+// into a freshly provisioned tenant: it validates the archive against the
+// target schema, remaps IDs of rows that already exist (seeded roles, VAT
+// rates, the bootstrap admin) and imports table by table so an interrupted
+// import can be resumed.
+package accountimport
+
+import (
+	"context"
+	"time"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain/accountexport"
+)
+
+// Status represents the import job lifecycle.
+type Status string
+
+const (
+	// StatusValidated: archive accepted, ready to run.
+	StatusValidated Status = "validated"
+	// StatusInvalid: validation found errors; the import cannot run.
+	StatusInvalid   Status = "invalid"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	// StatusFailed: the run stopped on an error; it can be resumed.
+	StatusFailed Status = "failed"
+)
+
+// Import is one account import job stored in sys_account_imports.
+// The archive itself is loaded separately (see Repository.GetFile).
+type Import struct {
+	ID              id.ID                   `db:"id" json:"id"`
+	Status          Status                  `db:"status" json:"status"`
+	RequestedBy     string                  `db:"requested_by" json:"requestedBy,omitempty"`
+	SourceTenantID  string                  `db:"source_tenant_id" json:"sourceTenantId,omitempty"`
+	FileSize        int64                   `db:"file_size" json:"fileSize"`
+	Manifest        *accountexport.Manifest `db:"manifest" json:"manifest,omitempty"`
+	Report          *Report                 `db:"report" json:"report,omitempty"`
+	CompletedTables []string                `db:"completed_tables" json:"completedTables"`
+	IDMap           IDMap                   `db:"id_map" json:"-"`
+	ErrorMessage    string                  `db:"error_message" json:"errorMessage,omitempty"`
+	CreatedAt       time.Time               `db:"created_at" json:"createdAt"`
+	StartedAt       *time.Time              `db:"started_at" json:"startedAt,omitempty"`
+	FinishedAt      *time.Time              `db:"finished_at" json:"finishedAt,omitempty"`
+}
+
+// Report is the validation report, extended with per-table results as the
+// import runs.
+type Report struct {
+	Errors   []Issue       `json:"errors,omitempty"`
+	Warnings []Issue       `json:"warnings,omitempty"`
+	Tables   []TableResult `json:"tables,omitempty"`
+}
+
+// Valid reports whether the archive can be imported.
+func (r *Report) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+func (r *Report) errorf(table, msg string) {
+	r.Errors = append(r.Errors, Issue{Table: table, Message: msg})
+}
+
+func (r *Report) warnf(table, msg string) {
+	r.Warnings = append(r.Warnings, Issue{Table: table, Message: msg})
+}
+
+// table returns the result entry for name.
+func (r *Report) table(name string) *TableResult {
+	for i := range r.Tables {
+		if r.Tables[i].Name == name {
+			return &r.Tables[i]
+		}
+	}
+	r.Tables = append(r.Tables, TableResult{Name: name})
+	return &r.Tables[len(r.Tables)-1]
+}
+
+// Issue is one validation finding.
+type Issue struct {
+	Table   string `json:"table,omitempty"`
+	Message string `json:"message"`
+}
+
+// TableResult records the outcome for one archive table.
+type TableResult struct {
+	Name string `json:"name"`
+	// Rows in the archive.
+	Rows int `json:"rows"`
+	// Inserted rows (filled in as the import runs).
+	Inserted int `json:"inserted"`
+	// Matched rows already existed in the target (by natural key) and were
+	// mapped onto the existing ID instead of being inserted.
+	Matched int `json:"matched"`
+	// Skipped is set for tables that are not imported (derived balances).
+	Skipped bool   `json:"skipped,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// Column describes a target table column.
+type Column struct {
+	Name string
+	// Required columns are NOT NULL without a default.
+	Required bool
+	// Generated columns (GENERATED ALWAYS / identity) cannot be written.
+	Generated bool
+}
+
+// TableSchema describes a target table.
+type TableSchema struct {
+	Name    string
+	Columns []Column
+}
+
+// ForeignKey is a single-column reference between target tables.
+type ForeignKey struct {
+	Table    string
+	Column   string
+	RefTable string
+}
+
+// Repository persists import jobs and archives.
+type Repository interface {
+	// Create inserts an import with its archive. ID and CreatedAt are set by the database.
+	Create(ctx context.Context, imp *Import, data []byte) error
+	// GetByID returns import metadata (without the archive).
+	GetByID(ctx context.Context, importID id.ID) (*Import, error)
+	// GetFile returns the archive.
+	GetFile(ctx context.Context, importID id.ID) ([]byte, error)
+	// List returns the most recent imports (without archives).
+	List(ctx context.Context, limit int) ([]Import, error)
+	// MarkRunning transitions a validated or failed import to running.
+	// Returns a conflict error when the import is in any other state.
+	MarkRunning(ctx context.Context, importID id.ID) error
+	// SaveProgress records a finished table. Called inside the table's
+	// transaction so data and progress commit together.
+	SaveProgress(ctx context.Context, importID id.ID, table string, idMap IDMap, report *Report) error
+	// Complete marks the import completed.
+	Complete(ctx context.Context, importID id.ID) error
+	// Fail marks the import failed with a message.
+	Fail(ctx context.Context, importID id.ID, errMsg string) error
+}
+
+// Sink writes imported rows into the tenant database.
+type Sink interface {
+	// Tables returns the target tables with their columns.
+	Tables(ctx context.Context) ([]TableSchema, error)
+	// ForeignKeys returns single-column foreign keys between target tables.
+	ForeignKeys(ctx context.Context) ([]ForeignKey, error)
+	// CountRows returns the number of rows in table.
+	CountRows(ctx context.Context, table string) (int64, error)
+	// NaturalKeys returns key value -> id for the existing rows of table.
+	NaturalKeys(ctx context.Context, table, keyColumn string) (map[string]string, error)
+	// InsertRows inserts JSON-encoded rows into table, writing only columns.
+	// Rows that conflict with existing ones are left untouched.
+	// Returns the number of inserted rows.
+	InsertRows(ctx context.Context, table string, columns []string, rows [][]byte) (int, error)
+}
@@ -0,0 +1,186 @@
+package accountimport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"metapus/internal/domain/accountexport"
+)
+
+// IDMap maps archive IDs onto IDs of rows that already exist in the target.
+//
+// IDs are UUIDs, so they are globally unique: rows are imported under their
+// original IDs and only rows matched by natural key need remapping. Because
+// a UUID cannot collide with any other value, references are rewritten by
+// replacing every string equal to a remapped ID, including values nested in
+// JSONB columns and arrays.
+type IDMap map[string]string
+
+// Rewrite replaces remapped IDs in row in place.
+func (m IDMap) Rewrite(row map[string]any) {
+	if len(m) == 0 {
+		return
+	}
+	for k, v := range row {
+		row[k] = m.rewriteValue(v)
+	}
+}
+
+func (m IDMap) rewriteValue(v any) any {
+	switch val := v.(type) {
+	case string:
+		if to, ok := m[val]; ok {
+			return to
+		}
+	case map[string]any:
+		for k, inner := range val {
+			val[k] = m.rewriteValue(inner)
+		}
+	case []any:
+		for i, inner := range val {
+			val[i] = m.rewriteValue(inner)
+		}
+	}
+	return v
+}
+
+// naturalKey returns the unique business key used to match archive rows with
+// rows already present in a freshly provisioned tenant (seeded roles, VAT
+// rates, the bootstrap admin), or "" when the table has none.
+func naturalKey(t TableSchema) string {
+	switch {
+	case t.Name == "users":
+		return "email"
+	case t.Name == "roles", strings.HasPrefix(t.Name, "cat_") && hasColumn(t, "code"):
+		return "code"
+	}
+	return ""
+}
+
+// idMatches is the result of matching archive rows by natural key.
+type idMatches struct {
+	// idMap holds archive ID -> existing ID for matches with a different ID.
+	idMap IDMap
+	// ids are the archive IDs of all matched rows (they are not inserted).
+	ids map[string]bool
+	// perTable counts matched rows by table.
+	perTable map[string]int
+}
+
+// matchExisting matches archive rows of tables with a natural key against the
+// target. The result is deterministic for a given target state, so it is
+// recomputed on every run: rows imported by an earlier attempt match
+// themselves and need no mapping.
+func matchExisting(ctx context.Context, sink Sink, a *Archive, schemas map[string]TableSchema) (*idMatches, error) {
+	m := &idMatches{idMap: IDMap{}, ids: map[string]bool{}, perTable: map[string]int{}}
+
+	for _, mt := range a.Manifest.Tables {
+		schema, ok := schemas[mt.Name]
+		if !ok || mt.Derived {
+			continue
+		}
+		key := naturalKey(schema)
+		if key == "" {
+			continue
+		}
+		current, err := sink.NaturalKeys(ctx, mt.Name, key)
+		if err != nil {
+			return nil, fmt.Errorf("load %s keys: %w", mt.Name, err)
+		}
+		if len(current) == 0 {
+			continue
+		}
+		err = a.Rows(mt, func(row map[string]any) error {
+			k, _ := row[key].(string)
+			oldID, _ := row["id"].(string)
+			if k == "" || oldID == "" {
+				return nil
+			}
+			if newID, ok := current[k]; ok {
+				m.ids[oldID] = true
+				m.perTable[mt.Name]++
+				if newID != oldID {
+					m.idMap[oldID] = newID
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// tableOrder sorts archive tables so that referenced tables are imported
+// before the tables referencing them. Tables caught in a reference cycle keep
+// alphabetical order at the end; the second return value lists them.
+func tableOrder(tables []accountexport.ManifestTable, fks []ForeignKey) ([]accountexport.ManifestTable, []string) {
+	byName := make(map[string]accountexport.ManifestTable, len(tables))
+	for _, t := range tables {
+		byName[t.Name] = t
+	}
+
+	deps := make(map[string]map[string]bool, len(tables))
+	for _, fk := range fks {
+		if fk.Table == fk.RefTable {
+			continue // self-references are ordered row by row
+		}
+		if _, ok := byName[fk.Table]; !ok {
+			continue
+		}
+		if _, ok := byName[fk.RefTable]; !ok {
+			continue
+		}
+		if deps[fk.Table] == nil {
+			deps[fk.Table] = map[string]bool{}
+		}
+		deps[fk.Table][fk.RefTable] = true
+	}
+
+	ordered := make([]accountexport.ManifestTable, 0, len(tables))
+	done := make(map[string]bool, len(tables))
+	for len(ordered) < len(tables) {
+		progress := false
+		// tables are sorted by name (export order), which keeps the result stable
+		for _, t := range tables {
+			if done[t.Name] {
+				continue
+			}
+			ready := true
+			for dep := range deps[t.Name] {
+				if !done[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				ordered = append(ordered, t)
+				done[t.Name] = true
+				progress = true
+			}
+		}
+		if !progress {
+			break
+		}
+	}
+
+	var cyclic []string
+	for _, t := range tables {
+		if !done[t.Name] {
+			ordered = append(ordered, t)
+			cyclic = append(cyclic, t.Name)
+		}
+	}
+	return ordered, cyclic
+}
+
+func hasColumn(t TableSchema, name string) bool {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
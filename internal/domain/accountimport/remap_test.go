@@ -0,0 +1,83 @@
+package accountimport
+
+import (
+	"testing"
+
+	"metapus/internal/domain/accountexport"
+)
+
+func TestIDMap_Rewrite(t *testing.T) {
+	m := IDMap{"old-role": "new-role"}
+	row := map[string]any{
+		"user_id": "u1",
+		"role_id": "old-role",
+		"attributes": map[string]any{
+			"roles": []any{"old-role", "other"},
+		},
+	}
+
+	m.Rewrite(row)
+
+	if row["role_id"] != "new-role" {
+		t.Errorf("role_id = %v, want new-role", row["role_id"])
+	}
+	if row["user_id"] != "u1" {
+		t.Errorf("user_id = %v, want u1 (unchanged)", row["user_id"])
+	}
+	roles := row["attributes"].(map[string]any)["roles"].([]any)
+	if roles[0] != "new-role" || roles[1] != "other" {
+		t.Errorf("nested roles = %v, want [new-role other]", roles)
+	}
+}
+
+func TestTableOrder(t *testing.T) {
+	tables := []accountexport.ManifestTable{
+		{Name: "cat_nomenclatures"},
+		{Name: "cat_units"},
+		{Name: "doc_goods_receipts"},
+		{Name: "roles"},
+		{Name: "user_roles"},
+		{Name: "users"},
+	}
+	fks := []ForeignKey{
+		{Table: "cat_nomenclatures", Column: "unit_id", RefTable: "cat_units"},
+		{Table: "cat_nomenclatures", Column: "parent_id", RefTable: "cat_nomenclatures"},
+		{Table: "doc_goods_receipts", Column: "created_by", RefTable: "users"},
+		{Table: "user_roles", Column: "user_id", RefTable: "users"},
+		{Table: "user_roles", Column: "role_id", RefTable: "roles"},
+	}
+
+	ordered, cyclic := tableOrder(tables, fks)
+	if len(cyclic) != 0 {
+		t.Fatalf("unexpected cycle: %v", cyclic)
+	}
+
+	pos := make(map[string]int, len(ordered))
+	for i, tbl := range ordered {
+		pos[tbl.Name] = i
+	}
+	for _, fk := range fks {
+		if fk.Table != fk.RefTable && pos[fk.RefTable] > pos[fk.Table] {
+			t.Errorf("%s imported before %s", fk.Table, fk.RefTable)
+		}
+	}
+}
+
+func TestTableOrder_Cycle(t *testing.T) {
+	tables := []accountexport.ManifestTable{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	fks := []ForeignKey{
+		{Table: "a", Column: "b_id", RefTable: "b"},
+		{Table: "b", Column: "a_id", RefTable: "a"},
+	}
+
+	ordered, cyclic := tableOrder(tables, fks)
+	if len(ordered) != 3 {
+		t.Fatalf("ordered has %d tables, want 3", len(ordered))
+	}
+	if ordered[0].Name != "c" {
+		t.Errorf("first table = %s, want c", ordered[0].Name)
+	}
+	if len(cyclic) != 2 {
+		t.Errorf("cyclic = %v, want [a b]", cyclic)
+	}
+}
@@ -0,0 +1,448 @@
+package accountimport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"metapus/internal/core/apperror"
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/accountexport"
+	"metapus/pkg/logger"
+)
+
+// insertBatchSize is the number of rows sent per INSERT.
+const insertBatchSize = 500
+
+// fillValues supply required columns that the export deliberately omits.
+// Imported users get an unusable password hash and must reset their password.
+var fillValues = map[string]map[string]any{
+	"users": {"password_hash": "!"},
+}
+
+// Service validates and runs account imports.
+type Service struct {
+	repo Repository
+	sink Sink
+
+	// running prevents concurrent runs of the same import in this process.
+	running sync.Map
+}
+
+// NewService creates an import service.
+func NewService(repo Repository, sink Sink) *Service {
+	return &Service{repo: repo, sink: sink}
+}
+
+// tablePlan describes how one archive table is written.
+type tablePlan struct {
+	table    accountexport.ManifestTable
+	columns  []string
+	fills    map[string]any
+	selfRefs []string
+}
+
+// plan is the validated import: report, table order and ID matches.
+type plan struct {
+	report  *Report
+	tables  []tablePlan
+	matches *idMatches
+}
+
+// Upload validates an archive against this tenant and stores it.
+// Invalid archives are stored too (status invalid) so the report can be reviewed.
+func (s *Service) Upload(ctx context.Context, data []byte) (*Import, error) {
+	a, err := OpenArchive(data)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.validate(ctx, a, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &Import{
+		Status:         StatusValidated,
+		RequestedBy:    appctx.GetUserID(ctx),
+		SourceTenantID: a.Manifest.TenantID,
+		FileSize:       int64(len(data)),
+		Manifest:       a.Manifest,
+		Report:         p.report,
+	}
+	if !p.report.Valid() {
+		imp.Status = StatusInvalid
+	}
+	if err := s.repo.Create(ctx, imp, data); err != nil {
+		return nil, fmt.Errorf("create import: %w", err)
+	}
+	return imp, nil
+}
+
+// Run starts a validated import, or resumes a failed one, in the background.
+func (s *Service) Run(ctx context.Context, importID id.ID) (*Import, error) {
+	imp, err := s.repo.GetByID(ctx, importID)
+	if err != nil {
+		return nil, err
+	}
+	if imp.Status != StatusValidated && imp.Status != StatusFailed {
+		return nil, apperror.NewConflict(fmt.Sprintf("import is %s; only validated or failed imports can be run", imp.Status))
+	}
+
+	key := tenant.GetTenantID(ctx) + "/" + importID.String()
+	if _, loaded := s.running.LoadOrStore(key, true); loaded {
+		return nil, apperror.NewConflict("import is already running")
+	}
+
+	if err := s.repo.MarkRunning(ctx, importID); err != nil {
+		s.running.Delete(key)
+		return nil, err
+	}
+	imp.Status = StatusRunning
+
+	// The job outlives the request: keep tenant pool/TxManager values, drop cancellation.
+	jobCtx := context.WithoutCancel(ctx)
+	go func() {
+		defer s.running.Delete(key)
+		s.run(jobCtx, imp)
+	}()
+
+	return imp, nil
+}
+
+// run imports the remaining tables and records the outcome.
+func (s *Service) run(ctx context.Context, imp *Import) {
+	fail := func(err error) {
+		logger.Error(ctx, "account import failed", "import_id", imp.ID, "error", err)
+		if ferr := s.repo.Fail(ctx, imp.ID, err.Error()); ferr != nil {
+			logger.Error(ctx, "account import: failed to record failure", "import_id", imp.ID, "error", ferr)
+		}
+	}
+
+	data, err := s.repo.GetFile(ctx, imp.ID)
+	if err != nil {
+		fail(err)
+		return
+	}
+	a, err := OpenArchive(data)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	completed := make(map[string]bool, len(imp.CompletedTables))
+	for _, t := range imp.CompletedTables {
+		completed[t] = true
+	}
+
+	// Re-validate: the target may have changed since upload, and the ID map
+	// must reflect rows imported by an earlier attempt.
+	p, err := s.validate(ctx, a, completed)
+	if err != nil {
+		fail(err)
+		return
+	}
+	if !p.report.Valid() {
+		fail(fmt.Errorf("validation failed: %s", p.report.Errors[0].Message))
+		return
+	}
+	// Keep results of tables finished by an earlier attempt.
+	if imp.Report != nil {
+		for i, r := range p.report.Tables {
+			if !completed[r.Name] {
+				continue
+			}
+			for _, prev := range imp.Report.Tables {
+				if prev.Name == r.Name {
+					p.report.Tables[i] = prev
+				}
+			}
+		}
+	}
+
+	txm, err := tenant.GetTxManager(ctx)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	logger.Info(ctx, "account import started",
+		"import_id", imp.ID,
+		"source_tenant", a.Manifest.TenantID,
+		"tables", len(p.tables),
+		"resumed_tables", len(completed),
+	)
+
+	for _, tp := range p.tables {
+		if completed[tp.table.Name] {
+			continue
+		}
+		err := txm.RunInTransaction(ctx, func(ctx context.Context) error {
+			inserted, err := s.importTable(ctx, a, tp, p.matches)
+			if err != nil {
+				return err
+			}
+			result := p.report.table(tp.table.Name)
+			result.Inserted = inserted
+			return s.repo.SaveProgress(ctx, imp.ID, tp.table.Name, p.matches.idMap, p.report)
+		})
+		if err != nil {
+			fail(fmt.Errorf("import %s: %w", tp.table.Name, err))
+			return
+		}
+	}
+
+	if err := s.repo.Complete(ctx, imp.ID); err != nil {
+		fail(err)
+		return
+	}
+	logger.Info(ctx, "account import completed", "import_id", imp.ID, "tables", len(p.tables))
+}
+
+// validate checks the archive against the target schema and builds the plan.
+// completed lists tables already imported by an earlier attempt.
+func (s *Service) validate(ctx context.Context, a *Archive, completed map[string]bool) (*plan, error) {
+	report := &Report{}
+	p := &plan{report: report, matches: &idMatches{idMap: IDMap{}, ids: map[string]bool{}, perTable: map[string]int{}}}
+
+	m := a.Manifest
+	if m.FormatVersion < 1 || m.FormatVersion > accountexport.FormatVersion {
+		report.errorf("", fmt.Sprintf("unsupported archive format version %d (supported: 1..%d)", m.FormatVersion, accountexport.FormatVersion))
+		return p, nil
+	}
+
+	tables, err := s.sink.Tables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load target tables: %w", err)
+	}
+	schemas := make(map[string]TableSchema, len(tables))
+	for _, t := range tables {
+		schemas[t.Name] = t
+	}
+	fks, err := s.sink.ForeignKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load foreign keys: %w", err)
+	}
+
+	ordered, cyclic := tableOrder(m.Tables, fks)
+	for _, name := range cyclic {
+		report.warnf(name, "table is part of a reference cycle; import order may violate foreign keys")
+	}
+
+	for _, mt := range ordered {
+		result := TableResult{Name: mt.Name, Rows: mt.Rows}
+		tp, ok := s.planTable(ctx, a, mt, schemas, fks, completed, report, &result)
+		report.Tables = append(report.Tables, result)
+		if ok {
+			p.tables = append(p.tables, tp)
+		}
+	}
+	if !report.Valid() {
+		return p, nil
+	}
+
+	matches, err := matchExisting(ctx, s.sink, a, schemas)
+	if err != nil {
+		return nil, err
+	}
+	p.matches = matches
+	for i := range report.Tables {
+		report.Tables[i].Matched = matches.perTable[report.Tables[i].Name]
+	}
+	return p, nil
+}
+
+// planTable validates one archive table; ok is false when it is not imported.
+func (s *Service) planTable(ctx context.Context, a *Archive, mt accountexport.ManifestTable, schemas map[string]TableSchema, fks []ForeignKey, completed map[string]bool, report *Report, result *TableResult) (tablePlan, bool) {
+	tp := tablePlan{table: mt, fills: fillValues[mt.Name]}
+
+	if mt.Derived {
+		result.Skipped = true
+		result.Reason = "derived from register movements; rebuilt by the target on import"
+		return tp, false
+	}
+	if !a.HasFile(mt) {
+		report.errorf(mt.Name, fmt.Sprintf("archive has no %s", mt.File))
+		return tp, false
+	}
+	schema, ok := schemas[mt.Name]
+	if !ok {
+		report.errorf(mt.Name, "table does not exist in the target schema")
+		return tp, false
+	}
+
+	archiveCols := make(map[string]bool, len(mt.Columns))
+	for _, c := range mt.Columns {
+		archiveCols[c] = true
+	}
+	omitted := make(map[string]bool, len(mt.OmittedColumns))
+	for _, c := range mt.OmittedColumns {
+		omitted[c] = true
+	}
+
+	targetCols := make(map[string]bool, len(schema.Columns))
+	for _, c := range schema.Columns {
+		targetCols[c.Name] = true
+		switch {
+		case c.Generated:
+			continue
+		case archiveCols[c.Name]:
+			tp.columns = append(tp.columns, c.Name)
+		case tp.fills[c.Name] != nil:
+			tp.columns = append(tp.columns, c.Name)
+			if omitted[c.Name] {
+				report.warnf(mt.Name, fmt.Sprintf("%s is not exported; imported rows get a placeholder value", c.Name))
+			}
+		case c.Required && omitted[c.Name]:
+			result.Skipped = true
+			result.Reason = fmt.Sprintf("requires %s, which is not exported", c.Name)
+			report.warnf(mt.Name, "table skipped: "+result.Reason)
+			return tp, false
+		case c.Required:
+			report.errorf(mt.Name, fmt.Sprintf("required column %s is missing from the archive", c.Name))
+			return tp, false
+		}
+	}
+	for _, c := range mt.Columns {
+		if !targetCols[c] {
+			report.warnf(mt.Name, fmt.Sprintf("column %s does not exist in the target schema and is dropped", c))
+		}
+	}
+
+	for _, fk := range fks {
+		if fk.Table == mt.Name && fk.RefTable == mt.Name {
+			tp.selfRefs = append(tp.selfRefs, fk.Column)
+		}
+	}
+
+	// Documents and movements cannot be merged into existing data.
+	if !completed[mt.Name] && (mt.Kind == accountexport.KindDocument || mt.Kind == accountexport.KindRegister) {
+		n, err := s.sink.CountRows(ctx, mt.Name)
+		if err != nil {
+			report.errorf(mt.Name, "count target rows: "+err.Error())
+			return tp, false
+		}
+		if n > 0 {
+			report.errorf(mt.Name, fmt.Sprintf("target already contains %d rows; import requires a freshly provisioned tenant", n))
+			return tp, false
+		}
+	}
+
+	rows := 0
+	if err := a.Rows(mt, func(map[string]any) error { rows++; return nil }); err != nil {
+		report.errorf(mt.Name, err.Error())
+		return tp, false
+	}
+	if rows != mt.Rows {
+		report.errorf(mt.Name, fmt.Sprintf("archive has %d rows, manifest declares %d", rows, mt.Rows))
+		return tp, false
+	}
+	return tp, true
+}
+
+// importTable inserts the rows of one table. Rows matched by natural key are
+// skipped; self-referencing rows (hierarchies) are inserted parents first.
+func (s *Service) importTable(ctx context.Context, a *Archive, tp tablePlan, matches *idMatches) (int, error) {
+	type pendingRow struct {
+		id   string
+		refs []string
+		data []byte
+	}
+
+	var rows []pendingRow
+	err := a.Rows(tp.table, func(row map[string]any) error {
+		rowID, _ := row["id"].(string)
+		if rowID != "" && matches.ids[rowID] {
+			return nil
+		}
+		matches.idMap.Rewrite(row)
+		for k, v := range tp.fills {
+			if row[k] == nil {
+				row[k] = v
+			}
+		}
+
+		pr := pendingRow{id: rowID}
+		for _, col := range tp.selfRefs {
+			if ref, ok := row[col].(string); ok && ref != "" {
+				pr.refs = append(pr.refs, ref)
+			}
+		}
+		data, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		pr.data = data
+		rows = append(rows, pr)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Insert in waves: a row is ready once none of its self-references point
+	// to a row that is still pending.
+	pending := make(map[string]bool, len(rows))
+	if len(tp.selfRefs) > 0 {
+		for _, r := range rows {
+			if r.id != "" {
+				pending[r.id] = true
+			}
+		}
+	}
+
+	inserted := 0
+	for len(rows) > 0 {
+		var ready [][]byte
+		var readyIDs []string
+		rest := rows[:0]
+		for _, r := range rows {
+			blocked := false
+			for _, ref := range r.refs {
+				if ref != r.id && pending[ref] {
+					blocked = true
+					break
+				}
+			}
+			if blocked {
+				rest = append(rest, r)
+				continue
+			}
+			ready = append(ready, r.data)
+			readyIDs = append(readyIDs, r.id)
+		}
+		if len(ready) == 0 {
+			return inserted, fmt.Errorf("%d rows form a parent reference cycle", len(rest))
+		}
+
+		for start := 0; start < len(ready); start += insertBatchSize {
+			end := min(start+insertBatchSize, len(ready))
+			n, err := s.sink.InsertRows(ctx, tp.table.Name, tp.columns, ready[start:end])
+			if err != nil {
+				return inserted, err
+			}
+			inserted += n
+		}
+		for _, rid := range readyIDs {
+			delete(pending, rid)
+		}
+		rows = rest
+	}
+	return inserted, nil
+}
+
+// Get returns an import by ID.
+func (s *Service) Get(ctx context.Context, importID id.ID) (*Import, error) {
+	return s.repo.GetByID(ctx, importID)
+}
+
+// List returns recent imports.
+func (s *Service) List(ctx context.Context, limit int) ([]Import, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	return s.repo.List(ctx, limit)
+}
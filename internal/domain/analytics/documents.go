@@ -0,0 +1,46 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DocumentType is a document kind to report counts for.
+type DocumentType struct {
+	Key   string // entity key, e.g. goods_receipt
+	Table string // document table, e.g. doc_goods_receipts
+}
+
+// DocumentCounter counts the documents of one table.
+// Implemented by postgres.AnalyticsRepo.
+type DocumentCounter interface {
+	CountDocuments(ctx context.Context, table string) (total, posted int, err error)
+}
+
+// TrackDocumentCounts emits EventDocumentCount for each document type of the
+// tenant in ctx. Returns the number of events emitted; types without
+// documents are skipped, types that fail to count do not stop the others.
+func (e *Emitter) TrackDocumentCounts(ctx context.Context, counter DocumentCounter, types []DocumentType) (int, error) {
+	if e == nil || !Enabled(ctx) {
+		return 0, nil
+	}
+	emitted := 0
+	var errs []error
+	for _, dt := range types {
+		total, posted, err := counter.CountDocuments(ctx, dt.Table)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("count %s: %w", dt.Key, err))
+			continue
+		}
+		if total == 0 {
+			continue
+		}
+		e.TrackN(ctx, EventDocumentCount, map[string]any{
+			"documentType": dt.Key,
+			"posted":       posted,
+		}, total)
+		emitted++
+	}
+	return emitted, errors.Join(errs...)
+}
@@ -0,0 +1,158 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/settings"
+	"metapus/pkg/logger"
+)
+
+const (
+	// DefaultFlushInterval is how often aggregated events are sent to the sink.
+	DefaultFlushInterval = 1 * time.Minute
+
+	// _maxPending bounds the distinct events kept between flushes; further
+	// events are dropped (analytics must never cost memory under load).
+	_maxPending = 10_000
+
+	_finalFlushTimeout = 5 * time.Second
+)
+
+// Emitter collects events in memory, aggregates identical ones and sends
+// them to the sink in batches. Delivery is best-effort: a failed batch is
+// logged and dropped.
+//
+// A nil *Emitter is valid and discards all events, so callers need no
+// "analytics enabled" checks.
+//
+// Lifecycle: Run() blocks until ctx is cancelled, then flushes once more.
+type Emitter struct {
+	sink     Sink
+	salt     []byte
+	interval time.Duration
+	log      *logger.Logger
+
+	mu      sync.Mutex
+	pending map[eventKey]*Event
+	order   []eventKey
+	dropped int
+}
+
+type eventKey struct {
+	anonymousID string
+	name        string
+	properties  string
+}
+
+// NewEmitter creates an emitter. salt keys the tenant pseudonyms (AnonymousID)
+// and must be the same for all processes reporting to one sink.
+func NewEmitter(sink Sink, salt []byte, log *logger.Logger) *Emitter {
+	return &Emitter{
+		sink:     sink,
+		salt:     salt,
+		interval: DefaultFlushInterval,
+		log:      log.WithComponent("analytics"),
+		pending:  make(map[eventKey]*Event),
+	}
+}
+
+// Track records one occurrence of an event for the tenant in ctx.
+func (e *Emitter) Track(ctx context.Context, name string, props map[string]any) {
+	e.TrackN(ctx, name, props, 1)
+}
+
+// TrackN records an event with a quantity (e.g. a document count).
+// Events without a tenant in ctx, or of a tenant that opted out, are dropped.
+func (e *Emitter) TrackN(ctx context.Context, name string, props map[string]any, n int) {
+	if e == nil {
+		return
+	}
+	tenantID := tenant.GetTenantID(ctx)
+	if tenantID == "" || !Enabled(ctx) {
+		return
+	}
+
+	raw, err := json.Marshal(props) // map keys are sorted: a canonical aggregation key
+	if err != nil {
+		return
+	}
+	key := eventKey{anonymousID: AnonymousID(e.salt, tenantID), name: name, properties: string(raw)}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if ev, ok := e.pending[key]; ok {
+		ev.Count += n
+		return
+	}
+	if len(e.pending) >= _maxPending {
+		e.dropped++
+		return
+	}
+	e.pending[key] = &Event{
+		Name:        name,
+		AnonymousID: key.anonymousID,
+		Properties:  props,
+		Count:       n,
+		Timestamp:   time.Now().UTC(),
+	}
+	e.order = append(e.order, key)
+}
+
+// Enabled reports whether the tenant in ctx shares analytics (analytics.enabled).
+func Enabled(ctx context.Context) bool {
+	return settings.Get(ctx, settings.KeyAnalyticsEnabled, settings.Subject{})
+}
+
+// Run flushes pending events every interval. Blocks until ctx is cancelled.
+func (e *Emitter) Run(ctx context.Context) {
+	if e == nil {
+		return
+	}
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), _finalFlushTimeout)
+			e.Flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			e.Flush(ctx)
+		}
+	}
+}
+
+// Flush sends the pending events and returns how many were sent.
+func (e *Emitter) Flush(ctx context.Context) int {
+	if e == nil {
+		return 0
+	}
+	e.mu.Lock()
+	events := make([]Event, 0, len(e.order))
+	for _, key := range e.order {
+		events = append(events, *e.pending[key])
+	}
+	dropped := e.dropped
+	e.pending = make(map[eventKey]*Event, len(events))
+	e.order = nil
+	e.dropped = 0
+	e.mu.Unlock()
+
+	if dropped > 0 {
+		e.log.Warnw("analytics events dropped, buffer full", "dropped", dropped)
+	}
+	if len(events) == 0 {
+		return 0
+	}
+	if err := e.sink.Send(ctx, events); err != nil {
+		e.log.Warnw("failed to send analytics events", "events", len(events), "error", err)
+		return 0
+	}
+	return len(events)
+}
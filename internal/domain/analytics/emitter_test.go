@@ -0,0 +1,116 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/settings"
+	"metapus/pkg/logger"
+)
+
+type captureSink struct {
+	batches [][]Event
+}
+
+func (s *captureSink) Send(_ context.Context, events []Event) error {
+	s.batches = append(s.batches, events)
+	return nil
+}
+
+// valueStore is an in-memory settings.ValueStore.
+type valueStore struct {
+	values []settings.Value
+}
+
+func (s *valueStore) ListValues(context.Context) ([]settings.Value, error) { return s.values, nil }
+
+func (s *valueStore) SetValue(_ context.Context, v settings.Value) (settings.Value, error) {
+	s.values = append(s.values, v)
+	return v, nil
+}
+
+func (s *valueStore) DeleteValue(context.Context, string, settings.Scope, id.ID) error { return nil }
+
+func tenantContext(tenantID string, store *valueStore) context.Context {
+	ctx := tenant.WithTenant(context.Background(), &tenant.Tenant{ID: tenantID})
+	return settings.WithResolver(ctx, settings.NewResolver(store))
+}
+
+func TestEmitterAggregatesIdenticalEvents(t *testing.T) {
+	sink := &captureSink{}
+	e := NewEmitter(sink, []byte("salt"), logger.Default())
+	ctx := tenantContext("t1", &valueStore{})
+
+	e.Track(ctx, EventReportRun, map[string]any{"dataset": "stock", "mode": "view"})
+	e.Track(ctx, EventReportRun, map[string]any{"mode": "view", "dataset": "stock"})
+	e.Track(ctx, EventReportRun, map[string]any{"dataset": "stock", "mode": "export"})
+
+	if n := e.Flush(context.Background()); n != 2 {
+		t.Fatalf("flushed %d events, want 2", n)
+	}
+	events := sink.batches[0]
+	if events[0].Count != 2 || events[1].Count != 1 {
+		t.Errorf("counts = %d, %d; want 2, 1", events[0].Count, events[1].Count)
+	}
+	if events[0].AnonymousID != AnonymousID([]byte("salt"), "t1") || events[0].AnonymousID == "t1" {
+		t.Errorf("anonymousId = %q", events[0].AnonymousID)
+	}
+
+	if n := e.Flush(context.Background()); n != 0 {
+		t.Errorf("second flush sent %d events, want 0", n)
+	}
+}
+
+func TestEmitterRespectsOptOut(t *testing.T) {
+	sink := &captureSink{}
+	e := NewEmitter(sink, []byte("salt"), logger.Default())
+
+	optedOut := &valueStore{values: []settings.Value{{
+		Key:   settings.KeyAnalyticsEnabled.Name(),
+		Scope: settings.ScopeTenant,
+		Value: json.RawMessage("false"),
+	}}}
+	e.Track(tenantContext("t1", optedOut), EventFeatureUsed, map[string]any{"feature": "GET /reports"})
+	e.Track(context.Background(), EventFeatureUsed, map[string]any{"feature": "GET /reports"}) // no tenant
+
+	if n := e.Flush(context.Background()); n != 0 {
+		t.Errorf("flushed %d events, want 0 for an opted-out tenant", n)
+	}
+}
+
+func TestNilEmitterIsNoop(t *testing.T) {
+	var e *Emitter
+	e.Track(tenantContext("t1", &valueStore{}), EventFeatureUsed, nil)
+	if n := e.Flush(context.Background()); n != 0 {
+		t.Errorf("nil emitter flushed %d events", n)
+	}
+}
+
+type fixedCounter map[string][2]int
+
+func (c fixedCounter) CountDocuments(_ context.Context, table string) (int, int, error) {
+	v := c[table]
+	return v[0], v[1], nil
+}
+
+func TestTrackDocumentCounts(t *testing.T) {
+	sink := &captureSink{}
+	e := NewEmitter(sink, []byte("salt"), logger.Default())
+	ctx := tenantContext("t1", &valueStore{})
+
+	n, err := e.TrackDocumentCounts(ctx, fixedCounter{"doc_goods_receipts": {10, 7}}, []DocumentType{
+		{Key: "goods_receipt", Table: "doc_goods_receipts"},
+		{Key: "goods_issue", Table: "doc_goods_issues"},
+	})
+	if err != nil || n != 1 {
+		t.Fatalf("TrackDocumentCounts = %d, %v; want 1, nil", n, err)
+	}
+	e.Flush(context.Background())
+	ev := sink.batches[0][0]
+	if ev.Name != EventDocumentCount || ev.Count != 10 || ev.Properties["documentType"] != "goods_receipt" || ev.Properties["posted"] != 7 {
+		t.Errorf("event = %+v", ev)
+	}
+}
@@ -0,0 +1,51 @@
+// Package analytics emits anonymized product analytics events — feature
+// usage, document counts by type, report runs — so the vendor can understand
+// feature adoption.
+//
+// Events carry no business data: a tenant is identified by a salted hash of
+// its ID, and properties are limited to route templates and entity or dataset
+// keys. Tenants opt out with the analytics.enabled setting.
+package analytics
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Event names.
+const (
+	// EventFeatureUsed is an API feature (method + route template) being used.
+	EventFeatureUsed = "feature_used"
+	// EventDocumentCount is the number of documents of one type (daily).
+	EventDocumentCount = "document_count"
+	// EventReportRun is a report being executed or exported.
+	EventReportRun = "report_run"
+)
+
+// Event is one analytics event. Identical events of a tenant within a flush
+// interval are aggregated: Count is the number of occurrences (or the counted
+// quantity for EventDocumentCount) and Timestamp is the first occurrence.
+type Event struct {
+	Name        string         `json:"event"`
+	AnonymousID string         `json:"anonymousId"`
+	Properties  map[string]any `json:"properties,omitempty"`
+	Count       int            `json:"count"`
+	Timestamp   time.Time      `json:"timestamp"`
+}
+
+// Sink delivers events to the analytics backend.
+type Sink interface {
+	Send(ctx context.Context, events []Event) error
+}
+
+// AnonymousID returns the stable pseudonymous identifier of a tenant: the
+// HMAC-SHA256 of its ID keyed by salt. Without the salt the tenant cannot be
+// recovered from the identifier.
+func AnonymousID(salt []byte, tenantID string) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(tenantID))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
@@ -0,0 +1,81 @@
+// Package artifact stores generated files — async report exports, backups —
+// in object storage with metadata in sys_artifacts, hands out expiring
+// signed download links and leaves lifecycle cleanup to the worker.
+package artifact
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"metapus/internal/core/id"
+)
+
+// Kind classifies what produced an artifact.
+type Kind string
+
+const (
+	KindReportExport Kind = "report_export"
+	KindBackup       Kind = "backup"
+)
+
+// IsValid reports whether k is a known artifact kind.
+func (k Kind) IsValid() bool {
+	switch k {
+	case KindReportExport, KindBackup:
+		return true
+	}
+	return false
+}
+
+// Artifact is the metadata of a stored file (sys_artifacts).
+type Artifact struct {
+	ID          id.ID      `db:"id" json:"id"`
+	Kind        Kind       `db:"kind" json:"kind"`
+	FileName    string     `db:"file_name" json:"fileName"`
+	ContentType string     `db:"content_type" json:"contentType"`
+	Size        int64      `db:"size" json:"size"`
+	Checksum    string     `db:"checksum" json:"checksum"`
+	StorageKey  string     `db:"storage_key" json:"-"`
+	CreatedBy   string     `db:"created_by" json:"createdBy,omitempty"`
+	ExpiresAt   *time.Time `db:"expires_at" json:"expiresAt,omitempty"`
+	CreatedAt   time.Time  `db:"created_at" json:"createdAt"`
+}
+
+// Expired reports whether the artifact is past its explicit expiry.
+func (a *Artifact) Expired(now time.Time) bool {
+	return a.ExpiresAt != nil && !now.Before(*a.ExpiresAt)
+}
+
+// ListFilter narrows List results.
+type ListFilter struct {
+	Kind  Kind
+	Limit int
+}
+
+// Repository persists artifact metadata.
+type Repository interface {
+	// Create inserts the artifact. ID and CreatedAt are set by the caller.
+	Create(ctx context.Context, a *Artifact) error
+	// GetByID returns apperror NotFound for a missing artifact.
+	GetByID(ctx context.Context, artifactID id.ID) (*Artifact, error)
+	// List returns the most recent artifacts.
+	List(ctx context.Context, f ListFilter) ([]Artifact, error)
+	// Delete removes the metadata row; NotFound if it does not exist.
+	Delete(ctx context.Context, artifactID id.ID) error
+}
+
+// BlobStore stores artifact contents by key. Satisfied by the blobstore
+// package; Get and Delete return apperror NotFound for a missing key.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Presigner is implemented by blob stores that can issue time-limited
+// download URLs themselves (S3). Clients then download straight from the
+// storage instead of through the API.
+type Presigner interface {
+	PresignGet(key, fileName string, ttl time.Duration) (string, error)
+}
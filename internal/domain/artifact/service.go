@@ -0,0 +1,186 @@
+package artifact
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"metapus/internal/core/apperror"
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/attachment"
+	"metapus/pkg/logger"
+)
+
+const (
+	// DefaultLinkTTL is the lifetime of a signed download link.
+	DefaultLinkTTL = 15 * time.Minute
+
+	_defaultListLimit = 50
+	_maxListLimit     = 500
+)
+
+// Service stores artifacts and issues download links.
+type Service struct {
+	repo    Repository
+	store   BlobStore
+	signer  *URLSigner
+	linkTTL time.Duration
+}
+
+// NewService creates an artifact service.
+func NewService(repo Repository, store BlobStore, signer *URLSigner) *Service {
+	return &Service{
+		repo:    repo,
+		store:   store,
+		signer:  signer,
+		linkTTL: DefaultLinkTTL,
+	}
+}
+
+// StoreInput describes a generated file to keep.
+type StoreInput struct {
+	Kind        Kind
+	FileName    string
+	ContentType string
+	Body        io.Reader
+	Size        int64
+	// ExpiresAt overrides the tenant retention (e.g. a one-off export link).
+	ExpiresAt *time.Time
+}
+
+// Store uploads the file and records its metadata. If the metadata cannot
+// be saved, the stored blob is removed.
+func (s *Service) Store(ctx context.Context, in StoreInput) (*Artifact, error) {
+	tenantID := tenant.GetTenantID(ctx)
+	if tenantID == "" {
+		return nil, apperror.NewInternal(fmt.Errorf("tenant not found in context"))
+	}
+	if !in.Kind.IsValid() {
+		return nil, apperror.NewValidation("unknown artifact kind").WithDetail("field", "kind")
+	}
+	if in.Size < 0 {
+		return nil, apperror.NewValidation("size must not be negative").WithDetail("field", "size")
+	}
+
+	fileName := attachment.SanitizeFileName(in.FileName)
+	contentType := attachment.NormalizeContentType(in.ContentType, fileName)
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	a := &Artifact{
+		ID:          id.New(),
+		Kind:        in.Kind,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        in.Size,
+		CreatedBy:   appctx.GetUserID(ctx),
+		ExpiresAt:   in.ExpiresAt,
+	}
+	a.StorageKey = storageKey(tenantID, a.Kind, a.ID)
+
+	hash := sha256.New()
+	if err := s.store.Put(ctx, a.StorageKey, io.TeeReader(io.LimitReader(in.Body, in.Size), hash), a.Size, a.ContentType); err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("store artifact: %w", err))
+	}
+	a.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	if err := s.repo.Create(ctx, a); err != nil {
+		s.discardBlob(ctx, a.StorageKey)
+		return nil, err
+	}
+	return a, nil
+}
+
+// Get returns artifact metadata.
+func (s *Service) Get(ctx context.Context, artifactID id.ID) (*Artifact, error) {
+	return s.repo.GetByID(ctx, artifactID)
+}
+
+// List returns the most recent artifacts.
+func (s *Service) List(ctx context.Context, f ListFilter) ([]Artifact, error) {
+	if f.Kind != "" && !f.Kind.IsValid() {
+		return nil, apperror.NewValidation("unknown artifact kind").WithDetail("field", "kind")
+	}
+	if f.Limit <= 0 {
+		f.Limit = _defaultListLimit
+	}
+	if f.Limit > _maxListLimit {
+		f.Limit = _maxListLimit
+	}
+	return s.repo.List(ctx, f)
+}
+
+// Delete removes an artifact. Metadata is removed first; a blob that cannot
+// be deleted afterwards is only logged, it is unreachable anyway.
+func (s *Service) Delete(ctx context.Context, artifactID id.ID) error {
+	a, err := s.repo.GetByID(ctx, artifactID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, a.ID); err != nil {
+		return err
+	}
+	s.discardBlob(ctx, a.StorageKey)
+	return nil
+}
+
+// DownloadURL returns an expiring download link. Stores that presign URLs
+// (S3) are downloaded from directly; otherwise the link points to the API
+// and is verified by Download. The link never outlives the artifact.
+func (s *Service) DownloadURL(ctx context.Context, a *Artifact) (string, time.Time, error) {
+	now := time.Now()
+	if a.Expired(now) {
+		return "", time.Time{}, apperror.NewNotFound("artifact", a.ID)
+	}
+	expires := now.Add(s.linkTTL)
+	if a.ExpiresAt != nil && a.ExpiresAt.Before(expires) {
+		expires = *a.ExpiresAt
+	}
+
+	if p, ok := s.store.(Presigner); ok {
+		u, err := p.PresignGet(a.StorageKey, a.FileName, expires.Sub(now))
+		if err != nil {
+			return "", time.Time{}, apperror.NewInternal(fmt.Errorf("presign artifact: %w", err))
+		}
+		return u, expires, nil
+	}
+	return s.signer.DownloadPath(tenant.GetTenantID(ctx), a.ID, expires), expires, nil
+}
+
+// Download verifies a signed link and returns the artifact with a reader
+// for its contents. The caller must close the reader.
+func (s *Service) Download(ctx context.Context, artifactID id.ID, expires int64, sig string) (*Artifact, io.ReadCloser, error) {
+	if !s.signer.Verify(tenant.GetTenantID(ctx), artifactID, expires, sig) {
+		return nil, nil, apperror.NewForbidden("download link is invalid or expired")
+	}
+	a, err := s.repo.GetByID(ctx, artifactID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if a.Expired(time.Now()) {
+		return nil, nil, apperror.NewNotFound("artifact", artifactID)
+	}
+	rc, err := s.store.Get(ctx, a.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, rc, nil
+}
+
+func (s *Service) discardBlob(ctx context.Context, key string) {
+	if err := s.store.Delete(ctx, key); err != nil && !apperror.IsNotFound(err) {
+		logger.Warn(ctx, "failed to delete artifact blob", "key", key, "error", err)
+	}
+}
+
+// storageKey builds the blob key. The tenant prefix keeps tenants apart
+// in a shared bucket or directory.
+func storageKey(tenantID string, kind Kind, artifactID id.ID) string {
+	return tenantID + "/artifacts/" + string(kind) + "/" + artifactID.String()
+}
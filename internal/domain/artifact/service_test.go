@@ -0,0 +1,173 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+)
+
+type memRepo struct {
+	items map[id.ID]*Artifact
+}
+
+func (r *memRepo) Create(_ context.Context, a *Artifact) error {
+	a.CreatedAt = time.Now()
+	r.items[a.ID] = a
+	return nil
+}
+
+func (r *memRepo) GetByID(_ context.Context, artifactID id.ID) (*Artifact, error) {
+	a, ok := r.items[artifactID]
+	if !ok {
+		return nil, apperror.NewNotFound("artifact", artifactID)
+	}
+	return a, nil
+}
+
+func (r *memRepo) List(context.Context, ListFilter) ([]Artifact, error) { return nil, nil }
+
+func (r *memRepo) Delete(_ context.Context, artifactID id.ID) error {
+	delete(r.items, artifactID)
+	return nil
+}
+
+type memStore struct {
+	blobs map[string][]byte
+}
+
+func (s *memStore) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.blobs[key] = data
+	return nil
+}
+
+func (s *memStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, apperror.NewNotFound("artifact_blob", key)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memStore) Delete(_ context.Context, key string) error {
+	delete(s.blobs, key)
+	return nil
+}
+
+type presigningStore struct {
+	memStore
+}
+
+func (s *presigningStore) PresignGet(key, _ string, _ time.Duration) (string, error) {
+	return "https://bucket.example/" + key + "?X-Amz-Signature=x", nil
+}
+
+func testContext() context.Context {
+	return tenant.WithTenant(context.Background(), &tenant.Tenant{ID: "t1"})
+}
+
+func TestStoreAndDownloadViaSignedPath(t *testing.T) {
+	ctx := testContext()
+	store := &memStore{blobs: map[string][]byte{}}
+	svc := NewService(&memRepo{items: map[id.ID]*Artifact{}}, store, NewURLSigner([]byte("k")))
+
+	a, err := svc.Store(ctx, StoreInput{
+		Kind:     KindReportExport,
+		FileName: "../sales.xlsx",
+		Body:     strings.NewReader("report"),
+		Size:     6,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.FileName != "sales.xlsx" || !strings.HasPrefix(a.StorageKey, "t1/artifacts/report_export/") {
+		t.Errorf("artifact = %+v", a)
+	}
+	if a.Checksum != "845e91831319e89c4d656bdb80c278ac09a7230d61e5dfd2e1b1fbb436ac8917" {
+		t.Errorf("checksum = %q", a.Checksum)
+	}
+
+	link, expires, err := svc.DownloadURL(ctx, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(link, "/api/v1/artifacts/"+a.ID.String()+"/download?") {
+		t.Errorf("link = %s", link)
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, rc, err := svc.Download(ctx, a.ID, expires.Unix(), u.Query().Get("sig"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "report" {
+		t.Errorf("downloaded %q", data)
+	}
+
+	if _, _, err := svc.Download(ctx, a.ID, expires.Unix(), "bad"); err == nil {
+		t.Error("a tampered signature must be rejected")
+	}
+}
+
+func TestDownloadURLUsesPresigner(t *testing.T) {
+	ctx := testContext()
+	store := &presigningStore{memStore{blobs: map[string][]byte{}}}
+	svc := NewService(&memRepo{items: map[id.ID]*Artifact{}}, store, NewURLSigner([]byte("k")))
+
+	a, err := svc.Store(ctx, StoreInput{Kind: KindBackup, FileName: "b.zip", Body: strings.NewReader("x"), Size: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	link, _, err := svc.DownloadURL(ctx, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(link, "https://bucket.example/t1/artifacts/backup/") {
+		t.Errorf("link = %s, want presigned storage URL", link)
+	}
+}
+
+func TestDownloadURLNeverOutlivesArtifact(t *testing.T) {
+	ctx := testContext()
+	svc := NewService(&memRepo{items: map[id.ID]*Artifact{}}, &memStore{blobs: map[string][]byte{}}, NewURLSigner([]byte("k")))
+
+	soon := time.Now().Add(time.Minute)
+	a := &Artifact{ID: id.New(), ExpiresAt: &soon}
+	_, expires, err := svc.DownloadURL(ctx, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !expires.Equal(soon) {
+		t.Errorf("link expires %v, want artifact expiry %v", expires, soon)
+	}
+
+	past := time.Now().Add(-time.Minute)
+	a.ExpiresAt = &past
+	if _, _, err := svc.DownloadURL(ctx, a); !apperror.IsNotFound(err) {
+		t.Errorf("expired artifact: err = %v, want NotFound", err)
+	}
+}
+
+func TestStoreRejectsUnknownKind(t *testing.T) {
+	svc := NewService(&memRepo{items: map[id.ID]*Artifact{}}, &memStore{blobs: map[string][]byte{}}, NewURLSigner([]byte("k")))
+	_, err := svc.Store(testContext(), StoreInput{Kind: "misc", Body: strings.NewReader(""), Size: 0})
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+}
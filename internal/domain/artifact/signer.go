@@ -0,0 +1,32 @@
+package artifact
+
+import (
+	"fmt"
+	"time"
+
+	"metapus/internal/core/crypto"
+	"metapus/internal/core/id"
+)
+
+// URLSigner issues and verifies signed download links served by the API,
+// used when the blob store cannot presign URLs (local directory).
+// A link is bound to tenant, artifact and expiry.
+type URLSigner struct {
+	links *crypto.LinkSigner
+}
+
+// NewURLSigner creates a signer with the given HMAC key.
+func NewURLSigner(key []byte) *URLSigner {
+	return &URLSigner{links: crypto.NewLinkSigner(key, "artifact")}
+}
+
+// DownloadPath returns the signed download path (relative to the API root).
+func (s *URLSigner) DownloadPath(tenantID string, artifactID id.ID, expires time.Time) string {
+	return s.links.SignedPath(fmt.Sprintf("/api/v1/artifacts/%s/download", artifactID),
+		tenantID, artifactID.String(), expires)
+}
+
+// Verify checks a signature and its expiry.
+func (s *URLSigner) Verify(tenantID string, artifactID id.ID, expires int64, sig string) bool {
+	return s.links.VerifyPath(tenantID, artifactID.String(), expires, sig)
+}
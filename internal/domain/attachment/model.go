@@ -0,0 +1,144 @@
+// Package attachment provides domain logic for files attached to documents
+// and catalog items. File metadata lives in the tenant database
+// (sys_attachments); file contents live in a pluggable BlobStore
+// (local filesystem or S3-compatible object storage).
+package attachment
+
+import (
+	"context"
+	"io"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/core/apperror"
+)
+
+// DefaultMaxSize is the upload size limit used when none is configured (20 MiB).
+const DefaultMaxSize int64 = 20 << 20
+
+// DefaultAllowedTypes lists the MIME types accepted when none are configured.
+// A trailing "/*" allows the whole top-level type.
+var DefaultAllowedTypes = []string{
+	"image/*",
+	"text/plain",
+	"text/csv",
+	"application/pdf",
+	"application/zip",
+	"application/xml",
+	"text/xml",
+	"application/msword",
+	"application/vnd.ms-excel",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"application/vnd.oasis.opendocument.text",
+	"application/vnd.oasis.opendocument.spreadsheet",
+}
+
+// Attachment is a file attached to a document or catalog item.
+type Attachment struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	EntityType  string     `json:"entityType" db:"entity_type"`
+	EntityID    uuid.UUID  `json:"entityId" db:"entity_id"`
+	FileName    string     `json:"fileName" db:"file_name"`
+	ContentType string     `json:"contentType" db:"content_type"`
+	Size        int64      `json:"size" db:"size"`
+	Checksum    string     `json:"checksum" db:"checksum"` // SHA-256, hex
+	StorageKey  string     `json:"-" db:"storage_key"`
+	UploadedBy  *uuid.UUID `json:"uploadedBy" db:"uploaded_by"`
+	CreatedAt   time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// BlobStore stores attachment contents by key.
+// Get and Delete return apperror NotFound for a missing key.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Limits restricts what may be uploaded.
+type Limits struct {
+	MaxSize      int64
+	AllowedTypes []string
+}
+
+// DefaultLimits returns the limits used when nothing is configured.
+func DefaultLimits() Limits {
+	return Limits{MaxSize: DefaultMaxSize, AllowedTypes: DefaultAllowedTypes}
+}
+
+// Allows reports whether contentType is in the allowed list.
+func (l Limits) Allows(contentType string) bool {
+	for _, allowed := range l.AllowedTypes {
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok {
+			if strings.HasPrefix(contentType, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if contentType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeContentType strips parameters and lowercases a MIME type.
+// If declared is empty or generic, the type is derived from the file extension.
+func NormalizeContentType(declared, fileName string) string {
+	ct := declared
+	if mt, _, err := mime.ParseMediaType(declared); err == nil {
+		ct = mt
+	}
+	ct = strings.ToLower(strings.TrimSpace(ct))
+	if ct == "" || ct == "application/octet-stream" {
+		if byExt := mime.TypeByExtension(strings.ToLower(path.Ext(fileName))); byExt != "" {
+			if mt, _, err := mime.ParseMediaType(byExt); err == nil {
+				return mt
+			}
+		}
+	}
+	return ct
+}
+
+// SanitizeFileName drops directory components and control characters
+// from a client-supplied file name.
+func SanitizeFileName(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	name = path.Base(name)
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	if len(name) > 255 {
+		name = name[len(name)-255:]
+	}
+	return name
+}
+
+// validateUpload checks size and content type of an upload against the limits.
+func (l Limits) validateUpload(size int64, contentType string) error {
+	if size <= 0 {
+		return apperror.NewValidation("validation failed").WithDetail("file", "file is empty")
+	}
+	if l.MaxSize > 0 && size > l.MaxSize {
+		return apperror.NewValidation("validation failed").
+			WithDetail("file", "file is too large").
+			WithDetail("maxSize", l.MaxSize)
+	}
+	if !l.Allows(contentType) {
+		return apperror.NewValidation("validation failed").
+			WithDetail("contentType", "file type is not allowed: "+contentType)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package attachment
+
+import "testing"
+
+func TestNormalizeContentType(t *testing.T) {
+	cases := []struct {
+		declared, fileName, want string
+	}{
+		{"image/PNG", "scan.png", "image/png"},
+		{"text/plain; charset=utf-8", "notes.txt", "text/plain"},
+		{"application/octet-stream", "invoice.pdf", "application/pdf"},
+		{"", "photo.jpg", "image/jpeg"},
+	}
+	for _, tc := range cases {
+		if got := NormalizeContentType(tc.declared, tc.fileName); got != tc.want {
+			t.Errorf("NormalizeContentType(%q, %q) = %q, want %q", tc.declared, tc.fileName, got, tc.want)
+		}
+	}
+}
+
+func TestLimitsValidateUpload(t *testing.T) {
+	l := Limits{MaxSize: 10, AllowedTypes: []string{"image/*", "application/pdf"}}
+
+	if err := l.validateUpload(5, "image/png"); err != nil {
+		t.Errorf("image/png: unexpected error %v", err)
+	}
+	if err := l.validateUpload(5, "application/pdf"); err != nil {
+		t.Errorf("application/pdf: unexpected error %v", err)
+	}
+	if err := l.validateUpload(11, "image/png"); err == nil {
+		t.Error("oversized file: want error")
+	}
+	if err := l.validateUpload(0, "image/png"); err == nil {
+		t.Error("empty file: want error")
+	}
+	if err := l.validateUpload(5, "application/x-msdownload"); err == nil {
+		t.Error("disallowed type: want error")
+	}
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	cases := map[string]string{
+		"../../etc/passwd":    "passwd",
+		`C:\docs\invoice.pdf`: "invoice.pdf",
+		"a\"b\n.txt":          "ab.txt",
+		"":                    "file",
+	}
+	for in, want := range cases {
+		if got := SanitizeFileName(in); got != want {
+			t.Errorf("SanitizeFileName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package attachment
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines storage operations for attachment metadata.
+type Repository interface {
+	// Create inserts attachment metadata.
+	Create(ctx context.Context, a *Attachment) error
+
+	// GetByID returns a single attachment.
+	GetByID(ctx context.Context, id uuid.UUID) (*Attachment, error)
+
+	// ListByEntity returns attachments of an entity, newest first.
+	ListByEntity(ctx context.Context, entityType string, entityID uuid.UUID) ([]*Attachment, error)
+
+	// Delete removes attachment metadata by ID.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,201 @@
+package attachment
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/core/apperror"
+	corectx "metapus/internal/core/context"
+	"metapus/internal/core/tenant"
+	"metapus/pkg/logger"
+)
+
+// Service manages attachments: metadata in Repository, contents in BlobStore.
+// Access to the owning entity is checked by the caller (HTTP layer) before
+// any Service method is invoked.
+type Service struct {
+	repo   Repository
+	store  BlobStore
+	limits Limits
+}
+
+// NewService creates a new attachment service.
+func NewService(repo Repository, store BlobStore, limits Limits) *Service {
+	if limits.MaxSize <= 0 {
+		limits.MaxSize = DefaultMaxSize
+	}
+	if len(limits.AllowedTypes) == 0 {
+		limits.AllowedTypes = DefaultAllowedTypes
+	}
+	return &Service{repo: repo, store: store, limits: limits}
+}
+
+// Limits returns the effective upload limits.
+func (s *Service) Limits() Limits {
+	return s.limits
+}
+
+// UploadInput describes a file being attached.
+type UploadInput struct {
+	EntityType  string
+	EntityID    uuid.UUID
+	FileName    string
+	ContentType string
+	Size        int64
+	Body        io.Reader
+}
+
+// List returns attachments of an entity.
+func (s *Service) List(ctx context.Context, entityType string, entityID uuid.UUID) ([]*Attachment, error) {
+	return s.repo.ListByEntity(ctx, entityType, entityID)
+}
+
+// Upload validates the file, stores its contents and records its metadata.
+// If the metadata cannot be saved, the stored blob is removed.
+func (s *Service) Upload(ctx context.Context, in UploadInput) (*Attachment, error) {
+	tenantID := tenant.GetTenantID(ctx)
+	if tenantID == "" {
+		return nil, apperror.NewInternal(fmt.Errorf("tenant not found in context"))
+	}
+
+	fileName := SanitizeFileName(in.FileName)
+	contentType := NormalizeContentType(in.ContentType, fileName)
+	if err := s.limits.validateUpload(in.Size, contentType); err != nil {
+		return nil, err
+	}
+
+	// Sniff the first bytes so that e.g. an HTML page cannot be smuggled in
+	// as an image and later served back to other users.
+	body := bufio.NewReaderSize(io.LimitReader(in.Body, in.Size), 512)
+	head, _ := body.Peek(512)
+	if err := checkSniffedType(contentType, http.DetectContentType(head)); err != nil {
+		return nil, err
+	}
+
+	attachmentID, err := uuid.NewV7()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("generate attachment id: %w", err))
+	}
+
+	a := &Attachment{
+		ID:          attachmentID,
+		EntityType:  in.EntityType,
+		EntityID:    in.EntityID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        in.Size,
+		StorageKey:  storageKey(tenantID, in.EntityType, in.EntityID, attachmentID),
+	}
+	if user := corectx.GetUser(ctx); user != nil {
+		if userID, err := uuid.Parse(user.UserID); err == nil {
+			a.UploadedBy = &userID
+		}
+	}
+
+	counter := &countingReader{r: body}
+	hash := sha256.New()
+	if err := s.store.Put(ctx, a.StorageKey, io.TeeReader(counter, hash), a.Size, a.ContentType); err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("store attachment: %w", err))
+	}
+	if counter.n != a.Size {
+		s.discardBlob(ctx, a.StorageKey)
+		return nil, apperror.NewValidation("validation failed").WithDetail("file", "file size does not match the declared size")
+	}
+	a.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	if err := s.repo.Create(ctx, a); err != nil {
+		s.discardBlob(ctx, a.StorageKey)
+		return nil, err
+	}
+	return a, nil
+}
+
+// Open returns attachment metadata and a reader for its contents.
+// The caller must close the reader.
+func (s *Service) Open(ctx context.Context, entityType string, entityID, attachmentID uuid.UUID) (*Attachment, io.ReadCloser, error) {
+	a, err := s.get(ctx, entityType, entityID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc, err := s.store.Get(ctx, a.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, rc, nil
+}
+
+// Delete removes an attachment. Metadata is removed first; a blob that
+// cannot be deleted afterwards is only logged, it is unreachable anyway.
+func (s *Service) Delete(ctx context.Context, entityType string, entityID, attachmentID uuid.UUID) error {
+	a, err := s.get(ctx, entityType, entityID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, a.ID); err != nil {
+		return err
+	}
+	s.discardBlob(ctx, a.StorageKey)
+	return nil
+}
+
+// get loads an attachment and checks that it belongs to the entity.
+func (s *Service) get(ctx context.Context, entityType string, entityID, attachmentID uuid.UUID) (*Attachment, error) {
+	a, err := s.repo.GetByID(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if a.EntityType != entityType || a.EntityID != entityID {
+		return nil, apperror.NewNotFound("attachment", attachmentID)
+	}
+	return a, nil
+}
+
+func (s *Service) discardBlob(ctx context.Context, key string) {
+	if err := s.store.Delete(ctx, key); err != nil && !apperror.IsNotFound(err) {
+		logger.Warn(ctx, "failed to delete attachment blob", "key", key, "error", err)
+	}
+}
+
+// storageKey builds the blob key. The tenant prefix keeps tenants apart
+// in a shared bucket or directory.
+func storageKey(tenantID, entityType string, entityID, attachmentID uuid.UUID) string {
+	return tenantID + "/" + entityType + "/" + entityID.String() + "/" + attachmentID.String()
+}
+
+// checkSniffedType rejects files whose contents contradict the declared type.
+func checkSniffedType(declared, sniffed string) error {
+	sniffed, _, _ = strings.Cut(sniffed, ";")
+	mismatch := false
+	switch {
+	case sniffed == "text/html" && declared != "text/html":
+		mismatch = true
+	case strings.HasPrefix(declared, "image/") && !strings.HasPrefix(sniffed, "image/"):
+		mismatch = true
+	case declared == "application/pdf" && sniffed != "application/pdf":
+		mismatch = true
+	}
+	if mismatch {
+		return apperror.NewValidation("validation failed").
+			WithDetail("contentType", "file contents do not match type "+declared)
+	}
+	return nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
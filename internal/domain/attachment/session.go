@@ -0,0 +1,139 @@
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/core/crypto"
+)
+
+// Upload sessions let clients with many files (e.g. inventory photos taken
+// on a phone) upload them straight to storage instead of one multipart
+// request each:
+//
+//  1. CreateSession declares a batch of files and returns an upload URL per
+//     file; AddFiles issues further batches for the same session.
+//  2. The client PUTs each file to its URL — a presigned storage URL (S3) or
+//     a signed API path (local storage).
+//  3. Finalize checks every file against its declaration and attaches all of
+//     them in one transaction, or none.
+
+const (
+	// DefaultSessionTTL is how long a session accepts uploads and finalize.
+	DefaultSessionTTL = time.Hour
+
+	// MaxBatchFiles bounds the files declared in one request.
+	MaxBatchFiles = 100
+
+	// MaxSessionFiles bounds the files of one session.
+	MaxSessionFiles = 500
+)
+
+// SessionStatus is the state of an upload session.
+type SessionStatus string
+
+const (
+	SessionOpen      SessionStatus = "open"
+	SessionFinalized SessionStatus = "finalized"
+)
+
+// UploadSession groups files uploaded for one entity.
+type UploadSession struct {
+	ID          uuid.UUID     `json:"id" db:"id"`
+	EntityType  string        `json:"entityType" db:"entity_type"`
+	EntityID    uuid.UUID     `json:"entityId" db:"entity_id"`
+	Status      SessionStatus `json:"status" db:"status"`
+	CreatedBy   *uuid.UUID    `json:"createdBy" db:"created_by"`
+	ExpiresAt   time.Time     `json:"expiresAt" db:"expires_at"`
+	FinalizedAt *time.Time    `json:"finalizedAt,omitempty" db:"finalized_at"`
+	CreatedAt   time.Time     `json:"createdAt" db:"created_at"`
+}
+
+// SessionFile is a file declared in a session. Its ID becomes the
+// attachment ID and its StorageKey the attachment's, so finalize does not
+// move any contents.
+type SessionFile struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	SessionID   uuid.UUID `json:"sessionId" db:"session_id"`
+	FileName    string    `json:"fileName" db:"file_name"`
+	ContentType string    `json:"contentType" db:"content_type"`
+	Size        int64     `json:"size" db:"size"`
+	StorageKey  string    `json:"-" db:"storage_key"`
+	CreatedAt   time.Time `json:"createdAt" db:"created_at"`
+}
+
+// FileSpec declares a file the client is about to upload.
+type FileSpec struct {
+	FileName    string
+	ContentType string
+	Size        int64
+}
+
+// UploadTarget tells the client where to upload a declared file.
+type UploadTarget struct {
+	File      *SessionFile
+	Method    string            // always PUT
+	URL       string            // absolute (storage) or API-relative
+	Headers   map[string]string // headers the request must carry
+	ExpiresAt time.Time
+}
+
+// SessionRepository persists upload sessions.
+type SessionRepository interface {
+	// CreateSession inserts the session; ID and ExpiresAt are set by the caller.
+	CreateSession(ctx context.Context, s *UploadSession) error
+
+	// GetSession returns apperror NotFound for a missing session. With
+	// forUpdate the row is locked until the end of the transaction.
+	GetSession(ctx context.Context, sessionID uuid.UUID, forUpdate bool) (*UploadSession, error)
+
+	// AddFiles inserts declared files of a session.
+	AddFiles(ctx context.Context, files []*SessionFile) error
+
+	// ListFiles returns the files of a session in declaration order.
+	ListFiles(ctx context.Context, sessionID uuid.UUID) ([]*SessionFile, error)
+
+	// GetFile returns a declared file with its session; NotFound if missing.
+	GetFile(ctx context.Context, fileID uuid.UUID) (*SessionFile, *UploadSession, error)
+
+	// MarkFinalized sets the session status to finalized.
+	MarkFinalized(ctx context.Context, sessionID uuid.UUID, at time.Time) error
+
+	// ListExpired returns open sessions that expired before t, oldest first.
+	ListExpired(ctx context.Context, t time.Time, limit int) ([]*UploadSession, error)
+
+	// DeleteSession removes a session and its declared files.
+	DeleteSession(ctx context.Context, sessionID uuid.UUID) error
+}
+
+// UploadPresigner is implemented by blob stores that can issue time-limited
+// upload URLs themselves (S3). Clients then upload straight to the storage.
+// The request must carry the returned headers.
+type UploadPresigner interface {
+	PresignPut(key, contentType string, ttl time.Duration) (url string, headers map[string]string, err error)
+}
+
+// UploadSigner issues and verifies signed upload paths served by the API,
+// used when the blob store cannot presign URLs (local directory).
+// A path is bound to tenant, file and expiry.
+type UploadSigner struct {
+	links *crypto.LinkSigner
+}
+
+// NewUploadSigner creates a signer with the given HMAC key.
+func NewUploadSigner(key []byte) *UploadSigner {
+	return &UploadSigner{links: crypto.NewLinkSigner(key, "upload")}
+}
+
+// UploadPath returns the signed upload path (relative to the API root).
+func (s *UploadSigner) UploadPath(tenantID string, fileID uuid.UUID, expires time.Time) string {
+	return s.links.SignedPath(fmt.Sprintf("/api/v1/uploads/%s", fileID), tenantID, fileID.String(), expires)
+}
+
+// Verify checks a signature and its expiry.
+func (s *UploadSigner) Verify(tenantID string, fileID uuid.UUID, expires int64, sig string) bool {
+	return s.links.VerifyPath(tenantID, fileID.String(), expires, sig)
+}
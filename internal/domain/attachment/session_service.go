@@ -0,0 +1,415 @@
+package attachment
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/core/apperror"
+	corectx "metapus/internal/core/context"
+	"metapus/internal/core/tenant"
+)
+
+// _purgeBatch bounds the expired sessions removed per PurgeExpired call.
+const _purgeBatch = 100
+
+// SessionService runs upload sessions on top of Service: files are checked
+// against the same limits and become ordinary attachments. Like Service, it
+// relies on the caller to check access to the owning entity.
+type SessionService struct {
+	attachments *Service
+	repo        SessionRepository
+	signer      *UploadSigner
+	ttl         time.Duration
+	now         func() time.Time
+}
+
+// NewSessionService creates an upload session service.
+func NewSessionService(attachments *Service, repo SessionRepository, signer *UploadSigner) *SessionService {
+	return &SessionService{
+		attachments: attachments,
+		repo:        repo,
+		signer:      signer,
+		ttl:         DefaultSessionTTL,
+		now:         time.Now,
+	}
+}
+
+// FileProblem explains why a file of a session cannot be attached.
+type FileProblem struct {
+	FileID   uuid.UUID `json:"fileId"`
+	FileName string    `json:"fileName"`
+	Error    string    `json:"error"`
+}
+
+// Create opens a session for an entity and declares the first batch of files.
+func (s *SessionService) Create(ctx context.Context, entityType string, entityID uuid.UUID, files []FileSpec) (*UploadSession, []UploadTarget, error) {
+	tenantID := tenant.GetTenantID(ctx)
+	if tenantID == "" {
+		return nil, nil, apperror.NewInternal(fmt.Errorf("tenant not found in context"))
+	}
+	sessionID, err := uuid.NewV7()
+	if err != nil {
+		return nil, nil, apperror.NewInternal(fmt.Errorf("generate session id: %w", err))
+	}
+
+	sess := &UploadSession{
+		ID:         sessionID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Status:     SessionOpen,
+		ExpiresAt:  s.now().Add(s.ttl),
+	}
+	if user := corectx.GetUser(ctx); user != nil {
+		if userID, err := uuid.Parse(user.UserID); err == nil {
+			sess.CreatedBy = &userID
+		}
+	}
+
+	declared, err := s.declare(tenantID, sess, files, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txm, err := tenant.GetTxManager(ctx)
+	if err != nil {
+		return nil, nil, apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateSession(ctx, sess); err != nil {
+			return err
+		}
+		return s.repo.AddFiles(ctx, declared)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targets, err := s.targets(tenantID, sess, declared)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sess, targets, nil
+}
+
+// AddFiles declares another batch of files in an open session.
+func (s *SessionService) AddFiles(ctx context.Context, entityType string, entityID, sessionID uuid.UUID, files []FileSpec) (*UploadSession, []UploadTarget, error) {
+	tenantID := tenant.GetTenantID(ctx)
+	txm, err := tenant.GetTxManager(ctx)
+	if err != nil {
+		return nil, nil, apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+
+	var (
+		sess     *UploadSession
+		declared []*SessionFile
+	)
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		sess, err = s.session(ctx, entityType, entityID, sessionID, true)
+		if err != nil {
+			return err
+		}
+		if err := s.checkOpen(sess); err != nil {
+			return err
+		}
+		existing, err := s.repo.ListFiles(ctx, sess.ID)
+		if err != nil {
+			return err
+		}
+		if declared, err = s.declare(tenantID, sess, files, len(existing)); err != nil {
+			return err
+		}
+		return s.repo.AddFiles(ctx, declared)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targets, err := s.targets(tenantID, sess, declared)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sess, targets, nil
+}
+
+// Get returns a session with its declared files.
+func (s *SessionService) Get(ctx context.Context, entityType string, entityID, sessionID uuid.UUID) (*UploadSession, []*SessionFile, error) {
+	sess, err := s.session(ctx, entityType, entityID, sessionID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	files, err := s.repo.ListFiles(ctx, sess.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sess, files, nil
+}
+
+// Receive stores the contents of a declared file uploaded to a signed API
+// path. The contents are checked against the declaration on Finalize; only
+// the size is enforced here, so storage cannot be filled beyond it.
+func (s *SessionService) Receive(ctx context.Context, fileID uuid.UUID, expires int64, sig string, body io.Reader) error {
+	if s.signer == nil || !s.signer.Verify(tenant.GetTenantID(ctx), fileID, expires, sig) {
+		return apperror.NewForbidden("upload link is invalid or expired")
+	}
+	f, sess, err := s.repo.GetFile(ctx, fileID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkOpen(sess); err != nil {
+		return err
+	}
+
+	counter := &countingReader{r: io.LimitReader(body, f.Size+1)}
+	if err := s.attachments.store.Put(ctx, f.StorageKey, counter, f.Size, f.ContentType); err != nil {
+		return apperror.NewInternal(fmt.Errorf("store upload: %w", err))
+	}
+	if counter.n != f.Size {
+		s.attachments.discardBlob(ctx, f.StorageKey)
+		return apperror.NewValidation("validation failed").WithDetail("file", "file size does not match the declared size")
+	}
+	return nil
+}
+
+// Finalize attaches all files of the session to its entity in one
+// transaction. Every file must have been uploaded and match its declared
+// size and type; otherwise nothing is attached, the problems are returned
+// as validation details and the session stays open for another attempt.
+// Finalizing a finalized session returns its attachments again, so clients
+// may safely retry.
+func (s *SessionService) Finalize(ctx context.Context, entityType string, entityID, sessionID uuid.UUID) ([]*Attachment, error) {
+	sess, files, err := s.Get(ctx, entityType, entityID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if sess.Status == SessionFinalized {
+		return s.finalized(ctx, files)
+	}
+	if err := s.checkOpen(sess); err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, apperror.NewValidation("upload session has no files").WithDetail("sessionId", sess.ID)
+	}
+
+	// Blobs are read outside the transaction: it would otherwise stay open
+	// for as long as the storage takes to stream every file.
+	attachments := make([]*Attachment, 0, len(files))
+	var problems []FileProblem
+	for _, f := range files {
+		checksum, err := s.verify(ctx, f)
+		if err != nil {
+			problems = append(problems, FileProblem{FileID: f.ID, FileName: f.FileName, Error: err.Error()})
+			continue
+		}
+		attachments = append(attachments, &Attachment{
+			ID:          f.ID,
+			EntityType:  sess.EntityType,
+			EntityID:    sess.EntityID,
+			FileName:    f.FileName,
+			ContentType: f.ContentType,
+			Size:        f.Size,
+			Checksum:    checksum,
+			StorageKey:  f.StorageKey,
+			UploadedBy:  sess.CreatedBy,
+		})
+	}
+	if len(problems) > 0 {
+		return nil, apperror.NewValidation("validation failed").WithDetail("files", problems)
+	}
+
+	txm, err := tenant.GetTxManager(ctx)
+	if err != nil {
+		return nil, apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		locked, err := s.repo.GetSession(ctx, sess.ID, true)
+		if err != nil {
+			return err
+		}
+		if locked.Status != SessionOpen {
+			return apperror.NewConcurrentModification("upload_session", sess.ID)
+		}
+		for _, a := range attachments {
+			if err := s.attachments.repo.Create(ctx, a); err != nil {
+				return err
+			}
+		}
+		return s.repo.MarkFinalized(ctx, sess.ID, s.now())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// PurgeExpired removes expired open sessions with their uploaded blobs.
+// Returns the number of sessions removed.
+func (s *SessionService) PurgeExpired(ctx context.Context) (int, error) {
+	expired, err := s.repo.ListExpired(ctx, s.now(), _purgeBatch)
+	if err != nil {
+		return 0, err
+	}
+	for i, sess := range expired {
+		files, err := s.repo.ListFiles(ctx, sess.ID)
+		if err != nil {
+			return i, err
+		}
+		// Rows go first: a finalize racing with the purge then fails
+		// instead of attaching a blob that is about to be deleted.
+		if err := s.repo.DeleteSession(ctx, sess.ID); err != nil {
+			return i, err
+		}
+		for _, f := range files {
+			s.attachments.discardBlob(ctx, f.StorageKey)
+		}
+	}
+	return len(expired), nil
+}
+
+// session loads a session and checks that it belongs to the entity.
+func (s *SessionService) session(ctx context.Context, entityType string, entityID, sessionID uuid.UUID, forUpdate bool) (*UploadSession, error) {
+	sess, err := s.repo.GetSession(ctx, sessionID, forUpdate)
+	if err != nil {
+		return nil, err
+	}
+	if sess.EntityType != entityType || sess.EntityID != entityID {
+		return nil, apperror.NewNotFound("upload_session", sessionID)
+	}
+	return sess, nil
+}
+
+// checkOpen rejects uploads to finalized or expired sessions.
+func (s *SessionService) checkOpen(sess *UploadSession) error {
+	if sess.Status != SessionOpen {
+		return apperror.NewBusinessRule("UPLOAD_SESSION_FINALIZED", "upload session is already finalized")
+	}
+	if !s.now().Before(sess.ExpiresAt) {
+		return apperror.NewBusinessRule("UPLOAD_SESSION_EXPIRED", "upload session has expired; start a new one")
+	}
+	return nil
+}
+
+// declare validates a batch of file specs and builds their session files.
+// Problems are reported per file index, e.g. "files[3].size".
+func (s *SessionService) declare(tenantID string, sess *UploadSession, specs []FileSpec, existing int) ([]*SessionFile, error) {
+	if len(specs) == 0 {
+		return nil, apperror.NewValidation("at least one file is required").WithDetail("field", "files")
+	}
+	if len(specs) > MaxBatchFiles {
+		return nil, apperror.NewValidation("too many files in one request").WithDetail("maxFiles", MaxBatchFiles)
+	}
+	if existing+len(specs) > MaxSessionFiles {
+		return nil, apperror.NewValidation("too many files in the session").WithDetail("maxFiles", MaxSessionFiles)
+	}
+
+	files := make([]*SessionFile, 0, len(specs))
+	invalid := apperror.NewValidation("validation failed")
+	for i, spec := range specs {
+		fileName := SanitizeFileName(spec.FileName)
+		contentType := NormalizeContentType(spec.ContentType, fileName)
+		if err := s.attachments.limits.validateUpload(spec.Size, contentType); err != nil {
+			var detail any = err.Error()
+			if appErr, ok := apperror.AsAppError(err); ok {
+				detail = appErr.Details
+			}
+			invalid = invalid.WithDetail("files["+strconv.Itoa(i)+"]", detail)
+			continue
+		}
+		fileID, err := uuid.NewV7()
+		if err != nil {
+			return nil, apperror.NewInternal(fmt.Errorf("generate file id: %w", err))
+		}
+		files = append(files, &SessionFile{
+			ID:          fileID,
+			SessionID:   sess.ID,
+			FileName:    fileName,
+			ContentType: contentType,
+			Size:        spec.Size,
+			StorageKey:  storageKey(tenantID, sess.EntityType, sess.EntityID, fileID),
+		})
+	}
+	if len(files) < len(specs) {
+		return nil, invalid
+	}
+	return files, nil
+}
+
+// targets issues an upload URL per file, valid until the session expires.
+func (s *SessionService) targets(tenantID string, sess *UploadSession, files []*SessionFile) ([]UploadTarget, error) {
+	ttl := sess.ExpiresAt.Sub(s.now())
+	presigner, direct := s.attachments.store.(UploadPresigner)
+	if !direct && s.signer == nil {
+		return nil, apperror.NewInternal(fmt.Errorf("upload sessions: store cannot presign and no signer is configured"))
+	}
+
+	targets := make([]UploadTarget, len(files))
+	for i, f := range files {
+		t := UploadTarget{File: f, Method: http.MethodPut, ExpiresAt: sess.ExpiresAt}
+		if direct {
+			u, headers, err := presigner.PresignPut(f.StorageKey, f.ContentType, ttl)
+			if err != nil {
+				return nil, apperror.NewInternal(fmt.Errorf("presign upload: %w", err))
+			}
+			t.URL, t.Headers = u, headers
+		} else {
+			t.URL = s.signer.UploadPath(tenantID, f.ID, sess.ExpiresAt)
+			t.Headers = map[string]string{"Content-Type": f.ContentType}
+		}
+		targets[i] = t
+	}
+	return targets, nil
+}
+
+// verify reads an uploaded blob and checks it against its declaration.
+// Returns the SHA-256 of the contents.
+func (s *SessionService) verify(ctx context.Context, f *SessionFile) (string, error) {
+	rc, err := s.attachments.store.Get(ctx, f.StorageKey)
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return "", fmt.Errorf("file was not uploaded")
+		}
+		return "", fmt.Errorf("read uploaded file: %w", err)
+	}
+	defer rc.Close()
+
+	body := bufio.NewReaderSize(rc, 512)
+	head, _ := body.Peek(512)
+	if err := checkSniffedType(f.ContentType, http.DetectContentType(head)); err != nil {
+		return "", fmt.Errorf("file contents do not match type %s", f.ContentType)
+	}
+
+	hash := sha256.New()
+	n, err := io.Copy(hash, io.LimitReader(body, f.Size+1))
+	if err != nil {
+		return "", fmt.Errorf("read uploaded file: %w", err)
+	}
+	if n != f.Size {
+		return "", fmt.Errorf("file size %d does not match the declared size %d", n, f.Size)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// finalized returns the attachments created by an earlier Finalize.
+func (s *SessionService) finalized(ctx context.Context, files []*SessionFile) ([]*Attachment, error) {
+	out := make([]*Attachment, 0, len(files))
+	for _, f := range files {
+		a, err := s.attachments.repo.GetByID(ctx, f.ID)
+		if err != nil {
+			if apperror.IsNotFound(err) {
+				continue // deleted since
+			}
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
@@ -0,0 +1,277 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/tenant"
+)
+
+type passTxManager struct{}
+
+func (passTxManager) RunInTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// memStore keeps blobs in memory.
+type memStore map[string][]byte
+
+func (m memStore) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	b, err := io.ReadAll(r)
+	m[key] = b
+	return err
+}
+
+func (m memStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	b, ok := m[key]
+	if !ok {
+		return nil, apperror.NewNotFound("blob", key)
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (m memStore) Delete(_ context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
+
+// memAttachments stores attachment metadata in memory.
+type memAttachments map[uuid.UUID]*Attachment
+
+func (m memAttachments) Create(_ context.Context, a *Attachment) error {
+	m[a.ID] = a
+	return nil
+}
+
+func (m memAttachments) GetByID(_ context.Context, id uuid.UUID) (*Attachment, error) {
+	if a, ok := m[id]; ok {
+		return a, nil
+	}
+	return nil, apperror.NewNotFound("attachment", id)
+}
+
+func (m memAttachments) ListByEntity(context.Context, string, uuid.UUID) ([]*Attachment, error) {
+	return nil, nil
+}
+
+func (m memAttachments) Delete(_ context.Context, id uuid.UUID) error {
+	delete(m, id)
+	return nil
+}
+
+// memSessions stores upload sessions in memory.
+type memSessions struct {
+	sessions map[uuid.UUID]*UploadSession
+	files    []*SessionFile
+}
+
+func (m *memSessions) CreateSession(_ context.Context, s *UploadSession) error {
+	m.sessions[s.ID] = s
+	return nil
+}
+
+func (m *memSessions) GetSession(_ context.Context, id uuid.UUID, _ bool) (*UploadSession, error) {
+	if s, ok := m.sessions[id]; ok {
+		copied := *s
+		return &copied, nil
+	}
+	return nil, apperror.NewNotFound("upload_session", id)
+}
+
+func (m *memSessions) AddFiles(_ context.Context, files []*SessionFile) error {
+	m.files = append(m.files, files...)
+	return nil
+}
+
+func (m *memSessions) ListFiles(_ context.Context, sessionID uuid.UUID) ([]*SessionFile, error) {
+	var out []*SessionFile
+	for _, f := range m.files {
+		if f.SessionID == sessionID {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+func (m *memSessions) GetFile(ctx context.Context, fileID uuid.UUID) (*SessionFile, *UploadSession, error) {
+	for _, f := range m.files {
+		if f.ID == fileID {
+			s, err := m.GetSession(ctx, f.SessionID, false)
+			return f, s, err
+		}
+	}
+	return nil, nil, apperror.NewNotFound("upload_file", fileID)
+}
+
+func (m *memSessions) MarkFinalized(_ context.Context, id uuid.UUID, at time.Time) error {
+	m.sessions[id].Status = SessionFinalized
+	m.sessions[id].FinalizedAt = &at
+	return nil
+}
+
+func (m *memSessions) ListExpired(_ context.Context, t time.Time, _ int) ([]*UploadSession, error) {
+	var out []*UploadSession
+	for _, s := range m.sessions {
+		if s.Status == SessionOpen && s.ExpiresAt.Before(t) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (m *memSessions) DeleteSession(_ context.Context, id uuid.UUID) error {
+	delete(m.sessions, id)
+	return nil
+}
+
+var pngBytes = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 24)...)
+
+func newTestSessionService() (*SessionService, memStore, memAttachments, *memSessions) {
+	store, attachments := memStore{}, memAttachments{}
+	sessions := &memSessions{sessions: map[uuid.UUID]*UploadSession{}}
+	svc := NewSessionService(NewService(attachments, store, DefaultLimits()), sessions, NewUploadSigner([]byte("secret")))
+	return svc, store, attachments, sessions
+}
+
+func testContext() context.Context {
+	ctx := tenant.WithTenant(context.Background(), &tenant.Tenant{ID: "t1"})
+	return tenant.WithTxManager(ctx, passTxManager{})
+}
+
+// upload PUTs body to a signed target the way the upload handler does.
+func upload(t *testing.T, ctx context.Context, svc *SessionService, target UploadTarget, body []byte) error {
+	t.Helper()
+	u, err := url.Parse(target.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expires, _ := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+	return svc.Receive(ctx, target.File.ID, expires, u.Query().Get("sig"), bytes.NewReader(body))
+}
+
+func TestSessionFinalizeAttachesAllOrNothing(t *testing.T) {
+	svc, _, attachments, _ := newTestSessionService()
+	ctx := testContext()
+	entityID := uuid.New()
+	size := int64(len(pngBytes))
+
+	sess, targets, err := svc.Create(ctx, "goods_receipt", entityID, []FileSpec{
+		{FileName: "shelf-1.png", ContentType: "image/png", Size: size},
+		{FileName: "shelf-2.png", ContentType: "image/png", Size: size},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(targets) != 2 || !strings.HasPrefix(targets[0].URL, "/api/v1/uploads/"+targets[0].File.ID.String()) {
+		t.Fatalf("targets = %+v", targets)
+	}
+	if err := upload(t, ctx, svc, targets[0], pngBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second file is missing: nothing is attached.
+	_, err = svc.Finalize(ctx, "goods_receipt", entityID, sess.ID)
+	appErr, ok := apperror.AsAppError(err)
+	if !ok || appErr.Code != apperror.CodeValidation {
+		t.Fatalf("Finalize with a missing file: err = %v", err)
+	}
+	problems, _ := appErr.Details["files"].([]FileProblem)
+	if len(problems) != 1 || problems[0].FileID != targets[1].File.ID {
+		t.Fatalf("problems = %+v", appErr.Details["files"])
+	}
+	if len(attachments) != 0 {
+		t.Fatalf("attachments created despite a missing file: %d", len(attachments))
+	}
+
+	if err := upload(t, ctx, svc, targets[1], pngBytes); err != nil {
+		t.Fatal(err)
+	}
+	list, err := svc.Finalize(ctx, "goods_receipt", entityID, sess.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || len(attachments) != 2 || list[0].ID != targets[0].File.ID || list[0].Checksum == "" {
+		t.Fatalf("finalized attachments = %+v", list)
+	}
+
+	// A retried finalize returns the same attachments.
+	again, err := svc.Finalize(ctx, "goods_receipt", entityID, sess.ID)
+	if err != nil || len(again) != 2 {
+		t.Fatalf("retried Finalize = %d, %v", len(again), err)
+	}
+	if err := upload(t, ctx, svc, targets[0], pngBytes); err == nil {
+		t.Error("upload to a finalized session succeeded")
+	}
+}
+
+func TestSessionReceiveChecksSignatureAndSize(t *testing.T) {
+	svc, store, _, _ := newTestSessionService()
+	ctx := testContext()
+	_, targets, err := svc.Create(ctx, "goods_receipt", uuid.New(), []FileSpec{
+		{FileName: "photo.png", ContentType: "image/png", Size: int64(len(pngBytes))},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expires := targets[0].ExpiresAt.Unix()
+	err = svc.Receive(ctx, targets[0].File.ID, expires, "forged", bytes.NewReader(pngBytes))
+	if appErr, ok := apperror.AsAppError(err); !ok || appErr.Code != apperror.CodeForbidden {
+		t.Fatalf("forged signature: err = %v", err)
+	}
+
+	err = upload(t, ctx, svc, targets[0], append(pngBytes, 0))
+	if appErr, ok := apperror.AsAppError(err); !ok || appErr.Code != apperror.CodeValidation {
+		t.Fatalf("oversized upload: err = %v", err)
+	}
+	if len(store) != 0 {
+		t.Fatalf("oversized upload left a blob behind")
+	}
+}
+
+func TestSessionDeclareReportsEachFile(t *testing.T) {
+	svc, _, _, sessions := newTestSessionService()
+	_, _, err := svc.Create(testContext(), "goods_receipt", uuid.New(), []FileSpec{
+		{FileName: "photo.png", ContentType: "image/png", Size: 10},
+		{FileName: "tool.exe", ContentType: "application/x-msdownload", Size: 10},
+	})
+	appErr, ok := apperror.AsAppError(err)
+	if !ok || appErr.Details["files[1]"] == nil || appErr.Details["files[0]"] != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if len(sessions.sessions) != 0 {
+		t.Fatal("session created for an invalid batch")
+	}
+}
+
+func TestSessionPurgeExpired(t *testing.T) {
+	svc, store, _, sessions := newTestSessionService()
+	ctx := testContext()
+	_, targets, err := svc.Create(ctx, "goods_receipt", uuid.New(), []FileSpec{
+		{FileName: "photo.png", ContentType: "image/png", Size: int64(len(pngBytes))},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := upload(t, ctx, svc, targets[0], pngBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	svc.now = func() time.Time { return time.Now().Add(2 * DefaultSessionTTL) }
+	n, err := svc.PurgeExpired(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("PurgeExpired = %d, %v; want 1, nil", n, err)
+	}
+	if len(sessions.sessions) != 0 || len(store) != 0 {
+		t.Fatalf("left sessions=%d blobs=%d", len(sessions.sessions), len(store))
+	}
+}
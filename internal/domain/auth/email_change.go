@@ -0,0 +1,252 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/pkg/logger"
+)
+
+// MailMessage is a single outgoing email.
+type MailMessage struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers transactional emails (verification links, security notices).
+// Implementations live in the infrastructure layer.
+type Mailer interface {
+	Send(ctx context.Context, msg MailMessage) error
+}
+
+// SetMailer configures the mailer used for transactional emails.
+func (s *Service) SetMailer(m Mailer) {
+	s.mailer = m
+}
+
+// SetEmailChangeRepository enables the change-email flow.
+func (s *Service) SetEmailChangeRepository(repo EmailChangeRepository) {
+	s.emailChangeRepo = repo
+}
+
+// RequestEmailChange starts the change-email flow for the given user.
+// The current password is required; wrong passwords count as failed logins.
+// A verification token is sent to the new address; the email is not changed
+// until ConfirmEmailChange is called.
+func (s *Service) RequestEmailChange(ctx context.Context, userID id.ID, password, newEmail string) error {
+	if _, err := s.requireTenantID(ctx); err != nil {
+		return err
+	}
+	if s.emailChangeRepo == nil || s.mailer == nil {
+		return apperror.NewBusinessRule("EMAIL_CHANGE_UNAVAILABLE", "email change is not configured")
+	}
+
+	newEmail = strings.TrimSpace(newEmail)
+	if _, err := mail.ParseAddress(newEmail); err != nil {
+		return apperror.NewValidation("invalid email").WithDetail("field", "newEmail")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if !apperror.IsNotFound(err) {
+			logger.Error(ctx, "failed to get user for email change", "user_id", userID, "error", err)
+		}
+		return apperror.NewNotFound("user", userID.String()).WithCause(err)
+	}
+
+	// A wrong password counts toward the login lockout, so this endpoint
+	// cannot be used to guess passwords past MaxLoginAttempts.
+	if user.IsLocked() {
+		return apperror.NewForbidden("account is temporarily locked")
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		user.RecordFailedLogin(s.config.MaxLoginAttempts, s.config.LockDuration)
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			logger.Error(ctx, "failed to record failed password check", "user_id", user.ID, "error", err)
+		}
+		return apperror.NewUnauthorized("invalid password")
+	}
+	if strings.EqualFold(user.Email, newEmail) {
+		return apperror.NewValidation("new email must differ from the current one").WithDetail("field", "newEmail")
+	}
+
+	exists, err := s.userRepo.Exists(ctx, newEmail)
+	if err != nil {
+		return fmt.Errorf("check email exists: %w", err)
+	}
+	if exists {
+		return apperror.NewConflict("email already registered").WithDetail("email", newEmail)
+	}
+
+	rawToken, err := generateRandomToken(32)
+	if err != nil {
+		return fmt.Errorf("generate email change token: %w", err)
+	}
+
+	req := &EmailChangeRequest{
+		ID:        id.New(),
+		UserID:    user.ID,
+		OldEmail:  user.Email,
+		NewEmail:  newEmail,
+		TokenHash: hashToken(rawToken),
+		ExpiresAt: time.Now().Add(s.config.EmailChangeTokenExpiry),
+		CreatedAt: time.Now(),
+	}
+
+	txm, err := s.getTxManager(ctx)
+	if err != nil {
+		return apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+	if err := txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		// Only the latest request stays valid.
+		if err := s.emailChangeRepo.CancelPending(ctx, user.ID); err != nil {
+			return fmt.Errorf("cancel pending email changes: %w", err)
+		}
+		if err := s.emailChangeRepo.Create(ctx, req); err != nil {
+			return fmt.Errorf("create email change request: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := s.mailer.Send(ctx, s.emailChangeVerificationMessage(newEmail, rawToken)); err != nil {
+		logger.Error(ctx, "failed to send email change verification", "user_id", user.ID, "error", err)
+		return apperror.NewInternal(fmt.Errorf("send verification email: %w", err))
+	}
+
+	logger.Info(ctx, "email change requested", "user_id", user.ID)
+	return nil
+}
+
+// ConfirmEmailChange completes the change-email flow. The token must belong to
+// the authenticated user. All existing sessions are revoked and a fresh token
+// pair carrying the new email claim is issued.
+func (s *Service) ConfirmEmailChange(ctx context.Context, userID id.ID, rawToken string, info SessionInfo) (*TokenPair, *User, error) {
+	if _, err := s.requireTenantID(ctx); err != nil {
+		return nil, nil, err
+	}
+	if s.emailChangeRepo == nil {
+		return nil, nil, apperror.NewBusinessRule("EMAIL_CHANGE_UNAVAILABLE", "email change is not configured")
+	}
+	if rawToken == "" {
+		return nil, nil, apperror.NewValidation("token is required").WithDetail("field", "token")
+	}
+
+	txm, err := s.getTxManager(ctx)
+	if err != nil {
+		return nil, nil, apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+
+	var (
+		tokens   *TokenPair
+		user     *User
+		oldEmail string
+	)
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		req, err := s.emailChangeRepo.GetByTokenHash(ctx, hashToken(rawToken))
+		if err != nil {
+			if !apperror.IsNotFound(err) {
+				logger.Error(ctx, "failed to get email change request", "error", err)
+			}
+			return apperror.NewValidation("invalid or expired token").WithCause(err)
+		}
+		// Do not reveal whether the token exists for another user.
+		if req.UserID != userID || !req.IsPending() {
+			return apperror.NewValidation("invalid or expired token")
+		}
+
+		exists, err := s.userRepo.Exists(ctx, req.NewEmail)
+		if err != nil {
+			return fmt.Errorf("check email exists: %w", err)
+		}
+		if exists {
+			return apperror.NewConflict("email already registered").WithDetail("email", req.NewEmail)
+		}
+
+		if err := s.userRepo.UpdateEmail(ctx, userID, req.NewEmail); err != nil {
+			return fmt.Errorf("update email: %w", err)
+		}
+		if err := s.emailChangeRepo.MarkConfirmed(ctx, req.ID); err != nil {
+			return fmt.Errorf("confirm email change: %w", err)
+		}
+
+		// Old tokens carry the old email claim — revoke everything.
+		if err := s.tokenRepo.RevokeAllUserTokens(ctx, userID, "email_changed"); err != nil {
+			return err
+		}
+		if s.authStateRepo != nil {
+			if err := s.authStateRepo.RevokeAllUserSessions(ctx, userID, "email_changed"); err != nil {
+				return err
+			}
+		}
+		if err := s.bumpUserAuthVersion(ctx, userID, "email_changed"); err != nil {
+			return fmt.Errorf("invalidate user access: %w", err)
+		}
+
+		user, err = s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("reload user: %w", err)
+		}
+		roles, _ := s.userRepo.LoadRoles(ctx, user.ID)
+		user.Roles = roles
+		permissions, _ := s.userRepo.LoadPermissions(ctx, user.ID)
+		user.Permissions = permissions
+
+		oldEmail = req.OldEmail
+		var genErr error
+		tokens, genErr = s.generateTokenPair(ctx, user, info, id.Nil())
+		return genErr
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	s.invalidateUserAuthCache(ctx, userID)
+
+	// Best-effort: the change is already committed.
+	if s.mailer != nil {
+		if err := s.mailer.Send(ctx, s.emailChangedNoticeMessage(oldEmail, user.Email)); err != nil {
+			logger.Warn(ctx, "failed to notify old address about email change", "user_id", userID, "error", err)
+		}
+	}
+
+	logger.Info(ctx, "email changed", "user_id", userID)
+	return tokens, user, nil
+}
+
+func (s *Service) emailChangeVerificationMessage(to, rawToken string) MailMessage {
+	link := rawToken
+	if s.config.EmailChangeConfirmURL != "" {
+		link = s.config.EmailChangeConfirmURL + "?token=" + rawToken
+	}
+	return MailMessage{
+		To:      []string{to},
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf(
+			"A request was made to use this address for your Metapus account.\n\n"+
+				"Confirm the change: %s\n\n"+
+				"The link expires in %s. If you did not request this, ignore this email.",
+			link, s.config.EmailChangeTokenExpiry,
+		),
+	}
+}
+
+func (s *Service) emailChangedNoticeMessage(to, newEmail string) MailMessage {
+	return MailMessage{
+		To:      []string{to},
+		Subject: "Your email address was changed",
+		Body: fmt.Sprintf(
+			"The email address of your Metapus account was changed to %s.\n\n"+
+				"All active sessions were signed out. If you did not make this change, contact your administrator immediately.",
+			newEmail,
+		),
+	}
+}
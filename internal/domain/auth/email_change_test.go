@@ -0,0 +1,261 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+)
+
+type fakeUsers struct {
+	UserRepository
+	users map[id.ID]*User
+}
+
+func (f *fakeUsers) GetByID(_ context.Context, userID id.ID) (*User, error) {
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, apperror.NewNotFound("user", userID.String())
+	}
+	clone := *u
+	return &clone, nil
+}
+
+func (f *fakeUsers) Update(_ context.Context, user *User) error {
+	clone := *user
+	f.users[user.ID] = &clone
+	return nil
+}
+
+func (f *fakeUsers) Exists(_ context.Context, email string) (bool, error) {
+	for _, u := range f.users {
+		if strings.EqualFold(u.Email, email) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeUsers) UpdateEmail(_ context.Context, userID id.ID, email string) error {
+	f.users[userID].Email = email
+	return nil
+}
+
+func (f *fakeUsers) LoadRoles(context.Context, id.ID) ([]Role, error)         { return nil, nil }
+func (f *fakeUsers) LoadPermissions(context.Context, id.ID) ([]string, error) { return nil, nil }
+func (f *fakeUsers) LoadPermissionScopes(context.Context, id.ID) (map[string]map[string][]string, error) {
+	return nil, nil
+}
+
+type fakeTokens struct {
+	TokenRepository
+	revoked []id.ID
+}
+
+func (f *fakeTokens) SaveRefreshToken(context.Context, *RefreshToken) error { return nil }
+func (f *fakeTokens) RevokeAllUserTokens(_ context.Context, userID id.ID, _ string) error {
+	f.revoked = append(f.revoked, userID)
+	return nil
+}
+
+type fakeAuthState struct{ AuthStateRepository }
+
+func (fakeAuthState) CreateSession(context.Context, *AuthSession) error          { return nil }
+func (fakeAuthState) RevokeAllUserSessions(context.Context, id.ID, string) error { return nil }
+func (fakeAuthState) BumpUserAuthVersion(context.Context, id.ID) (int64, error)  { return 2, nil }
+func (fakeAuthState) GetCurrentPolicyVersion(context.Context) (int64, error)     { return 1, nil }
+
+type fakeEmailChanges struct {
+	requests map[string]*EmailChangeRequest // by token hash
+}
+
+func (f *fakeEmailChanges) Create(_ context.Context, req *EmailChangeRequest) error {
+	f.requests[req.TokenHash] = req
+	return nil
+}
+
+func (f *fakeEmailChanges) GetByTokenHash(_ context.Context, tokenHash string) (*EmailChangeRequest, error) {
+	req, ok := f.requests[tokenHash]
+	if !ok {
+		return nil, apperror.NewNotFound("email change request", tokenHash)
+	}
+	return req, nil
+}
+
+func (f *fakeEmailChanges) MarkConfirmed(_ context.Context, requestID id.ID) error {
+	now := time.Now()
+	for _, req := range f.requests {
+		if req.ID == requestID {
+			req.ConfirmedAt = &now
+		}
+	}
+	return nil
+}
+
+func (f *fakeEmailChanges) CancelPending(_ context.Context, userID id.ID) error {
+	now := time.Now()
+	for _, req := range f.requests {
+		if req.UserID == userID && req.IsPending() {
+			req.CancelledAt = &now
+		}
+	}
+	return nil
+}
+
+type fakeMailer struct{ sent []MailMessage }
+
+func (f *fakeMailer) Send(_ context.Context, msg MailMessage) error {
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+type inlineTx struct{}
+
+func (inlineTx) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type emailChangeFixture struct {
+	svc     *Service
+	users   *fakeUsers
+	tokens  *fakeTokens
+	changes *fakeEmailChanges
+	mailer  *fakeMailer
+	user    *User
+	ctx     context.Context
+}
+
+func newEmailChangeFixture(t *testing.T) *emailChangeFixture {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &User{BaseEntity: entity.BaseEntity{ID: id.New()}, Email: "old@example.com", PasswordHash: string(hash), IsActive: true, EmailVerified: true}
+
+	f := &emailChangeFixture{
+		users:   &fakeUsers{users: map[id.ID]*User{user.ID: user}},
+		tokens:  &fakeTokens{},
+		changes: &fakeEmailChanges{requests: map[string]*EmailChangeRequest{}},
+		mailer:  &fakeMailer{},
+		user:    user,
+		ctx:     tenant.WithTenant(context.Background(), &tenant.Tenant{ID: "t1"}),
+	}
+	f.svc = NewService(f.users, nil, nil, f.tokens, fakeAuthState{}, nil, nil, inlineTx{},
+		NewJWTService(DefaultJWTConfig("test-secret")), DefaultServiceConfig())
+	f.svc.SetEmailChangeRepository(f.changes)
+	f.svc.SetMailer(f.mailer)
+	return f
+}
+
+// token extracts the raw verification token from the last sent email.
+func (f *emailChangeFixture) token(t *testing.T) string {
+	t.Helper()
+	if len(f.mailer.sent) == 0 {
+		t.Fatal("no verification email sent")
+	}
+	body := f.mailer.sent[len(f.mailer.sent)-1].Body
+	const marker = "Confirm the change: "
+	i := strings.Index(body, marker)
+	if i < 0 {
+		t.Fatalf("no token in email body %q", body)
+	}
+	return strings.Fields(body[i+len(marker):])[0]
+}
+
+func TestEmailChangeRequestAndConfirm(t *testing.T) {
+	f := newEmailChangeFixture(t)
+
+	if err := f.svc.RequestEmailChange(f.ctx, f.user.ID, "correct-password", "new@example.com"); err != nil {
+		t.Fatalf("RequestEmailChange: %v", err)
+	}
+	if got := f.mailer.sent[0].To; len(got) != 1 || got[0] != "new@example.com" {
+		t.Fatalf("verification sent to %v, want new@example.com", got)
+	}
+	if f.users.users[f.user.ID].Email != "old@example.com" {
+		t.Fatal("email changed before confirmation")
+	}
+
+	token := f.token(t)
+	tokens, user, err := f.svc.ConfirmEmailChange(f.ctx, f.user.ID, token, SessionInfo{})
+	if err != nil {
+		t.Fatalf("ConfirmEmailChange: %v", err)
+	}
+	if user.Email != "new@example.com" || tokens == nil || tokens.AccessToken == "" {
+		t.Fatalf("confirm returned email %q, tokens %+v", user.Email, tokens)
+	}
+	if len(f.tokens.revoked) != 1 {
+		t.Errorf("old tokens revoked %d times, want 1", len(f.tokens.revoked))
+	}
+	if last := f.mailer.sent[len(f.mailer.sent)-1]; last.To[0] != "old@example.com" {
+		t.Errorf("change notice sent to %v, want the old address", last.To)
+	}
+
+	// A token is single-use.
+	if _, _, err := f.svc.ConfirmEmailChange(f.ctx, f.user.ID, token, SessionInfo{}); err == nil {
+		t.Error("confirming twice succeeded")
+	}
+}
+
+func TestEmailChangeConfirmExpired(t *testing.T) {
+	f := newEmailChangeFixture(t)
+	if err := f.svc.RequestEmailChange(f.ctx, f.user.ID, "correct-password", "new@example.com"); err != nil {
+		t.Fatalf("RequestEmailChange: %v", err)
+	}
+	for _, req := range f.changes.requests {
+		req.ExpiresAt = time.Now().Add(-time.Minute)
+	}
+
+	_, _, err := f.svc.ConfirmEmailChange(f.ctx, f.user.ID, f.token(t), SessionInfo{})
+	appErr, ok := apperror.AsAppError(err)
+	if !ok || appErr.Code != apperror.CodeValidation {
+		t.Fatalf("expired token: err = %v, want a validation error", err)
+	}
+	if f.users.users[f.user.ID].Email != "old@example.com" {
+		t.Error("email changed with an expired token")
+	}
+}
+
+func TestEmailChangeConfirmOtherUser(t *testing.T) {
+	f := newEmailChangeFixture(t)
+	if err := f.svc.RequestEmailChange(f.ctx, f.user.ID, "correct-password", "new@example.com"); err != nil {
+		t.Fatalf("RequestEmailChange: %v", err)
+	}
+	if _, _, err := f.svc.ConfirmEmailChange(f.ctx, id.New(), f.token(t), SessionInfo{}); err == nil {
+		t.Error("another user confirmed the change")
+	}
+}
+
+func TestEmailChangeWrongPasswordLocksAccount(t *testing.T) {
+	f := newEmailChangeFixture(t)
+	maxAttempts := DefaultServiceConfig().MaxLoginAttempts
+
+	for i := 0; i < maxAttempts; i++ {
+		err := f.svc.RequestEmailChange(f.ctx, f.user.ID, "wrong-password", "new@example.com")
+		appErr, ok := apperror.AsAppError(err)
+		if !ok || appErr.Code != apperror.CodeUnauthorized {
+			t.Fatalf("attempt %d: err = %v, want unauthorized", i+1, err)
+		}
+	}
+	stored := f.users.users[f.user.ID]
+	if stored.FailedLoginAttempts != maxAttempts || !stored.IsLocked() {
+		t.Fatalf("after %d wrong passwords: attempts = %d, locked = %v", maxAttempts, stored.FailedLoginAttempts, stored.IsLocked())
+	}
+
+	// Locked: even the correct password is refused and no email is sent.
+	err := f.svc.RequestEmailChange(f.ctx, f.user.ID, "correct-password", "new@example.com")
+	appErr, ok := apperror.AsAppError(err)
+	if !ok || appErr.Code != apperror.CodeForbidden {
+		t.Fatalf("locked account: err = %v, want forbidden", err)
+	}
+	if len(f.mailer.sent) != 0 {
+		t.Errorf("%d emails sent, want none", len(f.mailer.sent))
+	}
+}
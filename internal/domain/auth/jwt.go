@@ -7,6 +7,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 
+	"metapus/internal/core/clock"
 	appctx "metapus/internal/core/context"
 )
 
@@ -15,6 +16,10 @@ type JWTConfig struct {
 	Secret         string
 	Issuer         string
 	AccessTokenTTL time.Duration
+
+	// Clock overrides the time source for issuing and validating tokens
+	// (tests only). Tenant time travel does not apply to tokens. Nil = real time.
+	Clock clock.Clock
 }
 
 // DefaultJWTConfig returns default JWT configuration.
@@ -29,17 +34,18 @@ func DefaultJWTConfig(secret string) JWTConfig {
 // Claims represents JWT claims.
 type Claims struct {
 	jwt.RegisteredClaims
-	UserID          string         `json:"uid"`
-	TenantID        string         `json:"tid"`
-	SessionID       string         `json:"sid"`
-	UserAuthVersion int64          `json:"uv"`
-	PolicyVersion   int64          `json:"pv"`
-	Email           string         `json:"email"`
-	Roles           []string       `json:"roles"`
-	Permissions     []string       `json:"perms,omitempty"`
-	IsAdmin         bool           `json:"adm,omitempty"`
-	MerchantIDs     []string       `json:"mids,omitempty"`
-	MerchantRoles   map[string]int `json:"mrs,omitempty"`
+	UserID           string                         `json:"uid"`
+	TenantID         string                         `json:"tid"`
+	SessionID        string                         `json:"sid"`
+	UserAuthVersion  int64                          `json:"uv"`
+	PolicyVersion    int64                          `json:"pv"`
+	Email            string                         `json:"email"`
+	Roles            []string                       `json:"roles"`
+	Permissions      []string                       `json:"perms,omitempty"`
+	PermissionScopes map[string]map[string][]string `json:"pscopes,omitempty"`
+	IsAdmin          bool                           `json:"adm,omitempty"`
+	MerchantIDs      []string                       `json:"mids,omitempty"`
+	MerchantRoles    map[string]int                 `json:"mrs,omitempty"`
 }
 
 // JWTService handles JWT operations.
@@ -57,11 +63,12 @@ func (s *JWTService) GenerateAccessToken(
 	userID, tenantID, sessionID, email string,
 	userAuthVersion, policyVersion int64,
 	roles, permissions []string,
+	permissionScopes map[string]map[string][]string,
 	isAdmin bool,
 	merchantIDs []string,
 	merchantRoles map[string]int,
 ) (string, time.Time, error) {
-	now := time.Now()
+	now := clock.OrReal(s.config.Clock).Now()
 	expiresAt := now.Add(s.config.AccessTokenTTL)
 
 	claims := Claims{
@@ -71,17 +78,18 @@ func (s *JWTService) GenerateAccessToken(
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
-		UserID:          userID,
-		TenantID:        tenantID,
-		SessionID:       sessionID,
-		UserAuthVersion: userAuthVersion,
-		PolicyVersion:   policyVersion,
-		Email:           email,
-		Roles:           roles,
-		Permissions:     permissions,
-		IsAdmin:         isAdmin,
-		MerchantIDs:     merchantIDs,
-		MerchantRoles:   merchantRoles,
+		UserID:           userID,
+		TenantID:         tenantID,
+		SessionID:        sessionID,
+		UserAuthVersion:  userAuthVersion,
+		PolicyVersion:    policyVersion,
+		Email:            email,
+		Roles:            roles,
+		Permissions:      permissions,
+		PermissionScopes: permissionScopes,
+		IsAdmin:          isAdmin,
+		MerchantIDs:      merchantIDs,
+		MerchantRoles:    merchantRoles,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -100,7 +108,7 @@ func (s *JWTService) ParseClaims(tokenString string) (*Claims, error) {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return []byte(s.config.Secret), nil
-	})
+	}, jwt.WithTimeFunc(clock.OrReal(s.config.Clock).Now))
 
 	if err != nil {
 		return nil, fmt.Errorf("parse token: %w", err)
@@ -127,14 +135,15 @@ func (s *JWTService) ValidateToken(tokenString string) (*appctx.UserContext, err
 	}
 
 	return &appctx.UserContext{
-		UserID:        claims.UserID,
-		TenantID:      claims.TenantID,
-		Email:         claims.Email,
-		Roles:         claims.Roles,
-		Permissions:   claims.Permissions,
-		IsAdmin:       claims.IsAdmin,
-		SessionID:     claims.SessionID,
-		MerchantIDs:   claims.MerchantIDs,
-		MerchantRoles: claims.MerchantRoles,
+		UserID:           claims.UserID,
+		TenantID:         claims.TenantID,
+		Email:            claims.Email,
+		Roles:            claims.Roles,
+		Permissions:      claims.Permissions,
+		IsAdmin:          claims.IsAdmin,
+		SessionID:        claims.SessionID,
+		MerchantIDs:      claims.MerchantIDs,
+		MerchantRoles:    claims.MerchantRoles,
+		PermissionScopes: claims.PermissionScopes,
 	}, nil
 }
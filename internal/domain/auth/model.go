@@ -37,6 +37,8 @@ type User struct {
 	// Loaded relations
 	Roles       []Role   `db:"-" json:"roles,omitempty"`
 	Permissions []string `db:"-" json:"permissions,omitempty"`
+	// PermissionScopes: permissions granted only within a scope (see Permission.Scope).
+	PermissionScopes map[string]map[string][]string `db:"-" json:"permissionScopes,omitempty"`
 
 	// Portal claims — transient, populated by auth.Service during token generation.
 	MerchantIDs []string `db:"-" json:"-"`
@@ -165,6 +167,10 @@ type Permission struct {
 	Description string `db:"description" json:"description,omitempty"`
 	Resource    string `db:"resource" json:"resource"`
 	Action      string `db:"action" json:"action"`
+
+	// Scope limits the permission as granted to a role: dimension -> allowed
+	// IDs (e.g. {"warehouse": [...]}); nil = unrestricted. Set on role permissions only.
+	Scope map[string][]string `db:"-" json:"scope,omitempty"`
 }
 
 // RefreshToken represents a refresh token for JWT refresh.
@@ -204,6 +210,18 @@ type AuthSession struct {
 	IPAddress       string     `db:"ip_address"`
 }
 
+// DeviceSession is an active session as listed to its user: the device that
+// last refreshed it and when.
+type DeviceSession struct {
+	ID         id.ID
+	CreatedAt  time.Time
+	LastSeenAt time.Time // issue time of the live refresh token
+	ExpiresAt  time.Time
+	UserAgent  string
+	IPAddress  string
+	Current    bool // the session of the caller
+}
+
 // AuthSessionState is the server-side authority used to validate access JWTs.
 type AuthSessionState struct {
 	SessionID       id.ID
@@ -250,3 +268,25 @@ type AssignRoleRequest struct {
 	UserID   id.ID  `json:"userId"`
 	RoleCode string `json:"roleCode"`
 }
+
+// EmailChangeRequest is a pending email change awaiting confirmation
+// from the new address.
+type EmailChangeRequest struct {
+	ID          id.ID      `db:"id"`
+	UserID      id.ID      `db:"user_id"`
+	OldEmail    string     `db:"old_email"`
+	NewEmail    string     `db:"new_email"`
+	TokenHash   string     `db:"token_hash"`
+	ExpiresAt   time.Time  `db:"expires_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+	ConfirmedAt *time.Time `db:"confirmed_at"`
+	CancelledAt *time.Time `db:"cancelled_at"`
+}
+
+// IsPending reports whether the request can still be confirmed.
+func (r *EmailChangeRequest) IsPending() bool {
+	if r.ConfirmedAt != nil || r.CancelledAt != nil {
+		return false
+	}
+	return time.Now().Before(r.ExpiresAt)
+}
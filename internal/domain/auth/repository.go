@@ -32,8 +32,13 @@ type UserRepository interface {
 	LoadRoles(ctx context.Context, userID id.ID) ([]Role, error)
 
 	// LoadPermissions loads user's permissions (flattened from roles).
+	// Permissions granted only within a scope are excluded (see LoadPermissionScopes).
 	LoadPermissions(ctx context.Context, userID id.ID) ([]string, error)
 
+	// LoadPermissionScopes loads the permissions the user holds only within a
+	// scope: permission -> dimension -> allowed IDs, merged across roles.
+	LoadPermissionScopes(ctx context.Context, userID id.ID) (map[string]map[string][]string, error)
+
 	// AssignRole assigns a role to user.
 	AssignRole(ctx context.Context, userID, roleID id.ID, grantedBy id.ID) error
 
@@ -42,6 +47,9 @@ type UserRepository interface {
 
 	// Exists checks if email exists (within tenant database).
 	Exists(ctx context.Context, email string) (bool, error)
+
+	// UpdateEmail replaces the user's email and marks it as verified.
+	UpdateEmail(ctx context.Context, userID id.ID, email string) error
 }
 
 // RoleRepository defines role storage operations.
@@ -74,8 +82,13 @@ type RoleRepository interface {
 	RevokePermission(ctx context.Context, roleID, permissionID id.ID) error
 
 	// SetPermissions replaces all permissions for a role (delete + bulk insert).
+	// Scopes of permissions the role keeps are preserved.
 	SetPermissions(ctx context.Context, roleID id.ID, permissionIDs []id.ID) error
 
+	// SetPermissionScope limits a permission of the role to a scope
+	// (dimension -> allowed IDs); an empty scope removes the limit.
+	SetPermissionScope(ctx context.Context, roleID, permissionID id.ID, scope map[string][]string) error
+
 	// CountUsersByRoleID returns the number of users assigned to a role.
 	CountUsersByRoleID(ctx context.Context, roleID id.ID) (int, error)
 
@@ -109,10 +122,32 @@ type TokenRepository interface {
 	// RevokeAllUserTokens revokes all tokens for a user.
 	RevokeAllUserTokens(ctx context.Context, userID id.ID, reason string) error
 
+	// RevokeSessionTokens revokes the tokens of one session.
+	RevokeSessionTokens(ctx context.Context, sessionID id.ID, reason string) error
+
+	// ListActiveSessions returns the user's sessions that hold a live refresh
+	// token, with the device recorded on that token; most recent first.
+	ListActiveSessions(ctx context.Context, userID id.ID) ([]DeviceSession, error)
+
 	// CleanupExpiredTokens removes expired tokens.
 	CleanupExpiredTokens(ctx context.Context) (int, error)
 }
 
+// EmailChangeRepository defines storage for pending email change requests.
+type EmailChangeRepository interface {
+	// Create saves a new email change request.
+	Create(ctx context.Context, req *EmailChangeRequest) error
+
+	// GetByTokenHash retrieves a request by token hash and locks it (FOR UPDATE).
+	GetByTokenHash(ctx context.Context, tokenHash string) (*EmailChangeRequest, error)
+
+	// MarkConfirmed marks the request as confirmed.
+	MarkConfirmed(ctx context.Context, requestID id.ID) error
+
+	// CancelPending cancels all pending requests of a user.
+	CancelPending(ctx context.Context, userID id.ID) error
+}
+
 // AuthStateRepository defines server-side session and auth epoch operations.
 type AuthStateRepository interface {
 	// CreateSession creates a server-side auth session.
@@ -7,12 +7,15 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
 	"metapus/internal/core/apperror"
 	appctx "metapus/internal/core/context"
+	"metapus/internal/core/events"
 	"metapus/internal/core/id"
 	"metapus/internal/core/tenant"
 	"metapus/internal/core/tx"
@@ -31,6 +34,12 @@ type ServiceConfig struct {
 	LockDuration       time.Duration
 	PasswordMinLength  int
 	RefreshTokenExpiry time.Duration
+
+	// EmailChangeTokenExpiry is the lifetime of a change-email verification token.
+	EmailChangeTokenExpiry time.Duration
+	// EmailChangeConfirmURL is the frontend page that receives ?token=...
+	// If empty, the raw token is sent in the email body.
+	EmailChangeConfirmURL string
 }
 
 // DefaultServiceConfig returns default configuration.
@@ -40,6 +49,8 @@ func DefaultServiceConfig() ServiceConfig {
 		LockDuration:       15 * time.Minute,
 		PasswordMinLength:  8,
 		RefreshTokenExpiry: 7 * 24 * time.Hour, // 7 days
+
+		EmailChangeTokenExpiry: 24 * time.Hour,
 	}
 }
 
@@ -55,6 +66,22 @@ type Service struct {
 	txManager        tx.Manager
 	jwtService       *JWTService
 	config           ServiceConfig
+
+	// Optional: change-email flow (see email_change.go).
+	emailChangeRepo EmailChangeRepository
+	mailer          Mailer
+
+	// Optional: domain events (user.registered).
+	eventPublisher events.Publisher
+
+	// Optional: invitation of users created by an admin.
+	inviter Inviter
+}
+
+// Inviter notifies a user created by an administrator (an invitation
+// email). Invite runs inside the transaction creating the user.
+type Inviter interface {
+	Invite(ctx context.Context, user *User) error
 }
 
 // NewService creates a new auth service.
@@ -84,6 +111,16 @@ func NewService(
 	}
 }
 
+// SetEventPublisher enables domain events of the auth service.
+func (s *Service) SetEventPublisher(p events.Publisher) {
+	s.eventPublisher = p
+}
+
+// SetInviter enables invitations of users created by an admin.
+func (s *Service) SetInviter(i Inviter) {
+	s.inviter = i
+}
+
 func (s *Service) getTxManager(ctx context.Context) (tx.Manager, error) {
 	if s.txManager != nil {
 		return s.txManager, nil
@@ -227,7 +264,12 @@ func (s *Service) Register(ctx context.Context, req RegisterRequest) (*User, err
 			}
 		}
 
-		return nil
+		return events.UserRegistered.Publish(ctx, s.eventPublisher, events.AggregateUser, user.ID, events.UserEvent{
+			UserID:    user.ID,
+			Email:     user.Email,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+		})
 	})
 
 	if err != nil {
@@ -419,6 +461,50 @@ func (s *Service) Logout(ctx context.Context, userID id.ID) error {
 	return nil
 }
 
+// ListSessions returns the active sessions of a user; currentSessionID is
+// the caller's own session and is marked as current.
+func (s *Service) ListSessions(ctx context.Context, userID, currentSessionID id.ID) ([]DeviceSession, error) {
+	sessions, err := s.tokenRepo.ListActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
+	}
+	return sessions, nil
+}
+
+// RevokeSession logs a user out of one of their sessions (e.g. a lost
+// device): its refresh tokens stop working and its access tokens are
+// rejected at once. Revoking an already revoked session is a no-op.
+func (s *Service) RevokeSession(ctx context.Context, userID, sessionID id.ID) error {
+	if s.authStateRepo == nil {
+		return apperror.NewInternal(fmt.Errorf("auth state repository is not configured"))
+	}
+	txm, err := s.getTxManager(ctx)
+	if err != nil {
+		return apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+	if err := txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		// Scoped by user: sessions of other users are not found.
+		state, err := s.authStateRepo.GetSessionState(ctx, userID, sessionID)
+		if err != nil {
+			return err
+		}
+		if state.RevokedAt != nil {
+			return nil
+		}
+		if err := s.tokenRepo.RevokeSessionTokens(ctx, sessionID, "revoked_by_user"); err != nil {
+			return err
+		}
+		return s.authStateRepo.RevokeSession(ctx, sessionID, "revoked_by_user")
+	}); err != nil {
+		return err
+	}
+	s.invalidateSessionAuthCache(ctx, sessionID)
+	return nil
+}
+
 // AssignRole assigns a role to a user.
 func (s *Service) AssignRole(ctx context.Context, userID id.ID, roleCode string) error {
 	// Get current user for audit
@@ -524,6 +610,8 @@ func (s *Service) GetUserByID(ctx context.Context, userID id.ID) (*User, error)
 	user.Roles = roles
 	permissions, _ := s.userRepo.LoadPermissions(ctx, user.ID)
 	user.Permissions = permissions
+	permissionScopes, _ := s.userRepo.LoadPermissionScopes(ctx, user.ID)
+	user.PermissionScopes = permissionScopes
 
 	return user, nil
 }
@@ -640,6 +728,12 @@ func (s *Service) CreateUserByAdmin(ctx context.Context, req RegisterRequest, ro
 				logger.Warn(ctx, "failed to assign role", "role_code", roleCode, "error", err)
 			}
 		}
+
+		if s.inviter != nil {
+			if err := s.inviter.Invite(ctx, user); err != nil {
+				return fmt.Errorf("invite user: %w", err)
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -843,6 +937,75 @@ func (s *Service) SetRolePermissions(ctx context.Context, roleID id.ID, permissi
 	return nil
 }
 
+// SetRolePermissionScope limits a permission of a role to a scope (dimension
+// -> allowed IDs, e.g. posting goods issues of one warehouse) or, with an
+// empty scope, grants it unrestricted again. Bumps the RBAC policy epoch.
+func (s *Service) SetRolePermissionScope(ctx context.Context, roleID, permissionID id.ID, scope map[string][]string) error {
+	permissions, err := s.ListRolePermissions(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	idx := slices.IndexFunc(permissions, func(p Permission) bool { return p.ID == permissionID })
+	if idx < 0 {
+		return apperror.NewNotFound("role permission", permissionID.String())
+	}
+	if err := validatePermissionScope(permissions[idx].Code, scope); err != nil {
+		return err
+	}
+
+	txm, err := s.getTxManager(ctx)
+	if err != nil {
+		return apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := s.roleRepo.SetPermissionScope(ctx, roleID, permissionID, scope); err != nil {
+			return err
+		}
+		if err := s.bumpPolicyEpoch(ctx, "role_permission_scope_changed"); err != nil {
+			return fmt.Errorf("bump policy version: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("set role permission scope: %w", err)
+	}
+	s.invalidatePolicyCache(ctx)
+
+	logger.Info(ctx, "role permission scope updated",
+		"role_id", roleID, "permission", permissions[idx].Code, "dimensions", len(scope))
+	return nil
+}
+
+// validatePermissionScope checks a scope for a permission. Scopes apply to
+// entity permissions ("catalog:warehouse:read", "document:goods_issue:post"),
+// whose records carry the dimensions; each dimension needs allowed IDs.
+func validatePermissionScope(code string, scope map[string][]string) error {
+	if len(scope) == 0 {
+		return nil
+	}
+	parts := strings.Split(code, ":")
+	if len(parts) != 3 || (parts[0] != "catalog" && parts[0] != "document") {
+		return apperror.NewValidation("only catalog and document permissions can be scoped").
+			WithDetail("permission", code)
+	}
+	for dim, ids := range scope {
+		if dim == "" {
+			return apperror.NewValidation("scope dimension is required").WithDetail("field", "scope")
+		}
+		if len(ids) == 0 {
+			return apperror.NewValidation("scope dimension needs at least one allowed id").
+				WithDetail("field", "scope."+dim)
+		}
+		for _, v := range ids {
+			if _, err := id.Parse(v); err != nil {
+				return apperror.NewValidation("invalid id in scope: "+v).WithDetail("field", "scope."+dim)
+			}
+		}
+	}
+	return nil
+}
+
 // Impersonate generates tokens for a target user (admin-only impersonation).
 // The caller must be an admin. Returns tokens that allow acting as the target user.
 func (s *Service) Impersonate(ctx context.Context, targetUserID id.ID, info SessionInfo) (*TokenPair, *User, error) {
@@ -912,6 +1075,14 @@ func (s *Service) generateTokenPair(ctx context.Context, user *User, info Sessio
 		roleCodes[i] = r.Code
 	}
 
+	// Permissions granted within a scope travel in the token next to the
+	// unrestricted ones; scope changes bump the policy epoch like role changes.
+	permissionScopes, err := s.userRepo.LoadPermissionScopes(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("load permission scopes: %w", err)
+	}
+	user.PermissionScopes = permissionScopes
+
 	// Load merchant associations for portal JWT claims.
 	// Best-effort: if merchantUserRepo is nil or query fails, skip portal claims.
 	var merchantIDs []string
@@ -964,7 +1135,7 @@ func (s *Service) generateTokenPair(ctx context.Context, user *User, info Sessio
 	accessToken, expiresAt, err := s.jwtService.GenerateAccessToken(
 		user.ID.String(), tenantID, sessionID.String(), user.Email,
 		userAuthVersion, policyVersion,
-		roleCodes, user.Permissions, user.IsAdmin,
+		roleCodes, user.Permissions, user.PermissionScopes, user.IsAdmin,
 		merchantIDs, merchantRoles,
 	)
 	if err != nil {
@@ -0,0 +1,87 @@
+// Package basedon creates documents "based on" other documents (1C "ввод на
+// основании"): a mapping fills a new document of one type from a basis
+// document of another type and links it through BasisType/BasisID.
+//
+// The mappings themselves are domain functions of the target document
+// package (e.g. goods_issue.FromSalesOrder); Mappings binds them to the
+// loading of the basis document.
+package basedon
+
+import (
+	"context"
+	"slices"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+)
+
+// Mapping is one way to create documents of type T.
+type Mapping[T any] struct {
+	// BasisType is the document type of the basis, e.g. sales_order.DocumentType.
+	BasisType string
+
+	// Permission is required to read the basis, e.g. document:sales_order:read.
+	Permission string
+
+	// Build loads the basis document and fills a new, unsaved document from it.
+	Build func(ctx context.Context, basisID id.ID) (T, error)
+}
+
+// Mappings holds the ways to create documents of type T, by basis type.
+type Mappings[T any] struct {
+	byBasis map[string]Mapping[T]
+}
+
+// NewMappings creates the mappings of a document type.
+func NewMappings[T any](mappings ...Mapping[T]) *Mappings[T] {
+	m := &Mappings[T]{byBasis: make(map[string]Mapping[T], len(mappings))}
+	for _, mapping := range mappings {
+		m.byBasis[mapping.BasisType] = mapping
+	}
+	return m
+}
+
+// Get returns the mapping from basisType.
+func (m *Mappings[T]) Get(basisType string) (Mapping[T], error) {
+	mapping, ok := m.byBasis[basisType]
+	if !ok {
+		return Mapping[T]{}, apperror.NewValidation("documents cannot be created based on "+basisType).
+			WithDetail("field", "basisType")
+	}
+	return mapping, nil
+}
+
+// BasisTypes returns the supported basis types, sorted.
+func (m *Mappings[T]) BasisTypes() []string {
+	basisTypes := make([]string, 0, len(m.byBasis))
+	for basisType := range m.byBasis {
+		basisTypes = append(basisTypes, basisType)
+	}
+	slices.Sort(basisTypes)
+	return basisTypes
+}
+
+// Prorate returns the share part/whole of amount, rounded to minor units;
+// used to carry amounts of partially taken basis lines.
+func Prorate(amount types.MinorUnits, part, whole types.Quantity) types.MinorUnits {
+	if part >= whole || whole <= 0 {
+		return amount
+	}
+	share := decimal.NewFromInt(int64(amount)).
+		Mul(decimal.NewFromInt(part.Int64Scaled())).
+		Div(decimal.NewFromInt(whole.Int64Scaled()))
+	return types.MinorUnits(share.Round(0).IntPart())
+}
+
+// InLineUnits converts a quantity in base units to the units of a line with
+// the given coefficient, rounded down to the quantity precision.
+func InLineUnits(base types.Quantity, coefficient decimal.Decimal) types.Quantity {
+	if !coefficient.IsPositive() || coefficient.Equal(decimal.NewFromInt(1)) {
+		return base
+	}
+	q := decimal.NewFromInt(base.Int64Scaled()).Div(coefficient)
+	return types.NewQuantityFromInt64Scaled(q.Floor().IntPart())
+}
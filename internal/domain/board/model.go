@@ -0,0 +1,144 @@
+// Package board provides the status board (kanban) view of documents:
+// documents of one type grouped by workflow state, with per-state counts and
+// lightweight cards, and moves between states that delegate to the document's
+// own state machine (post, unpost, close, approve).
+package board
+
+import (
+	"sort"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+)
+
+// Action is the document operation that performs a transition.
+type Action string
+
+const (
+	ActionPost    Action = "post"
+	ActionUnpost  Action = "unpost"
+	ActionClose   Action = "close"
+	ActionApprove Action = "approve"
+)
+
+// Standard states of the posting workflow.
+const (
+	StateDraft  = "draft"
+	StatePosted = "posted"
+)
+
+// State is a column of the board.
+type State struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+}
+
+// Transition moves a document from one state to another by performing Action.
+type Transition struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Action Action `json:"action"`
+}
+
+// Workflow lists the states of a document type in board order
+// and the transitions a user may request between them.
+type Workflow struct {
+	States      []State      `json:"states"`
+	Transitions []Transition `json:"transitions"`
+}
+
+// PostingWorkflow is the workflow of documents without their own states:
+// a document is either a draft or posted.
+func PostingWorkflow() *Workflow {
+	return &Workflow{
+		States: []State{
+			{Key: StateDraft, Label: "Черновик"},
+			{Key: StatePosted, Label: "Проведён"},
+		},
+		Transitions: []Transition{
+			{From: StateDraft, To: StatePosted, Action: ActionPost},
+			{From: StatePosted, To: StateDraft, Action: ActionUnpost},
+		},
+	}
+}
+
+// HasState reports whether key is a state of the workflow.
+func (w *Workflow) HasState(key string) bool {
+	for _, s := range w.States {
+		if s.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition returns the transition from one state to another.
+func (w *Workflow) Transition(from, to string) (Transition, error) {
+	if !w.HasState(to) {
+		return Transition{}, apperror.NewValidation("validation failed").WithDetail("state", "unknown state: "+to)
+	}
+	if from == to {
+		return Transition{}, apperror.NewBusinessRule("BOARD_SAME_STATE", "document is already in state "+to)
+	}
+	for _, t := range w.Transitions {
+		if t.From == from && t.To == to {
+			return t, nil
+		}
+	}
+	return Transition{}, apperror.NewBusinessRule("BOARD_TRANSITION_NOT_ALLOWED",
+		"cannot move document from "+from+" to "+to).
+		WithDetail("from", from).
+		WithDetail("to", to)
+}
+
+// Card is the lightweight presentation of a document on the board.
+type Card struct {
+	ID     id.ID     `json:"id" db:"id"`
+	Number string    `json:"number" db:"number"`
+	Date   time.Time `json:"date" db:"date"`
+	Posted bool      `json:"posted" db:"posted"`
+	State  string    `json:"state" db:"state"`
+}
+
+// Column is a state of the board with the number of documents in it
+// and the most recent of them.
+type Column struct {
+	State
+	Count int    `json:"count"`
+	Cards []Card `json:"cards"`
+}
+
+// Columns groups counts and cards by the workflow states, in workflow order.
+// States found in the data but missing from the workflow are appended
+// after the workflow states, so that no document disappears from the board.
+func (w *Workflow) Columns(counts map[string]int, cards []Card) []Column {
+	columns := make([]Column, 0, len(w.States))
+	index := make(map[string]int, len(w.States))
+	add := func(s State) {
+		index[s.Key] = len(columns)
+		columns = append(columns, Column{State: s, Count: counts[s.Key], Cards: []Card{}})
+	}
+
+	for _, s := range w.States {
+		add(s)
+	}
+	for _, c := range cards {
+		if _, ok := index[c.State]; !ok {
+			add(State{Key: c.State, Label: c.State})
+		}
+		col := &columns[index[c.State]]
+		col.Cards = append(col.Cards, c)
+	}
+	extra := make([]string, 0)
+	for key := range counts {
+		if _, ok := index[key]; !ok {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(extra)
+	for _, key := range extra {
+		add(State{Key: key, Label: key})
+	}
+	return columns
+}
@@ -0,0 +1,65 @@
+package board
+
+import (
+	"testing"
+
+	"metapus/internal/core/apperror"
+)
+
+func isCode(err error, code string) bool {
+	appErr, ok := apperror.AsAppError(err)
+	return ok && appErr.Code == code
+}
+
+func TestWorkflowTransition(t *testing.T) {
+	w := PostingWorkflow()
+
+	tr, err := w.Transition(StateDraft, StatePosted)
+	if err != nil {
+		t.Fatalf("draft → posted: %v", err)
+	}
+	if tr.Action != ActionPost {
+		t.Errorf("draft → posted: action = %q, want post", tr.Action)
+	}
+
+	if _, err := w.Transition(StatePosted, StatePosted); !isCode(err, "BOARD_SAME_STATE") {
+		t.Errorf("posted → posted: got %v, want BOARD_SAME_STATE", err)
+	}
+	if _, err := w.Transition(StateDraft, "archived"); err == nil {
+		t.Error("unknown state: expected validation error")
+	}
+
+	w.Transitions = w.Transitions[:1]
+	if _, err := w.Transition(StatePosted, StateDraft); !isCode(err, "BOARD_TRANSITION_NOT_ALLOWED") {
+		t.Errorf("posted → draft: got %v, want BOARD_TRANSITION_NOT_ALLOWED", err)
+	}
+}
+
+func TestWorkflowColumns(t *testing.T) {
+	w := PostingWorkflow()
+	counts := map[string]int{StateDraft: 3, StatePosted: 1, "legacy": 2}
+	cards := []Card{
+		{Number: "1", State: StatePosted},
+		{Number: "2", State: StateDraft},
+		{Number: "3", State: "legacy"},
+	}
+
+	columns := w.Columns(counts, cards)
+	if len(columns) != 3 {
+		t.Fatalf("got %d columns, want 3", len(columns))
+	}
+	if columns[0].Key != StateDraft || columns[0].Count != 3 || len(columns[0].Cards) != 1 {
+		t.Errorf("draft column = %+v", columns[0])
+	}
+	if columns[1].Key != StatePosted || columns[1].Count != 1 || columns[1].Cards[0].Number != "1" {
+		t.Errorf("posted column = %+v", columns[1])
+	}
+	if columns[2].Key != "legacy" || columns[2].Count != 2 || len(columns[2].Cards) != 1 {
+		t.Errorf("unknown state column = %+v", columns[2])
+	}
+
+	empty := w.Columns(nil, nil)
+	if empty[0].Cards == nil {
+		t.Error("empty column cards must be an empty slice, not nil")
+	}
+}
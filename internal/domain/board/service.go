@@ -0,0 +1,74 @@
+package board
+
+import (
+	"context"
+
+	"metapus/internal/core/id"
+	"metapus/internal/core/security"
+	"metapus/internal/domain"
+)
+
+// Source reads board data of one document type.
+// Implemented by document repositories (see BaseDocumentRepo.SetBoardStateExpr).
+type Source interface {
+	// BoardCounts returns the number of documents per state.
+	BoardCounts(ctx context.Context, filter domain.ListFilter) (map[string]int, error)
+
+	// BoardCards returns up to limit most recent documents of every state.
+	BoardCards(ctx context.Context, filter domain.ListFilter, limit int) ([]Card, error)
+
+	// BoardState returns the current state of a document.
+	BoardState(ctx context.Context, docID id.ID) (string, error)
+}
+
+// Service builds the board of one document type.
+type Service struct {
+	source   Source
+	workflow *Workflow
+}
+
+// NewService creates a board service. A nil workflow means PostingWorkflow.
+func NewService(source Source, workflow *Workflow) *Service {
+	if workflow == nil {
+		workflow = PostingWorkflow()
+	}
+	return &Service{source: source, workflow: workflow}
+}
+
+// Workflow returns the workflow of the board.
+func (s *Service) Workflow() *Workflow {
+	return s.workflow
+}
+
+// Columns returns the board: every state with its count and up to cardLimit cards.
+// Deletion-marked documents are never shown, regardless of the filter.
+func (s *Service) Columns(ctx context.Context, filter domain.ListFilter, cardLimit int) ([]Column, error) {
+	if filter.DataScope == nil {
+		filter.DataScope = security.GetDataScope(ctx)
+	}
+	filter.IncludeDeleted = false
+
+	counts, err := s.source.BoardCounts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := s.source.BoardCards(ctx, filter, cardLimit)
+	if err != nil {
+		return nil, err
+	}
+	return s.workflow.Columns(counts, cards), nil
+}
+
+// State returns the current state of a document.
+func (s *Service) State(ctx context.Context, docID id.ID) (string, error) {
+	return s.source.BoardState(ctx, docID)
+}
+
+// Plan returns the transition that moves the document to state to.
+func (s *Service) Plan(ctx context.Context, docID id.ID, to string) (Transition, error) {
+	from, err := s.source.BoardState(ctx, docID)
+	if err != nil {
+		return Transition{}, err
+	}
+	return s.workflow.Transition(from, to)
+}
@@ -0,0 +1,103 @@
+// Package cascadedelete implements two-phase deletion of deletion-marked
+// documents together with the documents that depend on them (documents
+// created on their basis, documents referencing them).
+//
+// Phase one (Preview) resolves the dependency cascade over the document
+// relations graph and, when nothing blocks it, issues a short-lived
+// confirmation token bound to the exact set of documents shown to the user.
+// Phase two (Purge) resolves the cascade again inside a transaction, checks
+// the token still matches it and physically deletes every document of the
+// cascade, dependents first.
+package cascadedelete
+
+import (
+	"context"
+	"time"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain"
+)
+
+// Ref identifies a document (or, for blockers, any entity).
+type Ref struct {
+	EntityName string `json:"entityName"`
+	EntityID   id.ID  `json:"entityId"`
+}
+
+// Node is a document that will be deleted as part of the cascade.
+type Node struct {
+	Ref
+	EntityType   string `json:"entityType"`
+	Presentation string `json:"presentation"`
+	Posted       bool   `json:"posted"`
+	DeletionMark bool   `json:"deletionMark"`
+	// Depth is 0 for requested documents, 1 for their direct dependents, etc.
+	Depth int `json:"depth"`
+	// DependsOn is the document that pulled this one into the cascade
+	// (nil for requested documents); Via is the referencing field,
+	// e.g. "basisId" or "lines.orderId".
+	DependsOn *Ref   `json:"dependsOn,omitempty"`
+	Via       string `json:"via,omitempty"`
+}
+
+// Edge states that From references To, so From must be deleted first.
+type Edge struct {
+	From Ref
+	To   Ref
+}
+
+// Blocker is an object that prevents the cascade: a posted dependent
+// document or a non-document entity referencing a document of the cascade.
+type Blocker struct {
+	Ref
+	EntityType   string `json:"entityType"`
+	Presentation string `json:"presentation"`
+	Reason       string `json:"reason"`
+	// Blocks is the cascade document the blocker depends on.
+	Blocks Ref    `json:"blocks"`
+	Via    string `json:"via,omitempty"`
+}
+
+// Cascade is the resolved dependency closure of the requested documents.
+type Cascade struct {
+	Nodes    []Node
+	Edges    []Edge
+	Blockers []Blocker
+}
+
+// Preview is the first phase response.
+type Preview struct {
+	// Items lists the documents in deletion order (dependents first).
+	Items    []Node    `json:"items"`
+	Blockers []Blocker `json:"blockers"`
+	// CanPurge is false while there are blockers; no token is issued then.
+	CanPurge  bool       `json:"canPurge"`
+	Token     string     `json:"token,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// PurgeResult is the second phase response.
+type PurgeResult struct {
+	Deleted int `json:"deleted"`
+}
+
+// Resolver builds the deletion cascade over the document relations graph.
+type Resolver interface {
+	// Resolve returns the cascade of the given documents. Every root must be
+	// an existing deletion-marked document (validation error otherwise).
+	Resolve(ctx context.Context, roots []domain.DeleteMarkedRequest) (*Cascade, error)
+}
+
+// Purger physically deletes documents.
+type Purger interface {
+	// Purge deletes the documents in the given order and returns how many
+	// were deleted. A document that is posted or no longer exists aborts the
+	// purge with a conflict error. Must be called inside a transaction.
+	Purge(ctx context.Context, nodes []Node) (int, error)
+}
+
+// Repository combines Resolver and Purger (one PostgreSQL implementation).
+type Repository interface {
+	Resolver
+	Purger
+}
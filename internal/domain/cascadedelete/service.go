@@ -0,0 +1,206 @@
+package cascadedelete
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"metapus/internal/core/apperror"
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/security"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain"
+	"metapus/pkg/logger"
+)
+
+const (
+	// DefaultTokenTTL is the lifetime of a purge confirmation token.
+	DefaultTokenTTL = 10 * time.Minute
+
+	// MaxRoots bounds the number of documents in one request.
+	MaxRoots = 100
+)
+
+// Service runs the preview → confirm → purge flow.
+type Service struct {
+	repo     Repository
+	signer   *TokenSigner
+	tokenTTL time.Duration
+	now      func() time.Time
+}
+
+// NewService creates a cascade deletion service.
+func NewService(repo Repository, signer *TokenSigner) *Service {
+	return &Service{
+		repo:     repo,
+		signer:   signer,
+		tokenTTL: DefaultTokenTTL,
+		now:      time.Now,
+	}
+}
+
+// Preview resolves the cascade of the given documents. When nothing blocks
+// it, the result carries a confirmation token for Purge.
+func (s *Service) Preview(ctx context.Context, roots []domain.DeleteMarkedRequest) (*Preview, error) {
+	roots, err := normalizeRoots(roots)
+	if err != nil {
+		return nil, err
+	}
+	if err := security.GetDataScope(ctx).CanMutate(); err != nil {
+		return nil, err
+	}
+
+	cascade, err := s.repo.Resolve(ctx, roots)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Preview{
+		Items:    deletionOrder(cascade),
+		Blockers: cascade.Blockers,
+		CanPurge: len(cascade.Blockers) == 0,
+	}
+	if p.Blockers == nil {
+		p.Blockers = []Blocker{}
+	}
+	if p.CanPurge {
+		expires := s.now().Add(s.tokenTTL).Truncate(time.Second)
+		sig := s.signer.Sign(tenant.GetTenantID(ctx), appctx.GetUserID(ctx), Digest(p.Items), expires.Unix())
+		p.Token = strconv.FormatInt(expires.Unix(), 10) + "." + sig
+		p.ExpiresAt = &expires
+	}
+	return p, nil
+}
+
+// Purge deletes the cascade confirmed by a Preview token. The cascade is
+// resolved again in the purge transaction; if it no longer matches the
+// previewed one (new dependents, removed documents) the purge is refused.
+func (s *Service) Purge(ctx context.Context, roots []domain.DeleteMarkedRequest, token string) (*PurgeResult, error) {
+	roots, err := normalizeRoots(roots)
+	if err != nil {
+		return nil, err
+	}
+	if err := security.GetDataScope(ctx).CanMutate(); err != nil {
+		return nil, err
+	}
+
+	expires, sig, ok := parseToken(token)
+	if !ok {
+		return nil, apperror.NewValidation("invalid confirmation token").WithDetail("field", "token")
+	}
+	if s.now().Unix() > expires {
+		return nil, apperror.NewBusinessRule("CONFIRMATION_EXPIRED",
+			"confirmation token has expired; preview the deletion again")
+	}
+
+	txm, err := tenant.GetTxManager(ctx)
+	if err != nil {
+		return nil, apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+
+	tenantID, userID := tenant.GetTenantID(ctx), appctx.GetUserID(ctx)
+	var deleted int
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		cascade, err := s.repo.Resolve(ctx, roots)
+		if err != nil {
+			return err
+		}
+		if len(cascade.Blockers) > 0 {
+			return apperror.NewBusinessRule("CASCADE_BLOCKED",
+				"deletion is blocked by dependent objects").WithDetail("blockers", cascade.Blockers)
+		}
+
+		nodes := deletionOrder(cascade)
+		if !s.signer.Verify(tenantID, userID, Digest(nodes), expires, sig) {
+			return apperror.NewConflict("dependent documents changed since the preview; preview the deletion again")
+		}
+
+		deleted, err = s.repo.Purge(ctx, nodes)
+		if err != nil {
+			return fmt.Errorf("purge cascade: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(ctx, "cascade delete purged", "roots", len(roots), "deleted", deleted)
+	return &PurgeResult{Deleted: deleted}, nil
+}
+
+// normalizeRoots drops duplicates and enforces MaxRoots.
+func normalizeRoots(roots []domain.DeleteMarkedRequest) ([]domain.DeleteMarkedRequest, error) {
+	if len(roots) == 0 {
+		return nil, apperror.NewValidation("at least one document is required").WithDetail("field", "items")
+	}
+	seen := make(map[Ref]struct{}, len(roots))
+	out := make([]domain.DeleteMarkedRequest, 0, len(roots))
+	for _, r := range roots {
+		key := Ref{EntityName: r.EntityName, EntityID: r.EntityID}
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, r)
+	}
+	if len(out) > MaxRoots {
+		return nil, apperror.NewValidation(fmt.Sprintf("at most %d documents per request", MaxRoots)).
+			WithDetail("field", "items")
+	}
+	return out, nil
+}
+
+// parseToken splits "<expires>.<signature>".
+func parseToken(token string) (int64, string, bool) {
+	exp, sig, ok := strings.Cut(token, ".")
+	if !ok || sig == "" {
+		return 0, "", false
+	}
+	expires, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return expires, sig, true
+}
+
+// deletionOrder orders cascade nodes so that every document comes after all
+// documents referencing it (post-order walk over dependents, starting from
+// the requested documents). Reference cycles are broken arbitrarily.
+func deletionOrder(c *Cascade) []Node {
+	byRef := make(map[Ref]Node, len(c.Nodes))
+	for _, n := range c.Nodes {
+		byRef[n.Ref] = n
+	}
+	dependents := make(map[Ref][]Ref, len(c.Edges))
+	for _, e := range c.Edges {
+		dependents[e.To] = append(dependents[e.To], e.From)
+	}
+
+	ordered := make([]Node, 0, len(c.Nodes))
+	visited := make(map[Ref]bool, len(c.Nodes))
+	var visit func(ref Ref)
+	visit = func(ref Ref) {
+		n, ok := byRef[ref]
+		if !ok || visited[ref] {
+			return
+		}
+		visited[ref] = true
+		for _, d := range dependents[ref] {
+			visit(d)
+		}
+		ordered = append(ordered, n)
+	}
+
+	for _, n := range c.Nodes {
+		if n.Depth == 0 {
+			visit(n.Ref)
+		}
+	}
+	for _, n := range c.Nodes {
+		visit(n.Ref)
+	}
+	return ordered
+}
@@ -0,0 +1,161 @@
+package cascadedelete
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain"
+)
+
+type passTxManager struct{}
+
+func (passTxManager) RunInTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+type memRepo struct {
+	cascade *Cascade
+	purged  []Node
+}
+
+func (r *memRepo) Resolve(context.Context, []domain.DeleteMarkedRequest) (*Cascade, error) {
+	return r.cascade, nil
+}
+
+func (r *memRepo) Purge(_ context.Context, nodes []Node) (int, error) {
+	r.purged = nodes
+	return len(nodes), nil
+}
+
+func testRef(name string) Ref {
+	return Ref{EntityName: name, EntityID: id.New()}
+}
+
+func testCtx() context.Context {
+	ctx := tenant.WithTxManager(context.Background(), passTxManager{})
+	return appctx.WithUser(ctx, &appctx.UserContext{UserID: "u1", IsAdmin: true})
+}
+
+// receipt ← issue (basis) ← transfer (references both)
+func testCascade() (*Cascade, Ref, Ref, Ref) {
+	receipt, issue, transfer := testRef("GoodsReceipt"), testRef("GoodsIssue"), testRef("GoodsTransfer")
+	return &Cascade{
+		Nodes: []Node{
+			{Ref: receipt, DeletionMark: true},
+			{Ref: issue, Depth: 1, DependsOn: &receipt},
+			{Ref: transfer, Depth: 1, DependsOn: &receipt},
+		},
+		Edges: []Edge{
+			{From: issue, To: receipt},
+			{From: transfer, To: receipt},
+			{From: transfer, To: issue},
+		},
+	}, receipt, issue, transfer
+}
+
+func TestDeletionOrder(t *testing.T) {
+	c, receipt, issue, transfer := testCascade()
+
+	got := deletionOrder(c)
+	pos := make(map[Ref]int, len(got))
+	for i, n := range got {
+		pos[n.Ref] = i
+	}
+	if len(got) != 3 {
+		t.Fatalf("len = %d, want 3", len(got))
+	}
+	if !(pos[transfer] < pos[issue] && pos[issue] < pos[receipt]) {
+		t.Errorf("order = %v, want transfer, issue, receipt", got)
+	}
+}
+
+func TestService_PreviewAndPurge(t *testing.T) {
+	c, receipt, _, _ := testCascade()
+	repo := &memRepo{cascade: c}
+	svc := NewService(repo, NewTokenSigner([]byte("k")))
+	ctx := testCtx()
+	roots := []domain.DeleteMarkedRequest{{EntityName: receipt.EntityName, EntityID: receipt.EntityID}}
+
+	p, err := svc.Preview(ctx, roots)
+	if err != nil {
+		t.Fatalf("Preview: %v", err)
+	}
+	if !p.CanPurge || p.Token == "" || len(p.Items) != 3 {
+		t.Fatalf("preview = %+v", p)
+	}
+
+	res, err := svc.Purge(ctx, roots, p.Token)
+	if err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	if res.Deleted != 3 || repo.purged[2].Ref != receipt {
+		t.Errorf("purged = %v", repo.purged)
+	}
+}
+
+func TestService_PurgeRejectsChangedCascade(t *testing.T) {
+	c, receipt, _, _ := testCascade()
+	repo := &memRepo{cascade: c}
+	svc := NewService(repo, NewTokenSigner([]byte("k")))
+	ctx := testCtx()
+	roots := []domain.DeleteMarkedRequest{{EntityName: receipt.EntityName, EntityID: receipt.EntityID}}
+
+	p, err := svc.Preview(ctx, roots)
+	if err != nil {
+		t.Fatalf("Preview: %v", err)
+	}
+
+	// A new dependent appeared after the preview.
+	extra := testRef("SalesOrder")
+	repo.cascade.Nodes = append(repo.cascade.Nodes, Node{Ref: extra, Depth: 1, DependsOn: &receipt})
+
+	_, err = svc.Purge(ctx, roots, p.Token)
+	if appErr, ok := apperror.AsAppError(err); !ok || appErr.Code != apperror.CodeConflict {
+		t.Fatalf("err = %v, want conflict", err)
+	}
+	if repo.purged != nil {
+		t.Error("nothing must be purged")
+	}
+
+	// Another user cannot reuse the token either.
+	repo.cascade.Nodes = repo.cascade.Nodes[:3]
+	other := appctx.WithUser(ctx, &appctx.UserContext{UserID: "u2", IsAdmin: true})
+	if _, err := svc.Purge(other, roots, p.Token); err == nil {
+		t.Error("expected error for another user's token")
+	}
+}
+
+func TestService_BlockedAndExpired(t *testing.T) {
+	c, receipt, _, _ := testCascade()
+	c.Blockers = []Blocker{{Ref: testRef("GoodsIssue"), Reason: "posted", Blocks: receipt}}
+	repo := &memRepo{cascade: c}
+	svc := NewService(repo, NewTokenSigner([]byte("k")))
+	ctx := testCtx()
+	roots := []domain.DeleteMarkedRequest{{EntityName: receipt.EntityName, EntityID: receipt.EntityID}}
+
+	p, err := svc.Preview(ctx, roots)
+	if err != nil {
+		t.Fatalf("Preview: %v", err)
+	}
+	if p.CanPurge || p.Token != "" {
+		t.Errorf("blocked preview issued a token: %+v", p)
+	}
+
+	svc.now = func() time.Time { return time.Now().Add(-time.Hour) }
+	repo.cascade.Blockers = nil
+	p, _ = svc.Preview(ctx, roots)
+	svc.now = time.Now
+	_, err = svc.Purge(ctx, roots, p.Token)
+	if appErr, ok := apperror.AsAppError(err); !ok || appErr.Code != "CONFIRMATION_EXPIRED" {
+		t.Errorf("err = %v, want CONFIRMATION_EXPIRED", err)
+	}
+
+	if _, err := svc.Purge(ctx, roots, "garbage"); err == nil {
+		t.Error("expected error for malformed token")
+	}
+}
@@ -0,0 +1,43 @@
+package cascadedelete
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"strings"
+
+	"metapus/internal/core/crypto"
+)
+
+// TokenSigner issues and verifies purge confirmation tokens.
+// A token is bound to tenant, user, the cascade digest and expiry.
+type TokenSigner struct {
+	tokens *crypto.LinkSigner
+}
+
+// NewTokenSigner creates a signer with the given HMAC key.
+func NewTokenSigner(key []byte) *TokenSigner {
+	return &TokenSigner{tokens: crypto.NewLinkSigner(key, "cascade-delete")}
+}
+
+// Sign returns the signature for the given cascade digest and expiry (unix seconds).
+func (s *TokenSigner) Sign(tenantID, userID, digest string, expires int64) string {
+	return s.tokens.Sign(tenantID, userID, digest, strconv.FormatInt(expires, 10))
+}
+
+// Verify checks a signature (expiry is checked by the caller).
+func (s *TokenSigner) Verify(tenantID, userID, digest string, expires int64, sig string) bool {
+	return s.tokens.Verify(sig, tenantID, userID, digest, strconv.FormatInt(expires, 10))
+}
+
+// Digest fingerprints the set of documents to delete, independent of order.
+func Digest(nodes []Node) string {
+	keys := make([]string, len(nodes))
+	for i, n := range nodes {
+		keys[i] = n.EntityName + ":" + n.EntityID.String()
+	}
+	slices.Sort(keys)
+	sum := sha256.Sum256([]byte(strings.Join(keys, "\n")))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,314 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/security"
+	"metapus/pkg/logger"
+)
+
+// MaxCatalogBatchSize bounds the items of one ApplyBatch call.
+const MaxCatalogBatchSize = 500
+
+// BatchOp is the kind of a CatalogBatchItem.
+type BatchOp string
+
+const (
+	BatchCreate BatchOp = "create"
+	BatchUpdate BatchOp = "update"
+	BatchDelete BatchOp = "delete"
+)
+
+// CatalogBatchItem is one change applied by ApplyBatch.
+type CatalogBatchItem[T any] struct {
+	Op BatchOp
+
+	// Entity is the new entity (BatchCreate).
+	Entity T
+
+	// ID is the changed entity (BatchUpdate, BatchDelete).
+	ID id.ID
+
+	// Apply applies the change to the current entity (BatchUpdate). The
+	// result carries the version the change is based on, as for Update.
+	Apply func(existing T) T
+
+	// Version is the version a deletion is based on; 0 skips the check
+	// (BatchDelete).
+	Version int
+}
+
+// versioned is implemented by entities embedding entity.BaseEntity.
+type versioned interface {
+	GetVersion() int
+}
+
+// ApplyBatch creates, updates and soft-deletes entities in one transaction.
+// Each item goes through the checks and hooks of Create, Update or Delete;
+// creations are inserted with a single COPY (see CatalogBatchRepository) and
+// the entities to change are locked and version-checked in a single pass.
+//
+// Like CreateBatch the batch is all-or-nothing: if any item fails, nothing
+// is applied and the per-item errors are returned with a nil error. With
+// dryRun the items are checked inside a transaction that is always rolled
+// back. On success the created and updated entities (and the deleted ones)
+// are returned by item index.
+func (s *CatalogService[T]) ApplyBatch(ctx context.Context, items []CatalogBatchItem[T], dryRun bool) ([]T, []BatchRowError, error) {
+	batchRepo, ok := s.repo.(CatalogBatchRepository[T])
+	if !ok {
+		return nil, nil, apperror.NewBusinessRule("BATCH_NOT_SUPPORTED",
+			fmt.Sprintf("%s does not support batch operations", s.entityName))
+	}
+	if len(items) > MaxCatalogBatchSize {
+		return nil, nil, apperror.NewValidation(fmt.Sprintf("at most %d items per batch", MaxCatalogBatchSize)).
+			WithDetail("field", "items")
+	}
+	if err := security.GetDataScope(ctx).CanMutate(); err != nil {
+		return nil, nil, err
+	}
+
+	txm, err := s.getTxManager(ctx)
+	if err != nil {
+		return nil, nil, apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+
+	var (
+		results []T
+		rowErrs []BatchRowError
+	)
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		results = make([]T, len(items))
+		rowErrs, err = s.prepareBatch(ctx, batchRepo, items, results)
+		if err != nil {
+			return err
+		}
+		if len(rowErrs) > 0 || dryRun {
+			return errBatchRollback
+		}
+		rowErrs, err = s.writeBatch(ctx, batchRepo, items, results)
+		if err != nil {
+			return err
+		}
+		if len(rowErrs) > 0 {
+			return errBatchRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBatchRollback) {
+		return nil, nil, err
+	}
+	if len(rowErrs) > 0 || dryRun {
+		return nil, rowErrs, nil
+	}
+
+	// After-hooks (outside transaction), same as the single-item operations
+	for i, item := range items {
+		var hookErr error
+		switch item.Op {
+		case BatchCreate:
+			hookErr = s.hooks.RunAfterCreate(ctx, results[i])
+		case BatchUpdate:
+			hookErr = s.hooks.RunAfterUpdate(ctx, results[i])
+		case BatchDelete:
+			hookErr = s.hooks.RunAfterDelete(ctx, results[i])
+		}
+		if hookErr != nil {
+			logger.Warn(ctx, "after-"+string(item.Op)+" hook failed", "entity", s.entityName, "error", hookErr)
+		}
+	}
+	return results, nil, nil
+}
+
+// prepareBatch locks the entities to change, checks every item and runs the
+// before-hooks. The entity each item will write is stored in results.
+func (s *CatalogService[T]) prepareBatch(ctx context.Context, repo CatalogBatchRepository[T], items []CatalogBatchItem[T], results []T) ([]BatchRowError, error) {
+	var rowErrs []BatchRowError
+
+	ids := make([]id.ID, 0, len(items))
+	seen := make(map[id.ID]struct{}, len(items))
+	repeated := make(map[int]struct{})
+	for i, item := range items {
+		if item.Op == BatchCreate {
+			continue
+		}
+		if _, dup := seen[item.ID]; dup {
+			repeated[i] = struct{}{}
+			rowErrs = append(rowErrs, BatchRowError{Index: i, Err: apperror.NewValidation(
+				fmt.Sprintf("%s is changed more than once", item.ID),
+			).WithDetail("field", "id")})
+			continue
+		}
+		seen[item.ID] = struct{}{}
+		ids = append(ids, item.ID)
+	}
+
+	current := make(map[id.ID]T, len(ids))
+	if len(ids) > 0 {
+		locked, err := repo.LockByIDs(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("lock %s batch: %w", s.entityName, err)
+		}
+		for _, ent := range locked {
+			current[ent.GetID()] = ent
+		}
+	}
+
+	var (
+		creates       []T
+		createIndexes []int
+	)
+	for i, item := range items {
+		if _, dup := repeated[i]; dup {
+			continue
+		}
+		var err error
+		switch item.Op {
+		case BatchCreate:
+			results[i] = item.Entity
+			if err = s.prepareBatchEntity(ctx, item.Entity); err == nil {
+				creates = append(creates, item.Entity)
+				createIndexes = append(createIndexes, i)
+			}
+		case BatchUpdate, BatchDelete:
+			old, ok := current[item.ID]
+			if !ok {
+				err = apperror.NewNotFound(s.entityName, item.ID.String())
+				break
+			}
+			if item.Op == BatchUpdate {
+				results[i], err = s.prepareBatchUpdate(ctx, old, item.Apply)
+			} else {
+				results[i], err = old, s.prepareBatchDelete(ctx, old, item.Version)
+			}
+		default:
+			err = apperror.NewValidation(fmt.Sprintf("unknown operation %q", item.Op)).WithDetail("field", "op")
+		}
+		if err != nil {
+			rowErrs = append(rowErrs, BatchRowError{Index: i, Err: err})
+		}
+	}
+
+	codeErrs, err := s.checkBatchCodes(ctx, repo, creates)
+	if err != nil {
+		return nil, err
+	}
+	for _, ce := range codeErrs {
+		rowErrs = append(rowErrs, BatchRowError{Index: createIndexes[ce.Index], Err: ce.Err})
+	}
+	return rowErrs, nil
+}
+
+// prepareBatchUpdate runs the pre-update steps of Update for one entity.
+func (s *CatalogService[T]) prepareBatchUpdate(ctx context.Context, old T, apply func(T) T) (T, error) {
+	ent := apply(cloneEntity(old))
+	if err := checkBatchVersion(s.entityName, old, versionOf(ent)); err != nil {
+		return ent, err
+	}
+	if err := s.checkRLSAccess(ctx, old); err != nil {
+		return ent, err
+	}
+	if err := s.checkRLSAccess(ctx, ent); err != nil {
+		return ent, err
+	}
+	if err := s.checkCELPolicy(ctx, "update", old); err != nil {
+		return ent, err
+	}
+	if writePolicy := security.GetFieldPolicy(ctx, s.entityName, "write"); writePolicy != nil {
+		if err := security.ValidateWrite(old, ent, writePolicy); err != nil {
+			return ent, err
+		}
+	}
+	if err := ent.Validate(ctx); err != nil {
+		return ent, s.normalizeValidationErr(err)
+	}
+	if err := s.validateHierarchy(ctx, ent); err != nil {
+		return ent, err
+	}
+	return ent, s.hooks.RunBeforeUpdate(ctx, ent)
+}
+
+// prepareBatchDelete runs the pre-delete steps of Delete for one entity.
+func (s *CatalogService[T]) prepareBatchDelete(ctx context.Context, old T, version int) error {
+	if version != 0 {
+		if err := checkBatchVersion(s.entityName, old, version); err != nil {
+			return err
+		}
+	}
+	if err := s.checkRLSAccess(ctx, old); err != nil {
+		return err
+	}
+	if err := s.checkCELPolicy(ctx, "delete", old); err != nil {
+		return err
+	}
+	return s.hooks.RunBeforeDelete(ctx, old)
+}
+
+// writeBatch applies the prepared items: creations with a single COPY, then
+// updates and deletions one by one. A failed statement aborts the
+// transaction, so writing stops at the first item error.
+func (s *CatalogService[T]) writeBatch(ctx context.Context, repo CatalogBatchRepository[T], items []CatalogBatchItem[T], results []T) ([]BatchRowError, error) {
+	var creates []T
+	for i, item := range items {
+		if item.Op == BatchCreate {
+			creates = append(creates, results[i])
+		}
+	}
+	if err := repo.CreateBatch(ctx, creates); err != nil {
+		return nil, fmt.Errorf("create %s batch: %w", s.entityName, err)
+	}
+
+	for i, item := range items {
+		ent := results[i]
+		var err error
+		switch item.Op {
+		case BatchCreate:
+			err = s.hooks.Run(ctx, CreateInTx, ent)
+		case BatchUpdate:
+			if err = s.repo.Update(ctx, ent); err == nil {
+				err = s.hooks.Run(ctx, UpdateInTx, ent)
+			}
+		case BatchDelete:
+			if err = s.repo.Delete(ctx, item.ID); err == nil {
+				err = s.hooks.Run(ctx, DeleteInTx, ent)
+			}
+		}
+		if err != nil {
+			return []BatchRowError{{Index: i, Err: err}}, nil
+		}
+	}
+	return nil, nil
+}
+
+// checkBatchVersion reports a change based on another version than current.
+func checkBatchVersion(entityName string, current entity.CatalogEntity, version int) error {
+	if v, ok := current.(versioned); ok && v.GetVersion() != version {
+		return apperror.NewConcurrentModification(entityName, current.GetID().String())
+	}
+	return nil
+}
+
+// versionOf returns the version of ent, 0 if it is not versioned.
+func versionOf(ent any) int {
+	if v, ok := ent.(versioned); ok {
+		return v.GetVersion()
+	}
+	return 0
+}
+
+// cloneEntity returns a shallow copy of ent (a pointer to a struct), so
+// applying a change to the copy leaves ent intact.
+func cloneEntity[T any](ent T) T {
+	v := reflect.ValueOf(ent)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return ent
+	}
+	cp := reflect.New(v.Elem().Type())
+	cp.Elem().Set(v.Elem())
+	return cp.Interface().(T)
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"testing"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+)
+
+func TestCloneEntity(t *testing.T) {
+	orig := entity.NewCatalog("001", "Original")
+	cp := cloneEntity(&orig)
+	cp.Name = "Changed"
+	cp.Touch()
+
+	if orig.Name != "Original" || orig.Version != 1 {
+		t.Fatalf("original changed: %q v%d", orig.Name, orig.Version)
+	}
+	if cp.ID != orig.ID || cp.Version != 2 {
+		t.Fatalf("copy = %v v%d, want %v v2", cp.ID, cp.Version, orig.ID)
+	}
+
+	var nilCatalog *entity.Catalog
+	if got := cloneEntity(nilCatalog); got != nil {
+		t.Fatalf("cloneEntity(nil) = %v", got)
+	}
+}
+
+func TestCheckBatchVersion(t *testing.T) {
+	current := entity.NewCatalog("001", "Current")
+	current.Version = 3
+
+	if err := checkBatchVersion("unit", &current, 3); err != nil {
+		t.Fatalf("same version: %v", err)
+	}
+	err := checkBatchVersion("unit", &current, 2)
+	if !apperror.IsConcurrentModification(err) {
+		t.Fatalf("outdated version: got %v, want concurrent modification", err)
+	}
+}
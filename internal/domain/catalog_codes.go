@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"metapus/internal/core/clock"
+	"metapus/internal/core/numerator"
+	"metapus/internal/core/translit"
+	"metapus/internal/domain/settings"
+)
+
+// maxCodeSuffix bounds the collision suffixes (-2 .. -99) tried for a code
+// generated from a name before falling back to the numerator.
+const maxCodeSuffix = 99
+
+// GenerateCode returns the code of a new catalog item created without one,
+// following the catalog's rule in settings.KeyCatalogCodes: by default the
+// transliterated name ("ООО Ромашка" → "OOO-ROMASHKA") with the rule prefix,
+// suffixed "-2", "-3", ... while another undeleted item has it; with source
+// "numerator", the next number of the numerator. numeratorPrefix is the
+// catalog's own numerator prefix (e.g. "CP"), also used when name has no
+// letters or digits.
+func (s *CatalogService[T]) GenerateCode(ctx context.Context, name, numeratorPrefix string) (string, error) {
+	rule := settings.Get(ctx, settings.KeyCatalogCodes, settings.Subject{})[s.entityName]
+	length := rule.Length
+	if length == 0 {
+		length = settings.DefaultCatalogCodeLength
+	}
+
+	if rule.Source != settings.CodeSourceNumerator {
+		code, err := s.codeFromName(ctx, name, rule.Prefix, length)
+		if err != nil || code != "" {
+			return code, err
+		}
+	}
+
+	prefix := numeratorPrefix
+	if rule.Prefix != "" {
+		prefix = rule.Prefix
+	}
+	code, err := s.numerator.GetNextNumber(ctx, numerator.DefaultConfig(prefix), nil, clock.Now(ctx))
+	if err != nil {
+		return "", fmt.Errorf("generate code: %w", err)
+	}
+	return code, nil
+}
+
+// codeFromName returns prefix + the transliterated name, made unique among
+// undeleted items with a numeric suffix, or "" when the name yields no code
+// or no free suffix is left.
+func (s *CatalogService[T]) codeFromName(ctx context.Context, name, prefix string, length int) (string, error) {
+	base := translit.Code(name, length-len(prefix))
+	if base == "" {
+		return "", nil
+	}
+
+	for n := 1; n <= maxCodeSuffix; n++ {
+		code := prefix + base
+		if n > 1 {
+			suffix := "-" + strconv.Itoa(n)
+			code = prefix + translit.Truncate(base, length-len(prefix)-len(suffix)) + suffix
+		}
+		exists, err := s.repo.ExistsByCode(ctx, code)
+		if err != nil {
+			return "", fmt.Errorf("check code uniqueness: %w", err)
+		}
+		if !exists {
+			return code, nil
+		}
+	}
+	return "", nil
+}
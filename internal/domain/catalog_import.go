@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/security"
+	"metapus/pkg/logger"
+)
+
+// BatchRowError is a validation error of one entity passed to CreateBatch.
+// Index is the position of the entity in the input slice.
+type BatchRowError struct {
+	Index int
+	Err   error
+}
+
+// errBatchRollback aborts the CreateBatch transaction (dry run or invalid rows).
+var errBatchRollback = errors.New("batch create rolled back")
+
+// codeAccessor is implemented by catalogs embedding entity.Catalog.
+type codeAccessor interface {
+	GetCode() string
+}
+
+// CreateBatch validates entities the same way as Create and inserts them with
+// a single COPY (see CatalogBatchRepository).
+//
+// The batch is all-or-nothing: if any entity fails validation, nothing is
+// inserted and the per-entity errors are returned with a nil error. With
+// dryRun the entities are validated — including before-create hooks such as
+// code generation — inside a transaction that is always rolled back.
+// The returned error is reserved for failures of the batch as a whole.
+func (s *CatalogService[T]) CreateBatch(ctx context.Context, entities []T, dryRun bool) ([]BatchRowError, error) {
+	batchRepo, ok := s.repo.(CatalogBatchRepository[T])
+	if !ok {
+		return nil, apperror.NewBusinessRule("IMPORT_NOT_SUPPORTED",
+			fmt.Sprintf("%s does not support bulk import", s.entityName))
+	}
+	if err := security.GetDataScope(ctx).CanMutate(); err != nil {
+		return nil, err
+	}
+
+	txm, err := s.getTxManager(ctx)
+	if err != nil {
+		return nil, apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+
+	var rowErrs []BatchRowError
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		rowErrs = nil
+		for i, ent := range entities {
+			if err := s.prepareBatchEntity(ctx, ent); err != nil {
+				rowErrs = append(rowErrs, BatchRowError{Index: i, Err: err})
+			}
+		}
+
+		codeErrs, err := s.checkBatchCodes(ctx, batchRepo, entities)
+		if err != nil {
+			return err
+		}
+		rowErrs = append(rowErrs, codeErrs...)
+
+		if len(rowErrs) > 0 || dryRun {
+			return errBatchRollback
+		}
+		if err := batchRepo.CreateBatch(ctx, entities); err != nil {
+			return fmt.Errorf("create %s batch: %w", s.entityName, err)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBatchRollback) {
+		return nil, err
+	}
+	if len(rowErrs) > 0 || dryRun {
+		return rowErrs, nil
+	}
+
+	// After-create hooks (outside transaction), same as Create
+	for _, ent := range entities {
+		if err := s.hooks.RunAfterCreate(ctx, ent); err != nil {
+			logger.Warn(ctx, "after-create hook failed", "entity", s.entityName, "error", err)
+		}
+	}
+	return nil, nil
+}
+
+// prepareBatchEntity runs the pre-insert steps of Create for one entity.
+func (s *CatalogService[T]) prepareBatchEntity(ctx context.Context, ent T) error {
+	if err := s.checkRLSAccess(ctx, ent); err != nil {
+		return err
+	}
+	if err := ent.Validate(ctx); err != nil {
+		return s.normalizeValidationErr(err)
+	}
+	if err := s.checkCELPolicy(ctx, "create", ent); err != nil {
+		return err
+	}
+	if err := s.validateHierarchy(ctx, ent); err != nil {
+		return err
+	}
+	return s.hooks.RunBeforeCreate(ctx, ent)
+}
+
+// checkBatchCodes reports codes repeated within the batch or already used.
+func (s *CatalogService[T]) checkBatchCodes(ctx context.Context, repo CatalogBatchRepository[T], entities []T) ([]BatchRowError, error) {
+	var rowErrs []BatchRowError
+	firstIndex := make(map[string]int, len(entities))
+	codes := make([]string, 0, len(entities))
+
+	for i, ent := range entities {
+		ca, ok := any(ent).(codeAccessor)
+		if !ok || ca.GetCode() == "" {
+			continue
+		}
+		code := ca.GetCode()
+		if _, dup := firstIndex[code]; dup {
+			rowErrs = append(rowErrs, BatchRowError{Index: i, Err: apperror.NewValidation(
+				fmt.Sprintf("code %q is used more than once", code),
+			).WithDetail("field", "code")})
+			continue
+		}
+		firstIndex[code] = i
+		codes = append(codes, code)
+	}
+	if len(codes) == 0 {
+		return rowErrs, nil
+	}
+
+	existing, err := repo.ExistingCodes(ctx, codes)
+	if err != nil {
+		return nil, fmt.Errorf("check existing codes: %w", err)
+	}
+	for code := range existing {
+		rowErrs = append(rowErrs, BatchRowError{Index: firstIndex[code], Err: apperror.NewDuplicate(s.entityName, "code", code)})
+	}
+	return rowErrs, nil
+}
@@ -0,0 +1,242 @@
+package catalogimport
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/apperror"
+)
+
+// attributesField is the DTO field holding custom attributes; file columns
+// map to it as "attributes.<name>".
+const attributesField = "attributes"
+
+var (
+	decimalType         = reflect.TypeFor[decimal.Decimal]()
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// Mapping maps file column headers to DTO field names (JSON names),
+// e.g. {"Наименование": "name", "ИНН": "inn"}.
+type Mapping map[string]string
+
+// FieldError is a decoding error of one cell.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string { return e.Field + ": " + e.Message }
+
+// Decoder converts table rows into create DTOs of type D. Cells are converted
+// according to the DTO field types (numbers, booleans, decimals, IDs) and
+// decoded through encoding/json, so DTO json tags and custom unmarshalers apply.
+type Decoder[D any] struct {
+	fields map[string]reflect.Type // json name → field type
+}
+
+// NewDecoder creates a decoder for the DTO type D.
+func NewDecoder[D any]() *Decoder[D] {
+	d := &Decoder[D]{fields: make(map[string]reflect.Type)}
+	collectFields(reflect.TypeFor[D](), d.fields)
+	return d
+}
+
+func collectFields(t reflect.Type, out map[string]reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			collectFields(f.Type, out)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		out[name] = f.Type
+	}
+}
+
+// HasField reports whether the DTO has the field (including "attributes.<name>").
+func (d *Decoder[D]) HasField(field string) bool {
+	if name, ok := strings.CutPrefix(field, attributesField+"."); ok {
+		t, has := d.fields[attributesField]
+		return has && name != "" && t.Kind() == reflect.Map
+	}
+	_, ok := d.fields[field]
+	return ok
+}
+
+// Columns resolves the file header to DTO fields: column index → field.
+// With an explicit mapping only mapped columns are imported; otherwise a
+// column is imported when its header equals a DTO field name (ignoring case,
+// spaces and underscores). Unknown target fields and missing columns are
+// validation errors.
+func (d *Decoder[D]) Columns(header []string, mapping Mapping) (map[int]string, error) {
+	cols := make(map[int]string)
+
+	if len(mapping) == 0 {
+		byKey := make(map[string]string, len(d.fields))
+		for name := range d.fields {
+			byKey[normalizeHeader(name)] = name
+		}
+		for i, h := range header {
+			if field, ok := byKey[normalizeHeader(h)]; ok {
+				cols[i] = field
+			}
+		}
+	} else {
+		index := make(map[string]int, len(header))
+		for i, h := range header {
+			if _, dup := index[h]; !dup {
+				index[h] = i
+			}
+		}
+		for column, field := range mapping {
+			if field == "" {
+				continue // explicitly skipped column
+			}
+			i, ok := index[column]
+			if !ok {
+				return nil, apperror.NewValidation(fmt.Sprintf("column %q not found in file", column)).
+					WithDetail("field", "mapping")
+			}
+			if !d.HasField(field) {
+				return nil, apperror.NewValidation(fmt.Sprintf("unknown field %q", field)).
+					WithDetail("field", "mapping")
+			}
+			cols[i] = field
+		}
+	}
+
+	if len(cols) == 0 {
+		return nil, apperror.NewValidation("no file columns match entity fields; provide a column mapping").
+			WithDetail("field", "mapping")
+	}
+	return cols, nil
+}
+
+func normalizeHeader(s string) string {
+	s = strings.ToLower(s)
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(s)
+}
+
+// Decode converts one row into a DTO. Empty cells are left unset.
+func (d *Decoder[D]) Decode(cols map[int]string, cells []string) (D, error) {
+	var dto D
+	values := make(map[string]any, len(cols))
+	var attrs map[string]any
+
+	for _, i := range slices.Sorted(maps.Keys(cols)) {
+		field := cols[i]
+		if i >= len(cells) || cells[i] == "" {
+			continue
+		}
+		raw := cells[i]
+
+		if name, ok := strings.CutPrefix(field, attributesField+"."); ok {
+			if attrs == nil {
+				attrs = make(map[string]any)
+				values[attributesField] = attrs
+			}
+			attrs[name] = raw
+			continue
+		}
+
+		v, err := cellValue(d.fields[field], raw)
+		if err != nil {
+			return dto, &FieldError{Field: field, Message: err.Error()}
+		}
+		values[field] = v
+	}
+
+	body, err := json.Marshal(values)
+	if err != nil {
+		return dto, fmt.Errorf("encode row: %w", err)
+	}
+	if err := json.Unmarshal(body, &dto); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return dto, &FieldError{Field: typeErr.Field, Message: "invalid value"}
+		}
+		return dto, &FieldError{Message: err.Error()}
+	}
+	return dto, nil
+}
+
+// cellValue converts a cell to a JSON-compatible value for a field of type t.
+func cellValue(t reflect.Type, raw string) (any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == decimalType {
+		return normalizeNumber(raw), nil // decimal.Decimal accepts quoted numbers
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return raw, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return raw, nil
+	case reflect.Bool:
+		return parseBool(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseInt(normalizeNumber(raw), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", raw)
+		}
+		return n, nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(normalizeNumber(raw), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", raw)
+		}
+		return f, nil
+	default:
+		if !json.Valid([]byte(raw)) {
+			return nil, fmt.Errorf("expected a JSON value")
+		}
+		return json.RawMessage(raw), nil
+	}
+}
+
+// normalizeNumber accepts spreadsheet formatting: "1 234,50" → "1234.50".
+func normalizeNumber(raw string) string {
+	s := strings.NewReplacer(" ", "", "\u00a0", "").Replace(raw)
+	return strings.Replace(s, ",", ".", 1)
+}
+
+func parseBool(raw string) (bool, error) {
+	switch strings.ToLower(raw) {
+	case "1", "true", "yes", "y", "да", "+":
+		return true, nil
+	case "0", "false", "no", "n", "нет", "-":
+		return false, nil
+	}
+	return false, fmt.Errorf("%q is not a boolean", raw)
+}
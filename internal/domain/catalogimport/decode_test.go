@@ -0,0 +1,93 @@
+package catalogimport
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+type testCreateDTO struct {
+	Name       string            `json:"name" binding:"required"`
+	Code       string            `json:"code"`
+	IsService  bool              `json:"isService"`
+	Weight     *decimal.Decimal  `json:"weight"`
+	Priority   int               `json:"priority"`
+	Attributes map[string]any    `json:"attributes"`
+	Tags       map[string]string `json:"-"`
+}
+
+func TestDecoder_ColumnsAuto(t *testing.T) {
+	d := NewDecoder[testCreateDTO]()
+
+	cols, err := d.Columns([]string{"Name", "Is Service", "unknown"}, nil)
+	if err != nil {
+		t.Fatalf("Columns: %v", err)
+	}
+	if cols[0] != "name" || cols[1] != "isService" || len(cols) != 2 {
+		t.Errorf("cols = %v", cols)
+	}
+
+	if _, err := d.Columns([]string{"foo"}, nil); err == nil {
+		t.Error("expected error when no column matches")
+	}
+}
+
+func TestDecoder_ColumnsMapping(t *testing.T) {
+	d := NewDecoder[testCreateDTO]()
+	header := []string{"Наименование", "Вес", "Цвет", "Примечание"}
+
+	cols, err := d.Columns(header, Mapping{"Наименование": "name", "Вес": "weight", "Цвет": "attributes.color", "Примечание": ""})
+	if err != nil {
+		t.Fatalf("Columns: %v", err)
+	}
+	if cols[0] != "name" || cols[1] != "weight" || cols[2] != "attributes.color" || len(cols) != 3 {
+		t.Errorf("cols = %v", cols)
+	}
+
+	if _, err := d.Columns(header, Mapping{"Наименование": "title"}); err == nil {
+		t.Error("expected error for unknown field")
+	}
+	if _, err := d.Columns(header, Mapping{"Артикул": "code"}); err == nil {
+		t.Error("expected error for missing column")
+	}
+}
+
+func TestDecoder_Decode(t *testing.T) {
+	d := NewDecoder[testCreateDTO]()
+	cols := map[int]string{0: "name", 1: "isService", 2: "weight", 3: "priority", 4: "attributes.color", 5: "code"}
+
+	dto, err := d.Decode(cols, []string{"Bolt", "да", "1 234,50", "3", "red", ""})
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if dto.Name != "Bolt" || !dto.IsService || dto.Priority != 3 || dto.Code != "" {
+		t.Errorf("dto = %+v", dto)
+	}
+	if dto.Weight == nil || !dto.Weight.Equal(decimal.RequireFromString("1234.5")) {
+		t.Errorf("weight = %v", dto.Weight)
+	}
+	if dto.Attributes["color"] != "red" {
+		t.Errorf("attributes = %v", dto.Attributes)
+	}
+}
+
+func TestDecoder_DecodeErrors(t *testing.T) {
+	d := NewDecoder[testCreateDTO]()
+
+	tests := []struct {
+		cells []string
+		field string
+	}{
+		{[]string{"Bolt", "maybe", ""}, "isService"},
+		{[]string{"Bolt", "", "ten"}, "priority"},
+	}
+	cols := map[int]string{0: "name", 1: "isService", 2: "priority"}
+	for _, tt := range tests {
+		_, err := d.Decode(cols, tt.cells)
+		var fe *FieldError
+		if !errors.As(err, &fe) || fe.Field != tt.field {
+			t.Errorf("Decode(%q) error = %v, want field %s", tt.cells, err, tt.field)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package catalogimport
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+
+	"metapus/internal/core/apperror"
+)
+
+// RowError describes why a file row cannot be imported.
+type RowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// Result is the outcome of an import or a dry run.
+// The import is all-or-nothing: Imported is 0 whenever Errors is not empty.
+type Result struct {
+	DryRun    bool       `json:"dryRun"`
+	TotalRows int        `json:"totalRows"`
+	ValidRows int        `json:"validRows"`
+	Imported  int        `json:"imported"`
+	Errors    []RowError `json:"errors"`
+}
+
+// NewRowError builds a RowError from a decoding, binding or domain error.
+// header/cols translate the error field back to the file column.
+func NewRowError(row int, err error, header []string, cols map[int]string) RowError {
+	re := RowError{Row: row, Message: err.Error()}
+
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		re.Field, re.Message = fe.Field, fe.Message
+	} else if appErr, ok := apperror.AsAppError(err); ok {
+		re.Message = appErr.Message
+		if field, ok := appErr.Details["field"].(string); ok {
+			re.Field = field
+		}
+	}
+
+	if re.Field != "" {
+		for i, f := range cols {
+			if f == re.Field && i < len(header) {
+				re.Column = header[i]
+				break
+			}
+		}
+	}
+	return re
+}
+
+// SortErrors orders errors by row number (stable for errors of one row).
+func SortErrors(errs []RowError) {
+	slices.SortStableFunc(errs, func(a, b RowError) int { return cmp.Compare(a.Row, b.Row) })
+}
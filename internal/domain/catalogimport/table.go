@@ -0,0 +1,165 @@
+// Package catalogimport reads CSV/XLSX files for bulk catalog import:
+// it parses the file into a table, maps file columns to DTO fields and
+// decodes each row into a create DTO. Validation and the insert itself are
+// done by domain.CatalogService.CreateBatch.
+package catalogimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"metapus/internal/core/apperror"
+)
+
+const (
+	// MaxFileSize bounds the uploaded file.
+	MaxFileSize = 10 << 20
+
+	// MaxRows bounds the number of data rows in one import (one COPY batch).
+	MaxRows = 10000
+)
+
+// Row is one data row of the file. Number is the 1-based line (CSV) or
+// sheet row (XLSX) shown to the user in error reports.
+type Row struct {
+	Number int
+	Cells  []string
+}
+
+// Table is a parsed import file: a header row followed by data rows.
+// Empty rows are skipped.
+type Table struct {
+	Header []string
+	Rows   []Row
+}
+
+// Parse reads a CSV or XLSX file (by extension). headerRow is the 1-based
+// row holding column names (e.g. 2 for list exports with a title row);
+// 0 means the first row.
+func Parse(fileName string, data []byte, headerRow int) (*Table, error) {
+	if headerRow <= 0 {
+		headerRow = 1
+	}
+
+	var records [][]string
+	var err error
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv", ".txt":
+		records, err = readCSV(data)
+	case ".xlsx":
+		records, err = readXLSX(data)
+	default:
+		return nil, apperror.NewValidation("unsupported file type: expected .csv or .xlsx").
+			WithDetail("field", "file")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) < headerRow {
+		return nil, apperror.NewValidation("file has no header row").
+			WithDetail("field", "file")
+	}
+
+	t := &Table{Header: trimCells(records[headerRow-1])}
+	for i := headerRow; i < len(records); i++ {
+		cells := trimCells(records[i])
+		if isEmptyRow(cells) {
+			continue
+		}
+		if len(t.Rows) == MaxRows {
+			return nil, apperror.NewValidation(fmt.Sprintf("file has more than %d rows", MaxRows)).
+				WithDetail("field", "file")
+		}
+		t.Rows = append(t.Rows, Row{Number: i + 1, Cells: cells})
+	}
+	return t, nil
+}
+
+// readCSV parses CSV with the delimiter detected from the header line
+// (";" is common in spreadsheets saved with a Russian locale).
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // UTF-8 BOM
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = detectDelimiter(data)
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	var records [][]string
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, apperror.NewValidation("invalid CSV: "+err.Error()).
+				WithDetail("field", "file")
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// detectDelimiter picks the most frequent of ";", "," and tab in the first line.
+func detectDelimiter(data []byte) rune {
+	line := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		line = data[:i]
+	}
+	best, bestCount := ',', 0
+	for _, d := range []rune{';', ',', '\t'} {
+		if n := bytes.Count(line, []byte(string(d))); n > bestCount {
+			best, bestCount = d, n
+		}
+	}
+	return best
+}
+
+// readXLSX reads the first sheet of a workbook.
+func readXLSX(data []byte) (_ [][]string, retErr error) {
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, apperror.NewValidation("invalid XLSX file").
+			WithDetail("field", "file")
+	}
+	defer func() {
+		if cErr := f.Close(); cErr != nil && retErr == nil {
+			retErr = fmt.Errorf("xlsx close: %w", cErr)
+		}
+	}()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, apperror.NewValidation("workbook has no sheets").
+			WithDetail("field", "file")
+	}
+	rows, err := f.GetRows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("read sheet %q: %w", sheets[0], err)
+	}
+	return rows, nil
+}
+
+func trimCells(cells []string) []string {
+	out := make([]string, len(cells))
+	for i, c := range cells {
+		out[i] = strings.TrimSpace(c)
+	}
+	return out
+}
+
+func isEmptyRow(cells []string) bool {
+	for _, c := range cells {
+		if c != "" {
+			return false
+		}
+	}
+	return true
+}
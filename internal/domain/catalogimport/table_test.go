@@ -0,0 +1,71 @@
+package catalogimport
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestParse_CSV(t *testing.T) {
+	data := []byte("\xef\xbb\xbfName;INN;Comment\nАльфа;7701234567;\"a;b\"\n;;\n  Бета ;;\n")
+
+	table, err := Parse("items.csv", data, 0)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := table.Header; len(got) != 3 || got[0] != "Name" || got[2] != "Comment" {
+		t.Fatalf("header = %q", got)
+	}
+	if len(table.Rows) != 2 {
+		t.Fatalf("rows = %d, want 2 (empty row skipped)", len(table.Rows))
+	}
+	if r := table.Rows[0]; r.Number != 2 || r.Cells[2] != "a;b" {
+		t.Errorf("row 0 = %+v", r)
+	}
+	if r := table.Rows[1]; r.Number != 4 || r.Cells[0] != "Бета" {
+		t.Errorf("row 1 = %+v, want number 4 and trimmed name", r)
+	}
+}
+
+func TestParse_HeaderRow(t *testing.T) {
+	data := []byte("Nomenclature export\nname,code\nBolt,B-1\n")
+
+	table, err := Parse("items.csv", data, 2)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if table.Header[0] != "name" || len(table.Rows) != 1 || table.Rows[0].Number != 3 {
+		t.Errorf("table = %+v", table)
+	}
+
+	if _, err := Parse("items.csv", data, 5); err == nil {
+		t.Error("expected error for header row beyond the file")
+	}
+}
+
+func TestParse_XLSX(t *testing.T) {
+	f := excelize.NewFile()
+	sheet := f.GetSheetName(0)
+	_ = f.SetSheetRow(sheet, "A1", &[]any{"name", "code"})
+	_ = f.SetSheetRow(sheet, "A2", &[]any{"Bolt", "B-1"})
+	_ = f.SetSheetRow(sheet, "A4", &[]any{"Nut", "N-1"})
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		t.Fatalf("write xlsx: %v", err)
+	}
+
+	table, err := Parse("Items.XLSX", buf.Bytes(), 0)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(table.Rows) != 2 || table.Rows[1].Number != 4 || table.Rows[1].Cells[0] != "Nut" {
+		t.Errorf("rows = %+v", table.Rows)
+	}
+}
+
+func TestParse_UnsupportedType(t *testing.T) {
+	if _, err := Parse("items.xls", []byte("x"), 0); err == nil {
+		t.Error("expected error for .xls")
+	}
+}
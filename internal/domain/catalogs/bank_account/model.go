@@ -0,0 +1,92 @@
+// Package bank_account provides the BankAccount catalog.
+// Bank accounts are the settlement accounts of a counterparty; one of them
+// is the default account used in payment documents.
+package bank_account
+
+import (
+	"context"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/pkg/requisites"
+)
+
+// BankAccount represents a settlement account of a counterparty.
+type BankAccount struct {
+	entity.Catalog
+
+	// CounterpartyID is the owner of the account
+	CounterpartyID id.ID `db:"counterparty_id" json:"counterpartyId" meta:"label:Контрагент"`
+
+	// AccountNumber is the 20-digit settlement account number
+	AccountNumber string `db:"account_number" json:"accountNumber" meta:"label:Номер счёта"`
+
+	// BIK is the bank identification code
+	BIK string `db:"bik" json:"bik" meta:"label:БИК"`
+
+	// BankName is the name of the bank
+	BankName string `db:"bank_name" json:"bankName" meta:"label:Банк"`
+
+	// CorrAccount is the optional correspondent account of the bank
+	CorrAccount *string `db:"corr_account" json:"corrAccount,omitempty" meta:"label:Корр. счёт"`
+
+	// CurrencyID is the optional currency of the account
+	CurrencyID *id.ID `db:"currency_id" json:"currencyId,omitempty" meta:"label:Валюта"`
+
+	// IsDefault marks the default account of the counterparty
+	IsDefault bool `db:"is_default" json:"isDefault" meta:"label:Основной"`
+}
+
+// NewBankAccount creates a new BankAccount with required fields.
+func NewBankAccount(code, name string, counterpartyID id.ID, accountNumber, bik, bankName string) *BankAccount {
+	return &BankAccount{
+		Catalog:        entity.NewCatalog(code, name),
+		CounterpartyID: counterpartyID,
+		AccountNumber:  accountNumber,
+		BIK:            bik,
+		BankName:       bankName,
+	}
+}
+
+// Validate implements entity.Validatable interface.
+func (a *BankAccount) Validate(ctx context.Context) error {
+	// Base catalog validation
+	if err := a.Catalog.Validate(ctx); err != nil {
+		return err
+	}
+
+	// CounterpartyID is required
+	if id.IsNil(a.CounterpartyID) {
+		return apperror.NewValidation("counterparty is required").
+			WithDetail("field", "counterpartyId")
+	}
+
+	if a.BankName == "" {
+		return apperror.NewValidation("bank name is required").
+			WithDetail("field", "bankName")
+	}
+
+	// BIK: 9 digits, Russian banks only
+	if !requisites.ValidBIK(a.BIK) {
+		return apperror.NewValidation("BIK must be 9 digits starting with 04").
+			WithDetail("field", "bik").
+			WithDetail("value", a.BIK)
+	}
+
+	// Account number: 20 digits with the control key of the bank
+	if !requisites.ValidAccount(a.AccountNumber, a.BIK) {
+		return apperror.NewValidation("invalid account number: wrong length or control key for this BIK").
+			WithDetail("field", "accountNumber").
+			WithDetail("value", a.AccountNumber)
+	}
+
+	// Correspondent account must belong to the same bank
+	if a.CorrAccount != nil && *a.CorrAccount != "" && !requisites.ValidCorrAccount(*a.CorrAccount, a.BIK) {
+		return apperror.NewValidation("invalid correspondent account: wrong length or control key for this BIK").
+			WithDetail("field", "corrAccount").
+			WithDetail("value", *a.CorrAccount)
+	}
+
+	return nil
+}
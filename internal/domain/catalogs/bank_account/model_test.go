@@ -0,0 +1,35 @@
+package bank_account
+
+import (
+	"context"
+	"testing"
+
+	"metapus/internal/core/id"
+)
+
+func TestValidateRequisites(t *testing.T) {
+	corr := "30101810400000000225"
+	wrongCorr := "30101810500000000653"
+
+	cases := []struct {
+		name    string
+		account string
+		bik     string
+		corr    *string
+		ok      bool
+	}{
+		{"valid", "40702810438000034726", "044525225", &corr, true},
+		{"no corr account", "40702810438000034726", "044525225", nil, true},
+		{"bad BIK", "40702810438000034726", "144525225", nil, false},
+		{"bad control key", "40702810438000034727", "044525225", nil, false},
+		{"corr of another bank", "40702810438000034726", "044525225", &wrongCorr, false},
+	}
+	for _, tc := range cases {
+		a := NewBankAccount("BA-1", "Сбербанк", id.New(), tc.account, tc.bik, "ПАО Сбербанк")
+		a.CorrAccount = tc.corr
+		err := a.Validate(context.Background())
+		if (err == nil) != tc.ok {
+			t.Errorf("%s: err = %v, want ok = %v", tc.name, err, tc.ok)
+		}
+	}
+}
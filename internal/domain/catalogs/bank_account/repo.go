@@ -0,0 +1,22 @@
+package bank_account
+
+import (
+	"context"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain"
+)
+
+// Repository defines the interface for BankAccount persistence.
+// Create and Update of a default account clear the default flag of the
+// other accounts of the counterparty in the same transaction.
+type Repository interface {
+	domain.CatalogRepository[*BankAccount]
+
+	// FindByCounterparty retrieves the accounts of a counterparty.
+	FindByCounterparty(ctx context.Context, counterpartyID id.ID) ([]*BankAccount, error)
+
+	// GetDefault retrieves the default account of a counterparty.
+	// Returns NotFound if the counterparty has no default account.
+	GetDefault(ctx context.Context, counterpartyID id.ID) (*BankAccount, error)
+}
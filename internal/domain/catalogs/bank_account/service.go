@@ -0,0 +1,76 @@
+package bank_account
+
+import (
+	"context"
+
+	"metapus/internal/core/id"
+	"metapus/internal/core/numerator"
+	"metapus/internal/domain"
+)
+
+// Service provides business logic for BankAccount catalog.
+// Uses composition with domain.CatalogService for common CRUD operations.
+type Service struct {
+	*domain.CatalogService[*BankAccount] // Embedded for delegation
+	repo                                 Repository
+	numerator                            numerator.Generator
+}
+
+// NewService creates a new BankAccount service.
+// In Database-per-Tenant, TxManager is obtained from context.
+func NewService(
+	repo Repository,
+	numerator numerator.Generator,
+) *Service {
+	base := domain.NewCatalogService(domain.CatalogServiceConfig[*BankAccount]{
+		Repo:       repo,
+		TxManager:  nil, // Will be obtained from context
+		Numerator:  numerator,
+		EntityName: "bank_account",
+	})
+
+	svc := &Service{
+		CatalogService: base,
+		repo:           repo,
+		numerator:      numerator,
+	}
+
+	base.Hooks().OnBeforeCreate(svc.prepareForCreate)
+
+	return svc
+}
+
+// prepareForCreate handles code generation and makes the first account of
+// a counterparty its default one.
+func (s *Service) prepareForCreate(ctx context.Context, a *BankAccount) error {
+	// Generate code if not provided
+	if a.Code == "" {
+		code, err := s.GenerateCode(ctx, a.Name, "BA")
+		if err != nil {
+			return err
+		}
+		a.Code = code
+	}
+
+	if !a.IsDefault {
+		existing, err := s.repo.FindByCounterparty(ctx, a.CounterpartyID)
+		if err != nil {
+			return err
+		}
+		a.IsDefault = len(existing) == 0
+	}
+
+	return nil
+}
+
+// --- Entity-specific methods ---
+
+// FindByCounterparty retrieves the accounts of a counterparty.
+func (s *Service) FindByCounterparty(ctx context.Context, counterpartyID id.ID) ([]*BankAccount, error) {
+	return s.repo.FindByCounterparty(ctx, counterpartyID)
+}
+
+// GetDefault retrieves the default account of a counterparty.
+func (s *Service) GetDefault(ctx context.Context, counterpartyID id.ID) (*BankAccount, error) {
+	return s.repo.GetDefault(ctx, counterpartyID)
+}
@@ -2,8 +2,6 @@ package contract
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"metapus/internal/core/id"
 	"metapus/internal/core/numerator"
@@ -46,9 +44,9 @@ func NewService(
 func (s *Service) prepareForCreate(ctx context.Context, c *Contract) error {
 	// Generate code if not provided
 	if c.Code == "" {
-		code, err := s.numerator.GetNextNumber(ctx, numerator.DefaultConfig("CT"), nil, time.Now())
+		code, err := s.GenerateCode(ctx, c.Name, "CT")
 		if err != nil {
-			return fmt.Errorf("generate code: %w", err)
+			return err
 		}
 		c.Code = code
 	}
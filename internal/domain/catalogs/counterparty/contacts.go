@@ -0,0 +1,172 @@
+package counterparty
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+)
+
+// phoneRE allows digits and the usual phone formatting characters.
+var phoneRE = regexp.MustCompile(`^\+?[\d\s().-]+$`)
+
+// phoneKeyDigits is the number of trailing digits compared when searching
+// by phone: a Russian number without the +7/8 prefix.
+const phoneKeyDigits = 10
+
+// Contact is a contact person of a counterparty (cat_counterparty_contacts).
+type Contact struct {
+	ID    id.ID   `db:"id" json:"id"`
+	Name  string  `db:"name" json:"name" meta:"label:ФИО"`
+	Role  *string `db:"role" json:"role,omitempty" meta:"label:Должность"`
+	Phone *string `db:"phone" json:"phone,omitempty" meta:"label:Телефон"`
+	Email *string `db:"email" json:"email,omitempty" meta:"label:Email"`
+}
+
+// ContactMatch is a contact found by phone or email, with its counterparty.
+type ContactMatch struct {
+	CounterpartyID   id.ID  `db:"counterparty_id" json:"counterpartyId"`
+	CounterpartyName string `db:"counterparty_name" json:"counterpartyName"`
+	Contact
+}
+
+// ContactFilter selects contacts by phone or email; both set means either.
+type ContactFilter struct {
+	// Phone is matched by its last 10 digits (see PhoneKey).
+	Phone string
+	// Email is matched case-insensitively.
+	Email string
+	Limit int
+}
+
+// PhoneKey returns the digits of phone compared by contact search: the last
+// 10, so "+7 (912) 345-67-89" and "89123456789" match.
+func PhoneKey(phone string) string {
+	digits := make([]byte, 0, len(phone))
+	for i := 0; i < len(phone); i++ {
+		if phone[i] >= '0' && phone[i] <= '9' {
+			digits = append(digits, phone[i])
+		}
+	}
+	if len(digits) > phoneKeyDigits {
+		digits = digits[len(digits)-phoneKeyDigits:]
+	}
+	return string(digits)
+}
+
+// validateContacts checks the contact persons: each has a name, a well-formed
+// phone and email, and a unique ID.
+func (c *Counterparty) validateContacts() error {
+	seen := make(map[id.ID]struct{}, len(c.Contacts))
+	for i, contact := range c.Contacts {
+		if contact.Name == "" {
+			return apperror.NewValidation("contact name is required").
+				WithDetail("field", "contacts").
+				WithDetail("lineNo", i+1)
+		}
+		if contact.Phone != nil && *contact.Phone != "" && (!phoneRE.MatchString(*contact.Phone) || PhoneKey(*contact.Phone) == "") {
+			return apperror.NewValidation("invalid contact phone format").
+				WithDetail("field", "contacts").
+				WithDetail("lineNo", i+1)
+		}
+		if contact.Email != nil && *contact.Email != "" && !isValidEmail(*contact.Email) {
+			return apperror.NewValidation("invalid contact email format").
+				WithDetail("field", "contacts").
+				WithDetail("lineNo", i+1)
+		}
+		if !id.IsNil(contact.ID) {
+			if _, dup := seen[contact.ID]; dup {
+				return apperror.NewValidation(fmt.Sprintf("contact %s is listed twice", contact.ID)).
+					WithDetail("field", "contacts").
+					WithDetail("lineNo", i+1)
+			}
+			seen[contact.ID] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// contactIndex returns the position of the contact in c.Contacts, or NotFound.
+func (c *Counterparty) contactIndex(contactID id.ID) (int, error) {
+	for i := range c.Contacts {
+		if c.Contacts[i].ID == contactID {
+			return i, nil
+		}
+	}
+	return -1, apperror.NewNotFound("contact", contactID.String())
+}
+
+// --- Nested contact operations ---
+// Contacts are a table part: every change saves the counterparty, so it is
+// versioned, audited and validated like any other edit.
+
+// ListContacts returns the contact persons of a counterparty.
+func (s *Service) ListContacts(ctx context.Context, counterpartyID id.ID) ([]Contact, error) {
+	cp, err := s.GetByID(ctx, counterpartyID)
+	if err != nil {
+		return nil, err
+	}
+	if cp.Contacts == nil {
+		return []Contact{}, nil
+	}
+	return cp.Contacts, nil
+}
+
+// AddContact appends a contact person to a counterparty.
+func (s *Service) AddContact(ctx context.Context, counterpartyID id.ID, contact Contact) (*Contact, error) {
+	cp, err := s.GetByID(ctx, counterpartyID)
+	if err != nil {
+		return nil, err
+	}
+	contact.ID = id.New()
+	cp.Contacts = append(cp.Contacts, contact)
+	if err := s.Update(ctx, cp); err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// UpdateContact replaces a contact person of a counterparty.
+func (s *Service) UpdateContact(ctx context.Context, counterpartyID, contactID id.ID, contact Contact) (*Contact, error) {
+	cp, err := s.GetByID(ctx, counterpartyID)
+	if err != nil {
+		return nil, err
+	}
+	i, err := cp.contactIndex(contactID)
+	if err != nil {
+		return nil, err
+	}
+	contact.ID = contactID
+	cp.Contacts[i] = contact
+	if err := s.Update(ctx, cp); err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// DeleteContact removes a contact person from a counterparty.
+func (s *Service) DeleteContact(ctx context.Context, counterpartyID, contactID id.ID) error {
+	cp, err := s.GetByID(ctx, counterpartyID)
+	if err != nil {
+		return err
+	}
+	i, err := cp.contactIndex(contactID)
+	if err != nil {
+		return err
+	}
+	cp.Contacts = append(cp.Contacts[:i], cp.Contacts[i+1:]...)
+	return s.Update(ctx, cp)
+}
+
+// FindContacts searches the contact persons of all counterparties by phone
+// or email.
+func (s *Service) FindContacts(ctx context.Context, filter ContactFilter) ([]ContactMatch, error) {
+	filter.Phone = PhoneKey(filter.Phone)
+	if filter.Phone == "" && filter.Email == "" {
+		return nil, apperror.NewValidation("phone or email is required").
+			WithDetail("field", "phone")
+	}
+	return s.repo.FindContacts(ctx, filter)
+}
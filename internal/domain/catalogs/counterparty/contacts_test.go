@@ -0,0 +1,48 @@
+package counterparty
+
+import (
+	"testing"
+
+	"metapus/internal/core/id"
+)
+
+func TestPhoneKey(t *testing.T) {
+	cases := map[string]string{
+		"+7 (912) 345-67-89": "9123456789",
+		"89123456789":        "9123456789",
+		"912-345-67-89":      "9123456789",
+		"12-34":              "1234",
+		"":                   "",
+	}
+	for phone, want := range cases {
+		if got := PhoneKey(phone); got != want {
+			t.Errorf("PhoneKey(%q) = %q, want %q", phone, got, want)
+		}
+	}
+}
+
+func TestValidateContacts(t *testing.T) {
+	phone, badPhone := "+7 912 345-67-89", "call me"
+	email, badEmail := "ivanov@example.com", "ivanov@"
+	dupID := id.New()
+
+	cases := []struct {
+		name     string
+		contacts []Contact
+		ok       bool
+	}{
+		{"valid", []Contact{{Name: "Иванов", Phone: &phone, Email: &email}, {Name: "Петров"}}, true},
+		{"no name", []Contact{{Phone: &phone}}, false},
+		{"bad phone", []Contact{{Name: "Иванов", Phone: &badPhone}}, false},
+		{"bad email", []Contact{{Name: "Иванов", Email: &badEmail}}, false},
+		{"duplicate id", []Contact{{ID: dupID, Name: "Иванов"}, {ID: dupID, Name: "Петров"}}, false},
+	}
+	for _, tc := range cases {
+		cp := NewCounterparty("CP-1", "ООО Ромашка", TypeCustomer, LegalCompany)
+		cp.Contacts = tc.contacts
+		err := cp.validateContacts()
+		if (err == nil) != tc.ok {
+			t.Errorf("%s: err = %v, want ok = %v", tc.name, err, tc.ok)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"metapus/internal/core/apperror"
 	"metapus/internal/core/entity"
+	"metapus/internal/domain/gallery"
 )
 
 // Pre-compiled regex patterns for validation (performance optimization)
@@ -77,6 +78,22 @@ type Counterparty struct {
 
 	// Comment is a free-form note
 	Comment *string `db:"comment" json:"comment,omitempty" meta:"label:Комментарий"`
+
+	// Contacts are the contact persons (cat_counterparty_contacts).
+	// Nil when not loaded: saving then leaves the stored contacts unchanged.
+	Contacts []Contact `db:"-" json:"contacts,omitempty" meta:"label:Контактные лица"`
+	// Images is the image gallery (sys_images), loaded for API responses.
+	Images []*gallery.Image `db:"-" json:"-"`
+}
+
+// GetImages returns the image gallery (implements gallery.Owner).
+func (c *Counterparty) GetImages() []*gallery.Image {
+	return c.Images
+}
+
+// SetImages sets the image gallery (implements gallery.Owner).
+func (c *Counterparty) SetImages(images []*gallery.Image) {
+	c.Images = images
 }
 
 // NewCounterparty creates a new Counterparty with required fields.
@@ -130,7 +147,7 @@ func (c *Counterparty) Validate(ctx context.Context) error {
 			WithDetail("field", "email")
 	}
 
-	return nil
+	return c.validateContacts()
 }
 
 // IsCustomer returns true if counterparty is a customer.
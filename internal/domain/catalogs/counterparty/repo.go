@@ -13,4 +13,7 @@ type Repository interface {
 	// FindByINN retrieves counterparty by INN (unique within tenant).
 	FindByINN(ctx context.Context, inn string) (*Counterparty, error)
 
+	// FindContacts searches contact persons by phone key or email.
+	FindContacts(ctx context.Context, filter ContactFilter) ([]ContactMatch, error)
+
 }
@@ -0,0 +1,50 @@
+package counterparty
+
+import (
+	"context"
+
+	"metapus/internal/core/apperror"
+	"metapus/pkg/requisites"
+)
+
+// Requisites are the registration details of a company or sole trader found
+// by INN in the state register.
+type Requisites struct {
+	INN          string    `json:"inn"`
+	KPP          string    `json:"kpp,omitempty"`
+	OGRN         string    `json:"ogrn,omitempty"`
+	Name         string    `json:"name"`
+	FullName     string    `json:"fullName,omitempty"`
+	LegalForm    LegalForm `json:"legalForm"`
+	LegalAddress string    `json:"legalAddress,omitempty"`
+	Director     string    `json:"director,omitempty"`
+	// Active is false for a liquidated or liquidating organization.
+	Active bool `json:"active"`
+}
+
+// RequisitesProvider looks up requisites by INN in an external service
+// (DaData and the like). Implementations return NotFound when no
+// organization has the INN.
+type RequisitesProvider interface {
+	FindByINN(ctx context.Context, inn string) (*Requisites, error)
+}
+
+// SetRequisitesProvider sets the provider used by LookupRequisites.
+func (s *Service) SetRequisitesProvider(p RequisitesProvider) {
+	s.requisites = p
+}
+
+// LookupRequisites returns the requisites of the organization with the INN,
+// used to fill a new counterparty. The INN control digits are checked
+// before the provider is called.
+func (s *Service) LookupRequisites(ctx context.Context, inn string) (*Requisites, error) {
+	if !requisites.ValidINN(inn) {
+		return nil, apperror.NewValidation("invalid INN: wrong length or control digits").
+			WithDetail("field", "inn").
+			WithDetail("value", inn)
+	}
+	if s.requisites == nil {
+		return nil, apperror.NewNotFound("requisites provider", "not configured")
+	}
+	return s.requisites.FindByINN(ctx, inn)
+}
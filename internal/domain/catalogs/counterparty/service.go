@@ -2,8 +2,6 @@ package counterparty
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"metapus/internal/core/apperror"
 	"metapus/internal/core/id"
@@ -17,6 +15,7 @@ type Service struct {
 	*domain.CatalogService[*Counterparty] // Embedded for delegation
 	repo                                  Repository
 	numerator                             numerator.Generator
+	requisites                            RequisitesProvider
 }
 
 // NewService creates a new Counterparty service.
@@ -50,10 +49,9 @@ func NewService(
 func (s *Service) prepareForCreate(ctx context.Context, cp *Counterparty) error {
 	// Generate code if not provided
 	if cp.Code == "" {
-		cfg := numerator.DefaultConfig("CP")
-		code, err := s.numerator.GetNextNumber(ctx, cfg, nil, time.Now())
+		code, err := s.GenerateCode(ctx, cp.Name, "CP")
 		if err != nil {
-			return fmt.Errorf("generate code: %w", err)
+			return err
 		}
 		cp.Code = code
 	}
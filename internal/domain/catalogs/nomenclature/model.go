@@ -10,6 +10,8 @@ import (
 	"metapus/internal/core/apperror"
 	"metapus/internal/core/entity"
 	"metapus/internal/core/id"
+	"metapus/internal/domain/gallery"
+	"metapus/pkg/barcode"
 )
 
 // NomenclatureType defines the type of item.
@@ -69,6 +71,33 @@ type Nomenclature struct {
 
 	// ImageURL is the item image URL
 	ImageURL *string `db:"image_url" json:"imageUrl,omitempty" meta:"label:Изображение"`
+
+	// PackagingUnits are the units the item is packed in (cat_nomenclature_units).
+	// Nil when not loaded: saving then leaves the stored units unchanged.
+	PackagingUnits []PackagingUnit `db:"-" json:"packagingUnits,omitempty" meta:"label:Упаковки"`
+
+	// Barcodes are the additional barcodes of the item (cat_nomenclature_barcodes).
+	// Nil when not loaded: saving then leaves the stored barcodes unchanged.
+	Barcodes []string `db:"-" json:"barcodes,omitempty" meta:"label:Штрихкоды"`
+	// Images is the image gallery (sys_images), loaded for API responses.
+	Images []*gallery.Image `db:"-" json:"-"`
+}
+
+// GetImages returns the image gallery (implements gallery.Owner).
+func (n *Nomenclature) GetImages() []*gallery.Image {
+	return n.Images
+}
+
+// SetImages sets the image gallery (implements gallery.Owner).
+func (n *Nomenclature) SetImages(images []*gallery.Image) {
+	n.Images = images
+}
+
+// PackagingUnit is a unit holding a fixed number of base units of the
+// nomenclature (e.g. a box of 12 pcs: Coefficient = 12).
+type PackagingUnit struct {
+	UnitID      id.ID           `db:"unit_id" json:"unitId" meta:"label:Единица"`
+	Coefficient decimal.Decimal `db:"coefficient" json:"coefficient" meta:"label:Коэффициент"`
 }
 
 // NewNomenclature creates a new Nomenclature with required fields.
@@ -115,9 +144,100 @@ func (n *Nomenclature) Validate(ctx context.Context) error {
 		}
 	}
 
+	if err := n.validatePackagingUnits(); err != nil {
+		return err
+	}
+	return n.validateBarcodes()
+}
+
+// validateBarcodes checks the primary and additional barcodes: each is a
+// valid EAN-13 or Code 128 code and is listed once.
+func (n *Nomenclature) validateBarcodes() error {
+	if n.Barcode != nil && *n.Barcode != "" {
+		if _, err := barcode.Detect(*n.Barcode); err != nil {
+			return apperror.NewValidation("invalid barcode: "+err.Error()).
+				WithDetail("field", "barcode")
+		}
+	}
+
+	seen := make(map[string]struct{}, len(n.Barcodes))
+	for i, code := range n.Barcodes {
+		if _, err := barcode.Detect(code); err != nil {
+			return apperror.NewValidation("invalid barcode: "+err.Error()).
+				WithDetail("field", "barcodes").
+				WithDetail("lineNo", i+1)
+		}
+		_, dup := seen[code]
+		if dup || (n.Barcode != nil && *n.Barcode == code) {
+			return apperror.NewValidation("barcode is listed more than once").
+				WithDetail("field", "barcodes").
+				WithDetail("lineNo", i+1)
+		}
+		seen[code] = struct{}{}
+	}
 	return nil
 }
 
+// AllBarcodes returns the primary barcode followed by the additional ones.
+func (n *Nomenclature) AllBarcodes() []string {
+	codes := make([]string, 0, len(n.Barcodes)+1)
+	if n.Barcode != nil && *n.Barcode != "" {
+		codes = append(codes, *n.Barcode)
+	}
+	return append(codes, n.Barcodes...)
+}
+
+// validatePackagingUnits checks the packaging units: a unit is listed once,
+// is not the base unit and holds a positive number of base units.
+func (n *Nomenclature) validatePackagingUnits() error {
+	if len(n.PackagingUnits) == 0 {
+		return nil
+	}
+	if n.BaseUnitID == nil || id.IsNil(*n.BaseUnitID) {
+		return apperror.NewValidation("packaging units require a base unit").
+			WithDetail("field", "baseUnitId")
+	}
+
+	seen := make(map[id.ID]struct{}, len(n.PackagingUnits))
+	for i, pu := range n.PackagingUnits {
+		switch {
+		case id.IsNil(pu.UnitID):
+			return apperror.NewValidation("unit is required").
+				WithDetail("field", "packagingUnits").
+				WithDetail("lineNo", i+1)
+		case pu.UnitID == *n.BaseUnitID:
+			return apperror.NewValidation("packaging unit cannot be the base unit").
+				WithDetail("field", "packagingUnits").
+				WithDetail("lineNo", i+1)
+		case !pu.Coefficient.IsPositive():
+			return apperror.NewValidation("coefficient must be positive").
+				WithDetail("field", "packagingUnits").
+				WithDetail("lineNo", i+1)
+		}
+		if _, dup := seen[pu.UnitID]; dup {
+			return apperror.NewValidation("packaging unit is listed more than once").
+				WithDetail("field", "packagingUnits").
+				WithDetail("lineNo", i+1)
+		}
+		seen[pu.UnitID] = struct{}{}
+	}
+	return nil
+}
+
+// PackagingCoefficient returns the base units in one unitID: 1 for the base
+// unit, the coefficient of a packaging unit, false for other units.
+func (n *Nomenclature) PackagingCoefficient(unitID id.ID) (decimal.Decimal, bool) {
+	if n.BaseUnitID != nil && *n.BaseUnitID == unitID {
+		return decimal.NewFromInt(1), true
+	}
+	for _, pu := range n.PackagingUnits {
+		if pu.UnitID == unitID {
+			return pu.Coefficient, true
+		}
+	}
+	return decimal.Zero, false
+}
+
 // IsPhysical returns true if item has physical presence (not a service).
 func (n *Nomenclature) IsPhysical() bool {
 	return n.Type != TypeService && n.Type != TypeWork
@@ -0,0 +1,39 @@
+package nomenclature
+
+import "testing"
+
+func TestValidateBarcodes(t *testing.T) {
+	primary := "4006381333931"
+
+	cases := []struct {
+		name     string
+		barcodes []string
+		ok       bool
+	}{
+		{"none", nil, true},
+		{"valid", []string{"5901234123457", "BOX-12"}, true},
+		{"bad check digit", []string{"5901234123458"}, false},
+		{"non-printable", []string{"A\tB"}, false},
+		{"duplicate", []string{"BOX-12", "BOX-12"}, false},
+		{"same as primary", []string{primary}, false},
+	}
+	for _, tc := range cases {
+		item := NewNomenclature("B1", "Bolt", TypeGoods)
+		item.Barcode = &primary
+		item.Barcodes = tc.barcodes
+		err := item.validateBarcodes()
+		if (err == nil) != tc.ok {
+			t.Errorf("%s: err = %v, want ok = %v", tc.name, err, tc.ok)
+		}
+	}
+
+	bad := "4006381333932"
+	item := NewNomenclature("B1", "Bolt", TypeGoods)
+	item.Barcode = &bad
+	if err := item.validateBarcodes(); err == nil {
+		t.Error("invalid primary EAN-13: err = nil, want a validation error")
+	}
+	if got := len(item.AllBarcodes()); got != 1 {
+		t.Errorf("AllBarcodes: len = %d, want 1", got)
+	}
+}
@@ -13,10 +13,10 @@ type Repository interface {
 	// FindByArticle retrieves nomenclature by article.
 	FindByArticle(ctx context.Context, article string) (*Nomenclature, error)
 
-	// FindByBarcode retrieves nomenclature by barcode.
+	// FindByBarcode retrieves nomenclature by its primary or an additional barcode.
 	FindByBarcode(ctx context.Context, barcode string) (*Nomenclature, error)
 
 
-	// FindLowStock retrieves items with stock below minimum.
+	// FindLowStock retrieves items with stock below their minimum level in any warehouse.
 	FindLowStock(ctx context.Context, filter domain.ListFilter) (domain.CursorListResult[*Nomenclature], error)
 }
@@ -0,0 +1,56 @@
+package nomenclature
+
+import (
+	"context"
+	"fmt"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+)
+
+// LineSerials is the nomenclature, quantity in base units and serial numbers
+// of a document line.
+type LineSerials struct {
+	NomenclatureID id.ID
+	BaseQuantity   types.Quantity
+	Serials        []string
+}
+
+// CheckLineSerials checks the serial numbers of document lines against the
+// nomenclature: a line of an item tracked by serial number needs a whole
+// quantity in base units and exactly one serial per unit, other lines must
+// not have serials.
+func CheckLineSerials(ctx context.Context, repo Repository, lines []LineSerials) error {
+	items := make(map[id.ID]*Nomenclature)
+	for i, line := range lines {
+		item, found := items[line.NomenclatureID]
+		if !found {
+			var err error
+			if item, err = repo.GetByID(ctx, line.NomenclatureID); err != nil {
+				return fmt.Errorf("get nomenclature %s: %w", line.NomenclatureID, err)
+			}
+			items[line.NomenclatureID] = item
+		}
+
+		if !item.TrackSerial {
+			if len(line.Serials) > 0 {
+				return apperror.NewValidation(
+					fmt.Sprintf("%q is not tracked by serial number", item.Name)).
+					WithDetail("field", "lines").
+					WithDetail("lineNo", i+1)
+			}
+			continue
+		}
+
+		scaled := line.BaseQuantity.Int64Scaled()
+		if scaled%types.QuantityScale != 0 || scaled/types.QuantityScale != int64(len(line.Serials)) {
+			return apperror.NewValidation(
+				fmt.Sprintf("%q is tracked by serial number: %d serial numbers entered for quantity %s",
+					item.Name, len(line.Serials), line.BaseQuantity)).
+				WithDetail("field", "lines").
+				WithDetail("lineNo", i+1)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package nomenclature
+
+import (
+	"context"
+	"testing"
+
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+)
+
+func TestCheckLineSerials(t *testing.T) {
+	phone := NewNomenclature("P1", "Phone", TypeGoods)
+	phone.ID = id.New()
+	phone.TrackSerial = true
+	cable := NewNomenclature("C1", "Cable", TypeGoods)
+	cable.ID = id.New()
+	repo := &fakeNomenclatures{items: map[id.ID]*Nomenclature{phone.ID: phone, cable.ID: cable}}
+
+	cases := []struct {
+		name string
+		line LineSerials
+		ok   bool
+	}{
+		{"one serial per unit", LineSerials{phone.ID, types.NewQuantityFromFloat64(2), []string{"A", "B"}}, true},
+		{"missing serials", LineSerials{phone.ID, types.NewQuantityFromFloat64(2), []string{"A"}}, false},
+		{"fractional quantity", LineSerials{phone.ID, types.NewQuantityFromFloat64(1.5), []string{"A"}}, false},
+		{"untracked without serials", LineSerials{cable.ID, types.NewQuantityFromFloat64(3), nil}, true},
+		{"untracked with serials", LineSerials{cable.ID, types.NewQuantityFromFloat64(1), []string{"A"}}, false},
+	}
+	for _, tc := range cases {
+		err := CheckLineSerials(context.Background(), repo, []LineSerials{tc.line})
+		if (err == nil) != tc.ok {
+			t.Errorf("%s: err = %v, want ok = %v", tc.name, err, tc.ok)
+		}
+	}
+}
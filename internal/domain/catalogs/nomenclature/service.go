@@ -2,13 +2,12 @@ package nomenclature
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"metapus/internal/core/apperror"
 	"metapus/internal/core/id"
 	"metapus/internal/core/numerator"
 	"metapus/internal/domain"
+	"metapus/pkg/barcode"
 )
 
 // Service provides business logic for Nomenclature catalog.
@@ -48,9 +47,9 @@ func NewService(
 func (s *Service) prepareForCreate(ctx context.Context, item *Nomenclature) error {
 	// Generate code if not provided
 	if item.Code == "" {
-		code, err := s.numerator.GetNextNumber(ctx, numerator.DefaultConfig("NM"), nil, time.Now())
+		code, err := s.GenerateCode(ctx, item.Name, "NM")
 		if err != nil {
-			return fmt.Errorf("generate code: %w", err)
+			return err
 		}
 		item.Code = code
 	}
@@ -64,14 +63,7 @@ func (s *Service) prepareForCreate(ctx context.Context, item *Nomenclature) erro
 	}
 
 	// Check barcode uniqueness
-	if item.Barcode != nil && *item.Barcode != "" {
-		if exists, _ := s.checkBarcodeExists(ctx, *item.Barcode, item.ID); exists {
-			return apperror.NewConflict("item with this barcode already exists").
-				WithDetail("barcode", item.Barcode)
-		}
-	}
-
-	return nil
+	return s.checkBarcodesUnique(ctx, item)
 }
 
 // prepareForUpdate handles uniqueness checks.
@@ -83,19 +75,12 @@ func (s *Service) prepareForUpdate(ctx context.Context, item *Nomenclature) erro
 		}
 	}
 
-	if item.Barcode != nil && *item.Barcode != "" {
-		if exists, _ := s.checkBarcodeExists(ctx, *item.Barcode, item.ID); exists {
-			return apperror.NewConflict("item with this barcode already exists").
-				WithDetail("barcode", item.Barcode)
-		}
-	}
-
-	return nil
+	return s.checkBarcodesUnique(ctx, item)
 }
 
 // --- Entity-specific methods ---
 
-// FindLowStock retrieves items with stock below minimum.
+// FindLowStock retrieves items with stock below their minimum level in any warehouse.
 func (s *Service) FindLowStock(ctx context.Context, filter domain.ListFilter) (domain.CursorListResult[*Nomenclature], error) {
 	return s.repo.FindLowStock(ctx, filter)
 }
@@ -105,9 +90,14 @@ func (s *Service) FindByArticle(ctx context.Context, article string) (*Nomenclat
 	return s.repo.FindByArticle(ctx, article)
 }
 
-// FindByBarcode retrieves nomenclature by barcode.
-func (s *Service) FindByBarcode(ctx context.Context, barcode string) (*Nomenclature, error) {
-	return s.repo.FindByBarcode(ctx, barcode)
+// FindByBarcode retrieves nomenclature by its primary or an additional
+// barcode. The code must be a valid EAN-13 or Code 128 barcode.
+func (s *Service) FindByBarcode(ctx context.Context, code string) (*Nomenclature, error) {
+	if _, err := barcode.Detect(code); err != nil {
+		return nil, apperror.NewValidation("invalid barcode: "+err.Error()).
+			WithDetail("field", "barcode")
+	}
+	return s.repo.FindByBarcode(ctx, code)
 }
 
 // checkArticleExists checks if article is already used.
@@ -119,6 +109,18 @@ func (s *Service) checkArticleExists(ctx context.Context, article string, exclud
 	return existing.ID != excludeID, nil
 }
 
+// checkBarcodesUnique checks that no other item uses the primary or an
+// additional barcode of item.
+func (s *Service) checkBarcodesUnique(ctx context.Context, item *Nomenclature) error {
+	for _, code := range item.AllBarcodes() {
+		if exists, _ := s.checkBarcodeExists(ctx, code, item.ID); exists {
+			return apperror.NewConflict("item with this barcode already exists").
+				WithDetail("barcode", code)
+		}
+	}
+	return nil
+}
+
 // checkBarcodeExists checks if barcode is already used.
 func (s *Service) checkBarcodeExists(ctx context.Context, barcode string, excludeID id.ID) (bool, error) {
 	existing, err := s.repo.FindByBarcode(ctx, barcode)
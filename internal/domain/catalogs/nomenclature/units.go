@@ -0,0 +1,116 @@
+package nomenclature
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/catalogs/unit"
+)
+
+// coefficientPlaces is the precision of document line coefficients
+// (NUMERIC(15,6)).
+const coefficientPlaces = 6
+
+// UnitLookup loads units of measure (implemented by unit.Repository).
+type UnitLookup interface {
+	GetByID(ctx context.Context, unitID id.ID) (*unit.Unit, error)
+}
+
+// UnitConverter resolves how many base units of a nomenclature one unit of a
+// document line holds. Lookups are not cached across calls: create one
+// converter per application and call Coefficients once per document.
+type UnitConverter struct {
+	nomenclatures Repository
+	units         UnitLookup
+}
+
+// NewUnitConverter creates a new unit converter.
+func NewUnitConverter(nomenclatures Repository, units UnitLookup) *UnitConverter {
+	return &UnitConverter{nomenclatures: nomenclatures, units: units}
+}
+
+// LineUnit is the nomenclature and unit of a document line.
+type LineUnit struct {
+	NomenclatureID id.ID
+	UnitID         id.ID
+	// LineNo is the 1-based number of the line in the document, reported
+	// in errors. Zero means the position in the lines passed to Coefficients.
+	LineNo int
+}
+
+// Coefficients returns the coefficient of every line, in order:
+//   - 1 for the base unit of the nomenclature;
+//   - the coefficient of a packaging unit of the nomenclature;
+//   - the ratio of the conversion factors (cat_units) for a unit of the same
+//     type as the base unit (e.g. grams for an item counted in kilograms).
+//
+// ok[i] is false for a nomenclature without a base unit: its line keeps the
+// entered coefficient. A unit that cannot be converted is a validation error.
+func (c *UnitConverter) Coefficients(ctx context.Context, lines []LineUnit) (coefficients []decimal.Decimal, ok []bool, err error) {
+	coefficients = make([]decimal.Decimal, len(lines))
+	ok = make([]bool, len(lines))
+
+	items := make(map[id.ID]*Nomenclature)
+	units := make(map[id.ID]*unit.Unit)
+	getUnit := func(unitID id.ID) (*unit.Unit, error) {
+		if u, found := units[unitID]; found {
+			return u, nil
+		}
+		u, err := c.units.GetByID(ctx, unitID)
+		if err != nil {
+			return nil, err
+		}
+		units[unitID] = u
+		return u, nil
+	}
+
+	for i, line := range lines {
+		item, found := items[line.NomenclatureID]
+		if !found {
+			if item, err = c.nomenclatures.GetByID(ctx, line.NomenclatureID); err != nil {
+				return nil, nil, fmt.Errorf("get nomenclature %s: %w", line.NomenclatureID, err)
+			}
+			items[line.NomenclatureID] = item
+		}
+		if item.BaseUnitID == nil || id.IsNil(*item.BaseUnitID) {
+			continue
+		}
+
+		if coef, known := item.PackagingCoefficient(line.UnitID); known {
+			coefficients[i], ok[i] = coef, true
+			continue
+		}
+
+		lineUnit, err := getUnit(line.UnitID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get unit %s: %w", line.UnitID, err)
+		}
+		baseUnit, err := getUnit(*item.BaseUnitID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get unit %s: %w", *item.BaseUnitID, err)
+		}
+		lineNo := line.LineNo
+		if lineNo == 0 {
+			lineNo = i + 1
+		}
+		if lineUnit.Type != baseUnit.Type || lineUnit.Type == unit.TypePack {
+			return nil, nil, apperror.NewValidation(
+				fmt.Sprintf("unit %q cannot be converted to the base unit %q of %q", lineUnit.Name, baseUnit.Name, item.Name)).
+				WithDetail("field", "lines").
+				WithDetail("lineNo", lineNo)
+		}
+		coef := lineUnit.ConversionFactor.DivRound(baseUnit.ConversionFactor, coefficientPlaces)
+		if !coef.IsPositive() {
+			return nil, nil, apperror.NewValidation(
+				fmt.Sprintf("unit %q is too small for the base unit %q of %q", lineUnit.Name, baseUnit.Name, item.Name)).
+				WithDetail("field", "lines").
+				WithDetail("lineNo", lineNo)
+		}
+		coefficients[i], ok[i] = coef, true
+	}
+	return coefficients, ok, nil
+}
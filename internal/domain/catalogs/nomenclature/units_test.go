@@ -0,0 +1,130 @@
+package nomenclature
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/catalogs/unit"
+)
+
+type fakeNomenclatures struct {
+	Repository
+	items map[id.ID]*Nomenclature
+}
+
+func (f *fakeNomenclatures) GetByID(_ context.Context, itemID id.ID) (*Nomenclature, error) {
+	item, ok := f.items[itemID]
+	if !ok {
+		return nil, apperror.NewNotFound("nomenclature", itemID.String())
+	}
+	return item, nil
+}
+
+type fakeUnits map[id.ID]*unit.Unit
+
+func (f fakeUnits) GetByID(_ context.Context, unitID id.ID) (*unit.Unit, error) {
+	u, ok := f[unitID]
+	if !ok {
+		return nil, apperror.NewNotFound("unit", unitID.String())
+	}
+	return u, nil
+}
+
+func newTestUnit(name string, unitType unit.UnitType, factor string) *unit.Unit {
+	u := unit.NewUnit(name, name, name, unitType)
+	u.ID = id.New()
+	u.ConversionFactor = decimal.RequireFromString(factor)
+	return u
+}
+
+func TestUnitConverterCoefficients(t *testing.T) {
+	pcs := newTestUnit("pcs", unit.TypePiece, "1")
+	box := newTestUnit("box", unit.TypePack, "1")
+	kg := newTestUnit("kg", unit.TypeWeight, "1")
+	g := newTestUnit("g", unit.TypeWeight, "0.001")
+	units := fakeUnits{pcs.ID: pcs, box.ID: box, kg.ID: kg, g.ID: g}
+
+	bolt := NewNomenclature("B1", "Bolt", TypeGoods)
+	bolt.ID = id.New()
+	bolt.BaseUnitID = &pcs.ID
+	bolt.PackagingUnits = []PackagingUnit{{UnitID: box.ID, Coefficient: decimal.NewFromInt(12)}}
+
+	flour := NewNomenclature("F1", "Flour", TypeGoods)
+	flour.ID = id.New()
+	flour.BaseUnitID = &kg.ID
+
+	misc := NewNomenclature("M1", "Misc", TypeGoods)
+	misc.ID = id.New()
+
+	conv := NewUnitConverter(&fakeNomenclatures{items: map[id.ID]*Nomenclature{
+		bolt.ID: bolt, flour.ID: flour, misc.ID: misc,
+	}}, units)
+
+	coefficients, ok, err := conv.Coefficients(context.Background(), []LineUnit{
+		{NomenclatureID: bolt.ID, UnitID: pcs.ID},
+		{NomenclatureID: bolt.ID, UnitID: box.ID},
+		{NomenclatureID: flour.ID, UnitID: g.ID},
+		{NomenclatureID: misc.ID, UnitID: box.ID},
+	})
+	if err != nil {
+		t.Fatalf("Coefficients: %v", err)
+	}
+
+	want := []string{"1", "12", "0.001", "0"}
+	wantOK := []bool{true, true, true, false}
+	for i := range want {
+		if ok[i] != wantOK[i] {
+			t.Errorf("line %d: ok = %v, want %v", i+1, ok[i], wantOK[i])
+		}
+		if !coefficients[i].Equal(decimal.RequireFromString(want[i])) {
+			t.Errorf("line %d: coefficient = %s, want %s", i+1, coefficients[i], want[i])
+		}
+	}
+
+	_, _, err = conv.Coefficients(context.Background(), []LineUnit{{NomenclatureID: bolt.ID, UnitID: kg.ID}})
+	appErr, isApp := apperror.AsAppError(err)
+	if !isApp || appErr.Code != apperror.CodeValidation {
+		t.Fatalf("kg for a piece item: err = %v, want a validation error", err)
+	}
+
+	// The error points at the document line, not the position among the
+	// lines passed in.
+	_, _, err = conv.Coefficients(context.Background(), []LineUnit{
+		{NomenclatureID: bolt.ID, UnitID: pcs.ID, LineNo: 2},
+		{NomenclatureID: bolt.ID, UnitID: kg.ID, LineNo: 5},
+	})
+	if appErr, _ := apperror.AsAppError(err); appErr == nil || appErr.Details["lineNo"] != 5 {
+		t.Fatalf("err = %v, want lineNo 5", err)
+	}
+}
+
+func TestValidatePackagingUnits(t *testing.T) {
+	pcs, box := id.New(), id.New()
+	item := NewNomenclature("B1", "Bolt", TypeGoods)
+	item.BaseUnitID = &pcs
+
+	cases := []struct {
+		name  string
+		units []PackagingUnit
+		ok    bool
+	}{
+		{"valid", []PackagingUnit{{UnitID: box, Coefficient: decimal.NewFromInt(12)}}, true},
+		{"base unit", []PackagingUnit{{UnitID: pcs, Coefficient: decimal.NewFromInt(1)}}, false},
+		{"zero coefficient", []PackagingUnit{{UnitID: box}}, false},
+		{"duplicate", []PackagingUnit{
+			{UnitID: box, Coefficient: decimal.NewFromInt(12)},
+			{UnitID: box, Coefficient: decimal.NewFromInt(24)},
+		}, false},
+	}
+	for _, tc := range cases {
+		item.PackagingUnits = tc.units
+		err := item.validatePackagingUnits()
+		if (err == nil) != tc.ok {
+			t.Errorf("%s: err = %v, want ok = %v", tc.name, err, tc.ok)
+		}
+	}
+}
@@ -79,3 +79,9 @@ func NewOrganization(code, name string, baseCurrencyID id.ID) *Organization {
 func (o *Organization) Validate(ctx context.Context) error {
 	return o.Catalog.Validate(ctx)
 }
+
+// GetRLSDimensions implements security.RLSDimensionable.
+// An organization is the organization dimension itself.
+func (o *Organization) GetRLSDimensions() map[string]string {
+	return map[string]string{"organization": o.ID.String()}
+}
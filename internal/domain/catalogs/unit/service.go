@@ -2,8 +2,6 @@ package unit
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"metapus/internal/core/apperror"
 	"metapus/internal/core/id"
@@ -48,9 +46,9 @@ func NewService(
 func (s *Service) prepareForCreate(ctx context.Context, unit *Unit) error {
 	// Generate code if not provided
 	if unit.Code == "" {
-		code, err := s.numerator.GetNextNumber(ctx, numerator.DefaultConfig("UN"), nil, time.Now())
+		code, err := s.GenerateCode(ctx, unit.Name, "UN")
 		if err != nil {
-			return fmt.Errorf("generate code: %w", err)
+			return err
 		}
 		unit.Code = code
 	}
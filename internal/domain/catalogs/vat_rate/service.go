@@ -2,8 +2,6 @@ package vat_rate
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"github.com/shopspring/decimal"
 
@@ -50,9 +48,9 @@ func NewService(
 func (s *Service) prepareForCreate(ctx context.Context, vr *VATRate) error {
 	// Generate code if not provided
 	if vr.Code == "" {
-		code, err := s.numerator.GetNextNumber(ctx, numerator.DefaultConfig("VR"), nil, time.Now())
+		code, err := s.GenerateCode(ctx, vr.Name, "VR")
 		if err != nil {
-			return fmt.Errorf("generate code: %w", err)
+			return err
 		}
 		vr.Code = code
 	}
@@ -73,6 +73,17 @@ func (w *Warehouse) Validate(ctx context.Context) error {
 	return nil
 }
 
+// GetRLSDimensions implements security.RLSDimensionable.
+// A warehouse belongs to the organization that owns it; warehouses without an
+// owner are visible to unrestricted users only, as in list queries.
+// The warehouse dimension is the warehouse itself.
+func (w *Warehouse) GetRLSDimensions() map[string]string {
+	return map[string]string{
+		"organization": w.OrganizationID.String(),
+		"warehouse":    w.ID.String(),
+	}
+}
+
 // CanAcceptStock returns true if warehouse can accept stock.
 func (w *Warehouse) CanAcceptStock() bool {
 	return w.IsActive && !w.IsFolder
@@ -2,8 +2,6 @@ package warehouse
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"metapus/internal/core/numerator"
 	"metapus/internal/domain"
@@ -47,9 +45,9 @@ func NewService(
 func (s *Service) prepareForCreate(ctx context.Context, wh *Warehouse) error {
 	// Generate code if not provided
 	if wh.Code == "" {
-		code, err := s.numerator.GetNextNumber(ctx, numerator.DefaultConfig("WH"), nil, time.Now())
+		code, err := s.GenerateCode(ctx, wh.Name, "WH")
 		if err != nil {
-			return fmt.Errorf("generate code: %w", err)
+			return err
 		}
 		wh.Code = code
 	}
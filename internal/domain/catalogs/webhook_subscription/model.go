@@ -0,0 +1,102 @@
+// Package webhook_subscription provides the WebhookSubscription catalog.
+// A subscription receives the outbox events matching its event filters as
+// signed HTTP POSTs (see package webhooks for the delivery pipeline).
+package webhook_subscription
+
+import (
+	"context"
+	"strings"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/urlsafe"
+)
+
+// MinSecretLength is the shortest accepted signing secret.
+const MinSecretLength = 16
+
+// WebhookSubscription is an external endpoint subscribed to events.
+type WebhookSubscription struct {
+	entity.Catalog
+
+	// URL is the HTTPS endpoint events are POSTed to
+	URL string `db:"url" json:"url" meta:"label:URL"`
+
+	// Secret is the HMAC-SHA256 signing key of X-Metapus-Signature.
+	// Never returned by the API.
+	Secret string `db:"secret" json:"-" meta:"label:Секрет"`
+
+	// EventTypes filters the delivered events: an exact type
+	// ("GoodsReceiptPosted"), a prefix pattern ("invoice.*") or "*".
+	// Empty means all events.
+	EventTypes []string `db:"event_types" json:"eventTypes" meta:"label:События"`
+
+	// IsActive pauses deliveries when false
+	IsActive bool `db:"is_active" json:"isActive" meta:"label:Активна"`
+}
+
+// NewWebhookSubscription creates a new active WebhookSubscription.
+func NewWebhookSubscription(code, name, url, secret string, eventTypes []string) *WebhookSubscription {
+	return &WebhookSubscription{
+		Catalog:    entity.NewCatalog(code, name),
+		URL:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		IsActive:   true,
+	}
+}
+
+// Validate implements entity.Validatable interface.
+func (s *WebhookSubscription) Validate(ctx context.Context) error {
+	// Base catalog validation
+	if err := s.Catalog.Validate(ctx); err != nil {
+		return err
+	}
+
+	if s.URL == "" {
+		return apperror.NewValidation("url is required").
+			WithDetail("field", "url")
+	}
+	// HTTPS only, no private or internal hosts (SSRF)
+	if err := urlsafe.ValidatePublicURL(s.URL, "url"); err != nil {
+		return err
+	}
+
+	if len(s.Secret) < MinSecretLength {
+		return apperror.NewValidation("secret must be at least 16 characters").
+			WithDetail("field", "secret")
+	}
+
+	for _, pattern := range s.EventTypes {
+		if strings.TrimSpace(pattern) == "" {
+			return apperror.NewValidation("event filter must not be empty").
+				WithDetail("field", "eventTypes")
+		}
+		if i := strings.Index(pattern, "*"); i >= 0 && i != len(pattern)-1 {
+			return apperror.NewValidation("'*' is only allowed at the end of an event filter").
+				WithDetail("field", "eventTypes").
+				WithDetail("value", pattern)
+		}
+	}
+
+	return nil
+}
+
+// Matches reports whether the subscription receives events of eventType.
+func (s *WebhookSubscription) Matches(eventType string) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, pattern := range s.EventTypes {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(eventType, prefix) {
+				return true
+			}
+			continue
+		}
+		if pattern == eventType {
+			return true
+		}
+	}
+	return false
+}
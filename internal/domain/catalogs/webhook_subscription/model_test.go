@@ -0,0 +1,53 @@
+package webhook_subscription
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWebhookSubscriptionMatches(t *testing.T) {
+	tests := []struct {
+		give   []string
+		event  string
+		wantOK bool
+	}{
+		{nil, "GoodsReceiptPosted", true},
+		{[]string{"*"}, "GoodsReceiptPosted", true},
+		{[]string{"GoodsReceiptPosted"}, "GoodsReceiptPosted", true},
+		{[]string{"GoodsReceiptPosted"}, "GoodsReceiptUnposted", false},
+		{[]string{"GoodsReceipt*"}, "GoodsReceiptUnposted", true},
+		{[]string{"invoice.*", "GoodsIssuePosted"}, "invoice.paid", true},
+		{[]string{"invoice.*"}, "invoices", false},
+	}
+	for _, tt := range tests {
+		s := &WebhookSubscription{EventTypes: tt.give}
+		if got := s.Matches(tt.event); got != tt.wantOK {
+			t.Errorf("%v.Matches(%q) = %v, want %v", tt.give, tt.event, got, tt.wantOK)
+		}
+	}
+}
+
+func TestWebhookSubscriptionValidate(t *testing.T) {
+	valid := func() *WebhookSubscription {
+		// An IP literal keeps the URL check off the network.
+		return NewWebhookSubscription("WH-1", "ERP", "https://93.184.216.34/hooks", "whsec_0123456789abcdef", []string{"invoice.*"})
+	}
+	if err := valid().Validate(context.Background()); err != nil {
+		t.Fatalf("valid subscription: %v", err)
+	}
+
+	tests := map[string]func(*WebhookSubscription){
+		"http url":       func(s *WebhookSubscription) { s.URL = "http://93.184.216.34/hooks" },
+		"private url":    func(s *WebhookSubscription) { s.URL = "https://10.0.0.1/hooks" },
+		"short secret":   func(s *WebhookSubscription) { s.Secret = "short" },
+		"inner wildcard": func(s *WebhookSubscription) { s.EventTypes = []string{"invoice.*.paid"} },
+		"empty filter":   func(s *WebhookSubscription) { s.EventTypes = []string{" "} },
+	}
+	for name, mutate := range tests {
+		s := valid()
+		mutate(s)
+		if err := s.Validate(context.Background()); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
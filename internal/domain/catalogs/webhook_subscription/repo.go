@@ -0,0 +1,15 @@
+package webhook_subscription
+
+import (
+	"context"
+
+	"metapus/internal/domain"
+)
+
+// Repository defines the interface for WebhookSubscription persistence.
+type Repository interface {
+	domain.CatalogRepository[*WebhookSubscription]
+
+	// ListActive retrieves the active, not deletion-marked subscriptions.
+	ListActive(ctx context.Context) ([]*WebhookSubscription, error)
+}
@@ -0,0 +1,57 @@
+package webhook_subscription
+
+import (
+	"context"
+
+	"metapus/internal/core/numerator"
+	"metapus/internal/domain"
+)
+
+// Service provides business logic for WebhookSubscription catalog.
+// Uses composition with domain.CatalogService for common CRUD operations.
+type Service struct {
+	*domain.CatalogService[*WebhookSubscription] // Embedded for delegation
+	repo                                         Repository
+}
+
+// NewService creates a new WebhookSubscription service.
+// In Database-per-Tenant, TxManager is obtained from context.
+func NewService(
+	repo Repository,
+	numerator numerator.Generator,
+) *Service {
+	base := domain.NewCatalogService(domain.CatalogServiceConfig[*WebhookSubscription]{
+		Repo:       repo,
+		TxManager:  nil, // Will be obtained from context
+		Numerator:  numerator,
+		EntityName: "webhook_subscription",
+	})
+
+	svc := &Service{
+		CatalogService: base,
+		repo:           repo,
+	}
+
+	base.Hooks().OnBeforeCreate(svc.prepareForCreate)
+
+	return svc
+}
+
+// prepareForCreate handles code generation.
+func (s *Service) prepareForCreate(ctx context.Context, sub *WebhookSubscription) error {
+	if sub.Code == "" {
+		code, err := s.GenerateCode(ctx, sub.Name, "WH")
+		if err != nil {
+			return err
+		}
+		sub.Code = code
+	}
+	return nil
+}
+
+// --- Entity-specific methods ---
+
+// ListActive retrieves the subscriptions that receive deliveries.
+func (s *Service) ListActive(ctx context.Context) ([]*WebhookSubscription, error) {
+	return s.repo.ListActive(ctx)
+}
@@ -4,16 +4,14 @@ package crypto
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
 	"crypto/tls"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"time"
 
+	corecrypto "metapus/internal/core/crypto"
 	"metapus/internal/core/id"
 	"metapus/internal/core/urlsafe"
 	"metapus/pkg/logger"
@@ -104,9 +102,26 @@ func createPinnedClient(resolved *urlsafe.ResolvedURL) *http.Client {
 	}
 }
 
-// Dispatch sends a webhook event to the given URL with HMAC signature and records the delivery.
-// webhookSecret is the merchant's webhook signing key.
-// Returns the persisted WebhookDelivery record (always non-nil on non-repo error).
+// WebhookRequest is a single signed webhook POST.
+type WebhookRequest struct {
+	URL        string
+	Secret     string    // HMAC signing key
+	Event      string    // X-Metapus-Event
+	DeliveryID string    // X-Metapus-Delivery-ID, stable across retries
+	Body       []byte    // JSON body, signed as is
+	Timestamp  time.Time // X-Metapus-Timestamp, signed with the body
+}
+
+// WebhookResponse is the outcome of a webhook POST that was sent.
+type WebhookResponse struct {
+	StatusCode     int // 0 when no HTTP response was received
+	ResponseTimeMs int
+}
+
+// Send POSTs a signed webhook request. It returns a nil response when the
+// request could not be sent at all (URL failed validation); otherwise the
+// response is non-nil and the error reports a transport failure or a non-2xx
+// status.
 //
 // SSRF-safe: resolves DNS once via ResolvePublicURL, then uses a pinned-IP
 // HTTP client — Go's HTTP client never performs its own DNS lookup.
@@ -116,6 +131,53 @@ func createPinnedClient(resolved *urlsafe.ResolvedURL) *http.Client {
 //   - X-Metapus-Signature: HMAC-SHA256(timestamp + "." + payload, secret) (Stripe-pattern)
 //   - X-Metapus-Timestamp: RFC3339 timestamp (include in HMAC to prevent replay)
 //   - X-Metapus-Delivery-ID: unique delivery ID for idempotency
+func (d *WebhookDispatcher) Send(ctx context.Context, r WebhookRequest) (*WebhookResponse, error) {
+	// Resolve DNS once + validate IP — eliminates DNS rebinding TOCTOU (CWE-367).
+	resolved, err := urlsafe.ResolvePublicURL(r.URL, "webhookUrl")
+	if err != nil {
+		logger.Error(ctx, "webhook URL failed validation at dispatch time",
+			"url", r.URL,
+			"error", err,
+		)
+		return nil, fmt.Errorf("webhook URL validation: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(r.Body))
+	if err != nil {
+		return nil, fmt.Errorf("create webhook request: %w", err)
+	}
+
+	// HMAC-SHA256 signature (Stripe-pattern: includes timestamp to prevent replay)
+	timestamp := r.Timestamp.UTC().Format(time.RFC3339)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Metapus-Event", r.Event)
+	req.Header.Set("X-Metapus-Signature", corecrypto.SignWebhook(r.Body, r.Secret, timestamp))
+	req.Header.Set("X-Metapus-Timestamp", timestamp)
+	req.Header.Set("X-Metapus-Delivery-ID", r.DeliveryID)
+
+	// Use pinned-IP client — no second DNS lookup.
+	pinnedClient := createPinnedClient(resolved)
+
+	// Measure response time.
+	start := time.Now()
+	resp, err := pinnedClient.Do(req)
+	result := &WebhookResponse{ResponseTimeMs: int(time.Since(start).Milliseconds())}
+	if err != nil {
+		return result, fmt.Errorf("webhook delivery: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode >= 300 {
+		return result, fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+	}
+	return result, nil
+}
+
+// Dispatch sends a webhook event to the given URL with HMAC signature and records the delivery.
+// webhookSecret is the merchant's webhook signing key.
+// Returns the persisted WebhookDelivery record (always non-nil on non-repo error).
+// See Send for the request format.
 func (d *WebhookDispatcher) Dispatch(
 	ctx context.Context,
 	deliveryRepo WebhookDeliveryRepository,
@@ -127,16 +189,6 @@ func (d *WebhookDispatcher) Dispatch(
 	data map[string]any,
 	attempt int,
 ) (*WebhookDelivery, error) {
-	// Resolve DNS once + validate IP — eliminates DNS rebinding TOCTOU (CWE-367).
-	resolved, err := urlsafe.ResolvePublicURL(webhookURL, "webhookUrl")
-	if err != nil {
-		logger.Error(ctx, "webhook URL failed validation at dispatch time",
-			"url", webhookURL,
-			"error", err,
-		)
-		return nil, fmt.Errorf("webhook URL validation: %w", err)
-	}
-
 	payload := WebhookPayload{
 		Event:     event,
 		Timestamp: time.Now().UTC(),
@@ -148,30 +200,19 @@ func (d *WebhookDispatcher) Dispatch(
 		return nil, fmt.Errorf("marshal webhook payload: %w", err)
 	}
 
-	// HMAC-SHA256 signature (Stripe-pattern: includes timestamp to prevent replay)
-	timestampStr := payload.Timestamp.Format(time.RFC3339)
-	signature := d.sign(body, webhookSecret, timestampStr)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
-	if err != nil {
-		return nil, fmt.Errorf("create webhook request: %w", err)
-	}
-
 	deliveryIDStr := id.New().String()
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-Metapus-Event", string(event))
-	req.Header.Set("X-Metapus-Signature", signature)
-	req.Header.Set("X-Metapus-Timestamp", payload.Timestamp.Format(time.RFC3339))
-	req.Header.Set("X-Metapus-Delivery-ID", deliveryIDStr)
-
-	// Use pinned-IP client — no second DNS lookup.
-	pinnedClient := createPinnedClient(resolved)
-
-	// Measure response time.
-	start := time.Now()
-	resp, httpErr := pinnedClient.Do(req)
-	elapsed := int(time.Since(start).Milliseconds())
+	resp, sendErr := d.Send(ctx, WebhookRequest{
+		URL:        webhookURL,
+		Secret:     webhookSecret,
+		Event:      string(event),
+		DeliveryID: deliveryIDStr,
+		Body:       body,
+		Timestamp:  payload.Timestamp,
+	})
+	if resp == nil {
+		return nil, sendErr
+	}
 
 	// Build delivery record (always persisted, success or failure).
 	delivery := &WebhookDelivery{
@@ -181,14 +222,18 @@ func (d *WebhookDispatcher) Dispatch(
 		EventType:      event,
 		WebhookURL:     webhookURL,
 		DeliveryID:     deliveryIDStr,
-		ResponseTimeMs: &elapsed,
+		ResponseTimeMs: &resp.ResponseTimeMs,
 		Attempt:        attempt,
 		RequestBody:    body,
 		CreatedAt:      time.Now().UTC(),
 	}
+	if resp.StatusCode != 0 {
+		delivery.StatusCode = &resp.StatusCode
+	}
 
-	if httpErr != nil {
-		errMsg := httpErr.Error()
+	switch {
+	case sendErr != nil && resp.StatusCode == 0:
+		errMsg := sendErr.Error()
 		delivery.ErrorMessage = &errMsg
 
 		logger.Warn(ctx, "webhook delivery failed",
@@ -196,31 +241,26 @@ func (d *WebhookDispatcher) Dispatch(
 			"event", event,
 			"delivery_id", deliveryIDStr,
 			"attempt", attempt,
-			"error", httpErr,
+			"error", sendErr,
 		)
-	} else {
-		defer func() { _ = resp.Body.Close() }()
-		delivery.StatusCode = &resp.StatusCode
-
-		if resp.StatusCode >= 300 {
-			errMsg := fmt.Sprintf("HTTP %d", resp.StatusCode)
-			delivery.ErrorMessage = &errMsg
+	case sendErr != nil:
+		errMsg := fmt.Sprintf("HTTP %d", resp.StatusCode)
+		delivery.ErrorMessage = &errMsg
 
-			logger.Warn(ctx, "webhook received non-2xx response",
-				"url", webhookURL,
-				"event", event,
-				"delivery_id", deliveryIDStr,
-				"attempt", attempt,
-				"status", resp.StatusCode,
-			)
-		} else {
-			logger.Info(ctx, "webhook delivered",
-				"url", webhookURL,
-				"event", event,
-				"delivery_id", deliveryIDStr,
-				"attempt", attempt,
-			)
-		}
+		logger.Warn(ctx, "webhook received non-2xx response",
+			"url", webhookURL,
+			"event", event,
+			"delivery_id", deliveryIDStr,
+			"attempt", attempt,
+			"status", resp.StatusCode,
+		)
+	default:
+		logger.Info(ctx, "webhook delivered",
+			"url", webhookURL,
+			"event", event,
+			"delivery_id", deliveryIDStr,
+			"attempt", attempt,
+		)
 	}
 
 	// Persist the delivery record.
@@ -234,23 +274,5 @@ func (d *WebhookDispatcher) Dispatch(
 		}
 	}
 
-	if httpErr != nil {
-		return delivery, fmt.Errorf("webhook delivery: %w", httpErr)
-	}
-	if resp.StatusCode >= 300 {
-		return delivery, fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
-	}
-
-	return delivery, nil
-}
-
-// sign creates an HMAC-SHA256 signature for the payload.
-// Includes timestamp in the signed data to prevent replay attacks (Stripe-pattern).
-// Merchant verification: HMAC-SHA256(timestamp + "." + body, secret)
-func (d *WebhookDispatcher) sign(payload []byte, secret string, timestamp string) string {
-	mac := hmac.New(sha256.New, []byte(secret))
-	mac.Write([]byte(timestamp))
-	mac.Write([]byte("."))
-	mac.Write(payload)
-	return hex.EncodeToString(mac.Sum(nil))
+	return delivery, sendErr
 }
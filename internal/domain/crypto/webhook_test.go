@@ -2,9 +2,6 @@
 package crypto
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"testing"
 )
 
@@ -51,45 +48,3 @@ func TestValidateWebhookURL(t *testing.T) {
 		})
 	}
 }
-
-func TestWebhookSign_IncludesTimestamp(t *testing.T) {
-	d := NewWebhookDispatcher()
-	payload := []byte(`{"event":"invoice.paid","data":{}}`)
-	secret := "merchant-webhook-secret-key"
-
-	sig1 := d.sign(payload, secret, "2026-05-06T12:00:00Z")
-	sig2 := d.sign(payload, secret, "2026-05-06T12:01:00Z")
-
-	// Same payload + different timestamp → different HMAC (replay-resistant)
-	if sig1 == sig2 {
-		t.Error("sign() should produce different signatures for different timestamps (replay protection)")
-	}
-
-	// Same inputs → deterministic
-	sig3 := d.sign(payload, secret, "2026-05-06T12:00:00Z")
-	if sig1 != sig3 {
-		t.Error("sign() should be deterministic for same inputs")
-	}
-}
-
-func TestWebhookSign_MerchantVerification(t *testing.T) {
-	// Simulate what a merchant would do to verify our webhook
-	d := NewWebhookDispatcher()
-	payload := []byte(`{"event":"invoice.confirmed","data":{"invoiceId":"abc-123"}}`)
-	secret := "merchant-secret"
-	timestamp := "2026-05-06T15:30:00Z"
-
-	// Metapus generates signature
-	signature := d.sign(payload, secret, timestamp)
-
-	// Merchant verifies: HMAC-SHA256(timestamp + "." + body, secret)
-	mac := hmac.New(sha256.New, []byte(secret))
-	mac.Write([]byte(timestamp))
-	mac.Write([]byte("."))
-	mac.Write(payload)
-	expected := hex.EncodeToString(mac.Sum(nil))
-
-	if signature != expected {
-		t.Errorf("merchant verification failed: got %s, want %s", signature, expected)
-	}
-}
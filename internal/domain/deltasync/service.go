@@ -0,0 +1,233 @@
+// Package deltasync serves differential sync for offline-first clients.
+//
+// A client pulls the changes of an entity since its sync token in ordered
+// batches: created and updated rows with their payload, and the ids of
+// deleted ones. Changed rows are found by the _txid CDC column every entity
+// table has; hard deletions leave tombstones (sys_sync_tombstones). Local
+// edits are pushed back through the entity's own update path, so the
+// optimistic lock (version) decides conflicts.
+package deltasync
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/clock"
+	"metapus/internal/core/id"
+	"metapus/internal/core/security"
+	"metapus/internal/metadata"
+)
+
+const (
+	// DefaultLimit is the batch size when the client asks for none.
+	DefaultLimit = 200
+	// MaxLimit caps the batch size.
+	MaxLimit = 1000
+
+	// TokenTTL is how long a client may stay offline. Older tokens are
+	// refused: tombstones of deletions since then may be gone, so the
+	// client must sync from scratch.
+	TokenTTL = 30 * 24 * time.Hour
+	// TombstoneRetention is how long tombstones are kept (TokenTTL plus a
+	// margin for transactions that ran across the start of a pass).
+	TombstoneRetention = TokenTTL + 24*time.Hour
+)
+
+// CodeTokenExpired is returned for tokens older than TokenTTL.
+const CodeTokenExpired = "SYNC_TOKEN_EXPIRED"
+
+// Change operations.
+const (
+	OpCreated = "created"
+	OpUpdated = "updated"
+	OpDeleted = "deleted"
+)
+
+// ScopeCondition restricts rows to those whose column holds one of Values.
+type ScopeCondition struct {
+	Column string
+	Values []string
+}
+
+// Query selects changes of one table after a position of the change stream.
+type Query struct {
+	Table   string
+	Since   uint64 // lowest txid of the pass
+	AfterTx uint64 // position within the pass (AfterID zero: from the start)
+	AfterID id.ID
+	Scope   []ScopeCondition
+	Limit   int
+}
+
+// Change is a changed or deleted row as read from storage.
+type Change struct {
+	TxID    uint64
+	ID      id.ID
+	Deleted bool
+	Row     map[string]any // column → value; nil when Deleted
+}
+
+// Store reads the change stream.
+type Store interface {
+	// SnapshotXmin returns the oldest transaction still running: changes of
+	// every older transaction are committed and visible.
+	SnapshotXmin(ctx context.Context) (uint64, error)
+
+	// Changes returns changed rows and tombstones ordered by (txid, id).
+	Changes(ctx context.Context, q Query) ([]Change, error)
+}
+
+// Item is one change delivered to the client.
+type Item struct {
+	Op      string         `json:"op"`
+	ID      string         `json:"id"`
+	Version int64          `json:"version,omitempty"`
+	Data    map[string]any `json:"data,omitempty"`
+}
+
+// Batch is a page of changes.
+type Batch struct {
+	Items []Item `json:"items"`
+	// Token is passed to the next pull. It is issued even when the batch is
+	// empty, so clients always store the last one.
+	Token string `json:"token"`
+	// HasMore is set while the current pass has further changes; clients
+	// pull again at once instead of waiting for their next sync.
+	HasMore bool `json:"hasMore"`
+}
+
+// Service serves change batches.
+type Service struct {
+	store Store
+}
+
+// NewService creates a sync service over store.
+func NewService(store Store) *Service {
+	return &Service{store: store}
+}
+
+// Pull returns up to limit changes of entity since rawToken.
+// Row-level security restricts the rows, field-level security masks their
+// fields the same way as the entity's list endpoint.
+func (s *Service) Pull(ctx context.Context, def *metadata.EntityDef, rawToken string, limit int) (*Batch, error) {
+	tok, err := DecodeToken(rawToken, def.Key)
+	if err != nil {
+		return nil, err
+	}
+	now := clock.Now(ctx)
+	if tok.expired(now) {
+		return nil, apperror.NewBusinessRule(CodeTokenExpired, "sync token expired, sync from scratch")
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	if !tok.inPass() {
+		// A new pass: fix the floor of the next one before reading.
+		floor, err := s.store.SnapshotXmin(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tok.Floor, tok.FloorAt = floor, now.Unix()
+	}
+
+	q := Query{Table: def.TableName, Since: tok.Since, AfterTx: tok.AfterTx, Limit: limit + 1}
+	if tok.inPass() {
+		q.AfterID, _ = id.Parse(tok.AfterID) // validated by DecodeToken
+	}
+	var ok bool
+	if q.Scope, ok = scope(ctx, def); !ok {
+		// No row is visible: the pass is complete and empty.
+		return &Batch{Items: []Item{}, Token: nextPass(tok).Encode()}, nil
+	}
+
+	changes, err := s.store.Changes(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	batch := &Batch{Items: make([]Item, 0, min(len(changes), limit))}
+	if len(changes) > limit {
+		changes = changes[:limit]
+		batch.HasMore = true
+	}
+
+	policy := security.GetFieldPolicy(ctx, def.Key, "read")
+	for _, ch := range changes {
+		batch.Items = append(batch.Items, item(ch, tok.Since == 0, policy))
+	}
+
+	if batch.HasMore {
+		last := changes[len(changes)-1]
+		tok.AfterTx, tok.AfterID = last.TxID, last.ID.String()
+		batch.Token = tok.Encode()
+	} else {
+		batch.Token = nextPass(tok).Encode()
+	}
+	return batch, nil
+}
+
+// nextPass returns the token of the pass after tok.
+func nextPass(tok Token) Token {
+	return Token{Entity: tok.Entity, Since: tok.Floor, SinceAt: tok.FloorAt}
+}
+
+// item converts a stored change. Fields the policy hides are cleared.
+func item(ch Change, initial bool, policy *security.FieldPolicy) Item {
+	it := Item{ID: ch.ID.String()}
+	if ch.Deleted {
+		it.Op = OpDeleted
+		return it
+	}
+	it.Version = rowVersion(ch.Row)
+	it.Op = OpUpdated
+	if initial || it.Version == 1 {
+		it.Op = OpCreated
+	}
+	if policy != nil {
+		for col := range ch.Row {
+			if col != "id" && col != "version" && !policy.IsFieldAllowed(col) {
+				ch.Row[col] = nil
+			}
+		}
+	}
+	it.Data = ch.Row
+	return it
+}
+
+func rowVersion(row map[string]any) int64 {
+	switch v := row["version"].(type) {
+	case json.Number:
+		n, _ := v.Int64()
+		return n
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
+
+// scope returns the RLS conditions of the caller for def. ok is false when
+// the caller has no access to any row of the entity.
+func scope(ctx context.Context, def *metadata.EntityDef) (conds []ScopeCondition, ok bool) {
+	ds := security.GetDataScope(ctx)
+	if ds == nil || ds.IsAdmin {
+		return nil, true
+	}
+	if !ds.GrantsCovered(def.Key, def.RLSDimensions) {
+		return nil, false
+	}
+	effective := ds.EffectiveDimensions(def.Key)
+	for dim, col := range def.RLSDimensions {
+		allowed, restricted := effective[dim]
+		if !restricted {
+			continue
+		}
+		if len(allowed) == 0 {
+			return nil, false
+		}
+		conds = append(conds, ScopeCondition{Column: col, Values: allowed})
+	}
+	return conds, true
+}
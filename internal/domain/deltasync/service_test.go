@@ -0,0 +1,100 @@
+package deltasync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/clock"
+	"metapus/internal/core/id"
+	"metapus/internal/metadata"
+)
+
+// memoryStore serves a fixed change stream, honouring the query cursor.
+type memoryStore struct {
+	xmin    uint64
+	changes []Change // ordered by (TxID, ID)
+}
+
+func (s *memoryStore) SnapshotXmin(context.Context) (uint64, error) {
+	return s.xmin, nil
+}
+
+func (s *memoryStore) Changes(_ context.Context, q Query) ([]Change, error) {
+	var out []Change
+	for _, ch := range s.changes {
+		if ch.TxID < q.Since {
+			continue
+		}
+		if !id.IsNil(q.AfterID) && (ch.TxID < q.AfterTx || ch.TxID == q.AfterTx && ch.ID.String() <= q.AfterID.String()) {
+			continue
+		}
+		out = append(out, ch)
+		if len(out) == q.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func row(version int) map[string]any {
+	return map[string]any{"version": json.Number(strconv.Itoa(version))}
+}
+
+func TestPull(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	ctx := clock.WithClock(context.Background(), clock.NewMock(now))
+	def := &metadata.EntityDef{Key: "counterparty", TableName: "cat_counterparties"}
+
+	a := id.MustParse("00000000-0000-0000-0000-00000000000a")
+	b := id.MustParse("00000000-0000-0000-0000-00000000000b")
+	c := id.MustParse("00000000-0000-0000-0000-00000000000c")
+	store := &memoryStore{xmin: 20, changes: []Change{
+		{TxID: 10, ID: a, Row: row(1)},
+		{TxID: 10, ID: b, Row: row(2)},
+		{TxID: 12, ID: c, Deleted: true},
+	}}
+	svc := NewService(store)
+
+	// Initial sync in pages of two: everything is "created".
+	first, err := svc.Pull(ctx, def, "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Items) != 2 || !first.HasMore || first.Items[1].Op != OpCreated {
+		t.Fatalf("first batch = %+v", first)
+	}
+	second, err := svc.Pull(ctx, def, first.Token, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second.Items) != 1 || second.HasMore || second.Items[0].Op != OpDeleted || second.Items[0].ID != c.String() {
+		t.Fatalf("second batch = %+v", second)
+	}
+
+	// The next pass starts at the floor of the previous one.
+	store.changes = append(store.changes, Change{TxID: 21, ID: b, Row: row(3)})
+	store.xmin = 30
+	third, err := svc.Pull(ctx, def, second.Token, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(third.Items) != 1 || third.Items[0].Op != OpUpdated || third.Items[0].Version != 3 {
+		t.Fatalf("third batch = %+v", third)
+	}
+
+	// Tokens are bound to their entity and expire.
+	if _, err := svc.Pull(ctx, &metadata.EntityDef{Key: "nomenclature"}, third.Token, 2); err == nil {
+		t.Error("token of another entity accepted")
+	}
+	later := clock.WithClock(context.Background(), clock.NewMock(now.Add(TokenTTL+time.Hour)))
+	_, err = svc.Pull(later, def, third.Token, 2)
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) || appErr.Code != CodeTokenExpired {
+		t.Errorf("expired token: err = %v", err)
+	}
+}
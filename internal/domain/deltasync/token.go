@@ -0,0 +1,68 @@
+package deltasync
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+)
+
+// Token is the position of a client in the change stream of one entity.
+// It is issued by the server and echoed back opaque (see Encode).
+//
+// Changes are read in passes ordered by (txid, id). A pass returns every
+// change with txid >= Since; its Floor — the oldest transaction still
+// running when the pass started — becomes Since of the next pass. Changes of
+// transactions that commit while a pass is read are therefore delivered by
+// the next pass at the latest, and may be delivered twice: clients apply
+// changes idempotently by id and version.
+type Token struct {
+	Entity  string `json:"e"`
+	Since   uint64 `json:"s"`
+	Floor   uint64 `json:"f"`
+	AfterTx uint64 `json:"t,omitempty"` // last change returned within the pass
+	AfterID string `json:"i,omitempty"`
+	SinceAt int64  `json:"a"` // unix time Since was taken as a floor
+	FloorAt int64  `json:"b"` // unix time Floor was taken
+}
+
+// inPass reports whether the token points inside an unfinished pass.
+func (t Token) inPass() bool {
+	return t.AfterID != ""
+}
+
+// Encode returns the opaque form of t.
+func (t Token) Encode() string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeToken parses a token issued for entity. An empty string is the
+// token of a client that has nothing yet.
+func DecodeToken(raw, entity string) (Token, error) {
+	if raw == "" {
+		return Token{Entity: entity}, nil
+	}
+	invalid := apperror.NewValidation("invalid sync token").WithDetail("field", "token")
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return Token{}, invalid
+	}
+	var t Token
+	if err := json.Unmarshal(data, &t); err != nil || t.Entity != entity {
+		return Token{}, invalid
+	}
+	if t.inPass() {
+		if _, err := id.Parse(t.AfterID); err != nil {
+			return Token{}, invalid
+		}
+	}
+	return t, nil
+}
+
+// expired reports whether deletions since the token may have been purged.
+func (t Token) expired(now time.Time) bool {
+	return t.Since > 0 && now.Sub(time.Unix(t.SinceAt, 0)) > TokenTTL
+}
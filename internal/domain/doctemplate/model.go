@@ -0,0 +1,145 @@
+// Package doctemplate provides domain logic for document templates (presets).
+// A template is a skeleton of a frequently used document: header defaults and
+// lines without quantities. New documents are created from it by supplying
+// the date and line quantities.
+package doctemplate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/core/apperror"
+)
+
+// Visibility defines who can see and use a template.
+type Visibility string
+
+const (
+	VisibilityPersonal Visibility = "personal" // author only
+	VisibilityShared   Visibility = "shared"   // all users of the tenant
+)
+
+// Template is a saved document skeleton for a document type.
+// Payload is the create request of the document type with instance-specific
+// fields and line quantities removed.
+type Template struct {
+	ID           uuid.UUID       `json:"id" db:"id"`
+	DocumentType string          `json:"documentType" db:"document_type"`
+	Name         string          `json:"name" db:"name"`
+	AuthorID     *uuid.UUID      `json:"authorId" db:"author_id"`
+	Visibility   Visibility      `json:"visibility" db:"visibility"`
+	Payload      json.RawMessage `json:"payload" db:"payload"`
+	DeletionMark bool            `json:"deletionMark" db:"deletion_mark"`
+	Version      int             `json:"version" db:"version"`
+	CreatedAt    time.Time       `json:"createdAt" db:"created_at"`
+	UpdatedAt    time.Time       `json:"updatedAt" db:"updated_at"`
+}
+
+// Validate checks basic integrity of the template. Pure function, no DB calls.
+func (t *Template) Validate(_ context.Context) error {
+	if t.DocumentType == "" {
+		return apperror.NewValidation("validation failed").WithDetail("documentType", "required")
+	}
+	if t.Name == "" {
+		return apperror.NewValidation("validation failed").WithDetail("name", "required")
+	}
+	if t.Visibility != VisibilityPersonal && t.Visibility != VisibilityShared {
+		return apperror.NewValidation("validation failed").WithDetail("visibility", "invalid visibility type")
+	}
+	if len(t.Payload) == 0 {
+		return apperror.NewValidation("validation failed").WithDetail("payload", "required")
+	}
+	return nil
+}
+
+// instanceFields are header fields that belong to a concrete document
+// and are never stored in a template.
+var instanceFields = []string{
+	"number", "date", "postImmediately",
+	"basisType", "basisId",
+	"supplierDocNumber", "supplierDocDate", "incomingNumber",
+}
+
+// instanceLineFields are line fields that are never stored in a template.
+var instanceLineFields = []string{"lineId", "quantity", "orderLineId"}
+
+// BuildPayload turns a document create request (JSON object) into a template
+// payload: instance-specific header fields and line quantities are removed.
+func BuildPayload(request json.RawMessage) (json.RawMessage, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(request, &obj); err != nil {
+		return nil, fmt.Errorf("decode document request: %w", err)
+	}
+	for _, f := range instanceFields {
+		delete(obj, f)
+	}
+
+	lines, err := decodeLines(obj)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		for _, f := range instanceLineFields {
+			delete(line, f)
+		}
+	}
+	if lines != nil {
+		if obj["lines"], err = json.Marshal(lines); err != nil {
+			return nil, fmt.Errorf("encode template lines: %w", err)
+		}
+	}
+
+	return json.Marshal(obj)
+}
+
+// Instantiate builds a document create request from a template payload.
+// quantities are matched to template lines by index; lines without a
+// quantity (missing or null) are left out of the new document.
+func Instantiate(payload json.RawMessage, date time.Time, quantities []json.RawMessage) (json.RawMessage, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &obj); err != nil {
+		return nil, fmt.Errorf("decode template payload: %w", err)
+	}
+
+	var err error
+	if obj["date"], err = json.Marshal(date); err != nil {
+		return nil, fmt.Errorf("encode date: %w", err)
+	}
+
+	lines, err := decodeLines(obj)
+	if err != nil {
+		return nil, err
+	}
+	if lines != nil {
+		filled := make([]map[string]json.RawMessage, 0, len(lines))
+		for i, line := range lines {
+			if i >= len(quantities) || len(quantities[i]) == 0 || string(quantities[i]) == "null" {
+				continue
+			}
+			line["quantity"] = quantities[i]
+			filled = append(filled, line)
+		}
+		if obj["lines"], err = json.Marshal(filled); err != nil {
+			return nil, fmt.Errorf("encode document lines: %w", err)
+		}
+	}
+
+	return json.Marshal(obj)
+}
+
+// decodeLines returns the "lines" table part of a request, or nil if absent.
+func decodeLines(obj map[string]json.RawMessage) ([]map[string]json.RawMessage, error) {
+	raw, ok := obj["lines"]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+	var lines []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &lines); err != nil {
+		return nil, fmt.Errorf("decode lines: %w", err)
+	}
+	return lines, nil
+}
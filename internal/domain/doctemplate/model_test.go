@@ -0,0 +1,85 @@
+package doctemplate
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestBuildPayloadStripsInstanceFields(t *testing.T) {
+	request := json.RawMessage(`{
+		"number": "GR-001",
+		"date": "2026-01-10T00:00:00Z",
+		"postImmediately": true,
+		"supplierId": "s1",
+		"lines": [
+			{"lineId": "l1", "productId": "p1", "quantity": 5, "unitPrice": 100},
+			{"lineId": "l2", "productId": "p2", "quantity": 2, "unitPrice": 50}
+		]
+	}`)
+
+	payload, err := BuildPayload(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got struct {
+		Number     *string          `json:"number"`
+		Date       *string          `json:"date"`
+		SupplierID string           `json:"supplierId"`
+		Lines      []map[string]any `json:"lines"`
+	}
+	if err := json.Unmarshal(payload, &got); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if got.Number != nil || got.Date != nil {
+		t.Errorf("instance fields kept: %s", payload)
+	}
+	if got.SupplierID != "s1" {
+		t.Errorf("supplierId = %q, want s1", got.SupplierID)
+	}
+	if len(got.Lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(got.Lines))
+	}
+	for _, line := range got.Lines {
+		if _, ok := line["quantity"]; ok {
+			t.Errorf("line quantity kept: %v", line)
+		}
+		if _, ok := line["lineId"]; ok {
+			t.Errorf("lineId kept: %v", line)
+		}
+		if _, ok := line["productId"]; !ok {
+			t.Errorf("productId dropped: %v", line)
+		}
+	}
+}
+
+func TestInstantiateFillsQuantities(t *testing.T) {
+	payload := json.RawMessage(`{"supplierId":"s1","lines":[{"productId":"p1"},{"productId":"p2"},{"productId":"p3"}]}`)
+	date := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	request, err := Instantiate(payload, date, []json.RawMessage{
+		json.RawMessage(`3`),
+		json.RawMessage(`null`), // skipped line
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got struct {
+		Date  time.Time `json:"date"`
+		Lines []struct {
+			ProductID string  `json:"productId"`
+			Quantity  float64 `json:"quantity"`
+		} `json:"lines"`
+	}
+	if err := json.Unmarshal(request, &got); err != nil {
+		t.Fatalf("decode request: %v", err)
+	}
+	if !got.Date.Equal(date) {
+		t.Errorf("date = %v, want %v", got.Date, date)
+	}
+	if len(got.Lines) != 1 || got.Lines[0].ProductID != "p1" || got.Lines[0].Quantity != 3 {
+		t.Errorf("lines = %+v, want only p1 with quantity 3", got.Lines)
+	}
+}
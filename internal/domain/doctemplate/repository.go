@@ -0,0 +1,26 @@
+package doctemplate
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines storage operations for document templates.
+type Repository interface {
+	// Create inserts a new template.
+	Create(ctx context.Context, t *Template) error
+
+	// Update modifies name and visibility of a template. Uses optimistic locking (version).
+	Update(ctx context.Context, t *Template) error
+
+	// Delete soft-deletes a template by ID.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// GetByID returns a single template.
+	GetByID(ctx context.Context, id uuid.UUID) (*Template, error)
+
+	// GetList returns templates of a document type accessible to the user:
+	// shared + personal (only for this userID).
+	GetList(ctx context.Context, documentType string, userID uuid.UUID) ([]*Template, error)
+}
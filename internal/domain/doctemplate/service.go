@@ -0,0 +1,139 @@
+package doctemplate
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/core/apperror"
+	corectx "metapus/internal/core/context"
+)
+
+// Service provides business logic for managing document templates.
+type Service struct {
+	repo Repository
+}
+
+// NewService creates a new document template service.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Create saves a new template. The current user becomes its author.
+func (s *Service) Create(ctx context.Context, t *Template) error {
+	userID, err := s.requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	t.AuthorID = &userID
+	if t.Visibility == "" {
+		t.Visibility = VisibilityPersonal
+	}
+
+	if err := t.Validate(ctx); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, t)
+}
+
+// Update changes name and visibility of a template. Only the author may do it.
+func (s *Service) Update(ctx context.Context, t *Template) error {
+	existing, err := s.getOwned(ctx, t.ID, "update")
+	if err != nil {
+		return err
+	}
+
+	// Carry over immutable fields from existing record.
+	t.DocumentType = existing.DocumentType
+	t.AuthorID = existing.AuthorID
+	t.Payload = existing.Payload
+
+	if err := t.Validate(ctx); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, t)
+}
+
+// Delete soft-deletes a template. Only the author may do it.
+func (s *Service) Delete(ctx context.Context, documentType string, id uuid.UUID) error {
+	existing, err := s.getOwned(ctx, id, "delete")
+	if err != nil {
+		return err
+	}
+	if existing.DocumentType != documentType {
+		return apperror.NewNotFound("document_template", id)
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// Get returns a template of the document type if the current user may use it.
+func (s *Service) Get(ctx context.Context, documentType string, id uuid.UUID) (*Template, error) {
+	userID, err := s.requireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Another user's personal template is reported as missing.
+	if t.DocumentType != documentType || !t.visibleTo(userID) {
+		return nil, apperror.NewNotFound("document_template", id)
+	}
+	return t, nil
+}
+
+// GetList returns templates of a document type accessible to the current user.
+func (s *Service) GetList(ctx context.Context, documentType string) ([]*Template, error) {
+	userID, err := s.requireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetList(ctx, documentType, userID)
+}
+
+// getOwned loads a template and checks that the current user may change it.
+// Administrators may change any template.
+func (s *Service) getOwned(ctx context.Context, id uuid.UUID, action string) (*Template, error) {
+	userID, err := s.requireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.visibleTo(userID) {
+		return nil, apperror.NewNotFound("document_template", id)
+	}
+	if !existing.ownedBy(userID) && !corectx.GetUser(ctx).IsAdmin {
+		return nil, apperror.NewForbidden("cannot " + action + " another user's template")
+	}
+	return existing, nil
+}
+
+func (t *Template) ownedBy(userID uuid.UUID) bool {
+	return t.AuthorID != nil && *t.AuthorID == userID
+}
+
+func (t *Template) visibleTo(userID uuid.UUID) bool {
+	return t.Visibility == VisibilityShared || t.ownedBy(userID)
+}
+
+// requireUserID extracts and parses user ID from context.
+func (s *Service) requireUserID(ctx context.Context) (uuid.UUID, error) {
+	user := corectx.GetUser(ctx)
+	if user == nil {
+		return uuid.UUID{}, apperror.NewUnauthorized("user not authenticated")
+	}
+
+	userID, err := uuid.Parse(user.UserID)
+	if err != nil {
+		return uuid.UUID{}, apperror.NewUnauthorized("invalid user ID format")
+	}
+
+	return userID, nil
+}
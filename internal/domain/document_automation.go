@@ -5,11 +5,13 @@ import (
 	"math"
 	"reflect"
 	"strings"
+	"time"
 
 	core_entity "metapus/internal/core/entity"
 	"metapus/internal/core/id"
 	"metapus/internal/core/tenant"
 	"metapus/internal/core/types"
+	"metapus/internal/domain/posting"
 	"metapus/pkg/logger"
 )
 
@@ -298,3 +300,11 @@ func (d *DocumentOutboxDecorator[T]) List(ctx context.Context, filter ListFilter
 func (d *DocumentOutboxDecorator[T]) ListIDs(ctx context.Context, filter ListFilter, maxIDs int) ([]id.ID, error) {
 	return d.next.ListIDs(ctx, filter, maxIDs)
 }
+
+func (d *DocumentOutboxDecorator[T]) PostDryRun(ctx context.Context, entityID id.ID) (*posting.DryRunResult, error) {
+	return d.next.PostDryRun(ctx, entityID)
+}
+
+func (d *DocumentOutboxDecorator[T]) SuggestNumber(ctx context.Context, date time.Time, organizationID *id.ID) (string, error) {
+	return d.next.SuggestNumber(ctx, date, organizationID)
+}
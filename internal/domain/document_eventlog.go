@@ -7,6 +7,7 @@ import (
 
 	"metapus/internal/core/eventlog"
 	"metapus/internal/core/id"
+	"metapus/internal/domain/posting"
 	"metapus/pkg/logger"
 )
 
@@ -159,3 +160,11 @@ func (s *EventLogDocumentService[T]) List(ctx context.Context, filter ListFilter
 func (s *EventLogDocumentService[T]) ListIDs(ctx context.Context, filter ListFilter, maxIDs int) ([]id.ID, error) {
 	return s.next.ListIDs(ctx, filter, maxIDs)
 }
+
+func (s *EventLogDocumentService[T]) PostDryRun(ctx context.Context, docID id.ID) (*posting.DryRunResult, error) {
+	return s.next.PostDryRun(ctx, docID)
+}
+
+func (s *EventLogDocumentService[T]) SuggestNumber(ctx context.Context, date time.Time, organizationID *id.ID) (string, error) {
+	return s.next.SuggestNumber(ctx, date, organizationID)
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"metapus/internal/core/id"
+	"metapus/internal/domain/posting"
 	"metapus/pkg/logger"
 )
 
@@ -19,6 +20,9 @@ type DocumentService[T any] interface {
 	Update(ctx context.Context, entity T) error
 	Delete(ctx context.Context, id id.ID) error
 	Post(ctx context.Context, id id.ID) error
+	// PostDryRun reports the movements Post would record and the validation
+	// errors that would reject it, without writing anything.
+	PostDryRun(ctx context.Context, id id.ID) (*posting.DryRunResult, error)
 	Unpost(ctx context.Context, id id.ID) error
 	PostAndSave(ctx context.Context, entity T) error
 	UpdateAndRepost(ctx context.Context, entity T) error
@@ -26,6 +30,8 @@ type DocumentService[T any] interface {
 	List(ctx context.Context, filter ListFilter) (CursorListResult[T], error)
 	// ListIDs returns all IDs matching filter (for filter-based batch operations).
 	ListIDs(ctx context.Context, filter ListFilter, maxIDs int) ([]id.ID, error)
+	// SuggestNumber returns the next free number without consuming the sequence.
+	SuggestNumber(ctx context.Context, date time.Time, organizationID *id.ID) (string, error)
 }
 
 // ServiceMiddleware is a function that wraps a DocumentService with additional behaviour.
@@ -140,3 +146,13 @@ func (s *LoggingDocumentService[T]) ListIDs(ctx context.Context, filter ListFilt
 	defer func(start time.Time) { s.log(ctx, "ListIDs", start, err) }(time.Now())
 	return s.next.ListIDs(ctx, filter, maxIDs)
 }
+
+func (s *LoggingDocumentService[T]) PostDryRun(ctx context.Context, docID id.ID) (result *posting.DryRunResult, err error) {
+	defer func(start time.Time) { s.log(ctx, "PostDryRun", start, err) }(time.Now())
+	return s.next.PostDryRun(ctx, docID)
+}
+
+func (s *LoggingDocumentService[T]) SuggestNumber(ctx context.Context, date time.Time, organizationID *id.ID) (result string, err error) {
+	defer func(start time.Time) { s.log(ctx, "SuggestNumber", start, err) }(time.Now())
+	return s.next.SuggestNumber(ctx, date, organizationID)
+}
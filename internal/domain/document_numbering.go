@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/clock"
+	"metapus/internal/core/id"
+	"metapus/internal/core/numerator"
+	"metapus/internal/domain/settings"
+)
+
+// suggestNumberCandidates bounds how many upcoming sequence values
+// SuggestNumber probes before giving up.
+const suggestNumberCandidates = 50
+
+// NumberChecker is implemented by document repositories that can detect
+// number collisions. Optional: when the repository does not implement it,
+// uniqueness is enforced by DB constraints only.
+type NumberChecker interface {
+	// NumberExists reports whether number is already used by another document
+	// dated within the calendar year of date, taken in date's location. When
+	// organizationID is non-nil the check is scoped to that organization.
+	// The document excludeID (the one being saved) is excluded.
+	NumberExists(ctx context.Context, number string, date time.Time, organizationID *id.ID, excludeID id.ID) (bool, error)
+}
+
+// datedDocument is implemented by entity.Document.
+type datedDocument interface {
+	GetDate() time.Time
+}
+
+// numberScope returns the uniqueness scope (date, organization) of a document.
+// The date keeps the document's location so the year window matches the year
+// the user sees on the document.
+func numberScope(ctx context.Context, doc any) (time.Time, *id.ID) {
+	date := clock.Now(ctx)
+	if d, ok := doc.(datedDocument); ok && !d.GetDate().IsZero() {
+		date = d.GetDate()
+	}
+	var orgID *id.ID
+	if o, ok := doc.(OrganizationOwned); ok && !id.IsNil(o.GetOrganizationID()) {
+		v := o.GetOrganizationID()
+		orgID = &v
+	}
+	return date, orgID
+}
+
+// checkNumberUnique rejects a manually supplied number that is already used
+// by another document of the same type in the same year (and organization).
+// Empty numbers are skipped — they are generated by the numerator.
+func checkNumberUnique(ctx context.Context, repo any, entityName string, docID id.ID, number string, doc any) error {
+	if number == "" {
+		return nil
+	}
+	checker, ok := repo.(NumberChecker)
+	if !ok {
+		return nil
+	}
+	date, orgID := numberScope(ctx, doc)
+	exists, err := checker.NumberExists(ctx, number, date, orgID, docID)
+	if err != nil {
+		return fmt.Errorf("check number uniqueness: %w", err)
+	}
+	if exists {
+		return NewNumberDuplicate(entityName, number, date)
+	}
+	return nil
+}
+
+// NewNumberDuplicate is the error returned for a document number that is
+// already taken. Repositories map uq_*_number violations to the same error,
+// so a collision that slips past checkNumberUnique (a concurrent save) looks
+// identical to the client.
+func NewNumberDuplicate(entityName, number string, date time.Time) *apperror.AppError {
+	err := apperror.NewDuplicate(entityName, "number", number)
+	if !date.IsZero() {
+		err = err.WithDetail("year", date.Year())
+	}
+	return err
+}
+
+// NumeratorConfig returns the numerator config for prefix with the scoped
+// numbering settings (numbering.includeYear, numbering.padWidth) of the
+// organization applied. A nil organizationID resolves tenant values only.
+func NumeratorConfig(ctx context.Context, prefix string, organizationID id.ID) numerator.Config {
+	cfg := numerator.DefaultConfig(prefix)
+	subj := settings.Subject{OrganizationID: organizationID}
+	cfg.IncludeYear = settings.Get(ctx, settings.KeyNumberingIncludeYear, subj)
+	cfg.PadWidth = settings.Get(ctx, settings.KeyNumberingPadWidth, subj)
+	return cfg
+}
+
+// suggestNumber returns the next free number for the given date/organization
+// without consuming the sequence.
+func suggestNumber(
+	ctx context.Context,
+	gen numerator.Generator,
+	prefix string,
+	repo any,
+	date time.Time,
+	organizationID *id.ID,
+) (string, error) {
+	if date.IsZero() {
+		date = clock.Now(ctx)
+	}
+	var orgID id.ID
+	if organizationID != nil {
+		orgID = *organizationID
+	}
+	candidates, err := gen.PeekNextNumbers(ctx, NumeratorConfig(ctx, prefix, orgID), date, suggestNumberCandidates)
+	if err != nil {
+		return "", fmt.Errorf("peek next numbers: %w", err)
+	}
+
+	checker, ok := repo.(NumberChecker)
+	if !ok {
+		if len(candidates) == 0 {
+			return "", apperror.NewInternal(fmt.Errorf("numerator returned no candidates"))
+		}
+		return candidates[0], nil
+	}
+	for _, number := range candidates {
+		exists, err := checker.NumberExists(ctx, number, date, organizationID, id.Nil())
+		if err != nil {
+			return "", fmt.Errorf("check number uniqueness: %w", err)
+		}
+		if !exists {
+			return number, nil
+		}
+	}
+	return "", apperror.NewBusinessRule("NUMBER_NOT_AVAILABLE",
+		"Не удалось подобрать свободный номер. Укажите номер вручную.")
+}
+
+// SuggestNumber returns the next free document number without consuming the sequence.
+func (s *BaseDocumentService[T, L]) SuggestNumber(ctx context.Context, date time.Time, organizationID *id.ID) (string, error) {
+	return suggestNumber(ctx, s.Numerator, s.NumeratorPrefix, s.Repo, date, organizationID)
+}
+
+// SuggestNumber returns the next free document number without consuming the sequence.
+func (s *BaseHeaderDocumentService[T]) SuggestNumber(ctx context.Context, date time.Time, organizationID *id.ID) (string, error) {
+	return suggestNumber(ctx, s.Numerator, s.NumeratorPrefix, s.Repo, date, organizationID)
+}
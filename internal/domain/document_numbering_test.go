@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+)
+
+type numberingTestDoc struct{ date time.Time }
+
+func (d numberingTestDoc) GetDate() time.Time { return d.date }
+
+// numberChecker records the date it was asked about.
+type numberChecker struct {
+	exists bool
+	date   time.Time
+}
+
+func (c *numberChecker) NumberExists(_ context.Context, _ string, date time.Time, _ *id.ID, _ id.ID) (bool, error) {
+	c.date = date
+	return c.exists, nil
+}
+
+func TestCheckNumberUniqueUsesDocumentLocation(t *testing.T) {
+	// 00:30 on 1 January in Moscow is still 31 December in UTC.
+	msk := time.FixedZone("MSK", 3*60*60)
+	doc := numberingTestDoc{date: time.Date(2025, time.January, 1, 0, 30, 0, 0, msk)}
+
+	checker := &numberChecker{exists: true}
+	err := checkNumberUnique(context.Background(), checker, "goods_receipt", id.New(), "ПТ-0001", doc)
+
+	if checker.date.Location() != msk || checker.date.Year() != 2025 {
+		t.Errorf("checked date = %v, want the document date in its own location", checker.date)
+	}
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperror.CodeDuplicate {
+		t.Fatalf("err = %v, want duplicate", err)
+	}
+	if appErr.Details["year"] != 2025 {
+		t.Errorf("year detail = %v, want 2025", appErr.Details["year"])
+	}
+}
+
+func TestCheckNumberUniqueSkipsGeneratedNumbers(t *testing.T) {
+	checker := &numberChecker{exists: true}
+	if err := checkNumberUnique(context.Background(), checker, "goods_receipt", id.New(), "", numberingTestDoc{}); err != nil {
+		t.Fatalf("empty number: %v", err)
+	}
+	if !checker.date.IsZero() {
+		t.Error("checker called for an empty number")
+	}
+}
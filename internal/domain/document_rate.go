@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// FreezeRate fixes the exchange rate of a document being posted: unless the
+// document already carries a frozen rate (repost), the register rate on the
+// document date is stored on it. The base-currency total is then
+// recalculated at the frozen rate.
+// No-op without a converter or for documents not implementing PostedRateDoc.
+func (s *BaseDocumentService[T, L]) FreezeRate(ctx context.Context, doc T) error {
+	if s.CurrencyConverter == nil {
+		return nil
+	}
+	rateDoc, ok := any(doc).(PostedRateDoc)
+	if !ok {
+		return s.ConvertToBase(ctx, doc)
+	}
+	if _, _, frozen := rateDoc.GetPostedRate(); !frozen {
+		rate, err := s.CurrencyConverter.EffectiveRate(ctx, rateDoc.Total().CurrencyID, rateDoc.GetDate())
+		if err != nil {
+			return err
+		}
+		if rate != nil {
+			rateDoc.SetPostedRate(rate.Rate, rate.Multiplier)
+		}
+	}
+	return s.ConvertToBase(ctx, doc)
+}
+
+// carryPostedRate takes the frozen rate from the stored state of a document:
+// a posted document keeps its rate across edits as long as its currency and
+// date are unchanged; otherwise (and for drafts) the rate is dropped, so it
+// is taken from the register on the next posting. Clients cannot set the
+// rate directly.
+func carryPostedRate[T any](oldDoc, doc T) {
+	newRD, ok := any(doc).(PostedRateDoc)
+	if !ok {
+		return
+	}
+	newRD.ClearPostedRate()
+
+	oldRD, ok := any(oldDoc).(PostedRateDoc)
+	if !ok {
+		return
+	}
+	if posted, ok := any(oldDoc).(interface{ IsPosted() bool }); !ok || !posted.IsPosted() {
+		return
+	}
+	rate, multiplier, frozen := oldRD.GetPostedRate()
+	if !frozen || oldRD.Total().CurrencyID != newRD.Total().CurrencyID || !sameDay(oldRD.GetDate(), newRD.GetDate()) {
+		return
+	}
+	newRD.SetPostedRate(rate, multiplier)
+}
+
+// releasePostedRate drops the frozen rate of a document being unposted.
+func releasePostedRate[T any](doc T) {
+	if rateDoc, ok := any(doc).(PostedRateDoc); ok {
+		rateDoc.ClearPostedRate()
+	}
+}
+
+// sameDay reports whether both times fall on the same calendar date (UTC),
+// the granularity of the exchange rates register.
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.UTC().Date()
+	by, bm, bd := b.UTC().Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+)
+
+type rateTestDoc struct {
+	entity.PostedRate
+	currencyID id.ID
+	date       time.Time
+	posted     bool
+	base       types.MinorUnits
+}
+
+func (d *rateTestDoc) Total() types.Money                         { return types.NewMoney(10000, d.currencyID) }
+func (d *rateTestDoc) GetDate() time.Time                         { return d.date }
+func (d *rateTestDoc) SetTotalAmountBase(amount types.MinorUnits) { d.base = amount }
+func (d *rateTestDoc) IsPosted() bool                             { return d.posted }
+
+func TestCarryPostedRate(t *testing.T) {
+	usd, eur := id.New(), id.New()
+	date := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	posted := &rateTestDoc{currencyID: usd, date: date, posted: true}
+	posted.SetPostedRate(decimal.RequireFromString("95.5"), 1)
+
+	tests := []struct {
+		name   string
+		old    *rateTestDoc
+		edited *rateTestDoc
+		keep   bool
+	}{
+		{"posted, same currency and day", posted, &rateTestDoc{currencyID: usd, date: date.Add(2 * time.Hour)}, true},
+		{"posted, currency changed", posted, &rateTestDoc{currencyID: eur, date: date}, false},
+		{"posted, date changed", posted, &rateTestDoc{currencyID: usd, date: date.AddDate(0, 0, -1)}, false},
+		{"draft", &rateTestDoc{currencyID: usd, date: date, PostedRate: posted.PostedRate}, &rateTestDoc{currencyID: usd, date: date}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A client-supplied rate is always replaced by the stored one.
+			tt.edited.SetPostedRate(decimal.NewFromInt(1), 1)
+
+			carryPostedRate(tt.old, tt.edited)
+
+			rate, _, ok := tt.edited.GetPostedRate()
+			if ok != tt.keep {
+				t.Fatalf("frozen = %v, want %v", ok, tt.keep)
+			}
+			if ok && !rate.Equal(decimal.RequireFromString("95.5")) {
+				t.Errorf("rate = %s, want 95.5", rate)
+			}
+		})
+	}
+}
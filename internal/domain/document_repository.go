@@ -2,9 +2,13 @@ package domain
 
 import (
 	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
 
 	"metapus/internal/core/entity"
 	"metapus/internal/core/id"
+	"metapus/internal/core/types"
 )
 
 // DocumentRepository defines CRUD + line operations for document entities.
@@ -72,6 +76,44 @@ type CurrencyResolveStrategy interface {
 	ResolveForDocument(ctx context.Context, explicitCurrencyID id.ID, contractID *id.ID, organizationID id.ID) (id.ID, error)
 }
 
+// BaseCurrencyConverter converts document amounts to the base (accounting)
+// currency at the rate in effect on the document date.
+// Implemented by exchange_rate.Converter. Optional — nil disables conversion.
+type BaseCurrencyConverter interface {
+	ToBase(ctx context.Context, amount types.Money, date time.Time) (types.Money, error)
+
+	// EffectiveRate returns the rate of the currency in effect on date,
+	// or nil for the base currency.
+	EffectiveRate(ctx context.Context, currencyID id.ID, date time.Time) (*FixedRate, error)
+
+	// ToBaseAtRate converts amount at a fixed rate.
+	ToBaseAtRate(ctx context.Context, amount types.Money, rate FixedRate) (types.Money, error)
+}
+
+// FixedRate is an exchange rate to the base currency: Rate base-currency
+// units per Multiplier units of the foreign currency.
+type FixedRate struct {
+	Rate       decimal.Decimal
+	Multiplier int
+}
+
+// BaseAmountDoc is implemented by documents that store their total in the
+// base currency alongside the total in the document currency.
+type BaseAmountDoc interface {
+	Total() types.Money
+	GetDate() time.Time
+	SetTotalAmountBase(amount types.MinorUnits)
+}
+
+// PostedRateDoc is implemented by BaseAmountDoc documents that freeze the
+// exchange rate on posting (entity.PostedRate).
+type PostedRateDoc interface {
+	BaseAmountDoc
+	GetPostedRate() (rate decimal.Decimal, multiplier int, ok bool)
+	SetPostedRate(rate decimal.Decimal, multiplier int)
+	ClearPostedRate()
+}
+
 // CurrencyCacheInvalidator allows catalog services to notify the currency resolver
 // that a cached lookup should be evicted (e.g., when a contract's currency changes).
 // Implemented by documents.CurrencyResolver. Optional — nil means no caching.
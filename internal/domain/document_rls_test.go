@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/security"
+)
+
+// rlsTestDoc carries the organization and warehouse dimensions of a
+// warehouse document such as GoodsReceipt.
+type rlsTestDoc struct {
+	entity.Document
+	organizationID id.ID
+	warehouseID    id.ID
+}
+
+func (d *rlsTestDoc) GetRLSDimensions() map[string]string {
+	return map[string]string{
+		"organization": d.organizationID.String(),
+		"warehouse":    d.warehouseID.String(),
+	}
+}
+func (d *rlsTestDoc) GetDocumentType() string                    { return "RLSTestDoc" }
+func (d *rlsTestDoc) GetLines() []struct{}                       { return nil }
+func (d *rlsTestDoc) SetLines([]struct{})                        {}
+func (d *rlsTestDoc) GetCurrencyID() id.ID                       { return id.ID{} }
+func (d *rlsTestDoc) SetCurrencyID(id.ID)                        {}
+func (d *rlsTestDoc) GetContractID() *id.ID                      { return nil }
+func (d *rlsTestDoc) ValidateCurrency(ctx context.Context) error { return nil }
+
+// errPastRLS is returned by the number check that follows the RLS check in
+// Create and PostAndSave: reaching it means the document was let through.
+var errPastRLS = errors.New("past the RLS check")
+
+type rlsTestRepo struct {
+	DocumentRepository[*rlsTestDoc, struct{}]
+}
+
+func (rlsTestRepo) NumberExists(context.Context, string, time.Time, *id.ID, id.ID) (bool, error) {
+	return false, errPastRLS
+}
+
+type rlsTestHeaderRepo struct {
+	HeaderDocumentRepository[*rlsTestDoc]
+}
+
+func (rlsTestHeaderRepo) NumberExists(context.Context, string, time.Time, *id.ID, id.ID) (bool, error) {
+	return false, errPastRLS
+}
+
+func TestDocumentCreateChecksRLSScope(t *testing.T) {
+	org, otherOrg := id.New(), id.New()
+	wh, otherWh := id.New(), id.New()
+
+	docs := NewBaseDocumentService(BaseDocumentServiceConfig[*rlsTestDoc, struct{}]{
+		Repo: rlsTestRepo{}, EntityName: "rls_test_doc",
+	})
+	headers := NewBaseHeaderDocumentService(BaseHeaderDocumentServiceConfig[*rlsTestDoc]{
+		Repo: rlsTestHeaderRepo{}, EntityName: "rls_test_doc",
+	})
+	ops := []struct {
+		name string
+		run  func(context.Context, *rlsTestDoc) error
+	}{
+		{"create", docs.Create},
+		{"post and save", docs.PostAndSave},
+		{"header create", headers.Create},
+	}
+
+	scopes := []struct {
+		name      string
+		dimension string
+		allowed   id.ID
+	}{
+		{"organization", "organization", org},
+		{"warehouse", "warehouse", wh},
+	}
+
+	tests := []struct {
+		name    string
+		doc     rlsTestDoc
+		allowed map[string]bool // by scope
+	}{
+		{"inside both", rlsTestDoc{organizationID: org, warehouseID: wh},
+			map[string]bool{"organization": true, "warehouse": true}},
+		{"other organization", rlsTestDoc{organizationID: otherOrg, warehouseID: wh},
+			map[string]bool{"organization": false, "warehouse": true}},
+		{"other warehouse", rlsTestDoc{organizationID: org, warehouseID: otherWh},
+			map[string]bool{"organization": true, "warehouse": false}},
+	}
+
+	for _, sc := range scopes {
+		scope := &security.DataScope{Dimensions: map[string][]string{sc.dimension: {sc.allowed.String()}}}
+		ctx := security.WithDataScope(context.Background(), scope)
+
+		for _, tt := range tests {
+			for _, op := range ops {
+				t.Run(sc.name+"/"+tt.name+"/"+op.name, func(t *testing.T) {
+					doc := tt.doc
+					doc.Document = entity.NewDocument()
+					doc.Number = "ПТ-0001"
+
+					err := op.run(ctx, &doc)
+					if tt.allowed[sc.name] {
+						if !errors.Is(err, errPastRLS) {
+							t.Fatalf("err = %v, want the document let through", err)
+						}
+						return
+					}
+					var appErr *apperror.AppError
+					if !errors.As(err, &appErr) || appErr.Code != apperror.CodeForbidden {
+						t.Fatalf("err = %v, want forbidden", err)
+					}
+				})
+			}
+		}
+	}
+}
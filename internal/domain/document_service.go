@@ -3,9 +3,9 @@ package domain
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"metapus/internal/core/apperror"
+	"metapus/internal/core/clock"
 	"metapus/internal/core/entity"
 	"metapus/internal/core/id"
 	"metapus/internal/core/numerator"
@@ -74,6 +74,7 @@ type BaseDocumentService[T DocumentEntity[L], L any] struct {
 	Numerator         numerator.Generator
 	TxManager         tx.Manager
 	CurrencyResolver  CurrencyResolveStrategy
+	CurrencyConverter BaseCurrencyConverter
 	PolicyEngine      *security.PolicyEngine
 	hooks             *HookRegistry[T]
 	NumeratorPrefix   string
@@ -83,6 +84,7 @@ type BaseDocumentService[T DocumentEntity[L], L any] struct {
 
 // NewBaseDocumentService creates a new BaseDocumentService.
 func NewBaseDocumentService[T DocumentEntity[L], L any](cfg BaseDocumentServiceConfig[T, L]) *BaseDocumentService[T, L] {
+	nameRepo(cfg.Repo, cfg.EntityName)
 	return &BaseDocumentService[T, L]{
 		Repo:              cfg.Repo,
 		PostingEngine:     cfg.PostingEngine,
@@ -107,6 +109,12 @@ func (s *BaseDocumentService[T, L]) SetPolicyEngine(engine *security.PolicyEngin
 	s.PolicyEngine = engine
 }
 
+// SetCurrencyConverter enables base-currency totals for documents
+// implementing BaseAmountDoc.
+func (s *BaseDocumentService[T, L]) SetCurrencyConverter(converter BaseCurrencyConverter) {
+	s.CurrencyConverter = converter
+}
+
 // GetTxManager returns TxManager from config or context.
 func (s *BaseDocumentService[T, L]) GetTxManager(ctx context.Context) (tx.Manager, error) {
 	if s.TxManager != nil {
@@ -138,13 +146,47 @@ func (s *BaseDocumentService[T, L]) ResolveCurrency(ctx context.Context, doc T)
 	return nil
 }
 
+// ConvertToBase recalculates the document total in base currency: at the
+// rate frozen on posting (PostedRateDoc) if the document carries one,
+// otherwise at the register rate on the document date.
+// No-op without a converter or for documents not implementing BaseAmountDoc.
+func (s *BaseDocumentService[T, L]) ConvertToBase(ctx context.Context, doc T) error {
+	if s.CurrencyConverter == nil {
+		return nil
+	}
+	baseDoc, ok := any(doc).(BaseAmountDoc)
+	if !ok {
+		return nil
+	}
+	if rateDoc, ok := baseDoc.(PostedRateDoc); ok {
+		if rate, multiplier, frozen := rateDoc.GetPostedRate(); frozen {
+			total, err := s.CurrencyConverter.ToBaseAtRate(ctx, baseDoc.Total(), FixedRate{Rate: rate, Multiplier: multiplier})
+			if err != nil {
+				return err
+			}
+			baseDoc.SetTotalAmountBase(total.Amount)
+			return nil
+		}
+	}
+	total, err := s.CurrencyConverter.ToBase(ctx, baseDoc.Total(), baseDoc.GetDate())
+	if err != nil {
+		return err
+	}
+	baseDoc.SetTotalAmountBase(total.Amount)
+	return nil
+}
+
 // GenerateNumber generates a document number if it is empty.
 func (s *BaseDocumentService[T, L]) GenerateNumber(ctx context.Context, doc T) error {
 	if doc.GetNumber() != "" {
 		return nil
 	}
-	cfg := numerator.DefaultConfig(s.NumeratorPrefix)
-	number, err := s.Numerator.GetNextNumber(ctx, cfg, &numerator.Options{Strategy: s.NumeratorStrategy}, time.Now())
+	var orgID id.ID
+	if orgOwned, ok := any(doc).(OrganizationOwned); ok {
+		orgID = orgOwned.GetOrganizationID()
+	}
+	cfg := NumeratorConfig(ctx, s.NumeratorPrefix, orgID)
+	number, err := s.Numerator.GetNextNumber(ctx, cfg, &numerator.Options{Strategy: s.NumeratorStrategy}, clock.Now(ctx))
 	if err != nil {
 		return fmt.Errorf("generate number: %w", err)
 	}
@@ -157,6 +199,11 @@ func (s *BaseDocumentService[T, L]) checkRLSAccess(ctx context.Context, doc T) e
 	return security.CheckRLSAccess(ctx, s.EntityName, doc)
 }
 
+// authorize delegates to security.Authorize for the document type.
+func (s *BaseDocumentService[T, L]) authorize(ctx context.Context, perm security.Permission) (context.Context, error) {
+	return security.Authorize(ctx, s.EntityName, perm)
+}
+
 // checkCELPolicy delegates to security.CheckCELPolicy.
 func (s *BaseDocumentService[T, L]) checkCELPolicy(ctx context.Context, action string, doc T) error {
 	return security.CheckCELPolicy(ctx, s.PolicyEngine, s.EntityName, action, doc)
@@ -174,6 +221,11 @@ func (s *BaseDocumentService[T, L]) Create(ctx context.Context, doc T) error {
 		return err
 	}
 
+	// RLS: the new document must fall within the user's scope (e.g. organizations)
+	if err := s.checkRLSAccess(ctx, doc); err != nil {
+		return err
+	}
+
 	// Resolve currency
 	if err := s.ResolveCurrency(ctx, doc); err != nil {
 		return err
@@ -184,11 +236,26 @@ func (s *BaseDocumentService[T, L]) Create(ctx context.Context, doc T) error {
 		return err
 	}
 
+	// Header totals must match the lines
+	if err := s.VerifyTotals(ctx, doc); err != nil {
+		return err
+	}
+
+	// Total in base currency
+	if err := s.ConvertToBase(ctx, doc); err != nil {
+		return err
+	}
+
 	// CEL policy check
 	if err := s.checkCELPolicy(ctx, "create", doc); err != nil {
 		return err
 	}
 
+	// Reject manual numbers that collide with existing documents
+	if err := checkNumberUnique(ctx, s.Repo, s.EntityName, doc.GetID(), doc.GetNumber(), doc); err != nil {
+		return err
+	}
+
 	// Generate number if empty
 	if err := s.GenerateNumber(ctx, doc); err != nil {
 		return err
@@ -206,7 +273,7 @@ func (s *BaseDocumentService[T, L]) Create(ctx context.Context, doc T) error {
 		if err := s.Repo.SaveLines(ctx, doc.GetID(), doc.GetLines()); err != nil {
 			return fmt.Errorf("save lines: %w", err)
 		}
-		return nil
+		return s.hooks.Run(ctx, CreateInTx, doc)
 	})
 	if err != nil {
 		return err
@@ -276,6 +343,10 @@ func (s *BaseDocumentService[T, L]) Update(ctx context.Context, doc T) error {
 	if err := s.checkRLSAccess(ctx, oldDoc); err != nil {
 		return err
 	}
+	// Both the stored and the new date must be in an open period
+	if err := s.PostingEngine.CheckPeriod(ctx, oldDoc, doc); err != nil {
+		return err
+	}
 
 	// FLS: validate that no restricted fields were modified
 	if writePolicy := security.GetFieldPolicy(ctx, s.EntityName, "write"); writePolicy != nil {
@@ -312,6 +383,22 @@ func (s *BaseDocumentService[T, L]) Update(ctx context.Context, doc T) error {
 		return err
 	}
 
+	// Header totals must match the lines
+	if err := s.VerifyTotals(ctx, doc); err != nil {
+		return err
+	}
+
+	// Total in base currency (a draft has no frozen rate)
+	carryPostedRate(oldDoc, doc)
+	if err := s.ConvertToBase(ctx, doc); err != nil {
+		return err
+	}
+
+	// Reject manual numbers that collide with existing documents
+	if err := checkNumberUnique(ctx, s.Repo, s.EntityName, doc.GetID(), doc.GetNumber(), doc); err != nil {
+		return err
+	}
+
 	// Update in transaction
 	txm, err := s.GetTxManager(ctx)
 	if err != nil {
@@ -324,7 +411,7 @@ func (s *BaseDocumentService[T, L]) Update(ctx context.Context, doc T) error {
 		if err := s.Repo.SaveLines(ctx, doc.GetID(), doc.GetLines()); err != nil {
 			return fmt.Errorf("save lines: %w", err)
 		}
-		return nil
+		return s.hooks.Run(ctx, UpdateInTx, doc)
 	})
 }
 
@@ -355,6 +442,9 @@ func (s *BaseDocumentService[T, L]) Delete(ctx context.Context, docID id.ID) err
 	if err := doc.State().CanDelete(); err != nil {
 		return err
 	}
+	if err := s.PostingEngine.CheckPeriod(ctx, doc); err != nil {
+		return err
+	}
 
 	return s.Repo.Delete(ctx, docID)
 }
@@ -389,10 +479,18 @@ func (s *BaseDocumentService[T, L]) SetDeletionMark(ctx context.Context, docID i
 	if doc.IsDeletionMarked() == marked {
 		return nil
 	}
+	if err := s.PostingEngine.CheckPeriod(ctx, doc); err != nil {
+		return err
+	}
 
 	if marked {
 		// Setting deletion mark
 		if doc.IsPosted() {
+			// Marking a posted document unposts it
+			ctx, err := s.authorize(ctx, security.PermissionUnpost)
+			if err != nil {
+				return err
+			}
 			// CEL: also check "unpost" since SetDeletionMark will reverse movements
 			if err := s.checkCELPolicy(ctx, "unpost", doc); err != nil {
 				return err
@@ -402,7 +500,11 @@ func (s *BaseDocumentService[T, L]) SetDeletionMark(ctx context.Context, docID i
 				// At this point MarkUnposted() has been called by the engine,
 				// movements have been reversed. We just need to set deletion mark.
 				doc.MarkDeleted()
-				return s.Repo.Update(ctx, doc)
+				releasePostedRate(doc)
+				if err := s.Repo.Update(ctx, doc); err != nil {
+					return err
+				}
+				return s.hooks.Run(ctx, UnpostInTx, doc)
 			}
 			return s.PostingEngine.Unpost(ctx, doc, updateDocAndMark)
 		}
@@ -437,6 +539,12 @@ func (s *BaseDocumentService[T, L]) Post(ctx context.Context, docID id.ID) error
 		return err
 	}
 
+	// Posting is granted apart from editing (document:<type>:post)
+	ctx, err := s.authorize(ctx, security.PermissionPost)
+	if err != nil {
+		return err
+	}
+
 	doc, err := s.GetByID(ctx, docID)
 	if err != nil {
 		return err
@@ -452,13 +560,48 @@ func (s *BaseDocumentService[T, L]) Post(ctx context.Context, docID id.ID) error
 		return err
 	}
 
+	// Header totals must match the lines (the stored ones may predate the check)
+	if err := s.VerifyTotals(ctx, doc); err != nil {
+		return err
+	}
+
+	// Freeze the exchange rate (a repost keeps the stored one)
+	if err := s.FreezeRate(ctx, doc); err != nil {
+		return err
+	}
+
 	updateDoc := func(ctx context.Context) error {
-		return s.Repo.Update(ctx, doc)
+		if err := s.Repo.Update(ctx, doc); err != nil {
+			return err
+		}
+		return s.hooks.Run(ctx, PostInTx, doc)
 	}
 
 	return s.PostingEngine.Post(ctx, doc, updateDoc)
 }
 
+// PostDryRun runs the posting pipeline without recording anything and returns
+// the would-be movements. A CEL "post" policy rejection is reported among the
+// validation errors; RLS denials are returned as error.
+func (s *BaseDocumentService[T, L]) PostDryRun(ctx context.Context, docID id.ID) (*posting.DryRunResult, error) {
+	doc, err := s.GetByID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkRLSAccess(ctx, doc); err != nil {
+		return nil, err
+	}
+
+	result, err := s.PostingEngine.DryRun(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCELPolicy(ctx, "post", doc); err != nil {
+		result.Errors = append(result.Errors, err)
+	}
+	return result, nil
+}
+
 // Unpost reverses document movements.
 func (s *BaseDocumentService[T, L]) Unpost(ctx context.Context, docID id.ID) error {
 	// RLS: check write permission
@@ -466,6 +609,12 @@ func (s *BaseDocumentService[T, L]) Unpost(ctx context.Context, docID id.ID) err
 		return err
 	}
 
+	// Unposting is granted apart from editing (document:<type>:unpost)
+	ctx, err := s.authorize(ctx, security.PermissionUnpost)
+	if err != nil {
+		return err
+	}
+
 	doc, err := s.GetByID(ctx, docID)
 	if err != nil {
 		return err
@@ -482,7 +631,11 @@ func (s *BaseDocumentService[T, L]) Unpost(ctx context.Context, docID id.ID) err
 	}
 
 	updateDoc := func(ctx context.Context) error {
-		return s.Repo.Update(ctx, doc)
+		releasePostedRate(doc)
+		if err := s.Repo.Update(ctx, doc); err != nil {
+			return err
+		}
+		return s.hooks.Run(ctx, UnpostInTx, doc)
 	}
 
 	return s.PostingEngine.Unpost(ctx, doc, updateDoc)
@@ -496,39 +649,71 @@ func (s *BaseDocumentService[T, L]) PostAndSave(ctx context.Context, doc T) erro
 		return err
 	}
 
+	// Creating a posted document needs the posting permission too
+	ctx, err := s.authorize(ctx, security.PermissionPost)
+	if err != nil {
+		return err
+	}
+
 	// Run before-create hooks (for enrichment: CreatedBy, UpdatedBy, etc.)
 	if err := s.hooks.RunBeforeCreate(ctx, doc); err != nil {
 		return err
 	}
 
+	// RLS: the new document must fall within the user's scope (e.g. organizations)
+	if err := s.checkRLSAccess(ctx, doc); err != nil {
+		return err
+	}
+
 	// Resolve currency
 	if err := s.ResolveCurrency(ctx, doc); err != nil {
 		return err
 	}
 
+	// Header totals must match the lines
+	if err := s.VerifyTotals(ctx, doc); err != nil {
+		return err
+	}
+
+	// Freeze the exchange rate and compute the total in base currency
+	if err := s.FreezeRate(ctx, doc); err != nil {
+		return err
+	}
+
 	// CEL policy check
 	if err := s.checkCELPolicy(ctx, "create", doc); err != nil {
 		return err
 	}
 
+	// Reject manual numbers that collide with existing documents
+	if err := checkNumberUnique(ctx, s.Repo, s.EntityName, doc.GetID(), doc.GetNumber(), doc); err != nil {
+		return err
+	}
+
 	// Generate number if empty
 	if err := s.GenerateNumber(ctx, doc); err != nil {
 		return err
 	}
 
 	updateDoc := func(ctx context.Context) error {
+		event := UpdateInTx
 		if doc.GetVersion() == 1 {
 			// New document - create
+			event = CreateInTx
 			if err := s.Repo.Create(ctx, doc); err != nil {
 				return err
 			}
-			return s.Repo.SaveLines(ctx, doc.GetID(), doc.GetLines())
+		} else if err := s.Repo.Update(ctx, doc); err != nil {
+			// Existing document - update
+			return err
 		}
-		// Existing document - update
-		if err := s.Repo.Update(ctx, doc); err != nil {
+		if err := s.Repo.SaveLines(ctx, doc.GetID(), doc.GetLines()); err != nil {
+			return err
+		}
+		if err := s.hooks.Run(ctx, event, doc); err != nil {
 			return err
 		}
-		return s.Repo.SaveLines(ctx, doc.GetID(), doc.GetLines())
+		return s.hooks.Run(ctx, PostInTx, doc)
 	}
 
 	return s.PostingEngine.Post(ctx, doc, updateDoc)
@@ -548,6 +733,13 @@ func (s *BaseDocumentService[T, L]) UpdateAndRepost(ctx context.Context, doc T)
 	if err := security.GetDataScope(ctx).CanMutate(); err != nil {
 		return err
 	}
+
+	// Saving a posted document reposts it
+	ctx, err := s.authorize(ctx, security.PermissionPost)
+	if err != nil {
+		return err
+	}
+
 	if err := s.checkRLSAccess(ctx, doc); err != nil {
 		return err
 	}
@@ -562,6 +754,10 @@ func (s *BaseDocumentService[T, L]) UpdateAndRepost(ctx context.Context, doc T)
 	if err := s.checkRLSAccess(ctx, oldDoc); err != nil {
 		return err
 	}
+	// The new date is checked by the posting engine
+	if err := s.PostingEngine.CheckPeriod(ctx, oldDoc); err != nil {
+		return err
+	}
 
 	// CEL policy check — evaluate against existing document state
 	if err := s.checkCELPolicy(ctx, "update", oldDoc); err != nil {
@@ -593,6 +789,23 @@ func (s *BaseDocumentService[T, L]) UpdateAndRepost(ctx context.Context, doc T)
 		return err
 	}
 
+	// Header totals must match the lines
+	if err := s.VerifyTotals(ctx, doc); err != nil {
+		return err
+	}
+
+	// Keep the rate frozen on the previous posting (same currency and date)
+	// or freeze the current one, then compute the total in base currency
+	carryPostedRate(oldDoc, doc)
+	if err := s.FreezeRate(ctx, doc); err != nil {
+		return err
+	}
+
+	// Reject manual numbers that collide with existing documents
+	if err := checkNumberUnique(ctx, s.Repo, s.EntityName, doc.GetID(), doc.GetNumber(), doc); err != nil {
+		return err
+	}
+
 	// Note: Validate() is called inside Engine.doPost() via CanPost(),
 	// which checks both state transitions and entity invariants.
 
@@ -600,7 +813,13 @@ func (s *BaseDocumentService[T, L]) UpdateAndRepost(ctx context.Context, doc T)
 		if err := s.Repo.Update(ctx, doc); err != nil {
 			return fmt.Errorf("update document: %w", err)
 		}
-		return s.Repo.SaveLines(ctx, doc.GetID(), doc.GetLines())
+		if err := s.Repo.SaveLines(ctx, doc.GetID(), doc.GetLines()); err != nil {
+			return err
+		}
+		if err := s.hooks.Run(ctx, UpdateInTx, doc); err != nil {
+			return err
+		}
+		return s.hooks.Run(ctx, PostInTx, doc)
 	}
 
 	return s.PostingEngine.Post(ctx, doc, updateDoc)
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/types"
+	"metapus/internal/domain/settings"
+	"metapus/pkg/logger"
+)
+
+// DocumentTotals are the header totals of a document with lines, stored
+// denormalized next to the lines they sum up.
+type DocumentTotals struct {
+	Quantity types.Quantity
+	Amount   types.MinorUnits
+	VAT      types.MinorUnits
+}
+
+// TotalsDoc is implemented by documents whose header totals are sums of
+// their lines. Totals not kept by a document stay zero on both sides.
+type TotalsDoc interface {
+	// Totals returns the header totals as set on the document.
+	Totals() DocumentTotals
+	// LineTotals returns the totals recomputed from the lines.
+	LineTotals() DocumentTotals
+	// SetTotals overwrites the header totals.
+	SetTotals(totals DocumentTotals)
+}
+
+// VerifyTotals checks the header totals of a document being saved or posted
+// against its lines. A mismatch is corrected from the lines or, with
+// settings.KeyDocumentTotalsMismatch "reject", refused with TOTALS_MISMATCH.
+// No-op for documents not implementing TotalsDoc.
+func (s *BaseDocumentService[T, L]) VerifyTotals(ctx context.Context, doc T) error {
+	totalsDoc, ok := any(doc).(TotalsDoc)
+	if !ok {
+		return nil
+	}
+	stored := totalsDoc.Totals()
+	if err := checkTotals(ctx, totalsDoc); err != nil {
+		return err
+	}
+	if fixed := totalsDoc.Totals(); fixed != stored {
+		logger.Warn(ctx, s.EntityName+" totals recalculated from lines",
+			"id", doc.GetID(),
+			"stored", totalsDetail(stored),
+			"computed", totalsDetail(fixed))
+	}
+	return nil
+}
+
+// checkTotals corrects or rejects header totals of doc differing from its lines.
+func checkTotals(ctx context.Context, doc TotalsDoc) error {
+	stored, computed := doc.Totals(), doc.LineTotals()
+	if stored == computed {
+		return nil
+	}
+	if settings.Get(ctx, settings.KeyDocumentTotalsMismatch, settings.Subject{}) == settings.TotalsMismatchReject {
+		return apperror.NewBusinessRule("TOTALS_MISMATCH", "Итоги документа не совпадают с суммой строк").
+			WithDetail("stored", totalsDetail(stored)).
+			WithDetail("computed", totalsDetail(computed))
+	}
+	doc.SetTotals(computed)
+	return nil
+}
+
+// totalsDetail renders totals for error details and logs.
+func totalsDetail(t DocumentTotals) map[string]any {
+	return map[string]any{
+		"quantity": t.Quantity.String(),
+		"amount":   int64(t.Amount),
+		"vat":      int64(t.VAT),
+	}
+}
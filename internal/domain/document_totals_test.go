@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/settings"
+)
+
+type totalsTestDoc struct {
+	totals, lines DocumentTotals
+}
+
+func (d *totalsTestDoc) Totals() DocumentTotals     { return d.totals }
+func (d *totalsTestDoc) LineTotals() DocumentTotals { return d.lines }
+func (d *totalsTestDoc) SetTotals(t DocumentTotals) { d.totals = t }
+
+// totalsSettings holds the tenant value of KeyDocumentTotalsMismatch.
+type totalsSettings string
+
+func (s totalsSettings) ListValues(context.Context) ([]settings.Value, error) {
+	raw, _ := json.Marshal(string(s))
+	return []settings.Value{{Key: settings.KeyDocumentTotalsMismatch.Name(), Scope: settings.ScopeTenant, Value: raw}}, nil
+}
+
+func (s totalsSettings) SetValue(_ context.Context, v settings.Value) (settings.Value, error) {
+	return v, nil
+}
+
+func (s totalsSettings) DeleteValue(context.Context, string, settings.Scope, id.ID) error { return nil }
+
+func TestCheckTotals(t *testing.T) {
+	lines := DocumentTotals{Quantity: 20000, Amount: 1200, VAT: 200}
+
+	ok := &totalsTestDoc{totals: lines, lines: lines}
+	if err := checkTotals(context.Background(), ok); err != nil {
+		t.Fatalf("matching totals: %v", err)
+	}
+
+	stale := &totalsTestDoc{totals: DocumentTotals{Quantity: 20000, Amount: 1000}, lines: lines}
+	if err := checkTotals(context.Background(), stale); err != nil {
+		t.Fatalf("fix (default): %v", err)
+	}
+	if stale.totals != lines {
+		t.Errorf("totals = %+v, want %+v", stale.totals, lines)
+	}
+
+	ctx := settings.WithResolver(context.Background(), settings.NewResolver(totalsSettings(settings.TotalsMismatchReject)))
+	stale = &totalsTestDoc{totals: DocumentTotals{Quantity: 20000, Amount: 1000}, lines: lines}
+	err := checkTotals(ctx, stale)
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) || appErr.Code != "TOTALS_MISMATCH" {
+		t.Fatalf("reject: err = %v, want TOTALS_MISMATCH", err)
+	}
+	if stale.totals.Amount != 1000 {
+		t.Errorf("rejected document was modified: %+v", stale.totals)
+	}
+}
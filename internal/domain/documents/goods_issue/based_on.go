@@ -0,0 +1,71 @@
+package goods_issue
+
+import (
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/domain/basedon"
+	"metapus/internal/domain/documents/sales_order"
+)
+
+// FromSalesOrder fills a new goods issue shipping what is still open on a
+// confirmed sales order. open maps order line IDs to the quantity still to
+// ship in base units (see customer_order.LineFulfillment.Open); lines with
+// nothing open are left out. Lines keep the order prices, amounts of
+// partially shipped lines are prorated.
+func FromSalesOrder(order *sales_order.SalesOrder, open map[id.ID]types.Quantity) (*GoodsIssue, error) {
+	if !order.Posted {
+		return nil, apperror.NewBusinessRule("SALES_ORDER_NOT_POSTED",
+			"goods can be shipped only against a confirmed sales order").
+			WithDetail("orderId", order.ID.String())
+	}
+	if order.Closed {
+		return nil, apperror.NewBusinessRule("SALES_ORDER_CLOSED",
+			"goods cannot be shipped against a closed sales order").
+			WithDetail("orderId", order.ID.String())
+	}
+
+	doc := NewGoodsIssue(order.OrganizationID, order.CounterpartyID, order.WarehouseID)
+	doc.Date = time.Now()
+	doc.ContractID = order.ContractID
+	doc.CurrencyID = order.CurrencyID
+	doc.AmountIncludesVAT = order.AmountIncludesVAT
+	doc.CustomerOrderNumber = order.Number
+	orderDate := order.Date
+	doc.CustomerOrderDate = &orderDate
+	doc.BasisType = sales_order.DocumentType
+	doc.BasisID = &order.ID
+
+	for _, ol := range order.Lines {
+		qty := min(basedon.InLineUnits(open[ol.LineID], ol.Coefficient), ol.Quantity)
+		if !qty.IsPositive() {
+			continue
+		}
+		orderLineID := ol.LineID
+		doc.Lines = append(doc.Lines, GoodsIssueLine{
+			LineID:          id.New(),
+			LineNo:          len(doc.Lines) + 1,
+			NomenclatureID:  ol.NomenclatureID,
+			UnitID:          ol.UnitID,
+			Coefficient:     ol.Coefficient,
+			Quantity:        qty,
+			UnitPrice:       ol.UnitPrice,
+			DiscountPercent: ol.DiscountPercent,
+			DiscountAmount:  basedon.Prorate(ol.DiscountAmount, qty, ol.Quantity),
+			VATRateID:       ol.VATRateID,
+			VATAmount:       basedon.Prorate(ol.VATAmount, qty, ol.Quantity),
+			Amount:          basedon.Prorate(ol.Amount, qty, ol.Quantity),
+			OrderLineID:     &orderLineID,
+		})
+	}
+	if len(doc.Lines) == 0 {
+		return nil, apperror.NewBusinessRule("SALES_ORDER_SHIPPED",
+			"everything ordered has already been shipped").
+			WithDetail("orderId", order.ID.String())
+	}
+
+	doc.recalculateTotals()
+	return doc, nil
+}
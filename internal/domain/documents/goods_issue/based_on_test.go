@@ -0,0 +1,71 @@
+package goods_issue
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/domain/documents/sales_order"
+)
+
+func qty(n int64) types.Quantity { return types.NewQuantityFromInt64Scaled(n * types.QuantityScale) }
+
+func TestFromSalesOrder(t *testing.T) {
+	order := sales_order.NewSalesOrder(id.New(), id.New(), id.New())
+	order.Number = "SO-1"
+	order.Posted = true
+	// 10 boxes of 12 pcs at 1200 with 20% VAT, and 5 pcs at 100.
+	order.AddLine(id.New(), id.New(), decimal.NewFromInt(12), qty(10), 1200, id.New(), 20, decimal.Zero)
+	order.AddLine(id.New(), id.New(), decimal.NewFromInt(1), qty(5), 100, id.New(), 0, decimal.Zero)
+	boxes, pieces := order.Lines[0], order.Lines[1]
+
+	// 4 boxes (48 pcs) are still open, the second line is shipped.
+	doc, err := FromSalesOrder(order, map[id.ID]types.Quantity{boxes.LineID: qty(48), pieces.LineID: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.BasisType != sales_order.DocumentType || doc.BasisID == nil || *doc.BasisID != order.ID {
+		t.Fatalf("basis = %s %v", doc.BasisType, doc.BasisID)
+	}
+	if doc.CustomerOrderNumber != "SO-1" || doc.WarehouseID != order.WarehouseID {
+		t.Fatalf("header not taken from the order: %+v", doc)
+	}
+	if len(doc.Lines) != 1 {
+		t.Fatalf("lines = %d, want 1", len(doc.Lines))
+	}
+	line := doc.Lines[0]
+	if line.Quantity != qty(4) || line.OrderLineID == nil || *line.OrderLineID != boxes.LineID {
+		t.Fatalf("line = %v of %v", line.Quantity, line.OrderLineID)
+	}
+	if line.Amount != boxes.Amount*4/10 || line.VATAmount != boxes.VATAmount*4/10 {
+		t.Fatalf("amounts = %d/%d, want prorated %d/%d", line.Amount, line.VATAmount, boxes.Amount*4/10, boxes.VATAmount*4/10)
+	}
+	if doc.TotalAmount != line.Amount || doc.TotalQuantity != line.Quantity {
+		t.Fatalf("totals = %d/%v", doc.TotalAmount, doc.TotalQuantity)
+	}
+}
+
+func TestFromSalesOrderRejects(t *testing.T) {
+	order := sales_order.NewSalesOrder(id.New(), id.New(), id.New())
+	order.AddLine(id.New(), id.New(), decimal.NewFromInt(1), qty(1), 100, id.New(), 0, decimal.Zero)
+	open := map[id.ID]types.Quantity{order.Lines[0].LineID: qty(1)}
+
+	check := func(name, code string, open map[id.ID]types.Quantity) {
+		t.Helper()
+		_, err := FromSalesOrder(order, open)
+		appErr, ok := apperror.AsAppError(err)
+		if !ok || appErr.Code != code {
+			t.Fatalf("%s: got %v, want %s", name, err, code)
+		}
+	}
+	check("draft", "SALES_ORDER_NOT_POSTED", open)
+
+	order.Posted = true
+	check("shipped", "SALES_ORDER_SHIPPED", nil)
+
+	order.Closed = true
+	check("closed", "SALES_ORDER_CLOSED", open)
+}
@@ -12,6 +12,7 @@ import (
 	"metapus/internal/core/id"
 	"metapus/internal/core/types"
 	"metapus/internal/domain" // <--- Added import
+	"metapus/internal/domain/documents/sales_order"
 	"metapus/internal/domain/posting"
 )
 
@@ -36,6 +37,9 @@ type GoodsIssue struct {
 	CustomerOrderNumber string     `db:"customer_order_number" json:"customerOrderNumber,omitempty" meta:"label:№ заказа покупателя"`
 	CustomerOrderDate   *time.Time `db:"customer_order_date" json:"customerOrderDate,omitempty" meta:"label:Дата заказа покупателя"`
 
+	// Intercompany transfer this document is one side of (see package intercompany)
+	IntercompanyRef *string `db:"intercompany_ref" json:"intercompanyRef,omitempty" meta:"label:Межфирменная передача"`
+
 	// Currency support trait
 	entity.CurrencyAware
 
@@ -43,9 +47,13 @@ type GoodsIssue struct {
 	AmountIncludesVAT bool `db:"amount_includes_vat" json:"amountIncludesVat" meta:"label:Сумма включает НДС"`
 
 	// Totals (calculated from lines)
-	TotalQuantity types.Quantity   `db:"total_quantity" json:"totalQuantity" meta:"label:Количество итого"`
-	TotalAmount   types.MinorUnits `db:"total_amount" json:"totalAmount" meta:"label:Сумма итого"`
-	TotalVAT      types.MinorUnits `db:"total_vat" json:"totalVat" meta:"label:НДС итого"`
+	TotalQuantity   types.Quantity   `db:"total_quantity" json:"totalQuantity" meta:"label:Количество итого"`
+	TotalAmount     types.MinorUnits `db:"total_amount" json:"totalAmount" meta:"label:Сумма итого"`
+	TotalVAT        types.MinorUnits `db:"total_vat" json:"totalVat" meta:"label:НДС итого"`
+	TotalAmountBase types.MinorUnits `db:"total_amount_base" json:"totalAmountBase" meta:"label:Сумма итого в базовой валюте"`
+
+	// Exchange rate frozen on posting
+	entity.PostedRate
 
 	// Table part: issued goods
 	Lines []GoodsIssueLine `db:"-" json:"lines" meta:"label:Товары"`
@@ -82,6 +90,12 @@ type GoodsIssueLine struct {
 
 	// Total amount for this line
 	Amount types.MinorUnits `db:"amount" json:"amount" meta:"label:Сумма"`
+
+	// Sales order line this line is shipped against (basis must be the order)
+	OrderLineID *id.ID `db:"order_line_id" json:"orderLineId,omitempty" meta:"label:Строка заказа"`
+
+	// Serial numbers of the units (nomenclature tracked by serial number)
+	Serials []string `db:"serials" json:"serials,omitempty" meta:"label:Серийные номера"`
 }
 
 // NewGoodsIssue creates a new goods issue document.
@@ -170,16 +184,45 @@ func (g *GoodsIssue) AddLine(
 	g.recalculateTotals()
 }
 
+// Total returns the document total with the document currency attached.
+func (g *GoodsIssue) Total() types.Money {
+	return types.NewMoney(g.TotalAmount, g.CurrencyID)
+}
+
+// VATTotal returns the document VAT total with the document currency attached.
+func (g *GoodsIssue) VATTotal() types.Money {
+	return types.NewMoney(g.TotalVAT, g.CurrencyID)
+}
+
+// SetTotalAmountBase implements domain.BaseAmountDoc.
+func (g *GoodsIssue) SetTotalAmountBase(amount types.MinorUnits) {
+	g.TotalAmountBase = amount
+}
+
+// recalculateTotals updates document totals from lines.
 func (g *GoodsIssue) recalculateTotals() {
-	g.TotalQuantity = types.Quantity(0)
-	g.TotalAmount = types.MinorUnits(0)
-	g.TotalVAT = types.MinorUnits(0)
+	g.SetTotals(g.LineTotals())
+}
+
+// Totals implements domain.TotalsDoc.
+func (g *GoodsIssue) Totals() domain.DocumentTotals {
+	return domain.DocumentTotals{Quantity: g.TotalQuantity, Amount: g.TotalAmount, VAT: g.TotalVAT}
+}
 
+// LineTotals implements domain.TotalsDoc.
+func (g *GoodsIssue) LineTotals() domain.DocumentTotals {
+	var t domain.DocumentTotals
 	for _, line := range g.Lines {
-		g.TotalQuantity += line.Quantity
-		g.TotalAmount += line.Amount
-		g.TotalVAT += line.VATAmount
+		t.Quantity += line.Quantity
+		t.Amount += line.Amount
+		t.VAT += line.VATAmount
 	}
+	return t
+}
+
+// SetTotals implements domain.TotalsDoc.
+func (g *GoodsIssue) SetTotals(t domain.DocumentTotals) {
+	g.TotalQuantity, g.TotalAmount, g.TotalVAT = t.Quantity, t.Amount, t.VAT
 }
 
 // Validate implements entity.Validatable.
@@ -207,8 +250,59 @@ func (g *GoodsIssue) Validate(ctx context.Context) error {
 			WithDetail("field", "warehouseId")
 	}
 
+	if g.BasisType != sales_order.DocumentType || g.BasisID == nil {
+		for i, line := range g.Lines {
+			if line.OrderLineID != nil {
+				return apperror.NewValidation("order line requires a sales order basis").
+					WithDetail("field", "lines").
+					WithDetail("line", i+1)
+			}
+		}
+	}
+
 	// Common line validation strategy
-	return domain.ValidateDocumentLines(g.Lines)
+	if err := domain.ValidateDocumentLines(g.Lines); err != nil {
+		return err
+	}
+	return domain.ValidateSerialLines(g.Lines)
+}
+
+// ValidateOrderLines checks the links to lines of the basis sales order:
+// the order must be confirmed and not closed, and every linked line must
+// belong to it and be for the same nomenclature.
+func (g *GoodsIssue) ValidateOrderLines(order *sales_order.SalesOrder) error {
+	orderLines := make(map[id.ID]id.ID, len(order.Lines))
+	for _, ol := range order.Lines {
+		orderLines[ol.LineID] = ol.NomenclatureID
+	}
+
+	for i, line := range g.Lines {
+		if line.OrderLineID == nil {
+			continue
+		}
+		if !order.Posted {
+			return apperror.NewBusinessRule("SALES_ORDER_NOT_POSTED",
+				"goods can be shipped only against a confirmed sales order").
+				WithDetail("orderId", order.ID.String())
+		}
+		if order.Closed {
+			return apperror.NewBusinessRule("SALES_ORDER_CLOSED",
+				"goods cannot be shipped against a closed sales order").
+				WithDetail("orderId", order.ID.String())
+		}
+		nomenclatureID, ok := orderLines[*line.OrderLineID]
+		if !ok {
+			return apperror.NewValidation("order line not found in the sales order").
+				WithDetail("field", "lines").
+				WithDetail("line", i+1)
+		}
+		if nomenclatureID != line.NomenclatureID {
+			return apperror.NewValidation("nomenclature differs from the order line").
+				WithDetail("field", "lines").
+				WithDetail("line", i+1)
+		}
+	}
+	return nil
 }
 
 // --- LinesAccessor implementation ---
@@ -241,6 +335,14 @@ func (l GoodsIssueLine) GetCoefficient() decimal.Decimal { return l.Coefficient
 func (l GoodsIssueLine) GetQuantity() types.Quantity     { return l.Quantity }
 func (l GoodsIssueLine) GetVATRateID() id.ID             { return l.VATRateID }
 
+// GetSerials implements domain.SerialLine.
+func (l GoodsIssueLine) GetSerials() []string { return l.Serials }
+
+// BaseQuantity returns the line quantity in base units.
+func (l GoodsIssueLine) BaseQuantity() types.Quantity {
+	return l.Quantity.MulCoefficient(l.Coefficient)
+}
+
 // --- OrganizationOwned implementation ---
 
 // GetOrganizationID implements domain.OrganizationOwned.
@@ -250,11 +352,12 @@ func (g *GoodsIssue) GetOrganizationID() id.ID {
 
 // --- RLSDimensionable override ---
 
-// GetRLSDimensions overrides entity.Document to add organization + customer dimensions.
+// GetRLSDimensions overrides entity.Document to add organization + customer + warehouse dimensions.
 func (g *GoodsIssue) GetRLSDimensions() map[string]string {
 	return map[string]string{
 		"organization": g.OrganizationID.String(),
 		"counterparty": g.CounterpartyID.String(),
+		"warehouse":    g.WarehouseID.String(),
 	}
 }
 
@@ -271,8 +374,7 @@ func (g *GoodsIssue) GenerateStockMovements(ctx context.Context) ([]entity.Stock
 
 	for _, line := range g.Lines {
 		// Convert to base unit quantity: Quantity * Coefficient
-		baseQtyDecimal := decimal.NewFromInt(line.Quantity.Int64Scaled()).Mul(line.Coefficient)
-		baseQty := types.NewQuantityFromInt64Scaled(baseQtyDecimal.IntPart())
+		baseQty := line.BaseQuantity()
 
 		movements = append(movements, entity.NewStockMovement(
 			g.ID,
@@ -289,10 +391,125 @@ func (g *GoodsIssue) GenerateStockMovements(ctx context.Context) ([]entity.Stock
 	return movements, nil
 }
 
+// GenerateCostMovements implements posting.CostMovementSource.
+// Creates unpriced write-offs — the cost register prices them by FIFO layers.
+func (g *GoodsIssue) GenerateCostMovements(ctx context.Context) ([]entity.CostMovement, error) {
+	newVersion := g.PostedVersion + 1
+	movements := make([]entity.CostMovement, 0, len(g.Lines))
+
+	for _, line := range g.Lines {
+		baseQty := line.BaseQuantity()
+
+		movements = append(movements, entity.NewCostWriteOff(
+			g.ID,
+			g.GetDocumentType(),
+			newVersion,
+			g.Date,
+			g.WarehouseID,
+			line.NomenclatureID,
+			baseQty,
+		))
+	}
+
+	return movements, nil
+}
+
+// GenerateStockReservationMovements implements posting.StockReservationMovementSource.
+// A goods issue based on a sales order creates EXPENSE movements that release
+// the order's reservation; the register caps them at what is still reserved.
+func (g *GoodsIssue) GenerateStockReservationMovements(ctx context.Context) ([]entity.StockReservationMovement, error) {
+	if g.BasisType != sales_order.DocumentType || g.BasisID == nil {
+		return nil, nil
+	}
+
+	newVersion := g.PostedVersion + 1
+	movements := make([]entity.StockReservationMovement, 0, len(g.Lines))
+
+	for _, line := range g.Lines {
+		baseQty := line.BaseQuantity()
+
+		movements = append(movements, entity.NewStockReservationMovement(
+			g.ID,
+			g.GetDocumentType(),
+			newVersion,
+			g.Date,
+			entity.RecordTypeExpense,
+			*g.BasisID,
+			g.WarehouseID,
+			line.NomenclatureID,
+			baseQty,
+		))
+	}
+
+	return movements, nil
+}
+
+// GenerateSalesOrderMovements implements posting.SalesOrderMovementSource.
+// A goods issue based on a sales order creates EXPENSE movements for lines
+// linked to order lines; the register caps them at the open quantity.
+func (g *GoodsIssue) GenerateSalesOrderMovements(ctx context.Context) ([]entity.SalesOrderMovement, error) {
+	if g.BasisType != sales_order.DocumentType || g.BasisID == nil {
+		return nil, nil
+	}
+
+	newVersion := g.PostedVersion + 1
+	movements := make([]entity.SalesOrderMovement, 0, len(g.Lines))
+
+	for _, line := range g.Lines {
+		if line.OrderLineID == nil {
+			continue
+		}
+		baseQty := line.BaseQuantity()
+
+		movements = append(movements, entity.NewSalesOrderMovement(
+			g.ID,
+			g.GetDocumentType(),
+			newVersion,
+			g.Date,
+			entity.RecordTypeExpense,
+			*g.BasisID,
+			*line.OrderLineID,
+			line.NomenclatureID,
+			baseQty,
+		))
+	}
+
+	return movements, nil
+}
+
+// GenerateSerialNumberMovements implements posting.SerialNumberMovementSource.
+// Creates EXPENSE movements — one per serial number issued.
+func (g *GoodsIssue) GenerateSerialNumberMovements(ctx context.Context) ([]entity.SerialNumberMovement, error) {
+	newVersion := g.PostedVersion + 1
+	var movements []entity.SerialNumberMovement
+
+	for _, line := range g.Lines {
+		for _, serial := range line.Serials {
+			movements = append(movements, entity.NewSerialNumberMovement(
+				g.ID,
+				g.GetDocumentType(),
+				newVersion,
+				g.Date,
+				entity.RecordTypeExpense,
+				g.WarehouseID,
+				line.NomenclatureID,
+				serial,
+			))
+		}
+	}
+
+	return movements, nil
+}
+
 // GetLineCount implements posting.LineCounter for pre-allocation.
 func (g *GoodsIssue) GetLineCount() int { return len(g.Lines) }
 
 // Ensure interface compliance at compile time.
 var _ posting.Postable = (*GoodsIssue)(nil)
+var _ domain.TotalsDoc = (*GoodsIssue)(nil)
 var _ posting.StockMovementSource = (*GoodsIssue)(nil)
+var _ posting.CostMovementSource = (*GoodsIssue)(nil)
+var _ posting.StockReservationMovementSource = (*GoodsIssue)(nil)
+var _ posting.SalesOrderMovementSource = (*GoodsIssue)(nil)
+var _ posting.SerialNumberMovementSource = (*GoodsIssue)(nil)
 var _ posting.LineCounter = (*GoodsIssue)(nil)
@@ -0,0 +1,63 @@
+package goods_receipt
+
+import (
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/domain/basedon"
+	"metapus/internal/domain/documents/purchase_order"
+)
+
+// FromPurchaseOrder fills a new goods receipt for what is still expected on
+// a posted purchase order. remaining maps order line IDs to the quantity
+// still expected in base units (see supplier_order.LineFulfillment.Remaining);
+// lines with nothing remaining are left out. Lines keep the order prices,
+// amounts of partially received lines are prorated.
+func FromPurchaseOrder(order *purchase_order.PurchaseOrder, remaining map[id.ID]types.Quantity) (*GoodsReceipt, error) {
+	if !order.Posted {
+		return nil, apperror.NewBusinessRule("PURCHASE_ORDER_NOT_POSTED",
+			"goods can be received only against a posted purchase order").
+			WithDetail("orderId", order.ID.String())
+	}
+
+	doc := NewGoodsReceipt(order.OrganizationID, order.CounterpartyID, order.WarehouseID)
+	doc.Date = time.Now()
+	doc.ContractID = order.ContractID
+	doc.CurrencyID = order.CurrencyID
+	doc.AmountIncludesVAT = order.AmountIncludesVAT
+	doc.BasisType = purchase_order.DocumentType
+	doc.BasisID = &order.ID
+
+	for _, ol := range order.Lines {
+		qty := min(basedon.InLineUnits(remaining[ol.LineID], ol.Coefficient), ol.Quantity)
+		if !qty.IsPositive() {
+			continue
+		}
+		orderLineID := ol.LineID
+		doc.Lines = append(doc.Lines, GoodsReceiptLine{
+			LineID:          id.New(),
+			LineNo:          len(doc.Lines) + 1,
+			NomenclatureID:  ol.NomenclatureID,
+			UnitID:          ol.UnitID,
+			Coefficient:     ol.Coefficient,
+			Quantity:        qty,
+			UnitPrice:       ol.UnitPrice,
+			DiscountPercent: ol.DiscountPercent,
+			DiscountAmount:  basedon.Prorate(ol.DiscountAmount, qty, ol.Quantity),
+			VATRateID:       ol.VATRateID,
+			VATAmount:       basedon.Prorate(ol.VATAmount, qty, ol.Quantity),
+			Amount:          basedon.Prorate(ol.Amount, qty, ol.Quantity),
+			OrderLineID:     &orderLineID,
+		})
+	}
+	if len(doc.Lines) == 0 {
+		return nil, apperror.NewBusinessRule("PURCHASE_ORDER_RECEIVED",
+			"everything ordered has already been received").
+			WithDetail("orderId", order.ID.String())
+	}
+
+	doc.recalculateTotals()
+	return doc, nil
+}
@@ -12,6 +12,7 @@ import (
 	"metapus/internal/core/id"
 	"metapus/internal/core/types"
 	"metapus/internal/domain" // Add domain import for ValidateDocumentLines
+	"metapus/internal/domain/documents/purchase_order"
 	"metapus/internal/domain/posting"
 )
 
@@ -39,6 +40,9 @@ type GoodsReceipt struct {
 	// Internal incoming document registration number
 	IncomingNumber *string `db:"incoming_number" json:"incomingNumber,omitempty" meta:"label:№ вх. документа"`
 
+	// Intercompany transfer this document is one side of (see package intercompany)
+	IntercompanyRef *string `db:"intercompany_ref" json:"intercompanyRef,omitempty" meta:"label:Межфирменная передача"`
+
 	// Currency support trait
 	entity.CurrencyAware
 
@@ -46,9 +50,13 @@ type GoodsReceipt struct {
 	AmountIncludesVAT bool `db:"amount_includes_vat" json:"amountIncludesVat" meta:"label:Сумма включает НДС"`
 
 	// Totals (calculated from lines)
-	TotalQuantity types.Quantity   `db:"total_quantity" json:"totalQuantity" meta:"label:Количество итого"`
-	TotalAmount   types.MinorUnits `db:"total_amount" json:"totalAmount" meta:"label:Сумма итого"`
-	TotalVAT      types.MinorUnits `db:"total_vat" json:"totalVat" meta:"label:НДС итого"`
+	TotalQuantity   types.Quantity   `db:"total_quantity" json:"totalQuantity" meta:"label:Количество итого"`
+	TotalAmount     types.MinorUnits `db:"total_amount" json:"totalAmount" meta:"label:Сумма итого"`
+	TotalVAT        types.MinorUnits `db:"total_vat" json:"totalVat" meta:"label:НДС итого"`
+	TotalAmountBase types.MinorUnits `db:"total_amount_base" json:"totalAmountBase" meta:"label:Сумма итого в базовой валюте"`
+
+	// Exchange rate frozen on posting
+	entity.PostedRate
 
 	// Table part: received goods
 	Lines []GoodsReceiptLine `db:"-" json:"lines" meta:"label:Товары"`
@@ -86,6 +94,12 @@ type GoodsReceiptLine struct {
 
 	// Total amount for this line
 	Amount types.MinorUnits `db:"amount" json:"amount" meta:"label:Сумма"`
+
+	// Purchase order line this line is received against (basis must be the order)
+	OrderLineID *id.ID `db:"order_line_id" json:"orderLineId,omitempty" meta:"label:Строка заказа"`
+
+	// Serial numbers of the units (nomenclature tracked by serial number)
+	Serials []string `db:"serials" json:"serials,omitempty" meta:"label:Серийные номера"`
 }
 
 func NewGoodsReceipt(organizationID id.ID, counterpartyID, warehouseID id.ID) *GoodsReceipt {
@@ -174,17 +188,45 @@ func (g *GoodsReceipt) AddLine(
 	g.recalculateTotals()
 }
 
+// Total returns the document total with the document currency attached.
+func (g *GoodsReceipt) Total() types.Money {
+	return types.NewMoney(g.TotalAmount, g.CurrencyID)
+}
+
+// VATTotal returns the document VAT total with the document currency attached.
+func (g *GoodsReceipt) VATTotal() types.Money {
+	return types.NewMoney(g.TotalVAT, g.CurrencyID)
+}
+
+// SetTotalAmountBase implements domain.BaseAmountDoc.
+func (g *GoodsReceipt) SetTotalAmountBase(amount types.MinorUnits) {
+	g.TotalAmountBase = amount
+}
+
 // recalculateTotals updates document totals from lines.
 func (g *GoodsReceipt) recalculateTotals() {
-	g.TotalQuantity = types.Quantity(0)
-	g.TotalAmount = types.MinorUnits(0)
-	g.TotalVAT = types.MinorUnits(0)
+	g.SetTotals(g.LineTotals())
+}
+
+// Totals implements domain.TotalsDoc.
+func (g *GoodsReceipt) Totals() domain.DocumentTotals {
+	return domain.DocumentTotals{Quantity: g.TotalQuantity, Amount: g.TotalAmount, VAT: g.TotalVAT}
+}
 
+// LineTotals implements domain.TotalsDoc.
+func (g *GoodsReceipt) LineTotals() domain.DocumentTotals {
+	var t domain.DocumentTotals
 	for _, line := range g.Lines {
-		g.TotalQuantity += line.Quantity
-		g.TotalAmount += line.Amount
-		g.TotalVAT += line.VATAmount
+		t.Quantity += line.Quantity
+		t.Amount += line.Amount
+		t.VAT += line.VATAmount
 	}
+	return t
+}
+
+// SetTotals implements domain.TotalsDoc.
+func (g *GoodsReceipt) SetTotals(t domain.DocumentTotals) {
+	g.TotalQuantity, g.TotalAmount, g.TotalVAT = t.Quantity, t.Amount, t.VAT
 }
 
 // Validate implements entity.Validatable.
@@ -212,8 +254,54 @@ func (g *GoodsReceipt) Validate(ctx context.Context) error {
 			WithDetail("field", "warehouseId")
 	}
 
+	if g.BasisType != purchase_order.DocumentType || g.BasisID == nil {
+		for i, line := range g.Lines {
+			if line.OrderLineID != nil {
+				return apperror.NewValidation("order line requires a purchase order basis").
+					WithDetail("field", "lines").
+					WithDetail("line", i+1)
+			}
+		}
+	}
+
 	// Common line validation strategy
-	return domain.ValidateDocumentLines(g.Lines)
+	if err := domain.ValidateDocumentLines(g.Lines); err != nil {
+		return err
+	}
+	return domain.ValidateSerialLines(g.Lines)
+}
+
+// ValidateOrderLines checks the links to lines of the basis purchase order:
+// the order must be posted, and every linked line must belong to it and be
+// for the same nomenclature.
+func (g *GoodsReceipt) ValidateOrderLines(order *purchase_order.PurchaseOrder) error {
+	orderLines := make(map[id.ID]id.ID, len(order.Lines))
+	for _, ol := range order.Lines {
+		orderLines[ol.LineID] = ol.NomenclatureID
+	}
+
+	for i, line := range g.Lines {
+		if line.OrderLineID == nil {
+			continue
+		}
+		if !order.Posted {
+			return apperror.NewBusinessRule("PURCHASE_ORDER_NOT_POSTED",
+				"goods can be received only against a posted purchase order").
+				WithDetail("orderId", order.ID.String())
+		}
+		nomenclatureID, ok := orderLines[*line.OrderLineID]
+		if !ok {
+			return apperror.NewValidation("order line not found in the purchase order").
+				WithDetail("field", "lines").
+				WithDetail("line", i+1)
+		}
+		if nomenclatureID != line.NomenclatureID {
+			return apperror.NewValidation("nomenclature differs from the order line").
+				WithDetail("field", "lines").
+				WithDetail("line", i+1)
+		}
+	}
+	return nil
 }
 
 // --- LinesAccessor implementation ---
@@ -246,6 +334,14 @@ func (l GoodsReceiptLine) GetCoefficient() decimal.Decimal { return l.Coefficien
 func (l GoodsReceiptLine) GetQuantity() types.Quantity     { return l.Quantity }
 func (l GoodsReceiptLine) GetVATRateID() id.ID             { return l.VATRateID }
 
+// GetSerials implements domain.SerialLine.
+func (l GoodsReceiptLine) GetSerials() []string { return l.Serials }
+
+// BaseQuantity returns the line quantity in base units.
+func (l GoodsReceiptLine) BaseQuantity() types.Quantity {
+	return l.Quantity.MulCoefficient(l.Coefficient)
+}
+
 // --- OrganizationOwned implementation ---
 
 // GetOrganizationID implements domain.OrganizationOwned.
@@ -255,11 +351,12 @@ func (g *GoodsReceipt) GetOrganizationID() id.ID {
 
 // --- RLSDimensionable override ---
 
-// GetRLSDimensions overrides entity.Document to add organization + supplier dimensions.
+// GetRLSDimensions overrides entity.Document to add organization + supplier + warehouse dimensions.
 func (g *GoodsReceipt) GetRLSDimensions() map[string]string {
 	return map[string]string{
 		"organization": g.OrganizationID.String(),
 		"counterparty": g.CounterpartyID.String(),
+		"warehouse":    g.WarehouseID.String(),
 	}
 }
 
@@ -280,8 +377,7 @@ func (g *GoodsReceipt) GenerateStockMovements(ctx context.Context) ([]entity.Sto
 	for _, line := range g.Lines {
 		// Convert to base unit quantity: Quantity * Coefficient
 		// Quantity is scaled x10000 internally. Coefficient is decimal.
-		baseQtyDecimal := decimal.NewFromInt(line.Quantity.Int64Scaled()).Mul(line.Coefficient)
-		baseQty := types.NewQuantityFromInt64Scaled(baseQtyDecimal.IntPart())
+		baseQty := line.BaseQuantity()
 
 		movements = append(movements, entity.NewStockMovement(
 			g.ID,
@@ -306,12 +402,11 @@ func (g *GoodsReceipt) GenerateCostMovements(ctx context.Context) ([]entity.Cost
 
 	for _, line := range g.Lines {
 		// Base unit quantity: Quantity * Coefficient
-		baseQtyDecimal := decimal.NewFromInt(line.Quantity.Int64Scaled()).Mul(line.Coefficient)
-		baseQty := types.NewQuantityFromInt64Scaled(baseQtyDecimal.IntPart())
+		baseQty := line.BaseQuantity()
 
 		// Cost amount = line amount (total with VAT or without, depending on policy)
 		// For goods receipt, the cost is the line amount excluding VAT
-		costAmount := line.Amount - line.VATAmount
+		costAmount := types.NewMoney(line.Amount-line.VATAmount, g.CurrencyID)
 
 		movements = append(movements, entity.NewCostMovement(
 			g.ID,
@@ -321,7 +416,6 @@ func (g *GoodsReceipt) GenerateCostMovements(ctx context.Context) ([]entity.Cost
 			entity.RecordTypeReceipt,
 			g.WarehouseID,
 			line.NomenclatureID,
-			g.CurrencyID,
 			baseQty,
 			costAmount,
 		))
@@ -347,19 +441,78 @@ func (g *GoodsReceipt) GenerateSettlementMovements(ctx context.Context) ([]entit
 		entity.RecordTypeReceipt,
 		g.CounterpartyID,
 		g.ContractID,
-		g.CurrencyID,
-		g.TotalAmount,
+		g.Total(),
 	)
 
 	return []entity.SettlementMovement{movement}, nil
 }
 
+// GeneratePurchaseOrderMovements implements posting.PurchaseOrderMovementSource.
+// A goods receipt based on a purchase order creates EXPENSE movements for lines
+// linked to order lines; the register caps them at what is still expected.
+func (g *GoodsReceipt) GeneratePurchaseOrderMovements(ctx context.Context) ([]entity.PurchaseOrderMovement, error) {
+	if g.BasisType != purchase_order.DocumentType || g.BasisID == nil {
+		return nil, nil
+	}
+
+	newVersion := g.PostedVersion + 1
+	movements := make([]entity.PurchaseOrderMovement, 0, len(g.Lines))
+
+	for _, line := range g.Lines {
+		if line.OrderLineID == nil {
+			continue
+		}
+		baseQty := line.BaseQuantity()
+
+		movements = append(movements, entity.NewPurchaseOrderMovement(
+			g.ID,
+			g.GetDocumentType(),
+			newVersion,
+			g.Date,
+			entity.RecordTypeExpense,
+			*g.BasisID,
+			*line.OrderLineID,
+			line.NomenclatureID,
+			baseQty,
+		))
+	}
+
+	return movements, nil
+}
+
+// GenerateSerialNumberMovements implements posting.SerialNumberMovementSource.
+// Creates RECEIPT movements — one per serial number received.
+func (g *GoodsReceipt) GenerateSerialNumberMovements(ctx context.Context) ([]entity.SerialNumberMovement, error) {
+	newVersion := g.PostedVersion + 1
+	var movements []entity.SerialNumberMovement
+
+	for _, line := range g.Lines {
+		for _, serial := range line.Serials {
+			movements = append(movements, entity.NewSerialNumberMovement(
+				g.ID,
+				g.GetDocumentType(),
+				newVersion,
+				g.Date,
+				entity.RecordTypeReceipt,
+				g.WarehouseID,
+				line.NomenclatureID,
+				serial,
+			))
+		}
+	}
+
+	return movements, nil
+}
+
 // GetLineCount implements posting.LineCounter for pre-allocation.
 func (g *GoodsReceipt) GetLineCount() int { return len(g.Lines) }
 
 // Ensure interface compliance at compile time.
 var _ posting.Postable = (*GoodsReceipt)(nil)
+var _ domain.TotalsDoc = (*GoodsReceipt)(nil)
 var _ posting.StockMovementSource = (*GoodsReceipt)(nil)
 var _ posting.CostMovementSource = (*GoodsReceipt)(nil)
 var _ posting.SettlementMovementSource = (*GoodsReceipt)(nil)
+var _ posting.PurchaseOrderMovementSource = (*GoodsReceipt)(nil)
+var _ posting.SerialNumberMovementSource = (*GoodsReceipt)(nil)
 var _ posting.LineCounter = (*GoodsReceipt)(nil)
@@ -0,0 +1,9 @@
+package goods_transfer
+
+import "metapus/internal/core/numerator"
+
+const (
+	// NumeratorStrategy defines the numbering strategy for this document type.
+	// GoodsTransfer is an internal document, gaps in numbering are acceptable.
+	NumeratorStrategy = numerator.StrategyCached
+)
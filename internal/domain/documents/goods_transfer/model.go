@@ -0,0 +1,277 @@
+// Package goods_transfer provides the GoodsTransfer document.
+// A goods transfer moves goods between warehouses of an organization,
+// optionally through a transit warehouse while the goods are on the way.
+package goods_transfer
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/domain"
+	"metapus/internal/domain/posting"
+)
+
+// DocumentType is the document type of goods transfers.
+const DocumentType = "GoodsTransfer"
+
+// GoodsTransfer represents an inter-warehouse transfer document.
+//
+// Without a transit warehouse goods leave the source and arrive at the
+// destination at the document date. With a transit warehouse they stay in
+// transit until ReceivedAt is set; the transfer is then reposted and the
+// goods move from transit to the destination at ReceivedAt.
+type GoodsTransfer struct {
+	entity.Document
+
+	// OrganizationID is the owning organization (required for multi-org ERP)
+	OrganizationID id.ID `db:"organization_id" json:"organizationId" meta:"label:Организация"`
+
+	// Warehouse goods are shipped from
+	SourceWarehouseID id.ID `db:"source_warehouse_id" json:"sourceWarehouseId" meta:"label:Склад-отправитель"`
+
+	// Warehouse goods are shipped to
+	DestinationWarehouseID id.ID `db:"destination_warehouse_id" json:"destinationWarehouseId" meta:"label:Склад-получатель"`
+
+	// Warehouse of type "transit" holding goods on the way (optional)
+	TransitWarehouseID *id.ID `db:"transit_warehouse_id" json:"transitWarehouseId,omitempty" meta:"label:Склад в пути"`
+
+	// Moment goods arrived at the destination (transit transfers only)
+	ReceivedAt *time.Time `db:"received_at" json:"receivedAt,omitempty" meta:"label:Дата поступления"`
+
+	// Totals (calculated from lines)
+	TotalQuantity types.Quantity `db:"total_quantity" json:"totalQuantity" meta:"label:Количество итого"`
+
+	// Table part: transferred goods
+	Lines []GoodsTransferLine `db:"-" json:"lines" meta:"label:Товары"`
+}
+
+// GoodsTransferLine represents a line in the goods transfer.
+type GoodsTransferLine struct {
+	// Line identification
+	LineID id.ID `db:"line_id" json:"lineId"`
+	LineNo int   `db:"line_no" json:"lineNo" meta:"label:№ строки"`
+
+	// Product reference
+	NomenclatureID id.ID `db:"nomenclature_id" json:"nomenclatureId" meta:"label:Номенклатура"`
+
+	// Unit of measurement (e.g., box, pallet)
+	UnitID id.ID `db:"unit_id" json:"unitId" meta:"label:Единица"`
+
+	// Coefficient for conversion to base unit (e.g., 12 if 1 box = 12 pcs)
+	Coefficient decimal.Decimal `db:"coefficient" json:"coefficient" meta:"label:Коэффициент"`
+
+	// Quantity in UnitID
+	Quantity types.Quantity `db:"quantity" json:"quantity" meta:"label:Количество"`
+}
+
+// NewGoodsTransfer creates a new goods transfer document.
+func NewGoodsTransfer(organizationID, sourceWarehouseID, destinationWarehouseID id.ID) *GoodsTransfer {
+	return &GoodsTransfer{
+		Document:               entity.NewDocument(),
+		OrganizationID:         organizationID,
+		SourceWarehouseID:      sourceWarehouseID,
+		DestinationWarehouseID: destinationWarehouseID,
+		Lines:                  make([]GoodsTransferLine, 0),
+	}
+}
+
+// AddLine adds a line to the goods transfer and recalculates totals.
+func (g *GoodsTransfer) AddLine(
+	nomenclatureID id.ID,
+	unitID id.ID,
+	coefficient decimal.Decimal,
+	quantity types.Quantity,
+) {
+	// Ensure coefficient is at least 1
+	if coefficient.LessThanOrEqual(decimal.Zero) {
+		coefficient = decimal.NewFromInt(1)
+	}
+
+	g.Lines = append(g.Lines, GoodsTransferLine{
+		LineID:         id.New(),
+		LineNo:         len(g.Lines) + 1,
+		NomenclatureID: nomenclatureID,
+		UnitID:         unitID,
+		Coefficient:    coefficient,
+		Quantity:       quantity,
+	})
+	g.recalculateTotals()
+}
+
+func (g *GoodsTransfer) recalculateTotals() {
+	g.SetTotals(g.LineTotals())
+}
+
+// Totals implements domain.TotalsDoc (a transfer keeps no amounts).
+func (g *GoodsTransfer) Totals() domain.DocumentTotals {
+	return domain.DocumentTotals{Quantity: g.TotalQuantity}
+}
+
+// LineTotals implements domain.TotalsDoc.
+func (g *GoodsTransfer) LineTotals() domain.DocumentTotals {
+	var t domain.DocumentTotals
+	for _, line := range g.Lines {
+		t.Quantity += line.Quantity
+	}
+	return t
+}
+
+// SetTotals implements domain.TotalsDoc.
+func (g *GoodsTransfer) SetTotals(t domain.DocumentTotals) {
+	g.TotalQuantity = t.Quantity
+}
+
+// InTransit reports whether goods have left the source but not yet arrived.
+func (g *GoodsTransfer) InTransit() bool {
+	return g.TransitWarehouseID != nil && g.ReceivedAt == nil
+}
+
+// Validate implements entity.Validatable.
+func (g *GoodsTransfer) Validate(ctx context.Context) error {
+	if err := g.Document.Validate(ctx); err != nil {
+		return err
+	}
+
+	if id.IsNil(g.OrganizationID) {
+		return apperror.NewValidation("organization is required").
+			WithDetail("field", "organizationId")
+	}
+
+	if id.IsNil(g.SourceWarehouseID) {
+		return apperror.NewValidation("source warehouse is required").
+			WithDetail("field", "sourceWarehouseId")
+	}
+
+	if id.IsNil(g.DestinationWarehouseID) {
+		return apperror.NewValidation("destination warehouse is required").
+			WithDetail("field", "destinationWarehouseId")
+	}
+
+	if g.SourceWarehouseID == g.DestinationWarehouseID {
+		return apperror.NewValidation("source and destination warehouses must differ").
+			WithDetail("field", "destinationWarehouseId")
+	}
+
+	if g.TransitWarehouseID != nil {
+		if *g.TransitWarehouseID == g.SourceWarehouseID || *g.TransitWarehouseID == g.DestinationWarehouseID {
+			return apperror.NewValidation("transit warehouse must differ from source and destination").
+				WithDetail("field", "transitWarehouseId")
+		}
+		if g.ReceivedAt != nil && g.ReceivedAt.Before(g.Date) {
+			return apperror.NewValidation("received date cannot be before document date").
+				WithDetail("field", "receivedAt")
+		}
+	} else if g.ReceivedAt != nil {
+		return apperror.NewValidation("received date requires a transit warehouse").
+			WithDetail("field", "receivedAt")
+	}
+
+	return domain.ValidateStockLines(g.Lines)
+}
+
+// --- LinesAccessor implementation ---
+
+// GetLines returns the document lines (defensive copy).
+func (g *GoodsTransfer) GetLines() []GoodsTransferLine {
+	out := make([]GoodsTransferLine, len(g.Lines))
+	copy(out, g.Lines)
+	return out
+}
+
+// SetLines replaces the document lines (defensive copy).
+func (g *GoodsTransfer) SetLines(lines []GoodsTransferLine) {
+	g.Lines = make([]GoodsTransferLine, len(lines))
+	copy(g.Lines, lines)
+}
+
+// --- ValidatableStockLine implementation for GoodsTransferLine ---
+
+func (l GoodsTransferLine) GetNomenclatureID() id.ID        { return l.NomenclatureID }
+func (l GoodsTransferLine) GetUnitID() id.ID                { return l.UnitID }
+func (l GoodsTransferLine) GetCoefficient() decimal.Decimal { return l.Coefficient }
+func (l GoodsTransferLine) GetQuantity() types.Quantity     { return l.Quantity }
+
+// BaseQuantity returns the line quantity in base units.
+func (l GoodsTransferLine) BaseQuantity() types.Quantity {
+	return l.Quantity.MulCoefficient(l.Coefficient)
+}
+
+// --- CurrencyAwareDoc stubs (a transfer has no amounts) ---
+
+func (g *GoodsTransfer) GetCurrencyID() id.ID                     { return id.ID{} }
+func (g *GoodsTransfer) SetCurrencyID(_ id.ID)                    {}
+func (g *GoodsTransfer) ValidateCurrency(_ context.Context) error { return nil }
+func (g *GoodsTransfer) GetContractID() *id.ID                    { return nil }
+
+// --- OrganizationOwned implementation ---
+
+// GetOrganizationID implements domain.OrganizationOwned.
+func (g *GoodsTransfer) GetOrganizationID() id.ID {
+	return g.OrganizationID
+}
+
+// --- RLSDimensionable override ---
+
+// GetRLSDimensions overrides entity.Document to add the organization dimension.
+func (g *GoodsTransfer) GetRLSDimensions() map[string]string {
+	return map[string]string{
+		"organization": g.OrganizationID.String(),
+	}
+}
+
+// --- Postable interface implementation ---
+// GetID, GetPostedVersion, IsPosted, CanPost, MarkPosted, MarkUnposted are inherited from entity.Document
+
+func (g *GoodsTransfer) GetDocumentType() string { return DocumentType }
+
+// GenerateStockMovements implements posting.StockMovementSource.
+// Creates paired EXPENSE/RECEIPT movements per leg — quantity in base units:
+// line.Quantity * line.Coefficient.
+//
+//   - no transit warehouse: source → destination at Date;
+//   - in transit: source → transit at Date;
+//   - received: source → transit at Date, transit → destination at ReceivedAt.
+func (g *GoodsTransfer) GenerateStockMovements(ctx context.Context) ([]entity.StockMovement, error) {
+	newVersion := g.PostedVersion + 1
+	movements := make([]entity.StockMovement, 0, len(g.Lines)*4)
+
+	leg := func(period time.Time, from, to id.ID, nomenclatureID id.ID, qty types.Quantity) {
+		movements = append(movements,
+			entity.NewStockMovement(g.ID, g.GetDocumentType(), newVersion, period,
+				entity.RecordTypeExpense, from, nomenclatureID, qty),
+			entity.NewStockMovement(g.ID, g.GetDocumentType(), newVersion, period,
+				entity.RecordTypeReceipt, to, nomenclatureID, qty),
+		)
+	}
+
+	for _, line := range g.Lines {
+		baseQty := line.BaseQuantity()
+
+		if g.TransitWarehouseID == nil {
+			leg(g.Date, g.SourceWarehouseID, g.DestinationWarehouseID, line.NomenclatureID, baseQty)
+			continue
+		}
+
+		leg(g.Date, g.SourceWarehouseID, *g.TransitWarehouseID, line.NomenclatureID, baseQty)
+		if g.ReceivedAt != nil {
+			leg(*g.ReceivedAt, *g.TransitWarehouseID, g.DestinationWarehouseID, line.NomenclatureID, baseQty)
+		}
+	}
+
+	return movements, nil
+}
+
+// GetLineCount implements posting.LineCounter for pre-allocation.
+func (g *GoodsTransfer) GetLineCount() int { return len(g.Lines) }
+
+// Ensure interface compliance at compile time.
+var _ posting.Postable = (*GoodsTransfer)(nil)
+var _ domain.TotalsDoc = (*GoodsTransfer)(nil)
+var _ posting.StockMovementSource = (*GoodsTransfer)(nil)
+var _ posting.LineCounter = (*GoodsTransfer)(nil)
@@ -0,0 +1,66 @@
+package goods_transfer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+)
+
+func TestGenerateStockMovementsTransitLegs(t *testing.T) {
+	ctx := context.Background()
+	src, dst, transit, nom := id.New(), id.New(), id.New(), id.New()
+
+	doc := NewGoodsTransfer(id.New(), src, dst)
+	doc.Date = time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	doc.AddLine(nom, id.New(), decimal.NewFromInt(12), types.NewQuantityFromInt64Scaled(2*types.QuantityScale))
+	baseQty := types.NewQuantityFromInt64Scaled(24 * types.QuantityScale)
+
+	type leg struct {
+		rt entity.RecordType
+		wh id.ID
+	}
+	check := func(name string, want []leg) {
+		t.Helper()
+		if err := doc.Validate(ctx); err != nil {
+			t.Fatalf("%s: Validate: %v", name, err)
+		}
+		got, err := doc.GenerateStockMovements(ctx)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%s: got %d movements, want %d", name, len(got), len(want))
+		}
+		for i, m := range got {
+			if m.RecordType != want[i].rt || m.WarehouseID != want[i].wh || m.Quantity != baseQty {
+				t.Errorf("%s: movement %d = %s %v qty %v", name, i, m.RecordType, m.WarehouseID, m.Quantity)
+			}
+		}
+	}
+
+	check("direct", []leg{{entity.RecordTypeExpense, src}, {entity.RecordTypeReceipt, dst}})
+
+	doc.TransitWarehouseID = &transit
+	if !doc.InTransit() {
+		t.Fatal("InTransit = false without receivedAt")
+	}
+	check("in transit", []leg{{entity.RecordTypeExpense, src}, {entity.RecordTypeReceipt, transit}})
+
+	received := doc.Date.Add(48 * time.Hour)
+	doc.ReceivedAt = &received
+	check("received", []leg{
+		{entity.RecordTypeExpense, src}, {entity.RecordTypeReceipt, transit},
+		{entity.RecordTypeExpense, transit}, {entity.RecordTypeReceipt, dst},
+	})
+
+	doc.TransitWarehouseID = &src
+	if err := doc.Validate(ctx); err == nil {
+		t.Fatal("Validate accepted the source warehouse as transit")
+	}
+}
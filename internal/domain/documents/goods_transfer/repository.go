@@ -0,0 +1,24 @@
+package goods_transfer
+
+import (
+	"context"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain"
+)
+
+// Repository defines operations for goods transfer documents.
+type Repository interface {
+	Create(ctx context.Context, doc *GoodsTransfer) error
+	GetByID(ctx context.Context, docID id.ID) (*GoodsTransfer, error)
+	GetByNumber(ctx context.Context, number string) (*GoodsTransfer, error)
+	Update(ctx context.Context, doc *GoodsTransfer) error
+	Delete(ctx context.Context, docID id.ID) error
+
+	GetLines(ctx context.Context, docID id.ID) ([]GoodsTransferLine, error)
+	SaveLines(ctx context.Context, docID id.ID, lines []GoodsTransferLine) error
+
+	// List operations — uses universal filter engine via domain.ListFilter.AdvancedFilters
+	List(ctx context.Context, filter domain.ListFilter) (domain.CursorListResult[*GoodsTransfer], error)
+	ListIDs(ctx context.Context, filter domain.ListFilter, maxIDs int) ([]id.ID, error)
+}
@@ -0,0 +1,74 @@
+package goods_transfer
+
+import (
+	"context"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/numerator"
+	"metapus/internal/core/tx"
+	"metapus/internal/domain"
+	"metapus/internal/domain/catalogs/warehouse"
+	"metapus/internal/domain/posting"
+)
+
+// WarehouseGetter loads warehouses to check the transit warehouse type.
+type WarehouseGetter interface {
+	GetByID(ctx context.Context, id id.ID) (*warehouse.Warehouse, error)
+}
+
+// Service provides business operations for goods transfer documents.
+// Embeds BaseDocumentService for common CRUD + posting logic.
+type Service struct {
+	*domain.BaseDocumentService[*GoodsTransfer, GoodsTransferLine]
+	warehouses WarehouseGetter
+}
+
+// NewService creates a new goods transfer service.
+// In Database-per-Tenant, TxManager is obtained from context.
+func NewService(
+	repo Repository,
+	postingEngine *posting.Engine,
+	num numerator.Generator,
+	txManager tx.Manager,
+	warehouses WarehouseGetter,
+) *Service {
+	base := domain.NewBaseDocumentService(domain.BaseDocumentServiceConfig[*GoodsTransfer, GoodsTransferLine]{
+		Repo:              repo,
+		PostingEngine:     postingEngine,
+		Numerator:         num,
+		TxManager:         txManager,
+		NumeratorPrefix:   "GT",
+		NumeratorStrategy: NumeratorStrategy,
+		EntityName:        "goods_transfer",
+	})
+	s := &Service{BaseDocumentService: base, warehouses: warehouses}
+
+	base.GetHooks().OnBeforeCreate(s.validateTransitWarehouse)
+	base.GetHooks().OnBeforeUpdate(s.validateTransitWarehouse)
+
+	return s
+}
+
+// Hooks returns the hook registry for registering callbacks.
+func (s *Service) Hooks() *domain.HookRegistry[*GoodsTransfer] {
+	return s.GetHooks()
+}
+
+// validateTransitWarehouse ensures goods in transit are kept in a warehouse
+// of type "transit", so they never count as available stock of a real warehouse.
+func (s *Service) validateTransitWarehouse(ctx context.Context, doc *GoodsTransfer) error {
+	if doc.TransitWarehouseID == nil || s.warehouses == nil {
+		return nil
+	}
+
+	wh, err := s.warehouses.GetByID(ctx, *doc.TransitWarehouseID)
+	if err != nil {
+		return err
+	}
+	if wh.Type != warehouse.TypeTransit {
+		return apperror.NewValidation("transit warehouse must be of type transit").
+			WithDetail("field", "transitWarehouseId")
+	}
+	return nil
+}
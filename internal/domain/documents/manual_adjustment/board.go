@@ -0,0 +1,23 @@
+package manual_adjustment
+
+import "metapus/internal/domain/board"
+
+// BoardStateApproved is the board state of an approved, not yet posted adjustment.
+const BoardStateApproved = "approved"
+
+// BoardWorkflow is the status board workflow of manual adjustments:
+// draft → approved (four-eyes approval) → posted.
+func BoardWorkflow() *board.Workflow {
+	return &board.Workflow{
+		States: []board.State{
+			{Key: board.StateDraft, Label: "Черновик"},
+			{Key: BoardStateApproved, Label: "Утверждён"},
+			{Key: board.StatePosted, Label: "Проведён"},
+		},
+		Transitions: []board.Transition{
+			{From: board.StateDraft, To: BoardStateApproved, Action: board.ActionApprove},
+			{From: BoardStateApproved, To: board.StatePosted, Action: board.ActionPost},
+			{From: board.StatePosted, To: BoardStateApproved, Action: board.ActionUnpost},
+		},
+	}
+}
@@ -0,0 +1,9 @@
+package manual_adjustment
+
+import "metapus/internal/core/numerator"
+
+const (
+	// NumeratorStrategy defines the numbering strategy for this document type.
+	// Adjustments are audited corrections, so gaps must not appear: Strict strategy.
+	NumeratorStrategy = numerator.StrategyStrict
+)
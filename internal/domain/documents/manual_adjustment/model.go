@@ -0,0 +1,330 @@
+// Package manual_adjustment provides the ManualAdjustment document.
+//
+// A manual adjustment lets a privileged user enter arbitrary register
+// movements (stock, cost, settlements) to correct data issues without SQL
+// access. Every adjustment carries a mandatory reason and must be approved
+// by a second user before it can be posted. Movements are recorded through
+// the standard posting engine under recorder type "ManualAdjustment", so
+// journals and reports can always tell them apart from business documents.
+package manual_adjustment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/domain/posting"
+)
+
+// DocumentType is the recorder type written to register movements.
+const DocumentType = "ManualAdjustment"
+
+// Register identifies the accumulation register an adjustment line targets.
+type Register string
+
+const (
+	RegisterStock      Register = "stock"      // reg_stock_movements
+	RegisterCost       Register = "cost"       // reg_cost_movements
+	RegisterSettlement Register = "settlement" // reg_settlement_movements
+)
+
+// IsValid reports whether r is a supported register.
+func (r Register) IsValid() bool {
+	switch r {
+	case RegisterStock, RegisterCost, RegisterSettlement:
+		return true
+	}
+	return false
+}
+
+// ManualAdjustment represents a register movement correction document.
+type ManualAdjustment struct {
+	entity.Document
+
+	// OrganizationID is the owning organization
+	OrganizationID id.ID `db:"organization_id" json:"organizationId" meta:"label:Организация"`
+
+	// Reason explains why the correction is needed (required)
+	Reason string `db:"reason" json:"reason" meta:"label:Основание корректировки"`
+
+	// Currency of cost and settlement amounts
+	entity.CurrencyAware
+
+	// Approval (four-eyes): set by a user other than the author,
+	// cleared whenever the document is edited.
+	ApprovedBy *id.ID     `db:"approved_by" json:"approvedBy,omitempty" meta:"label:Утвердил"`
+	ApprovedAt *time.Time `db:"approved_at" json:"approvedAt,omitempty" meta:"label:Дата утверждения"`
+
+	// Table part: register movements
+	Lines []ManualAdjustmentLine `db:"-" json:"lines" meta:"label:Движения"`
+}
+
+// ManualAdjustmentLine is one movement to record in a register.
+// Which dimensions and resources are required depends on Register.
+type ManualAdjustmentLine struct {
+	LineID id.ID `db:"line_id" json:"lineId"`
+	LineNo int   `db:"line_no" json:"lineNo" meta:"label:№ строки"`
+
+	Register   Register          `db:"register" json:"register" meta:"label:Регистр"`
+	RecordType entity.RecordType `db:"record_type" json:"recordType" meta:"label:Вид движения"`
+
+	// Stock / cost dimensions
+	WarehouseID    *id.ID `db:"warehouse_id" json:"warehouseId,omitempty" meta:"label:Склад"`
+	NomenclatureID *id.ID `db:"nomenclature_id" json:"nomenclatureId,omitempty" meta:"label:Номенклатура"`
+
+	// Settlement dimensions
+	CounterpartyID *id.ID `db:"counterparty_id" json:"counterpartyId,omitempty" meta:"label:Контрагент"`
+	ContractID     *id.ID `db:"contract_id" json:"contractId,omitempty" meta:"label:Договор"`
+
+	// Resources
+	Quantity types.Quantity   `db:"quantity" json:"quantity" meta:"label:Количество"`
+	Amount   types.MinorUnits `db:"amount" json:"amount" meta:"label:Сумма"`
+}
+
+// NewManualAdjustment creates a new manual adjustment document.
+func NewManualAdjustment(organizationID id.ID, reason string) *ManualAdjustment {
+	return &ManualAdjustment{
+		Document:       entity.NewDocument(),
+		OrganizationID: organizationID,
+		Reason:         reason,
+		Lines:          make([]ManualAdjustmentLine, 0),
+	}
+}
+
+// AddLine appends a line and assigns its number.
+func (a *ManualAdjustment) AddLine(line ManualAdjustmentLine) {
+	line.LineID = id.New()
+	line.LineNo = len(a.Lines) + 1
+	a.Lines = append(a.Lines, line)
+}
+
+// IsApproved reports whether the document has been approved.
+func (a *ManualAdjustment) IsApproved() bool {
+	return a.ApprovedBy != nil && !id.IsNil(*a.ApprovedBy)
+}
+
+// Approve records approval by userID at the given time.
+// The author cannot approve their own adjustment.
+func (a *ManualAdjustment) Approve(userID id.ID, at time.Time) error {
+	if a.IsDeletionMarked() {
+		return apperror.NewBusinessRule("MANUAL_ADJUSTMENT_DELETED", "deleted adjustment cannot be approved")
+	}
+	if a.Posted {
+		return apperror.NewBusinessRule("MANUAL_ADJUSTMENT_POSTED", "posted adjustment cannot be approved; unpost it first")
+	}
+	if a.IsApproved() {
+		return apperror.NewBusinessRule("MANUAL_ADJUSTMENT_APPROVED", "adjustment is already approved")
+	}
+	if !id.IsNil(a.CreatedBy) && a.CreatedBy == userID {
+		return apperror.NewBusinessRule("MANUAL_ADJUSTMENT_SELF_APPROVAL", "adjustment must be approved by a user other than its author")
+	}
+	at = at.UTC()
+	a.ApprovedBy = &userID
+	a.ApprovedAt = &at
+	return nil
+}
+
+// ClearApproval drops the approval (called on every edit).
+func (a *ManualAdjustment) ClearApproval() {
+	a.ApprovedBy = nil
+	a.ApprovedAt = nil
+}
+
+// Validate implements entity.Validatable.
+func (a *ManualAdjustment) Validate(ctx context.Context) error {
+	if err := a.Document.Validate(ctx); err != nil {
+		return err
+	}
+
+	if id.IsNil(a.OrganizationID) {
+		return apperror.NewValidation("organization is required").
+			WithDetail("field", "organizationId")
+	}
+
+	if strings.TrimSpace(a.Reason) == "" {
+		return apperror.NewValidation("reason is required").
+			WithDetail("field", "reason")
+	}
+
+	if err := a.ValidateCurrency(ctx); err != nil {
+		return err
+	}
+
+	if len(a.Lines) == 0 {
+		return apperror.NewValidation("at least one line is required").
+			WithDetail("field", "lines")
+	}
+	for i, line := range a.Lines {
+		if err := line.validate(); err != nil {
+			return err.WithDetail("line", i+1)
+		}
+	}
+	return nil
+}
+
+func (l ManualAdjustmentLine) validate() *apperror.AppError {
+	if !l.Register.IsValid() {
+		return apperror.NewValidation(fmt.Sprintf("unknown register %q", l.Register)).
+			WithDetail("field", "register")
+	}
+	if l.RecordType != entity.RecordTypeReceipt && l.RecordType != entity.RecordTypeExpense {
+		return apperror.NewValidation("record type must be receipt or expense").
+			WithDetail("field", "recordType")
+	}
+	if l.Quantity.IsNegative() || l.Amount.IsNegative() {
+		return apperror.NewValidation("quantity and amount must not be negative; use the expense record type").
+			WithDetail("field", "quantity")
+	}
+
+	switch l.Register {
+	case RegisterStock, RegisterCost:
+		if l.WarehouseID == nil || id.IsNil(*l.WarehouseID) {
+			return apperror.NewValidation("warehouse is required").
+				WithDetail("field", "warehouseId")
+		}
+		if l.NomenclatureID == nil || id.IsNil(*l.NomenclatureID) {
+			return apperror.NewValidation("nomenclature is required").
+				WithDetail("field", "nomenclatureId")
+		}
+		if l.Register == RegisterStock && !l.Quantity.IsPositive() {
+			return apperror.NewValidation("quantity must be positive").
+				WithDetail("field", "quantity")
+		}
+		if l.Register == RegisterCost && l.Quantity.IsZero() && l.Amount.IsZero() {
+			return apperror.NewValidation("quantity or amount is required").
+				WithDetail("field", "amount")
+		}
+	case RegisterSettlement:
+		if l.CounterpartyID == nil || id.IsNil(*l.CounterpartyID) {
+			return apperror.NewValidation("counterparty is required").
+				WithDetail("field", "counterpartyId")
+		}
+		if !l.Amount.IsPositive() {
+			return apperror.NewValidation("amount must be positive").
+				WithDetail("field", "amount")
+		}
+	}
+	return nil
+}
+
+// --- LinesAccessor implementation ---
+
+// GetLines returns the document lines (defensive copy).
+func (a *ManualAdjustment) GetLines() []ManualAdjustmentLine {
+	out := make([]ManualAdjustmentLine, len(a.Lines))
+	copy(out, a.Lines)
+	return out
+}
+
+// SetLines replaces the document lines (defensive copy).
+func (a *ManualAdjustment) SetLines(lines []ManualAdjustmentLine) {
+	a.Lines = make([]ManualAdjustmentLine, len(lines))
+	copy(a.Lines, lines)
+}
+
+// --- CurrencyAwareDoc implementation ---
+
+// GetContractID returns nil: the currency is never taken from a contract.
+func (a *ManualAdjustment) GetContractID() *id.ID {
+	return nil
+}
+
+// --- OrganizationOwned implementation ---
+
+// GetOrganizationID implements domain.OrganizationOwned.
+func (a *ManualAdjustment) GetOrganizationID() id.ID {
+	return a.OrganizationID
+}
+
+// --- RLSDimensionable override ---
+
+// GetRLSDimensions overrides entity.Document to add the organization dimension.
+func (a *ManualAdjustment) GetRLSDimensions() map[string]string {
+	return map[string]string{
+		"organization": a.OrganizationID.String(),
+	}
+}
+
+// --- Postable interface implementation ---
+
+func (a *ManualAdjustment) GetDocumentType() string { return DocumentType }
+
+// CanPost overrides entity.Document: besides the lifecycle state and the
+// full document invariants, posting requires an approval.
+func (a *ManualAdjustment) CanPost(ctx context.Context) error {
+	if err := a.State().CanPost(); err != nil {
+		return err
+	}
+	if err := a.Validate(ctx); err != nil {
+		return err
+	}
+	if !a.IsApproved() {
+		return apperror.NewBusinessRule("MANUAL_ADJUSTMENT_NOT_APPROVED", "adjustment must be approved before posting")
+	}
+	return nil
+}
+
+// GenerateStockMovements implements posting.StockMovementSource.
+func (a *ManualAdjustment) GenerateStockMovements(ctx context.Context) ([]entity.StockMovement, error) {
+	newVersion := a.PostedVersion + 1
+	var movements []entity.StockMovement
+	for _, line := range a.Lines {
+		if line.Register != RegisterStock {
+			continue
+		}
+		movements = append(movements, entity.NewStockMovement(
+			a.ID, DocumentType, newVersion, a.Date, line.RecordType,
+			*line.WarehouseID, *line.NomenclatureID, line.Quantity,
+		))
+	}
+	return movements, nil
+}
+
+// GenerateCostMovements implements posting.CostMovementSource.
+func (a *ManualAdjustment) GenerateCostMovements(ctx context.Context) ([]entity.CostMovement, error) {
+	newVersion := a.PostedVersion + 1
+	var movements []entity.CostMovement
+	for _, line := range a.Lines {
+		if line.Register != RegisterCost {
+			continue
+		}
+		movements = append(movements, entity.NewCostMovement(
+			a.ID, DocumentType, newVersion, a.Date, line.RecordType,
+			*line.WarehouseID, *line.NomenclatureID, line.Quantity,
+			types.NewMoney(line.Amount, a.CurrencyID),
+		))
+	}
+	return movements, nil
+}
+
+// GenerateSettlementMovements implements posting.SettlementMovementSource.
+func (a *ManualAdjustment) GenerateSettlementMovements(ctx context.Context) ([]entity.SettlementMovement, error) {
+	newVersion := a.PostedVersion + 1
+	var movements []entity.SettlementMovement
+	for _, line := range a.Lines {
+		if line.Register != RegisterSettlement {
+			continue
+		}
+		movements = append(movements, entity.NewSettlementMovement(
+			a.ID, DocumentType, newVersion, a.Date, line.RecordType,
+			*line.CounterpartyID, line.ContractID,
+			types.NewMoney(line.Amount, a.CurrencyID),
+		))
+	}
+	return movements, nil
+}
+
+// GetLineCount implements posting.LineCounter for pre-allocation.
+func (a *ManualAdjustment) GetLineCount() int { return len(a.Lines) }
+
+// Ensure interface compliance at compile time.
+var _ posting.Postable = (*ManualAdjustment)(nil)
+var _ posting.StockMovementSource = (*ManualAdjustment)(nil)
+var _ posting.CostMovementSource = (*ManualAdjustment)(nil)
+var _ posting.SettlementMovementSource = (*ManualAdjustment)(nil)
+var _ posting.LineCounter = (*ManualAdjustment)(nil)
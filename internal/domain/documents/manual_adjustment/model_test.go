@@ -0,0 +1,91 @@
+package manual_adjustment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+)
+
+func newTestAdjustment() *ManualAdjustment {
+	warehouseID, nomenclatureID, counterpartyID := id.New(), id.New(), id.New()
+	doc := NewManualAdjustment(id.New(), "inventory count 2026-09")
+	doc.CurrencyID = id.New()
+	doc.CreatedBy = id.New()
+	doc.AddLine(ManualAdjustmentLine{
+		Register: RegisterStock, RecordType: entity.RecordTypeReceipt,
+		WarehouseID: &warehouseID, NomenclatureID: &nomenclatureID,
+		Quantity: types.NewQuantityFromInt64Scaled(3 * types.QuantityScale),
+	})
+	doc.AddLine(ManualAdjustmentLine{
+		Register: RegisterSettlement, RecordType: entity.RecordTypeExpense,
+		CounterpartyID: &counterpartyID, Amount: 1500,
+	})
+	return doc
+}
+
+func TestCanPostRequiresApprovalByAnotherUser(t *testing.T) {
+	ctx := context.Background()
+	doc := newTestAdjustment()
+
+	if err := doc.CanPost(ctx); !isCode(err, "MANUAL_ADJUSTMENT_NOT_APPROVED") {
+		t.Fatalf("CanPost before approval = %v, want MANUAL_ADJUSTMENT_NOT_APPROVED", err)
+	}
+	if err := doc.Approve(doc.CreatedBy, time.Now()); !isCode(err, "MANUAL_ADJUSTMENT_SELF_APPROVAL") {
+		t.Fatalf("self approval = %v, want MANUAL_ADJUSTMENT_SELF_APPROVAL", err)
+	}
+	if err := doc.Approve(id.New(), time.Now()); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if err := doc.CanPost(ctx); err != nil {
+		t.Fatalf("CanPost after approval = %v", err)
+	}
+
+	doc.ClearApproval()
+	if doc.IsApproved() || doc.ApprovedAt != nil {
+		t.Fatal("ClearApproval left approval fields set")
+	}
+}
+
+func TestValidateRequiresReasonAndLineDimensions(t *testing.T) {
+	ctx := context.Background()
+
+	doc := newTestAdjustment()
+	doc.Reason = "  "
+	if err := doc.Validate(ctx); err == nil {
+		t.Fatal("Validate accepted an empty reason")
+	}
+
+	doc = newTestAdjustment()
+	doc.Lines[1].CounterpartyID = nil
+	if err := doc.Validate(ctx); err == nil {
+		t.Fatal("Validate accepted a settlement line without counterparty")
+	}
+}
+
+func TestMovementsAreRoutedByRegister(t *testing.T) {
+	ctx := context.Background()
+	doc := newTestAdjustment()
+
+	stock, _ := doc.GenerateStockMovements(ctx)
+	cost, _ := doc.GenerateCostMovements(ctx)
+	settlements, _ := doc.GenerateSettlementMovements(ctx)
+	if len(stock) != 1 || len(cost) != 0 || len(settlements) != 1 {
+		t.Fatalf("movements stock=%d cost=%d settlement=%d, want 1/0/1", len(stock), len(cost), len(settlements))
+	}
+	if stock[0].RecorderType != DocumentType || settlements[0].RecorderType != DocumentType {
+		t.Fatalf("recorder types %q/%q, want %q", stock[0].RecorderType, settlements[0].RecorderType, DocumentType)
+	}
+	if settlements[0].RecordType != entity.RecordTypeExpense || settlements[0].CurrencyID != doc.CurrencyID {
+		t.Fatalf("settlement movement = %+v", settlements[0])
+	}
+}
+
+func isCode(err error, code string) bool {
+	appErr, ok := apperror.AsAppError(err)
+	return ok && appErr.Code == code
+}
@@ -0,0 +1,24 @@
+package manual_adjustment
+
+import (
+	"context"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain"
+)
+
+// Repository defines operations for manual adjustment documents.
+type Repository interface {
+	Create(ctx context.Context, doc *ManualAdjustment) error
+	GetByID(ctx context.Context, docID id.ID) (*ManualAdjustment, error)
+	GetByNumber(ctx context.Context, number string) (*ManualAdjustment, error)
+	Update(ctx context.Context, doc *ManualAdjustment) error
+	Delete(ctx context.Context, docID id.ID) error
+
+	GetLines(ctx context.Context, docID id.ID) ([]ManualAdjustmentLine, error)
+	SaveLines(ctx context.Context, docID id.ID, lines []ManualAdjustmentLine) error
+
+	// List operations — uses universal filter engine via domain.ListFilter.AdvancedFilters
+	List(ctx context.Context, filter domain.ListFilter) (domain.CursorListResult[*ManualAdjustment], error)
+	ListIDs(ctx context.Context, filter domain.ListFilter, maxIDs int) ([]id.ID, error)
+}
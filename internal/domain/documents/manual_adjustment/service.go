@@ -0,0 +1,95 @@
+package manual_adjustment
+
+import (
+	"context"
+	"fmt"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/clock"
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/id"
+	"metapus/internal/core/numerator"
+	"metapus/internal/core/security"
+	"metapus/internal/core/tx"
+	"metapus/internal/domain"
+	"metapus/internal/domain/posting"
+)
+
+// Service provides business operations for manual adjustment documents.
+// Embeds BaseDocumentService for common CRUD + posting logic and adds Approve.
+type Service struct {
+	*domain.BaseDocumentService[*ManualAdjustment, ManualAdjustmentLine]
+}
+
+// NewService creates a new manual adjustment service.
+// In Database-per-Tenant, TxManager is obtained from context.
+func NewService(
+	repo Repository,
+	postingEngine *posting.Engine,
+	num numerator.Generator,
+	txManager tx.Manager,
+	currencyStrategy domain.CurrencyResolveStrategy,
+) *Service {
+	base := domain.NewBaseDocumentService(domain.BaseDocumentServiceConfig[*ManualAdjustment, ManualAdjustmentLine]{
+		Repo:              repo,
+		PostingEngine:     postingEngine,
+		Numerator:         num,
+		TxManager:         txManager,
+		CurrencyResolver:  currencyStrategy,
+		NumeratorPrefix:   "MA",
+		NumeratorStrategy: NumeratorStrategy,
+		EntityName:        "manual_adjustment",
+	})
+
+	// Any edit invalidates a previous approval: the approver signed off on
+	// exactly the movements that were there at the time.
+	base.GetHooks().OnBeforeUpdate(func(ctx context.Context, doc *ManualAdjustment) error {
+		doc.ClearApproval()
+		return nil
+	})
+
+	return &Service{BaseDocumentService: base}
+}
+
+// Hooks returns the hook registry for registering callbacks.
+func (s *Service) Hooks() *domain.HookRegistry[*ManualAdjustment] {
+	return s.GetHooks()
+}
+
+// Approve records the current user's approval of an unposted adjustment.
+func (s *Service) Approve(ctx context.Context, docID id.ID) (*ManualAdjustment, error) {
+	if err := security.GetDataScope(ctx).CanMutate(); err != nil {
+		return nil, err
+	}
+
+	userID, err := id.Parse(appctx.GetUserID(ctx))
+	if err != nil || id.IsNil(userID) {
+		return nil, apperror.NewUnauthorized("authentication required")
+	}
+
+	doc, err := s.GetByID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.Validate(ctx); err != nil {
+		return nil, err
+	}
+	if err := doc.Approve(userID, clock.Now(ctx)); err != nil {
+		return nil, err
+	}
+
+	txm, err := s.GetTxManager(ctx)
+	if err != nil {
+		return nil, apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := s.Repo.Update(ctx, doc); err != nil {
+			return fmt.Errorf("update document: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
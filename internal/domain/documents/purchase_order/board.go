@@ -0,0 +1,24 @@
+package purchase_order
+
+import "metapus/internal/domain/board"
+
+// BoardStateDraft is the board state of a purchase order that is not posted;
+// posted orders are shown by their fulfillment status.
+const BoardStateDraft = "draft"
+
+// BoardWorkflow is the status board workflow of purchase orders.
+// Fulfillment states are reached by posting goods receipts, not by board moves.
+func BoardWorkflow() *board.Workflow {
+	return &board.Workflow{
+		States: []board.State{
+			{Key: BoardStateDraft, Label: "Черновик"},
+			{Key: FulfillmentOpen, Label: "Ожидает поступления"},
+			{Key: FulfillmentPartial, Label: "Поступил частично"},
+			{Key: FulfillmentReceived, Label: "Поступил"},
+		},
+		Transitions: []board.Transition{
+			{From: BoardStateDraft, To: FulfillmentOpen, Action: board.ActionPost},
+			{From: FulfillmentOpen, To: BoardStateDraft, Action: board.ActionUnpost},
+		},
+	}
+}
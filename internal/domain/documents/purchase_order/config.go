@@ -0,0 +1,9 @@
+package purchase_order
+
+import "metapus/internal/core/numerator"
+
+const (
+	// NumeratorStrategy defines the numbering strategy for this document type.
+	// PurchaseOrder numbers are sent to suppliers, so we use Strict strategy.
+	NumeratorStrategy = numerator.StrategyStrict
+)
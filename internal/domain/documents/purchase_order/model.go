@@ -0,0 +1,378 @@
+// Package purchase_order provides the PurchaseOrder document.
+// A posted purchase order records goods expected from a supplier; goods
+// receipts created on its basis are linked to order lines and fulfill it.
+package purchase_order
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/domain"
+	"metapus/internal/domain/posting"
+)
+
+// PurchaseOrder represents an order to a supplier.
+// FulfillmentStatus is maintained by the purchase order register when
+// linked goods receipts are posted or unposted; it is never written by Update.
+type PurchaseOrder struct {
+	entity.Document
+
+	// OrganizationID is the owning organization (required for multi-org ERP)
+	OrganizationID id.ID `db:"organization_id" json:"organizationId" meta:"label:Организация"`
+
+	// Counterparty reference (role: supplier)
+	CounterpartyID id.ID `db:"counterparty_id" json:"counterpartyId" meta:"label:Поставщик"`
+
+	// Contract / Agreement reference
+	ContractID *id.ID `db:"contract_id" json:"contractId,omitempty" meta:"label:Договор"`
+
+	// Warehouse goods are expected at
+	WarehouseID id.ID `db:"warehouse_id" json:"warehouseId" meta:"label:Склад"`
+
+	// Expected delivery date (default for lines without their own date)
+	ExpectedDate *time.Time `db:"expected_date" json:"expectedDate,omitempty" meta:"label:Дата поступления"`
+
+	// Fulfillment status (read-only, maintained by posting of goods receipts)
+	FulfillmentStatus string `db:"fulfillment_status" json:"fulfillmentStatus" meta:"label:Состояние"`
+
+	// Currency support trait
+	entity.CurrencyAware
+
+	// AmountIncludesVAT indicates whether prices are VAT-inclusive (gross) or VAT-exclusive (net)
+	AmountIncludesVAT bool `db:"amount_includes_vat" json:"amountIncludesVat" meta:"label:Сумма включает НДС"`
+
+	// Totals (calculated from lines)
+	TotalQuantity   types.Quantity   `db:"total_quantity" json:"totalQuantity" meta:"label:Количество итого"`
+	TotalAmount     types.MinorUnits `db:"total_amount" json:"totalAmount" meta:"label:Сумма итого"`
+	TotalVAT        types.MinorUnits `db:"total_vat" json:"totalVat" meta:"label:НДС итого"`
+	TotalAmountBase types.MinorUnits `db:"total_amount_base" json:"totalAmountBase" meta:"label:Сумма итого в базовой валюте"`
+
+	// Exchange rate frozen on posting
+	entity.PostedRate
+
+	// Table part: ordered goods
+	Lines []PurchaseOrderLine `db:"-" json:"lines" meta:"label:Товары"`
+}
+
+// PurchaseOrderLine represents a line in the purchase order.
+type PurchaseOrderLine struct {
+	// Line identification
+	LineID id.ID `db:"line_id" json:"lineId"`
+	LineNo int   `db:"line_no" json:"lineNo" meta:"label:№ строки"`
+
+	// Product reference
+	NomenclatureID id.ID `db:"nomenclature_id" json:"nomenclatureId" meta:"label:Номенклатура"`
+
+	// Unit of measurement (e.g., box, pallet)
+	UnitID id.ID `db:"unit_id" json:"unitId" meta:"label:Единица"`
+
+	// Coefficient for conversion to base unit (e.g., 12 if 1 box = 12 pcs)
+	Coefficient decimal.Decimal `db:"coefficient" json:"coefficient" meta:"label:Коэффициент"`
+
+	// Quantity in UnitID
+	Quantity types.Quantity `db:"quantity" json:"quantity" meta:"label:Количество"`
+
+	// Expected delivery date of this line (overrides the header date)
+	ExpectedDate *time.Time `db:"expected_date" json:"expectedDate,omitempty" meta:"label:Дата поступления"`
+
+	// Price per UnitID (in minor units)
+	UnitPrice types.MinorUnits `db:"unit_price" json:"unitPrice" meta:"label:Цена"`
+
+	// Discount
+	DiscountPercent decimal.Decimal  `db:"discount_percent" json:"discountPercent" meta:"label:Скидка %"`
+	DiscountAmount  types.MinorUnits `db:"discount_amount" json:"discountAmount" meta:"label:Скидка сумма"`
+
+	// VAT (reference to cat_vat_rates)
+	VATRateID id.ID            `db:"vat_rate_id" json:"vatRateId" meta:"label:Ставка НДС"`
+	VATAmount types.MinorUnits `db:"vat_amount" json:"vatAmount" meta:"label:Сумма НДС"`
+
+	// Total amount for this line
+	Amount types.MinorUnits `db:"amount" json:"amount" meta:"label:Сумма"`
+}
+
+// NewPurchaseOrder creates a new purchase order document.
+func NewPurchaseOrder(organizationID id.ID, counterpartyID, warehouseID id.ID) *PurchaseOrder {
+	return &PurchaseOrder{
+		Document:          entity.NewDocument(),
+		OrganizationID:    organizationID,
+		CounterpartyID:    counterpartyID,
+		WarehouseID:       warehouseID,
+		FulfillmentStatus: FulfillmentOpen,
+		AmountIncludesVAT: false,
+		Lines:             make([]PurchaseOrderLine, 0),
+	}
+}
+
+// AddLine adds a line to the purchase order and recalculates totals.
+func (g *PurchaseOrder) AddLine(
+	nomenclatureID id.ID,
+	unitID id.ID,
+	coefficient decimal.Decimal,
+	quantity types.Quantity,
+	unitPrice types.MinorUnits,
+	vatRateID id.ID,
+	vatPercent int,
+	discountPercent decimal.Decimal,
+) {
+	lineNo := len(g.Lines) + 1
+
+	// Ensure coefficient is at least 1
+	if coefficient.LessThanOrEqual(decimal.Zero) {
+		coefficient = decimal.NewFromInt(1)
+	}
+
+	// All intermediate calculations use decimal.Decimal to avoid truncation.
+	// Final results are rounded to nearest integer (banker's rounding).
+	scaleDec := decimal.NewFromInt(types.QuantityScale)
+	qtyDec := decimal.NewFromInt(quantity.Int64Scaled())
+	priceDec := decimal.NewFromInt(int64(unitPrice))
+
+	// baseAmount = quantity * unitPrice (quantity is scaled by 10000)
+	baseAmountDec := qtyDec.Mul(priceDec).Div(scaleDec)
+
+	// Apply discount
+	discountAmountDec := decimal.Zero
+	if discountPercent.IsPositive() {
+		discountAmountDec = baseAmountDec.Mul(discountPercent).Div(decimal.NewFromInt(100))
+	}
+	netAmountDec := baseAmountDec.Sub(discountAmountDec)
+	discountAmount := types.MinorUnits(discountAmountDec.Round(0).IntPart())
+	netAmount := types.MinorUnits(netAmountDec.Round(0).IntPart())
+
+	// Calculate VAT based on AmountIncludesVAT flag
+	var vatAmount types.MinorUnits
+	var totalAmount types.MinorUnits
+	vatPercentDec := decimal.NewFromInt(int64(vatPercent))
+	if g.AmountIncludesVAT {
+		// Price includes VAT: extract VAT from net amount
+		// vatAmount = netAmount * vatPercent / (100 + vatPercent)
+		if vatPercent > 0 {
+			vatAmountDec := netAmountDec.Mul(vatPercentDec).Div(decimal.NewFromInt(int64(100 + vatPercent)))
+			vatAmount = types.MinorUnits(vatAmountDec.Round(0).IntPart())
+		}
+		totalAmount = netAmount
+	} else {
+		// Price excludes VAT: add VAT on top
+		vatAmountDec := netAmountDec.Mul(vatPercentDec).Div(decimal.NewFromInt(100))
+		vatAmount = types.MinorUnits(vatAmountDec.Round(0).IntPart())
+		totalAmount = netAmount + vatAmount
+	}
+
+	line := PurchaseOrderLine{
+		LineID:          id.New(),
+		LineNo:          lineNo,
+		NomenclatureID:  nomenclatureID,
+		UnitID:          unitID,
+		Coefficient:     coefficient,
+		Quantity:        quantity,
+		UnitPrice:       unitPrice,
+		DiscountPercent: discountPercent,
+		DiscountAmount:  discountAmount,
+		VATRateID:       vatRateID,
+		VATAmount:       vatAmount,
+		Amount:          totalAmount,
+	}
+
+	g.Lines = append(g.Lines, line)
+	g.recalculateTotals()
+}
+
+// Total returns the document total with the document currency attached.
+func (g *PurchaseOrder) Total() types.Money {
+	return types.NewMoney(g.TotalAmount, g.CurrencyID)
+}
+
+// VATTotal returns the document VAT total with the document currency attached.
+func (g *PurchaseOrder) VATTotal() types.Money {
+	return types.NewMoney(g.TotalVAT, g.CurrencyID)
+}
+
+// SetTotalAmountBase implements domain.BaseAmountDoc.
+func (g *PurchaseOrder) SetTotalAmountBase(amount types.MinorUnits) {
+	g.TotalAmountBase = amount
+}
+
+// recalculateTotals updates document totals from lines.
+func (g *PurchaseOrder) recalculateTotals() {
+	g.SetTotals(g.LineTotals())
+}
+
+// Totals implements domain.TotalsDoc.
+func (g *PurchaseOrder) Totals() domain.DocumentTotals {
+	return domain.DocumentTotals{Quantity: g.TotalQuantity, Amount: g.TotalAmount, VAT: g.TotalVAT}
+}
+
+// LineTotals implements domain.TotalsDoc.
+func (g *PurchaseOrder) LineTotals() domain.DocumentTotals {
+	var t domain.DocumentTotals
+	for _, line := range g.Lines {
+		t.Quantity += line.Quantity
+		t.Amount += line.Amount
+		t.VAT += line.VATAmount
+	}
+	return t
+}
+
+// SetTotals implements domain.TotalsDoc.
+func (g *PurchaseOrder) SetTotals(t domain.DocumentTotals) {
+	g.TotalQuantity, g.TotalAmount, g.TotalVAT = t.Quantity, t.Amount, t.VAT
+}
+
+// Validate implements entity.Validatable.
+func (g *PurchaseOrder) Validate(ctx context.Context) error {
+	if err := g.Document.Validate(ctx); err != nil {
+		return err
+	}
+
+	if id.IsNil(g.OrganizationID) {
+		return apperror.NewValidation("organization is required").
+			WithDetail("field", "organizationId")
+	}
+
+	if err := g.ValidateCurrency(ctx); err != nil {
+		return err
+	}
+
+	if id.IsNil(g.CounterpartyID) {
+		return apperror.NewValidation("counterparty is required").
+			WithDetail("field", "counterpartyId")
+	}
+
+	if id.IsNil(g.WarehouseID) {
+		return apperror.NewValidation("warehouse is required").
+			WithDetail("field", "warehouseId")
+	}
+
+	if g.ExpectedDate != nil && g.ExpectedDate.Before(g.Date) {
+		return apperror.NewValidation("expected date cannot be before document date").
+			WithDetail("field", "expectedDate")
+	}
+	for i, line := range g.Lines {
+		if line.ExpectedDate != nil && line.ExpectedDate.Before(g.Date) {
+			return apperror.NewValidation("expected date cannot be before document date").
+				WithDetail("field", "lines").
+				WithDetail("line", i+1)
+		}
+	}
+
+	// Common line validation strategy
+	return domain.ValidateDocumentLines(g.Lines)
+}
+
+// --- LinesAccessor implementation ---
+
+// GetLines returns the document lines (defensive copy).
+func (g *PurchaseOrder) GetLines() []PurchaseOrderLine {
+	out := make([]PurchaseOrderLine, len(g.Lines))
+	copy(out, g.Lines)
+	return out
+}
+
+// SetLines replaces the document lines (defensive copy).
+func (g *PurchaseOrder) SetLines(lines []PurchaseOrderLine) {
+	g.Lines = make([]PurchaseOrderLine, len(lines))
+	copy(g.Lines, lines)
+}
+
+// --- CurrencyAwareDoc implementation ---
+
+// GetContractID returns the contract ID (may be nil).
+func (g *PurchaseOrder) GetContractID() *id.ID {
+	return g.ContractID
+}
+
+// --- ValidatableDocLine implementation for PurchaseOrderLine ---
+
+func (l PurchaseOrderLine) GetNomenclatureID() id.ID        { return l.NomenclatureID }
+func (l PurchaseOrderLine) GetUnitID() id.ID                { return l.UnitID }
+func (l PurchaseOrderLine) GetCoefficient() decimal.Decimal { return l.Coefficient }
+func (l PurchaseOrderLine) GetQuantity() types.Quantity     { return l.Quantity }
+func (l PurchaseOrderLine) GetVATRateID() id.ID             { return l.VATRateID }
+
+// BaseQuantity returns the line quantity in base units.
+func (l PurchaseOrderLine) BaseQuantity() types.Quantity {
+	return l.Quantity.MulCoefficient(l.Coefficient)
+}
+
+// --- OrganizationOwned implementation ---
+
+// GetOrganizationID implements domain.OrganizationOwned.
+func (g *PurchaseOrder) GetOrganizationID() id.ID {
+	return g.OrganizationID
+}
+
+// --- RLSDimensionable override ---
+
+// GetRLSDimensions overrides entity.Document to add organization + supplier + warehouse dimensions.
+func (g *PurchaseOrder) GetRLSDimensions() map[string]string {
+	return map[string]string{
+		"organization": g.OrganizationID.String(),
+		"counterparty": g.CounterpartyID.String(),
+		"warehouse":    g.WarehouseID.String(),
+	}
+}
+
+// --- Postable interface implementation ---
+// GetID, GetPostedVersion, IsPosted, CanPost, MarkPosted, MarkUnposted are inherited from entity.Document
+
+func (g *PurchaseOrder) GetDocumentType() string { return DocumentType }
+
+// DocumentType is the document type of purchase orders; GoodsReceipt uses it
+// to recognize an order basis.
+const DocumentType = "PurchaseOrder"
+
+// Fulfillment statuses of a purchase order.
+const (
+	FulfillmentOpen     = "open"               // nothing received yet
+	FulfillmentPartial  = "partially_received" // some lines are still expected
+	FulfillmentReceived = "received"           // everything ordered has been received
+)
+
+// LineExpectedDate returns the expected date of a line, falling back to the header.
+func (g *PurchaseOrder) LineExpectedDate(line PurchaseOrderLine) *time.Time {
+	if line.ExpectedDate != nil {
+		return line.ExpectedDate
+	}
+	return g.ExpectedDate
+}
+
+// GeneratePurchaseOrderMovements implements posting.PurchaseOrderMovementSource.
+// Creates RECEIPT movements (goods ordered) per line — quantity in base units:
+// line.Quantity * line.Coefficient.
+func (g *PurchaseOrder) GeneratePurchaseOrderMovements(ctx context.Context) ([]entity.PurchaseOrderMovement, error) {
+	newVersion := g.PostedVersion + 1
+	movements := make([]entity.PurchaseOrderMovement, 0, len(g.Lines))
+
+	for _, line := range g.Lines {
+		baseQty := line.BaseQuantity()
+
+		movements = append(movements, entity.NewPurchaseOrderMovement(
+			g.ID,
+			g.GetDocumentType(),
+			newVersion,
+			g.Date,
+			entity.RecordTypeReceipt,
+			g.ID,
+			line.LineID,
+			line.NomenclatureID,
+			baseQty,
+		))
+	}
+
+	return movements, nil
+}
+
+// GetLineCount implements posting.LineCounter for pre-allocation.
+func (g *PurchaseOrder) GetLineCount() int { return len(g.Lines) }
+
+// Ensure interface compliance at compile time.
+var _ posting.Postable = (*PurchaseOrder)(nil)
+var _ domain.TotalsDoc = (*PurchaseOrder)(nil)
+var _ posting.PurchaseOrderMovementSource = (*PurchaseOrder)(nil)
+var _ posting.LineCounter = (*PurchaseOrder)(nil)
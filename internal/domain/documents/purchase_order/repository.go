@@ -0,0 +1,24 @@
+package purchase_order
+
+import (
+	"context"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain"
+)
+
+// Repository defines operations for purchase order documents.
+type Repository interface {
+	Create(ctx context.Context, doc *PurchaseOrder) error
+	GetByID(ctx context.Context, docID id.ID) (*PurchaseOrder, error)
+	GetByNumber(ctx context.Context, number string) (*PurchaseOrder, error)
+	Update(ctx context.Context, doc *PurchaseOrder) error
+	Delete(ctx context.Context, docID id.ID) error
+
+	GetLines(ctx context.Context, docID id.ID) ([]PurchaseOrderLine, error)
+	SaveLines(ctx context.Context, docID id.ID, lines []PurchaseOrderLine) error
+
+	// List operations — uses universal filter engine via domain.ListFilter.AdvancedFilters
+	List(ctx context.Context, filter domain.ListFilter) (domain.CursorListResult[*PurchaseOrder], error)
+	ListIDs(ctx context.Context, filter domain.ListFilter, maxIDs int) ([]id.ID, error)
+}
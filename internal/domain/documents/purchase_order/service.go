@@ -0,0 +1,41 @@
+package purchase_order
+
+import (
+	"metapus/internal/core/numerator"
+	"metapus/internal/core/tx"
+	"metapus/internal/domain"
+	"metapus/internal/domain/posting"
+)
+
+// Service provides business operations for purchase order documents.
+// Embeds BaseDocumentService for common CRUD + posting logic.
+type Service struct {
+	*domain.BaseDocumentService[*PurchaseOrder, PurchaseOrderLine]
+}
+
+// NewService creates a new purchase order service.
+// In Database-per-Tenant, TxManager is obtained from context.
+func NewService(
+	repo Repository,
+	postingEngine *posting.Engine,
+	num numerator.Generator,
+	txManager tx.Manager,
+	currencyStrategy domain.CurrencyResolveStrategy,
+) *Service {
+	base := domain.NewBaseDocumentService(domain.BaseDocumentServiceConfig[*PurchaseOrder, PurchaseOrderLine]{
+		Repo:              repo,
+		PostingEngine:     postingEngine,
+		Numerator:         num,
+		TxManager:         txManager,
+		CurrencyResolver:  currencyStrategy,
+		NumeratorPrefix:   "PO",
+		NumeratorStrategy: NumeratorStrategy,
+		EntityName:        "purchase_order",
+	})
+	return &Service{BaseDocumentService: base}
+}
+
+// Hooks returns the hook registry for registering callbacks.
+func (s *Service) Hooks() *domain.HookRegistry[*PurchaseOrder] {
+	return s.GetHooks()
+}
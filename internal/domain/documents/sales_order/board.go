@@ -0,0 +1,21 @@
+package sales_order
+
+import "metapus/internal/domain/board"
+
+// BoardWorkflow is the status board workflow of sales orders.
+// Board states are the order statuses; shipped orders leave the board flow.
+func BoardWorkflow() *board.Workflow {
+	return &board.Workflow{
+		States: []board.State{
+			{Key: StatusDraft, Label: "Черновик"},
+			{Key: StatusConfirmed, Label: "Подтверждён"},
+			{Key: StatusShipped, Label: "Отгружен"},
+			{Key: StatusClosed, Label: "Закрыт"},
+		},
+		Transitions: []board.Transition{
+			{From: StatusDraft, To: StatusConfirmed, Action: board.ActionPost},
+			{From: StatusConfirmed, To: StatusDraft, Action: board.ActionUnpost},
+			{From: StatusConfirmed, To: StatusClosed, Action: board.ActionClose},
+		},
+	}
+}
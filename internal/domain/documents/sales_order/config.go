@@ -0,0 +1,9 @@
+package sales_order
+
+import "metapus/internal/core/numerator"
+
+const (
+	// NumeratorStrategy defines the numbering strategy for this document type.
+	// SalesOrder numbers are shown to customers, so we use Strict strategy.
+	NumeratorStrategy = numerator.StrategyStrict
+)
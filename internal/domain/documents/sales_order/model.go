@@ -0,0 +1,422 @@
+// Package sales_order provides the SalesOrder document.
+// A posted (confirmed) sales order records ordered goods and, if AutoReserve
+// is set, reserves them in its warehouse. Goods issues created on its basis
+// are linked to order lines, release the reservation and decrement the open
+// quantity of the order.
+package sales_order
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/domain"
+	"metapus/internal/domain/posting"
+)
+
+// SalesOrder represents a customer order document.
+// Reserves goods in a warehouse until they are shipped by a GoodsIssue.
+// Status is maintained by the sales order register when the order and
+// linked goods issues are posted or unposted; it is never written by Update.
+type SalesOrder struct {
+	entity.Document
+
+	// OrganizationID is the owning organization (required for multi-org ERP)
+	OrganizationID id.ID `db:"organization_id" json:"organizationId" meta:"label:Организация"`
+
+	// Counterparty reference (role: customer)
+	CounterpartyID id.ID `db:"counterparty_id" json:"counterpartyId" meta:"label:Покупатель"`
+
+	// Contract / Agreement reference
+	ContractID *id.ID `db:"contract_id" json:"contractId,omitempty" meta:"label:Договор"`
+
+	// Warehouse in which goods are reserved
+	WarehouseID id.ID `db:"warehouse_id" json:"warehouseId" meta:"label:Склад"`
+
+	// Requested shipment date
+	ShipmentDate *time.Time `db:"shipment_date" json:"shipmentDate,omitempty" meta:"label:Дата отгрузки"`
+
+	// AutoReserve reserves ordered goods when the order is confirmed (posted)
+	AutoReserve bool `db:"auto_reserve" json:"autoReserve" meta:"label:Резервировать"`
+
+	// Closed orders accept no more shipments; what is left is released
+	Closed bool `db:"closed" json:"closed" meta:"label:Закрыт"`
+
+	// Order status (read-only, maintained by posting of the order and goods issues)
+	Status string `db:"status" json:"status" meta:"label:Состояние"`
+
+	// Currency support trait
+	entity.CurrencyAware
+
+	// AmountIncludesVAT indicates whether prices are VAT-inclusive (gross) or VAT-exclusive (net)
+	AmountIncludesVAT bool `db:"amount_includes_vat" json:"amountIncludesVat" meta:"label:Сумма включает НДС"`
+
+	// Totals (calculated from lines)
+	TotalQuantity   types.Quantity   `db:"total_quantity" json:"totalQuantity" meta:"label:Количество итого"`
+	TotalAmount     types.MinorUnits `db:"total_amount" json:"totalAmount" meta:"label:Сумма итого"`
+	TotalVAT        types.MinorUnits `db:"total_vat" json:"totalVat" meta:"label:НДС итого"`
+	TotalAmountBase types.MinorUnits `db:"total_amount_base" json:"totalAmountBase" meta:"label:Сумма итого в базовой валюте"`
+
+	// Exchange rate frozen on posting
+	entity.PostedRate
+
+	// Table part: ordered goods
+	Lines []SalesOrderLine `db:"-" json:"lines" meta:"label:Товары"`
+}
+
+// SalesOrderLine represents a line in the sales order.
+type SalesOrderLine struct {
+	// Line identification
+	LineID id.ID `db:"line_id" json:"lineId"`
+	LineNo int   `db:"line_no" json:"lineNo" meta:"label:№ строки"`
+
+	// Product reference
+	NomenclatureID id.ID `db:"nomenclature_id" json:"nomenclatureId" meta:"label:Номенклатура"`
+
+	// Unit of measurement (e.g., box, pallet)
+	UnitID id.ID `db:"unit_id" json:"unitId" meta:"label:Единица"`
+
+	// Coefficient for conversion to base unit (e.g., 12 if 1 box = 12 pcs)
+	Coefficient decimal.Decimal `db:"coefficient" json:"coefficient" meta:"label:Коэффициент"`
+
+	// Quantity in UnitID
+	Quantity types.Quantity `db:"quantity" json:"quantity" meta:"label:Количество"`
+
+	// Price per UnitID (in minor units)
+	UnitPrice types.MinorUnits `db:"unit_price" json:"unitPrice" meta:"label:Цена"`
+
+	// Discount
+	DiscountPercent decimal.Decimal  `db:"discount_percent" json:"discountPercent" meta:"label:Скидка %"`
+	DiscountAmount  types.MinorUnits `db:"discount_amount" json:"discountAmount" meta:"label:Скидка сумма"`
+
+	// VAT (reference to cat_vat_rates)
+	VATRateID id.ID            `db:"vat_rate_id" json:"vatRateId" meta:"label:Ставка НДС"`
+	VATAmount types.MinorUnits `db:"vat_amount" json:"vatAmount" meta:"label:Сумма НДС"`
+
+	// Total amount for this line
+	Amount types.MinorUnits `db:"amount" json:"amount" meta:"label:Сумма"`
+}
+
+// NewSalesOrder creates a new sales order document.
+func NewSalesOrder(organizationID id.ID, counterpartyID, warehouseID id.ID) *SalesOrder {
+	return &SalesOrder{
+		Document:          entity.NewDocument(),
+		OrganizationID:    organizationID,
+		CounterpartyID:    counterpartyID,
+		WarehouseID:       warehouseID,
+		AutoReserve:       true,
+		Status:            StatusDraft,
+		AmountIncludesVAT: false,
+		Lines:             make([]SalesOrderLine, 0),
+	}
+}
+
+// AddLine adds a line to the sales order and recalculates totals.
+func (g *SalesOrder) AddLine(
+	nomenclatureID id.ID,
+	unitID id.ID,
+	coefficient decimal.Decimal,
+	quantity types.Quantity,
+	unitPrice types.MinorUnits,
+	vatRateID id.ID,
+	vatPercent int,
+	discountPercent decimal.Decimal,
+) {
+	lineNo := len(g.Lines) + 1
+
+	// Ensure coefficient is at least 1
+	if coefficient.LessThanOrEqual(decimal.Zero) {
+		coefficient = decimal.NewFromInt(1)
+	}
+
+	// All intermediate calculations use decimal.Decimal to avoid truncation.
+	// Final results are rounded to nearest integer (banker's rounding).
+	scaleDec := decimal.NewFromInt(types.QuantityScale)
+	qtyDec := decimal.NewFromInt(quantity.Int64Scaled())
+	priceDec := decimal.NewFromInt(int64(unitPrice))
+
+	// baseAmount = quantity * unitPrice (quantity is scaled by 10000)
+	baseAmountDec := qtyDec.Mul(priceDec).Div(scaleDec)
+
+	// Apply discount
+	discountAmountDec := decimal.Zero
+	if discountPercent.IsPositive() {
+		discountAmountDec = baseAmountDec.Mul(discountPercent).Div(decimal.NewFromInt(100))
+	}
+	netAmountDec := baseAmountDec.Sub(discountAmountDec)
+	discountAmount := types.MinorUnits(discountAmountDec.Round(0).IntPart())
+	netAmount := types.MinorUnits(netAmountDec.Round(0).IntPart())
+
+	// Calculate VAT based on AmountIncludesVAT flag
+	var vatAmount types.MinorUnits
+	var totalAmount types.MinorUnits
+	vatPercentDec := decimal.NewFromInt(int64(vatPercent))
+	if g.AmountIncludesVAT {
+		// Price includes VAT: extract VAT from net amount
+		// vatAmount = netAmount * vatPercent / (100 + vatPercent)
+		if vatPercent > 0 {
+			vatAmountDec := netAmountDec.Mul(vatPercentDec).Div(decimal.NewFromInt(int64(100 + vatPercent)))
+			vatAmount = types.MinorUnits(vatAmountDec.Round(0).IntPart())
+		}
+		totalAmount = netAmount
+	} else {
+		// Price excludes VAT: add VAT on top
+		vatAmountDec := netAmountDec.Mul(vatPercentDec).Div(decimal.NewFromInt(100))
+		vatAmount = types.MinorUnits(vatAmountDec.Round(0).IntPart())
+		totalAmount = netAmount + vatAmount
+	}
+
+	line := SalesOrderLine{
+		LineID:          id.New(),
+		LineNo:          lineNo,
+		NomenclatureID:  nomenclatureID,
+		UnitID:          unitID,
+		Coefficient:     coefficient,
+		Quantity:        quantity,
+		UnitPrice:       unitPrice,
+		DiscountPercent: discountPercent,
+		DiscountAmount:  discountAmount,
+		VATRateID:       vatRateID,
+		VATAmount:       vatAmount,
+		Amount:          totalAmount,
+	}
+
+	g.Lines = append(g.Lines, line)
+	g.recalculateTotals()
+}
+
+// Total returns the document total with the document currency attached.
+func (g *SalesOrder) Total() types.Money {
+	return types.NewMoney(g.TotalAmount, g.CurrencyID)
+}
+
+// VATTotal returns the document VAT total with the document currency attached.
+func (g *SalesOrder) VATTotal() types.Money {
+	return types.NewMoney(g.TotalVAT, g.CurrencyID)
+}
+
+// SetTotalAmountBase implements domain.BaseAmountDoc.
+func (g *SalesOrder) SetTotalAmountBase(amount types.MinorUnits) {
+	g.TotalAmountBase = amount
+}
+
+// recalculateTotals updates document totals from lines.
+func (g *SalesOrder) recalculateTotals() {
+	g.SetTotals(g.LineTotals())
+}
+
+// Totals implements domain.TotalsDoc.
+func (g *SalesOrder) Totals() domain.DocumentTotals {
+	return domain.DocumentTotals{Quantity: g.TotalQuantity, Amount: g.TotalAmount, VAT: g.TotalVAT}
+}
+
+// LineTotals implements domain.TotalsDoc.
+func (g *SalesOrder) LineTotals() domain.DocumentTotals {
+	var t domain.DocumentTotals
+	for _, line := range g.Lines {
+		t.Quantity += line.Quantity
+		t.Amount += line.Amount
+		t.VAT += line.VATAmount
+	}
+	return t
+}
+
+// SetTotals implements domain.TotalsDoc.
+func (g *SalesOrder) SetTotals(t domain.DocumentTotals) {
+	g.TotalQuantity, g.TotalAmount, g.TotalVAT = t.Quantity, t.Amount, t.VAT
+}
+
+// Validate implements entity.Validatable.
+func (g *SalesOrder) Validate(ctx context.Context) error {
+	if err := g.Document.Validate(ctx); err != nil {
+		return err
+	}
+
+	if id.IsNil(g.OrganizationID) {
+		return apperror.NewValidation("organization is required").
+			WithDetail("field", "organizationId")
+	}
+
+	if err := g.ValidateCurrency(ctx); err != nil {
+		return err
+	}
+
+	if id.IsNil(g.CounterpartyID) {
+		return apperror.NewValidation("counterparty is required").
+			WithDetail("field", "counterpartyId")
+	}
+
+	if id.IsNil(g.WarehouseID) {
+		return apperror.NewValidation("warehouse is required").
+			WithDetail("field", "warehouseId")
+	}
+
+	// Common line validation strategy
+	return domain.ValidateDocumentLines(g.Lines)
+}
+
+// --- LinesAccessor implementation ---
+
+// GetLines returns the document lines (defensive copy).
+func (g *SalesOrder) GetLines() []SalesOrderLine {
+	out := make([]SalesOrderLine, len(g.Lines))
+	copy(out, g.Lines)
+	return out
+}
+
+// SetLines replaces the document lines (defensive copy).
+func (g *SalesOrder) SetLines(lines []SalesOrderLine) {
+	g.Lines = make([]SalesOrderLine, len(lines))
+	copy(g.Lines, lines)
+}
+
+// --- CurrencyAwareDoc implementation ---
+
+// GetContractID returns the contract ID (may be nil).
+func (g *SalesOrder) GetContractID() *id.ID {
+	return g.ContractID
+}
+
+// --- ValidatableDocLine implementation for SalesOrderLine ---
+
+func (l SalesOrderLine) GetNomenclatureID() id.ID        { return l.NomenclatureID }
+func (l SalesOrderLine) GetUnitID() id.ID                { return l.UnitID }
+func (l SalesOrderLine) GetCoefficient() decimal.Decimal { return l.Coefficient }
+func (l SalesOrderLine) GetQuantity() types.Quantity     { return l.Quantity }
+func (l SalesOrderLine) GetVATRateID() id.ID             { return l.VATRateID }
+
+// --- OrganizationOwned implementation ---
+
+// GetOrganizationID implements domain.OrganizationOwned.
+func (g *SalesOrder) GetOrganizationID() id.ID {
+	return g.OrganizationID
+}
+
+// --- RLSDimensionable override ---
+
+// GetRLSDimensions overrides entity.Document to add organization + customer + warehouse dimensions.
+func (g *SalesOrder) GetRLSDimensions() map[string]string {
+	return map[string]string{
+		"organization": g.OrganizationID.String(),
+		"counterparty": g.CounterpartyID.String(),
+		"warehouse":    g.WarehouseID.String(),
+	}
+}
+
+// --- Postable interface implementation ---
+// GetID, GetPostedVersion, IsPosted, CanPost, MarkPosted, MarkUnposted are inherited from entity.Document
+
+func (g *SalesOrder) GetDocumentType() string { return DocumentType }
+
+// DocumentType is the document type of sales orders; GoodsIssue uses it
+// to recognize an order basis.
+const DocumentType = "SalesOrder"
+
+// Statuses of a sales order.
+const (
+	StatusDraft     = "draft"     // not posted
+	StatusConfirmed = "confirmed" // posted, goods are still to be shipped
+	StatusShipped   = "shipped"   // everything ordered has been shipped
+	StatusClosed    = "closed"    // closed before being fully shipped
+)
+
+// Close marks a confirmed order as closed. Re-posting the closed order
+// releases its open quantity and remaining reservation.
+func (g *SalesOrder) Close() error {
+	if !g.Posted {
+		return apperror.NewBusinessRule("SALES_ORDER_NOT_POSTED", "only a confirmed sales order can be closed")
+	}
+	if g.Closed {
+		return apperror.NewBusinessRule("SALES_ORDER_CLOSED", "sales order is already closed")
+	}
+	g.Closed = true
+	return nil
+}
+
+// GenerateSalesOrderMovements implements posting.SalesOrderMovementSource.
+// Creates RECEIPT movements per line — quantity in base units. A closed order
+// also creates EXPENSE movements for the same quantities; the register caps
+// them at the open quantity, so the order is left with nothing to ship.
+func (g *SalesOrder) GenerateSalesOrderMovements(ctx context.Context) ([]entity.SalesOrderMovement, error) {
+	newVersion := g.PostedVersion + 1
+	movements := make([]entity.SalesOrderMovement, 0, len(g.Lines))
+
+	for _, line := range g.Lines {
+		baseQty := line.BaseQuantity()
+		movements = append(movements, entity.NewSalesOrderMovement(
+			g.ID, g.GetDocumentType(), newVersion, g.Date,
+			entity.RecordTypeReceipt, g.ID, line.LineID, line.NomenclatureID, baseQty,
+		))
+		if g.Closed {
+			movements = append(movements, entity.NewSalesOrderMovement(
+				g.ID, g.GetDocumentType(), newVersion, g.Date,
+				entity.RecordTypeExpense, g.ID, line.LineID, line.NomenclatureID, baseQty,
+			))
+		}
+	}
+
+	return movements, nil
+}
+
+// GenerateStockReservationMovements implements posting.StockReservationMovementSource.
+// Creates RECEIPT movements (reserves goods) when AutoReserve is set — quantity
+// in base units: line.Quantity * line.Coefficient. A closed order also releases
+// them (EXPENSE, capped by the register at what is still reserved).
+func (g *SalesOrder) GenerateStockReservationMovements(ctx context.Context) ([]entity.StockReservationMovement, error) {
+	if !g.AutoReserve {
+		return nil, nil
+	}
+
+	newVersion := g.PostedVersion + 1
+	movements := make([]entity.StockReservationMovement, 0, len(g.Lines))
+
+	for _, line := range g.Lines {
+		baseQty := line.BaseQuantity()
+		movements = append(movements, entity.NewStockReservationMovement(
+			g.ID,
+			g.GetDocumentType(),
+			newVersion,
+			g.Date,
+			entity.RecordTypeReceipt,
+			g.ID,
+			g.WarehouseID,
+			line.NomenclatureID,
+			baseQty,
+		))
+		if g.Closed {
+			movements = append(movements, entity.NewStockReservationMovement(
+				g.ID,
+				g.GetDocumentType(),
+				newVersion,
+				g.Date,
+				entity.RecordTypeExpense,
+				g.ID,
+				g.WarehouseID,
+				line.NomenclatureID,
+				baseQty,
+			))
+		}
+	}
+
+	return movements, nil
+}
+
+// BaseQuantity returns the line quantity in base units.
+func (l SalesOrderLine) BaseQuantity() types.Quantity {
+	return l.Quantity.MulCoefficient(l.Coefficient)
+}
+
+// GetLineCount implements posting.LineCounter for pre-allocation.
+func (g *SalesOrder) GetLineCount() int { return len(g.Lines) }
+
+// Ensure interface compliance at compile time.
+var _ posting.Postable = (*SalesOrder)(nil)
+var _ domain.TotalsDoc = (*SalesOrder)(nil)
+var _ posting.StockReservationMovementSource = (*SalesOrder)(nil)
+var _ posting.SalesOrderMovementSource = (*SalesOrder)(nil)
+var _ posting.LineCounter = (*SalesOrder)(nil)
@@ -0,0 +1,64 @@
+package sales_order
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+)
+
+func TestCloseReleasesOrderedQuantities(t *testing.T) {
+	ctx := context.Background()
+	doc := NewSalesOrder(id.New(), id.New(), id.New())
+	doc.AddLine(id.New(), id.New(), decimal.NewFromInt(6), types.NewQuantityFromFloat64(2), 100, id.New(), 0, decimal.Zero)
+	baseQty := types.NewQuantityFromFloat64(12)
+
+	if err := doc.Close(); err == nil {
+		t.Fatal("closing a draft order: expected error")
+	}
+
+	count := func(records []entity.RecordType) map[entity.RecordType]int {
+		n := make(map[entity.RecordType]int)
+		for _, rt := range records {
+			n[rt]++
+		}
+		return n
+	}
+
+	doc.MarkPosted()
+	if err := doc.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	orderMovements, err := doc.GenerateSalesOrderMovements(ctx)
+	if err != nil {
+		t.Fatalf("GenerateSalesOrderMovements: %v", err)
+	}
+	records := make([]entity.RecordType, 0, len(orderMovements))
+	for _, m := range orderMovements {
+		if m.Quantity != baseQty || m.OrderLineID != doc.Lines[0].LineID {
+			t.Errorf("order movement %+v, want quantity %v of line %s", m, baseQty, doc.Lines[0].LineID)
+		}
+		records = append(records, m.RecordType)
+	}
+	if n := count(records); n[entity.RecordTypeReceipt] != 1 || n[entity.RecordTypeExpense] != 1 {
+		t.Errorf("order movements %v, want one receipt and one release", n)
+	}
+
+	reservations, err := doc.GenerateStockReservationMovements(ctx)
+	if err != nil {
+		t.Fatalf("GenerateStockReservationMovements: %v", err)
+	}
+	if len(reservations) != 2 {
+		t.Errorf("got %d reservation movements, want reserve and release", len(reservations))
+	}
+
+	doc.AutoReserve = false
+	if reservations, _ = doc.GenerateStockReservationMovements(ctx); len(reservations) != 0 {
+		t.Errorf("got %d reservation movements without auto-reserve, want 0", len(reservations))
+	}
+}
@@ -0,0 +1,24 @@
+package sales_order
+
+import (
+	"context"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain"
+)
+
+// Repository defines operations for sales order documents.
+type Repository interface {
+	Create(ctx context.Context, doc *SalesOrder) error
+	GetByID(ctx context.Context, docID id.ID) (*SalesOrder, error)
+	GetByNumber(ctx context.Context, number string) (*SalesOrder, error)
+	Update(ctx context.Context, doc *SalesOrder) error
+	Delete(ctx context.Context, docID id.ID) error
+
+	GetLines(ctx context.Context, docID id.ID) ([]SalesOrderLine, error)
+	SaveLines(ctx context.Context, docID id.ID, lines []SalesOrderLine) error
+
+	// List operations — uses universal filter engine via domain.ListFilter.AdvancedFilters
+	List(ctx context.Context, filter domain.ListFilter) (domain.CursorListResult[*SalesOrder], error)
+	ListIDs(ctx context.Context, filter domain.ListFilter, maxIDs int) ([]id.ID, error)
+}
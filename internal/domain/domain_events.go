@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/events"
+	"metapus/internal/core/id"
+)
+
+// EventDocument is the part of a document that document events describe.
+// Both DocumentEntity and HeaderDocumentEntity satisfy it.
+type EventDocument interface {
+	GetID() id.ID
+	GetNumber() string
+	IsPosted() bool
+	GetVersion() int
+}
+
+// RegisterDocumentEvents publishes document.created, document.posted and
+// document.unposted events of a document type through in-transaction hooks:
+// an event is committed together with the change it describes.
+// If publisher is nil, this is a no-op.
+func RegisterDocumentEvents[T EventDocument](hooks *HookRegistry[T], documentType string, publisher events.Publisher) {
+	if publisher == nil {
+		return
+	}
+	publish := func(t events.Type[events.DocumentEvent]) Hook[T] {
+		return func(ctx context.Context, doc T) error {
+			return t.Publish(ctx, publisher, events.AggregateDocument, doc.GetID(), events.DocumentEvent{
+				DocumentType: documentType,
+				DocumentID:   doc.GetID(),
+				Number:       doc.GetNumber(),
+				Posted:       doc.IsPosted(),
+				Version:      doc.GetVersion(),
+			})
+		}
+	}
+	hooks.On(CreateInTx, publish(events.DocumentCreated))
+	hooks.On(PostInTx, publish(events.DocumentPosted))
+	hooks.On(UnpostInTx, publish(events.DocumentUnposted))
+}
+
+// RegisterCatalogEvents publishes catalog.changed events of a catalog
+// through in-transaction hooks. If publisher is nil, this is a no-op.
+func RegisterCatalogEvents[T entity.CatalogEntity](svc *CatalogService[T], catalogType string, publisher events.Publisher) {
+	if publisher == nil {
+		return
+	}
+	publish := func(action string) Hook[T] {
+		return func(ctx context.Context, item T) error {
+			data := events.CatalogEvent{CatalogType: catalogType, ItemID: item.GetID(), Action: action}
+			if coded, ok := any(item).(interface{ GetCode() string }); ok {
+				data.Code = coded.GetCode()
+			}
+			return events.CatalogChanged.Publish(ctx, publisher, events.AggregateCatalog, item.GetID(), data)
+		}
+	}
+	svc.Hooks().On(CreateInTx, publish(events.CatalogCreated))
+	svc.Hooks().On(UpdateInTx, publish(events.CatalogUpdated))
+	svc.Hooks().On(DeleteInTx, publish(events.CatalogDeleted))
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"metapus/internal/core/events"
+	"metapus/internal/core/id"
+)
+
+type eventDoc struct {
+	id     id.ID
+	posted bool
+}
+
+func (d *eventDoc) GetID() id.ID      { return d.id }
+func (d *eventDoc) GetNumber() string { return "INV-7" }
+func (d *eventDoc) IsPosted() bool    { return d.posted }
+func (d *eventDoc) GetVersion() int   { return 2 }
+
+type capturePublisher struct {
+	envs []events.Envelope
+	err  error
+}
+
+func (p *capturePublisher) Publish(_ context.Context, env events.Envelope) error {
+	p.envs = append(p.envs, env)
+	return p.err
+}
+
+func TestRegisterDocumentEvents(t *testing.T) {
+	hooks := NewHookRegistry[*eventDoc]()
+	pub := &capturePublisher{}
+	RegisterDocumentEvents(hooks, "goods_issue", pub)
+
+	doc := &eventDoc{id: id.New(), posted: true}
+	if err := hooks.Run(context.Background(), PostInTx, doc); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(pub.envs) != 1 || pub.envs[0].Type != "document.posted" {
+		t.Fatalf("published %+v", pub.envs)
+	}
+	data, err := events.DocumentPosted.Decode(pub.envs[0])
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if data.DocumentType != "goods_issue" || data.DocumentID != doc.id || data.Number != "INV-7" || !data.Posted {
+		t.Errorf("data = %+v", data)
+	}
+
+	// A publish failure must fail the hook, rolling the transaction back.
+	pub.err = errors.New("outbox unavailable")
+	if err := hooks.Run(context.Background(), UnpostInTx, doc); err == nil {
+		t.Error("expected the publish error")
+	}
+
+	// Before/after hooks do not publish.
+	n := len(pub.envs)
+	_ = hooks.RunAfterCreate(context.Background(), doc)
+	if len(pub.envs) != n {
+		t.Error("after-create hook must not publish")
+	}
+}
+
+func TestRegisterDocumentEventsNilPublisher(t *testing.T) {
+	hooks := NewHookRegistry[*eventDoc]()
+	RegisterDocumentEvents[*eventDoc](hooks, "goods_issue", nil)
+	if err := hooks.Run(context.Background(), CreateInTx, &eventDoc{id: id.New()}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+}
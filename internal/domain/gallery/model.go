@@ -0,0 +1,66 @@
+// Package gallery provides image galleries of catalog items (nomenclature
+// photos, counterparty logos). Image metadata lives in the tenant database
+// (sys_images); the original and a thumbnail live in the same BlobStore as
+// attachments.
+package gallery
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultMaxSize is the upload size limit used when none is configured (10 MiB).
+	DefaultMaxSize int64 = 10 << 20
+	// MaxPixels bounds width x height of an image so that a small file
+	// cannot decode into a huge bitmap.
+	MaxPixels = 40_000_000
+	// MaxImages bounds the images of one item.
+	MaxImages = 50
+	// ThumbnailSize is the longer side of a thumbnail, in pixels.
+	ThumbnailSize = 256
+)
+
+// AllowedTypes lists the image types that can be decoded for thumbnails.
+var AllowedTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+// Image is an image of a catalog item.
+type Image struct {
+	ID           uuid.UUID  `json:"id" db:"id"`
+	EntityType   string     `json:"entityType" db:"entity_type"`
+	EntityID     uuid.UUID  `json:"entityId" db:"entity_id"`
+	FileName     string     `json:"fileName" db:"file_name"`
+	ContentType  string     `json:"contentType" db:"content_type"`
+	Size         int64      `json:"size" db:"size"`
+	Width        int        `json:"width" db:"width"`
+	Height       int        `json:"height" db:"height"`
+	StorageKey   string     `json:"-" db:"storage_key"`
+	ThumbnailKey string     `json:"-" db:"thumbnail_key"`
+	SortOrder    int        `json:"sortOrder" db:"sort_order"`
+	IsPrimary    bool       `json:"isPrimary" db:"is_primary"`
+	UploadedBy   *uuid.UUID `json:"uploadedBy" db:"uploaded_by"`
+	CreatedAt    time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// ThumbnailContentType returns the type of the thumbnail: JPEG for JPEG
+// photos, PNG (which keeps transparency) otherwise.
+func (i *Image) ThumbnailContentType() string {
+	if i.ContentType == "image/jpeg" {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+// Owner is a catalog entity with an image gallery. The images are loaded
+// for API responses and are managed through the gallery endpoints only.
+type Owner interface {
+	GetImages() []*Image
+	SetImages(images []*Image)
+}
+
+// allowed reports whether contentType can be uploaded.
+func allowed(contentType string) bool {
+	return slices.Contains(AllowedTypes, contentType)
+}
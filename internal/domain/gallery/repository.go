@@ -0,0 +1,28 @@
+package gallery
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines storage operations for image metadata.
+type Repository interface {
+	// Create inserts image metadata. The image is put at the end of the
+	// gallery and becomes primary if it is the first one; SortOrder,
+	// IsPrimary and CreatedAt are set from the database.
+	Create(ctx context.Context, img *Image) error
+	// GetByID returns a single image.
+	GetByID(ctx context.Context, id uuid.UUID) (*Image, error)
+	// ListByEntity returns the images of an entity in gallery order.
+	ListByEntity(ctx context.Context, entityType string, entityID uuid.UUID) ([]*Image, error)
+	// ListByEntities returns the images of several entities in gallery order.
+	ListByEntities(ctx context.Context, entityType string, entityIDs []uuid.UUID) ([]*Image, error)
+	// Delete removes image metadata. If the image was primary, the next
+	// image of the gallery becomes primary.
+	Delete(ctx context.Context, id uuid.UUID) error
+	// SetOrder sets the gallery order to the order of ids.
+	SetOrder(ctx context.Context, entityType string, entityID uuid.UUID, ids []uuid.UUID) error
+	// SetPrimary makes the image the only primary image of the entity.
+	SetPrimary(ctx context.Context, entityType string, entityID, imageID uuid.UUID) error
+}
@@ -0,0 +1,316 @@
+package gallery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif" // register the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/core/apperror"
+	corectx "metapus/internal/core/context"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/attachment"
+	"metapus/pkg/logger"
+)
+
+// thumbnailQuality is the JPEG quality of thumbnails of JPEG photos.
+const thumbnailQuality = 85
+
+// Service manages image galleries: metadata in Repository, the original and
+// its thumbnail in the attachment BlobStore. Access to the owning entity is
+// checked by the caller (HTTP layer) before any Service method is invoked.
+type Service struct {
+	repo    Repository
+	store   attachment.BlobStore
+	maxSize int64
+}
+
+// NewService creates a new gallery service. maxSize <= 0 means DefaultMaxSize.
+func NewService(repo Repository, store attachment.BlobStore, maxSize int64) *Service {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	return &Service{repo: repo, store: store, maxSize: maxSize}
+}
+
+// MaxSize returns the effective upload size limit.
+func (s *Service) MaxSize() int64 {
+	return s.maxSize
+}
+
+// UploadInput describes an image being added to a gallery.
+type UploadInput struct {
+	EntityType  string
+	EntityID    uuid.UUID
+	FileName    string
+	ContentType string
+	Size        int64
+	Body        io.Reader
+}
+
+// List returns the images of an entity in gallery order.
+func (s *Service) List(ctx context.Context, entityType string, entityID uuid.UUID) ([]*Image, error) {
+	return s.repo.ListByEntity(ctx, entityType, entityID)
+}
+
+// ListByEntities returns the images of several entities, grouped by entity.
+func (s *Service) ListByEntities(ctx context.Context, entityType string, entityIDs []uuid.UUID) (map[uuid.UUID][]*Image, error) {
+	if len(entityIDs) == 0 {
+		return nil, nil
+	}
+	list, err := s.repo.ListByEntities(ctx, entityType, entityIDs)
+	if err != nil {
+		return nil, err
+	}
+	byEntity := make(map[uuid.UUID][]*Image, len(entityIDs))
+	for _, img := range list {
+		byEntity[img.EntityID] = append(byEntity[img.EntityID], img)
+	}
+	return byEntity, nil
+}
+
+// Upload validates and decodes the image, stores it with a thumbnail and
+// records its metadata at the end of the gallery. If the metadata cannot be
+// saved, the stored blobs are removed.
+func (s *Service) Upload(ctx context.Context, in UploadInput) (*Image, error) {
+	tenantID := tenant.GetTenantID(ctx)
+	if tenantID == "" {
+		return nil, apperror.NewInternal(fmt.Errorf("tenant not found in context"))
+	}
+
+	fileName := attachment.SanitizeFileName(in.FileName)
+	contentType := attachment.NormalizeContentType(in.ContentType, fileName)
+	if err := s.validateUpload(in.Size, contentType); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.ListByEntity(ctx, in.EntityType, in.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= MaxImages {
+		return nil, apperror.NewValidation("validation failed").
+			WithDetail("file", "too many images").
+			WithDetail("maxImages", MaxImages)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(in.Body, in.Size+1))
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("read image: %w", err))
+	}
+	if int64(len(data)) != in.Size {
+		return nil, apperror.NewValidation("validation failed").WithDetail("file", "file size does not match the declared size")
+	}
+
+	src, err := decode(data, contentType)
+	if err != nil {
+		return nil, err
+	}
+	thumb, err := encodeThumbnail(Thumbnail(src, ThumbnailSize), contentType)
+	if err != nil {
+		return nil, apperror.NewInternal(err)
+	}
+
+	imageID, err := uuid.NewV7()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("generate image id: %w", err))
+	}
+	key := storageKey(tenantID, in.EntityType, in.EntityID, imageID)
+	img := &Image{
+		ID:           imageID,
+		EntityType:   in.EntityType,
+		EntityID:     in.EntityID,
+		FileName:     fileName,
+		ContentType:  contentType,
+		Size:         in.Size,
+		Width:        src.Bounds().Dx(),
+		Height:       src.Bounds().Dy(),
+		StorageKey:   key,
+		ThumbnailKey: key + "-thumb",
+	}
+	if user := corectx.GetUser(ctx); user != nil {
+		if userID, err := uuid.Parse(user.UserID); err == nil {
+			img.UploadedBy = &userID
+		}
+	}
+
+	if err := s.store.Put(ctx, img.StorageKey, bytes.NewReader(data), img.Size, img.ContentType); err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("store image: %w", err))
+	}
+	if err := s.store.Put(ctx, img.ThumbnailKey, bytes.NewReader(thumb), int64(len(thumb)), img.ThumbnailContentType()); err != nil {
+		s.discardBlobs(ctx, img)
+		return nil, apperror.NewInternal(fmt.Errorf("store thumbnail: %w", err))
+	}
+	if err := s.repo.Create(ctx, img); err != nil {
+		s.discardBlobs(ctx, img)
+		return nil, err
+	}
+	return img, nil
+}
+
+// Open returns image metadata and a reader for the original or the
+// thumbnail. The caller must close the reader.
+func (s *Service) Open(ctx context.Context, entityType string, entityID, imageID uuid.UUID, thumbnail bool) (*Image, io.ReadCloser, error) {
+	img, err := s.get(ctx, entityType, entityID, imageID)
+	if err != nil {
+		return nil, nil, err
+	}
+	key := img.StorageKey
+	if thumbnail {
+		key = img.ThumbnailKey
+	}
+	rc, err := s.store.Get(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return img, rc, nil
+}
+
+// Delete removes an image. Metadata is removed first; blobs that cannot be
+// deleted afterwards are only logged, they are unreachable anyway.
+func (s *Service) Delete(ctx context.Context, entityType string, entityID, imageID uuid.UUID) error {
+	img, err := s.get(ctx, entityType, entityID, imageID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, img.ID); err != nil {
+		return err
+	}
+	s.discardBlobs(ctx, img)
+	return nil
+}
+
+// Reorder sets the gallery order. ids must list every image of the entity
+// exactly once.
+func (s *Service) Reorder(ctx context.Context, entityType string, entityID uuid.UUID, ids []uuid.UUID) ([]*Image, error) {
+	current, err := s.repo.ListByEntity(ctx, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOrder(current, ids); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetOrder(ctx, entityType, entityID, ids); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByEntity(ctx, entityType, entityID)
+}
+
+// SetPrimary makes the image the primary image of the entity.
+func (s *Service) SetPrimary(ctx context.Context, entityType string, entityID, imageID uuid.UUID) error {
+	img, err := s.get(ctx, entityType, entityID, imageID)
+	if err != nil {
+		return err
+	}
+	return s.repo.SetPrimary(ctx, entityType, entityID, img.ID)
+}
+
+// get loads an image and checks that it belongs to the entity.
+func (s *Service) get(ctx context.Context, entityType string, entityID, imageID uuid.UUID) (*Image, error) {
+	img, err := s.repo.GetByID(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+	if img.EntityType != entityType || img.EntityID != entityID {
+		return nil, apperror.NewNotFound("image", imageID)
+	}
+	return img, nil
+}
+
+// validateUpload checks size and content type of an upload.
+func (s *Service) validateUpload(size int64, contentType string) error {
+	if size <= 0 {
+		return apperror.NewValidation("validation failed").WithDetail("file", "file is empty")
+	}
+	if size > s.maxSize {
+		return apperror.NewValidation("validation failed").
+			WithDetail("file", "file is too large").
+			WithDetail("maxSize", s.maxSize)
+	}
+	if !allowed(contentType) {
+		return apperror.NewValidation("validation failed").
+			WithDetail("contentType", "image type is not supported: "+contentType).
+			WithDetail("allowedTypes", AllowedTypes)
+	}
+	return nil
+}
+
+func (s *Service) discardBlobs(ctx context.Context, img *Image) {
+	for _, key := range []string{img.StorageKey, img.ThumbnailKey} {
+		if err := s.store.Delete(ctx, key); err != nil && !apperror.IsNotFound(err) {
+			logger.Warn(ctx, "failed to delete image blob", "key", key, "error", err)
+		}
+	}
+}
+
+// decode decodes the image, checking that its format is the declared type
+// and that its dimensions are within MaxPixels.
+func decode(data []byte, contentType string) (image.Image, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, apperror.NewValidation("validation failed").WithDetail("file", "file is not a valid image")
+	}
+	if "image/"+format != contentType {
+		return nil, apperror.NewValidation("validation failed").
+			WithDetail("contentType", "file contents do not match type "+contentType)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxPixels {
+		return nil, apperror.NewValidation("validation failed").
+			WithDetail("file", "image dimensions are too large").
+			WithDetail("maxPixels", MaxPixels)
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, apperror.NewValidation("validation failed").WithDetail("file", "file is not a valid image")
+	}
+	return src, nil
+}
+
+// encodeThumbnail encodes a thumbnail in the format of ThumbnailContentType.
+func encodeThumbnail(thumb image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+			return nil, fmt.Errorf("encode thumbnail: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+	if err := png.Encode(&buf, thumb); err != nil {
+		return nil, fmt.Errorf("encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// checkOrder checks that ids lists every image exactly once.
+func checkOrder(current []*Image, ids []uuid.UUID) error {
+	known := make(map[uuid.UUID]bool, len(current))
+	for _, img := range current {
+		known[img.ID] = false
+	}
+	for _, imageID := range ids {
+		seen, ok := known[imageID]
+		if !ok || seen {
+			return apperror.NewValidation("ids must list every image of the item once").
+				WithDetail("field", "ids").
+				WithDetail("id", imageID)
+		}
+		known[imageID] = true
+	}
+	if len(ids) != len(current) {
+		return apperror.NewValidation("ids must list every image of the item once").
+			WithDetail("field", "ids")
+	}
+	return nil
+}
+
+// storageKey builds the blob key of the original; the thumbnail key adds
+// "-thumb". Images share the tenant/entity layout of attachments.
+func storageKey(tenantID, entityType string, entityID, imageID uuid.UUID) string {
+	return tenantID + "/" + entityType + "/" + entityID.String() + "/images/" + imageID.String()
+}
@@ -0,0 +1,221 @@
+package gallery
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/tenant"
+)
+
+// memStore keeps blobs in memory.
+type memStore map[string][]byte
+
+func (m memStore) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	b, err := io.ReadAll(r)
+	m[key] = b
+	return err
+}
+
+func (m memStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	b, ok := m[key]
+	if !ok {
+		return nil, apperror.NewNotFound("blob", key)
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (m memStore) Delete(_ context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
+
+// memImages stores image metadata in memory, in gallery order.
+type memImages struct {
+	Repository
+	list []*Image
+}
+
+func (m *memImages) Create(_ context.Context, img *Image) error {
+	img.SortOrder = len(m.list) + 1
+	img.IsPrimary = len(m.list) == 0
+	m.list = append(m.list, img)
+	return nil
+}
+
+func (m *memImages) GetByID(_ context.Context, imageID uuid.UUID) (*Image, error) {
+	for _, img := range m.list {
+		if img.ID == imageID {
+			return img, nil
+		}
+	}
+	return nil, apperror.NewNotFound("image", imageID)
+}
+
+func (m *memImages) ListByEntity(_ context.Context, _ string, _ uuid.UUID) ([]*Image, error) {
+	return slices.Clone(m.list), nil
+}
+
+func (m *memImages) SetOrder(_ context.Context, _ string, _ uuid.UUID, ids []uuid.UUID) error {
+	for i, imageID := range ids {
+		img, _ := m.GetByID(context.Background(), imageID)
+		img.SortOrder = i + 1
+	}
+	slices.SortFunc(m.list, func(a, b *Image) int { return a.SortOrder - b.SortOrder })
+	return nil
+}
+
+func testContext() context.Context {
+	return tenant.WithTenant(context.Background(), &tenant.Tenant{ID: "t1"})
+}
+
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestThumbnail(t *testing.T) {
+	cases := []struct {
+		w, h, wantW, wantH int
+	}{
+		{1024, 512, 256, 128},
+		{300, 900, 85, 256},
+		{100, 50, 100, 50},
+		{4000, 3, 256, 1},
+	}
+	for _, tc := range cases {
+		src := image.NewRGBA(image.Rect(0, 0, tc.w, tc.h))
+		got := Thumbnail(src, ThumbnailSize).Bounds()
+		if got.Dx() != tc.wantW || got.Dy() != tc.wantH {
+			t.Errorf("%dx%d: thumbnail %dx%d, want %dx%d", tc.w, tc.h, got.Dx(), got.Dy(), tc.wantW, tc.wantH)
+		}
+	}
+}
+
+func TestUploadStoresOriginalAndThumbnail(t *testing.T) {
+	store := memStore{}
+	repo := &memImages{}
+	svc := NewService(repo, store, 0)
+	ctx := testContext()
+	entityID := uuid.New()
+
+	data := testPNG(t, 600, 300)
+	img, err := svc.Upload(ctx, UploadInput{
+		EntityType: "nomenclature", EntityID: entityID,
+		FileName: "photo.png", ContentType: "image/png",
+		Size: int64(len(data)), Body: bytes.NewReader(data),
+	})
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	if img.Width != 600 || img.Height != 300 || !img.IsPrimary {
+		t.Errorf("image = %dx%d primary=%v, want 600x300 primary", img.Width, img.Height, img.IsPrimary)
+	}
+	if !bytes.Equal(store[img.StorageKey], data) {
+		t.Error("original is not stored as uploaded")
+	}
+	thumb, err := png.Decode(bytes.NewReader(store[img.ThumbnailKey]))
+	if err != nil {
+		t.Fatalf("decode thumbnail: %v", err)
+	}
+	if b := thumb.Bounds(); b.Dx() != 256 || b.Dy() != 128 {
+		t.Errorf("thumbnail %dx%d, want 256x128", b.Dx(), b.Dy())
+	}
+
+	_, rc, err := svc.Open(ctx, "counterparty", entityID, img.ID, false)
+	if !apperror.IsNotFound(err) {
+		if rc != nil {
+			rc.Close()
+		}
+		t.Errorf("open through another entity type: err = %v, want not found", err)
+	}
+}
+
+func TestUploadRejectsMismatchedType(t *testing.T) {
+	svc := NewService(&memImages{}, memStore{}, 0)
+	ctx := testContext()
+
+	data := testPNG(t, 10, 10)
+	_, err := svc.Upload(ctx, UploadInput{
+		EntityType: "nomenclature", EntityID: uuid.New(),
+		FileName: "photo.jpg", ContentType: "image/jpeg",
+		Size: int64(len(data)), Body: bytes.NewReader(data),
+	})
+	if appErr, ok := apperror.AsAppError(err); !ok || appErr.Code != apperror.CodeValidation {
+		t.Errorf("PNG declared as JPEG: err = %v, want a validation error", err)
+	}
+
+	text := []byte("not an image")
+	_, err = svc.Upload(ctx, UploadInput{
+		EntityType: "nomenclature", EntityID: uuid.New(),
+		FileName: "photo.png", ContentType: "image/png",
+		Size: int64(len(text)), Body: bytes.NewReader(text),
+	})
+	if appErr, ok := apperror.AsAppError(err); !ok || appErr.Code != apperror.CodeValidation {
+		t.Errorf("text declared as PNG: err = %v, want a validation error", err)
+	}
+}
+
+func TestJPEGThumbnailIsJPEG(t *testing.T) {
+	store := memStore{}
+	svc := NewService(&memImages{}, store, 0)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 512, 512)), nil); err != nil {
+		t.Fatal(err)
+	}
+	img, err := svc.Upload(testContext(), UploadInput{
+		EntityType: "nomenclature", EntityID: uuid.New(),
+		FileName: "photo.jpg", ContentType: "image/jpeg",
+		Size: int64(buf.Len()), Body: bytes.NewReader(buf.Bytes()),
+	})
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	if _, err := jpeg.Decode(bytes.NewReader(store[img.ThumbnailKey])); err != nil {
+		t.Errorf("thumbnail of a JPEG is not a JPEG: %v", err)
+	}
+}
+
+func TestReorder(t *testing.T) {
+	repo := &memImages{}
+	svc := NewService(repo, memStore{}, 0)
+	entityID := uuid.New()
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	for _, imageID := range []uuid.UUID{a, b, c} {
+		_ = repo.Create(context.Background(), &Image{ID: imageID, EntityType: "nomenclature", EntityID: entityID})
+	}
+
+	list, err := svc.Reorder(context.Background(), "nomenclature", entityID, []uuid.UUID{c, a, b})
+	if err != nil {
+		t.Fatalf("Reorder: %v", err)
+	}
+	if list[0].ID != c || list[1].ID != a || list[2].ID != b {
+		t.Error("images are not in the requested order")
+	}
+
+	for _, ids := range [][]uuid.UUID{{a, b}, {a, a, b}, {a, b, uuid.New()}} {
+		if _, err := svc.Reorder(context.Background(), "nomenclature", entityID, ids); err == nil {
+			t.Errorf("Reorder(%v): err = nil, want a validation error", ids)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package gallery
+
+import (
+	"image"
+	"image/color"
+)
+
+// Thumbnail scales src down so that its longer side is at most size pixels,
+// keeping the aspect ratio. Every thumbnail pixel is the average of the
+// source pixels it covers (box filter). A source that already fits is
+// returned as is.
+func Thumbnail(src image.Image, size int) image.Image {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	if sw <= size && sh <= size {
+		return src
+	}
+
+	dw, dh := size, size
+	if sw >= sh {
+		dh = max(1, sh*size/sw)
+	} else {
+		dw = max(1, sw*size/sh)
+	}
+
+	// Accumulate each source pixel into the thumbnail pixel covering it.
+	sums := make([][4]uint64, dw*dh)
+	counts := make([]uint64, dw*dh)
+	for y := 0; y < sh; y++ {
+		row := (y * dh / sh) * dw
+		for x := 0; x < sw; x++ {
+			i := row + x*dw/sw
+			r, g, bl, a := src.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			sums[i][0] += uint64(r)
+			sums[i][1] += uint64(g)
+			sums[i][2] += uint64(bl)
+			sums[i][3] += uint64(a)
+			counts[i]++
+		}
+	}
+
+	dst := image.NewRGBA64(image.Rect(0, 0, dw, dh))
+	for i, s := range sums {
+		n := counts[i]
+		dst.SetRGBA64(i%dw, i/dw, color.RGBA64{
+			R: uint16(s[0] / n),
+			G: uint16(s[1] / n),
+			B: uint16(s[2] / n),
+			A: uint16(s[3] / n),
+		})
+	}
+	return dst
+}
@@ -0,0 +1,637 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/security"
+	"metapus/pkg/logger"
+)
+
+// _maxDepth bounds the nesting of a query (each level is one more batch of
+// SQL queries).
+const _maxDepth = 8
+
+// Request is a GraphQL-over-HTTP request body.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response is a GraphQL response. Data is omitted when the request failed
+// before execution (syntax or validation errors).
+type Response struct {
+	Data   any      `json:"data,omitempty"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// Error is a GraphQL error.
+type Error struct {
+	Message    string         `json:"message"`
+	Locations  []Position     `json:"locations,omitempty"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// Error codes reported in Error.Extensions["code"].
+const (
+	CodeParseFailed      = "GRAPHQL_PARSE_FAILED"
+	CodeValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+	CodeBadUserInput     = "BAD_USER_INPUT"
+	CodeForbidden        = "FORBIDDEN"
+	CodeInternal         = "INTERNAL_SERVER_ERROR"
+)
+
+func newError(code, message string, pos *Position, path []any) *Error {
+	e := &Error{Message: message, Extensions: map[string]any{"code": code}}
+	if pos != nil {
+		e.Locations = []Position{*pos}
+	}
+	if len(path) > 0 {
+		e.Path = append([]any(nil), path...)
+	}
+	return e
+}
+
+// Authorizer checks that the caller holds a permission; it is the same
+// check RequirePermission applies to the REST routes. It returns ctx with the
+// data scope narrowed when the permission is granted within a scope only.
+type Authorizer func(ctx context.Context, permission string) (context.Context, error)
+
+// Service executes GraphQL queries against the generated schema.
+type Service struct {
+	schema *Schema
+	loader Loader
+}
+
+// NewService creates a new GraphQL service.
+func NewService(schema *Schema, loader Loader) *Service {
+	return &Service{schema: schema, loader: loader}
+}
+
+// Schema returns the generated schema.
+func (s *Service) Schema() *Schema { return s.schema }
+
+// Execute runs a query. Errors are reported in the response, never returned:
+// a field that fails (e.g. missing permission) is null with an error entry
+// while the rest of the query still resolves.
+func (s *Service) Execute(ctx context.Context, req Request, authorize Authorizer) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		var pos *Position
+		if se, ok := err.(*SyntaxError); ok {
+			pos = &se.Pos
+		}
+		return &Response{Errors: []*Error{newError(CodeParseFailed, err.Error(), pos, nil)}}
+	}
+
+	op, gqlErr := selectOperation(doc, req.OperationName)
+	if gqlErr != nil {
+		return &Response{Errors: []*Error{gqlErr}}
+	}
+	vars, errs := coerceVariables(op, req.Variables)
+	if len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+
+	v := &validator{schema: s.schema, doc: doc, vars: vars}
+	roots := v.collectRoot(op.SelectionSet)
+	if len(v.errs) > 0 {
+		return &Response{Errors: v.errs}
+	}
+
+	ex := &execution{svc: s, authorize: authorize}
+	data := &object{}
+	for _, p := range roots {
+		data.set(p.key, ex.resolveRoot(ctx, p))
+	}
+	return &Response{Data: data, Errors: ex.errs}
+}
+
+func selectOperation(doc *Document, name string) (*Operation, *Error) {
+	var op *Operation
+	switch {
+	case name != "":
+		for _, o := range doc.Operations {
+			if o.Name == name {
+				op = o
+			}
+		}
+		if op == nil {
+			return nil, newError(CodeBadUserInput, fmt.Sprintf("unknown operation %q", name), nil, nil)
+		}
+	case len(doc.Operations) == 1:
+		op = doc.Operations[0]
+	default:
+		return nil, newError(CodeBadUserInput, "operationName is required for a document with several operations", nil, nil)
+	}
+	if op.Type != "query" {
+		return nil, newError(CodeValidationFailed, "only queries are supported; use the REST API to change data", nil, nil)
+	}
+	return op, nil
+}
+
+func coerceVariables(op *Operation, given map[string]any) (map[string]any, []*Error) {
+	vars := make(map[string]any, len(op.Variables))
+	var errs []*Error
+	for _, def := range op.Variables {
+		val, ok := given[def.Name]
+		if !ok && def.Default != nil {
+			v, err := def.Default.Resolve(nil)
+			if err != nil {
+				errs = append(errs, newError(CodeBadUserInput, fmt.Sprintf("variable $%s: %v", def.Name, err), nil, nil))
+				continue
+			}
+			val = v
+		}
+		if val == nil && strings.HasSuffix(def.Type, "!") {
+			errs = append(errs, newError(CodeBadUserInput, fmt.Sprintf("variable $%s of type %s is required", def.Name, def.Type), nil, nil))
+			continue
+		}
+		vars[def.Name] = val
+	}
+	return vars, errs
+}
+
+// --- validation: selections → plans ---
+
+// plan is a validated field selection.
+type plan struct {
+	key      string
+	field    *SchemaField // nil for __typename and root fields
+	typename bool
+	args     map[string]any
+	children []*plan
+	pos      Position
+
+	// Root fields only.
+	root *ObjectType
+	list bool
+}
+
+type validator struct {
+	schema *Schema
+	doc    *Document
+	vars   map[string]any
+	errs   []*Error
+}
+
+func (v *validator) fail(code string, pos Position, format string, args ...any) {
+	v.errs = append(v.errs, newError(code, fmt.Sprintf(format, args...), &pos, nil))
+}
+
+// collectRoot validates the root selection set against the Query type.
+func (v *validator) collectRoot(sels []Selection) []*plan {
+	var out []*plan
+	for _, group := range v.group(sels, "Query", map[string]bool{}) {
+		f := group[0]
+		p := &plan{key: f.ResponseKey(), pos: f.Pos}
+		if f.Name == "__typename" {
+			p.typename = true
+			out = append(out, p)
+			continue
+		}
+		t, list, ok := v.schema.Root(f.Name)
+		if !ok {
+			v.fail(CodeValidationFailed, f.Pos, "cannot query field %q on type \"Query\"", f.Name)
+			continue
+		}
+		p.root, p.list = t, list
+		p.args = v.rootArgs(f, list)
+		p.children = v.children(t, group, 1)
+		out = append(out, p)
+	}
+	return out
+}
+
+func (v *validator) rootArgs(f *Field, list bool) map[string]any {
+	allowed := map[string]bool{"id": true}
+	if list {
+		allowed = map[string]bool{"limit": true, "offset": true, "filter": true, "orderBy": true, "desc": true}
+	}
+	args := map[string]any{}
+	for _, a := range f.Arguments {
+		if !allowed[a.Name] {
+			v.fail(CodeValidationFailed, f.Pos, "unknown argument %q on field %q", a.Name, f.Name)
+			continue
+		}
+		val, err := a.Value.Resolve(v.vars)
+		if err != nil {
+			v.fail(CodeBadUserInput, f.Pos, "argument %q: %v", a.Name, err)
+			continue
+		}
+		args[a.Name] = val
+	}
+	if !list && args["id"] == nil {
+		v.fail(CodeValidationFailed, f.Pos, "field %q requires argument \"id\"", f.Name)
+	}
+	return args
+}
+
+// children validates the merged sub-selections of a field group on type t.
+func (v *validator) children(t *ObjectType, group []*Field, depth int) []*plan {
+	var sels []Selection
+	for _, f := range group {
+		sels = append(sels, f.SelectionSet...)
+	}
+	if len(sels) == 0 {
+		v.fail(CodeValidationFailed, group[0].Pos, "field %q of type %q must have a selection of subfields", group[0].Name, t.Name)
+		return nil
+	}
+	if depth > _maxDepth {
+		v.fail(CodeValidationFailed, group[0].Pos, "query is nested deeper than %d levels", _maxDepth)
+		return nil
+	}
+
+	var out []*plan
+	for _, g := range v.group(sels, t.Name, map[string]bool{}) {
+		f := g[0]
+		p := &plan{key: f.ResponseKey(), pos: f.Pos}
+		if f.Name == "__typename" {
+			p.typename = true
+			out = append(out, p)
+			continue
+		}
+		sf, ok := t.Fields[f.Name]
+		if !ok {
+			v.fail(CodeValidationFailed, f.Pos, "cannot query field %q on type %q", f.Name, t.Name)
+			continue
+		}
+		if len(f.Arguments) > 0 {
+			v.fail(CodeValidationFailed, f.Pos, "unknown argument %q on field %q", f.Arguments[0].Name, f.Name)
+			continue
+		}
+		p.field = sf
+		if sf.Kind == FieldScalar {
+			if len(f.SelectionSet) > 0 {
+				v.fail(CodeValidationFailed, f.Pos, "field %q must not have a selection since type %q has no subfields", f.Name, sf.Type)
+				continue
+			}
+		} else {
+			p.children = v.children(sf.Target, g, depth+1)
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// group flattens fragments, applies @skip/@include and groups fields by
+// response key (fields with the same key are merged).
+func (v *validator) group(sels []Selection, typeName string, visiting map[string]bool) [][]*Field {
+	var keys []string
+	groups := map[string][]*Field{}
+
+	var walk func(sels []Selection)
+	walk = func(sels []Selection) {
+		for _, sel := range sels {
+			switch s := sel.(type) {
+			case *Field:
+				if !v.included(s.Directives, s.Pos) {
+					continue
+				}
+				key := s.ResponseKey()
+				if prev, ok := groups[key]; ok && prev[0].Name != s.Name {
+					v.fail(CodeValidationFailed, s.Pos, "fields %q and %q conflict because they have the same response name %q", prev[0].Name, s.Name, key)
+					continue
+				}
+				if _, ok := groups[key]; !ok {
+					keys = append(keys, key)
+				}
+				groups[key] = append(groups[key], s)
+			case *InlineFragment:
+				if !v.included(s.Directives, Position{}) {
+					continue
+				}
+				if s.TypeCondition != "" && s.TypeCondition != typeName {
+					continue
+				}
+				walk(s.SelectionSet)
+			case *FragmentSpread:
+				if !v.included(s.Directives, s.Pos) {
+					continue
+				}
+				frag, ok := v.doc.Fragments[s.Name]
+				if !ok {
+					v.fail(CodeValidationFailed, s.Pos, "unknown fragment %q", s.Name)
+					continue
+				}
+				if visiting[s.Name] {
+					v.fail(CodeValidationFailed, s.Pos, "fragment %q spreads itself", s.Name)
+					continue
+				}
+				if frag.TypeCondition != typeName {
+					v.fail(CodeValidationFailed, s.Pos, "fragment %q on type %q cannot be spread on type %q", s.Name, frag.TypeCondition, typeName)
+					continue
+				}
+				visiting[s.Name] = true
+				walk(frag.SelectionSet)
+				delete(visiting, s.Name)
+			}
+		}
+	}
+	walk(sels)
+
+	out := make([][]*Field, len(keys))
+	for i, k := range keys {
+		out[i] = groups[k]
+	}
+	return out
+}
+
+// included evaluates @skip(if:) and @include(if:).
+func (v *validator) included(dirs []*Directive, pos Position) bool {
+	for _, d := range dirs {
+		if d.Name != "skip" && d.Name != "include" {
+			v.fail(CodeValidationFailed, pos, "unknown directive \"@%s\"", d.Name)
+			continue
+		}
+		var cond bool
+		for _, a := range d.Arguments {
+			if a.Name == "if" {
+				val, _ := a.Value.Resolve(v.vars)
+				cond, _ = val.(bool)
+			}
+		}
+		if d.Name == "skip" && cond || d.Name == "include" && !cond {
+			return false
+		}
+	}
+	return true
+}
+
+// --- execution ---
+
+type execution struct {
+	svc       *Service
+	authorize Authorizer
+	errs      []*Error
+}
+
+func (ex *execution) addErr(err error, p *plan, path []any) {
+	code, msg := CodeInternal, "internal error"
+	if appErr, ok := apperror.AsAppError(err); ok && appErr.HTTPStatus < 500 {
+		code, msg = appErr.Code, appErr.Message
+		if appErr.HTTPStatus == 403 {
+			code = CodeForbidden
+		}
+	}
+	ex.errs = append(ex.errs, newError(code, msg, &p.pos, path))
+}
+
+func (ex *execution) resolveRoot(ctx context.Context, p *plan) any {
+	if p.typename {
+		return "Query"
+	}
+	path := []any{p.key}
+	t := p.root
+	ctx, err := ex.authorize(ctx, t.Permission())
+	if err != nil {
+		ex.addErr(err, p, path)
+		return nil
+	}
+
+	q, err := rootQuery(t, p)
+	if err != nil {
+		ex.addErr(err, p, path)
+		return nil
+	}
+	if !ex.scope(ctx, t, &q) {
+		if p.list {
+			return []any{}
+		}
+		return nil
+	}
+	q.Columns = columns(t, p.children)
+
+	rows, err := ex.svc.loader.Load(ctx, t, q)
+	if err != nil {
+		logger.Error(ctx, "graphql: load failed", "type", t.Name, "error", err)
+		ex.addErr(err, p, path)
+		return nil
+	}
+	objs := ex.resolve(ctx, t, rows, p.children, path)
+	if p.list {
+		out := make([]any, len(objs))
+		for i, o := range objs {
+			out[i] = o
+		}
+		return out
+	}
+	if len(objs) == 0 {
+		return nil
+	}
+	return objs[0]
+}
+
+// scope adds the RLS conditions of the caller to q. It returns false when
+// the caller has no access to any row of the entity.
+func (ex *execution) scope(ctx context.Context, t *ObjectType, q *LoadQuery) bool {
+	ds := security.GetDataScope(ctx)
+	if ds == nil || ds.IsAdmin {
+		return true
+	}
+	if !ds.GrantsCovered(t.Entity.Key, t.Entity.RLSDimensions) {
+		return false
+	}
+	effective := ds.EffectiveDimensions(t.Entity.Key)
+	for dim, col := range t.Entity.RLSDimensions {
+		allowed, restricted := effective[dim]
+		if !restricted {
+			continue
+		}
+		if len(allowed) == 0 {
+			return false
+		}
+		q.Scope = append(q.Scope, ScopeCondition{Column: col, Values: allowed})
+	}
+	return true
+}
+
+// resolve builds the objects for a batch of rows of t. Nested references
+// and table parts are loaded once for the whole batch.
+func (ex *execution) resolve(ctx context.Context, t *ObjectType, rows []Row, plans []*plan, path []any) []*object {
+	policy := security.GetFieldPolicy(ctx, t.Entity.Key, "read")
+	allowed := func(column string) bool {
+		if policy == nil {
+			return true
+		}
+		if t.IsLine() {
+			return policy.IsTablePartFieldAllowed(t.Part, column)
+		}
+		return policy.IsFieldAllowed(column)
+	}
+
+	nested := make(map[*plan]map[string]any, len(plans))
+	for _, p := range plans {
+		if p.field == nil || p.field.Kind == FieldScalar {
+			continue
+		}
+		fieldPath := append(append([]any(nil), path...), p.key)
+		switch p.field.Kind {
+		case FieldReference:
+			if allowed(p.field.Column) {
+				nested[p] = ex.loadReferences(ctx, p, rows, fieldPath)
+			}
+		case FieldTablePart:
+			nested[p] = ex.loadLines(ctx, t, p, rows, fieldPath)
+		}
+	}
+
+	out := make([]*object, len(rows))
+	for i, row := range rows {
+		o := &object{}
+		for _, p := range plans {
+			switch {
+			case p.typename:
+				o.set(p.key, t.Name)
+			case p.field.Kind == FieldScalar:
+				if !allowed(p.field.Column) {
+					o.set(p.key, nil)
+					continue
+				}
+				o.set(p.key, outputValue(p.field, row[p.field.Column]))
+			case p.field.Kind == FieldReference:
+				ref, _ := row[p.field.Column].(string)
+				o.set(p.key, nested[p][ref])
+			case p.field.Kind == FieldTablePart:
+				docID, _ := row["id"].(string)
+				lines, ok := nested[p][docID]
+				if !ok {
+					lines = []any{}
+				}
+				o.set(p.key, lines)
+			}
+		}
+		out[i] = o
+	}
+	return out
+}
+
+// loadReferences loads the entities referenced by the rows, keyed by id.
+func (ex *execution) loadReferences(ctx context.Context, p *plan, rows []Row, path []any) map[string]any {
+	target := p.field.Target
+	ids := distinct(rows, p.field.Column)
+	if len(ids) == 0 {
+		return nil
+	}
+	ctx, err := ex.authorize(ctx, target.Permission())
+	if err != nil {
+		ex.addErr(err, p, path)
+		return nil
+	}
+	q := LoadQuery{IDs: ids, Columns: columns(target, p.children)}
+	if !ex.scope(ctx, target, &q) {
+		return nil
+	}
+	loaded, err := ex.svc.loader.Load(ctx, target, q)
+	if err != nil {
+		logger.Error(ctx, "graphql: load references failed", "type", target.Name, "error", err)
+		ex.addErr(err, p, path)
+		return nil
+	}
+	objs := ex.resolve(ctx, target, loaded, p.children, path)
+	byID := make(map[string]any, len(objs))
+	for i, row := range loaded {
+		if rowID, ok := row["id"].(string); ok {
+			byID[rowID] = objs[i]
+		}
+	}
+	return byID
+}
+
+// loadLines loads the table part lines of the rows, grouped by document id.
+func (ex *execution) loadLines(ctx context.Context, t *ObjectType, p *plan, rows []Row, path []any) map[string]any {
+	line := p.field.Target
+	ids := distinct(rows, "id")
+	if len(ids) == 0 {
+		return nil
+	}
+	cols := columns(line, p.children)
+	loaded, err := ex.svc.loader.Load(ctx, line, LoadQuery{ParentIDs: ids, Columns: cols})
+	if err != nil {
+		logger.Error(ctx, "graphql: load lines failed", "type", line.Name, "error", err)
+		ex.addErr(err, p, path)
+		return nil
+	}
+	objs := ex.resolve(ctx, line, loaded, p.children, path)
+	byParent := map[string]any{}
+	for i, row := range loaded {
+		parent, _ := row[line.ParentColumn].(string)
+		lines, _ := byParent[parent].([]any)
+		byParent[parent] = append(lines, objs[i])
+	}
+	return byParent
+}
+
+// columns returns the columns needed to resolve plans on t: the selected
+// scalars, reference ids and the keys used to attach nested rows.
+func columns(t *ObjectType, plans []*plan) []string {
+	seen := map[string]bool{}
+	var out []string
+	add := func(c string) {
+		if c != "" && !seen[c] {
+			seen[c] = true
+			out = append(out, c)
+		}
+	}
+	if t.IsLine() {
+		add(t.ParentColumn)
+	} else {
+		add("id")
+	}
+	for _, p := range plans {
+		if p.field != nil {
+			add(p.field.Column)
+		}
+	}
+	return out
+}
+
+func distinct(rows []Row, column string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, r := range rows {
+		if v, ok := r[column].(string); ok && v != "" && !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// object is a response object; fields keep the order of the selection.
+type object struct {
+	keys   []string
+	values []any
+}
+
+func (o *object) set(key string, value any) {
+	o.keys = append(o.keys, key)
+	o.values = append(o.values, value)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o *object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		b.Write(key)
+		b.WriteByte(':')
+		val, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(val)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
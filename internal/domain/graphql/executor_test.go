@@ -0,0 +1,208 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/security"
+	"metapus/internal/core/types"
+	"metapus/internal/metadata"
+)
+
+type testCounterparty struct {
+	ID   id.ID  `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+	INN  string `json:"inn" db:"inn"`
+}
+
+type testReceipt struct {
+	ID         id.ID             `json:"id" db:"id"`
+	Number     string            `json:"number" db:"number"`
+	SupplierID id.ID             `json:"supplierId" db:"supplier_id" meta:"ref:supplier"`
+	Lines      []testReceiptLine `json:"lines"`
+}
+
+type testReceiptLine struct {
+	LineNo   int            `json:"lineNo" db:"line_no"`
+	Quantity types.Quantity `json:"quantity" db:"quantity"`
+}
+
+func testSchema() *Schema {
+	reg := metadata.NewRegistry()
+
+	cp := metadata.Inspect(testCounterparty{}, "Counterparty", metadata.TypeCatalog)
+	cp.Key, cp.TableName = "counterparty", "cat_counterparties"
+	reg.Register(cp)
+	reg.RegisterReferenceMapping("supplier", "Counterparty")
+
+	gr := metadata.Inspect(testReceipt{}, "GoodsReceipt", metadata.TypeDocument)
+	gr.Key, gr.TableName = "goods_receipt", "doc_goods_receipts"
+	reg.Register(gr)
+
+	return NewSchema(reg)
+}
+
+// memLoader serves rows per table and records the queries.
+type memLoader struct {
+	tables  map[string][]Row
+	queries []string
+}
+
+func (m *memLoader) Load(_ context.Context, t *ObjectType, q LoadQuery) ([]Row, error) {
+	m.queries = append(m.queries, t.Table)
+	var out []Row
+	for _, r := range m.tables[t.Table] {
+		switch {
+		case q.IDs != nil && !contains(q.IDs, r["id"]):
+		case q.ParentIDs != nil && !contains(q.ParentIDs, r[t.ParentColumn]):
+		default:
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func contains(list []string, v any) bool {
+	s, _ := v.(string)
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	supplierA = "0190a000-0000-7000-8000-00000000000a"
+	receipt1  = "0190a000-0000-7000-8000-000000000001"
+	receipt2  = "0190a000-0000-7000-8000-000000000002"
+)
+
+func testLoader() *memLoader {
+	return &memLoader{tables: map[string][]Row{
+		"cat_counterparties": {{"id": supplierA, "name": "Acme", "inn": "7701"}},
+		"doc_goods_receipts": {
+			{"id": receipt1, "number": "GR-1", "supplier_id": supplierA},
+			{"id": receipt2, "number": "GR-2", "supplier_id": supplierA},
+		},
+		"doc_goods_receipt_lines": {
+			{"document_id": receipt1, "line_no": json.Number("1"), "quantity": json.Number("25000")},
+		},
+	}}
+}
+
+func allowAll(ctx context.Context, _ string) (context.Context, error) { return ctx, nil }
+
+func marshal(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestExecuteNestedResolution(t *testing.T) {
+	loader := testLoader()
+	svc := NewService(testSchema(), loader)
+
+	resp := svc.Execute(context.Background(), Request{Query: `
+		query Receipts($n: Int) {
+			docs: goodsReceiptList(limit: $n) {
+				number
+				supplier { ...party }
+				lines { quantity }
+			}
+		}
+		fragment party on Counterparty { name __typename }`,
+		Variables: map[string]any{"n": json.Number("10")},
+	}, allowAll)
+
+	if len(resp.Errors) > 0 {
+		t.Fatalf("errors: %s", marshal(t, resp.Errors))
+	}
+	want := `{"docs":[` +
+		`{"number":"GR-1","supplier":{"name":"Acme","__typename":"Counterparty"},"lines":[{"quantity":2.5000}]},` +
+		`{"number":"GR-2","supplier":{"name":"Acme","__typename":"Counterparty"},"lines":[]}]}`
+	if got := marshal(t, resp.Data); got != want {
+		t.Errorf("data:\n got %s\nwant %s", got, want)
+	}
+	// One query per entity and level, not per row.
+	if got := strings.Join(loader.queries, ","); got != "doc_goods_receipts,cat_counterparties,doc_goods_receipt_lines" {
+		t.Errorf("queries = %s", got)
+	}
+}
+
+func TestExecutePermissions(t *testing.T) {
+	svc := NewService(testSchema(), testLoader())
+	denyCatalogs := func(ctx context.Context, perm string) (context.Context, error) {
+		if strings.HasPrefix(perm, "catalog:") {
+			return ctx, apperror.NewForbidden("insufficient permissions")
+		}
+		return ctx, nil
+	}
+
+	resp := svc.Execute(context.Background(), Request{
+		Query: `{ goodsReceipt(id: "` + receipt1 + `") { number supplierId supplier { name } } }`,
+	}, denyCatalogs)
+
+	if got := marshal(t, resp.Data); got != `{"goodsReceipt":{"number":"GR-1","supplierId":"`+supplierA+`","supplier":null}}` {
+		t.Errorf("data = %s", got)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != CodeForbidden {
+		t.Fatalf("errors = %s", marshal(t, resp.Errors))
+	}
+	if got := marshal(t, resp.Errors[0].Path); got != `["goodsReceipt","supplier"]` {
+		t.Errorf("path = %s", got)
+	}
+}
+
+func TestExecuteFieldPolicy(t *testing.T) {
+	svc := NewService(testSchema(), testLoader())
+	ctx := security.WithFieldPolicies(context.Background(), map[string]*security.FieldPolicy{
+		"counterparty:read": {AllowedFields: []string{"*", "-inn"}},
+	})
+
+	resp := svc.Execute(ctx, Request{Query: `{ counterpartyList { name inn } }`}, allowAll)
+	if got := marshal(t, resp.Data); got != `{"counterpartyList":[{"name":"Acme","inn":null}]}` {
+		t.Errorf("data = %s", got)
+	}
+}
+
+func TestExecuteValidation(t *testing.T) {
+	svc := NewService(testSchema(), testLoader())
+	cases := map[string]string{
+		`{ goodsReceiptList { total } }`:         `cannot query field "total"`,
+		`{ goodsReceiptList { supplier } }`:      "must have a selection of subfields",
+		`{ goodsReceipt { number } }`:            `requires argument "id"`,
+		`mutation { goodsReceiptList { id } }`:   "only queries are supported",
+		`{ goodsReceiptList(first: 1) { id } }`:  `unknown argument "first"`,
+		`{ goodsReceiptList { number { id } } }`: "must not have a selection",
+		`{ goodsReceiptList { id `:               "syntax error",
+	}
+	for query, want := range cases {
+		resp := svc.Execute(context.Background(), Request{Query: query}, allowAll)
+		if resp.Data != nil || len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, want) {
+			t.Errorf("%s: errors = %s, want %q", query, marshal(t, resp.Errors), want)
+		}
+	}
+}
+
+func TestSchemaSDL(t *testing.T) {
+	sdl := testSchema().SDL()
+	for _, want := range []string{
+		"goodsReceipt(id: ID!): GoodsReceipt\n",
+		"counterpartyList(limit: Int = 50",
+		"  supplier: Counterparty\n",
+		"  lines: [GoodsReceiptLines!]!\n",
+		"type GoodsReceiptLines {\n  lineNo: Long\n  quantity: Decimal\n}",
+	} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("SDL missing %q:\n%s", want, sdl)
+		}
+	}
+}
@@ -0,0 +1,660 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// The parser covers the executable part of the GraphQL language: operations
+// with variables, fields with aliases and arguments, fragments (named and
+// inline) and directives. Type system definitions are not accepted.
+
+// Document is a parsed GraphQL request document.
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation is a query, mutation or subscription definition.
+type Operation struct {
+	Type         string // "query", "mutation", "subscription"
+	Name         string
+	Variables    []*VariableDef
+	SelectionSet []Selection
+}
+
+// VariableDef is a declared operation variable.
+type VariableDef struct {
+	Name    string
+	Type    string // as written, e.g. "ID!", "[String]"
+	Default *Value
+}
+
+// Fragment is a named fragment definition.
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	SelectionSet  []Selection
+}
+
+// Selection is a *Field, *FragmentSpread or *InlineFragment.
+type Selection interface{ selection() }
+
+// Field is a selected field.
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet []Selection
+	Pos          Position
+}
+
+// FragmentSpread is "...Name".
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+	Pos        Position
+}
+
+// InlineFragment is "... on Type { ... }".
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+}
+
+func (*Field) selection()          {}
+func (*FragmentSpread) selection() {}
+func (*InlineFragment) selection() {}
+
+// ResponseKey is the alias, or the field name when there is none.
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// Argument is a field or directive argument.
+type Argument struct {
+	Name  string
+	Value *Value
+}
+
+// Directive is "@name(args)".
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+}
+
+// ValueKind is the kind of a literal value.
+type ValueKind int
+
+const (
+	ValueVariable ValueKind = iota
+	ValueInt
+	ValueFloat
+	ValueString
+	ValueBoolean
+	ValueNull
+	ValueEnum
+	ValueList
+	ValueObject
+)
+
+// Value is a literal or a variable reference.
+type Value struct {
+	Kind   ValueKind
+	Raw    string // scalar text, enum name or variable name
+	List   []*Value
+	Fields []*ObjectField
+}
+
+// ObjectField is a field of an input object literal.
+type ObjectField struct {
+	Name  string
+	Value *Value
+}
+
+// Resolve converts the value to Go: int64, float64, string, bool, nil,
+// []any or map[string]any. Variables are taken from vars.
+func (v *Value) Resolve(vars map[string]any) (any, error) {
+	switch v.Kind {
+	case ValueVariable:
+		return vars[v.Raw], nil
+	case ValueInt:
+		return strconv.ParseInt(v.Raw, 10, 64)
+	case ValueFloat:
+		return strconv.ParseFloat(v.Raw, 64)
+	case ValueString, ValueEnum:
+		return v.Raw, nil
+	case ValueBoolean:
+		return v.Raw == "true", nil
+	case ValueNull:
+		return nil, nil
+	case ValueList:
+		out := make([]any, len(v.List))
+		for i, item := range v.List {
+			r, err := item.Resolve(vars)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = r
+		}
+		return out, nil
+	case ValueObject:
+		out := make(map[string]any, len(v.Fields))
+		for _, f := range v.Fields {
+			r, err := f.Value.Resolve(vars)
+			if err != nil {
+				return nil, err
+			}
+			out[f.Name] = r
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("unknown value kind %d", v.Kind)
+}
+
+// Position is a 1-based location in the query text.
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// SyntaxError is a lexing or parsing error.
+type SyntaxError struct {
+	Message string
+	Pos     Position
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at %d:%d: %s", e.Pos.Line, e.Pos.Column, e.Message)
+}
+
+// Parse parses a GraphQL request document.
+func Parse(query string) (doc *Document, err error) {
+	p := &parser{lex: lexer{src: query, line: 1, col: 1}}
+	defer func() {
+		if r := recover(); r != nil {
+			se, ok := r.(*SyntaxError)
+			if !ok {
+				panic(r)
+			}
+			doc, err = nil, se
+		}
+	}()
+	p.next()
+	return p.parseDocument(), nil
+}
+
+// --- lexer ---
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   Position
+}
+
+type lexer struct {
+	src       string
+	off       int
+	line, col int
+}
+
+func (l *lexer) fail(pos Position, format string, args ...any) {
+	panic(&SyntaxError{Message: fmt.Sprintf(format, args...), Pos: pos})
+}
+
+func (l *lexer) peekByte(n int) byte {
+	if l.off+n < len(l.src) {
+		return l.src[l.off+n]
+	}
+	return 0
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n && l.off < len(l.src); i++ {
+		if l.src[l.off] == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+		l.off++
+	}
+}
+
+// skipIgnored skips whitespace, commas, comments and a byte order mark.
+func (l *lexer) skipIgnored() {
+	for l.off < len(l.src) {
+		switch c := l.src[l.off]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.advance(1)
+		case c == '#':
+			for l.off < len(l.src) && l.src[l.off] != '\n' {
+				l.advance(1)
+			}
+		case strings.HasPrefix(l.src[l.off:], "\uFEFF"):
+			l.off += len("\uFEFF")
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) next() token {
+	l.skipIgnored()
+	pos := Position{Line: l.line, Column: l.col}
+	if l.off >= len(l.src) {
+		return token{kind: tokEOF, pos: pos}
+	}
+
+	c := l.src[l.off]
+	switch {
+	case c == '.':
+		if !strings.HasPrefix(l.src[l.off:], "...") {
+			l.fail(pos, "unexpected %q", c)
+		}
+		l.advance(3)
+		return token{kind: tokPunct, value: "...", pos: pos}
+	case strings.IndexByte("!$&()=:@[]{}|", c) >= 0:
+		l.advance(1)
+		return token{kind: tokPunct, value: string(c), pos: pos}
+	case c == '_' || isLetter(c):
+		start := l.off
+		for l.off < len(l.src) && (l.src[l.off] == '_' || isLetter(l.src[l.off]) || isDigit(l.src[l.off])) {
+			l.advance(1)
+		}
+		return token{kind: tokName, value: l.src[start:l.off], pos: pos}
+	case c == '-' || isDigit(c):
+		return l.number(pos)
+	case c == '"':
+		if strings.HasPrefix(l.src[l.off:], `"""`) {
+			return l.blockString(pos)
+		}
+		return l.string(pos)
+	}
+	r, _ := utf8.DecodeRuneInString(l.src[l.off:])
+	l.fail(pos, "unexpected character %q", r)
+	return token{}
+}
+
+func (l *lexer) number(pos Position) token {
+	start := l.off
+	if l.src[l.off] == '-' {
+		l.advance(1)
+	}
+	digits := func() {
+		if !isDigit(l.peekByte(0)) {
+			l.fail(Position{Line: l.line, Column: l.col}, "invalid number")
+		}
+		for isDigit(l.peekByte(0)) {
+			l.advance(1)
+		}
+	}
+	digits()
+	kind := tokInt
+	if l.peekByte(0) == '.' {
+		kind = tokFloat
+		l.advance(1)
+		digits()
+	}
+	if c := l.peekByte(0); c == 'e' || c == 'E' {
+		kind = tokFloat
+		l.advance(1)
+		if c := l.peekByte(0); c == '+' || c == '-' {
+			l.advance(1)
+		}
+		digits()
+	}
+	if c := l.peekByte(0); c == '_' || c == '.' || isLetter(c) {
+		l.fail(Position{Line: l.line, Column: l.col}, "invalid number")
+	}
+	return token{kind: kind, value: l.src[start:l.off], pos: pos}
+}
+
+func (l *lexer) string(pos Position) token {
+	l.advance(1)
+	var b strings.Builder
+	for {
+		if l.off >= len(l.src) || l.src[l.off] == '\n' {
+			l.fail(pos, "unterminated string")
+		}
+		c := l.src[l.off]
+		switch c {
+		case '"':
+			l.advance(1)
+			return token{kind: tokString, value: b.String(), pos: pos}
+		case '\\':
+			esc := l.peekByte(1)
+			switch esc {
+			case '"', '\\', '/':
+				b.WriteByte(esc)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if l.off+6 > len(l.src) {
+					l.fail(pos, "invalid unicode escape")
+				}
+				n, err := strconv.ParseUint(l.src[l.off+2:l.off+6], 16, 32)
+				if err != nil {
+					l.fail(pos, "invalid unicode escape")
+				}
+				b.WriteRune(rune(n))
+				l.advance(4)
+			default:
+				l.fail(Position{Line: l.line, Column: l.col}, "invalid escape \\%c", esc)
+			}
+			l.advance(2)
+		default:
+			b.WriteByte(c)
+			l.advance(1)
+		}
+	}
+}
+
+// blockString reads a """block string""" and applies the common
+// indentation removal of the spec.
+func (l *lexer) blockString(pos Position) token {
+	l.advance(3)
+	start := l.off
+	for {
+		if l.off >= len(l.src) {
+			l.fail(pos, "unterminated block string")
+		}
+		if strings.HasPrefix(l.src[l.off:], `\"""`) {
+			l.advance(4)
+			continue
+		}
+		if strings.HasPrefix(l.src[l.off:], `"""`) {
+			raw := strings.ReplaceAll(l.src[start:l.off], `\"""`, `"""`)
+			l.advance(3)
+			return token{kind: tokString, value: blockStringValue(raw), pos: pos}
+		}
+		l.advance(1)
+	}
+}
+
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if n := len(line) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= indent {
+				lines[i] = lines[i][indent:]
+			} else {
+				lines[i] = ""
+			}
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+// --- parser ---
+
+type parser struct {
+	lex lexer
+	tok token
+}
+
+func (p *parser) next() { p.tok = p.lex.next() }
+
+func (p *parser) fail(format string, args ...any) {
+	p.lex.fail(p.tok.pos, format, args...)
+}
+
+func (p *parser) peek(punct string) bool {
+	return p.tok.kind == tokPunct && p.tok.value == punct
+}
+
+func (p *parser) skip(punct string) bool {
+	if p.peek(punct) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(punct string) {
+	if !p.skip(punct) {
+		p.fail("expected %q, found %s", punct, p.describe())
+	}
+}
+
+func (p *parser) name() string {
+	if p.tok.kind != tokName {
+		p.fail("expected name, found %s", p.describe())
+	}
+	v := p.tok.value
+	p.next()
+	return v
+}
+
+func (p *parser) describe() string {
+	if p.tok.kind == tokEOF {
+		return "end of document"
+	}
+	return strconv.Quote(p.tok.value)
+}
+
+func (p *parser) parseDocument() *Document {
+	doc := &Document{Fragments: map[string]*Fragment{}}
+	for p.tok.kind != tokEOF {
+		switch {
+		case p.peek("{"):
+			doc.Operations = append(doc.Operations, &Operation{Type: "query", SelectionSet: p.selectionSet()})
+		case p.tok.kind == tokName && p.tok.value == "fragment":
+			p.next()
+			f := &Fragment{Name: p.name()}
+			if f.Name == "on" {
+				p.fail("fragment cannot be named \"on\"")
+			}
+			if p.name() != "on" {
+				p.fail("expected \"on\"")
+			}
+			f.TypeCondition = p.name()
+			p.directives()
+			f.SelectionSet = p.selectionSet()
+			if _, dup := doc.Fragments[f.Name]; dup {
+				p.fail("duplicate fragment %q", f.Name)
+			}
+			doc.Fragments[f.Name] = f
+		case p.tok.kind == tokName && (p.tok.value == "query" || p.tok.value == "mutation" || p.tok.value == "subscription"):
+			op := &Operation{Type: p.tok.value}
+			p.next()
+			if p.tok.kind == tokName {
+				op.Name = p.name()
+			}
+			if p.skip("(") {
+				for !p.skip(")") {
+					op.Variables = append(op.Variables, p.variableDef())
+				}
+			}
+			p.directives()
+			op.SelectionSet = p.selectionSet()
+			doc.Operations = append(doc.Operations, op)
+		default:
+			p.fail("unexpected %s", p.describe())
+		}
+	}
+	if len(doc.Operations) == 0 {
+		p.fail("document contains no operation")
+	}
+	return doc
+}
+
+func (p *parser) variableDef() *VariableDef {
+	p.expect("$")
+	v := &VariableDef{Name: p.name()}
+	p.expect(":")
+	v.Type = p.typeRef()
+	if p.skip("=") {
+		v.Default = p.value(true)
+	}
+	p.directives()
+	return v
+}
+
+func (p *parser) typeRef() string {
+	var t string
+	if p.skip("[") {
+		t = "[" + p.typeRef() + "]"
+		p.expect("]")
+	} else {
+		t = p.name()
+	}
+	if p.skip("!") {
+		t += "!"
+	}
+	return t
+}
+
+func (p *parser) selectionSet() []Selection {
+	p.expect("{")
+	var set []Selection
+	for !p.skip("}") {
+		set = append(set, p.selection())
+	}
+	if len(set) == 0 {
+		p.fail("selection set cannot be empty")
+	}
+	return set
+}
+
+func (p *parser) selection() Selection {
+	pos := p.tok.pos
+	if p.skip("...") {
+		if p.tok.kind == tokName && p.tok.value != "on" {
+			return &FragmentSpread{Name: p.name(), Directives: p.directives(), Pos: pos}
+		}
+		inline := &InlineFragment{}
+		if p.tok.kind == tokName {
+			p.next() // "on"
+			inline.TypeCondition = p.name()
+		}
+		inline.Directives = p.directives()
+		inline.SelectionSet = p.selectionSet()
+		return inline
+	}
+
+	f := &Field{Name: p.name(), Pos: pos}
+	if p.skip(":") {
+		f.Alias, f.Name = f.Name, p.name()
+	}
+	f.Arguments = p.arguments(false)
+	f.Directives = p.directives()
+	if p.peek("{") {
+		f.SelectionSet = p.selectionSet()
+	}
+	return f
+}
+
+func (p *parser) arguments(constant bool) []*Argument {
+	if !p.skip("(") {
+		return nil
+	}
+	var args []*Argument
+	for !p.skip(")") {
+		a := &Argument{Name: p.name()}
+		p.expect(":")
+		a.Value = p.value(constant)
+		args = append(args, a)
+	}
+	return args
+}
+
+func (p *parser) directives() []*Directive {
+	var out []*Directive
+	for p.skip("@") {
+		out = append(out, &Directive{Name: p.name(), Arguments: p.arguments(false)})
+	}
+	return out
+}
+
+func (p *parser) value(constant bool) *Value {
+	tok := p.tok
+	switch {
+	case p.peek("$"):
+		if constant {
+			p.fail("variable not allowed here")
+		}
+		p.next()
+		return &Value{Kind: ValueVariable, Raw: p.name()}
+	case p.skip("["):
+		v := &Value{Kind: ValueList}
+		for !p.skip("]") {
+			v.List = append(v.List, p.value(constant))
+		}
+		return v
+	case p.skip("{"):
+		v := &Value{Kind: ValueObject}
+		for !p.skip("}") {
+			f := &ObjectField{Name: p.name()}
+			p.expect(":")
+			f.Value = p.value(constant)
+			v.Fields = append(v.Fields, f)
+		}
+		return v
+	case tok.kind == tokInt:
+		p.next()
+		return &Value{Kind: ValueInt, Raw: tok.value}
+	case tok.kind == tokFloat:
+		p.next()
+		return &Value{Kind: ValueFloat, Raw: tok.value}
+	case tok.kind == tokString:
+		p.next()
+		return &Value{Kind: ValueString, Raw: tok.value}
+	case tok.kind == tokName:
+		p.next()
+		switch tok.value {
+		case "true", "false":
+			return &Value{Kind: ValueBoolean, Raw: tok.value}
+		case "null":
+			return &Value{Kind: ValueNull}
+		}
+		return &Value{Kind: ValueEnum, Raw: tok.value}
+	}
+	p.fail("expected value, found %s", p.describe())
+	return nil
+}
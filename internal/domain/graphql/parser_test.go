@@ -0,0 +1,79 @@
+package graphql
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	doc, err := Parse(`
+		# receipts with their supplier
+		query Q($id: ID!, $tags: [String!] = ["a", "b"]) @cached {
+			r: goodsReceipt(id: $id) {
+				number, date
+				supplier @include(if: true) { name }
+				... on GoodsReceipt { posted }
+				...more
+			}
+		}
+		fragment more on GoodsReceipt { comment: description }`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	op := doc.Operations[0]
+	if op.Type != "query" || op.Name != "Q" || len(op.Variables) != 2 {
+		t.Fatalf("operation = %+v", op)
+	}
+	if v := op.Variables[1]; v.Type != "[String!]" || v.Default == nil || len(v.Default.List) != 2 {
+		t.Errorf("variable = %+v", v)
+	}
+
+	root := op.SelectionSet[0].(*Field)
+	if root.ResponseKey() != "r" || root.Name != "goodsReceipt" || root.Arguments[0].Value.Kind != ValueVariable {
+		t.Errorf("root field = %+v", root)
+	}
+	if len(root.SelectionSet) != 5 {
+		t.Fatalf("selections = %d, want 5", len(root.SelectionSet))
+	}
+	if in, ok := root.SelectionSet[3].(*InlineFragment); !ok || in.TypeCondition != "GoodsReceipt" {
+		t.Errorf("inline fragment = %#v", root.SelectionSet[3])
+	}
+	if f := doc.Fragments["more"]; f == nil || f.SelectionSet[0].(*Field).Alias != "comment" {
+		t.Errorf("fragment = %+v", f)
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	doc, err := Parse(`{ f(a: -12, b: 1.5e2, c: "x\"A", d: null, e: ASC, o: {k: [1, true]}, s: """
+		block
+		  text
+	""") { id } }`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	args := map[string]any{}
+	for _, a := range doc.Operations[0].SelectionSet[0].(*Field).Arguments {
+		v, err := a.Value.Resolve(nil)
+		if err != nil {
+			t.Fatalf("%s: %v", a.Name, err)
+		}
+		args[a.Name] = v
+	}
+	if args["a"] != int64(-12) || args["b"] != 150.0 || args["c"] != `x"A` || args["d"] != nil || args["e"] != "ASC" {
+		t.Errorf("scalars = %v", args)
+	}
+	if o := args["o"].(map[string]any)["k"].([]any); o[0] != int64(1) || o[1] != true {
+		t.Errorf("object = %v", args["o"])
+	}
+	if args["s"] != "block\n  text" {
+		t.Errorf("block string = %q", args["s"])
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, q := range []string{``, `{}`, `{ a(b: ) }`, `{ a "`, `query { a } extra`, `{ a(b: 1.) }`, `{ a(b: $) }`} {
+		if _, err := Parse(q); err == nil {
+			t.Errorf("Parse(%q): expected error", q)
+		}
+	}
+}
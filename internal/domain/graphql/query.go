@@ -0,0 +1,212 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/metadata"
+)
+
+// Row is a loaded row: column name → JSON-decoded value (uuid and
+// timestamps as strings, numbers as json.Number).
+type Row map[string]any
+
+// Loader reads rows of schema types.
+type Loader interface {
+	// Load returns the rows of t matching q, with only q.Columns.
+	Load(ctx context.Context, t *ObjectType, q LoadQuery) ([]Row, error)
+}
+
+// LoadQuery selects rows of an entity or a table part.
+type LoadQuery struct {
+	Columns []string
+
+	// IDs restricts entity rows by id; ParentIDs restricts lines by their
+	// document. Nil means no restriction.
+	IDs       []string
+	ParentIDs []string
+
+	Filters []Condition
+	Scope   []ScopeCondition // RLS: column IN values
+
+	OrderBy string // column; empty = entity default
+	Desc    bool
+	Limit   int // 0 = no limit
+	Offset  int
+}
+
+// Condition is an equality filter; a nil Value means IS NULL. Cast is the
+// SQL type the parameter is cast to ("numeric"), if any.
+type Condition struct {
+	Column string
+	Value  any
+	Cast   string
+}
+
+// ScopeCondition restricts a column to the values a data scope allows.
+type ScopeCondition struct {
+	Column string
+	Values []string
+}
+
+// rootQuery builds the query of a root field from its arguments.
+func rootQuery(t *ObjectType, p *plan) (LoadQuery, error) {
+	if !p.list {
+		s, ok := p.args["id"].(string)
+		if !ok {
+			return LoadQuery{}, apperror.NewValidation("argument \"id\" must be an ID").WithDetail("field", "id")
+		}
+		if _, err := id.Parse(s); err != nil {
+			return LoadQuery{}, apperror.NewValidation("argument \"id\" is not a valid ID").WithDetail("field", "id")
+		}
+		return LoadQuery{IDs: []string{s}}, nil
+	}
+
+	q := LoadQuery{Limit: _defaultLimit}
+	if v, ok := p.args["limit"]; ok && v != nil {
+		n, err := intArg("limit", v)
+		if err != nil {
+			return q, err
+		}
+		q.Limit = min(max(n, 1), _maxLimit)
+	}
+	if v, ok := p.args["offset"]; ok && v != nil {
+		n, err := intArg("offset", v)
+		if err != nil {
+			return q, err
+		}
+		q.Offset = max(n, 0)
+	}
+	if v, ok := p.args["orderBy"]; ok && v != nil {
+		name, _ := v.(string)
+		f, ok := t.Fields[name]
+		if !ok || f.Kind != FieldScalar {
+			return q, apperror.NewValidation(fmt.Sprintf("cannot order %s by %q", t.Name, name)).WithDetail("field", "orderBy")
+		}
+		q.OrderBy = f.Column
+	}
+	if v, ok := p.args["desc"].(bool); ok {
+		q.Desc = v
+	}
+	if v, ok := p.args["filter"]; ok && v != nil {
+		filter, ok := v.(map[string]any)
+		if !ok {
+			return q, apperror.NewValidation("argument \"filter\" must be an object").WithDetail("field", "filter")
+		}
+		for name, val := range filter {
+			f, ok := t.Fields[name]
+			if !ok || f.Kind != FieldScalar || f.Def.Type == metadata.TypeJSON {
+				return q, apperror.NewValidation(fmt.Sprintf("cannot filter %s by %q", t.Name, name)).WithDetail("field", "filter."+name)
+			}
+			c, err := condition(f, val)
+			if err != nil {
+				return q, err
+			}
+			q.Filters = append(q.Filters, c)
+		}
+	}
+	return q, nil
+}
+
+func intArg(name string, v any) (int, error) {
+	switch n := v.(type) {
+	case int64:
+		return int(n), nil
+	case float64:
+		if n == math.Trunc(n) {
+			return int(n), nil
+		}
+	case json.Number:
+		if i, err := n.Int64(); err == nil {
+			return int(i), nil
+		}
+	}
+	return 0, apperror.NewValidation(fmt.Sprintf("argument %q must be an integer", name)).WithDetail("field", name)
+}
+
+// condition converts a filter value to the type of the column.
+func condition(f *SchemaField, val any) (Condition, error) {
+	c := Condition{Column: f.Column}
+	if val == nil {
+		return c, nil
+	}
+	bad := func() (Condition, error) {
+		return c, apperror.NewValidation(fmt.Sprintf("invalid value for %q", f.Name)).WithDetail("field", "filter."+f.Name)
+	}
+
+	switch f.Def.Type {
+	case metadata.TypeReference:
+		s, ok := val.(string)
+		if !ok {
+			return bad()
+		}
+		ref, err := id.Parse(s)
+		if err != nil {
+			return bad()
+		}
+		c.Value = ref
+	case metadata.TypeBoolean:
+		b, ok := val.(bool)
+		if !ok {
+			return bad()
+		}
+		c.Value = b
+	case metadata.TypeDate, metadata.TypeDatetime:
+		s, ok := val.(string)
+		if !ok {
+			return bad()
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			if t, err = time.Parse(time.DateOnly, s); err != nil {
+				return bad()
+			}
+		}
+		c.Value = t
+	case metadata.TypeInteger, metadata.TypeMoney, metadata.TypeNumber, metadata.TypeDecimal:
+		d, err := decimal.NewFromString(fmt.Sprint(val))
+		if err != nil {
+			return bad()
+		}
+		if f.Def.ValueScale > 0 {
+			d = d.Mul(decimal.NewFromInt(int64(f.Def.ValueScale)))
+		}
+		if f.Def.Type == metadata.TypeInteger || f.Def.Type == metadata.TypeMoney || f.Def.ValueScale > 0 {
+			if !d.IsInteger() {
+				return bad()
+			}
+			c.Value = d.IntPart()
+		} else {
+			c.Value, c.Cast = d.String(), "numeric"
+		}
+	default:
+		s, ok := val.(string)
+		if !ok {
+			return bad()
+		}
+		c.Value = s
+	}
+	return c, nil
+}
+
+// outputValue converts a loaded column value to its GraphQL form. Scaled
+// quantities are returned in user units, like the REST API does.
+func outputValue(f *SchemaField, v any) any {
+	if f.Def.ValueScale <= 1 || v == nil {
+		return v
+	}
+	d, err := decimal.NewFromString(fmt.Sprint(v))
+	if err != nil {
+		return v
+	}
+	digits := int32(len(strconv.Itoa(f.Def.ValueScale)) - 1)
+	return json.Number(d.Shift(-digits).StringFixed(digits))
+}
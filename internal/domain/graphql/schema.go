@@ -0,0 +1,317 @@
+// Package graphql serves a read-only GraphQL API over catalogs and documents.
+//
+// The schema is generated from metadata.Registry: every entity with a table
+// becomes an object type and two root fields (a lookup by id and a list),
+// reference fields get a nested object field resolving the referenced
+// entity, and document table parts become lists of line objects. Queries
+// are executed with one SQL query per entity and nesting level (references
+// and lines are batch-loaded, not resolved row by row), under the same
+// access rules as the REST API: the "<type>:<key>:read" permission, RLS
+// dimensions and field-level security.
+package graphql
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"metapus/internal/metadata"
+)
+
+// Default and maximum page size of list fields.
+const (
+	_defaultLimit = 50
+	_maxLimit     = 500
+)
+
+// FieldKind tells how a schema field is resolved.
+type FieldKind int
+
+const (
+	FieldScalar    FieldKind = iota // column value
+	FieldReference                  // object loaded by the id in Column
+	FieldTablePart                  // lines loaded by the parent id
+)
+
+// ObjectType is an entity or a table part line.
+type ObjectType struct {
+	Name   string
+	Entity *metadata.EntityDef // owning entity (for lines: the document)
+	Table  string
+	// Part is the table part name for line types; empty for entities.
+	Part string
+	// ParentColumn is the line's foreign key to the document.
+	ParentColumn string
+
+	Fields map[string]*SchemaField
+	Order  []string // field names in declaration order
+}
+
+// IsLine reports whether the type is a table part line.
+func (t *ObjectType) IsLine() bool { return t.Part != "" }
+
+// Permission is the read permission of the entity, as required by its REST
+// routes ("catalog:counterparty:read").
+func (t *ObjectType) Permission() string {
+	return string(t.Entity.Type) + ":" + t.Entity.Key + ":read"
+}
+
+// SchemaField is a field of an object type.
+type SchemaField struct {
+	Name   string
+	Kind   FieldKind
+	Column string
+	Def    metadata.FieldDef
+	Type   string      // GraphQL type for the SDL
+	Target *ObjectType // referenced entity or line type
+}
+
+// Schema is the generated GraphQL schema.
+type Schema struct {
+	types map[string]*ObjectType
+	// roots maps query field names to entity types; list fields are
+	// marked in lists.
+	roots map[string]*ObjectType
+	lists map[string]bool
+}
+
+// NewSchema generates the schema from the metadata registry. Must be
+// called after all entities are registered.
+func NewSchema(reg *metadata.Registry) *Schema {
+	s := &Schema{
+		types: map[string]*ObjectType{},
+		roots: map[string]*ObjectType{},
+		lists: map[string]bool{},
+	}
+
+	defs := reg.List()
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+
+	// Object types first, so references can point at any of them.
+	for i := range defs {
+		def := &defs[i]
+		if def.TableName == "" || def.Key == "" || !isName(def.Name) {
+			continue
+		}
+		s.types[def.Name] = &ObjectType{Name: def.Name, Entity: def, Table: def.TableName, Fields: map[string]*SchemaField{}}
+	}
+
+	for _, t := range s.sortedTypes() {
+		def := t.Entity
+		for _, f := range def.Fields {
+			s.addColumn(reg, t, f)
+		}
+		if def.Type == metadata.TypeDocument {
+			for _, tp := range def.TableParts {
+				s.addTablePart(reg, t, tp)
+			}
+		}
+
+		single := lowerFirst(t.Name)
+		s.roots[single] = t
+		s.roots[single+"List"] = t
+		s.lists[single+"List"] = true
+	}
+	return s
+}
+
+// Type returns an object type by name.
+func (s *Schema) Type(name string) (*ObjectType, bool) {
+	t, ok := s.types[name]
+	return t, ok
+}
+
+// Root returns the entity type of a root query field and whether the field
+// returns a list.
+func (s *Schema) Root(name string) (t *ObjectType, list, ok bool) {
+	t, ok = s.roots[name]
+	return t, s.lists[name], ok
+}
+
+func (s *Schema) sortedTypes() []*ObjectType {
+	out := make([]*ObjectType, 0, len(s.types))
+	for _, t := range s.types {
+		if !t.IsLine() {
+			out = append(out, t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// addColumn adds a column field and, for references to a known entity, the
+// nested object field (supplierId → supplier).
+func (s *Schema) addColumn(reg *metadata.Registry, t *ObjectType, f metadata.FieldDef) {
+	if f.Column == "" || f.Column == "-" || f.Type == metadata.TypeTypedRef || !isName(f.Name) {
+		return
+	}
+	t.add(&SchemaField{Name: f.Name, Kind: FieldScalar, Column: f.Column, Def: f, Type: scalarType(f)})
+
+	if f.Type != metadata.TypeReference {
+		return
+	}
+	name, ok := strings.CutSuffix(f.Name, "Id")
+	if !ok || name == "" {
+		return
+	}
+	if target := s.referenceTarget(reg, t, f.ReferenceType); target != nil {
+		t.add(&SchemaField{Name: name, Kind: FieldReference, Column: f.Column, Def: f, Type: target.Name, Target: target})
+	}
+}
+
+// referenceTarget resolves a reference type ("supplier", "parent") to an
+// entity type of the schema.
+func (s *Schema) referenceTarget(reg *metadata.Registry, from *ObjectType, refType string) *ObjectType {
+	if refType == "" {
+		return nil
+	}
+	if refType == "parent" && !from.IsLine() {
+		return s.types[from.Entity.Name]
+	}
+	if name, ok := reg.GetEntityByRefType(refType); ok {
+		return s.types[name]
+	}
+	if def, ok := reg.Get(refType); ok {
+		return s.types[def.Name]
+	}
+	return nil
+}
+
+// addTablePart adds a line type for a document table part. Lines live in
+// "<document table without plural s>_<part>" keyed by document_id, e.g.
+// doc_goods_receipts → doc_goods_receipt_lines.
+func (s *Schema) addTablePart(reg *metadata.Registry, t *ObjectType, tp metadata.TablePartDef) {
+	if !isName(tp.Name) {
+		return
+	}
+	line := &ObjectType{
+		Name:         t.Name + upperFirst(tp.Name),
+		Entity:       t.Entity,
+		Table:        strings.TrimSuffix(t.Table, "s") + "_" + toSnake(tp.Name),
+		Part:         tp.Name,
+		ParentColumn: "document_id",
+		Fields:       map[string]*SchemaField{},
+	}
+	if _, exists := s.types[line.Name]; exists {
+		return
+	}
+	s.types[line.Name] = line
+	for _, col := range tp.Columns {
+		s.addColumn(reg, line, col)
+	}
+	t.add(&SchemaField{Name: tp.Name, Kind: FieldTablePart, Type: "[" + line.Name + "!]!", Target: line})
+}
+
+func (t *ObjectType) add(f *SchemaField) {
+	if _, exists := t.Fields[f.Name]; exists {
+		return
+	}
+	t.Fields[f.Name] = f
+	t.Order = append(t.Order, f.Name)
+}
+
+// scalarType maps a metadata field type to a GraphQL scalar.
+func scalarType(f metadata.FieldDef) string {
+	switch f.Type {
+	case metadata.TypeReference:
+		return "ID"
+	case metadata.TypeInteger:
+		return "Long"
+	case metadata.TypeNumber, metadata.TypeDecimal:
+		return "Decimal"
+	case metadata.TypeMoney:
+		return "Money"
+	case metadata.TypeBoolean:
+		return "Boolean"
+	case metadata.TypeDate, metadata.TypeDatetime:
+		return "DateTime"
+	case metadata.TypeJSON:
+		return "JSON"
+	default:
+		return "String"
+	}
+}
+
+// SDL renders the schema in the GraphQL schema definition language.
+func (s *Schema) SDL() string {
+	var b strings.Builder
+	b.WriteString(`"""Signed 64-bit integer."""` + "\nscalar Long\n")
+	b.WriteString(`"""Decimal number, e.g. a quantity with 4 fractional digits."""` + "\nscalar Decimal\n")
+	b.WriteString(`"""Amount in minor currency units (cents)."""` + "\nscalar Money\n")
+	b.WriteString(`"""RFC 3339 timestamp."""` + "\nscalar DateTime\n")
+	b.WriteString("scalar JSON\n\n")
+
+	b.WriteString("type Query {\n")
+	roots := make([]string, 0, len(s.roots))
+	for name := range s.roots {
+		roots = append(roots, name)
+	}
+	sort.Strings(roots)
+	for _, name := range roots {
+		t := s.roots[name]
+		if s.lists[name] {
+			b.WriteString("  " + name + "(limit: Int = " + strconv.Itoa(_defaultLimit) + ", offset: Int = 0, filter: JSON, orderBy: String, desc: Boolean = false): [" + t.Name + "!]!\n")
+		} else {
+			b.WriteString("  " + name + "(id: ID!): " + t.Name + "\n")
+		}
+	}
+	b.WriteString("}\n")
+
+	names := make([]string, 0, len(s.types))
+	for name := range s.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t := s.types[name]
+		b.WriteString("\ntype " + t.Name + " {\n")
+		for _, fname := range t.Order {
+			f := t.Fields[fname]
+			b.WriteString("  " + f.Name + ": " + f.Type + "\n")
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+func isName(s string) bool {
+	if s == "" || isDigit(s[0]) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c != '_' && !isLetter(c) && !isDigit(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// toSnake converts a camelCase name to snake_case.
+func toSnake(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'A' && c <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			c += 'a' - 'A'
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
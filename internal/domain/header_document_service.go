@@ -3,9 +3,9 @@ package domain
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"metapus/internal/core/apperror"
+	"metapus/internal/core/clock"
 	"metapus/internal/core/id"
 	"metapus/internal/core/numerator"
 	"metapus/internal/core/security"
@@ -49,6 +49,7 @@ type BaseHeaderDocumentService[T HeaderDocumentEntity] struct {
 
 // NewBaseHeaderDocumentService creates a new BaseHeaderDocumentService.
 func NewBaseHeaderDocumentService[T HeaderDocumentEntity](cfg BaseHeaderDocumentServiceConfig[T]) *BaseHeaderDocumentService[T] {
+	nameRepo(cfg.Repo, cfg.EntityName)
 	return &BaseHeaderDocumentService[T]{
 		Repo:              cfg.Repo,
 		PostingEngine:     cfg.PostingEngine,
@@ -109,8 +110,12 @@ func (s *BaseHeaderDocumentService[T]) GenerateNumber(ctx context.Context, doc T
 	if doc.GetNumber() != "" {
 		return nil
 	}
-	cfg := numerator.DefaultConfig(s.NumeratorPrefix)
-	number, err := s.Numerator.GetNextNumber(ctx, cfg, &numerator.Options{Strategy: s.NumeratorStrategy}, time.Now())
+	var orgID id.ID
+	if orgOwned, ok := any(doc).(OrganizationOwned); ok {
+		orgID = orgOwned.GetOrganizationID()
+	}
+	cfg := NumeratorConfig(ctx, s.NumeratorPrefix, orgID)
+	number, err := s.Numerator.GetNextNumber(ctx, cfg, &numerator.Options{Strategy: s.NumeratorStrategy}, clock.Now(ctx))
 	if err != nil {
 		return fmt.Errorf("generate number: %w", err)
 	}
@@ -123,6 +128,11 @@ func (s *BaseHeaderDocumentService[T]) checkRLSAccess(ctx context.Context, doc T
 	return security.CheckRLSAccess(ctx, s.EntityName, doc)
 }
 
+// authorize delegates to security.Authorize for the document type.
+func (s *BaseHeaderDocumentService[T]) authorize(ctx context.Context, perm security.Permission) (context.Context, error) {
+	return security.Authorize(ctx, s.EntityName, perm)
+}
+
 // checkCELPolicy delegates to security.CheckCELPolicy.
 func (s *BaseHeaderDocumentService[T]) checkCELPolicy(ctx context.Context, action string, doc T) error {
 	return security.CheckCELPolicy(ctx, s.PolicyEngine, s.EntityName, action, doc)
@@ -136,6 +146,9 @@ func (s *BaseHeaderDocumentService[T]) Create(ctx context.Context, doc T) error
 	if err := s.hooks.RunBeforeCreate(ctx, doc); err != nil {
 		return err
 	}
+	if err := s.checkRLSAccess(ctx, doc); err != nil {
+		return err
+	}
 	if err := s.ResolveCurrency(ctx, doc); err != nil {
 		return err
 	}
@@ -145,6 +158,9 @@ func (s *BaseHeaderDocumentService[T]) Create(ctx context.Context, doc T) error
 	if err := s.checkCELPolicy(ctx, "create", doc); err != nil {
 		return err
 	}
+	if err := checkNumberUnique(ctx, s.Repo, s.EntityName, doc.GetID(), doc.GetNumber(), doc); err != nil {
+		return err
+	}
 	if err := s.GenerateNumber(ctx, doc); err != nil {
 		return err
 	}
@@ -154,7 +170,10 @@ func (s *BaseHeaderDocumentService[T]) Create(ctx context.Context, doc T) error
 		return apperror.NewInternal(err).WithDetail("missing", "tx_manager")
 	}
 	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
-		return s.Repo.Create(ctx, doc)
+		if err := s.Repo.Create(ctx, doc); err != nil {
+			return err
+		}
+		return s.hooks.Run(ctx, CreateInTx, doc)
 	})
 	if err != nil {
 		return err
@@ -204,6 +223,10 @@ func (s *BaseHeaderDocumentService[T]) Update(ctx context.Context, doc T) error
 	if err := s.checkRLSAccess(ctx, oldDoc); err != nil {
 		return err
 	}
+	// Both the stored and the new date must be in an open period
+	if err := s.PostingEngine.CheckPeriod(ctx, oldDoc, doc); err != nil {
+		return err
+	}
 	if writePolicy := security.GetFieldPolicy(ctx, s.EntityName, "write"); writePolicy != nil {
 		if err := security.ValidateWrite(oldDoc, doc, writePolicy); err != nil {
 			return err
@@ -229,12 +252,19 @@ func (s *BaseHeaderDocumentService[T]) Update(ctx context.Context, doc T) error
 		return err
 	}
 
+	if err := checkNumberUnique(ctx, s.Repo, s.EntityName, doc.GetID(), doc.GetNumber(), doc); err != nil {
+		return err
+	}
+
 	txm, err := s.GetTxManager(ctx)
 	if err != nil {
 		return apperror.NewInternal(err).WithDetail("missing", "tx_manager")
 	}
 	return txm.RunInTransaction(ctx, func(ctx context.Context) error {
-		return s.Repo.Update(ctx, doc)
+		if err := s.Repo.Update(ctx, doc); err != nil {
+			return err
+		}
+		return s.hooks.Run(ctx, UpdateInTx, doc)
 	})
 }
 
@@ -256,6 +286,9 @@ func (s *BaseHeaderDocumentService[T]) Delete(ctx context.Context, docID id.ID)
 	if err := doc.State().CanDelete(); err != nil {
 		return err
 	}
+	if err := s.PostingEngine.CheckPeriod(ctx, doc); err != nil {
+		return err
+	}
 	return s.Repo.Delete(ctx, docID)
 }
 
@@ -278,15 +311,25 @@ func (s *BaseHeaderDocumentService[T]) SetDeletionMark(ctx context.Context, docI
 	if doc.IsDeletionMarked() == marked {
 		return nil
 	}
+	if err := s.PostingEngine.CheckPeriod(ctx, doc); err != nil {
+		return err
+	}
 
 	if marked {
 		if doc.IsPosted() {
+			ctx, err := s.authorize(ctx, security.PermissionUnpost)
+			if err != nil {
+				return err
+			}
 			if err := s.checkCELPolicy(ctx, "unpost", doc); err != nil {
 				return err
 			}
 			updateDocAndMark := func(ctx context.Context) error {
 				doc.MarkDeleted()
-				return s.Repo.Update(ctx, doc)
+				if err := s.Repo.Update(ctx, doc); err != nil {
+					return err
+				}
+				return s.hooks.Run(ctx, UnpostInTx, doc)
 			}
 			return s.PostingEngine.Unpost(ctx, doc, updateDocAndMark)
 		}
@@ -316,6 +359,10 @@ func (s *BaseHeaderDocumentService[T]) Post(ctx context.Context, docID id.ID) er
 	if err := security.GetDataScope(ctx).CanMutate(); err != nil {
 		return err
 	}
+	ctx, err := s.authorize(ctx, security.PermissionPost)
+	if err != nil {
+		return err
+	}
 	doc, err := s.GetByID(ctx, docID)
 	if err != nil {
 		return err
@@ -328,16 +375,44 @@ func (s *BaseHeaderDocumentService[T]) Post(ctx context.Context, docID id.ID) er
 	}
 
 	updateDoc := func(ctx context.Context) error {
-		return s.Repo.Update(ctx, doc)
+		if err := s.Repo.Update(ctx, doc); err != nil {
+			return err
+		}
+		return s.hooks.Run(ctx, PostInTx, doc)
 	}
 	return s.PostingEngine.Post(ctx, doc, updateDoc)
 }
 
+// PostDryRun runs the posting pipeline without recording anything.
+// See BaseDocumentService.PostDryRun.
+func (s *BaseHeaderDocumentService[T]) PostDryRun(ctx context.Context, docID id.ID) (*posting.DryRunResult, error) {
+	doc, err := s.GetByID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkRLSAccess(ctx, doc); err != nil {
+		return nil, err
+	}
+
+	result, err := s.PostingEngine.DryRun(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCELPolicy(ctx, "post", doc); err != nil {
+		result.Errors = append(result.Errors, err)
+	}
+	return result, nil
+}
+
 // Unpost reverses document movements.
 func (s *BaseHeaderDocumentService[T]) Unpost(ctx context.Context, docID id.ID) error {
 	if err := security.GetDataScope(ctx).CanMutate(); err != nil {
 		return err
 	}
+	ctx, err := s.authorize(ctx, security.PermissionUnpost)
+	if err != nil {
+		return err
+	}
 	doc, err := s.GetByID(ctx, docID)
 	if err != nil {
 		return err
@@ -350,7 +425,10 @@ func (s *BaseHeaderDocumentService[T]) Unpost(ctx context.Context, docID id.ID)
 	}
 
 	updateDoc := func(ctx context.Context) error {
-		return s.Repo.Update(ctx, doc)
+		if err := s.Repo.Update(ctx, doc); err != nil {
+			return err
+		}
+		return s.hooks.Run(ctx, UnpostInTx, doc)
 	}
 	return s.PostingEngine.Unpost(ctx, doc, updateDoc)
 }
@@ -360,6 +438,10 @@ func (s *BaseHeaderDocumentService[T]) PostAndSave(ctx context.Context, doc T) e
 	if err := security.GetDataScope(ctx).CanMutate(); err != nil {
 		return err
 	}
+	ctx, err := s.authorize(ctx, security.PermissionPost)
+	if err != nil {
+		return err
+	}
 	if err := s.hooks.RunBeforeCreate(ctx, doc); err != nil {
 		return err
 	}
@@ -369,15 +451,27 @@ func (s *BaseHeaderDocumentService[T]) PostAndSave(ctx context.Context, doc T) e
 	if err := s.checkCELPolicy(ctx, "create", doc); err != nil {
 		return err
 	}
+	if err := checkNumberUnique(ctx, s.Repo, s.EntityName, doc.GetID(), doc.GetNumber(), doc); err != nil {
+		return err
+	}
 	if err := s.GenerateNumber(ctx, doc); err != nil {
 		return err
 	}
 
 	updateDoc := func(ctx context.Context) error {
+		event := UpdateInTx
 		if doc.GetVersion() == 1 {
-			return s.Repo.Create(ctx, doc)
+			event = CreateInTx
+			if err := s.Repo.Create(ctx, doc); err != nil {
+				return err
+			}
+		} else if err := s.Repo.Update(ctx, doc); err != nil {
+			return err
 		}
-		return s.Repo.Update(ctx, doc)
+		if err := s.hooks.Run(ctx, event, doc); err != nil {
+			return err
+		}
+		return s.hooks.Run(ctx, PostInTx, doc)
 	}
 	return s.PostingEngine.Post(ctx, doc, updateDoc)
 }
@@ -387,6 +481,10 @@ func (s *BaseHeaderDocumentService[T]) UpdateAndRepost(ctx context.Context, doc
 	if err := security.GetDataScope(ctx).CanMutate(); err != nil {
 		return err
 	}
+	ctx, err := s.authorize(ctx, security.PermissionPost)
+	if err != nil {
+		return err
+	}
 	if err := s.checkRLSAccess(ctx, doc); err != nil {
 		return err
 	}
@@ -398,6 +496,10 @@ func (s *BaseHeaderDocumentService[T]) UpdateAndRepost(ctx context.Context, doc
 	if err := s.checkRLSAccess(ctx, oldDoc); err != nil {
 		return err
 	}
+	// The new date is checked by the posting engine
+	if err := s.PostingEngine.CheckPeriod(ctx, oldDoc); err != nil {
+		return err
+	}
 	if err := s.checkCELPolicy(ctx, "update", oldDoc); err != nil {
 		return err
 	}
@@ -420,8 +522,18 @@ func (s *BaseHeaderDocumentService[T]) UpdateAndRepost(ctx context.Context, doc
 		return err
 	}
 
+	if err := checkNumberUnique(ctx, s.Repo, s.EntityName, doc.GetID(), doc.GetNumber(), doc); err != nil {
+		return err
+	}
+
 	updateDoc := func(ctx context.Context) error {
-		return s.Repo.Update(ctx, doc)
+		if err := s.Repo.Update(ctx, doc); err != nil {
+			return err
+		}
+		if err := s.hooks.Run(ctx, UpdateInTx, doc); err != nil {
+			return err
+		}
+		return s.hooks.Run(ctx, PostInTx, doc)
 	}
 	return s.PostingEngine.Post(ctx, doc, updateDoc)
 }
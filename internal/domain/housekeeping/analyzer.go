@@ -0,0 +1,307 @@
+package housekeeping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain/notifications"
+	"metapus/internal/domain/settings"
+)
+
+// _sampleSize bounds the anomalies listed in one notification.
+const _sampleSize = 20
+
+// Thresholds are the per-tenant limits of the checks. Zero values disable
+// the corresponding check.
+type Thresholds struct {
+	PendingKeyAge time.Duration
+	DraftAge      time.Duration
+	ClosedUntil   time.Time // first day of the open period
+}
+
+// LoadThresholds resolves the tenant's thresholds from scoped settings.
+func LoadThresholds(ctx context.Context) Thresholds {
+	var t Thresholds
+	t.PendingKeyAge = time.Duration(settings.Get(ctx, settings.KeyHousekeepingPendingKeyMinutes, settings.Subject{})) * time.Minute
+	t.DraftAge = time.Duration(settings.Get(ctx, settings.KeyHousekeepingDraftAgeDays, settings.Subject{})) * 24 * time.Hour
+	if v := settings.Get(ctx, settings.KeyPeriodClosedUntil, settings.Subject{}); v != "" {
+		t.ClosedUntil, _ = time.Parse(time.DateOnly, v) // validated on save
+	}
+	return t
+}
+
+// Finding is one anomaly of a kind, e.g. one unposted document.
+type Finding struct {
+	Kind  Kind
+	ID    string // key or document id
+	Label string // human-readable description
+	Link  string // frontend path; empty when there is no page for it
+}
+
+// Analyzer runs the checks of one tenant and notifies its administrators.
+// It remembers what it already reported, so an anomaly is notified once
+// while it persists (and again after a worker restart). Not safe for
+// concurrent use; the worker runs one analyzer per tenant.
+type Analyzer struct {
+	repo          Repository
+	notifications notifications.Repository
+	documentTypes []DocumentType
+	now           func() time.Time
+
+	reported map[string]struct{} // group + "/" + finding ID
+}
+
+// NewAnalyzer creates an analyzer checking documentTypes for unposted documents.
+func NewAnalyzer(repo Repository, notifs notifications.Repository, documentTypes []DocumentType) *Analyzer {
+	return &Analyzer{
+		repo:          repo,
+		notifications: notifs,
+		documentTypes: documentTypes,
+		now:           time.Now,
+		reported:      map[string]struct{}{},
+	}
+}
+
+// group is the findings of one check, reported as one notification.
+type group struct {
+	key      string // kind, or kind/document type
+	title    string
+	message  string
+	link     string
+	total    int
+	findings []Finding
+}
+
+// Run checks the tenant in ctx and notifies the administrators about new
+// anomalies. Returns the number of anomalies found. A failed check does not
+// stop the others; their errors are joined.
+func (a *Analyzer) Run(ctx context.Context) (int, error) {
+	th := LoadThresholds(ctx)
+	now := a.now()
+
+	var (
+		groups []group
+		errs   []error
+	)
+	if th.PendingKeyAge > 0 {
+		g, err := a.pendingKeys(ctx, now.Add(-th.PendingKeyAge), th.PendingKeyAge)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			groups = append(groups, g)
+		}
+	}
+	for _, dt := range a.documentTypes {
+		if th.DraftAge > 0 {
+			// Drafts of a closed period are reported by the closed-period check.
+			f := UnpostedFilter{CreatedBefore: now.Add(-th.DraftAge), DateFrom: th.ClosedUntil}
+			g, err := a.unposted(ctx, KindOldDraft, dt, f)
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				g.title = "Old drafts: " + dt.Label
+				g.message = fmt.Sprintf("%d documents created more than %d days ago are still not posted.",
+					g.total, int(th.DraftAge.Hours()/24))
+				groups = append(groups, g)
+			}
+		}
+		if !th.ClosedUntil.IsZero() {
+			f := UnpostedFilter{DateBefore: th.ClosedUntil}
+			g, err := a.unposted(ctx, KindUnpostedClosedPeriod, dt, f)
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				g.title = "Unposted in closed period: " + dt.Label
+				g.message = fmt.Sprintf("%d documents dated before %s (closed period) are not posted.",
+					g.total, th.ClosedUntil.Format(time.DateOnly))
+				groups = append(groups, g)
+			}
+		}
+		if len(dt.Totals) > 0 {
+			g, err := a.totalsMismatches(ctx, dt)
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				groups = append(groups, g)
+			}
+		}
+	}
+
+	found := 0
+	var fresh []group
+	for _, g := range groups {
+		found += g.total
+		if a.remember(g) {
+			fresh = append(fresh, g)
+		}
+	}
+	if len(fresh) > 0 {
+		if err := a.notify(ctx, fresh); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return found, errors.Join(errs...)
+}
+
+func (a *Analyzer) pendingKeys(ctx context.Context, before time.Time, age time.Duration) (group, error) {
+	keys, total, err := a.repo.PendingKeys(ctx, before, _sampleSize)
+	if err != nil {
+		return group{}, fmt.Errorf("pending idempotency keys: %w", err)
+	}
+	g := group{
+		key:   string(KindStuckIdempotencyKey),
+		title: "Stuck requests",
+		message: fmt.Sprintf("%d idempotency keys have been pending for more than %d minutes. "+
+			"The requests were probably interrupted; retries with the same key are rejected until the key expires.",
+			total, int(age.Minutes())),
+		total: total,
+	}
+	for _, k := range keys {
+		g.findings = append(g.findings, Finding{
+			Kind:  KindStuckIdempotencyKey,
+			ID:    k.Key,
+			Label: fmt.Sprintf("%s by user %s since %s", k.Operation, k.UserID, k.CreatedAt.UTC().Format(time.RFC3339)),
+		})
+	}
+	return g, nil
+}
+
+func (a *Analyzer) unposted(ctx context.Context, kind Kind, dt DocumentType, f UnpostedFilter) (group, error) {
+	docs, total, err := a.repo.UnpostedDocuments(ctx, dt.Table, f, _sampleSize)
+	if err != nil {
+		return group{}, fmt.Errorf("%s %s: %w", kind, dt.Key, err)
+	}
+	g := group{
+		key:   string(kind) + "/" + dt.Key,
+		link:  documentsURL(dt.RoutePrefix),
+		total: total,
+	}
+	g.findings = documentFindings(kind, g.link, docs)
+	if len(docs) == 1 && total == 1 {
+		g.link = g.findings[0].Link
+	}
+	return g, nil
+}
+
+func (a *Analyzer) totalsMismatches(ctx context.Context, dt DocumentType) (group, error) {
+	docs, total, err := a.repo.TotalsMismatches(ctx, dt, _sampleSize)
+	if err != nil {
+		return group{}, fmt.Errorf("%s %s: %w", KindTotalsMismatch, dt.Key, err)
+	}
+	g := group{
+		key:   string(KindTotalsMismatch) + "/" + dt.Key,
+		title: "Totals mismatch: " + dt.Label,
+		message: fmt.Sprintf("%d documents have totals that differ from the sum of their lines. "+
+			"Saving or posting such a document recalculates its totals (or is rejected, see documents.totalsMismatch).",
+			total),
+		link:  documentsURL(dt.RoutePrefix),
+		total: total,
+	}
+	g.findings = documentFindings(KindTotalsMismatch, g.link, docs)
+	if len(docs) == 1 && total == 1 {
+		g.link = g.findings[0].Link
+	}
+	return g, nil
+}
+
+// remember records the findings of g and reports whether any of them is new.
+// Findings of g that are gone are forgotten, so they are notified again if
+// they reappear.
+func (a *Analyzer) remember(g group) bool {
+	prefix := g.key + "/"
+	current := make(map[string]struct{}, len(g.findings))
+	fresh := false
+	for _, f := range g.findings {
+		k := prefix + f.ID
+		current[k] = struct{}{}
+		if _, ok := a.reported[k]; !ok {
+			fresh = true
+		}
+	}
+	for k := range a.reported {
+		if strings.HasPrefix(k, prefix) {
+			if _, ok := current[k]; !ok {
+				delete(a.reported, k)
+			}
+		}
+	}
+	for k := range current {
+		a.reported[k] = struct{}{}
+	}
+	return fresh
+}
+
+// notify sends one notification per group to every administrator.
+func (a *Analyzer) notify(ctx context.Context, groups []group) error {
+	admins, err := a.repo.AdminUserIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("list administrators: %w", err)
+	}
+	if len(admins) == 0 {
+		return nil
+	}
+
+	var batch []*notifications.Notification
+	for _, g := range groups {
+		items := make([]map[string]any, len(g.findings))
+		for i, f := range g.findings {
+			item := map[string]any{"id": f.ID, "label": f.Label}
+			if f.Link != "" {
+				item["link"] = f.Link
+			}
+			items[i] = item
+		}
+		for _, userID := range admins {
+			nid := id.New()
+			n := &notifications.Notification{
+				ID:       &nid,
+				UserID:   userID,
+				Title:    g.title,
+				Message:  g.message,
+				Severity: notifications.SeverityWarning,
+				Attributes: map[string]any{
+					"source": "housekeeping",
+					"kind":   g.findings[0].Kind,
+					"total":  g.total,
+					"items":  items,
+				},
+			}
+			if g.link != "" {
+				link := g.link
+				n.Link = &link
+			}
+			batch = append(batch, n)
+		}
+	}
+	if err := a.notifications.CreateBatch(ctx, batch); err != nil {
+		return fmt.Errorf("create notifications: %w", err)
+	}
+	return nil
+}
+
+// documentFindings lists docs as findings linking to their pages under listURL.
+func documentFindings(kind Kind, listURL string, docs []DocumentRef) []Finding {
+	findings := make([]Finding, 0, len(docs))
+	for _, d := range docs {
+		findings = append(findings, Finding{
+			Kind:  kind,
+			ID:    d.ID.String(),
+			Label: fmt.Sprintf("No. %s of %s", d.Number, d.Date.Format(time.DateOnly)),
+			Link:  listURL + "/" + d.ID.String(),
+		})
+	}
+	return findings
+}
+
+// documentsURL returns the frontend list page of a document type.
+// Mirrors frontend/lib/entity-url.ts → pluralizePrefix().
+func documentsURL(routePrefix string) string {
+	if !strings.HasSuffix(routePrefix, "s") {
+		routePrefix += "s"
+	}
+	return "/documents/" + routePrefix
+}
@@ -0,0 +1,184 @@
+package housekeeping
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain/notifications"
+	"metapus/internal/domain/settings"
+)
+
+// fakeRepo serves fixed anomalies and records the filters it was asked for.
+type fakeRepo struct {
+	keys    []PendingKey
+	docs    map[string][]DocumentRef // filter label → docs
+	admins  []id.ID
+	filters []UnpostedFilter
+	totals  []DocumentRef
+}
+
+func (r *fakeRepo) PendingKeys(context.Context, time.Time, int) ([]PendingKey, int, error) {
+	return r.keys, len(r.keys), nil
+}
+
+func (r *fakeRepo) UnpostedDocuments(_ context.Context, _ string, f UnpostedFilter, _ int) ([]DocumentRef, int, error) {
+	r.filters = append(r.filters, f)
+	label := "draft"
+	if !f.DateBefore.IsZero() {
+		label = "closed"
+	}
+	return r.docs[label], len(r.docs[label]), nil
+}
+
+func (r *fakeRepo) TotalsMismatches(context.Context, DocumentType, int) ([]DocumentRef, int, error) {
+	return r.totals, len(r.totals), nil
+}
+
+func (r *fakeRepo) AdminUserIDs(context.Context) ([]id.ID, error) { return r.admins, nil }
+
+type fakeNotifications struct {
+	notifications.Repository
+	created []*notifications.Notification
+}
+
+func (n *fakeNotifications) CreateBatch(_ context.Context, batch []*notifications.Notification) error {
+	n.created = append(n.created, batch...)
+	return nil
+}
+
+// valueStore holds tenant values of scoped settings.
+type valueStore map[string]any
+
+func (s valueStore) ListValues(context.Context) ([]settings.Value, error) {
+	var out []settings.Value
+	for k, v := range s {
+		raw, _ := json.Marshal(v)
+		out = append(out, settings.Value{Key: k, Scope: settings.ScopeTenant, Value: raw})
+	}
+	return out, nil
+}
+
+func (s valueStore) SetValue(_ context.Context, v settings.Value) (settings.Value, error) {
+	return v, nil
+}
+
+func (s valueStore) DeleteValue(context.Context, string, settings.Scope, id.ID) error { return nil }
+
+func withSettings(values valueStore) context.Context {
+	return settings.WithResolver(context.Background(), settings.NewResolver(values))
+}
+
+var receipts = DocumentType{Key: "goods_receipt", Label: "Goods Receipts", Table: "doc_goods_receipts", RoutePrefix: "goods-receipt"}
+
+func TestAnalyzerNotifiesNewAnomaliesOnce(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	admin := id.New()
+	draft := DocumentRef{ID: id.New(), Number: "GR-7", Date: now.AddDate(0, -2, 0)}
+	repo := &fakeRepo{
+		keys:   []PendingKey{{Key: "k1", UserID: "u1", Operation: "POST /document/goods-receipt", CreatedAt: now.Add(-time.Hour)}},
+		docs:   map[string][]DocumentRef{"draft": {draft}},
+		admins: []id.ID{admin},
+	}
+	notifs := &fakeNotifications{}
+	a := NewAnalyzer(repo, notifs, []DocumentType{receipts})
+	a.now = func() time.Time { return now }
+	ctx := withSettings(valueStore{})
+
+	found, err := a.Run(ctx)
+	if err != nil || found != 2 {
+		t.Fatalf("Run = %d, %v; want 2, nil", found, err)
+	}
+	if len(notifs.created) != 2 {
+		t.Fatalf("notifications = %d, want 2", len(notifs.created))
+	}
+	keys, drafts := notifs.created[0], notifs.created[1]
+	if keys.UserID != admin || keys.Link != nil || !strings.Contains(keys.Message, "30 minutes") {
+		t.Errorf("stuck key notification = %+v", keys)
+	}
+	if drafts.Link == nil || *drafts.Link != "/documents/goods-receipts/"+draft.ID.String() {
+		t.Errorf("draft link = %v", drafts.Link)
+	}
+	if drafts.Attributes["kind"] != KindOldDraft || drafts.Severity != notifications.SeverityWarning {
+		t.Errorf("draft notification = %+v", drafts)
+	}
+	if f := repo.filters[0]; !f.CreatedBefore.Equal(now.AddDate(0, 0, -30)) || !f.DateFrom.IsZero() {
+		t.Errorf("draft filter = %+v", f)
+	}
+
+	// Same anomalies again: nothing new to report.
+	if _, err := a.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifs.created) != 2 {
+		t.Fatalf("repeated run notified again: %d", len(notifs.created))
+	}
+
+	// A new draft re-alerts its group only, linking to the list.
+	repo.keys = nil
+	repo.docs["draft"] = append(repo.docs["draft"], DocumentRef{ID: id.New(), Number: "GR-8", Date: now})
+	if _, err := a.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifs.created) != 3 || *notifs.created[2].Link != "/documents/goods-receipts" {
+		t.Fatalf("notifications after new draft = %d", len(notifs.created))
+	}
+}
+
+func TestAnalyzerThresholds(t *testing.T) {
+	repo := &fakeRepo{docs: map[string][]DocumentRef{"closed": {{ID: id.New(), Number: "GR-1"}}}, admins: []id.ID{id.New()}}
+	notifs := &fakeNotifications{}
+	a := NewAnalyzer(repo, notifs, []DocumentType{receipts})
+	ctx := withSettings(valueStore{
+		settings.KeyHousekeepingPendingKeyMinutes.Name(): 0,
+		settings.KeyHousekeepingDraftAgeDays.Name():      0,
+		settings.KeyPeriodClosedUntil.Name():             "2026-07-01",
+	})
+
+	if _, err := a.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	closedUntil := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	if len(repo.filters) != 1 || !repo.filters[0].DateBefore.Equal(closedUntil) {
+		t.Fatalf("filters = %+v; want only the closed-period check", repo.filters)
+	}
+	if len(notifs.created) != 1 || !strings.Contains(notifs.created[0].Message, "before 2026-07-01") {
+		t.Fatalf("notifications = %+v", notifs.created)
+	}
+}
+
+func TestAnalyzerTotalsMismatch(t *testing.T) {
+	doc := DocumentRef{ID: id.New(), Number: "GR-3", Date: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)}
+	repo := &fakeRepo{totals: []DocumentRef{doc}, admins: []id.ID{id.New()}}
+	notifs := &fakeNotifications{}
+	ctx := withSettings(valueStore{
+		settings.KeyHousekeepingPendingKeyMinutes.Name(): 0,
+		settings.KeyHousekeepingDraftAgeDays.Name():      0,
+	})
+
+	// Without totals columns the check is skipped.
+	if found, err := NewAnalyzer(repo, notifs, []DocumentType{receipts}).Run(ctx); err != nil || found != 0 {
+		t.Fatalf("Run = %d, %v; want 0, nil", found, err)
+	}
+
+	withTotals := receipts
+	withTotals.LinesTable = "doc_goods_receipt_lines"
+	withTotals.Totals = map[string]string{"total_amount": "amount"}
+	found, err := NewAnalyzer(repo, notifs, []DocumentType{withTotals}).Run(ctx)
+	if err != nil || found != 1 {
+		t.Fatalf("Run = %d, %v; want 1, nil", found, err)
+	}
+	if len(notifs.created) != 1 {
+		t.Fatalf("notifications = %d, want 1", len(notifs.created))
+	}
+	n := notifs.created[0]
+	if n.Attributes["kind"] != KindTotalsMismatch || n.Title != "Totals mismatch: Goods Receipts" {
+		t.Errorf("notification = %+v", n)
+	}
+	if n.Link == nil || *n.Link != "/documents/goods-receipts/"+doc.ID.String() {
+		t.Errorf("link = %v", n.Link)
+	}
+}
@@ -0,0 +1,81 @@
+// Package housekeeping detects data anomalies of a tenant — requests stuck
+// behind a pending idempotency key, forgotten drafts, unposted documents in a
+// closed period, document totals out of line with the lines — and alerts the tenant administrators with in-app
+// notifications. The worker runs the analyzer hourly.
+package housekeeping
+
+import (
+	"context"
+	"time"
+
+	"metapus/internal/core/id"
+)
+
+// Kind identifies a type of anomaly.
+type Kind string
+
+const (
+	KindStuckIdempotencyKey  Kind = "stuck_idempotency_key"
+	KindOldDraft             Kind = "old_draft"
+	KindUnpostedClosedPeriod Kind = "unposted_closed_period"
+	KindTotalsMismatch       Kind = "totals_mismatch"
+)
+
+// TotalColumns maps the header total columns of documents with lines to the
+// line columns they sum up.
+var TotalColumns = map[string]string{
+	"total_quantity": "quantity",
+	"total_amount":   "amount",
+	"total_vat":      "vat_amount",
+}
+
+// DocumentType is a document type checked for unposted documents.
+type DocumentType struct {
+	Key         string // entity key, e.g. goods_receipt
+	Label       string // plural display name, e.g. "Goods Receipts"
+	Table       string // document table, e.g. doc_goods_receipts
+	RoutePrefix string // frontend route segment, e.g. goods-receipt
+
+	// LinesTable and Totals (header column → line column, a subset of
+	// TotalColumns) enable the totals check; empty for documents without
+	// totals kept from lines.
+	LinesTable string
+	Totals     map[string]string
+}
+
+// PendingKey is an idempotency key whose operation never completed.
+type PendingKey struct {
+	Key       string
+	UserID    string
+	Operation string
+	CreatedAt time.Time
+}
+
+// DocumentRef identifies an unposted document.
+type DocumentRef struct {
+	ID     id.ID
+	Number string
+	Date   time.Time
+}
+
+// UnpostedFilter selects live unposted documents; zero times are not applied.
+type UnpostedFilter struct {
+	CreatedBefore time.Time // created_at < CreatedBefore
+	DateBefore    time.Time // date < DateBefore
+	DateFrom      time.Time // date >= DateFrom
+}
+
+// Repository reads the data the analyzer checks. Lists are ordered oldest
+// first and capped at limit; total is the number of matches without the cap.
+// Implemented by postgres.HousekeepingRepo.
+type Repository interface {
+	PendingKeys(ctx context.Context, createdBefore time.Time, limit int) (keys []PendingKey, total int, err error)
+	UnpostedDocuments(ctx context.Context, table string, f UnpostedFilter, limit int) (docs []DocumentRef, total int, err error)
+
+	// TotalsMismatches returns live documents of dt whose header totals
+	// differ from the sums of their lines.
+	TotalsMismatches(ctx context.Context, dt DocumentType, limit int) (docs []DocumentRef, total int, err error)
+
+	// AdminUserIDs returns the active administrators of the tenant.
+	AdminUserIDs(ctx context.Context) ([]id.ID, error)
+}
@@ -0,0 +1,65 @@
+package intercompany
+
+import (
+	"fmt"
+	"strconv"
+
+	"metapus/internal/domain/documents/goods_issue"
+	"metapus/internal/domain/documents/goods_receipt"
+)
+
+// Compare returns the differences between the two sides of a transfer:
+// both must carry the same reference, belong to different organizations,
+// share date, currency, state and lines, and the receipt must be based on
+// the issue. Each side may still be edited on its own, so this is what the
+// consistency check reports.
+func Compare(issue *goods_issue.GoodsIssue, receipt *goods_receipt.GoodsReceipt) []Mismatch {
+	var out []Mismatch
+	add := func(field string, line int, a, b string) {
+		if a != b {
+			out = append(out, Mismatch{Field: field, Line: line, Issue: a, Receipt: b})
+		}
+	}
+
+	add("intercompanyRef", 0, deref(issue.IntercompanyRef), deref(receipt.IntercompanyRef))
+	if issue.OrganizationID == receipt.OrganizationID {
+		out = append(out, Mismatch{Field: "organizationId", Issue: issue.OrganizationID.String(), Receipt: receipt.OrganizationID.String()})
+	}
+	basis := ""
+	if receipt.BasisType == issue.GetDocumentType() && receipt.BasisID != nil {
+		basis = receipt.BasisID.String()
+	}
+	add("basisId", 0, issue.ID.String(), basis)
+	add("date", 0, issue.Date.Format("2006-01-02"), receipt.Date.Format("2006-01-02"))
+	add("currencyId", 0, issue.CurrencyID.String(), receipt.CurrencyID.String())
+	add("amountIncludesVat", 0, strconv.FormatBool(issue.AmountIncludesVAT), strconv.FormatBool(receipt.AmountIncludesVAT))
+	add("posted", 0, strconv.FormatBool(issue.Posted), strconv.FormatBool(receipt.Posted))
+	add("deletionMark", 0, strconv.FormatBool(issue.DeletionMark), strconv.FormatBool(receipt.DeletionMark))
+	add("totalAmount", 0, fmt.Sprint(issue.TotalAmount), fmt.Sprint(receipt.TotalAmount))
+
+	if len(issue.Lines) != len(receipt.Lines) {
+		add("lines", 0, strconv.Itoa(len(issue.Lines)), strconv.Itoa(len(receipt.Lines)))
+		return out
+	}
+	for i, il := range issue.Lines {
+		rl := receipt.Lines[i]
+		n := i + 1
+		add("nomenclatureId", n, il.NomenclatureID.String(), rl.NomenclatureID.String())
+		add("unitId", n, il.UnitID.String(), rl.UnitID.String())
+		if !il.Coefficient.Equal(rl.Coefficient) {
+			out = append(out, Mismatch{Field: "coefficient", Line: n, Issue: il.Coefficient.String(), Receipt: rl.Coefficient.String()})
+		}
+		add("quantity", n, fmt.Sprint(il.Quantity), fmt.Sprint(rl.Quantity))
+		add("unitPrice", n, fmt.Sprint(il.UnitPrice), fmt.Sprint(rl.UnitPrice))
+		add("vatRateId", n, il.VATRateID.String(), rl.VATRateID.String())
+		add("amount", n, fmt.Sprint(il.Amount), fmt.Sprint(rl.Amount))
+	}
+	return out
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
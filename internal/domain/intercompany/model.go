@@ -0,0 +1,94 @@
+// Package intercompany implements goods transfers between organizations of
+// the same tenant (legal entities sharing one database).
+//
+// A transfer is a mirrored pair of documents created in one transaction: a
+// GoodsIssue from the sending organization and a GoodsReceipt into the
+// receiving one, based on the issue. Both documents carry the same transfer
+// reference (IntercompanyRef, numbered with the "IC" prefix), which is how
+// the pair is found later and checked for consistency.
+package intercompany
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/domain"
+	"metapus/internal/domain/documents/goods_issue"
+	"metapus/internal/domain/documents/goods_receipt"
+)
+
+// Party is one side of a transfer.
+type Party struct {
+	OrganizationID id.ID `json:"organizationId"`
+	WarehouseID    id.ID `json:"warehouseId"`
+	// CounterpartyID is the other organization as a counterparty in this
+	// organization's books: the buyer for the sender, the supplier for the
+	// receiver.
+	CounterpartyID id.ID `json:"counterpartyId"`
+}
+
+// Line is a transferred item; it becomes a line of both documents.
+type Line struct {
+	NomenclatureID id.ID            `json:"nomenclatureId"`
+	UnitID         id.ID            `json:"unitId"`
+	Coefficient    decimal.Decimal  `json:"coefficient"`
+	Quantity       types.Quantity   `json:"quantity"`
+	UnitPrice      types.MinorUnits `json:"unitPrice"`
+	VATRateID      id.ID            `json:"vatRateId"`
+	VATPercent     int              `json:"vatPercent"`
+}
+
+// Request describes a transfer to create.
+type Request struct {
+	Date              time.Time `json:"date"`
+	From              Party     `json:"from"`
+	To                Party     `json:"to"`
+	CurrencyID        id.ID     `json:"currencyId,omitempty"` // nil — resolved per document
+	AmountIncludesVAT bool      `json:"amountIncludesVat"`
+	Description       string    `json:"description,omitempty"`
+	Lines             []Line    `json:"lines"`
+	// Post posts both documents; otherwise they are saved as drafts.
+	Post bool `json:"post"`
+}
+
+// Transfer is a created transfer.
+type Transfer struct {
+	Reference string                      `json:"reference"`
+	Issue     *goods_issue.GoodsIssue     `json:"issue"`
+	Receipt   *goods_receipt.GoodsReceipt `json:"receipt"`
+}
+
+// Mismatch is a difference between the two sides of a transfer.
+type Mismatch struct {
+	Field   string `json:"field"`
+	Line    int    `json:"line,omitempty"` // 1-based; 0 for header fields
+	Issue   string `json:"issue"`
+	Receipt string `json:"receipt"`
+}
+
+// Check is the result of a consistency check of a transfer.
+type Check struct {
+	Reference  string     `json:"reference"`
+	IssueID    *id.ID     `json:"issueId,omitempty"`
+	ReceiptID  *id.ID     `json:"receiptId,omitempty"`
+	Consistent bool       `json:"consistent"`
+	Mismatches []Mismatch `json:"mismatches"`
+}
+
+// Repository finds the documents of a transfer.
+type Repository interface {
+	// FindPair returns the IDs of the goods issue and the goods receipt
+	// carrying the reference; either is nil when missing.
+	FindPair(ctx context.Context, reference string) (issueID, receiptID *id.ID, err error)
+}
+
+// IssueService and ReceiptService are the document services the transfer
+// documents are created through (numbering, validation, posting, hooks).
+type (
+	IssueService   = domain.DocumentService[*goods_issue.GoodsIssue]
+	ReceiptService = domain.DocumentService[*goods_receipt.GoodsReceipt]
+)
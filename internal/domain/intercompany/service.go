@@ -0,0 +1,194 @@
+package intercompany
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/clock"
+	"metapus/internal/core/id"
+	"metapus/internal/core/numerator"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain"
+	"metapus/internal/domain/documents/goods_issue"
+	"metapus/internal/domain/documents/goods_receipt"
+)
+
+// NumeratorPrefix is the prefix of transfer references (IC-2026-00001).
+// References are numbered per sending organization.
+const NumeratorPrefix = "IC"
+
+// Service creates intercompany transfers and checks their consistency.
+type Service struct {
+	issues   IssueService
+	receipts ReceiptService
+	repo     Repository
+	num      numerator.Generator
+}
+
+// NewService creates a new intercompany transfer service.
+func NewService(issues IssueService, receipts ReceiptService, repo Repository, num numerator.Generator) *Service {
+	return &Service{issues: issues, receipts: receipts, repo: repo, num: num}
+}
+
+// Validate checks the request before any document is built.
+func (r *Request) Validate() error {
+	if r.Date.IsZero() {
+		return apperror.NewValidation("date is required").WithDetail("field", "date")
+	}
+	for _, p := range []struct {
+		field string
+		party Party
+	}{{"from", r.From}, {"to", r.To}} {
+		switch {
+		case id.IsNil(p.party.OrganizationID):
+			return apperror.NewValidation("organization is required").WithDetail("field", p.field+".organizationId")
+		case id.IsNil(p.party.WarehouseID):
+			return apperror.NewValidation("warehouse is required").WithDetail("field", p.field+".warehouseId")
+		case id.IsNil(p.party.CounterpartyID):
+			return apperror.NewValidation("counterparty is required").WithDetail("field", p.field+".counterpartyId")
+		}
+	}
+	if r.From.OrganizationID == r.To.OrganizationID {
+		return apperror.NewValidation("intercompany transfer requires two different organizations").
+			WithDetail("field", "to.organizationId")
+	}
+	if len(r.Lines) == 0 {
+		return apperror.NewValidation("at least one line is required").WithDetail("field", "lines")
+	}
+	for i, l := range r.Lines {
+		if l.Quantity <= 0 {
+			return apperror.NewValidation("quantity must be positive").
+				WithDetail("field", "lines").
+				WithDetail("line", i+1)
+		}
+	}
+	return nil
+}
+
+// Create creates (and, if requested, posts) both documents of a transfer in
+// one transaction. The receipt takes the issue's number, date and currency,
+// so the pair is consistent by construction; it is still compared before
+// commit to catch hooks that changed one side.
+func (s *Service) Create(ctx context.Context, req Request) (*Transfer, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	txm, err := tenant.GetTxManager(ctx)
+	if err != nil {
+		return nil, apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+
+	var transfer *Transfer
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		cfg := domain.NumeratorConfig(ctx, NumeratorPrefix, req.From.OrganizationID)
+		ref, err := s.num.GetNextNumber(ctx, cfg, &numerator.Options{Strategy: numerator.StrategyStrict}, clock.Now(ctx))
+		if err != nil {
+			return fmt.Errorf("generate transfer reference: %w", err)
+		}
+
+		issue := newIssue(req, ref)
+		if err := save(ctx, s.issues, issue, req.Post); err != nil {
+			return err
+		}
+		receipt := newReceipt(req, ref, issue)
+		if err := save(ctx, s.receipts, receipt, req.Post); err != nil {
+			return err
+		}
+
+		if mismatches := Compare(issue, receipt); len(mismatches) > 0 {
+			return apperror.NewBusinessRule("INTERCOMPANY_MISMATCH",
+				"goods issue and goods receipt of the transfer differ").
+				WithDetail("field", mismatches[0].Field)
+		}
+		transfer = &Transfer{Reference: ref, Issue: issue, Receipt: receipt}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// Check loads both documents of a transfer and reports their differences.
+func (s *Service) Check(ctx context.Context, reference string) (*Check, error) {
+	issueID, receiptID, err := s.repo.FindPair(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+	if issueID == nil && receiptID == nil {
+		return nil, apperror.NewNotFound("intercompany_transfer", reference)
+	}
+
+	check := &Check{Reference: reference, IssueID: issueID, ReceiptID: receiptID, Mismatches: []Mismatch{}}
+	switch {
+	case issueID == nil:
+		check.Mismatches = append(check.Mismatches, Mismatch{Field: "document", Issue: "missing", Receipt: receiptID.String()})
+	case receiptID == nil:
+		check.Mismatches = append(check.Mismatches, Mismatch{Field: "document", Issue: issueID.String(), Receipt: "missing"})
+	default:
+		issue, err := s.issues.GetByID(ctx, *issueID)
+		if err != nil {
+			return nil, err
+		}
+		receipt, err := s.receipts.GetByID(ctx, *receiptID)
+		if err != nil {
+			return nil, err
+		}
+		check.Mismatches = append(check.Mismatches, Compare(issue, receipt)...)
+	}
+	check.Consistent = len(check.Mismatches) == 0
+	return check, nil
+}
+
+// save creates the document, posting it when requested.
+func save[T any](ctx context.Context, svc domain.DocumentService[T], doc T, post bool) error {
+	if post {
+		return svc.PostAndSave(ctx, doc)
+	}
+	return svc.Create(ctx, doc)
+}
+
+// newIssue builds the sending side of the transfer.
+func newIssue(req Request, ref string) *goods_issue.GoodsIssue {
+	doc := goods_issue.NewGoodsIssue(req.From.OrganizationID, req.From.CounterpartyID, req.From.WarehouseID)
+	doc.Date = req.Date
+	doc.CurrencyID = req.CurrencyID
+	doc.AmountIncludesVAT = req.AmountIncludesVAT
+	doc.Description = req.Description
+	doc.IntercompanyRef = &ref
+	for _, l := range req.Lines {
+		doc.AddLine(l.NomenclatureID, l.UnitID, coefficient(l), l.Quantity, l.UnitPrice, l.VATRateID, l.VATPercent, decimal.Zero)
+	}
+	return doc
+}
+
+// newReceipt builds the receiving side from the saved issue: it is based on
+// the issue and refers to it as the supplier's document.
+func newReceipt(req Request, ref string, issue *goods_issue.GoodsIssue) *goods_receipt.GoodsReceipt {
+	doc := goods_receipt.NewGoodsReceipt(req.To.OrganizationID, req.To.CounterpartyID, req.To.WarehouseID)
+	doc.Date = issue.Date
+	doc.CurrencyID = issue.CurrencyID
+	doc.AmountIncludesVAT = issue.AmountIncludesVAT
+	doc.Description = req.Description
+	issueDate := issue.Date
+	doc.SupplierDocNumber = issue.Number
+	doc.SupplierDocDate = &issueDate
+	doc.BasisType = issue.GetDocumentType()
+	doc.BasisID = &issue.ID
+	doc.IntercompanyRef = &ref
+	for _, l := range req.Lines {
+		doc.AddLine(l.NomenclatureID, l.UnitID, coefficient(l), l.Quantity, l.UnitPrice, l.VATRateID, l.VATPercent, decimal.Zero)
+	}
+	return doc
+}
+
+func coefficient(l Line) decimal.Decimal {
+	if l.Coefficient.IsZero() {
+		return decimal.NewFromInt(1)
+	}
+	return l.Coefficient
+}
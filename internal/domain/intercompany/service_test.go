@@ -0,0 +1,159 @@
+package intercompany
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/numerator"
+	"metapus/internal/core/tenant"
+	"metapus/internal/core/types"
+	"metapus/internal/domain"
+	"metapus/internal/domain/documents/goods_issue"
+	"metapus/internal/domain/documents/goods_receipt"
+)
+
+type passTxManager struct{}
+
+func (passTxManager) RunInTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+type fixedNumerator struct{ numerator.Generator }
+
+func (fixedNumerator) GetNextNumber(context.Context, numerator.Config, *numerator.Options, time.Time) (string, error) {
+	return "IC-00001", nil
+}
+
+// memDocs stands in for a document service: Create assigns a number and
+// a currency the way BaseDocumentService would.
+type memDocs[T any] struct {
+	domain.DocumentService[T]
+	saved  []T
+	posted bool
+	onSave func(T)
+}
+
+func (m *memDocs[T]) Create(_ context.Context, doc T) error {
+	m.onSave(doc)
+	m.saved = append(m.saved, doc)
+	return nil
+}
+
+func (m *memDocs[T]) PostAndSave(ctx context.Context, doc T) error {
+	m.posted = true
+	return m.Create(ctx, doc)
+}
+
+func testRequest() Request {
+	return Request{
+		Date: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+		From: Party{OrganizationID: id.New(), WarehouseID: id.New(), CounterpartyID: id.New()},
+		To:   Party{OrganizationID: id.New(), WarehouseID: id.New(), CounterpartyID: id.New()},
+		Lines: []Line{
+			{NomenclatureID: id.New(), UnitID: id.New(), Quantity: types.NewQuantityFromInt64Scaled(20000), UnitPrice: 1500, VATRateID: id.New(), VATPercent: 20},
+		},
+	}
+}
+
+func TestRequestValidate(t *testing.T) {
+	same := testRequest()
+	same.To.OrganizationID = same.From.OrganizationID
+	noLines := testRequest()
+	noLines.Lines = nil
+	noWarehouse := testRequest()
+	noWarehouse.To.WarehouseID = id.ID{}
+
+	for name, req := range map[string]Request{"same organization": same, "no lines": noLines, "no warehouse": noWarehouse} {
+		if err := req.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+	req := testRequest()
+	if err := req.Validate(); err != nil {
+		t.Errorf("valid request: %v", err)
+	}
+}
+
+func TestServiceCreate(t *testing.T) {
+	currency := id.New()
+	issues := &memDocs[*goods_issue.GoodsIssue]{onSave: func(d *goods_issue.GoodsIssue) {
+		d.Number = "GI-00007"
+		d.CurrencyID = currency
+	}}
+	receipts := &memDocs[*goods_receipt.GoodsReceipt]{onSave: func(*goods_receipt.GoodsReceipt) {}}
+	svc := NewService(issues, receipts, nil, fixedNumerator{})
+	ctx := tenant.WithTxManager(context.Background(), passTxManager{})
+
+	req := testRequest()
+	req.Post = true
+	tr, err := svc.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !issues.posted || !receipts.posted {
+		t.Error("both documents must be posted")
+	}
+
+	r := tr.Receipt
+	if tr.Reference != "IC-00001" || *r.IntercompanyRef != tr.Reference || *tr.Issue.IntercompanyRef != tr.Reference {
+		t.Errorf("reference not shared: %q", tr.Reference)
+	}
+	if r.BasisID == nil || *r.BasisID != tr.Issue.ID || r.SupplierDocNumber != "GI-00007" {
+		t.Errorf("receipt not linked to the issue: basis=%v supplierDoc=%q", r.BasisID, r.SupplierDocNumber)
+	}
+	if r.CurrencyID != currency || r.OrganizationID != req.To.OrganizationID {
+		t.Errorf("receipt currency/organization = %v/%v", r.CurrencyID, r.OrganizationID)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	issues := &memDocs[*goods_issue.GoodsIssue]{onSave: func(*goods_issue.GoodsIssue) {}}
+	receipts := &memDocs[*goods_receipt.GoodsReceipt]{onSave: func(*goods_receipt.GoodsReceipt) {}}
+	svc := NewService(issues, receipts, nil, fixedNumerator{})
+	tr, err := svc.Create(tenant.WithTxManager(context.Background(), passTxManager{}), testRequest())
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// The receipt was edited on its own afterwards.
+	tr.Receipt.Lines[0].Quantity = types.NewQuantityFromInt64Scaled(10000)
+	tr.Receipt.Posted = true
+
+	got := map[string]int{}
+	for _, m := range Compare(tr.Issue, tr.Receipt) {
+		got[m.Field] = m.Line
+	}
+	if line, ok := got["quantity"]; !ok || line != 1 {
+		t.Errorf("quantity mismatch not reported on line 1: %v", got)
+	}
+	if _, ok := got["posted"]; !ok || len(got) != 2 {
+		t.Errorf("mismatches = %v, want quantity and posted", got)
+	}
+}
+
+type memRepo struct{ issueID, receiptID *id.ID }
+
+func (r memRepo) FindPair(context.Context, string) (*id.ID, *id.ID, error) {
+	return r.issueID, r.receiptID, nil
+}
+
+func TestServiceCheckMissingSide(t *testing.T) {
+	issueID := id.New()
+	svc := NewService(nil, nil, memRepo{issueID: &issueID}, nil)
+
+	check, err := svc.Check(context.Background(), "IC-00001")
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if check.Consistent || len(check.Mismatches) != 1 || check.Mismatches[0].Receipt != "missing" {
+		t.Errorf("check = %+v", check)
+	}
+
+	_, err = NewService(nil, nil, memRepo{}, nil).Check(context.Background(), "IC-404")
+	if !apperror.IsNotFound(err) {
+		t.Errorf("err = %v, want not found", err)
+	}
+}
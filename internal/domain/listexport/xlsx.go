@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/xuri/excelize/v2"
+
+	"metapus/internal/core/format"
 )
 
 // Column describes a single export column.
@@ -23,7 +25,13 @@ type Column struct {
 // XLSX writes a flat list as an Excel .xlsx file to w.
 // title is rendered as a bold header row (e.g. entity plural name).
 // columns defines the order and headers. rows contains DTO data as maps.
-func XLSX(w io.Writer, title string, columns []Column, rows []map[string]any) (retErr error) {
+// fm renders dates and flags for the tenant locale (nil = format.DefaultLocale);
+// numbers stay numeric cells so Excel applies the viewer's separators.
+func XLSX(w io.Writer, title string, columns []Column, rows []map[string]any, fm *format.Formatter) (retErr error) {
+	if fm == nil {
+		fm = format.New(format.DefaultLocale)
+	}
+
 	f := excelize.NewFile()
 	defer func() {
 		if cErr := f.Close(); cErr != nil && retErr == nil {
@@ -103,7 +111,7 @@ func XLSX(w io.Writer, title string, columns []Column, rows []map[string]any) (r
 		dataRow := make([]any, len(columns))
 		for i, col := range columns {
 			val := row[col.Key]
-			cell := formatCell(val, cellStyleID, cellRightStyleID, cellIntStyleID)
+			cell := formatCell(fm, val, cellStyleID, cellRightStyleID, cellIntStyleID)
 			dataRow[i] = cell
 		}
 		if err := sw.SetRow(fmt.Sprintf("A%d", rowNum), dataRow); err != nil {
@@ -120,7 +128,7 @@ func XLSX(w io.Writer, title string, columns []Column, rows []map[string]any) (r
 }
 
 // formatCell converts a value to an excelize.Cell with appropriate style.
-func formatCell(val any, textStyle, numStyle, intStyle int) excelize.Cell {
+func formatCell(fm *format.Formatter, val any, textStyle, numStyle, intStyle int) excelize.Cell {
 	if val == nil {
 		return excelize.Cell{Value: "", StyleID: textStyle}
 	}
@@ -140,22 +148,19 @@ func formatCell(val any, textStyle, numStyle, intStyle int) excelize.Cell {
 	case int32:
 		return excelize.Cell{Value: int64(v), StyleID: numStyle}
 	case bool:
-		if v {
-			return excelize.Cell{Value: "Да", StyleID: textStyle}
-		}
-		return excelize.Cell{Value: "Нет", StyleID: textStyle}
+		return excelize.Cell{Value: fm.Bool(v), StyleID: textStyle}
 	case string:
-		// Try to parse ISO date strings and format as DD.MM.YYYY HH:MM
+		// Try to parse ISO date strings and format as locale date (+ time)
 		if len(v) >= 10 && (strings.Contains(v, "T") || strings.Count(v, "-") >= 2) {
 			if t, err := time.Parse(time.RFC3339, v); err == nil {
-				return excelize.Cell{Value: t.Format("02.01.2006 15:04"), StyleID: textStyle}
+				return excelize.Cell{Value: fm.DateTime(t), StyleID: textStyle}
 			}
 			if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
-				return excelize.Cell{Value: t.Format("02.01.2006 15:04"), StyleID: textStyle}
+				return excelize.Cell{Value: fm.DateTime(t), StyleID: textStyle}
 			}
 			// Date-only format
 			if t, err := time.Parse("2006-01-02", v); err == nil {
-				return excelize.Cell{Value: t.Format("02.01.2006"), StyleID: textStyle}
+				return excelize.Cell{Value: fm.Date(t), StyleID: textStyle}
 			}
 		}
 		return excelize.Cell{Value: v, StyleID: textStyle}
@@ -0,0 +1,78 @@
+// Package modules lets tenants switch whole functional modules (crypto
+// payments, orders) on and off with feature flags.
+//
+// A module is controlled by the sys_feature_flags row named "module.<key>".
+// Without that row the module is enabled, so existing tenants keep every
+// module until a flag is created for them. A disabled module's routes answer
+// 404 (or 402 when the flag's variant is "payment_required"), its entities are
+// hidden from the navigation metadata and background jobs skip it.
+package modules
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Built-in modules.
+const (
+	Crypto = "crypto"
+	Orders = "orders"
+)
+
+// FlagPrefix prefixes the feature flag name of a module.
+const FlagPrefix = "module."
+
+// VariantPaymentRequired marks a flag of a module that is disabled because
+// the tenant's plan does not include it.
+const VariantPaymentRequired = "payment_required"
+
+// FeatureFlagsChangedChannel is the NOTIFY channel fired by the
+// sys_feature_flags trigger in the tenant database. Payload is the flag name.
+const FeatureFlagsChangedChannel = "feature_flags_changed"
+
+// FlagName returns the feature flag name controlling module.
+func FlagName(module string) string {
+	return FlagPrefix + module
+}
+
+// IsModuleFlag reports whether flagName controls a module.
+func IsModuleFlag(flagName string) bool {
+	return strings.HasPrefix(flagName, FlagPrefix)
+}
+
+// Flag is a module feature flag as stored in sys_feature_flags.
+type Flag struct {
+	Name       string
+	Enabled    bool
+	Variant    string
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+}
+
+// active reports whether the flag enables its module at now.
+func (f Flag) active(now time.Time) bool {
+	if !f.Enabled {
+		return false
+	}
+	if f.ValidFrom != nil && now.Before(*f.ValidFrom) {
+		return false
+	}
+	if f.ValidUntil != nil && !now.Before(*f.ValidUntil) {
+		return false
+	}
+	return true
+}
+
+// Status is the state of a module for a tenant.
+type Status struct {
+	Enabled bool
+	// PaymentRequired is set for a disabled module the tenant can buy.
+	PaymentRequired bool
+}
+
+// FlagStore reads module feature flags of the tenant in ctx.
+type FlagStore interface {
+	// ListModuleFlags returns all flags named with FlagPrefix.
+	ListModuleFlags(ctx context.Context) ([]Flag, error)
+}
@@ -0,0 +1,126 @@
+package modules
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"metapus/internal/core/clock"
+	"metapus/internal/core/tenant"
+	"metapus/pkg/logger"
+)
+
+// DefaultCacheTTL bounds staleness when a change notification is missed.
+const DefaultCacheTTL = 5 * time.Minute
+
+// Resolver resolves module status with a per-tenant in-memory cache of the
+// module flags, kept until Invalidate is called (by the NOTIFY listener) or
+// the snapshot is older than the TTL. Validity windows of the flags are
+// evaluated on every call against the clock in ctx.
+type Resolver struct {
+	store FlagStore
+	ttl   time.Duration
+
+	mu    sync.RWMutex
+	cache map[string]*flagSnapshot // tenantID -> snapshot
+	// generation is bumped on every invalidation so that a load racing
+	// with a notification does not store a stale snapshot.
+	generation uint64
+
+	// onLoad is called after a tenant snapshot is loaded (e.g. to start
+	// listening for invalidations of that tenant).
+	onLoad func(tenantID string)
+}
+
+type flagSnapshot struct {
+	byModule map[string]Flag
+	loadedAt time.Time
+}
+
+// NewResolver creates a resolver over store.
+func NewResolver(store FlagStore) *Resolver {
+	return &Resolver{
+		store: store,
+		ttl:   DefaultCacheTTL,
+		cache: make(map[string]*flagSnapshot),
+	}
+}
+
+// SetLoadHook registers fn to be called after a tenant snapshot is loaded.
+func (r *Resolver) SetLoadHook(fn func(tenantID string)) {
+	r.onLoad = fn
+}
+
+// Invalidate drops the cached flags of a tenant.
+func (r *Resolver) Invalidate(tenantID string) {
+	r.mu.Lock()
+	delete(r.cache, tenantID)
+	r.generation++
+	r.mu.Unlock()
+}
+
+// snapshot returns the cached flags of the tenant in ctx, loading them if needed.
+func (r *Resolver) snapshot(ctx context.Context) (map[string]Flag, error) {
+	tenantID := tenant.GetTenantID(ctx)
+
+	r.mu.RLock()
+	snap, ok := r.cache[tenantID]
+	gen := r.generation
+	r.mu.RUnlock()
+	if ok && time.Since(snap.loadedAt) < r.ttl {
+		return snap.byModule, nil
+	}
+
+	flags, err := r.store.ListModuleFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byModule := make(map[string]Flag, len(flags))
+	for _, f := range flags {
+		if IsModuleFlag(f.Name) {
+			byModule[f.Name[len(FlagPrefix):]] = f
+		}
+	}
+
+	// Without a tenant in ctx the cache key would be shared — don't cache.
+	if tenantID == "" {
+		return byModule, nil
+	}
+
+	r.mu.Lock()
+	if r.generation == gen {
+		r.cache[tenantID] = &flagSnapshot{byModule: byModule, loadedAt: time.Now()}
+	}
+	r.mu.Unlock()
+
+	if r.onLoad != nil {
+		r.onLoad(tenantID)
+	}
+	return byModule, nil
+}
+
+// Status returns the status of module for the tenant in ctx.
+// A module without a flag is enabled.
+func (r *Resolver) Status(ctx context.Context, module string) (Status, error) {
+	flags, err := r.snapshot(ctx)
+	if err != nil {
+		return Status{}, err
+	}
+	f, ok := flags[module]
+	if !ok || f.active(clock.Now(ctx)) {
+		return Status{Enabled: true}, nil
+	}
+	return Status{PaymentRequired: f.Variant == VariantPaymentRequired}, nil
+}
+
+// Enabled reports whether module is enabled for the tenant in ctx.
+// It fails open: when the flags cannot be loaded the module is reported
+// enabled and the error is logged. Use Status where the error matters.
+func (r *Resolver) Enabled(ctx context.Context, module string) bool {
+	st, err := r.Status(ctx, module)
+	if err != nil {
+		logger.Warn(ctx, "module flags unavailable, treating module as enabled", "module", module, "error", err)
+		return true
+	}
+	return st.Enabled
+}
@@ -0,0 +1,63 @@
+package modules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"metapus/internal/core/clock"
+	"metapus/internal/core/tenant"
+)
+
+// memoryStore is an in-memory FlagStore counting loads.
+type memoryStore struct {
+	flags []Flag
+	loads int
+}
+
+func (s *memoryStore) ListModuleFlags(context.Context) ([]Flag, error) {
+	s.loads++
+	return append([]Flag(nil), s.flags...), nil
+}
+
+func TestResolverStatus(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	expired := now.Add(-time.Hour)
+	store := &memoryStore{flags: []Flag{
+		{Name: FlagName(Crypto), Enabled: false, Variant: VariantPaymentRequired},
+		{Name: FlagName(Orders), Enabled: true, ValidUntil: &expired},
+		{Name: "beta_ui", Enabled: false},
+	}}
+	r := NewResolver(store)
+	ctx := clock.WithClock(tenant.WithTenant(context.Background(), &tenant.Tenant{ID: "t1"}), clock.NewMock(now))
+
+	tests := []struct {
+		module string
+		want   Status
+	}{
+		{Crypto, Status{PaymentRequired: true}},
+		{Orders, Status{}},
+		{"reports", Status{Enabled: true}},
+	}
+	for _, tt := range tests {
+		got, err := r.Status(ctx, tt.module)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Status(%s) = %+v, want %+v", tt.module, got, tt.want)
+		}
+	}
+	if store.loads != 1 {
+		t.Errorf("loads = %d, want 1 (cached)", store.loads)
+	}
+
+	store.flags[0].Enabled = true
+	r.Invalidate("t1")
+	if !r.Enabled(ctx, Crypto) {
+		t.Error("crypto still disabled after invalidation")
+	}
+	if store.loads != 2 {
+		t.Errorf("loads = %d, want 2", store.loads)
+	}
+}
@@ -0,0 +1,96 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"metapus/internal/core/jobs"
+	"metapus/internal/core/types"
+)
+
+// EmailTemplate names an email template and fixes the Go type of its data.
+// A template has a subject and a body per tenant language
+// (templates/<locale>/<name>.tmpl).
+type EmailTemplate[D any] string
+
+// emailData decodes the queued data of each template for rendering.
+var emailData = map[string]func(raw json.RawMessage) (any, error){}
+
+// newEmailTemplate declares a template with data of type D.
+func newEmailTemplate[D any](name string) EmailTemplate[D] {
+	emailData[name] = func(raw json.RawMessage) (any, error) {
+		var d D
+		if err := json.Unmarshal(raw, &d); err != nil {
+			return nil, err
+		}
+		return d, nil
+	}
+	return EmailTemplate[D](name)
+}
+
+// Email templates.
+var (
+	DocumentAwaitingApprovalEmail = newEmailTemplate[DocumentAwaitingApproval]("document_awaiting_approval")
+	LowStockAlertEmail            = newEmailTemplate[LowStockAlert]("low_stock_alert")
+	UserInvitedEmail              = newEmailTemplate[UserInvited]("user_invited")
+)
+
+// DocumentAwaitingApproval is the data of DocumentAwaitingApprovalEmail.
+type DocumentAwaitingApproval struct {
+	DocumentLabel string    `json:"documentLabel"` // e.g. "Корректировка регистров"
+	Number        string    `json:"number"`
+	Date          time.Time `json:"date"`
+	Author        string    `json:"author,omitempty"`
+	Comment       string    `json:"comment,omitempty"`
+	Link          string    `json:"link,omitempty"`
+}
+
+// LowStockAlert is the data of LowStockAlertEmail.
+type LowStockAlert struct {
+	Items []LowStockItem `json:"items"`
+	Link  string         `json:"link,omitempty"`
+}
+
+// LowStockItem is a product whose balance fell below its minimum.
+type LowStockItem struct {
+	Nomenclature string         `json:"nomenclature"`
+	Warehouse    string         `json:"warehouse"`
+	Quantity     types.Quantity `json:"quantity"`
+	MinQuantity  types.Quantity `json:"minQuantity"`
+}
+
+// UserInvited is the data of UserInvitedEmail.
+type UserInvited struct {
+	Email     string `json:"email"`
+	FirstName string `json:"firstName,omitempty"`
+	InvitedBy string `json:"invitedBy,omitempty"`
+	LoginURL  string `json:"loginUrl,omitempty"`
+}
+
+// EmailJob is a queued email: the template, its recipients and data.
+type EmailJob struct {
+	Template string          `json:"template"`
+	To       []string        `json:"to"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// SendEmail is the background job that renders and sends a queued email
+// (see EmailSender).
+var SendEmail = jobs.Type[EmailJob]("notifications.email")
+
+// Enqueue queues the email to the recipients. Called inside a transaction,
+// the email is sent only if the transaction commits. It is rendered in the
+// tenant language when the worker sends it.
+func (t EmailTemplate[D]) Enqueue(ctx context.Context, q *jobs.Queue, to []string, data D) error {
+	if len(to) == 0 {
+		return nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("encode %s email: %w", t, err)
+	}
+	_, err = SendEmail.Enqueue(ctx, q, EmailJob{Template: string(t), To: to, Data: raw})
+	return err
+}
@@ -0,0 +1,57 @@
+package notifications
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"metapus/internal/core/format"
+)
+
+//go:embed templates
+var emailTemplateFS embed.FS
+
+// EmailRenderer renders email templates. Each locale has its own template
+// set under templates/<locale>/, parsed with formatting helpers bound to it.
+type EmailRenderer struct {
+	templates map[format.Locale]*template.Template
+}
+
+// NewEmailRenderer loads the embedded email templates.
+func NewEmailRenderer() (*EmailRenderer, error) {
+	r := &EmailRenderer{templates: make(map[format.Locale]*template.Template)}
+	for _, locale := range []format.Locale{format.LocaleRU, format.LocaleEN} {
+		f := format.New(locale)
+		tmpl, err := template.New("").Funcs(template.FuncMap{
+			"formatDate": f.Date,
+			"formatQty":  f.Quantity,
+		}).ParseFS(emailTemplateFS, "templates/"+string(locale)+"/*.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("load email templates: %w", err)
+		}
+		r.templates[locale] = tmpl
+	}
+	return r, nil
+}
+
+// Render executes the "<name>.subject" and "<name>.body" templates of the
+// locale (falling back to format.DefaultLocale).
+func (r *EmailRenderer) Render(locale format.Locale, name string, data any) (subject, body string, err error) {
+	tmpl, ok := r.templates[locale]
+	if !ok {
+		tmpl = r.templates[format.DefaultLocale]
+	}
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name+".subject", data); err != nil {
+		return "", "", fmt.Errorf("render %s subject: %w", name, err)
+	}
+	// The subject must be a single line.
+	subject = strings.Join(strings.Fields(buf.String()), " ")
+	buf.Reset()
+	if err := tmpl.ExecuteTemplate(&buf, name+".body", data); err != nil {
+		return "", "", fmt.Errorf("render %s body: %w", name, err)
+	}
+	return subject, strings.TrimSpace(buf.String()) + "\n", nil
+}
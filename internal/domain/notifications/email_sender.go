@@ -0,0 +1,60 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"metapus/internal/core/format"
+	"metapus/internal/core/jobs"
+	"metapus/internal/domain/auth"
+	"metapus/internal/domain/settings"
+)
+
+// EmailSender sends queued emails: it handles SendEmail jobs in the worker,
+// rendering each email in the tenant language (General.Locale).
+type EmailSender struct {
+	mailer   auth.Mailer
+	settings settings.Repository
+	renderer *EmailRenderer
+}
+
+// NewEmailSender creates an email sender. mailer is usually the
+// tenant-aware mail.TenantMailer.
+func NewEmailSender(mailer auth.Mailer, settingsRepo settings.Repository) (*EmailSender, error) {
+	renderer, err := NewEmailRenderer()
+	if err != nil {
+		return nil, err
+	}
+	return &EmailSender{mailer: mailer, settings: settingsRepo, renderer: renderer}, nil
+}
+
+// Register registers the SendEmail job handler.
+func (s *EmailSender) Register(reg *jobs.Registry) {
+	jobs.Handle(reg, SendEmail, s.send)
+}
+
+// send renders and sends one queued email. Unknown templates and data that
+// do not decode are permanent failures; mailer errors are retried.
+func (s *EmailSender) send(ctx context.Context, job EmailJob) error {
+	decode, ok := emailData[job.Template]
+	if !ok {
+		return jobs.Permanent(fmt.Errorf("unknown email template %q", job.Template))
+	}
+	data, err := decode(job.Data)
+	if err != nil {
+		return jobs.Permanent(fmt.Errorf("decode %s email: %w", job.Template, err))
+	}
+
+	locale := format.DefaultLocale
+	if st, err := s.settings.Get(ctx); err != nil {
+		return fmt.Errorf("load tenant locale: %w", err)
+	} else if st.General.Locale != "" {
+		locale = format.Locale(st.General.Locale)
+	}
+
+	subject, body, err := s.renderer.Render(locale, job.Template, data)
+	if err != nil {
+		return jobs.Permanent(err)
+	}
+	return s.mailer.Send(ctx, auth.MailMessage{To: job.To, Subject: subject, Body: body})
+}
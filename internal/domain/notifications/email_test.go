@@ -0,0 +1,88 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"metapus/internal/core/format"
+	"metapus/internal/core/jobs"
+	"metapus/internal/core/types"
+	"metapus/internal/domain/auth"
+	"metapus/internal/domain/settings"
+)
+
+func TestEmailTemplatesRenderInEveryLocale(t *testing.T) {
+	r, err := NewEmailRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := map[string]any{
+		string(DocumentAwaitingApprovalEmail): DocumentAwaitingApproval{
+			DocumentLabel: "Корректировка регистров", Number: "MA-001",
+			Date: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), Author: "a@example.com", Link: "https://erp/documents/x",
+		},
+		string(LowStockAlertEmail): LowStockAlert{Items: []LowStockItem{
+			{Nomenclature: "Bolt", Warehouse: "Main", Quantity: types.NewQuantityFromFloat64(2), MinQuantity: types.NewQuantityFromFloat64(10)},
+		}},
+		string(UserInvitedEmail): UserInvited{Email: "new@example.com", FirstName: "Ann", LoginURL: "https://erp/login"},
+	}
+	for _, locale := range []format.Locale{format.LocaleRU, format.LocaleEN} {
+		for name := range emailData {
+			subject, body, err := r.Render(locale, name, data[name])
+			if err != nil {
+				t.Fatalf("%s/%s: %v", locale, name, err)
+			}
+			if subject == "" || strings.Contains(subject, "\n") || strings.TrimSpace(body) == "" {
+				t.Errorf("%s/%s: subject %q, body %q", locale, name, subject, body)
+			}
+		}
+	}
+
+	_, body, _ := r.Render(format.LocaleRU, string(DocumentAwaitingApprovalEmail), data[string(DocumentAwaitingApprovalEmail)])
+	if !strings.Contains(body, "05.03.2026") || !strings.Contains(body, "https://erp/documents/x") {
+		t.Errorf("ru body = %q", body)
+	}
+	_, body, _ = r.Render(format.LocaleEN, string(LowStockAlertEmail), data[string(LowStockAlertEmail)])
+	if !strings.Contains(body, "Bolt (Main): 2") {
+		t.Errorf("en body = %q", body)
+	}
+}
+
+type fakeMailer struct{ sent []auth.MailMessage }
+
+func (m *fakeMailer) Send(_ context.Context, msg auth.MailMessage) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+type fakeSettings struct {
+	settings.Repository
+	locale string
+}
+
+func (f fakeSettings) Get(context.Context) (*settings.Settings, error) {
+	return &settings.Settings{General: settings.GeneralSettings{Locale: f.locale}}, nil
+}
+
+func TestEmailSenderUsesTenantLanguage(t *testing.T) {
+	mailer := &fakeMailer{}
+	sender, err := NewEmailSender(mailer, fakeSettings{locale: "en"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := json.Marshal(UserInvited{Email: "new@example.com"})
+	if err := sender.send(context.Background(), EmailJob{Template: string(UserInvitedEmail), To: []string{"new@example.com"}, Data: raw}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if len(mailer.sent) != 1 || mailer.sent[0].Subject != "You have been invited to Metapus" {
+		t.Fatalf("sent = %+v", mailer.sent)
+	}
+
+	err = sender.send(context.Background(), EmailJob{Template: "missing", To: []string{"x@example.com"}})
+	if !jobs.IsPermanent(err) {
+		t.Errorf("unknown template: err = %v, want permanent", err)
+	}
+}
@@ -0,0 +1,90 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/jobs"
+	"metapus/internal/domain/auth"
+)
+
+// RecipientResolver finds the email recipients of a notification.
+type RecipientResolver interface {
+	// ListEmailsByPermission returns the emails of active users granted the
+	// permission (or administrators).
+	ListEmailsByPermission(ctx context.Context, permission string) ([]string, error)
+}
+
+// Emails queues the notification emails of the built-in use cases for the
+// tenant in ctx. Links are built from appURL, the public frontend URL;
+// empty leaves them relative.
+type Emails struct {
+	queue      *jobs.Queue
+	recipients RecipientResolver
+	appURL     string
+}
+
+// NewEmails creates the email notifications.
+func NewEmails(queue *jobs.Queue, recipients RecipientResolver, appURL string) *Emails {
+	return &Emails{queue: queue, recipients: recipients, appURL: strings.TrimRight(appURL, "/")}
+}
+
+// URL returns the absolute frontend URL of path.
+func (e *Emails) URL(path string) string {
+	return e.appURL + path
+}
+
+// DocumentAwaitingApproval emails the users allowed to approve the document
+// (permission, e.g. document:manual_adjustment:approve). The current user,
+// the author, is skipped: nobody approves their own document.
+func (e *Emails) DocumentAwaitingApproval(ctx context.Context, permission string, data DocumentAwaitingApproval) error {
+	to, err := e.recipients.ListEmailsByPermission(ctx, permission)
+	if err != nil {
+		return fmt.Errorf("resolve approvers: %w", err)
+	}
+	if user := appctx.GetUser(ctx); user != nil {
+		to = removeEmail(to, user.Email)
+		if data.Author == "" {
+			data.Author = user.Email
+		}
+	}
+	return DocumentAwaitingApprovalEmail.Enqueue(ctx, e.queue, to, data)
+}
+
+// LowStockAlert emails the users granted permission about products below
+// their minimum stock.
+func (e *Emails) LowStockAlert(ctx context.Context, permission string, data LowStockAlert) error {
+	if len(data.Items) == 0 {
+		return nil
+	}
+	to, err := e.recipients.ListEmailsByPermission(ctx, permission)
+	if err != nil {
+		return fmt.Errorf("resolve low-stock recipients: %w", err)
+	}
+	return LowStockAlertEmail.Enqueue(ctx, e.queue, to, data)
+}
+
+// Invite implements auth.Inviter: it emails a user created by an
+// administrator.
+func (e *Emails) Invite(ctx context.Context, user *auth.User) error {
+	data := UserInvited{Email: user.Email, FirstName: user.FirstName, LoginURL: e.URL("/login")}
+	if inviter := appctx.GetUser(ctx); inviter != nil {
+		data.InvitedBy = inviter.Email
+	}
+	return UserInvitedEmail.Enqueue(ctx, e.queue, []string{user.Email}, data)
+}
+
+// removeEmail returns emails without email (case-insensitive).
+func removeEmail(emails []string, email string) []string {
+	out := emails[:0]
+	for _, e := range emails {
+		if !strings.EqualFold(e, email) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+var _ auth.Inviter = (*Emails)(nil)
@@ -0,0 +1,131 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/format"
+	"metapus/internal/core/id"
+)
+
+// UserResolver finds the in-app recipients of a notification.
+type UserResolver interface {
+	// ListUserIDsByPermission returns the IDs of active users granted the
+	// permission (or administrators).
+	ListUserIDsByPermission(ctx context.Context, permission string) ([]id.ID, error)
+}
+
+// DocumentPosted is the data of a "document posted" notification.
+type DocumentPosted struct {
+	DocumentLabel string
+	Number        string
+	Date          time.Time
+	Link          string
+}
+
+// Inbox creates the in-app notifications of the built-in use cases in the
+// tenant database of ctx. Notifications are created in the caller's
+// transaction, so they appear (and are streamed) only if it commits.
+type Inbox struct {
+	repo  Repository
+	users UserResolver
+}
+
+// NewInbox creates the in-app notifications.
+func NewInbox(repo Repository, users UserResolver) *Inbox {
+	return &Inbox{repo: repo, users: users}
+}
+
+// DocumentPosted notifies the author of a document posted by another user.
+func (b *Inbox) DocumentPosted(ctx context.Context, author id.ID, data DocumentPosted) error {
+	if id.IsNil(author) || author.String() == appctx.GetUserID(ctx) {
+		return nil
+	}
+	message := fmt.Sprintf("No. %s of %s was posted", data.Number, data.Date.Format(time.DateOnly))
+	if user := appctx.GetUser(ctx); user != nil && user.Email != "" {
+		message += " by " + user.Email
+	}
+	n := newNotification(author, "Posted: "+data.DocumentLabel, message+".", SeveritySuccess, data.Link)
+	n.Attributes = map[string]any{"source": "document", "kind": "posted"}
+	if err := b.repo.Create(ctx, n); err != nil {
+		return fmt.Errorf("create notification: %w", err)
+	}
+	return nil
+}
+
+// DocumentAwaitingApproval notifies the users allowed to approve the
+// document (permission, e.g. document:manual_adjustment:approve). Like the
+// email, it skips the current user, the author.
+func (b *Inbox) DocumentAwaitingApproval(ctx context.Context, permission string, data DocumentAwaitingApproval) error {
+	users, err := b.users.ListUserIDsByPermission(ctx, permission)
+	if err != nil {
+		return fmt.Errorf("resolve approvers: %w", err)
+	}
+	author := appctx.GetUserID(ctx)
+	message := fmt.Sprintf("No. %s of %s is awaiting your approval.", data.Number, data.Date.Format(time.DateOnly))
+	if data.Comment != "" {
+		message += " " + data.Comment
+	}
+
+	var batch []*Notification
+	for _, userID := range users {
+		if userID.String() == author {
+			continue
+		}
+		n := newNotification(userID, "Approval required: "+data.DocumentLabel, message, SeverityInfo, data.Link)
+		n.Attributes = map[string]any{"source": "document", "kind": "awaiting_approval"}
+		batch = append(batch, n)
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := b.repo.CreateBatch(ctx, batch); err != nil {
+		return fmt.Errorf("create notifications: %w", err)
+	}
+	return nil
+}
+
+// LowStockAlert notifies the users granted permission about products below
+// their minimum stock, like the email of the same name.
+func (b *Inbox) LowStockAlert(ctx context.Context, permission string, data LowStockAlert) error {
+	if len(data.Items) == 0 {
+		return nil
+	}
+	users, err := b.users.ListUserIDsByPermission(ctx, permission)
+	if err != nil {
+		return fmt.Errorf("resolve low-stock recipients: %w", err)
+	}
+
+	first, f := data.Items[0], format.New(format.LocaleEN)
+	message := fmt.Sprintf("%s (%s): %s, minimum %s.",
+		first.Nomenclature, first.Warehouse, f.Quantity(first.Quantity), f.Quantity(first.MinQuantity))
+	if len(data.Items) > 1 {
+		message += fmt.Sprintf(" And %d more.", len(data.Items)-1)
+	}
+	title := fmt.Sprintf("Low stock: %d item(s) below minimum", len(data.Items))
+
+	batch := make([]*Notification, 0, len(users))
+	for _, userID := range users {
+		n := newNotification(userID, title, message, SeverityWarning, data.Link)
+		n.Attributes = map[string]any{"source": "stock", "kind": "low_stock", "count": len(data.Items)}
+		batch = append(batch, n)
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := b.repo.CreateBatch(ctx, batch); err != nil {
+		return fmt.Errorf("create notifications: %w", err)
+	}
+	return nil
+}
+
+func newNotification(userID id.ID, title, message string, severity Severity, link string) *Notification {
+	nid := id.New()
+	n := &Notification{ID: &nid, UserID: userID, Title: title, Message: message, Severity: severity}
+	if link != "" {
+		n.Link = &link
+	}
+	return n
+}
@@ -0,0 +1,169 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"metapus/internal/core/id"
+)
+
+// ChangedChannel is the NOTIFY channel fired by the sys_notifications
+// triggers in the tenant database. Payload is a JSON-encoded Change.
+const ChangedChannel = "notifications_changed"
+
+// ChangeOp is the kind of a notification change.
+type ChangeOp string
+
+const (
+	// ChangeCreated: notification ID was created for the user.
+	ChangeCreated ChangeOp = "created"
+	// ChangeUpdated: notifications of the user were read, unread or deleted.
+	ChangeUpdated ChangeOp = "updated"
+	// ChangeReset: changes may have been missed, the subscriber must reload.
+	ChangeReset ChangeOp = "reset"
+)
+
+// Change describes a change of the notifications of a user.
+type Change struct {
+	Op     ChangeOp `json:"op"`
+	ID     *id.ID   `json:"id,omitempty"`
+	UserID string   `json:"userId"`
+}
+
+// ParseChange decodes a ChangedChannel payload.
+func ParseChange(payload string) (Change, error) {
+	var c Change
+	if err := json.Unmarshal([]byte(payload), &c); err != nil {
+		return Change{}, fmt.Errorf("decode notification change: %w", err)
+	}
+	if c.UserID == "" {
+		return Change{}, fmt.Errorf("decode notification change: missing userId")
+	}
+	return c, nil
+}
+
+// maxPendingChanges bounds the changes queued for a slow subscriber; on
+// overflow they collapse into a single ChangeReset.
+const maxPendingChanges = 64
+
+// Broker fans out notification changes to the subscribers (open streams) of
+// each user. Changes are fed by a listener of ChangedChannel, so streams see
+// notifications created by any process.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[string]map[string]map[*Subscription]struct{} // tenantID -> userID -> subscriptions
+
+	onSubscribe func(tenantID string)
+}
+
+// NewBroker creates an empty broker.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[string]map[string]map[*Subscription]struct{})}
+}
+
+// SetSubscribeHook registers fn to be called when a tenant gets a subscriber;
+// the listener uses it to watch tenants lazily.
+func (b *Broker) SetSubscribeHook(fn func(tenantID string)) {
+	b.onSubscribe = fn
+}
+
+// Subscribe registers a subscriber for the changes of a user. Call Close
+// when done.
+func (b *Broker) Subscribe(tenantID, userID string) *Subscription {
+	s := &Subscription{broker: b, tenantID: tenantID, userID: userID, ready: make(chan struct{}, 1)}
+
+	b.mu.Lock()
+	users, ok := b.subs[tenantID]
+	if !ok {
+		users = make(map[string]map[*Subscription]struct{})
+		b.subs[tenantID] = users
+	}
+	if users[userID] == nil {
+		users[userID] = make(map[*Subscription]struct{})
+	}
+	users[userID][s] = struct{}{}
+	b.mu.Unlock()
+
+	if b.onSubscribe != nil {
+		b.onSubscribe(tenantID)
+	}
+	return s
+}
+
+// Publish delivers c to the subscribers of c.UserID in the tenant.
+func (b *Broker) Publish(tenantID string, c Change) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subs[tenantID][c.UserID] {
+		s.push(c)
+	}
+}
+
+// Reset tells every subscriber of the tenant that changes may have been missed.
+func (b *Broker) Reset(tenantID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for userID, subs := range b.subs[tenantID] {
+		for s := range subs {
+			s.push(Change{Op: ChangeReset, UserID: userID})
+		}
+	}
+}
+
+func (b *Broker) unsubscribe(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	users := b.subs[s.tenantID]
+	delete(users[s.userID], s)
+	if len(users[s.userID]) == 0 {
+		delete(users, s.userID)
+	}
+	if len(users) == 0 {
+		delete(b.subs, s.tenantID)
+	}
+}
+
+// Subscription receives the notification changes of one user.
+type Subscription struct {
+	broker           *Broker
+	tenantID, userID string
+
+	mu      sync.Mutex
+	pending []Change
+	ready   chan struct{}
+}
+
+// Ready is signalled when changes are pending; collect them with Changes.
+func (s *Subscription) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Changes returns and clears the pending changes.
+func (s *Subscription) Changes() []Change {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changes := s.pending
+	s.pending = nil
+	return changes
+}
+
+// Close unsubscribes.
+func (s *Subscription) Close() {
+	s.broker.unsubscribe(s)
+}
+
+func (s *Subscription) push(c Change) {
+	s.mu.Lock()
+	if len(s.pending) >= maxPendingChanges {
+		s.pending = []Change{{Op: ChangeReset, UserID: s.userID}}
+	} else {
+		s.pending = append(s.pending, c)
+	}
+	s.mu.Unlock()
+
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,162 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+)
+
+func TestBrokerDeliversChangesToTheUser(t *testing.T) {
+	b := NewBroker()
+	var watched []string
+	b.SetSubscribeHook(func(tenantID string) { watched = append(watched, tenantID) })
+
+	alice := b.Subscribe("t1", "alice")
+	defer alice.Close()
+	bob := b.Subscribe("t1", "bob")
+	defer bob.Close()
+	other := b.Subscribe("t2", "alice")
+	defer other.Close()
+
+	nid := id.New()
+	b.Publish("t1", Change{Op: ChangeCreated, ID: &nid, UserID: "alice"})
+
+	select {
+	case <-alice.Ready():
+	default:
+		t.Fatal("alice was not signalled")
+	}
+	if got := alice.Changes(); len(got) != 1 || *got[0].ID != nid {
+		t.Fatalf("alice changes = %+v", got)
+	}
+	if got := bob.Changes(); len(got) != 0 {
+		t.Errorf("bob got %+v", got)
+	}
+	if got := other.Changes(); len(got) != 0 {
+		t.Errorf("alice of another tenant got %+v", got)
+	}
+	if len(watched) != 3 || watched[0] != "t1" || watched[2] != "t2" {
+		t.Errorf("subscribe hook calls = %v", watched)
+	}
+}
+
+func TestBrokerResetAndOverflow(t *testing.T) {
+	b := NewBroker()
+	s := b.Subscribe("t1", "alice")
+
+	b.Reset("t1")
+	if got := s.Changes(); len(got) != 1 || got[0].Op != ChangeReset || got[0].UserID != "alice" {
+		t.Fatalf("after reset: %+v", got)
+	}
+
+	for range maxPendingChanges + 1 {
+		b.Publish("t1", Change{Op: ChangeUpdated, UserID: "alice"})
+	}
+	if got := s.Changes(); len(got) != 1 || got[0].Op != ChangeReset {
+		t.Fatalf("overflow did not collapse into a reset: %d changes", len(got))
+	}
+
+	s.Close()
+	b.Publish("t1", Change{Op: ChangeUpdated, UserID: "alice"})
+	if got := s.Changes(); len(got) != 0 {
+		t.Errorf("closed subscription got %+v", got)
+	}
+	if len(b.subs) != 0 {
+		t.Errorf("subscriptions left: %v", b.subs)
+	}
+}
+
+func TestParseChange(t *testing.T) {
+	c, err := ParseChange(`{"op":"created","id":"0192d5c4-7a10-7000-8000-000000000001","userId":"u1"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Op != ChangeCreated || c.ID == nil || c.UserID != "u1" {
+		t.Errorf("parsed %+v", c)
+	}
+	if _, err := ParseChange(`{"op":"updated"}`); err == nil {
+		t.Error("change without user accepted")
+	}
+}
+
+type fakeNotificationRepo struct {
+	Repository
+	created []*Notification
+}
+
+func (r *fakeNotificationRepo) Create(_ context.Context, n *Notification) error {
+	r.created = append(r.created, n)
+	return nil
+}
+
+func (r *fakeNotificationRepo) CreateBatch(_ context.Context, batch []*Notification) error {
+	r.created = append(r.created, batch...)
+	return nil
+}
+
+type fakeUsers []id.ID
+
+func (u fakeUsers) ListUserIDsByPermission(context.Context, string) ([]id.ID, error) {
+	return u, nil
+}
+
+func TestInboxSkipsTheCurrentUser(t *testing.T) {
+	author, approver := id.New(), id.New()
+	repo := &fakeNotificationRepo{}
+	inbox := NewInbox(repo, fakeUsers{author, approver})
+	ctx := appctx.WithUser(context.Background(), &appctx.UserContext{UserID: author.String(), Email: "a@example.com"})
+	date := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	err := inbox.DocumentAwaitingApproval(ctx, "document:manual_adjustment:approve", DocumentAwaitingApproval{
+		DocumentLabel: "Корректировка регистров", Number: "MA-001", Date: date, Link: "/documents/manual-adjustments/x",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.created) != 1 || repo.created[0].UserID != approver || *repo.created[0].Link != "/documents/manual-adjustments/x" {
+		t.Fatalf("approval notifications = %+v", repo.created)
+	}
+
+	// Posting one's own document notifies nobody; posting another's notifies the author.
+	repo.created = nil
+	if err := inbox.DocumentPosted(ctx, author, DocumentPosted{Number: "1", Date: date}); err != nil {
+		t.Fatal(err)
+	}
+	if err := inbox.DocumentPosted(ctx, approver, DocumentPosted{Number: "2", Date: date}); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.created) != 1 || repo.created[0].UserID != approver {
+		t.Fatalf("posted notifications = %+v", repo.created)
+	}
+}
+
+func TestInboxLowStockAlert(t *testing.T) {
+	keeper, admin := id.New(), id.New()
+	repo := &fakeNotificationRepo{}
+	inbox := NewInbox(repo, fakeUsers{keeper, admin})
+
+	if err := inbox.LowStockAlert(context.Background(), "stock_level.alerts", LowStockAlert{}); err != nil || len(repo.created) != 0 {
+		t.Fatalf("empty alert: err = %v, notifications = %+v", err, repo.created)
+	}
+
+	err := inbox.LowStockAlert(context.Background(), "stock_level.alerts", LowStockAlert{
+		Items: []LowStockItem{
+			{Nomenclature: "Bolt", Warehouse: "Main", Quantity: types.NewQuantityFromFloat64(2), MinQuantity: types.NewQuantityFromFloat64(10)},
+			{Nomenclature: "Nut", Warehouse: "Main", MinQuantity: types.NewQuantityFromFloat64(5)},
+		},
+		Link: "/reports/stock-replenishment",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.created) != 2 || repo.created[0].Severity != SeverityWarning || *repo.created[0].Link != "/reports/stock-replenishment" {
+		t.Fatalf("low-stock notifications = %+v", repo.created)
+	}
+	if want := "Bolt (Main): 2.000, minimum 10.000. And 1 more."; repo.created[0].Message != want {
+		t.Errorf("message = %q, want %q", repo.created[0].Message, want)
+	}
+}
@@ -0,0 +1,26 @@
+// Package period closes accounting periods per organization: documents of
+// an organization dated before its lock date cannot be posted, unposted,
+// changed or deleted, unless the user may override the lock.
+package period
+
+import (
+	"time"
+
+	"metapus/internal/core/id"
+)
+
+// PermissionOverride lets a user change documents in closed periods.
+const PermissionOverride = "period:override"
+
+// Lock is the closed period of an organization.
+type Lock struct {
+	OrganizationID id.ID `db:"organization_id" json:"organizationId"`
+
+	// ClosedUntil is the first day of the open period; documents dated
+	// earlier are locked.
+	ClosedUntil time.Time `db:"closed_until" json:"closedUntil"`
+
+	Comment  string    `db:"comment" json:"comment,omitempty"`
+	ClosedBy *id.ID    `db:"closed_by" json:"closedBy,omitempty"`
+	ClosedAt time.Time `db:"closed_at" json:"closedAt"`
+}
@@ -0,0 +1,22 @@
+package period
+
+import (
+	"context"
+
+	"metapus/internal/core/id"
+)
+
+// Repository stores the period locks of the tenant database.
+type Repository interface {
+	// List returns the locks of all organizations.
+	List(ctx context.Context) ([]Lock, error)
+
+	// Get returns the lock of an organization, nil if it has none.
+	Get(ctx context.Context, organizationID id.ID) (*Lock, error)
+
+	// Save creates or replaces the lock of an organization.
+	Save(ctx context.Context, lock *Lock) error
+
+	// Delete removes the lock of an organization.
+	Delete(ctx context.Context, organizationID id.ID) error
+}
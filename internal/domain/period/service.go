@@ -0,0 +1,147 @@
+package period
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/clock"
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/settings"
+	"metapus/pkg/logger"
+)
+
+// Service closes and reopens periods and checks documents against them.
+type Service struct {
+	repo Repository
+	now  func() time.Time
+}
+
+// NewService creates the period closing service.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// List returns the locks of all organizations.
+func (s *Service) List(ctx context.Context) ([]Lock, error) {
+	return s.repo.List(ctx)
+}
+
+// Close closes the period of an organization before until (a date): the
+// lock date can only move forward, use Reopen to move it back.
+func (s *Service) Close(ctx context.Context, organizationID id.ID, until time.Time, comment string) (*Lock, error) {
+	until = clock.DateOf(until)
+	if until.IsZero() {
+		return nil, apperror.NewValidation("closedUntil is required").WithDetail("field", "closedUntil")
+	}
+
+	current, err := s.repo.Get(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && !until.After(current.ClosedUntil) {
+		return nil, apperror.NewValidation(fmt.Sprintf("the period is already closed until %s; reopen it to move the date back",
+			current.ClosedUntil.Format(time.DateOnly))).WithDetail("field", "closedUntil")
+	}
+	return s.save(ctx, organizationID, until, comment)
+}
+
+// Reopen moves the lock date of an organization back to until; a zero until
+// opens every period.
+func (s *Service) Reopen(ctx context.Context, organizationID id.ID, until time.Time, comment string) (*Lock, error) {
+	until = clock.DateOf(until)
+	current, err := s.repo.Get(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, apperror.NewNotFound("period_lock", organizationID.String())
+	}
+	if !until.Before(current.ClosedUntil) {
+		return nil, apperror.NewValidation(fmt.Sprintf("closedUntil must be before %s",
+			current.ClosedUntil.Format(time.DateOnly))).WithDetail("field", "closedUntil")
+	}
+
+	if until.IsZero() {
+		if err := s.repo.Delete(ctx, organizationID); err != nil {
+			return nil, err
+		}
+		logger.Info(ctx, "period reopened", "organization_id", organizationID, "user_id", appctx.GetUserID(ctx))
+		return nil, nil
+	}
+	return s.save(ctx, organizationID, until, comment)
+}
+
+func (s *Service) save(ctx context.Context, organizationID id.ID, until time.Time, comment string) (*Lock, error) {
+	lock := &Lock{OrganizationID: organizationID, ClosedUntil: until, Comment: comment, ClosedAt: s.now()}
+	if userID, err := id.Parse(appctx.GetUserID(ctx)); err == nil {
+		lock.ClosedBy = &userID
+	}
+	if err := s.repo.Save(ctx, lock); err != nil {
+		return nil, err
+	}
+	logger.Info(ctx, "period lock changed",
+		"organization_id", organizationID,
+		"closed_until", until.Format(time.DateOnly),
+		"user_id", appctx.GetUserID(ctx))
+	return lock, nil
+}
+
+// ClosedUntil returns the lock date of an organization: the later of its
+// lock and the tenant-wide period.closedUntil setting. Zero means no
+// period is closed.
+func (s *Service) ClosedUntil(ctx context.Context, organizationID id.ID) (time.Time, error) {
+	var until time.Time
+	if v := settings.Get(ctx, settings.KeyPeriodClosedUntil, settings.Subject{}); v != "" {
+		until, _ = time.Parse(time.DateOnly, v) // validated on save
+	}
+	if id.IsNil(organizationID) {
+		return until, nil
+	}
+	lock, err := s.repo.Get(ctx, organizationID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if lock != nil && lock.ClosedUntil.After(until) {
+		until = lock.ClosedUntil
+	}
+	return until, nil
+}
+
+// CheckPeriod rejects a change of a document of the organization dated at
+// any of dates in a closed period, unless the user holds PermissionOverride.
+// Implements posting.PeriodGuard.
+func (s *Service) CheckPeriod(ctx context.Context, organizationID id.ID, dates ...time.Time) error {
+	until, err := s.ClosedUntil(ctx, organizationID)
+	if err != nil {
+		return fmt.Errorf("load period lock: %w", err)
+	}
+	if until.IsZero() {
+		return nil
+	}
+	for _, date := range dates {
+		if !clock.DateOf(date).Before(until) {
+			continue
+		}
+		if canOverride(ctx) {
+			logger.Info(ctx, "closed period overridden",
+				"organization_id", organizationID,
+				"date", date.Format(time.DateOnly),
+				"user_id", appctx.GetUserID(ctx))
+			return nil
+		}
+		return apperror.NewPeriodClosed(date.Format("2006-01")).
+			WithDetail("organizationId", organizationID.String()).
+			WithDetail("closedUntil", until.Format(time.DateOnly))
+	}
+	return nil
+}
+
+// canOverride reports whether the current user may change closed periods.
+func canOverride(ctx context.Context) bool {
+	user := appctx.GetUser(ctx)
+	return user != nil && (user.IsAdmin || slices.Contains(user.Permissions, PermissionOverride))
+}
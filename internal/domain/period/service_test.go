@@ -0,0 +1,102 @@
+package period
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/id"
+)
+
+type memRepo struct {
+	locks map[id.ID]Lock
+}
+
+func (r *memRepo) List(context.Context) ([]Lock, error) {
+	locks := make([]Lock, 0, len(r.locks))
+	for _, l := range r.locks {
+		locks = append(locks, l)
+	}
+	return locks, nil
+}
+
+func (r *memRepo) Get(_ context.Context, orgID id.ID) (*Lock, error) {
+	l, ok := r.locks[orgID]
+	if !ok {
+		return nil, nil
+	}
+	return &l, nil
+}
+
+func (r *memRepo) Save(_ context.Context, l *Lock) error {
+	r.locks[l.OrganizationID] = *l
+	return nil
+}
+
+func (r *memRepo) Delete(_ context.Context, orgID id.ID) error {
+	delete(r.locks, orgID)
+	return nil
+}
+
+func date(s string) time.Time {
+	t, _ := time.Parse(time.DateOnly, s)
+	return t
+}
+
+func TestServiceCloseAndCheck(t *testing.T) {
+	svc := NewService(&memRepo{locks: map[id.ID]Lock{}})
+	org := id.New()
+	ctx := appctx.WithUser(context.Background(), &appctx.UserContext{UserID: id.New().String()})
+
+	if _, err := svc.Close(ctx, org, date("2026-04-01"), "Q1"); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := svc.Close(ctx, org, date("2026-03-01"), ""); err == nil {
+		t.Fatal("Close moved the lock date back")
+	}
+
+	err := svc.CheckPeriod(ctx, org, date("2026-03-31").Add(15*time.Hour))
+	if appErr, ok := apperror.AsAppError(err); !ok || appErr.Code != apperror.CodePeriodClosed {
+		t.Fatalf("CheckPeriod in closed period = %v, want PERIOD_CLOSED", err)
+	}
+	if err := svc.CheckPeriod(ctx, org, date("2026-04-01")); err != nil {
+		t.Fatalf("CheckPeriod in open period: %v", err)
+	}
+	if err := svc.CheckPeriod(ctx, id.New(), date("2026-01-01")); err != nil {
+		t.Fatalf("CheckPeriod of another organization: %v", err)
+	}
+
+	override := appctx.WithUser(context.Background(), &appctx.UserContext{Permissions: []string{PermissionOverride}})
+	if err := svc.CheckPeriod(override, org, date("2026-01-01")); err != nil {
+		t.Fatalf("CheckPeriod with %s: %v", PermissionOverride, err)
+	}
+}
+
+func TestServiceReopen(t *testing.T) {
+	repo := &memRepo{locks: map[id.ID]Lock{}}
+	svc := NewService(repo)
+	org := id.New()
+	ctx := context.Background()
+
+	if _, err := svc.Reopen(ctx, org, time.Time{}, ""); !apperror.IsNotFound(err) {
+		t.Fatalf("Reopen without lock = %v, want not found", err)
+	}
+	if _, err := svc.Close(ctx, org, date("2026-04-01"), ""); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := svc.Reopen(ctx, org, date("2026-05-01"), ""); err == nil {
+		t.Fatal("Reopen moved the lock date forward")
+	}
+	lock, err := svc.Reopen(ctx, org, date("2026-02-01"), "correction")
+	if err != nil || !lock.ClosedUntil.Equal(date("2026-02-01")) {
+		t.Fatalf("Reopen = %v, %v", lock, err)
+	}
+	if lock, err := svc.Reopen(ctx, org, time.Time{}, ""); err != nil || lock != nil {
+		t.Fatalf("Reopen all = %v, %v", lock, err)
+	}
+	if len(repo.locks) != 0 {
+		t.Fatal("lock not deleted")
+	}
+}
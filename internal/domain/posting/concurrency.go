@@ -0,0 +1,118 @@
+package posting
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/settings"
+)
+
+// Lock modes of a document type (settings.KeyPostingLockModes).
+const (
+	// LockModeDocument only locks the posted document (default).
+	LockModeDocument = "document"
+	// LockModeWarehouse runs the postings touching the same warehouse one at a time.
+	LockModeWarehouse = "warehouse"
+	// LockModeSequential runs all postings of the document type one at a time.
+	LockModeSequential = "sequential"
+)
+
+// PartitionLocker acquires exclusive transactional locks on arbitrary keys.
+// Used to serialize concurrent postings that would otherwise contend for
+// the same balance rows (deadlocks, serialization failures under load).
+// Must be called inside a transaction — the locks are released on
+// COMMIT/ROLLBACK. A DocumentLocker implementing it is picked up by NewEngine.
+type PartitionLocker interface {
+	// LockPartitions locks keys in the given order; callers sort them so
+	// that postings locking several keys cannot deadlock.
+	LockPartitions(ctx context.Context, keys []string) error
+}
+
+// WarehousePartitioner is an optional interface for recorders whose stored
+// movements belong to warehouses. The engine locks them too in
+// LockModeWarehouse: re-posting or unposting changes the balances of the
+// warehouses of the previous version.
+type WarehousePartitioner interface {
+	PostedWarehouses(ctx context.Context, recorderID id.ID) ([]id.ID, error)
+}
+
+// SetPartitionLocker replaces the locker used by the lock modes.
+func (e *Engine) SetPartitionLocker(locker PartitionLocker) {
+	e.partitionLocker = locker
+}
+
+// lockPartitions serializes the posting of doc with the concurrent postings
+// according to the lock mode of its type. set holds the new movements (nil
+// on unpost). Called inside the posting transaction after the document lock.
+// Keys start with the tenant ID: advisory locks are database-wide, and
+// tenants sharing a database must not wait for each other.
+func (e *Engine) lockPartitions(ctx context.Context, doc Postable, set *MovementSet) error {
+	if e.partitionLocker == nil {
+		return nil
+	}
+	docType := doc.GetDocumentType()
+	prefix := tenant.GetTenantID(ctx) + ":"
+
+	var keys []string
+	switch settings.Get(ctx, settings.KeyPostingLockModes, settings.Subject{})[docType] {
+	case LockModeSequential:
+		keys = []string{prefix + "posting:" + docType}
+	case LockModeWarehouse:
+		warehouses, err := e.postingWarehouses(ctx, doc, set)
+		if err != nil {
+			return err
+		}
+		for _, w := range warehouses {
+			keys = append(keys, prefix+"warehouse:"+w.String())
+		}
+		slices.Sort(keys)
+	default:
+		return nil
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	if err := e.partitionLocker.LockPartitions(ctx, keys); err != nil {
+		return fmt.Errorf("lock posting partitions: %w", err)
+	}
+	return nil
+}
+
+// postingWarehouses returns the warehouses whose balances the posting
+// changes: those of the new movements and, for a posted document, those of
+// its stored movements.
+func (e *Engine) postingWarehouses(ctx context.Context, doc Postable, set *MovementSet) ([]id.ID, error) {
+	var warehouses []id.ID
+	add := func(w id.ID) {
+		if !id.IsNil(w) && !slices.Contains(warehouses, w) {
+			warehouses = append(warehouses, w)
+		}
+	}
+	if set != nil {
+		for _, m := range set.StockMovements {
+			add(m.WarehouseID)
+		}
+		for _, m := range set.CostMovements {
+			add(m.WarehouseID)
+		}
+	}
+	if doc.IsPosted() {
+		for _, rec := range e.recorders {
+			p, ok := rec.(WarehousePartitioner)
+			if !ok {
+				continue
+			}
+			posted, err := p.PostedWarehouses(ctx, doc.GetID())
+			if err != nil {
+				return nil, fmt.Errorf("%s posted warehouses: %w", rec.Name(), err)
+			}
+			for _, w := range posted {
+				add(w)
+			}
+		}
+	}
+	return warehouses, nil
+}
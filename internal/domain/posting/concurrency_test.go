@@ -0,0 +1,119 @@
+package posting
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/settings"
+)
+
+// lockModesStore holds a single tenant value of posting.lockModes.
+type lockModesStore struct{ modes map[string]string }
+
+func (s *lockModesStore) ListValues(context.Context) ([]settings.Value, error) {
+	raw, _ := json.Marshal(s.modes)
+	return []settings.Value{{Key: settings.KeyPostingLockModes.Name(), Scope: settings.ScopeTenant, Value: raw}}, nil
+}
+
+func (s *lockModesStore) SetValue(_ context.Context, v settings.Value) (settings.Value, error) {
+	return v, nil
+}
+
+func (s *lockModesStore) DeleteValue(context.Context, string, settings.Scope, id.ID) error { return nil }
+
+type recordingLocker struct{ keys [][]string }
+
+func (l *recordingLocker) LockDocument(context.Context, string, id.ID) error { return nil }
+
+func (l *recordingLocker) LockPartitions(_ context.Context, keys []string) error {
+	l.keys = append(l.keys, keys)
+	return nil
+}
+
+// warehouseDoc moves stock in the given warehouses.
+type warehouseDoc struct {
+	dryRunDoc
+	warehouses []id.ID
+}
+
+func (d *warehouseDoc) GenerateStockMovements(ctx context.Context) ([]entity.StockMovement, error) {
+	var movements []entity.StockMovement
+	for _, w := range d.warehouses {
+		movements = append(movements, entity.StockMovement{
+			MovementBase: entity.NewMovementBase(d.id, "TestDoc", 2, time.Now(), entity.RecordTypeReceipt),
+			WarehouseID:  w,
+		})
+	}
+	return movements, nil
+}
+
+// postedWarehouses reports the warehouses of the stored movements.
+type postedWarehouses struct {
+	fakeRecorder
+	warehouses []id.ID
+}
+
+func (r *postedWarehouses) PostedWarehouses(context.Context, id.ID) ([]id.ID, error) {
+	return r.warehouses, nil
+}
+
+func lockModesContext(modes map[string]string) context.Context {
+	ctx := tenant.WithTenant(context.Background(), &tenant.Tenant{ID: "t1"})
+	ctx = tenant.WithTxManager(ctx, &rollbackTxManager{})
+	return settings.WithResolver(ctx, settings.NewResolver(&lockModesStore{modes: modes}))
+}
+
+func TestPostLockModes(t *testing.T) {
+	w1, w2, w3 := id.New(), id.New(), id.New()
+	noop := func(context.Context) error { return nil }
+
+	tests := []struct {
+		name  string
+		mode  string
+		want  []string
+		calls int
+	}{
+		{name: "document", mode: LockModeDocument, calls: 0},
+		{name: "sequential", mode: LockModeSequential, want: []string{"t1:posting:TestDoc"}, calls: 1},
+		{name: "warehouse", mode: LockModeWarehouse, calls: 1,
+			want: slices.Sorted(slices.Values([]string{"t1:warehouse:" + w1.String(), "t1:warehouse:" + w2.String(), "t1:warehouse:" + w3.String()}))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locker := &recordingLocker{}
+			rec := &postedWarehouses{warehouses: []id.ID{w3, w1}}
+			engine := NewEngine(locker, rec)
+			doc := &warehouseDoc{dryRunDoc: dryRunDoc{id: id.New(), posted: true}, warehouses: []id.ID{w2, w1}}
+
+			if err := engine.Post(lockModesContext(map[string]string{"TestDoc": tt.mode}), doc, noop); err != nil {
+				t.Fatalf("Post: %v", err)
+			}
+			if len(locker.keys) != tt.calls {
+				t.Fatalf("LockPartitions calls = %d, want %d", len(locker.keys), tt.calls)
+			}
+			if tt.calls > 0 && !slices.Equal(locker.keys[0], tt.want) {
+				t.Fatalf("locked %v, want %v", locker.keys[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestUnpostLocksPostedWarehouses(t *testing.T) {
+	w1 := id.New()
+	locker := &recordingLocker{}
+	engine := NewEngine(locker, &postedWarehouses{warehouses: []id.ID{w1}})
+	doc := &warehouseDoc{dryRunDoc: dryRunDoc{id: id.New(), posted: true}}
+
+	if err := engine.Unpost(lockModesContext(map[string]string{"TestDoc": LockModeWarehouse}), doc, func(context.Context) error { return nil }); err != nil {
+		t.Fatalf("Unpost: %v", err)
+	}
+	if len(locker.keys) != 1 || !slices.Equal(locker.keys[0], []string{"t1:warehouse:" + w1.String()}) {
+		t.Fatalf("locked %v, want the posted warehouse", locker.keys)
+	}
+}
@@ -75,3 +75,5 @@ func (r *CryptoFeeRecorder) RecordFromSet(ctx context.Context, set *MovementSet)
 func (r *CryptoFeeRecorder) ReverseMovements(ctx context.Context, recorderID id.ID, beforeVersion int) error {
 	return r.service.ReverseMovements(ctx, recorderID, beforeVersion)
 }
+
+func (r *CryptoFeeRecorder) MovementProvider() entity.MovementProvider { return r.service }
@@ -77,6 +77,8 @@ func (r *CryptoMerchantBalanceRecorder) ReverseMovements(ctx context.Context, re
 	return r.service.ReverseMovements(ctx, recorderID, beforeVersion)
 }
 
+func (r *CryptoMerchantBalanceRecorder) MovementProvider() entity.MovementProvider { return r.service }
+
 // ValidateBeforePost implements PostingValidator — checks merchant balance availability
 // for expense movements with pessimistic locking (FOR UPDATE + resource ordering).
 // Analogous to StockRecorder.ValidateBeforePost.
@@ -75,3 +75,5 @@ func (r *CryptoBalanceRecorder) RecordFromSet(ctx context.Context, set *Movement
 func (r *CryptoBalanceRecorder) ReverseMovements(ctx context.Context, recorderID id.ID, beforeVersion int) error {
 	return r.service.ReverseMovements(ctx, recorderID, beforeVersion)
 }
+
+func (r *CryptoBalanceRecorder) MovementProvider() entity.MovementProvider { return r.service }
@@ -0,0 +1,83 @@
+package posting
+
+import (
+	"context"
+	"fmt"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/registers/customer_order"
+)
+
+// ---------------------------------------------------------------------------
+// Sales order register — Visitor + Recorder
+// ---------------------------------------------------------------------------
+
+// SalesOrderMovementSource is implemented by documents that generate
+// sales order movements (SalesOrder orders, GoodsIssue ships).
+type SalesOrderMovementSource interface {
+	GenerateSalesOrderMovements(ctx context.Context) ([]entity.SalesOrderMovement, error)
+}
+
+const _salesOrderExtKey = "sales_order"
+
+// SalesOrderVisitor collects sales order movements from documents
+// that implement SalesOrderMovementSource.
+type SalesOrderVisitor struct{}
+
+// Name implements RegisterVisitor.
+func (v *SalesOrderVisitor) Name() string { return _salesOrderExtKey }
+
+// CollectMovements implements RegisterVisitor.
+func (v *SalesOrderVisitor) CollectMovements(ctx context.Context, doc Postable, set *MovementSet) error {
+	src, ok := doc.(SalesOrderMovementSource)
+	if !ok {
+		return nil
+	}
+
+	movements, err := src.GenerateSalesOrderMovements(ctx)
+	if err != nil {
+		return fmt.Errorf("generate sales order movements: %w", err)
+	}
+
+	if len(movements) > 0 {
+		set.SetExtension(_salesOrderExtKey, movements)
+	}
+	return nil
+}
+
+// salesOrderMovements returns the sales order movements collected into the set.
+func salesOrderMovements(set *MovementSet) []entity.SalesOrderMovement {
+	raw, ok := set.GetExtension(_salesOrderExtKey)
+	if !ok {
+		return nil
+	}
+	movements, _ := raw.([]entity.SalesOrderMovement)
+	return movements
+}
+
+// SalesOrderRecorder adapts customer_order.Service into a RegisterRecorder.
+type SalesOrderRecorder struct {
+	service *customer_order.Service
+}
+
+// NewSalesOrderRecorder creates a new SalesOrderRecorder.
+func NewSalesOrderRecorder(s *customer_order.Service) *SalesOrderRecorder {
+	return &SalesOrderRecorder{service: s}
+}
+
+func (r *SalesOrderRecorder) Name() string { return _salesOrderExtKey }
+
+func (r *SalesOrderRecorder) RecordFromSet(ctx context.Context, set *MovementSet) error {
+	movements := salesOrderMovements(set)
+	if len(movements) == 0 {
+		return nil
+	}
+	return r.service.RecordMovements(ctx, movements)
+}
+
+func (r *SalesOrderRecorder) ReverseMovements(ctx context.Context, recorderID id.ID, beforeVersion int) error {
+	return r.service.ReverseMovements(ctx, recorderID, beforeVersion)
+}
+
+func (r *SalesOrderRecorder) MovementProvider() entity.MovementProvider { return r.service }
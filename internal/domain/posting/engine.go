@@ -4,7 +4,10 @@ package posting
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"metapus/internal/core/apperror"
 	"metapus/internal/core/entity"
@@ -138,6 +141,22 @@ type Engine struct {
 	// Hooks for extensibility
 	beforePost []PostHook
 	afterPost  []PostHook
+
+	periodGuard PeriodGuard // optional; nil = no closed periods
+
+	partitionLocker PartitionLocker // optional; nil = lock modes disabled
+}
+
+// PeriodGuard rejects changes to documents dated in a closed accounting
+// period (implemented by period.Service).
+type PeriodGuard interface {
+	CheckPeriod(ctx context.Context, organizationID id.ID, dates ...time.Time) error
+}
+
+// Dated is implemented by documents checked against closed periods.
+type Dated interface {
+	GetDate() time.Time
+	GetOrganizationID() id.ID
 }
 
 // PostHook is called during the posting lifecycle.
@@ -167,6 +186,9 @@ func NewEngine(docLocker DocumentLocker, recorders ...RegisterRecorder) *Engine
 		recorders: recorders,
 		docLocker: docLocker,
 	}
+	if pl, ok := docLocker.(PartitionLocker); ok {
+		e.partitionLocker = pl
+	}
 	// Register built-in visitors
 	e.visitors = append(e.visitors, &StockVisitor{}, &CostVisitor{}, &SettlementVisitor{})
 	return e
@@ -184,6 +206,43 @@ func (e *Engine) AddRecorder(r RegisterRecorder) {
 	e.recorders = append(e.recorders, r)
 }
 
+// MovementProviders returns the movement providers of all recorders that
+// implement MovementSource, in registration order.
+func (e *Engine) MovementProviders() []entity.MovementProvider {
+	providers := make([]entity.MovementProvider, 0, len(e.recorders))
+	for _, rec := range e.recorders {
+		if src, ok := rec.(MovementSource); ok {
+			providers = append(providers, src.MovementProvider())
+		}
+	}
+	return providers
+}
+
+// SetPeriodGuard enables closed period checks: posting and unposting a
+// document dated in a closed period fails.
+func (e *Engine) SetPeriodGuard(guard PeriodGuard) {
+	e.periodGuard = guard
+}
+
+// CheckPeriod rejects changes to docs dated in a closed period. Documents
+// that are not Dated pass. Document services call it before saving or
+// deleting, with the stored and the changed document.
+func (e *Engine) CheckPeriod(ctx context.Context, docs ...Postable) error {
+	if e == nil || e.periodGuard == nil {
+		return nil
+	}
+	for _, doc := range docs {
+		dated, ok := doc.(Dated)
+		if !ok {
+			continue
+		}
+		if err := e.periodGuard.CheckPeriod(ctx, dated.GetOrganizationID(), dated.GetDate()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // OnBeforePost registers a hook to run BEFORE the posting transaction.
 // Suitable for: validation, permission checks, fail-fast logic.
 // NOT suitable for: database writes (won't be in the same transaction).
@@ -207,7 +266,10 @@ func (e *Engine) OnAfterPost(hook PostHook) {
 // 5. Updates document posted state
 //
 // If the document is already posted, it will be re-posted (like 1C behavior).
-func (e *Engine) Post(ctx context.Context, doc Postable, updateDoc func(context.Context) error) error {
+func (e *Engine) Post(ctx context.Context, doc Postable, updateDoc func(context.Context) error) (err error) {
+	ctx, span := startSpan(ctx, "posting.Post", doc)
+	defer func() { endSpan(span, err) }()
+
 	return e.doPost(ctx, doc, updateDoc)
 }
 
@@ -221,6 +283,9 @@ func (e *Engine) doPost(ctx context.Context, doc Postable, updateDoc func(contex
 	if err := doc.CanPost(ctx); err != nil {
 		return fmt.Errorf("cannot post: %w", err)
 	}
+	if err := e.CheckPeriod(ctx, doc); err != nil {
+		return err
+	}
 
 	// Run before-post hooks
 	for _, hook := range e.beforePost {
@@ -242,6 +307,19 @@ func (e *Engine) doPost(ctx context.Context, doc Postable, updateDoc func(contex
 			}
 		}
 
+		// Collect movements via registered visitors (Visitor pattern).
+		// Visitors only read the document, so the movements are known
+		// before the balances are touched.
+		movements, err := e.collectMovements(ctx, doc)
+		if err != nil {
+			return fmt.Errorf("collect movements: %w", err)
+		}
+
+		// Serialize with concurrent postings per the lock mode of the type
+		if err := e.lockPartitions(ctx, doc, movements); err != nil {
+			return err
+		}
+
 		// If re-posting, reverse old movements first
 		if isRepost {
 			oldVersion := doc.GetPostedVersion()
@@ -250,12 +328,6 @@ func (e *Engine) doPost(ctx context.Context, doc Postable, updateDoc func(contex
 			}
 		}
 
-		// Collect movements via registered visitors (Visitor pattern)
-		movements, err := e.collectMovements(ctx, doc)
-		if err != nil {
-			return fmt.Errorf("collect movements: %w", err)
-		}
-
 		if movements.IsEmpty() {
 			logger.Warn(ctx, "document generated no movements",
 				"document_id", doc.GetID(),
@@ -312,8 +384,101 @@ func (e *Engine) doPost(ctx context.Context, doc Postable, updateDoc func(contex
 	return nil
 }
 
+// DryRunResult is the outcome of Engine.DryRun.
+type DryRunResult struct {
+	// Movements are the register movements the document would record.
+	// Nil when they could not be generated.
+	Movements *MovementSet
+
+	// Errors are the validation failures that would reject the posting
+	// (CanPost, before-post hooks, register validators such as stock
+	// availability). Empty means Post would succeed.
+	Errors []error
+}
+
+// errDryRunRollback aborts the dry-run transaction.
+var errDryRunRollback = errors.New("posting dry-run: rollback")
+
+// DryRun runs the posting pipeline without recording anything: it validates
+// the document, generates its movements and runs register validators, then
+// rolls back. For a posted document the old movements are reversed inside
+// the rolled-back transaction so availability checks see the same balances
+// a real re-post would. Validation failures are collected in the result;
+// only infrastructure failures are returned as error.
+//
+// Must not be called inside an open transaction: the rollback relies on
+// DryRun owning the transaction.
+func (e *Engine) DryRun(ctx context.Context, doc Postable) (_ *DryRunResult, err error) {
+	ctx, span := startSpan(ctx, "posting.DryRun", doc)
+	defer func() { endSpan(span, err) }()
+
+	result := &DryRunResult{}
+
+	if err := doc.CanPost(ctx); err != nil {
+		result.Errors = append(result.Errors, err)
+	}
+	for _, hook := range e.beforePost {
+		if err := hook(ctx, doc); err != nil {
+			result.Errors = append(result.Errors, err)
+		}
+	}
+
+	txm, err := tenant.GetTxManager(ctx)
+	if err != nil {
+		return nil, apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		if doc.IsPosted() {
+			if err := e.reverseAllMovements(ctx, doc.GetID(), doc.GetPostedVersion()+1); err != nil {
+				return fmt.Errorf("reverse old movements: %w", err)
+			}
+		}
+
+		movements, err := e.collectMovements(ctx, doc)
+		if err != nil {
+			if isValidationError(err) {
+				result.Errors = append(result.Errors, err)
+				return errDryRunRollback
+			}
+			return fmt.Errorf("collect movements: %w", err)
+		}
+		result.Movements = movements
+
+		for _, rec := range e.recorders {
+			if validator, ok := rec.(PostingValidator); ok {
+				if err := validator.ValidateBeforePost(ctx, movements); err != nil {
+					if !isValidationError(err) {
+						return err
+					}
+					result.Errors = append(result.Errors, err)
+				}
+			}
+		}
+		return errDryRunRollback
+	})
+	if err != nil && !errors.Is(err, errDryRunRollback) {
+		return nil, err
+	}
+	return result, nil
+}
+
+// isValidationError reports whether err is a client-side (4xx) AppError,
+// as opposed to an infrastructure failure.
+func isValidationError(err error) bool {
+	appErr, ok := apperror.AsAppError(err)
+	return ok && appErr.HTTPStatus >= http.StatusBadRequest && appErr.HTTPStatus < http.StatusInternalServerError
+}
+
 // Unpost reverses document movements from registers.
-func (e *Engine) Unpost(ctx context.Context, doc Postable, updateDoc func(context.Context) error) error {
+func (e *Engine) Unpost(ctx context.Context, doc Postable, updateDoc func(context.Context) error) (err error) {
+	ctx, span := startSpan(ctx, "posting.Unpost", doc)
+	defer func() { endSpan(span, err) }()
+
+	return e.doUnpost(ctx, doc, updateDoc)
+}
+
+// doUnpost is the unposting implementation.
+func (e *Engine) doUnpost(ctx context.Context, doc Postable, updateDoc func(context.Context) error) error {
 	if !doc.IsPosted() {
 		// Idempotent no-op: document is already unposted — nothing to do.
 		// Analogous to 1C behavior: "Отменить проведение" on an unposted document is a silent success.
@@ -322,6 +487,9 @@ func (e *Engine) Unpost(ctx context.Context, doc Postable, updateDoc func(contex
 			"document_type", doc.GetDocumentType())
 		return nil
 	}
+	if err := e.CheckPeriod(ctx, doc); err != nil {
+		return err
+	}
 
 	txm, err := tenant.GetTxManager(ctx)
 	if err != nil {
@@ -335,6 +503,10 @@ func (e *Engine) Unpost(ctx context.Context, doc Postable, updateDoc func(contex
 			}
 		}
 
+		if err := e.lockPartitions(ctx, doc, nil); err != nil {
+			return err
+		}
+
 		// Delete movements for this document version across all registers
 		if err := e.reverseAllMovements(ctx, doc.GetID(), doc.GetPostedVersion()+1); err != nil {
 			return fmt.Errorf("reverse movements: %w", err)
@@ -0,0 +1,110 @@
+package posting
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/registers/stock"
+)
+
+// rollbackTxManager runs fn and records whether it asked for a rollback.
+type rollbackTxManager struct{ rolledBack bool }
+
+func (m *rollbackTxManager) RunInTransaction(ctx context.Context, fn func(context.Context) error) error {
+	err := fn(ctx)
+	m.rolledBack = err != nil
+	return err
+}
+
+type dryRunDoc struct {
+	id      id.ID
+	posted  bool
+	canPost error
+}
+
+func (d *dryRunDoc) GetID() id.ID                      { return d.id }
+func (d *dryRunDoc) GetDocumentType() string           { return "TestDoc" }
+func (d *dryRunDoc) GetPostedVersion() int             { return 1 }
+func (d *dryRunDoc) IsPosted() bool                    { return d.posted }
+func (d *dryRunDoc) CanPost(ctx context.Context) error { return d.canPost }
+func (d *dryRunDoc) MarkPosted()                       { d.posted = true }
+func (d *dryRunDoc) MarkUnposted()                     { d.posted = false }
+
+func (d *dryRunDoc) GenerateStockMovements(ctx context.Context) ([]entity.StockMovement, error) {
+	return []entity.StockMovement{{
+		MovementBase: entity.NewMovementBase(d.id, "TestDoc", 2, time.Now(), entity.RecordTypeExpense),
+	}}, nil
+}
+
+// fakeRecorder counts writes and fails validation with validateErr.
+type fakeRecorder struct {
+	recorded, reversed int
+	validateErr        error
+}
+
+func (r *fakeRecorder) Name() string { return "fake" }
+func (r *fakeRecorder) RecordFromSet(ctx context.Context, set *MovementSet) error {
+	r.recorded++
+	return nil
+}
+func (r *fakeRecorder) ReverseMovements(ctx context.Context, recorderID id.ID, beforeVersion int) error {
+	r.reversed++
+	return nil
+}
+func (r *fakeRecorder) ValidateBeforePost(ctx context.Context, set *MovementSet) error {
+	return r.validateErr
+}
+
+func TestDryRunCollectsMovementsAndErrorsWithoutRecording(t *testing.T) {
+	txm := &rollbackTxManager{}
+	ctx := tenant.WithTxManager(context.Background(), txm)
+	rec := &fakeRecorder{validateErr: apperror.NewInsufficientStock("n1", 5, 2)}
+	engine := NewEngine(nil, rec)
+	doc := &dryRunDoc{id: id.New(), posted: true, canPost: apperror.NewValidation("warehouse is required")}
+
+	result, err := engine.DryRun(ctx, doc)
+	if err != nil {
+		t.Fatalf("DryRun error = %v", err)
+	}
+	if len(result.Movements.StockMovements) != 1 {
+		t.Fatalf("stock movements = %d, want 1", len(result.Movements.StockMovements))
+	}
+	if len(result.Errors) != 2 {
+		t.Fatalf("errors = %v, want CanPost and stock validation", result.Errors)
+	}
+	if rec.recorded != 0 {
+		t.Fatalf("RecordFromSet called %d times during dry run", rec.recorded)
+	}
+	if rec.reversed != 1 || !txm.rolledBack {
+		t.Fatalf("reposted doc: reversed=%d rolledBack=%v, want reversal inside a rolled-back tx", rec.reversed, txm.rolledBack)
+	}
+	if !doc.posted {
+		t.Fatal("DryRun changed the document posted state")
+	}
+}
+
+func TestDryRunReturnsInfrastructureErrors(t *testing.T) {
+	ctx := tenant.WithTxManager(context.Background(), &rollbackTxManager{})
+	dbErr := errors.New("connection reset")
+	engine := NewEngine(nil, &fakeRecorder{validateErr: dbErr})
+
+	if _, err := engine.DryRun(ctx, &dryRunDoc{id: id.New()}); !errors.Is(err, dbErr) {
+		t.Fatalf("DryRun error = %v, want %v", err, dbErr)
+	}
+}
+
+func TestMovementProvidersFromRecorders(t *testing.T) {
+	stockSvc := stock.NewService(nil)
+	engine := NewEngine(nil, NewStockRecorder(stockSvc), &fakeRecorder{})
+
+	providers := engine.MovementProviders()
+	if len(providers) != 1 || providers[0] != entity.MovementProvider(stockSvc) {
+		t.Fatalf("MovementProviders = %v, want only the stock service", providers)
+	}
+}
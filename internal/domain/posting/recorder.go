@@ -10,9 +10,11 @@ import (
 
 	"metapus/internal/core/entity"
 	"metapus/internal/core/id"
+	"metapus/internal/core/types"
 	"metapus/internal/domain/registers/cost"
 	"metapus/internal/domain/registers/settlement"
 	"metapus/internal/domain/registers/stock"
+	"metapus/internal/domain/settings"
 )
 
 // RegisterRecorder handles recording and reversal of movements for one register type.
@@ -43,6 +45,14 @@ type PostingValidator interface {
 	ValidateBeforePost(ctx context.Context, set *MovementSet) error
 }
 
+// MovementSource is an optional interface for recorders whose register can
+// list the movements a document produced (the document "Movements" view).
+// Engine.MovementProviders collects them so a new register shows up in the
+// view as soon as its recorder is added to the engine.
+type MovementSource interface {
+	MovementProvider() entity.MovementProvider
+}
+
 // ---------------------------------------------------------------------------
 // Built-in recorders (adapters over existing register services)
 // ---------------------------------------------------------------------------
@@ -71,10 +81,24 @@ func (r *StockRecorder) ReverseMovements(ctx context.Context, recorderID id.ID,
 	return r.service.ReverseMovements(ctx, recorderID, beforeVersion)
 }
 
-// ValidateBeforePost implements PostingValidator — checks stock availability
-// for expense movements with resource ordering to prevent deadlocks.
+func (r *StockRecorder) MovementProvider() entity.MovementProvider { return r.service }
+
+// PostedWarehouses implements WarehousePartitioner.
+func (r *StockRecorder) PostedWarehouses(ctx context.Context, recorderID id.ID) ([]id.ID, error) {
+	return r.service.PostedWarehouses(ctx, recorderID)
+}
+
+// ValidateBeforePost implements PostingValidator — checks the inventory freeze
+// and stock availability for expense movements with resource ordering to prevent deadlocks.
 func (r *StockRecorder) ValidateBeforePost(ctx context.Context, set *MovementSet) error {
-	return validateStockAvailability(r.service, ctx, set.StockMovements)
+	if err := r.service.CheckFreeze(ctx, set.StockMovements); err != nil {
+		return err
+	}
+	opts := stock.AvailabilityOptions{
+		ConsiderReservations: settings.Get(ctx, settings.KeyStockRespectReservations, settings.Subject{}),
+		ReservedFor:          releasedOrderID(set),
+	}
+	return validateStockAvailability(r.service, ctx, set.StockMovements, opts)
 }
 
 // CostRecorder adapts cost.Service into a RegisterRecorder.
@@ -100,6 +124,8 @@ func (r *CostRecorder) ReverseMovements(ctx context.Context, recorderID id.ID, b
 	return r.service.ReverseMovements(ctx, recorderID, beforeVersion)
 }
 
+func (r *CostRecorder) MovementProvider() entity.MovementProvider { return r.service }
+
 // SettlementRecorder adapts settlement.Service into a RegisterRecorder.
 type SettlementRecorder struct {
 	service *settlement.Service
@@ -123,6 +149,8 @@ func (r *SettlementRecorder) ReverseMovements(ctx context.Context, recorderID id
 	return r.service.ReverseMovements(ctx, recorderID, beforeVersion)
 }
 
+func (r *SettlementRecorder) MovementProvider() entity.MovementProvider { return r.service }
+
 // DefaultRecorders returns the built-in register recorders for stock, cost, and settlement.
 // Use this when constructing the default Engine:
 //
@@ -147,16 +175,20 @@ type stockDimKey struct {
 }
 
 // validateStockAvailability checks if there's enough stock for expense movements.
+// Receipts of the same document into the same warehouse offset its expenses
+// (e.g., a transfer passing through a transit warehouse).
 // Extracted as a package-level function used by StockRecorder.
-func validateStockAvailability(stockService *stock.Service, ctx context.Context, movements []entity.StockMovement) error {
+func validateStockAvailability(stockService *stock.Service, ctx context.Context, movements []entity.StockMovement, opts stock.AvailabilityOptions) error {
 	reserves := make(map[stockDimKey]*stock.StockReservation)
+	received := make(map[stockDimKey]types.Quantity)
 
 	for _, m := range movements {
+		key := stockDimKey{m.WarehouseID, m.NomenclatureID}
 		if m.RecordType != entity.RecordTypeExpense {
+			received[key] += m.Quantity
 			continue
 		}
 
-		key := stockDimKey{m.WarehouseID, m.NomenclatureID}
 		if existing, ok := reserves[key]; ok {
 			existing.RequiredQty += m.Quantity
 		} else {
@@ -168,6 +200,17 @@ func validateStockAvailability(stockService *stock.Service, ctx context.Context,
 		}
 	}
 
+	for key, qty := range received {
+		r, ok := reserves[key]
+		if !ok {
+			continue
+		}
+		r.RequiredQty -= qty
+		if r.RequiredQty <= 0 {
+			delete(reserves, key)
+		}
+	}
+
 	if len(reserves) == 0 {
 		return nil
 	}
@@ -186,5 +229,5 @@ func validateStockAvailability(stockService *stock.Service, ctx context.Context,
 		return bytes.Compare(items[i].NomenclatureID[:], items[j].NomenclatureID[:]) < 0
 	})
 
-	return stockService.CheckAndReserveStock(ctx, items)
+	return stockService.CheckAndReserveStock(ctx, items, opts)
 }
@@ -0,0 +1,83 @@
+package posting
+
+import (
+	"context"
+	"fmt"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/registers/serial_number"
+)
+
+// ---------------------------------------------------------------------------
+// Serial number register — Visitor + Recorder
+// ---------------------------------------------------------------------------
+
+// SerialNumberMovementSource is implemented by documents that generate
+// serial number movements (GoodsReceipt receives serials, GoodsIssue issues them).
+type SerialNumberMovementSource interface {
+	GenerateSerialNumberMovements(ctx context.Context) ([]entity.SerialNumberMovement, error)
+}
+
+const _serialNumberExtKey = "serial_number"
+
+// SerialNumberVisitor collects serial number movements from documents
+// that implement SerialNumberMovementSource.
+type SerialNumberVisitor struct{}
+
+// Name implements RegisterVisitor.
+func (v *SerialNumberVisitor) Name() string { return _serialNumberExtKey }
+
+// CollectMovements implements RegisterVisitor.
+func (v *SerialNumberVisitor) CollectMovements(ctx context.Context, doc Postable, set *MovementSet) error {
+	src, ok := doc.(SerialNumberMovementSource)
+	if !ok {
+		return nil
+	}
+
+	movements, err := src.GenerateSerialNumberMovements(ctx)
+	if err != nil {
+		return fmt.Errorf("generate serial number movements: %w", err)
+	}
+
+	if len(movements) > 0 {
+		set.SetExtension(_serialNumberExtKey, movements)
+	}
+	return nil
+}
+
+// serialNumberMovements returns the serial number movements collected into the set.
+func serialNumberMovements(set *MovementSet) []entity.SerialNumberMovement {
+	raw, ok := set.GetExtension(_serialNumberExtKey)
+	if !ok {
+		return nil
+	}
+	movements, _ := raw.([]entity.SerialNumberMovement)
+	return movements
+}
+
+// SerialNumberRecorder adapts serial_number.Service into a RegisterRecorder.
+type SerialNumberRecorder struct {
+	service *serial_number.Service
+}
+
+// NewSerialNumberRecorder creates a new SerialNumberRecorder.
+func NewSerialNumberRecorder(s *serial_number.Service) *SerialNumberRecorder {
+	return &SerialNumberRecorder{service: s}
+}
+
+func (r *SerialNumberRecorder) Name() string { return _serialNumberExtKey }
+
+func (r *SerialNumberRecorder) RecordFromSet(ctx context.Context, set *MovementSet) error {
+	movements := serialNumberMovements(set)
+	if len(movements) == 0 {
+		return nil
+	}
+	return r.service.RecordMovements(ctx, movements)
+}
+
+func (r *SerialNumberRecorder) ReverseMovements(ctx context.Context, recorderID id.ID, beforeVersion int) error {
+	return r.service.ReverseMovements(ctx, recorderID, beforeVersion)
+}
+
+func (r *SerialNumberRecorder) MovementProvider() entity.MovementProvider { return r.service }
@@ -0,0 +1,53 @@
+package posting
+
+import (
+	"context"
+
+	"metapus/internal/core/events"
+	"metapus/internal/core/id"
+)
+
+// StockEventRecorder publishes the stock movements of a posting as a
+// StockMoved domain event. It runs inside the posting transaction, so the
+// event is written to the outbox only if the posting commits. It records
+// nothing itself; add it after the stock recorder.
+type StockEventRecorder struct {
+	publisher events.Publisher
+}
+
+// NewStockEventRecorder creates a StockEventRecorder. A nil publisher
+// disables the events.
+func NewStockEventRecorder(publisher events.Publisher) *StockEventRecorder {
+	return &StockEventRecorder{publisher: publisher}
+}
+
+func (r *StockEventRecorder) Name() string { return "stock_events" }
+
+func (r *StockEventRecorder) RecordFromSet(ctx context.Context, set *MovementSet) error {
+	if len(set.StockMovements) == 0 {
+		return nil
+	}
+	first := set.StockMovements[0]
+	data := events.StockMovementEvent{
+		DocumentType: first.RecorderType,
+		DocumentID:   first.RecorderID,
+		Version:      first.RecorderVersion,
+		Movements:    make([]events.StockMovementLine, 0, len(set.StockMovements)),
+	}
+	for _, m := range set.StockMovements {
+		data.Movements = append(data.Movements, events.StockMovementLine{
+			Period:         m.Period,
+			RecordType:     string(m.RecordType),
+			WarehouseID:    m.WarehouseID,
+			NomenclatureID: m.NomenclatureID,
+			Quantity:       m.Quantity,
+		})
+	}
+	return events.StockMoved.Publish(ctx, r.publisher, events.AggregateDocument, first.RecorderID, data)
+}
+
+// ReverseMovements is a no-op: a reposting publishes the new movement set,
+// and unposting is published as DocumentUnposted.
+func (r *StockEventRecorder) ReverseMovements(context.Context, id.ID, int) error {
+	return nil
+}
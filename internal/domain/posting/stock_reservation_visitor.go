@@ -0,0 +1,94 @@
+package posting
+
+import (
+	"context"
+	"fmt"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/registers/stock_reservation"
+)
+
+// ---------------------------------------------------------------------------
+// Stock Reservation register — Visitor + Recorder
+// ---------------------------------------------------------------------------
+
+// StockReservationMovementSource is implemented by documents that generate
+// stock reservation movements (SalesOrder reserves, GoodsIssue releases).
+type StockReservationMovementSource interface {
+	GenerateStockReservationMovements(ctx context.Context) ([]entity.StockReservationMovement, error)
+}
+
+const _stockReservationExtKey = "stock_reservation"
+
+// StockReservationVisitor collects reservation movements from documents
+// that implement StockReservationMovementSource.
+type StockReservationVisitor struct{}
+
+// Name implements RegisterVisitor.
+func (v *StockReservationVisitor) Name() string { return _stockReservationExtKey }
+
+// CollectMovements implements RegisterVisitor.
+func (v *StockReservationVisitor) CollectMovements(ctx context.Context, doc Postable, set *MovementSet) error {
+	src, ok := doc.(StockReservationMovementSource)
+	if !ok {
+		return nil
+	}
+
+	movements, err := src.GenerateStockReservationMovements(ctx)
+	if err != nil {
+		return fmt.Errorf("generate stock reservation movements: %w", err)
+	}
+
+	if len(movements) > 0 {
+		set.SetExtension(_stockReservationExtKey, movements)
+	}
+	return nil
+}
+
+// stockReservationMovements returns the reservation movements collected into the set.
+func stockReservationMovements(set *MovementSet) []entity.StockReservationMovement {
+	raw, ok := set.GetExtension(_stockReservationExtKey)
+	if !ok {
+		return nil
+	}
+	movements, _ := raw.([]entity.StockReservationMovement)
+	return movements
+}
+
+// releasedOrderID returns the order whose reservation the set releases, if any.
+// Its own reservation must not block the shipment of that order.
+func releasedOrderID(set *MovementSet) id.ID {
+	for _, m := range stockReservationMovements(set) {
+		if m.RecordType == entity.RecordTypeExpense {
+			return m.OrderID
+		}
+	}
+	return id.ID{}
+}
+
+// StockReservationRecorder adapts stock_reservation.Service into a RegisterRecorder.
+type StockReservationRecorder struct {
+	service *stock_reservation.Service
+}
+
+// NewStockReservationRecorder creates a new StockReservationRecorder.
+func NewStockReservationRecorder(s *stock_reservation.Service) *StockReservationRecorder {
+	return &StockReservationRecorder{service: s}
+}
+
+func (r *StockReservationRecorder) Name() string { return _stockReservationExtKey }
+
+func (r *StockReservationRecorder) RecordFromSet(ctx context.Context, set *MovementSet) error {
+	movements := stockReservationMovements(set)
+	if len(movements) == 0 {
+		return nil
+	}
+	return r.service.RecordMovements(ctx, movements)
+}
+
+func (r *StockReservationRecorder) ReverseMovements(ctx context.Context, recorderID id.ID, beforeVersion int) error {
+	return r.service.ReverseMovements(ctx, recorderID, beforeVersion)
+}
+
+func (r *StockReservationRecorder) MovementProvider() entity.MovementProvider { return r.service }
@@ -0,0 +1,83 @@
+package posting
+
+import (
+	"context"
+	"fmt"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/registers/supplier_order"
+)
+
+// ---------------------------------------------------------------------------
+// Purchase order register — Visitor + Recorder
+// ---------------------------------------------------------------------------
+
+// PurchaseOrderMovementSource is implemented by documents that generate
+// purchase order movements (PurchaseOrder orders, GoodsReceipt receives).
+type PurchaseOrderMovementSource interface {
+	GeneratePurchaseOrderMovements(ctx context.Context) ([]entity.PurchaseOrderMovement, error)
+}
+
+const _purchaseOrderExtKey = "purchase_order"
+
+// PurchaseOrderVisitor collects purchase order movements from documents
+// that implement PurchaseOrderMovementSource.
+type PurchaseOrderVisitor struct{}
+
+// Name implements RegisterVisitor.
+func (v *PurchaseOrderVisitor) Name() string { return _purchaseOrderExtKey }
+
+// CollectMovements implements RegisterVisitor.
+func (v *PurchaseOrderVisitor) CollectMovements(ctx context.Context, doc Postable, set *MovementSet) error {
+	src, ok := doc.(PurchaseOrderMovementSource)
+	if !ok {
+		return nil
+	}
+
+	movements, err := src.GeneratePurchaseOrderMovements(ctx)
+	if err != nil {
+		return fmt.Errorf("generate purchase order movements: %w", err)
+	}
+
+	if len(movements) > 0 {
+		set.SetExtension(_purchaseOrderExtKey, movements)
+	}
+	return nil
+}
+
+// purchaseOrderMovements returns the purchase order movements collected into the set.
+func purchaseOrderMovements(set *MovementSet) []entity.PurchaseOrderMovement {
+	raw, ok := set.GetExtension(_purchaseOrderExtKey)
+	if !ok {
+		return nil
+	}
+	movements, _ := raw.([]entity.PurchaseOrderMovement)
+	return movements
+}
+
+// PurchaseOrderRecorder adapts supplier_order.Service into a RegisterRecorder.
+type PurchaseOrderRecorder struct {
+	service *supplier_order.Service
+}
+
+// NewPurchaseOrderRecorder creates a new PurchaseOrderRecorder.
+func NewPurchaseOrderRecorder(s *supplier_order.Service) *PurchaseOrderRecorder {
+	return &PurchaseOrderRecorder{service: s}
+}
+
+func (r *PurchaseOrderRecorder) Name() string { return _purchaseOrderExtKey }
+
+func (r *PurchaseOrderRecorder) RecordFromSet(ctx context.Context, set *MovementSet) error {
+	movements := purchaseOrderMovements(set)
+	if len(movements) == 0 {
+		return nil
+	}
+	return r.service.RecordMovements(ctx, movements)
+}
+
+func (r *PurchaseOrderRecorder) ReverseMovements(ctx context.Context, recorderID id.ID, beforeVersion int) error {
+	return r.service.ReverseMovements(ctx, recorderID, beforeVersion)
+}
+
+func (r *PurchaseOrderRecorder) MovementProvider() entity.MovementProvider { return r.service }
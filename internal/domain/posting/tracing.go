@@ -0,0 +1,31 @@
+package posting
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("metapus/posting")
+
+// startSpan starts a span for a posting operation on doc.
+func startSpan(ctx context.Context, name string, doc Postable) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(
+		attribute.String("document.type", doc.GetDocumentType()),
+		attribute.String("document.id", doc.GetID().String()),
+		attribute.Bool("document.posted", doc.IsPosted()),
+		attribute.Int("document.posted_version", doc.GetPostedVersion()),
+	))
+}
+
+// endSpan records err on the span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
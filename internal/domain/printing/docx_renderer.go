@@ -83,6 +83,9 @@ func RenderDOCX(w io.Writer, data *PrintData) error {
 			run.Size(14)
 		}
 	}
+	if t.AmountInWords != "" {
+		doc.AddEmptyParagraph().AddText(t.AmountInWords).Bold(true)
+	}
 
 	doc.AddEmptyParagraph()
 	doc.AddEmptyParagraph()
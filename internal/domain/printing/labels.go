@@ -0,0 +1,66 @@
+package printing
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"image/png"
+	"io"
+
+	"metapus/pkg/barcode"
+)
+
+// Label sheet layout: labels of 60 x 40 mm in rows of labelColumns.
+const (
+	labelColumns = 3
+	// LabelsMax is the most labels rendered on one sheet.
+	LabelsMax = 300
+
+	labelWidthPx  = 227 // 60 mm at 96 dpi
+	labelHeightPx = 151 // 40 mm at 96 dpi
+)
+
+var labelTemplate = template.Must(template.ParseFS(templateFS, "templates/labels.gohtml"))
+
+// Label is one product label: name, article and barcode.
+type Label struct {
+	Name    string
+	Article string
+	Code    string
+	// Image is the barcode PNG as a data URI.
+	Image template.URL
+}
+
+// NewLabel creates a label with the barcode image of code.
+func NewLabel(name, article, code string) (Label, error) {
+	img, err := barcode.Image(code, 2, 60)
+	if err != nil {
+		return Label{}, fmt.Errorf("barcode %q: %w", code, err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return Label{}, fmt.Errorf("encode barcode image: %w", err)
+	}
+	return Label{
+		Name:    name,
+		Article: article,
+		Code:    code,
+		Image:   template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())),
+	}, nil
+}
+
+// RenderLabelsHTML renders the label sheet (A4, 60 x 40 mm labels).
+func RenderLabelsHTML(w io.Writer, labels []Label) error {
+	return labelTemplate.ExecuteTemplate(w, "labels.gohtml", labels)
+}
+
+// RenderLabelsPNG renders the label sheet to a PNG image sized to fit the labels.
+func RenderLabelsPNG(w io.Writer, labels []Label) error {
+	var html bytes.Buffer
+	if err := RenderLabelsHTML(&html, labels); err != nil {
+		return err
+	}
+	rows := (len(labels) + labelColumns - 1) / labelColumns
+	return RenderPNG(w, html.Bytes(), labelColumns*labelWidthPx, rows*labelHeightPx)
+}
@@ -15,26 +15,59 @@ import (
 // using the system-installed Chromium-based browser (Edge on Windows, Chrome/Chromium on Linux).
 // This produces pixel-perfect output identical to what the user sees in the browser.
 func RenderPDF(w io.Writer, htmlContent []byte) error {
-	browserPath, err := findBrowser()
+	pdfBytes, err := runBrowser(htmlContent, "output.pdf", func(outPath string) []string {
+		return []string{
+			"--print-to-pdf=" + outPath,
+			"--no-pdf-header-footer",
+			"--print-to-pdf-no-header",
+		}
+	})
 	if err != nil {
 		return err
 	}
+	_, err = w.Write(pdfBytes)
+	return err
+}
+
+// RenderPNG takes a screenshot of the rendered HTML (e.g. a label sheet) in
+// a browser window of width x height pixels.
+func RenderPNG(w io.Writer, htmlContent []byte, width, height int) error {
+	pngBytes, err := runBrowser(htmlContent, "output.png", func(outPath string) []string {
+		return []string{
+			"--screenshot=" + outPath,
+			fmt.Sprintf("--window-size=%d,%d", width, height),
+			"--hide-scrollbars",
+		}
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(pngBytes)
+	return err
+}
+
+// runBrowser writes the HTML to a temp file, runs the headless browser with
+// the output flags and returns the produced output file.
+func runBrowser(htmlContent []byte, outName string, outputFlags func(outPath string) []string) ([]byte, error) {
+	browserPath, err := findBrowser()
+	if err != nil {
+		return nil, err
+	}
 
 	// Write HTML to a temp file (headless Chrome needs a file:// URL)
 	tmpDir, err := os.MkdirTemp("", "metapus-pdf-*")
 	if err != nil {
-		return fmt.Errorf("create temp dir: %w", err)
+		return nil, fmt.Errorf("create temp dir: %w", err)
 	}
 	defer func() { _ = os.RemoveAll(tmpDir) }()
 
 	htmlPath := filepath.Join(tmpDir, "print.html")
 	if err := os.WriteFile(htmlPath, htmlContent, 0644); err != nil {
-		return fmt.Errorf("write HTML temp file: %w", err)
+		return nil, fmt.Errorf("write HTML temp file: %w", err)
 	}
 
-	pdfPath := filepath.Join(tmpDir, "output.pdf")
+	outPath := filepath.Join(tmpDir, outName)
 
-	// Run headless browser with --print-to-pdf
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -44,10 +77,8 @@ func RenderPDF(w io.Writer, htmlContent []byte) error {
 		"--disable-javascript",                  // Prevent script execution in user-controlled HTML
 		"--disable-software-rasterizer",
 		"--run-all-compositor-stages-before-draw",
-		"--print-to-pdf=" + pdfPath,
-		"--no-pdf-header-footer",
-		"--print-to-pdf-no-header",
 	}
+	args = append(args, outputFlags(outPath)...)
 	// --no-sandbox only when explicitly opted in (e.g., Docker root user).
 	// In production prefer running Chrome as non-root with seccomp profile.
 	if os.Getenv("CHROME_NO_SANDBOX") == "true" {
@@ -60,17 +91,15 @@ func RenderPDF(w io.Writer, htmlContent []byte) error {
 	cmd.Stdout = io.Discard
 
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("browser print-to-pdf failed: %w", err)
+		return nil, fmt.Errorf("browser %s failed: %w", outName, err)
 	}
 
-	// Read the generated PDF
-	pdfBytes, err := os.ReadFile(pdfPath)
+	// Read the generated file
+	out, err := os.ReadFile(outPath)
 	if err != nil {
-		return fmt.Errorf("read generated PDF: %w", err)
+		return nil, fmt.Errorf("read generated %s: %w", outName, err)
 	}
-
-	_, err = w.Write(pdfBytes)
-	return err
+	return out, nil
 }
 
 // findBrowser locates a Chromium-based browser on the system.
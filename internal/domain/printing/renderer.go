@@ -5,11 +5,9 @@ import (
 	"fmt"
 	"html/template"
 	"io"
-	"strconv"
-	"strings"
 	"time"
 
-	"metapus/internal/core/types"
+	"metapus/internal/core/format"
 )
 
 //go:embed templates
@@ -25,6 +23,10 @@ type PrintData struct {
 	DecimalPlaces int
 	// CurrencySymbol is the currency symbol, e.g. "₽".
 	CurrencySymbol string
+	// CurrencyCode is the ISO code used for the amount in words, e.g. "RUB".
+	CurrencyCode string
+	// Format formats numbers and dates for the tenant locale (nil = format.DefaultLocale).
+	Format *format.Formatter
 	// Doc is the typed document response DTO; templates access its fields via reflection.
 	Doc any
 	// Table is a format-agnostic pre-formatted representation used by XLSX/DOCX renderers.
@@ -32,46 +34,56 @@ type PrintData struct {
 	Table *PrintTable
 }
 
+// Formatter returns d.Format or the default-locale formatter.
+func (d *PrintData) Formatter() *format.Formatter {
+	if d.Format != nil {
+		return d.Format
+	}
+	return format.New(format.DefaultLocale)
+}
+
 // Renderer renders print form HTML using embedded Go templates.
+// Templates are parsed once per locale so formatting helpers are bound to it.
 type Renderer struct {
-	templates *template.Template
+	templates map[format.Locale]*template.Template
 }
 
 // NewRenderer loads all embedded templates and returns a ready Renderer.
 func NewRenderer() (*Renderer, error) {
-	tmpl, err := template.New("").Funcs(buildFuncMap()).ParseFS(templateFS, "templates/*.gohtml")
-	if err != nil {
-		return nil, fmt.Errorf("load print templates: %w", err)
+	r := &Renderer{templates: make(map[format.Locale]*template.Template)}
+	for _, locale := range []format.Locale{format.LocaleRU, format.LocaleEN} {
+		tmpl, err := template.New("").Funcs(buildFuncMap(format.New(locale))).ParseFS(templateFS, "templates/*.gohtml")
+		if err != nil {
+			return nil, fmt.Errorf("load print templates: %w", err)
+		}
+		r.templates[locale] = tmpl
 	}
-	return &Renderer{templates: tmpl}, nil
+	return r, nil
 }
 
 // Render executes the named template (e.g. "goods_receipt.gohtml") into w.
 func (r *Renderer) Render(w io.Writer, templateName string, data *PrintData) error {
-	return r.templates.ExecuteTemplate(w, templateName, data)
+	tmpl, ok := r.templates[data.Formatter().Locale()]
+	if !ok {
+		tmpl = r.templates[format.DefaultLocale]
+	}
+	return tmpl.ExecuteTemplate(w, templateName, data)
 }
 
-// buildFuncMap returns the template helper functions.
-func buildFuncMap() template.FuncMap {
+// buildFuncMap returns the template helper functions bound to f.
+func buildFuncMap(f *format.Formatter) template.FuncMap {
 	return template.FuncMap{
-		"formatDate": func(t time.Time) string {
-			if t.IsZero() {
-				return ""
-			}
-			return t.Format("02.01.2006")
-		},
+		"formatDate": f.Date,
 		"formatDatePtr": func(t *time.Time) string {
-			if t == nil || t.IsZero() {
+			if t == nil {
 				return ""
 			}
-			return t.Format("02.01.2006")
-		},
-		"formatMoney": func(v types.MinorUnits, dp int) string {
-			return formatMoneyStr(v, dp)
-		},
-		"formatQty": func(v types.Quantity) string {
-			return formatQtyStr(v)
+			return f.Date(*t)
 		},
+		"formatMoney":       f.Money,
+		"formatMoneySymbol": f.MoneyWithSymbol,
+		"formatQty":         f.Quantity,
+		"amountInWords":     f.AmountInWords,
 		"derefStr": func(s *string) string {
 			if s == nil {
 				return ""
@@ -81,97 +93,3 @@ func buildFuncMap() template.FuncMap {
 		"add": func(a, b int) int { return a + b },
 	}
 }
-
-// FormatMoney formats MinorUnits to a Russian-locale decimal string.
-// Exported for use by handler BuildPrintData closures building PrintTable.
-func FormatMoney(v types.MinorUnits, dp int) string {
-	return formatMoneyStr(v, dp)
-}
-
-// FormatQty formats Quantity to a human-readable string.
-// Exported for use by handler BuildPrintData closures building PrintTable.
-func FormatQty(v types.Quantity) string {
-	return formatQtyStr(v)
-}
-
-// FormatDate formats time.Time to DD.MM.YYYY.
-func FormatDate(t time.Time) string {
-	if t.IsZero() {
-		return ""
-	}
-	return t.Format("02.01.2006")
-}
-
-// formatMoneyStr converts MinorUnits to a Russian-locale decimal string.
-// Example: formatMoneyStr(123456, 2) → "1 234,56"
-func formatMoneyStr(v types.MinorUnits, dp int) string {
-	if dp < 0 {
-		dp = 2
-	}
-	abs := int64(v)
-	negative := abs < 0
-	if negative {
-		abs = -abs
-	}
-
-	scale := int64(1)
-	for i := 0; i < dp; i++ {
-		scale *= 10
-	}
-
-	intPart := abs / scale
-	fracPart := abs % scale
-
-	intStr := formatIntSpaces(intPart)
-	if dp == 0 {
-		if negative {
-			return "-" + intStr
-		}
-		return intStr
-	}
-
-	fracStr := fmt.Sprintf("%0*d", dp, fracPart)
-	result := intStr + "," + fracStr
-	if negative {
-		return "-" + result
-	}
-	return result
-}
-
-// formatIntSpaces formats an int64 with space thousands separators.
-// Example: 1234567 → "1 234 567", 0 → "0"
-func formatIntSpaces(n int64) string {
-	if n == 0 {
-		return "0"
-	}
-	s := strconv.FormatInt(n, 10)
-	var sb strings.Builder
-	start := len(s) % 3
-	if start > 0 {
-		sb.WriteString(s[:start])
-	}
-	for i := start; i < len(s); i += 3 {
-		if sb.Len() > 0 {
-			sb.WriteRune('\u00a0') // non-breaking space
-		}
-		sb.WriteString(s[i : i+3])
-	}
-	return sb.String()
-}
-
-// formatQtyStr converts Quantity to a human-readable string.
-// Keeps at least 3 decimal places; trims excess trailing zeros.
-// Example: Quantity(10000) → "1.000", Quantity(15500) → "1.550"
-func formatQtyStr(v types.Quantity) string {
-	f := v.Float64()
-	s := fmt.Sprintf("%.4f", f)
-	parts := strings.SplitN(s, ".", 2)
-	if len(parts) == 2 {
-		frac := strings.TrimRight(parts[1], "0")
-		if len(frac) < 3 {
-			frac = frac + strings.Repeat("0", 3-len(frac))
-		}
-		return parts[0] + "." + frac
-	}
-	return s
-}
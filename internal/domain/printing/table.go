@@ -18,6 +18,8 @@ type PrintTable struct {
 	Rows []PrintTableRow
 	// Totals are label-value pairs shown below the table.
 	Totals []PrintTotalLine
+	// AmountInWords is the "total in words" line shown under the totals (empty = omitted).
+	AmountInWords string
 	// SignatureBlock describes the signature area with layout mode and entries.
 	SignatureBlock *PrintSignatureBlock
 }
@@ -67,6 +67,9 @@ func RenderXLSX(w io.Writer, data *PrintData) error {
 			{Type: "bottom", Color: "999999", Style: 2},
 		},
 	})
+	amountWordsStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Size: 10, Bold: true},
+	})
 
 	row := 1
 	numCols := len(t.Columns)
@@ -148,6 +151,12 @@ func RenderXLSX(w io.Writer, data *PrintData) error {
 		}
 		row++
 	}
+	if t.AmountInWords != "" {
+		ac := cellRef(1, row)
+		_ = f.SetCellValue(sheet, ac, t.AmountInWords)
+		_ = f.SetCellStyle(sheet, ac, ac, amountWordsStyle)
+		row++
+	}
 	row += 2
 
 	// ── Signatures (layout-driven from PrintSignatureBlock) ──────────────
@@ -0,0 +1,211 @@
+// Package recurring provides recurring document generation: a schedule
+// creates documents of one type from a document template on a CRON
+// schedule (e.g. a monthly service issue). Schedules are executed by the
+// worker (see Runner); every execution is recorded in the run history.
+package recurring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/apperror"
+)
+
+// FailurePolicy defines what happens to occurrences that could not produce
+// a document (creation failed or the worker was down).
+type FailurePolicy string
+
+const (
+	// FailurePolicySkip drops failed and missed occurrences; the next
+	// occurrence creates a regular document.
+	FailurePolicySkip FailurePolicy = "skip"
+	// FailurePolicyMerge carries failed and missed occurrences over: the next
+	// successful run creates one document with quantities multiplied by the
+	// number of occurrences it covers.
+	FailurePolicyMerge FailurePolicy = "merge"
+)
+
+// RunStatus is the outcome of a schedule run.
+type RunStatus string
+
+const (
+	RunStatusSuccess RunStatus = "success"
+	RunStatusFailed  RunStatus = "failed"
+	RunStatusSkipped RunStatus = "skipped"
+)
+
+// maxCatchUp caps how many missed occurrences a single run accounts for.
+const maxCatchUp = 1000
+
+// cronParser accepts the same 6-field format as scheduled automation rules
+// (sec min hour dom month dow) plus descriptors such as @monthly.
+var cronParser = cron.NewParser(
+	cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+// Schedule creates documents of DocumentType from a template on a CRON schedule.
+// Documents are created on behalf of the schedule author.
+type Schedule struct {
+	ID            uuid.UUID       `json:"id" db:"id"`
+	DocumentType  string          `json:"documentType" db:"document_type"`
+	Name          string          `json:"name" db:"name"`
+	TemplateID    uuid.UUID       `json:"templateId" db:"template_id"`
+	CronExpr      string          `json:"cronExpr" db:"cron_expr"`
+	Timezone      string          `json:"timezone" db:"timezone"`
+	Quantities    json.RawMessage `json:"quantities" db:"quantities"` // per template line, see doctemplate.Instantiate
+	AutoPost      bool            `json:"autoPost" db:"auto_post"`
+	FailurePolicy FailurePolicy   `json:"failurePolicy" db:"failure_policy"`
+	Active        bool            `json:"active" db:"active"`
+	NextRunAt     *time.Time      `json:"nextRunAt" db:"next_run_at"`
+	LastRunAt     *time.Time      `json:"lastRunAt" db:"last_run_at"`
+	PendingCount  int             `json:"pendingCount" db:"pending_count"` // occurrences carried over (merge policy)
+	AuthorID      *uuid.UUID      `json:"authorId" db:"author_id"`
+	DeletionMark  bool            `json:"deletionMark" db:"deletion_mark"`
+	Version       int             `json:"version" db:"version"`
+	CreatedAt     time.Time       `json:"createdAt" db:"created_at"`
+	UpdatedAt     time.Time       `json:"updatedAt" db:"updated_at"`
+}
+
+// Run is one execution of a schedule.
+type Run struct {
+	ID           uuid.UUID  `json:"id" db:"id"`
+	ScheduleID   uuid.UUID  `json:"scheduleId" db:"schedule_id"`
+	ScheduledFor time.Time  `json:"scheduledFor" db:"scheduled_for"`
+	Occurrences  int        `json:"occurrences" db:"occurrences"` // occurrences covered by this run
+	Status       RunStatus  `json:"status" db:"status"`
+	DocumentID   *uuid.UUID `json:"documentId" db:"document_id"`
+	Error        string     `json:"error,omitempty" db:"error"`
+	StartedAt    time.Time  `json:"startedAt" db:"started_at"`
+	FinishedAt   time.Time  `json:"finishedAt" db:"finished_at"`
+}
+
+// Validate checks basic integrity of the schedule. Pure function, no DB calls.
+func (s *Schedule) Validate(_ context.Context) error {
+	if s.DocumentType == "" {
+		return apperror.NewValidation("validation failed").WithDetail("documentType", "required")
+	}
+	if s.Name == "" {
+		return apperror.NewValidation("validation failed").WithDetail("name", "required")
+	}
+	if s.TemplateID == uuid.Nil {
+		return apperror.NewValidation("validation failed").WithDetail("templateId", "required")
+	}
+	if _, err := s.cronSchedule(); err != nil {
+		return apperror.NewValidation("validation failed").WithDetail("cronExpr", err.Error())
+	}
+	if s.FailurePolicy != FailurePolicySkip && s.FailurePolicy != FailurePolicyMerge {
+		return apperror.NewValidation("validation failed").WithDetail("failurePolicy", "must be skip or merge")
+	}
+	if _, err := s.quantities(); err != nil {
+		return apperror.NewValidation("validation failed").WithDetail("quantities", err.Error())
+	}
+	return nil
+}
+
+// cronSchedule parses the CRON expression in the schedule's time zone.
+func (s *Schedule) cronSchedule() (cron.Schedule, error) {
+	if s.CronExpr == "" {
+		return nil, fmt.Errorf("required")
+	}
+	tz := s.Timezone
+	if tz == "" {
+		tz = "UTC"
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", tz)
+	}
+	return cronParser.Parse("CRON_TZ=" + tz + " " + s.CronExpr)
+}
+
+// Next returns the first occurrence strictly after t.
+func (s *Schedule) Next(t time.Time) (time.Time, error) {
+	sched, err := s.cronSchedule()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return sched.Next(t), nil
+}
+
+// Due returns the occurrences that are due at now, starting from NextRunAt:
+// their number, the latest of them and the first occurrence after now.
+// count is 0 if the schedule is not due yet.
+func (s *Schedule) Due(now time.Time) (count int, latest, next time.Time, err error) {
+	sched, err := s.cronSchedule()
+	if err != nil {
+		return 0, time.Time{}, time.Time{}, err
+	}
+	if s.NextRunAt == nil || s.NextRunAt.After(now) {
+		return 0, time.Time{}, sched.Next(now), nil
+	}
+
+	latest = *s.NextRunAt
+	count = 1
+	for next = sched.Next(latest); !next.After(now); next = sched.Next(next) {
+		if count == maxCatchUp {
+			// Long outage of a frequent schedule: stop counting.
+			return count, latest, sched.Next(now), nil
+		}
+		latest = next
+		count++
+	}
+	return count, latest, next, nil
+}
+
+// quantities decodes the per-line quantities.
+func (s *Schedule) quantities() ([]json.RawMessage, error) {
+	if len(s.Quantities) == 0 || string(s.Quantities) == "null" {
+		return nil, nil
+	}
+	var q []json.RawMessage
+	if err := json.Unmarshal(s.Quantities, &q); err != nil {
+		return nil, fmt.Errorf("must be an array")
+	}
+	for i, v := range q {
+		if len(v) == 0 || string(v) == "null" {
+			continue
+		}
+		if _, err := parseQuantity(v); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+	}
+	return q, nil
+}
+
+// ScaleQuantities multiplies every non-null quantity by factor.
+// Null entries are kept: they leave the template line out of the document.
+func ScaleQuantities(quantities []json.RawMessage, factor int) ([]json.RawMessage, error) {
+	if factor == 1 {
+		return quantities, nil
+	}
+	scaled := make([]json.RawMessage, len(quantities))
+	for i, v := range quantities {
+		if len(v) == 0 || string(v) == "null" {
+			scaled[i] = v
+			continue
+		}
+		q, err := parseQuantity(v)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		scaled[i] = json.RawMessage(q.Mul(decimal.NewFromInt(int64(factor))).String())
+	}
+	return scaled, nil
+}
+
+// parseQuantity accepts a JSON number or a numeric string.
+func parseQuantity(v json.RawMessage) (decimal.Decimal, error) {
+	var q decimal.Decimal
+	if err := json.Unmarshal(v, &q); err != nil {
+		return decimal.Zero, fmt.Errorf("invalid quantity %s", v)
+	}
+	if !q.IsPositive() {
+		return decimal.Zero, fmt.Errorf("quantity must be positive")
+	}
+	return q, nil
+}
@@ -0,0 +1,38 @@
+package recurring
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines storage operations for recurring schedules and their runs.
+type Repository interface {
+	// Create inserts a new schedule.
+	Create(ctx context.Context, s *Schedule) error
+
+	// Update modifies a schedule. Uses optimistic locking (version).
+	Update(ctx context.Context, s *Schedule) error
+
+	// Delete soft-deletes a schedule by ID.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// GetByID returns a single schedule.
+	GetByID(ctx context.Context, id uuid.UUID) (*Schedule, error)
+
+	// GetList returns schedules of a document type.
+	GetList(ctx context.Context, documentType string) ([]*Schedule, error)
+
+	// ClaimDue locks one active schedule with next_run_at <= now until
+	// leaseUntil, so that concurrent workers do not run it twice.
+	// Returns nil if nothing is due.
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time) (*Schedule, error)
+
+	// CompleteRun stores the runs, advances the schedule (next_run_at,
+	// last_run_at, pending_count) and releases its lease, in one transaction.
+	CompleteRun(ctx context.Context, s *Schedule, runs ...*Run) error
+
+	// ListRuns returns the most recent runs of a schedule, newest first.
+	ListRuns(ctx context.Context, scheduleID uuid.UUID, limit int) ([]*Run, error)
+}
@@ -0,0 +1,157 @@
+package recurring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	corectx "metapus/internal/core/context"
+	"metapus/internal/core/format"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/doctemplate"
+	"metapus/pkg/logger"
+)
+
+const (
+	// runLease is how long a claimed schedule stays locked for other workers.
+	runLease = 5 * time.Minute
+	// maxRunsPerBatch bounds the work done by a single RunDue call.
+	maxRunsPerBatch = 100
+	// maxErrorLength bounds the error text stored in the run history.
+	maxErrorLength = 2000
+)
+
+// DocumentCreator creates a document of a type from a template payload,
+// exactly like POST /document/{type}/from-template/:templateId does.
+type DocumentCreator interface {
+	CreateFromTemplate(ctx context.Context, documentType string, payload json.RawMessage,
+		date time.Time, quantities []json.RawMessage, post bool) (uuid.UUID, error)
+}
+
+// Runner executes due schedules. It is driven by the worker; ctx must carry
+// the tenant, pool and TxManager.
+type Runner struct {
+	repo      Repository
+	templates doctemplate.Repository
+	creator   DocumentCreator
+	now       func() time.Time
+}
+
+// NewRunner creates a schedule runner.
+func NewRunner(repo Repository, templates doctemplate.Repository, creator DocumentCreator) *Runner {
+	return &Runner{repo: repo, templates: templates, creator: creator, now: time.Now}
+}
+
+// RunDue executes all schedules that are due and returns how many were run.
+func (r *Runner) RunDue(ctx context.Context) (int, error) {
+	processed := 0
+	for processed < maxRunsPerBatch {
+		now := r.now()
+		sch, err := r.repo.ClaimDue(ctx, now, now.Add(runLease))
+		if err != nil {
+			return processed, err
+		}
+		if sch == nil {
+			return processed, nil
+		}
+		if err := r.run(ctx, sch, now); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// run executes one claimed schedule and records the outcome.
+// Only storage errors are returned; document creation failures become failed runs.
+func (r *Runner) run(ctx context.Context, sch *Schedule, now time.Time) error {
+	count, latest, next, err := sch.Due(now)
+	if err != nil {
+		// Cannot happen for a validated schedule; stop it rather than retry forever.
+		logger.Error(ctx, "recurring: invalid schedule, deactivating", "schedule_id", sch.ID, "error", err)
+		sch.Active = false
+		sch.NextRunAt = nil
+		return r.repo.CompleteRun(ctx, sch, &Run{
+			ScheduleID: sch.ID, ScheduledFor: now, Occurrences: 1, Status: RunStatusFailed,
+			Error: err.Error(), StartedAt: now, FinishedAt: now,
+		})
+	}
+	if count == 0 {
+		sch.NextRunAt = &next
+		return r.repo.CompleteRun(ctx, sch)
+	}
+
+	var runs []*Run
+	occurrences := count
+	switch sch.FailurePolicy {
+	case FailurePolicyMerge:
+		occurrences += sch.PendingCount
+	default:
+		if count > 1 {
+			runs = append(runs, &Run{
+				ScheduleID: sch.ID, ScheduledFor: *sch.NextRunAt, Occurrences: count - 1,
+				Status: RunStatusSkipped, Error: "missed occurrences skipped",
+				StartedAt: now, FinishedAt: now,
+			})
+		}
+		occurrences = 1
+	}
+
+	run := &Run{ScheduleID: sch.ID, ScheduledFor: latest, Occurrences: occurrences, StartedAt: now}
+	docID, err := r.createDocument(ctx, sch, latest, occurrences)
+	run.FinishedAt = r.now()
+	if err != nil {
+		logger.Warn(ctx, "recurring: document creation failed",
+			"schedule_id", sch.ID, "document_type", sch.DocumentType, "error", err)
+		run.Status = RunStatusFailed
+		run.Error = format.Truncate(err.Error(), maxErrorLength)
+		sch.PendingCount = 0
+		if sch.FailurePolicy == FailurePolicyMerge {
+			sch.PendingCount = occurrences
+		}
+	} else {
+		run.Status = RunStatusSuccess
+		run.DocumentID = &docID
+		sch.PendingCount = 0
+	}
+	runs = append(runs, run)
+
+	sch.NextRunAt = &next
+	sch.LastRunAt = &now
+	return r.repo.CompleteRun(ctx, sch, runs...)
+}
+
+// createDocument instantiates the template on behalf of the schedule author.
+func (r *Runner) createDocument(ctx context.Context, sch *Schedule, date time.Time, occurrences int) (uuid.UUID, error) {
+	t, err := r.templates.GetByID(ctx, sch.TemplateID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if t.DocumentType != sch.DocumentType {
+		return uuid.Nil, fmt.Errorf("template %s is not a %s template", t.ID, sch.DocumentType)
+	}
+	ownTemplate := t.AuthorID != nil && sch.AuthorID != nil && *t.AuthorID == *sch.AuthorID
+	if t.Visibility != doctemplate.VisibilityShared && !ownTemplate {
+		return uuid.Nil, fmt.Errorf("template %s is no longer available to the schedule author", t.ID)
+	}
+
+	quantities, err := sch.quantities()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	quantities, err = ScaleQuantities(quantities, occurrences)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if sch.AuthorID != nil {
+		ctx = corectx.WithUser(ctx, &corectx.UserContext{
+			UserID:   sch.AuthorID.String(),
+			TenantID: tenant.GetTenantID(ctx),
+		})
+	}
+	return r.creator.CreateFromTemplate(ctx, sch.DocumentType, t.Payload, date, quantities, sch.AutoPost)
+}
@@ -0,0 +1,118 @@
+package recurring
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/domain/doctemplate"
+)
+
+type fakeRepo struct {
+	Repository
+	due  *Schedule
+	runs []*Run
+}
+
+func (f *fakeRepo) ClaimDue(_ context.Context, _, _ time.Time) (*Schedule, error) {
+	s := f.due
+	f.due = nil
+	return s, nil
+}
+
+func (f *fakeRepo) CompleteRun(_ context.Context, _ *Schedule, runs ...*Run) error {
+	f.runs = append(f.runs, runs...)
+	return nil
+}
+
+type fakeTemplates struct {
+	doctemplate.Repository
+	t *doctemplate.Template
+}
+
+func (f *fakeTemplates) GetByID(_ context.Context, _ uuid.UUID) (*doctemplate.Template, error) {
+	return f.t, nil
+}
+
+type fakeCreator struct {
+	err        error
+	quantities []json.RawMessage
+}
+
+func (f *fakeCreator) CreateFromTemplate(_ context.Context, _ string, _ json.RawMessage, _ time.Time, q []json.RawMessage, _ bool) (uuid.UUID, error) {
+	f.quantities = q
+	return uuid.New(), f.err
+}
+
+// monthlySchedule is due on the 1st of each month; NextRunAt is 1 March,
+// so at 15 May three occurrences (March, April, May) are due.
+func monthlySchedule(policy FailurePolicy, pending int) *Schedule {
+	next := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	return &Schedule{
+		ID: uuid.New(), DocumentType: "goods_issue", Name: "Monthly service",
+		TemplateID: uuid.New(), CronExpr: "0 0 9 1 * *", Timezone: "UTC",
+		Quantities: json.RawMessage(`[2, null, "1.5"]`), FailurePolicy: policy,
+		Active: true, NextRunAt: &next, PendingCount: pending,
+	}
+}
+
+func newTestRunner(sch *Schedule, creator *fakeCreator) (*Runner, *fakeRepo) {
+	repo := &fakeRepo{due: sch}
+	templates := &fakeTemplates{t: &doctemplate.Template{
+		ID: sch.TemplateID, DocumentType: sch.DocumentType, Visibility: doctemplate.VisibilityShared,
+	}}
+	r := NewRunner(repo, templates, creator)
+	r.now = func() time.Time { return time.Date(2026, 5, 15, 12, 0, 0, 0, time.UTC) }
+	return r, repo
+}
+
+func TestRunnerSkipPolicyDropsMissedOccurrences(t *testing.T) {
+	sch := monthlySchedule(FailurePolicySkip, 0)
+	creator := &fakeCreator{}
+	r, repo := newTestRunner(sch, creator)
+
+	if n, err := r.RunDue(context.Background()); err != nil || n != 1 {
+		t.Fatalf("RunDue = %d, %v; want 1, nil", n, err)
+	}
+	if len(repo.runs) != 2 || repo.runs[0].Status != RunStatusSkipped || repo.runs[0].Occurrences != 2 {
+		t.Fatalf("want a skipped run covering 2 occurrences first, got %+v", repo.runs)
+	}
+	if repo.runs[1].Status != RunStatusSuccess || repo.runs[1].Occurrences != 1 {
+		t.Fatalf("want a successful run of 1 occurrence, got %+v", repo.runs[1])
+	}
+	if got := string(creator.quantities[0]); got != "2" {
+		t.Errorf("quantity = %s, want 2", got)
+	}
+	if want := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC); !sch.NextRunAt.Equal(want) {
+		t.Errorf("NextRunAt = %v, want %v", sch.NextRunAt, want)
+	}
+}
+
+func TestRunnerMergePolicyCarriesOverFailures(t *testing.T) {
+	sch := monthlySchedule(FailurePolicyMerge, 1)
+	creator := &fakeCreator{err: errors.New("insufficient stock")}
+	r, repo := newTestRunner(sch, creator)
+
+	if _, err := r.RunDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.runs) != 1 || repo.runs[0].Status != RunStatusFailed {
+		t.Fatalf("want one failed run, got %+v", repo.runs)
+	}
+	if sch.PendingCount != 4 {
+		t.Fatalf("PendingCount = %d, want 4 (1 carried + 3 due)", sch.PendingCount)
+	}
+	if got := string(creator.quantities[0]); got != "8" {
+		t.Errorf("quantity = %s, want 8", got)
+	}
+	if got := string(creator.quantities[2]); got != "6" {
+		t.Errorf("quantity = %s, want 6", got)
+	}
+	if string(creator.quantities[1]) != "null" {
+		t.Errorf("null quantity must stay null, got %s", creator.quantities[1])
+	}
+}
@@ -0,0 +1,155 @@
+package recurring
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/core/apperror"
+	corectx "metapus/internal/core/context"
+	"metapus/internal/domain/doctemplate"
+)
+
+// defaultRunHistory is the number of runs returned by ListRuns.
+const defaultRunHistory = 50
+
+// Service provides business logic for managing recurring schedules.
+type Service struct {
+	repo      Repository
+	templates *doctemplate.Service
+	now       func() time.Time
+}
+
+// NewService creates a new recurring schedule service.
+func NewService(repo Repository, templates *doctemplate.Service) *Service {
+	return &Service{repo: repo, templates: templates, now: time.Now}
+}
+
+// Create saves a new schedule. The current user becomes its author and
+// must be able to use the template.
+func (s *Service) Create(ctx context.Context, sch *Schedule) error {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return err
+	}
+	sch.AuthorID = &userID
+	if sch.FailurePolicy == "" {
+		sch.FailurePolicy = FailurePolicySkip
+	}
+
+	if err := s.prepare(ctx, sch); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, sch)
+}
+
+// Update changes a schedule. Only the author (or an administrator) may do it.
+// Changing the timing re-plans the next run from now.
+func (s *Service) Update(ctx context.Context, sch *Schedule) error {
+	existing, err := s.getOwned(ctx, sch.DocumentType, sch.ID, "update")
+	if err != nil {
+		return err
+	}
+
+	// Carry over fields the client does not control.
+	sch.AuthorID = existing.AuthorID
+	sch.LastRunAt = existing.LastRunAt
+	sch.PendingCount = existing.PendingCount
+
+	if err := s.prepare(ctx, sch); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, sch)
+}
+
+// Delete soft-deletes a schedule. Only the author (or an administrator) may do it.
+func (s *Service) Delete(ctx context.Context, documentType string, id uuid.UUID) error {
+	if _, err := s.getOwned(ctx, documentType, id, "delete"); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// Get returns a schedule of the document type.
+func (s *Service) Get(ctx context.Context, documentType string, id uuid.UUID) (*Schedule, error) {
+	sch, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sch.DocumentType != documentType {
+		return nil, apperror.NewNotFound("recurring_schedule", id)
+	}
+	return sch, nil
+}
+
+// GetList returns schedules of a document type.
+func (s *Service) GetList(ctx context.Context, documentType string) ([]*Schedule, error) {
+	return s.repo.GetList(ctx, documentType)
+}
+
+// ListRuns returns the recent run history of a schedule.
+func (s *Service) ListRuns(ctx context.Context, documentType string, id uuid.UUID) ([]*Run, error) {
+	if _, err := s.Get(ctx, documentType, id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRuns(ctx, id, defaultRunHistory)
+}
+
+// prepare validates the schedule, checks the template and plans the next run.
+func (s *Service) prepare(ctx context.Context, sch *Schedule) error {
+	if sch.Timezone == "" {
+		sch.Timezone = "UTC"
+	}
+	if err := sch.Validate(ctx); err != nil {
+		return err
+	}
+
+	// The author must be able to see the template (own or shared).
+	if _, err := s.templates.Get(ctx, sch.DocumentType, sch.TemplateID); err != nil {
+		if apperror.IsNotFound(err) {
+			return apperror.NewValidation("validation failed").WithDetail("templateId", "template not found")
+		}
+		return err
+	}
+
+	sch.NextRunAt = nil
+	if sch.Active {
+		next, err := sch.Next(s.now())
+		if err != nil {
+			return apperror.NewValidation("validation failed").WithDetail("cronExpr", err.Error())
+		}
+		sch.NextRunAt = &next
+	}
+	return nil
+}
+
+// getOwned loads a schedule and checks that the current user may change it.
+func (s *Service) getOwned(ctx context.Context, documentType string, id uuid.UUID, action string) (*Schedule, error) {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.Get(ctx, documentType, id)
+	if err != nil {
+		return nil, err
+	}
+	owned := existing.AuthorID != nil && *existing.AuthorID == userID
+	if !owned && !corectx.GetUser(ctx).IsAdmin {
+		return nil, apperror.NewForbidden("cannot " + action + " another user's schedule")
+	}
+	return existing, nil
+}
+
+// requireUserID extracts and parses user ID from context.
+func requireUserID(ctx context.Context) (uuid.UUID, error) {
+	user := corectx.GetUser(ctx)
+	if user == nil {
+		return uuid.UUID{}, apperror.NewUnauthorized("user not authenticated")
+	}
+	userID, err := uuid.Parse(user.UserID)
+	if err != nil {
+		return uuid.UUID{}, apperror.NewUnauthorized("invalid user ID")
+	}
+	return userID, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"metapus/internal/core/entity"
 	"metapus/internal/core/id"
+	"metapus/internal/core/types"
 )
 
 // Repository defines operations for the cost register.
@@ -31,4 +32,21 @@ type Repository interface {
 
 	// GetBalancesByNomenclature returns balances across all warehouses for a nomenclature
 	GetBalancesByNomenclature(ctx context.Context, nomenclatureID id.ID) ([]entity.CostBalance, error)
+
+	// FIFO operations
+
+	// GetFIFOLayers locks warehouse+nomenclature for the rest of the transaction
+	// and returns receipt layers not yet consumed by expenses, oldest first.
+	GetFIFOLayers(ctx context.Context, warehouseID, nomenclatureID id.ID) ([]Layer, error)
+}
+
+// Layer is a cost receipt not yet fully written off.
+// Each receipt movement forms one layer; expenses consume layers in
+// (period, created_at) order regardless of their currency.
+type Layer struct {
+	LineID     id.ID            `db:"line_id"`
+	CurrencyID id.ID            `db:"currency_id"`
+	Quantity   types.Quantity   `db:"quantity"`
+	Amount     types.MinorUnits `db:"amount"`
+	Remaining  types.Quantity   `db:"remaining"`
 }
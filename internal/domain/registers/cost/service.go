@@ -2,12 +2,17 @@
 package cost
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
 
 	"metapus/internal/core/apperror"
 	"metapus/internal/core/entity"
 	"metapus/internal/core/id"
+	"metapus/internal/core/types"
 	"metapus/pkg/logger"
 )
 
@@ -22,11 +27,20 @@ func NewService(repo Repository) *Service {
 }
 
 // RecordMovements records cost movements from a document posting.
+// Unpriced write-offs (entity.NewCostWriteOff) are priced by FIFO first.
 func (s *Service) RecordMovements(ctx context.Context, movements []entity.CostMovement) error {
 	if len(movements) == 0 {
 		return nil
 	}
 
+	movements, err := s.priceWriteOffs(ctx, movements)
+	if err != nil {
+		return err
+	}
+	if len(movements) == 0 {
+		return nil
+	}
+
 	for i, m := range movements {
 		if !m.Quantity.IsPositive() {
 			return apperror.NewValidation(fmt.Sprintf("cost movement %d: quantity must be positive", i))
@@ -51,6 +65,129 @@ func (s *Service) RecordMovements(ctx context.Context, movements []entity.CostMo
 	return nil
 }
 
+// layerKey identifies the FIFO queue of a product in a warehouse.
+type layerKey struct {
+	warehouseID, nomenclatureID id.ID
+}
+
+// priceWriteOffs replaces unpriced write-offs with expense movements priced
+// from FIFO layers, one per layer currency. Quantity not covered by layers
+// (goods received without cost) is left unpriced and dropped with a warning.
+func (s *Service) priceWriteOffs(ctx context.Context, movements []entity.CostMovement) ([]entity.CostMovement, error) {
+	queues := make(map[layerKey][]Layer)
+	for _, m := range movements {
+		if m.IsUnpriced() {
+			queues[layerKey{m.WarehouseID, m.NomenclatureID}] = nil
+		}
+	}
+	if len(queues) == 0 {
+		return movements, nil
+	}
+
+	// Lock in deterministic order to prevent deadlocks between postings.
+	keys := make([]layerKey, 0, len(queues))
+	for k := range queues {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if c := bytes.Compare(keys[i].warehouseID[:], keys[j].warehouseID[:]); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(keys[i].nomenclatureID[:], keys[j].nomenclatureID[:]) < 0
+	})
+	for _, k := range keys {
+		layers, err := s.repo.GetFIFOLayers(ctx, k.warehouseID, k.nomenclatureID)
+		if err != nil {
+			return nil, fmt.Errorf("get cost layers: %w", err)
+		}
+		queues[k] = layers
+	}
+
+	result := make([]entity.CostMovement, 0, len(movements))
+	for _, m := range movements {
+		if !m.IsUnpriced() {
+			result = append(result, m)
+			continue
+		}
+
+		k := layerKey{m.WarehouseID, m.NomenclatureID}
+		priced, uncovered := consumeLayers(queues[k], m)
+		result = append(result, priced...)
+		if uncovered.IsPositive() {
+			logger.Warn(ctx, "cost write-off exceeds FIFO layers",
+				"recorder_id", m.RecorderID,
+				"warehouse_id", m.WarehouseID,
+				"nomenclature_id", m.NomenclatureID,
+				"uncovered_quantity", uncovered.String(),
+			)
+		}
+	}
+	return result, nil
+}
+
+// consumeLayers takes the write-off quantity from the front of the queue
+// (updating Remaining in place) and returns one priced movement per currency
+// plus the quantity the layers could not cover.
+//
+// A layer's amount is apportioned cumulatively — cost of the first N units is
+// round(Amount*N/Quantity) — so a fully consumed layer is written off exactly.
+func consumeLayers(layers []Layer, m entity.CostMovement) ([]entity.CostMovement, types.Quantity) {
+	need := m.Quantity
+	var priced []entity.CostMovement
+	byCurrency := make(map[id.ID]int)
+
+	for i := range layers {
+		if !need.IsPositive() {
+			break
+		}
+		l := &layers[i]
+		if !l.Remaining.IsPositive() {
+			continue
+		}
+
+		take := min(need, l.Remaining)
+		consumedBefore := l.Quantity - l.Remaining
+		amount := costOfUnits(l, consumedBefore+take) - costOfUnits(l, consumedBefore)
+		l.Remaining -= take
+		need -= take
+
+		if idx, ok := byCurrency[l.CurrencyID]; ok {
+			priced[idx].Quantity += take
+			priced[idx].Amount += amount
+			continue
+		}
+		pm := m
+		if len(priced) > 0 {
+			pm.LineID = id.New()
+		}
+		pm.CurrencyID = l.CurrencyID
+		pm.Quantity = take
+		pm.Amount = amount
+		byCurrency[l.CurrencyID] = len(priced)
+		priced = append(priced, pm)
+	}
+
+	// Drop pieces that rounded to zero cost: the register requires positive amounts.
+	out := priced[:0]
+	for _, pm := range priced {
+		if pm.Amount.IsPositive() {
+			out = append(out, pm)
+		}
+	}
+	return out, need
+}
+
+// costOfUnits returns the cost of the first units of a layer.
+func costOfUnits(l *Layer, units types.Quantity) types.MinorUnits {
+	if units >= l.Quantity {
+		return l.Amount
+	}
+	v := decimal.NewFromInt(int64(l.Amount)).
+		Mul(decimal.NewFromInt(units.Int64Scaled())).
+		Div(decimal.NewFromInt(l.Quantity.Int64Scaled()))
+	return types.MinorUnits(v.Round(0).IntPart())
+}
+
 // ReverseMovements removes movements for a document (used during unposting).
 func (s *Service) ReverseMovements(ctx context.Context, recorderID id.ID, beforeVersion int) error {
 	if err := s.repo.DeleteMovementsByRecorder(ctx, recorderID, beforeVersion); err != nil {
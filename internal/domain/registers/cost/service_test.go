@@ -0,0 +1,51 @@
+package cost
+
+import (
+	"testing"
+	"time"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+)
+
+func TestConsumeLayers(t *testing.T) {
+	rub, usd := id.New(), id.New()
+	qty := func(n int64) types.Quantity { return types.NewQuantityFromInt64Scaled(n * types.QuantityScale) }
+
+	layers := []Layer{
+		{LineID: id.New(), CurrencyID: rub, Quantity: qty(3), Amount: 100, Remaining: qty(3)},
+		{LineID: id.New(), CurrencyID: usd, Quantity: qty(2), Amount: 50, Remaining: qty(2)},
+	}
+	writeOff := func(n int64) entity.CostMovement {
+		return entity.NewCostWriteOff(id.New(), "GoodsIssue", 1, time.Now(), id.New(), id.New(), qty(n))
+	}
+
+	// 1 of 3 units: round(100/3) = 33.
+	priced, uncovered := consumeLayers(layers, writeOff(1))
+	if len(priced) != 1 || priced[0].Amount != 33 || priced[0].CurrencyID != rub || uncovered != 0 {
+		t.Fatalf("first write-off: got %+v, uncovered %v", priced, uncovered)
+	}
+
+	// Remaining 2 units of the first layer take the rest of its amount exactly,
+	// then the second layer is consumed in its own currency.
+	priced, uncovered = consumeLayers(layers, writeOff(3))
+	if len(priced) != 2 {
+		t.Fatalf("second write-off: want 2 movements, got %+v", priced)
+	}
+	if priced[0].Amount != 67 || priced[0].Quantity != qty(2) {
+		t.Errorf("rub piece: got amount %d qty %v", priced[0].Amount, priced[0].Quantity)
+	}
+	if priced[1].Amount != 25 || priced[1].CurrencyID != usd || priced[1].LineID == priced[0].LineID {
+		t.Errorf("usd piece: got %+v", priced[1])
+	}
+	if uncovered != 0 {
+		t.Errorf("uncovered = %v, want 0", uncovered)
+	}
+
+	// Only one unit left in the layers.
+	priced, uncovered = consumeLayers(layers, writeOff(2))
+	if len(priced) != 1 || priced[0].Amount != 25 || uncovered != qty(1) {
+		t.Fatalf("third write-off: got %+v, uncovered %v", priced, uncovered)
+	}
+}
@@ -0,0 +1,60 @@
+// Package customer_order provides the sales order accumulation register.
+// Posted sales orders record ordered goods (receipt), goods issues linked to
+// order lines record shipped goods (expense); closing an order releases what
+// is left. The balance of an order line is its open quantity.
+package customer_order
+
+import (
+	"context"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+)
+
+// Repository defines storage operations for the sales order register.
+type Repository interface {
+	// CreateMovements batch inserts movements (used during posting)
+	CreateMovements(ctx context.Context, movements []entity.SalesOrderMovement) error
+
+	// DeleteMovementsByRecorder removes all movements for a document version
+	DeleteMovementsByRecorder(ctx context.Context, recorderID id.ID, beforeVersion int) error
+
+	// GetMovementsByRecorder retrieves all movements for a document
+	GetMovementsByRecorder(ctx context.Context, recorderID id.ID) ([]entity.SalesOrderMovement, error)
+
+	// GetBalancesForUpdate returns order line balances with row locks, in key order.
+	// Keys not found in the balances table are returned with Quantity=0.
+	GetBalancesForUpdate(ctx context.Context, keys []BalanceKey) ([]entity.SalesOrderBalance, error)
+
+	// GetLineFulfillment returns ordered, shipped and released quantities per line of an order.
+	GetLineFulfillment(ctx context.Context, orderID id.ID) ([]LineFulfillment, error)
+
+	// RefreshOrderStatus recalculates the status of the orders from their
+	// movements (called in the posting transaction).
+	RefreshOrderStatus(ctx context.Context, orderIDs []id.ID) error
+}
+
+// BalanceKey is the dimension key of an order line balance.
+type BalanceKey struct {
+	OrderID     id.ID
+	OrderLineID id.ID
+}
+
+// LineFulfillment is the fulfillment of one sales order line, in base units.
+// Released is the quantity written off when the order was closed.
+type LineFulfillment struct {
+	OrderLineID    id.ID          `db:"order_line_id" json:"orderLineId"`
+	NomenclatureID id.ID          `db:"nomenclature_id" json:"nomenclatureId"`
+	Ordered        types.Quantity `db:"ordered" json:"ordered"`
+	Shipped        types.Quantity `db:"shipped" json:"shipped"`
+	Released       types.Quantity `db:"released" json:"released"`
+}
+
+// Open returns the quantity still to be shipped (never negative).
+func (f LineFulfillment) Open() types.Quantity {
+	if f.Shipped+f.Released >= f.Ordered {
+		return 0
+	}
+	return f.Ordered - f.Shipped - f.Released
+}
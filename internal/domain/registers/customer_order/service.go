@@ -0,0 +1,219 @@
+package customer_order
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/pkg/logger"
+)
+
+// Service provides business operations for the sales order register.
+// Transactions are managed by the caller (posting engine).
+type Service struct {
+	repo Repository
+}
+
+// NewService creates a new sales order register service.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// RecordMovements records sales order movements from a document posting
+// and refreshes the status of the affected orders.
+//
+// Shipments against an order (expense) are capped at the open quantity of
+// the line: goods shipped over the order leave stock but do not count
+// towards the order. Fully capped movements are dropped.
+func (s *Service) RecordMovements(ctx context.Context, movements []entity.SalesOrderMovement) error {
+	if len(movements) == 0 {
+		return nil
+	}
+
+	for i, m := range movements {
+		if !m.Quantity.IsPositive() {
+			return apperror.NewValidation(fmt.Sprintf("movement %d: quantity must be positive", i))
+		}
+		if id.IsNil(m.RecorderID) {
+			return apperror.NewValidation(fmt.Sprintf("movement %d: recorder_id is required", i))
+		}
+		if id.IsNil(m.OrderID) || id.IsNil(m.OrderLineID) {
+			return apperror.NewValidation(fmt.Sprintf("movement %d: order line is required", i))
+		}
+	}
+
+	movements, err := s.capShipments(ctx, movements)
+	if err != nil {
+		return err
+	}
+	if len(movements) == 0 {
+		return nil
+	}
+
+	if err := s.repo.CreateMovements(ctx, movements); err != nil {
+		return fmt.Errorf("create sales order movements: %w", err)
+	}
+	if err := s.repo.RefreshOrderStatus(ctx, orderIDs(movements)); err != nil {
+		return fmt.Errorf("refresh sales order status: %w", err)
+	}
+
+	logger.Info(ctx, "recorded sales order movements",
+		"count", len(movements),
+		"recorder_id", movements[0].RecorderID,
+	)
+	return nil
+}
+
+// capShipments limits expense movements to the locked order line balances.
+// Receipts in the same set are counted first, so a closing order may order
+// and release within one posting.
+func (s *Service) capShipments(ctx context.Context, movements []entity.SalesOrderMovement) ([]entity.SalesOrderMovement, error) {
+	remaining := make(map[BalanceKey]types.Quantity)
+	for _, m := range movements {
+		if m.RecordType == entity.RecordTypeExpense {
+			remaining[BalanceKey{m.OrderID, m.OrderLineID}] = 0
+		}
+	}
+	if len(remaining) == 0 {
+		return movements, nil
+	}
+
+	// Lock in deterministic order to prevent deadlocks between shipments.
+	keys := make([]BalanceKey, 0, len(remaining))
+	for k := range remaining {
+		keys = append(keys, k)
+	}
+	SortBalanceKeys(keys)
+
+	balances, err := s.repo.GetBalancesForUpdate(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("get sales order balances for update: %w", err)
+	}
+	for _, b := range balances {
+		remaining[BalanceKey{b.OrderID, b.OrderLineID}] = b.Quantity
+	}
+	for _, m := range movements {
+		if m.RecordType != entity.RecordTypeReceipt {
+			continue
+		}
+		k := BalanceKey{m.OrderID, m.OrderLineID}
+		if _, ok := remaining[k]; ok {
+			remaining[k] += m.Quantity
+		}
+	}
+
+	result := make([]entity.SalesOrderMovement, 0, len(movements))
+	for _, m := range movements {
+		if m.RecordType == entity.RecordTypeExpense {
+			k := BalanceKey{m.OrderID, m.OrderLineID}
+			if remaining[k] <= 0 {
+				continue
+			}
+			if m.Quantity > remaining[k] {
+				m.Quantity = remaining[k]
+			}
+			remaining[k] -= m.Quantity
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+// SortBalanceKeys sorts keys by order and order line for resource ordering.
+// Prevents deadlocks when locking multiple balance rows.
+func SortBalanceKeys(keys []BalanceKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if c := bytes.Compare(keys[i].OrderID[:], keys[j].OrderID[:]); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(keys[i].OrderLineID[:], keys[j].OrderLineID[:]) < 0
+	})
+}
+
+// ReverseMovements removes movements for a document (used during unposting)
+// and refreshes the status of the affected orders.
+func (s *Service) ReverseMovements(ctx context.Context, recorderID id.ID, beforeVersion int) error {
+	movements, err := s.repo.GetMovementsByRecorder(ctx, recorderID)
+	if err != nil {
+		return fmt.Errorf("get sales order movements: %w", err)
+	}
+	if len(movements) == 0 {
+		return nil
+	}
+
+	if err := s.repo.DeleteMovementsByRecorder(ctx, recorderID, beforeVersion); err != nil {
+		return fmt.Errorf("delete sales order movements: %w", err)
+	}
+	if err := s.repo.RefreshOrderStatus(ctx, orderIDs(movements)); err != nil {
+		return fmt.Errorf("refresh sales order status: %w", err)
+	}
+
+	logger.Info(ctx, "reversed sales order movements",
+		"recorder_id", recorderID,
+		"before_version", beforeVersion,
+	)
+	return nil
+}
+
+// GetLineFulfillment returns ordered, shipped and released quantities per order line.
+func (s *Service) GetLineFulfillment(ctx context.Context, orderID id.ID) ([]LineFulfillment, error) {
+	return s.repo.GetLineFulfillment(ctx, orderID)
+}
+
+func orderIDs(movements []entity.SalesOrderMovement) []id.ID {
+	seen := make(map[id.ID]struct{})
+	result := make([]id.ID, 0, 1)
+	for _, m := range movements {
+		if _, ok := seen[m.OrderID]; ok {
+			continue
+		}
+		seen[m.OrderID] = struct{}{}
+		result = append(result, m.OrderID)
+	}
+	return result
+}
+
+// ---------------------------------------------------------------------------
+// Implementation of entity.MovementProvider
+// ---------------------------------------------------------------------------
+
+func (s *Service) RegisterName() string {
+	return "Заказы покупателей"
+}
+
+func (s *Service) GetDocumentMovements(ctx context.Context, recorderID id.ID) ([]entity.DocumentMovement, error) {
+	movements, err := s.repo.GetMovementsByRecorder(ctx, recorderID)
+	if err != nil {
+		return nil, fmt.Errorf("get sales order movements: %w", err)
+	}
+
+	columns := []entity.MovementColumnDef{
+		{Key: "nomenclature", Label: "Номенклатура", Type: "ref"},
+		{Key: "order", Label: "Заказ покупателя", Type: "ref"},
+		{Key: "quantity", Label: "Количество", Type: "quantity"},
+	}
+
+	result := make([]entity.DocumentMovement, 0, len(movements))
+	for _, m := range movements {
+		data := map[string]any{
+			"nomenclature": entity.MovementRefValue{ID: m.NomenclatureID.String(), Name: m.NomenclatureID.String()},
+			"order":        entity.MovementRefValue{ID: m.OrderID.String(), Name: m.OrderID.String()},
+			"quantity":     m.Quantity.Float64(),
+		}
+
+		result = append(result, entity.DocumentMovement{
+			RegisterName: s.RegisterName(),
+			RecordType:   string(m.RecordType),
+			Period:       m.Period,
+			Columns:      columns,
+			Data:         data,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package exchange_rate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/domain"
+	"metapus/internal/domain/catalogs/currency"
+)
+
+// CurrencyDirectory is the part of the currency catalog the converter needs.
+// Implemented by catalog_repo.CurrencyRepo.
+type CurrencyDirectory interface {
+	GetByID(ctx context.Context, currencyID id.ID) (*currency.Currency, error)
+	GetBaseCurrency(ctx context.Context) (*currency.Currency, error)
+}
+
+// Converter converts amounts to the base (accounting) currency at the rate
+// in effect on a date. Implements domain.BaseCurrencyConverter.
+//
+// Rates come from all active sources; when several sources have a rate, the
+// source with the best priority wins (cat_rate_sources.priority).
+type Converter struct {
+	rates      Repository
+	currencies CurrencyDirectory
+}
+
+// NewConverter creates a currency converter.
+func NewConverter(rates Repository, currencies CurrencyDirectory) *Converter {
+	return &Converter{rates: rates, currencies: currencies}
+}
+
+// ToBase converts amount to the base currency at the rate effective on date.
+// Amounts already in the base currency are returned unchanged. Returns a
+// validation error when the currency has no rate on or before date.
+func (c *Converter) ToBase(ctx context.Context, amount types.Money, date time.Time) (types.Money, error) {
+	if amount.Amount.IsZero() {
+		base, err := c.currencies.GetBaseCurrency(ctx)
+		if err != nil {
+			return types.Money{}, fmt.Errorf("get base currency: %w", err)
+		}
+		return types.NewMoney(amount.Amount, base.ID), nil
+	}
+
+	rate, err := c.EffectiveRate(ctx, amount.CurrencyID, date)
+	if err != nil {
+		return types.Money{}, err
+	}
+	if rate == nil {
+		return amount, nil
+	}
+	return c.ToBaseAtRate(ctx, amount, *rate)
+}
+
+// EffectiveRate returns the rate of the currency in effect on date, or nil
+// for the base currency. Returns a validation error when the currency has
+// no rate on or before date.
+func (c *Converter) EffectiveRate(ctx context.Context, currencyID id.ID, date time.Time) (*domain.FixedRate, error) {
+	base, err := c.currencies.GetBaseCurrency(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get base currency: %w", err)
+	}
+	if currencyID == base.ID {
+		return nil, nil
+	}
+
+	rate, err := c.rates.GetEffectiveRate(ctx, currencyID, date)
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			code := currencyID.String()
+			if cur, cErr := c.currencies.GetByID(ctx, currencyID); cErr == nil && cur != nil {
+				code = cur.Code
+			}
+			return nil, apperror.NewValidation(
+				fmt.Sprintf("no exchange rate for currency %s on %s", code, date.Format("2006-01-02")),
+			).WithDetail("field", "currencyId").
+				WithDetail("currencyId", currencyID.String()).
+				WithDetail("date", date.Format("2006-01-02"))
+		}
+		return nil, fmt.Errorf("get exchange rate: %w", err)
+	}
+	return &domain.FixedRate{Rate: rate.Rate, Multiplier: rate.Multiplier}, nil
+}
+
+// ToBaseAtRate converts amount to the base currency at a fixed rate
+// (e.g. the rate frozen on a posted document).
+func (c *Converter) ToBaseAtRate(ctx context.Context, amount types.Money, rate domain.FixedRate) (types.Money, error) {
+	base, err := c.currencies.GetBaseCurrency(ctx)
+	if err != nil {
+		return types.Money{}, fmt.Errorf("get base currency: %w", err)
+	}
+	if amount.CurrencyID == base.ID || amount.Amount.IsZero() {
+		return types.NewMoney(amount.Amount, base.ID), nil
+	}
+
+	cur, err := c.currencies.GetByID(ctx, amount.CurrencyID)
+	if err != nil {
+		return types.Money{}, fmt.Errorf("get currency: %w", err)
+	}
+
+	r := ExchangeRate{Rate: rate.Rate, Multiplier: rate.Multiplier}
+	major := amount.Amount.ToDecimal(cur.DecimalPlaces)
+	converted := types.NewMinorUnitsFromDecimal(r.ToBaseAmount(major), base.DecimalPlaces)
+	return types.NewMoney(converted, base.ID), nil
+}
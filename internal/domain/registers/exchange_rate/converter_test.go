@@ -0,0 +1,107 @@
+package exchange_rate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/domain"
+	"metapus/internal/domain/catalogs/currency"
+)
+
+type fakeCurrencies map[id.ID]*currency.Currency
+
+func (f fakeCurrencies) GetByID(_ context.Context, currencyID id.ID) (*currency.Currency, error) {
+	return f[currencyID], nil
+}
+
+func (f fakeCurrencies) GetBaseCurrency(context.Context) (*currency.Currency, error) {
+	for _, c := range f {
+		if c.IsBase {
+			return c, nil
+		}
+	}
+	return nil, apperror.NewNotFound("currency", "base")
+}
+
+type fakeRates struct {
+	Repository
+	rates map[id.ID]*ExchangeRate
+}
+
+func (f fakeRates) GetEffectiveRate(_ context.Context, currencyID id.ID, _ time.Time) (*ExchangeRate, error) {
+	if r, ok := f.rates[currencyID]; ok {
+		return r, nil
+	}
+	return nil, apperror.NewNotFound("exchange_rate", currencyID.String())
+}
+
+func newCurrency(code string, decimals int, isBase bool) *currency.Currency {
+	return &currency.Currency{Catalog: entity.NewCatalog(code, code), DecimalPlaces: decimals, IsBase: isBase}
+}
+
+func TestConverterToBase(t *testing.T) {
+	rub := newCurrency("RUB", 2, true)
+	jpy := newCurrency("JPY", 0, false)
+	usd := newCurrency("USD", 2, false)
+	currencies := fakeCurrencies{rub.ID: rub, jpy.ID: jpy, usd.ID: usd}
+	rates := fakeRates{rates: map[id.ID]*ExchangeRate{
+		jpy.ID: {Rate: decimal.RequireFromString("54.32"), Multiplier: 100},
+	}}
+	conv := NewConverter(rates, currencies)
+	ctx := context.Background()
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	// 10 000 JPY at 54.32 RUB per 100 JPY = 5 432.00 RUB.
+	got, err := conv.ToBase(ctx, types.NewMoney(10000, jpy.ID), date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Amount != 543200 || got.CurrencyID != rub.ID {
+		t.Errorf("ToBase(JPY) = %d %s, want 543200 RUB", got.Amount, got.CurrencyID)
+	}
+
+	// Base currency amounts are returned as is.
+	got, err = conv.ToBase(ctx, types.NewMoney(1500, rub.ID), date)
+	if err != nil || got.Amount != 1500 {
+		t.Errorf("ToBase(RUB) = %d, %v", got.Amount, err)
+	}
+
+	// Missing rate is a validation error.
+	_, err = conv.ToBase(ctx, types.NewMoney(100, usd.ID), date)
+	if appErr, ok := apperror.AsAppError(err); !ok || appErr.Code != apperror.CodeValidation {
+		t.Errorf("missing rate error = %v, want validation error", err)
+	}
+}
+
+func TestConverterFixedRate(t *testing.T) {
+	rub := newCurrency("RUB", 2, true)
+	usd := newCurrency("USD", 2, false)
+	currencies := fakeCurrencies{rub.ID: rub, usd.ID: usd}
+	rates := fakeRates{rates: map[id.ID]*ExchangeRate{
+		usd.ID: {Rate: decimal.RequireFromString("95.5"), Multiplier: 1},
+	}}
+	conv := NewConverter(rates, currencies)
+	ctx := context.Background()
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	rate, err := conv.EffectiveRate(ctx, usd.ID, date)
+	if err != nil || rate == nil || !rate.Rate.Equal(decimal.RequireFromString("95.5")) || rate.Multiplier != 1 {
+		t.Fatalf("EffectiveRate(USD) = %+v, %v", rate, err)
+	}
+	if rate, err := conv.EffectiveRate(ctx, rub.ID, date); err != nil || rate != nil {
+		t.Errorf("EffectiveRate(RUB) = %+v, %v, want nil", rate, err)
+	}
+
+	// A frozen rate wins over the register: 100.00 USD at 90 = 9 000.00 RUB.
+	got, err := conv.ToBaseAtRate(ctx, types.NewMoney(10000, usd.ID), domain.FixedRate{Rate: decimal.NewFromInt(90), Multiplier: 1})
+	if err != nil || got.Amount != 900000 {
+		t.Errorf("ToBaseAtRate = %d, %v, want 900000", got.Amount, err)
+	}
+}
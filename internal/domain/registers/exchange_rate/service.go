@@ -10,6 +10,7 @@ import (
 
 	"github.com/shopspring/decimal"
 
+	"metapus/internal/core/apperror"
 	"metapus/internal/core/id"
 	"metapus/pkg/logger"
 )
@@ -44,8 +45,30 @@ type Repository interface {
 
 	// ListByCurrency returns all rates for a currency ordered by date DESC.
 	ListByCurrency(ctx context.Context, currencyID, rateSourceID id.ID, limit int) ([]ExchangeRate, error)
+
+	// GetEffectiveRate returns the rate in effect on asOf from the active source
+	// with the best priority that has one (lower priority value wins).
+	GetEffectiveRate(ctx context.Context, currencyID id.ID, asOf time.Time) (*ExchangeRate, error)
+
+	// List returns rates matching the filter ordered by date DESC.
+	List(ctx context.Context, filter ListFilter) ([]ExchangeRate, error)
+
+	// Delete removes the rate for (currency_id, date, rate_source_id).
+	Delete(ctx context.Context, currencyID, rateSourceID id.ID, date time.Time) error
+}
+
+// ListFilter selects rates for the rates API. Zero fields are not applied.
+type ListFilter struct {
+	CurrencyID   *id.ID
+	RateSourceID *id.ID
+	From         *time.Time // inclusive
+	To           *time.Time // inclusive
+	Limit        int
 }
 
+// _maxListLimit caps the number of rates returned by ListRates.
+const _maxListLimit = 1000
+
 // Service provides business operations for the exchange rates register.
 type Service struct {
 	repo Repository
@@ -58,14 +81,20 @@ func NewService(repo Repository) *Service {
 
 // UpsertRate creates or updates a rate record.
 func (s *Service) UpsertRate(ctx context.Context, rate *ExchangeRate) error {
+	if id.IsNil(rate.CurrencyID) {
+		return apperror.NewValidation("currency is required").WithDetail("field", "currencyId")
+	}
+	if rate.Date.IsZero() {
+		return apperror.NewValidation("rate date is required").WithDetail("field", "date")
+	}
 	if rate.Rate.LessThanOrEqual(decimal.Zero) {
-		return fmt.Errorf("exchange rate must be positive: %s", rate.Rate)
+		return apperror.NewValidation(fmt.Sprintf("exchange rate must be positive: %s", rate.Rate)).WithDetail("field", "rate")
 	}
 	if rate.Multiplier < 1 {
-		return fmt.Errorf("multiplier must be >= 1: %d", rate.Multiplier)
+		return apperror.NewValidation(fmt.Sprintf("multiplier must be >= 1: %d", rate.Multiplier)).WithDetail("field", "multiplier")
 	}
 	if id.IsNil(rate.RateSourceID) {
-		return fmt.Errorf("rate source ID is required")
+		return apperror.NewValidation("rate source is required").WithDetail("field", "rateSourceId")
 	}
 
 	if err := s.repo.Upsert(ctx, rate); err != nil {
@@ -91,3 +120,31 @@ func (s *Service) GetLatestRate(ctx context.Context, currencyID, rateSourceID id
 func (s *Service) GetRateOnDate(ctx context.Context, currencyID id.ID, date time.Time, rateSourceID id.ID) (*ExchangeRate, error) {
 	return s.repo.GetLatestRate(ctx, currencyID, rateSourceID, date)
 }
+
+// GetEffectiveRate returns the rate in effect on a date across all active
+// sources, preferring the source with the best priority.
+func (s *Service) GetEffectiveRate(ctx context.Context, currencyID id.ID, asOf time.Time) (*ExchangeRate, error) {
+	return s.repo.GetEffectiveRate(ctx, currencyID, asOf)
+}
+
+// ListRates returns rates matching the filter.
+func (s *Service) ListRates(ctx context.Context, filter ListFilter) ([]ExchangeRate, error) {
+	if filter.Limit <= 0 || filter.Limit > _maxListLimit {
+		filter.Limit = _maxListLimit
+	}
+	return s.repo.List(ctx, filter)
+}
+
+// DeleteRate removes a rate record.
+func (s *Service) DeleteRate(ctx context.Context, currencyID, rateSourceID id.ID, date time.Time) error {
+	if err := s.repo.Delete(ctx, currencyID, rateSourceID, date); err != nil {
+		return err
+	}
+
+	logger.Info(ctx, "exchange rate deleted",
+		"currency_id", currencyID,
+		"date", date.Format("2006-01-02"),
+		"rate_source_id", rateSourceID,
+	)
+	return nil
+}
@@ -0,0 +1,79 @@
+// Package serial_number provides the serial number register. Goods receipts
+// of nomenclature tracked by serial number (track_serial) record every
+// received serial (receipt), goods issues record every issued serial
+// (expense). The pool (reg_serial_numbers) keeps the state of each serial
+// ever received: in stock or issued, and the warehouse of its last receipt.
+package serial_number
+
+import (
+	"context"
+	"time"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+)
+
+// Repository defines storage operations for the serial number register.
+type Repository interface {
+	// CreateMovements batch inserts movements (used during posting)
+	CreateMovements(ctx context.Context, movements []entity.SerialNumberMovement) error
+
+	// DeleteMovementsByRecorder removes all movements for a document version
+	DeleteMovementsByRecorder(ctx context.Context, recorderID id.ID, beforeVersion int) error
+
+	// GetMovementsByRecorder retrieves all movements for a document
+	GetMovementsByRecorder(ctx context.Context, recorderID id.ID) ([]entity.SerialNumberMovement, error)
+
+	// LockSerials returns the pool rows of the keys with row locks, in key
+	// order. Missing rows are created first (not in stock).
+	LockSerials(ctx context.Context, keys []SerialKey) ([]Serial, error)
+
+	// RefreshSerials recalculates the pool rows of the keys from the movements.
+	RefreshSerials(ctx context.Context, keys []SerialKey) error
+
+	// List returns the serials in stock matching the filter.
+	List(ctx context.Context, filter ListFilter) ([]Serial, error)
+
+	// History returns the movements of one serial, oldest first.
+	History(ctx context.Context, key SerialKey) ([]HistoryEntry, error)
+}
+
+// SerialKey identifies a serial: serial numbers are unique per nomenclature.
+type SerialKey struct {
+	NomenclatureID id.ID
+	SerialNumber   string
+}
+
+// Serial is the state of a serial in the pool.
+type Serial struct {
+	NomenclatureID id.ID     `db:"nomenclature_id" json:"nomenclatureId"`
+	SerialNumber   string    `db:"serial_number" json:"serialNumber"`
+	WarehouseID    *id.ID    `db:"warehouse_id" json:"warehouseId,omitempty"`
+	Balance        int       `db:"balance" json:"-"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// InStock reports whether the serial was received and not issued since.
+func (s Serial) InStock() bool {
+	return s.Balance > 0
+}
+
+// ListFilter selects serials in stock.
+type ListFilter struct {
+	NomenclatureID *id.ID
+	// WarehouseID matches the warehouse of the last receipt.
+	WarehouseID *id.ID
+	// Search matches serial numbers by prefix, case-insensitively.
+	Search string
+	Limit  int
+}
+
+// HistoryEntry is one document movement of a serial.
+type HistoryEntry struct {
+	Period         time.Time         `db:"period" json:"period"`
+	RecordType     entity.RecordType `db:"record_type" json:"recordType"`
+	RecorderID     id.ID             `db:"recorder_id" json:"recorderId"`
+	RecorderType   string            `db:"recorder_type" json:"recorderType"`
+	DocumentNumber string            `db:"document_number" json:"documentNumber"`
+	WarehouseID    id.ID             `db:"warehouse_id" json:"warehouseId"`
+}
@@ -0,0 +1,195 @@
+package serial_number
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/pkg/logger"
+)
+
+// Service provides business operations for the serial number register.
+// Transactions are managed by the caller (posting engine).
+type Service struct {
+	repo Repository
+}
+
+// NewService creates a new serial number register service.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// RecordMovements records serial number movements from a document posting
+// and refreshes the pool.
+//
+// The serials are locked first: a serial already in stock cannot be received
+// again and a serial not in stock cannot be issued. The warehouse of an
+// issue is not checked against the warehouse of the last receipt, since
+// transfers between warehouses do not carry serials.
+func (s *Service) RecordMovements(ctx context.Context, movements []entity.SerialNumberMovement) error {
+	if len(movements) == 0 {
+		return nil
+	}
+
+	seen := make(map[SerialKey]entity.RecordType, len(movements))
+	for i, m := range movements {
+		if id.IsNil(m.RecorderID) {
+			return apperror.NewValidation(fmt.Sprintf("movement %d: recorder_id is required", i))
+		}
+		if id.IsNil(m.NomenclatureID) || m.SerialNumber == "" {
+			return apperror.NewValidation(fmt.Sprintf("movement %d: serial number is required", i))
+		}
+		k := SerialKey{m.NomenclatureID, m.SerialNumber}
+		if _, dup := seen[k]; dup {
+			return apperror.NewValidation(fmt.Sprintf("serial number %q is listed twice", m.SerialNumber)).
+				WithDetail("nomenclatureId", m.NomenclatureID.String())
+		}
+		seen[k] = m.RecordType
+	}
+
+	keys := movementKeys(movements)
+	serials, err := s.repo.LockSerials(ctx, keys)
+	if err != nil {
+		return fmt.Errorf("lock serial numbers: %w", err)
+	}
+	for _, serial := range serials {
+		switch seen[SerialKey{serial.NomenclatureID, serial.SerialNumber}] {
+		case entity.RecordTypeReceipt:
+			if serial.InStock() {
+				return apperror.NewBusinessRule("SERIAL_IN_STOCK",
+					fmt.Sprintf("serial number %q is already in stock", serial.SerialNumber)).
+					WithDetail("nomenclatureId", serial.NomenclatureID.String()).
+					WithDetail("serialNumber", serial.SerialNumber)
+			}
+		case entity.RecordTypeExpense:
+			if !serial.InStock() {
+				return apperror.NewBusinessRule("SERIAL_NOT_AVAILABLE",
+					fmt.Sprintf("serial number %q is not in stock", serial.SerialNumber)).
+					WithDetail("nomenclatureId", serial.NomenclatureID.String()).
+					WithDetail("serialNumber", serial.SerialNumber)
+			}
+		}
+	}
+
+	if err := s.repo.CreateMovements(ctx, movements); err != nil {
+		return fmt.Errorf("create serial number movements: %w", err)
+	}
+	if err := s.repo.RefreshSerials(ctx, keys); err != nil {
+		return fmt.Errorf("refresh serial numbers: %w", err)
+	}
+
+	logger.Info(ctx, "recorded serial number movements",
+		"count", len(movements),
+		"recorder_id", movements[0].RecorderID,
+	)
+	return nil
+}
+
+// ReverseMovements removes movements for a document (used during unposting)
+// and refreshes the pool. Like stock, unposting is not blocked when the
+// serial has moved since; re-posting checks the serials again.
+func (s *Service) ReverseMovements(ctx context.Context, recorderID id.ID, beforeVersion int) error {
+	movements, err := s.repo.GetMovementsByRecorder(ctx, recorderID)
+	if err != nil {
+		return fmt.Errorf("get serial number movements: %w", err)
+	}
+	if len(movements) == 0 {
+		return nil
+	}
+
+	if err := s.repo.DeleteMovementsByRecorder(ctx, recorderID, beforeVersion); err != nil {
+		return fmt.Errorf("delete serial number movements: %w", err)
+	}
+	if err := s.repo.RefreshSerials(ctx, movementKeys(movements)); err != nil {
+		return fmt.Errorf("refresh serial numbers: %w", err)
+	}
+
+	logger.Info(ctx, "reversed serial number movements",
+		"recorder_id", recorderID,
+		"before_version", beforeVersion,
+	)
+	return nil
+}
+
+// List returns the serials in stock matching the filter (selection on issue).
+func (s *Service) List(ctx context.Context, filter ListFilter) ([]Serial, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// History returns the documents that moved a serial, oldest first.
+func (s *Service) History(ctx context.Context, nomenclatureID id.ID, serialNumber string) ([]HistoryEntry, error) {
+	if id.IsNil(nomenclatureID) || serialNumber == "" {
+		return nil, apperror.NewValidation("nomenclatureId and serial are required")
+	}
+	return s.repo.History(ctx, SerialKey{nomenclatureID, serialNumber})
+}
+
+// SortSerialKeys sorts keys by nomenclature and serial number for resource
+// ordering. Prevents deadlocks when locking multiple pool rows.
+func SortSerialKeys(keys []SerialKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if c := bytes.Compare(keys[i].NomenclatureID[:], keys[j].NomenclatureID[:]); c != 0 {
+			return c < 0
+		}
+		return keys[i].SerialNumber < keys[j].SerialNumber
+	})
+}
+
+func movementKeys(movements []entity.SerialNumberMovement) []SerialKey {
+	seen := make(map[SerialKey]struct{}, len(movements))
+	keys := make([]SerialKey, 0, len(movements))
+	for _, m := range movements {
+		k := SerialKey{m.NomenclatureID, m.SerialNumber}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		keys = append(keys, k)
+	}
+	SortSerialKeys(keys)
+	return keys
+}
+
+// ---------------------------------------------------------------------------
+// Implementation of entity.MovementProvider
+// ---------------------------------------------------------------------------
+
+func (s *Service) RegisterName() string {
+	return "Серийные номера"
+}
+
+func (s *Service) GetDocumentMovements(ctx context.Context, recorderID id.ID) ([]entity.DocumentMovement, error) {
+	movements, err := s.repo.GetMovementsByRecorder(ctx, recorderID)
+	if err != nil {
+		return nil, fmt.Errorf("get serial number movements: %w", err)
+	}
+
+	columns := []entity.MovementColumnDef{
+		{Key: "warehouse", Label: "Склад", Type: "ref"},
+		{Key: "nomenclature", Label: "Номенклатура", Type: "ref"},
+		{Key: "serialNumber", Label: "Серийный номер", Type: "text"},
+	}
+
+	result := make([]entity.DocumentMovement, 0, len(movements))
+	for _, m := range movements {
+		data := map[string]any{
+			"warehouse":    entity.MovementRefValue{ID: m.WarehouseID.String(), Name: m.WarehouseID.String()},
+			"nomenclature": entity.MovementRefValue{ID: m.NomenclatureID.String(), Name: m.NomenclatureID.String()},
+			"serialNumber": m.SerialNumber,
+		}
+
+		result = append(result, entity.DocumentMovement{
+			RegisterName: s.RegisterName(),
+			RecordType:   string(m.RecordType),
+			Period:       m.Period,
+			Columns:      columns,
+			Data:         data,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package serial_number
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+)
+
+// fakeRepo serves fixed serial balances and records created movements.
+type fakeRepo struct {
+	Repository
+	balances  map[SerialKey]int
+	created   []entity.SerialNumberMovement
+	refreshed []SerialKey
+}
+
+func (r *fakeRepo) LockSerials(_ context.Context, keys []SerialKey) ([]Serial, error) {
+	out := make([]Serial, len(keys))
+	for i, k := range keys {
+		out[i] = Serial{NomenclatureID: k.NomenclatureID, SerialNumber: k.SerialNumber, Balance: r.balances[k]}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) CreateMovements(_ context.Context, movements []entity.SerialNumberMovement) error {
+	r.created = append(r.created, movements...)
+	return nil
+}
+
+func (r *fakeRepo) RefreshSerials(_ context.Context, keys []SerialKey) error {
+	r.refreshed = append(r.refreshed, keys...)
+	return nil
+}
+
+func TestRecordMovementsChecksAvailability(t *testing.T) {
+	itemID, warehouseID := id.New(), id.New()
+	repo := &fakeRepo{balances: map[SerialKey]int{
+		{itemID, "SN-1"}: 1,
+		{itemID, "SN-2"}: 0,
+	}}
+	svc := NewService(repo)
+
+	move := func(recordType entity.RecordType, serial string) entity.SerialNumberMovement {
+		return entity.NewSerialNumberMovement(id.New(), "GoodsIssue", 1, time.Now(),
+			recordType, warehouseID, itemID, serial)
+	}
+
+	cases := []struct {
+		name     string
+		movement entity.SerialNumberMovement
+		code     string
+	}{
+		{"issue in stock", move(entity.RecordTypeExpense, "SN-1"), ""},
+		{"issue already issued", move(entity.RecordTypeExpense, "SN-2"), "SERIAL_NOT_AVAILABLE"},
+		{"issue unknown", move(entity.RecordTypeExpense, "SN-3"), "SERIAL_NOT_AVAILABLE"},
+		{"receive in stock", move(entity.RecordTypeReceipt, "SN-1"), "SERIAL_IN_STOCK"},
+		{"receive returned", move(entity.RecordTypeReceipt, "SN-2"), ""},
+	}
+	for _, tc := range cases {
+		repo.created = nil
+		err := svc.RecordMovements(context.Background(), []entity.SerialNumberMovement{tc.movement})
+		if tc.code == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tc.name, err)
+			} else if len(repo.created) != 1 {
+				t.Errorf("%s: created %d movements, want 1", tc.name, len(repo.created))
+			}
+			continue
+		}
+		appErr, ok := apperror.AsAppError(err)
+		if !ok || appErr.Code != tc.code {
+			t.Errorf("%s: err = %v, want %s", tc.name, err, tc.code)
+		}
+		if len(repo.created) != 0 {
+			t.Errorf("%s: movements created despite the error", tc.name)
+		}
+	}
+}
+
+func TestRecordMovementsRejectsDuplicateSerial(t *testing.T) {
+	itemID, warehouseID, docID := id.New(), id.New(), id.New()
+	svc := NewService(&fakeRepo{})
+
+	m := entity.NewSerialNumberMovement(docID, "GoodsReceipt", 1, time.Now(),
+		entity.RecordTypeReceipt, warehouseID, itemID, "SN-1")
+	err := svc.RecordMovements(context.Background(), []entity.SerialNumberMovement{m, m})
+	if appErr, ok := apperror.AsAppError(err); !ok || appErr.Code != apperror.CodeValidation {
+		t.Fatalf("err = %v, want a validation error", err)
+	}
+}
@@ -0,0 +1,286 @@
+package stock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"metapus/internal/core/apperror"
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/core/types"
+	"metapus/pkg/logger"
+)
+
+// CorrectionRecorderType is the recorder_type of movements written by a stock correction.
+const CorrectionRecorderType = "stock_correction"
+
+// maxCorrectionDocuments bounds the document set of a single correction.
+const maxCorrectionDocuments = 1000
+
+// CorrectionFilter selects the movements to correct.
+// RecorderIDs is the document set; warehouse/nomenclature narrow it down.
+type CorrectionFilter struct {
+	RecorderIDs    []id.ID `json:"recorderIds"`
+	WarehouseID    *id.ID  `json:"warehouseId,omitempty"`
+	NomenclatureID *id.ID  `json:"nomenclatureId,omitempty"`
+}
+
+// CorrectionRequest describes a bulk reassignment of stock movements.
+// At least one target dimension must be set; unset targets keep the original value.
+type CorrectionRequest struct {
+	Filter               CorrectionFilter
+	TargetWarehouseID    *id.ID
+	TargetNomenclatureID *id.ID
+	Reason               string
+}
+
+// Validate checks the request shape (no I/O).
+func (r CorrectionRequest) Validate() error {
+	if len(r.Filter.RecorderIDs) == 0 {
+		return apperror.NewValidation("at least one document is required").WithDetail("field", "recorderIds")
+	}
+	if len(r.Filter.RecorderIDs) > maxCorrectionDocuments {
+		return apperror.NewValidation(fmt.Sprintf("too many documents (max %d)", maxCorrectionDocuments)).
+			WithDetail("field", "recorderIds")
+	}
+	if r.TargetWarehouseID == nil && r.TargetNomenclatureID == nil {
+		return apperror.NewValidation("targetWarehouseId or targetNomenclatureId is required")
+	}
+	if r.TargetWarehouseID != nil && id.IsNil(*r.TargetWarehouseID) {
+		return apperror.NewValidation("invalid targetWarehouseId").WithDetail("field", "targetWarehouseId")
+	}
+	if r.TargetNomenclatureID != nil && id.IsNil(*r.TargetNomenclatureID) {
+		return apperror.NewValidation("invalid targetNomenclatureId").WithDetail("field", "targetNomenclatureId")
+	}
+	if strings.TrimSpace(r.Reason) == "" {
+		return apperror.NewValidation("reason is required").WithDetail("field", "reason")
+	}
+	return nil
+}
+
+// Correction is the system document that records a bulk movement correction.
+// Its adjustment movements are stored in reg_stock_movements with
+// recorder_id = Correction.ID and recorder_type = CorrectionRecorderType.
+type Correction struct {
+	ID                   id.ID            `db:"id" json:"id"`
+	Reason               string           `db:"reason" json:"reason"`
+	Filter               CorrectionFilter `db:"filter" json:"filter"`
+	TargetWarehouseID    *id.ID           `db:"target_warehouse_id" json:"targetWarehouseId,omitempty"`
+	TargetNomenclatureID *id.ID           `db:"target_nomenclature_id" json:"targetNomenclatureId,omitempty"`
+	MovementCount        int              `db:"movement_count" json:"movementCount"`
+	CreatedBy            string           `db:"created_by" json:"createdBy,omitempty"`
+	CreatedAt            time.Time        `db:"created_at" json:"createdAt"`
+
+	// SourceLineIDs are the corrected movements; each movement is corrected
+	// at most once.
+	SourceLineIDs []id.ID `db:"-" json:"-"`
+}
+
+// BalanceChange is the projected effect of a correction on one balance key.
+type BalanceChange struct {
+	WarehouseID    id.ID          `json:"warehouseId"`
+	NomenclatureID id.ID          `json:"nomenclatureId"`
+	Current        types.Quantity `json:"current"`
+	Delta          types.Quantity `json:"delta"`
+	Resulting      types.Quantity `json:"resulting"`
+}
+
+// CorrectionPlan is the result of a preview: affected movements, the
+// adjustment movements that would be written and the balance impact.
+type CorrectionPlan struct {
+	Movements      []entity.StockMovement
+	Adjustments    []entity.StockMovement
+	BalanceChanges []BalanceChange
+}
+
+// CorrectionRepository stores correction system documents.
+type CorrectionRepository interface {
+	// Create saves a correction document with its source lines. Returns a
+	// conflict error when a source movement was corrected or deleted meanwhile.
+	Create(ctx context.Context, c *Correction) error
+
+	// List returns corrections, newest first.
+	List(ctx context.Context, limit, offset int) ([]Correction, error)
+}
+
+// CorrectionService performs admin bulk corrections of stock movements.
+// Original movements are never modified: each affected movement is reversed
+// and re-recorded with the target dimensions under a Correction document,
+// so balances are adjusted by the regular register triggers. A movement is
+// corrected once; its document cannot be unposted or re-posted afterwards
+// (Service.ReverseMovements).
+type CorrectionService struct {
+	repo        Repository
+	corrections CorrectionRepository
+}
+
+// NewCorrectionService creates a new correction service.
+func NewCorrectionService(repo Repository, corrections CorrectionRepository) *CorrectionService {
+	return &CorrectionService{
+		repo:        repo,
+		corrections: corrections,
+	}
+}
+
+// Preview returns the correction plan without writing anything.
+func (s *CorrectionService) Preview(ctx context.Context, req CorrectionRequest) (*CorrectionPlan, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.plan(ctx, req, id.Nil(), s.repo.GetBalances)
+}
+
+// Apply executes the correction in a single transaction and returns the
+// created correction document together with the applied plan.
+func (s *CorrectionService) Apply(ctx context.Context, req CorrectionRequest) (*Correction, *CorrectionPlan, error) {
+	if err := req.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	txm, err := tenant.GetTxManager(ctx)
+	if err != nil {
+		return nil, nil, apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+
+	doc := &Correction{
+		ID:                   id.New(),
+		Reason:               strings.TrimSpace(req.Reason),
+		Filter:               req.Filter,
+		TargetWarehouseID:    req.TargetWarehouseID,
+		TargetNomenclatureID: req.TargetNomenclatureID,
+		CreatedBy:            appctx.GetUserID(ctx),
+		CreatedAt:            time.Now().UTC(),
+	}
+
+	var plan *CorrectionPlan
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		plan, err = s.plan(ctx, req, doc.ID, s.repo.GetBalancesForUpdate)
+		if err != nil {
+			return err
+		}
+
+		// Reject corrections that would drive a balance below zero.
+		for _, bc := range plan.BalanceChanges {
+			if bc.Delta < 0 && bc.Resulting < 0 {
+				return apperror.NewInsufficientStock(
+					bc.NomenclatureID.String(),
+					bc.Delta.Neg().Float64(),
+					bc.Current.Float64(),
+				)
+			}
+		}
+
+		doc.MovementCount = len(plan.Movements)
+		doc.SourceLineIDs = make([]id.ID, len(plan.Movements))
+		for i, m := range plan.Movements {
+			doc.SourceLineIDs[i] = m.LineID
+		}
+		if err := s.corrections.Create(ctx, doc); err != nil {
+			return fmt.Errorf("create correction: %w", err)
+		}
+		if err := s.repo.CreateMovements(ctx, plan.Adjustments); err != nil {
+			return fmt.Errorf("create adjustment movements: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger.Info(ctx, "stock movements corrected",
+		"correction_id", doc.ID,
+		"movements", doc.MovementCount,
+		"documents", len(req.Filter.RecorderIDs),
+	)
+	return doc, plan, nil
+}
+
+// List returns correction documents, newest first.
+func (s *CorrectionService) List(ctx context.Context, limit, offset int) ([]Correction, error) {
+	return s.corrections.List(ctx, limit, offset)
+}
+
+// plan builds adjustment movements and balance changes. Apply reads the
+// balances with row locks so that it sees a consistent picture; Preview
+// reads them without locks, as it may run on a read replica.
+func (s *CorrectionService) plan(ctx context.Context, req CorrectionRequest, recorderID id.ID,
+	getBalances func(context.Context, []BalanceKey) ([]entity.StockBalance, error)) (*CorrectionPlan, error) {
+	movements, err := s.repo.GetMovementsForCorrection(ctx, req.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("get movements for correction: %w", err)
+	}
+
+	plan := &CorrectionPlan{}
+	deltas := make(map[BalanceKey]types.Quantity)
+	var keys []BalanceKey
+	addDelta := func(k BalanceKey, q types.Quantity) {
+		if _, ok := deltas[k]; !ok {
+			keys = append(keys, k)
+		}
+		deltas[k] += q
+	}
+
+	for _, m := range movements {
+		targetWh, targetNom := m.WarehouseID, m.NomenclatureID
+		if req.TargetWarehouseID != nil {
+			targetWh = *req.TargetWarehouseID
+		}
+		if req.TargetNomenclatureID != nil {
+			targetNom = *req.TargetNomenclatureID
+		}
+		if targetWh == m.WarehouseID && targetNom == m.NomenclatureID {
+			continue // already on target dimensions
+		}
+
+		reversal := entity.NewStockMovement(recorderID, CorrectionRecorderType, 1, m.Period,
+			oppositeRecordType(m.RecordType), m.WarehouseID, m.NomenclatureID, m.Quantity)
+		replacement := entity.NewStockMovement(recorderID, CorrectionRecorderType, 1, m.Period,
+			m.RecordType, targetWh, targetNom, m.Quantity)
+
+		plan.Movements = append(plan.Movements, m)
+		plan.Adjustments = append(plan.Adjustments, reversal, replacement)
+		addDelta(BalanceKey{m.WarehouseID, m.NomenclatureID}, reversal.SignedQuantity())
+		addDelta(BalanceKey{targetWh, targetNom}, replacement.SignedQuantity())
+	}
+
+	if len(plan.Movements) == 0 {
+		return nil, apperror.NewValidation("no movements match the correction filter (movements corrected earlier are excluded)")
+	}
+
+	balances, err := getBalances(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("get balances: %w", err)
+	}
+	current := make(map[BalanceKey]types.Quantity, len(balances))
+	for _, b := range balances {
+		current[BalanceKey{b.WarehouseID, b.NomenclatureID}] = b.Quantity
+	}
+
+	for _, k := range keys {
+		delta := deltas[k]
+		if delta == 0 {
+			continue
+		}
+		plan.BalanceChanges = append(plan.BalanceChanges, BalanceChange{
+			WarehouseID:    k.WarehouseID,
+			NomenclatureID: k.NomenclatureID,
+			Current:        current[k],
+			Delta:          delta,
+			Resulting:      current[k] + delta,
+		})
+	}
+
+	return plan, nil
+}
+
+func oppositeRecordType(rt entity.RecordType) entity.RecordType {
+	if rt == entity.RecordTypeReceipt {
+		return entity.RecordTypeExpense
+	}
+	return entity.RecordTypeReceipt
+}
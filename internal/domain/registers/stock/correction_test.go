@@ -0,0 +1,93 @@
+package stock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+)
+
+// fakeCorrectionRepo serves fixed movements and balances and records which
+// balance read the service used.
+type fakeCorrectionRepo struct {
+	Repository
+	movements  []entity.StockMovement
+	corrected  int
+	lockedRead bool
+	deleted    bool
+}
+
+func (r *fakeCorrectionRepo) GetMovementsForCorrection(context.Context, CorrectionFilter) ([]entity.StockMovement, error) {
+	return r.movements, nil
+}
+
+func (r *fakeCorrectionRepo) GetBalances(_ context.Context, keys []BalanceKey) ([]entity.StockBalance, error) {
+	out := make([]entity.StockBalance, len(keys))
+	for i, k := range keys {
+		out[i] = entity.StockBalance{WarehouseID: k.WarehouseID, NomenclatureID: k.NomenclatureID,
+			Quantity: types.NewQuantityFromFloat64(10)}
+	}
+	return out, nil
+}
+
+func (r *fakeCorrectionRepo) GetBalancesForUpdate(ctx context.Context, keys []BalanceKey) ([]entity.StockBalance, error) {
+	r.lockedRead = true
+	return r.GetBalances(ctx, keys)
+}
+
+func (r *fakeCorrectionRepo) CountCorrectedMovements(context.Context, id.ID, int) (int, error) {
+	return r.corrected, nil
+}
+
+func (r *fakeCorrectionRepo) DeleteMovementsByRecorder(context.Context, id.ID, int) error {
+	r.deleted = true
+	return nil
+}
+
+// Preview may run on a read replica, which rejects row locks.
+func TestCorrectionPreviewReadsBalancesWithoutLocks(t *testing.T) {
+	from, to, nom := id.New(), id.New(), id.New()
+	repo := &fakeCorrectionRepo{movements: []entity.StockMovement{
+		entity.NewStockMovement(id.New(), "GoodsReceipt", 1, time.Now(), entity.RecordTypeReceipt,
+			from, nom, types.NewQuantityFromFloat64(3)),
+	}}
+	svc := NewCorrectionService(repo, nil)
+
+	plan, err := svc.Preview(context.Background(), CorrectionRequest{
+		Filter:            CorrectionFilter{RecorderIDs: []id.ID{repo.movements[0].RecorderID}},
+		TargetWarehouseID: &to,
+		Reason:            "wrong warehouse",
+	})
+	if err != nil {
+		t.Fatalf("Preview: %v", err)
+	}
+	if repo.lockedRead {
+		t.Error("Preview read balances FOR UPDATE")
+	}
+	if len(plan.BalanceChanges) != 2 || plan.BalanceChanges[0].Resulting != types.NewQuantityFromFloat64(7) {
+		t.Errorf("balance changes = %+v", plan.BalanceChanges)
+	}
+}
+
+func TestReverseMovementsRejectsCorrectedDocument(t *testing.T) {
+	repo := &fakeCorrectionRepo{corrected: 2}
+	err := NewService(repo).ReverseMovements(context.Background(), id.New(), 3)
+
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) || appErr.Code != "STOCK_MOVEMENTS_CORRECTED" {
+		t.Fatalf("got %v, want STOCK_MOVEMENTS_CORRECTED", err)
+	}
+	if repo.deleted {
+		t.Error("corrected movements were deleted")
+	}
+
+	repo.corrected = 0
+	if err := NewService(repo).ReverseMovements(context.Background(), id.New(), 3); err != nil || !repo.deleted {
+		t.Fatalf("uncorrected document: err = %v, deleted = %v", err, repo.deleted)
+	}
+}
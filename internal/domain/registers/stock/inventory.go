@@ -0,0 +1,240 @@
+package stock
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"metapus/internal/core/apperror"
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/settings"
+	"metapus/pkg/logger"
+)
+
+// Inventory count statuses.
+const (
+	InventoryInProgress = "in_progress"
+	InventoryCompleted  = "completed"
+	InventoryCancelled  = "cancelled"
+)
+
+// Inventory freeze modes (settings.KeyStockInventoryFreeze).
+const (
+	FreezeOff      = "off"      // counts do not affect posting
+	FreezeBlock    = "block"    // movements in a counted scope are rejected
+	FreezeOverride = "override" // rejected unless the user may override the freeze
+)
+
+// PermissionOverrideFreeze allows posting into a counted scope in FreezeOverride mode.
+const PermissionOverrideFreeze = "inventory_count.override_freeze"
+
+// InventoryCount is the system document of a physical stock count.
+// While it is in progress, stock movements in its scope may be frozen
+// so that the counted quantities stay valid.
+type InventoryCount struct {
+	ID              id.ID      `db:"id" json:"id"`
+	WarehouseID     id.ID      `db:"warehouse_id" json:"warehouseId"`
+	NomenclatureIDs []id.ID    `db:"nomenclature_ids" json:"nomenclatureIds"` // empty = whole warehouse
+	Status          string     `db:"status" json:"status"`
+	Comment         string     `db:"comment" json:"comment,omitempty"`
+	StartedBy       string     `db:"started_by" json:"startedBy,omitempty"`
+	StartedAt       time.Time  `db:"started_at" json:"startedAt"`
+	FinishedAt      *time.Time `db:"finished_at" json:"finishedAt,omitempty"`
+}
+
+// Covers reports whether the count scope includes the nomenclature.
+func (c InventoryCount) Covers(nomenclatureID id.ID) bool {
+	return len(c.NomenclatureIDs) == 0 || slices.Contains(c.NomenclatureIDs, nomenclatureID)
+}
+
+// overlaps reports whether two counts of the same warehouse share any nomenclature.
+func (c InventoryCount) overlaps(other InventoryCount) bool {
+	if len(c.NomenclatureIDs) == 0 || len(other.NomenclatureIDs) == 0 {
+		return true
+	}
+	for _, nid := range c.NomenclatureIDs {
+		if other.Covers(nid) {
+			return true
+		}
+	}
+	return false
+}
+
+// InventoryRepository stores inventory count system documents.
+type InventoryRepository interface {
+	// Create saves a new inventory count.
+	Create(ctx context.Context, c *InventoryCount) error
+
+	// GetByID returns an inventory count.
+	GetByID(ctx context.Context, countID id.ID) (*InventoryCount, error)
+
+	// Finish sets the final status and finish time of an in-progress count.
+	Finish(ctx context.Context, c *InventoryCount) error
+
+	// List returns counts, newest first. Empty status means all statuses.
+	List(ctx context.Context, status string, limit, offset int) ([]InventoryCount, error)
+
+	// ListInProgress returns in-progress counts of the given warehouses.
+	ListInProgress(ctx context.Context, warehouseIDs []id.ID) ([]InventoryCount, error)
+}
+
+// InventoryService manages inventory counts and enforces the posting freeze.
+type InventoryService struct {
+	repo InventoryRepository
+}
+
+// NewInventoryService creates a new inventory service.
+func NewInventoryService(repo InventoryRepository) *InventoryService {
+	return &InventoryService{repo: repo}
+}
+
+// Start opens an inventory count for a warehouse (or a part of its nomenclature).
+// Overlapping in-progress counts of the same warehouse are rejected.
+func (s *InventoryService) Start(ctx context.Context, warehouseID id.ID, nomenclatureIDs []id.ID, comment string) (*InventoryCount, error) {
+	if id.IsNil(warehouseID) {
+		return nil, apperror.NewValidation("warehouse is required").WithDetail("field", "warehouseId")
+	}
+	for _, nid := range nomenclatureIDs {
+		if id.IsNil(nid) {
+			return nil, apperror.NewValidation("invalid nomenclature id").WithDetail("field", "nomenclatureIds")
+		}
+	}
+
+	txm, err := tenant.GetTxManager(ctx)
+	if err != nil {
+		return nil, apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+
+	count := &InventoryCount{
+		ID:              id.New(),
+		WarehouseID:     warehouseID,
+		NomenclatureIDs: nomenclatureIDs,
+		Status:          InventoryInProgress,
+		Comment:         comment,
+		StartedBy:       appctx.GetUserID(ctx),
+		StartedAt:       time.Now().UTC(),
+	}
+
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		active, err := s.repo.ListInProgress(ctx, []id.ID{warehouseID})
+		if err != nil {
+			return fmt.Errorf("list in-progress counts: %w", err)
+		}
+		for _, a := range active {
+			if a.overlaps(*count) {
+				return apperror.NewConflict("an inventory count is already in progress for this scope").
+					WithDetail("inventoryCountId", a.ID.String())
+			}
+		}
+		if err := s.repo.Create(ctx, count); err != nil {
+			return fmt.Errorf("create inventory count: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(ctx, "inventory count started",
+		"inventory_count_id", count.ID,
+		"warehouse_id", warehouseID,
+		"nomenclature_count", len(nomenclatureIDs),
+	)
+	return count, nil
+}
+
+// Complete finishes an in-progress count and lifts its freeze.
+func (s *InventoryService) Complete(ctx context.Context, countID id.ID) (*InventoryCount, error) {
+	return s.finish(ctx, countID, InventoryCompleted)
+}
+
+// Cancel abandons an in-progress count and lifts its freeze.
+func (s *InventoryService) Cancel(ctx context.Context, countID id.ID) (*InventoryCount, error) {
+	return s.finish(ctx, countID, InventoryCancelled)
+}
+
+func (s *InventoryService) finish(ctx context.Context, countID id.ID, status string) (*InventoryCount, error) {
+	count, err := s.repo.GetByID(ctx, countID)
+	if err != nil {
+		return nil, err
+	}
+	if count.Status != InventoryInProgress {
+		return nil, apperror.NewBusinessRule("INVENTORY_NOT_IN_PROGRESS",
+			fmt.Sprintf("inventory count is %s", count.Status))
+	}
+
+	now := time.Now().UTC()
+	count.Status = status
+	count.FinishedAt = &now
+	if err := s.repo.Finish(ctx, count); err != nil {
+		return nil, err
+	}
+
+	logger.Info(ctx, "inventory count finished",
+		"inventory_count_id", count.ID,
+		"status", status,
+	)
+	return count, nil
+}
+
+// List returns inventory counts, newest first.
+func (s *InventoryService) List(ctx context.Context, status string, limit, offset int) ([]InventoryCount, error) {
+	return s.repo.List(ctx, status, limit, offset)
+}
+
+// FreezeActive reports whether the tenant freezes stock during inventory counts.
+func (s *InventoryService) FreezeActive(ctx context.Context) bool {
+	return settings.Get(ctx, settings.KeyStockInventoryFreeze, settings.Subject{}) != FreezeOff
+}
+
+// CheckFreeze rejects movements that hit the scope of an in-progress count,
+// unless the freeze is off or the user may override it.
+func (s *InventoryService) CheckFreeze(ctx context.Context, movements []entity.StockMovement) error {
+	if len(movements) == 0 {
+		return nil
+	}
+	mode := settings.Get(ctx, settings.KeyStockInventoryFreeze, settings.Subject{})
+	if mode == FreezeOff || (mode == FreezeOverride && canOverrideFreeze(ctx)) {
+		return nil
+	}
+
+	warehouseIDs := make([]id.ID, 0, 1)
+	for _, m := range movements {
+		if !slices.Contains(warehouseIDs, m.WarehouseID) {
+			warehouseIDs = append(warehouseIDs, m.WarehouseID)
+		}
+	}
+
+	active, err := s.repo.ListInProgress(ctx, warehouseIDs)
+	if err != nil {
+		return fmt.Errorf("list in-progress counts: %w", err)
+	}
+	if len(active) == 0 {
+		return nil
+	}
+
+	for _, m := range movements {
+		for _, c := range active {
+			if c.WarehouseID == m.WarehouseID && c.Covers(m.NomenclatureID) {
+				return apperror.NewBusinessRule("INVENTORY_IN_PROGRESS",
+					"stock is frozen by an inventory count in progress").
+					WithDetail("inventoryCountId", c.ID.String()).
+					WithDetail("warehouseId", m.WarehouseID.String()).
+					WithDetail("nomenclatureId", m.NomenclatureID.String())
+			}
+		}
+	}
+	return nil
+}
+
+func canOverrideFreeze(ctx context.Context) bool {
+	u := appctx.GetUser(ctx)
+	if u == nil {
+		return false
+	}
+	return u.IsAdmin || slices.Contains(u.Permissions, PermissionOverrideFreeze)
+}
@@ -0,0 +1,123 @@
+package stock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/core/types"
+	"metapus/internal/domain/settings"
+)
+
+// fakeInventoryRepo serves a fixed set of in-progress counts.
+type fakeInventoryRepo struct {
+	InventoryRepository
+	active []InventoryCount
+}
+
+func (r *fakeInventoryRepo) ListInProgress(_ context.Context, warehouseIDs []id.ID) ([]InventoryCount, error) {
+	var out []InventoryCount
+	for _, c := range r.active {
+		for _, wh := range warehouseIDs {
+			if c.WarehouseID == wh {
+				out = append(out, c)
+			}
+		}
+	}
+	return out, nil
+}
+
+// freezeStore holds a single tenant value of stock.inventoryFreeze.
+type freezeStore struct{ mode string }
+
+func (s *freezeStore) ListValues(context.Context) ([]settings.Value, error) {
+	raw, _ := json.Marshal(s.mode)
+	return []settings.Value{{Key: settings.KeyStockInventoryFreeze.Name(), Scope: settings.ScopeTenant, Value: raw}}, nil
+}
+
+func (s *freezeStore) SetValue(_ context.Context, v settings.Value) (settings.Value, error) {
+	return v, nil
+}
+
+func (s *freezeStore) DeleteValue(context.Context, string, settings.Scope, id.ID) error { return nil }
+
+func freezeContext(mode string, user *appctx.UserContext) context.Context {
+	ctx := tenant.WithTenant(context.Background(), &tenant.Tenant{ID: "t1"})
+	ctx = settings.WithResolver(ctx, settings.NewResolver(&freezeStore{mode: mode}))
+	if user != nil {
+		ctx = appctx.WithUser(ctx, user)
+	}
+	return ctx
+}
+
+func TestInventoryCheckFreeze(t *testing.T) {
+	warehouseID, otherWarehouseID := id.New(), id.New()
+	counted, notCounted := id.New(), id.New()
+	svc := NewInventoryService(&fakeInventoryRepo{active: []InventoryCount{{
+		ID:              id.New(),
+		WarehouseID:     warehouseID,
+		NomenclatureIDs: []id.ID{counted},
+		Status:          InventoryInProgress,
+	}}})
+
+	movement := func(wh, nom id.ID) []entity.StockMovement {
+		return []entity.StockMovement{entity.NewStockMovement(id.New(), "GoodsReceipt", 1,
+			time.Now(), entity.RecordTypeReceipt, wh, nom, types.NewQuantityFromFloat64(1))}
+	}
+	clerk := &appctx.UserContext{UserID: "u1"}
+	supervisor := &appctx.UserContext{UserID: "u2", Permissions: []string{PermissionOverrideFreeze}}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		movements []entity.StockMovement
+		frozen    bool
+	}{
+		{"off", freezeContext(FreezeOff, clerk), movement(warehouseID, counted), false},
+		{"block counted", freezeContext(FreezeBlock, clerk), movement(warehouseID, counted), true},
+		{"block permission ignored", freezeContext(FreezeBlock, supervisor), movement(warehouseID, counted), true},
+		{"block outside scope", freezeContext(FreezeBlock, clerk), movement(warehouseID, notCounted), false},
+		{"block other warehouse", freezeContext(FreezeBlock, clerk), movement(otherWarehouseID, counted), false},
+		{"override without permission", freezeContext(FreezeOverride, clerk), movement(warehouseID, counted), true},
+		{"override with permission", freezeContext(FreezeOverride, supervisor), movement(warehouseID, counted), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.CheckFreeze(tt.ctx, tt.movements)
+			if !tt.frozen {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var appErr *apperror.AppError
+			if !errors.As(err, &appErr) || appErr.Code != "INVENTORY_IN_PROGRESS" {
+				t.Fatalf("got %v, want INVENTORY_IN_PROGRESS", err)
+			}
+		})
+	}
+}
+
+func TestInventoryCountOverlaps(t *testing.T) {
+	a, b := id.New(), id.New()
+	whole := InventoryCount{}
+	onlyA := InventoryCount{NomenclatureIDs: []id.ID{a}}
+	onlyB := InventoryCount{NomenclatureIDs: []id.ID{b}}
+
+	if !whole.overlaps(onlyA) || !onlyA.overlaps(whole) {
+		t.Error("whole-warehouse count must overlap any count")
+	}
+	if onlyA.overlaps(onlyB) {
+		t.Error("disjoint nomenclature scopes must not overlap")
+	}
+	if !onlyA.overlaps(InventoryCount{NomenclatureIDs: []id.ID{b, a}}) {
+		t.Error("shared nomenclature must overlap")
+	}
+}
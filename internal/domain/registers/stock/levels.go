@@ -0,0 +1,169 @@
+package stock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/core/types"
+	"metapus/pkg/logger"
+)
+
+// PermissionLowStockAlerts is held by the recipients of low-stock alerts.
+const PermissionLowStockAlerts = "stock_level.alerts"
+
+// maxLevelListLimit caps the number of levels returned by ListLevels.
+const maxLevelListLimit = 1000
+
+// Level is the minimum (and optional maximum) stock of a nomenclature in a
+// warehouse. A balance below MinQuantity raises a low-stock alert;
+// replenishment suggestions fill up to MaxQuantity (MinQuantity when unset).
+type Level struct {
+	WarehouseID    id.ID           `db:"warehouse_id" json:"warehouseId"`
+	NomenclatureID id.ID           `db:"nomenclature_id" json:"nomenclatureId"`
+	MinQuantity    types.Quantity  `db:"min_quantity" json:"minQuantity"`
+	MaxQuantity    *types.Quantity `db:"max_quantity" json:"maxQuantity,omitempty"`
+	UpdatedAt      time.Time       `db:"updated_at" json:"updatedAt"`
+}
+
+// Validate checks the level shape (no I/O).
+func (l *Level) Validate() error {
+	if id.IsNil(l.WarehouseID) {
+		return apperror.NewValidation("warehouse is required").WithDetail("field", "warehouseId")
+	}
+	if id.IsNil(l.NomenclatureID) {
+		return apperror.NewValidation("nomenclature is required").WithDetail("field", "nomenclatureId")
+	}
+	if l.MinQuantity.IsNegative() {
+		return apperror.NewValidation("minQuantity must not be negative").WithDetail("field", "minQuantity")
+	}
+	if l.MaxQuantity != nil && *l.MaxQuantity < l.MinQuantity {
+		return apperror.NewValidation("maxQuantity must not be less than minQuantity").WithDetail("field", "maxQuantity")
+	}
+	return nil
+}
+
+// LevelFilter selects levels. Zero fields are not applied.
+type LevelFilter struct {
+	WarehouseID    *id.ID
+	NomenclatureID *id.ID
+	Limit          int
+}
+
+// LowStockAlert is a balance below its minimum level. It stays active until
+// the balance recovers.
+type LowStockAlert struct {
+	WarehouseID      id.ID          `db:"warehouse_id" json:"warehouseId"`
+	NomenclatureID   id.ID          `db:"nomenclature_id" json:"nomenclatureId"`
+	WarehouseName    string         `db:"warehouse_name" json:"warehouseName"`
+	NomenclatureName string         `db:"nomenclature_name" json:"nomenclatureName"`
+	Quantity         types.Quantity `db:"quantity" json:"quantity"`
+	MinQuantity      types.Quantity `db:"min_quantity" json:"minQuantity"`
+	RaisedAt         time.Time      `db:"raised_at" json:"raisedAt"`
+}
+
+// LevelRepository stores stock levels and low-stock alerts.
+type LevelRepository interface {
+	// Upsert creates or replaces the level of (warehouse, nomenclature).
+	Upsert(ctx context.Context, level *Level) error
+
+	// Delete removes the level of (warehouse, nomenclature).
+	Delete(ctx context.Context, warehouseID, nomenclatureID id.ID) error
+
+	// List returns the levels matching the filter.
+	List(ctx context.Context, filter LevelFilter) ([]Level, error)
+
+	// ListAlerts returns the active alerts, newest first.
+	ListAlerts(ctx context.Context) ([]LowStockAlert, error)
+
+	// RefreshAlerts compares the current balances with the levels: alerts
+	// of recovered balances are removed, the others are updated. Returns the
+	// alerts raised by this call (not active before).
+	RefreshAlerts(ctx context.Context) ([]LowStockAlert, error)
+}
+
+// LowStockNotifier notifies the users about newly raised alerts.
+type LowStockNotifier interface {
+	NotifyLowStock(ctx context.Context, alerts []LowStockAlert) error
+}
+
+// LevelService manages stock levels and raises low-stock alerts.
+type LevelService struct {
+	repo     LevelRepository
+	notifier LowStockNotifier // nil: alerts are only listed
+}
+
+// NewLevelService creates a new stock level service.
+func NewLevelService(repo LevelRepository) *LevelService {
+	return &LevelService{repo: repo}
+}
+
+// SetNotifier enables notifications of newly raised alerts.
+func (s *LevelService) SetNotifier(n LowStockNotifier) {
+	s.notifier = n
+}
+
+// SetLevel creates or replaces a level.
+func (s *LevelService) SetLevel(ctx context.Context, level *Level) error {
+	if err := level.Validate(); err != nil {
+		return err
+	}
+	if err := s.repo.Upsert(ctx, level); err != nil {
+		return fmt.Errorf("upsert stock level: %w", err)
+	}
+	logger.Info(ctx, "stock level set",
+		"warehouse_id", level.WarehouseID,
+		"nomenclature_id", level.NomenclatureID,
+		"min_quantity", level.MinQuantity.String())
+	return nil
+}
+
+// DeleteLevel removes a level. Its alert, if any, is cleared by the next check.
+func (s *LevelService) DeleteLevel(ctx context.Context, warehouseID, nomenclatureID id.ID) error {
+	return s.repo.Delete(ctx, warehouseID, nomenclatureID)
+}
+
+// ListLevels returns the levels matching the filter.
+func (s *LevelService) ListLevels(ctx context.Context, filter LevelFilter) ([]Level, error) {
+	if filter.Limit <= 0 || filter.Limit > maxLevelListLimit {
+		filter.Limit = maxLevelListLimit
+	}
+	return s.repo.List(ctx, filter)
+}
+
+// Alerts returns the active low-stock alerts.
+func (s *LevelService) Alerts(ctx context.Context) ([]LowStockAlert, error) {
+	return s.repo.ListAlerts(ctx)
+}
+
+// CheckAlerts refreshes the alerts of the tenant in ctx and notifies the
+// newly raised ones in the same transaction, so a shortage is notified once.
+// Returns the number of raised alerts.
+func (s *LevelService) CheckAlerts(ctx context.Context) (int, error) {
+	txm, err := tenant.GetTxManager(ctx)
+	if err != nil {
+		return 0, apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+
+	var raised []LowStockAlert
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		raised, err = s.repo.RefreshAlerts(ctx)
+		if err != nil {
+			return fmt.Errorf("refresh low-stock alerts: %w", err)
+		}
+		if len(raised) == 0 || s.notifier == nil {
+			return nil
+		}
+		return s.notifier.NotifyLowStock(ctx, raised)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(raised) > 0 {
+		logger.Info(ctx, "low-stock alerts raised", "count", len(raised))
+	}
+	return len(raised), nil
+}
@@ -0,0 +1,127 @@
+package stock
+
+import (
+	"context"
+	"fmt"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/jobs"
+	"metapus/internal/core/tenant"
+	"metapus/internal/core/types"
+	"metapus/pkg/logger"
+)
+
+// RecalculateFilter narrows a balance rebuild down to a warehouse and/or a
+// nomenclature; empty rebuilds the whole register.
+type RecalculateFilter struct {
+	WarehouseID    *id.ID `json:"warehouseId,omitempty"`
+	NomenclatureID *id.ID `json:"nomenclatureId,omitempty"`
+}
+
+// BalanceDiscrepancy is a stored balance that differs from the sum of the
+// movements of its key.
+type BalanceDiscrepancy struct {
+	WarehouseID    id.ID          `json:"warehouseId"`
+	NomenclatureID id.ID          `json:"nomenclatureId"`
+	Stored         types.Quantity `json:"stored"`
+	Computed       types.Quantity `json:"computed"`
+}
+
+// Delta returns the correction applied to the stored balance.
+func (d BalanceDiscrepancy) Delta() types.Quantity {
+	return d.Computed - d.Stored
+}
+
+// Recalculation is the result of a balance rebuild.
+type Recalculation struct {
+	Filter        RecalculateFilter
+	Discrepancies []BalanceDiscrepancy
+	// Applied is false for a dry run: the discrepancies are only reported.
+	Applied bool
+}
+
+// RecalculateJobPayload is the payload of RecalculateJob.
+type RecalculateJobPayload struct {
+	Filter RecalculateFilter `json:"filter"`
+}
+
+// RecalculateJob rebuilds balances in the worker.
+var RecalculateJob = jobs.Type[RecalculateJobPayload]("stock.recalculate_balances")
+
+// RecalculationService rebuilds reg_stock_balances from reg_stock_movements.
+// The balance table is maintained by triggers; a rebuild repairs it after a
+// manual data fix or a trigger bug and reports what was wrong.
+type RecalculationService struct {
+	repo Repository
+}
+
+// NewRecalculationService creates a new recalculation service.
+func NewRecalculationService(repo Repository) *RecalculationService {
+	return &RecalculationService{repo: repo}
+}
+
+// Register registers the RecalculateJob handler.
+func (s *RecalculationService) Register(reg *jobs.Registry) {
+	jobs.Handle(reg, RecalculateJob, func(ctx context.Context, p RecalculateJobPayload) error {
+		_, err := s.Recalculate(ctx, p.Filter, false)
+		return err
+	})
+}
+
+// Enqueue queues a rebuild for the worker and returns the job ID.
+func (s *RecalculationService) Enqueue(ctx context.Context, queue *jobs.Queue, filter RecalculateFilter) (string, error) {
+	if err := filter.validate(); err != nil {
+		return "", err
+	}
+	jobID, err := RecalculateJob.Enqueue(ctx, queue, RecalculateJobPayload{Filter: filter}, jobs.MaxAttempts(3))
+	if err != nil {
+		return "", fmt.Errorf("enqueue balance recalculation: %w", err)
+	}
+	return jobID.String(), nil
+}
+
+// Recalculate compares the stored balances in the filter with the movements
+// and, unless dryRun, rewrites the ones that differ, in one transaction.
+func (s *RecalculationService) Recalculate(ctx context.Context, filter RecalculateFilter, dryRun bool) (*Recalculation, error) {
+	if err := filter.validate(); err != nil {
+		return nil, err
+	}
+	txm, err := tenant.GetTxManager(ctx)
+	if err != nil {
+		return nil, apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+
+	result := &Recalculation{Filter: filter, Applied: !dryRun}
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result.Discrepancies, err = s.repo.RecalculateBalances(ctx, filter, dryRun)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("recalculate stock balances: %w", err)
+	}
+
+	if len(result.Discrepancies) > 0 {
+		logger.Warn(ctx, "stock balance discrepancies found",
+			"count", len(result.Discrepancies),
+			"applied", result.Applied,
+			"warehouse_id", filter.WarehouseID,
+			"nomenclature_id", filter.NomenclatureID)
+	} else {
+		logger.Info(ctx, "stock balances recalculated, no discrepancies",
+			"warehouse_id", filter.WarehouseID,
+			"nomenclature_id", filter.NomenclatureID)
+	}
+	return result, nil
+}
+
+func (f RecalculateFilter) validate() error {
+	if f.WarehouseID != nil && id.IsNil(*f.WarehouseID) {
+		return apperror.NewValidation("invalid warehouseId").WithDetail("field", "warehouseId")
+	}
+	if f.NomenclatureID != nil && id.IsNil(*f.NomenclatureID) {
+		return apperror.NewValidation("invalid nomenclatureId").WithDetail("field", "nomenclatureId")
+	}
+	return nil
+}
@@ -24,11 +24,24 @@ type Repository interface {
 	// GetMovementsByRecorder retrieves all movements for a document
 	GetMovementsByRecorder(ctx context.Context, recorderID id.ID) ([]entity.StockMovement, error)
 
+	// GetMovementsForCorrection retrieves movements of a document set matching the filter.
+	// Movements recorded by stock corrections themselves and movements
+	// already moved by an earlier correction are excluded.
+	GetMovementsForCorrection(ctx context.Context, filter CorrectionFilter) ([]entity.StockMovement, error)
+
+	// CountCorrectedMovements counts the movements of a document older than
+	// beforeVersion that were moved by stock corrections
+	CountCorrectedMovements(ctx context.Context, recorderID id.ID, beforeVersion int) (int, error)
+
 	// Balance operations
 
 	// GetBalance returns current balance for warehouse+product
 	GetBalance(ctx context.Context, warehouseID, nomenclatureID id.ID) (entity.StockBalance, error)
 
+	// GetBalances returns balances for multiple warehouse+product pairs without locks.
+	// Keys not found in reg_stock_balances are returned with Quantity=0.
+	GetBalances(ctx context.Context, keys []BalanceKey) ([]entity.StockBalance, error)
+
 	// GetBalanceForUpdate returns balance with row lock for stock control
 	GetBalanceForUpdate(ctx context.Context, warehouseID, nomenclatureID id.ID) (entity.StockBalance, error)
 
@@ -49,24 +62,50 @@ type Repository interface {
 	// Used by the product picker dialog to show stock availability.
 	GetBalancesByNomenclatureIDs(ctx context.Context, nomenclatureIDs []id.ID, warehouseID *id.ID) (map[id.ID]types.Quantity, error)
 
+	// GetReservedQuantities returns quantities reserved in the stock reservation
+	// register per warehouse+product, summed over orders. Reservations of
+	// exceptOrderID are left out (pass a nil ID to count all orders).
+	GetReservedQuantities(ctx context.Context, keys []BalanceKey, exceptOrderID id.ID) (map[BalanceKey]types.Quantity, error)
+
+	// GetAvailableQuantity returns the physical balance minus all reservations
+	GetAvailableQuantity(ctx context.Context, warehouseID, nomenclatureID id.ID) (types.Quantity, error)
+
 	// GetBalancesAtDate calculates balances as of a specific date (for reports)
 	GetBalancesAtDate(ctx context.Context, warehouseID, nomenclatureID id.ID, date time.Time) (types.Quantity, error)
 
 	// Reporting
 
-	// GetMovementHistory returns movement history for a product
-	GetMovementHistory(ctx context.Context, nomenclatureID id.ID, filter MovementFilter) ([]entity.StockMovement, error)
+	// GetMovementHistory returns one keyset page of movement history for a product,
+	// newest first, with totals over the whole filtered set
+	GetMovementHistory(ctx context.Context, nomenclatureID id.ID, filter MovementFilter) (MovementHistory, error)
 
 	// GetTurnover calculates receipt and expense totals for period
 	GetTurnover(ctx context.Context, filter TurnoverFilter) (Turnover, error)
 
 	// Maintenance
 
-	// RecalculateBalances rebuilds balance table from movements
-	RecalculateBalances(ctx context.Context, warehouseID, nomenclatureID *id.ID) error
+	// RecalculateBalances rebuilds the balances in the filter from movements
+	// and returns the ones that differed; with dryRun nothing is written.
+	// Must run in a transaction: movements are locked against writes meanwhile.
+	RecalculateBalances(ctx context.Context, filter RecalculateFilter, dryRun bool) ([]BalanceDiscrepancy, error)
+
+	// RefreshSnapshots builds the month-end balance snapshots of months ended
+	// by before, used by GetBalancesAtDate; returns the number of months built
+	RefreshSnapshots(ctx context.Context, before time.Time) (int, error)
 
 	// CheckStockAvailability checks if required quantity is available (with lock)
-	CheckStockAvailability(ctx context.Context, warehouseID, nomenclatureID id.ID, requiredQty types.Quantity) error
+	CheckStockAvailability(ctx context.Context, warehouseID, nomenclatureID id.ID, requiredQty types.Quantity, opts AvailabilityOptions) error
+}
+
+// AvailabilityOptions controls what counts as available stock in availability checks.
+type AvailabilityOptions struct {
+	// ConsiderReservations subtracts quantities reserved for sales orders
+	// from the physical balance.
+	ConsiderReservations bool
+
+	// ReservedFor is the sales order the checked movements ship. Its own
+	// reservations stay available to the document. Nil for none.
+	ReservedFor id.ID
 }
 
 // BalanceKey represents a unique dimension key for stock balance lookup.
@@ -92,7 +131,30 @@ type MovementFilter struct {
 	FromDate    *time.Time
 	ToDate      *time.Time
 	Limit       int
-	Offset      int
+	// After is the opaque cursor from MovementHistory.NextCursor; empty for the first page.
+	After string
+}
+
+// MovementHistory is one page of movement history ordered by
+// (period, created_at, line_id) DESC.
+type MovementHistory struct {
+	Items      []entity.StockMovement
+	NextCursor string
+	HasMore    bool
+	// Totals cover the whole filtered set, not only this page.
+	Totals MovementTotals
+}
+
+// MovementTotals is the footer of a movement history listing.
+type MovementTotals struct {
+	Count   int64          `json:"count"`
+	Receipt types.Quantity `json:"receipt"`
+	Expense types.Quantity `json:"expense"`
+}
+
+// Net returns receipt minus expense.
+func (t MovementTotals) Net() types.Quantity {
+	return t.Receipt - t.Expense
 }
 
 // TurnoverFilter for turnover reports.
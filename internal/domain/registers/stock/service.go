@@ -4,6 +4,7 @@ package stock
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"metapus/internal/core/apperror"
 	"metapus/internal/core/entity"
@@ -15,7 +16,15 @@ import (
 // Service provides business operations for the stock register.
 // In Database-per-Tenant architecture, transactions are managed by the caller (posting engine).
 type Service struct {
-	repo Repository
+	repo   Repository
+	freeze FreezeChecker
+}
+
+// FreezeChecker rejects movements frozen by an inventory count in progress.
+// Implemented by InventoryService.
+type FreezeChecker interface {
+	FreezeActive(ctx context.Context) bool
+	CheckFreeze(ctx context.Context, movements []entity.StockMovement) error
 }
 
 // NewService creates a new stock register service.
@@ -25,6 +34,20 @@ func NewService(repo Repository) *Service {
 	}
 }
 
+// SetFreezeChecker enables the inventory freeze for postings and reversals.
+func (s *Service) SetFreezeChecker(checker FreezeChecker) {
+	s.freeze = checker
+}
+
+// CheckFreeze rejects movements in the scope of an inventory count in progress.
+// No-op when no freeze checker is set.
+func (s *Service) CheckFreeze(ctx context.Context, movements []entity.StockMovement) error {
+	if s.freeze == nil {
+		return nil
+	}
+	return s.freeze.CheckFreeze(ctx, movements)
+}
+
 // RecordMovements records stock movements from a document posting.
 // This is called during document posting within a transaction.
 func (s *Service) RecordMovements(ctx context.Context, movements []entity.StockMovement) error {
@@ -56,7 +79,37 @@ func (s *Service) RecordMovements(ctx context.Context, movements []entity.StockM
 }
 
 // ReverseMovements removes movements for a document (used during unposting).
+// Movements moved by a stock correction cannot be removed: the correction's
+// adjustments would stay and the balances would drift.
 func (s *Service) ReverseMovements(ctx context.Context, recorderID id.ID, beforeVersion int) error {
+	corrected, err := s.repo.CountCorrectedMovements(ctx, recorderID, beforeVersion)
+	if err != nil {
+		return fmt.Errorf("count corrected movements: %w", err)
+	}
+	if corrected > 0 {
+		return apperror.NewBusinessRule("STOCK_MOVEMENTS_CORRECTED",
+			"the document's stock movements were moved by a stock correction; it cannot be unposted or re-posted").
+			WithDetail("recorderId", recorderID.String()).
+			WithDetail("movements", corrected)
+	}
+
+	// Reversal changes counted balances just like posting does.
+	if s.freeze != nil && s.freeze.FreezeActive(ctx) {
+		movements, err := s.repo.GetMovementsByRecorder(ctx, recorderID)
+		if err != nil {
+			return fmt.Errorf("get movements: %w", err)
+		}
+		reversed := movements[:0]
+		for _, m := range movements {
+			if m.RecorderVersion < beforeVersion {
+				reversed = append(reversed, m)
+			}
+		}
+		if err := s.freeze.CheckFreeze(ctx, reversed); err != nil {
+			return err
+		}
+	}
+
 	if err := s.repo.DeleteMovementsByRecorder(ctx, recorderID, beforeVersion); err != nil {
 		return fmt.Errorf("delete movements: %w", err)
 	}
@@ -69,10 +122,30 @@ func (s *Service) ReverseMovements(ctx context.Context, recorderID id.ID, before
 	return nil
 }
 
+// PostedWarehouses returns the warehouses of the stored movements of a
+// document.
+func (s *Service) PostedWarehouses(ctx context.Context, recorderID id.ID) ([]id.ID, error) {
+	movements, err := s.repo.GetMovementsByRecorder(ctx, recorderID)
+	if err != nil {
+		return nil, fmt.Errorf("get movements: %w", err)
+	}
+	warehouses := make([]id.ID, 0, 1)
+	for _, m := range movements {
+		if !slices.Contains(warehouses, m.WarehouseID) {
+			warehouses = append(warehouses, m.WarehouseID)
+		}
+	}
+	return warehouses, nil
+}
+
 // CheckAndReserveStock validates stock availability with pessimistic locking.
 // Should be called within a transaction before creating expense movements.
 // Uses a single batch query (GetBalancesForUpdate) instead of N individual queries.
-func (s *Service) CheckAndReserveStock(ctx context.Context, items []StockReservation) error {
+//
+// With opts.ConsiderReservations, quantities reserved for sales orders other
+// than opts.ReservedFor are not available. Reservation balances are not locked:
+// reservations are soft and a concurrent order may still over-reserve.
+func (s *Service) CheckAndReserveStock(ctx context.Context, items []StockReservation, opts AvailabilityOptions) error {
 	if len(items) == 0 {
 		return nil
 	}
@@ -101,6 +174,16 @@ func (s *Service) CheckAndReserveStock(ctx context.Context, items []StockReserva
 		balanceMap[dimKey{b.WarehouseID, b.NomenclatureID}] = b.Quantity
 	}
 
+	if opts.ConsiderReservations {
+		reserved, err := s.repo.GetReservedQuantities(ctx, keys, opts.ReservedFor)
+		if err != nil {
+			return fmt.Errorf("get reserved quantities: %w", err)
+		}
+		for k, qty := range reserved {
+			balanceMap[dimKey{k.WarehouseID, k.NomenclatureID}] -= qty
+		}
+	}
+
 	// Validate each reservation.
 	for _, item := range items {
 		available := balanceMap[dimKey{item.WarehouseID, item.NomenclatureID}]
@@ -138,6 +221,12 @@ func (s *Service) GetNomenclatureAvailability(ctx context.Context, nomenclatureI
 	return total, nil
 }
 
+// GetAvailableQuantity returns the stock of a product in a warehouse that is
+// not reserved for sales orders.
+func (s *Service) GetAvailableQuantity(ctx context.Context, warehouseID, nomenclatureID id.ID) (types.Quantity, error) {
+	return s.repo.GetAvailableQuantity(ctx, warehouseID, nomenclatureID)
+}
+
 // GetWarehouseStock returns all products with stock in a warehouse.
 func (s *Service) GetWarehouseStock(ctx context.Context, warehouseID id.ID) ([]entity.StockBalance, error) {
 	return s.repo.GetBalancesByWarehouse(ctx, warehouseID, BalanceFilter{
@@ -0,0 +1,38 @@
+// Package stock_reservation provides the stock reservation accumulation register.
+// Sales orders reserve goods (receipt), goods issues based on an order release
+// them (expense). The stock register subtracts open reservations when it
+// checks availability, so shipments cannot take goods reserved for others.
+package stock_reservation
+
+import (
+	"context"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+)
+
+// Repository defines storage operations for the stock reservation register.
+type Repository interface {
+	// CreateMovements batch inserts movements (used during posting)
+	CreateMovements(ctx context.Context, movements []entity.StockReservationMovement) error
+
+	// DeleteMovementsByRecorder removes all movements for a document version
+	DeleteMovementsByRecorder(ctx context.Context, recorderID id.ID, beforeVersion int) error
+
+	// GetMovementsByRecorder retrieves all movements for a document
+	GetMovementsByRecorder(ctx context.Context, recorderID id.ID) ([]entity.StockReservationMovement, error)
+
+	// GetBalancesForUpdate returns reservation balances with row locks, in key order.
+	// Keys not found in the balances table are returned with Quantity=0.
+	GetBalancesForUpdate(ctx context.Context, keys []BalanceKey) ([]entity.StockReservationBalance, error)
+
+	// GetBalancesByOrder returns open (positive) reservations of an order
+	GetBalancesByOrder(ctx context.Context, orderID id.ID) ([]entity.StockReservationBalance, error)
+}
+
+// BalanceKey is the dimension key of a reservation balance.
+type BalanceKey struct {
+	OrderID        id.ID
+	WarehouseID    id.ID
+	NomenclatureID id.ID
+}
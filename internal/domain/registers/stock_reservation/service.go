@@ -0,0 +1,195 @@
+package stock_reservation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/pkg/logger"
+)
+
+// Service provides business operations for the stock reservation register.
+// Transactions are managed by the caller (posting engine).
+type Service struct {
+	repo Repository
+}
+
+// NewService creates a new stock reservation register service.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// RecordMovements records reservation movements from a document posting.
+//
+// Releases (expense) are capped at the quantity still reserved for the order:
+// a shipment larger than the reservation releases the whole reservation and
+// the rest is shipped from free stock. Fully capped movements are dropped.
+func (s *Service) RecordMovements(ctx context.Context, movements []entity.StockReservationMovement) error {
+	if len(movements) == 0 {
+		return nil
+	}
+
+	for i, m := range movements {
+		if !m.Quantity.IsPositive() {
+			return apperror.NewValidation(fmt.Sprintf("movement %d: quantity must be positive", i))
+		}
+		if id.IsNil(m.RecorderID) {
+			return apperror.NewValidation(fmt.Sprintf("movement %d: recorder_id is required", i))
+		}
+		if id.IsNil(m.OrderID) {
+			return apperror.NewValidation(fmt.Sprintf("movement %d: order_id is required", i))
+		}
+	}
+
+	movements, err := s.capReleases(ctx, movements)
+	if err != nil {
+		return err
+	}
+	if len(movements) == 0 {
+		return nil
+	}
+
+	if err := s.repo.CreateMovements(ctx, movements); err != nil {
+		return fmt.Errorf("create stock reservation movements: %w", err)
+	}
+
+	logger.Info(ctx, "recorded stock reservation movements",
+		"count", len(movements),
+		"recorder_id", movements[0].RecorderID,
+	)
+	return nil
+}
+
+// capReleases limits expense movements to the locked reservation balances.
+// Receipts in the same set are counted first, so a document may reserve and
+// release within one posting.
+func (s *Service) capReleases(ctx context.Context, movements []entity.StockReservationMovement) ([]entity.StockReservationMovement, error) {
+	remaining := make(map[BalanceKey]types.Quantity)
+	for _, m := range movements {
+		if m.RecordType == entity.RecordTypeExpense {
+			remaining[BalanceKey{m.OrderID, m.WarehouseID, m.NomenclatureID}] = 0
+		}
+	}
+	if len(remaining) == 0 {
+		return movements, nil
+	}
+
+	// Lock in deterministic order to prevent deadlocks between shipments.
+	keys := make([]BalanceKey, 0, len(remaining))
+	for k := range remaining {
+		keys = append(keys, k)
+	}
+	SortBalanceKeys(keys)
+
+	balances, err := s.repo.GetBalancesForUpdate(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("get reservation balances for update: %w", err)
+	}
+	for _, b := range balances {
+		remaining[BalanceKey{b.OrderID, b.WarehouseID, b.NomenclatureID}] = b.Quantity
+	}
+	for _, m := range movements {
+		if m.RecordType != entity.RecordTypeReceipt {
+			continue
+		}
+		k := BalanceKey{m.OrderID, m.WarehouseID, m.NomenclatureID}
+		if _, ok := remaining[k]; ok {
+			remaining[k] += m.Quantity
+		}
+	}
+
+	result := make([]entity.StockReservationMovement, 0, len(movements))
+	for _, m := range movements {
+		if m.RecordType == entity.RecordTypeExpense {
+			k := BalanceKey{m.OrderID, m.WarehouseID, m.NomenclatureID}
+			if remaining[k] <= 0 {
+				continue
+			}
+			if m.Quantity > remaining[k] {
+				m.Quantity = remaining[k]
+			}
+			remaining[k] -= m.Quantity
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+// SortBalanceKeys sorts keys by order, warehouse and product for resource ordering.
+// Prevents deadlocks when locking multiple balance rows.
+func SortBalanceKeys(keys []BalanceKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if c := bytes.Compare(keys[i].OrderID[:], keys[j].OrderID[:]); c != 0 {
+			return c < 0
+		}
+		if c := bytes.Compare(keys[i].WarehouseID[:], keys[j].WarehouseID[:]); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(keys[i].NomenclatureID[:], keys[j].NomenclatureID[:]) < 0
+	})
+}
+
+// ReverseMovements removes movements for a document (used during unposting).
+func (s *Service) ReverseMovements(ctx context.Context, recorderID id.ID, beforeVersion int) error {
+	if err := s.repo.DeleteMovementsByRecorder(ctx, recorderID, beforeVersion); err != nil {
+		return fmt.Errorf("delete stock reservation movements: %w", err)
+	}
+
+	logger.Info(ctx, "reversed stock reservation movements",
+		"recorder_id", recorderID,
+		"before_version", beforeVersion,
+	)
+	return nil
+}
+
+// GetOrderReservations returns what is still reserved for a sales order.
+func (s *Service) GetOrderReservations(ctx context.Context, orderID id.ID) ([]entity.StockReservationBalance, error) {
+	return s.repo.GetBalancesByOrder(ctx, orderID)
+}
+
+// ---------------------------------------------------------------------------
+// Implementation of entity.MovementProvider
+// ---------------------------------------------------------------------------
+
+func (s *Service) RegisterName() string {
+	return "Резервы товаров"
+}
+
+func (s *Service) GetDocumentMovements(ctx context.Context, recorderID id.ID) ([]entity.DocumentMovement, error) {
+	movements, err := s.repo.GetMovementsByRecorder(ctx, recorderID)
+	if err != nil {
+		return nil, fmt.Errorf("get stock reservation movements: %w", err)
+	}
+
+	columns := []entity.MovementColumnDef{
+		{Key: "nomenclature", Label: "Номенклатура", Type: "ref"},
+		{Key: "warehouse", Label: "Склад", Type: "ref"},
+		{Key: "order", Label: "Заказ покупателя", Type: "ref"},
+		{Key: "quantity", Label: "Количество", Type: "quantity"},
+	}
+
+	result := make([]entity.DocumentMovement, 0, len(movements))
+	for _, m := range movements {
+		data := map[string]any{
+			"nomenclature": entity.MovementRefValue{ID: m.NomenclatureID.String(), Name: m.NomenclatureID.String()},
+			"warehouse":    entity.MovementRefValue{ID: m.WarehouseID.String(), Name: m.WarehouseID.String()},
+			"order":        entity.MovementRefValue{ID: m.OrderID.String(), Name: m.OrderID.String()},
+			"quantity":     m.Quantity.Float64(),
+		}
+
+		result = append(result, entity.DocumentMovement{
+			RegisterName: s.RegisterName(),
+			RecordType:   string(m.RecordType),
+			Period:       m.Period,
+			Columns:      columns,
+			Data:         data,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,58 @@
+// Package supplier_order provides the purchase order accumulation register.
+// Posted purchase orders record ordered goods (receipt), goods receipts linked
+// to order lines record received goods (expense). The balance of an order line
+// is the quantity still expected from the supplier.
+package supplier_order
+
+import (
+	"context"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+)
+
+// Repository defines storage operations for the purchase order register.
+type Repository interface {
+	// CreateMovements batch inserts movements (used during posting)
+	CreateMovements(ctx context.Context, movements []entity.PurchaseOrderMovement) error
+
+	// DeleteMovementsByRecorder removes all movements for a document version
+	DeleteMovementsByRecorder(ctx context.Context, recorderID id.ID, beforeVersion int) error
+
+	// GetMovementsByRecorder retrieves all movements for a document
+	GetMovementsByRecorder(ctx context.Context, recorderID id.ID) ([]entity.PurchaseOrderMovement, error)
+
+	// GetBalancesForUpdate returns order line balances with row locks, in key order.
+	// Keys not found in the balances table are returned with Quantity=0.
+	GetBalancesForUpdate(ctx context.Context, keys []BalanceKey) ([]entity.PurchaseOrderBalance, error)
+
+	// GetLineFulfillment returns ordered and received quantities per line of an order.
+	GetLineFulfillment(ctx context.Context, orderID id.ID) ([]LineFulfillment, error)
+
+	// RefreshOrderStatus recalculates the fulfillment status of the orders
+	// from their movements (called in the posting transaction).
+	RefreshOrderStatus(ctx context.Context, orderIDs []id.ID) error
+}
+
+// BalanceKey is the dimension key of an order line balance.
+type BalanceKey struct {
+	OrderID     id.ID
+	OrderLineID id.ID
+}
+
+// LineFulfillment is the fulfillment of one purchase order line, in base units.
+type LineFulfillment struct {
+	OrderLineID    id.ID          `db:"order_line_id" json:"orderLineId"`
+	NomenclatureID id.ID          `db:"nomenclature_id" json:"nomenclatureId"`
+	Ordered        types.Quantity `db:"ordered" json:"ordered"`
+	Received       types.Quantity `db:"received" json:"received"`
+}
+
+// Remaining returns the quantity still expected (never negative).
+func (f LineFulfillment) Remaining() types.Quantity {
+	if f.Received >= f.Ordered {
+		return 0
+	}
+	return f.Ordered - f.Received
+}
@@ -0,0 +1,80 @@
+package supplier_order
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+)
+
+// fakeRepo serves fixed order line balances and records created movements.
+type fakeRepo struct {
+	Repository
+	balances  map[BalanceKey]types.Quantity
+	created   []entity.PurchaseOrderMovement
+	refreshed []id.ID
+}
+
+func (r *fakeRepo) GetBalancesForUpdate(_ context.Context, keys []BalanceKey) ([]entity.PurchaseOrderBalance, error) {
+	out := make([]entity.PurchaseOrderBalance, len(keys))
+	for i, k := range keys {
+		out[i] = entity.PurchaseOrderBalance{OrderID: k.OrderID, OrderLineID: k.OrderLineID, Quantity: r.balances[k]}
+	}
+	return out, nil
+}
+
+func (r *fakeRepo) CreateMovements(_ context.Context, movements []entity.PurchaseOrderMovement) error {
+	r.created = append(r.created, movements...)
+	return nil
+}
+
+func (r *fakeRepo) RefreshOrderStatus(_ context.Context, orderIDs []id.ID) error {
+	r.refreshed = append(r.refreshed, orderIDs...)
+	return nil
+}
+
+func TestRecordMovementsCapsReceivedAtOrdered(t *testing.T) {
+	orderID, lineA, lineB, lineC := id.New(), id.New(), id.New(), id.New()
+	repo := &fakeRepo{balances: map[BalanceKey]types.Quantity{
+		{orderID, lineA}: types.NewQuantityFromFloat64(10),
+		{orderID, lineB}: types.NewQuantityFromFloat64(3),
+	}}
+	svc := NewService(repo)
+
+	receive := func(lineID id.ID, qty float64) entity.PurchaseOrderMovement {
+		return entity.NewPurchaseOrderMovement(id.New(), "GoodsReceipt", 1, time.Now(),
+			entity.RecordTypeExpense, orderID, lineID, id.New(), types.NewQuantityFromFloat64(qty))
+	}
+
+	err := svc.RecordMovements(context.Background(), []entity.PurchaseOrderMovement{
+		receive(lineA, 4), // within the order
+		receive(lineB, 5), // over-delivery, capped at 3
+		receive(lineC, 2), // nothing expected, dropped
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make(map[id.ID]types.Quantity)
+	for _, m := range repo.created {
+		got[m.OrderLineID] += m.Quantity
+	}
+	want := map[id.ID]types.Quantity{
+		lineA: types.NewQuantityFromFloat64(4),
+		lineB: types.NewQuantityFromFloat64(3),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got movements for %d lines, want %d", len(got), len(want))
+	}
+	for lineID, qty := range want {
+		if got[lineID] != qty {
+			t.Errorf("line %s: got %v, want %v", lineID, got[lineID], qty)
+		}
+	}
+	if len(repo.refreshed) != 1 || repo.refreshed[0] != orderID {
+		t.Errorf("refreshed %v, want [%s]", repo.refreshed, orderID)
+	}
+}
@@ -0,0 +1,183 @@
+package compiler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/reports/schema"
+)
+
+// Period filter keys shared by period-based datasets (turnover, journal).
+const (
+	periodFromKey = "from_date"
+	periodToKey   = "to_date"
+)
+
+// PlanLimits bounds the cost of report execution for one tenant plan.
+// Zero values mean "unlimited".
+type PlanLimits struct {
+	// MaxConcurrent is the number of reports a tenant may execute at once.
+	MaxConcurrent int
+	// MaxPeriod is the longest from_date..to_date span a report may scan.
+	MaxPeriod time.Duration
+}
+
+// DefaultPlanLimits are the report limits of the standard tenant plans.
+var DefaultPlanLimits = map[tenant.Plan]PlanLimits{
+	tenant.PlanStandard:   {MaxConcurrent: 2, MaxPeriod: 366 * 24 * time.Hour},
+	tenant.PlanPremium:    {MaxConcurrent: 5, MaxPeriod: 3 * 366 * 24 * time.Hour},
+	tenant.PlanEnterprise: {MaxConcurrent: 20},
+}
+
+// DefaultQueueWait is how long an execution waits for a free slot before
+// the request is rejected with 429.
+const DefaultQueueWait = 5 * time.Second
+
+// Budget enforces per-tenant plan limits on report execution.
+//
+// Soft limits: executions over MaxConcurrent are queued for up to queueWait,
+// then rejected with 429 and Retry-After. Background executions (see
+// WithBackgroundExecution) are queued until a slot frees up or ctx ends.
+// Requests without a tenant in context (single-tenant mode) are not limited.
+type Budget struct {
+	limits    map[tenant.Plan]PlanLimits
+	queueWait time.Duration
+
+	mu    sync.Mutex
+	slots map[string]chan struct{} // tenantID -> semaphore
+}
+
+// NewBudget creates a budget with the given plan limits.
+// Plans missing from limits fall back to the standard plan.
+func NewBudget(limits map[tenant.Plan]PlanLimits, queueWait time.Duration) *Budget {
+	return &Budget{
+		limits:    limits,
+		queueWait: queueWait,
+		slots:     make(map[string]chan struct{}),
+	}
+}
+
+type backgroundKey struct{}
+
+// WithBackgroundExecution marks ctx as a background execution (scheduled or
+// async report) that waits for a slot instead of being rejected.
+func WithBackgroundExecution(ctx context.Context) context.Context {
+	return context.WithValue(ctx, backgroundKey{}, true)
+}
+
+func isBackgroundExecution(ctx context.Context) bool {
+	v, _ := ctx.Value(backgroundKey{}).(bool)
+	return v
+}
+
+func (b *Budget) limitsFor(t *tenant.Tenant) PlanLimits {
+	if l, ok := b.limits[t.Plan]; ok {
+		return l
+	}
+	return b.limits[tenant.PlanStandard]
+}
+
+// Acquire reserves an execution slot for the tenant in ctx.
+// The returned release must be called when the execution finishes.
+func (b *Budget) Acquire(ctx context.Context) (release func(), err error) {
+	t := tenant.GetTenant(ctx)
+	if t == nil {
+		return func() {}, nil
+	}
+	limits := b.limitsFor(t)
+	if limits.MaxConcurrent <= 0 {
+		return func() {}, nil
+	}
+
+	sem := b.semaphore(t.ID, limits.MaxConcurrent)
+	release = func() { <-sem }
+
+	select {
+	case sem <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	var timeout <-chan time.Time
+	if !isBackgroundExecution(ctx) {
+		timer := time.NewTimer(b.queueWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case sem <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timeout:
+		return nil, apperror.NewTooManyRequests(
+			fmt.Sprintf("report limit of plan %q reached: %d concurrent executions", t.Plan, limits.MaxConcurrent),
+			b.queueWait,
+		)
+	}
+}
+
+// semaphore returns the tenant's slot channel, recreating it when the plan
+// capacity changed. Executions holding slots of a replaced channel release
+// into the old one, so a plan change takes effect for new executions only.
+func (b *Budget) semaphore(tenantID string, capacity int) chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sem, ok := b.slots[tenantID]
+	if !ok || cap(sem) != capacity {
+		sem = make(chan struct{}, capacity)
+		b.slots[tenantID] = sem
+	}
+	return sem
+}
+
+// CheckPeriod rejects requests scanning a longer period than the tenant plan allows.
+// Only datasets with a from_date filter are period-bounded; a missing
+// from_date then means "since the beginning" and is rejected too.
+func (b *Budget) CheckPeriod(ctx context.Context, ds *schema.Dataset, filters map[string]any) error {
+	t := tenant.GetTenant(ctx)
+	if t == nil {
+		return nil
+	}
+	maxPeriod := b.limitsFor(t).MaxPeriod
+	if maxPeriod <= 0 || !ds.HasFilter(periodFromKey) {
+		return nil
+	}
+
+	from, ok := parseFilterDate(filters, periodFromKey)
+	if !ok {
+		return apperror.NewValidation(fmt.Sprintf("plan %q requires a report period", t.Plan)).
+			WithDetail("field", periodFromKey)
+	}
+	to, ok := parseFilterDate(filters, periodToKey)
+	if !ok {
+		to = time.Now()
+	}
+
+	if to.Sub(from) > maxPeriod {
+		return apperror.NewValidation(fmt.Sprintf("report period exceeds the limit of plan %q", t.Plan)).
+			WithDetail("field", periodFromKey).
+			WithDetail("maxPeriodDays", int(maxPeriod/(24*time.Hour)))
+	}
+	return nil
+}
+
+func parseFilterDate(filters map[string]any, key string) (time.Time, bool) {
+	s, ok := filters[key].(string)
+	if !ok || s == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
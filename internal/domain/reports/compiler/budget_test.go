@@ -0,0 +1,75 @@
+package compiler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/reports/schema"
+)
+
+func TestBudgetAcquireThrottlesOverPlanConcurrency(t *testing.T) {
+	b := NewBudget(map[tenant.Plan]PlanLimits{tenant.PlanStandard: {MaxConcurrent: 1}}, 10*time.Millisecond)
+	ctx := tenant.WithTenant(context.Background(), &tenant.Tenant{ID: "t1", Plan: tenant.PlanStandard})
+
+	release, err := b.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("first Acquire: %v", err)
+	}
+
+	_, err = b.Acquire(ctx)
+	appErr, ok := apperror.AsAppError(err)
+	if !ok || appErr.Code != apperror.CodeTooManyRequests {
+		t.Fatalf("second Acquire = %v, want TOO_MANY_REQUESTS", err)
+	}
+	if seconds, ok := appErr.RetryAfter(); !ok || seconds < 1 {
+		t.Errorf("RetryAfter = %d, %v", seconds, ok)
+	}
+
+	// Another tenant has its own slots.
+	other := tenant.WithTenant(context.Background(), &tenant.Tenant{ID: "t2", Plan: tenant.PlanStandard})
+	releaseOther, err := b.Acquire(other)
+	if err != nil {
+		t.Fatalf("other tenant Acquire: %v", err)
+	}
+	releaseOther()
+
+	// A background execution waits for the slot instead of failing.
+	done := make(chan error, 1)
+	go func() {
+		r, err := b.Acquire(WithBackgroundExecution(ctx))
+		if err == nil {
+			r()
+		}
+		done <- err
+	}()
+	time.Sleep(30 * time.Millisecond)
+	release()
+	if err := <-done; err != nil {
+		t.Fatalf("background Acquire: %v", err)
+	}
+}
+
+func TestBudgetCheckPeriod(t *testing.T) {
+	b := NewBudget(map[tenant.Plan]PlanLimits{tenant.PlanStandard: {MaxPeriod: 31 * 24 * time.Hour}}, time.Second)
+	ctx := tenant.WithTenant(context.Background(), &tenant.Tenant{ID: "t1", Plan: tenant.PlanStandard})
+	ds := &schema.Dataset{Key: "turnover", Filters: []schema.FilterDef{{Key: "from_date"}, {Key: "to_date"}}}
+
+	if err := b.CheckPeriod(ctx, ds, map[string]any{"from_date": "2026-01-01", "to_date": "2026-01-31"}); err != nil {
+		t.Fatalf("one month: %v", err)
+	}
+	if err := b.CheckPeriod(ctx, ds, map[string]any{"from_date": "2026-01-01", "to_date": "2026-03-01"}); err == nil {
+		t.Fatal("two months accepted")
+	}
+	if err := b.CheckPeriod(ctx, ds, map[string]any{}); err == nil {
+		t.Fatal("missing period accepted")
+	}
+	if err := b.CheckPeriod(ctx, &schema.Dataset{Key: "balance"}, map[string]any{}); err != nil {
+		t.Fatalf("dataset without period: %v", err)
+	}
+	if err := b.CheckPeriod(context.Background(), ds, map[string]any{}); err != nil {
+		t.Fatalf("no tenant: %v", err)
+	}
+}
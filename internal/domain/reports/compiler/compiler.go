@@ -96,6 +96,7 @@ type Compiler struct {
 	registry *metadata.Registry
 	datasets map[string]*schema.Dataset
 	builder  squirrel.StatementBuilderType
+	budget   *Budget // optional per-plan execution limits
 }
 
 // NewCompiler creates a Compiler with the given metadata registry and datasets.
@@ -111,6 +112,12 @@ func NewCompiler(reg *metadata.Registry, datasets []*schema.Dataset) *Compiler {
 	}
 }
 
+// SetBudget enables per-tenant plan limits on Execute.
+// Must be called before the compiler serves requests.
+func (c *Compiler) SetBudget(b *Budget) {
+	c.budget = b
+}
+
 // GetDataset returns a dataset by key, or nil.
 func (c *Compiler) GetDataset(key string) *schema.Dataset {
 	return c.datasets[key]
@@ -132,6 +139,18 @@ func (c *Compiler) Execute(ctx context.Context, req QueryRequest) (*QueryResult,
 		return nil, apperror.NewInternal(fmt.Errorf("unknown dataset: %q", req.Dataset))
 	}
 
+	// 0. Plan limits: period first (cheap), then an execution slot
+	if c.budget != nil {
+		if err := c.budget.CheckPeriod(ctx, ds, req.Filters); err != nil {
+			return nil, err
+		}
+		release, err := c.budget.Acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
 	// 1. Determine selected fields (default to all non-hidden)
 	selectPaths := req.Select
 	if len(selectPaths) == 0 {
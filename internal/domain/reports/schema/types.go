@@ -171,6 +171,10 @@ type FilterDef struct {
 
 	// Default is the default value for the filter.
 	Default any `json:"default,omitempty"`
+
+	// Options holds the allowed values for Type==FilterEnum.
+	// E.g. the dimension set of a turnover-balance report.
+	Options []EnumValue `json:"options,omitempty"`
 }
 
 // FilterType defines the type of filter control rendered in the UI.
@@ -209,6 +213,16 @@ func (ds *Dataset) FindField(name string) *Field {
 	return nil
 }
 
+// HasFilter reports whether the dataset declares a filter parameter with the given key.
+func (ds *Dataset) HasFilter(key string) bool {
+	for i := range ds.Filters {
+		if ds.Filters[i].Key == key {
+			return true
+		}
+	}
+	return false
+}
+
 // SelectableFields returns fields that are not filter-only.
 func (ds *Dataset) SelectableFields() []Field {
 	result := make([]Field, 0, len(ds.Fields))
@@ -114,6 +114,20 @@ type CatalogRepository[T entity.CatalogEntity] interface {
 	GetPath(ctx context.Context, id id.ID) ([]T, error)
 }
 
+// CatalogBatchRepository is an optional extension of CatalogRepository for
+// bulk inserts (catalog import) and batch operations. Implemented by catalog_repo.BaseCatalogRepo.
+type CatalogBatchRepository[T entity.CatalogEntity] interface {
+	// CreateBatch inserts entities with a single COPY. Requires a transaction.
+	CreateBatch(ctx context.Context, entities []T) error
+
+	// ExistingCodes returns the subset of codes already used by other entities.
+	ExistingCodes(ctx context.Context, codes []string) (map[string]struct{}, error)
+
+	// LockByIDs returns the entities with ids (missing ones are left out),
+	// locked until the end of the transaction. Requires a transaction.
+	LockByIDs(ctx context.Context, ids []id.ID) ([]T, error)
+}
+
 // --- Hooks ---
 
 // HookEvent represents lifecycle event type.
@@ -126,6 +140,15 @@ const (
 	AfterUpdate  HookEvent = "after_update"
 	BeforeDelete HookEvent = "before_delete"
 	AfterDelete  HookEvent = "after_delete"
+
+	// In-transaction hooks run inside the write transaction, right after the
+	// write. An error rolls the write back. Use them for writes that must
+	// commit together with the entity, such as outbox events.
+	CreateInTx HookEvent = "create_in_tx"
+	UpdateInTx HookEvent = "update_in_tx"
+	DeleteInTx HookEvent = "delete_in_tx"
+	PostInTx   HookEvent = "post_in_tx"   // documents: after movements are recorded
+	UnpostInTx HookEvent = "unpost_in_tx" // documents: after movements are reversed
 )
 
 // Hook is a function that runs at specific lifecycle points.
@@ -0,0 +1,93 @@
+// Package reposting re-posts all posted documents of a date range in
+// chronological order, regenerating their register movements — e.g. after
+// a fix of the posting logic or of costs. A run is queued as a background
+// job; the worker executes it and stores its progress in the run.
+package reposting
+
+import (
+	"context"
+	"time"
+
+	"metapus/internal/core/id"
+)
+
+// Status of a repost run.
+type Status string
+
+const (
+	StatusPending   Status = "pending" // queued for the worker
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed" // every document was processed; see Failed
+	StatusFailed    Status = "failed"    // the run stopped before the end
+)
+
+// Run is one re-posting of a date range stored in sys_repost_runs.
+type Run struct {
+	ID     id.ID  `db:"id" json:"id"`
+	Status Status `db:"status" json:"status"`
+
+	// DateFrom and DateTo bound the document dates, both days included.
+	DateFrom time.Time `db:"date_from" json:"dateFrom"`
+	DateTo   time.Time `db:"date_to" json:"dateTo"`
+	// DocumentTypes limits the run to these types; empty means all.
+	DocumentTypes []string `db:"document_types" json:"documentTypes"`
+
+	// Progress: Total documents found, Processed so far (including the
+	// Failed ones), and the date of the last processed document.
+	Total            int        `db:"total" json:"total"`
+	Processed        int        `db:"processed" json:"processed"`
+	Failed           int        `db:"failed" json:"failed"`
+	LastDocumentDate *time.Time `db:"last_document_date" json:"lastDocumentDate,omitempty"`
+
+	// Errors lists the first documents that could not be re-posted.
+	Errors       []DocumentError `db:"errors" json:"errors"`
+	ErrorMessage string          `db:"error_message" json:"errorMessage,omitempty"`
+
+	RequestedBy *id.ID     `db:"requested_by" json:"requestedBy,omitempty"`
+	CreatedAt   time.Time  `db:"created_at" json:"createdAt"`
+	StartedAt   *time.Time `db:"started_at" json:"startedAt,omitempty"`
+	FinishedAt  *time.Time `db:"finished_at" json:"finishedAt,omitempty"`
+}
+
+// DocumentError is a document the run could not re-post.
+type DocumentError struct {
+	DocumentType string    `json:"documentType"`
+	DocumentID   id.ID     `json:"documentId"`
+	Number       string    `json:"number"`
+	Date         time.Time `json:"date"`
+	Message      string    `json:"message"`
+}
+
+// Document is a posted document to re-post.
+type Document struct {
+	Type   string
+	ID     id.ID
+	Number string
+	Date   time.Time
+}
+
+// Repository stores the repost runs of the tenant database.
+type Repository interface {
+	Create(ctx context.Context, run *Run) error
+	Get(ctx context.Context, runID id.ID) (*Run, error)
+	// List returns the latest runs, newest first.
+	List(ctx context.Context, limit int) ([]Run, error)
+	// Update saves the status and progress of a run.
+	Update(ctx context.Context, run *Run) error
+}
+
+// DocumentSource finds the documents to re-post.
+type DocumentSource interface {
+	// DocumentTypes returns the document types that can be listed.
+	DocumentTypes() []string
+
+	// ListPosted returns the posted documents of the types dated in
+	// [from, to), ordered by date (then creation).
+	ListPosted(ctx context.Context, types []string, from, to time.Time) ([]Document, error)
+}
+
+// Reposter re-posts a single document through its document service, with
+// the same checks and hooks as POST /document/{type}/:id/post.
+type Reposter interface {
+	Repost(ctx context.Context, documentType string, docID id.ID) error
+}
@@ -0,0 +1,230 @@
+package reposting
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/clock"
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/format"
+	"metapus/internal/core/id"
+	"metapus/internal/core/jobs"
+	"metapus/internal/core/tenant"
+	"metapus/pkg/logger"
+)
+
+const (
+	// progressEvery is how many documents are processed between progress saves.
+	progressEvery = 25
+	// maxRunErrors bounds the document errors stored in a run.
+	maxRunErrors = 100
+	// maxErrorLength bounds the error text of a document.
+	maxErrorLength = 500
+	// jobTimeout bounds one attempt of a run (and the worker lease).
+	jobTimeout = 4 * time.Hour
+)
+
+// JobPayload is the payload of RepostJob.
+type JobPayload struct {
+	RunID id.ID `json:"runId"`
+}
+
+// RepostJob executes a queued run in the worker.
+var RepostJob = jobs.Type[JobPayload]("posting.repost")
+
+// Request starts a run.
+type Request struct {
+	DateFrom      time.Time
+	DateTo        time.Time
+	DocumentTypes []string
+}
+
+// Service queues repost runs and executes them.
+type Service struct {
+	repo     Repository
+	docs     DocumentSource
+	queue    *jobs.Queue
+	reposter Reposter // set by Register (worker only)
+	now      func() time.Time
+}
+
+// NewService creates the reposting service.
+func NewService(repo Repository, docs DocumentSource, queue *jobs.Queue) *Service {
+	return &Service{repo: repo, docs: docs, queue: queue, now: time.Now}
+}
+
+// Register registers the RepostJob handler, re-posting through reposter.
+func (s *Service) Register(reg *jobs.Registry, reposter Reposter) {
+	s.reposter = reposter
+	jobs.Handle(reg, RepostJob, s.runJob, jobs.WithTimeout(jobTimeout))
+}
+
+// Start validates the request and queues a run.
+func (s *Service) Start(ctx context.Context, req Request) (*Run, error) {
+	from, to := clock.DateOf(req.DateFrom), clock.DateOf(req.DateTo)
+	if from.IsZero() || to.IsZero() {
+		return nil, apperror.NewValidation("dateFrom and dateTo are required").WithDetail("field", "dateFrom")
+	}
+	if to.Before(from) {
+		return nil, apperror.NewValidation("dateTo must not be before dateFrom").WithDetail("field", "dateTo")
+	}
+	known := s.docs.DocumentTypes()
+	for _, t := range req.DocumentTypes {
+		if !slices.Contains(known, t) {
+			return nil, apperror.NewValidation(fmt.Sprintf("unknown document type %q", t)).
+				WithDetail("field", "documentTypes")
+		}
+	}
+
+	run := &Run{
+		ID:            id.New(),
+		Status:        StatusPending,
+		DateFrom:      from,
+		DateTo:        to,
+		DocumentTypes: req.DocumentTypes,
+		Errors:        []DocumentError{},
+		CreatedAt:     s.now(),
+	}
+	if run.DocumentTypes == nil {
+		run.DocumentTypes = []string{}
+	}
+	if userID, err := id.Parse(appctx.GetUserID(ctx)); err == nil {
+		run.RequestedBy = &userID
+	}
+
+	txm, err := tenant.GetTxManager(ctx)
+	if err != nil {
+		return nil, apperror.NewInternal(err).WithDetail("missing", "tx_manager")
+	}
+	err = txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, run); err != nil {
+			return err
+		}
+		_, err := RepostJob.Enqueue(ctx, s.queue, JobPayload{RunID: run.ID}, jobs.MaxAttempts(3))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.Info(ctx, "repost run queued",
+		"run_id", run.ID,
+		"date_from", from.Format(time.DateOnly),
+		"date_to", to.Format(time.DateOnly))
+	return run, nil
+}
+
+// Get returns a run with its progress.
+func (s *Service) Get(ctx context.Context, runID id.ID) (*Run, error) {
+	return s.repo.Get(ctx, runID)
+}
+
+// List returns the latest runs, newest first.
+func (s *Service) List(ctx context.Context, limit int) ([]Run, error) {
+	return s.repo.List(ctx, limit)
+}
+
+// runJob handles RepostJob. A retried run starts over: re-posting is
+// idempotent.
+func (s *Service) runJob(ctx context.Context, payload JobPayload) error {
+	run, err := s.repo.Get(ctx, payload.RunID)
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return jobs.Permanent(err)
+		}
+		return err
+	}
+	if run.Status == StatusCompleted {
+		return nil
+	}
+	if s.reposter == nil {
+		return jobs.Permanent(fmt.Errorf("reposting is not registered"))
+	}
+	return s.Execute(ctx, run, s.reposter)
+}
+
+// Execute re-posts the documents of run in chronological order, saving the
+// progress as it goes. A document that fails is recorded in the run and
+// skipped; the run fails only if listing the documents or saving the
+// progress fails, or ctx is cancelled.
+func (s *Service) Execute(ctx context.Context, run *Run, reposter Reposter) error {
+	started := s.now()
+	run.Status = StatusRunning
+	run.StartedAt = &started
+	run.FinishedAt = nil
+	run.Total, run.Processed, run.Failed = 0, 0, 0
+	run.LastDocumentDate = nil
+	run.Errors = []DocumentError{}
+	run.ErrorMessage = ""
+
+	types := run.DocumentTypes
+	if len(types) == 0 {
+		types = s.docs.DocumentTypes()
+	}
+	docs, err := s.docs.ListPosted(ctx, types, run.DateFrom, run.DateTo.AddDate(0, 0, 1))
+	if err != nil {
+		return s.fail(ctx, run, fmt.Errorf("list documents: %w", err))
+	}
+	run.Total = len(docs)
+	if err := s.repo.Update(ctx, run); err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		if err := ctx.Err(); err != nil {
+			return s.fail(ctx, run, err)
+		}
+		if err := reposter.Repost(ctx, doc.Type, doc.ID); err != nil {
+			run.Failed++
+			if len(run.Errors) < maxRunErrors {
+				run.Errors = append(run.Errors, DocumentError{
+					DocumentType: doc.Type,
+					DocumentID:   doc.ID,
+					Number:       doc.Number,
+					Date:         doc.Date,
+					Message:      format.Truncate(err.Error(), maxErrorLength),
+				})
+			}
+			logger.Warn(ctx, "repost failed",
+				"run_id", run.ID,
+				"document_type", doc.Type,
+				"document_id", doc.ID,
+				"error", err)
+		}
+		run.Processed++
+		date := doc.Date
+		run.LastDocumentDate = &date
+		if run.Processed%progressEvery == 0 {
+			if err := s.repo.Update(ctx, run); err != nil {
+				return err
+			}
+		}
+	}
+
+	finished := s.now()
+	run.Status = StatusCompleted
+	run.FinishedAt = &finished
+	if err := s.repo.Update(ctx, run); err != nil {
+		return err
+	}
+	logger.Info(ctx, "repost run completed",
+		"run_id", run.ID,
+		"documents", run.Processed,
+		"failed", run.Failed,
+		"duration_ms", finished.Sub(started).Milliseconds())
+	return nil
+}
+
+// fail stores the error of a stopped run and returns it.
+func (s *Service) fail(ctx context.Context, run *Run, cause error) error {
+	finished := s.now()
+	run.Status = StatusFailed
+	run.FinishedAt = &finished
+	run.ErrorMessage = format.Truncate(cause.Error(), maxErrorLength)
+	if err := s.repo.Update(context.WithoutCancel(ctx), run); err != nil {
+		logger.Error(ctx, "save failed repost run", "run_id", run.ID, "error", err)
+	}
+	return cause
+}
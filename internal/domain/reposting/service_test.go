@@ -0,0 +1,118 @@
+package reposting
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+)
+
+type memRepo struct {
+	updates int
+}
+
+func (r *memRepo) Create(context.Context, *Run) error { return nil }
+func (r *memRepo) Get(context.Context, id.ID) (*Run, error) {
+	return nil, apperror.NewNotFound("repost_run", "")
+}
+func (r *memRepo) List(context.Context, int) ([]Run, error) { return nil, nil }
+func (r *memRepo) Update(context.Context, *Run) error       { r.updates++; return nil }
+
+type memDocs struct {
+	docs     []Document
+	from, to time.Time
+}
+
+func (d *memDocs) DocumentTypes() []string { return []string{"goods_issue", "goods_receipt"} }
+
+func (d *memDocs) ListPosted(_ context.Context, _ []string, from, to time.Time) ([]Document, error) {
+	d.from, d.to = from, to
+	return d.docs, nil
+}
+
+type reposterFunc func(ctx context.Context, documentType string, docID id.ID) error
+
+func (f reposterFunc) Repost(ctx context.Context, documentType string, docID id.ID) error {
+	return f(ctx, documentType, docID)
+}
+
+func TestStartValidation(t *testing.T) {
+	svc := NewService(&memRepo{}, &memDocs{}, nil)
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		req  Request
+	}{
+		{"missing dates", Request{DateFrom: day}},
+		{"inverted range", Request{DateFrom: day, DateTo: day.AddDate(0, 0, -1)}},
+		{"unknown type", Request{DateFrom: day, DateTo: day, DocumentTypes: []string{"invoice"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Start(context.Background(), tt.req)
+			if appErr, ok := apperror.AsAppError(err); !ok || appErr.Code != apperror.CodeValidation {
+				t.Fatalf("Start() error = %v, want a validation error", err)
+			}
+		})
+	}
+}
+
+func TestExecuteRecordsProgressAndErrors(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	failing := id.New()
+	docs := &memDocs{}
+	for i := range 30 {
+		doc := Document{Type: "goods_issue", ID: id.New(), Number: "N", Date: day.Add(time.Duration(i) * time.Hour)}
+		if i == 3 {
+			doc.ID = failing
+		}
+		docs.docs = append(docs.docs, doc)
+	}
+	repo := &memRepo{}
+	svc := NewService(repo, docs, nil)
+
+	var reposted int
+	reposter := reposterFunc(func(_ context.Context, _ string, docID id.ID) error {
+		if docID == failing {
+			return errors.New("period is closed")
+		}
+		reposted++
+		return nil
+	})
+
+	run := &Run{ID: id.New(), DateFrom: day, DateTo: day}
+	if err := svc.Execute(context.Background(), run, reposter); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if run.Status != StatusCompleted || run.Total != 30 || run.Processed != 30 || run.Failed != 1 || reposted != 29 {
+		t.Fatalf("run = %s %d/%d failed %d, reposted %d", run.Status, run.Processed, run.Total, run.Failed, reposted)
+	}
+	if len(run.Errors) != 1 || run.Errors[0].DocumentID != failing {
+		t.Fatalf("errors = %+v, want the failing document", run.Errors)
+	}
+	if !docs.to.Equal(day.AddDate(0, 0, 1)) {
+		t.Errorf("listed up to %v, want the day after DateTo", docs.to)
+	}
+	// start, one progress save after 25 documents, completion
+	if repo.updates != 3 {
+		t.Errorf("updates = %d, want 3", repo.updates)
+	}
+}
+
+func TestExecuteCancelledFails(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	docs := &memDocs{docs: []Document{{Type: "goods_issue", ID: id.New(), Date: day}}}
+	svc := NewService(&memRepo{}, docs, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	run := &Run{ID: id.New(), DateFrom: day, DateTo: day}
+	err := svc.Execute(ctx, run, reposterFunc(func(context.Context, string, id.ID) error { return nil }))
+	if !errors.Is(err, context.Canceled) || run.Status != StatusFailed || run.ErrorMessage == "" {
+		t.Fatalf("Execute() = %v, run %s %q", err, run.Status, run.ErrorMessage)
+	}
+}
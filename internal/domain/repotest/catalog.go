@@ -0,0 +1,312 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/domain"
+	"metapus/internal/domain/cursor"
+)
+
+// CatalogSuite checks an implementation of domain.CatalogRepository.
+type CatalogSuite[T entity.CatalogEntity] struct {
+	// Repo is the implementation under test.
+	Repo domain.CatalogRepository[T]
+
+	// New returns an unsaved entity with the given code and name and all
+	// other required fields filled in.
+	New func(code, name string) T
+
+	// Catalog exposes the embedded entity.Catalog of an entity.
+	Catalog func(T) *entity.Catalog
+
+	// Hierarchical enables the folder, tree and path checks.
+	Hierarchical bool
+}
+
+// Run executes the suite as subtests of t. ctx must carry everything the
+// repository needs (e.g. the tenant TxManager for the postgres repos).
+func (s CatalogSuite[T]) Run(t *testing.T, ctx context.Context) {
+	t.Run("CreateAndGet", func(t *testing.T) { s.testCreateAndGet(t, ctx) })
+	t.Run("NotFound", func(t *testing.T) { s.testNotFound(t, ctx) })
+	t.Run("Update", func(t *testing.T) { s.testUpdate(t, ctx) })
+	t.Run("OptimisticLock", func(t *testing.T) { s.testOptimisticLock(t, ctx) })
+	t.Run("DeletionMark", func(t *testing.T) { s.testDeletionMark(t, ctx) })
+	t.Run("Delete", func(t *testing.T) { s.testDelete(t, ctx) })
+	t.Run("ListFilters", func(t *testing.T) { s.testListFilters(t, ctx) })
+	t.Run("ListPaging", func(t *testing.T) { s.testListPaging(t, ctx) })
+	if s.Hierarchical {
+		t.Run("Tree", func(t *testing.T) { s.testTree(t, ctx) })
+	}
+}
+
+// create saves a new entity and returns it.
+func (s CatalogSuite[T]) create(t *testing.T, ctx context.Context, name string, configure func(*entity.Catalog)) T {
+	t.Helper()
+	e := s.New(Unique("rt"), name)
+	if configure != nil {
+		configure(s.Catalog(e))
+	}
+	must(t, s.Repo.Create(ctx, e), "create")
+	return e
+}
+
+func (s CatalogSuite[T]) testCreateAndGet(t *testing.T, ctx context.Context) {
+	e := s.create(t, ctx, "Contract item", nil)
+	want := s.Catalog(e)
+
+	got, err := s.Repo.GetByID(ctx, want.ID)
+	must(t, err, "get by id")
+	if c := s.Catalog(got); c.ID != want.ID || c.Code != want.Code || c.Name != want.Name {
+		t.Fatalf("GetByID = {%s %q %q}, want {%s %q %q}", c.ID, c.Code, c.Name, want.ID, want.Code, want.Name)
+	}
+	if v := s.Catalog(got).Version; v < 1 {
+		t.Errorf("version = %d after create, want >= 1", v)
+	}
+
+	got, err = s.Repo.GetByCode(ctx, want.Code)
+	must(t, err, "get by code")
+	if s.Catalog(got).ID != want.ID {
+		t.Errorf("GetByCode returned %s, want %s", s.Catalog(got).ID, want.ID)
+	}
+
+	ok, err := s.Repo.Exists(ctx, want.ID)
+	must(t, err, "exists")
+	if !ok {
+		t.Error("Exists = false for created entity")
+	}
+	ok, err = s.Repo.ExistsByCode(ctx, want.Code)
+	must(t, err, "exists by code")
+	if !ok {
+		t.Error("ExistsByCode = false for created entity")
+	}
+}
+
+func (s CatalogSuite[T]) testNotFound(t *testing.T, ctx context.Context) {
+	missing := id.New()
+
+	_, err := s.Repo.GetByID(ctx, missing)
+	requireCode(t, err, apperror.CodeNotFound)
+
+	_, err = s.Repo.GetByCode(ctx, Unique("missing"))
+	requireCode(t, err, apperror.CodeNotFound)
+
+	requireCode(t, s.Repo.Delete(ctx, missing), apperror.CodeNotFound)
+	requireCode(t, s.Repo.SetDeletionMark(ctx, missing, true), apperror.CodeNotFound)
+
+	ok, err := s.Repo.Exists(ctx, missing)
+	must(t, err, "exists")
+	if ok {
+		t.Error("Exists = true for missing id")
+	}
+}
+
+func (s CatalogSuite[T]) testUpdate(t *testing.T, ctx context.Context) {
+	e := s.create(t, ctx, "Before update", nil)
+	c := s.Catalog(e)
+	before := c.Version
+
+	c.Name = "After update"
+	must(t, s.Repo.Update(ctx, e), "update")
+	if c.Version != before+1 {
+		t.Errorf("in-memory version = %d after update, want %d", c.Version, before+1)
+	}
+
+	got, err := s.Repo.GetByID(ctx, c.ID)
+	must(t, err, "get by id")
+	if g := s.Catalog(got); g.Name != "After update" || g.Version != before+1 {
+		t.Errorf("stored {name %q, version %d}, want {%q, %d}", g.Name, g.Version, "After update", before+1)
+	}
+}
+
+func (s CatalogSuite[T]) testOptimisticLock(t *testing.T, ctx context.Context) {
+	e := s.create(t, ctx, "Locked", nil)
+	entityID := s.Catalog(e).ID
+
+	first, err := s.Repo.GetByID(ctx, entityID)
+	must(t, err, "get first copy")
+	second, err := s.Repo.GetByID(ctx, entityID)
+	must(t, err, "get second copy")
+
+	s.Catalog(first).Name = "First writer"
+	must(t, s.Repo.Update(ctx, first), "update first copy")
+
+	s.Catalog(second).Name = "Second writer"
+	requireCode(t, s.Repo.Update(ctx, second), apperror.CodeConcurrentModification)
+
+	got, err := s.Repo.GetByID(ctx, entityID)
+	must(t, err, "get by id")
+	if name := s.Catalog(got).Name; name != "First writer" {
+		t.Errorf("name = %q, stale update must not win", name)
+	}
+}
+
+func (s CatalogSuite[T]) testDeletionMark(t *testing.T, ctx context.Context) {
+	e := s.create(t, ctx, "Marked", nil)
+	entityID := s.Catalog(e).ID
+
+	must(t, s.Repo.SetDeletionMark(ctx, entityID, true), "set deletion mark")
+	got, err := s.Repo.GetByID(ctx, entityID)
+	must(t, err, "get marked entity")
+	if !s.Catalog(got).DeletionMark {
+		t.Error("DeletionMark = false after SetDeletionMark(true)")
+	}
+
+	if n := s.countListed(t, ctx, domain.ListFilter{IDs: []id.ID{entityID}}); n != 0 {
+		t.Errorf("List without IncludeDeleted returned %d marked items, want 0", n)
+	}
+	if n := s.countListed(t, ctx, domain.ListFilter{IDs: []id.ID{entityID}, IncludeDeleted: true}); n != 1 {
+		t.Errorf("List with IncludeDeleted returned %d items, want 1", n)
+	}
+
+	must(t, s.Repo.SetDeletionMark(ctx, entityID, false), "clear deletion mark")
+	if n := s.countListed(t, ctx, domain.ListFilter{IDs: []id.ID{entityID}}); n != 1 {
+		t.Errorf("List after clearing the mark returned %d items, want 1", n)
+	}
+}
+
+func (s CatalogSuite[T]) testDelete(t *testing.T, ctx context.Context) {
+	e := s.create(t, ctx, "Deleted", nil)
+	entityID := s.Catalog(e).ID
+
+	must(t, s.Repo.Delete(ctx, entityID), "delete")
+
+	_, err := s.Repo.GetByID(ctx, entityID)
+	requireCode(t, err, apperror.CodeNotFound)
+	ok, err := s.Repo.Exists(ctx, entityID)
+	must(t, err, "exists")
+	if ok {
+		t.Error("Exists = true after physical delete")
+	}
+}
+
+func (s CatalogSuite[T]) testListFilters(t *testing.T, ctx context.Context) {
+	token := Unique("srch")
+	a := s.create(t, ctx, "Alpha "+token, nil)
+	b := s.create(t, ctx, "Beta "+token, nil)
+	other := s.create(t, ctx, "Gamma unrelated", nil)
+
+	res, err := s.Repo.List(ctx, domain.ListFilter{Search: token, Limit: 10, OrderBy: "name"})
+	must(t, err, "list by search")
+	if ids := s.ids(res.Items); len(ids) != 2 || ids[0] != s.Catalog(a).ID || ids[1] != s.Catalog(b).ID {
+		t.Errorf("search %q returned %v, want [%s %s] ordered by name", token, ids, s.Catalog(a).ID, s.Catalog(b).ID)
+	}
+
+	res, err = s.Repo.List(ctx, domain.ListFilter{IDs: []id.ID{s.Catalog(other).ID}, Limit: 10})
+	must(t, err, "list by ids")
+	if ids := s.ids(res.Items); len(ids) != 1 || ids[0] != s.Catalog(other).ID {
+		t.Errorf("IDs filter returned %v, want [%s]", ids, s.Catalog(other).ID)
+	}
+	if res.TotalCount == nil || *res.TotalCount != 1 {
+		t.Errorf("TotalCount = %v, want 1", res.TotalCount)
+	}
+
+	res, err = s.Repo.List(ctx, domain.ListFilter{IDs: []id.ID{s.Catalog(other).ID}, Limit: 10, SkipCount: true})
+	must(t, err, "list with SkipCount")
+	if res.TotalCount != nil {
+		t.Errorf("TotalCount = %d with SkipCount, want nil", *res.TotalCount)
+	}
+}
+
+func (s CatalogSuite[T]) testListPaging(t *testing.T, ctx context.Context) {
+	var all []id.ID
+	for _, name := range []string{"Page 1", "Page 2", "Page 3"} {
+		all = append(all, s.Catalog(s.create(t, ctx, name, nil)).ID)
+	}
+
+	first, err := s.Repo.List(ctx, domain.ListFilter{IDs: all, Limit: 2, OrderBy: "name"})
+	must(t, err, "list first page")
+	if got := s.ids(first.Items); len(got) != 2 || got[0] != all[0] || got[1] != all[1] {
+		t.Fatalf("first page = %v, want %v", got, all[:2])
+	}
+	if !first.HasMore || first.NextCursor == "" {
+		t.Fatalf("first page HasMore=%v NextCursor=%q, want a next cursor", first.HasMore, first.NextCursor)
+	}
+
+	second, err := s.Repo.List(ctx, domain.ListFilter{
+		IDs: all, Limit: 2, OrderBy: "name",
+		CursorReq: &cursor.Request{Direction: cursor.DirAfter, Token: first.NextCursor},
+	})
+	must(t, err, "list second page")
+	if got := s.ids(second.Items); len(got) != 1 || got[0] != all[2] {
+		t.Fatalf("second page = %v, want [%s]", got, all[2])
+	}
+	if second.HasMore {
+		t.Error("second page HasMore = true on the last page")
+	}
+}
+
+func (s CatalogSuite[T]) testTree(t *testing.T, ctx context.Context) {
+	folder := func(c *entity.Catalog) { c.IsFolder = true }
+	root := s.create(t, ctx, "Root", folder)
+	rootID := s.Catalog(root).ID
+	sub := s.create(t, ctx, "Sub", func(c *entity.Catalog) { c.IsFolder = true; c.SetParent(rootID) })
+	subID := s.Catalog(sub).ID
+	leaf := s.create(t, ctx, "Leaf", func(c *entity.Catalog) { c.SetParent(subID) })
+	leafID := s.Catalog(leaf).ID
+	sibling := s.create(t, ctx, "Sibling", func(c *entity.Catalog) { c.SetParent(rootID) })
+	siblingID := s.Catalog(sibling).ID
+
+	tree, err := s.Repo.GetTree(ctx, &rootID)
+	must(t, err, "get tree")
+	if got := s.idSet(tree); len(got) != 3 || !got[subID] || !got[leafID] || !got[siblingID] {
+		t.Errorf("GetTree(root) = %v, want descendants {sub, leaf, sibling}", s.ids(tree))
+	}
+
+	path, err := s.Repo.GetPath(ctx, leafID)
+	must(t, err, "get path")
+	if got := s.ids(path); len(got) != 3 || got[0] != rootID || got[1] != subID || got[2] != leafID {
+		t.Errorf("GetPath(leaf) = %v, want [root sub leaf]", got)
+	}
+
+	res, err := s.Repo.List(ctx, domain.ListFilter{ParentID: &rootID, Limit: 10, OrderBy: "name"})
+	must(t, err, "list children")
+	if got := s.ids(res.Items); len(got) != 2 || got[0] != siblingID || got[1] != subID {
+		t.Errorf("children of root = %v, want [sibling sub]", got)
+	}
+
+	isFolder := true
+	res, err = s.Repo.List(ctx, domain.ListFilter{ParentID: &rootID, IsFolder: &isFolder, Limit: 10})
+	must(t, err, "list folders")
+	if got := s.ids(res.Items); len(got) != 1 || got[0] != subID {
+		t.Errorf("folders under root = %v, want [sub]", got)
+	}
+
+	// Marked branches are excluded from the tree.
+	must(t, s.Repo.SetDeletionMark(ctx, subID, true), "mark sub folder")
+	tree, err = s.Repo.GetTree(ctx, &rootID)
+	must(t, err, "get tree after mark")
+	if got := s.idSet(tree); len(got) != 1 || !got[siblingID] {
+		t.Errorf("GetTree(root) after marking sub = %v, want {sibling}", s.ids(tree))
+	}
+}
+
+// countListed returns the number of items List returns for f.
+func (s CatalogSuite[T]) countListed(t *testing.T, ctx context.Context, f domain.ListFilter) int {
+	t.Helper()
+	if f.Limit == 0 {
+		f.Limit = 50
+	}
+	res, err := s.Repo.List(ctx, f)
+	must(t, err, "list")
+	return len(res.Items)
+}
+
+func (s CatalogSuite[T]) ids(items []T) []id.ID {
+	out := make([]id.ID, len(items))
+	for i, e := range items {
+		out[i] = s.Catalog(e).ID
+	}
+	return out
+}
+
+func (s CatalogSuite[T]) idSet(items []T) map[id.ID]bool {
+	out := make(map[id.ID]bool, len(items))
+	for _, e := range items {
+		out[s.Catalog(e).ID] = true
+	}
+	return out
+}
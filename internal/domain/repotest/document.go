@@ -0,0 +1,204 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/domain"
+	"metapus/internal/domain/cursor"
+)
+
+// DocumentSuite checks an implementation of domain.DocumentRepository.
+type DocumentSuite[T any, L any] struct {
+	// Repo is the implementation under test.
+	Repo domain.DocumentRepository[T, L]
+
+	// New returns an unsaved document with the given number and all
+	// references pointing at existing rows.
+	New func(number string) T
+
+	// Document exposes the embedded entity.Document of a document.
+	Document func(T) *entity.Document
+
+	// NewLines returns n valid lines. Line identity is compared by count
+	// and order, so the factory may leave line IDs to the repository.
+	NewLines func(n int) []L
+}
+
+// Run executes the suite as subtests of t.
+func (s DocumentSuite[T, L]) Run(t *testing.T, ctx context.Context) {
+	t.Run("CreateAndGet", func(t *testing.T) { s.testCreateAndGet(t, ctx) })
+	t.Run("NotFound", func(t *testing.T) { s.testNotFound(t, ctx) })
+	t.Run("OptimisticLock", func(t *testing.T) { s.testOptimisticLock(t, ctx) })
+	t.Run("SoftDelete", func(t *testing.T) { s.testSoftDelete(t, ctx) })
+	t.Run("Lines", func(t *testing.T) { s.testLines(t, ctx) })
+	t.Run("ListAndListIDs", func(t *testing.T) { s.testList(t, ctx) })
+}
+
+func (s DocumentSuite[T, L]) create(t *testing.T, ctx context.Context, number string) T {
+	t.Helper()
+	doc := s.New(number)
+	must(t, s.Repo.Create(ctx, doc), "create")
+	return doc
+}
+
+func (s DocumentSuite[T, L]) testCreateAndGet(t *testing.T, ctx context.Context) {
+	doc := s.create(t, ctx, Unique("RT-"))
+	want := s.Document(doc)
+
+	got, err := s.Repo.GetByID(ctx, want.ID)
+	must(t, err, "get by id")
+	if d := s.Document(got); d.ID != want.ID || d.Number != want.Number || d.Posted {
+		t.Fatalf("GetByID = {%s %q posted=%v}, want {%s %q posted=false}", d.ID, d.Number, d.Posted, want.ID, want.Number)
+	}
+
+	got, err = s.Repo.GetByNumber(ctx, want.Number)
+	must(t, err, "get by number")
+	if s.Document(got).ID != want.ID {
+		t.Errorf("GetByNumber returned %s, want %s", s.Document(got).ID, want.ID)
+	}
+}
+
+func (s DocumentSuite[T, L]) testNotFound(t *testing.T, ctx context.Context) {
+	_, err := s.Repo.GetByID(ctx, id.New())
+	requireCode(t, err, apperror.CodeNotFound)
+
+	_, err = s.Repo.GetByNumber(ctx, Unique("NONE-"))
+	requireCode(t, err, apperror.CodeNotFound)
+
+	requireCode(t, s.Repo.Delete(ctx, id.New()), apperror.CodeNotFound)
+}
+
+func (s DocumentSuite[T, L]) testOptimisticLock(t *testing.T, ctx context.Context) {
+	doc := s.create(t, ctx, Unique("RT-"))
+	docID := s.Document(doc).ID
+
+	first, err := s.Repo.GetByID(ctx, docID)
+	must(t, err, "get first copy")
+	second, err := s.Repo.GetByID(ctx, docID)
+	must(t, err, "get second copy")
+
+	before := s.Document(first).Version
+	s.Document(first).Description = "first writer"
+	must(t, s.Repo.Update(ctx, first), "update first copy")
+	if v := s.Document(first).Version; v != before+1 {
+		t.Errorf("in-memory version = %d after update, want %d", v, before+1)
+	}
+
+	s.Document(second).Description = "second writer"
+	requireCode(t, s.Repo.Update(ctx, second), apperror.CodeConcurrentModification)
+
+	got, err := s.Repo.GetByID(ctx, docID)
+	must(t, err, "get by id")
+	if d := s.Document(got); d.Description != "first writer" || d.Version != before+1 {
+		t.Errorf("stored {description %q, version %d}, want {%q, %d}", d.Description, d.Version, "first writer", before+1)
+	}
+}
+
+func (s DocumentSuite[T, L]) testSoftDelete(t *testing.T, ctx context.Context) {
+	number := Unique("RT-")
+	doc := s.create(t, ctx, number)
+	docID := s.Document(doc).ID
+
+	must(t, s.Repo.Delete(ctx, docID), "delete")
+
+	got, err := s.Repo.GetByID(ctx, docID)
+	must(t, err, "get deleted document")
+	if !s.Document(got).DeletionMark {
+		t.Error("DeletionMark = false after Delete; documents are soft-deleted")
+	}
+
+	ids, err := s.Repo.ListIDs(ctx, domain.ListFilter{Search: number}, 10)
+	must(t, err, "list ids")
+	if len(ids) != 0 {
+		t.Errorf("ListIDs without IncludeDeleted = %v, want none", ids)
+	}
+	ids, err = s.Repo.ListIDs(ctx, domain.ListFilter{Search: number, IncludeDeleted: true}, 10)
+	must(t, err, "list ids with deleted")
+	if len(ids) != 1 || ids[0] != docID {
+		t.Errorf("ListIDs with IncludeDeleted = %v, want [%s]", ids, docID)
+	}
+}
+
+func (s DocumentSuite[T, L]) testLines(t *testing.T, ctx context.Context) {
+	doc := s.create(t, ctx, Unique("RT-"))
+	docID := s.Document(doc).ID
+
+	lines, err := s.Repo.GetLines(ctx, docID)
+	must(t, err, "get lines of new document")
+	if len(lines) != 0 {
+		t.Fatalf("new document has %d lines, want 0", len(lines))
+	}
+
+	must(t, s.Repo.SaveLines(ctx, docID, s.NewLines(3)), "save 3 lines")
+	lines, err = s.Repo.GetLines(ctx, docID)
+	must(t, err, "get lines")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3", len(lines))
+	}
+
+	// SaveLines replaces the table part, it does not append.
+	must(t, s.Repo.SaveLines(ctx, docID, s.NewLines(1)), "save 1 line")
+	lines, err = s.Repo.GetLines(ctx, docID)
+	must(t, err, "get lines after replace")
+	if len(lines) != 1 {
+		t.Fatalf("got %d lines after replace, want 1", len(lines))
+	}
+
+	must(t, s.Repo.SaveLines(ctx, docID, nil), "clear lines")
+	lines, err = s.Repo.GetLines(ctx, docID)
+	must(t, err, "get lines after clear")
+	if len(lines) != 0 {
+		t.Fatalf("got %d lines after clear, want 0", len(lines))
+	}
+}
+
+func (s DocumentSuite[T, L]) testList(t *testing.T, ctx context.Context) {
+	prefix := Unique("RL")
+	want := make(map[id.ID]bool)
+	for _, n := range []string{"-1", "-2", "-3"} {
+		want[s.Document(s.create(t, ctx, prefix+n)).ID] = true
+	}
+
+	first, err := s.Repo.List(ctx, domain.ListFilter{Search: prefix, Limit: 2})
+	must(t, err, "list first page")
+	if len(first.Items) != 2 || !first.HasMore || first.NextCursor == "" {
+		t.Fatalf("first page: %d items, HasMore=%v, NextCursor=%q; want 2 items and a next cursor",
+			len(first.Items), first.HasMore, first.NextCursor)
+	}
+	if first.TotalCount == nil || *first.TotalCount != 3 {
+		t.Errorf("TotalCount = %v, want 3", first.TotalCount)
+	}
+
+	second, err := s.Repo.List(ctx, domain.ListFilter{
+		Search: prefix, Limit: 2,
+		CursorReq: &cursor.Request{Direction: cursor.DirAfter, Token: first.NextCursor},
+	})
+	must(t, err, "list second page")
+	if len(second.Items) != 1 || second.HasMore {
+		t.Fatalf("second page: %d items, HasMore=%v; want 1 item on the last page", len(second.Items), second.HasMore)
+	}
+
+	seen := make(map[id.ID]bool)
+	for _, doc := range append(first.Items, second.Items...) {
+		docID := s.Document(doc).ID
+		if !want[docID] || seen[docID] {
+			t.Errorf("unexpected or repeated document %s across pages", docID)
+		}
+		seen[docID] = true
+	}
+
+	ids, err := s.Repo.ListIDs(ctx, domain.ListFilter{Search: prefix}, 10)
+	must(t, err, "list ids")
+	if len(ids) != 3 {
+		t.Errorf("ListIDs = %d ids, want 3", len(ids))
+	}
+
+	_, err = s.Repo.ListIDs(ctx, domain.ListFilter{Search: prefix}, 2)
+	if err == nil {
+		t.Error("ListIDs over maxIDs returned no error")
+	}
+}
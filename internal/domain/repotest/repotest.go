@@ -0,0 +1,54 @@
+// Package repotest contains behavioral contract suites for the domain
+// repository interfaces (catalog, document, stock register).
+//
+// A suite is parameterized by the implementation under test plus a few
+// factories for valid entities, so the same checks run against the postgres
+// repositories today and against any alternative backend or refactor later:
+//
+//	repotest.CatalogSuite[*unit.Unit]{
+//		Repo:    catalog_repo.NewUnitRepo(),
+//		New:     func(code, name string) *unit.Unit { return unit.NewUnit(code, name, "pc", unit.TypePiece) },
+//		Catalog: func(u *unit.Unit) *entity.Catalog { return &u.Catalog },
+//	}.Run(t, ctx)
+//
+// Suites never assume an empty database: every test creates its own rows
+// with unique codes/numbers and filters on them, so they can run against a
+// shared scratch database repeatedly.
+package repotest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"metapus/internal/core/apperror"
+)
+
+// Unique returns prefix followed by a random hex suffix. Use it for codes and
+// numbers so repeated runs do not collide on unique constraints.
+func Unique(prefix string) string {
+	var b [4]byte
+	_, _ = rand.Read(b[:])
+	return prefix + hex.EncodeToString(b[:])
+}
+
+// requireCode fails the test unless err is an *apperror.AppError with code.
+func requireCode(t *testing.T, err error, code string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("expected %s error, got nil", code)
+	}
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) || appErr.Code != code {
+		t.Fatalf("expected %s error, got %v", code, err)
+	}
+}
+
+// must fails the test on a non-nil error.
+func must(t *testing.T, err error, what string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %v", what, err)
+	}
+}
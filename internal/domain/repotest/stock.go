@@ -0,0 +1,376 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/core/types"
+	"metapus/internal/domain/registers/stock"
+)
+
+// StockSuite checks an implementation of stock.Repository: movements,
+// balances maintained from movements, turnovers and stock control.
+type StockSuite struct {
+	// Repo is the implementation under test.
+	Repo stock.Repository
+
+	// NewKey returns a fresh warehouse+nomenclature pair. Backends that
+	// enforce references create the catalog rows here. Nil means random IDs.
+	NewKey func() stock.BalanceKey
+
+	// SetStoredBalance overwrites the stored balance of key without a
+	// movement, simulating a balance that drifted from the movements. Nil
+	// skips the recalculation cases that need drift.
+	SetStoredBalance func(ctx context.Context, key stock.BalanceKey, quantity types.Quantity) error
+
+	// Corrections stores stock correction documents. Nil skips the
+	// correction cases.
+	Corrections stock.CorrectionRepository
+}
+
+const stockRecorderType = "repotest"
+
+// Run executes the suite as subtests of t.
+func (s StockSuite) Run(t *testing.T, ctx context.Context) {
+	t.Run("MovementsAndBalance", func(t *testing.T) { s.testMovementsAndBalance(t, ctx) })
+	t.Run("MissingBalanceIsZero", func(t *testing.T) { s.testMissingBalance(t, ctx) })
+	t.Run("DeleteByRecorderVersion", func(t *testing.T) { s.testDeleteByRecorder(t, ctx) })
+	t.Run("Turnover", func(t *testing.T) { s.testTurnover(t, ctx) })
+	t.Run("MovementHistoryKeyset", func(t *testing.T) { s.testMovementHistory(t, ctx) })
+	t.Run("StockAvailability", func(t *testing.T) { s.testAvailability(t, ctx) })
+	t.Run("BalancesAtDateWithSnapshots", func(t *testing.T) { s.testBalancesAtDate(t, ctx) })
+	t.Run("RecalculateBalances", func(t *testing.T) { s.testRecalculateBalances(t, ctx) })
+	if s.Corrections != nil {
+		t.Run("CorrectionMovesOnce", func(t *testing.T) { s.testCorrectionMovesOnce(t, ctx) })
+		t.Run("CorrectedDocumentKeepsMovements", func(t *testing.T) { s.testCorrectedDocumentKeepsMovements(t, ctx) })
+	}
+}
+
+func (s StockSuite) key() stock.BalanceKey {
+	if s.NewKey != nil {
+		return s.NewKey()
+	}
+	return stock.BalanceKey{WarehouseID: id.New(), NomenclatureID: id.New()}
+}
+
+// post records a receipt and an optional expense for key under one recorder.
+func (s StockSuite) post(t *testing.T, ctx context.Context, recorderID id.ID, version int, period time.Time,
+	key stock.BalanceKey, receipt, expense float64) {
+	t.Helper()
+	movements := []entity.StockMovement{
+		entity.NewStockMovement(recorderID, stockRecorderType, version, period, entity.RecordTypeReceipt,
+			key.WarehouseID, key.NomenclatureID, types.NewQuantityFromFloat64(receipt)),
+	}
+	if expense > 0 {
+		movements = append(movements, entity.NewStockMovement(recorderID, stockRecorderType, version, period,
+			entity.RecordTypeExpense, key.WarehouseID, key.NomenclatureID, types.NewQuantityFromFloat64(expense)))
+	}
+	must(t, s.Repo.CreateMovements(ctx, movements), "create movements")
+}
+
+func (s StockSuite) requireBalance(t *testing.T, ctx context.Context, key stock.BalanceKey, want float64) {
+	t.Helper()
+	bal, err := s.Repo.GetBalance(ctx, key.WarehouseID, key.NomenclatureID)
+	must(t, err, "get balance")
+	if bal.Quantity != types.NewQuantityFromFloat64(want) {
+		t.Fatalf("balance = %s, want %v", bal.Quantity, want)
+	}
+}
+
+func (s StockSuite) testMovementsAndBalance(t *testing.T, ctx context.Context) {
+	key := s.key()
+	recorderID := id.New()
+	s.post(t, ctx, recorderID, 1, time.Now(), key, 10, 3)
+
+	movements, err := s.Repo.GetMovementsByRecorder(ctx, recorderID)
+	must(t, err, "get movements")
+	if len(movements) != 2 {
+		t.Fatalf("got %d movements, want 2", len(movements))
+	}
+	s.requireBalance(t, ctx, key, 7)
+
+	byWarehouse, err := s.Repo.GetBalancesByWarehouse(ctx, key.WarehouseID, stock.BalanceFilter{})
+	must(t, err, "get balances by warehouse")
+	if len(byWarehouse) != 1 || byWarehouse[0].NomenclatureID != key.NomenclatureID {
+		t.Errorf("balances by warehouse = %+v, want the single posted item", byWarehouse)
+	}
+}
+
+func (s StockSuite) testMissingBalance(t *testing.T, ctx context.Context) {
+	posted, missing := s.key(), s.key()
+	s.post(t, ctx, id.New(), 1, time.Now(), posted, 5, 0)
+
+	s.requireBalance(t, ctx, missing, 0)
+
+	balances, err := s.Repo.GetBalancesForUpdate(ctx, []stock.BalanceKey{missing, posted})
+	must(t, err, "get balances for update")
+	if len(balances) != 2 {
+		t.Fatalf("GetBalancesForUpdate returned %d balances, want one per key", len(balances))
+	}
+	for _, b := range balances {
+		want := types.Quantity(0)
+		if b.WarehouseID == posted.WarehouseID && b.NomenclatureID == posted.NomenclatureID {
+			want = types.NewQuantityFromFloat64(5)
+		}
+		if b.Quantity != want {
+			t.Errorf("balance for %s/%s = %s, want %s", b.WarehouseID, b.NomenclatureID, b.Quantity, want)
+		}
+	}
+}
+
+func (s StockSuite) testDeleteByRecorder(t *testing.T, ctx context.Context) {
+	key := s.key()
+	recorderID := id.New()
+	s.post(t, ctx, recorderID, 1, time.Now(), key, 4, 0)
+	s.post(t, ctx, recorderID, 2, time.Now(), key, 6, 0)
+	s.requireBalance(t, ctx, key, 10)
+
+	// Re-posting removes movements of earlier versions only.
+	must(t, s.Repo.DeleteMovementsByRecorder(ctx, recorderID, 2), "delete movements before version 2")
+	movements, err := s.Repo.GetMovementsByRecorder(ctx, recorderID)
+	must(t, err, "get movements")
+	if len(movements) != 1 || movements[0].RecorderVersion != 2 {
+		t.Fatalf("movements after delete = %+v, want only version 2", movements)
+	}
+	s.requireBalance(t, ctx, key, 6)
+
+	// Unposting removes everything.
+	must(t, s.Repo.DeleteMovementsByRecorder(ctx, recorderID, 3), "delete all movements")
+	s.requireBalance(t, ctx, key, 0)
+}
+
+func (s StockSuite) testTurnover(t *testing.T, ctx context.Context) {
+	key := s.key()
+	start := time.Now().UTC().Truncate(time.Hour).Add(-48 * time.Hour)
+	s.post(t, ctx, id.New(), 1, start.Add(-time.Hour), key, 2, 0) // before the period: opening balance
+	s.post(t, ctx, id.New(), 1, start.Add(time.Hour), key, 10, 3)
+	s.post(t, ctx, id.New(), 1, start.Add(30*time.Hour), key, 0.5, 0) // after the period
+
+	turnover, err := s.Repo.GetTurnover(ctx, stock.TurnoverFilter{
+		WarehouseID:    &key.WarehouseID,
+		NomenclatureID: &key.NomenclatureID,
+		FromDate:       start,
+		ToDate:         start.Add(24 * time.Hour),
+	})
+	must(t, err, "get turnover")
+
+	q := types.NewQuantityFromFloat64
+	if turnover.OpeningBalance != q(2) || turnover.Receipt != q(10) || turnover.Expense != q(3) || turnover.ClosingBalance != q(9) {
+		t.Errorf("turnover = opening %s, receipt %s, expense %s, closing %s; want 2, 10, 3, 9",
+			turnover.OpeningBalance, turnover.Receipt, turnover.Expense, turnover.ClosingBalance)
+	}
+}
+
+func (s StockSuite) testMovementHistory(t *testing.T, ctx context.Context) {
+	key := s.key()
+	period := time.Now().UTC().Truncate(time.Second)
+	s.post(t, ctx, id.New(), 1, period, key, 4, 1) // two movements in the same period
+	s.post(t, ctx, id.New(), 1, period.Add(-time.Hour), key, 2, 0)
+
+	filter := stock.MovementFilter{WarehouseID: &key.WarehouseID, Limit: 2}
+	first, err := s.Repo.GetMovementHistory(ctx, key.NomenclatureID, filter)
+	must(t, err, "get first page")
+	if len(first.Items) != 2 || !first.HasMore || first.NextCursor == "" {
+		t.Fatalf("first page = %d items, hasMore %v, cursor %q; want 2, true, non-empty",
+			len(first.Items), first.HasMore, first.NextCursor)
+	}
+
+	filter.After = first.NextCursor
+	second, err := s.Repo.GetMovementHistory(ctx, key.NomenclatureID, filter)
+	must(t, err, "get second page")
+	if len(second.Items) != 1 || second.HasMore || !second.Items[0].Period.Equal(period.Add(-time.Hour)) {
+		t.Fatalf("second page = %+v, want only the oldest movement", second.Items)
+	}
+
+	q := types.NewQuantityFromFloat64
+	if second.Totals != first.Totals || first.Totals.Count != 3 || first.Totals.Receipt != q(6) || first.Totals.Expense != q(1) {
+		t.Errorf("totals = %+v / %+v, want count 3, receipt 6, expense 1 on every page", first.Totals, second.Totals)
+	}
+}
+
+func (s StockSuite) testAvailability(t *testing.T, ctx context.Context) {
+	key := s.key()
+	s.post(t, ctx, id.New(), 1, time.Now(), key, 5, 0)
+
+	must(t, s.Repo.CheckStockAvailability(ctx, key.WarehouseID, key.NomenclatureID, types.NewQuantityFromFloat64(5), stock.AvailabilityOptions{}),
+		"check available quantity")
+	requireCode(t,
+		s.Repo.CheckStockAvailability(ctx, key.WarehouseID, key.NomenclatureID, types.NewQuantityFromFloat64(5.5), stock.AvailabilityOptions{}),
+		apperror.CodeInsufficientStock)
+}
+
+func (s StockSuite) testBalancesAtDate(t *testing.T, ctx context.Context) {
+	key, late := s.key(), s.key()
+	now := time.Now().UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	first, second := month.AddDate(0, -2, 1), month.AddDate(0, -1, 1)
+	s.post(t, ctx, id.New(), 1, first, key, 10, 0)
+	s.post(t, ctx, id.New(), 1, second, key, 10, 4)
+	s.post(t, ctx, id.New(), 1, now, key, 1, 0)
+
+	_, err := s.Repo.RefreshSnapshots(ctx, now)
+	must(t, err, "refresh snapshots")
+
+	requireAt := func(key stock.BalanceKey, date time.Time, want float64) {
+		t.Helper()
+		got, err := s.Repo.GetBalancesAtDate(ctx, key.WarehouseID, key.NomenclatureID, date)
+		must(t, err, "get balance at date")
+		if got != types.NewQuantityFromFloat64(want) {
+			t.Errorf("balance at %s = %s, want %v", date.Format(time.DateOnly), got, want)
+		}
+	}
+	requireAt(key, first.Add(-time.Hour), 0)
+	requireAt(key, second.Add(-time.Hour), 10)
+	requireAt(key, month, 16)
+	requireAt(key, now, 17)
+
+	// Back-dated posting and unposting keep later snapshots exact,
+	// also for a product without snapshots yet.
+	backdated := id.New()
+	s.post(t, ctx, backdated, 1, first.Add(time.Hour), key, 2, 0)
+	s.post(t, ctx, backdated, 1, first.Add(time.Hour), late, 3, 0)
+	requireAt(key, month, 18)
+	requireAt(late, month, 3)
+	must(t, s.Repo.DeleteMovementsByRecorder(ctx, backdated, 2), "unpost back-dated movements")
+	requireAt(key, month, 16)
+	requireAt(late, now, 0)
+}
+
+// recalculate runs RecalculateBalances for key in a transaction.
+func (s StockSuite) recalculate(t *testing.T, ctx context.Context, key stock.BalanceKey, dryRun bool) []stock.BalanceDiscrepancy {
+	t.Helper()
+	txm, err := tenant.GetTxManager(ctx)
+	must(t, err, "tx manager")
+	filter := stock.RecalculateFilter{WarehouseID: &key.WarehouseID, NomenclatureID: &key.NomenclatureID}
+	var found []stock.BalanceDiscrepancy
+	must(t, txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		found, err = s.Repo.RecalculateBalances(ctx, filter, dryRun)
+		return err
+	}), "recalculate balances")
+	return found
+}
+
+// requireDiscrepancy fails unless found is the single discrepancy of key
+// from stored to computed.
+func requireDiscrepancy(t *testing.T, found []stock.BalanceDiscrepancy, key stock.BalanceKey, stored, computed float64) {
+	t.Helper()
+	want := stock.BalanceDiscrepancy{
+		WarehouseID:    key.WarehouseID,
+		NomenclatureID: key.NomenclatureID,
+		Stored:         types.NewQuantityFromFloat64(stored),
+		Computed:       types.NewQuantityFromFloat64(computed),
+	}
+	if len(found) != 1 || found[0] != want {
+		t.Fatalf("discrepancies = %+v, want [%+v]", found, want)
+	}
+}
+
+func (s StockSuite) testRecalculateBalances(t *testing.T, ctx context.Context) {
+	t.Run("EmptyRegister", func(t *testing.T) {
+		key := s.key()
+		for _, dryRun := range []bool{true, false} {
+			if found := s.recalculate(t, ctx, key, dryRun); len(found) != 0 {
+				t.Fatalf("dryRun=%v: discrepancies = %+v, want none without movements", dryRun, found)
+			}
+		}
+		s.requireBalance(t, ctx, key, 0)
+	})
+
+	t.Run("InSync", func(t *testing.T) {
+		key := s.key()
+		s.post(t, ctx, id.New(), 1, time.Now(), key, 10, 4)
+		for _, dryRun := range []bool{true, false} {
+			if found := s.recalculate(t, ctx, key, dryRun); len(found) != 0 {
+				t.Fatalf("dryRun=%v: discrepancies = %+v, want none for trigger-maintained balances", dryRun, found)
+			}
+		}
+		s.requireBalance(t, ctx, key, 6)
+	})
+
+	if s.SetStoredBalance == nil {
+		return
+	}
+
+	t.Run("CorrectsDrift", func(t *testing.T) {
+		key := s.key()
+		s.post(t, ctx, id.New(), 1, time.Now(), key, 10, 4)
+		must(t, s.SetStoredBalance(ctx, key, types.NewQuantityFromFloat64(9)), "set stored balance")
+
+		requireDiscrepancy(t, s.recalculate(t, ctx, key, true), key, 9, 6)
+		s.requireBalance(t, ctx, key, 9)
+
+		requireDiscrepancy(t, s.recalculate(t, ctx, key, false), key, 9, 6)
+		s.requireBalance(t, ctx, key, 6)
+
+		if found := s.recalculate(t, ctx, key, true); len(found) != 0 {
+			t.Fatalf("discrepancies after the rebuild = %+v, want none", found)
+		}
+	})
+
+	t.Run("ZeroesBalanceWithoutMovements", func(t *testing.T) {
+		key := s.key()
+		must(t, s.SetStoredBalance(ctx, key, types.NewQuantityFromFloat64(5)), "set stored balance")
+
+		requireDiscrepancy(t, s.recalculate(t, ctx, key, false), key, 5, 0)
+		s.requireBalance(t, ctx, key, 0)
+
+		if found := s.recalculate(t, ctx, key, true); len(found) != 0 {
+			t.Fatalf("discrepancies after the rebuild = %+v, want none", found)
+		}
+	})
+}
+
+// correct moves the movements of recorderID to the warehouse of target.
+func (s StockSuite) correct(ctx context.Context, recorderID id.ID, target stock.BalanceKey) error {
+	_, _, err := stock.NewCorrectionService(s.Repo, s.Corrections).Apply(ctx, stock.CorrectionRequest{
+		Filter:            stock.CorrectionFilter{RecorderIDs: []id.ID{recorderID}},
+		TargetWarehouseID: &target.WarehouseID,
+		Reason:            "repotest",
+	})
+	return err
+}
+
+func (s StockSuite) testCorrectionMovesOnce(t *testing.T, ctx context.Context) {
+	source, first, second := s.key(), s.key(), s.key()
+	recorderID := id.New()
+	s.post(t, ctx, recorderID, 1, time.Now(), source, 10, 0)
+	first.NomenclatureID, second.NomenclatureID = source.NomenclatureID, source.NomenclatureID
+
+	must(t, s.correct(ctx, recorderID, first), "apply correction")
+	s.requireBalance(t, ctx, source, 0)
+	s.requireBalance(t, ctx, first, 10)
+
+	// Applying again or re-targeting finds nothing left to move: the
+	// source movements must not be reversed a second time.
+	requireCode(t, s.correct(ctx, recorderID, first), apperror.CodeValidation)
+	requireCode(t, s.correct(ctx, recorderID, second), apperror.CodeValidation)
+	s.requireBalance(t, ctx, source, 0)
+	s.requireBalance(t, ctx, first, 10)
+	s.requireBalance(t, ctx, second, 0)
+}
+
+func (s StockSuite) testCorrectedDocumentKeepsMovements(t *testing.T, ctx context.Context) {
+	source, target := s.key(), s.key()
+	target.NomenclatureID = source.NomenclatureID
+	recorderID := id.New()
+	s.post(t, ctx, recorderID, 1, time.Now(), source, 10, 0)
+	must(t, s.correct(ctx, recorderID, target), "apply correction")
+
+	// Re-posting (version 2) or unposting would delete the corrected
+	// movements and leave the correction's adjustments behind.
+	svc := stock.NewService(s.Repo)
+	requireCode(t, svc.ReverseMovements(ctx, recorderID, 2), "STOCK_MOVEMENTS_CORRECTED")
+
+	movements, err := s.Repo.GetMovementsByRecorder(ctx, recorderID)
+	must(t, err, "get movements")
+	if len(movements) != 1 {
+		t.Fatalf("got %d movements, want the corrected one kept", len(movements))
+	}
+	s.requireBalance(t, ctx, source, 0)
+	s.requireBalance(t, ctx, target, 10)
+}
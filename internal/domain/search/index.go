@@ -0,0 +1,117 @@
+package search
+
+import (
+	"context"
+	"time"
+
+	"metapus/internal/core/security"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/settings"
+)
+
+// Index is an external full-text index of searchable rows (OpenSearch) for
+// tenants with very large catalogs. Every tenant has its own index; rows are
+// written by the Indexer and read by Service.Search.
+type Index interface {
+	// Upsert adds or replaces rows of the tenant's index.
+	Upsert(ctx context.Context, tenantID string, rows []IndexedRow) error
+	// Delete removes a row; a missing row is not an error.
+	Delete(ctx context.Context, tenantID, entityKey, entityID string) error
+	// Reset drops the tenant's index before a full rebuild.
+	Reset(ctx context.Context, tenantID string) error
+	// Search runs one query per entity and returns their hits in query order.
+	Search(ctx context.Context, tenantID, text string, queries []IndexQuery) ([][]IndexedRow, error)
+}
+
+// IndexedRow is the indexed projection of one catalog or document row.
+type IndexedRow struct {
+	EntityKey    string            `json:"entityKey"`
+	EntityID     string            `json:"entityId"`
+	Title        string            `json:"title"`
+	Subtitle     string            `json:"subtitle,omitempty"`
+	Text         string            `json:"text"` // search columns joined by spaces
+	Date         *time.Time        `json:"date,omitempty"`
+	DeletionMark bool              `json:"deletionMark"`
+	Dimensions   map[string]string `json:"dimensions,omitempty"` // RLS column -> value
+}
+
+// IndexQuery selects the hits of one entity, mirroring a sub-select of the
+// Postgres search.
+type IndexQuery struct {
+	EntityKey      string
+	Filters        map[string][]string // RLS column -> allowed values
+	ExcludeDeleted bool
+	SortByDate     bool // documents: newest first; otherwise by relevance
+	Limit          int
+}
+
+// SetIndex enables the external index for tenants with search.backend = opensearch.
+func (s *Service) SetIndex(index Index) {
+	s.index = index
+}
+
+// usesIndex reports whether the tenant in ctx searches through the external index.
+func (s *Service) usesIndex(ctx context.Context) bool {
+	if s.index == nil || tenant.GetTenantID(ctx) == "" {
+		return false
+	}
+	return settings.Get(ctx, settings.KeySearchBackend, settings.Subject{}) == settings.SearchBackendOpenSearch
+}
+
+// searchIndex answers a global search from the external index. Entity
+// filtering, RLS and ordering follow the Postgres search.
+func (s *Service) searchIndex(ctx context.Context, scope *security.DataScope, query string, limit int) ([]SearchResult, error) {
+	queries := make([]IndexQuery, 0, len(s.entities))
+	entities := make([]SearchableEntity, 0, len(s.entities))
+	for _, e := range s.entities {
+		filters, ok := rlsFilters(e, scope)
+		if !ok {
+			continue
+		}
+		queries = append(queries, IndexQuery{
+			EntityKey:      e.EntityKey,
+			Filters:        filters,
+			ExcludeDeleted: e.EntityType == "catalog",
+			SortByDate:     e.EntityType == "document",
+			Limit:          limit,
+		})
+		entities = append(entities, e)
+	}
+	if len(queries) == 0 {
+		return nil, nil
+	}
+
+	hits, err := s.index.Search(ctx, tenant.GetTenantID(ctx), query, queries)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResult, 0, len(queries)*limit)
+	for i, e := range entities {
+		if i >= len(hits) {
+			break
+		}
+		for _, row := range hits[i] {
+			results = append(results, SearchResult{
+				EntityType: e.EntityType,
+				EntityName: e.EntityName,
+				EntityKey:  e.EntityKey,
+				EntityID:   row.EntityID,
+				Title:      row.Title,
+				Subtitle:   row.Subtitle,
+				URL:        s.buildURL(searchRow{EntityType: e.EntityType, RoutePrefix: e.RoutePrefix, ID: row.EntityID}),
+			})
+		}
+	}
+	return results, nil
+}
+
+// entityByTable returns the searchable entity stored in table.
+func (s *Service) entityByTable(table string) (SearchableEntity, bool) {
+	for _, e := range s.entities {
+		if e.TableName == table {
+			return e, true
+		}
+	}
+	return SearchableEntity{}, false
+}
@@ -0,0 +1,156 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"metapus/internal/core/tenant"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+// _rebuildBatchSize is the number of rows read and indexed per round trip
+// during a full rebuild.
+const _rebuildBatchSize = 500
+
+// Indexer keeps a tenant's external index in sync with its tables.
+// It consumes the search_index outbox messages queued by the base catalog
+// and document repositories (see postgres.EnqueueSearchIndex) and implements
+// postgres.OutboxHandler for them.
+type Indexer struct {
+	svc   *Service
+	index Index
+}
+
+// NewIndexer creates an indexer for the searchable entities of svc.
+func NewIndexer(svc *Service, index Index) *Indexer {
+	return &Indexer{svc: svc, index: index}
+}
+
+// Handle processes a search_index outbox message.
+func (ix *Indexer) Handle(ctx context.Context, msg *postgres.OutboxMessage) error {
+	switch msg.EventType {
+	case postgres.SearchIndexEventReindex:
+		var p postgres.SearchIndexPayload
+		if err := json.Unmarshal(msg.Payload, &p); err != nil {
+			return fmt.Errorf("unmarshal search index payload: %w", err)
+		}
+		return ix.Reindex(ctx, p.Table, p.EntityID)
+	case postgres.SearchIndexEventRebuild:
+		_, err := ix.Rebuild(ctx)
+		return err
+	}
+	return fmt.Errorf("unknown search index event %q", msg.EventType)
+}
+
+// Reindex refreshes one row: its current state is upserted, or the row is
+// removed from the index when it no longer exists. Tables without a
+// searchable entity are ignored.
+func (ix *Indexer) Reindex(ctx context.Context, table, entityID string) error {
+	e, ok := ix.svc.entityByTable(table)
+	if !ok {
+		return nil
+	}
+	tenantID := tenant.GetTenantID(ctx)
+
+	rows, err := ix.loadRows(ctx, e, "id = $1::uuid", entityID)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return ix.index.Delete(ctx, tenantID, e.EntityKey, entityID)
+	}
+	return ix.index.Upsert(ctx, tenantID, rows)
+}
+
+// Rebuild drops the tenant's index and indexes all searchable tables again.
+// Used when a tenant switches to the external index. Returns indexed rows.
+func (ix *Indexer) Rebuild(ctx context.Context) (int, error) {
+	tenantID := tenant.GetTenantID(ctx)
+	if err := ix.index.Reset(ctx, tenantID); err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, e := range ix.svc.entities {
+		after := "00000000-0000-0000-0000-000000000000"
+		for {
+			rows, err := ix.loadRows(ctx, e, "id > $1::uuid ORDER BY id LIMIT "+fmt.Sprint(_rebuildBatchSize), after)
+			if err != nil {
+				return total, err
+			}
+			if len(rows) == 0 {
+				break
+			}
+			if err := ix.index.Upsert(ctx, tenantID, rows); err != nil {
+				return total, err
+			}
+			total += len(rows)
+			if len(rows) < _rebuildBatchSize {
+				break
+			}
+			after = rows[len(rows)-1].EntityID
+		}
+	}
+	return total, nil
+}
+
+// loadRows reads the indexed projection of rows of e matching where ($1 = arg).
+func (ix *Indexer) loadRows(ctx context.Context, e SearchableEntity, where string, arg any) ([]IndexedRow, error) {
+	textCols := make([]string, 0, len(e.SearchCols))
+	for _, col := range e.SearchCols {
+		textCols = append(textCols, col+"::text")
+	}
+	subtitleExpr := "''"
+	if e.SubtitleCol != "" {
+		subtitleExpr = "COALESCE(" + e.SubtitleCol + "::text, '')"
+	}
+	dateExpr := "NULL::timestamptz"
+	if e.EntityType == "document" {
+		dateExpr = "date::timestamptz"
+	}
+	dimCols := make([]string, 0, len(e.RLSDimensions))
+	selectDims := ""
+	for _, col := range e.RLSDimensions {
+		dimCols = append(dimCols, col)
+		selectDims += ", " + col + "::text"
+	}
+
+	sql := fmt.Sprintf(
+		"SELECT id::text, COALESCE(%s::text, ''), %s, concat_ws(' ', %s), %s, deletion_mark%s FROM %s WHERE %s",
+		e.TitleCol, subtitleExpr, strings.Join(textCols, ", "), dateExpr, selectDims, e.TableName, where,
+	)
+
+	rows, err := postgres.MustGetTxManager(ctx).GetQuerier(ctx).Query(ctx, sql, arg)
+	if err != nil {
+		return nil, fmt.Errorf("load %s for search index: %w", e.TableName, err)
+	}
+	defer rows.Close()
+
+	var result []IndexedRow
+	for rows.Next() {
+		row := IndexedRow{EntityKey: e.EntityKey}
+		var date *time.Time
+		dims := make([]*string, len(dimCols))
+		dest := []any{&row.EntityID, &row.Title, &row.Subtitle, &row.Text, &date, &row.DeletionMark}
+		for i := range dims {
+			dest = append(dest, &dims[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scan %s for search index: %w", e.TableName, err)
+		}
+		row.Date = date
+		for i, col := range dimCols {
+			if dims[i] != nil {
+				if row.Dimensions == nil {
+					row.Dimensions = make(map[string]string, len(dimCols))
+				}
+				row.Dimensions[col] = *dims[i]
+			}
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
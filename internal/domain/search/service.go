@@ -14,6 +14,7 @@ import (
 	"metapus/internal/core/security"
 	"metapus/internal/infrastructure/storage/postgres"
 	"metapus/internal/metadata"
+	"metapus/pkg/logger"
 )
 
 // _minQueryLength is the minimum number of runes to trigger a search.
@@ -57,6 +58,10 @@ type SearchResponse struct {
 type Service struct {
 	entities []SearchableEntity
 	registry *metadata.Registry
+
+	// index is the optional external index, used for tenants with
+	// search.backend = opensearch. nil = Postgres only.
+	index Index
 }
 
 // NewService creates a search service from the metadata registry.
@@ -130,6 +135,16 @@ func (s *Service) Search(ctx context.Context, query string, limitPerEntity int)
 	}
 
 	scope := security.GetDataScope(ctx)
+
+	if s.usesIndex(ctx) {
+		results, err := s.searchIndex(ctx, scope, query, limitPerEntity)
+		if err == nil {
+			return &SearchResponse{Query: query, Results: results}, nil
+		}
+		// The index is a replica of the tables — Postgres can always answer.
+		logger.Warn(ctx, "search index unavailable, falling back to postgres", "error", err)
+	}
+
 	pattern := "%" + escapeLikePattern(query) + "%"
 
 	// Build UNION ALL from all searchable entities.
@@ -197,27 +212,20 @@ func (s *Service) buildSubSelect(e SearchableEntity, scope *security.DataScope,
 	wheres = append(wheres, searchCond)
 
 	// Apply RLS conditions from DataScope
+	filters, ok := rlsFilters(e, scope)
+	if !ok {
+		return "", nil, paramIdx
+	}
 	var extraArgs []any
-	if scope != nil && !scope.IsAdmin && len(e.RLSDimensions) > 0 {
-		effective := scope.EffectiveDimensions(e.EntityKey)
-		for dimName, dbCol := range e.RLSDimensions {
-			allowedIDs, hasDim := effective[dimName]
-			if !hasDim {
-				continue // no restriction on this dimension
-			}
-			if len(allowedIDs) == 0 {
-				// Empty = no access → skip this entity entirely
-				return "", nil, paramIdx
-			}
-			// Build IN ($N, $N+1, ...)
-			placeholders := make([]string, 0, len(allowedIDs))
-			for _, id := range allowedIDs {
-				placeholders = append(placeholders, "$"+strconv.Itoa(paramIdx))
-				extraArgs = append(extraArgs, id)
-				paramIdx++
-			}
-			wheres = append(wheres, dbCol+" IN ("+strings.Join(placeholders, ", ")+")")
+	for dbCol, allowedIDs := range filters {
+		// Build IN ($N, $N+1, ...)
+		placeholders := make([]string, 0, len(allowedIDs))
+		for _, id := range allowedIDs {
+			placeholders = append(placeholders, "$"+strconv.Itoa(paramIdx))
+			extraArgs = append(extraArgs, id)
+			paramIdx++
 		}
+		wheres = append(wheres, dbCol+" IN ("+strings.Join(placeholders, ", ")+")")
 	}
 
 	whereClause := strings.Join(wheres, " AND ")
@@ -244,6 +252,31 @@ func (s *Service) buildSubSelect(e SearchableEntity, scope *security.DataScope,
 	return subSQL, extraArgs, paramIdx
 }
 
+// rlsFilters returns the allowed values per RLS column of an entity for scope.
+// ok is false when a dimension is present but empty (no access to the entity).
+func rlsFilters(e SearchableEntity, scope *security.DataScope) (map[string][]string, bool) {
+	if scope == nil || scope.IsAdmin {
+		return nil, true
+	}
+	if !scope.GrantsCovered(e.EntityKey, e.RLSDimensions) {
+		return nil, false
+	}
+	effective := scope.EffectiveDimensions(e.EntityKey)
+	filters := make(map[string][]string, len(e.RLSDimensions))
+	for dimName, dbCol := range e.RLSDimensions {
+		allowedIDs, hasDim := effective[dimName]
+		if !hasDim {
+			continue // no restriction on this dimension
+		}
+		if len(allowedIDs) == 0 {
+			// Empty = no access → skip this entity entirely
+			return nil, false
+		}
+		filters[dbCol] = allowedIDs
+	}
+	return filters, true
+}
+
 // buildURL constructs the frontend URL for a search result.
 // RoutePrefix is singular on the backend (e.g. "goods-receipt") but
 // Next.js routes use plural form (e.g. "/documents/goods-receipts").
@@ -117,6 +117,13 @@ func (u *ProfileUser) FullName() string {
 	return u.FirstName + " " + u.LastName
 }
 
+// ProfileRole represents a role assigned to a security profile (for the Roles tab).
+type ProfileRole struct {
+	ID   id.ID  `db:"id"`
+	Code string `db:"code"`
+	Name string `db:"name"`
+}
+
 // ProfileBrief is a lightweight profile reference for batch enrichment.
 type ProfileBrief struct {
 	ID   id.ID  `db:"id"`
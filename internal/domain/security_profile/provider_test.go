@@ -53,6 +53,8 @@ func (r *mockRepo) Update(_ context.Context, _ *SecurityProfile) error { return
 func (r *mockRepo) Delete(_ context.Context, _ id.ID) error            { return nil }
 func (r *mockRepo) AssignToUser(_ context.Context, _, _ id.ID) error   { return nil }
 func (r *mockRepo) RemoveFromUser(_ context.Context, _, _ id.ID) error { return nil }
+func (r *mockRepo) AssignToRole(_ context.Context, _, _ id.ID) error   { return nil }
+func (r *mockRepo) RemoveFromRole(_ context.Context, _, _ id.ID) error { return nil }
 
 func TestCachedProfileProvider_CacheHit(t *testing.T) {
 	repo := newMockRepo()
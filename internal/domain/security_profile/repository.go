@@ -30,8 +30,8 @@ type Repository interface {
 	GetByID(ctx context.Context, profileID id.ID) (*SecurityProfile, error)
 
 	// GetByUserID loads the effective security profile for a user.
-	// If the user has multiple profiles, dimensions and field policies are merged.
-	// Returns nil (no error) if the user has no assigned profile.
+	// A directly assigned profile takes precedence over profiles of the user's roles.
+	// Returns nil (no error) if neither the user nor their roles have a profile.
 	GetByUserID(ctx context.Context, userID id.ID) (*SecurityProfile, error)
 
 	// List returns all profiles (admin panel).
@@ -51,4 +51,10 @@ type Repository interface {
 
 	// RemoveFromUser unlinks a user from a security profile.
 	RemoveFromUser(ctx context.Context, userID, profileID id.ID) error
+
+	// AssignToRole links a role to a security profile.
+	AssignToRole(ctx context.Context, roleID, profileID id.ID) error
+
+	// RemoveFromRole unlinks a role from a security profile.
+	RemoveFromRole(ctx context.Context, roleID, profileID id.ID) error
 }
@@ -43,10 +43,25 @@ type CatalogServiceConfig[T entity.CatalogEntity] struct {
 	EntityName   string
 }
 
+// entityNamedRepo is implemented by repositories that resolve per-entity
+// RLS dimensions (including scoped permission grants) by entity name.
+type entityNamedRepo interface {
+	SetEntityName(name string)
+}
+
+// nameRepo tells repo the entity name its service checks access under, so
+// list filtering and point checks apply the same per-entity scope.
+func nameRepo(repo any, entityName string) {
+	if r, ok := repo.(entityNamedRepo); ok && entityName != "" {
+		r.SetEntityName(entityName)
+	}
+}
+
 // NewCatalogService creates a new catalog service.
 // CatalogMeta is automatically resolved from the entity registry.
 func NewCatalogService[T entity.CatalogEntity](cfg CatalogServiceConfig[T]) *CatalogService[T] {
 	meta := entity.GetCatalogMeta(cfg.EntityName)
+	nameRepo(cfg.Repo, cfg.EntityName)
 
 	var hv *HierarchyValidator
 	if meta.Hierarchical {
@@ -159,7 +174,7 @@ func (s *CatalogService[T]) Create(ctx context.Context, entity T) error {
 		if err := s.repo.Create(ctx, entity); err != nil {
 			return fmt.Errorf("create %s: %w", s.entityName, err)
 		}
-		return nil
+		return s.hooks.Run(ctx, CreateInTx, entity)
 	})
 	if err != nil {
 		return err
@@ -270,7 +285,7 @@ func (s *CatalogService[T]) Update(ctx context.Context, entity T) error {
 		if err := s.repo.Update(ctx, entity); err != nil {
 			return fmt.Errorf("update %s: %w", s.entityName, err)
 		}
-		return nil
+		return s.hooks.Run(ctx, UpdateInTx, entity)
 	})
 	if err != nil {
 		return err
@@ -319,7 +334,7 @@ func (s *CatalogService[T]) Delete(ctx context.Context, entityID id.ID) error {
 		if err := s.repo.Delete(ctx, entityID); err != nil {
 			return fmt.Errorf("delete %s: %w", s.entityName, err)
 		}
-		return nil
+		return s.hooks.Run(ctx, DeleteInTx, entity)
 	})
 	if err != nil {
 		return err
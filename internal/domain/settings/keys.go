@@ -0,0 +1,244 @@
+package settings
+
+import (
+	"context"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/format"
+)
+
+// Scoped setting keys. Each key documents its consumer.
+
+// KeyNumberingIncludeYear adds the year to generated document numbers (documents, numerator).
+var KeyNumberingIncludeYear = Define("numbering.includeYear",
+	"Include the year in generated document numbers",
+	true, []Scope{ScopeOrganization, ScopeTenant}, nil)
+
+// KeyNumberingPadWidth is the minimum width of the counter part of document numbers (numerator).
+var KeyNumberingPadWidth = Define("numbering.padWidth",
+	"Minimum width of the sequential part of document numbers",
+	5, []Scope{ScopeOrganization, ScopeTenant}, func(v int) error {
+		if v < 1 || v > 12 {
+			return apperror.NewValidation("padWidth must be between 1 and 12").WithDetail("key", "numbering.padWidth")
+		}
+		return nil
+	})
+
+// KeyPostingBatchConcurrency overrides Performance.BatchConcurrency for batch
+// post/unpost (posting). Clamped to the tenant pool size by the consumer.
+var KeyPostingBatchConcurrency = Define("posting.batchConcurrency",
+	"Parallel documents in batch post/unpost",
+	5, []Scope{ScopeUser, ScopeTenant}, func(v int) error {
+		if v < 1 {
+			return apperror.NewValidation("batchConcurrency must be positive").WithDetail("key", "posting.batchConcurrency")
+		}
+		return nil
+	})
+
+// KeyPostingLockModes serializes posting per document type (posting):
+// "document" (default) only locks the posted document, "warehouse" runs the
+// postings touching the same warehouse one at a time, "sequential" runs all
+// postings of the type one at a time. E.g. {"goods_issue": "warehouse"}.
+var KeyPostingLockModes = Define("posting.lockModes",
+	"Serialization of concurrent posting, per document type",
+	map[string]string{}, []Scope{ScopeTenant}, func(v map[string]string) error {
+		for docType, mode := range v {
+			switch mode {
+			case "document", "warehouse", "sequential":
+			default:
+				return apperror.NewValidation("lock mode must be document, warehouse or sequential").
+					WithDetail("key", "posting.lockModes").WithDetail("documentType", docType)
+			}
+		}
+		return nil
+	})
+
+// KeyStockRespectReservations makes the stock availability check subtract
+// goods reserved by sales orders (posting).
+var KeyStockRespectReservations = Define("stock.respectReservations",
+	"Exclude goods reserved by sales orders from available stock",
+	true, []Scope{ScopeTenant}, nil)
+
+// KeyStockInventoryFreeze controls stock movements into the scope of an
+// inventory count in progress (posting): "off", "block", or "override" —
+// blocked unless the user holds inventory_count.override_freeze.
+var KeyStockInventoryFreeze = Define("stock.inventoryFreeze",
+	"Freeze stock movements while an inventory count is in progress",
+	"off", []Scope{ScopeTenant}, func(v string) error {
+		switch v {
+		case "off", "block", "override":
+			return nil
+		}
+		return apperror.NewValidation("inventoryFreeze must be off, block or override").WithDetail("key", "stock.inventoryFreeze")
+	})
+
+// KeyReportRowLimit caps the rows returned by a report query when the request
+// sets no limit (reports).
+var KeyReportRowLimit = Define("reports.rowLimit",
+	"Maximum rows returned by a report without an explicit limit",
+	10000, []Scope{ScopeUser, ScopeOrganization, ScopeTenant}, func(v int) error {
+		if v < 1 || v > 1_000_000 {
+			return apperror.NewValidation("rowLimit must be between 1 and 1000000").WithDetail("key", "reports.rowLimit")
+		}
+		return nil
+	})
+
+// KeyPrintLocale overrides General.Locale for print forms and list exports (documents).
+// Empty means the tenant locale.
+var KeyPrintLocale = Define("documents.printLocale",
+	"Locale of print forms and exported lists",
+	"", []Scope{ScopeUser, ScopeOrganization, ScopeTenant}, func(v string) error {
+		if v == "" {
+			return nil
+		}
+		if _, err := format.ParseLocale(v); err != nil {
+			return apperror.NewValidation("unsupported locale").WithDetail("key", "documents.printLocale")
+		}
+		return nil
+	})
+
+// Handling of documents whose header totals differ from the sum of their
+// lines (KeyDocumentTotalsMismatch).
+const (
+	TotalsMismatchFix    = "fix"    // recalculate the totals from the lines
+	TotalsMismatchReject = "reject" // refuse to save or post the document
+)
+
+// KeyDocumentTotalsMismatch controls what saving or posting a document with
+// header totals out of line with its lines does (documents).
+var KeyDocumentTotalsMismatch = Define("documents.totalsMismatch",
+	"Action when document totals do not match its lines: fix or reject",
+	TotalsMismatchFix, []Scope{ScopeTenant}, func(v string) error {
+		switch v {
+		case TotalsMismatchFix, TotalsMismatchReject:
+			return nil
+		}
+		return apperror.NewValidation("totalsMismatch must be fix or reject").WithDetail("key", "documents.totalsMismatch")
+	})
+
+// PrintFormatter returns the formatter for print forms and exports: the
+// resolved KeyPrintLocale when set, otherwise the tenant locale.
+func PrintFormatter(ctx context.Context, general GeneralSettings, subj Subject) *format.Formatter {
+	if locale := Get(ctx, KeyPrintLocale, subj); locale != "" {
+		return format.ForLocale(locale)
+	}
+	return general.Formatter()
+}
+
+// Search backends selectable with KeySearchBackend.
+const (
+	SearchBackendPostgres   = "postgres"
+	SearchBackendOpenSearch = "opensearch"
+)
+
+// KeySearchBackend selects the backend of global search (search): "postgres"
+// (ILIKE over the entity tables) or "opensearch" — the tenant's index in the
+// external search cluster, kept in sync by the worker. Without a configured
+// cluster the opensearch value falls back to postgres.
+var KeySearchBackend = Define("search.backend",
+	"Backend of global search",
+	SearchBackendPostgres, []Scope{ScopeTenant}, func(v string) error {
+		switch v {
+		case SearchBackendPostgres, SearchBackendOpenSearch:
+			return nil
+		}
+		return apperror.NewValidation("backend must be postgres or opensearch").WithDetail("key", "search.backend")
+	})
+
+// KeyAnalyticsEnabled lets a tenant opt out of anonymized product analytics
+// (analytics). When disabled, events of the tenant are dropped at the source.
+var KeyAnalyticsEnabled = Define("analytics.enabled",
+	"Share anonymized product usage statistics with the vendor",
+	true, []Scope{ScopeTenant}, nil)
+
+// KeyHousekeepingPendingKeyMinutes is the age after which a pending
+// idempotency key is reported as stuck (housekeeping). 0 disables the check.
+var KeyHousekeepingPendingKeyMinutes = Define("housekeeping.pendingKeyMinutes",
+	"Report idempotency keys pending longer than this many minutes (0 = off)",
+	30, []Scope{ScopeTenant}, func(v int) error {
+		if v < 0 {
+			return apperror.NewValidation("pendingKeyMinutes must not be negative").WithDetail("key", "housekeeping.pendingKeyMinutes")
+		}
+		return nil
+	})
+
+// KeyHousekeepingDraftAgeDays is the age after which an unposted document is
+// reported as a forgotten draft (housekeeping). 0 disables the check.
+var KeyHousekeepingDraftAgeDays = Define("housekeeping.draftAgeDays",
+	"Report unposted documents created more than this many days ago (0 = off)",
+	30, []Scope{ScopeTenant}, func(v int) error {
+		if v < 0 {
+			return apperror.NewValidation("draftAgeDays must not be negative").WithDetail("key", "housekeeping.draftAgeDays")
+		}
+		return nil
+	})
+
+// KeyPeriodClosedUntil is the first day of the open accounting period,
+// "YYYY-MM-DD" (housekeeping reports unposted documents dated earlier).
+// Empty means no period is closed.
+var KeyPeriodClosedUntil = Define("period.closedUntil",
+	"First day of the open accounting period (YYYY-MM-DD); earlier periods are closed",
+	"", []Scope{ScopeTenant}, func(v string) error {
+		if v == "" {
+			return nil
+		}
+		if _, err := time.Parse(time.DateOnly, v); err != nil {
+			return apperror.NewValidation("closedUntil must be a date (YYYY-MM-DD)").WithDetail("key", "period.closedUntil")
+		}
+		return nil
+	})
+
+// Sources of generated catalog codes (KeyCatalogCodes).
+const (
+	CodeSourceName      = "name"      // transliterated name; collisions get -2, -3, ...
+	CodeSourceNumerator = "numerator" // prefix + sequence number
+)
+
+// DefaultCatalogCodeLength is the code length used when a rule sets none.
+// It fits the shortest catalog code column (VARCHAR(20)).
+const DefaultCatalogCodeLength = 20
+
+// CatalogCodeRule configures the code generated for a catalog item created
+// without one.
+type CatalogCodeRule struct {
+	Source string `json:"source,omitempty"` // CodeSourceName (default) or CodeSourceNumerator
+	// Prefix is prepended to the code. Numerator codes without a prefix use
+	// the catalog's own (e.g. "CP" for counterparties).
+	Prefix string `json:"prefix,omitempty"`
+	// Length is the maximum code length including the prefix; 0 means
+	// DefaultCatalogCodeLength.
+	Length int `json:"length,omitempty"`
+}
+
+// KeyCatalogCodes configures code generation per catalog entity name
+// (domain), e.g. {"counterparty": {"prefix": "K-", "length": 30}}. Catalogs
+// without an entry get their transliterated name.
+var KeyCatalogCodes = Define("catalogs.codes",
+	"Generation of codes omitted on catalog create, per catalog",
+	map[string]CatalogCodeRule{}, []Scope{ScopeTenant}, func(v map[string]CatalogCodeRule) error {
+		for entity, rule := range v {
+			invalid := func(msg string) error {
+				return apperror.NewValidation(msg).WithDetail("key", "catalogs.codes").WithDetail("entity", entity)
+			}
+			switch rule.Source {
+			case "", CodeSourceName, CodeSourceNumerator:
+			default:
+				return invalid("source must be name or numerator")
+			}
+			if rule.Length != 0 && (rule.Length < 4 || rule.Length > 50) {
+				return invalid("length must be between 4 and 50")
+			}
+			if len(rule.Prefix) > 10 {
+				return invalid("prefix must be at most 10 characters")
+			}
+			length := rule.Length
+			if length == 0 {
+				length = DefaultCatalogCodeLength
+			}
+			if len(rule.Prefix)+3 > length {
+				return invalid("prefix leaves no room for the code")
+			}
+		}
+		return nil
+	})
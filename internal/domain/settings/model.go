@@ -3,7 +3,14 @@
 // Organization-specific settings (requisites, accounting policy) live in cat_organizations.
 package settings
 
-import "time"
+import (
+	"net/mail"
+	"strconv"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/format"
+)
 
 // Settings represents the tenant-wide system configuration.
 // Only system-level settings remain here; org-specific data is in cat_organizations.
@@ -18,6 +25,12 @@ type Settings struct {
 	Sales      SalesSettings      `json:"sales"`
 	Purchasing PurchasingSettings `json:"purchasing"`
 
+	// Integrations
+	Email EmailSettings `json:"email"`
+
+	// Maintenance
+	Retention RetentionSettings `json:"retention"`
+
 	Version   int       `json:"version"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
@@ -29,15 +42,39 @@ type GeneralSettings struct {
 	// Timezone is an IANA timezone identifier, e.g. "Asia/Shanghai", "Europe/Moscow".
 	// Used as the default timezone for scheduled operations (report distribution, etc.).
 	Timezone string `json:"timezone"`
+	// Locale controls number, money and date formatting in print forms and
+	// export files ("ru", "en"). Empty means format.DefaultLocale.
+	Locale string `json:"locale,omitempty"`
 }
 
 // DefaultGeneral returns sensible defaults for general settings.
 func DefaultGeneral() GeneralSettings {
 	return GeneralSettings{
 		Timezone: "UTC",
+		Locale:   string(format.DefaultLocale),
 	}
 }
 
+// Validate checks field values (no I/O).
+func (g GeneralSettings) Validate() error {
+	if g.Timezone != "" {
+		if _, err := time.LoadLocation(g.Timezone); err != nil {
+			return apperror.NewValidation("invalid timezone").WithDetail("field", "timezone")
+		}
+	}
+	if g.Locale != "" {
+		if _, err := format.ParseLocale(g.Locale); err != nil {
+			return apperror.NewValidation("unsupported locale").WithDetail("field", "locale")
+		}
+	}
+	return nil
+}
+
+// Formatter returns the value formatter for the configured locale.
+func (g GeneralSettings) Formatter() *format.Formatter {
+	return format.ForLocale(g.Locale)
+}
+
 // ── Numbering ───────────────────────────────────────────────────────────
 
 // NumberingSettings holds document auto-numbering parameters (system-wide).
@@ -139,3 +176,129 @@ func DefaultPurchasing() PurchasingSettings {
 		RequireApproval:        false,
 	}
 }
+
+// ── Retention ───────────────────────────────────────────────────────────
+
+// Retention bounds, validated on update.
+const (
+	MaxIdempotencyKeyHours = 30 * 24
+	MaxRefreshTokenDays    = 365
+	MaxArtifactDays        = 365
+)
+
+// RetentionSettings controls how long the worker keeps transient records.
+type RetentionSettings struct {
+	// IdempotencyKeyHours is how long idempotency keys (and cached responses)
+	// are kept after creation. A retried request older than this executes again.
+	IdempotencyKeyHours int `json:"idempotencyKeyHours"`
+	// RefreshTokenDays is how long expired or revoked refresh tokens are kept
+	// (session history in the UI) before they are deleted. 0 deletes them immediately.
+	RefreshTokenDays int `json:"refreshTokenDays"`
+	// ArtifactDays is how long generated files (report exports, backups) are
+	// kept unless the producer set an explicit expiry.
+	ArtifactDays int `json:"artifactDays"`
+}
+
+// DefaultRetention returns sensible defaults for retention settings.
+func DefaultRetention() RetentionSettings {
+	return RetentionSettings{
+		IdempotencyKeyHours: 24,
+		RefreshTokenDays:    7,
+		ArtifactDays:        30,
+	}
+}
+
+// Validate checks field values (no I/O).
+func (r RetentionSettings) Validate() error {
+	if r.IdempotencyKeyHours < 1 || r.IdempotencyKeyHours > MaxIdempotencyKeyHours {
+		return apperror.NewValidation("idempotencyKeyHours must be between 1 and " + strconv.Itoa(MaxIdempotencyKeyHours)).
+			WithDetail("field", "idempotencyKeyHours")
+	}
+	if r.RefreshTokenDays < 0 || r.RefreshTokenDays > MaxRefreshTokenDays {
+		return apperror.NewValidation("refreshTokenDays must be between 0 and " + strconv.Itoa(MaxRefreshTokenDays)).
+			WithDetail("field", "refreshTokenDays")
+	}
+	if r.ArtifactDays < 1 || r.ArtifactDays > MaxArtifactDays {
+		return apperror.NewValidation("artifactDays must be between 1 and " + strconv.Itoa(MaxArtifactDays)).
+			WithDetail("field", "artifactDays")
+	}
+	return nil
+}
+
+// IdempotencyKeyTTL returns IdempotencyKeyHours as a duration.
+func (r RetentionSettings) IdempotencyKeyTTL() time.Duration {
+	return time.Duration(r.IdempotencyKeyHours) * time.Hour
+}
+
+// RefreshTokenTTL returns RefreshTokenDays as a duration.
+func (r RetentionSettings) RefreshTokenTTL() time.Duration {
+	return time.Duration(r.RefreshTokenDays) * 24 * time.Hour
+}
+
+// ArtifactTTL returns ArtifactDays as a duration.
+func (r RetentionSettings) ArtifactTTL() time.Duration {
+	return time.Duration(r.ArtifactDays) * 24 * time.Hour
+}
+
+// ── Email ───────────────────────────────────────────────────────────────
+
+// Email provider identifiers.
+const (
+	// EmailProviderPlatform uses the platform-level default sender (SMTP_* env).
+	EmailProviderPlatform = "platform"
+	// EmailProviderSMTP uses the tenant's own SMTP server.
+	EmailProviderSMTP = "smtp"
+	// EmailProviderSendGrid uses the SendGrid HTTP API with the tenant's API key.
+	EmailProviderSendGrid = "sendgrid"
+)
+
+// EmailSettings holds the tenant's outgoing email provider configuration.
+// The secret (SMTP password or provider API key) is stored encrypted in a
+// separate column and is never returned to clients — only HasSecret is.
+type EmailSettings struct {
+	Provider string `json:"provider"`
+	SMTPHost string `json:"smtpHost,omitempty"`
+	SMTPPort string `json:"smtpPort,omitempty"`
+	// Username for SMTP auth. Defaults to From if empty.
+	Username string `json:"username,omitempty"`
+	From     string `json:"from,omitempty"`
+
+	// HasSecret is true when an encrypted secret is stored (read-only).
+	HasSecret bool `json:"hasSecret"`
+}
+
+// DefaultEmail returns sensible defaults for email settings.
+func DefaultEmail() EmailSettings {
+	return EmailSettings{
+		Provider: EmailProviderPlatform,
+	}
+}
+
+// UsesPlatform reports whether emails should go through the platform default sender.
+func (e EmailSettings) UsesPlatform() bool {
+	return e.Provider == "" || e.Provider == EmailProviderPlatform
+}
+
+// Validate checks provider-specific required fields (no I/O).
+func (e EmailSettings) Validate() error {
+	switch e.Provider {
+	case "", EmailProviderPlatform:
+		return nil
+	case EmailProviderSMTP:
+		if e.SMTPHost == "" {
+			return apperror.NewValidation("smtpHost is required").WithDetail("field", "smtpHost")
+		}
+		if e.SMTPPort != "" {
+			if port, err := strconv.Atoi(e.SMTPPort); err != nil || port < 1 || port > 65535 {
+				return apperror.NewValidation("invalid smtpPort").WithDetail("field", "smtpPort")
+			}
+		}
+	case EmailProviderSendGrid:
+	default:
+		return apperror.NewValidation("unknown email provider").WithDetail("field", "provider")
+	}
+	if _, err := mail.ParseAddress(e.From); err != nil {
+		return apperror.NewValidation("invalid from address").WithDetail("field", "from")
+	}
+	return nil
+}
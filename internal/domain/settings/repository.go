@@ -3,6 +3,8 @@ package settings
 import (
 	"context"
 	"encoding/json"
+
+	"metapus/internal/core/id"
 )
 
 // Repository defines storage operations for tenant-level system settings.
@@ -15,4 +17,23 @@ type Repository interface {
 	// version is the expected current version (for conflict detection).
 	// Returns the updated settings on success.
 	UpdateSection(ctx context.Context, section string, data json.RawMessage, version int) (*Settings, error)
+
+	// UpdateEmail replaces the email section with optimistic locking.
+	// If secret is non-nil it is encrypted and stored; an empty secret clears it.
+	UpdateEmail(ctx context.Context, email EmailSettings, secret *string, version int) (*Settings, error)
+
+	// GetEmailSecret returns the decrypted email provider secret (nil if not set).
+	GetEmailSecret(ctx context.Context) ([]byte, error)
+}
+
+// ValueStore defines storage operations for scoped setting values (sys_setting_values).
+type ValueStore interface {
+	// ListValues returns all stored values of the tenant.
+	ListValues(ctx context.Context) ([]Value, error)
+
+	// SetValue inserts or replaces the value of key at scope/scopeID.
+	SetValue(ctx context.Context, v Value) (Value, error)
+
+	// DeleteValue removes the value of key at scope/scopeID (no-op if absent).
+	DeleteValue(ctx context.Context, key string, scope Scope, scopeID id.ID) error
 }
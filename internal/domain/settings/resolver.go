@@ -0,0 +1,240 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"metapus/internal/core/apperror"
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/pkg/logger"
+)
+
+// SettingValuesChangedChannel is the NOTIFY channel fired by the sys_setting_values
+// trigger in the tenant database. Payload is the changed key.
+const SettingValuesChangedChannel = "setting_values_changed"
+
+// DefaultCacheTTL bounds staleness when a change notification is missed
+// (e.g. the LISTEN connection was down).
+const DefaultCacheTTL = 5 * time.Minute
+
+// Resolver resolves scoped setting values with a per-tenant in-memory cache.
+//
+// All values of a tenant are loaded in one query (there are few of them) and
+// kept until Invalidate is called — by the NOTIFY listener or by a local write —
+// or the snapshot is older than the TTL.
+type Resolver struct {
+	store ValueStore
+	ttl   time.Duration
+
+	mu    sync.RWMutex
+	cache map[string]*valueSnapshot // tenantID -> snapshot
+	// generation is bumped on every invalidation so that a load racing
+	// with a notification does not store a stale snapshot.
+	generation uint64
+
+	// onLoad is called after a tenant snapshot is loaded (e.g. to start
+	// listening for invalidations of that tenant).
+	onLoad func(tenantID string)
+}
+
+type valueSnapshot struct {
+	byKey    map[string][]Value
+	loadedAt time.Time
+}
+
+// NewResolver creates a resolver over store.
+func NewResolver(store ValueStore) *Resolver {
+	return &Resolver{
+		store: store,
+		ttl:   DefaultCacheTTL,
+		cache: make(map[string]*valueSnapshot),
+	}
+}
+
+// SetLoadHook registers fn to be called after a tenant snapshot is loaded.
+func (r *Resolver) SetLoadHook(fn func(tenantID string)) {
+	r.onLoad = fn
+}
+
+// Invalidate drops the cached values of a tenant.
+func (r *Resolver) Invalidate(tenantID string) {
+	r.mu.Lock()
+	delete(r.cache, tenantID)
+	r.generation++
+	r.mu.Unlock()
+}
+
+// snapshot returns the cached values of the tenant in ctx, loading them if needed.
+func (r *Resolver) snapshot(ctx context.Context) (map[string][]Value, error) {
+	tenantID := tenant.GetTenantID(ctx)
+
+	r.mu.RLock()
+	snap, ok := r.cache[tenantID]
+	gen := r.generation
+	r.mu.RUnlock()
+	if ok && time.Since(snap.loadedAt) < r.ttl {
+		return snap.byKey, nil
+	}
+
+	values, err := r.store.ListValues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string][]Value, len(values))
+	for _, v := range values {
+		byKey[v.Key] = append(byKey[v.Key], v)
+	}
+
+	// Without a tenant in ctx the cache key would be shared — don't cache.
+	if tenantID == "" {
+		return byKey, nil
+	}
+
+	r.mu.Lock()
+	if r.generation == gen {
+		r.cache[tenantID] = &valueSnapshot{byKey: byKey, loadedAt: time.Now()}
+	}
+	r.mu.Unlock()
+
+	if r.onLoad != nil {
+		r.onLoad(tenantID)
+	}
+	return byKey, nil
+}
+
+// Resolve returns the most specific stored value of key for subj and its scope.
+// found is false when no scope overrides the key (callers use the default).
+func (r *Resolver) Resolve(ctx context.Context, key string, subj Subject) (raw json.RawMessage, scope Scope, found bool, err error) {
+	byKey, err := r.snapshot(ctx)
+	if err != nil {
+		return nil, "", false, err
+	}
+	values := byKey[key]
+	for _, s := range resolutionOrder {
+		scopeID, ok := subj.scopeID(s)
+		if !ok {
+			continue
+		}
+		for _, v := range values {
+			if v.Scope == s && v.ScopeID == scopeID {
+				return v.Value, s, true, nil
+			}
+		}
+	}
+	return nil, "", false, nil
+}
+
+// List returns all stored values of the tenant in ctx.
+func (r *Resolver) List(ctx context.Context) ([]Value, error) {
+	return r.store.ListValues(ctx)
+}
+
+// Set validates and stores a value, then drops the local cache of the tenant.
+func (r *Resolver) Set(ctx context.Context, key string, scope Scope, scopeID id.ID, raw json.RawMessage) (Value, error) {
+	def, err := checkScope(key, scope, scopeID)
+	if err != nil {
+		return Value{}, err
+	}
+	if err := def.Validate(raw); err != nil {
+		return Value{}, err
+	}
+
+	v := Value{Key: key, Scope: scope, ScopeID: scopeID, Value: raw}
+	if userID, err := id.Parse(appctx.GetUserID(ctx)); err == nil {
+		v.UpdatedBy = &userID
+	}
+	saved, err := r.store.SetValue(ctx, v)
+	if err != nil {
+		return Value{}, err
+	}
+	r.Invalidate(tenant.GetTenantID(ctx))
+	return saved, nil
+}
+
+// Delete removes a stored value, falling back to the next scope.
+func (r *Resolver) Delete(ctx context.Context, key string, scope Scope, scopeID id.ID) error {
+	if _, err := checkScope(key, scope, scopeID); err != nil {
+		return err
+	}
+	if err := r.store.DeleteValue(ctx, key, scope, scopeID); err != nil {
+		return err
+	}
+	r.Invalidate(tenant.GetTenantID(ctx))
+	return nil
+}
+
+// checkScope validates key/scope/scopeID of a write.
+func checkScope(key string, scope Scope, scopeID id.ID) (Definition, error) {
+	def, ok := Lookup(key)
+	if !ok {
+		return Definition{}, apperror.NewNotFound("setting", key)
+	}
+	if !scope.IsValid() || !def.AllowsScope(scope) {
+		return Definition{}, apperror.NewValidation("scope is not allowed for this setting").
+			WithDetail("key", key).WithDetail("scope", string(scope))
+	}
+	if (scope == ScopeTenant) != id.IsNil(scopeID) {
+		return Definition{}, apperror.NewValidation("scopeId is required for user and organization scopes and must be empty for tenant scope").
+			WithDetail("field", "scopeId")
+	}
+	return def, nil
+}
+
+// ── Context injection ───────────────────────────────────────────────────
+
+type resolverKey struct{}
+
+// WithResolver returns ctx carrying r.
+func WithResolver(ctx context.Context, r *Resolver) context.Context {
+	return context.WithValue(ctx, resolverKey{}, r)
+}
+
+// ResolverFromContext returns the resolver stored in ctx, or nil.
+func ResolverFromContext(ctx context.Context) *Resolver {
+	r, _ := ctx.Value(resolverKey{}).(*Resolver)
+	return r
+}
+
+// SubjectFromContext returns a subject for the current user (no organization).
+func SubjectFromContext(ctx context.Context) Subject {
+	var subj Subject
+	if userID, err := id.Parse(appctx.GetUserID(ctx)); err == nil {
+		subj.UserID = userID
+	}
+	return subj
+}
+
+// Get resolves key for subj through the resolver in ctx.
+// Settings never fail business operations: without a resolver, on storage
+// errors or undecodable values the key default is returned.
+func Get[T any](ctx context.Context, key Key[T], subj Subject) T {
+	v, _ := GetOK(ctx, key, subj)
+	return v
+}
+
+// GetOK is like Get but also reports whether a stored value was found.
+// Used where a legacy setting provides the fallback instead of the key default.
+func GetOK[T any](ctx context.Context, key Key[T], subj Subject) (T, bool) {
+	r := ResolverFromContext(ctx)
+	if r == nil {
+		return key.def, false
+	}
+	raw, _, found, err := r.Resolve(ctx, key.name, subj)
+	if err != nil {
+		logger.Warn(ctx, "settings: resolve failed, using default", "key", key.name, "error", err)
+		return key.def, false
+	}
+	if !found {
+		return key.def, false
+	}
+	v, err := key.decode(raw)
+	if err != nil {
+		logger.Warn(ctx, "settings: stored value is invalid, using default", "key", key.name, "error", err)
+		return key.def, false
+	}
+	return v, true
+}
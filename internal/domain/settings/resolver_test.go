@@ -0,0 +1,132 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+)
+
+// memoryStore is an in-memory ValueStore counting loads.
+type memoryStore struct {
+	values []Value
+	loads  int
+}
+
+func (s *memoryStore) ListValues(context.Context) ([]Value, error) {
+	s.loads++
+	return append([]Value(nil), s.values...), nil
+}
+
+func (s *memoryStore) SetValue(_ context.Context, v Value) (Value, error) {
+	for i := range s.values {
+		if s.values[i].Key == v.Key && s.values[i].Scope == v.Scope && s.values[i].ScopeID == v.ScopeID {
+			s.values[i] = v
+			return v, nil
+		}
+	}
+	s.values = append(s.values, v)
+	return v, nil
+}
+
+func (s *memoryStore) DeleteValue(_ context.Context, key string, scope Scope, scopeID id.ID) error {
+	for i := range s.values {
+		if s.values[i].Key == key && s.values[i].Scope == scope && s.values[i].ScopeID == scopeID {
+			s.values = append(s.values[:i], s.values[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func TestResolverResolutionOrder(t *testing.T) {
+	store := &memoryStore{}
+	r := NewResolver(store)
+	ctx := WithResolver(tenant.WithTenant(context.Background(), &tenant.Tenant{ID: "t1"}), r)
+	userID, orgID := id.New(), id.New()
+	subj := Subject{UserID: userID, OrganizationID: orgID}
+
+	if got := Get(ctx, KeyReportRowLimit, subj); got != KeyReportRowLimit.Default() {
+		t.Fatalf("no values: got %d, want default %d", got, KeyReportRowLimit.Default())
+	}
+
+	steps := []struct {
+		scope   Scope
+		scopeID id.ID
+		value   int
+	}{
+		{ScopeTenant, id.ID{}, 300},
+		{ScopeOrganization, orgID, 200},
+		{ScopeUser, userID, 100},
+	}
+	for _, step := range steps {
+		raw, _ := json.Marshal(step.value)
+		if _, err := r.Set(ctx, KeyReportRowLimit.Name(), step.scope, step.scopeID, raw); err != nil {
+			t.Fatalf("Set %s: %v", step.scope, err)
+		}
+		if got := Get(ctx, KeyReportRowLimit, subj); got != step.value {
+			t.Fatalf("after Set %s: got %d, want %d", step.scope, got, step.value)
+		}
+	}
+
+	// Another user of the same organization falls back to the organization value.
+	if got := Get(ctx, KeyReportRowLimit, Subject{UserID: id.New(), OrganizationID: orgID}); got != 200 {
+		t.Fatalf("other user: got %d, want 200", got)
+	}
+
+	if err := r.Delete(ctx, KeyReportRowLimit.Name(), ScopeUser, userID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if got := Get(ctx, KeyReportRowLimit, subj); got != 200 {
+		t.Fatalf("after Delete: got %d, want 200", got)
+	}
+}
+
+func TestResolverCachesUntilInvalidated(t *testing.T) {
+	store := &memoryStore{}
+	r := NewResolver(store)
+	ctx := WithResolver(tenant.WithTenant(context.Background(), &tenant.Tenant{ID: "t1"}), r)
+
+	Get(ctx, KeyNumberingPadWidth, Subject{})
+	Get(ctx, KeyNumberingIncludeYear, Subject{})
+	if store.loads != 1 {
+		t.Fatalf("loads = %d, want 1", store.loads)
+	}
+
+	// A write from another node arrives via NOTIFY → Invalidate.
+	store.values = append(store.values, Value{Key: KeyNumberingPadWidth.Name(), Scope: ScopeTenant, Value: json.RawMessage(`7`)})
+	if got := Get(ctx, KeyNumberingPadWidth, Subject{}); got != 5 {
+		t.Fatalf("before invalidation: got %d, want cached 5", got)
+	}
+	r.Invalidate("t1")
+	if got := Get(ctx, KeyNumberingPadWidth, Subject{}); got != 7 {
+		t.Fatalf("after invalidation: got %d, want 7", got)
+	}
+}
+
+func TestResolverSetValidatesScopeAndValue(t *testing.T) {
+	r := NewResolver(&memoryStore{})
+	ctx := tenant.WithTenant(context.Background(), &tenant.Tenant{ID: "t1"})
+
+	cases := []struct {
+		name    string
+		key     string
+		scope   Scope
+		scopeID id.ID
+		value   string
+	}{
+		{"unknown key", "no.such.key", ScopeTenant, id.ID{}, `1`},
+		{"scope not allowed", KeyNumberingPadWidth.Name(), ScopeUser, id.New(), `5`},
+		{"tenant scope with id", KeyNumberingPadWidth.Name(), ScopeTenant, id.New(), `5`},
+		{"organization scope without id", KeyNumberingPadWidth.Name(), ScopeOrganization, id.ID{}, `5`},
+		{"wrong type", KeyNumberingPadWidth.Name(), ScopeTenant, id.ID{}, `"wide"`},
+		{"out of range", KeyNumberingPadWidth.Name(), ScopeTenant, id.ID{}, `40`},
+	}
+	for _, tc := range cases {
+		if _, err := r.Set(ctx, tc.key, tc.scope, tc.scopeID, json.RawMessage(tc.value)); err == nil {
+			t.Errorf("%s: Set succeeded, want error", tc.name)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+)
+
+// Scoped settings complement the sections of Settings: a typed key may be
+// overridden per organization or per user, and reads resolve the most specific
+// value in the order user → organization → tenant → key default.
+//
+//	limit := settings.Get(ctx, settings.KeyReportRowLimit, settings.SubjectFromContext(ctx))
+
+// Scope is the level a scoped setting value is stored at.
+type Scope string
+
+const (
+	ScopeUser         Scope = "user"
+	ScopeOrganization Scope = "organization"
+	ScopeTenant       Scope = "tenant"
+)
+
+// resolutionOrder lists scopes from most to least specific.
+var resolutionOrder = []Scope{ScopeUser, ScopeOrganization, ScopeTenant}
+
+// IsValid reports whether s is a known scope.
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeUser, ScopeOrganization, ScopeTenant:
+		return true
+	}
+	return false
+}
+
+// Subject identifies who a setting is resolved for. Zero IDs skip their scope.
+type Subject struct {
+	UserID         id.ID
+	OrganizationID id.ID
+}
+
+// scopeID returns the subject's ID for scope and whether the scope applies.
+func (s Subject) scopeID(scope Scope) (id.ID, bool) {
+	switch scope {
+	case ScopeUser:
+		return s.UserID, !id.IsNil(s.UserID)
+	case ScopeOrganization:
+		return s.OrganizationID, !id.IsNil(s.OrganizationID)
+	case ScopeTenant:
+		return id.ID{}, true
+	}
+	return id.ID{}, false
+}
+
+// Value is a stored scoped setting value. ScopeID is nil for ScopeTenant.
+type Value struct {
+	Key       string          `json:"key"`
+	Scope     Scope           `json:"scope"`
+	ScopeID   id.ID           `json:"scopeId"`
+	Value     json.RawMessage `json:"value"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	UpdatedBy *id.ID          `json:"updatedBy,omitempty"`
+}
+
+// Definition describes a registered scoped setting key.
+type Definition struct {
+	Key         string  `json:"key"`
+	Description string  `json:"description"`
+	Type        string  `json:"type"` // "boolean", "integer", "string", "object"
+	Scopes      []Scope `json:"scopes"`
+	Default     any     `json:"default"`
+
+	validate func(raw json.RawMessage) error
+}
+
+// AllowsScope reports whether values of this key may be stored at scope.
+func (d Definition) AllowsScope(scope Scope) bool {
+	for _, s := range d.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that raw decodes into the key's type and passes its rules.
+func (d Definition) Validate(raw json.RawMessage) error {
+	return d.validate(raw)
+}
+
+// Key is a typed handle of a registered scoped setting.
+type Key[T any] struct {
+	name string
+	def  T
+}
+
+// Name returns the key name, e.g. "reports.rowLimit".
+func (k Key[T]) Name() string { return k.name }
+
+// Default returns the value used when no scope overrides the key.
+func (k Key[T]) Default() T { return k.def }
+
+// decode unmarshals raw into T.
+func (k Key[T]) decode(raw json.RawMessage) (T, error) {
+	var v T
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return v, fmt.Errorf("setting %s: %w", k.name, err)
+	}
+	return v, nil
+}
+
+var (
+	definitionsMu sync.RWMutex
+	definitions   = map[string]Definition{}
+)
+
+// Define registers a scoped setting key. validate may be nil.
+// Panics on duplicate keys — keys are declared once at package init.
+func Define[T any](name, description string, def T, scopes []Scope, validate func(T) error) Key[T] {
+	key := Key[T]{name: name, def: def}
+
+	definitionsMu.Lock()
+	defer definitionsMu.Unlock()
+	if _, exists := definitions[name]; exists {
+		panic("settings: duplicate key " + name)
+	}
+	definitions[name] = Definition{
+		Key:         name,
+		Description: description,
+		Type:        typeName(def),
+		Scopes:      scopes,
+		Default:     def,
+		validate: func(raw json.RawMessage) error {
+			v, err := key.decode(raw)
+			if err != nil {
+				return apperror.NewValidation("invalid value type").WithDetail("key", name).WithDetail("type", typeName(def))
+			}
+			if validate != nil {
+				return validate(v)
+			}
+			return nil
+		},
+	}
+	return key
+}
+
+// Lookup returns the definition of a registered key.
+func Lookup(name string) (Definition, bool) {
+	definitionsMu.RLock()
+	defer definitionsMu.RUnlock()
+	d, ok := definitions[name]
+	return d, ok
+}
+
+// Definitions returns all registered keys sorted by name.
+func Definitions() []Definition {
+	definitionsMu.RLock()
+	defer definitionsMu.RUnlock()
+	result := make([]Definition, 0, len(definitions))
+	for _, d := range definitions {
+		result = append(result, d)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case bool:
+		return "boolean"
+	case int, int64:
+		return "integer"
+	case string:
+		return "string"
+	}
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/shopspring/decimal"
 
@@ -10,16 +12,31 @@ import (
 	"metapus/internal/core/types"
 )
 
-// ValidatableDocLine provides access to common line fields for reusable validation.
-// Document line types implement this to benefit from ValidateDocumentLines.
-type ValidatableDocLine interface {
+// ValidatableStockLine provides access to the quantity fields of a goods line.
+// Lines without prices (e.g., transfers) implement this to use ValidateStockLines.
+type ValidatableStockLine interface {
 	GetNomenclatureID() id.ID
 	GetUnitID() id.ID
 	GetCoefficient() decimal.Decimal
 	GetQuantity() types.Quantity
+}
+
+// ValidatableDocLine provides access to common line fields for reusable validation.
+// Document line types implement this to benefit from ValidateDocumentLines.
+type ValidatableDocLine interface {
+	ValidatableStockLine
 	GetVATRateID() id.ID
 }
 
+// SerialLine provides access to the serial numbers of a goods line.
+type SerialLine interface {
+	GetNomenclatureID() id.ID
+	GetSerials() []string
+}
+
+// maxSerialNumberLen is the length of reg_serial_movements.serial_number.
+const maxSerialNumberLen = 100
+
 // ValidationRule is a reusable validation strategy for documents.
 // Multiple rules can be composed to build a validation pipeline.
 type ValidationRule[T any] func(ctx context.Context, doc T) error
@@ -37,6 +54,24 @@ func RunValidationRules[T any](ctx context.Context, doc T, rules ...ValidationRu
 // ValidateDocumentLines validates common fields across all document line types.
 // Checks: non-empty lines, nomenclature, unit, coefficient > 0, quantity > 0, VAT rate.
 func ValidateDocumentLines[L ValidatableDocLine](lines []L) error {
+	if err := ValidateStockLines(lines); err != nil {
+		return err
+	}
+
+	for i, line := range lines {
+		if id.IsNil(line.GetVATRateID()) {
+			return apperror.NewValidation("VAT rate is required").
+				WithDetail("field", "lines").
+				WithDetail("lineNo", i+1)
+		}
+	}
+
+	return nil
+}
+
+// ValidateStockLines validates the quantity fields of goods lines.
+// Checks: non-empty lines, nomenclature, unit, coefficient > 0, quantity > 0.
+func ValidateStockLines[L ValidatableStockLine](lines []L) error {
 	if len(lines) == 0 {
 		return apperror.NewValidation("at least one line is required").
 			WithDetail("field", "lines")
@@ -65,10 +100,41 @@ func ValidateDocumentLines[L ValidatableDocLine](lines []L) error {
 				WithDetail("field", "lines").
 				WithDetail("lineNo", lineNo)
 		}
-		if id.IsNil(line.GetVATRateID()) {
-			return apperror.NewValidation("VAT rate is required").
-				WithDetail("field", "lines").
-				WithDetail("lineNo", lineNo)
+	}
+
+	return nil
+}
+
+// ValidateSerialLines validates the serial numbers entered on goods lines.
+// Checks: no blank or too long serials, no serial listed twice for the same
+// nomenclature. Whether the item needs serials is checked against the
+// catalog (see nomenclature.CheckLineSerials).
+func ValidateSerialLines[L SerialLine](lines []L) error {
+	seen := make(map[id.ID]map[string]struct{})
+	for i, line := range lines {
+		lineNo := i + 1
+		serials := line.GetSerials()
+		if len(serials) == 0 {
+			continue
+		}
+
+		known := seen[line.GetNomenclatureID()]
+		if known == nil {
+			known = make(map[string]struct{}, len(serials))
+			seen[line.GetNomenclatureID()] = known
+		}
+		for _, serial := range serials {
+			if strings.TrimSpace(serial) == "" || len(serial) > maxSerialNumberLen {
+				return apperror.NewValidation("serial number must be 1-100 characters").
+					WithDetail("field", "lines").
+					WithDetail("lineNo", lineNo)
+			}
+			if _, dup := known[serial]; dup {
+				return apperror.NewValidation(fmt.Sprintf("serial number %q is listed twice", serial)).
+					WithDetail("field", "lines").
+					WithDetail("lineNo", lineNo)
+			}
+			known[serial] = struct{}{}
 		}
 	}
 
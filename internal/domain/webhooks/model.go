@@ -0,0 +1,98 @@
+// Package webhooks delivers outbox events to webhook subscriptions.
+//
+// The worker's outbox relay fans every event out to the active subscriptions
+// whose filters match (Dispatcher.Fanout); each (event, subscription) pair
+// becomes a delivery in sys_webhook_deliveries. Dispatcher.DeliverDue POSTs
+// due deliveries with HMAC signatures and reschedules failures with
+// exponential backoff. The delivery table doubles as the delivery log
+// served over /api/v1/webhooks.
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"metapus/internal/core/id"
+)
+
+// MaxAttempts is how many times a delivery is tried before it fails.
+const MaxAttempts = 6
+
+// DeliveryStatus is the state of a webhook delivery.
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"   // waiting for the next attempt
+	DeliveryDelivered DeliveryStatus = "delivered" // the endpoint answered 2xx
+	DeliveryFailed    DeliveryStatus = "failed"    // out of attempts; retried manually
+)
+
+// Event is an outbox event to fan out.
+type Event struct {
+	OutboxID   id.ID
+	Type       string
+	Payload    json.RawMessage
+	OccurredAt time.Time
+}
+
+// Delivery is one event sent to one subscription, with the outcome of its
+// last attempt. ID is sent as X-Metapus-Delivery-ID so receivers can
+// deduplicate retries.
+type Delivery struct {
+	ID             id.ID           `json:"id"`
+	SubscriptionID id.ID           `json:"subscriptionId"`
+	OutboxID       id.ID           `json:"outboxId"`
+	EventType      string          `json:"eventType"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         DeliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"nextAttemptAt"`
+	LastStatusCode *int            `json:"lastStatusCode,omitempty"`
+	LastError      *string         `json:"lastError,omitempty"`
+	ResponseTimeMs *int            `json:"responseTimeMs,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+}
+
+// body is the JSON sent to the endpoint. It is rebuilt from stored fields,
+// so every attempt of a delivery sends the same bytes.
+func (d *Delivery) body() ([]byte, error) {
+	return json.Marshal(struct {
+		ID         string          `json:"id"`
+		Event      string          `json:"event"`
+		OccurredAt time.Time       `json:"occurredAt"`
+		Data       json.RawMessage `json:"data"`
+	}{d.ID.String(), d.EventType, d.CreatedAt.UTC(), d.Payload})
+}
+
+// DeliveryFilter defines filter criteria for querying the delivery log.
+type DeliveryFilter struct {
+	SubscriptionID *id.ID
+	Status         *DeliveryStatus
+	EventType      string
+	Limit          int
+	Offset         int
+}
+
+// Repository persists webhook deliveries.
+type Repository interface {
+	// Enqueue inserts pending deliveries. Deliveries of an outbox message
+	// already enqueued for the same subscription are skipped, so a retried
+	// outbox message is not fanned out twice. Returns the number inserted.
+	Enqueue(ctx context.Context, deliveries []*Delivery) (int, error)
+
+	// ClaimDue returns up to limit pending deliveries whose next attempt is
+	// due, moving their next attempt lease into the future so concurrent
+	// workers skip them; a crashed worker's claims become due again.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*Delivery, error)
+
+	// Save stores the status and attempt fields of a delivery.
+	Save(ctx context.Context, d *Delivery) error
+
+	// GetByID retrieves a single delivery.
+	GetByID(ctx context.Context, deliveryID id.ID) (*Delivery, error)
+
+	// List returns filtered and paginated deliveries, newest first.
+	List(ctx context.Context, filter DeliveryFilter) ([]Delivery, int, error)
+}
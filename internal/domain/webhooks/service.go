@@ -0,0 +1,215 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/catalogs/webhook_subscription"
+	"metapus/internal/domain/crypto"
+	"metapus/pkg/logger"
+)
+
+const (
+	// deliverBatchSize bounds the deliveries sent per DeliverDue call.
+	deliverBatchSize = 50
+
+	// claimLease is how long a claimed delivery is hidden from other
+	// workers. Longer than a webhook request (10s timeout).
+	claimLease = 2 * time.Minute
+)
+
+// SubscriptionSource provides the subscriptions deliveries are sent to.
+// Implemented by webhook_subscription.Repository.
+type SubscriptionSource interface {
+	ListActive(ctx context.Context) ([]*webhook_subscription.WebhookSubscription, error)
+	GetByID(ctx context.Context, subscriptionID id.ID) (*webhook_subscription.WebhookSubscription, error)
+}
+
+// Sender POSTs a signed webhook request.
+// Implemented by crypto.WebhookDispatcher (SSRF-safe pinned-IP client).
+type Sender interface {
+	Send(ctx context.Context, r crypto.WebhookRequest) (*crypto.WebhookResponse, error)
+}
+
+// Backoff returns the delay before the next attempt; retryCount is 0 after
+// the first failure. postgres.WebhookBackoff is the production schedule.
+type Backoff interface {
+	NextDelay(retryCount int) time.Duration
+}
+
+// Service exposes the delivery log.
+type Service struct {
+	repo Repository
+}
+
+// NewService creates a new delivery log service.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// List returns filtered and paginated deliveries.
+func (s *Service) List(ctx context.Context, filter DeliveryFilter) ([]Delivery, int, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// GetByID returns a single delivery.
+func (s *Service) GetByID(ctx context.Context, deliveryID id.ID) (*Delivery, error) {
+	return s.repo.GetByID(ctx, deliveryID)
+}
+
+// Retry schedules a failed delivery for immediate redelivery with a fresh
+// set of attempts.
+func (s *Service) Retry(ctx context.Context, deliveryID id.ID) (*Delivery, error) {
+	d, err := s.repo.GetByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status != DeliveryFailed {
+		return nil, apperror.NewBusinessRule("WEBHOOK_DELIVERY_NOT_FAILED",
+			"Повторить можно только неудавшуюся доставку.").
+			WithDetail("status", d.Status)
+	}
+	d.Status = DeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = time.Now().UTC()
+	if err := s.repo.Save(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Dispatcher fans events out to subscriptions and sends due deliveries.
+type Dispatcher struct {
+	repo    Repository
+	subs    SubscriptionSource
+	sender  Sender
+	backoff Backoff
+}
+
+// NewDispatcher creates a new delivery dispatcher.
+func NewDispatcher(repo Repository, subs SubscriptionSource, sender Sender, backoff Backoff) *Dispatcher {
+	return &Dispatcher{repo: repo, subs: subs, sender: sender, backoff: backoff}
+}
+
+// Fanout enqueues a delivery of ev for every active subscription whose
+// filters match. Safe to call again for the same event.
+func (d *Dispatcher) Fanout(ctx context.Context, ev Event) (int, error) {
+	subs, err := d.subs.ListActive(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list webhook subscriptions: %w", err)
+	}
+
+	now := time.Now().UTC()
+	var deliveries []*Delivery
+	for _, sub := range subs {
+		if !sub.Matches(ev.Type) {
+			continue
+		}
+		deliveries = append(deliveries, &Delivery{
+			ID:             id.New(),
+			SubscriptionID: sub.ID,
+			OutboxID:       ev.OutboxID,
+			EventType:      ev.Type,
+			Payload:        ev.Payload,
+			Status:         DeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      ev.OccurredAt,
+		})
+	}
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+	return d.repo.Enqueue(ctx, deliveries)
+}
+
+// DeliverDue sends the due deliveries and returns how many succeeded.
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	batch, err := d.repo.ClaimDue(ctx, deliverBatchSize, claimLease)
+	if err != nil {
+		return 0, fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+
+	var errs []error
+	delivered := 0
+	for _, dl := range batch {
+		d.attempt(ctx, dl)
+		if err := d.repo.Save(ctx, dl); err != nil {
+			errs = append(errs, fmt.Errorf("save webhook delivery %s: %w", dl.ID, err))
+			continue
+		}
+		if dl.Status == DeliveryDelivered {
+			delivered++
+		}
+	}
+	return delivered, errors.Join(errs...)
+}
+
+// attempt sends a delivery once and records the outcome on it.
+func (d *Dispatcher) attempt(ctx context.Context, dl *Delivery) {
+	now := time.Now().UTC()
+	dl.Attempts++
+
+	sub, err := d.subs.GetByID(ctx, dl.SubscriptionID)
+	if err == nil && (!sub.IsActive || sub.DeletionMark) {
+		err = errors.New("subscription is inactive")
+	}
+	if err != nil {
+		// Nothing to send to: fail without retries; an administrator can
+		// retry after reactivating the subscription.
+		d.fail(dl, err, true)
+		return
+	}
+
+	body, err := dl.body()
+	if err != nil {
+		d.fail(dl, err, true)
+		return
+	}
+
+	resp, err := d.sender.Send(ctx, crypto.WebhookRequest{
+		URL:        sub.URL,
+		Secret:     sub.Secret,
+		Event:      dl.EventType,
+		DeliveryID: dl.ID.String(),
+		Body:       body,
+		Timestamp:  now,
+	})
+	dl.LastStatusCode, dl.ResponseTimeMs = nil, nil
+	if resp != nil {
+		dl.ResponseTimeMs = &resp.ResponseTimeMs
+		if resp.StatusCode != 0 {
+			dl.LastStatusCode = &resp.StatusCode
+		}
+	}
+	if err != nil {
+		logger.Warn(ctx, "webhook subscription delivery failed",
+			"delivery_id", dl.ID,
+			"subscription_id", dl.SubscriptionID,
+			"attempt", dl.Attempts,
+			"error", err,
+		)
+		d.fail(dl, err, false)
+		return
+	}
+
+	dl.Status = DeliveryDelivered
+	dl.DeliveredAt = &now
+	dl.LastError = nil
+}
+
+// fail records a failed attempt and schedules the next one, unless the
+// delivery is out of attempts or permanent is set.
+func (d *Dispatcher) fail(dl *Delivery, err error, permanent bool) {
+	msg := err.Error()
+	dl.LastError = &msg
+	if permanent || dl.Attempts >= MaxAttempts {
+		dl.Status = DeliveryFailed
+		return
+	}
+	dl.Status = DeliveryPending
+	dl.NextAttemptAt = time.Now().UTC().Add(d.backoff.NextDelay(dl.Attempts - 1))
+}
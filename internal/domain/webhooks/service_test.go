@@ -0,0 +1,287 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/catalogs/webhook_subscription"
+	"metapus/internal/domain/crypto"
+)
+
+// memRepo is an in-memory Repository.
+type memRepo struct {
+	deliveries map[id.ID]*Delivery
+}
+
+func newMemRepo() *memRepo { return &memRepo{deliveries: map[id.ID]*Delivery{}} }
+
+func (r *memRepo) Enqueue(_ context.Context, ds []*Delivery) (int, error) {
+	n := 0
+next:
+	for _, d := range ds {
+		for _, e := range r.deliveries {
+			if e.OutboxID == d.OutboxID && e.SubscriptionID == d.SubscriptionID {
+				continue next
+			}
+		}
+		clone := *d
+		r.deliveries[d.ID] = &clone
+		n++
+	}
+	return n, nil
+}
+
+func (r *memRepo) ClaimDue(_ context.Context, limit int, lease time.Duration) ([]*Delivery, error) {
+	now := time.Now()
+	var out []*Delivery
+	for _, d := range r.deliveries {
+		if len(out) == limit {
+			break
+		}
+		if d.Status == DeliveryPending && !d.NextAttemptAt.After(now) {
+			d.NextAttemptAt = now.Add(lease)
+			clone := *d
+			out = append(out, &clone)
+		}
+	}
+	return out, nil
+}
+
+func (r *memRepo) Save(_ context.Context, d *Delivery) error {
+	clone := *d
+	r.deliveries[d.ID] = &clone
+	return nil
+}
+
+func (r *memRepo) GetByID(_ context.Context, deliveryID id.ID) (*Delivery, error) {
+	d, ok := r.deliveries[deliveryID]
+	if !ok {
+		return nil, apperror.NewNotFound("webhook_delivery", deliveryID.String())
+	}
+	clone := *d
+	return &clone, nil
+}
+
+func (r *memRepo) List(context.Context, DeliveryFilter) ([]Delivery, int, error) {
+	return nil, 0, nil
+}
+
+// only returns the single stored delivery.
+func (r *memRepo) only(t *testing.T) *Delivery {
+	t.Helper()
+	if len(r.deliveries) != 1 {
+		t.Fatalf("%d deliveries, want 1", len(r.deliveries))
+	}
+	for _, d := range r.deliveries {
+		return d
+	}
+	return nil
+}
+
+// makeDue makes every pending delivery due now.
+func (r *memRepo) makeDue() {
+	for _, d := range r.deliveries {
+		d.NextAttemptAt = time.Now().Add(-time.Second)
+	}
+}
+
+type memSubs []*webhook_subscription.WebhookSubscription
+
+func (s memSubs) ListActive(context.Context) ([]*webhook_subscription.WebhookSubscription, error) {
+	var out []*webhook_subscription.WebhookSubscription
+	for _, sub := range s {
+		if sub.IsActive && !sub.DeletionMark {
+			out = append(out, sub)
+		}
+	}
+	return out, nil
+}
+
+func (s memSubs) GetByID(_ context.Context, subscriptionID id.ID) (*webhook_subscription.WebhookSubscription, error) {
+	for _, sub := range s {
+		if sub.ID == subscriptionID {
+			return sub, nil
+		}
+	}
+	return nil, apperror.NewNotFound("webhook_subscription", subscriptionID.String())
+}
+
+// recordingSender answers with the queued status codes (0 = transport error).
+type recordingSender struct {
+	statuses []int
+	requests []crypto.WebhookRequest
+}
+
+func (s *recordingSender) Send(_ context.Context, r crypto.WebhookRequest) (*crypto.WebhookResponse, error) {
+	s.requests = append(s.requests, r)
+	status := 200
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	resp := &crypto.WebhookResponse{StatusCode: status, ResponseTimeMs: 5}
+	switch {
+	case status == 0:
+		return resp, errors.New("connection refused")
+	case status >= 300:
+		return resp, errors.New("webhook returned non-2xx")
+	}
+	return resp, nil
+}
+
+// doublingBackoff is 1m, 2m, 4m, ...
+type doublingBackoff struct{}
+
+func (doublingBackoff) NextDelay(retryCount int) time.Duration { return time.Minute << retryCount }
+
+func newSubscription(eventTypes ...string) *webhook_subscription.WebhookSubscription {
+	return webhook_subscription.NewWebhookSubscription("WH-1", "ERP", "https://erp.example.com/hooks", "whsec_0123456789abcdef", eventTypes)
+}
+
+func newEvent(eventType string) Event {
+	return Event{OutboxID: id.New(), Type: eventType, Payload: json.RawMessage(`{"number":"ПТ-0001"}`), OccurredAt: time.Now().UTC()}
+}
+
+func TestFanoutMatchesFiltersOnce(t *testing.T) {
+	receipts := newSubscription("GoodsReceipt*")
+	all := newSubscription()
+	paused := newSubscription()
+	paused.IsActive = false
+	repo := newMemRepo()
+	d := NewDispatcher(repo, memSubs{receipts, all, paused}, &recordingSender{}, doublingBackoff{})
+
+	ev := newEvent("GoodsReceiptPosted")
+	n, err := d.Fanout(context.Background(), ev)
+	if err != nil || n != 2 {
+		t.Fatalf("Fanout = %d, %v; want 2 deliveries", n, err)
+	}
+	// A retried outbox message is not fanned out again.
+	if n, _ := d.Fanout(context.Background(), ev); n != 0 {
+		t.Errorf("second Fanout enqueued %d deliveries, want 0", n)
+	}
+
+	if n, _ := d.Fanout(context.Background(), newEvent("GoodsIssuePosted")); n != 1 {
+		t.Errorf("GoodsIssuePosted fanned out to %d subscriptions, want 1", n)
+	}
+}
+
+func TestDeliverDueSignsAndDelivers(t *testing.T) {
+	sub := newSubscription()
+	repo := newMemRepo()
+	sender := &recordingSender{}
+	d := NewDispatcher(repo, memSubs{sub}, sender, doublingBackoff{})
+
+	if _, err := d.Fanout(context.Background(), newEvent("GoodsReceiptPosted")); err != nil {
+		t.Fatal(err)
+	}
+	n, err := d.DeliverDue(context.Background())
+	if err != nil || n != 1 {
+		t.Fatalf("DeliverDue = %d, %v; want 1 delivered", n, err)
+	}
+
+	dl := repo.only(t)
+	if dl.Status != DeliveryDelivered || dl.Attempts != 1 || dl.DeliveredAt == nil {
+		t.Errorf("delivery = %+v, want delivered after 1 attempt", dl)
+	}
+	req := sender.requests[0]
+	if req.URL != sub.URL || req.Secret != sub.Secret || req.DeliveryID != dl.ID.String() || req.Event != "GoodsReceiptPosted" {
+		t.Errorf("request = %+v", req)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(req.Body, &body); err != nil {
+		t.Fatalf("body: %v", err)
+	}
+	if body["event"] != "GoodsReceiptPosted" || body["data"].(map[string]any)["number"] != "ПТ-0001" {
+		t.Errorf("body = %s", req.Body)
+	}
+
+	// Nothing is due any more.
+	if n, _ := d.DeliverDue(context.Background()); n != 0 || len(sender.requests) != 1 {
+		t.Errorf("delivered again: %d", n)
+	}
+}
+
+func TestDeliverDueBacksOffAndGivesUp(t *testing.T) {
+	repo := newMemRepo()
+	sender := &recordingSender{statuses: []int{0, 500, 503, 500, 502, 500}}
+	d := NewDispatcher(repo, memSubs{newSubscription()}, sender, doublingBackoff{})
+	if _, err := d.Fanout(context.Background(), newEvent("GoodsReceiptPosted")); err != nil {
+		t.Fatal(err)
+	}
+
+	for attempt := 1; attempt <= MaxAttempts; attempt++ {
+		repo.makeDue()
+		before := time.Now()
+		if _, err := d.DeliverDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		dl := repo.only(t)
+		if dl.Attempts != attempt || dl.LastError == nil {
+			t.Fatalf("attempt %d: %+v", attempt, dl)
+		}
+		if attempt == MaxAttempts {
+			if dl.Status != DeliveryFailed {
+				t.Errorf("after %d attempts status = %s, want failed", attempt, dl.Status)
+			}
+			break
+		}
+		wantDelay := time.Minute << (attempt - 1)
+		if dl.Status != DeliveryPending || dl.NextAttemptAt.Before(before.Add(wantDelay)) || dl.NextAttemptAt.After(time.Now().Add(wantDelay)) {
+			t.Errorf("attempt %d: status %s, next attempt in %v; want pending in %v",
+				attempt, dl.Status, time.Until(dl.NextAttemptAt).Round(time.Second), wantDelay)
+		}
+	}
+
+	// Every attempt sent the same signed body under the same delivery ID.
+	for _, req := range sender.requests[1:] {
+		if !bytes.Equal(req.Body, sender.requests[0].Body) || req.DeliveryID != sender.requests[0].DeliveryID {
+			t.Fatal("retries must resend the same body and delivery ID")
+		}
+	}
+}
+
+func TestDeliverDueInactiveSubscriptionFails(t *testing.T) {
+	sub := newSubscription()
+	repo := newMemRepo()
+	sender := &recordingSender{}
+	d := NewDispatcher(repo, memSubs{sub}, sender, doublingBackoff{})
+	if _, err := d.Fanout(context.Background(), newEvent("GoodsReceiptPosted")); err != nil {
+		t.Fatal(err)
+	}
+	sub.IsActive = false
+
+	if _, err := d.DeliverDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if dl := repo.only(t); dl.Status != DeliveryFailed || len(sender.requests) != 0 {
+		t.Errorf("status %s, %d requests; want failed without sending", dl.Status, len(sender.requests))
+	}
+}
+
+func TestRetryFailedDelivery(t *testing.T) {
+	repo := newMemRepo()
+	svc := NewService(repo)
+	failed := &Delivery{ID: id.New(), Status: DeliveryFailed, Attempts: MaxAttempts, NextAttemptAt: time.Now().Add(time.Hour)}
+	delivered := &Delivery{ID: id.New(), Status: DeliveryDelivered, Attempts: 1}
+	repo.deliveries[failed.ID] = failed
+	repo.deliveries[delivered.ID] = delivered
+
+	got, err := svc.Retry(context.Background(), failed.ID)
+	if err != nil {
+		t.Fatalf("Retry: %v", err)
+	}
+	if got.Status != DeliveryPending || got.Attempts != 0 || got.NextAttemptAt.After(time.Now()) {
+		t.Errorf("retried delivery = %+v, want pending and due", got)
+	}
+
+	_, err = svc.Retry(context.Background(), delivered.ID)
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) || appErr.Code != "WEBHOOK_DELIVERY_NOT_FAILED" {
+		t.Errorf("retrying a delivered delivery: err = %v", err)
+	}
+}
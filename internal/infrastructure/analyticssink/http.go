@@ -0,0 +1,100 @@
+// Package analyticssink provides analytics.Sink backends: a Segment-compatible
+// HTTP batch API and a table in the meta database.
+package analyticssink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"metapus/internal/domain/analytics"
+)
+
+// HTTPConfig holds connection settings for a Segment-like batch endpoint.
+type HTTPConfig struct {
+	// URL is the batch endpoint, e.g. https://api.segment.io/v1/batch.
+	URL string
+	// WriteKey authenticates the source (HTTP Basic username, empty password).
+	WriteKey string
+}
+
+// HTTPSink posts events to a Segment-compatible batch API
+// ({"batch": [{"type": "track", ...}]}).
+// Thread-safe: stateless client.
+type HTTPSink struct {
+	cfg    HTTPConfig
+	client *http.Client
+}
+
+// NewHTTPSink creates an HTTP sink.
+func NewHTTPSink(cfg HTTPConfig) (*HTTPSink, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("analytics http sink: url is required")
+	}
+	return &HTTPSink{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+type trackMessage struct {
+	Type        string         `json:"type"`
+	Event       string         `json:"event"`
+	AnonymousID string         `json:"anonymousId"`
+	Properties  map[string]any `json:"properties"`
+	Timestamp   time.Time      `json:"timestamp"`
+}
+
+// Send posts the events as one batch. The aggregated count is sent as the
+// "count" property.
+func (s *HTTPSink) Send(ctx context.Context, events []analytics.Event) error {
+	batch := make([]trackMessage, 0, len(events))
+	for _, ev := range events {
+		props := make(map[string]any, len(ev.Properties)+1)
+		for k, v := range ev.Properties {
+			props[k] = v
+		}
+		props["count"] = ev.Count
+		batch = append(batch, trackMessage{
+			Type:        "track",
+			Event:       ev.Name,
+			AnonymousID: ev.AnonymousID,
+			Properties:  props,
+			Timestamp:   ev.Timestamp,
+		})
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"batch":  batch,
+		"sentAt": time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal analytics batch: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.cfg.WriteKey != "" {
+		req.SetBasicAuth(s.cfg.WriteKey, "")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("analytics request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("analytics HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package analyticssink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"metapus/internal/domain/analytics"
+)
+
+func TestHTTPSinkPostsSegmentBatch(t *testing.T) {
+	var body struct {
+		Batch []struct {
+			Type        string         `json:"type"`
+			Event       string         `json:"event"`
+			AnonymousID string         `json:"anonymousId"`
+			Properties  map[string]any `json:"properties"`
+		} `json:"batch"`
+	}
+	var user string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ = r.BasicAuth()
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	sink, err := NewHTTPSink(HTTPConfig{URL: srv.URL, WriteKey: "wk"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sink.Send(context.Background(), []analytics.Event{{
+		Name:        analytics.EventReportRun,
+		AnonymousID: "a1",
+		Properties:  map[string]any{"dataset": "stock"},
+		Count:       3,
+		Timestamp:   time.Now(),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user != "wk" {
+		t.Errorf("basic auth user = %q, want write key", user)
+	}
+	if len(body.Batch) != 1 {
+		t.Fatalf("batch = %+v", body.Batch)
+	}
+	msg := body.Batch[0]
+	if msg.Type != "track" || msg.Event != analytics.EventReportRun || msg.AnonymousID != "a1" {
+		t.Errorf("message = %+v", msg)
+	}
+	if msg.Properties["dataset"] != "stock" || msg.Properties["count"] != float64(3) {
+		t.Errorf("properties = %v", msg.Properties)
+	}
+}
+
+func TestHTTPSinkReportsErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad write key", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	sink, err := NewHTTPSink(HTTPConfig{URL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Send(context.Background(), []analytics.Event{{Name: "x"}}); err == nil {
+		t.Error("expected error for HTTP 401")
+	}
+}
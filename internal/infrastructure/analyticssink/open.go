@@ -0,0 +1,46 @@
+package analyticssink
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"metapus/internal/domain/analytics"
+)
+
+// Sink kinds selectable with Config.Kind.
+const (
+	KindNone  = "none"
+	KindHTTP  = "http"
+	KindTable = "table"
+)
+
+// Config selects and configures the analytics sink.
+type Config struct {
+	Kind string
+	HTTP HTTPConfig
+}
+
+// Open creates the configured sink. Returns nil for KindNone (or empty):
+// analytics is disabled. metaPool is used by KindTable.
+func Open(ctx context.Context, cfg Config, metaPool *pgxpool.Pool) (analytics.Sink, error) {
+	switch cfg.Kind {
+	case "", KindNone:
+		return nil, nil
+	case KindHTTP:
+		sink, err := NewHTTPSink(cfg.HTTP)
+		if err != nil {
+			return nil, err
+		}
+		return sink, nil
+	case KindTable:
+		sink := NewTableSink(metaPool)
+		if err := sink.EnsureTable(ctx); err != nil {
+			return nil, err
+		}
+		return sink, nil
+	default:
+		return nil, fmt.Errorf("unknown analytics sink %q", cfg.Kind)
+	}
+}
@@ -0,0 +1,68 @@
+package analyticssink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"metapus/internal/domain/analytics"
+)
+
+// TableSink stores events in the analytics_events table of the meta
+// database, for deployments without an external analytics service.
+// The table is created automatically on first use (EnsureTable).
+type TableSink struct {
+	pool *pgxpool.Pool
+}
+
+// NewTableSink creates a sink backed by the meta database.
+func NewTableSink(pool *pgxpool.Pool) *TableSink {
+	return &TableSink{pool: pool}
+}
+
+// EnsureTable creates the analytics_events table if it does not exist.
+// Safe to call on every startup — fully idempotent.
+func (s *TableSink) EnsureTable(ctx context.Context) error {
+	_, err := s.pool.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS analytics_events (
+			id           BIGSERIAL PRIMARY KEY,
+			event        VARCHAR(100) NOT NULL,
+			anonymous_id VARCHAR(64)  NOT NULL,
+			properties   JSONB        NOT NULL DEFAULT '{}',
+			count        INT          NOT NULL DEFAULT 1,
+			occurred_at  TIMESTAMPTZ  NOT NULL,
+			received_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+		);
+		CREATE INDEX IF NOT EXISTS idx_analytics_events_event ON analytics_events (event, occurred_at);
+		CREATE INDEX IF NOT EXISTS idx_analytics_events_anonymous ON analytics_events (anonymous_id, occurred_at);
+	`)
+	if err != nil {
+		return fmt.Errorf("ensure analytics_events table: %w", err)
+	}
+	return nil
+}
+
+// Send inserts the events in one batch.
+func (s *TableSink) Send(ctx context.Context, events []analytics.Event) error {
+	batch := &pgx.Batch{}
+	for _, ev := range events {
+		props, err := json.Marshal(ev.Properties)
+		if err != nil {
+			return fmt.Errorf("marshal event properties: %w", err)
+		}
+		if ev.Properties == nil {
+			props = []byte("{}")
+		}
+		batch.Queue(`
+			INSERT INTO analytics_events (event, anonymous_id, properties, count, occurred_at)
+			VALUES ($1, $2, $3, $4, $5)`,
+			ev.Name, ev.AnonymousID, props, ev.Count, ev.Timestamp)
+	}
+	if err := s.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("insert analytics events: %w", err)
+	}
+	return nil
+}
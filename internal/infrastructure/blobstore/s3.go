@@ -0,0 +1,302 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"metapus/internal/core/apperror"
+)
+
+// S3Config holds connection settings for S3-compatible storage.
+type S3Config struct {
+	// Endpoint is the service URL, e.g. https://s3.eu-central-1.amazonaws.com
+	// or http://minio:9000.
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3Store keeps blobs as objects in an S3 bucket. Requests use path-style
+// addressing (endpoint/bucket/key), which both AWS S3 and MinIO accept,
+// and are signed with AWS Signature Version 4.
+type S3Store struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewS3Store creates an S3 store.
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, fmt.Errorf("s3 store: endpoint, bucket, access key and secret key are required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("s3 store: invalid endpoint %q", cfg.Endpoint)
+	}
+	return &S3Store{
+		cfg:      cfg,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 5 * time.Minute},
+		now:      time.Now,
+	}, nil
+}
+
+// Put uploads the object. The payload is streamed unsigned
+// (UNSIGNED-PAYLOAD), so size must be exact.
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Get downloads the object.
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, apperror.NewNotFound("attachment_blob", key)
+		}
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete removes the object. S3 reports success for missing keys as well.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3Store) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	u := *s.endpoint
+	u.Path = s.endpoint.Path + "/" + s.cfg.Bucket + "/" + key
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("s3 %s: build request: %w", method, err)
+	}
+	return req, nil
+}
+
+// do signs and sends the request. Non-2xx responses are returned together
+// with an error; the response body is already closed in that case.
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	s.sign(req, s.now().UTC())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 %s: %w", req.Method, err)
+	}
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return resp, fmt.Errorf("s3 %s: status %d: %s", req.Method, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+const (
+	sigAlgorithm    = "AWS4-HMAC-SHA256"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// sign adds AWS Signature Version 4 headers to the request.
+func (s *S3Store) sign(req *http.Request, t time.Time) {
+	amzDate := t.Format("20060102T150405Z")
+	day := t.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	// Canonical headers: host, content-type (if set) and all x-amz-* headers.
+	names := []string{"host"}
+	for name := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || strings.HasPrefix(lower, "x-amz-") {
+			names = append(names, lower)
+		}
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		value := req.URL.Host
+		if name != "host" {
+			value = strings.TrimSpace(req.Header.Get(name))
+		}
+		canonicalHeaders.WriteString(name + ":" + value + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	scope := day + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		sigAlgorithm,
+		amzDate,
+		scope,
+		hexSHA256(canonicalRequest),
+	}, "\n")
+
+	signature := hex.EncodeToString(hmacSHA256(s.signingKey(day), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigAlgorithm, s.cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+// maxPresignTTL is the longest validity S3 accepts for a presigned URL.
+const maxPresignTTL = 7 * 24 * time.Hour
+
+// PresignGet returns a URL that downloads the object without credentials
+// until ttl elapses (AWS Signature Version 4, query-string authentication).
+// A non-empty fileName is sent back as an attachment Content-Disposition.
+func (s *S3Store) PresignGet(key, fileName string, ttl time.Duration) (string, error) {
+	params := map[string]string{}
+	if fileName != "" {
+		params["response-content-disposition"] = "attachment; filename*=UTF-8''" + url.PathEscape(fileName)
+	}
+	return s.presign(http.MethodGet, key, params, "", ttl), nil
+}
+
+// PresignPut returns a URL that uploads the object without credentials
+// until ttl elapses. The Content-Type header is signed, so the upload must
+// send the returned headers unchanged.
+func (s *S3Store) PresignPut(key, contentType string, ttl time.Duration) (string, map[string]string, error) {
+	return s.presign(http.MethodPut, key, map[string]string{}, contentType, ttl),
+		map[string]string{"Content-Type": contentType}, nil
+}
+
+// presign signs a request for key in the query string. A non-empty
+// contentType is a signed header besides host.
+func (s *S3Store) presign(method, key string, params map[string]string, contentType string, ttl time.Duration) string {
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+	if ttl > maxPresignTTL {
+		ttl = maxPresignTTL
+	}
+
+	t := s.now().UTC()
+	amzDate := t.Format("20060102T150405Z")
+	day := t.Format("20060102")
+	scope := day + "/" + s.cfg.Region + "/s3/aws4_request"
+
+	u := *s.endpoint
+	u.Path = s.endpoint.Path + "/" + s.cfg.Bucket + "/" + key
+
+	headers, signedHeaders := "host:"+u.Host+"\n", "host"
+	if contentType != "" {
+		headers, signedHeaders = "content-type:"+contentType+"\n"+headers, "content-type;host"
+	}
+
+	params["X-Amz-Algorithm"] = sigAlgorithm
+	params["X-Amz-Credential"] = s.cfg.AccessKey + "/" + scope
+	params["X-Amz-Date"] = amzDate
+	params["X-Amz-Expires"] = strconv.Itoa(int(ttl / time.Second))
+	params["X-Amz-SignedHeaders"] = signedHeaders
+	query := canonicalQuery(params)
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		query,
+		headers,
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		sigAlgorithm,
+		amzDate,
+		scope,
+		hexSHA256(canonicalRequest),
+	}, "\n")
+	signature := hex.EncodeToString(hmacSHA256(s.signingKey(day), stringToSign))
+
+	u.RawQuery = query + "&X-Amz-Signature=" + signature
+	return u.String()
+}
+
+// signingKey derives the SigV4 signing key for a day (YYYYMMDD).
+func (s *S3Store) signingKey(day string) []byte {
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), day)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	return hmacSHA256(key, "aws4_request")
+}
+
+// canonicalQuery encodes params sorted by name with SigV4 URI encoding
+// (spaces as %20, "/" escaped).
+func canonicalQuery(params map[string]string) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, sigV4Escape(name)+"="+sigV4Escape(params[name]))
+	}
+	return strings.Join(parts, "&")
+}
+
+func sigV4Escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hexSHA256(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,126 @@
+package blobstore
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestS3Store(t *testing.T) *S3Store {
+	t.Helper()
+	store, err := NewS3Store(S3Config{
+		Endpoint:  "http://minio:9000",
+		Region:    "eu-central-1",
+		Bucket:    "artifacts",
+		AccessKey: "AKID",
+		SecretKey: "secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.now = func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) }
+	return store
+}
+
+func TestS3PresignGet(t *testing.T) {
+	store := newTestS3Store(t)
+
+	raw, err := store.PresignGet("tenant-1/artifacts/backup/a1", "отчёт 1.xlsx", 15*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Host != "minio:9000" || u.Path != "/artifacts/tenant-1/artifacts/backup/a1" {
+		t.Errorf("url = %s, want path-style object URL", raw)
+	}
+
+	q := u.Query()
+	for name, want := range map[string]string{
+		"X-Amz-Algorithm":              "AWS4-HMAC-SHA256",
+		"X-Amz-Credential":             "AKID/20261016/eu-central-1/s3/aws4_request",
+		"X-Amz-Date":                   "20261016T120000Z",
+		"X-Amz-Expires":                "900",
+		"X-Amz-SignedHeaders":          "host",
+		"response-content-disposition": "attachment; filename*=UTF-8''%D0%BE%D1%82%D1%87%D1%91%D1%82%201.xlsx",
+	} {
+		if got := q.Get(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	if len(q.Get("X-Amz-Signature")) != 64 {
+		t.Errorf("signature = %q, want 64 hex chars", q.Get("X-Amz-Signature"))
+	}
+	if strings.Contains(u.RawQuery, "+") {
+		t.Errorf("query %q must encode spaces as %%20", u.RawQuery)
+	}
+}
+
+func TestS3PresignGetSignatureDependsOnKeyAndTTL(t *testing.T) {
+	store := newTestS3Store(t)
+
+	sig := func(key string, ttl time.Duration) string {
+		raw, err := store.PresignGet(key, "", ttl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, _ := url.Parse(raw)
+		return u.Query().Get("X-Amz-Signature")
+	}
+
+	base := sig("k1", time.Hour)
+	if base != sig("k1", time.Hour) {
+		t.Error("signature must be deterministic for the same request")
+	}
+	if base == sig("k2", time.Hour) {
+		t.Error("signature must change with the object key")
+	}
+	if base == sig("k1", 2*time.Hour) {
+		t.Error("signature must change with the expiry")
+	}
+}
+
+func TestS3PresignGetClampsTTL(t *testing.T) {
+	store := newTestS3Store(t)
+
+	raw, err := store.PresignGet("k", "", 30*24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(raw)
+	if got := u.Query().Get("X-Amz-Expires"); got != "604800" {
+		t.Errorf("X-Amz-Expires = %s, want 604800 (7 days)", got)
+	}
+}
+
+func TestS3PresignPutSignsContentType(t *testing.T) {
+	store := newTestS3Store(t)
+
+	put := func(contentType string) (*url.URL, map[string]string) {
+		raw, headers, err := store.PresignPut("tenant-1/goods_receipt/d1/f1", contentType, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, _ := url.Parse(raw)
+		return u, headers
+	}
+
+	u, headers := put("image/jpeg")
+	if got := u.Query().Get("X-Amz-SignedHeaders"); got != "content-type;host" {
+		t.Errorf("X-Amz-SignedHeaders = %q", got)
+	}
+	if headers["Content-Type"] != "image/jpeg" {
+		t.Errorf("headers = %v", headers)
+	}
+
+	get, _ := store.PresignGet("tenant-1/goods_receipt/d1/f1", "", time.Hour)
+	getURL, _ := url.Parse(get)
+	png, _ := put("image/png")
+	sig := u.Query().Get("X-Amz-Signature")
+	if sig == getURL.Query().Get("X-Amz-Signature") || sig == png.Query().Get("X-Amz-Signature") {
+		t.Error("signature must change with the method and the content type")
+	}
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/pkg/logger"
+)
+
+// EntityInvalidatedChannel is the NOTIFY channel fired by notify_entity_invalidated
+// on every write to a catalog table (see migration 00050).
+const EntityInvalidatedChannel = "entity_invalidated"
+
+// Invalidation ops. OpReset has no entity or ID: the tenant's LISTEN
+// connection was (re)established or lost and every cached row is suspect.
+const (
+	OpInsert = "insert"
+	OpUpdate = "update"
+	OpDelete = "delete"
+	OpReset  = "reset"
+)
+
+// Invalidation is a standardized cache invalidation event.
+type Invalidation struct {
+	TenantID string `json:"-"`
+	// Entity is the table name, e.g. "cat_counterparties" (same keys as the
+	// reference resolver uses).
+	Entity  string `json:"entity"`
+	ID      id.ID  `json:"id"`
+	Version int    `json:"version"`
+	Op      string `json:"op"`
+}
+
+// InvalidationHandler reacts to an invalidation. Must be fast and non-blocking:
+// handlers run on the tenant's LISTEN goroutine.
+type InvalidationHandler func(ctx context.Context, inv Invalidation)
+
+// InvalidationSubscriber delivers entity invalidations of all API instances
+// to local caches.
+//
+// Usage:
+//
+//	sub := cache.NewInvalidationSubscriber(tenantManager)
+//	sub.Subscribe("cat_counterparties", counterpartyCache.Invalidate)
+//	sub.Start(ctx)
+//	defer sub.Stop()
+//	// a cache calls sub.Watch(tenantID) when it first loads data of a tenant
+type InvalidationSubscriber struct {
+	*tenantWatcher
+
+	mu       sync.RWMutex
+	handlers map[string][]InvalidationHandler // entity -> handlers; "" = all entities
+}
+
+// NewInvalidationSubscriber creates a subscriber. Call Start to enable it.
+func NewInvalidationSubscriber(manager *tenant.Manager) *InvalidationSubscriber {
+	s := &InvalidationSubscriber{handlers: make(map[string][]InvalidationHandler)}
+	s.tenantWatcher = newTenantWatcher(manager, EntityInvalidatedChannel, s.reset, s.notify)
+	return s
+}
+
+// Subscribe registers h for invalidations of entity ("" for every entity).
+// Resets are delivered to all handlers.
+func (s *InvalidationSubscriber) Subscribe(entity string, h InvalidationHandler) {
+	s.mu.Lock()
+	s.handlers[entity] = append(s.handlers[entity], h)
+	s.mu.Unlock()
+}
+
+func (s *InvalidationSubscriber) notify(ctx context.Context, tenantID, payload string) {
+	var inv Invalidation
+	if err := json.Unmarshal([]byte(payload), &inv); err != nil {
+		logger.Warn(ctx, "invalid entity invalidation payload", "tenant_id", tenantID, "payload", payload, "error", err)
+		return
+	}
+	inv.TenantID = tenantID
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, h := range s.handlers[inv.Entity] {
+		h(ctx, inv)
+	}
+	for _, h := range s.handlers[""] {
+		h(ctx, inv)
+	}
+}
+
+func (s *InvalidationSubscriber) reset(tenantID string) {
+	inv := Invalidation{TenantID: tenantID, Op: OpReset}
+	ctx := context.Background()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, hs := range s.handlers {
+		for _, h := range hs {
+			h(ctx, inv)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"context"
+
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/modules"
+	"metapus/pkg/logger"
+)
+
+// ModuleListener invalidates the modules.Resolver cache of a tenant when a
+// module flag changes in that tenant's database. Like SettingsListener it
+// watches tenants lazily, from the resolver's load hook.
+type ModuleListener struct {
+	*tenantWatcher
+	resolver *modules.Resolver
+}
+
+// NewModuleListener creates a listener for resolver. Call Start to enable it.
+func NewModuleListener(manager *tenant.Manager, resolver *modules.Resolver) *ModuleListener {
+	l := &ModuleListener{resolver: resolver}
+	l.tenantWatcher = newTenantWatcher(manager, modules.FeatureFlagsChangedChannel,
+		resolver.Invalidate,
+		func(ctx context.Context, tenantID, flagName string) {
+			if !modules.IsModuleFlag(flagName) {
+				return
+			}
+			logger.Info(ctx, "module flag changed", "tenant_id", tenantID, "flag", flagName)
+			resolver.Invalidate(tenantID)
+		},
+	)
+	resolver.SetLoadHook(l.Watch)
+	return l
+}
@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"context"
+
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/notifications"
+	"metapus/pkg/logger"
+)
+
+// NotificationListener feeds notifications.Broker with the sys_notifications
+// changes of a tenant. Tenants are watched lazily, from the broker's
+// subscribe hook: only tenants with an open notification stream hold a
+// LISTEN connection.
+type NotificationListener struct {
+	*tenantWatcher
+	broker *notifications.Broker
+}
+
+// NewNotificationListener creates a listener for broker. Call Start to enable it.
+func NewNotificationListener(manager *tenant.Manager, broker *notifications.Broker) *NotificationListener {
+	l := &NotificationListener{broker: broker}
+	l.tenantWatcher = newTenantWatcher(manager, notifications.ChangedChannel,
+		broker.Reset,
+		func(ctx context.Context, tenantID, payload string) {
+			change, err := notifications.ParseChange(payload)
+			if err != nil {
+				logger.Warn(ctx, "notification listener: bad payload", "tenant_id", tenantID, "error", err)
+				return
+			}
+			broker.Publish(tenantID, change)
+		},
+	)
+	broker.SetSubscribeHook(l.Watch)
+	return l
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -24,6 +25,9 @@ type SchemaCache struct {
 	customFields map[string][]CustomFieldSchema // entityType -> fields
 	featureFlags map[string]FeatureFlag         // flagName -> flag
 
+	// version is bumped on every invalidation (see Version, WaitForChange).
+	version Version
+
 	// Listeners for cache invalidation
 	listeners   []InvalidationListener
 	listenersMu sync.RWMutex
@@ -208,6 +212,10 @@ func (c *SchemaCache) handleNotification(channel, payload string) {
 		c.invalidateFeatureFlags(c.ctx, payload)
 	}
 
+	// Bump even when the reload failed: clients revalidate and see
+	// whatever the cache serves now.
+	c.version.Bump()
+
 	// Notify registered listeners with panic recovery (no goroutine fan-out).
 	// This keeps invalidation delivery bounded and avoids goroutine storms on bursts of NOTIFY events.
 	c.listenersMu.RLock()
@@ -456,6 +464,31 @@ func (c *SchemaCache) GetFeatureConfig(flagName string) map[string]any {
 	return cfg
 }
 
+// FeatureFlags returns a copy of all feature flags sorted by name.
+func (c *SchemaCache) FeatureFlags() []FeatureFlag {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	flags := make([]FeatureFlag, 0, len(c.featureFlags))
+	for _, flag := range c.featureFlags {
+		flag.Config = maps.Clone(flag.Config)
+		flags = append(flags, flag)
+	}
+	slices.SortFunc(flags, func(a, b FeatureFlag) int { return strings.Compare(a.FlagName, b.FlagName) })
+	return flags
+}
+
+// Version returns the cache version, bumped on every invalidation.
+func (c *SchemaCache) Version() uint64 {
+	return c.version.Current()
+}
+
+// WaitForChange blocks until the cache version differs from since or ctx is
+// done, and returns the current version.
+func (c *SchemaCache) WaitForChange(ctx context.Context, since uint64) uint64 {
+	return c.version.Wait(ctx, since)
+}
+
 // OnInvalidation registers a callback for cache invalidation events.
 func (c *SchemaCache) OnInvalidation(listener InvalidationListener) {
 	c.listenersMu.Lock()
@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"context"
+
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/settings"
+	"metapus/pkg/logger"
+)
+
+// SettingsListener invalidates the settings.Resolver cache of a tenant when
+// sys_setting_values changes in that tenant's database.
+//
+// Tenants are watched lazily (see settings.Resolver.SetLoadHook). Values
+// loaded before LISTEN succeeds may already be stale; until then the
+// resolver's TTL bounds staleness instead.
+type SettingsListener struct {
+	*tenantWatcher
+	resolver *settings.Resolver
+}
+
+// NewSettingsListener creates a listener for resolver. Call Start to enable it.
+func NewSettingsListener(manager *tenant.Manager, resolver *settings.Resolver) *SettingsListener {
+	l := &SettingsListener{resolver: resolver}
+	l.tenantWatcher = newTenantWatcher(manager, settings.SettingValuesChangedChannel,
+		resolver.Invalidate,
+		func(ctx context.Context, tenantID, key string) {
+			logger.Debug(ctx, "setting changed", "tenant_id", tenantID, "key", key)
+			resolver.Invalidate(tenantID)
+		},
+	)
+	resolver.SetLoadHook(l.Watch)
+	return l
+}
@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/tenant"
+	"metapus/pkg/logger"
+)
+
+// tenantWatcher holds one LISTEN connection per watched tenant.
+//
+// Connections are opened outside the tenant pool so that idle pool eviction
+// is not blocked by a long-lived acquire. Tenants are watched lazily; when a
+// connection fails the tenant is unwatched and watched again on the next Watch.
+type tenantWatcher struct {
+	manager *tenant.Manager
+	channel string
+
+	// onReset is called once LISTEN succeeds and again when the connection
+	// is gone: notifications outside that window are missed, so everything
+	// cached for the tenant must be treated as stale.
+	onReset func(tenantID string)
+	// onNotify is called for every notification on channel.
+	onNotify func(ctx context.Context, tenantID, payload string)
+
+	mu      sync.Mutex
+	ctx     context.Context
+	cancel  context.CancelFunc
+	active  map[string]context.CancelFunc // tenantID -> listener cancel
+	wg      sync.WaitGroup
+	started bool
+}
+
+func newTenantWatcher(
+	manager *tenant.Manager,
+	channel string,
+	onReset func(tenantID string),
+	onNotify func(ctx context.Context, tenantID, payload string),
+) *tenantWatcher {
+	return &tenantWatcher{
+		manager:  manager,
+		channel:  channel,
+		onReset:  onReset,
+		onNotify: onNotify,
+		active:   make(map[string]context.CancelFunc),
+	}
+}
+
+// Start enables watching tenants.
+func (w *tenantWatcher) Start(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.started {
+		return
+	}
+	w.ctx, w.cancel = context.WithCancel(ctx)
+	w.started = true
+}
+
+// Stop closes all LISTEN connections.
+func (w *tenantWatcher) Stop() {
+	w.mu.Lock()
+	if !w.started {
+		w.mu.Unlock()
+		return
+	}
+	cancel := w.cancel
+	w.started = false
+	w.cancel = nil
+	w.active = make(map[string]context.CancelFunc)
+	w.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	w.wg.Wait()
+}
+
+// Watch starts listening for notifications of tenantID unless already listening.
+func (w *tenantWatcher) Watch(tenantID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.started {
+		return
+	}
+	if _, ok := w.active[tenantID]; ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(w.ctx)
+	w.active[tenantID] = cancel
+	w.wg.Add(1)
+	go w.listen(ctx, tenantID)
+}
+
+// listen holds a LISTEN connection for one tenant until ctx is cancelled or
+// the connection fails.
+func (w *tenantWatcher) listen(ctx context.Context, tenantID string) {
+	defer w.wg.Done()
+	defer w.unwatch(tenantID)
+
+	mp, err := w.manager.GetPool(ctx, tenantID)
+	if err != nil {
+		logger.Warn(ctx, "tenant listener: tenant pool unavailable", "channel", w.channel, "tenant_id", tenantID, "error", err)
+		return
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, mp.Pool().Config().ConnConfig.Copy())
+	if err != nil {
+		logger.Warn(ctx, "tenant listener: connect failed", "channel", w.channel, "tenant_id", tenantID, "error", err)
+		return
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+w.channel); err != nil {
+		logger.Warn(ctx, "tenant listener: LISTEN failed", "channel", w.channel, "tenant_id", tenantID, "error", err)
+		return
+	}
+	w.onReset(tenantID)
+	defer w.onReset(tenantID)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Warn(ctx, "tenant LISTEN connection lost", "channel", w.channel, "tenant_id", tenantID, "error", err)
+			}
+			return
+		}
+		payload, ok := mp.Tenant().NotifyPayload(notification.Payload)
+		if !ok {
+			continue // another tenant of a shared database
+		}
+		w.onNotify(ctx, tenantID, payload)
+	}
+}
+
+func (w *tenantWatcher) unwatch(tenantID string) {
+	w.mu.Lock()
+	if cancel, ok := w.active[tenantID]; ok {
+		cancel()
+		delete(w.active, tenantID)
+	}
+	w.mu.Unlock()
+}
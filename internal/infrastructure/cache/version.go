@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"sync"
+)
+
+// Version is a counter bumped on every cache invalidation. Readers derive
+// HTTP validators from it and long-poll for its next change with Wait.
+// The zero value is ready to use.
+type Version struct {
+	mu      sync.Mutex
+	n       uint64
+	changed chan struct{} // closed and replaced on every Bump
+}
+
+// Current returns the current version.
+func (v *Version) Current() uint64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.n
+}
+
+// Bump increments the version and wakes all waiters.
+func (v *Version) Bump() uint64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.n++
+	if v.changed != nil {
+		close(v.changed)
+		v.changed = nil
+	}
+	return v.n
+}
+
+// Wait blocks until the version differs from since or ctx is done,
+// and returns the version at that moment.
+func (v *Version) Wait(ctx context.Context, since uint64) uint64 {
+	v.mu.Lock()
+	if v.n != since {
+		defer v.mu.Unlock()
+		return v.n
+	}
+	if v.changed == nil {
+		v.changed = make(chan struct{})
+	}
+	changed := v.changed
+	v.mu.Unlock()
+
+	select {
+	case <-changed:
+	case <-ctx.Done():
+	}
+	return v.Current()
+}
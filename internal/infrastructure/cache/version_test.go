@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestVersionWaitWakesOnBump(t *testing.T) {
+	var v Version
+	if got := v.Wait(context.Background(), 7); got != 0 {
+		t.Fatalf("Wait with a stale version = %d, want 0 immediately", got)
+	}
+
+	done := make(chan uint64)
+	go func() { done <- v.Wait(context.Background(), 0) }()
+	select {
+	case got := <-done:
+		t.Fatalf("Wait returned %d before a bump", got)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	v.Bump()
+	select {
+	case got := <-done:
+		if got != 1 {
+			t.Fatalf("Wait = %d, want 1", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wait not woken by Bump")
+	}
+}
+
+func TestVersionWaitHonoursContext(t *testing.T) {
+	var v Version
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if got := v.Wait(ctx, 0); got != 0 {
+		t.Fatalf("Wait = %d, want 0", got)
+	}
+}
@@ -0,0 +1,133 @@
+package eventsink
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+
+	"metapus/internal/core/events"
+)
+
+// KafkaConfig holds connection settings for a Kafka cluster.
+type KafkaConfig struct {
+	// Brokers are the bootstrap brokers, e.g. kafka-1:9092.
+	Brokers []string
+	// Username and Password enable SASL/PLAIN authentication.
+	Username string
+	Password string
+	// TLS connects to the brokers over TLS.
+	TLS bool
+	// TopicTemplate names the topic of an event, see Topic.
+	TopicTemplate string
+}
+
+// kafkaTimeout bounds dialing a broker and each produce request.
+const kafkaTimeout = 10 * time.Second
+
+// kafkaWriter is the part of kafka.Writer the sink uses.
+type kafkaWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// KafkaSink produces events to Kafka with the kafka-go client. The record
+// key is the aggregate ID, hashed to the partition, so the events of one
+// document stay in order within a partition; the value is the envelope.
+// A kafka-go writer serves one topic, so the sink keeps a writer per topic.
+// Thread-safe.
+type KafkaSink struct {
+	cfg       KafkaConfig
+	newWriter func(topic string) kafkaWriter
+
+	mu      sync.Mutex
+	writers map[string]kafkaWriter
+}
+
+// NewKafkaSink creates a Kafka sink. It does not connect until the first
+// delivery.
+func NewKafkaSink(cfg KafkaConfig) (*KafkaSink, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("kafka event sink: brokers are required")
+	}
+	dialer := &kafka.Dialer{Timeout: kafkaTimeout, DualStack: true, ClientID: "metapus"}
+	if cfg.TLS {
+		dialer.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if cfg.Username != "" {
+		dialer.SASLMechanism = plain.Mechanism{Username: cfg.Username, Password: cfg.Password}
+	}
+	return &KafkaSink{
+		cfg: cfg,
+		newWriter: func(topic string) kafkaWriter {
+			return kafka.NewWriter(kafka.WriterConfig{
+				Brokers:  cfg.Brokers,
+				Topic:    topic,
+				Dialer:   dialer,
+				Balancer: &kafka.Hash{},
+				// Deliveries are one event at a time: send each record
+				// at once instead of waiting for the batch timeout.
+				BatchSize:    1,
+				ReadTimeout:  kafkaTimeout,
+				WriteTimeout: kafkaTimeout,
+				RequiredAcks: -1, // all in-sync replicas
+			})
+		},
+		writers: map[string]kafkaWriter{},
+	}, nil
+}
+
+// Name implements events.Sink.
+func (s *KafkaSink) Name() string { return "kafka" }
+
+// Deliver implements events.Sink. The event counts as delivered when all
+// in-sync replicas acknowledged the record.
+func (s *KafkaSink) Deliver(ctx context.Context, env events.Envelope) error {
+	value, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	topic := Topic(s.cfg.TopicTemplate, env)
+	err = s.writer(topic).WriteMessages(ctx, kafka.Message{
+		Key:   []byte(env.AggregateID.String()),
+		Value: value,
+	})
+	if err != nil {
+		return fmt.Errorf("kafka produce to %s: %w", topic, err)
+	}
+	return nil
+}
+
+// writer returns the writer of topic, creating it on first use.
+func (s *KafkaSink) writer(topic string) kafkaWriter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := s.writers[topic]
+	if !ok {
+		w = s.newWriter(topic)
+		s.writers[topic] = w
+	}
+	return w
+}
+
+// Close flushes and closes the writers.
+func (s *KafkaSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var firstErr error
+	for topic, w := range s.writers {
+		if err := w.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("close kafka writer for %s: %w", topic, err)
+		}
+		delete(s.writers, topic)
+	}
+	return firstErr
+}
+
+var _ events.Sink = (*KafkaSink)(nil)
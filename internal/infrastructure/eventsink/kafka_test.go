@@ -0,0 +1,114 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+
+	"metapus/internal/core/events"
+	"metapus/internal/core/id"
+)
+
+func TestTopicIsPerTenant(t *testing.T) {
+	env := events.Envelope{Type: "document.posted", TenantID: "acme corp", AggregateType: "document"}
+
+	if got := Topic("", env); got != "metapus.acme_corp.document.posted" {
+		t.Errorf("default topic = %q", got)
+	}
+	if got := Topic("erp-{aggregate}-{tenant}", env); got != "erp-document-acme_corp" {
+		t.Errorf("custom topic = %q", got)
+	}
+}
+
+// fakeKafkaWriter records the messages written to one topic.
+type fakeKafkaWriter struct {
+	err    error
+	msgs   []kafka.Message
+	closed bool
+}
+
+func (w *fakeKafkaWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.msgs = append(w.msgs, msgs...)
+	return nil
+}
+
+func (w *fakeKafkaWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+// newFakeKafkaSink returns a sink whose writers are fakes, by topic.
+func newFakeKafkaSink(t *testing.T, writeErr error) (*KafkaSink, map[string]*fakeKafkaWriter) {
+	t.Helper()
+	sink, err := NewKafkaSink(KafkaConfig{Brokers: []string{"kafka:9092"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writers := map[string]*fakeKafkaWriter{}
+	sink.newWriter = func(topic string) kafkaWriter {
+		w := &fakeKafkaWriter{err: writeErr}
+		writers[topic] = w
+		return w
+	}
+	return sink, writers
+}
+
+func TestKafkaSinkProducesRecord(t *testing.T) {
+	sink, writers := newFakeKafkaSink(t, nil)
+
+	env := events.Envelope{ID: id.New(), Type: "stock.moved", TenantID: "t1", AggregateID: id.New()}
+	for range 2 {
+		if err := sink.Deliver(context.Background(), env); err != nil {
+			t.Fatalf("Deliver: %v", err)
+		}
+	}
+	other := events.Envelope{ID: id.New(), Type: "stock.moved", TenantID: "t2", AggregateID: id.New()}
+	if err := sink.Deliver(context.Background(), other); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	if len(writers) != 2 {
+		t.Fatalf("writers = %v, want one per topic", writers)
+	}
+	w := writers["metapus.t1.stock.moved"]
+	if w == nil || len(w.msgs) != 2 {
+		t.Fatalf("writer of metapus.t1.stock.moved = %+v, want the writer reused", w)
+	}
+	var value events.Envelope
+	if err := json.Unmarshal(w.msgs[0].Value, &value); err != nil {
+		t.Fatalf("decode value: %v", err)
+	}
+	if string(w.msgs[0].Key) != env.AggregateID.String() || value.ID != env.ID {
+		t.Errorf("record = key %q, value %+v", w.msgs[0].Key, value)
+	}
+
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for topic, w := range writers {
+		if !w.closed {
+			t.Errorf("writer of %s not closed", topic)
+		}
+	}
+}
+
+func TestKafkaSinkReportsWriteError(t *testing.T) {
+	sink, _ := newFakeKafkaSink(t, errors.New("topic authorization failed"))
+
+	err := sink.Deliver(context.Background(), events.Envelope{Type: "document.posted"})
+	if err == nil {
+		t.Fatal("expected the write error to fail the delivery")
+	}
+}
+
+func TestNewKafkaSinkRequiresBrokers(t *testing.T) {
+	if _, err := NewKafkaSink(KafkaConfig{}); err == nil {
+		t.Fatal("expected an error without brokers")
+	}
+}
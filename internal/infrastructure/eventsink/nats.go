@@ -0,0 +1,127 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"metapus/internal/core/events"
+)
+
+// NATSConfig holds connection settings for a NATS server.
+type NATSConfig struct {
+	// URL is the server address: nats://[user:pass@]host:4222, or tls://
+	// for a TLS connection. A bare host:port is accepted. Several servers
+	// of a cluster are separated by commas.
+	URL string
+	// Token authenticates with a server auth token.
+	Token string
+	// SubjectTemplate names the subject of an event, see Topic.
+	SubjectTemplate string
+	// JetStream waits for the stream's publish acknowledgement and sets
+	// Nats-Msg-Id to the event ID, so the stream drops redelivered events.
+	// Without it the event counts as delivered once the server received it
+	// (core NATS has no persistence).
+	JetStream bool
+}
+
+// natsTimeout bounds connecting and each publish when ctx has no deadline.
+const natsTimeout = 10 * time.Second
+
+// NATSSink publishes events to NATS with the nats.go client. It keeps one
+// connection, which the client reconnects after network failures; a
+// connection the client gave up on is replaced on the next delivery.
+// Thread-safe.
+type NATSSink struct {
+	cfg NATSConfig
+
+	mu sync.Mutex
+	nc *nats.Conn
+	js jetstream.JetStream
+}
+
+// NewNATSSink creates a NATS sink. It does not connect until the first
+// delivery.
+func NewNATSSink(cfg NATSConfig) (*NATSSink, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("nats event sink: url is required")
+	}
+	return &NATSSink{cfg: cfg}, nil
+}
+
+// Name implements events.Sink.
+func (s *NATSSink) Name() string { return "nats" }
+
+// Deliver implements events.Sink.
+func (s *NATSSink) Deliver(ctx context.Context, env events.Envelope) error {
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+	subject := Topic(s.cfg.SubjectTemplate, env)
+
+	nc, js, err := s.conn()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, natsTimeout)
+		defer cancel()
+	}
+	if s.cfg.JetStream {
+		_, err = js.Publish(ctx, subject, payload, jetstream.WithMsgID(env.ID.String()))
+	} else if err = nc.Publish(subject, payload); err == nil {
+		// Core NATS: the round trip tells the server processed the publish.
+		err = nc.FlushWithContext(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("nats publish to %s: %w", subject, err)
+	}
+	return nil
+}
+
+// conn returns the connection, connecting if there is none or the client
+// closed it after running out of reconnect attempts.
+func (s *NATSSink) conn() (*nats.Conn, jetstream.JetStream, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.nc != nil && !s.nc.IsClosed() {
+		return s.nc, s.js, nil
+	}
+	opts := []nats.Option{nats.Name("metapus"), nats.Timeout(natsTimeout)}
+	if s.cfg.Token != "" {
+		opts = append(opts, nats.Token(s.cfg.Token))
+	}
+	nc, err := nats.Connect(s.cfg.URL, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("nats connect: %w", err)
+	}
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, nil, fmt.Errorf("nats jetstream: %w", err)
+	}
+	s.nc, s.js = nc, js
+	return nc, js, nil
+}
+
+// Close closes the connection.
+func (s *NATSSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.nc != nil {
+		s.nc.Close()
+		s.nc, s.js = nil, nil
+	}
+	return nil
+}
+
+var _ events.Sink = (*NATSSink)(nil)
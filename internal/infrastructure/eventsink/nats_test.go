@@ -0,0 +1,163 @@
+package eventsink
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"metapus/internal/core/events"
+	"metapus/internal/core/id"
+)
+
+// natsPublish is a message received by fakeNATS.
+type natsPublish struct {
+	subject, reply, headers, payload string
+}
+
+// fakeNATS is a minimal NATS server for the nats.go client: it answers
+// PING and records publishes, acknowledging them with ack on the client's
+// reply subscription when a reply subject is given.
+type fakeNATS struct {
+	ln    net.Listener
+	ack   string
+	got   chan natsPublish
+	conns atomic.Int32
+}
+
+func newFakeNATS(t *testing.T, ack string) *fakeNATS {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeNATS{ln: ln, ack: ack, got: make(chan natsPublish, 10)}
+	go f.serve()
+	t.Cleanup(func() { _ = ln.Close() })
+	return f
+}
+
+func (f *fakeNATS) serve() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		f.conns.Add(1)
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeNATS) handle(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	_, _ = io.WriteString(conn, `INFO {"server_id":"fake","version":"2.10.0","proto":1,"headers":true,"max_payload":1048576}`+"\r\n")
+	r := bufio.NewReader(conn)
+	sid := ""
+	for {
+		line, err := readLine(r)
+		if err != nil {
+			return
+		}
+		op, args, _ := strings.Cut(line, " ")
+		fields := strings.Fields(args)
+		switch op {
+		case "PING":
+			_, _ = io.WriteString(conn, "PONG\r\n")
+		case "SUB":
+			sid = fields[1]
+		case "PUB", "HPUB":
+			total, _ := strconv.Atoi(fields[len(fields)-1])
+			hdrLen := 0
+			if op == "HPUB" {
+				hdrLen, _ = strconv.Atoi(fields[len(fields)-2])
+			}
+			data := make([]byte, total+2)
+			if _, err := io.ReadFull(r, data); err != nil {
+				return
+			}
+			msg := natsPublish{subject: fields[0], headers: string(data[:hdrLen]), payload: string(data[hdrLen:total])}
+			if (op == "PUB" && len(fields) == 3) || (op == "HPUB" && len(fields) == 4) {
+				msg.reply = fields[1]
+			}
+			f.got <- msg
+			if msg.reply != "" {
+				_, _ = fmt.Fprintf(conn, "MSG %s %s %d\r\n%s\r\n", msg.reply, sid, len(f.ack), f.ack)
+			}
+		}
+	}
+}
+
+func TestNATSSinkPublishesWithJetStreamAck(t *testing.T) {
+	srv := newFakeNATS(t, `{"stream":"EVENTS","seq":7}`)
+	sink, err := NewNATSSink(NATSConfig{URL: srv.ln.Addr().String(), JetStream: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = sink.Close() }()
+
+	env := events.Envelope{ID: id.New(), Type: "document.posted", TenantID: "t1"}
+	for range 2 {
+		if err := sink.Deliver(context.Background(), env); err != nil {
+			t.Fatalf("Deliver: %v", err)
+		}
+	}
+
+	msg := <-srv.got
+	if msg.subject != "metapus.t1.document.posted" || msg.reply == "" {
+		t.Errorf("publish = %+v", msg)
+	}
+	if !strings.Contains(msg.headers, "Nats-Msg-Id: "+env.ID.String()) {
+		t.Errorf("headers = %q, want the event ID as message ID", msg.headers)
+	}
+	if !strings.Contains(msg.payload, env.ID.String()) {
+		t.Errorf("payload = %q", msg.payload)
+	}
+	if n := srv.conns.Load(); n != 1 {
+		t.Errorf("connections = %d, want the connection reused", n)
+	}
+}
+
+func TestNATSSinkReportsJetStreamError(t *testing.T) {
+	srv := newFakeNATS(t, `{"error":{"code":503,"description":"stream offline"}}`)
+	sink, err := NewNATSSink(NATSConfig{URL: "nats://" + srv.ln.Addr().String(), JetStream: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = sink.Close() }()
+
+	err = sink.Deliver(context.Background(), events.Envelope{Type: "document.posted"})
+	if err == nil || !strings.Contains(err.Error(), "stream offline") {
+		t.Fatalf("err = %v, want the JetStream error", err)
+	}
+}
+
+func TestNATSSinkCorePublish(t *testing.T) {
+	srv := newFakeNATS(t, "")
+	sink, err := NewNATSSink(NATSConfig{URL: srv.ln.Addr().String(), SubjectTemplate: "erp.{tenant}.{aggregate}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = sink.Close() }()
+
+	env := events.Envelope{Type: "stock.moved", TenantID: "t1", AggregateType: "document"}
+	if err := sink.Deliver(context.Background(), env); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+	if msg := <-srv.got; msg.subject != "erp.t1.document" || msg.reply != "" {
+		t.Errorf("publish = %+v", msg)
+	}
+}
+
+// readLine reads a protocol line without the trailing CRLF.
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
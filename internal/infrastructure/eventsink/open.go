@@ -0,0 +1,34 @@
+package eventsink
+
+import (
+	"fmt"
+
+	"metapus/internal/core/events"
+)
+
+// Sink kinds accepted by Open.
+const (
+	KindLog   = "log"
+	KindKafka = "kafka"
+	KindNATS  = "nats"
+)
+
+// Config configures the broker sinks.
+type Config struct {
+	Kafka KafkaConfig
+	NATS  NATSConfig
+}
+
+// Open creates the sink of the given kind.
+func Open(kind string, cfg Config) (events.Sink, error) {
+	switch kind {
+	case KindLog:
+		return events.LogSink{}, nil
+	case KindKafka:
+		return NewKafkaSink(cfg.Kafka)
+	case KindNATS:
+		return NewNATSSink(cfg.NATS)
+	default:
+		return nil, fmt.Errorf("unknown event sink %q", kind)
+	}
+}
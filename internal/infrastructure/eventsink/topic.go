@@ -0,0 +1,48 @@
+// Package eventsink provides events.Sink backends that stream domain events
+// to message brokers: Kafka (kafka-go) and NATS (nats.go, optionally
+// JetStream).
+package eventsink
+
+import (
+	"strings"
+
+	"metapus/internal/core/events"
+)
+
+// DefaultTopicTemplate names a topic (Kafka) or subject (NATS) per tenant
+// and event type, e.g. metapus.acme.document.posted.
+const DefaultTopicTemplate = "metapus.{tenant}.{type}"
+
+// Topic expands a topic template for env. Placeholders: {tenant} (tenant
+// ID), {type} (event type) and {aggregate} (aggregate type). Characters
+// brokers do not accept in names are replaced with "_"; the dots of the
+// event type are kept so NATS subscribers can use wildcards per type.
+func Topic(template string, env events.Envelope) string {
+	if template == "" {
+		template = DefaultTopicTemplate
+	}
+	tenantID := env.TenantID
+	if tenantID == "" {
+		tenantID = "_"
+	}
+	return strings.NewReplacer(
+		"{tenant}", sanitize(tenantID, false),
+		"{type}", sanitize(env.Type, true),
+		"{aggregate}", sanitize(env.AggregateType, false),
+	).Replace(template)
+}
+
+// sanitize keeps the characters valid in both Kafka topic names and NATS
+// subject tokens: letters, digits, "_" and "-" (and "." if keepDots).
+func sanitize(s string, keepDots bool) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			return r
+		case r == '.' && keepDots:
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
@@ -0,0 +1,126 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/infrastructure/grpcapi/integrationv1"
+	appmeta "metapus/internal/metadata"
+)
+
+// Page size of ListCatalogItems.
+const (
+	_defaultCatalogLimit = 100
+	_maxCatalogLimit     = 1000
+)
+
+// CatalogReader reads catalog rows as column → value maps.
+// Implemented by postgres.CatalogRowReader.
+type CatalogReader interface {
+	Rows(ctx context.Context, table string, ids []id.ID, includeDeleted bool, limit, offset int) ([]map[string]any, error)
+}
+
+// CatalogServer implements integrationv1.CatalogServiceServer for every
+// catalog of the metadata registry.
+type CatalogServer struct {
+	integrationv1.UnimplementedCatalogServiceServer
+
+	tables map[string]string // catalog key → table
+	reader CatalogReader
+}
+
+// NewCatalogServer creates a catalog service over the catalogs of reg.
+func NewCatalogServer(reg *appmeta.Registry, reader CatalogReader) *CatalogServer {
+	tables := map[string]string{}
+	for _, def := range reg.List() {
+		if def.Type == appmeta.TypeCatalog && def.Key != "" && def.TableName != "" {
+			tables[def.Key] = def.TableName
+		}
+	}
+	return &CatalogServer{tables: tables, reader: reader}
+}
+
+// GetCatalogItem returns one catalog item, including one marked for deletion.
+func (s *CatalogServer) GetCatalogItem(ctx context.Context, req *integrationv1.GetCatalogItemRequest) (*integrationv1.CatalogItem, error) {
+	table, err := s.table(req.GetCatalog())
+	if err != nil {
+		return nil, err
+	}
+	itemID, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.reader.Rows(ctx, table, []id.ID{itemID}, true, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, apperror.NewNotFound(req.GetCatalog(), itemID)
+	}
+	return catalogItem(rows[0])
+}
+
+// ListCatalogItems returns a page of catalog items ordered by name.
+func (s *CatalogServer) ListCatalogItems(ctx context.Context, req *integrationv1.ListCatalogItemsRequest) (*integrationv1.ListCatalogItemsResponse, error) {
+	table, err := s.table(req.GetCatalog())
+	if err != nil {
+		return nil, err
+	}
+	var ids []id.ID
+	for _, raw := range req.GetIds() {
+		itemID, err := parseID("ids", raw)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, itemID)
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = _defaultCatalogLimit
+	}
+	limit = min(limit, _maxCatalogLimit)
+
+	rows, err := s.reader.Rows(ctx, table, ids, req.GetIncludeDeleted(), limit, max(int(req.GetOffset()), 0))
+	if err != nil {
+		return nil, err
+	}
+	resp := &integrationv1.ListCatalogItemsResponse{Items: make([]*integrationv1.CatalogItem, 0, len(rows))}
+	for _, row := range rows {
+		item, err := catalogItem(row)
+		if err != nil {
+			return nil, err
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp, nil
+}
+
+func (s *CatalogServer) table(catalog string) (string, error) {
+	table, ok := s.tables[catalog]
+	if !ok {
+		return "", apperror.NewNotFound("catalog", catalog)
+	}
+	return table, nil
+}
+
+func catalogItem(row map[string]any) (*integrationv1.CatalogItem, error) {
+	fields, err := structpb.NewStruct(row)
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("convert catalog row: %w", err))
+	}
+	itemID, _ := row["id"].(string)
+	return &integrationv1.CatalogItem{Id: itemID, Fields: fields}, nil
+}
+
+func parseID(field, raw string) (id.ID, error) {
+	v, err := id.Parse(raw)
+	if err != nil {
+		return id.ID{}, apperror.NewValidation("invalid "+field+" format").WithDetail("field", field)
+	}
+	return v, nil
+}
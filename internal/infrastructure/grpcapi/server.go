@@ -0,0 +1,181 @@
+// Package grpcapi serves the integration gRPC API (see integrationv1) for
+// internal services. Requests are resolved to a tenant database the same way
+// as HTTP requests: the tenant from the "x-tenant-id" metadata header, a
+// TxManager for its pool in the context.
+package grpcapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/clock"
+	"metapus/internal/core/tenant"
+	"metapus/internal/infrastructure/grpcapi/integrationv1"
+	"metapus/internal/infrastructure/storage/postgres"
+	appmeta "metapus/internal/metadata"
+	"metapus/pkg/logger"
+)
+
+// TenantHeader is the metadata key carrying the tenant ID
+// (the gRPC counterpart of the X-Tenant-ID HTTP header).
+const TenantHeader = "x-tenant-id"
+
+// Config configures the integration gRPC server.
+type Config struct {
+	TenantManager *tenant.Manager
+
+	// APIKey is the shared key internal services send as
+	// "authorization: Bearer <key>". Required.
+	APIKey string
+
+	// Registry lists the catalogs served by CatalogService.
+	Registry *appmeta.Registry
+
+	Catalogs CatalogReader
+	Stock    StockReader
+}
+
+// NewServer creates the gRPC server with the integration services registered.
+func NewServer(cfg Config) *grpc.Server {
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		errorInterceptor,
+		authInterceptor(cfg.APIKey),
+		tenantInterceptor(cfg.TenantManager),
+	))
+	integrationv1.RegisterCatalogServiceServer(s, NewCatalogServer(cfg.Registry, cfg.Catalogs))
+	integrationv1.RegisterStockServiceServer(s, NewStockServer(cfg.Stock))
+	return s
+}
+
+// errorInterceptor converts application errors to gRPC statuses and panics
+// to Internal, so a failing handler never takes the server down.
+func errorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(ctx, "grpc handler panic", "method", info.FullMethod, "panic", r, "stack", string(debug.Stack()))
+			resp, err = nil, status.Error(codes.Internal, "internal error")
+		}
+	}()
+
+	resp, err = handler(ctx, req)
+	if err != nil {
+		err = toStatus(ctx, info.FullMethod, err)
+	}
+	return resp, err
+}
+
+// toStatus maps an error to a gRPC status by its HTTP status. Internal
+// errors are logged and returned without details, as over HTTP.
+func toStatus(ctx context.Context, method string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, tenant.ErrTenantBusy) {
+		err = apperror.NewTenantBusy(time.Second)
+	}
+	appErr, ok := apperror.AsAppError(err)
+	if !ok {
+		logger.Error(ctx, "grpc request failed", "method", method, "error", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	code := codes.Internal
+	switch appErr.HTTPStatus {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.Aborted
+	case http.StatusUnprocessableEntity:
+		code = codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	case http.StatusMisdirectedRequest, http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	}
+	if code == codes.Internal {
+		logger.Error(ctx, "grpc request failed", "method", method, "error", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+	return status.Error(code, appErr.Message)
+}
+
+// authInterceptor checks the shared integration key.
+func authInterceptor(apiKey string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		token, ok := strings.CutPrefix(firstHeader(ctx, "authorization"), "Bearer ")
+		if !ok || apiKey == "" || subtle.ConstantTimeCompare([]byte(token), []byte(apiKey)) != 1 {
+			return nil, apperror.NewUnauthorized("invalid integration key")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// tenantInterceptor resolves the tenant database, like middleware.TenantDB.
+func tenantInterceptor(manager *tenant.Manager) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		rawTenantID := firstHeader(ctx, TenantHeader)
+		if rawTenantID == "" {
+			return nil, apperror.NewValidation("tenant is required").WithDetail("header", TenantHeader)
+		}
+		tenantUUID, err := uuid.Parse(rawTenantID)
+		if err != nil {
+			return nil, apperror.NewValidation("invalid tenant id").WithDetail("header", TenantHeader)
+		}
+		tenantID := tenantUUID.String()
+
+		managedPool, err := manager.GetPool(ctx, tenantID)
+		if err != nil {
+			logger.Warn(ctx, "tenant pool error", "tenant_id", tenantID, "error", err)
+			switch {
+			case errors.Is(err, tenant.ErrTenantNotFound):
+				return nil, apperror.NewNotFound("tenant", tenantID)
+			case errors.Is(err, tenant.ErrTenantNotActive):
+				return nil, apperror.NewForbidden("tenant is not active")
+			case errors.Is(err, tenant.ErrTenantVersionMismatch), errors.Is(err, tenant.ErrMaxPoolLimit):
+				return nil, status.Error(codes.Unavailable, "service temporarily unavailable")
+			default:
+				return nil, apperror.NewInternal(err)
+			}
+		}
+
+		if managedPool.Shed() {
+			return nil, apperror.NewTenantBusy(time.Second)
+		}
+
+		// Track active request for graceful shutdown
+		managedPool.AcquireRef()
+		defer managedPool.ReleaseRef()
+
+		ctx = tenant.WithPool(ctx, managedPool.Pool())
+		ctx = tenant.WithTxManager(ctx, postgres.NewTxManagerFromAcquirer(managedPool))
+		ctx = tenant.WithTenant(ctx, managedPool.Tenant())
+		ctx = clock.WithClock(ctx, managedPool.Tenant().Clock())
+		return handler(ctx, req)
+	}
+}
+
+func firstHeader(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
@@ -0,0 +1,141 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/infrastructure/grpcapi/integrationv1"
+	appmeta "metapus/internal/metadata"
+)
+
+// fakeCatalogs serves fixed rows and records the last query.
+type fakeCatalogs struct {
+	rows           []map[string]any
+	table          string
+	ids            []id.ID
+	includeDeleted bool
+	limit          int
+}
+
+func (f *fakeCatalogs) Rows(_ context.Context, table string, ids []id.ID, includeDeleted bool, limit, _ int) ([]map[string]any, error) {
+	f.table, f.ids, f.includeDeleted, f.limit = table, ids, includeDeleted, limit
+	return f.rows, nil
+}
+
+type fakeStock struct{ balances []entity.StockBalance }
+
+func (f *fakeStock) GetWarehouseStock(context.Context, id.ID) ([]entity.StockBalance, error) {
+	return f.balances, nil
+}
+
+func (f *fakeStock) GetAvailableQuantity(context.Context, id.ID, id.ID) (types.Quantity, error) {
+	return 0, apperror.NewInternal(context.DeadlineExceeded)
+}
+
+// dial serves the integration services without tenant resolution over an
+// in-memory connection.
+func dial(t *testing.T, catalogs CatalogReader, stock StockReader) *grpc.ClientConn {
+	t.Helper()
+	reg := appmeta.NewRegistry()
+	reg.Register(appmeta.EntityDef{Name: "Warehouse", Key: "warehouse", Type: appmeta.TypeCatalog, TableName: "cat_warehouses"})
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(errorInterceptor, authInterceptor("secret")))
+	integrationv1.RegisterCatalogServiceServer(s, NewCatalogServer(reg, catalogs))
+	integrationv1.RegisterStockServiceServer(s, NewStockServer(stock))
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func withKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+key)
+}
+
+func TestCatalogServiceOverGRPC(t *testing.T) {
+	itemID := id.New()
+	catalogs := &fakeCatalogs{rows: []map[string]any{{"id": itemID.String(), "name": "Main", "allow_negative_stock": false}}}
+	client := integrationv1.NewCatalogServiceClient(dial(t, catalogs, &fakeStock{}))
+
+	_, err := client.GetCatalogItem(withKey("wrong"), &integrationv1.GetCatalogItemRequest{Catalog: "warehouse", Id: itemID.String()})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("wrong key: %v", err)
+	}
+
+	item, err := client.GetCatalogItem(withKey("secret"), &integrationv1.GetCatalogItemRequest{Catalog: "warehouse", Id: itemID.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.GetId() != itemID.String() || item.GetFields().AsMap()["name"] != "Main" {
+		t.Errorf("item = %v", item)
+	}
+	if catalogs.table != "cat_warehouses" || len(catalogs.ids) != 1 || !catalogs.includeDeleted {
+		t.Errorf("query = %+v", catalogs)
+	}
+
+	if _, err := client.ListCatalogItems(withKey("secret"), &integrationv1.ListCatalogItemsRequest{Catalog: "warehouse", Limit: 5000}); err != nil {
+		t.Fatal(err)
+	}
+	if catalogs.limit != _maxCatalogLimit || catalogs.includeDeleted {
+		t.Errorf("list query = %+v", catalogs)
+	}
+
+	_, err = client.GetCatalogItem(withKey("secret"), &integrationv1.GetCatalogItemRequest{Catalog: "goods_receipt", Id: itemID.String()})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("unknown catalog: %v", err)
+	}
+	_, err = client.GetCatalogItem(withKey("secret"), &integrationv1.GetCatalogItemRequest{Catalog: "warehouse", Id: "42"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid id: %v", err)
+	}
+}
+
+func TestStockServiceOverGRPC(t *testing.T) {
+	warehouseID, productID := id.New(), id.New()
+	moved := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	stock := &fakeStock{balances: []entity.StockBalance{
+		{WarehouseID: warehouseID, NomenclatureID: productID, Quantity: types.NewQuantityFromFloat64(12.5), LastMovementAt: moved},
+	}}
+	client := integrationv1.NewStockServiceClient(dial(t, &fakeCatalogs{}, stock))
+
+	resp, err := client.GetWarehouseStock(withKey("secret"), &integrationv1.GetWarehouseStockRequest{WarehouseId: warehouseID.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetBalances()) != 1 {
+		t.Fatalf("balances = %v", resp.GetBalances())
+	}
+	b := resp.GetBalances()[0]
+	if b.GetNomenclatureId() != productID.String() || b.GetQuantity() != "12.5000" || !b.GetLastMovementAt().AsTime().Equal(moved) {
+		t.Errorf("balance = %v", b)
+	}
+
+	// Internal errors reach the client without their cause.
+	_, err = client.GetAvailableQuantity(withKey("secret"), &integrationv1.GetAvailableQuantityRequest{
+		WarehouseId: warehouseID.String(), NomenclatureId: productID.String(),
+	})
+	if st, _ := status.FromError(err); st.Code() != codes.Internal || st.Message() != "internal error" {
+		t.Errorf("internal error: %v", err)
+	}
+}
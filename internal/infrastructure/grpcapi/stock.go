@@ -0,0 +1,71 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/infrastructure/grpcapi/integrationv1"
+)
+
+// StockReader reads the stock register. Implemented by stock.Service.
+type StockReader interface {
+	GetWarehouseStock(ctx context.Context, warehouseID id.ID) ([]entity.StockBalance, error)
+	GetAvailableQuantity(ctx context.Context, warehouseID, nomenclatureID id.ID) (types.Quantity, error)
+}
+
+// StockServer implements integrationv1.StockServiceServer.
+type StockServer struct {
+	integrationv1.UnimplementedStockServiceServer
+
+	stock StockReader
+}
+
+// NewStockServer creates a stock service.
+func NewStockServer(stock StockReader) *StockServer {
+	return &StockServer{stock: stock}
+}
+
+// GetWarehouseStock returns the non-zero balances of a warehouse.
+func (s *StockServer) GetWarehouseStock(ctx context.Context, req *integrationv1.GetWarehouseStockRequest) (*integrationv1.GetWarehouseStockResponse, error) {
+	warehouseID, err := parseID("warehouse_id", req.GetWarehouseId())
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := s.stock.GetWarehouseStock(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &integrationv1.GetWarehouseStockResponse{Balances: make([]*integrationv1.StockBalance, len(balances))}
+	for i, b := range balances {
+		resp.Balances[i] = &integrationv1.StockBalance{
+			WarehouseId:    b.WarehouseID.String(),
+			NomenclatureId: b.NomenclatureID.String(),
+			Quantity:       b.Quantity.String(),
+			LastMovementAt: timestamppb.New(b.LastMovementAt),
+		}
+	}
+	return resp, nil
+}
+
+// GetAvailableQuantity returns the unreserved stock of a product in a warehouse.
+func (s *StockServer) GetAvailableQuantity(ctx context.Context, req *integrationv1.GetAvailableQuantityRequest) (*integrationv1.GetAvailableQuantityResponse, error) {
+	warehouseID, err := parseID("warehouse_id", req.GetWarehouseId())
+	if err != nil {
+		return nil, err
+	}
+	nomenclatureID, err := parseID("nomenclature_id", req.GetNomenclatureId())
+	if err != nil {
+		return nil, err
+	}
+
+	qty, err := s.stock.GetAvailableQuantity(ctx, warehouseID, nomenclatureID)
+	if err != nil {
+		return nil, err
+	}
+	return &integrationv1.GetAvailableQuantityResponse{Quantity: qty.String()}, nil
+}
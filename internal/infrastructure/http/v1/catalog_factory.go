@@ -5,9 +5,11 @@ package v1
 
 import (
 	"metapus/internal/core/eventlog"
+	"metapus/internal/core/events"
 	"metapus/internal/core/numerator"
 	"metapus/internal/core/security"
 	"metapus/internal/domain"
+	"metapus/internal/domain/catalogs/counterparty"
 	"metapus/internal/infrastructure/http/v1/handlers"
 )
 
@@ -16,8 +18,10 @@ type CatalogDeps struct {
 	BaseHandler              *handlers.BaseHandler
 	Numerator                numerator.Generator
 	PolicyEngine             *security.PolicyEngine
-	EventWriter              eventlog.Writer                // optional — nil disables event logging
+	EventWriter              eventlog.Writer                 // optional — nil disables event logging
 	CurrencyCacheInvalidator domain.CurrencyCacheInvalidator // optional — nil when no currency caching
+	EventPublisher           events.Publisher                // optional — nil disables domain events
+	RequisitesProvider       counterparty.RequisitesProvider // optional — nil disables requisites lookup by INN
 }
 
 // CatalogRegistration is the Abstract Factory interface for catalog types.
@@ -0,0 +1,152 @@
+// Package v1 provides HTTP API version 1.
+// document_creator.go — Document creation outside of HTTP requests.
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/numerator"
+	"metapus/internal/domain/documents"
+	"metapus/internal/domain/modules"
+	"metapus/internal/domain/printing"
+	"metapus/internal/domain/recurring"
+	"metapus/internal/domain/registers/exchange_rate"
+	"metapus/internal/domain/reposting"
+	"metapus/internal/infrastructure/http/v1/handlers"
+	"metapus/internal/infrastructure/storage/postgres"
+	"metapus/internal/infrastructure/storage/postgres/catalog_repo"
+	"metapus/internal/infrastructure/storage/postgres/register_repo"
+	"metapus/internal/platform"
+)
+
+// templatePayloadCreator is implemented by document handlers that can create
+// a document from a template payload (see BaseDocumentHandler).
+type templatePayloadCreator interface {
+	CreateFromTemplatePayload(ctx context.Context, payload json.RawMessage, date time.Time,
+		quantities []json.RawMessage, post bool) (id.ID, error)
+}
+
+// documentReposter is implemented by document handlers that can re-post a
+// document outside of an HTTP request (see BaseDocumentHandler.Repost).
+type documentReposter interface {
+	Repost(ctx context.Context, docID id.ID) error
+}
+
+// DocumentCreatorConfig configures a DocumentCreator.
+type DocumentCreatorConfig struct {
+	Registry  *FactoryRegistry
+	Numerator numerator.Generator
+
+	// Modules refuses documents of modules disabled for the tenant (optional).
+	Modules *modules.Resolver
+}
+
+// DocumentCreator creates documents from template payloads outside of HTTP
+// requests (recurring schedules executed by the worker) and re-posts them
+// (the re-posting tool). Every registered document type is built by its
+// DocumentRegistration with the same hooks, numbering and posting pipeline
+// as the API.
+type DocumentCreator struct {
+	creators  map[string]templatePayloadCreator // entity key (goods_receipt) → handler
+	reposters map[string]documentReposter       // entity key → handler
+	modules   map[string]string                 // entity key → module (module members only)
+	resolver  *modules.Resolver
+}
+
+// NewDocumentCreator builds all registered document types.
+func NewDocumentCreator(cfg DocumentCreatorConfig) *DocumentCreator {
+	postingEngine := newDocumentPostingEngine(nil)
+	deps := DocumentDeps{
+		BaseHandler:   handlers.NewBaseHandler(),
+		PostingEngine: postingEngine,
+		Numerator:     cfg.Numerator,
+		CurrencyResolver: documents.NewCurrencyResolver(
+			catalog_repo.NewContractRepo(), catalog_repo.NewOrganizationRepo(), catalog_repo.NewCurrencyRepo(),
+		),
+		EventWriter:       postgres.NewEventLogRepo(),
+		OutboxPublisher:   postgres.NewOutboxPublisher(),
+		PrintRegistry:     printing.NewPrintFormRegistry(),
+		MovementProviders: postingEngine.MovementProviders(),
+		SettingsRepo:      postgres.NewSettingsRepo(),
+		CurrencyConverter: newCurrencyConverter(),
+	}
+
+	creators := make(map[string]templatePayloadCreator)
+	reposters := make(map[string]documentReposter)
+	moduleOf := make(map[string]string)
+	for _, factory := range cfg.Registry.Documents() {
+		key := deriveEntityKey(factory.Permission())
+		handler := factory.Build(deps)
+		if c, ok := handler.(templatePayloadCreator); ok {
+			creators[key] = c
+		}
+		if r, ok := handler.(documentReposter); ok {
+			reposters[key] = r
+		}
+		if mm, ok := factory.(platform.ModuleMember); ok {
+			moduleOf[key] = mm.Module()
+		}
+	}
+	return &DocumentCreator{creators: creators, reposters: reposters, modules: moduleOf, resolver: cfg.Modules}
+}
+
+// newCurrencyConverter builds the base-currency converter over the exchange
+// rates register and the currency catalog.
+func newCurrencyConverter() *exchange_rate.Converter {
+	return exchange_rate.NewConverter(register_repo.NewExchangeRateRepo(), catalog_repo.NewCurrencyRepo())
+}
+
+// CreateFromTemplate implements recurring.DocumentCreator.
+func (d *DocumentCreator) CreateFromTemplate(ctx context.Context, documentType string, payload json.RawMessage,
+	date time.Time, quantities []json.RawMessage, post bool) (uuid.UUID, error) {
+	c, ok := d.creators[documentType]
+	if !ok {
+		return uuid.Nil, apperror.NewInternal(fmt.Errorf("document type %q cannot be created from a template", documentType))
+	}
+	if err := d.checkModule(ctx, documentType); err != nil {
+		return uuid.Nil, err
+	}
+	return c.CreateFromTemplatePayload(ctx, payload, date, quantities, post)
+}
+
+// Repost implements reposting.Reposter.
+func (d *DocumentCreator) Repost(ctx context.Context, documentType string, docID id.ID) error {
+	r, ok := d.reposters[documentType]
+	if !ok {
+		return apperror.NewInternal(fmt.Errorf("document type %q cannot be re-posted", documentType))
+	}
+	if err := d.checkModule(ctx, documentType); err != nil {
+		return err
+	}
+	return r.Repost(ctx, docID)
+}
+
+// checkModule refuses document types of modules disabled for the tenant.
+func (d *DocumentCreator) checkModule(ctx context.Context, documentType string) error {
+	module, ok := d.modules[documentType]
+	if !ok || d.resolver == nil {
+		return nil
+	}
+	st, err := d.resolver.Status(ctx, module)
+	if err != nil {
+		return err
+	}
+	if !st.Enabled {
+		return apperror.NewForbidden(fmt.Sprintf("module %q is disabled", module)).
+			WithDetail("module", module)
+	}
+	return nil
+}
+
+// Ensure interface compliance.
+var (
+	_ recurring.DocumentCreator = (*DocumentCreator)(nil)
+	_ reposting.Reposter        = (*DocumentCreator)(nil)
+)
@@ -6,9 +6,11 @@ package v1
 import (
 	"metapus/internal/core/entity"
 	"metapus/internal/core/eventlog"
+	"metapus/internal/core/events"
 	"metapus/internal/core/numerator"
 	"metapus/internal/core/security"
 	"metapus/internal/domain"
+	"metapus/internal/domain/notifications"
 	"metapus/internal/domain/posting"
 	"metapus/internal/domain/printing"
 	"metapus/internal/domain/settings"
@@ -22,9 +24,11 @@ type DocumentDeps struct {
 	Numerator        numerator.Generator
 	CurrencyResolver domain.CurrencyResolveStrategy
 	CurrencyMetadataResolver domain.CurrencyMetadataResolver // Added for automation outbox
+	CurrencyConverter domain.BaseCurrencyConverter // optional — nil leaves base-currency totals untouched
 	PolicyEngine     *security.PolicyEngine
 	EventWriter      eventlog.Writer // optional — nil disables event logging
 	OutboxPublisher  domain.OutboxPublisher // optional — nil disables outbox events
+	EventPublisher   events.Publisher       // optional — nil disables domain events
 	PrintRegistry    *printing.PrintFormRegistry
 	PrintRenderer    *printing.Renderer      // nil disables print route
 	RelatedDocFinder domain.RelatedDocFinder // optional — nil disables related documents route
@@ -36,6 +40,12 @@ type DocumentDeps struct {
 	// SettingsRepo provides tenant-level settings (batch concurrency, etc.).
 	// If nil, default values are used in handlers.
 	SettingsRepo settings.Repository
+
+	// NotificationEmails queues notification emails (optional).
+	NotificationEmails *notifications.Emails
+
+	// NotificationInbox creates in-app notifications (optional).
+	NotificationInbox *notifications.Inbox
 }
 
 // DocumentRegistration is the Abstract Factory interface for document types.
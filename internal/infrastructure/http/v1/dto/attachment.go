@@ -0,0 +1,128 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/domain/attachment"
+)
+
+// AttachmentResponse is the response DTO for an attached file.
+type AttachmentResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	FileName    string     `json:"fileName"`
+	ContentType string     `json:"contentType"`
+	Size        int64      `json:"size"`
+	Checksum    string     `json:"checksum"`
+	UploadedBy  *uuid.UUID `json:"uploadedBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// MapAttachmentResponse converts a domain Attachment to a response DTO.
+func MapAttachmentResponse(a *attachment.Attachment) *AttachmentResponse {
+	return &AttachmentResponse{
+		ID:          a.ID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		UploadedBy:  a.UploadedBy,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+// MapAttachmentListResponse converts a list of attachments to response DTOs.
+func MapAttachmentListResponse(list []*attachment.Attachment) []*AttachmentResponse {
+	result := make([]*AttachmentResponse, len(list))
+	for i, a := range list {
+		result[i] = MapAttachmentResponse(a)
+	}
+	return result
+}
+
+// UploadFileRequest declares a file of an upload session.
+type UploadFileRequest struct {
+	FileName    string `json:"fileName" binding:"required"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size" binding:"required"`
+}
+
+// UploadSessionRequest declares a batch of files of an upload session.
+type UploadSessionRequest struct {
+	Files []UploadFileRequest `json:"files" binding:"required,dive"`
+}
+
+// ToFileSpecs converts the request to domain file specs.
+func (r *UploadSessionRequest) ToFileSpecs() []attachment.FileSpec {
+	specs := make([]attachment.FileSpec, len(r.Files))
+	for i, f := range r.Files {
+		specs[i] = attachment.FileSpec{FileName: f.FileName, ContentType: f.ContentType, Size: f.Size}
+	}
+	return specs
+}
+
+// UploadSessionFileResponse is a declared file, with its upload URL when
+// just issued.
+type UploadSessionFileResponse struct {
+	ID          uuid.UUID         `json:"id"`
+	FileName    string            `json:"fileName"`
+	ContentType string            `json:"contentType"`
+	Size        int64             `json:"size"`
+	Method      string            `json:"method,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+}
+
+// UploadSessionResponse is the response DTO for an upload session.
+type UploadSessionResponse struct {
+	ID          uuid.UUID                    `json:"id"`
+	Status      attachment.SessionStatus     `json:"status"`
+	ExpiresAt   time.Time                    `json:"expiresAt"`
+	FinalizedAt *time.Time                   `json:"finalizedAt,omitempty"`
+	CreatedAt   time.Time                    `json:"createdAt"`
+	Files       []*UploadSessionFileResponse `json:"files"`
+}
+
+func mapUploadSession(s *attachment.UploadSession, files []*UploadSessionFileResponse) *UploadSessionResponse {
+	return &UploadSessionResponse{
+		ID:          s.ID,
+		Status:      s.Status,
+		ExpiresAt:   s.ExpiresAt,
+		FinalizedAt: s.FinalizedAt,
+		CreatedAt:   s.CreatedAt,
+		Files:       files,
+	}
+}
+
+func mapUploadSessionFile(f *attachment.SessionFile) *UploadSessionFileResponse {
+	return &UploadSessionFileResponse{
+		ID:          f.ID,
+		FileName:    f.FileName,
+		ContentType: f.ContentType,
+		Size:        f.Size,
+	}
+}
+
+// MapUploadSessionTargets converts a session and newly issued upload targets
+// to a response DTO. Files lists only the files of the targets.
+func MapUploadSessionTargets(s *attachment.UploadSession, targets []attachment.UploadTarget) *UploadSessionResponse {
+	files := make([]*UploadSessionFileResponse, len(targets))
+	for i, t := range targets {
+		files[i] = mapUploadSessionFile(t.File)
+		files[i].Method = t.Method
+		files[i].URL = t.URL
+		files[i].Headers = t.Headers
+	}
+	return mapUploadSession(s, files)
+}
+
+// MapUploadSessionResponse converts a session with all its declared files
+// to a response DTO.
+func MapUploadSessionResponse(s *attachment.UploadSession, list []*attachment.SessionFile) *UploadSessionResponse {
+	files := make([]*UploadSessionFileResponse, len(list))
+	for i, f := range list {
+		files[i] = mapUploadSessionFile(f)
+	}
+	return mapUploadSession(s, files)
+}
@@ -46,6 +46,17 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refreshToken" binding:"required"`
 }
 
+// ChangeEmailRequest starts the change-email flow.
+type ChangeEmailRequest struct {
+	Password string `json:"password" binding:"required"`
+	NewEmail string `json:"newEmail" binding:"required,email"`
+}
+
+// ConfirmEmailChangeRequest completes the change-email flow.
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
 // AssignRoleRequest for assigning role to user.
 type AssignRoleRequest struct {
 	UserID   string `json:"userId" binding:"required,uuid"`
@@ -70,6 +81,12 @@ type SetRolePermissionsRequest struct {
 	PermissionIDs []string `json:"permissionIds" binding:"required"`
 }
 
+// SetPermissionScopeRequest limits a role permission to a scope:
+// dimension -> allowed IDs, e.g. {"warehouse": ["..."]}. Empty = unrestricted.
+type SetPermissionScopeRequest struct {
+	Scope map[string][]string `json:"scope"`
+}
+
 // UpdateUserRequest for admin user update.
 type UpdateUserRequest struct {
 	FirstName *string `json:"firstName"`
@@ -115,6 +132,34 @@ func FromTokenPair(tp *auth.TokenPair) *TokenResponse {
 	}
 }
 
+// SessionResponse represents an active session of the current user.
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	UserAgent  string    `json:"userAgent,omitempty"`
+	IPAddress  string    `json:"ipAddress,omitempty"`
+	Current    bool      `json:"current"`
+}
+
+// FromDeviceSessions creates responses from domain sessions.
+func FromDeviceSessions(sessions []auth.DeviceSession) []SessionResponse {
+	out := make([]SessionResponse, len(sessions))
+	for i, s := range sessions {
+		out[i] = SessionResponse{
+			ID:         s.ID.String(),
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			ExpiresAt:  s.ExpiresAt,
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IPAddress,
+			Current:    s.Current,
+		}
+	}
+	return out
+}
+
 // UserResponse represents user in API response.
 type UserResponse struct {
 	ID              string                `json:"id"`
@@ -195,6 +240,8 @@ type PermissionResponse struct {
 	Description string `json:"description,omitempty"`
 	Resource    string `json:"resource"`
 	Action      string `json:"action"`
+	// Scope limits a role permission (dimension -> allowed IDs); omitted = unrestricted.
+	Scope map[string][]string `json:"scope,omitempty"`
 }
 
 // FromPermission creates response from domain permission.
@@ -206,6 +253,7 @@ func FromPermission(p *auth.Permission) *PermissionResponse {
 		Description: p.Description,
 		Resource:    p.Resource,
 		Action:      p.Action,
+		Scope:       p.Scope,
 	}
 }
 
@@ -213,6 +261,8 @@ func FromPermission(p *auth.Permission) *PermissionResponse {
 type EffectiveAccessResponse struct {
 	User          *UserResponse                 `json:"user"`
 	Permissions   []string                      `json:"permissions"`
+	// PermissionScopes lists permissions held only within a scope.
+	PermissionScopes map[string]map[string][]string `json:"permissionScopes,omitempty"`
 	RLSDimensions map[string][]RLSDimensionItem `json:"rlsDimensions,omitempty"`
 	FLSPolicies   []EffectiveFLSPolicy          `json:"flsPolicies,omitempty"`
 	CELRules      []EffectiveCELRule            `json:"celRules,omitempty"`
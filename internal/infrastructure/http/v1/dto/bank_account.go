@@ -0,0 +1,145 @@
+package dto
+
+import (
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/catalogs/bank_account"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+// --- Request DTOs ---
+
+// CreateBankAccountRequest is the request body for creating a bank account.
+// Name defaults to the bank name and the account number.
+type CreateBankAccountRequest struct {
+	Code           string            `json:"code"`
+	Name           string            `json:"name"`
+	CounterpartyID string            `json:"counterpartyId" binding:"required"`
+	AccountNumber  string            `json:"accountNumber" binding:"required"`
+	BIK            string            `json:"bik" binding:"required"`
+	BankName       string            `json:"bankName" binding:"required"`
+	CorrAccount    *string           `json:"corrAccount"`
+	CurrencyID     *string           `json:"currencyId"`
+	IsDefault      bool              `json:"isDefault"`
+	Attributes     entity.Attributes `json:"attributes"`
+}
+
+// ToEntity converts DTO to domain entity.
+func (r *CreateBankAccountRequest) ToEntity() *bank_account.BankAccount {
+	counterpartyID, _ := id.Parse(r.CounterpartyID)
+	a := bank_account.NewBankAccount(r.Code, bankAccountName(r.Name, r.BankName, r.AccountNumber),
+		counterpartyID, r.AccountNumber, r.BIK, r.BankName)
+	a.CorrAccount = r.CorrAccount
+	a.CurrencyID = stringPtrToIDPtr(r.CurrencyID)
+	a.IsDefault = r.IsDefault
+	a.Attributes = r.Attributes
+	return a
+}
+
+// UpdateBankAccountRequest is the request body for updating a bank account.
+type UpdateBankAccountRequest struct {
+	Code           string            `json:"code"`
+	Name           string            `json:"name"`
+	CounterpartyID string            `json:"counterpartyId" binding:"required"`
+	AccountNumber  string            `json:"accountNumber" binding:"required"`
+	BIK            string            `json:"bik" binding:"required"`
+	BankName       string            `json:"bankName" binding:"required"`
+	CorrAccount    *string           `json:"corrAccount"`
+	CurrencyID     *string           `json:"currencyId"`
+	IsDefault      bool              `json:"isDefault"`
+	Attributes     entity.Attributes `json:"attributes"`
+	Version        int               `json:"version" binding:"required"`
+}
+
+// ApplyTo applies update DTO to existing entity.
+func (r *UpdateBankAccountRequest) ApplyTo(a *bank_account.BankAccount) {
+	a.Code = r.Code
+	a.Name = bankAccountName(r.Name, r.BankName, r.AccountNumber)
+	counterpartyID, _ := id.Parse(r.CounterpartyID)
+	a.CounterpartyID = counterpartyID
+	a.AccountNumber = r.AccountNumber
+	a.BIK = r.BIK
+	a.BankName = r.BankName
+	a.CorrAccount = r.CorrAccount
+	a.CurrencyID = stringPtrToIDPtr(r.CurrencyID)
+	a.IsDefault = r.IsDefault
+	a.Attributes = r.Attributes
+	a.Version = r.Version
+}
+
+// bankAccountName returns name, or "<bank> (<account>)" when it is empty.
+func bankAccountName(name, bankName, accountNumber string) string {
+	if name != "" {
+		return name
+	}
+	return bankName + " (" + accountNumber + ")"
+}
+
+// --- Response DTOs ---
+
+// BankAccountResponse is the response body for a bank account.
+type BankAccountResponse struct {
+	ID             string            `json:"id"`
+	Code           string            `json:"code"`
+	Name           string            `json:"name"`
+	CounterpartyID string            `json:"counterpartyId"`
+	AccountNumber  string            `json:"accountNumber"`
+	BIK            string            `json:"bik"`
+	BankName       string            `json:"bankName"`
+	CorrAccount    *string           `json:"corrAccount,omitempty"`
+	CurrencyID     *string           `json:"currencyId,omitempty"`
+	IsDefault      bool              `json:"isDefault"`
+	DeletionMark   bool              `json:"deletionMark"`
+	Version        int               `json:"version"`
+	Attributes     entity.Attributes `json:"attributes,omitempty"`
+
+	// Resolved reference display names (populated by ResolveRefs)
+	Counterparty *postgres.RefDisplay         `json:"counterparty,omitempty"`
+	Currency     *postgres.CurrencyRefDisplay `json:"currency,omitempty"`
+}
+
+// FromBankAccount creates response DTO from domain entity.
+// Pass nil for refs if reference resolution is not needed.
+func FromBankAccount(a *bank_account.BankAccount, refs ...postgres.ResolvedRefs) *BankAccountResponse {
+	resp := &BankAccountResponse{
+		ID:             a.ID.String(),
+		Code:           a.Code,
+		Name:           a.Name,
+		CounterpartyID: a.CounterpartyID.String(),
+		AccountNumber:  a.AccountNumber,
+		BIK:            a.BIK,
+		BankName:       a.BankName,
+		CorrAccount:    a.CorrAccount,
+		CurrencyID:     idToStringPtr(a.CurrencyID),
+		IsDefault:      a.IsDefault,
+		DeletionMark:   a.DeletionMark,
+		Version:        a.Version,
+		Attributes:     a.Attributes,
+	}
+
+	// Populate resolved reference display names
+	if len(refs) > 0 && refs[0] != nil {
+		resolved := refs[0]
+		d := resolved.Get(TableCounterparties, a.CounterpartyID)
+		if d.ID != "" {
+			resp.Counterparty = &d
+		}
+		if a.CurrencyID != nil {
+			currD := resolved.Get(TableCurrencies, *a.CurrencyID)
+			if currD.ID != "" {
+				resp.Currency = &postgres.CurrencyRefDisplay{
+					ID: currD.ID, Name: currD.Name,
+				}
+			}
+		}
+	}
+
+	return resp
+}
+
+// CollectBankAccountRefs registers all reference IDs from a BankAccount
+// into the resolver for batch resolution.
+func CollectBankAccountRefs(resolver *postgres.ReferenceResolver, a *bank_account.BankAccount) {
+	resolver.Add(TableCounterparties, a.CounterpartyID)
+	resolver.AddPtr(TableCurrencies, a.CurrencyID)
+}
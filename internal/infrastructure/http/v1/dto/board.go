@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"metapus/internal/core/id"
+	"metapus/internal/domain/board"
+)
+
+// BoardResponse is the status board of a document type.
+type BoardResponse struct {
+	Columns     []board.Column     `json:"columns"`
+	Transitions []board.Transition `json:"transitions"` // moves a user may request
+}
+
+// MoveBoardStateRequest is the request body for moving a document to another board state.
+type MoveBoardStateRequest struct {
+	State string `json:"state" binding:"required"`
+}
+
+// MoveBoardStateResponse reports the board state of a moved document.
+type MoveBoardStateResponse struct {
+	ID     id.ID        `json:"id"`
+	Action board.Action `json:"action"`
+	State  string       `json:"state"` // state after the move, re-read from the document
+}
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
 	"metapus/internal/domain/catalogs/counterparty"
 )
 
@@ -26,6 +27,39 @@ type CreateCounterpartyRequest struct {
 	ParentID      *string                       `json:"parentId"`
 	IsFolder      bool                          `json:"isFolder"`
 	Attributes    entity.Attributes             `json:"attributes"`
+	Contacts      []ContactDTO                  `json:"contacts" binding:"omitempty,dive"`
+}
+
+// ContactDTO is a contact person of a counterparty. ID is empty for a new
+// contact.
+type ContactDTO struct {
+	ID    string  `json:"id,omitempty"`
+	Name  string  `json:"name" binding:"required"`
+	Role  *string `json:"role,omitempty"`
+	Phone *string `json:"phone,omitempty"`
+	Email *string `json:"email,omitempty"`
+}
+
+// ToDomain converts the DTO to a contact.
+func (r *ContactDTO) ToDomain() counterparty.Contact {
+	contactID, _ := id.Parse(r.ID)
+	return counterparty.Contact{ID: contactID, Name: r.Name, Role: r.Role, Phone: r.Phone, Email: r.Email}
+}
+
+// FromContact creates the DTO of a contact.
+func FromContact(c counterparty.Contact) ContactDTO {
+	return ContactDTO{ID: c.ID.String(), Name: c.Name, Role: c.Role, Phone: c.Phone, Email: c.Email}
+}
+
+func contactsToDomain(contacts []ContactDTO) []counterparty.Contact {
+	if contacts == nil {
+		return nil
+	}
+	out := make([]counterparty.Contact, len(contacts))
+	for i := range contacts {
+		out[i] = contacts[i].ToDomain()
+	}
+	return out
 }
 
 // ToEntity converts DTO to domain entity.
@@ -44,6 +78,7 @@ func (r *CreateCounterpartyRequest) ToEntity() *counterparty.Counterparty {
 	cp.ParentID = stringPtrToIDPtr(r.ParentID)
 	cp.IsFolder = r.IsFolder
 	cp.Attributes = r.Attributes
+	cp.Contacts = contactsToDomain(r.Contacts)
 	return cp
 }
 
@@ -66,6 +101,7 @@ type UpdateCounterpartyRequest struct {
 	ParentID      *string                       `json:"parentId"`
 	IsFolder      bool                          `json:"isFolder"`
 	Attributes    entity.Attributes             `json:"attributes"`
+	Contacts      []ContactDTO                  `json:"contacts" binding:"omitempty,dive"` // nil keeps the stored contacts
 	Version       int                           `json:"version" binding:"required"`
 }
 
@@ -88,6 +124,9 @@ func (r *UpdateCounterpartyRequest) ApplyTo(cp *counterparty.Counterparty) {
 	cp.ParentID = stringPtrToIDPtr(r.ParentID)
 	cp.IsFolder = r.IsFolder
 	cp.Attributes = r.Attributes
+	if r.Contacts != nil {
+		cp.Contacts = contactsToDomain(r.Contacts)
+	}
 	cp.Version = r.Version
 }
 
@@ -115,11 +154,14 @@ type CounterpartyResponse struct {
 	DeletionMark  bool                          `json:"deletionMark"`
 	Version       int                           `json:"version"`
 	Attributes    entity.Attributes             `json:"attributes,omitempty"`
+	Contacts      []ContactDTO                  `json:"contacts,omitempty"`
+	Images        []*ImageResponse              `json:"images,omitempty"`
 }
 
-// FromCounterparty creates response DTO from domain entity.
+// FromCounterparty creates response DTO from domain entity. Contacts are
+// included when loaded (single item responses).
 func FromCounterparty(cp *counterparty.Counterparty) *CounterpartyResponse {
-	return &CounterpartyResponse{
+	resp := &CounterpartyResponse{
 		ID:            cp.ID.String(),
 		Code:          cp.Code,
 		Name:          cp.Name,
@@ -140,5 +182,33 @@ func FromCounterparty(cp *counterparty.Counterparty) *CounterpartyResponse {
 		DeletionMark:  cp.DeletionMark,
 		Version:       cp.Version,
 		Attributes:    cp.Attributes,
+		Images:        MapImageListResponse(CatalogItemPath("counterparties", cp.ID), cp.Images),
+	}
+	if cp.Contacts != nil {
+		resp.Contacts = make([]ContactDTO, len(cp.Contacts))
+		for i, c := range cp.Contacts {
+			resp.Contacts[i] = FromContact(c)
+		}
+	}
+	return resp
+}
+
+// ContactMatchResponse is a contact found by phone or email.
+type ContactMatchResponse struct {
+	CounterpartyID   string `json:"counterpartyId"`
+	CounterpartyName string `json:"counterpartyName"`
+	ContactDTO
+}
+
+// FromContactMatches creates response DTOs of contact search results.
+func FromContactMatches(matches []counterparty.ContactMatch) []ContactMatchResponse {
+	out := make([]ContactMatchResponse, len(matches))
+	for i, m := range matches {
+		out[i] = ContactMatchResponse{
+			CounterpartyID:   m.CounterpartyID.String(),
+			CounterpartyName: m.CounterpartyName,
+			ContactDTO:       FromContact(m.Contact),
+		}
 	}
+	return out
 }
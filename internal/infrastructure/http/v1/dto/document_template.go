@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/domain/doctemplate"
+)
+
+// SaveDocumentTemplateRequest is the request body for saving a document as a template.
+type SaveDocumentTemplateRequest struct {
+	Name       string                 `json:"name" binding:"required,max=255"`
+	Visibility doctemplate.Visibility `json:"visibility"` // default: personal
+}
+
+// UpdateDocumentTemplateRequest is the request body for renaming or sharing a template.
+type UpdateDocumentTemplateRequest struct {
+	Name       string                 `json:"name" binding:"required,max=255"`
+	Visibility doctemplate.Visibility `json:"visibility" binding:"required"`
+	Version    int                    `json:"version" binding:"required"`
+}
+
+// CreateFromTemplateRequest is the request body for creating a document from a template.
+// Quantities are matched to template lines by index; lines with a null or
+// missing quantity are left out of the new document.
+type CreateFromTemplateRequest struct {
+	Date            *time.Time        `json:"date,omitempty"` // default: now
+	Quantities      []json.RawMessage `json:"quantities,omitempty"`
+	PostImmediately bool              `json:"postImmediately,omitempty"`
+}
+
+// DocumentTemplateResponse is the response DTO for a document template.
+type DocumentTemplateResponse struct {
+	ID           uuid.UUID              `json:"id"`
+	DocumentType string                 `json:"documentType"`
+	Name         string                 `json:"name"`
+	AuthorID     *uuid.UUID             `json:"authorId"`
+	Visibility   doctemplate.Visibility `json:"visibility"`
+	Payload      json.RawMessage        `json:"payload"`
+	Version      int                    `json:"version"`
+	CreatedAt    time.Time              `json:"createdAt"`
+	UpdatedAt    time.Time              `json:"updatedAt"`
+}
+
+// MapDocumentTemplateResponse converts a domain Template to a response DTO.
+func MapDocumentTemplateResponse(t *doctemplate.Template) *DocumentTemplateResponse {
+	return &DocumentTemplateResponse{
+		ID:           t.ID,
+		DocumentType: t.DocumentType,
+		Name:         t.Name,
+		AuthorID:     t.AuthorID,
+		Visibility:   t.Visibility,
+		Payload:      t.Payload,
+		Version:      t.Version,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+	}
+}
+
+// MapDocumentTemplateListResponse converts a list of templates to response DTOs.
+func MapDocumentTemplateListResponse(list []*doctemplate.Template) []*DocumentTemplateResponse {
+	result := make([]*DocumentTemplateResponse, len(list))
+	for i, t := range list {
+		result[i] = MapDocumentTemplateResponse(t)
+	}
+	return result
+}
@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/types"
+	"metapus/internal/domain/registers/exchange_rate"
+)
+
+// _rateDateLayout is the wire format of exchange rate dates (effective day).
+const _rateDateLayout = "2006-01-02"
+
+// UpsertExchangeRateRequest is the request body for creating or replacing
+// the rate of a currency on a date from a source.
+type UpsertExchangeRateRequest struct {
+	CurrencyID   string          `json:"currencyId" binding:"required"`
+	RateSourceID string          `json:"rateSourceId" binding:"required"`
+	Date         string          `json:"date" binding:"required"` // YYYY-MM-DD
+	Rate         decimal.Decimal `json:"rate" binding:"required"`
+	Multiplier   int             `json:"multiplier"` // defaults to 1
+}
+
+// ExchangeRateResponse is a single rate record.
+type ExchangeRateResponse struct {
+	CurrencyID   string          `json:"currencyId"`
+	RateSourceID string          `json:"rateSourceId"`
+	Date         string          `json:"date"`
+	Rate         decimal.Decimal `json:"rate"`
+	Multiplier   int             `json:"multiplier"`
+}
+
+// ExchangeRateListResponse is a list of rate records.
+type ExchangeRateListResponse struct {
+	Items []ExchangeRateResponse `json:"items"`
+}
+
+// FromExchangeRate converts a register record to its response.
+func FromExchangeRate(r exchange_rate.ExchangeRate) ExchangeRateResponse {
+	return ExchangeRateResponse{
+		CurrencyID:   r.CurrencyID.String(),
+		RateSourceID: r.RateSourceID.String(),
+		Date:         r.Date.Format(_rateDateLayout),
+		Rate:         r.Rate,
+		Multiplier:   r.Multiplier,
+	}
+}
+
+// ConvertAmountResponse is an amount converted to the base currency.
+type ConvertAmountResponse struct {
+	Amount         types.MinorUnits `json:"amount"`
+	CurrencyID     string           `json:"currencyId"`
+	BaseAmount     types.MinorUnits `json:"baseAmount"`
+	BaseCurrencyID string           `json:"baseCurrencyId"`
+	Date           string           `json:"date"`
+}
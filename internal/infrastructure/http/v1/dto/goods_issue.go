@@ -40,6 +40,20 @@ type GoodsIssueLineRequest struct {
 	VATRateID       string           `json:"vatRateId" binding:"required"`
 	VATPercent      int              `json:"vatPercent"`
 	DiscountPercent decimal.Decimal  `json:"discountPercent"`
+	OrderLineID     *string          `json:"orderLineId,omitempty"`
+	Serials         []string         `json:"serials,omitempty"`
+}
+
+// applyLineExtras sets the fields AddLine does not take on the last added
+// document line: the link to a sales order line and the serial numbers.
+func (l GoodsIssueLineRequest) applyLineExtras(doc *goods_issue.GoodsIssue) {
+	last := &doc.Lines[len(doc.Lines)-1]
+	last.Serials = l.Serials
+	if l.OrderLineID == nil {
+		return
+	}
+	orderLineID, _ := id.Parse(*l.OrderLineID)
+	last.OrderLineID = &orderLineID
 }
 
 func (r *CreateGoodsIssueRequest) ToEntity() *goods_issue.GoodsIssue {
@@ -80,6 +94,7 @@ func (r *CreateGoodsIssueRequest) ToEntity() *goods_issue.GoodsIssue {
 			coefficient = decimal.NewFromInt(1)
 		}
 		doc.AddLine(nomenclatureID, unitID, coefficient, line.Quantity, line.UnitPrice, vatRateID, line.VATPercent, line.DiscountPercent)
+		line.applyLineExtras(doc)
 	}
 
 	return doc
@@ -165,6 +180,7 @@ func (r *UpdateGoodsIssueRequest) ApplyTo(doc *goods_issue.GoodsIssue) {
 				coefficient = decimal.NewFromInt(1)
 			}
 			doc.AddLine(nomenclatureID, unitID, coefficient, line.Quantity, line.UnitPrice, vatRateID, line.VATPercent, line.DiscountPercent)
+			line.applyLineExtras(doc)
 		}
 	}
 }
@@ -183,10 +199,12 @@ type GoodsIssueResponse struct {
 	WarehouseID         string                   `json:"warehouseId"`
 	CustomerOrderNumber string                   `json:"customerOrderNumber,omitempty"`
 	CustomerOrderDate   *time.Time               `json:"customerOrderDate,omitempty"`
+	IntercompanyRef     *string                  `json:"intercompanyRef,omitempty"`
 	CurrencyID          string                   `json:"currencyId"`
 	AmountIncludesVAT   bool                     `json:"amountIncludesVat"`
 	TotalQuantity       types.Quantity           `json:"totalQuantity"`
 	TotalAmount         types.MinorUnits         `json:"totalAmount"`
+	TotalAmountBase     types.MinorUnits         `json:"totalAmountBase"`
 	TotalVAT            types.MinorUnits         `json:"totalVat"`
 	Description         string                   `json:"description,omitempty"`
 	BasisType           string                   `json:"basisType,omitempty"`
@@ -214,6 +232,7 @@ type GoodsIssueLineResponse struct {
 	UnitID          string           `json:"unitId"`
 	Coefficient     decimal.Decimal  `json:"coefficient"`
 	Quantity        types.Quantity   `json:"quantity"`
+	BaseQuantity    types.Quantity   `json:"baseQuantity"` // Quantity in base units of the nomenclature
 	UnitPrice       types.MinorUnits `json:"unitPrice"`
 	DiscountPercent decimal.Decimal  `json:"discountPercent"`
 	DiscountAmount  types.MinorUnits `json:"discountAmount"`
@@ -221,6 +240,8 @@ type GoodsIssueLineResponse struct {
 	VATPercent      int              `json:"vatPercent"`
 	VATAmount       types.MinorUnits `json:"vatAmount"`
 	Amount          types.MinorUnits `json:"amount"`
+	OrderLineID     *string          `json:"orderLineId,omitempty"`
+	Serials         []string         `json:"serials,omitempty"`
 
 	// Resolved reference display names
 	Nomenclature *postgres.RefDisplay `json:"nomenclature,omitempty"`
@@ -261,10 +282,12 @@ func FromGoodsIssue(doc *goods_issue.GoodsIssue, refs postgres.ResolvedRefs, cur
 		WarehouseID:         doc.WarehouseID.String(),
 		CustomerOrderNumber: doc.CustomerOrderNumber,
 		CustomerOrderDate:   doc.CustomerOrderDate,
+		IntercompanyRef:     doc.IntercompanyRef,
 		CurrencyID:          doc.CurrencyID.String(),
 		AmountIncludesVAT:   doc.AmountIncludesVAT,
 		TotalQuantity:       doc.TotalQuantity,
 		TotalAmount:         doc.TotalAmount,
+		TotalAmountBase:     doc.TotalAmountBase,
 		TotalVAT:            doc.TotalVAT,
 		Description:         doc.Description,
 		BasisType:           doc.BasisType,
@@ -317,12 +340,18 @@ func FromGoodsIssue(doc *goods_issue.GoodsIssue, refs postgres.ResolvedRefs, cur
 			UnitID:          line.UnitID.String(),
 			Coefficient:     line.Coefficient,
 			Quantity:        line.Quantity,
+			BaseQuantity:    line.BaseQuantity(),
 			UnitPrice:       line.UnitPrice,
 			DiscountPercent: line.DiscountPercent,
 			DiscountAmount:  line.DiscountAmount,
 			VATRateID:       line.VATRateID.String(),
 			VATAmount:       line.VATAmount,
 			Amount:          line.Amount,
+			Serials:         line.Serials,
+		}
+		if line.OrderLineID != nil {
+			orderLineID := line.OrderLineID.String()
+			lineResp.OrderLineID = &orderLineID
 		}
 
 		if resolved != nil {
@@ -43,6 +43,20 @@ type GoodsReceiptLineRequest struct {
 	VATRateID       string           `json:"vatRateId" binding:"required"`
 	VATPercent      int              `json:"vatPercent"`
 	DiscountPercent decimal.Decimal  `json:"discountPercent"`
+	OrderLineID     *string          `json:"orderLineId,omitempty"`
+	Serials         []string         `json:"serials,omitempty"`
+}
+
+// applyLineExtras sets the fields AddLine does not take on the last added
+// document line: the link to a purchase order line and the serial numbers.
+func (l GoodsReceiptLineRequest) applyLineExtras(doc *goods_receipt.GoodsReceipt) {
+	last := &doc.Lines[len(doc.Lines)-1]
+	last.Serials = l.Serials
+	if l.OrderLineID == nil {
+		return
+	}
+	orderLineID, _ := id.Parse(*l.OrderLineID)
+	last.OrderLineID = &orderLineID
 }
 
 // ToEntity converts request to domain entity.
@@ -85,6 +99,7 @@ func (r *CreateGoodsReceiptRequest) ToEntity() *goods_receipt.GoodsReceipt {
 			coefficient = decimal.NewFromInt(1)
 		}
 		doc.AddLine(nomenclatureID, unitID, coefficient, line.Quantity, line.UnitPrice, vatRateID, line.VATPercent, line.DiscountPercent)
+		line.applyLineExtras(doc)
 	}
 
 	return doc
@@ -176,6 +191,7 @@ func (r *UpdateGoodsReceiptRequest) ApplyTo(doc *goods_receipt.GoodsReceipt) {
 				coefficient = decimal.NewFromInt(1)
 			}
 			doc.AddLine(nomenclatureID, unitID, coefficient, line.Quantity, line.UnitPrice, vatRateID, line.VATPercent, line.DiscountPercent)
+			line.applyLineExtras(doc)
 		}
 	}
 }
@@ -196,10 +212,12 @@ type GoodsReceiptResponse struct {
 	SupplierDocNumber string                     `json:"supplierDocNumber,omitempty"`
 	SupplierDocDate   *time.Time                 `json:"supplierDocDate,omitempty"`
 	IncomingNumber    *string                    `json:"incomingNumber,omitempty"`
+	IntercompanyRef   *string                    `json:"intercompanyRef,omitempty"`
 	CurrencyID        string                     `json:"currencyId"`
 	AmountIncludesVAT bool                       `json:"amountIncludesVat"`
 	TotalQuantity     types.Quantity             `json:"totalQuantity"`
 	TotalAmount       types.MinorUnits           `json:"totalAmount"`
+	TotalAmountBase   types.MinorUnits           `json:"totalAmountBase"`
 	TotalVAT          types.MinorUnits           `json:"totalVat"`
 	Description       string                     `json:"description,omitempty"`
 	BasisType         string                     `json:"basisType,omitempty"`
@@ -228,6 +246,7 @@ type GoodsReceiptLineResponse struct {
 	UnitID          string           `json:"unitId"`
 	Coefficient     decimal.Decimal  `json:"coefficient"`
 	Quantity        types.Quantity   `json:"quantity"`
+	BaseQuantity    types.Quantity   `json:"baseQuantity"` // Quantity in base units of the nomenclature
 	UnitPrice       types.MinorUnits `json:"unitPrice"`
 	DiscountPercent decimal.Decimal  `json:"discountPercent"`
 	DiscountAmount  types.MinorUnits `json:"discountAmount"`
@@ -235,6 +254,8 @@ type GoodsReceiptLineResponse struct {
 	VATPercent      int              `json:"vatPercent"`
 	VATAmount       types.MinorUnits `json:"vatAmount"`
 	Amount          types.MinorUnits `json:"amount"`
+	OrderLineID     *string          `json:"orderLineId,omitempty"`
+	Serials         []string         `json:"serials,omitempty"`
 
 	// Resolved reference display names
 	Nomenclature *postgres.RefDisplay `json:"nomenclature,omitempty"`
@@ -284,10 +305,12 @@ func FromGoodsReceipt(doc *goods_receipt.GoodsReceipt, refs postgres.ResolvedRef
 		SupplierDocNumber: doc.SupplierDocNumber,
 		SupplierDocDate:   doc.SupplierDocDate,
 		IncomingNumber:    doc.IncomingNumber,
+		IntercompanyRef:   doc.IntercompanyRef,
 		CurrencyID:        doc.CurrencyID.String(),
 		AmountIncludesVAT: doc.AmountIncludesVAT,
 		TotalQuantity:     doc.TotalQuantity,
 		TotalAmount:       doc.TotalAmount,
+		TotalAmountBase:   doc.TotalAmountBase,
 		TotalVAT:          doc.TotalVAT,
 		Description:       doc.Description,
 		BasisType:         doc.BasisType,
@@ -340,6 +363,7 @@ func FromGoodsReceipt(doc *goods_receipt.GoodsReceipt, refs postgres.ResolvedRef
 			UnitID:          line.UnitID.String(),
 			Coefficient:     line.Coefficient,
 			Quantity:        line.Quantity,
+			BaseQuantity:    line.BaseQuantity(),
 			UnitPrice:       line.UnitPrice,
 			DiscountPercent: line.DiscountPercent,
 			DiscountAmount:  line.DiscountAmount,
@@ -347,6 +371,11 @@ func FromGoodsReceipt(doc *goods_receipt.GoodsReceipt, refs postgres.ResolvedRef
 			VATPercent:      line.VATPercent,
 			VATAmount:       line.VATAmount,
 			Amount:          line.Amount,
+			Serials:         line.Serials,
+		}
+		if line.OrderLineID != nil {
+			orderLineID := line.OrderLineID.String()
+			lineResp.OrderLineID = &orderLineID
 		}
 
 		if resolved != nil {
@@ -0,0 +1,285 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/domain/documents/goods_transfer"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+// --- Request DTOs ---
+
+type CreateGoodsTransferRequest struct {
+	Number                 string                     `json:"number,omitempty"`
+	Date                   time.Time                  `json:"date" binding:"required"`
+	OrganizationID         string                     `json:"organizationId" binding:"required"`
+	SourceWarehouseID      string                     `json:"sourceWarehouseId" binding:"required"`
+	DestinationWarehouseID string                     `json:"destinationWarehouseId" binding:"required"`
+	TransitWarehouseID     *string                    `json:"transitWarehouseId,omitempty"`
+	ReceivedAt             *time.Time                 `json:"receivedAt,omitempty"`
+	Description            string                     `json:"description,omitempty"`
+	BasisType              string                     `json:"basisType,omitempty"`
+	BasisID                *string                    `json:"basisId,omitempty"`
+	Lines                  []GoodsTransferLineRequest `json:"lines" binding:"required,min=1,dive"`
+	PostImmediately        bool                       `json:"postImmediately,omitempty"`
+}
+
+type GoodsTransferLineRequest struct {
+	NomenclatureID string          `json:"nomenclatureId" binding:"required"`
+	UnitID         string          `json:"unitId" binding:"required"`
+	Coefficient    decimal.Decimal `json:"coefficient"`
+	Quantity       types.Quantity  `json:"quantity" binding:"required,gt=0"`
+}
+
+func (r *CreateGoodsTransferRequest) ToEntity() *goods_transfer.GoodsTransfer {
+	orgID, _ := id.Parse(r.OrganizationID)
+	sourceID, _ := id.Parse(r.SourceWarehouseID)
+	destinationID, _ := id.Parse(r.DestinationWarehouseID)
+
+	doc := goods_transfer.NewGoodsTransfer(orgID, sourceID, destinationID)
+	doc.Number = r.Number
+	doc.Date = r.Date
+	doc.ReceivedAt = r.ReceivedAt
+	doc.Description = r.Description
+	doc.BasisType = r.BasisType
+
+	if r.TransitWarehouseID != nil {
+		transitID, _ := id.Parse(*r.TransitWarehouseID)
+		doc.TransitWarehouseID = &transitID
+	}
+
+	if r.BasisID != nil {
+		basisID, _ := id.Parse(*r.BasisID)
+		doc.BasisID = &basisID
+	}
+
+	for _, line := range r.Lines {
+		nomenclatureID, _ := id.Parse(line.NomenclatureID)
+		unitID, _ := id.Parse(line.UnitID)
+		coefficient := line.Coefficient
+		if coefficient.IsZero() {
+			coefficient = decimal.NewFromInt(1)
+		}
+		doc.AddLine(nomenclatureID, unitID, coefficient, line.Quantity)
+	}
+
+	return doc
+}
+
+type UpdateGoodsTransferRequest struct {
+	Version                int                        `json:"version" binding:"required,min=1"`
+	Number                 *string                    `json:"number,omitempty"`
+	Date                   *time.Time                 `json:"date,omitempty"`
+	OrganizationID         *string                    `json:"organizationId,omitempty"`
+	SourceWarehouseID      *string                    `json:"sourceWarehouseId,omitempty"`
+	DestinationWarehouseID *string                    `json:"destinationWarehouseId,omitempty"`
+	TransitWarehouseID     *string                    `json:"transitWarehouseId,omitempty"`
+	ReceivedAt             *time.Time                 `json:"receivedAt,omitempty"`
+	Description            *string                    `json:"description,omitempty"`
+	BasisType              *string                    `json:"basisType,omitempty"`
+	BasisID                *string                    `json:"basisId,omitempty"`
+	Lines                  []GoodsTransferLineRequest `json:"lines,omitempty"`
+}
+
+// ApplyTo applies updates to an existing entity.
+// Sets the client-provided version on the entity so the repo performs
+// WHERE version = $client_version for optimistic locking.
+func (r *UpdateGoodsTransferRequest) ApplyTo(doc *goods_transfer.GoodsTransfer) {
+	doc.SetVersion(r.Version)
+	if r.Number != nil {
+		doc.Number = *r.Number
+	}
+	if r.Date != nil {
+		doc.Date = *r.Date
+	}
+	if r.OrganizationID != nil {
+		orgID, _ := id.Parse(*r.OrganizationID)
+		doc.OrganizationID = orgID
+	}
+	if r.SourceWarehouseID != nil {
+		sourceID, _ := id.Parse(*r.SourceWarehouseID)
+		doc.SourceWarehouseID = sourceID
+	}
+	if r.DestinationWarehouseID != nil {
+		destinationID, _ := id.Parse(*r.DestinationWarehouseID)
+		doc.DestinationWarehouseID = destinationID
+	}
+	if r.TransitWarehouseID != nil {
+		transitID, _ := id.Parse(*r.TransitWarehouseID)
+		doc.TransitWarehouseID = &transitID
+	}
+	if r.ReceivedAt != nil {
+		doc.ReceivedAt = r.ReceivedAt
+	}
+	if r.Description != nil {
+		doc.Description = *r.Description
+	}
+	if r.BasisType != nil {
+		doc.BasisType = *r.BasisType
+	}
+	if r.BasisID != nil {
+		basisID, _ := id.Parse(*r.BasisID)
+		doc.BasisID = &basisID
+	}
+
+	if r.Lines != nil {
+		doc.Lines = make([]goods_transfer.GoodsTransferLine, 0, len(r.Lines))
+		for _, line := range r.Lines {
+			nomenclatureID, _ := id.Parse(line.NomenclatureID)
+			unitID, _ := id.Parse(line.UnitID)
+			coefficient := line.Coefficient
+			if coefficient.IsZero() {
+				coefficient = decimal.NewFromInt(1)
+			}
+			doc.AddLine(nomenclatureID, unitID, coefficient, line.Quantity)
+		}
+	}
+}
+
+// --- Response DTOs ---
+
+type GoodsTransferResponse struct {
+	ID                     string                      `json:"id"`
+	Number                 string                      `json:"number"`
+	Date                   time.Time                   `json:"date"`
+	Posted                 bool                        `json:"posted"`
+	PostedVersion          int                         `json:"postedVersion,omitempty"`
+	OrganizationID         string                      `json:"organizationId"`
+	SourceWarehouseID      string                      `json:"sourceWarehouseId"`
+	DestinationWarehouseID string                      `json:"destinationWarehouseId"`
+	TransitWarehouseID     *string                     `json:"transitWarehouseId,omitempty"`
+	ReceivedAt             *time.Time                  `json:"receivedAt,omitempty"`
+	InTransit              bool                        `json:"inTransit"`
+	TotalQuantity          types.Quantity              `json:"totalQuantity"`
+	Description            string                      `json:"description,omitempty"`
+	BasisType              string                      `json:"basisType,omitempty"`
+	BasisID                *string                     `json:"basisId,omitempty"`
+	Lines                  []GoodsTransferLineResponse `json:"lines,omitempty"`
+	Version                int                         `json:"version"`
+	DeletionMark           bool                        `json:"deletionMark"`
+	CreatedAt              time.Time                   `json:"createdAt"`
+	UpdatedAt              time.Time                   `json:"updatedAt"`
+
+	// Resolved reference display names (populated by handler, not stored in DB)
+	Organization         *postgres.RefDisplay `json:"organization,omitempty"`
+	SourceWarehouse      *postgres.RefDisplay `json:"sourceWarehouse,omitempty"`
+	DestinationWarehouse *postgres.RefDisplay `json:"destinationWarehouse,omitempty"`
+	TransitWarehouse     *postgres.RefDisplay `json:"transitWarehouse,omitempty"`
+	CreatedByUser        *postgres.RefDisplay `json:"createdByUser,omitempty"`
+	UpdatedByUser        *postgres.RefDisplay `json:"updatedByUser,omitempty"`
+}
+
+type GoodsTransferLineResponse struct {
+	LineID         string          `json:"lineId"`
+	LineNo         int             `json:"lineNo"`
+	NomenclatureID string          `json:"nomenclatureId"`
+	UnitID         string          `json:"unitId"`
+	Coefficient    decimal.Decimal `json:"coefficient"`
+	Quantity       types.Quantity  `json:"quantity"`
+	BaseQuantity   types.Quantity  `json:"baseQuantity"` // Quantity in base units of the nomenclature
+
+	// Resolved reference display names
+	Nomenclature *postgres.RefDisplay `json:"nomenclature,omitempty"`
+	Unit         *postgres.RefDisplay `json:"unit,omitempty"`
+}
+
+// CollectGoodsTransferRefs registers all reference IDs from a GoodsTransfer
+// into the resolver for batch resolution.
+func CollectGoodsTransferRefs(resolver *postgres.ReferenceResolver, doc *goods_transfer.GoodsTransfer) {
+	resolver.Add(TableOrganizations, doc.OrganizationID)
+	resolver.Add(TableWarehouses, doc.SourceWarehouseID)
+	resolver.Add(TableWarehouses, doc.DestinationWarehouseID)
+	resolver.AddPtr(TableWarehouses, doc.TransitWarehouseID)
+	resolver.Add(TableUsers, doc.CreatedBy)
+	resolver.Add(TableUsers, doc.UpdatedBy)
+
+	for _, line := range doc.Lines {
+		resolver.Add(TableNomenclature, line.NomenclatureID)
+		resolver.Add(TableUnits, line.UnitID)
+	}
+}
+
+// FromGoodsTransfer converts domain entity to response DTO.
+// Pass nil for refs if reference resolution is not needed.
+func FromGoodsTransfer(doc *goods_transfer.GoodsTransfer, refs postgres.ResolvedRefs) *GoodsTransferResponse {
+	resp := &GoodsTransferResponse{
+		ID:                     doc.ID.String(),
+		Number:                 doc.Number,
+		Date:                   doc.Date,
+		Posted:                 doc.Posted,
+		PostedVersion:          doc.PostedVersion,
+		OrganizationID:         doc.OrganizationID.String(),
+		SourceWarehouseID:      doc.SourceWarehouseID.String(),
+		DestinationWarehouseID: doc.DestinationWarehouseID.String(),
+		ReceivedAt:             doc.ReceivedAt,
+		InTransit:              doc.InTransit(),
+		TotalQuantity:          doc.TotalQuantity,
+		Description:            doc.Description,
+		BasisType:              doc.BasisType,
+		Version:                doc.Version,
+		DeletionMark:           doc.DeletionMark,
+		CreatedAt:              doc.CreatedAt,
+		UpdatedAt:              doc.UpdatedAt,
+	}
+
+	if doc.TransitWarehouseID != nil {
+		s := doc.TransitWarehouseID.String()
+		resp.TransitWarehouseID = &s
+	}
+
+	if doc.BasisID != nil {
+		s := doc.BasisID.String()
+		resp.BasisID = &s
+	}
+
+	// Populate resolved reference display names
+	if refs != nil {
+		org := refs.Get(TableOrganizations, doc.OrganizationID)
+		resp.Organization = &org
+		src := refs.Get(TableWarehouses, doc.SourceWarehouseID)
+		resp.SourceWarehouse = &src
+		dst := refs.Get(TableWarehouses, doc.DestinationWarehouseID)
+		resp.DestinationWarehouse = &dst
+		resp.TransitWarehouse = refs.GetPtr(TableWarehouses, doc.TransitWarehouseID)
+
+		createdBy := doc.CreatedBy
+		updatedBy := doc.UpdatedBy
+		resp.CreatedByUser = refs.GetPtr(TableUsers, &createdBy)
+		resp.UpdatedByUser = refs.GetPtr(TableUsers, &updatedBy)
+	}
+
+	resp.Lines = make([]GoodsTransferLineResponse, len(doc.Lines))
+	for i, line := range doc.Lines {
+		lineResp := GoodsTransferLineResponse{
+			LineID:         line.LineID.String(),
+			LineNo:         line.LineNo,
+			NomenclatureID: line.NomenclatureID.String(),
+			UnitID:         line.UnitID.String(),
+			Coefficient:    line.Coefficient,
+			Quantity:       line.Quantity,
+			BaseQuantity:   line.BaseQuantity(),
+		}
+
+		if refs != nil {
+			prod := refs.Get(TableNomenclature, line.NomenclatureID)
+			lineResp.Nomenclature = &prod
+			unit := refs.Get(TableUnits, line.UnitID)
+			lineResp.Unit = &unit
+		}
+
+		resp.Lines[i] = lineResp
+	}
+
+	return resp
+}
+
+type GoodsTransferListResponse struct {
+	Items      []*GoodsTransferResponse `json:"items"`
+	TotalCount int                      `json:"totalCount"`
+	Limit      int                      `json:"limit"`
+	Offset     int                      `json:"offset"`
+}
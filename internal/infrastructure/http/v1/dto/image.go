@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain/gallery"
+)
+
+// ImageResponse is the response DTO for an image of a catalog item.
+type ImageResponse struct {
+	ID           uuid.UUID `json:"id"`
+	FileName     string    `json:"fileName"`
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	SortOrder    int       `json:"sortOrder"`
+	IsPrimary    bool      `json:"isPrimary"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnailUrl"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// CatalogItemPath returns the API path of a catalog item, e.g.
+// /api/v1/catalog/nomenclatures/{id}.
+func CatalogItemPath(routePrefix string, itemID id.ID) string {
+	return "/api/v1/catalog/" + routePrefix + "/" + itemID.String()
+}
+
+// MapImageResponse converts a domain Image to a response DTO. itemPath is
+// the API path of the owning item (see CatalogItemPath).
+func MapImageResponse(itemPath string, img *gallery.Image) *ImageResponse {
+	url := itemPath + "/images/" + img.ID.String()
+	return &ImageResponse{
+		ID:           img.ID,
+		FileName:     img.FileName,
+		ContentType:  img.ContentType,
+		Size:         img.Size,
+		Width:        img.Width,
+		Height:       img.Height,
+		SortOrder:    img.SortOrder,
+		IsPrimary:    img.IsPrimary,
+		URL:          url,
+		ThumbnailURL: url + "/thumbnail",
+		CreatedAt:    img.CreatedAt,
+	}
+}
+
+// MapImageListResponse converts a gallery to response DTOs. A nil gallery
+// (not loaded) maps to nil.
+func MapImageListResponse(itemPath string, list []*gallery.Image) []*ImageResponse {
+	if list == nil {
+		return nil
+	}
+	result := make([]*ImageResponse, len(list))
+	for i, img := range list {
+		result[i] = MapImageResponse(itemPath, img)
+	}
+	return result
+}
+
+// ReorderImagesRequest sets the gallery order of a catalog item.
+type ReorderImagesRequest struct {
+	IDs []uuid.UUID `json:"ids" binding:"required"`
+}
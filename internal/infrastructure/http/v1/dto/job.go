@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"metapus/internal/core/jobs"
+)
+
+// JobResponse is the API representation of a queued background job.
+type JobResponse struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"maxAttempts"`
+	RunAt       string          `json:"runAt"`
+	LastError   string          `json:"lastError,omitempty"`
+	CreatedBy   *string         `json:"createdBy,omitempty"`
+	CreatedAt   string          `json:"createdAt"`
+	FinishedAt  *string         `json:"finishedAt,omitempty"`
+}
+
+// JobListResponse is the job list response.
+type JobListResponse struct {
+	Items []JobResponse `json:"items"`
+}
+
+// MapJob converts a queued job to its DTO.
+func MapJob(j *jobs.Job) JobResponse {
+	r := JobResponse{
+		ID:          j.ID.String(),
+		Type:        j.Type,
+		Payload:     j.Payload,
+		Status:      string(j.Status),
+		Attempts:    j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+		RunAt:       j.RunAt.UTC().Format(time.RFC3339),
+		LastError:   j.LastError,
+		CreatedAt:   j.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if j.CreatedBy != nil {
+		s := j.CreatedBy.String()
+		r.CreatedBy = &s
+	}
+	if j.FinishedAt != nil {
+		s := j.FinishedAt.UTC().Format(time.RFC3339)
+		r.FinishedAt = &s
+	}
+	return r
+}
+
+// MapJobs converts queued jobs to DTOs.
+func MapJobs(list []*jobs.Job) []JobResponse {
+	out := make([]JobResponse, len(list))
+	for i, j := range list {
+		out[i] = MapJob(j)
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/core/jobs"
+)
+
+// CreateJobScheduleRequest is the request body for creating a job schedule.
+type CreateJobScheduleRequest struct {
+	Name          string             `json:"name" binding:"required,max=255"`
+	JobType       string             `json:"jobType" binding:"required,max=100"`  // e.g. reports.generate
+	Params        json.RawMessage    `json:"params,omitempty"`                    // payload of every job
+	CronExpr      string             `json:"cronExpr" binding:"required,max=100"` // 6-field CRON or @daily etc.
+	Timezone      string             `json:"timezone,omitempty"`                  // default: UTC
+	MisfirePolicy jobs.MisfirePolicy `json:"misfirePolicy,omitempty"`             // default: run_once
+	MaxAttempts   int                `json:"maxAttempts,omitempty"`               // default: 5
+	Active        *bool              `json:"active,omitempty"`                    // default: true
+}
+
+// UpdateJobScheduleRequest is the request body for changing a job schedule.
+type UpdateJobScheduleRequest struct {
+	CreateJobScheduleRequest
+	Version int `json:"version" binding:"required"`
+}
+
+// ToSchedule maps the request to a job Schedule.
+func (r *CreateJobScheduleRequest) ToSchedule() *jobs.Schedule {
+	active := true
+	if r.Active != nil {
+		active = *r.Active
+	}
+	return &jobs.Schedule{
+		Name:          r.Name,
+		JobType:       r.JobType,
+		Params:        r.Params,
+		CronExpr:      r.CronExpr,
+		Timezone:      r.Timezone,
+		MisfirePolicy: r.MisfirePolicy,
+		MaxAttempts:   r.MaxAttempts,
+		Active:        active,
+	}
+}
+
+// JobScheduleResponse is the response DTO for a job schedule.
+type JobScheduleResponse struct {
+	ID            uuid.UUID          `json:"id"`
+	Name          string             `json:"name"`
+	JobType       string             `json:"jobType"`
+	Params        json.RawMessage    `json:"params"`
+	CronExpr      string             `json:"cronExpr"`
+	Timezone      string             `json:"timezone"`
+	MisfirePolicy jobs.MisfirePolicy `json:"misfirePolicy"`
+	MaxAttempts   int                `json:"maxAttempts"`
+	Active        bool               `json:"active"`
+	NextRunAt     *time.Time         `json:"nextRunAt"`
+	LastRunAt     *time.Time         `json:"lastRunAt"`
+	AuthorID      *uuid.UUID         `json:"authorId"`
+	Version       int                `json:"version"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	UpdatedAt     time.Time          `json:"updatedAt"`
+}
+
+// MapJobScheduleResponse converts a job Schedule to a response DTO.
+func MapJobScheduleResponse(s *jobs.Schedule) *JobScheduleResponse {
+	return &JobScheduleResponse{
+		ID:            s.ID,
+		Name:          s.Name,
+		JobType:       s.JobType,
+		Params:        s.Params,
+		CronExpr:      s.CronExpr,
+		Timezone:      s.Timezone,
+		MisfirePolicy: s.MisfirePolicy,
+		MaxAttempts:   s.MaxAttempts,
+		Active:        s.Active,
+		NextRunAt:     s.NextRunAt,
+		LastRunAt:     s.LastRunAt,
+		AuthorID:      s.AuthorID,
+		Version:       s.Version,
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+	}
+}
+
+// MapJobScheduleListResponse converts a list of job schedules to response DTOs.
+func MapJobScheduleListResponse(list []*jobs.Schedule) []*JobScheduleResponse {
+	result := make([]*JobScheduleResponse, len(list))
+	for i, s := range list {
+		result[i] = MapJobScheduleResponse(s)
+	}
+	return result
+}
+
+// RunJobScheduleResponse is the response of running a schedule out of turn.
+type RunJobScheduleResponse struct {
+	JobID uuid.UUID `json:"jobId"`
+}
@@ -0,0 +1,248 @@
+package dto
+
+import (
+	"time"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/domain/documents/manual_adjustment"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+// --- Request DTOs ---
+
+type CreateManualAdjustmentRequest struct {
+	Number          string                        `json:"number,omitempty"`
+	Date            time.Time                     `json:"date" binding:"required"`
+	OrganizationID  string                        `json:"organizationId" binding:"required"`
+	Reason          string                        `json:"reason" binding:"required"`
+	CurrencyID      string                        `json:"currencyId,omitempty"`
+	Description     string                        `json:"description,omitempty"`
+	Lines           []ManualAdjustmentLineRequest `json:"lines" binding:"required,min=1,dive"`
+	PostImmediately bool                          `json:"postImmediately,omitempty"`
+}
+
+type ManualAdjustmentLineRequest struct {
+	Register       string           `json:"register" binding:"required,oneof=stock cost settlement"`
+	RecordType     string           `json:"recordType" binding:"required,oneof=receipt expense"`
+	WarehouseID    *string          `json:"warehouseId,omitempty"`
+	NomenclatureID *string          `json:"nomenclatureId,omitempty"`
+	CounterpartyID *string          `json:"counterpartyId,omitempty"`
+	ContractID     *string          `json:"contractId,omitempty"`
+	Quantity       types.Quantity   `json:"quantity" binding:"gte=0"`
+	Amount         types.MinorUnits `json:"amount" binding:"gte=0"`
+}
+
+func (l *ManualAdjustmentLineRequest) toLine() manual_adjustment.ManualAdjustmentLine {
+	return manual_adjustment.ManualAdjustmentLine{
+		Register:       manual_adjustment.Register(l.Register),
+		RecordType:     entity.RecordType(l.RecordType),
+		WarehouseID:    stringPtrToIDPtr(l.WarehouseID),
+		NomenclatureID: stringPtrToIDPtr(l.NomenclatureID),
+		CounterpartyID: stringPtrToIDPtr(l.CounterpartyID),
+		ContractID:     stringPtrToIDPtr(l.ContractID),
+		Quantity:       l.Quantity,
+		Amount:         l.Amount,
+	}
+}
+
+func (r *CreateManualAdjustmentRequest) ToEntity() *manual_adjustment.ManualAdjustment {
+	orgID, _ := id.Parse(r.OrganizationID)
+	doc := manual_adjustment.NewManualAdjustment(orgID, r.Reason)
+	doc.Number = r.Number
+	doc.Date = r.Date
+	doc.Description = r.Description
+
+	if r.CurrencyID != "" {
+		currencyID, _ := id.Parse(r.CurrencyID)
+		doc.CurrencyID = currencyID
+	}
+
+	for i := range r.Lines {
+		doc.AddLine(r.Lines[i].toLine())
+	}
+
+	return doc
+}
+
+type UpdateManualAdjustmentRequest struct {
+	Version        int                           `json:"version" binding:"required,min=1"`
+	Number         *string                       `json:"number,omitempty"`
+	Date           *time.Time                    `json:"date,omitempty"`
+	OrganizationID *string                       `json:"organizationId,omitempty"`
+	Reason         *string                       `json:"reason,omitempty"`
+	CurrencyID     *string                       `json:"currencyId,omitempty"`
+	Description    *string                       `json:"description,omitempty"`
+	Lines          []ManualAdjustmentLineRequest `json:"lines,omitempty" binding:"omitempty,dive"`
+}
+
+// ApplyTo applies updates to an existing entity.
+// Sets the client-provided version on the entity so the repo performs
+// WHERE version = $client_version for optimistic locking.
+func (r *UpdateManualAdjustmentRequest) ApplyTo(doc *manual_adjustment.ManualAdjustment) {
+	doc.SetVersion(r.Version)
+	if r.Number != nil {
+		doc.Number = *r.Number
+	}
+	if r.Date != nil {
+		doc.Date = *r.Date
+	}
+	if r.OrganizationID != nil {
+		orgID, _ := id.Parse(*r.OrganizationID)
+		doc.OrganizationID = orgID
+	}
+	if r.Reason != nil {
+		doc.Reason = *r.Reason
+	}
+	if r.CurrencyID != nil {
+		currencyID, _ := id.Parse(*r.CurrencyID)
+		doc.CurrencyID = currencyID
+	}
+	if r.Description != nil {
+		doc.Description = *r.Description
+	}
+
+	if r.Lines != nil {
+		doc.Lines = make([]manual_adjustment.ManualAdjustmentLine, 0, len(r.Lines))
+		for i := range r.Lines {
+			doc.AddLine(r.Lines[i].toLine())
+		}
+	}
+}
+
+// --- Response DTOs ---
+
+type ManualAdjustmentResponse struct {
+	ID             string                         `json:"id"`
+	Number         string                         `json:"number"`
+	Date           time.Time                      `json:"date"`
+	Posted         bool                           `json:"posted"`
+	PostedVersion  int                            `json:"postedVersion,omitempty"`
+	OrganizationID string                         `json:"organizationId"`
+	Reason         string                         `json:"reason"`
+	CurrencyID     string                         `json:"currencyId"`
+	Approved       bool                           `json:"approved"`
+	ApprovedBy     *string                        `json:"approvedBy,omitempty"`
+	ApprovedAt     *time.Time                     `json:"approvedAt,omitempty"`
+	Description    string                         `json:"description,omitempty"`
+	Lines          []ManualAdjustmentLineResponse `json:"lines,omitempty"`
+	Version        int                            `json:"version"`
+	DeletionMark   bool                           `json:"deletionMark"`
+	CreatedAt      time.Time                      `json:"createdAt"`
+	UpdatedAt      time.Time                      `json:"updatedAt"`
+
+	// Resolved reference display names (populated by handler, not stored in DB)
+	Organization   *postgres.RefDisplay         `json:"organization,omitempty"`
+	Currency       *postgres.CurrencyRefDisplay `json:"currency,omitempty"`
+	ApprovedByUser *postgres.RefDisplay         `json:"approvedByUser,omitempty"`
+	CreatedByUser  *postgres.RefDisplay         `json:"createdByUser,omitempty"`
+	UpdatedByUser  *postgres.RefDisplay         `json:"updatedByUser,omitempty"`
+}
+
+type ManualAdjustmentLineResponse struct {
+	LineID         string           `json:"lineId"`
+	LineNo         int              `json:"lineNo"`
+	Register       string           `json:"register"`
+	RecordType     string           `json:"recordType"`
+	WarehouseID    *string          `json:"warehouseId,omitempty"`
+	NomenclatureID *string          `json:"nomenclatureId,omitempty"`
+	CounterpartyID *string          `json:"counterpartyId,omitempty"`
+	ContractID     *string          `json:"contractId,omitempty"`
+	Quantity       types.Quantity   `json:"quantity"`
+	Amount         types.MinorUnits `json:"amount"`
+
+	// Resolved reference display names
+	Warehouse    *postgres.RefDisplay `json:"warehouse,omitempty"`
+	Nomenclature *postgres.RefDisplay `json:"nomenclature,omitempty"`
+	Counterparty *postgres.RefDisplay `json:"counterparty,omitempty"`
+	Contract     *postgres.RefDisplay `json:"contract,omitempty"`
+}
+
+// CollectManualAdjustmentRefs registers all reference IDs from a ManualAdjustment
+// into the resolver for batch resolution.
+func CollectManualAdjustmentRefs(resolver *postgres.ReferenceResolver, doc *manual_adjustment.ManualAdjustment) {
+	resolver.Add(TableOrganizations, doc.OrganizationID)
+	resolver.Add(TableCurrencies, doc.CurrencyID)
+	resolver.AddPtr(TableUsers, doc.ApprovedBy)
+	resolver.Add(TableUsers, doc.CreatedBy)
+	resolver.Add(TableUsers, doc.UpdatedBy)
+
+	for _, line := range doc.Lines {
+		resolver.AddPtr(TableWarehouses, line.WarehouseID)
+		resolver.AddPtr(TableNomenclature, line.NomenclatureID)
+		resolver.AddPtr(TableCounterparties, line.CounterpartyID)
+		resolver.AddPtr(TableContracts, line.ContractID)
+	}
+}
+
+// FromManualAdjustment converts domain entity to response DTO.
+// Pass nil for refs if reference resolution is not needed.
+// Optional currencyRefs provides enriched currency display (decimalPlaces, symbol).
+func FromManualAdjustment(doc *manual_adjustment.ManualAdjustment, refs postgres.ResolvedRefs, currencyRefs ...postgres.ResolvedCurrencyRefs) *ManualAdjustmentResponse {
+	resp := &ManualAdjustmentResponse{
+		ID:             doc.ID.String(),
+		Number:         doc.Number,
+		Date:           doc.Date,
+		Posted:         doc.Posted,
+		PostedVersion:  doc.PostedVersion,
+		OrganizationID: doc.OrganizationID.String(),
+		Reason:         doc.Reason,
+		CurrencyID:     doc.CurrencyID.String(),
+		Approved:       doc.IsApproved(),
+		ApprovedBy:     idToStringPtr(doc.ApprovedBy),
+		ApprovedAt:     doc.ApprovedAt,
+		Description:    doc.Description,
+		Version:        doc.Version,
+		DeletionMark:   doc.DeletionMark,
+		CreatedAt:      doc.CreatedAt,
+		UpdatedAt:      doc.UpdatedAt,
+	}
+
+	// Populate resolved reference display names
+	resolved := refs
+	if resolved != nil {
+		org := resolved.Get(TableOrganizations, doc.OrganizationID)
+		resp.Organization = &org
+		if len(currencyRefs) > 0 && currencyRefs[0] != nil {
+			cr := currencyRefs[0].Get(doc.CurrencyID)
+			resp.Currency = &cr
+		} else {
+			generic := resolved.Get(TableCurrencies, doc.CurrencyID)
+			resp.Currency = &postgres.CurrencyRefDisplay{ID: generic.ID, Name: generic.Name, DecimalPlaces: 2}
+		}
+		resp.ApprovedByUser = resolved.GetPtr(TableUsers, doc.ApprovedBy)
+
+		createdBy := doc.CreatedBy
+		updatedBy := doc.UpdatedBy
+		resp.CreatedByUser = resolved.GetPtr(TableUsers, &createdBy)
+		resp.UpdatedByUser = resolved.GetPtr(TableUsers, &updatedBy)
+	}
+
+	resp.Lines = make([]ManualAdjustmentLineResponse, len(doc.Lines))
+	for i, line := range doc.Lines {
+		lineResp := ManualAdjustmentLineResponse{
+			LineID:         line.LineID.String(),
+			LineNo:         line.LineNo,
+			Register:       string(line.Register),
+			RecordType:     string(line.RecordType),
+			WarehouseID:    idToStringPtr(line.WarehouseID),
+			NomenclatureID: idToStringPtr(line.NomenclatureID),
+			CounterpartyID: idToStringPtr(line.CounterpartyID),
+			ContractID:     idToStringPtr(line.ContractID),
+			Quantity:       line.Quantity,
+			Amount:         line.Amount,
+		}
+
+		if resolved != nil {
+			lineResp.Warehouse = resolved.GetPtr(TableWarehouses, line.WarehouseID)
+			lineResp.Nomenclature = resolved.GetPtr(TableNomenclature, line.NomenclatureID)
+			lineResp.Counterparty = resolved.GetPtr(TableCounterparties, line.CounterpartyID)
+			lineResp.Contract = resolved.GetPtr(TableContracts, line.ContractID)
+		}
+
+		resp.Lines[i] = lineResp
+	}
+
+	return resp
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/shopspring/decimal"
 
 	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
 	"metapus/internal/domain/catalogs/nomenclature"
 	"metapus/internal/infrastructure/storage/postgres"
 )
@@ -32,6 +33,31 @@ type CreateNomenclatureRequest struct {
 	ParentID         *string                       `json:"parentId"`
 	IsFolder         bool                          `json:"isFolder"`
 	Attributes       entity.Attributes             `json:"attributes"`
+	PackagingUnits   []PackagingUnitDTO            `json:"packagingUnits" binding:"omitempty,dive"`
+	Barcodes         []string                      `json:"barcodes"`
+}
+
+// PackagingUnitDTO is a packaging unit of a nomenclature item.
+type PackagingUnitDTO struct {
+	UnitID      string          `json:"unitId" binding:"required"`
+	Coefficient decimal.Decimal `json:"coefficient"`
+
+	// Resolved reference display name (responses only)
+	Unit *postgres.RefDisplay `json:"unit,omitempty"`
+}
+
+// packagingUnitsToDomain converts request packaging units; nil stays nil so
+// an update without the field keeps the stored units.
+func packagingUnitsToDomain(units []PackagingUnitDTO) []nomenclature.PackagingUnit {
+	if units == nil {
+		return nil
+	}
+	out := make([]nomenclature.PackagingUnit, len(units))
+	for i, pu := range units {
+		unitID, _ := id.Parse(pu.UnitID)
+		out[i] = nomenclature.PackagingUnit{UnitID: unitID, Coefficient: pu.Coefficient}
+	}
+	return out
 }
 
 // ToEntity converts DTO to domain entity.
@@ -53,6 +79,8 @@ func (r *CreateNomenclatureRequest) ToEntity() *nomenclature.Nomenclature {
 	item.ParentID = stringPtrToIDPtr(r.ParentID)
 	item.IsFolder = r.IsFolder
 	item.Attributes = r.Attributes
+	item.PackagingUnits = packagingUnitsToDomain(r.PackagingUnits)
+	item.Barcodes = r.Barcodes
 	return item
 }
 
@@ -77,6 +105,8 @@ type UpdateNomenclatureRequest struct {
 	ParentID         *string                       `json:"parentId"`
 	IsFolder         bool                          `json:"isFolder"`
 	Attributes       entity.Attributes             `json:"attributes"`
+	PackagingUnits   []PackagingUnitDTO            `json:"packagingUnits" binding:"omitempty,dive"` // nil keeps the stored units
+	Barcodes         []string                      `json:"barcodes"`                                 // nil keeps the stored barcodes
 	Version          int                           `json:"version" binding:"required"`
 }
 
@@ -101,6 +131,12 @@ func (r *UpdateNomenclatureRequest) ApplyTo(item *nomenclature.Nomenclature) {
 	item.ParentID = stringPtrToIDPtr(r.ParentID)
 	item.IsFolder = r.IsFolder
 	item.Attributes = r.Attributes
+	if r.PackagingUnits != nil {
+		item.PackagingUnits = packagingUnitsToDomain(r.PackagingUnits)
+	}
+	if r.Barcodes != nil {
+		item.Barcodes = r.Barcodes
+	}
 	item.Version = r.Version
 }
 
@@ -130,6 +166,9 @@ type NomenclatureResponse struct {
 	DeletionMark     bool                          `json:"deletionMark"`
 	Version          int                           `json:"version"`
 	Attributes       entity.Attributes             `json:"attributes,omitempty"`
+	PackagingUnits   []PackagingUnitDTO            `json:"packagingUnits,omitempty"`
+	Barcodes         []string                      `json:"barcodes,omitempty"`
+	Images           []*ImageResponse              `json:"images,omitempty"`
 
 	// Resolved reference display names (populated by ResolveRefs)
 	BaseUnit       *postgres.RefDisplay `json:"baseUnit,omitempty"`
@@ -163,11 +202,23 @@ func FromNomenclature(item *nomenclature.Nomenclature, refs ...postgres.Resolved
 		DeletionMark:     item.DeletionMark,
 		Version:          item.Version,
 		Attributes:       item.Attributes,
+		Barcodes:         item.Barcodes,
+		Images:           MapImageListResponse(CatalogItemPath("nomenclatures", item.ID), item.Images),
+	}
+	if item.PackagingUnits != nil {
+		resp.PackagingUnits = make([]PackagingUnitDTO, len(item.PackagingUnits))
+		for i, pu := range item.PackagingUnits {
+			resp.PackagingUnits[i] = PackagingUnitDTO{UnitID: pu.UnitID.String(), Coefficient: pu.Coefficient}
+		}
 	}
 
 	// Populate resolved reference display names
 	if len(refs) > 0 && refs[0] != nil {
 		resolved := refs[0]
+		for i, pu := range item.PackagingUnits {
+			unitRef := resolved.Get(TableUnits, pu.UnitID)
+			resp.PackagingUnits[i].Unit = &unitRef
+		}
 		resp.BaseUnit = resolved.GetPtr(TableUnits, item.BaseUnitID)
 		resp.DefaultVatRate = resolved.GetPtr(TableVATRates, item.DefaultVatRateID)
 		resp.Manufacturer = resolved.GetPtr(TableCounterparties, item.ManufacturerID)
@@ -180,6 +231,18 @@ func FromNomenclature(item *nomenclature.Nomenclature, refs ...postgres.Resolved
 // into the resolver for batch resolution.
 func CollectNomenclatureRefs(resolver *postgres.ReferenceResolver, item *nomenclature.Nomenclature) {
 	resolver.AddPtr(TableUnits, item.BaseUnitID)
+	for _, pu := range item.PackagingUnits {
+		resolver.Add(TableUnits, pu.UnitID)
+	}
 	resolver.AddPtr(TableVATRates, item.DefaultVatRateID)
 	resolver.AddPtr(TableCounterparties, item.ManufacturerID)
 }
+
+// PrintLabelsRequest is the body of POST /catalog/nomenclatures/labels.
+type PrintLabelsRequest struct {
+	IDs []string `json:"ids" binding:"required,min=1,dive,required"`
+	// Copies is the number of labels per item (default 1).
+	Copies int `json:"copies" binding:"omitempty,min=1,max=100"`
+	// Output is "pdf" (default), "png" or "html".
+	Output string `json:"output" binding:"omitempty,oneof=pdf png html"`
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/domain/posting"
+)
+
+// PostDryRunResponse is returned by POST /{entity}/:id/post?dryRun=true.
+type PostDryRunResponse struct {
+	// CanPost is true when a real Post would succeed.
+	CanPost   bool                `json:"canPost"`
+	Movements PostDryRunMovements `json:"movements"`
+	Errors    []ErrorResponse     `json:"errors"`
+}
+
+// PostDryRunMovements lists the would-be register movements by register.
+type PostDryRunMovements struct {
+	Stock      []entity.StockMovement      `json:"stock"`
+	Cost       []entity.CostMovement       `json:"cost"`
+	Settlement []entity.SettlementMovement `json:"settlement"`
+	Extensions map[string]any              `json:"extensions,omitempty"`
+}
+
+// FromDryRunResult converts a posting dry-run result to the API response.
+func FromDryRunResult(r *posting.DryRunResult) PostDryRunResponse {
+	resp := PostDryRunResponse{
+		CanPost: len(r.Errors) == 0,
+		Movements: PostDryRunMovements{
+			Stock:      []entity.StockMovement{},
+			Cost:       []entity.CostMovement{},
+			Settlement: []entity.SettlementMovement{},
+		},
+		Errors: make([]ErrorResponse, 0, len(r.Errors)),
+	}
+	if m := r.Movements; m != nil {
+		if m.StockMovements != nil {
+			resp.Movements.Stock = m.StockMovements
+		}
+		if m.CostMovements != nil {
+			resp.Movements.Cost = m.CostMovements
+		}
+		if m.SettlementMovements != nil {
+			resp.Movements.Settlement = m.SettlementMovements
+		}
+		resp.Movements.Extensions = m.Extensions
+	}
+	for _, err := range r.Errors {
+		if appErr, ok := apperror.AsAppError(err); ok {
+			resp.Errors = append(resp.Errors, ErrorResponse{
+				Code:    appErr.Code,
+				Message: appErr.Message,
+				Details: appErr.Details,
+			})
+			continue
+		}
+		resp.Errors = append(resp.Errors, ErrorResponse{
+			Code:    apperror.CodeValidation,
+			Message: err.Error(),
+		})
+	}
+	return resp
+}
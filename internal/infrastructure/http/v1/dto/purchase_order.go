@@ -0,0 +1,410 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/domain/documents/purchase_order"
+	"metapus/internal/domain/registers/supplier_order"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+// --- Request DTOs ---
+
+type CreatePurchaseOrderRequest struct {
+	Number            string                     `json:"number,omitempty"`
+	Date              time.Time                  `json:"date" binding:"required"`
+	OrganizationID    string                     `json:"organizationId" binding:"required"`
+	CounterpartyID    string                     `json:"counterpartyId" binding:"required"`
+	ContractID        *string                    `json:"contractId,omitempty"`
+	WarehouseID       string                     `json:"warehouseId" binding:"required"`
+	ExpectedDate      *time.Time                 `json:"expectedDate,omitempty"`
+	CurrencyID        string                     `json:"currencyId,omitempty"`
+	AmountIncludesVAT bool                       `json:"amountIncludesVat"`
+	Description       string                     `json:"description,omitempty"`
+	BasisType         string                     `json:"basisType,omitempty"`
+	BasisID           *string                    `json:"basisId,omitempty"`
+	Lines             []PurchaseOrderLineRequest `json:"lines" binding:"required,min=1,dive"`
+	PostImmediately   bool                       `json:"postImmediately,omitempty"`
+}
+
+type PurchaseOrderLineRequest struct {
+	LineID          *string          `json:"lineId,omitempty"`
+	NomenclatureID  string           `json:"nomenclatureId" binding:"required"`
+	UnitID          string           `json:"unitId" binding:"required"`
+	Coefficient     decimal.Decimal  `json:"coefficient"`
+	Quantity        types.Quantity   `json:"quantity" binding:"required,gt=0"`
+	ExpectedDate    *time.Time       `json:"expectedDate,omitempty"`
+	UnitPrice       types.MinorUnits `json:"unitPrice" binding:"required,gte=0"`
+	VATRateID       string           `json:"vatRateId" binding:"required"`
+	VATPercent      int              `json:"vatPercent"`
+	DiscountPercent decimal.Decimal  `json:"discountPercent"`
+}
+
+// applyLineFields sets the expected date of the last added document line and,
+// on update, keeps the ID of an existing line so that goods receipts linked
+// to it stay linked.
+func (l PurchaseOrderLineRequest) applyLineFields(doc *purchase_order.PurchaseOrder, existing map[id.ID]struct{}) {
+	line := &doc.Lines[len(doc.Lines)-1]
+	line.ExpectedDate = l.ExpectedDate
+	if l.LineID == nil {
+		return
+	}
+	if lineID, err := id.Parse(*l.LineID); err == nil {
+		if _, ok := existing[lineID]; ok {
+			line.LineID = lineID
+			delete(existing, lineID) // a line ID may be kept only once
+		}
+	}
+}
+
+func (r *CreatePurchaseOrderRequest) ToEntity() *purchase_order.PurchaseOrder {
+	supplierID, _ := id.Parse(r.CounterpartyID)
+	warehouseID, _ := id.Parse(r.WarehouseID)
+
+	orgID, _ := id.Parse(r.OrganizationID)
+	doc := purchase_order.NewPurchaseOrder(orgID, supplierID, warehouseID)
+	doc.Number = r.Number
+	doc.Date = r.Date
+	doc.ExpectedDate = r.ExpectedDate
+	doc.AmountIncludesVAT = r.AmountIncludesVAT
+	doc.Description = r.Description
+	doc.BasisType = r.BasisType
+
+	if r.BasisID != nil {
+		basisID, _ := id.Parse(*r.BasisID)
+		doc.BasisID = &basisID
+	}
+
+	if r.ContractID != nil {
+		contractID, _ := id.Parse(*r.ContractID)
+		doc.ContractID = &contractID
+	}
+
+	if r.CurrencyID != "" {
+		currencyID, _ := id.Parse(r.CurrencyID)
+		doc.CurrencyID = currencyID
+	}
+
+	for _, line := range r.Lines {
+		nomenclatureID, _ := id.Parse(line.NomenclatureID)
+		unitID, _ := id.Parse(line.UnitID)
+		vatRateID, _ := id.Parse(line.VATRateID)
+		coefficient := line.Coefficient
+		if coefficient.IsZero() {
+			coefficient = decimal.NewFromInt(1)
+		}
+		doc.AddLine(nomenclatureID, unitID, coefficient, line.Quantity, line.UnitPrice, vatRateID, line.VATPercent, line.DiscountPercent)
+		line.applyLineFields(doc, nil)
+	}
+
+	return doc
+}
+
+type UpdatePurchaseOrderRequest struct {
+	Version           int                        `json:"version" binding:"required,min=1"`
+	Number            *string                    `json:"number,omitempty"`
+	Date              *time.Time                 `json:"date,omitempty"`
+	OrganizationID    *string                    `json:"organizationId,omitempty"`
+	CounterpartyID    *string                    `json:"counterpartyId,omitempty"`
+	ContractID        *string                    `json:"contractId,omitempty"`
+	WarehouseID       *string                    `json:"warehouseId,omitempty"`
+	ExpectedDate      *time.Time                 `json:"expectedDate,omitempty"`
+	CurrencyID        *string                    `json:"currencyId,omitempty"`
+	AmountIncludesVAT *bool                      `json:"amountIncludesVat,omitempty"`
+	Description       *string                    `json:"description,omitempty"`
+	BasisType         *string                    `json:"basisType,omitempty"`
+	BasisID           *string                    `json:"basisId,omitempty"`
+	Lines             []PurchaseOrderLineRequest `json:"lines,omitempty"`
+}
+
+// ApplyTo applies updates to an existing entity.
+// Sets the client-provided version on the entity so the repo performs
+// WHERE version = $client_version for optimistic locking.
+func (r *UpdatePurchaseOrderRequest) ApplyTo(doc *purchase_order.PurchaseOrder) {
+	doc.SetVersion(r.Version)
+	if r.Number != nil {
+		doc.Number = *r.Number
+	}
+	if r.Date != nil {
+		doc.Date = *r.Date
+	}
+	if r.OrganizationID != nil {
+		orgID, _ := id.Parse(*r.OrganizationID)
+		doc.OrganizationID = orgID
+	}
+	if r.CounterpartyID != nil {
+		supplierID, _ := id.Parse(*r.CounterpartyID)
+		doc.CounterpartyID = supplierID
+	}
+	if r.ContractID != nil {
+		contractID, _ := id.Parse(*r.ContractID)
+		doc.ContractID = &contractID
+	}
+	if r.WarehouseID != nil {
+		warehouseID, _ := id.Parse(*r.WarehouseID)
+		doc.WarehouseID = warehouseID
+	}
+	if r.ExpectedDate != nil {
+		doc.ExpectedDate = r.ExpectedDate
+	}
+	if r.CurrencyID != nil {
+		currencyID, _ := id.Parse(*r.CurrencyID)
+		doc.CurrencyID = currencyID
+	}
+	if r.AmountIncludesVAT != nil {
+		doc.AmountIncludesVAT = *r.AmountIncludesVAT
+	}
+	if r.Description != nil {
+		doc.Description = *r.Description
+	}
+	if r.BasisType != nil {
+		doc.BasisType = *r.BasisType
+	}
+	if r.BasisID != nil {
+		basisID, _ := id.Parse(*r.BasisID)
+		doc.BasisID = &basisID
+	}
+
+	if r.Lines != nil {
+		existing := make(map[id.ID]struct{}, len(doc.Lines))
+		for _, line := range doc.Lines {
+			existing[line.LineID] = struct{}{}
+		}
+		doc.Lines = make([]purchase_order.PurchaseOrderLine, 0, len(r.Lines))
+		for _, line := range r.Lines {
+			nomenclatureID, _ := id.Parse(line.NomenclatureID)
+			unitID, _ := id.Parse(line.UnitID)
+			vatRateID, _ := id.Parse(line.VATRateID)
+			coefficient := line.Coefficient
+			if coefficient.IsZero() {
+				coefficient = decimal.NewFromInt(1)
+			}
+			doc.AddLine(nomenclatureID, unitID, coefficient, line.Quantity, line.UnitPrice, vatRateID, line.VATPercent, line.DiscountPercent)
+			line.applyLineFields(doc, existing)
+		}
+	}
+}
+
+// --- Response DTOs ---
+
+type PurchaseOrderResponse struct {
+	ID                string                      `json:"id"`
+	Number            string                      `json:"number"`
+	Date              time.Time                   `json:"date"`
+	Posted            bool                        `json:"posted"`
+	PostedVersion     int                         `json:"postedVersion,omitempty"`
+	OrganizationID    string                      `json:"organizationId"`
+	CounterpartyID    string                      `json:"counterpartyId"`
+	ContractID        *string                     `json:"contractId,omitempty"`
+	WarehouseID       string                      `json:"warehouseId"`
+	ExpectedDate      *time.Time                  `json:"expectedDate,omitempty"`
+	FulfillmentStatus string                      `json:"fulfillmentStatus"`
+	CurrencyID        string                      `json:"currencyId"`
+	AmountIncludesVAT bool                        `json:"amountIncludesVat"`
+	TotalQuantity     types.Quantity              `json:"totalQuantity"`
+	TotalAmount       types.MinorUnits            `json:"totalAmount"`
+	TotalAmountBase   types.MinorUnits            `json:"totalAmountBase"`
+	TotalVAT          types.MinorUnits            `json:"totalVat"`
+	Description       string                      `json:"description,omitempty"`
+	BasisType         string                      `json:"basisType,omitempty"`
+	BasisID           *string                     `json:"basisId,omitempty"`
+	Lines             []PurchaseOrderLineResponse `json:"lines,omitempty"`
+	Version           int                         `json:"version"`
+	DeletionMark      bool                        `json:"deletionMark"`
+	CreatedAt         time.Time                   `json:"createdAt"`
+	UpdatedAt         time.Time                   `json:"updatedAt"`
+
+	// Resolved reference display names (populated by handler, not stored in DB)
+	Organization  *postgres.RefDisplay         `json:"organization,omitempty"`
+	Counterparty  *postgres.RefDisplay         `json:"counterparty,omitempty"`
+	Contract      *postgres.RefDisplay         `json:"contract,omitempty"`
+	Warehouse     *postgres.RefDisplay         `json:"warehouse,omitempty"`
+	Currency      *postgres.CurrencyRefDisplay `json:"currency,omitempty"`
+	CreatedByUser *postgres.RefDisplay         `json:"createdByUser,omitempty"`
+	UpdatedByUser *postgres.RefDisplay         `json:"updatedByUser,omitempty"`
+}
+
+type PurchaseOrderLineResponse struct {
+	LineID          string           `json:"lineId"`
+	LineNo          int              `json:"lineNo"`
+	NomenclatureID  string           `json:"nomenclatureId"`
+	UnitID          string           `json:"unitId"`
+	Coefficient     decimal.Decimal  `json:"coefficient"`
+	Quantity        types.Quantity   `json:"quantity"`
+	BaseQuantity    types.Quantity   `json:"baseQuantity"` // Quantity in base units of the nomenclature
+	ExpectedDate    *time.Time       `json:"expectedDate,omitempty"`
+	UnitPrice       types.MinorUnits `json:"unitPrice"`
+	DiscountPercent decimal.Decimal  `json:"discountPercent"`
+	DiscountAmount  types.MinorUnits `json:"discountAmount"`
+	VATRateID       string           `json:"vatRateId"`
+	VATPercent      int              `json:"vatPercent"`
+	VATAmount       types.MinorUnits `json:"vatAmount"`
+	Amount          types.MinorUnits `json:"amount"`
+
+	// Resolved reference display names
+	Nomenclature *postgres.RefDisplay `json:"nomenclature,omitempty"`
+	Unit         *postgres.RefDisplay `json:"unit,omitempty"`
+	VATRate      *postgres.RefDisplay `json:"vatRate,omitempty"`
+}
+
+// CollectPurchaseOrderRefs registers all reference IDs from a PurchaseOrder
+// into the resolver for batch resolution.
+func CollectPurchaseOrderRefs(resolver *postgres.ReferenceResolver, doc *purchase_order.PurchaseOrder) {
+	resolver.Add(TableOrganizations, doc.OrganizationID)
+	resolver.Add(TableCounterparties, doc.CounterpartyID)
+	resolver.AddPtr(TableContracts, doc.ContractID)
+	resolver.Add(TableWarehouses, doc.WarehouseID)
+	resolver.Add(TableCurrencies, doc.CurrencyID)
+	resolver.Add(TableUsers, doc.CreatedBy)
+	resolver.Add(TableUsers, doc.UpdatedBy)
+
+	for _, line := range doc.Lines {
+		resolver.Add(TableNomenclature, line.NomenclatureID)
+		resolver.Add(TableUnits, line.UnitID)
+		resolver.Add(TableVATRates, line.VATRateID)
+	}
+}
+
+// FromPurchaseOrder converts domain entity to response DTO.
+// Pass nil for refs if reference resolution is not needed.
+// Optional currencyRefs provides enriched currency display (decimalPlaces, symbol).
+func FromPurchaseOrder(doc *purchase_order.PurchaseOrder, refs postgres.ResolvedRefs, currencyRefs ...postgres.ResolvedCurrencyRefs) *PurchaseOrderResponse {
+	resp := &PurchaseOrderResponse{
+		ID:                doc.ID.String(),
+		Number:            doc.Number,
+		Date:              doc.Date,
+		Posted:            doc.Posted,
+		PostedVersion:     doc.PostedVersion,
+		OrganizationID:    doc.OrganizationID.String(),
+		CounterpartyID:    doc.CounterpartyID.String(),
+		WarehouseID:       doc.WarehouseID.String(),
+		ExpectedDate:      doc.ExpectedDate,
+		FulfillmentStatus: doc.FulfillmentStatus,
+		CurrencyID:        doc.CurrencyID.String(),
+		AmountIncludesVAT: doc.AmountIncludesVAT,
+		TotalQuantity:     doc.TotalQuantity,
+		TotalAmount:       doc.TotalAmount,
+		TotalAmountBase:   doc.TotalAmountBase,
+		TotalVAT:          doc.TotalVAT,
+		Description:       doc.Description,
+		BasisType:         doc.BasisType,
+		Version:           doc.Version,
+		DeletionMark:      doc.DeletionMark,
+		CreatedAt:         doc.CreatedAt,
+		UpdatedAt:         doc.UpdatedAt,
+	}
+
+	if doc.ContractID != nil {
+		s := doc.ContractID.String()
+		resp.ContractID = &s
+	}
+
+	if doc.BasisID != nil {
+		s := doc.BasisID.String()
+		resp.BasisID = &s
+	}
+
+	// Populate resolved reference display names
+	resolved := refs
+	if resolved != nil {
+		org := resolved.Get(TableOrganizations, doc.OrganizationID)
+		resp.Organization = &org
+		supp := resolved.Get(TableCounterparties, doc.CounterpartyID)
+		resp.Counterparty = &supp
+		wh := resolved.Get(TableWarehouses, doc.WarehouseID)
+		resp.Warehouse = &wh
+		if len(currencyRefs) > 0 && currencyRefs[0] != nil {
+			cr := currencyRefs[0].Get(doc.CurrencyID)
+			resp.Currency = &cr
+		} else {
+			generic := resolved.Get(TableCurrencies, doc.CurrencyID)
+			resp.Currency = &postgres.CurrencyRefDisplay{ID: generic.ID, Name: generic.Name, DecimalPlaces: 2}
+		}
+		resp.Contract = resolved.GetPtr(TableContracts, doc.ContractID)
+
+		createdBy := doc.CreatedBy
+		updatedBy := doc.UpdatedBy
+		resp.CreatedByUser = resolved.GetPtr(TableUsers, &createdBy)
+		resp.UpdatedByUser = resolved.GetPtr(TableUsers, &updatedBy)
+	}
+
+	resp.Lines = make([]PurchaseOrderLineResponse, len(doc.Lines))
+	for i, line := range doc.Lines {
+		lineResp := PurchaseOrderLineResponse{
+			LineID:          line.LineID.String(),
+			LineNo:          line.LineNo,
+			NomenclatureID:  line.NomenclatureID.String(),
+			UnitID:          line.UnitID.String(),
+			Coefficient:     line.Coefficient,
+			Quantity:        line.Quantity,
+			BaseQuantity:    line.BaseQuantity(),
+			ExpectedDate:    line.ExpectedDate,
+			UnitPrice:       line.UnitPrice,
+			DiscountPercent: line.DiscountPercent,
+			DiscountAmount:  line.DiscountAmount,
+			VATRateID:       line.VATRateID.String(),
+			VATAmount:       line.VATAmount,
+			Amount:          line.Amount,
+		}
+
+		if resolved != nil {
+			prod := resolved.Get(TableNomenclature, line.NomenclatureID)
+			lineResp.Nomenclature = &prod
+			unit := resolved.Get(TableUnits, line.UnitID)
+			lineResp.Unit = &unit
+			vr := resolved.Get(TableVATRates, line.VATRateID)
+			lineResp.VATRate = &vr
+		}
+
+		resp.Lines[i] = lineResp
+	}
+
+	return resp
+}
+
+type PurchaseOrderListResponse struct {
+	Items      []*PurchaseOrderResponse `json:"items"`
+	TotalCount int                      `json:"totalCount"`
+	Limit      int                      `json:"limit"`
+	Offset     int                      `json:"offset"`
+}
+
+// PurchaseOrderFulfillmentResponse is the per-line fulfillment of a purchase order.
+type PurchaseOrderFulfillmentResponse struct {
+	OrderID           string                         `json:"orderId"`
+	FulfillmentStatus string                         `json:"fulfillmentStatus"`
+	Lines             []PurchaseOrderFulfillmentLine `json:"lines"`
+}
+
+// PurchaseOrderFulfillmentLine reports ordered, received and remaining
+// quantities of an order line, in base units.
+type PurchaseOrderFulfillmentLine struct {
+	OrderLineID    string         `json:"orderLineId"`
+	NomenclatureID string         `json:"nomenclatureId"`
+	Ordered        types.Quantity `json:"ordered"`
+	Received       types.Quantity `json:"received"`
+	Remaining      types.Quantity `json:"remaining"`
+}
+
+// FromPurchaseOrderFulfillment converts register data to a fulfillment response.
+func FromPurchaseOrderFulfillment(doc *purchase_order.PurchaseOrder, lines []supplier_order.LineFulfillment) *PurchaseOrderFulfillmentResponse {
+	resp := &PurchaseOrderFulfillmentResponse{
+		OrderID:           doc.ID.String(),
+		FulfillmentStatus: doc.FulfillmentStatus,
+		Lines:             make([]PurchaseOrderFulfillmentLine, len(lines)),
+	}
+	for i, l := range lines {
+		resp.Lines[i] = PurchaseOrderFulfillmentLine{
+			OrderLineID:    l.OrderLineID.String(),
+			NomenclatureID: l.NomenclatureID.String(),
+			Ordered:        l.Ordered,
+			Received:       l.Received,
+			Remaining:      l.Remaining(),
+		}
+	}
+	return resp
+}
@@ -0,0 +1,130 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"metapus/internal/domain/recurring"
+)
+
+// CreateRecurringScheduleRequest is the request body for creating a recurring schedule.
+type CreateRecurringScheduleRequest struct {
+	Name          string                  `json:"name" binding:"required,max=255"`
+	TemplateID    uuid.UUID               `json:"templateId" binding:"required"`
+	CronExpr      string                  `json:"cronExpr" binding:"required,max=100"` // 6-field CRON or @monthly etc.
+	Timezone      string                  `json:"timezone,omitempty"`                  // default: UTC
+	Quantities    json.RawMessage         `json:"quantities,omitempty"`                // per template line, null skips a line
+	AutoPost      bool                    `json:"autoPost,omitempty"`
+	FailurePolicy recurring.FailurePolicy `json:"failurePolicy,omitempty"` // default: skip
+	Active        *bool                   `json:"active,omitempty"`        // default: true
+}
+
+// UpdateRecurringScheduleRequest is the request body for changing a recurring schedule.
+type UpdateRecurringScheduleRequest struct {
+	CreateRecurringScheduleRequest
+	Version int `json:"version" binding:"required"`
+}
+
+// ToSchedule maps the request to a domain Schedule of the document type.
+func (r *CreateRecurringScheduleRequest) ToSchedule(documentType string) *recurring.Schedule {
+	active := true
+	if r.Active != nil {
+		active = *r.Active
+	}
+	return &recurring.Schedule{
+		DocumentType:  documentType,
+		Name:          r.Name,
+		TemplateID:    r.TemplateID,
+		CronExpr:      r.CronExpr,
+		Timezone:      r.Timezone,
+		Quantities:    r.Quantities,
+		AutoPost:      r.AutoPost,
+		FailurePolicy: r.FailurePolicy,
+		Active:        active,
+	}
+}
+
+// RecurringScheduleResponse is the response DTO for a recurring schedule.
+type RecurringScheduleResponse struct {
+	ID            uuid.UUID               `json:"id"`
+	DocumentType  string                  `json:"documentType"`
+	Name          string                  `json:"name"`
+	TemplateID    uuid.UUID               `json:"templateId"`
+	CronExpr      string                  `json:"cronExpr"`
+	Timezone      string                  `json:"timezone"`
+	Quantities    json.RawMessage         `json:"quantities"`
+	AutoPost      bool                    `json:"autoPost"`
+	FailurePolicy recurring.FailurePolicy `json:"failurePolicy"`
+	Active        bool                    `json:"active"`
+	NextRunAt     *time.Time              `json:"nextRunAt"`
+	LastRunAt     *time.Time              `json:"lastRunAt"`
+	PendingCount  int                     `json:"pendingCount"`
+	AuthorID      *uuid.UUID              `json:"authorId"`
+	Version       int                     `json:"version"`
+	CreatedAt     time.Time               `json:"createdAt"`
+	UpdatedAt     time.Time               `json:"updatedAt"`
+}
+
+// MapRecurringScheduleResponse converts a domain Schedule to a response DTO.
+func MapRecurringScheduleResponse(s *recurring.Schedule) *RecurringScheduleResponse {
+	return &RecurringScheduleResponse{
+		ID:            s.ID,
+		DocumentType:  s.DocumentType,
+		Name:          s.Name,
+		TemplateID:    s.TemplateID,
+		CronExpr:      s.CronExpr,
+		Timezone:      s.Timezone,
+		Quantities:    s.Quantities,
+		AutoPost:      s.AutoPost,
+		FailurePolicy: s.FailurePolicy,
+		Active:        s.Active,
+		NextRunAt:     s.NextRunAt,
+		LastRunAt:     s.LastRunAt,
+		PendingCount:  s.PendingCount,
+		AuthorID:      s.AuthorID,
+		Version:       s.Version,
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+	}
+}
+
+// MapRecurringScheduleListResponse converts a list of schedules to response DTOs.
+func MapRecurringScheduleListResponse(list []*recurring.Schedule) []*RecurringScheduleResponse {
+	result := make([]*RecurringScheduleResponse, len(list))
+	for i, s := range list {
+		result[i] = MapRecurringScheduleResponse(s)
+	}
+	return result
+}
+
+// RecurringRunResponse is the response DTO for one run of a recurring schedule.
+type RecurringRunResponse struct {
+	ID           uuid.UUID           `json:"id"`
+	ScheduledFor time.Time           `json:"scheduledFor"`
+	Occurrences  int                 `json:"occurrences"`
+	Status       recurring.RunStatus `json:"status"`
+	DocumentID   *uuid.UUID          `json:"documentId"`
+	Error        string              `json:"error,omitempty"`
+	StartedAt    time.Time           `json:"startedAt"`
+	FinishedAt   time.Time           `json:"finishedAt"`
+}
+
+// MapRecurringRunListResponse converts schedule runs to response DTOs.
+func MapRecurringRunListResponse(list []*recurring.Run) []*RecurringRunResponse {
+	result := make([]*RecurringRunResponse, len(list))
+	for i, r := range list {
+		result[i] = &RecurringRunResponse{
+			ID:           r.ID,
+			ScheduledFor: r.ScheduledFor,
+			Occurrences:  r.Occurrences,
+			Status:       r.Status,
+			DocumentID:   r.DocumentID,
+			Error:        r.Error,
+			StartedAt:    r.StartedAt,
+			FinishedAt:   r.FinishedAt,
+		}
+	}
+	return result
+}
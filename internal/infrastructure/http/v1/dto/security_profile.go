@@ -197,6 +197,11 @@ type AssignProfileRequest struct {
 	UserID string `json:"userId" binding:"required,uuid"`
 }
 
+// AssignProfileRoleRequest is the request for assigning a role to a security profile.
+type AssignProfileRoleRequest struct {
+	RoleID string `json:"roleId" binding:"required,uuid"`
+}
+
 // --- User list response for admin ---
 
 // UserListItem represents a user in the admin user list.
@@ -220,14 +225,31 @@ type ProfileUserItem struct {
 	FullName string `json:"fullName"`
 }
 
+// ProfileRoleItem represents a role assigned to a specific profile.
+type ProfileRoleItem struct {
+	ID   string `json:"id"`
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
 // --- Audit log response ---
 
 // AuditEntryResponse is the API representation of a single audit log entry.
 type AuditEntryResponse struct {
-	ID        string         `json:"id"`
-	Action    string         `json:"action"`
-	UserID    string         `json:"userId,omitempty"`
-	UserEmail string         `json:"userEmail,omitempty"`
-	Changes   map[string]any `json:"changes,omitempty"`
-	CreatedAt time.Time      `json:"createdAt"`
+	ID         string         `json:"id"`
+	EntityType string         `json:"entityType,omitempty"`
+	EntityID   string         `json:"entityId,omitempty"`
+	Action     string         `json:"action"`
+	UserID     string         `json:"userId,omitempty"`
+	UserEmail  string         `json:"userEmail,omitempty"`
+	Changes    map[string]any `json:"changes,omitempty"`
+	TraceID    string         `json:"traceId,omitempty"`
+	CreatedAt  time.Time      `json:"createdAt"`
+}
+
+// AuditListResponse is a page of the audit trail.
+type AuditListResponse struct {
+	Items      []AuditEntryResponse `json:"items"`
+	NextCursor string               `json:"nextCursor,omitempty"`
+	HasMore    bool                 `json:"hasMore"`
 }
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"metapus/internal/domain/registers/serial_number"
+)
+
+// SerialNumberResponse is a serial in stock.
+type SerialNumberResponse struct {
+	NomenclatureID string    `json:"nomenclatureId"`
+	SerialNumber   string    `json:"serialNumber"`
+	WarehouseID    *string   `json:"warehouseId,omitempty"` // Warehouse of the last receipt
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// FromSerialNumber converts a pool row to response DTO.
+func FromSerialNumber(s serial_number.Serial) SerialNumberResponse {
+	resp := SerialNumberResponse{
+		NomenclatureID: s.NomenclatureID.String(),
+		SerialNumber:   s.SerialNumber,
+		UpdatedAt:      s.UpdatedAt,
+	}
+	if s.WarehouseID != nil {
+		warehouseID := s.WarehouseID.String()
+		resp.WarehouseID = &warehouseID
+	}
+	return resp
+}
+
+// SerialHistoryEntryResponse is one document movement of a serial.
+type SerialHistoryEntryResponse struct {
+	Period         time.Time `json:"period"`
+	RecordType     string    `json:"recordType"`
+	DocumentID     string    `json:"documentId"`
+	DocumentType   string    `json:"documentType"`
+	DocumentNumber string    `json:"documentNumber"`
+	WarehouseID    string    `json:"warehouseId"`
+}
+
+// FromSerialHistoryEntry converts a history entry to response DTO.
+func FromSerialHistoryEntry(e serial_number.HistoryEntry) SerialHistoryEntryResponse {
+	return SerialHistoryEntryResponse{
+		Period:         e.Period,
+		RecordType:     string(e.RecordType),
+		DocumentID:     e.RecorderID.String(),
+		DocumentType:   e.RecorderType,
+		DocumentNumber: e.DocumentNumber,
+		WarehouseID:    e.WarehouseID.String(),
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"metapus/internal/core/entity"
 	"metapus/internal/core/id"
+	"metapus/internal/core/types"
 	"metapus/internal/domain/registers/stock"
 )
 
@@ -102,8 +103,327 @@ type StockBalanceListResponse struct {
 	Items []StockBalanceResponse `json:"items"`
 }
 
-// StockMovementListResponse represents a list of stock movements.
+// StockMovementListResponse represents a keyset page of stock movements.
 type StockMovementListResponse struct {
-	Items      []StockMovementResponse `json:"items"`
-	TotalCount int                     `json:"totalCount,omitempty"`
+	Items      []StockMovementResponse     `json:"items"`
+	NextCursor string                      `json:"nextCursor,omitempty"`
+	HasMore    bool                        `json:"hasMore"`
+	TotalCount int64                       `json:"totalCount"`
+	Totals     StockMovementTotalsResponse `json:"totals"`
+}
+
+// StockMovementTotalsResponse is the footer over all movements matching the filter.
+type StockMovementTotalsResponse struct {
+	Receipt float64 `json:"receipt"`
+	Expense float64 `json:"expense"`
+	Net     float64 `json:"net"`
+}
+
+// FromStockMovementHistory converts a movement history page to response DTO.
+func FromStockMovementHistory(h stock.MovementHistory) StockMovementListResponse {
+	items := make([]StockMovementResponse, len(h.Items))
+	for i, m := range h.Items {
+		items[i] = FromStockMovement(m)
+	}
+	return StockMovementListResponse{
+		Items:      items,
+		NextCursor: h.NextCursor,
+		HasMore:    h.HasMore,
+		TotalCount: h.Totals.Count,
+		Totals: StockMovementTotalsResponse{
+			Receipt: h.Totals.Receipt.Float64(),
+			Expense: h.Totals.Expense.Float64(),
+			Net:     h.Totals.Net().Float64(),
+		},
+	}
+}
+
+// --- Stock Correction (admin) ---
+
+// StockCorrectionRequest is the body for stock correction preview/apply.
+type StockCorrectionRequest struct {
+	RecorderIDs          []string `json:"recorderIds" binding:"required,min=1,dive,uuid"`
+	WarehouseID          *string  `json:"warehouseId,omitempty" binding:"omitempty,uuid"`
+	NomenclatureID       *string  `json:"nomenclatureId,omitempty" binding:"omitempty,uuid"`
+	TargetWarehouseID    *string  `json:"targetWarehouseId,omitempty" binding:"omitempty,uuid"`
+	TargetNomenclatureID *string  `json:"targetNomenclatureId,omitempty" binding:"omitempty,uuid"`
+	Reason               string   `json:"reason" binding:"required,max=1000"`
+}
+
+// ToDomain converts DTO to the domain correction request.
+func (r *StockCorrectionRequest) ToDomain() stock.CorrectionRequest {
+	recorderIDs := make([]id.ID, 0, len(r.RecorderIDs))
+	for _, s := range r.RecorderIDs {
+		if parsed, err := id.Parse(s); err == nil {
+			recorderIDs = append(recorderIDs, parsed)
+		}
+	}
+	return stock.CorrectionRequest{
+		Filter: stock.CorrectionFilter{
+			RecorderIDs:    recorderIDs,
+			WarehouseID:    stringPtrToIDPtr(r.WarehouseID),
+			NomenclatureID: stringPtrToIDPtr(r.NomenclatureID),
+		},
+		TargetWarehouseID:    stringPtrToIDPtr(r.TargetWarehouseID),
+		TargetNomenclatureID: stringPtrToIDPtr(r.TargetNomenclatureID),
+		Reason:               r.Reason,
+	}
+}
+
+// StockBalanceChangeResponse is the projected balance impact of a correction.
+type StockBalanceChangeResponse struct {
+	WarehouseID    string  `json:"warehouseId"`
+	NomenclatureID string  `json:"nomenclatureId"`
+	Current        float64 `json:"current"`
+	Delta          float64 `json:"delta"`
+	Resulting      float64 `json:"resulting"`
+}
+
+// StockCorrectionPlanResponse is the preview (or applied) correction plan.
+type StockCorrectionPlanResponse struct {
+	Movements      []StockMovementResponse      `json:"movements"`
+	Adjustments    []StockMovementResponse      `json:"adjustments"`
+	BalanceChanges []StockBalanceChangeResponse `json:"balanceChanges"`
+}
+
+// FromStockCorrectionPlan converts domain plan to response DTO.
+func FromStockCorrectionPlan(p *stock.CorrectionPlan) StockCorrectionPlanResponse {
+	resp := StockCorrectionPlanResponse{
+		Movements:      make([]StockMovementResponse, len(p.Movements)),
+		Adjustments:    make([]StockMovementResponse, len(p.Adjustments)),
+		BalanceChanges: make([]StockBalanceChangeResponse, len(p.BalanceChanges)),
+	}
+	for i, m := range p.Movements {
+		resp.Movements[i] = FromStockMovement(m)
+	}
+	for i, m := range p.Adjustments {
+		resp.Adjustments[i] = FromStockMovement(m)
+	}
+	for i, bc := range p.BalanceChanges {
+		resp.BalanceChanges[i] = StockBalanceChangeResponse{
+			WarehouseID:    bc.WarehouseID.String(),
+			NomenclatureID: bc.NomenclatureID.String(),
+			Current:        bc.Current.Float64(),
+			Delta:          bc.Delta.Float64(),
+			Resulting:      bc.Resulting.Float64(),
+		}
+	}
+	return resp
+}
+
+// StockCorrectionResponse represents an applied correction document.
+type StockCorrectionResponse struct {
+	ID                   string    `json:"id"`
+	Reason               string    `json:"reason"`
+	RecorderIDs          []string  `json:"recorderIds"`
+	WarehouseID          *string   `json:"warehouseId,omitempty"`
+	NomenclatureID       *string   `json:"nomenclatureId,omitempty"`
+	TargetWarehouseID    *string   `json:"targetWarehouseId,omitempty"`
+	TargetNomenclatureID *string   `json:"targetNomenclatureId,omitempty"`
+	MovementCount        int       `json:"movementCount"`
+	CreatedBy            string    `json:"createdBy,omitempty"`
+	CreatedAt            time.Time `json:"createdAt"`
+}
+
+// FromStockCorrection converts domain correction to response DTO.
+func FromStockCorrection(c stock.Correction) StockCorrectionResponse {
+	recorderIDs := make([]string, len(c.Filter.RecorderIDs))
+	for i, rid := range c.Filter.RecorderIDs {
+		recorderIDs[i] = rid.String()
+	}
+	return StockCorrectionResponse{
+		ID:                   c.ID.String(),
+		Reason:               c.Reason,
+		RecorderIDs:          recorderIDs,
+		WarehouseID:          idToStringPtr(c.Filter.WarehouseID),
+		NomenclatureID:       idToStringPtr(c.Filter.NomenclatureID),
+		TargetWarehouseID:    idToStringPtr(c.TargetWarehouseID),
+		TargetNomenclatureID: idToStringPtr(c.TargetNomenclatureID),
+		MovementCount:        c.MovementCount,
+		CreatedBy:            c.CreatedBy,
+		CreatedAt:            c.CreatedAt,
+	}
+}
+
+// ApplyStockCorrectionResponse is returned after a correction is applied.
+type ApplyStockCorrectionResponse struct {
+	Correction StockCorrectionResponse     `json:"correction"`
+	Plan       StockCorrectionPlanResponse `json:"plan"`
+}
+
+// --- Inventory counts ---
+
+// StartInventoryCountRequest is the body for starting an inventory count.
+// Empty nomenclatureIds counts the whole warehouse.
+type StartInventoryCountRequest struct {
+	WarehouseID     string   `json:"warehouseId" binding:"required,uuid"`
+	NomenclatureIDs []string `json:"nomenclatureIds,omitempty" binding:"omitempty,dive,uuid"`
+	Comment         string   `json:"comment,omitempty" binding:"max=1000"`
+}
+
+// ParseIDs returns the parsed warehouse and nomenclature IDs.
+func (r *StartInventoryCountRequest) ParseIDs() (id.ID, []id.ID) {
+	warehouseID, _ := id.Parse(r.WarehouseID)
+	nomenclatureIDs := make([]id.ID, 0, len(r.NomenclatureIDs))
+	for _, s := range r.NomenclatureIDs {
+		if parsed, err := id.Parse(s); err == nil {
+			nomenclatureIDs = append(nomenclatureIDs, parsed)
+		}
+	}
+	return warehouseID, nomenclatureIDs
+}
+
+// InventoryCountResponse represents an inventory count.
+type InventoryCountResponse struct {
+	ID              string     `json:"id"`
+	WarehouseID     string     `json:"warehouseId"`
+	NomenclatureIDs []string   `json:"nomenclatureIds"`
+	Status          string     `json:"status"`
+	Comment         string     `json:"comment,omitempty"`
+	StartedBy       string     `json:"startedBy,omitempty"`
+	StartedAt       time.Time  `json:"startedAt"`
+	FinishedAt      *time.Time `json:"finishedAt,omitempty"`
+}
+
+// FromInventoryCount converts domain inventory count to response DTO.
+func FromInventoryCount(c stock.InventoryCount) InventoryCountResponse {
+	nomenclatureIDs := make([]string, len(c.NomenclatureIDs))
+	for i, nid := range c.NomenclatureIDs {
+		nomenclatureIDs[i] = nid.String()
+	}
+	return InventoryCountResponse{
+		ID:              c.ID.String(),
+		WarehouseID:     c.WarehouseID.String(),
+		NomenclatureIDs: nomenclatureIDs,
+		Status:          c.Status,
+		Comment:         c.Comment,
+		StartedBy:       c.StartedBy,
+		StartedAt:       c.StartedAt,
+		FinishedAt:      c.FinishedAt,
+	}
+}
+
+// StockRecalculateRequest is the body for a stock balance rebuild.
+// Without warehouse/nomenclature the whole register is rebuilt.
+type StockRecalculateRequest struct {
+	WarehouseID    *string `json:"warehouseId,omitempty" binding:"omitempty,uuid"`
+	NomenclatureID *string `json:"nomenclatureId,omitempty" binding:"omitempty,uuid"`
+	// DryRun only reports the discrepancies.
+	DryRun bool `json:"dryRun"`
+	// Async queues the rebuild for the worker instead of running it in the request.
+	Async bool `json:"async"`
+}
+
+// ToDomain converts DTO to the domain recalculation filter.
+func (r *StockRecalculateRequest) ToDomain() stock.RecalculateFilter {
+	return stock.RecalculateFilter{
+		WarehouseID:    stringPtrToIDPtr(r.WarehouseID),
+		NomenclatureID: stringPtrToIDPtr(r.NomenclatureID),
+	}
+}
+
+// StockBalanceDiscrepancyResponse is a stored balance that differed from its movements.
+type StockBalanceDiscrepancyResponse struct {
+	WarehouseID    string  `json:"warehouseId"`
+	NomenclatureID string  `json:"nomenclatureId"`
+	Stored         float64 `json:"stored"`
+	Computed       float64 `json:"computed"`
+	Delta          float64 `json:"delta"`
+}
+
+// StockRecalculationResponse is the result of a balance rebuild.
+type StockRecalculationResponse struct {
+	Applied       bool                              `json:"applied"`
+	Discrepancies []StockBalanceDiscrepancyResponse `json:"discrepancies"`
+}
+
+// FromStockRecalculation converts domain recalculation to response DTO.
+func FromStockRecalculation(r *stock.Recalculation) StockRecalculationResponse {
+	resp := StockRecalculationResponse{
+		Applied:       r.Applied,
+		Discrepancies: make([]StockBalanceDiscrepancyResponse, len(r.Discrepancies)),
+	}
+	for i, d := range r.Discrepancies {
+		resp.Discrepancies[i] = StockBalanceDiscrepancyResponse{
+			WarehouseID:    d.WarehouseID.String(),
+			NomenclatureID: d.NomenclatureID.String(),
+			Stored:         d.Stored.Float64(),
+			Computed:       d.Computed.Float64(),
+			Delta:          d.Delta().Float64(),
+		}
+	}
+	return resp
+}
+
+// UpsertStockLevelRequest is the body for setting the stock level of a
+// nomenclature in a warehouse.
+type UpsertStockLevelRequest struct {
+	WarehouseID    string   `json:"warehouseId" binding:"required,uuid"`
+	NomenclatureID string   `json:"nomenclatureId" binding:"required,uuid"`
+	MinQuantity    float64  `json:"minQuantity"`
+	MaxQuantity    *float64 `json:"maxQuantity,omitempty"`
+}
+
+// ToDomain converts DTO to the domain stock level.
+func (r *UpsertStockLevelRequest) ToDomain() stock.Level {
+	level := stock.Level{MinQuantity: types.NewQuantityFromFloat64(r.MinQuantity)}
+	if v := stringPtrToIDPtr(&r.WarehouseID); v != nil {
+		level.WarehouseID = *v
+	}
+	if v := stringPtrToIDPtr(&r.NomenclatureID); v != nil {
+		level.NomenclatureID = *v
+	}
+	if r.MaxQuantity != nil {
+		maxQty := types.NewQuantityFromFloat64(*r.MaxQuantity)
+		level.MaxQuantity = &maxQty
+	}
+	return level
+}
+
+// StockLevelResponse is the stock level of a nomenclature in a warehouse.
+type StockLevelResponse struct {
+	WarehouseID    string    `json:"warehouseId"`
+	NomenclatureID string    `json:"nomenclatureId"`
+	MinQuantity    float64   `json:"minQuantity"`
+	MaxQuantity    *float64  `json:"maxQuantity,omitempty"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// FromStockLevel converts domain level to response DTO.
+func FromStockLevel(l stock.Level) StockLevelResponse {
+	resp := StockLevelResponse{
+		WarehouseID:    l.WarehouseID.String(),
+		NomenclatureID: l.NomenclatureID.String(),
+		MinQuantity:    l.MinQuantity.Float64(),
+		UpdatedAt:      l.UpdatedAt,
+	}
+	if l.MaxQuantity != nil {
+		maxQty := l.MaxQuantity.Float64()
+		resp.MaxQuantity = &maxQty
+	}
+	return resp
+}
+
+// LowStockAlertResponse is an active low-stock alert.
+type LowStockAlertResponse struct {
+	WarehouseID      string    `json:"warehouseId"`
+	WarehouseName    string    `json:"warehouseName"`
+	NomenclatureID   string    `json:"nomenclatureId"`
+	NomenclatureName string    `json:"nomenclatureName"`
+	Quantity         float64   `json:"quantity"`
+	MinQuantity      float64   `json:"minQuantity"`
+	RaisedAt         time.Time `json:"raisedAt"`
+}
+
+// FromLowStockAlert converts domain alert to response DTO.
+func FromLowStockAlert(a stock.LowStockAlert) LowStockAlertResponse {
+	return LowStockAlertResponse{
+		WarehouseID:      a.WarehouseID.String(),
+		WarehouseName:    a.WarehouseName,
+		NomenclatureID:   a.NomenclatureID.String(),
+		NomenclatureName: a.NomenclatureName,
+		Quantity:         a.Quantity.Float64(),
+		MinQuantity:      a.MinQuantity.Float64(),
+		RaisedAt:         a.RaisedAt,
+	}
 }
@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"metapus/internal/core/entity"
+	"metapus/internal/domain/catalogs/webhook_subscription"
+)
+
+// --- Request DTOs ---
+
+// CreateWebhookSubscriptionRequest is the request body for creating a webhook subscription.
+type CreateWebhookSubscriptionRequest struct {
+	Code       string            `json:"code"`
+	Name       string            `json:"name" binding:"required"`
+	URL        string            `json:"url" binding:"required"`
+	Secret     string            `json:"secret" binding:"required,min=16"`
+	EventTypes []string          `json:"eventTypes"`
+	IsActive   *bool             `json:"isActive"`
+	Attributes entity.Attributes `json:"attributes"`
+}
+
+// ToEntity converts DTO to domain entity.
+func (r *CreateWebhookSubscriptionRequest) ToEntity() *webhook_subscription.WebhookSubscription {
+	s := webhook_subscription.NewWebhookSubscription(r.Code, r.Name, r.URL, r.Secret, r.EventTypes)
+	if r.IsActive != nil {
+		s.IsActive = *r.IsActive
+	}
+	s.Attributes = r.Attributes
+	return s
+}
+
+// UpdateWebhookSubscriptionRequest is the request body for updating a webhook subscription.
+// An empty Secret keeps the current one.
+type UpdateWebhookSubscriptionRequest struct {
+	Code       string            `json:"code"`
+	Name       string            `json:"name" binding:"required"`
+	URL        string            `json:"url" binding:"required"`
+	Secret     string            `json:"secret" binding:"omitempty,min=16"`
+	EventTypes []string          `json:"eventTypes"`
+	IsActive   bool              `json:"isActive"`
+	Attributes entity.Attributes `json:"attributes"`
+	Version    int               `json:"version" binding:"required"`
+}
+
+// ApplyTo applies update DTO to existing entity.
+func (r *UpdateWebhookSubscriptionRequest) ApplyTo(s *webhook_subscription.WebhookSubscription) {
+	s.Code = r.Code
+	s.Name = r.Name
+	s.URL = r.URL
+	if r.Secret != "" {
+		s.Secret = r.Secret
+	}
+	s.EventTypes = r.EventTypes
+	s.IsActive = r.IsActive
+	s.Attributes = r.Attributes
+	s.Version = r.Version
+}
+
+// --- Response DTOs ---
+
+// WebhookSubscriptionResponse is the response body for a webhook subscription.
+// The signing secret is write-only.
+type WebhookSubscriptionResponse struct {
+	ID           string            `json:"id"`
+	Code         string            `json:"code"`
+	Name         string            `json:"name"`
+	URL          string            `json:"url"`
+	EventTypes   []string          `json:"eventTypes"`
+	IsActive     bool              `json:"isActive"`
+	DeletionMark bool              `json:"deletionMark"`
+	Version      int               `json:"version"`
+	Attributes   entity.Attributes `json:"attributes,omitempty"`
+}
+
+// FromWebhookSubscription creates response DTO from domain entity.
+func FromWebhookSubscription(s *webhook_subscription.WebhookSubscription) *WebhookSubscriptionResponse {
+	eventTypes := s.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return &WebhookSubscriptionResponse{
+		ID:           s.ID.String(),
+		Code:         s.Code,
+		Name:         s.Name,
+		URL:          s.URL,
+		EventTypes:   eventTypes,
+		IsActive:     s.IsActive,
+		DeletionMark: s.DeletionMark,
+		Version:      s.Version,
+		Attributes:   s.Attributes,
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/accountexport"
+)
+
+// AccountExportHandler serves full account exports (/system/account-export).
+type AccountExportHandler struct {
+	*BaseHandler
+	svc *accountexport.Service
+}
+
+// NewAccountExportHandler creates a new handler.
+func NewAccountExportHandler(base *BaseHandler, svc *accountexport.Service) *AccountExportHandler {
+	return &AccountExportHandler{
+		BaseHandler: base,
+		svc:         svc,
+	}
+}
+
+// accountExportResponse adds a signed download link to a completed export.
+type accountExportResponse struct {
+	*accountexport.Export
+	DownloadURL       string     `json:"downloadUrl,omitempty"`
+	DownloadExpiresAt *time.Time `json:"downloadExpiresAt,omitempty"`
+}
+
+// RegisterRoutes wires the admin routes under the provided (admin-only) group.
+func (h *AccountExportHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/account-export", h.Start)
+	rg.GET("/account-export", h.List)
+	rg.GET("/account-export/:id", h.Get)
+}
+
+// Start godoc
+//
+//	@Summary     Start a full account export
+//	@Description Builds a zip archive of all catalogs, documents, register movements, users and roles in the background
+//	@Tags        system
+//	@Produce     json
+//	@Success     202  {object} accountexport.Export
+//	@Router      /system/account-export [post]
+func (h *AccountExportHandler) Start(c *gin.Context) {
+	e, err := h.svc.Start(c.Request.Context())
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, e)
+}
+
+// List godoc
+//
+//	@Summary     List account exports
+//	@Tags        system
+//	@Produce     json
+//	@Param       limit query int false "Page size (default 20)"
+//	@Router      /system/account-export [get]
+func (h *AccountExportHandler) List(c *gin.Context) {
+	items, err := h.svc.List(c.Request.Context(), h.ParseIntQuery(c, "limit", 20))
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	if items == nil {
+		items = []accountexport.Export{}
+	}
+	h.OK(c, gin.H{"items": items})
+}
+
+// Get godoc
+//
+//	@Summary     Get account export status
+//	@Description Returns the export; completed exports include a short-lived signed download link
+//	@Tags        system
+//	@Produce     json
+//	@Param       id path string true "Export ID"
+//	@Router      /system/account-export/{id} [get]
+func (h *AccountExportHandler) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	exportID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid export ID"))
+		return
+	}
+
+	e, err := h.svc.Get(ctx, exportID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	resp := accountExportResponse{Export: e}
+	if url, expires := h.svc.DownloadURL(ctx, e); url != "" {
+		resp.DownloadURL = url
+		resp.DownloadExpiresAt = &expires
+	}
+	h.OK(c, resp)
+}
+
+// Download serves the archive for a signed link. Registered outside the
+// JWT-protected group: the signature is the credential.
+func (h *AccountExportHandler) Download(c *gin.Context) {
+	exportID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid export ID"))
+		return
+	}
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		h.Error(c, apperror.NewForbidden("download link is invalid or expired"))
+		return
+	}
+
+	fileName, data, err := h.svc.Download(c.Request.Context(), exportID, expires, c.Query("sig"))
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", contentDisposition(sanitizeFilename(strings.TrimSuffix(fileName, ".zip")), "zip"))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/zip", data)
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/accountimport"
+)
+
+// maxImportArchiveSize bounds uploaded archives (stored inline as BYTEA).
+const maxImportArchiveSize = 512 << 20
+
+// AccountImportHandler serves account imports (/system/account-import).
+type AccountImportHandler struct {
+	*BaseHandler
+	svc *accountimport.Service
+}
+
+// NewAccountImportHandler creates a new handler.
+func NewAccountImportHandler(base *BaseHandler, svc *accountimport.Service) *AccountImportHandler {
+	return &AccountImportHandler{
+		BaseHandler: base,
+		svc:         svc,
+	}
+}
+
+// RegisterRoutes wires the routes under the provided (admin-only) group.
+func (h *AccountImportHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/account-import", h.Upload)
+	rg.GET("/account-import", h.List)
+	rg.GET("/account-import/:id", h.Get)
+	rg.POST("/account-import/:id/run", h.Run)
+}
+
+// Upload godoc
+//
+//	@Summary     Upload an account export archive
+//	@Description Validates the archive against this tenant and returns the validation report. The request body is the zip archive.
+//	@Tags        system
+//	@Accept      application/zip
+//	@Produce     json
+//	@Success     201  {object} accountimport.Import
+//	@Router      /system/account-import [post]
+func (h *AccountImportHandler) Upload(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportArchiveSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.Error(c, apperror.NewValidation("archive exceeds the maximum upload size"))
+			return
+		}
+		h.Error(c, apperror.NewValidation("failed to read archive"))
+		return
+	}
+	if len(body) == 0 {
+		h.Error(c, apperror.NewValidation("request body must contain the zip archive"))
+		return
+	}
+
+	imp, err := h.svc.Upload(c.Request.Context(), body)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, imp)
+}
+
+// List godoc
+//
+//	@Summary     List account imports
+//	@Tags        system
+//	@Produce     json
+//	@Param       limit query int false "Page size (default 20)"
+//	@Router      /system/account-import [get]
+func (h *AccountImportHandler) List(c *gin.Context) {
+	items, err := h.svc.List(c.Request.Context(), h.ParseIntQuery(c, "limit", 20))
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	if items == nil {
+		items = []accountimport.Import{}
+	}
+	h.OK(c, gin.H{"items": items})
+}
+
+// Get godoc
+//
+//	@Summary     Get account import status and report
+//	@Tags        system
+//	@Produce     json
+//	@Param       id path string true "Import ID"
+//	@Router      /system/account-import/{id} [get]
+func (h *AccountImportHandler) Get(c *gin.Context) {
+	importID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid import ID"))
+		return
+	}
+
+	imp, err := h.svc.Get(c.Request.Context(), importID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.OK(c, imp)
+}
+
+// Run godoc
+//
+//	@Summary     Run or resume an account import
+//	@Description Starts a validated import, or resumes a failed one from the first unfinished table
+//	@Tags        system
+//	@Produce     json
+//	@Param       id path string true "Import ID"
+//	@Success     202  {object} accountimport.Import
+//	@Router      /system/account-import/{id}/run [post]
+func (h *AccountImportHandler) Run(c *gin.Context) {
+	importID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid import ID"))
+		return
+	}
+
+	imp, err := h.svc.Run(c.Request.Context(), importID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, imp)
+}
@@ -1,13 +1,20 @@
 package handlers
 
 import (
+	"net"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"metapus/internal/core/apperror"
+	appctx "metapus/internal/core/context"
 	"metapus/internal/core/tenant"
 	"metapus/internal/core/version"
+	"metapus/internal/infrastructure/http/v1/middleware"
 	"metapus/internal/infrastructure/storage/postgres/migration"
+	"metapus/pkg/logger"
 )
 
 // AdminTenantHandler provides Cloud Control Plane endpoints
@@ -19,6 +26,13 @@ type AdminTenantHandler struct {
 	base     *BaseHandler
 	registry tenant.Registry
 	updater  *migration.TenantUpdater
+	mover    *migration.RegionMover
+	audit    tenant.AuditLog // nil disables the audit trail
+
+	// manager enables the lifecycle endpoints (create, suspend, activate,
+	// plan change); defaults is the placement of tenants created without one.
+	manager  *tenant.Manager
+	defaults tenant.Placement
 }
 
 // NewAdminTenantHandler creates an admin handler for tenant management.
@@ -26,34 +40,108 @@ func NewAdminTenantHandler(base *BaseHandler, registry tenant.Registry, updater
 	return &AdminTenantHandler{base: base, registry: registry, updater: updater}
 }
 
+// SetRegionMover enables the region move endpoint.
+func (h *AdminTenantHandler) SetRegionMover(mover *migration.RegionMover) {
+	h.mover = mover
+}
+
+// SetAuditLog enables the meta-level audit trail of admin actions and the
+// audit query endpoint.
+func (h *AdminTenantHandler) SetAuditLog(audit tenant.AuditLog) {
+	h.audit = audit
+}
+
+// updaterAgentActor is the audit actor of the internal (Updater Agent) endpoints.
+const updaterAgentActor = "updater-agent"
+
+// record appends an admin action to the audit trail. The action has already
+// happened, so a failed write is logged rather than failing the request.
+func (h *AdminTenantHandler) record(c *gin.Context, actor string, e tenant.AuditEntry) {
+	if h.audit == nil {
+		return
+	}
+	e.Actor = actor
+	if e.Actor == "" {
+		e.Actor = auditActor(c)
+	}
+	if err := h.audit.Record(c.Request.Context(), &e); err != nil {
+		logger.Warn(c.Request.Context(), "tenant audit: record failed",
+			"action", e.Action, "tenant_id", e.TenantID, "error", err)
+	}
+}
+
+// auditActor identifies the authenticated admin user (or control-plane
+// operator) of the request.
+func auditActor(c *gin.Context) string {
+	if operator := middleware.GetAdminOperator(c.Request.Context()); operator != "" {
+		return "admin:" + operator
+	}
+	user := appctx.GetUser(c.Request.Context())
+	if user == nil {
+		return "unknown"
+	}
+	actor := "user:" + user.TenantID + "/" + user.UserID
+	if user.Email != "" {
+		actor += " " + user.Email
+	}
+	return actor
+}
+
 // TenantSummary is the response DTO for tenant list and details.
 type TenantSummary struct {
 	ID            string `json:"id"`
 	Slug          string `json:"slug"`
 	DisplayName   string `json:"displayName"`
 	DBName        string `json:"dbName"`
+	DBSchema      string `json:"dbSchema,omitempty"`
 	Status        string `json:"status"`
 	Plan          string `json:"plan"`
 	SchemaVersion int    `json:"schemaVersion"`
 	VersionGroup  string `json:"versionGroup"`
+	Region        string `json:"region"`
+	Cluster       string `json:"cluster"`
+	DBHost        string `json:"dbHost"`
+	DBPort        int    `json:"dbPort"`
 	CreatedAt     string `json:"createdAt"`
 	UpdatedAt     string `json:"updatedAt"`
+	// Emergency read-only mode; the reason is empty while the mode is off.
+	ReadOnly       bool   `json:"readOnly"`
+	ReadOnlyReason string `json:"readOnlyReason,omitempty"`
+	// Issues of the schema drift last found by `tenant verify`.
+	SchemaDrift []string `json:"schemaDrift,omitempty"`
+	// Read replica endpoint (host:port); empty without a replica.
+	ReadReplica string `json:"readReplica,omitempty"`
 	// Computed
 	SchemaUpToDate bool `json:"schemaUpToDate"`
 }
 
 func toTenantSummary(t *tenant.Tenant) TenantSummary {
+	readOnly, isReadOnly := t.ReadOnly()
+	drift, _ := t.RecordedSchemaDrift()
+	var replica string
+	if host, port, ok := t.ReadReplica(); ok {
+		replica = net.JoinHostPort(host, strconv.Itoa(port))
+	}
 	return TenantSummary{
 		ID:             t.ID,
 		Slug:           t.Slug,
 		DisplayName:    t.DisplayName,
 		DBName:         t.DBName,
+		DBSchema:       t.DBSchema,
 		Status:         string(t.Status),
 		Plan:           string(t.Plan),
 		SchemaVersion:  t.SchemaVersion,
 		VersionGroup:   t.VersionGroup,
+		Region:         t.Region,
+		Cluster:        t.Cluster,
+		DBHost:         t.DBHost,
+		DBPort:         t.DBPort,
 		CreatedAt:      t.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt:      t.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		ReadOnly:       isReadOnly,
+		ReadOnlyReason: readOnly.Reason,
+		SchemaDrift:    drift,
+		ReadReplica:    replica,
 		SchemaUpToDate: version.CompatibleSchema(t.SchemaVersion),
 	}
 }
@@ -143,6 +231,12 @@ func (h *AdminTenantHandler) Promote(c *gin.Context) {
 		h.base.HandleError(c, err)
 		return
 	}
+	h.record(c, "", tenant.AuditEntry{
+		TenantID: tenantID,
+		Action:   tenant.AuditVersionGroupChanged,
+		Before:   map[string]any{"versionGroup": oldGroup},
+		After:    map[string]any{"versionGroup": req.VersionGroup},
+	})
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":   "tenant promoted",
@@ -174,10 +268,22 @@ func (h *AdminTenantHandler) UpdateSchemaVersion(c *gin.Context) {
 		return
 	}
 
+	t, err := h.registry.GetByID(c.Request.Context(), tenantID)
+	if err != nil {
+		h.base.HandleError(c, err)
+		return
+	}
+
 	if err := h.registry.UpdateSchemaVersion(c.Request.Context(), tenantID, req.SchemaVersion); err != nil {
 		h.base.HandleError(c, err)
 		return
 	}
+	h.record(c, "", tenant.AuditEntry{
+		TenantID: tenantID,
+		Action:   tenant.AuditSchemaVersionChanged,
+		Before:   map[string]any{"schemaVersion": t.SchemaVersion},
+		After:    map[string]any{"schemaVersion": req.SchemaVersion},
+	})
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":       "schema version updated",
@@ -240,6 +346,7 @@ func (h *AdminTenantHandler) TriggerUpdate(c *gin.Context) {
 		h.base.HandleError(c, err)
 		return
 	}
+	h.record(c, "", tenant.AuditEntry{TenantID: tenantID, Action: tenant.AuditUpdateStarted})
 
 	c.JSON(http.StatusAccepted, gin.H{
 		"message":  "schema update started",
@@ -257,6 +364,7 @@ func (h *AdminTenantHandler) RetryUpdate(c *gin.Context) {
 		h.base.HandleError(c, err)
 		return
 	}
+	h.record(c, "", tenant.AuditEntry{TenantID: tenantID, Action: tenant.AuditUpdateRetried})
 
 	c.JSON(http.StatusAccepted, gin.H{
 		"message":  "migration retry started",
@@ -274,6 +382,7 @@ func (h *AdminTenantHandler) RollbackUpdate(c *gin.Context) {
 		h.base.HandleError(c, err)
 		return
 	}
+	h.record(c, "", tenant.AuditEntry{TenantID: tenantID, Action: tenant.AuditRollbackStarted})
 
 	c.JSON(http.StatusAccepted, gin.H{
 		"message":  "rollback started",
@@ -282,6 +391,136 @@ func (h *AdminTenantHandler) RollbackUpdate(c *gin.Context) {
 	})
 }
 
+// MoveRegionRequest is the request body for moving a tenant to another region.
+type MoveRegionRequest struct {
+	Region  string `json:"region" binding:"required"`
+	Cluster string `json:"cluster"`
+	DBHost  string `json:"dbHost" binding:"required"`
+	DBPort  int    `json:"dbPort"`
+}
+
+// MoveRegion starts a background job copying the tenant database to another
+// region/cluster and switching its placement. Progress and errors are
+// reported by the migration-status endpoint.
+// POST /api/v1/admin/tenants/:tenantId/move
+func (h *AdminTenantHandler) MoveRegion(c *gin.Context) {
+	tenantID := c.Param("tenantId")
+
+	if h.mover == nil {
+		h.base.HandleError(c, apperror.NewNotImplemented("region moves are not enabled"))
+		return
+	}
+
+	var req MoveRegionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "region and dbHost are required"})
+		return
+	}
+	if req.DBPort == 0 {
+		req.DBPort = 5432
+	}
+
+	target := tenant.Placement{
+		Region:  req.Region,
+		Cluster: req.Cluster,
+		DBHost:  req.DBHost,
+		DBPort:  req.DBPort,
+	}
+
+	t, err := h.registry.GetByID(c.Request.Context(), tenantID)
+	if err != nil {
+		h.base.HandleError(c, err)
+		return
+	}
+
+	if err := h.mover.StartMove(c.Request.Context(), tenantID, target); err != nil {
+		h.base.HandleError(c, err)
+		return
+	}
+	h.record(c, "", tenant.AuditEntry{
+		TenantID: tenantID,
+		Action:   tenant.AuditMoveStarted,
+		Before:   placementValues(t.Placement()),
+		After:    placementValues(target),
+	})
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":  "region move started",
+		"tenantId": tenantID,
+		"region":   target.Region,
+		"cluster":  target.Cluster,
+		"status":   "updating",
+	})
+}
+
+// placementValues are the audit values of a tenant placement.
+func placementValues(p tenant.Placement) map[string]any {
+	return map[string]any{"region": p.Region, "cluster": p.Cluster, "dbHost": p.DBHost, "dbPort": p.DBPort}
+}
+
+// Audit returns the meta-level audit trail, newest first. Filters: tenantId,
+// action, actor, from/to (RFC 3339); page with beforeId = nextBeforeId of
+// the previous page.
+// GET /api/v1/admin/tenants/audit
+func (h *AdminTenantHandler) Audit(c *gin.Context) {
+	if h.audit == nil {
+		h.base.HandleError(c, apperror.NewNotImplemented("tenant audit is not enabled"))
+		return
+	}
+
+	filter := tenant.AuditFilter{
+		TenantID: c.Query("tenantId"),
+		Action:   c.Query("action"),
+		Actor:    c.Query("actor"),
+	}
+	for _, q := range []struct {
+		name string
+		dst  **time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		if v := c.Query(q.name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				h.base.HandleError(c, apperror.NewValidation("invalid "+q.name+": expected RFC 3339").WithDetail("field", q.name))
+				return
+			}
+			*q.dst = &t
+		}
+	}
+	for _, q := range []struct {
+		name string
+		dst  *int
+	}{{"limit", &filter.Limit}} {
+		if v := c.Query(q.name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				h.base.HandleError(c, apperror.NewValidation("invalid "+q.name).WithDetail("field", q.name))
+				return
+			}
+			*q.dst = n
+		}
+	}
+	if v := c.Query("beforeId"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			h.base.HandleError(c, apperror.NewValidation("invalid beforeId").WithDetail("field", "beforeId"))
+			return
+		}
+		filter.BeforeID = n
+	}
+
+	entries, err := h.audit.List(c.Request.Context(), filter)
+	if err != nil {
+		h.base.HandleError(c, err)
+		return
+	}
+
+	resp := gin.H{"items": entries}
+	if len(entries) > 0 {
+		resp["nextBeforeId"] = entries[len(entries)-1].ID
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
 // MigrationStatus returns current migration state for a tenant.
 // GET /api/v1/admin/tenants/:tenantId/migration-status
 func (h *AdminTenantHandler) MigrationStatus(c *gin.Context) {
@@ -325,6 +564,7 @@ func (h *AdminTenantHandler) InternalTriggerUpdate(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	h.record(c, updaterAgentActor, tenant.AuditEntry{TenantID: tenantID, Action: tenant.AuditUpdateStarted})
 
 	c.JSON(http.StatusAccepted, gin.H{
 		"message":  "schema update started",
@@ -342,6 +582,7 @@ func (h *AdminTenantHandler) InternalRetryUpdate(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	h.record(c, updaterAgentActor, tenant.AuditEntry{TenantID: tenantID, Action: tenant.AuditUpdateRetried})
 
 	c.JSON(http.StatusAccepted, gin.H{
 		"message":  "migration retry started",
@@ -359,6 +600,7 @@ func (h *AdminTenantHandler) InternalRollbackUpdate(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	h.record(c, updaterAgentActor, tenant.AuditEntry{TenantID: tenantID, Action: tenant.AuditRollbackStarted})
 
 	c.JSON(http.StatusAccepted, gin.H{
 		"message":  "rollback started",
@@ -0,0 +1,414 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/tenant"
+	"metapus/internal/core/version"
+	"metapus/internal/infrastructure/storage/postgres"
+	"metapus/internal/infrastructure/storage/postgres/migration"
+	"metapus/pkg/logger"
+)
+
+// SetLifecycle enables the tenant lifecycle endpoints of the control-plane
+// API (create, suspend, activate, plan change). New tenant databases are
+// created at defaults unless the request names a placement; the manager
+// builds their DSN and drops cached pools so status and plan changes apply
+// to the next request.
+func (h *AdminTenantHandler) SetLifecycle(manager *tenant.Manager, defaults tenant.Placement) {
+	h.manager = manager
+	h.defaults = defaults
+}
+
+// _tenantSlug restricts slugs to what is safe in a database name (mt_<slug>).
+var _tenantSlug = regexp.MustCompile(`^[a-z][a-z0-9_]{1,39}$`)
+
+// _sharedDatabase restricts the names of shared tenant databases.
+var _sharedDatabase = regexp.MustCompile(`^[a-z][a-z0-9_]{1,62}$`)
+
+// CreateTenantRequest is the request body for tenant creation.
+type CreateTenantRequest struct {
+	Slug        string `json:"slug" binding:"required"`
+	DisplayName string `json:"displayName" binding:"required"`
+	Plan        string `json:"plan"`
+	Region      string `json:"region"`
+	Cluster     string `json:"cluster"`
+	DBHost      string `json:"dbHost"`
+	DBPort      int    `json:"dbPort"`
+	// SharedDatabase places the tenant in its own schema of this database
+	// (created on first use) instead of a database of its own.
+	SharedDatabase string `json:"sharedDatabase"`
+}
+
+// Create provisions a tenant the way `tenant create` does: creates its
+// database, applies all migrations and registers it as active. The
+// registry row is written last, so a failed provisioning leaves nothing to
+// clean up and can be repeated.
+// POST /api/v1/control-plane/tenants
+func (h *AdminTenantHandler) Create(c *gin.Context) {
+	if h.manager == nil {
+		h.base.HandleError(c, apperror.NewNotImplemented("tenant lifecycle is not enabled"))
+		return
+	}
+	ctx := c.Request.Context()
+
+	var req CreateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.base.HandleError(c, apperror.NewValidation("slug and displayName are required"))
+		return
+	}
+	req.Slug = strings.ToLower(req.Slug)
+	if !_tenantSlug.MatchString(req.Slug) {
+		h.base.HandleError(c, apperror.NewValidation("slug must be 2-40 latin letters, digits or underscores, starting with a letter").
+			WithDetail("field", "slug"))
+		return
+	}
+	plan := tenant.Plan(req.Plan)
+	if plan == "" {
+		plan = tenant.PlanStandard
+	}
+	if !plan.Valid() {
+		h.base.HandleError(c, apperror.NewValidation("unknown plan").
+			WithDetail("field", "plan").WithDetail("plans", tenant.Plans))
+		return
+	}
+
+	if req.SharedDatabase != "" && !_sharedDatabase.MatchString(req.SharedDatabase) {
+		h.base.HandleError(c, apperror.NewValidation("sharedDatabase must be latin lowercase letters, digits or underscores, starting with a letter").
+			WithDetail("field", "sharedDatabase"))
+		return
+	}
+
+	t := &tenant.Tenant{
+		Slug:        req.Slug,
+		DisplayName: req.DisplayName,
+		DBName:      "mt_" + req.Slug,
+		Status:      tenant.StatusActive,
+		Plan:        plan,
+		Region:      req.Region,
+		Cluster:     req.Cluster,
+		DBHost:      req.DBHost,
+		DBPort:      req.DBPort,
+	}
+	if req.SharedDatabase != "" {
+		t.DBName, t.DBSchema = req.SharedDatabase, tenant.SchemaNameFor(req.Slug)
+	}
+	if t.DBHost == "" {
+		t.DBHost = h.defaults.DBHost
+		if t.Region == "" && t.Cluster == "" {
+			t.Region, t.Cluster = h.defaults.Region, h.defaults.Cluster
+		}
+	}
+	if t.DBPort == 0 {
+		t.DBPort = h.defaults.DBPort
+	}
+
+	// Check for a taken slug before touching the database server; the unique
+	// index still catches a concurrent create below.
+	tenants, err := h.registry.ListAll(ctx)
+	if err != nil {
+		h.base.HandleError(c, err)
+		return
+	}
+	for _, existing := range tenants {
+		if existing.Slug == t.Slug || (existing.DBName == t.DBName && existing.DBSchema == t.DBSchema) {
+			h.base.HandleError(c, apperror.NewDuplicate("tenant", "slug", t.Slug))
+			return
+		}
+	}
+
+	output, err := migration.ProvisionDatabase(ctx, h.manager.TenantDSN(t))
+	if err != nil {
+		logger.Error(ctx, "tenant provisioning failed", "slug", t.Slug, "error", err, "output", output)
+		h.base.HandleError(c, apperror.NewInternal(err).WithDetail("slug", t.Slug))
+		return
+	}
+
+	if err := h.registry.Create(ctx, t); err != nil {
+		if postgres.IsUniqueViolation(err) {
+			err = apperror.NewDuplicate("tenant", "slug", t.Slug)
+		}
+		h.base.HandleError(c, err)
+		return
+	}
+	if err := h.registry.UpdateSchemaVersion(ctx, t.ID, version.ExpectedSchemaVersion); err != nil {
+		logger.Warn(ctx, "tenant created, schema version not recorded", "tenant_id", t.ID, "error", err)
+	} else {
+		t.SchemaVersion = version.ExpectedSchemaVersion
+	}
+	h.record(c, "", tenant.AuditEntry{
+		TenantID: t.ID,
+		Action:   tenant.AuditCreated,
+		After:    map[string]any{"slug": t.Slug, "dbName": t.DBName, "dbSchema": t.DBSchema, "plan": string(t.Plan), "status": string(t.Status)},
+	})
+
+	c.JSON(http.StatusCreated, toTenantSummary(t))
+}
+
+// Suspend blocks all requests of a tenant until it is activated again.
+// POST /api/v1/control-plane/tenants/:tenantId/suspend
+func (h *AdminTenantHandler) Suspend(c *gin.Context) {
+	t, ok := h.lifecycleTenant(c)
+	if !ok {
+		return
+	}
+	switch t.Status {
+	case tenant.StatusPendingDeletion, tenant.StatusDeleted:
+		h.base.HandleError(c, apperror.NewConflict("tenant is "+string(t.Status)).WithDetail("status", t.Status))
+		return
+	}
+	h.setStatus(c, t, tenant.StatusSuspended, tenant.AuditSuspended)
+}
+
+// Activate re-enables a suspended tenant.
+// POST /api/v1/control-plane/tenants/:tenantId/activate
+func (h *AdminTenantHandler) Activate(c *gin.Context) {
+	t, ok := h.lifecycleTenant(c)
+	if !ok {
+		return
+	}
+	switch t.Status {
+	case tenant.StatusPendingDeletion:
+		h.base.HandleError(c, apperror.NewConflict("tenant is scheduled for deletion; cancel the deletion first").
+			WithDetail("status", t.Status))
+		return
+	case tenant.StatusDeleted:
+		h.base.HandleError(c, apperror.NewConflict("tenant is deleted; restore its archive into a new tenant instead").
+			WithDetail("status", t.Status))
+		return
+	case tenant.StatusUpdating, tenant.StatusMigrationFailed:
+		h.base.HandleError(c, apperror.NewConflict("tenant schema update is in progress or failed; use retry-update or rollback-update").
+			WithDetail("status", t.Status))
+		return
+	}
+	h.setStatus(c, t, tenant.StatusActive, tenant.AuditActivated)
+}
+
+// setStatus switches the tenant to status, records action and drops its
+// cached pool so the change applies to the next request.
+func (h *AdminTenantHandler) setStatus(c *gin.Context, t *tenant.Tenant, status tenant.Status, action string) {
+	if err := h.registry.UpdateStatusByID(c.Request.Context(), t.ID, status); err != nil {
+		h.base.HandleError(c, err)
+		return
+	}
+	h.manager.EvictPool(t.ID)
+	h.record(c, "", tenant.AuditEntry{
+		TenantID: t.ID,
+		Action:   action,
+		Before:   map[string]any{"status": string(t.Status)},
+		After:    map[string]any{"status": string(status)},
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"tenantId":  t.ID,
+		"oldStatus": string(t.Status),
+		"newStatus": string(status),
+	})
+}
+
+// ChangePlanRequest is the request body for a plan change.
+type ChangePlanRequest struct {
+	Plan string `json:"plan" binding:"required"`
+}
+
+// ChangePlan moves a tenant to another subscription plan. Plan features
+// and limits follow the new plan from the next request on.
+// PUT /api/v1/control-plane/tenants/:tenantId/plan
+func (h *AdminTenantHandler) ChangePlan(c *gin.Context) {
+	var req ChangePlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.base.HandleError(c, apperror.NewValidation("plan is required").WithDetail("field", "plan"))
+		return
+	}
+	plan := tenant.Plan(req.Plan)
+	if !plan.Valid() {
+		h.base.HandleError(c, apperror.NewValidation("unknown plan").
+			WithDetail("field", "plan").WithDetail("plans", tenant.Plans))
+		return
+	}
+
+	t, ok := h.lifecycleTenant(c)
+	if !ok {
+		return
+	}
+	if err := h.registry.UpdatePlan(c.Request.Context(), t.ID, plan); err != nil {
+		h.base.HandleError(c, err)
+		return
+	}
+	h.manager.EvictPool(t.ID)
+	h.record(c, "", tenant.AuditEntry{
+		TenantID: t.ID,
+		Action:   tenant.AuditPlanChanged,
+		Before:   map[string]any{"plan": string(t.Plan)},
+		After:    map[string]any{"plan": string(plan)},
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"tenantId": t.ID,
+		"oldPlan":  string(t.Plan),
+		"newPlan":  string(plan),
+	})
+}
+
+// SetReadOnlyRequest is the request body of a read-only mode switch.
+type SetReadOnlyRequest struct {
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason"`
+}
+
+// SetReadOnly switches the emergency read-only mode of a tenant. While it is
+// on, users can sign in and read, mutating requests answer 423 with the
+// reason and background jobs of the tenant pause. Enabling requires a reason.
+// PUT /api/v1/control-plane/tenants/:tenantId/read-only
+func (h *AdminTenantHandler) SetReadOnly(c *gin.Context) {
+	var req SetReadOnlyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.base.HandleError(c, apperror.NewValidation("invalid request body"))
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Enabled && req.Reason == "" {
+		h.base.HandleError(c, apperror.NewValidation("reason is required to enable read-only mode").
+			WithDetail("field", "reason"))
+		return
+	}
+
+	t, ok := h.lifecycleTenant(c)
+	if !ok {
+		return
+	}
+	before, wasReadOnly := t.ReadOnly()
+
+	var (
+		set    map[string]any
+		unset  []string
+		action = tenant.AuditReadOnlyDisabled
+		after  = map[string]any{"read_only": false}
+	)
+	if req.Enabled {
+		mode := tenant.ReadOnlyMode{Reason: req.Reason, Since: time.Now(), By: auditActor(c)}
+		if wasReadOnly {
+			mode.Since = before.Since
+		}
+		set = map[string]any{tenant.SettingReadOnly: mode.Setting()}
+		action = tenant.AuditReadOnlyEnabled
+		after = map[string]any{"read_only": true, "reason": req.Reason}
+	} else {
+		unset = []string{tenant.SettingReadOnly}
+	}
+	if err := h.registry.MergeSettings(c.Request.Context(), t.ID, set, unset); err != nil {
+		h.base.HandleError(c, err)
+		return
+	}
+	h.manager.EvictPool(t.ID)
+
+	beforeAudit := map[string]any{"read_only": wasReadOnly}
+	if wasReadOnly {
+		beforeAudit["reason"] = before.Reason
+	}
+	h.record(c, "", tenant.AuditEntry{
+		TenantID: t.ID,
+		Action:   action,
+		Before:   beforeAudit,
+		After:    after,
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"tenantId": t.ID,
+		"readOnly": req.Enabled,
+		"reason":   req.Reason,
+	})
+}
+
+// SetReadReplicaRequest is the request body of a read replica change. An
+// empty host removes the replica; a zero port means the port of the primary.
+type SetReadReplicaRequest struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+// SetReadReplica registers or removes the read replica of a tenant. The
+// tenant pool is reopened, so reads hinted as replica-safe (lists, cards,
+// reports) go to the replica from the next request on.
+// PUT /api/v1/control-plane/tenants/:tenantId/read-replica
+func (h *AdminTenantHandler) SetReadReplica(c *gin.Context) {
+	var req SetReadReplicaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.base.HandleError(c, apperror.NewValidation("invalid request body"))
+		return
+	}
+	req.Host = strings.TrimSpace(req.Host)
+	if req.Port < 0 || req.Port > 65535 {
+		h.base.HandleError(c, apperror.NewValidation("invalid port").WithDetail("field", "port"))
+		return
+	}
+
+	t, ok := h.lifecycleTenant(c)
+	if !ok {
+		return
+	}
+	if req.Port == 0 {
+		req.Port = t.DBPort
+	}
+
+	var (
+		set   map[string]any
+		unset []string
+		after = map[string]any{}
+	)
+	if req.Host != "" {
+		set = map[string]any{tenant.SettingReadReplica: tenant.ReplicaSetting(req.Host, req.Port)}
+		after = tenant.ReplicaSetting(req.Host, req.Port)
+	} else {
+		unset = []string{tenant.SettingReadReplica}
+	}
+	if err := h.registry.MergeSettings(c.Request.Context(), t.ID, set, unset); err != nil {
+		h.base.HandleError(c, err)
+		return
+	}
+	h.manager.EvictPool(t.ID)
+
+	before := map[string]any{}
+	if host, port, ok := t.ReadReplica(); ok {
+		before = tenant.ReplicaSetting(host, port)
+	}
+	h.record(c, "", tenant.AuditEntry{
+		TenantID: t.ID,
+		Action:   tenant.AuditReplicaChanged,
+		Before:   before,
+		After:    after,
+	})
+
+	resp := gin.H{"tenantId": t.ID, "readReplica": nil}
+	if req.Host != "" {
+		resp["readReplica"] = gin.H{"host": req.Host, "port": req.Port}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// lifecycleTenant loads the tenant of a lifecycle request. It writes the
+// response and returns false when lifecycle endpoints are disabled or the
+// tenant does not exist.
+func (h *AdminTenantHandler) lifecycleTenant(c *gin.Context) (*tenant.Tenant, bool) {
+	if h.manager == nil {
+		h.base.HandleError(c, apperror.NewNotImplemented("tenant lifecycle is not enabled"))
+		return nil, false
+	}
+	tenantID := c.Param("tenantId")
+	t, err := h.registry.GetByID(c.Request.Context(), tenantID)
+	if err != nil {
+		if errors.Is(err, tenant.ErrTenantNotFound) {
+			err = apperror.NewNotFound("tenant", tenantID)
+		}
+		h.base.HandleError(c, err)
+		return nil, false
+	}
+	return t, true
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/artifact"
+	"metapus/pkg/logger"
+)
+
+// ArtifactHandler serves generated files — report exports and backups
+// (/system/artifacts).
+type ArtifactHandler struct {
+	*BaseHandler
+	svc *artifact.Service
+}
+
+// NewArtifactHandler creates a new handler.
+func NewArtifactHandler(base *BaseHandler, svc *artifact.Service) *ArtifactHandler {
+	return &ArtifactHandler{
+		BaseHandler: base,
+		svc:         svc,
+	}
+}
+
+// artifactResponse adds a signed download link to an artifact.
+type artifactResponse struct {
+	*artifact.Artifact
+	DownloadURL       string    `json:"downloadUrl"`
+	DownloadExpiresAt time.Time `json:"downloadExpiresAt"`
+}
+
+// RegisterRoutes wires the admin routes under the provided (admin-only) group.
+func (h *ArtifactHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/artifacts", h.List)
+	rg.GET("/artifacts/:id", h.Get)
+	rg.DELETE("/artifacts/:id", h.Delete)
+}
+
+// List godoc
+//
+//	@Summary     List artifacts
+//	@Tags        system
+//	@Produce     json
+//	@Param       kind  query string false "Filter by kind (report_export, backup)"
+//	@Param       limit query int    false "Page size (default 50, max 500)"
+//	@Router      /system/artifacts [get]
+func (h *ArtifactHandler) List(c *gin.Context) {
+	items, err := h.svc.List(c.Request.Context(), artifact.ListFilter{
+		Kind:  artifact.Kind(c.Query("kind")),
+		Limit: h.ParseIntQuery(c, "limit", 0),
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.OK(c, gin.H{"items": items})
+}
+
+// Get godoc
+//
+//	@Summary     Get an artifact
+//	@Description Returns artifact metadata with a short-lived signed download link
+//	@Tags        system
+//	@Produce     json
+//	@Param       id path string true "Artifact ID"
+//	@Router      /system/artifacts/{id} [get]
+func (h *ArtifactHandler) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	artifactID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid artifact ID"))
+		return
+	}
+
+	a, err := h.svc.Get(ctx, artifactID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	link, expires, err := h.svc.DownloadURL(ctx, a)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.OK(c, artifactResponse{Artifact: a, DownloadURL: link, DownloadExpiresAt: expires})
+}
+
+// Delete godoc
+//
+//	@Summary     Delete an artifact
+//	@Tags        system
+//	@Param       id path string true "Artifact ID"
+//	@Success     204
+//	@Router      /system/artifacts/{id} [delete]
+func (h *ArtifactHandler) Delete(c *gin.Context) {
+	artifactID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid artifact ID"))
+		return
+	}
+	if err := h.svc.Delete(c.Request.Context(), artifactID); err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.NoContent(c)
+}
+
+// Download streams the file for a signed link. Registered outside the
+// JWT-protected group: the signature is the credential. Only used by blob
+// stores without presigned URLs; S3 links point to the bucket directly.
+func (h *ArtifactHandler) Download(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	artifactID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid artifact ID"))
+		return
+	}
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		h.Error(c, apperror.NewForbidden("download link is invalid or expired"))
+		return
+	}
+
+	a, rc, err := h.svc.Download(ctx, artifactID, expires, c.Query("sig"))
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	defer rc.Close()
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`,
+		sanitizeASCII(a.FileName), url.PathEscape(a.FileName)))
+	c.Header("Content-Type", a.ContentType)
+	c.Header("Content-Length", strconv.FormatInt(a.Size, 10))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, rc); err != nil {
+		logger.Warn(ctx, "artifact download interrupted", "artifact_id", a.ID, "error", err)
+	}
+}
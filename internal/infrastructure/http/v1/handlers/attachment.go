@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/attachment"
+	"metapus/internal/infrastructure/http/v1/dto"
+	"metapus/pkg/logger"
+)
+
+// multipartOverhead is the allowance for multipart framing on top of the file size limit.
+const multipartOverhead = 1 << 20
+
+// attachmentEndpoints implements the attachment endpoints shared by document
+// and catalog handlers. The owning entity is loaded through checkOwner before
+// any attachment is touched, so a missing entity or one hidden by RLS is a 404.
+type attachmentEndpoints struct {
+	*BaseHandler
+	service    *attachment.Service
+	entityType string
+	checkOwner func(ctx context.Context, entityID id.ID) error
+}
+
+// owner parses :id, checks access to the owning entity and returns its ID.
+func (e attachmentEndpoints) owner(c *gin.Context) (id.ID, bool) {
+	if e.service == nil {
+		e.Error(c, apperror.NewNotFound("attachment", c.Param("attachmentId")))
+		return id.ID{}, false
+	}
+	entityID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		e.Error(c, apperror.NewValidation("invalid id format"))
+		return id.ID{}, false
+	}
+	if err := e.checkOwner(c.Request.Context(), entityID); err != nil {
+		e.Error(c, err)
+		return id.ID{}, false
+	}
+	return entityID, true
+}
+
+func (e attachmentEndpoints) attachmentID(c *gin.Context) (id.ID, bool) {
+	attachmentID, err := id.Parse(c.Param("attachmentId"))
+	if err != nil {
+		e.Error(c, apperror.NewValidation("invalid attachment id format"))
+		return id.ID{}, false
+	}
+	return attachmentID, true
+}
+
+func (e attachmentEndpoints) list(c *gin.Context) {
+	entityID, ok := e.owner(c)
+	if !ok {
+		return
+	}
+
+	list, err := e.service.List(c.Request.Context(), e.entityType, entityID)
+	if err != nil {
+		e.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MapAttachmentListResponse(list))
+}
+
+func (e attachmentEndpoints) upload(c *gin.Context) {
+	entityID, ok := e.owner(c)
+	if !ok {
+		return
+	}
+
+	maxSize := e.service.Limits().MaxSize
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			e.Error(c, apperror.NewValidation("validation failed").
+				WithDetail("file", "file is too large").
+				WithDetail("maxSize", maxSize))
+			return
+		}
+		e.Error(c, apperror.NewValidation("multipart field 'file' is required").WithDetail("error", err.Error()))
+		return
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		e.Error(c, apperror.NewInternal(fmt.Errorf("open uploaded file: %w", err)))
+		return
+	}
+	defer f.Close()
+
+	a, err := e.service.Upload(c.Request.Context(), attachment.UploadInput{
+		EntityType:  e.entityType,
+		EntityID:    entityID,
+		FileName:    fh.Filename,
+		ContentType: fh.Header.Get("Content-Type"),
+		Size:        fh.Size,
+		Body:        f,
+	})
+	if err != nil {
+		e.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.MapAttachmentResponse(a))
+}
+
+func (e attachmentEndpoints) download(c *gin.Context) {
+	entityID, ok := e.owner(c)
+	if !ok {
+		return
+	}
+	attachmentID, ok := e.attachmentID(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	a, rc, err := e.service.Open(ctx, e.entityType, entityID, attachmentID)
+	if err != nil {
+		e.Error(c, err)
+		return
+	}
+	defer rc.Close()
+
+	// Always served as a download; nosniff keeps browsers from rendering it.
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`,
+		sanitizeASCII(a.FileName), url.PathEscape(a.FileName)))
+	c.Header("Content-Type", a.ContentType)
+	c.Header("Content-Length", strconv.FormatInt(a.Size, 10))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, rc); err != nil {
+		logger.Warn(ctx, "attachment download interrupted", "attachment_id", a.ID, "error", err)
+	}
+}
+
+func (e attachmentEndpoints) delete(c *gin.Context) {
+	entityID, ok := e.owner(c)
+	if !ok {
+		return
+	}
+	attachmentID, ok := e.attachmentID(c)
+	if !ok {
+		return
+	}
+
+	if err := e.service.Delete(c.Request.Context(), e.entityType, entityID, attachmentID); err != nil {
+		e.Error(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// --- Documents ---
+
+// SetAttachmentService enables attachment endpoints for the handler.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) SetAttachmentService(svc *attachment.Service) {
+	h.attachments = svc
+}
+
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) attachmentEndpoints() attachmentEndpoints {
+	return attachmentEndpoints{
+		BaseHandler: h.BaseHandler,
+		service:     h.attachments,
+		entityType:  h.entityName,
+		checkOwner: func(ctx context.Context, entityID id.ID) error {
+			_, err := h.service.GetByID(ctx, entityID)
+			return err
+		},
+	}
+}
+
+// ListAttachments handles GET /{entity}/:id/attachments.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) ListAttachments(c *gin.Context) {
+	h.attachmentEndpoints().list(c)
+}
+
+// UploadAttachment handles POST /{entity}/:id/attachments (multipart field "file").
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) UploadAttachment(c *gin.Context) {
+	h.attachmentEndpoints().upload(c)
+}
+
+// DownloadAttachment handles GET /{entity}/:id/attachments/:attachmentId.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) DownloadAttachment(c *gin.Context) {
+	h.attachmentEndpoints().download(c)
+}
+
+// DeleteAttachment handles DELETE /{entity}/:id/attachments/:attachmentId.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) DeleteAttachment(c *gin.Context) {
+	h.attachmentEndpoints().delete(c)
+}
+
+// --- Catalogs ---
+
+// SetAttachmentService enables attachment endpoints for the handler.
+func (h *CatalogHandler[T, CreateDTO, UpdateDTO]) SetAttachmentService(svc *attachment.Service) {
+	h.attachments = svc
+}
+
+func (h *CatalogHandler[T, CreateDTO, UpdateDTO]) attachmentEndpoints() attachmentEndpoints {
+	return attachmentEndpoints{
+		BaseHandler: h.BaseHandler,
+		service:     h.attachments,
+		entityType:  h.entityName,
+		checkOwner: func(ctx context.Context, entityID id.ID) error {
+			_, err := h.service.GetByID(ctx, entityID)
+			return err
+		},
+	}
+}
+
+// ListAttachments handles GET /{entity}/:id/attachments.
+func (h *CatalogHandler[T, CreateDTO, UpdateDTO]) ListAttachments(c *gin.Context) {
+	h.attachmentEndpoints().list(c)
+}
+
+// UploadAttachment handles POST /{entity}/:id/attachments (multipart field "file").
+func (h *CatalogHandler[T, CreateDTO, UpdateDTO]) UploadAttachment(c *gin.Context) {
+	h.attachmentEndpoints().upload(c)
+}
+
+// DownloadAttachment handles GET /{entity}/:id/attachments/:attachmentId.
+func (h *CatalogHandler[T, CreateDTO, UpdateDTO]) DownloadAttachment(c *gin.Context) {
+	h.attachmentEndpoints().download(c)
+}
+
+// DeleteAttachment handles DELETE /{entity}/:id/attachments/:attachmentId.
+func (h *CatalogHandler[T, CreateDTO, UpdateDTO]) DeleteAttachment(c *gin.Context) {
+	h.attachmentEndpoints().delete(c)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/infrastructure/http/v1/dto"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+// AuditLogHandler provides HTTP handlers for the audit trail of entity changes.
+type AuditLogHandler struct {
+	BaseHandler
+	audit *postgres.AuditService
+}
+
+// NewAuditLogHandler creates a new audit trail handler.
+func NewAuditLogHandler(audit *postgres.AuditService) *AuditLogHandler {
+	return &AuditLogHandler{audit: audit}
+}
+
+// List handles GET /audit — audit trail entries, newest first.
+// Query: entityType (table name, e.g. doc_sales_orders), entityId, userId,
+// action, traceId, dateFrom, dateTo, after (cursor), limit (max 200).
+func (h *AuditLogHandler) List(c *gin.Context) {
+	f := postgres.AuditFilter{
+		EntityType: c.Query("entityType"),
+		UserID:     c.Query("userId"),
+		Action:     postgres.AuditAction(c.Query("action")),
+		TraceID:    c.Query("traceId"),
+		After:      c.Query("after"),
+		Limit:      min(max(h.ParseIntQuery(c, "limit", 50), 1), 200),
+	}
+
+	if v := c.Query("entityId"); v != "" {
+		parsed, err := id.Parse(v)
+		if err != nil {
+			h.Error(c, apperror.NewValidation("invalid entityId"))
+			return
+		}
+		f.EntityID = &parsed
+	}
+	switch f.Action {
+	case "", postgres.AuditActionCreate, postgres.AuditActionUpdate, postgres.AuditActionDelete,
+		postgres.AuditActionPost, postgres.AuditActionUnpost:
+	default:
+		h.Error(c, apperror.NewValidation("invalid action, expected create, update, delete, post or unpost"))
+		return
+	}
+	if v := c.Query("dateFrom"); v != "" {
+		t, err := parseDateParam(v, false)
+		if err != nil {
+			h.Error(c, apperror.NewValidation("invalid dateFrom format, expected YYYY-MM-DD or RFC3339"))
+			return
+		}
+		f.DateFrom = &t
+	}
+	if v := c.Query("dateTo"); v != "" {
+		t, err := parseDateParam(v, true)
+		if err != nil {
+			h.Error(c, apperror.NewValidation("invalid dateTo format, expected YYYY-MM-DD or RFC3339"))
+			return
+		}
+		f.DateTo = &t
+	}
+
+	page, err := h.audit.List(c.Request.Context(), f)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	items := make([]dto.AuditEntryResponse, len(page.Items))
+	for i, e := range page.Items {
+		items[i] = toAuditEntryResponse(e)
+	}
+
+	c.JSON(http.StatusOK, dto.AuditListResponse{
+		Items:      items,
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	})
+}
+
+// toAuditEntryResponse maps an audit entry to its API representation.
+func toAuditEntryResponse(e postgres.AuditEntry) dto.AuditEntryResponse {
+	var changes map[string]any
+	if len(e.Changes) > 0 {
+		_ = json.Unmarshal(e.Changes, &changes)
+	}
+	return dto.AuditEntryResponse{
+		ID:         e.ID.String(),
+		EntityType: e.EntityType,
+		EntityID:   e.EntityID.String(),
+		Action:     string(e.Action),
+		UserID:     e.UserID,
+		UserEmail:  e.UserEmail,
+		Changes:    changes,
+		TraceID:    e.TraceID,
+		CreatedAt:  e.CreatedAt,
+	}
+}
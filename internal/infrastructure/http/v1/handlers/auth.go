@@ -249,6 +249,157 @@ func (h *AuthHandler) Me(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.FromUser(user))
 }
 
+// ListSessions handles GET /auth/sessions — the active sessions (devices)
+// of the current user.
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user := appctx.GetUser(ctx)
+	if user == nil {
+		h.Error(c, apperror.NewUnauthorized("not authenticated"))
+		return
+	}
+
+	userID, err := id.Parse(user.UserID)
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid user id"))
+		return
+	}
+	currentID, _ := id.Parse(user.SessionID)
+
+	sessions, err := h.service.ListSessions(ctx, userID, currentID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": dto.FromDeviceSessions(sessions)})
+}
+
+// RevokeSession handles DELETE /auth/sessions/:sessionId — logs the current
+// user out of one of their sessions, e.g. on another device.
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user := appctx.GetUser(ctx)
+	if user == nil {
+		h.Error(c, apperror.NewUnauthorized("not authenticated"))
+		return
+	}
+
+	userID, err := id.Parse(user.UserID)
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid user id"))
+		return
+	}
+	sessionID, err := id.Parse(c.Param("sessionId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid sessionId"))
+		return
+	}
+
+	if err := h.service.RevokeSession(ctx, userID, sessionID); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	// Revoking the own session is a logout: drop the refresh cookie too.
+	if sessionID.String() == user.SessionID {
+		h.clearRefreshTokenCookie(c)
+	}
+
+	h.emitSessionEvent(ctx, eventlog.EventSessionRevoked, eventlog.SeverityInfo,
+		user.Email, c.ClientIP(),
+		fmt.Sprintf("Session revoked by user: %s", user.Email),
+		map[string]any{"email": user.Email, "user_id": user.UserID, "session_id": sessionID.String()},
+	)
+
+	c.Status(http.StatusNoContent)
+}
+
+// ChangeEmail handles POST /auth/change-email.
+// Sends a verification link to the new address; the email changes only after confirmation.
+func (h *AuthHandler) ChangeEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user := appctx.GetUser(ctx)
+	if user == nil {
+		h.Error(c, apperror.NewUnauthorized("not authenticated"))
+		return
+	}
+
+	userID, err := id.Parse(user.UserID)
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid user id"))
+		return
+	}
+
+	var req dto.ChangeEmailRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.service.RequestEmailChange(ctx, userID, req.Password, req.NewEmail); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	h.emitSessionEvent(ctx, eventlog.EventSessionEmailChangeRequested, eventlog.SeverityInfo,
+		user.Email, c.ClientIP(),
+		fmt.Sprintf("Email change requested: %s", user.Email),
+		map[string]any{"email": user.Email, "user_id": user.UserID},
+	)
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "verification email sent"})
+}
+
+// ConfirmEmailChange handles POST /auth/change-email/confirm.
+// Returns a fresh token pair: all previous sessions are revoked.
+func (h *AuthHandler) ConfirmEmailChange(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userCtx := appctx.GetUser(ctx)
+	if userCtx == nil {
+		h.Error(c, apperror.NewUnauthorized("not authenticated"))
+		return
+	}
+
+	userID, err := id.Parse(userCtx.UserID)
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid user id"))
+		return
+	}
+
+	var req dto.ConfirmEmailChangeRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	info := auth.SessionInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+
+	tokens, user, err := h.service.ConfirmEmailChange(ctx, userID, req.Token, info)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	h.emitSessionEvent(ctx, eventlog.EventSessionEmailChanged, eventlog.SeverityWarning,
+		user.Email, c.ClientIP(),
+		fmt.Sprintf("Email changed: %s -> %s", userCtx.Email, user.Email),
+		map[string]any{"old_email": userCtx.Email, "new_email": user.Email, "user_id": userCtx.UserID},
+	)
+
+	h.setRefreshTokenCookie(c, tokens.RefreshToken)
+
+	c.JSON(http.StatusOK, dto.LoginResponse{
+		Tokens: dto.FromTokenPair(tokens),
+		User:   dto.FromUser(user),
+	})
+}
+
 // AssignRole handles POST /auth/assign-role
 func (h *AuthHandler) AssignRole(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -407,6 +558,7 @@ func (h *AuthHandler) GetEffectiveAccess(c *gin.Context) {
 	resp := dto.EffectiveAccessResponse{
 		User:        dto.FromUser(user),
 		Permissions: user.Permissions,
+		PermissionScopes: user.PermissionScopes,
 	}
 
 	// Load security profile for RLS/FLS/CEL
@@ -623,6 +775,36 @@ func (h *AuthHandler) SetRolePermissions(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "permissions updated"})
 }
 
+// SetRolePermissionScope handles PUT /auth/roles/:roleId/permissions/:permissionId/scope —
+// limits the role's permission to a scope (e.g. one warehouse), or lifts the
+// limit with an empty scope.
+func (h *AuthHandler) SetRolePermissionScope(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	roleID, err := id.Parse(c.Param("roleId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid roleId"))
+		return
+	}
+	permissionID, err := id.Parse(c.Param("permissionId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid permissionId"))
+		return
+	}
+
+	var req dto.SetPermissionScopeRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.service.SetRolePermissionScope(ctx, roleID, permissionID, req.Scope); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "permission scope updated"})
+}
+
 // ListRoles handles GET /auth/roles
 func (h *AuthHandler) ListRoles(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -717,8 +899,14 @@ func (h *AuthHandler) RegisterRoutes(public, protected *gin.RouterGroup) {
 	public.POST("/refresh", h.Refresh)
 
 	// Protected routes (auth required)
-	protected.POST("/logout", h.Logout)
+	protected.POST("/logout", middleware.PermitAuthenticated(), h.Logout)
 	protected.GET("/me", h.Me)
+	protected.GET("/sessions", h.ListSessions)
+	protected.DELETE("/sessions/:sessionId", middleware.PermitAuthenticated(), h.RevokeSession)
+	// Change-email flow: rate limited — each request sends an email.
+	emailChangeLimit := middleware.RateLimit(0.1, 3)
+	protected.POST("/change-email", middleware.PermitAuthenticated(), emailChangeLimit, h.ChangeEmail)
+	protected.POST("/change-email/confirm", middleware.PermitAuthenticated(), emailChangeLimit, h.ConfirmEmailChange)
 	// NOTE: These endpoints are privileged. Keep them protected from privilege escalation.
 	protected.POST("/assign-role", middleware.RequireRole("admin"), h.AssignRole)
 	protected.POST("/revoke-role", middleware.RequireRole("admin"), h.RevokeRole)
@@ -735,11 +923,12 @@ func (h *AuthHandler) RegisterRoutes(public, protected *gin.RouterGroup) {
 	protected.DELETE("/roles/:roleId", middleware.RequireRole("admin"), h.DeleteRole)
 	protected.GET("/roles/:roleId/permissions", h.ListRolePermissions)
 	protected.PUT("/roles/:roleId/permissions", middleware.RequireRole("admin"), h.SetRolePermissions)
+	protected.PUT("/roles/:roleId/permissions/:permissionId/scope", middleware.RequireRole("admin"), h.SetRolePermissionScope)
 	protected.GET("/permissions", h.ListPermissions)
 
 	// WebSocket ticket issuer (requires JWT auth)
 	if h.wsTicketStore != nil {
-		protected.POST("/ws-ticket", h.IssueWSTicket)
+		protected.POST("/ws-ticket", middleware.PermitAuthenticated(), h.IssueWSTicket)
 	}
 }
 
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/security"
+	"metapus/internal/domain/catalogs/bank_account"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+// BankAccountHandler adds default account selection to the bank account
+// catalog handler.
+type BankAccountHandler struct {
+	*CatalogHandler[*bank_account.BankAccount, dto.CreateBankAccountRequest, dto.UpdateBankAccountRequest]
+	accounts *bank_account.Service
+}
+
+// NewBankAccountHandler creates a new bank account handler.
+func NewBankAccountHandler(
+	catalog *CatalogHandler[*bank_account.BankAccount, dto.CreateBankAccountRequest, dto.UpdateBankAccountRequest],
+	service *bank_account.Service,
+) *BankAccountHandler {
+	return &BankAccountHandler{CatalogHandler: catalog, accounts: service}
+}
+
+// GetDefaultAccount handles GET /catalog/bank-accounts/default?counterpartyId=
+// — the default account of the counterparty.
+func (h *BankAccountHandler) GetDefaultAccount(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	raw := c.Query("counterpartyId")
+	counterpartyID, err := id.Parse(raw)
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid counterpartyId format").WithDetail("counterpartyId", raw))
+		return
+	}
+
+	account, err := h.accounts.GetDefault(ctx, counterpartyID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	var refs any
+	if h.resolveRefs != nil {
+		refs, err = h.resolveRefs(ctx, account)
+		if err != nil {
+			h.Error(c, err)
+			return
+		}
+	}
+
+	if policy := security.GetFieldPolicy(ctx, h.entityName, "read"); policy != nil {
+		security.MaskForRead(account, policy)
+	}
+
+	c.JSON(http.StatusOK, h.toDTO(account, refs))
+}
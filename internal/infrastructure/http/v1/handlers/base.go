@@ -9,6 +9,7 @@ import (
 
 	"metapus/internal/core/apperror"
 	appctx "metapus/internal/core/context"
+	"metapus/internal/core/id"
 	"metapus/internal/core/security"
 	"metapus/internal/domain"
 	"metapus/internal/domain/cursor"
@@ -68,6 +69,22 @@ func (h *BaseHandler) ParseIntQuery(c *gin.Context, key string, defaultVal int)
 	return parsed
 }
 
+// ParseIDQuery parses an optional UUID query parameter: nil when it is
+// absent. Writes a validation error and returns ok=false when the value is
+// malformed.
+func (h *BaseHandler) ParseIDQuery(c *gin.Context, key string) (*id.ID, bool) {
+	val := c.Query(key)
+	if val == "" {
+		return nil, true
+	}
+	parsed, err := id.Parse(val)
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid "+key+" format"))
+		return nil, false
+	}
+	return &parsed, true
+}
+
 // GetTenantID extracts tenant ID from request context.
 func (h *BaseHandler) GetTenantID(c *gin.Context) string {
 	return appctx.GetTenantID(c.Request.Context())
@@ -123,14 +140,18 @@ func (h *BaseHandler) Success(c *gin.Context, message string) {
 // This is the single entry point for parsing filters across all catalogs and documents.
 // defaultOrderBy is the default ORDER BY field (e.g., "name" for catalogs, "-date" for documents).
 //
-// Cursor params (mutually exclusive): after, before, around.
+// Cursor params (mutually exclusive): after, before, around. Cursors are the
+// opaque nextCursor/prevCursor tokens of the previous page.
+//
+// The first page counts all matching rows (totalCount); skipCount=true or
+// withCount=false skips the COUNT(*). Cursor pages never count.
 func (h *BaseHandler) ParseListFilter(c *gin.Context, defaultOrderBy string) (domain.ListFilter, error) {
 	filter := domain.DefaultListFilter()
 	filter.Search = c.Query("search")
 	filter.Limit = min(max(h.ParseIntQuery(c, "limit", 50), 1), 500)
 	filter.OrderBy = c.DefaultQuery("orderBy", defaultOrderBy)
 	filter.IncludeDeleted = c.Query("includeDeleted") == "true"
-	filter.SkipCount = c.Query("skipCount") == "true"
+	filter.SkipCount = c.Query("skipCount") == "true" || c.Query("withCount") == "false"
 
 	// Parse cursor-based pagination params
 	afterToken := c.Query("after")
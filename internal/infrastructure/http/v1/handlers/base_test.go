@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/domain/cursor"
+)
+
+func TestParseListFilterPagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewBaseHandler()
+	parse := func(query string) (skipCount bool, req *cursor.Request) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/items?"+query, nil)
+		f, err := h.ParseListFilter(c, "name")
+		if err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		return f.SkipCount, f.CursorReq
+	}
+
+	for query, want := range map[string]bool{
+		"":                false,
+		"withCount=true":  false,
+		"withCount=false": true,
+		"skipCount=true":  true,
+	} {
+		if got, _ := parse(query); got != want {
+			t.Errorf("%q: SkipCount = %v, want %v", query, got, want)
+		}
+	}
+
+	if _, req := parse("after=tok"); req == nil || req.Direction != cursor.DirAfter || req.Token != "tok" {
+		t.Errorf("after: cursor %+v", req)
+	}
+	if _, req := parse(""); req != nil {
+		t.Errorf("first page: cursor %+v", req)
+	}
+}
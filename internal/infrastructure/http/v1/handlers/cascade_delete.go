@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/domain"
+	"metapus/internal/domain/cascadedelete"
+)
+
+// CascadeDeleteHandler handles two-phase deletion of marked documents
+// together with their dependent documents.
+type CascadeDeleteHandler struct {
+	svc *cascadedelete.Service
+}
+
+// NewCascadeDeleteHandler creates a new CascadeDeleteHandler.
+func NewCascadeDeleteHandler(svc *cascadedelete.Service) *CascadeDeleteHandler {
+	return &CascadeDeleteHandler{svc: svc}
+}
+
+// cascadePreviewRequest lists the marked documents to delete.
+type cascadePreviewRequest struct {
+	Items []domain.DeleteMarkedRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// cascadePurgeRequest repeats the previewed documents with the confirmation token.
+type cascadePurgeRequest struct {
+	Items []domain.DeleteMarkedRequest `json:"items" binding:"required,min=1,dive"`
+	Token string                       `json:"token" binding:"required"`
+}
+
+// Preview handles POST /system/marked-objects/cascade-preview — lists the
+// documents that would be deleted and issues a confirmation token.
+func (h *CascadeDeleteHandler) Preview(c *gin.Context) {
+	var req cascadePreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.NewValidation("invalid request: " + err.Error()))
+		c.Abort()
+		return
+	}
+
+	preview, err := h.svc.Preview(c.Request.Context(), req.Items)
+	if err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// Purge handles POST /system/marked-objects/purge — deletes the previewed
+// cascade; requires the token returned by Preview.
+func (h *CascadeDeleteHandler) Purge(c *gin.Context) {
+	var req cascadePurgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.NewValidation("invalid request: " + err.Error()))
+		c.Abort()
+		return
+	}
+
+	result, err := h.svc.Purge(c.Request.Context(), req.Items, req.Token)
+	if err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -12,6 +12,7 @@ import (
 	"metapus/internal/core/id"
 	"metapus/internal/core/security"
 	"metapus/internal/domain"
+	"metapus/internal/domain/attachment"
 	"metapus/internal/infrastructure/http/v1/dto"
 )
 
@@ -31,6 +32,10 @@ type CatalogHandler[T entity.CatalogEntity, CreateDTO any, UpdateDTO any] struct
 	// If nil, no resolution is performed (same pattern as BaseDocumentHandler).
 	resolveRefs      func(ctx context.Context, entities ...T) (any, error)
 	mapToDTOWithRefs func(entity T, refs any) any
+
+	// attachments stores attached files. Set via SetAttachmentService;
+	// if nil, attachment endpoints respond with 404.
+	attachments *attachment.Service
 }
 
 // CatalogHandlerConfig configures the catalog handler.
@@ -129,7 +134,7 @@ func (h *CatalogHandler[T, CreateDTO, UpdateDTO]) List(c *gin.Context) {
 		items[i] = h.toDTO(item, refs)
 	}
 
-	c.JSON(http.StatusOK, dto.CursorListResponse{
+	writeNegotiated(c, http.StatusOK, dto.CursorListResponse{
 		Items:       items,
 		NextCursor:  result.NextCursor,
 		PrevCursor:  result.PrevCursor,
@@ -244,6 +249,31 @@ func (h *CatalogHandler[T, CreateDTO, UpdateDTO]) Update(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// PushSyncChanges handles POST /{entity}/sync/push - applies edits made
+// offline; a change based on an outdated version is reported as a conflict.
+func (h *CatalogHandler[T, CreateDTO, UpdateDTO]) PushSyncChanges(c *gin.Context) {
+	var req syncPushRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	c.JSON(http.StatusOK, pushSyncChanges(c.Request.Context(), req.Changes, syncTarget[T, UpdateDTO]{
+		get:    h.service.GetByID,
+		apply:  h.mapUpdateDTO,
+		update: h.service.Update,
+		view: func(ctx context.Context, entity T) any {
+			var refs any
+			if h.resolveRefs != nil {
+				refs, _ = h.resolveRefs(ctx, entity)
+			}
+			if policy := security.GetFieldPolicy(ctx, h.entityName, "read"); policy != nil {
+				security.MaskForRead(entity, policy)
+			}
+			return h.toDTO(entity, refs)
+		},
+	}))
+}
+
 // Delete handles DELETE /{entity}/:id - soft delete entity.
 func (h *CatalogHandler[T, CreateDTO, UpdateDTO]) Delete(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/security"
+	"metapus/internal/domain"
+	"metapus/internal/domain/catalogimport"
+	"metapus/internal/infrastructure/http/v1/middleware"
+)
+
+// Batch item results.
+const (
+	batchApplied  = "applied"
+	batchValid    = "valid" // dry run: the item would be applied
+	batchSkipped  = "skipped"
+	batchConflict = "conflict"
+	batchRejected = "rejected"
+)
+
+// catalogBatchRequest is the body of POST /{entity}/batch.
+type catalogBatchRequest struct {
+	Items []catalogBatchItem `json:"items" binding:"required,min=1,max=500,dive"`
+}
+
+// catalogBatchItem is one change: the body of POST /{entity} (create) or of
+// PUT /:id (update, including the version), or a deletion.
+type catalogBatchItem struct {
+	Op      domain.BatchOp  `json:"op" binding:"required,oneof=create update delete"`
+	ID      string          `json:"id,omitempty"`
+	Version int             `json:"version,omitempty"` // delete: 0 skips the version check
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+type catalogBatchResult struct {
+	Index  int            `json:"index"`
+	Op     domain.BatchOp `json:"op"`
+	ID     string         `json:"id,omitempty"`
+	Status string         `json:"status"`
+	Data   any            `json:"data,omitempty"`
+	Error  string         `json:"error,omitempty"`
+	Field  string         `json:"field,omitempty"`
+}
+
+type catalogBatchResponse struct {
+	Applied bool                 `json:"applied"`
+	DryRun  bool                 `json:"dryRun,omitempty"`
+	Results []catalogBatchResult `json:"results"`
+	Created int                  `json:"created"`
+	Updated int                  `json:"updated"`
+	Deleted int                  `json:"deleted"`
+}
+
+// Batch returns the handler of POST /{entity}/batch. permission is the
+// permission prefix of the entity: each operation present in the batch
+// requires its own permission (permission + ":create" etc.).
+//
+// All items are applied in one transaction or none is: the response lists
+// the result of every item, and the items preventing the batch are
+// "conflict" (outdated version) or "rejected". With ?dryRun=true the items
+// are only checked.
+func (h *CatalogHandler[T, CreateDTO, UpdateDTO]) Batch(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req catalogBatchRequest
+		if !h.BindJSON(c, &req) {
+			return
+		}
+		dryRun := c.Query("dryRun") == "true"
+
+		checked := make(map[domain.BatchOp]struct{}, 3)
+		for _, item := range req.Items {
+			if _, ok := checked[item.Op]; ok {
+				continue
+			}
+			opCtx, err := middleware.CheckPermission(c, permission+":"+string(item.Op))
+			if err != nil {
+				h.Error(c, err)
+				return
+			}
+			c.Request = c.Request.WithContext(opCtx)
+			checked[item.Op] = struct{}{}
+		}
+		ctx := c.Request.Context()
+
+		resp := catalogBatchResponse{DryRun: dryRun, Results: make([]catalogBatchResult, len(req.Items))}
+		items := make([]domain.CatalogBatchItem[T], 0, len(req.Items))
+		indexes := make([]int, 0, len(req.Items))
+		for i, raw := range req.Items {
+			resp.Results[i] = catalogBatchResult{Index: i, Op: raw.Op, ID: raw.ID}
+			item, err := h.decodeBatchItem(raw)
+			if err != nil {
+				setBatchError(&resp.Results[i], err)
+				continue
+			}
+			items = append(items, item)
+			indexes = append(indexes, i)
+		}
+		invalid := len(items) < len(req.Items)
+
+		// Items that failed decoding already cancel the batch; still check
+		// the rest so the report is complete.
+		entities, rowErrs, err := h.service.ApplyBatch(ctx, items, dryRun || invalid)
+		if err != nil {
+			h.Error(c, err)
+			return
+		}
+		for _, re := range rowErrs {
+			setBatchError(&resp.Results[indexes[re.Index]], re.Err)
+		}
+
+		resp.Applied = !dryRun && !invalid && len(rowErrs) == 0
+		var refs any
+		if resp.Applied && h.resolveRefs != nil {
+			refs, _ = h.resolveRefs(ctx, entities...)
+		}
+		for i, idx := range indexes {
+			res := &resp.Results[idx]
+			if res.Status != "" {
+				continue
+			}
+			switch {
+			case dryRun && !invalid && len(rowErrs) == 0:
+				res.Status = batchValid
+			case !resp.Applied:
+				res.Status = batchSkipped
+			default:
+				res.Status = batchApplied
+				res.ID = entities[i].GetID().String()
+				switch items[i].Op {
+				case domain.BatchCreate:
+					resp.Created++
+					res.Data = h.batchView(ctx, entities[i], refs)
+				case domain.BatchUpdate:
+					resp.Updated++
+					res.Data = h.batchView(ctx, entities[i], refs)
+				case domain.BatchDelete:
+					resp.Deleted++
+				}
+			}
+		}
+
+		h.CompleteIdempotency(c, http.StatusOK, "application/json", resp)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// decodeBatchItem turns a request item into a service item.
+func (h *CatalogHandler[T, CreateDTO, UpdateDTO]) decodeBatchItem(raw catalogBatchItem) (domain.CatalogBatchItem[T], error) {
+	item := domain.CatalogBatchItem[T]{Op: raw.Op, Version: raw.Version}
+	if raw.Op != domain.BatchCreate {
+		entityID, err := id.Parse(raw.ID)
+		if err != nil {
+			return item, apperror.NewValidation("invalid id format").WithDetail("field", "id")
+		}
+		item.ID = entityID
+	}
+
+	switch raw.Op {
+	case domain.BatchCreate:
+		var dto CreateDTO
+		if err := decodeBatchData(raw.Data, &dto); err != nil {
+			return item, err
+		}
+		item.Entity = h.mapCreateDTO(dto)
+	case domain.BatchUpdate:
+		var dto UpdateDTO
+		if err := decodeBatchData(raw.Data, &dto); err != nil {
+			return item, err
+		}
+		item.Apply = func(existing T) T { return h.mapUpdateDTO(dto, existing) }
+	}
+	return item, nil
+}
+
+// decodeBatchData decodes and validates the DTO of an item like BindJSON.
+func decodeBatchData(data json.RawMessage, dto any) error {
+	if len(data) == 0 {
+		return apperror.NewValidation("data is required").WithDetail("field", "data")
+	}
+	if err := json.Unmarshal(data, dto); err != nil {
+		return apperror.NewValidation("invalid data: "+err.Error()).WithDetail("field", "data")
+	}
+	return validateImportDTO(dto)
+}
+
+// batchView maps an entity for the response, masked like GET /:id.
+func (h *CatalogHandler[T, CreateDTO, UpdateDTO]) batchView(ctx context.Context, entity T, refs any) any {
+	if policy := security.GetFieldPolicy(ctx, h.entityName, "read"); policy != nil {
+		security.MaskForRead(entity, policy)
+	}
+	return h.toDTO(entity, refs)
+}
+
+// setBatchError reports err as the result of an item.
+func setBatchError(res *catalogBatchResult, err error) {
+	res.Status = batchRejected
+	if apperror.IsConcurrentModification(err) {
+		res.Status = batchConflict
+	}
+	res.Error = err.Error()
+	var fieldErr *catalogimport.FieldError
+	if errors.As(err, &fieldErr) {
+		res.Field = fieldErr.Field
+	} else if appErr, ok := apperror.AsAppError(err); ok {
+		if field, ok := appErr.Details["field"].(string); ok {
+			res.Field = field
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/gallery"
+	"metapus/internal/infrastructure/http/v1/dto"
+	"metapus/pkg/logger"
+)
+
+// galleryEntity is a catalog entity with an image gallery.
+type galleryEntity interface {
+	entity.CatalogEntity
+	gallery.Owner
+}
+
+// CatalogImageHandler adds image gallery endpoints to a catalog handler and
+// loads the gallery of every item it returns, so responses include the
+// image URLs.
+type CatalogImageHandler[T galleryEntity, CreateDTO any, UpdateDTO any] struct {
+	*CatalogHandler[T, CreateDTO, UpdateDTO]
+	routePrefix string
+
+	// images stores the galleries. Set via SetImageService;
+	// if nil, image endpoints respond with 404 and responses have no images.
+	images *gallery.Service
+}
+
+// NewCatalogImageHandler wraps a catalog handler with image gallery support.
+// routePrefix is the catalog route prefix used in image URLs, e.g. "nomenclatures".
+func NewCatalogImageHandler[T galleryEntity, CreateDTO any, UpdateDTO any](
+	catalog *CatalogHandler[T, CreateDTO, UpdateDTO],
+	routePrefix string,
+) *CatalogImageHandler[T, CreateDTO, UpdateDTO] {
+	h := &CatalogImageHandler[T, CreateDTO, UpdateDTO]{CatalogHandler: catalog, routePrefix: routePrefix}
+
+	// Every response path resolves refs before mapping to DTO: load the
+	// galleries there.
+	resolve := catalog.resolveRefs
+	catalog.resolveRefs = func(ctx context.Context, entities ...T) (any, error) {
+		if err := h.loadImages(ctx, entities); err != nil {
+			return nil, err
+		}
+		if resolve == nil {
+			return nil, nil
+		}
+		return resolve(ctx, entities...)
+	}
+	return h
+}
+
+// SetImageService enables image endpoints for the handler.
+func (h *CatalogImageHandler[T, CreateDTO, UpdateDTO]) SetImageService(svc *gallery.Service) {
+	h.images = svc
+}
+
+// loadImages sets the gallery of every entity in one query.
+func (h *CatalogImageHandler[T, CreateDTO, UpdateDTO]) loadImages(ctx context.Context, entities []T) error {
+	if h.images == nil || len(entities) == 0 {
+		return nil
+	}
+	ids := make([]id.ID, len(entities))
+	for i, e := range entities {
+		ids[i] = e.GetID()
+	}
+	byEntity, err := h.images.ListByEntities(ctx, h.entityName, ids)
+	if err != nil {
+		return err
+	}
+	for _, e := range entities {
+		images := byEntity[e.GetID()]
+		if images == nil {
+			images = []*gallery.Image{}
+		}
+		e.SetImages(images)
+	}
+	return nil
+}
+
+// owner parses :id, checks access to the owning entity and returns its ID.
+// A missing entity or one hidden by RLS is a 404.
+func (h *CatalogImageHandler[T, CreateDTO, UpdateDTO]) owner(c *gin.Context) (id.ID, bool) {
+	if h.images == nil {
+		h.Error(c, apperror.NewNotFound("image", c.Param("imageId")))
+		return id.ID{}, false
+	}
+	entityID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return id.ID{}, false
+	}
+	if _, err := h.service.GetByID(c.Request.Context(), entityID); err != nil {
+		h.Error(c, err)
+		return id.ID{}, false
+	}
+	return entityID, true
+}
+
+func (h *CatalogImageHandler[T, CreateDTO, UpdateDTO]) imageID(c *gin.Context) (id.ID, bool) {
+	imageID, err := id.Parse(c.Param("imageId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid image id format"))
+		return id.ID{}, false
+	}
+	return imageID, true
+}
+
+func (h *CatalogImageHandler[T, CreateDTO, UpdateDTO]) itemPath(entityID id.ID) string {
+	return dto.CatalogItemPath(h.routePrefix, entityID)
+}
+
+// ListImages handles GET /{entity}/:id/images — the gallery in display order.
+func (h *CatalogImageHandler[T, CreateDTO, UpdateDTO]) ListImages(c *gin.Context) {
+	entityID, ok := h.owner(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.images.List(c.Request.Context(), h.entityName, entityID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MapImageListResponse(h.itemPath(entityID), list))
+}
+
+// UploadImage handles POST /{entity}/:id/images (multipart field "file") —
+// adds a JPEG, PNG or GIF image at the end of the gallery and generates its
+// thumbnail. The first image of an item becomes primary.
+func (h *CatalogImageHandler[T, CreateDTO, UpdateDTO]) UploadImage(c *gin.Context) {
+	entityID, ok := h.owner(c)
+	if !ok {
+		return
+	}
+
+	maxSize := h.images.MaxSize()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.Error(c, apperror.NewValidation("validation failed").
+				WithDetail("file", "file is too large").
+				WithDetail("maxSize", maxSize))
+			return
+		}
+		h.Error(c, apperror.NewValidation("multipart field 'file' is required").WithDetail("error", err.Error()))
+		return
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		h.Error(c, apperror.NewInternal(fmt.Errorf("open uploaded file: %w", err)))
+		return
+	}
+	defer f.Close()
+
+	img, err := h.images.Upload(c.Request.Context(), gallery.UploadInput{
+		EntityType:  h.entityName,
+		EntityID:    entityID,
+		FileName:    fh.Filename,
+		ContentType: fh.Header.Get("Content-Type"),
+		Size:        fh.Size,
+		Body:        f,
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.MapImageResponse(h.itemPath(entityID), img))
+}
+
+// DownloadImage handles GET /{entity}/:id/images/:imageId — the original image.
+func (h *CatalogImageHandler[T, CreateDTO, UpdateDTO]) DownloadImage(c *gin.Context) {
+	h.serveImage(c, false)
+}
+
+// DownloadImageThumbnail handles GET /{entity}/:id/images/:imageId/thumbnail.
+func (h *CatalogImageHandler[T, CreateDTO, UpdateDTO]) DownloadImageThumbnail(c *gin.Context) {
+	h.serveImage(c, true)
+}
+
+// serveImage writes the original or the thumbnail. Images are decoded on
+// upload, so unlike attachments they are served inline; their contents
+// never change, so clients may cache them.
+func (h *CatalogImageHandler[T, CreateDTO, UpdateDTO]) serveImage(c *gin.Context, thumbnail bool) {
+	entityID, ok := h.owner(c)
+	if !ok {
+		return
+	}
+	imageID, ok := h.imageID(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	img, rc, err := h.images.Open(ctx, h.entityName, entityID, imageID, thumbnail)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	defer rc.Close()
+
+	contentType := img.ContentType
+	if thumbnail {
+		contentType = img.ThumbnailContentType()
+	} else {
+		c.Header("Content-Length", strconv.FormatInt(img.Size, 10))
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Cache-Control", "private, max-age=86400")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, rc); err != nil {
+		logger.Warn(ctx, "image download interrupted", "image_id", img.ID, "error", err)
+	}
+}
+
+// DeleteImage handles DELETE /{entity}/:id/images/:imageId. Deleting the
+// primary image makes the next one primary.
+func (h *CatalogImageHandler[T, CreateDTO, UpdateDTO]) DeleteImage(c *gin.Context) {
+	entityID, ok := h.owner(c)
+	if !ok {
+		return
+	}
+	imageID, ok := h.imageID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.images.Delete(c.Request.Context(), h.entityName, entityID, imageID); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReorderImages handles PUT /{entity}/:id/images/order — body {"ids": [...]}
+// listing every image of the item in the new order.
+func (h *CatalogImageHandler[T, CreateDTO, UpdateDTO]) ReorderImages(c *gin.Context) {
+	entityID, ok := h.owner(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReorderImagesRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	list, err := h.images.Reorder(c.Request.Context(), h.entityName, entityID, req.IDs)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MapImageListResponse(h.itemPath(entityID), list))
+}
+
+// SetPrimaryImage handles POST /{entity}/:id/images/:imageId/primary.
+func (h *CatalogImageHandler[T, CreateDTO, UpdateDTO]) SetPrimaryImage(c *gin.Context) {
+	entityID, ok := h.owner(c)
+	if !ok {
+		return
+	}
+	imageID, ok := h.imageID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.images.SetPrimary(c.Request.Context(), h.entityName, entityID, imageID); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
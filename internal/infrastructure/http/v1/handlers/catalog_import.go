@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/domain/catalogimport"
+)
+
+// Import handles POST /{entity}/import — bulk creation of catalog items from
+// a CSV/XLSX file (multipart/form-data).
+//
+// Form fields:
+//   - file: .csv or .xlsx (first sheet)
+//   - mapping: optional JSON object {"file column": "dtoField"}; without it
+//     columns named like DTO fields (e.g. "name", "inn") are imported
+//   - headerRow: optional 1-based header row (default 1)
+//   - dryRun: "true" validates every row without inserting
+//
+// Rows are decoded into the create DTO, validated like POST /{entity} and
+// inserted with a single COPY. The import is all-or-nothing: any row error
+// cancels it, and all row errors are reported in the response.
+func (h *CatalogHandler[T, CreateDTO, UpdateDTO]) Import(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, catalogimport.MaxFileSize+multipartOverhead)
+	fh, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.Error(c, apperror.NewValidation("validation failed").
+				WithDetail("file", "file is too large").
+				WithDetail("maxSize", catalogimport.MaxFileSize))
+			return
+		}
+		h.Error(c, apperror.NewValidation("multipart field 'file' is required").WithDetail("error", err.Error()))
+		return
+	}
+
+	var mapping catalogimport.Mapping
+	if raw := c.PostForm("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			h.Error(c, apperror.NewValidation("mapping must be a JSON object of column → field").
+				WithDetail("field", "mapping"))
+			return
+		}
+	}
+	headerRow, _ := strconv.Atoi(c.PostForm("headerRow"))
+	dryRun := c.PostForm("dryRun") == "true" || c.Query("dryRun") == "true"
+
+	f, err := fh.Open()
+	if err != nil {
+		h.Error(c, apperror.NewInternal(fmt.Errorf("open uploaded file: %w", err)))
+		return
+	}
+	data, err := io.ReadAll(f)
+	_ = f.Close()
+	if err != nil {
+		h.Error(c, apperror.NewInternal(fmt.Errorf("read uploaded file: %w", err)))
+		return
+	}
+
+	table, err := catalogimport.Parse(fh.Filename, data, headerRow)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	decoder := catalogimport.NewDecoder[CreateDTO]()
+	cols, err := decoder.Columns(table.Header, mapping)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	result := catalogimport.Result{DryRun: dryRun, TotalRows: len(table.Rows), Errors: []catalogimport.RowError{}}
+	entities := make([]T, 0, len(table.Rows))
+	rowNumbers := make([]int, 0, len(table.Rows))
+
+	for _, row := range table.Rows {
+		req, err := decoder.Decode(cols, row.Cells)
+		if err == nil {
+			err = validateImportDTO(&req)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, catalogimport.NewRowError(row.Number, err, table.Header, cols))
+			continue
+		}
+		entities = append(entities, h.mapCreateDTO(req))
+		rowNumbers = append(rowNumbers, row.Number)
+	}
+
+	// Rows that failed decoding already cancel the import; still validate the
+	// rest so the report is complete.
+	rowErrs, err := h.service.CreateBatch(ctx, entities, dryRun || len(result.Errors) > 0)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	failed := make(map[int]struct{}, len(rowErrs))
+	for _, re := range rowErrs {
+		failed[re.Index] = struct{}{}
+		result.Errors = append(result.Errors, catalogimport.NewRowError(rowNumbers[re.Index], re.Err, table.Header, cols))
+	}
+	catalogimport.SortErrors(result.Errors)
+
+	result.ValidRows = len(entities) - len(failed)
+	if !dryRun && len(result.Errors) == 0 {
+		result.Imported = len(entities)
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// validateImportDTO applies the DTO binding rules (as ShouldBindJSON does for
+// POST /{entity}) and reports the first failing field by its JSON name.
+func validateImportDTO(obj any) error {
+	err := binding.Validator.ValidateStruct(obj)
+	if err == nil {
+		return nil
+	}
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) || len(verrs) == 0 {
+		return apperror.NewValidation(err.Error())
+	}
+
+	fe := verrs[0]
+	field := fe.Field()
+	if sf, ok := reflect.TypeOf(obj).Elem().FieldByName(fe.StructField()); ok {
+		if name := strings.SplitN(sf.Tag.Get("json"), ",", 2)[0]; name != "" && name != "-" {
+			field = name
+		}
+	}
+	return &catalogimport.FieldError{Field: field, Message: fmt.Sprintf("failed on the '%s' rule", fe.Tag())}
+}
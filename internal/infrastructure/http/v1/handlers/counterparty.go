@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/catalogs/counterparty"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+// CounterpartyHandler adds requisites lookup by INN and contact persons to
+// the counterparty catalog handler with image gallery.
+type CounterpartyHandler struct {
+	*CatalogImageHandler[*counterparty.Counterparty, dto.CreateCounterpartyRequest, dto.UpdateCounterpartyRequest]
+	counterparties *counterparty.Service
+}
+
+// NewCounterpartyHandler creates a new counterparty handler.
+func NewCounterpartyHandler(
+	catalog *CatalogImageHandler[*counterparty.Counterparty, dto.CreateCounterpartyRequest, dto.UpdateCounterpartyRequest],
+	service *counterparty.Service,
+) *CounterpartyHandler {
+	return &CounterpartyHandler{CatalogImageHandler: catalog, counterparties: service}
+}
+
+// LookupRequisites handles GET /catalog/counterparties/requisites?inn= — the
+// requisites of the organization with the INN from the configured provider,
+// used to fill a new counterparty.
+func (h *CounterpartyHandler) LookupRequisites(c *gin.Context) {
+	r, err := h.counterparties.LookupRequisites(c.Request.Context(), c.Query("inn"))
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, r)
+}
+
+// FindContacts handles GET /catalog/counterparties/contacts?phone=&email= —
+// the contact persons with the phone (compared by its last 10 digits) or
+// the email, with their counterparties.
+func (h *CounterpartyHandler) FindContacts(c *gin.Context) {
+	matches, err := h.counterparties.FindContacts(c.Request.Context(), counterparty.ContactFilter{
+		Phone: c.Query("phone"),
+		Email: c.Query("email"),
+		Limit: min(max(h.ParseIntQuery(c, "limit", 50), 1), 500),
+	})
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": dto.FromContactMatches(matches)})
+}
+
+// ListContacts handles GET /catalog/counterparties/:id/contacts.
+func (h *CounterpartyHandler) ListContacts(c *gin.Context) {
+	counterpartyID, ok := h.counterpartyID(c)
+	if !ok {
+		return
+	}
+
+	contacts, err := h.counterparties.ListContacts(c.Request.Context(), counterpartyID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	items := make([]dto.ContactDTO, len(contacts))
+	for i, contact := range contacts {
+		items[i] = dto.FromContact(contact)
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// AddContact handles POST /catalog/counterparties/:id/contacts.
+func (h *CounterpartyHandler) AddContact(c *gin.Context) {
+	counterpartyID, ok := h.counterpartyID(c)
+	if !ok {
+		return
+	}
+	var req dto.ContactDTO
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	contact, err := h.counterparties.AddContact(c.Request.Context(), counterpartyID, req.ToDomain())
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.FromContact(*contact))
+}
+
+// UpdateContact handles PUT /catalog/counterparties/:id/contacts/:contactId.
+func (h *CounterpartyHandler) UpdateContact(c *gin.Context) {
+	counterpartyID, ok := h.counterpartyID(c)
+	if !ok {
+		return
+	}
+	contactID, ok := h.contactID(c)
+	if !ok {
+		return
+	}
+	var req dto.ContactDTO
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	contact, err := h.counterparties.UpdateContact(c.Request.Context(), counterpartyID, contactID, req.ToDomain())
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromContact(*contact))
+}
+
+// DeleteContact handles DELETE /catalog/counterparties/:id/contacts/:contactId.
+func (h *CounterpartyHandler) DeleteContact(c *gin.Context) {
+	counterpartyID, ok := h.counterpartyID(c)
+	if !ok {
+		return
+	}
+	contactID, ok := h.contactID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.counterparties.DeleteContact(c.Request.Context(), counterpartyID, contactID); err != nil {
+		h.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CounterpartyHandler) counterpartyID(c *gin.Context) (id.ID, bool) {
+	counterpartyID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return id.ID{}, false
+	}
+	return counterpartyID, true
+}
+
+func (h *CounterpartyHandler) contactID(c *gin.Context) (id.ID, bool) {
+	contactID, err := id.Parse(c.Param("contactId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid contact id format"))
+		return id.ID{}, false
+	}
+	return contactID, true
+}
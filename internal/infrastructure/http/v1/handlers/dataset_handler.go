@@ -1,14 +1,17 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
 	"metapus/internal/core/apperror"
+	"metapus/internal/domain/analytics"
 	"metapus/internal/domain/reports/compiler"
 	"metapus/internal/domain/reports/export"
+	"metapus/internal/domain/settings"
 	"metapus/internal/metadata"
 )
 
@@ -21,19 +24,29 @@ import (
 // DatasetReportHandler serves dataset-based report endpoints.
 type DatasetReportHandler struct {
 	*BaseHandler
-	compiler *compiler.Compiler
-	registry *metadata.Registry
+	compiler  *compiler.Compiler
+	registry  *metadata.Registry
+	analytics *analytics.Emitter // nil disables report run events
 }
 
 // NewDatasetReportHandler creates a handler for dataset-based reports.
-func NewDatasetReportHandler(base *BaseHandler, comp *compiler.Compiler, reg *metadata.Registry) *DatasetReportHandler {
+func NewDatasetReportHandler(base *BaseHandler, comp *compiler.Compiler, reg *metadata.Registry, emitter *analytics.Emitter) *DatasetReportHandler {
 	return &DatasetReportHandler{
 		BaseHandler: base,
 		compiler:    comp,
 		registry:    reg,
+		analytics:   emitter,
 	}
 }
 
+// trackRun emits a report run event (mode: view, grouped, export).
+func (h *DatasetReportHandler) trackRun(ctx context.Context, datasetKey, mode string) {
+	h.analytics.Track(ctx, analytics.EventReportRun, map[string]any{
+		"dataset": datasetKey,
+		"mode":    mode,
+	})
+}
+
 // HandleMeta returns a gin.HandlerFunc that serves GET /reports/{key}/metadata.
 func (h *DatasetReportHandler) HandleMeta(datasetKey string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -57,15 +70,35 @@ func (h *DatasetReportHandler) HandleExecute(c *gin.Context) {
 		return
 	}
 
+	applyReportRowLimit(ctx, &req)
+
 	result, err := h.compiler.Execute(ctx, req)
 	if err != nil {
-		h.Error(c, apperror.NewInternal(err))
+		h.Error(c, reportExecutionError(err))
 		return
 	}
+	h.trackRun(ctx, req.Dataset, "view")
 
 	c.JSON(http.StatusOK, result)
 }
 
+// applyReportRowLimit caps requests without an explicit limit by the
+// reports.rowLimit setting of the current user.
+func applyReportRowLimit(ctx context.Context, req *compiler.QueryRequest) {
+	if req.Limit <= 0 {
+		req.Limit = settings.Get(ctx, settings.KeyReportRowLimit, settings.SubjectFromContext(ctx))
+	}
+}
+
+// reportExecutionError keeps client-facing errors of the compiler (plan
+// limits, validation) and hides everything else as an internal error.
+func reportExecutionError(err error) error {
+	if apperror.IsAppError(err) {
+		return err
+	}
+	return apperror.NewInternal(err)
+}
+
 // HandleExport returns a gin.HandlerFunc that serves POST /reports/{key}/export.
 func (h *DatasetReportHandler) HandleExport(datasetKey string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -82,9 +115,10 @@ func (h *DatasetReportHandler) HandleExport(datasetKey string) gin.HandlerFunc {
 
 		result, err := h.compiler.Execute(ctx, req)
 		if err != nil {
-			h.Error(c, apperror.NewInternal(err))
+			h.Error(c, reportExecutionError(err))
 			return
 		}
+		h.trackRun(ctx, datasetKey, "export")
 
 		ds := h.compiler.GetDataset(datasetKey)
 		meta := compiler.DatasetToMeta(ds, h.registry)
@@ -110,12 +144,14 @@ func (h *DatasetReportHandler) HandleGrouped(datasetKey string) gin.HandlerFunc
 			return
 		}
 		req.Dataset = datasetKey
+		applyReportRowLimit(ctx, &req)
 
 		result, err := h.compiler.Execute(ctx, req)
 		if err != nil {
-			h.Error(c, apperror.NewInternal(err))
+			h.Error(c, reportExecutionError(err))
 			return
 		}
+		h.trackRun(ctx, datasetKey, "grouped")
 
 		ds := h.compiler.GetDataset(datasetKey)
 		meta := compiler.DatasetToMeta(ds, h.registry)
@@ -15,6 +15,11 @@ import (
 	"metapus/internal/core/id"
 	"metapus/internal/core/security"
 	"metapus/internal/domain"
+	"metapus/internal/domain/attachment"
+	"metapus/internal/domain/basedon"
+	"metapus/internal/domain/board"
+	"metapus/internal/domain/doctemplate"
+	"metapus/internal/domain/recurring"
 	domainFilter "metapus/internal/domain/filter"
 	"metapus/internal/domain/settings"
 	"metapus/internal/infrastructure/http/v1/dto"
@@ -48,6 +53,33 @@ type BaseDocumentHandler[T any, CreateDTO any, UpdateDTO any] struct {
 	// settingsRepo reads tenant-level settings (batch concurrency, etc.).
 	// If nil, default values are used.
 	settingsRepo settings.Repository
+
+	// templates stores document templates. Set via SetTemplateService;
+	// if nil, template endpoints respond with 404.
+	templates *doctemplate.Service
+
+	// attachments stores attached files. Set via SetAttachmentService;
+	// if nil, attachment endpoints respond with 404.
+	attachments *attachment.Service
+
+	// uploadSessions runs bulk attachment uploads. Set via
+	// SetUploadSessionService; if nil, upload session endpoints respond with 404.
+	uploadSessions *attachment.SessionService
+
+	// schedules stores recurring document schedules. Set via SetRecurringService;
+	// if nil, schedule endpoints respond with 404.
+	schedules *recurring.Service
+
+	// board builds the status board. Set via SetBoard;
+	// if nil, board endpoints respond with 404.
+	board *board.Service
+
+	// boardActions perform document-specific board actions (close, approve).
+	boardActions map[board.Action]boardAction
+
+	// basedOn creates documents from basis documents. Set via SetBasedOn;
+	// if nil, the based-on endpoint rejects every basis type.
+	basedOn *basedon.Mappings[T]
 }
 
 // BaseDocumentHandlerConfig configures the document handler.
@@ -149,12 +181,9 @@ func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) Get(c *gin.Context) {
 }
 
 // GetMovements fetches movements for this document across all configured MovementProviders.
+// The document is loaded first so a missing document is a 404 and RLS applies
+// to its movements the same way it applies to the document itself.
 func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) GetMovements(c *gin.Context) {
-	if len(h.movementProviders) == 0 {
-		c.JSON(http.StatusOK, gin.H{"movements": []entity.DocumentMovement{}, "count": 0})
-		return
-	}
-
 	ctx := c.Request.Context()
 	docIDStr := c.Param("id")
 	docID, err := id.Parse(docIDStr)
@@ -163,7 +192,12 @@ func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) GetMovements(c *gin.Conte
 		return
 	}
 
-	var allMovements []entity.DocumentMovement
+	if _, err := h.service.GetByID(ctx, docID); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	allMovements := []entity.DocumentMovement{}
 
 	// Extract movements from every configured provider
 	for _, provider := range h.movementProviders {
@@ -197,8 +231,6 @@ func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) GetMovements(c *gin.Conte
 // Note: GoodsReceipt and GoodsIssue BOTH have PostImmediately.
 // Let's add a `IsPostImmediately(CreateDTO) bool` function to config?
 func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) Create(c *gin.Context) {
-	ctx := c.Request.Context()
-
 	var req CreateDTO
 	if !h.BindJSON(c, &req) {
 		return
@@ -228,7 +260,15 @@ func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) Create(c *gin.Context) {
 	// Let's add `SetUserID(T, string)` to config?
 	// And `IsPostImmediately(CreateDTO) bool` to config.
 
-	if h.isPostImmediately != nil && h.isPostImmediately(req) {
+	h.createDocument(c, doc, h.isPostImmediately != nil && h.isPostImmediately(req))
+}
+
+// createDocument saves (and optionally posts) a new document and writes the response.
+// Shared by Create and CreateFromTemplate.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) createDocument(c *gin.Context, doc T, postImmediately bool) {
+	ctx := c.Request.Context()
+
+	if postImmediately {
 		if err := h.service.PostAndSave(ctx, doc); err != nil {
 			h.Error(c, err)
 			return
@@ -298,6 +338,31 @@ func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) Update(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// PushSyncChanges handles POST /{entity}/sync/push - applies edits made
+// offline; a change based on an outdated version is reported as a conflict.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) PushSyncChanges(c *gin.Context) {
+	var req syncPushRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	c.JSON(http.StatusOK, pushSyncChanges(c.Request.Context(), req.Changes, syncTarget[T, UpdateDTO]{
+		get:    h.service.GetByID,
+		apply:  h.mapUpdateDTO,
+		update: h.service.Update,
+		view: func(ctx context.Context, doc T) any {
+			var refs any
+			if h.resolveRefs != nil {
+				refs, _ = h.resolveRefs(ctx, doc)
+			}
+			if policy := security.GetFieldPolicy(ctx, h.entityName, "read"); policy != nil {
+				security.MaskForRead(doc, policy)
+			}
+			return h.toDTO(doc, refs)
+		},
+	}))
+}
+
 // Delete handles DELETE /{entity}/:id
 func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) Delete(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -317,6 +382,8 @@ func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) Delete(c *gin.Context) {
 }
 
 // Post handles POST /{entity}/:id/post
+// With ?dryRun=true nothing is written: the response lists the movements the
+// document would record and the validation errors that would reject it.
 func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) Post(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -326,6 +393,16 @@ func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) Post(c *gin.Context) {
 		return
 	}
 
+	if c.Query("dryRun") == "true" {
+		result, err := h.service.PostDryRun(ctx, docID)
+		if err != nil {
+			h.Error(c, err)
+			return
+		}
+		h.OK(c, dto.FromDryRunResult(result))
+		return
+	}
+
 	if err := h.service.Post(ctx, docID); err != nil {
 		h.Error(c, err)
 		return
@@ -352,6 +429,12 @@ func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) Post(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// Repost re-posts a posted document outside of an HTTP request, through
+// the same service pipeline as Post. Used by the re-posting tool.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) Repost(ctx context.Context, docID id.ID) error {
+	return h.service.Post(ctx, docID)
+}
+
 // Unpost handles POST /{entity}/:id/unpost
 func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) Unpost(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -465,7 +548,7 @@ func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) List(c *gin.Context) {
 		items[i] = h.toDTO(item, refs)
 	}
 
-	c.JSON(http.StatusOK, dto.CursorListResponse{
+	writeNegotiated(c, http.StatusOK, dto.CursorListResponse{
 		Items:       items,
 		NextCursor:  result.NextCursor,
 		PrevCursor:  result.PrevCursor,
@@ -476,6 +559,41 @@ func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) List(c *gin.Context) {
 	})
 }
 
+// SuggestNumber handles GET /{entity}/next-number — returns the next free
+// document number for the given date (and organization) without consuming
+// the sequence. Query: date (RFC3339 or YYYY-MM-DD, default now), organizationId.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) SuggestNumber(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	date := time.Now()
+	if raw := c.Query("date"); raw != "" {
+		parsed, err := parseDateParam(raw, false)
+		if err != nil {
+			h.Error(c, apperror.NewValidation("invalid date format").WithDetail("field", "date"))
+			return
+		}
+		date = parsed
+	}
+
+	var orgID *id.ID
+	if raw := c.Query("organizationId"); raw != "" {
+		parsed, err := id.Parse(raw)
+		if err != nil {
+			h.Error(c, apperror.NewValidation("invalid organizationId format").WithDetail("field", "organizationId"))
+			return
+		}
+		orgID = &parsed
+	}
+
+	number, err := h.service.SuggestNumber(ctx, date, orgID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"number": number})
+}
+
 // ExportList handles POST /{entity}/export-list — exports the current list view to XLSX.
 // Reuses the same List pipeline (filters, sorting, RLS, FLS, FK resolution)
 // but without pagination (capped at ExportMaxRows).
@@ -546,9 +664,14 @@ const _defaultBatchConcurrency = 5
 // _maxConnsPerTenant must match tenant.Manager config. Used for clamping.
 const _maxConnsPerTenant = 10
 
-// getBatchConcurrency reads the configured concurrency from tenant settings.
+// getBatchConcurrency reads the configured concurrency: the scoped
+// posting.batchConcurrency value of the current user or tenant, then the
+// legacy performance section of tenant settings.
 // Falls back to defaultBatchConcurrency on any error or if settingsRepo is nil.
 func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) getBatchConcurrency(ctx context.Context) int {
+	if v, ok := settings.GetOK(ctx, settings.KeyPostingBatchConcurrency, settings.SubjectFromContext(ctx)); ok {
+		return settings.ClampBatchConcurrency(v, _maxConnsPerTenant)
+	}
 	if h.settingsRepo == nil {
 		return _defaultBatchConcurrency
 	}
@@ -610,7 +733,8 @@ func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) executeBatchConcurrent(
 // Processes each document independently (partial mode):
 //   - One failure does not roll back others
 //   - Returns per-item results for the client to display
-//   - Permission checks are performed per-action inside the service layer
+//   - Posting permissions are checked per-action inside the service layer,
+//     deletion marks need the delete permission
 //   - Documents are processed concurrently via worker pool
 func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) BatchAction(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -619,6 +743,9 @@ func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) BatchAction(c *gin.Contex
 	if !h.BindJSON(c, &req) {
 		return
 	}
+	if !h.checkBatchAction(c, req.Action) {
+		return
+	}
 
 	// Pre-validate IDs and build typed slice
 	parsedIDs := make([]id.ID, 0, len(req.IDs))
@@ -693,6 +820,9 @@ func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) BatchActionByFilter(c *gi
 	if !h.BindJSON(c, &req) {
 		return
 	}
+	if !h.checkBatchAction(c, req.Action) {
+		return
+	}
 
 	// Build ListFilter from request
 	listFilter := domain.DefaultListFilter()
@@ -871,6 +1001,16 @@ func writeSSE(c *gin.Context, event sseEvent) {
 	c.Writer.Flush()
 }
 
+// checkBatchAction checks the permission of a batch action the service layer
+// does not: deletion marks need the delete permission (posting and unposting
+// are checked per document by the service).
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) checkBatchAction(c *gin.Context, action string) bool {
+	if action == "setDeletionMark" || action == "clearDeletionMark" {
+		return h.requireEntityPermission(c, "delete")
+	}
+	return true
+}
+
 // executeAction runs a single batch action on one document.
 func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) executeAction(
 	ctx context.Context, docID id.ID, action string,
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/basedon"
+	"metapus/internal/infrastructure/http/v1/middleware"
+)
+
+// SetBasedOn enables creating documents based on other documents.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) SetBasedOn(mappings *basedon.Mappings[T]) {
+	h.basedOn = mappings
+}
+
+// CreateBasedOn handles POST /{entity}/based-on/:basisType/:basisId — creates
+// an unposted document filled from the basis document (e.g. a goods issue
+// from a sales order) and linked to it. Reading the basis requires its own
+// read permission.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) CreateBasedOn(c *gin.Context) {
+	basisType := c.Param("basisType")
+	if h.basedOn == nil {
+		h.Error(c, apperror.NewValidation("documents cannot be created based on "+basisType).
+			WithDetail("field", "basisType"))
+		return
+	}
+	mapping, err := h.basedOn.Get(basisType)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	basisID, err := id.Parse(c.Param("basisId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	basisCtx, err := middleware.CheckPermission(c, mapping.Permission)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	doc, err := mapping.Build(basisCtx, basisID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	h.createDocument(c, doc, false)
+}
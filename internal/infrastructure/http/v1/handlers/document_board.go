@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/board"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+const (
+	// defaultBoardCards is the number of cards returned per board column.
+	defaultBoardCards = 20
+	// maxBoardCards bounds the cardLimit query parameter.
+	maxBoardCards = 100
+)
+
+// boardAction performs a board transition on a document.
+type boardAction func(ctx context.Context, docID id.ID) error
+
+// SetBoard enables status board endpoints for the handler.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) SetBoard(svc *board.Service) {
+	h.board = svc
+}
+
+// setBoardAction registers the operation behind a document-specific board
+// action (close, approve). Post and unpost are handled by the base handler.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) setBoardAction(action board.Action, fn boardAction) {
+	if h.boardActions == nil {
+		h.boardActions = make(map[board.Action]boardAction)
+	}
+	h.boardActions[action] = fn
+}
+
+// boardService returns the board service or writes a 404 if the board is disabled.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) boardService(c *gin.Context) (*board.Service, bool) {
+	if h.board == nil {
+		h.Error(c, apperror.NewNotFound("board", h.entityName))
+		return nil, false
+	}
+	return h.board, true
+}
+
+// resolveBoardAction returns the operation performing a board action.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) resolveBoardAction(action board.Action) (boardAction, bool) {
+	switch action {
+	case board.ActionPost:
+		return h.service.Post, true
+	case board.ActionUnpost:
+		return h.service.Unpost, true
+	}
+	fn, ok := h.boardActions[action]
+	return fn, ok
+}
+
+// boardPermission is the permission a board action requires;
+// it matches the permission of the action's own route.
+func boardPermission(action board.Action) string {
+	if action == board.ActionClose {
+		return "post"
+	}
+	return string(action)
+}
+
+// GetBoard handles GET /{entity}/board — documents grouped by workflow state.
+// Accepts the list filters (search, advanced filters); cardLimit bounds
+// the cards per column (default 20, max 100).
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) GetBoard(c *gin.Context) {
+	svc, ok := h.boardService(c)
+	if !ok {
+		return
+	}
+
+	filter, err := h.ParseListFilter(c, "-date")
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	cardLimit := min(max(h.ParseIntQuery(c, "cardLimit", defaultBoardCards), 0), maxBoardCards)
+
+	columns, err := svc.Columns(c.Request.Context(), filter, cardLimit)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BoardResponse{
+		Columns:     columns,
+		Transitions: svc.Workflow().Transitions,
+	})
+}
+
+// MoveBoardState handles PATCH /{entity}/:id/state — moves a document to
+// another board state by performing the transition's action (post, unpost,
+// close, approve). The action's own permission is checked here.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) MoveBoardState(c *gin.Context) {
+	svc, ok := h.boardService(c)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+
+	docID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	var req dto.MoveBoardStateRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	// Enforce read access (RLS, read policies) before revealing the state.
+	if _, err := h.service.GetByID(ctx, docID); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	transition, err := svc.Plan(ctx, docID, req.State)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	if !h.requireEntityPermission(c, boardPermission(transition.Action)) {
+		return
+	}
+
+	perform, ok := h.resolveBoardAction(transition.Action)
+	if !ok {
+		h.Error(c, apperror.NewBusinessRule("BOARD_ACTION_NOT_SUPPORTED",
+			"action "+string(transition.Action)+" is not supported by this document"))
+		return
+	}
+	if err := perform(ctx, docID); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	// Re-read: some states are maintained by registers during posting.
+	state, err := svc.State(ctx, docID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MoveBoardStateResponse{
+		ID:     docID,
+		Action: transition.Action,
+		State:  state,
+	})
+}
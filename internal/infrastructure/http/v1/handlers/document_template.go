@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/doctemplate"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+// SetTemplateService enables document template endpoints for the handler.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) SetTemplateService(svc *doctemplate.Service) {
+	h.templates = svc
+}
+
+// templateService returns the template service or writes a 404 if templates are disabled.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) templateService(c *gin.Context) (*doctemplate.Service, bool) {
+	if h.templates == nil {
+		h.Error(c, apperror.NewNotFound("document_template", c.Param("templateId")))
+		return nil, false
+	}
+	return h.templates, true
+}
+
+// ListTemplates handles GET /{entity}/templates — templates available to the current user.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) ListTemplates(c *gin.Context) {
+	svc, ok := h.templateService(c)
+	if !ok {
+		return
+	}
+
+	list, err := svc.GetList(c.Request.Context(), h.entityName)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MapDocumentTemplateListResponse(list))
+}
+
+// SaveAsTemplate handles POST /{entity}/:id/save-as-template — stores the document
+// header and lines (without quantities) as a reusable template.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) SaveAsTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	svc, ok := h.templateService(c)
+	if !ok {
+		return
+	}
+
+	docID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	var req dto.SaveDocumentTemplateRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	doc, err := h.service.GetByID(ctx, docID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	// Round-trip the document through its create request so that only
+	// fields accepted on create end up in the template.
+	h.applyFLSRead(c, doc)
+	raw, err := json.Marshal(h.mapToDTO(doc))
+	if err != nil {
+		h.Error(c, apperror.NewInternal(err))
+		return
+	}
+	var createReq CreateDTO
+	if err := json.Unmarshal(raw, &createReq); err != nil {
+		h.Error(c, apperror.NewInternal(err))
+		return
+	}
+	if raw, err = json.Marshal(createReq); err != nil {
+		h.Error(c, apperror.NewInternal(err))
+		return
+	}
+	payload, err := doctemplate.BuildPayload(raw)
+	if err != nil {
+		h.Error(c, apperror.NewInternal(err))
+		return
+	}
+
+	t := &doctemplate.Template{
+		ID:           uuid.New(),
+		DocumentType: h.entityName,
+		Name:         req.Name,
+		Visibility:   req.Visibility,
+		Payload:      payload,
+	}
+	if err := svc.Create(ctx, t); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.MapDocumentTemplateResponse(t))
+}
+
+// UpdateTemplate handles PUT /{entity}/templates/:templateId — rename or change sharing.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) UpdateTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	svc, ok := h.templateService(c)
+	if !ok {
+		return
+	}
+
+	templateID, err := uuid.Parse(c.Param("templateId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid UUID"))
+		return
+	}
+
+	var req dto.UpdateDocumentTemplateRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	if _, err := svc.Get(ctx, h.entityName, templateID); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	t := &doctemplate.Template{
+		ID:         templateID,
+		Name:       req.Name,
+		Visibility: req.Visibility,
+		Version:    req.Version,
+	}
+	if err := svc.Update(ctx, t); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MapDocumentTemplateResponse(t))
+}
+
+// DeleteTemplate handles DELETE /{entity}/templates/:templateId
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) DeleteTemplate(c *gin.Context) {
+	svc, ok := h.templateService(c)
+	if !ok {
+		return
+	}
+
+	templateID, err := uuid.Parse(c.Param("templateId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid UUID"))
+		return
+	}
+
+	if err := svc.Delete(c.Request.Context(), h.entityName, templateID); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateFromTemplate handles POST /{entity}/from-template/:templateId — creates
+// a new document from a template. The body supplies the date and line quantities.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) CreateFromTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	svc, ok := h.templateService(c)
+	if !ok {
+		return
+	}
+
+	templateID, err := uuid.Parse(c.Param("templateId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid UUID"))
+		return
+	}
+
+	var req dto.CreateFromTemplateRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	t, err := svc.Get(ctx, h.entityName, templateID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	date := time.Now()
+	if req.Date != nil {
+		date = *req.Date
+	}
+	doc, err := h.instantiateTemplate(t.Payload, date, req.Quantities)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	h.createDocument(c, doc, req.PostImmediately)
+}
+
+// CreateFromTemplatePayload creates (and optionally posts) a document from a
+// template payload outside of an HTTP request. Used by recurring schedules.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) CreateFromTemplatePayload(
+	ctx context.Context, payload json.RawMessage, date time.Time, quantities []json.RawMessage, post bool,
+) (id.ID, error) {
+	doc, err := h.instantiateTemplate(payload, date, quantities)
+	if err != nil {
+		return id.ID{}, err
+	}
+
+	if post {
+		err = h.service.PostAndSave(ctx, doc)
+	} else {
+		err = h.service.Create(ctx, doc)
+	}
+	if err != nil {
+		return id.ID{}, err
+	}
+
+	accessor, ok := any(doc).(interface{ GetID() id.ID })
+	if !ok {
+		return id.ID{}, apperror.NewInternal(fmt.Errorf("%s document has no ID accessor", h.entityName))
+	}
+	return accessor.GetID(), nil
+}
+
+// instantiateTemplate builds a document from a template payload. The result
+// is decoded and validated exactly like a regular create request.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) instantiateTemplate(
+	payload json.RawMessage, date time.Time, quantities []json.RawMessage,
+) (T, error) {
+	var zero T
+	raw, err := doctemplate.Instantiate(payload, date, quantities)
+	if err != nil {
+		return zero, apperror.NewInternal(err)
+	}
+
+	var createReq CreateDTO
+	if err := json.Unmarshal(raw, &createReq); err != nil {
+		return zero, apperror.NewValidation("invalid request body").WithDetail("error", err.Error())
+	}
+	if err := binding.Validator.ValidateStruct(&createReq); err != nil {
+		return zero, apperror.NewValidation("invalid request body").WithDetail("error", err.Error())
+	}
+	return h.mapCreateDTO(createReq), nil
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/ugorji/go/codec"
+)
+
+// MIMEMsgPack is the media type of MessagePack responses.
+const MIMEMsgPack = "application/msgpack"
+
+// negotiatedFormats are the response formats of list and sync endpoints,
+// JSON first: it is served unless the client asks for MessagePack.
+var negotiatedFormats = []string{binding.MIMEJSON, MIMEMsgPack, binding.MIMEMSGPACK}
+
+// MessagePack extension types of the scalars whose JSON form is text: the
+// payload is that text (UTF-8), e.g. "0192…" for an ID and "95.1234" for a
+// decimal.
+const (
+	msgpackExtID      = 1
+	msgpackExtDecimal = 2
+)
+
+// msgpackHandle encodes responses in one pass. Struct fields are named by
+// their json tags (codec's default tag keys). Times are MessagePack
+// timestamps (codec always encodes time.Time natively), IDs and decimals
+// are text extensions, and types.Quantity encodes itself as a number
+// (codec.Selfer).
+var msgpackHandle = newMsgpackHandle()
+
+func newMsgpackHandle() *codec.MsgpackHandle {
+	h := &codec.MsgpackHandle{}
+	h.WriteExt = true
+	mustSetTextExt(h, msgpackExtID, uuid.UUID{}, func(v any) string { return v.(*uuid.UUID).String() })
+	mustSetTextExt(h, msgpackExtDecimal, decimal.Decimal{}, func(v any) string { return v.(*decimal.Decimal).String() })
+	return h
+}
+
+// textExt writes a value as its text form. Responses are never decoded
+// with msgpackHandle, so ReadExt is not needed.
+type textExt func(v any) string
+
+func (x textExt) WriteExt(v any) []byte { return []byte(x(v)) }
+func (x textExt) ReadExt(any, []byte)   { panic("msgpack: text extensions are encode-only") }
+
+func mustSetTextExt(h *codec.MsgpackHandle, tag uint64, zero any, text textExt) {
+	if err := h.SetBytesExt(reflect.TypeOf(zero), tag, text); err != nil {
+		panic(err)
+	}
+}
+
+// writeNegotiated writes v as JSON, or as MessagePack for clients that send
+// Accept: application/msgpack (high-volume clients: scanners, sync).
+// Both carry the same fields under the same names; only the framing differs.
+func writeNegotiated(c *gin.Context, status int, v any) {
+	c.Header("Vary", "Accept")
+	if f := c.NegotiateFormat(negotiatedFormats...); f != MIMEMsgPack && f != binding.MIMEMSGPACK {
+		c.JSON(status, v)
+		return
+	}
+
+	var body []byte
+	if err := codec.NewEncoderBytes(&body, msgpackHandle).Encode(v); err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	c.Data(status, MIMEMsgPack+"; charset=utf-8", body)
+}
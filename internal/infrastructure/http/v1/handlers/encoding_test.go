@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/shopspring/decimal"
+	"github.com/ugorji/go/codec"
+
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+)
+
+func TestWriteNegotiated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	type item struct {
+		Name     string         `json:"name"`
+		Quantity types.Quantity `json:"quantity"`
+		Lines    int            `json:"lines"`
+		Note     string         `json:"note,omitempty"`
+	}
+	r := gin.New()
+	r.GET("/items", func(c *gin.Context) {
+		writeNegotiated(c, http.StatusOK, gin.H{"items": []item{{Name: "Bolt", Quantity: types.NewQuantityFromFloat64(1.5), Lines: 3}}})
+	})
+	get := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/items", nil)
+		req.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, accept := range []string{"", "*/*", "application/json", "text/html"} {
+		if w := get(accept); !strings.HasPrefix(w.Header().Get("Content-Type"), binding.MIMEJSON) {
+			t.Errorf("Accept %q: Content-Type %q, want JSON", accept, w.Header().Get("Content-Type"))
+		}
+	}
+
+	w := get("application/msgpack, application/json;q=0.5")
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), MIMEMsgPack) {
+		t.Fatalf("Content-Type %q, want MessagePack", w.Header().Get("Content-Type"))
+	}
+	var got map[string]any
+	if err := binding.MsgPack.BindBody(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	items, _ := got["items"].([]any)
+	if len(items) != 1 {
+		t.Fatalf("items = %#v", got["items"])
+	}
+	first, _ := items[0].(map[any]any)
+	if first == nil {
+		t.Fatalf("item = %#v", items[0])
+	}
+	// Same fields and values as the JSON form; whole numbers as integers.
+	// (gin's decoder reads strings as raw bytes.)
+	name, _ := first["name"].([]byte)
+	if string(name) != "Bolt" || first["quantity"] != 1.5 || fmt.Sprint(first["lines"]) != "3" {
+		t.Errorf("item = %#v", first)
+	}
+	if _, ok := first["note"]; ok {
+		t.Error("omitted field present")
+	}
+}
+
+// idText and decimalText receive the text extensions on decode.
+type (
+	idText      string
+	decimalText string
+)
+
+type textReader[T ~string] struct{}
+
+func (textReader[T]) WriteExt(any) []byte          { panic("decode only") }
+func (textReader[T]) ReadExt(dst any, data []byte) { *dst.(*T) = T(data) }
+
+// serveNegotiated runs writeNegotiated for v and returns the MessagePack
+// response decoded into generic values (strings as strings).
+func serveNegotiated(t *testing.T, v any) (map[string]any, *httptest.ResponseRecorder) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/", func(c *gin.Context) { writeNegotiated(c, http.StatusOK, v) })
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", MIMEMsgPack)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), MIMEMsgPack) {
+		t.Fatalf("status %d, Content-Type %q", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	h := &codec.MsgpackHandle{}
+	h.RawToString = true
+	h.MapType = reflect.TypeOf(map[string]any(nil))
+	if err := h.SetBytesExt(reflect.TypeOf(idText("")), msgpackExtID, textReader[idText]{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.SetBytesExt(reflect.TypeOf(decimalText("")), msgpackExtDecimal, textReader[decimalText]{}); err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := codec.NewDecoderBytes(w.Body.Bytes(), h).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return got, w
+}
+
+func TestWriteNegotiatedRoundTrip(t *testing.T) {
+	type base struct {
+		ID      id.ID     `json:"id"`
+		Created time.Time `json:"createdAt"`
+	}
+	type line struct {
+		base
+		Quantity types.Quantity   `json:"quantity"`
+		Amount   types.MinorUnits `json:"amount"`
+		Rate     decimal.Decimal  `json:"rate"`
+		Parent   *id.ID           `json:"parentId,omitempty"`
+		Posted   *time.Time       `json:"postedAt"`
+		Note     string           `json:"note,omitempty"`
+		Secret   string           `json:"-"`
+		Untagged bool
+	}
+	lineID, parentID := id.New(), id.New()
+	created := time.Date(2026, 10, 16, 9, 30, 15, 123000000, time.FixedZone("MSK", 3*60*60))
+	v := struct {
+		Lines []line `json:"lines"`
+	}{Lines: []line{{
+		base:     base{ID: lineID, Created: created},
+		Quantity: types.NewQuantityFromFloat64(2.125),
+		Amount:   -150075,
+		Rate:     decimal.RequireFromString("95.1234"),
+		Parent:   &parentID,
+		Secret:   "hidden",
+		Untagged: true,
+	}}}
+
+	got, _ := serveNegotiated(t, v)
+
+	// The JSON form of the same value has the same keys.
+	var asJSON map[string]any
+	body, _ := json.Marshal(v)
+	if err := json.Unmarshal(body, &asJSON); err != nil {
+		t.Fatal(err)
+	}
+	gotLine := got["lines"].([]any)[0].(map[string]any)
+	jsonLine := asJSON["lines"].([]any)[0].(map[string]any)
+	if !reflect.DeepEqual(sortedKeys(gotLine), sortedKeys(jsonLine)) {
+		t.Errorf("keys = %v, JSON keys = %v", sortedKeys(gotLine), sortedKeys(jsonLine))
+	}
+
+	want := map[string]any{
+		"id":       idText(lineID.String()),
+		"quantity": 2.125,
+		"amount":   int64(-150075),
+		"rate":     decimalText("95.1234"),
+		"parentId": idText(parentID.String()),
+		"postedAt": nil,
+		"Untagged": true,
+	}
+	for k, w := range want {
+		if g := gotLine[k]; !reflect.DeepEqual(g, w) {
+			t.Errorf("%s = %#v (%T), want %#v", k, g, g, w)
+		}
+	}
+	// Times are MessagePack timestamps of the same instant.
+	if got, ok := gotLine["createdAt"].(time.Time); !ok || !got.Equal(created) {
+		t.Errorf("createdAt = %#v, want %v", gotLine["createdAt"], created)
+	}
+}
+
+func TestWriteNegotiatedLargeValues(t *testing.T) {
+	type item struct {
+		N        int              `json:"n"`
+		Amount   types.MinorUnits `json:"amount"`
+		Quantity types.Quantity   `json:"quantity"`
+	}
+	const n = 20000
+	items := make([]item, n)
+	for i := range items {
+		items[i] = item{N: i, Amount: types.MinorUnits(math.MaxInt64 - int64(i)), Quantity: types.Quantity(i)}
+	}
+	text := strings.Repeat("Ж", 100_000) // > 64 KiB: str32
+	v := gin.H{"items": items, "text": text, "min": int64(math.MinInt64)}
+
+	got, w := serveNegotiated(t, v)
+
+	if got["text"] != text {
+		t.Errorf("text length %d, want %d", len(fmt.Sprint(got["text"])), len(text))
+	}
+	if got["min"] != int64(math.MinInt64) {
+		t.Errorf("min = %v", got["min"])
+	}
+	gotItems := got["items"].([]any)
+	if len(gotItems) != n {
+		t.Fatalf("%d items, want %d", len(gotItems), n)
+	}
+	last := gotItems[n-1].(map[string]any)
+	if fmt.Sprint(last["n"]) != fmt.Sprint(n-1) ||
+		fmt.Sprint(last["amount"]) != fmt.Sprint(int64(math.MaxInt64-(n-1))) ||
+		last["quantity"] != types.Quantity(n-1).Float64() {
+		t.Errorf("last item = %#v", last)
+	}
+
+	body, _ := json.Marshal(v)
+	if w.Body.Len() >= len(body) {
+		t.Errorf("MessagePack %d bytes, JSON %d", w.Body.Len(), len(body))
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
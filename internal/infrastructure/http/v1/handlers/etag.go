@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeCachedJSON writes v as JSON with a strong ETag made of version and a
+// digest of the body, or 304 Not Modified if the request's If-None-Match
+// already holds it. The body digest keeps the tag exact for responses that
+// differ per user (field policies) under the same version.
+func writeCachedJSON(c *gin.Context, version string, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + version + "-" + hex.EncodeToString(sum[:8]) + `"`
+
+	c.Header("ETag", etag)
+	// Responses depend on the caller's permissions: caches may keep them
+	// only for the same user and must revalidate before every use.
+	c.Header("Cache-Control", "private, no-cache")
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// etagMatches reports whether an If-None-Match header value lists etag.
+// Weak comparison is used, as RFC 9110 requires for If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/domain/registers/exchange_rate"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+// _rateDateLayout is the format of exchange rate dates in requests.
+const _rateDateLayout = "2006-01-02"
+
+// ExchangeRateHandler handles HTTP requests for the exchange rates register.
+type ExchangeRateHandler struct {
+	*BaseHandler
+	service   *exchange_rate.Service
+	converter *exchange_rate.Converter
+}
+
+// NewExchangeRateHandler creates a new exchange rates handler.
+func NewExchangeRateHandler(base *BaseHandler, service *exchange_rate.Service, converter *exchange_rate.Converter) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		BaseHandler: base,
+		service:     service,
+		converter:   converter,
+	}
+}
+
+// List handles GET /registers/exchange-rates
+// Optional filters: currencyId, rateSourceId, from, to (YYYY-MM-DD), limit.
+func (h *ExchangeRateHandler) List(c *gin.Context) {
+	filter := exchange_rate.ListFilter{Limit: h.ParseIntQuery(c, "limit", 100)}
+
+	var ok bool
+	if filter.CurrencyID, ok = h.ParseIDQuery(c, "currencyId"); !ok {
+		return
+	}
+	if filter.RateSourceID, ok = h.ParseIDQuery(c, "rateSourceId"); !ok {
+		return
+	}
+	if filter.From, ok = h.optionalDate(c, "from"); !ok {
+		return
+	}
+	if filter.To, ok = h.optionalDate(c, "to"); !ok {
+		return
+	}
+
+	rates, err := h.service.ListRates(c.Request.Context(), filter)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	items := make([]dto.ExchangeRateResponse, len(rates))
+	for i, r := range rates {
+		items[i] = dto.FromExchangeRate(r)
+	}
+	c.JSON(http.StatusOK, dto.ExchangeRateListResponse{Items: items})
+}
+
+// Upsert handles PUT /registers/exchange-rates
+// Creates or replaces the rate for (currency, date, source).
+func (h *ExchangeRateHandler) Upsert(c *gin.Context) {
+	var req dto.UpsertExchangeRateRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	currencyID, err := id.Parse(req.CurrencyID)
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid currencyId format"))
+		return
+	}
+	rateSourceID, err := id.Parse(req.RateSourceID)
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid rateSourceId format"))
+		return
+	}
+	date, err := time.Parse(_rateDateLayout, req.Date)
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid date format, expected YYYY-MM-DD"))
+		return
+	}
+	if req.Multiplier == 0 {
+		req.Multiplier = 1
+	}
+
+	rate := exchange_rate.ExchangeRate{
+		CurrencyID:   currencyID,
+		Date:         date,
+		Rate:         req.Rate,
+		Multiplier:   req.Multiplier,
+		RateSourceID: rateSourceID,
+	}
+	if err := h.service.UpsertRate(c.Request.Context(), &rate); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.FromExchangeRate(rate))
+}
+
+// Delete handles DELETE /registers/exchange-rates?currencyId=&rateSourceId=&date=
+func (h *ExchangeRateHandler) Delete(c *gin.Context) {
+	currencyID, err := id.Parse(c.Query("currencyId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("currencyId is required"))
+		return
+	}
+	rateSourceID, err := id.Parse(c.Query("rateSourceId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("rateSourceId is required"))
+		return
+	}
+	date, err := time.Parse(_rateDateLayout, c.Query("date"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("date is required, expected YYYY-MM-DD"))
+		return
+	}
+
+	if err := h.service.DeleteRate(c.Request.Context(), currencyID, rateSourceID, date); err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.NoContent(c)
+}
+
+// Convert handles GET /registers/exchange-rates/convert?currencyId=&amount=&date=
+// Converts an amount in minor units to the base currency at the rate
+// effective on date (default: today).
+func (h *ExchangeRateHandler) Convert(c *gin.Context) {
+	currencyID, err := id.Parse(c.Query("currencyId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("currencyId is required"))
+		return
+	}
+	amount, err := strconv.ParseInt(c.Query("amount"), 10, 64)
+	if err != nil {
+		h.Error(c, apperror.NewValidation("amount is required (minor units)"))
+		return
+	}
+	date := time.Now().UTC()
+	if s := c.Query("date"); s != "" {
+		if date, err = time.Parse(_rateDateLayout, s); err != nil {
+			h.Error(c, apperror.NewValidation("invalid date format, expected YYYY-MM-DD"))
+			return
+		}
+	}
+
+	base, err := h.converter.ToBase(c.Request.Context(), types.NewMoney(types.MinorUnits(amount), currencyID), date)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ConvertAmountResponse{
+		Amount:         types.MinorUnits(amount),
+		CurrencyID:     currencyID.String(),
+		BaseAmount:     base.Amount,
+		BaseCurrencyID: base.CurrencyID.String(),
+		Date:           date.Format(_rateDateLayout),
+	})
+}
+
+// optionalDate parses an optional YYYY-MM-DD query parameter.
+func (h *ExchangeRateHandler) optionalDate(c *gin.Context, key string) (*time.Time, bool) {
+	s := c.Query(key)
+	if s == "" {
+		return nil, true
+	}
+	parsed, err := time.Parse(_rateDateLayout, s)
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid "+key+" format, expected YYYY-MM-DD"))
+		return nil, false
+	}
+	return &parsed, true
+}
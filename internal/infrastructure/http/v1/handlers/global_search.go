@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"metapus/internal/domain/search"
+	"metapus/internal/infrastructure/storage/postgres"
 )
 
 // GlobalSearchHandler handles GET /api/v1/search.
@@ -40,3 +41,15 @@ func (h *GlobalSearchHandler) Search(c *gin.Context) {
 
 	c.JSON(200, result)
 }
+
+// Reindex queues a full rebuild of the tenant's external search index.
+// POST /api/v1/search/reindex
+func (h *GlobalSearchHandler) Reindex(c *gin.Context) {
+	if err := postgres.EnqueueSearchRebuild(c.Request.Context()); err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(202, gin.H{"status": "queued"})
+}
@@ -11,6 +11,7 @@ import (
 
 	"metapus/internal/core/apperror"
 	"metapus/internal/core/entity"
+	"metapus/internal/core/format"
 	"metapus/internal/core/id"
 	"metapus/internal/core/tenant"
 	"metapus/internal/domain"
@@ -96,13 +97,14 @@ func NewGoodsIssueHandler(
 
 	if printRegistry != nil && printRenderer != nil {
 		h.printHandler = NewDocumentPrintHandler(base, DocumentPrintHandlerConfig[*goods_issue.GoodsIssue]{
-			Service:     service,
-			EntityName:  "goods_issue",
-			DocType:     "goods_issue",
-			Registry:    printRegistry,
-			Renderer:    printRenderer,
-			ResolveRefs: resolveGoodsIssueRefs,
-			BuildPrintData: func(entity *goods_issue.GoodsIssue, refs any, showPrices bool) *printing.PrintData {
+			Service:      service,
+			EntityName:   "goods_issue",
+			DocType:      "goods_issue",
+			Registry:     printRegistry,
+			Renderer:     printRenderer,
+			ResolveRefs:  resolveGoodsIssueRefs,
+			SettingsRepo: settingsRepo,
+			BuildPrintData: func(entity *goods_issue.GoodsIssue, refs any, showPrices bool, f *format.Formatter) *printing.PrintData {
 				var resp *dto.GoodsIssueResponse
 				if bag, ok := refs.(*dto.DocRefsBag); ok {
 					resp = dto.FromGoodsIssue(entity, bag.Refs, bag.CurrencyRefs)
@@ -110,18 +112,21 @@ func NewGoodsIssueHandler(
 					resp = dto.FromGoodsIssue(entity, nil)
 				}
 				dp := 2
-				symbol := ""
+				symbol, code := "", ""
 				if resp.Currency != nil {
 					dp = resp.Currency.DecimalPlaces
 					symbol = resp.Currency.Symbol
+					code = resp.Currency.ISOCode
 				}
 				return &printing.PrintData{
 					FormLabel:      "Реализация товаров",
 					ShowPrices:     showPrices,
 					DecimalPlaces:  dp,
 					CurrencySymbol: symbol,
+					CurrencyCode:   code,
+					Format:         f,
 					Doc:            resp,
-					Table:          buildGoodsIssueTable(resp, dp, symbol, showPrices),
+					Table:          buildGoodsIssueTable(resp, dp, symbol, code, showPrices, f),
 				}
 			},
 		})
@@ -227,9 +232,14 @@ func (h *GoodsIssueHandler) Copy(c *gin.Context) {
 	copy.AmountIncludesVAT = source.AmountIncludesVAT
 	copy.Description = source.Description
 
+	// Lines keep their prices and VAT; links to order lines are not copied
+	// (the copy has no basis).
 	for _, line := range source.Lines {
-		copy.AddLine(line.NomenclatureID, line.UnitID, line.Coefficient, line.Quantity, line.UnitPrice, line.VATRateID, 0, line.DiscountPercent)
+		line.LineID = id.New()
+		line.OrderLineID = nil
+		copy.Lines = append(copy.Lines, line)
 	}
+	copy.SetTotals(copy.LineTotals())
 
 	if err := h.service.Create(ctx, copy); err != nil {
 		h.Error(c, err)
@@ -248,10 +258,10 @@ func (h *GoodsIssueHandler) Copy(c *gin.Context) {
 }
 
 // buildGoodsIssueTable builds a PrintTable from a GoodsIssueResponse for XLSX/DOCX renderers.
-func buildGoodsIssueTable(resp *dto.GoodsIssueResponse, dp int, currSymbol string, showPrices bool) *printing.PrintTable {
+func buildGoodsIssueTable(resp *dto.GoodsIssueResponse, dp int, currSymbol, currCode string, showPrices bool, f *format.Formatter) *printing.PrintTable {
 	t := &printing.PrintTable{
 		Title:    "Реализация товаров",
-		Subtitle: fmt.Sprintf("№ %s от %s", resp.Number, printing.FormatDate(resp.Date)),
+		Subtitle: fmt.Sprintf("№ %s от %s", resp.Number, f.Date(resp.Date)),
 	}
 
 	// Header fields
@@ -307,17 +317,17 @@ func buildGoodsIssueTable(resp *dto.GoodsIssueResponse, dp int, currSymbol strin
 				strconv.Itoa(line.LineNo),
 				prodName,
 				unitName,
-				printing.FormatQty(line.Quantity),
-				printing.FormatMoney(line.UnitPrice, dp),
-				printing.FormatMoney(line.Amount, dp),
-				printing.FormatMoney(line.VATAmount, dp),
+				f.Quantity(line.Quantity),
+				f.Money(line.UnitPrice, dp),
+				f.Money(line.Amount, dp),
+				f.Money(line.VATAmount, dp),
 			}
 		} else {
 			row.Values = []string{
 				strconv.Itoa(line.LineNo),
 				prodName,
 				unitName,
-				printing.FormatQty(line.Quantity),
+				f.Quantity(line.Quantity),
 			}
 		}
 		t.Rows = append(t.Rows, row)
@@ -326,9 +336,10 @@ func buildGoodsIssueTable(resp *dto.GoodsIssueResponse, dp int, currSymbol strin
 	// Totals
 	if showPrices {
 		t.Totals = []printing.PrintTotalLine{
-			{Label: "Итого", Value: printing.FormatMoney(resp.TotalAmount, dp) + " " + currSymbol, Grand: true},
-			{Label: "В том числе НДС", Value: printing.FormatMoney(resp.TotalVAT, dp) + " " + currSymbol},
+			{Label: "Итого", Value: f.MoneyWithSymbol(resp.TotalAmount, dp, currSymbol), Grand: true},
+			{Label: "В том числе НДС", Value: f.MoneyWithSymbol(resp.TotalVAT, dp, currSymbol)},
 		}
+		t.AmountInWords = "Всего на сумму: " + f.AmountInWords(resp.TotalAmount, dp, currCode)
 	}
 
 	// Signatures (horizontal layout matching HTML print form)
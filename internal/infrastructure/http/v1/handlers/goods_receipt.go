@@ -11,6 +11,7 @@ import (
 
 	"metapus/internal/core/apperror"
 	"metapus/internal/core/entity"
+	"metapus/internal/core/format"
 	"metapus/internal/core/id"
 	"metapus/internal/core/tenant"
 	"metapus/internal/domain"
@@ -96,13 +97,14 @@ func NewGoodsReceiptHandler(
 
 	if printRegistry != nil && printRenderer != nil {
 		h.printHandler = NewDocumentPrintHandler(base, DocumentPrintHandlerConfig[*goods_receipt.GoodsReceipt]{
-			Service:     service,
-			EntityName:  "goods_receipt",
-			DocType:     "goods_receipt",
-			Registry:    printRegistry,
-			Renderer:    printRenderer,
-			ResolveRefs: resolveGoodsReceiptRefs,
-			BuildPrintData: func(entity *goods_receipt.GoodsReceipt, refs any, showPrices bool) *printing.PrintData {
+			Service:      service,
+			EntityName:   "goods_receipt",
+			DocType:      "goods_receipt",
+			Registry:     printRegistry,
+			Renderer:     printRenderer,
+			ResolveRefs:  resolveGoodsReceiptRefs,
+			SettingsRepo: settingsRepo,
+			BuildPrintData: func(entity *goods_receipt.GoodsReceipt, refs any, showPrices bool, f *format.Formatter) *printing.PrintData {
 				var resp *dto.GoodsReceiptResponse
 				if bag, ok := refs.(*dto.DocRefsBag); ok {
 					resp = dto.FromGoodsReceipt(entity, bag.Refs, bag.CurrencyRefs)
@@ -110,18 +112,21 @@ func NewGoodsReceiptHandler(
 					resp = dto.FromGoodsReceipt(entity, nil)
 				}
 				dp := 2
-				symbol := ""
+				symbol, code := "", ""
 				if resp.Currency != nil {
 					dp = resp.Currency.DecimalPlaces
 					symbol = resp.Currency.Symbol
+					code = resp.Currency.ISOCode
 				}
 				return &printing.PrintData{
 					FormLabel:      "Поступление товаров",
 					ShowPrices:     showPrices,
 					DecimalPlaces:  dp,
 					CurrencySymbol: symbol,
+					CurrencyCode:   code,
+					Format:         f,
 					Doc:            resp,
-					Table:          buildGoodsReceiptTable(resp, dp, symbol, showPrices),
+					Table:          buildGoodsReceiptTable(resp, dp, symbol, code, showPrices, f),
 				}
 			},
 		})
@@ -228,9 +233,14 @@ func (h *GoodsReceiptHandler) Copy(c *gin.Context) {
 	copy.AmountIncludesVAT = source.AmountIncludesVAT
 	copy.Description = source.Description
 
+	// Lines keep their prices and VAT; links to order lines are not copied
+	// (the copy has no basis).
 	for _, line := range source.Lines {
-		copy.AddLine(line.NomenclatureID, line.UnitID, line.Coefficient, line.Quantity, line.UnitPrice, line.VATRateID, 0, line.DiscountPercent)
+		line.LineID = id.New()
+		line.OrderLineID = nil
+		copy.Lines = append(copy.Lines, line)
 	}
+	copy.SetTotals(copy.LineTotals())
 
 	if err := h.service.Create(ctx, copy); err != nil {
 		h.Error(c, err)
@@ -249,10 +259,10 @@ func (h *GoodsReceiptHandler) Copy(c *gin.Context) {
 }
 
 // buildGoodsReceiptTable builds a PrintTable from a GoodsReceiptResponse for XLSX/DOCX renderers.
-func buildGoodsReceiptTable(resp *dto.GoodsReceiptResponse, dp int, currSymbol string, showPrices bool) *printing.PrintTable {
+func buildGoodsReceiptTable(resp *dto.GoodsReceiptResponse, dp int, currSymbol, currCode string, showPrices bool, f *format.Formatter) *printing.PrintTable {
 	t := &printing.PrintTable{
 		Title:    "Поступление товаров",
-		Subtitle: fmt.Sprintf("№ %s от %s", resp.Number, printing.FormatDate(resp.Date)),
+		Subtitle: fmt.Sprintf("№ %s от %s", resp.Number, f.Date(resp.Date)),
 	}
 
 	// Header fields
@@ -308,17 +318,17 @@ func buildGoodsReceiptTable(resp *dto.GoodsReceiptResponse, dp int, currSymbol s
 				strconv.Itoa(line.LineNo),
 				prodName,
 				unitName,
-				printing.FormatQty(line.Quantity),
-				printing.FormatMoney(line.UnitPrice, dp),
-				printing.FormatMoney(line.Amount, dp),
-				printing.FormatMoney(line.VATAmount, dp),
+				f.Quantity(line.Quantity),
+				f.Money(line.UnitPrice, dp),
+				f.Money(line.Amount, dp),
+				f.Money(line.VATAmount, dp),
 			}
 		} else {
 			row.Values = []string{
 				strconv.Itoa(line.LineNo),
 				prodName,
 				unitName,
-				printing.FormatQty(line.Quantity),
+				f.Quantity(line.Quantity),
 			}
 		}
 		t.Rows = append(t.Rows, row)
@@ -327,9 +337,10 @@ func buildGoodsReceiptTable(resp *dto.GoodsReceiptResponse, dp int, currSymbol s
 	// Totals
 	if showPrices {
 		t.Totals = []printing.PrintTotalLine{
-			{Label: "Итого", Value: printing.FormatMoney(resp.TotalAmount, dp) + " " + currSymbol, Grand: true},
-			{Label: "В том числе НДС", Value: printing.FormatMoney(resp.TotalVAT, dp) + " " + currSymbol},
+			{Label: "Итого", Value: f.MoneyWithSymbol(resp.TotalAmount, dp, currSymbol), Grand: true},
+			{Label: "В том числе НДС", Value: f.MoneyWithSymbol(resp.TotalVAT, dp, currSymbol)},
 		}
+		t.AmountInWords = "Всего на сумму: " + f.AmountInWords(resp.TotalAmount, dp, currCode)
 	}
 
 	// Signatures (horizontal layout matching HTML print form)
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain"
+	"metapus/internal/domain/documents/goods_transfer"
+	"metapus/internal/domain/settings"
+	"metapus/internal/infrastructure/http/v1/dto"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+// GoodsTransferHandler handles HTTP requests for GoodsTransfer documents.
+// Standard CRUD/posting methods are handled by BaseDocumentHandler via ResolveRefs callback.
+// Only entity-specific methods (Copy, UpdateAndRepost) are overridden.
+type GoodsTransferHandler struct {
+	*BaseDocumentHandler[*goods_transfer.GoodsTransfer, dto.CreateGoodsTransferRequest, dto.UpdateGoodsTransferRequest]
+	service            domain.DocumentService[*goods_transfer.GoodsTransfer]
+	relatedDocsHandler *RelatedDocumentsHandler
+}
+
+// resolveGoodsTransferRefs batch-resolves all reference IDs for a list of GoodsTransfer documents.
+func resolveGoodsTransferRefs(ctx context.Context, docs ...*goods_transfer.GoodsTransfer) (any, error) {
+	resolver := postgres.NewReferenceResolver()
+	for _, doc := range docs {
+		dto.CollectGoodsTransferRefs(resolver, doc)
+	}
+	pool := tenant.MustGetPool(ctx)
+	refs, err := resolver.Resolve(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+// NewGoodsTransferHandler creates a new goods transfer handler.
+// Accepts domain.DocumentService interface — can be a concrete service or a decorated wrapper.
+func NewGoodsTransferHandler(
+	base *BaseHandler,
+	service domain.DocumentService[*goods_transfer.GoodsTransfer],
+	relatedDocFinder domain.RelatedDocFinder,
+	movementProviders []entity.MovementProvider,
+	movementRefResolver domain.RefResolver,
+	settingsRepo settings.Repository,
+) *GoodsTransferHandler {
+	cfg := BaseDocumentHandlerConfig[*goods_transfer.GoodsTransfer, dto.CreateGoodsTransferRequest, dto.UpdateGoodsTransferRequest]{
+		Service:    service,
+		EntityName: "goods_transfer",
+		MapCreateDTO: func(req dto.CreateGoodsTransferRequest) *goods_transfer.GoodsTransfer {
+			return req.ToEntity()
+		},
+		MapUpdateDTO: func(req dto.UpdateGoodsTransferRequest, existing *goods_transfer.GoodsTransfer) *goods_transfer.GoodsTransfer {
+			req.ApplyTo(existing)
+			return existing
+		},
+		MapToDTO: func(entity *goods_transfer.GoodsTransfer) any {
+			return dto.FromGoodsTransfer(entity, nil)
+		},
+		IsPostImmediately: func(req dto.CreateGoodsTransferRequest) bool {
+			return req.PostImmediately
+		},
+		ResolveRefs: resolveGoodsTransferRefs,
+		MapToDTOWithRefs: func(entity *goods_transfer.GoodsTransfer, refs any) any {
+			resolvedRefs, _ := refs.(postgres.ResolvedRefs)
+			return dto.FromGoodsTransfer(entity, resolvedRefs)
+		},
+		MovementProviders:   movementProviders,
+		MovementRefResolver: movementRefResolver,
+		SettingsRepo:        settingsRepo,
+	}
+
+	h := &GoodsTransferHandler{
+		BaseDocumentHandler: NewBaseDocumentHandler(base, cfg),
+		service:             service,
+	}
+
+	// Related documents (optional)
+	if relatedDocFinder != nil {
+		h.relatedDocsHandler = NewRelatedDocumentsHandler(relatedDocFinder, goods_transfer.DocumentType)
+	}
+
+	return h
+}
+
+// GetRelatedDocuments handles GET /document/goods-transfer/:id/related-documents.
+// Implements DocumentRelatedDocsHandler interface (auto-registered by RegisterDocumentRoutes).
+func (h *GoodsTransferHandler) GetRelatedDocuments(c *gin.Context) {
+	if h.relatedDocsHandler == nil {
+		c.JSON(http.StatusOK, gin.H{"groups": []any{}})
+		return
+	}
+	h.relatedDocsHandler.GetRelatedDocuments(c)
+}
+
+// UpdateAndRepost handles PUT /document/goods-transfer/:id/repost — atomic update + re-post.
+// Used to register the arrival of goods in transit: set receivedAt and repost.
+func (h *GoodsTransferHandler) UpdateAndRepost(c *gin.Context) {
+	ctx := c.Request.Context()
+	docID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	var req dto.UpdateGoodsTransferRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	doc, err := h.service.GetByID(ctx, docID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	req.ApplyTo(doc)
+
+	if err := h.service.UpdateAndRepost(ctx, doc); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	refs, _ := resolveGoodsTransferRefs(ctx, doc)
+	resolvedRefs, _ := refs.(postgres.ResolvedRefs)
+	response := dto.FromGoodsTransfer(doc, resolvedRefs)
+	h.CompleteIdempotency(c, http.StatusOK, "application/json", response)
+	c.JSON(http.StatusOK, response)
+}
+
+// Copy handles POST /document/goods-transfer/:id/copy — with resolved references.
+// The copy is not received yet, even if the source transfer was.
+func (h *GoodsTransferHandler) Copy(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	docID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	source, err := h.service.GetByID(ctx, docID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	copy := goods_transfer.NewGoodsTransfer(source.OrganizationID, source.SourceWarehouseID, source.DestinationWarehouseID)
+	copy.Date = time.Now()
+	copy.TransitWarehouseID = source.TransitWarehouseID
+	copy.Description = source.Description
+
+	for _, line := range source.Lines {
+		copy.AddLine(line.NomenclatureID, line.UnitID, line.Coefficient, line.Quantity)
+	}
+
+	if err := h.service.Create(ctx, copy); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	refs, _ := resolveGoodsTransferRefs(ctx, copy)
+	resolvedRefs, _ := refs.(postgres.ResolvedRefs)
+	response := dto.FromGoodsTransfer(copy, resolvedRefs)
+	h.CompleteIdempotency(c, http.StatusCreated, "application/json", response)
+	c.JSON(http.StatusCreated, response)
+}
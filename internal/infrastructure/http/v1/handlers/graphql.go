@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/security"
+	"metapus/internal/domain/graphql"
+	"metapus/internal/infrastructure/http/v1/middleware"
+)
+
+// GraphQLHandler serves the read-only GraphQL API (/graphql).
+type GraphQLHandler struct {
+	service *graphql.Service
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler.
+func NewGraphQLHandler(service *graphql.Service) *GraphQLHandler {
+	return &GraphQLHandler{service: service}
+}
+
+// Query godoc
+//
+//	@Summary     Run a GraphQL query
+//	@Description Executes a query against the schema generated from entity metadata. Each entity read requires the same "<type>:<key>:read" permission as its REST routes; fields the caller may not read are null with an error entry.
+//	@Tags        graphql
+//	@Accept      json
+//	@Produce     json
+//	@Param       body body     graphql.Request true "GraphQL request"
+//	@Success     200  {object} graphql.Response
+//	@Router      /graphql [post]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var req graphql.Request
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if v := c.Query("variables"); v != "" {
+			if err := decodeJSON([]byte(v), &req.Variables); err != nil {
+				_ = c.Error(apperror.NewValidation("invalid variables: " + err.Error()))
+				c.Abort()
+				return
+			}
+		}
+	} else {
+		body, err := c.GetRawData()
+		if err == nil {
+			err = decodeJSON(body, &req)
+		}
+		if err != nil {
+			_ = c.Error(apperror.NewValidation("invalid request: " + err.Error()))
+			c.Abort()
+			return
+		}
+	}
+	if req.Query == "" {
+		_ = c.Error(apperror.NewValidation("query is required").WithDetail("field", "query"))
+		c.Abort()
+		return
+	}
+
+	// Grants accumulate on the request context as fields are authorized;
+	// each field's context takes the request's DataScope so far.
+	authorize := func(ctx context.Context, permission string) (context.Context, error) {
+		granted, err := middleware.CheckPermission(c, permission)
+		if err != nil {
+			return ctx, err
+		}
+		c.Request = c.Request.WithContext(granted)
+		return security.WithDataScope(ctx, security.GetDataScope(granted)), nil
+	}
+	c.JSON(http.StatusOK, h.service.Execute(c.Request.Context(), req, authorize))
+}
+
+// Schema godoc
+//
+//	@Summary     GraphQL schema
+//	@Description Returns the generated schema in SDL.
+//	@Tags        graphql
+//	@Produce     plain
+//	@Success     200 {string} string
+//	@Router      /graphql/schema [get]
+func (h *GraphQLHandler) Schema(c *gin.Context) {
+	c.String(http.StatusOK, h.service.Schema().SDL())
+}
+
+// decodeJSON decodes keeping numbers as json.Number, so 64-bit ids and
+// amounts in variables are not rounded through float64.
+func decodeJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
@@ -2,6 +2,7 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -124,6 +125,7 @@ func (h *MultiTenantHealthHandler) Ready(c *gin.Context) {
 func (h *MultiTenantHealthHandler) Info(c *gin.Context) {
 	metaStat := h.metaPool.Stat()
 	tenantStats := h.tenantManager.Stats()
+	readOnly := h.readOnlyTenants(c.Request.Context())
 
 	c.JSON(http.StatusOK, gin.H{
 		"app":     "metapus",
@@ -136,9 +138,11 @@ func (h *MultiTenantHealthHandler) Info(c *gin.Context) {
 		},
 		"tenants": map[string]any{
 			"active_pools":  tenantStats.TotalPools,
+			"max_pools":     tenantStats.MaxPools,
 			"total_conns":   tenantStats.TotalConns,
 			"idle_conns":    tenantStats.IdleConns,
 			"acquired_conn": tenantStats.AcquiredConns,
+			"read_only":     len(readOnly),
 		},
 	})
 }
@@ -147,23 +151,66 @@ func (h *MultiTenantHealthHandler) Info(c *gin.Context) {
 // GET /health/tenants
 func (h *MultiTenantHealthHandler) TenantsStats(c *gin.Context) {
 	stats := h.tenantManager.Stats()
+	readOnly := h.readOnlyTenants(c.Request.Context())
 
 	tenantDetails := make([]gin.H, 0, len(stats.Tenants))
 	for _, t := range stats.Tenants {
+		mode, isReadOnly := readOnly[t.TenantID]
 		tenantDetails = append(tenantDetails, gin.H{
-			"tenant_id":      t.TenantID,
-			"db_name":        t.DBName,
-			"total_conns":    t.TotalConns,
-			"idle_conns":     t.IdleConns,
-			"acquired_conns": t.AcquiredConns,
-			"active_refs":    t.ActiveRefs,
-			"last_used":      t.LastUsed,
+			"tenant_id":        t.TenantID,
+			"db_name":          t.DBName,
+			"total_conns":      t.TotalConns,
+			"idle_conns":       t.IdleConns,
+			"acquired_conns":   t.AcquiredConns,
+			"active_refs":      t.ActiveRefs,
+			"queue_length":     t.QueueLength,
+			"busy_rejections":  t.BusyRejections,
+			"last_used":        t.LastUsed,
+			"read_only":        isReadOnly,
+			"read_only_reason": mode.Reason,
+			"replica":          t.Replica,
+		})
+	}
+
+	readOnlyDetails := make([]gin.H, 0, len(readOnly))
+	for tenantID, mode := range readOnly {
+		readOnlyDetails = append(readOnlyDetails, gin.H{
+			"tenant_id": tenantID,
+			"reason":    mode.Reason,
+			"since":     mode.Since,
+			"by":        mode.By,
 		})
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"total_pools": stats.TotalPools,
-		"total_conns": stats.TotalConns,
-		"tenants":     tenantDetails,
+		"total_pools":  stats.TotalPools,
+		"max_pools":    stats.MaxPools,
+		"total_conns":  stats.TotalConns,
+		"queue_length": stats.QueueLength,
+		"evictions": gin.H{
+			"idle":     stats.IdleEvictions,
+			"pressure": stats.PressureEvictions,
+		},
+		"slot_waits":    stats.SlotWaits,
+		"slot_timeouts": stats.SlotTimeouts,
+		"tenants":       tenantDetails,
+		"read_only":     readOnlyDetails,
 	})
 }
+
+// readOnlyTenants returns the tenants in read-only mode by ID. The registry
+// is the cached one, so this does not query the meta-database per probe; a
+// failed lookup only hides the mode from the health output.
+func (h *MultiTenantHealthHandler) readOnlyTenants(ctx context.Context) map[string]tenant.ReadOnlyMode {
+	tenants, err := h.tenantManager.GetRegistry().ListAll(ctx)
+	if err != nil {
+		return nil
+	}
+	modes := make(map[string]tenant.ReadOnlyMode)
+	for _, t := range tenants {
+		if mode, ok := t.ReadOnly(); ok {
+			modes[t.ID] = mode
+		}
+	}
+	return modes
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/domain/intercompany"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+// IntercompanyHandler serves intercompany transfers
+// (/document/intercompany-transfer).
+type IntercompanyHandler struct {
+	*BaseHandler
+	svc *intercompany.Service
+}
+
+// NewIntercompanyHandler creates a new IntercompanyHandler.
+func NewIntercompanyHandler(base *BaseHandler, svc *intercompany.Service) *IntercompanyHandler {
+	return &IntercompanyHandler{BaseHandler: base, svc: svc}
+}
+
+// intercompanyTransferResponse is the created transfer with both documents.
+type intercompanyTransferResponse struct {
+	Reference string                    `json:"reference"`
+	Issue     *dto.GoodsIssueResponse   `json:"issue"`
+	Receipt   *dto.GoodsReceiptResponse `json:"receipt"`
+}
+
+// Create godoc
+//
+//	@Summary     Create an intercompany transfer
+//	@Description Creates a goods issue from the sending organization and a mirrored goods receipt into the receiving one in one transaction; both share the transfer reference.
+//	@Tags        documents
+//	@Accept      json
+//	@Produce     json
+//	@Param       body body     intercompany.Request true "Transfer"
+//	@Success     201  {object} intercompanyTransferResponse
+//	@Router      /document/intercompany-transfer [post]
+func (h *IntercompanyHandler) Create(c *gin.Context) {
+	var req intercompany.Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Error(c, apperror.NewValidation("invalid request: "+err.Error()))
+		return
+	}
+
+	transfer, err := h.svc.Create(c.Request.Context(), req)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, intercompanyTransferResponse{
+		Reference: transfer.Reference,
+		Issue:     dto.FromGoodsIssue(transfer.Issue, nil),
+		Receipt:   dto.FromGoodsReceipt(transfer.Receipt, nil),
+	})
+}
+
+// Check godoc
+//
+//	@Summary     Check an intercompany transfer
+//	@Description Compares the goods issue and the goods receipt of the transfer and lists their differences.
+//	@Tags        documents
+//	@Produce     json
+//	@Param       ref path     string true "Transfer reference"
+//	@Success     200 {object} intercompany.Check
+//	@Router      /document/intercompany-transfer/{ref}/check [get]
+func (h *IntercompanyHandler) Check(c *gin.Context) {
+	check, err := h.svc.Check(c.Request.Context(), c.Param("ref"))
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, check)
+}
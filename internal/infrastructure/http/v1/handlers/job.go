@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/jobs"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+// JobHandler serves the /system/jobs API: the background job queue and its
+// dead letters.
+type JobHandler struct {
+	repo jobs.Repository
+}
+
+// NewJobHandler creates a handler.
+func NewJobHandler(repo jobs.Repository) *JobHandler {
+	return &JobHandler{repo: repo}
+}
+
+// RegisterRoutes wires all job queue routes under the provided group.
+func (h *JobHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/jobs", h.List)
+	rg.GET("/jobs/:id", h.Get)
+	rg.POST("/jobs/:id/requeue", h.Requeue)
+}
+
+// List godoc
+//
+//	@Summary     List queued jobs
+//	@Description Returns background jobs, newest first; status=dead lists the dead letters
+//	@Tags        system
+//	@Produce     json
+//	@Param       type   query  string false "Filter by job type (e.g. reports.generate)"
+//	@Param       status query  string false "Filter by status (pending|running|done|dead)"
+//	@Param       limit  query  int    false "Page size (default 100, max 500)"
+//	@Success     200  {object} dto.JobListResponse
+//	@Router      /system/jobs [get]
+func (h *JobHandler) List(c *gin.Context) {
+	f := jobs.Filter{
+		Type:   c.Query("type"),
+		Status: jobs.Status(c.Query("status")),
+	}
+	if f.Status != "" && !f.Status.Valid() {
+		_ = c.Error(apperror.NewValidation("invalid status").WithDetail("field", "status"))
+		c.Abort()
+		return
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var l int
+		if _, err := parseInt(limitStr, &l); err == nil && l > 0 && l <= 500 {
+			f.Limit = l
+		}
+	}
+
+	list, err := h.repo.List(c.Request.Context(), f)
+	if err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	c.JSON(http.StatusOK, dto.JobListResponse{Items: dto.MapJobs(list)})
+}
+
+// Get godoc
+//
+//	@Summary     Get a queued job
+//	@Tags        system
+//	@Produce     json
+//	@Param       id   path  string true "Job ID"
+//	@Success     200  {object} dto.JobResponse
+//	@Router      /system/jobs/{id} [get]
+func (h *JobHandler) Get(c *gin.Context) {
+	jobID, ok := h.jobID(c)
+	if !ok {
+		return
+	}
+	j, err := h.repo.GetByID(c.Request.Context(), jobID)
+	if err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	c.JSON(http.StatusOK, dto.MapJob(j))
+}
+
+// Requeue godoc
+//
+//	@Summary     Requeue a dead job
+//	@Description Makes a dead job pending again with a fresh set of attempts
+//	@Tags        system
+//	@Produce     json
+//	@Param       id   path  string true "Job ID"
+//	@Success     200  {object} dto.JobResponse
+//	@Router      /system/jobs/{id}/requeue [post]
+func (h *JobHandler) Requeue(c *gin.Context) {
+	jobID, ok := h.jobID(c)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+	if err := h.repo.Requeue(ctx, jobID); err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	j, err := h.repo.GetByID(ctx, jobID)
+	if err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	c.JSON(http.StatusOK, dto.MapJob(j))
+}
+
+// jobID parses the :id path parameter or writes a 400.
+func (h *JobHandler) jobID(c *gin.Context) (uuid.UUID, bool) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.NewValidation("invalid UUID").WithDetail("field", "id"))
+		c.Abort()
+		return uuid.Nil, false
+	}
+	return jobID, true
+}
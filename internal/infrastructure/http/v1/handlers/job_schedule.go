@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/jobs"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+// JobScheduleHandler serves the /system/schedules API: CRON schedules that
+// enqueue background jobs.
+type JobScheduleHandler struct {
+	svc *jobs.ScheduleService
+}
+
+// NewJobScheduleHandler creates a handler.
+func NewJobScheduleHandler(svc *jobs.ScheduleService) *JobScheduleHandler {
+	return &JobScheduleHandler{svc: svc}
+}
+
+// RegisterRoutes wires all job schedule routes under the provided group.
+func (h *JobScheduleHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/schedules", h.List)
+	rg.POST("/schedules", h.Create)
+	rg.GET("/schedules/:id", h.Get)
+	rg.PUT("/schedules/:id", h.Update)
+	rg.DELETE("/schedules/:id", h.Delete)
+	rg.POST("/schedules/:id/run", h.Run)
+}
+
+// List godoc
+//
+//	@Summary     List job schedules
+//	@Tags        system
+//	@Produce     json
+//	@Success     200  {array} dto.JobScheduleResponse
+//	@Router      /system/schedules [get]
+func (h *JobScheduleHandler) List(c *gin.Context) {
+	list, err := h.svc.List(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	c.JSON(http.StatusOK, dto.MapJobScheduleListResponse(list))
+}
+
+// Create godoc
+//
+//	@Summary     Create a job schedule
+//	@Description Enqueues a job of the type with the params on a CRON schedule, on behalf of the current user
+//	@Tags        system
+//	@Accept      json
+//	@Produce     json
+//	@Param       body body dto.CreateJobScheduleRequest true "Schedule"
+//	@Success     201  {object} dto.JobScheduleResponse
+//	@Router      /system/schedules [post]
+func (h *JobScheduleHandler) Create(c *gin.Context) {
+	var req dto.CreateJobScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.NewValidation(err.Error()))
+		c.Abort()
+		return
+	}
+
+	sch := req.ToSchedule()
+	if err := h.svc.Create(c.Request.Context(), sch); err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	c.JSON(http.StatusCreated, dto.MapJobScheduleResponse(sch))
+}
+
+// Get godoc
+//
+//	@Summary     Get a job schedule
+//	@Tags        system
+//	@Produce     json
+//	@Param       id   path  string true "Schedule ID"
+//	@Success     200  {object} dto.JobScheduleResponse
+//	@Router      /system/schedules/{id} [get]
+func (h *JobScheduleHandler) Get(c *gin.Context) {
+	scheduleID, ok := h.scheduleID(c)
+	if !ok {
+		return
+	}
+	sch, err := h.svc.Get(c.Request.Context(), scheduleID)
+	if err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	c.JSON(http.StatusOK, dto.MapJobScheduleResponse(sch))
+}
+
+// Update godoc
+//
+//	@Summary     Update a job schedule
+//	@Description Changing the timing re-plans the next run from now
+//	@Tags        system
+//	@Accept      json
+//	@Produce     json
+//	@Param       id   path  string true "Schedule ID"
+//	@Param       body body dto.UpdateJobScheduleRequest true "Schedule"
+//	@Success     200  {object} dto.JobScheduleResponse
+//	@Router      /system/schedules/{id} [put]
+func (h *JobScheduleHandler) Update(c *gin.Context) {
+	scheduleID, ok := h.scheduleID(c)
+	if !ok {
+		return
+	}
+	var req dto.UpdateJobScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperror.NewValidation(err.Error()))
+		c.Abort()
+		return
+	}
+
+	sch := req.ToSchedule()
+	sch.ID = scheduleID
+	sch.Version = req.Version
+	if err := h.svc.Update(c.Request.Context(), sch); err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	c.JSON(http.StatusOK, dto.MapJobScheduleResponse(sch))
+}
+
+// Delete godoc
+//
+//	@Summary     Delete a job schedule
+//	@Description Jobs the schedule already enqueued stay in the queue
+//	@Tags        system
+//	@Param       id   path  string true "Schedule ID"
+//	@Success     204
+//	@Router      /system/schedules/{id} [delete]
+func (h *JobScheduleHandler) Delete(c *gin.Context) {
+	scheduleID, ok := h.scheduleID(c)
+	if !ok {
+		return
+	}
+	if err := h.svc.Delete(c.Request.Context(), scheduleID); err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Run godoc
+//
+//	@Summary     Run a job schedule now
+//	@Description Enqueues a job of the schedule at once; the next planned run is not affected
+//	@Tags        system
+//	@Produce     json
+//	@Param       id   path  string true "Schedule ID"
+//	@Success     202  {object} dto.RunJobScheduleResponse
+//	@Router      /system/schedules/{id}/run [post]
+func (h *JobScheduleHandler) Run(c *gin.Context) {
+	scheduleID, ok := h.scheduleID(c)
+	if !ok {
+		return
+	}
+	jobID, err := h.svc.RunNow(c.Request.Context(), scheduleID)
+	if err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	c.JSON(http.StatusAccepted, dto.RunJobScheduleResponse{JobID: jobID})
+}
+
+// scheduleID parses the :id path parameter or writes a 400.
+func (h *JobScheduleHandler) scheduleID(c *gin.Context) (uuid.UUID, bool) {
+	scheduleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.NewValidation("invalid UUID").WithDetail("field", "id"))
+		c.Abort()
+		return uuid.Nil, false
+	}
+	return scheduleID, true
+}
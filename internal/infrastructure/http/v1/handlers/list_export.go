@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math"
@@ -10,11 +11,14 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"metapus/internal/core/apperror"
+	"metapus/internal/core/format"
 	"metapus/internal/core/security"
 	"metapus/internal/domain"
 	domainFilter "metapus/internal/domain/filter"
 	"metapus/internal/domain/listexport"
+	"metapus/internal/domain/settings"
 	"metapus/internal/infrastructure/http/v1/dto"
+	"metapus/internal/infrastructure/storage/postgres"
 )
 
 // ExportMaxRows is the safety limit for list export.
@@ -177,7 +181,7 @@ func writeExportXLSX(c *gin.Context, title string, req dto.ExportListRequest, dt
 	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
 	c.Status(http.StatusOK)
 
-	if err := listexport.XLSX(c.Writer, title, columns, rows); err != nil {
+	if err := listexport.XLSX(c.Writer, title, columns, rows, tenantFormatter(c.Request.Context())); err != nil {
 		// Headers already sent — log but can't change status
 		_ = c.Error(err)
 	}
@@ -215,7 +219,18 @@ func ExportTablePart(c *gin.Context) {
 	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
 	c.Status(http.StatusOK)
 
-	if err := listexport.XLSX(c.Writer, xlsxTitle, columns, req.Rows); err != nil {
+	if err := listexport.XLSX(c.Writer, xlsxTitle, columns, req.Rows, tenantFormatter(c.Request.Context())); err != nil {
 		_ = c.Error(err)
 	}
 }
+
+// tenantFormatter returns the formatter for the print locale of the current
+// user, falling back to the tenant locale setting.
+// Falls back to the default locale if settings cannot be read.
+func tenantFormatter(ctx context.Context) *format.Formatter {
+	s, err := postgres.NewSettingsRepo().Get(ctx)
+	if err != nil {
+		return format.New(format.DefaultLocale)
+	}
+	return settings.PrintFormatter(ctx, s.General, settings.SubjectFromContext(ctx))
+}
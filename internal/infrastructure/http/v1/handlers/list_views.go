@@ -9,6 +9,7 @@ import (
 	"metapus/internal/core/apperror"
 	"metapus/internal/domain/listview"
 	"metapus/internal/infrastructure/http/v1/dto"
+	"metapus/internal/infrastructure/http/v1/middleware"
 )
 
 // ListViewHandler handles list view CRUD endpoints.
@@ -145,7 +146,8 @@ func (h *ListViewHandler) SetDefault(c *gin.Context) {
 
 // RegisterListViewRoutes registers list view routes on the given router group.
 func RegisterListViewRoutes(rg *gin.RouterGroup, handler *ListViewHandler) {
-	views := rg.Group("/me/list-views")
+	// The caller's own views: no permission beyond authentication.
+	views := rg.Group("/me/list-views", middleware.PermitAuthenticated())
 	{
 		views.GET("/:entityType", handler.GetList)
 		views.POST("", handler.Create)
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain"
+	"metapus/internal/domain/board"
+	"metapus/internal/domain/documents/manual_adjustment"
+	"metapus/internal/domain/settings"
+	"metapus/internal/infrastructure/http/v1/dto"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+// ManualAdjustmentApprover approves manual adjustments (implemented by manual_adjustment.Service).
+type ManualAdjustmentApprover interface {
+	Approve(ctx context.Context, docID id.ID) (*manual_adjustment.ManualAdjustment, error)
+}
+
+// ManualAdjustmentHandler handles HTTP requests for ManualAdjustment documents.
+// Standard CRUD/posting methods are handled by BaseDocumentHandler; Approve is entity-specific.
+type ManualAdjustmentHandler struct {
+	*BaseDocumentHandler[*manual_adjustment.ManualAdjustment, dto.CreateManualAdjustmentRequest, dto.UpdateManualAdjustmentRequest]
+	approver ManualAdjustmentApprover
+}
+
+// resolveManualAdjustmentRefs batch-resolves all reference IDs for a list of ManualAdjustment documents.
+// Returns an opaque DocRefsBag for use by MapToDTOWithRefs.
+func resolveManualAdjustmentRefs(ctx context.Context, docs ...*manual_adjustment.ManualAdjustment) (any, error) {
+	resolver := postgres.NewReferenceResolver()
+	for _, doc := range docs {
+		dto.CollectManualAdjustmentRefs(resolver, doc)
+	}
+
+	pool := tenant.MustGetPool(ctx)
+	refs, err := resolver.Resolve(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	currencyRefs, err := resolver.ResolveCurrencies(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.DocRefsBag{Refs: refs, CurrencyRefs: currencyRefs}, nil
+}
+
+// NewManualAdjustmentHandler creates a new manual adjustment handler.
+// service may be a decorated wrapper; approver must be the undecorated service.
+func NewManualAdjustmentHandler(
+	base *BaseHandler,
+	service domain.DocumentService[*manual_adjustment.ManualAdjustment],
+	approver ManualAdjustmentApprover,
+	movementProviders []entity.MovementProvider,
+	movementRefResolver domain.RefResolver,
+	settingsRepo settings.Repository,
+) *ManualAdjustmentHandler {
+	cfg := BaseDocumentHandlerConfig[*manual_adjustment.ManualAdjustment, dto.CreateManualAdjustmentRequest, dto.UpdateManualAdjustmentRequest]{
+		Service:    service,
+		EntityName: "manual_adjustment",
+		MapCreateDTO: func(req dto.CreateManualAdjustmentRequest) *manual_adjustment.ManualAdjustment {
+			return req.ToEntity()
+		},
+		MapUpdateDTO: func(req dto.UpdateManualAdjustmentRequest, existing *manual_adjustment.ManualAdjustment) *manual_adjustment.ManualAdjustment {
+			req.ApplyTo(existing)
+			return existing
+		},
+		MapToDTO: func(entity *manual_adjustment.ManualAdjustment) any {
+			return dto.FromManualAdjustment(entity, nil)
+		},
+		IsPostImmediately: func(req dto.CreateManualAdjustmentRequest) bool {
+			return req.PostImmediately
+		},
+		ResolveRefs: resolveManualAdjustmentRefs,
+		MapToDTOWithRefs: func(entity *manual_adjustment.ManualAdjustment, refs any) any {
+			bag := refs.(*dto.DocRefsBag)
+			return dto.FromManualAdjustment(entity, bag.Refs, bag.CurrencyRefs)
+		},
+		MovementProviders:   movementProviders,
+		MovementRefResolver: movementRefResolver,
+		SettingsRepo:        settingsRepo,
+	}
+
+	h := &ManualAdjustmentHandler{
+		BaseDocumentHandler: NewBaseDocumentHandler(base, cfg),
+		approver:            approver,
+	}
+	h.setBoardAction(board.ActionApprove, func(ctx context.Context, docID id.ID) error {
+		_, err := approver.Approve(ctx, docID)
+		return err
+	})
+	return h
+}
+
+// Approve handles POST /document/manual-adjustment/:id/approve.
+// Implements DocumentApproveHandler (auto-registered by RegisterDocumentRoutes).
+func (h *ManualAdjustmentHandler) Approve(c *gin.Context) {
+	ctx := c.Request.Context()
+	docID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	doc, err := h.approver.Approve(ctx, docID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	refs, _ := resolveManualAdjustmentRefs(ctx, doc)
+	var response any
+	if bag, ok := refs.(*dto.DocRefsBag); ok {
+		response = dto.FromManualAdjustment(doc, bag.Refs, bag.CurrencyRefs)
+	} else {
+		response = dto.FromManualAdjustment(doc, nil)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// Copy handles POST /document/manual-adjustment/:id/copy.
+// The copy is a new unapproved draft with the same reason and lines.
+func (h *ManualAdjustmentHandler) Copy(c *gin.Context) {
+	ctx := c.Request.Context()
+	docID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	source, err := h.service.GetByID(ctx, docID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	copy := manual_adjustment.NewManualAdjustment(source.OrganizationID, source.Reason)
+	copy.Date = time.Now()
+	copy.CurrencyID = source.CurrencyID
+	copy.Description = source.Description
+	for _, line := range source.Lines {
+		copy.AddLine(line)
+	}
+
+	h.createDocument(c, copy, false)
+}
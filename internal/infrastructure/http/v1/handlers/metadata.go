@@ -1,24 +1,207 @@
 package handlers
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/security"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/modules"
 	"metapus/internal/infrastructure/cache"
+	"metapus/internal/infrastructure/http/v1/middleware"
 	"metapus/internal/metadata"
 )
 
+// _maxVersionWait caps the long-poll of GET /meta/version below the
+// server's write timeout.
+const _maxVersionWait = 25 * time.Second
+
+// MetadataHandler serves entity metadata and feature flags. Responses carry
+// strong ETags derived from the metadata version (see Version).
 type MetadataHandler struct {
 	registry    *metadata.Registry
-	schemaCache *cache.SchemaCache
+	schemaCache *cache.SchemaCache // optional
+
+	// registryHash fingerprints the registry, so versions of different
+	// builds never collide after a restart resets the cache version.
+	registryHash string
+
+	routes []middleware.RouteAuthz // set by the router once all routes are registered
+
+	modules *modules.Resolver // optional: hides entities of disabled modules
 }
 
 func NewMetadataHandler(registry *metadata.Registry, schemaCache *cache.SchemaCache) *MetadataHandler {
 	return &MetadataHandler{
-		registry:    registry,
-		schemaCache: schemaCache,
+		registry:     registry,
+		schemaCache:  schemaCache,
+		registryHash: registryHash(registry),
+	}
+}
+
+func registryHash(registry *metadata.Registry) string {
+	data, _ := json.Marshal(registry.List())
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:4])
+}
+
+// version returns the opaque metadata version "<registry hash>.<cache version>".
+func (h *MetadataHandler) version() string {
+	return h.registryHash + "." + strconv.FormatUint(h.cacheVersion(), 10)
+}
+
+func (h *MetadataHandler) cacheVersion() uint64 {
+	if h.schemaCache == nil {
+		return 0
+	}
+	return h.schemaCache.Version()
+}
+
+// VersionResponse is the body of GET /api/v1/meta/version.
+type VersionResponse struct {
+	Version string `json:"version"`
+}
+
+// Version returns the metadata version, which changes on every custom field
+// or feature flag invalidation. With ?since=<version>&wait=<seconds> it
+// long-polls: the response is held until the version differs from since or
+// the wait (at most 25s) elapses, so clients refetch only what changed.
+// GET /api/v1/meta/version
+func (h *MetadataHandler) Version(c *gin.Context) {
+	since := c.Query("since")
+	var wait time.Duration
+	if raw := c.Query("wait"); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds < 0 {
+			_ = c.Error(apperror.NewValidation("wait must be a non-negative number of seconds").WithDetail("field", "wait"))
+			c.Abort()
+			return
+		}
+		wait = min(time.Duration(seconds)*time.Second, _maxVersionWait)
+	}
+
+	if since != "" && since == h.version() && wait > 0 {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), wait)
+		defer cancel()
+		if h.schemaCache != nil {
+			_, n, _ := strings.Cut(since, ".")
+			cur, _ := strconv.ParseUint(n, 10, 64)
+			h.schemaCache.WaitForChange(ctx, cur)
+		} else {
+			<-ctx.Done()
+		}
+	}
+
+	// Not cached by If-None-Match: a poller always gets the version back.
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, VersionResponse{Version: h.version()})
+}
+
+// SetModules hides entities of modules disabled for the tenant.
+func (h *MetadataHandler) SetModules(resolver *modules.Resolver) {
+	h.modules = resolver
+}
+
+// moduleEnabled reports whether def belongs to no module or to one enabled
+// for the tenant in ctx.
+func (h *MetadataHandler) moduleEnabled(ctx context.Context, def metadata.EntityDef) bool {
+	return def.Module == "" || h.modules == nil || h.modules.Enabled(ctx, def.Module)
+}
+
+// listEntities returns the registered entities of enabled modules.
+func (h *MetadataHandler) listEntities(ctx context.Context) []metadata.EntityDef {
+	entities := h.registry.List()
+	visible := entities[:0]
+	for _, e := range entities {
+		if h.moduleEnabled(ctx, e) {
+			visible = append(visible, e)
+		}
 	}
+	return visible
+}
+
+// getEntity returns the entity called name unless its module is disabled.
+func (h *MetadataHandler) getEntity(ctx context.Context, name string) (metadata.EntityDef, bool) {
+	def, ok := h.registry.Get(name)
+	if !ok || !h.moduleEnabled(ctx, def) {
+		return metadata.EntityDef{}, false
+	}
+	return def, true
+}
+
+// SetRoutes sets the route authorization table served by ListRoutes.
+func (h *MetadataHandler) SetRoutes(routes []middleware.RouteAuthz) {
+	h.routes = routes
+}
+
+// ListRoutes returns the authorization guards declared for every API route.
+// GET /api/v1/meta/routes
+func (h *MetadataHandler) ListRoutes(c *gin.Context) {
+	routes := h.routes
+	if routes == nil {
+		routes = []middleware.RouteAuthz{}
+	}
+	writeCachedJSON(c, h.version(), routes)
+}
+
+// FeatureFlagResponse is a feature flag as served to clients.
+type FeatureFlagResponse struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Enabled     bool           `json:"enabled"`
+	Variant     string         `json:"variant,omitempty"`
+	Config      map[string]any `json:"config,omitempty"`
+}
+
+// ListFeatureFlags returns all feature flags of the tenant, sorted by name.
+// The list is empty when the schema cache is not configured.
+// GET /api/v1/meta/feature-flags
+func (h *MetadataHandler) ListFeatureFlags(c *gin.Context) {
+	result := []FeatureFlagResponse{}
+	if h.schemaCache != nil {
+		for _, f := range h.schemaCache.FeatureFlags() {
+			result = append(result, FeatureFlagResponse{
+				Name:        f.FlagName,
+				Description: f.Description,
+				Enabled:     f.IsEnabled,
+				Variant:     f.Variant,
+				Config:      f.Config,
+			})
+		}
+	}
+	writeCachedJSON(c, h.version(), result)
+}
+
+// FeaturesResponse lists the plan-dependent features of the tenant.
+type FeaturesResponse struct {
+	Plan     string                `json:"plan,omitempty"`
+	Features []tenant.FeatureState `json:"features"`
+}
+
+// ListFeatures returns the features of the tenant: those included in its
+// plan merged with the overrides of the tenant settings. Without a tenant
+// (single-tenant mode) every feature is enabled.
+// GET /api/v1/meta/features
+func (h *MetadataHandler) ListFeatures(c *gin.Context) {
+	resp := FeaturesResponse{}
+	if t := tenant.GetTenant(c.Request.Context()); t != nil {
+		resp.Plan = string(t.Plan)
+		resp.Features = t.FeatureStates()
+	} else {
+		for _, f := range tenant.Features {
+			resp.Features = append(resp.Features, tenant.FeatureState{Feature: f, Enabled: true, Source: tenant.FeatureSourcePlan})
+		}
+	}
+	writeCachedJSON(c, h.version(), resp)
 }
 
 // ListEntities returns a list of all registered entities (summarized).
@@ -26,8 +209,12 @@ func NewMetadataHandler(registry *metadata.Registry, schemaCache *cache.SchemaCa
 func (h *MetadataHandler) ListEntities(c *gin.Context) {
 	// We might want to return a simplified list (names/types/labels) only,
 	// but for now returning full definitions is fine for MVP.
-	entities := h.registry.List()
-	c.JSON(http.StatusOK, entities)
+	ctx := c.Request.Context()
+	entities := h.listEntities(ctx)
+	for i := range entities {
+		applyFieldAccess(ctx, &entities[i])
+	}
+	writeCachedJSON(c, h.version(), entities)
 }
 
 // EntitySummary is a lightweight representation of an entity for frontend metadata store.
@@ -37,13 +224,15 @@ type EntitySummary struct {
 	Type         metadata.EntityType   `json:"type"`
 	Presentation metadata.Presentation `json:"presentation"`
 	RoutePrefix  string                `json:"routePrefix,omitempty"`
+	Module       string                `json:"module,omitempty"`
 }
 
 // ListEntitiesSummary returns a lightweight list of all registered entities.
-// Includes only key, name, type, presentation, and routePrefix — no field definitions.
+// Includes only key, name, type, presentation, routePrefix and module — no field definitions.
+// Entities of modules disabled for the tenant are left out, so navigation hides them.
 // GET /api/v1/meta/entities
 func (h *MetadataHandler) ListEntitiesSummary(c *gin.Context) {
-	entities := h.registry.List()
+	entities := h.listEntities(c.Request.Context())
 	result := make([]EntitySummary, 0, len(entities))
 	for _, e := range entities {
 		result = append(result, EntitySummary{
@@ -52,19 +241,21 @@ func (h *MetadataHandler) ListEntitiesSummary(c *gin.Context) {
 			Type:         e.Type,
 			Presentation: e.Presentation,
 			RoutePrefix:  e.RoutePrefix,
+			Module:       e.Module,
 		})
 	}
-	c.JSON(http.StatusOK, result)
+	writeCachedJSON(c, h.version(), result)
 }
 
 // GetEntity returns the full metadata for a specific entity.
 // GET /api/v1/meta/:name
 func (h *MetadataHandler) GetEntity(c *gin.Context) {
 	name := c.Param("name")
-	if def, ok := h.registry.Get(name); ok {
+	if def, ok := h.getEntity(c.Request.Context(), name); ok {
 		// Merge custom fields from cache before returning
 		def.MergeCustomFields(h.schemaCache)
-		c.JSON(http.StatusOK, def)
+		applyFieldAccess(c.Request.Context(), &def)
+		writeCachedJSON(c, h.version(), def)
 	} else {
 		c.Status(http.StatusNotFound)
 	}
@@ -75,14 +266,14 @@ func (h *MetadataHandler) GetEntity(c *gin.Context) {
 // GET /api/v1/meta/:name/mock
 func (h *MetadataHandler) GetEntityMock(c *gin.Context) {
 	name := c.Param("name")
-	def, ok := h.registry.Get(name)
+	def, ok := h.getEntity(c.Request.Context(), name)
 	if !ok {
 		c.Status(http.StatusNotFound)
 		return
 	}
 	// Merge custom fields so mock data includes them
 	def.MergeCustomFields(h.schemaCache)
-	c.JSON(http.StatusOK, def.GenerateMock())
+	writeCachedJSON(c, h.version(), def.GenerateMock())
 }
 
 // GetEntityFilters returns a flat list of FilterFieldMeta for a specific entity.
@@ -90,12 +281,22 @@ func (h *MetadataHandler) GetEntityMock(c *gin.Context) {
 // GET /api/v1/meta/:name/filters
 func (h *MetadataHandler) GetEntityFilters(c *gin.Context) {
 	name := c.Param("name")
-	def, ok := h.registry.Get(name)
+	def, ok := h.getEntity(c.Request.Context(), name)
 	if !ok {
 		c.Status(http.StatusNotFound)
 		return
 	}
 	// Merge custom fields so they appear in filters
 	def.MergeCustomFields(h.schemaCache)
-	c.JSON(http.StatusOK, def.ToFilterMeta(h.registry))
+	applyFieldAccess(c.Request.Context(), &def)
+	writeCachedJSON(c, h.version(), def.ToFilterMeta(h.registry))
+}
+
+// applyFieldAccess marks fields hidden/read-only for the current user's
+// field-level security policies (no policies for admins or users without a profile).
+func applyFieldAccess(ctx context.Context, def *metadata.EntityDef) {
+	def.ApplyFieldPolicies(
+		security.GetFieldPolicy(ctx, def.Key, "read"),
+		security.GetFieldPolicy(ctx, def.Key, "write"),
+	)
 }
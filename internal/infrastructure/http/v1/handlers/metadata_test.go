@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/domain/modules"
+	"metapus/internal/metadata"
+)
+
+func TestMetadataETagRevalidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reg := metadata.NewRegistry()
+	reg.Register(metadata.EntityDef{Name: "Warehouse", Key: "warehouse", Type: metadata.TypeCatalog, TableName: "cat_warehouses"})
+	h := NewMetadataHandler(reg, nil)
+	r := gin.New()
+	r.GET("/meta/entities", h.ListEntitiesSummary)
+	r.GET("/meta/version", h.Version)
+
+	get := func(path, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	first := get("/meta/entities", "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || !strings.HasPrefix(etag, `"`+h.version()+"-") {
+		t.Fatalf("first response: %d, ETag %q", first.Code, etag)
+	}
+	if w := get("/meta/entities", `"other", `+etag); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Fatalf("revalidation: %d %q", w.Code, w.Body.String())
+	}
+	if w := get("/meta/entities", `"other"`); w.Code != http.StatusOK {
+		t.Fatalf("stale tag: %d", w.Code)
+	}
+
+	// A stale since returns at once even with a long wait.
+	w := get("/meta/version?since=old.0&wait=25", "")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), h.version()) {
+		t.Fatalf("version: %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestMetadataHidesDisabledModules(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reg := metadata.NewRegistry()
+	reg.Register(metadata.EntityDef{Name: "Warehouse", Key: "warehouse", Type: metadata.TypeCatalog})
+	reg.Register(metadata.EntityDef{Name: "Wallet", Key: "wallet", Type: metadata.TypeCatalog, Module: modules.Crypto})
+	h := NewMetadataHandler(reg, nil)
+	h.SetModules(modules.NewResolver(moduleFlags{{Name: modules.FlagName(modules.Crypto)}}))
+	r := gin.New()
+	r.GET("/meta/entities", h.ListEntitiesSummary)
+	r.GET("/meta/:name", h.GetEntity)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/meta/entities", nil))
+	if !strings.Contains(w.Body.String(), `"Warehouse"`) || strings.Contains(w.Body.String(), `"Wallet"`) {
+		t.Errorf("entities = %s", w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/meta/Wallet", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("GET /meta/Wallet = %d, want 404", w.Code)
+	}
+}
+
+type moduleFlags []modules.Flag
+
+func (f moduleFlags) ListModuleFlags(context.Context) ([]modules.Flag, error) { return f, nil }
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/core/security"
+	"metapus/internal/domain/catalogs/nomenclature"
+	"metapus/internal/domain/printing"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+// NomenclatureHandler adds barcode lookup and label printing to the
+// nomenclature catalog handler with image gallery.
+type NomenclatureHandler struct {
+	*CatalogImageHandler[*nomenclature.Nomenclature, dto.CreateNomenclatureRequest, dto.UpdateNomenclatureRequest]
+	nomenclatures *nomenclature.Service
+}
+
+// NewNomenclatureHandler creates a new nomenclature handler.
+func NewNomenclatureHandler(
+	catalog *CatalogImageHandler[*nomenclature.Nomenclature, dto.CreateNomenclatureRequest, dto.UpdateNomenclatureRequest],
+	service *nomenclature.Service,
+) *NomenclatureHandler {
+	return &NomenclatureHandler{CatalogImageHandler: catalog, nomenclatures: service}
+}
+
+// FindByBarcode handles GET /catalog/nomenclatures/by-barcode/:code — the item
+// with the primary or an additional barcode. The code must be a valid EAN-13
+// or Code 128 barcode.
+func (h *NomenclatureHandler) FindByBarcode(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	item, err := h.nomenclatures.FindByBarcode(ctx, c.Param("code"))
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	var refs any
+	if h.resolveRefs != nil {
+		refs, err = h.resolveRefs(ctx, item)
+		if err != nil {
+			h.Error(c, err)
+			return
+		}
+	}
+
+	if policy := security.GetFieldPolicy(ctx, h.entityName, "read"); policy != nil {
+		security.MaskForRead(item, policy)
+	}
+
+	c.JSON(http.StatusOK, h.toDTO(item, refs))
+}
+
+// PrintLabels handles POST /catalog/nomenclatures/labels — a sheet of
+// 60 x 40 mm labels (name, article, barcode) for the selected items, as PDF
+// (default), PNG or HTML. Each item is printed with its primary barcode, or
+// its first additional one; an item without a barcode is a validation error.
+func (h *NomenclatureHandler) PrintLabels(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.PrintLabelsRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+	copies := req.Copies
+	if copies == 0 {
+		copies = 1
+	}
+	if len(req.IDs)*copies > printing.LabelsMax {
+		h.Error(c, apperror.NewValidation("too many labels").
+			WithDetail("max", printing.LabelsMax))
+		return
+	}
+
+	labels := make([]printing.Label, 0, len(req.IDs)*copies)
+	for _, raw := range req.IDs {
+		itemID, err := id.Parse(raw)
+		if err != nil {
+			h.Error(c, apperror.NewValidation("invalid id format").WithDetail("id", raw))
+			return
+		}
+		// GetByID applies the access checks of the catalog service.
+		item, err := h.service.GetByID(ctx, itemID)
+		if err != nil {
+			h.Error(c, err)
+			return
+		}
+		codes := item.AllBarcodes()
+		if len(codes) == 0 {
+			h.Error(c, apperror.NewValidation("item has no barcode").
+				WithDetail("id", raw).
+				WithDetail("name", item.Name))
+			return
+		}
+		var article string
+		if item.Article != nil {
+			article = *item.Article
+		}
+		label, err := printing.NewLabel(item.Name, article, codes[0])
+		if err != nil {
+			h.Error(c, apperror.NewValidation(err.Error()).WithDetail("id", raw))
+			return
+		}
+		for range copies {
+			labels = append(labels, label)
+		}
+	}
+
+	var buf bytes.Buffer
+	switch req.Output {
+	case "png":
+		if err := printing.RenderLabelsPNG(&buf, labels); err != nil {
+			h.Error(c, apperror.NewInternal(err))
+			return
+		}
+		c.Header("Content-Type", "image/png")
+		c.Header("Content-Disposition", contentDisposition("labels", "png"))
+
+	case "html":
+		if err := printing.RenderLabelsHTML(&buf, labels); err != nil {
+			h.Error(c, apperror.NewInternal(err))
+			return
+		}
+		c.Header("Content-Type", "text/html; charset=utf-8")
+
+	default: // pdf
+		var htmlBuf bytes.Buffer
+		if err := printing.RenderLabelsHTML(&htmlBuf, labels); err != nil {
+			h.Error(c, apperror.NewInternal(err))
+			return
+		}
+		if err := printing.RenderPDF(&buf, htmlBuf.Bytes()); err != nil {
+			h.Error(c, apperror.NewInternal(err))
+			return
+		}
+		c.Header("Content-Type", "application/pdf")
+		c.Header("Content-Disposition", contentDisposition("labels", "pdf"))
+	}
+
+	c.Status(http.StatusOK)
+	_, _ = c.Writer.Write(buf.Bytes())
+}
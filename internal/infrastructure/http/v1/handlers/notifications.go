@@ -1,13 +1,18 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"metapus/internal/core/apperror"
 	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
 	"metapus/internal/domain/auth"
 	"metapus/internal/domain/notifications"
 	ws "metapus/internal/infrastructure/websocket"
@@ -17,13 +22,15 @@ type NotificationHandler struct {
 	BaseHandler   *BaseHandler
 	repo          notifications.Repository
 	wsTicketStore *auth.WSTicketStore
+	broker        *notifications.Broker
 }
 
-func NewNotificationHandler(repo notifications.Repository, ticketStore *auth.WSTicketStore) *NotificationHandler {
+func NewNotificationHandler(repo notifications.Repository, ticketStore *auth.WSTicketStore, broker *notifications.Broker) *NotificationHandler {
 	return &NotificationHandler{
 		BaseHandler:   NewBaseHandler(),
 		repo:          repo,
 		wsTicketStore: ticketStore,
+		broker:        broker,
 	}
 }
 
@@ -222,3 +229,107 @@ func (h *NotificationHandler) Delete(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// streamHeartbeat keeps idle notification streams open through proxies.
+const streamHeartbeat = 25 * time.Second
+
+// Stream serves the caller's notifications as Server-Sent Events.
+// EventSource cannot send headers, so the stream is authenticated like
+// ServeWS: with a single-use ticket from POST /auth/ws-ticket (?ticket=).
+// Events: "unread" {unreadCount} on connect and after every change,
+// "notification" with a new notification, and "reset" when changes may have
+// been missed and the list must be reloaded.
+func (h *NotificationHandler) Stream(c *gin.Context) {
+	ticket := c.Query("ticket")
+	if ticket == "" {
+		_ = c.Error(apperror.NewUnauthorized("missing ticket parameter — obtain via POST /auth/ws-ticket"))
+		c.Abort()
+		return
+	}
+
+	userIDStr, tenantID, ok := h.wsTicketStore.ValidateTicket(ticket)
+	if !ok || tenantID != tenant.GetTenantID(c.Request.Context()) {
+		_ = c.Error(apperror.NewUnauthorized("invalid or expired ticket"))
+		c.Abort()
+		return
+	}
+	userID, err := id.Parse(userIDStr)
+	if err != nil {
+		_ = c.Error(apperror.NewUnauthorized("invalid user identity"))
+		c.Abort()
+		return
+	}
+
+	// Subscribe before the first counter so no change falls in between.
+	sub := h.broker.Subscribe(tenantID, userIDStr)
+	defer sub.Close()
+
+	ctx := c.Request.Context()
+	unread, err := h.repo.CountUnread(ctx, userID)
+	if err != nil {
+		_ = c.Error(apperror.NewInternal(err))
+		c.Abort()
+		return
+	}
+
+	// The stream outlives the server WriteTimeout.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // disable nginx buffering
+	c.Status(http.StatusOK)
+	writeStreamEvent(c, "unread", gin.H{"unreadCount": unread})
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			_, _ = fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+		case <-sub.Ready():
+			if err := h.streamChanges(ctx, c, userID, sub.Changes()); err != nil {
+				writeStreamEvent(c, "reset", gin.H{"error": "failed to load notifications"})
+				_ = c.Error(err)
+				return
+			}
+		}
+	}
+}
+
+// streamChanges sends the new notifications among changes followed by the
+// unread counter.
+func (h *NotificationHandler) streamChanges(ctx context.Context, c *gin.Context, userID id.ID, changes []notifications.Change) error {
+	for _, ch := range changes {
+		switch {
+		case ch.Op == notifications.ChangeReset:
+			writeStreamEvent(c, "reset", gin.H{})
+		case ch.Op == notifications.ChangeCreated && ch.ID != nil:
+			n, err := h.repo.GetByID(ctx, *ch.ID)
+			if err != nil {
+				if apperror.IsNotFound(err) {
+					continue // deleted meanwhile
+				}
+				return err
+			}
+			if n.UserID == userID {
+				writeStreamEvent(c, "notification", n)
+			}
+		}
+	}
+	unread, err := h.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return err
+	}
+	writeStreamEvent(c, "unread", gin.H{"unreadCount": unread})
+	return nil
+}
+
+// writeStreamEvent writes a named SSE event and flushes.
+func writeStreamEvent(c *gin.Context, event string, data any) {
+	payload, _ := json.Marshal(data)
+	_, _ = fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event, payload)
+	c.Writer.Flush()
+}
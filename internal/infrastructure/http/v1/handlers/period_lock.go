@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/period"
+)
+
+// PeriodLockHandler closes and reopens accounting periods per organization.
+type PeriodLockHandler struct {
+	*BaseHandler
+	service *period.Service
+}
+
+// NewPeriodLockHandler creates a new handler.
+func NewPeriodLockHandler(base *BaseHandler, service *period.Service) *PeriodLockHandler {
+	return &PeriodLockHandler{
+		BaseHandler: base,
+		service:     service,
+	}
+}
+
+// --- DTOs ---
+
+// PeriodLockRequest is the request body of close and reopen.
+type PeriodLockRequest struct {
+	// ClosedUntil is the first day of the open period (YYYY-MM-DD); empty
+	// on reopen opens every period.
+	ClosedUntil string `json:"closedUntil"`
+	Comment     string `json:"comment"`
+}
+
+// --- Handlers ---
+
+// List returns the period locks of all organizations.
+// GET /api/v1/system/period-locks
+func (h *PeriodLockHandler) List(c *gin.Context) {
+	locks, err := h.service.List(c.Request.Context())
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+	h.OK(c, locks)
+}
+
+// Close closes the period of an organization until the given date.
+// POST /api/v1/system/period-locks/:organizationId/close
+func (h *PeriodLockHandler) Close(c *gin.Context) {
+	orgID, req, until, ok := h.parseLockRequest(c)
+	if !ok {
+		return
+	}
+	lock, err := h.service.Close(c.Request.Context(), orgID, until, req.Comment)
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+	h.OK(c, lock)
+}
+
+// Reopen moves the lock date of an organization back; without closedUntil
+// every period is opened.
+// POST /api/v1/system/period-locks/:organizationId/reopen
+func (h *PeriodLockHandler) Reopen(c *gin.Context) {
+	orgID, req, until, ok := h.parseLockRequest(c)
+	if !ok {
+		return
+	}
+	lock, err := h.service.Reopen(c.Request.Context(), orgID, until, req.Comment)
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+	if lock == nil {
+		h.NoContent(c)
+		return
+	}
+	h.OK(c, lock)
+}
+
+func (h *PeriodLockHandler) parseLockRequest(c *gin.Context) (id.ID, PeriodLockRequest, time.Time, bool) {
+	var req PeriodLockRequest
+	orgID, err := id.Parse(c.Param("organizationId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid organization id format"))
+		return orgID, req, time.Time{}, false
+	}
+	if !h.BindJSON(c, &req) {
+		return orgID, req, time.Time{}, false
+	}
+	var until time.Time
+	if req.ClosedUntil != "" {
+		if until, err = time.Parse(time.DateOnly, req.ClosedUntil); err != nil {
+			h.Error(c, apperror.NewValidation("closedUntil must be a date (YYYY-MM-DD)").WithDetail("field", "closedUntil"))
+			return orgID, req, time.Time{}, false
+		}
+	}
+	return orgID, req, until, true
+}
@@ -11,10 +11,12 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"metapus/internal/core/apperror"
+	"metapus/internal/core/format"
 	"metapus/internal/core/id"
 	"metapus/internal/core/security"
 	"metapus/internal/domain"
 	"metapus/internal/domain/printing"
+	"metapus/internal/domain/settings"
 )
 
 // DocumentPrintHandlerConfig configures a document print handler.
@@ -32,7 +34,10 @@ type DocumentPrintHandlerConfig[T any] struct {
 	// ResolveRefs resolves FK references for display names (nil = skip).
 	ResolveRefs func(ctx context.Context, entities ...T) (any, error)
 	// BuildPrintData converts the FLS-masked entity + resolved refs to print template context.
-	BuildPrintData func(entity T, refs any, showPrices bool) *printing.PrintData
+	// f formats numbers and dates for the tenant locale.
+	BuildPrintData func(entity T, refs any, showPrices bool, f *format.Formatter) *printing.PrintData
+	// SettingsRepo provides the tenant locale (nil = format.DefaultLocale).
+	SettingsRepo settings.Repository
 }
 
 // DocumentPrintHandler provides the Print HTTP handler for a single document type.
@@ -110,7 +115,7 @@ func (h *DocumentPrintHandler[T]) Print(c *gin.Context) {
 	}
 
 	// Build the template data context (includes Table for XLSX/DOCX).
-	printData := h.cfg.BuildPrintData(doc, refs, showPrices)
+	printData := h.cfg.BuildPrintData(doc, refs, showPrices, h.formatter(ctx, doc))
 
 	var buf bytes.Buffer
 
@@ -151,6 +156,24 @@ func (h *DocumentPrintHandler[T]) Print(c *gin.Context) {
 	_, _ = c.Writer.Write(buf.Bytes())
 }
 
+// formatter returns the formatter for the print locale of the current user and
+// document organization, falling back to the tenant locale.
+// Falls back to the default locale if settings are unavailable.
+func (h *DocumentPrintHandler[T]) formatter(ctx context.Context, doc T) *format.Formatter {
+	if h.cfg.SettingsRepo == nil {
+		return format.New(format.DefaultLocale)
+	}
+	s, err := h.cfg.SettingsRepo.Get(ctx)
+	if err != nil {
+		return format.New(format.DefaultLocale)
+	}
+	subj := settings.SubjectFromContext(ctx)
+	if o, ok := any(doc).(domain.OrganizationOwned); ok {
+		subj.OrganizationID = o.GetOrganizationID()
+	}
+	return settings.PrintFormatter(ctx, s.General, subj)
+}
+
 // ListPrintForms handles GET /document/{type}/print-forms
 // Returns []PrintFormSummary with available print forms for the document type.
 func (h *DocumentPrintHandler[T]) ListPrintForms(c *gin.Context) {
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain"
+	"metapus/internal/domain/documents/purchase_order"
+	"metapus/internal/domain/registers/supplier_order"
+	"metapus/internal/domain/settings"
+	"metapus/internal/infrastructure/http/v1/dto"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+// PurchaseOrderHandler handles HTTP requests for PurchaseOrder documents.
+// Standard CRUD/posting methods are handled by BaseDocumentHandler via ResolveRefs callback.
+// Only entity-specific methods (Copy, UpdateAndRepost, GetFulfillment) are overridden.
+type PurchaseOrderHandler struct {
+	*BaseDocumentHandler[*purchase_order.PurchaseOrder, dto.CreatePurchaseOrderRequest, dto.UpdatePurchaseOrderRequest]
+	service            domain.DocumentService[*purchase_order.PurchaseOrder]
+	fulfillment        *supplier_order.Service
+	relatedDocsHandler *RelatedDocumentsHandler
+}
+
+// resolvePurchaseOrderRefs batch-resolves all reference IDs for a list of PurchaseOrder documents.
+// Returns an opaque DocRefsBag for use by MapToDTOWithRefs.
+func resolvePurchaseOrderRefs(ctx context.Context, docs ...*purchase_order.PurchaseOrder) (any, error) {
+	resolver := postgres.NewReferenceResolver()
+	for _, doc := range docs {
+		dto.CollectPurchaseOrderRefs(resolver, doc)
+	}
+
+	pool := tenant.MustGetPool(ctx)
+	refs, err := resolver.Resolve(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	currencyRefs, err := resolver.ResolveCurrencies(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.DocRefsBag{Refs: refs, CurrencyRefs: currencyRefs}, nil
+}
+
+// NewPurchaseOrderHandler creates a new purchase order handler.
+// Accepts domain.DocumentService interface — can be a concrete service or a decorated wrapper.
+func NewPurchaseOrderHandler(
+	base *BaseHandler,
+	service domain.DocumentService[*purchase_order.PurchaseOrder],
+	fulfillment *supplier_order.Service,
+	relatedDocFinder domain.RelatedDocFinder,
+	movementProviders []entity.MovementProvider,
+	movementRefResolver domain.RefResolver,
+	settingsRepo settings.Repository,
+) *PurchaseOrderHandler {
+	cfg := BaseDocumentHandlerConfig[*purchase_order.PurchaseOrder, dto.CreatePurchaseOrderRequest, dto.UpdatePurchaseOrderRequest]{
+		Service:    service,
+		EntityName: "purchase_order",
+		MapCreateDTO: func(req dto.CreatePurchaseOrderRequest) *purchase_order.PurchaseOrder {
+			return req.ToEntity()
+		},
+		MapUpdateDTO: func(req dto.UpdatePurchaseOrderRequest, existing *purchase_order.PurchaseOrder) *purchase_order.PurchaseOrder {
+			req.ApplyTo(existing)
+			return existing
+		},
+		MapToDTO: func(entity *purchase_order.PurchaseOrder) any {
+			return dto.FromPurchaseOrder(entity, nil)
+		},
+		IsPostImmediately: func(req dto.CreatePurchaseOrderRequest) bool {
+			return req.PostImmediately
+		},
+		ResolveRefs: resolvePurchaseOrderRefs,
+		MapToDTOWithRefs: func(entity *purchase_order.PurchaseOrder, refs any) any {
+			bag := refs.(*dto.DocRefsBag)
+			return dto.FromPurchaseOrder(entity, bag.Refs, bag.CurrencyRefs)
+		},
+		MovementProviders:   movementProviders,
+		MovementRefResolver: movementRefResolver,
+		SettingsRepo:        settingsRepo,
+	}
+
+	h := &PurchaseOrderHandler{
+		BaseDocumentHandler: NewBaseDocumentHandler(base, cfg),
+		service:             service,
+		fulfillment:         fulfillment,
+	}
+
+	// Related documents (optional)
+	if relatedDocFinder != nil {
+		h.relatedDocsHandler = NewRelatedDocumentsHandler(relatedDocFinder, "PurchaseOrder")
+	}
+
+	return h
+}
+
+// GetRelatedDocuments handles GET /document/purchase-order/:id/related-documents.
+// Implements DocumentRelatedDocsHandler interface (auto-registered by RegisterDocumentRoutes).
+func (h *PurchaseOrderHandler) GetRelatedDocuments(c *gin.Context) {
+	if h.relatedDocsHandler == nil {
+		c.JSON(http.StatusOK, gin.H{"groups": []any{}})
+		return
+	}
+	h.relatedDocsHandler.GetRelatedDocuments(c)
+}
+
+// GetFulfillment handles GET /document/purchase-order/:id/fulfillment —
+// ordered, received and remaining quantities per order line.
+// Implements DocumentFulfillmentHandler interface (auto-registered by RegisterDocumentRoutes).
+func (h *PurchaseOrderHandler) GetFulfillment(c *gin.Context) {
+	ctx := c.Request.Context()
+	docID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	doc, err := h.service.GetByID(ctx, docID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	lines, err := h.fulfillment.GetLineFulfillment(ctx, docID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.FromPurchaseOrderFulfillment(doc, lines))
+}
+
+// UpdateAndRepost handles PUT /document/purchase-order/:id/repost — atomic update + re-post.
+// Accepts the same body as Update. The document is updated and re-posted in a single transaction.
+func (h *PurchaseOrderHandler) UpdateAndRepost(c *gin.Context) {
+	ctx := c.Request.Context()
+	docID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	var req dto.UpdatePurchaseOrderRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	doc, err := h.service.GetByID(ctx, docID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	req.ApplyTo(doc)
+
+	if err := h.service.UpdateAndRepost(ctx, doc); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	refs, _ := resolvePurchaseOrderRefs(ctx, doc)
+	var response any
+	if bag, ok := refs.(*dto.DocRefsBag); ok {
+		response = dto.FromPurchaseOrder(doc, bag.Refs, bag.CurrencyRefs)
+	} else {
+		response = dto.FromPurchaseOrder(doc, nil)
+	}
+	h.CompleteIdempotency(c, http.StatusOK, "application/json", response)
+	c.JSON(http.StatusOK, response)
+}
+
+// Copy handles POST /document/purchase-order/:id/copy — with resolved references.
+func (h *PurchaseOrderHandler) Copy(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	docID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	source, err := h.service.GetByID(ctx, docID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	copy := purchase_order.NewPurchaseOrder(source.OrganizationID, source.CounterpartyID, source.WarehouseID)
+	copy.Date = time.Now()
+	copy.ContractID = source.ContractID
+	copy.ExpectedDate = source.ExpectedDate
+	copy.CurrencyID = source.CurrencyID
+	copy.AmountIncludesVAT = source.AmountIncludesVAT
+	copy.Description = source.Description
+
+	// Lines keep their prices and VAT.
+	for _, line := range source.Lines {
+		line.LineID = id.New()
+		copy.Lines = append(copy.Lines, line)
+	}
+	copy.SetTotals(copy.LineTotals())
+
+	if err := h.service.Create(ctx, copy); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	refs, _ := resolvePurchaseOrderRefs(ctx, copy)
+	var response any
+	if bag, ok := refs.(*dto.DocRefsBag); ok {
+		response = dto.FromPurchaseOrder(copy, bag.Refs, bag.CurrencyRefs)
+	} else {
+		response = dto.FromPurchaseOrder(copy, nil)
+	}
+	h.CompleteIdempotency(c, http.StatusCreated, "application/json", response)
+	c.JSON(http.StatusCreated, response)
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"metapus/internal/core/apperror"
+	corectx "metapus/internal/core/context"
+	"metapus/internal/domain/recurring"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+// SetRecurringService enables recurring schedule endpoints for the handler.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) SetRecurringService(svc *recurring.Service) {
+	h.schedules = svc
+}
+
+// recurringService returns the schedule service or writes a 404 if schedules are disabled.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) recurringService(c *gin.Context) (*recurring.Service, bool) {
+	if h.schedules == nil {
+		h.Error(c, apperror.NewNotFound("recurring_schedule", c.Param("scheduleId")))
+		return nil, false
+	}
+	return h.schedules, true
+}
+
+// checkAutoPost rejects auto-posting schedules for users who cannot post
+// the document type: the worker posts on the author's behalf.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) checkAutoPost(c *gin.Context, autoPost bool) bool {
+	if !autoPost {
+		return true
+	}
+	return h.requireEntityPermission(c, "post")
+}
+
+// requireEntityPermission checks a document permission that is not enforced by
+// the route (it depends on the request body) and writes a 403 if it is missing.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) requireEntityPermission(c *gin.Context, action string) bool {
+	user := corectx.GetUser(c.Request.Context())
+	permission := "document:" + h.entityName + ":" + action
+	if user != nil && (user.IsAdmin || slices.Contains(user.Permissions, permission)) {
+		return true
+	}
+	h.Error(c, apperror.NewForbidden("insufficient permissions").WithDetail("required_permission", permission))
+	return false
+}
+
+// ListSchedules handles GET /{entity}/schedules.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) ListSchedules(c *gin.Context) {
+	svc, ok := h.recurringService(c)
+	if !ok {
+		return
+	}
+
+	list, err := svc.GetList(c.Request.Context(), h.entityName)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MapRecurringScheduleListResponse(list))
+}
+
+// CreateSchedule handles POST /{entity}/schedules — creates documents from a
+// template on a CRON schedule.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) CreateSchedule(c *gin.Context) {
+	svc, ok := h.recurringService(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateRecurringScheduleRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+	if !h.checkAutoPost(c, req.AutoPost) {
+		return
+	}
+
+	sch := req.ToSchedule(h.entityName)
+	if err := svc.Create(c.Request.Context(), sch); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.MapRecurringScheduleResponse(sch))
+}
+
+// UpdateSchedule handles PUT /{entity}/schedules/:scheduleId.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) UpdateSchedule(c *gin.Context) {
+	svc, ok := h.recurringService(c)
+	if !ok {
+		return
+	}
+
+	scheduleID, err := uuid.Parse(c.Param("scheduleId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid UUID"))
+		return
+	}
+
+	var req dto.UpdateRecurringScheduleRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+	if !h.checkAutoPost(c, req.AutoPost) {
+		return
+	}
+
+	sch := req.ToSchedule(h.entityName)
+	sch.ID = scheduleID
+	sch.Version = req.Version
+	if err := svc.Update(c.Request.Context(), sch); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MapRecurringScheduleResponse(sch))
+}
+
+// DeleteSchedule handles DELETE /{entity}/schedules/:scheduleId.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) DeleteSchedule(c *gin.Context) {
+	svc, ok := h.recurringService(c)
+	if !ok {
+		return
+	}
+
+	scheduleID, err := uuid.Parse(c.Param("scheduleId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid UUID"))
+		return
+	}
+
+	if err := svc.Delete(c.Request.Context(), h.entityName, scheduleID); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListScheduleRuns handles GET /{entity}/schedules/:scheduleId/runs — the run history.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) ListScheduleRuns(c *gin.Context) {
+	svc, ok := h.recurringService(c)
+	if !ok {
+		return
+	}
+
+	scheduleID, err := uuid.Parse(c.Param("scheduleId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid UUID"))
+		return
+	}
+
+	runs, err := svc.ListRuns(c.Request.Context(), h.entityName, scheduleID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MapRecurringRunListResponse(runs))
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/reposting"
+)
+
+// RepostHandler serves the /system/repost-runs API: re-posting the posted
+// documents of a date range in the background.
+type RepostHandler struct {
+	*BaseHandler
+	service *reposting.Service
+}
+
+// NewRepostHandler creates a new handler.
+func NewRepostHandler(base *BaseHandler, service *reposting.Service) *RepostHandler {
+	return &RepostHandler{
+		BaseHandler: base,
+		service:     service,
+	}
+}
+
+// RegisterRoutes wires the repost routes under the provided group.
+func (h *RepostHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/repost-runs", h.List)
+	rg.POST("/repost-runs", h.Start)
+	rg.GET("/repost-runs/:id", h.Get)
+}
+
+// StartRepostRequest is the request body of Start.
+type StartRepostRequest struct {
+	DateFrom      string   `json:"dateFrom" binding:"required"` // YYYY-MM-DD
+	DateTo        string   `json:"dateTo" binding:"required"`   // YYYY-MM-DD, included
+	DocumentTypes []string `json:"documentTypes"`               // e.g. goods_issue; empty = all
+}
+
+// List returns the latest runs with their progress, newest first.
+// GET /api/v1/system/repost-runs?limit=50
+func (h *RepostHandler) List(c *gin.Context) {
+	limit := h.ParseIntQuery(c, "limit", 50)
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+	runs, err := h.service.List(c.Request.Context(), limit)
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+	h.OK(c, runs)
+}
+
+// Get returns a run with its progress.
+// GET /api/v1/system/repost-runs/:id
+func (h *RepostHandler) Get(c *gin.Context) {
+	runID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+	run, err := h.service.Get(c.Request.Context(), runID)
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+	h.OK(c, run)
+}
+
+// Start queues the re-posting of the posted documents dated in the range;
+// the worker runs it. Follow the progress with Get.
+// POST /api/v1/system/repost-runs
+func (h *RepostHandler) Start(c *gin.Context) {
+	var req StartRepostRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+	from, err := time.Parse(time.DateOnly, req.DateFrom)
+	if err != nil {
+		h.Error(c, apperror.NewValidation("dateFrom must be a date (YYYY-MM-DD)").WithDetail("field", "dateFrom"))
+		return
+	}
+	to, err := time.Parse(time.DateOnly, req.DateTo)
+	if err != nil {
+		h.Error(c, apperror.NewValidation("dateTo must be a date (YYYY-MM-DD)").WithDetail("field", "dateTo"))
+		return
+	}
+
+	run, err := h.service.Start(c.Request.Context(), reposting.Request{
+		DateFrom:      from,
+		DateTo:        to,
+		DocumentTypes: req.DocumentTypes,
+	})
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, run)
+}
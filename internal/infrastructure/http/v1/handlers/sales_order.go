@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain"
+	"metapus/internal/domain/board"
+	"metapus/internal/domain/documents/sales_order"
+	"metapus/internal/domain/registers/customer_order"
+	"metapus/internal/domain/settings"
+	"metapus/internal/infrastructure/http/v1/dto"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+// SalesOrderHandler handles HTTP requests for SalesOrder documents.
+// Standard CRUD/posting methods are handled by BaseDocumentHandler via ResolveRefs callback.
+// Only entity-specific methods (Copy, UpdateAndRepost, GetFulfillment, Close) are overridden.
+type SalesOrderHandler struct {
+	*BaseDocumentHandler[*sales_order.SalesOrder, dto.CreateSalesOrderRequest, dto.UpdateSalesOrderRequest]
+	service            domain.DocumentService[*sales_order.SalesOrder]
+	fulfillment        *customer_order.Service
+	relatedDocsHandler *RelatedDocumentsHandler
+}
+
+// resolveSalesOrderRefs batch-resolves all reference IDs for a list of SalesOrder documents.
+// Returns an opaque DocRefsBag for use by MapToDTOWithRefs.
+func resolveSalesOrderRefs(ctx context.Context, docs ...*sales_order.SalesOrder) (any, error) {
+	resolver := postgres.NewReferenceResolver()
+	for _, doc := range docs {
+		dto.CollectSalesOrderRefs(resolver, doc)
+	}
+
+	pool := tenant.MustGetPool(ctx)
+	refs, err := resolver.Resolve(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	currencyRefs, err := resolver.ResolveCurrencies(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.DocRefsBag{Refs: refs, CurrencyRefs: currencyRefs}, nil
+}
+
+// NewSalesOrderHandler creates a new sales order handler.
+// Accepts domain.DocumentService interface — can be a concrete service or a decorated wrapper.
+func NewSalesOrderHandler(
+	base *BaseHandler,
+	service domain.DocumentService[*sales_order.SalesOrder],
+	fulfillment *customer_order.Service,
+	relatedDocFinder domain.RelatedDocFinder,
+	movementProviders []entity.MovementProvider,
+	movementRefResolver domain.RefResolver,
+	settingsRepo settings.Repository,
+) *SalesOrderHandler {
+	cfg := BaseDocumentHandlerConfig[*sales_order.SalesOrder, dto.CreateSalesOrderRequest, dto.UpdateSalesOrderRequest]{
+		Service:    service,
+		EntityName: "sales_order",
+		MapCreateDTO: func(req dto.CreateSalesOrderRequest) *sales_order.SalesOrder {
+			return req.ToEntity()
+		},
+		MapUpdateDTO: func(req dto.UpdateSalesOrderRequest, existing *sales_order.SalesOrder) *sales_order.SalesOrder {
+			req.ApplyTo(existing)
+			return existing
+		},
+		MapToDTO: func(entity *sales_order.SalesOrder) any {
+			return dto.FromSalesOrder(entity, nil)
+		},
+		IsPostImmediately: func(req dto.CreateSalesOrderRequest) bool {
+			return req.PostImmediately
+		},
+		ResolveRefs: resolveSalesOrderRefs,
+		MapToDTOWithRefs: func(entity *sales_order.SalesOrder, refs any) any {
+			bag := refs.(*dto.DocRefsBag)
+			return dto.FromSalesOrder(entity, bag.Refs, bag.CurrencyRefs)
+		},
+		MovementProviders:   movementProviders,
+		MovementRefResolver: movementRefResolver,
+		SettingsRepo:        settingsRepo,
+	}
+
+	h := &SalesOrderHandler{
+		BaseDocumentHandler: NewBaseDocumentHandler(base, cfg),
+		service:             service,
+		fulfillment:         fulfillment,
+	}
+
+	// Related documents (optional)
+	if relatedDocFinder != nil {
+		h.relatedDocsHandler = NewRelatedDocumentsHandler(relatedDocFinder, "SalesOrder")
+	}
+	h.setBoardAction(board.ActionClose, h.closeOrder)
+
+	return h
+}
+
+// GetRelatedDocuments handles GET /document/sales-order/:id/related-documents.
+// Implements DocumentRelatedDocsHandler interface (auto-registered by RegisterDocumentRoutes).
+func (h *SalesOrderHandler) GetRelatedDocuments(c *gin.Context) {
+	if h.relatedDocsHandler == nil {
+		c.JSON(http.StatusOK, gin.H{"groups": []any{}})
+		return
+	}
+	h.relatedDocsHandler.GetRelatedDocuments(c)
+}
+
+// GetFulfillment handles GET /document/sales-order/:id/fulfillment —
+// ordered, shipped and open quantities per order line.
+// Implements DocumentFulfillmentHandler interface (auto-registered by RegisterDocumentRoutes).
+func (h *SalesOrderHandler) GetFulfillment(c *gin.Context) {
+	ctx := c.Request.Context()
+	docID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	doc, err := h.service.GetByID(ctx, docID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	lines, err := h.fulfillment.GetLineFulfillment(ctx, docID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.FromSalesOrderFulfillment(doc, lines))
+}
+
+// Close handles POST /document/sales-order/:id/close — closes a confirmed order.
+// The order is re-posted as closed: its open quantity and remaining reservation
+// are released and no more goods issues may be linked to it.
+// Implements DocumentCloseHandler interface (auto-registered by RegisterDocumentRoutes).
+func (h *SalesOrderHandler) Close(c *gin.Context) {
+	ctx := c.Request.Context()
+	docID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	if err := h.closeOrder(ctx, docID); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	// Re-read to return the status refreshed by the register.
+	doc, err := h.service.GetByID(ctx, docID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	refs, _ := resolveSalesOrderRefs(ctx, doc)
+	var response any
+	if bag, ok := refs.(*dto.DocRefsBag); ok {
+		response = dto.FromSalesOrder(doc, bag.Refs, bag.CurrencyRefs)
+	} else {
+		response = dto.FromSalesOrder(doc, nil)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// closeOrder closes a confirmed order and re-posts it.
+// Also performs the board "close" action.
+func (h *SalesOrderHandler) closeOrder(ctx context.Context, docID id.ID) error {
+	doc, err := h.service.GetByID(ctx, docID)
+	if err != nil {
+		return err
+	}
+	if err := doc.Close(); err != nil {
+		return err
+	}
+	return h.service.UpdateAndRepost(ctx, doc)
+}
+
+// UpdateAndRepost handles PUT /document/sales-order/:id/repost — atomic update + re-post.
+// Accepts the same body as Update. The document is updated and re-posted in a single transaction.
+func (h *SalesOrderHandler) UpdateAndRepost(c *gin.Context) {
+	ctx := c.Request.Context()
+	docID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	var req dto.UpdateSalesOrderRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	doc, err := h.service.GetByID(ctx, docID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	req.ApplyTo(doc)
+
+	if err := h.service.UpdateAndRepost(ctx, doc); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	refs, _ := resolveSalesOrderRefs(ctx, doc)
+	var response any
+	if bag, ok := refs.(*dto.DocRefsBag); ok {
+		response = dto.FromSalesOrder(doc, bag.Refs, bag.CurrencyRefs)
+	} else {
+		response = dto.FromSalesOrder(doc, nil)
+	}
+	h.CompleteIdempotency(c, http.StatusOK, "application/json", response)
+	c.JSON(http.StatusOK, response)
+}
+
+// Copy handles POST /document/sales-order/:id/copy — with resolved references.
+func (h *SalesOrderHandler) Copy(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	docID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	source, err := h.service.GetByID(ctx, docID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	copy := sales_order.NewSalesOrder(source.OrganizationID, source.CounterpartyID, source.WarehouseID)
+	copy.Date = time.Now()
+	copy.ContractID = source.ContractID
+	copy.ShipmentDate = source.ShipmentDate
+	copy.AutoReserve = source.AutoReserve
+	copy.CurrencyID = source.CurrencyID
+	copy.AmountIncludesVAT = source.AmountIncludesVAT
+	copy.Description = source.Description
+
+	// Lines keep their prices and VAT.
+	for _, line := range source.Lines {
+		line.LineID = id.New()
+		copy.Lines = append(copy.Lines, line)
+	}
+	copy.SetTotals(copy.LineTotals())
+
+	if err := h.service.Create(ctx, copy); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	refs, _ := resolveSalesOrderRefs(ctx, copy)
+	var response any
+	if bag, ok := refs.(*dto.DocRefsBag); ok {
+		response = dto.FromSalesOrder(copy, bag.Refs, bag.CurrencyRefs)
+	} else {
+		response = dto.FromSalesOrder(copy, nil)
+	}
+	h.CompleteIdempotency(c, http.StatusCreated, "application/json", response)
+	c.JSON(http.StatusCreated, response)
+}
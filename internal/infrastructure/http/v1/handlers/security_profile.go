@@ -288,6 +288,108 @@ func (h *SecurityProfileHandler) RemoveUser(c *gin.Context) {
 	h.Success(c, "user removed from profile")
 }
 
+// ListProfileRoles returns roles assigned to a security profile.
+// GET /api/v1/security/profiles/:profileId/roles
+func (h *SecurityProfileHandler) ListProfileRoles(c *gin.Context) {
+	profileID, err := id.Parse(c.Param("profileId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid profileId"))
+		return
+	}
+
+	repo, ok := h.repo.(interface {
+		ListRolesByProfileID(ctx context.Context, profileID id.ID) ([]security_profile.ProfileRole, error)
+	})
+	if !ok {
+		h.Error(c, apperror.NewInternal(nil).WithDetail("reason", "repo does not support ListRolesByProfileID"))
+		return
+	}
+
+	roles, err := repo.ListRolesByProfileID(c.Request.Context(), profileID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	items := make([]dto.ProfileRoleItem, len(roles))
+	for i, ro := range roles {
+		items[i] = dto.ProfileRoleItem{
+			ID:   ro.ID.String(),
+			Code: ro.Code,
+			Name: ro.Name,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// AssignRole assigns a role to a security profile. Users of the role without
+// a directly assigned profile get this profile.
+// POST /api/v1/security/profiles/:profileId/roles
+func (h *SecurityProfileHandler) AssignRole(c *gin.Context) {
+	profileID, err := id.Parse(c.Param("profileId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid profileId"))
+		return
+	}
+
+	var req dto.AssignProfileRoleRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	roleID, err := id.Parse(req.RoleID)
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid roleId"))
+		return
+	}
+
+	if err := h.repo.AssignToRole(c.Request.Context(), roleID, profileID); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	// Any user may hold the role — clear the whole cache
+	h.invalidateAll()
+
+	h.logAudit(c.Request.Context(), profileID, postgres.AuditActionUpdate, map[string]any{
+		"action": "assign_role",
+		"roleId": roleID.String(),
+	})
+
+	h.Success(c, "role assigned to profile")
+}
+
+// RemoveRole removes a role from a security profile.
+// DELETE /api/v1/security/profiles/:profileId/roles/:roleId
+func (h *SecurityProfileHandler) RemoveRole(c *gin.Context) {
+	profileID, err := id.Parse(c.Param("profileId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid profileId"))
+		return
+	}
+
+	roleID, err := id.Parse(c.Param("roleId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid roleId"))
+		return
+	}
+
+	if err := h.repo.RemoveFromRole(c.Request.Context(), roleID, profileID); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	h.invalidateAll()
+
+	h.logAudit(c.Request.Context(), profileID, postgres.AuditActionUpdate, map[string]any{
+		"action": "remove_role",
+		"roleId": roleID.String(),
+	})
+
+	h.Success(c, "role removed from profile")
+}
+
 // GetAuditHistory returns audit log entries for a security profile.
 // GET /api/v1/security/profiles/:profileId/audit
 func (h *SecurityProfileHandler) GetAuditHistory(c *gin.Context) {
@@ -307,18 +409,7 @@ func (h *SecurityProfileHandler) GetAuditHistory(c *gin.Context) {
 
 	items := make([]dto.AuditEntryResponse, len(entries))
 	for i, e := range entries {
-		var changes map[string]any
-		if len(e.Changes) > 0 {
-			_ = json.Unmarshal(e.Changes, &changes)
-		}
-		items[i] = dto.AuditEntryResponse{
-			ID:        e.ID.String(),
-			Action:    string(e.Action),
-			UserID:    e.UserID,
-			UserEmail: e.UserEmail,
-			Changes:   changes,
-			CreatedAt: e.CreatedAt,
-		}
+		items[i] = toAuditEntryResponse(e)
 	}
 
 	c.JSON(http.StatusOK, gin.H{"items": items})
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/registers/serial_number"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+// SerialNumberHandler handles the serial number pool and serial history.
+type SerialNumberHandler struct {
+	*BaseHandler
+	service *serial_number.Service
+}
+
+// NewSerialNumberHandler creates a new serial number handler.
+func NewSerialNumberHandler(base *BaseHandler, service *serial_number.Service) *SerialNumberHandler {
+	return &SerialNumberHandler{
+		BaseHandler: base,
+		service:     service,
+	}
+}
+
+// List handles GET /registers/serial-numbers
+// Returns the serials in stock for selection on issue.
+// Optional filters: nomenclatureId, warehouseId, search (serial prefix), limit.
+func (h *SerialNumberHandler) List(c *gin.Context) {
+	filter := serial_number.ListFilter{
+		Search: c.Query("search"),
+		Limit:  h.ParseIntQuery(c, "limit", 100),
+	}
+	var ok bool
+	if filter.NomenclatureID, ok = h.ParseIDQuery(c, "nomenclatureId"); !ok {
+		return
+	}
+	if filter.WarehouseID, ok = h.ParseIDQuery(c, "warehouseId"); !ok {
+		return
+	}
+
+	serials, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	items := make([]dto.SerialNumberResponse, len(serials))
+	for i, s := range serials {
+		items[i] = dto.FromSerialNumber(s)
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// History handles GET /registers/serial-numbers/history?nomenclatureId=&serial=
+// Returns the documents that moved the serial, oldest first.
+func (h *SerialNumberHandler) History(c *gin.Context) {
+	nomenclatureID, err := id.Parse(c.Query("nomenclatureId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("nomenclatureId is required"))
+		return
+	}
+
+	entries, err := h.service.History(c.Request.Context(), nomenclatureID, c.Query("serial"))
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	items := make([]dto.SerialHistoryEntryResponse, len(entries))
+	for i, e := range entries {
+		items[i] = dto.FromSerialHistoryEntry(e)
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
@@ -2,20 +2,31 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"regexp"
 
 	"github.com/gin-gonic/gin"
 
 	"metapus/internal/core/apperror"
+	"metapus/internal/core/clock"
+	"metapus/internal/core/id"
+	"metapus/internal/core/numerator"
+	"metapus/internal/domain"
+	"metapus/internal/domain/auth"
 	"metapus/internal/domain/settings"
 	"metapus/internal/infrastructure/http/v1/middleware"
+	"metapus/pkg/logger"
 )
 
 // SettingsHandler handles system settings endpoints.
 type SettingsHandler struct {
 	*BaseHandler
-	repo settings.Repository
+	repo      settings.Repository
+	mailer    auth.Mailer         // optional: enables POST /settings/email/test
+	numerator numerator.Generator // optional: enables GET /settings/numerators/:prefix/preview
+	resolver  *settings.Resolver  // optional: enables /settings/values endpoints
 }
 
 // NewSettingsHandler creates a new settings handler.
@@ -26,6 +37,21 @@ func NewSettingsHandler(base *BaseHandler, repo settings.Repository) *SettingsHa
 	}
 }
 
+// SetMailer configures the tenant-aware mailer used by the test-send endpoint.
+func (h *SettingsHandler) SetMailer(m auth.Mailer) {
+	h.mailer = m
+}
+
+// SetNumerator configures the numerator used by the number preview endpoint.
+func (h *SettingsHandler) SetNumerator(g numerator.Generator) {
+	h.numerator = g
+}
+
+// SetResolver configures the scoped settings resolver used by /settings/values.
+func (h *SettingsHandler) SetResolver(r *settings.Resolver) {
+	h.resolver = r
+}
+
 // Get handles GET /settings — returns the full settings object.
 func (h *SettingsHandler) Get(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -79,6 +105,29 @@ func (h *SettingsHandler) UpdateSection(c *gin.Context) {
 		return
 	}
 
+	switch section {
+	case "general":
+		var general settings.GeneralSettings
+		if err := json.Unmarshal(req.Data, &general); err != nil {
+			h.Error(c, apperror.NewValidation("invalid general settings: "+err.Error()))
+			return
+		}
+		if err := general.Validate(); err != nil {
+			h.Error(c, err)
+			return
+		}
+	case "retention":
+		var retention settings.RetentionSettings
+		if err := json.Unmarshal(req.Data, &retention); err != nil {
+			h.Error(c, apperror.NewValidation("invalid retention settings: "+err.Error()))
+			return
+		}
+		if err := retention.Validate(); err != nil {
+			h.Error(c, err)
+			return
+		}
+	}
+
 	updated, err := h.repo.UpdateSection(ctx, section, req.Data, req.Version)
 	if err != nil {
 		h.Error(c, err)
@@ -88,12 +137,295 @@ func (h *SettingsHandler) UpdateSection(c *gin.Context) {
 	c.JSON(http.StatusOK, updated)
 }
 
+// updateEmailRequest is the request body for PUT /settings/email.
+// Secret semantics: omitted/null keeps the stored secret, "" clears it.
+type updateEmailRequest struct {
+	Provider string  `json:"provider" binding:"required"`
+	SMTPHost string  `json:"smtpHost"`
+	SMTPPort string  `json:"smtpPort"`
+	Username string  `json:"username"`
+	From     string  `json:"from"`
+	Secret   *string `json:"secret"`
+	Version  int     `json:"version"  binding:"required"`
+}
+
+// UpdateEmail handles PUT /settings/email — replaces the email provider
+// configuration. The secret is encrypted at rest and never returned.
+func (h *SettingsHandler) UpdateEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req updateEmailRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	email := settings.EmailSettings{
+		Provider: req.Provider,
+		SMTPHost: req.SMTPHost,
+		SMTPPort: req.SMTPPort,
+		Username: req.Username,
+		From:     req.From,
+	}
+	if err := email.Validate(); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	updated, err := h.repo.UpdateEmail(ctx, email, req.Secret, req.Version)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// testEmailRequest is the request body for POST /settings/email/test.
+type testEmailRequest struct {
+	To string `json:"to" binding:"required,email"`
+}
+
+// TestEmail handles POST /settings/email/test — sends a test message through
+// the currently saved provider configuration.
+func (h *SettingsHandler) TestEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if h.mailer == nil {
+		h.Error(c, apperror.NewBusinessRule("EMAIL_UNAVAILABLE", "email sending is not configured"))
+		return
+	}
+
+	var req testEmailRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	err := h.mailer.Send(ctx, auth.MailMessage{
+		To:      []string{req.To},
+		Subject: "Metapus test email",
+		Body:    "This is a test message sent from the Metapus email settings page.",
+	})
+	if err != nil {
+		// CWE-209: provider errors may contain hostnames/credentials hints — log only.
+		logger.Warn(ctx, "test email failed", "error", err)
+		h.Error(c, apperror.NewBusinessRule("EMAIL_TEST_FAILED", "failed to send test email, check provider settings"))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// numeratorPrefixRe restricts prefixes to the format used by document services.
+var numeratorPrefixRe = regexp.MustCompile(`^[A-Za-z0-9_-]{1,20}$`)
+
+// PreviewNumber handles GET /settings/numerators/:prefix/preview — returns
+// the number the next document would get, without incrementing the sequence.
+// Optional query: date (RFC3339 or YYYY-MM-DD) selects the numbering period,
+// organizationId applies that organization's numbering settings.
+func (h *SettingsHandler) PreviewNumber(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if h.numerator == nil {
+		h.Error(c, apperror.NewBusinessRule("NUMERATOR_UNAVAILABLE", "numerator is not configured"))
+		return
+	}
+
+	prefix := c.Param("prefix")
+	if !numeratorPrefixRe.MatchString(prefix) {
+		h.Error(c, apperror.NewValidation("invalid numerator prefix").WithDetail("field", "prefix"))
+		return
+	}
+
+	period := clock.Now(ctx)
+	if raw := c.Query("date"); raw != "" {
+		parsed, err := parseDateParam(raw, false)
+		if err != nil {
+			h.Error(c, apperror.NewValidation("invalid date format").WithDetail("field", "date"))
+			return
+		}
+		period = parsed
+	}
+
+	var orgID id.ID
+	if raw := c.Query("organizationId"); raw != "" {
+		parsed, err := id.Parse(raw)
+		if err != nil {
+			h.Error(c, apperror.NewValidation("invalid organizationId").WithDetail("field", "organizationId"))
+			return
+		}
+		orgID = parsed
+	}
+
+	numbers, err := h.numerator.PeekNextNumbers(ctx, domain.NumeratorConfig(ctx, prefix, orgID), period, 1)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	if len(numbers) == 0 {
+		h.Error(c, apperror.NewInternal(fmt.Errorf("numerator returned no numbers for prefix %q", prefix)))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"prefix": prefix,
+		"number": numbers[0],
+	})
+}
+
+// ── Scoped setting values ───────────────────────────────────────────────
+
+// ListDefinitions handles GET /settings/values/definitions — returns all
+// registered setting keys with their type, allowed scopes and default.
+func (h *SettingsHandler) ListDefinitions(c *gin.Context) {
+	h.OK(c, gin.H{"items": settings.Definitions()})
+}
+
+// ListValues handles GET /settings/values — returns all stored values of the tenant.
+func (h *SettingsHandler) ListValues(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if !h.requireResolver(c) {
+		return
+	}
+	values, err := h.resolver.List(ctx)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	if values == nil {
+		values = []settings.Value{}
+	}
+	h.OK(c, gin.H{"items": values})
+}
+
+// effectiveValue is a resolved setting with the scope it came from.
+type effectiveValue struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+	// Source is the scope of the stored value, or "default".
+	Source string `json:"source"`
+}
+
+// Effective handles GET /settings/values/effective — resolves every key for
+// the current user. Optional query: organizationId adds the organization scope.
+func (h *SettingsHandler) Effective(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if !h.requireResolver(c) {
+		return
+	}
+	subj := settings.SubjectFromContext(ctx)
+	if raw := c.Query("organizationId"); raw != "" {
+		orgID, err := id.Parse(raw)
+		if err != nil {
+			h.Error(c, apperror.NewValidation("invalid organizationId").WithDetail("field", "organizationId"))
+			return
+		}
+		subj.OrganizationID = orgID
+	}
+
+	defs := settings.Definitions()
+	items := make([]effectiveValue, 0, len(defs))
+	for _, def := range defs {
+		raw, scope, found, err := h.resolver.Resolve(ctx, def.Key, subj)
+		if err != nil {
+			h.Error(c, err)
+			return
+		}
+		item := effectiveValue{Key: def.Key, Value: def.Default, Source: "default"}
+		if found {
+			item.Value = raw
+			item.Source = string(scope)
+		}
+		items = append(items, item)
+	}
+	h.OK(c, gin.H{"items": items})
+}
+
+// setValueRequest is the request body for PUT /settings/values/:key.
+// ScopeID is omitted for the tenant scope.
+type setValueRequest struct {
+	Scope   settings.Scope  `json:"scope"   binding:"required"`
+	ScopeID *id.ID          `json:"scopeId"`
+	Value   json.RawMessage `json:"value"   binding:"required"`
+}
+
+// SetValue handles PUT /settings/values/:key — stores a value at a scope.
+func (h *SettingsHandler) SetValue(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if !h.requireResolver(c) {
+		return
+	}
+	var req setValueRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+	var scopeID id.ID
+	if req.ScopeID != nil {
+		scopeID = *req.ScopeID
+	}
+
+	saved, err := h.resolver.Set(ctx, c.Param("key"), req.Scope, scopeID, req.Value)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.OK(c, saved)
+}
+
+// DeleteValue handles DELETE /settings/values/:key?scope=&scopeId= — removes
+// a stored value so resolution falls back to the next scope.
+func (h *SettingsHandler) DeleteValue(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if !h.requireResolver(c) {
+		return
+	}
+	var scopeID id.ID
+	if raw := c.Query("scopeId"); raw != "" {
+		parsed, err := id.Parse(raw)
+		if err != nil {
+			h.Error(c, apperror.NewValidation("invalid scopeId").WithDetail("field", "scopeId"))
+			return
+		}
+		scopeID = parsed
+	}
+
+	if err := h.resolver.Delete(ctx, c.Param("key"), settings.Scope(c.Query("scope")), scopeID); err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.NoContent(c)
+}
+
+// requireResolver reports an error when scoped settings are not configured.
+func (h *SettingsHandler) requireResolver(c *gin.Context) bool {
+	if h.resolver == nil {
+		h.Error(c, apperror.NewBusinessRule("SETTINGS_UNAVAILABLE", "scoped settings are not configured"))
+		return false
+	}
+	return true
+}
+
 // RegisterRoutes registers settings routes on the given router group.
 func (h *SettingsHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	// Number preview is used by create forms — available to any authenticated user.
+	rg.GET("/settings/numerators/:prefix/preview", h.PreviewNumber)
+	// Effective values of the current user — used by clients to adapt UI.
+	rg.GET("/settings/values/effective", h.Effective)
+
 	sg := rg.Group("/settings")
 	sg.Use(middleware.RequireRole("admin"))
 	{
 		sg.GET("", h.Get)
+		sg.PUT("/email", h.UpdateEmail)
+		sg.POST("/email/test", middleware.RateLimit(0.2, 3), h.TestEmail)
 		sg.PATCH("/:section", h.UpdateSection)
+
+		sg.GET("/values/definitions", h.ListDefinitions)
+		sg.GET("/values", h.ListValues)
+		sg.PUT("/values/:key", h.SetValue)
+		sg.DELETE("/values/:key", h.DeleteValue)
 	}
 }
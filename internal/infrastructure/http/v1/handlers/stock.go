@@ -94,6 +94,7 @@ func (h *StockHandler) GetBalances(c *gin.Context) {
 }
 
 // GetMovements handles GET /registers/stock/movements
+// Keyset-paginated: pass nextCursor from the previous page as ?after=.
 func (h *StockHandler) GetMovements(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -111,8 +112,8 @@ func (h *StockHandler) GetMovements(c *gin.Context) {
 	}
 
 	filter := stock.MovementFilter{
-		Limit:  h.ParseIntQuery(c, "limit", 100),
-		Offset: h.ParseIntQuery(c, "offset", 0),
+		Limit: min(max(h.ParseIntQuery(c, "limit", 100), 1), 1000),
+		After: c.Query("after"),
 	}
 
 	// Parse optional warehouse filter
@@ -136,21 +137,13 @@ func (h *StockHandler) GetMovements(c *gin.Context) {
 		}
 	}
 
-	movements, err := h.repo.GetMovementHistory(ctx, nomenclatureID, filter)
+	history, err := h.repo.GetMovementHistory(ctx, nomenclatureID, filter)
 	if err != nil {
 		h.Error(c, err)
 		return
 	}
 
-	response := make([]dto.StockMovementResponse, len(movements))
-	for i, m := range movements {
-		response[i] = dto.FromStockMovement(m)
-	}
-
-	c.JSON(http.StatusOK, dto.StockMovementListResponse{
-		Items:      response,
-		TotalCount: len(response),
-	})
+	c.JSON(http.StatusOK, dto.FromStockMovementHistory(history))
 }
 
 // GetTurnovers handles GET /registers/stock/turnovers
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/domain/registers/stock"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+// StockCorrectionHandler handles admin bulk corrections of stock movements.
+type StockCorrectionHandler struct {
+	*BaseHandler
+	service *stock.CorrectionService
+}
+
+// NewStockCorrectionHandler creates a new stock correction handler.
+func NewStockCorrectionHandler(base *BaseHandler, service *stock.CorrectionService) *StockCorrectionHandler {
+	return &StockCorrectionHandler{
+		BaseHandler: base,
+		service:     service,
+	}
+}
+
+// Preview handles POST /registers/stock/corrections/preview
+func (h *StockCorrectionHandler) Preview(c *gin.Context) {
+	var req dto.StockCorrectionRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	plan, err := h.service.Preview(c.Request.Context(), req.ToDomain())
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.FromStockCorrectionPlan(plan))
+}
+
+// Apply handles POST /registers/stock/corrections
+func (h *StockCorrectionHandler) Apply(c *gin.Context) {
+	var req dto.StockCorrectionRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	doc, plan, err := h.service.Apply(c.Request.Context(), req.ToDomain())
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ApplyStockCorrectionResponse{
+		Correction: dto.FromStockCorrection(*doc),
+		Plan:       dto.FromStockCorrectionPlan(plan),
+	})
+}
+
+// List handles GET /registers/stock/corrections
+func (h *StockCorrectionHandler) List(c *gin.Context) {
+	items, err := h.service.List(c.Request.Context(),
+		h.ParseIntQuery(c, "limit", 100),
+		h.ParseIntQuery(c, "offset", 0),
+	)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	resp := make([]dto.StockCorrectionResponse, len(items))
+	for i, item := range items {
+		resp[i] = dto.FromStockCorrection(item)
+	}
+	c.JSON(http.StatusOK, gin.H{"items": resp})
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/registers/stock"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+// StockInventoryHandler handles inventory counts of the stock register.
+type StockInventoryHandler struct {
+	*BaseHandler
+	service *stock.InventoryService
+}
+
+// NewStockInventoryHandler creates a new inventory count handler.
+func NewStockInventoryHandler(base *BaseHandler, service *stock.InventoryService) *StockInventoryHandler {
+	return &StockInventoryHandler{
+		BaseHandler: base,
+		service:     service,
+	}
+}
+
+// Start handles POST /registers/stock/inventory-counts
+func (h *StockInventoryHandler) Start(c *gin.Context) {
+	var req dto.StartInventoryCountRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	warehouseID, nomenclatureIDs := req.ParseIDs()
+	count, err := h.service.Start(c.Request.Context(), warehouseID, nomenclatureIDs, req.Comment)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.FromInventoryCount(*count))
+}
+
+// Complete handles POST /registers/stock/inventory-counts/:id/complete
+func (h *StockInventoryHandler) Complete(c *gin.Context) {
+	countID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	count, err := h.service.Complete(c.Request.Context(), countID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.FromInventoryCount(*count))
+}
+
+// Cancel handles POST /registers/stock/inventory-counts/:id/cancel
+func (h *StockInventoryHandler) Cancel(c *gin.Context) {
+	countID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id format"))
+		return
+	}
+
+	count, err := h.service.Cancel(c.Request.Context(), countID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.FromInventoryCount(*count))
+}
+
+// List handles GET /registers/stock/inventory-counts?status=in_progress
+func (h *StockInventoryHandler) List(c *gin.Context) {
+	items, err := h.service.List(c.Request.Context(),
+		c.Query("status"),
+		h.ParseIntQuery(c, "limit", 100),
+		h.ParseIntQuery(c, "offset", 0),
+	)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	resp := make([]dto.InventoryCountResponse, len(items))
+	for i, item := range items {
+		resp[i] = dto.FromInventoryCount(item)
+	}
+	c.JSON(http.StatusOK, gin.H{"items": resp})
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/registers/stock"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+// StockLevelHandler handles minimum/maximum stock levels and low-stock alerts.
+type StockLevelHandler struct {
+	*BaseHandler
+	service *stock.LevelService
+}
+
+// NewStockLevelHandler creates a new stock level handler.
+func NewStockLevelHandler(base *BaseHandler, service *stock.LevelService) *StockLevelHandler {
+	return &StockLevelHandler{
+		BaseHandler: base,
+		service:     service,
+	}
+}
+
+// List handles GET /registers/stock/levels
+// Optional filters: warehouseId, nomenclatureId, limit.
+func (h *StockLevelHandler) List(c *gin.Context) {
+	filter := stock.LevelFilter{Limit: h.ParseIntQuery(c, "limit", 100)}
+	var ok bool
+	if filter.WarehouseID, ok = h.ParseIDQuery(c, "warehouseId"); !ok {
+		return
+	}
+	if filter.NomenclatureID, ok = h.ParseIDQuery(c, "nomenclatureId"); !ok {
+		return
+	}
+
+	levels, err := h.service.ListLevels(c.Request.Context(), filter)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	items := make([]dto.StockLevelResponse, len(levels))
+	for i, l := range levels {
+		items[i] = dto.FromStockLevel(l)
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// Upsert handles PUT /registers/stock/levels
+// Creates or replaces the level of (warehouse, nomenclature).
+func (h *StockLevelHandler) Upsert(c *gin.Context) {
+	var req dto.UpsertStockLevelRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	level := req.ToDomain()
+	if err := h.service.SetLevel(c.Request.Context(), &level); err != nil {
+		h.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromStockLevel(level))
+}
+
+// Delete handles DELETE /registers/stock/levels?warehouseId=&nomenclatureId=
+func (h *StockLevelHandler) Delete(c *gin.Context) {
+	warehouseID, err := id.Parse(c.Query("warehouseId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("warehouseId is required"))
+		return
+	}
+	nomenclatureID, err := id.Parse(c.Query("nomenclatureId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("nomenclatureId is required"))
+		return
+	}
+
+	if err := h.service.DeleteLevel(c.Request.Context(), warehouseID, nomenclatureID); err != nil {
+		h.Error(c, err)
+		return
+	}
+	h.NoContent(c)
+}
+
+// Alerts handles GET /registers/stock/low-stock-alerts
+// Returns the balances currently below their minimum, as of the last check.
+func (h *StockLevelHandler) Alerts(c *gin.Context) {
+	alerts, err := h.service.Alerts(c.Request.Context())
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	items := make([]dto.LowStockAlertResponse, len(alerts))
+	for i, a := range alerts {
+		items[i] = dto.FromLowStockAlert(a)
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
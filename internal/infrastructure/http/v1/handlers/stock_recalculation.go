@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/jobs"
+	"metapus/internal/domain/registers/stock"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+// StockRecalculationHandler handles admin rebuilds of stock balances.
+type StockRecalculationHandler struct {
+	*BaseHandler
+	service *stock.RecalculationService
+	queue   *jobs.Queue
+}
+
+// NewStockRecalculationHandler creates a new stock recalculation handler.
+func NewStockRecalculationHandler(base *BaseHandler, service *stock.RecalculationService, queue *jobs.Queue) *StockRecalculationHandler {
+	return &StockRecalculationHandler{
+		BaseHandler: base,
+		service:     service,
+		queue:       queue,
+	}
+}
+
+// Recalculate handles POST /registers/stock/balances/recalculate
+func (h *StockRecalculationHandler) Recalculate(c *gin.Context) {
+	var req dto.StockRecalculateRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	if req.Async {
+		jobID, err := h.service.Enqueue(c.Request.Context(), h.queue, req.ToDomain())
+		if err != nil {
+			h.Error(c, err)
+			return
+		}
+		c.JSON(http.StatusAccepted, gin.H{"jobId": jobID})
+		return
+	}
+
+	result, err := h.service.Recalculate(c.Request.Context(), req.ToDomain(), req.DryRun)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromStockRecalculation(result))
+}
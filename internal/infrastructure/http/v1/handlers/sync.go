@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/deltasync"
+	"metapus/internal/metadata"
+)
+
+// SyncHandler serves change pulls of the differential sync API
+// (GET /{entity}/sync). Local edits are pushed to the entity handler
+// itself (POST /{entity}/sync/push), see PushSyncChanges.
+type SyncHandler struct {
+	service *deltasync.Service
+}
+
+// NewSyncHandler creates a new SyncHandler.
+func NewSyncHandler(service *deltasync.Service) *SyncHandler {
+	return &SyncHandler{service: service}
+}
+
+// Pull returns the handler of GET /{entity}/sync for def.
+//
+//	@Summary     Pull changes since a sync token
+//	@Description Returns created, updated and deleted items ordered by change, starting after the token (none: from scratch). Store the returned token and pull again at once while hasMore is set. An expired token (SYNC_TOKEN_EXPIRED) means the client must sync from scratch.
+//	@Tags        sync
+//	@Produce     json,application/msgpack
+//	@Param       token query    string false "Sync token of the previous pull"
+//	@Param       limit query    int    false "Batch size (default 200, max 1000)"
+//	@Success     200   {object} deltasync.Batch
+//	@Router      /catalog/{entity}/sync [get]
+func (h *SyncHandler) Pull(def metadata.EntityDef) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := 0
+		if v := c.Query("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				_ = c.Error(apperror.NewValidation("invalid limit").WithDetail("field", "limit"))
+				c.Abort()
+				return
+			}
+			limit = n
+		}
+		batch, err := h.service.Pull(c.Request.Context(), &def, c.Query("token"), limit)
+		if err != nil {
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+		writeNegotiated(c, http.StatusOK, batch)
+	}
+}
+
+// Push results.
+const (
+	syncApplied  = "applied"
+	syncConflict = "conflict"
+	syncRejected = "rejected"
+)
+
+// syncPushRequest carries edits made offline. Each change is the body of
+// the entity's PUT /:id, including the version it was based on.
+type syncPushRequest struct {
+	Changes []syncPushChange `json:"changes" binding:"required,min=1,max=500,dive"`
+}
+
+type syncPushChange struct {
+	ID   string          `json:"id" binding:"required"`
+	Data json.RawMessage `json:"data" binding:"required"`
+}
+
+// syncPushResult reports one change. A conflict carries the server's
+// current state, for the client to merge and push again.
+type syncPushResult struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"`
+	Data    any    `json:"data,omitempty"`
+	Current any    `json:"current,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type syncPushResponse struct {
+	Results   []syncPushResult `json:"results"`
+	Applied   int              `json:"applied"`
+	Conflicts int              `json:"conflicts"`
+	Rejected  int              `json:"rejected"`
+}
+
+// syncTarget is the update path of an entity handler.
+type syncTarget[T any, UpdateDTO any] struct {
+	get    func(ctx context.Context, id id.ID) (T, error)
+	apply  func(dto UpdateDTO, existing T) T
+	update func(ctx context.Context, entity T) error
+	view   func(ctx context.Context, entity T) any
+}
+
+// pushSyncChanges applies each change independently (partial mode, like
+// batch actions) through the regular update path, so the optimistic lock
+// decides conflicts: a change based on an outdated version is not applied.
+func pushSyncChanges[T any, UpdateDTO any](ctx context.Context, changes []syncPushChange, t syncTarget[T, UpdateDTO]) syncPushResponse {
+	resp := syncPushResponse{Results: make([]syncPushResult, 0, len(changes))}
+	for _, ch := range changes {
+		res := pushSyncChange(ctx, ch, t)
+		switch res.Status {
+		case syncApplied:
+			resp.Applied++
+		case syncConflict:
+			resp.Conflicts++
+		default:
+			resp.Rejected++
+		}
+		resp.Results = append(resp.Results, res)
+	}
+	return resp
+}
+
+func pushSyncChange[T any, UpdateDTO any](ctx context.Context, ch syncPushChange, t syncTarget[T, UpdateDTO]) syncPushResult {
+	res := syncPushResult{ID: ch.ID, Status: syncRejected}
+	entityID, err := id.Parse(ch.ID)
+	if err != nil {
+		res.Error = "invalid id format"
+		return res
+	}
+	var req UpdateDTO
+	err = json.Unmarshal(ch.Data, &req)
+	if err == nil {
+		err = binding.Validator.ValidateStruct(&req)
+	}
+	if err != nil {
+		res.Error = "invalid data: " + err.Error()
+		return res
+	}
+
+	existing, err := t.get(ctx, entityID)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	updated := t.apply(req, existing)
+	if err := t.update(ctx, updated); err != nil {
+		if !apperror.IsConcurrentModification(err) {
+			res.Error = err.Error()
+			return res
+		}
+		res.Status = syncConflict
+		res.Error = err.Error()
+		if current, err := t.get(ctx, entityID); err == nil {
+			res.Current = t.view(ctx, current)
+		}
+		return res
+	}
+	res.Status = syncApplied
+	res.Data = t.view(ctx, updated)
+	return res
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+// UniqueRuleHandler manages declarative uniqueness rules (sys_unique_rules).
+// Creating a rule builds its partial unique index on the entity table;
+// violations surface as DUPLICATE_ENTRY with the rule name.
+type UniqueRuleHandler struct {
+	*BaseHandler
+	repo *postgres.UniqueRuleRepo
+}
+
+// NewUniqueRuleHandler creates a new handler.
+func NewUniqueRuleHandler(base *BaseHandler, repo *postgres.UniqueRuleRepo) *UniqueRuleHandler {
+	return &UniqueRuleHandler{
+		BaseHandler: base,
+		repo:        repo,
+	}
+}
+
+// --- DTOs ---
+
+// CreateUniqueRuleRequest is the request body for creating a uniqueness rule.
+type CreateUniqueRuleRequest struct {
+	EntityType      string   `json:"entityType" binding:"required"`
+	Name            string   `json:"name" binding:"required"`
+	Fields          []string `json:"fields" binding:"required,min=1"`
+	CaseInsensitive bool     `json:"caseInsensitive"`
+	Message         string   `json:"message"`
+}
+
+// --- Handlers ---
+
+// List returns uniqueness rules, optionally filtered by entityType.
+// GET /api/v1/system/unique-rules?entityType=counterparty
+func (h *UniqueRuleHandler) List(c *gin.Context) {
+	rules, err := h.repo.List(c.Request.Context(), c.Query("entityType"))
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+	h.OK(c, rules)
+}
+
+// Get returns a single uniqueness rule by ID.
+// GET /api/v1/system/unique-rules/:id
+func (h *UniqueRuleHandler) Get(c *gin.Context) {
+	rule, err := h.repo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+	h.OK(c, rule)
+}
+
+// Create creates a uniqueness rule and its index. Fails with CONFLICT
+// (listing sample duplicates) when existing records already violate it.
+// POST /api/v1/system/unique-rules
+func (h *UniqueRuleHandler) Create(c *gin.Context) {
+	var req CreateUniqueRuleRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	rule := &postgres.UniqueRuleRecord{
+		EntityType:      req.EntityType,
+		Name:            req.Name,
+		Fields:          req.Fields,
+		CaseInsensitive: req.CaseInsensitive,
+		Message:         req.Message,
+	}
+	if err := h.repo.Create(c.Request.Context(), rule); err != nil {
+		h.HandleError(c, err)
+		return
+	}
+
+	h.Created(c, rule.ID)
+}
+
+// Delete removes a uniqueness rule and drops its index.
+// DELETE /api/v1/system/unique-rules/:id
+func (h *UniqueRuleHandler) Delete(c *gin.Context) {
+	if err := h.repo.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		h.HandleError(c, err)
+		return
+	}
+	h.NoContent(c)
+}
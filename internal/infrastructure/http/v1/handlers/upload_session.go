@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/attachment"
+	"metapus/internal/infrastructure/http/v1/dto"
+)
+
+// uploadSessionEndpoints implements the upload session endpoints of a
+// document. Access is checked on the owning entity, as for attachments.
+type uploadSessionEndpoints struct {
+	attachmentEndpoints
+	sessions *attachment.SessionService
+}
+
+// owner checks access to the owning entity; a handler without upload
+// sessions responds with 404.
+func (e uploadSessionEndpoints) owner(c *gin.Context) (id.ID, bool) {
+	if e.sessions == nil {
+		e.Error(c, apperror.NewNotFound("upload_session", c.Param("sessionId")))
+		return id.ID{}, false
+	}
+	return e.attachmentEndpoints.owner(c)
+}
+
+func (e uploadSessionEndpoints) sessionID(c *gin.Context) (id.ID, bool) {
+	sessionID, err := id.Parse(c.Param("sessionId"))
+	if err != nil {
+		e.Error(c, apperror.NewValidation("invalid session id format"))
+		return id.ID{}, false
+	}
+	return sessionID, true
+}
+
+func (e uploadSessionEndpoints) create(c *gin.Context) {
+	entityID, ok := e.owner(c)
+	if !ok {
+		return
+	}
+	var req dto.UploadSessionRequest
+	if !e.BindJSON(c, &req) {
+		return
+	}
+
+	sess, targets, err := e.sessions.Create(c.Request.Context(), e.entityType, entityID, req.ToFileSpecs())
+	if err != nil {
+		e.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.MapUploadSessionTargets(sess, targets))
+}
+
+func (e uploadSessionEndpoints) get(c *gin.Context) {
+	entityID, ok := e.owner(c)
+	if !ok {
+		return
+	}
+	sessionID, ok := e.sessionID(c)
+	if !ok {
+		return
+	}
+
+	sess, files, err := e.sessions.Get(c.Request.Context(), e.entityType, entityID, sessionID)
+	if err != nil {
+		e.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MapUploadSessionResponse(sess, files))
+}
+
+func (e uploadSessionEndpoints) addFiles(c *gin.Context) {
+	entityID, ok := e.owner(c)
+	if !ok {
+		return
+	}
+	sessionID, ok := e.sessionID(c)
+	if !ok {
+		return
+	}
+	var req dto.UploadSessionRequest
+	if !e.BindJSON(c, &req) {
+		return
+	}
+
+	sess, targets, err := e.sessions.AddFiles(c.Request.Context(), e.entityType, entityID, sessionID, req.ToFileSpecs())
+	if err != nil {
+		e.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MapUploadSessionTargets(sess, targets))
+}
+
+func (e uploadSessionEndpoints) finalize(c *gin.Context) {
+	entityID, ok := e.owner(c)
+	if !ok {
+		return
+	}
+	sessionID, ok := e.sessionID(c)
+	if !ok {
+		return
+	}
+
+	list, err := e.sessions.Finalize(c.Request.Context(), e.entityType, entityID, sessionID)
+	if err != nil {
+		e.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MapAttachmentListResponse(list))
+}
+
+// SetUploadSessionService enables upload session endpoints for the handler.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) SetUploadSessionService(svc *attachment.SessionService) {
+	h.uploadSessions = svc
+}
+
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) uploadSessionEndpoints() uploadSessionEndpoints {
+	return uploadSessionEndpoints{attachmentEndpoints: h.attachmentEndpoints(), sessions: h.uploadSessions}
+}
+
+// CreateUploadSession handles POST /{entity}/:id/attachments/upload-sessions.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) CreateUploadSession(c *gin.Context) {
+	h.uploadSessionEndpoints().create(c)
+}
+
+// GetUploadSession handles GET /{entity}/:id/attachments/upload-sessions/:sessionId.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) GetUploadSession(c *gin.Context) {
+	h.uploadSessionEndpoints().get(c)
+}
+
+// AddUploadSessionFiles handles POST /{entity}/:id/attachments/upload-sessions/:sessionId/files.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) AddUploadSessionFiles(c *gin.Context) {
+	h.uploadSessionEndpoints().addFiles(c)
+}
+
+// FinalizeUploadSession handles POST /{entity}/:id/attachments/upload-sessions/:sessionId/finalize.
+func (h *BaseDocumentHandler[T, CreateDTO, UpdateDTO]) FinalizeUploadSession(c *gin.Context) {
+	h.uploadSessionEndpoints().finalize(c)
+}
+
+// UploadHandler receives files of upload sessions at signed paths, used when
+// the attachment store cannot presign upload URLs. The signature (bound to
+// tenant, file and expiry) replaces the JWT.
+type UploadHandler struct {
+	*BaseHandler
+	sessions *attachment.SessionService
+}
+
+// NewUploadHandler creates a new upload handler.
+func NewUploadHandler(base *BaseHandler, sessions *attachment.SessionService) *UploadHandler {
+	return &UploadHandler{BaseHandler: base, sessions: sessions}
+}
+
+// Upload handles PUT /uploads/:fileId?tenant=&expires=&sig= (raw file body).
+func (h *UploadHandler) Upload(c *gin.Context) {
+	fileID, err := id.Parse(c.Param("fileId"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid file id format"))
+		return
+	}
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		h.Error(c, apperror.NewForbidden("upload link is invalid or expired"))
+		return
+	}
+
+	if err := h.sessions.Receive(c.Request.Context(), fileID, expires, c.Query("sig"), c.Request.Body); err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
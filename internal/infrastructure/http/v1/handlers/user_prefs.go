@@ -10,6 +10,7 @@ import (
 	"metapus/internal/core/apperror"
 	"metapus/internal/core/id"
 	"metapus/internal/domain/userpref"
+	"metapus/internal/infrastructure/http/v1/middleware"
 )
 
 // UserPrefsHandler handles user preferences endpoints.
@@ -240,7 +241,8 @@ func (h *UserPrefsHandler) requireUserID(c *gin.Context) (id.ID, error) {
 
 // RegisterRoutes registers user preferences routes on the given router group.
 func (h *UserPrefsHandler) RegisterRoutes(rg *gin.RouterGroup) {
-	me := rg.Group("/me/preferences")
+	// The caller's own preferences: no permission beyond authentication.
+	me := rg.Group("/me/preferences", middleware.PermitAuthenticated())
 	{
 		me.GET("", h.GetPreferences)
 		me.PUT("/interface", h.SaveInterface)
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/webhooks"
+)
+
+// WebhookHandler exposes the webhook delivery log via API.
+type WebhookHandler struct {
+	*BaseHandler
+	service *webhooks.Service
+}
+
+// NewWebhookHandler creates a new handler.
+func NewWebhookHandler(base *BaseHandler, service *webhooks.Service) *WebhookHandler {
+	return &WebhookHandler{
+		BaseHandler: base,
+		service:     service,
+	}
+}
+
+// parseDeliveryFilter extracts the delivery log filter from query string.
+func (h *WebhookHandler) parseDeliveryFilter(c *gin.Context) webhooks.DeliveryFilter {
+	filter := webhooks.DeliveryFilter{
+		Limit:     50,
+		EventType: c.Query("eventType"),
+	}
+
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			filter.Limit = parsed
+		}
+	}
+	if o := c.Query("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			filter.Offset = parsed
+		}
+	}
+	if subscriptionID := c.Query("subscriptionId"); subscriptionID != "" {
+		parsed, err := id.Parse(subscriptionID)
+		if err == nil {
+			filter.SubscriptionID = &parsed
+		}
+	}
+	if status := c.Query("status"); status != "" {
+		s := webhooks.DeliveryStatus(status)
+		filter.Status = &s
+	}
+
+	return filter
+}
+
+// ListDeliveries returns filtered and paginated deliveries, newest first.
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	deliveries, total, err := h.service.List(c.Request.Context(), h.parseDeliveryFilter(c))
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	if deliveries == nil {
+		deliveries = []webhooks.Delivery{}
+	}
+
+	h.OK(c, gin.H{
+		"items": deliveries,
+		"total": total,
+	})
+}
+
+// GetDelivery returns a single delivery.
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	deliveryID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id parameter").WithDetail("id", c.Param("id")))
+		return
+	}
+
+	delivery, err := h.service.GetByID(c.Request.Context(), deliveryID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	h.OK(c, delivery)
+}
+
+// RetryDelivery schedules a failed delivery for immediate redelivery.
+func (h *WebhookHandler) RetryDelivery(c *gin.Context) {
+	deliveryID, err := id.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, apperror.NewValidation("invalid id parameter").WithDetail("id", c.Param("id")))
+		return
+	}
+
+	delivery, err := h.service.Retry(c.Request.Context(), deliveryID)
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	h.OK(c, delivery)
+}
+
+// RegisterRoutes registers the delivery log endpoints.
+func (h *WebhookHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	deliveries := rg.Group("/deliveries")
+	{
+		deliveries.GET("", h.ListDeliveries)
+		deliveries.GET("/:id", h.GetDelivery)
+		deliveries.POST("/:id/retry", h.RetryDelivery)
+	}
+}
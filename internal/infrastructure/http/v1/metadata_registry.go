@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"strings"
+
+	"metapus/internal/metadata"
+	"metapus/internal/platform"
+)
+
+// BuildMetadataRegistry builds the entity metadata registry from the factory
+// registry without registering routes. Used by processes without the HTTP API
+// (e.g. the worker's search indexer); NewRouter populates its own registry
+// while registering entity routes.
+func BuildMetadataRegistry(factoryReg *FactoryRegistry) *metadata.Registry {
+	reg := metadata.NewRegistry()
+	refEndpoints := catalogRefEndpoints(factoryReg)
+
+	for _, factory := range factoryReg.Catalogs() {
+		if rp, ok := factory.(platform.ReferenceProvider); ok {
+			for _, refType := range rp.ReferenceTypes() {
+				reg.RegisterReferenceMapping(refType, factory.EntityName())
+			}
+		}
+		reg.Register(catalogEntityDef(factory, refEndpoints))
+	}
+	for _, factory := range factoryReg.Documents() {
+		reg.Register(documentEntityDef(factory, refEndpoints))
+	}
+	return reg
+}
+
+// catalogRefEndpoints maps reference types declared by catalog factories to
+// their API endpoints (refType -> /catalog/{routePrefix}).
+func catalogRefEndpoints(factoryReg *FactoryRegistry) map[string]string {
+	refEndpoints := map[string]string{
+		"parent": "", // parent is self-referencing, skip
+	}
+	for _, factory := range factoryReg.Catalogs() {
+		if rp, ok := factory.(platform.ReferenceProvider); ok {
+			for _, refType := range rp.ReferenceTypes() {
+				refEndpoints[refType] = "/catalog/" + factory.RoutePrefix()
+			}
+		}
+	}
+	return refEndpoints
+}
+
+// catalogEntityDef derives the metadata of a catalog from its factory
+// (optional: Inspectable, Presentable, TableNameProvider, RLSProvider, SearchFieldsProvider,
+// ModuleMember).
+func catalogEntityDef(factory CatalogRegistration, refEndpoints map[string]string) metadata.EntityDef {
+	var def metadata.EntityDef
+	if insp, ok := factory.(platform.Inspectable); ok {
+		def = metadata.Inspect(insp.EntityStruct(), factory.EntityName(), metadata.TypeCatalog)
+	} else {
+		def = metadata.EntityDef{Name: factory.EntityName(), Type: metadata.TypeCatalog}
+	}
+	if pres, ok := factory.(platform.Presentable); ok {
+		def.Presentation = pres.EntityPresentation()
+	}
+	if tp, ok := factory.(platform.TableNameProvider); ok {
+		def.TableName = tp.TableName()
+	} else {
+		// Derive table name from convention: cat_{routePrefix} (e.g. cat_counterparties)
+		def.TableName = "cat_" + strings.ReplaceAll(factory.RoutePrefix(), "-", "_")
+	}
+	def.Key = deriveEntityKey(factory.Permission())
+	def.RoutePrefix = factory.RoutePrefix()
+	def.SetRefEndpoints(refEndpoints)
+	if rls, ok := factory.(platform.RLSProvider); ok {
+		def.RLSDimensions = rls.RLSDimensions()
+	}
+	setSearchColumns(&def, factory)
+	if mm, ok := factory.(platform.ModuleMember); ok {
+		def.Module = mm.Module()
+	}
+	return def
+}
+
+// documentEntityDef derives the metadata of a document from its factory
+// (optional: Inspectable, Presentable, TableNameProvider, RLSProvider, SearchFieldsProvider,
+// ModuleMember).
+func documentEntityDef(factory DocumentRegistration, refEndpoints map[string]string) metadata.EntityDef {
+	var def metadata.EntityDef
+	if insp, ok := factory.(platform.Inspectable); ok {
+		def = metadata.Inspect(insp.EntityStruct(), factory.EntityName(), metadata.TypeDocument)
+	} else {
+		def = metadata.EntityDef{Name: factory.EntityName(), Type: metadata.TypeDocument}
+	}
+	if pres, ok := factory.(platform.Presentable); ok {
+		def.Presentation = pres.EntityPresentation()
+	}
+	def.Key = deriveEntityKey(factory.Permission())
+	def.RoutePrefix = factory.RoutePrefix()
+	if tp, ok := factory.(platform.TableNameProvider); ok {
+		def.TableName = tp.TableName()
+	} else {
+		// Derive table name from convention: doc_{entityKey}s (e.g. doc_goods_receipts)
+		def.TableName = "doc_" + def.Key + "s"
+	}
+	def.SetRefEndpoints(refEndpoints)
+	if rls, ok := factory.(platform.RLSProvider); ok {
+		def.RLSDimensions = rls.RLSDimensions()
+	}
+	setSearchColumns(&def, factory)
+	if mm, ok := factory.(platform.ModuleMember); ok {
+		def.Module = mm.Module()
+	}
+	return def
+}
+
+// setSearchColumns applies the global search columns declared by the factory.
+func setSearchColumns(def *metadata.EntityDef, factory any) {
+	if sf, ok := factory.(platform.SearchFieldsProvider); ok {
+		fields := sf.SearchableFields()
+		def.SearchColumns = &metadata.SearchColumns{
+			SearchCols:  fields.SearchCols,
+			TitleCol:    fields.TitleCol,
+			SubtitleCol: fields.SubtitleCol,
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+)
+
+// AdminTokenValidator validates control-plane admin tokens and returns the
+// operator they were issued to.
+type AdminTokenValidator interface {
+	Validate(tokenString string) (operator string, err error)
+}
+
+type adminOperatorKey struct{}
+
+// WithAdminOperator stores the authenticated control-plane operator in ctx.
+func WithAdminOperator(ctx context.Context, operator string) context.Context {
+	return context.WithValue(ctx, adminOperatorKey{}, operator)
+}
+
+// GetAdminOperator returns the control-plane operator of the request, or ""
+// outside the control-plane API.
+func GetAdminOperator(ctx context.Context) string {
+	operator, _ := ctx.Value(adminOperatorKey{}).(string)
+	return operator
+}
+
+// AdminJWT authenticates the control-plane API with an admin token
+// (Authorization: Bearer). Admin tokens are signed with their own secret and
+// carry no tenant, so the group needs neither X-Tenant-ID nor a tenant
+// database — it works for suspended and migration_failed tenants too.
+func AdminJWT(validator AdminTokenValidator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "bearer") || token == "" {
+			abortUnauthorized(c, "missing authorization header")
+			return
+		}
+
+		operator, err := validator.Validate(token)
+		if err != nil {
+			_ = c.Error(apperror.NewUnauthorized("invalid admin token").WithCause(err))
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(WithAdminOperator(c.Request.Context(), operator))
+		c.Next()
+	}
+}
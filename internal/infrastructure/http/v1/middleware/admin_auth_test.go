@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/domain/auth"
+)
+
+func TestAdminJWT(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	admin := auth.NewAdminTokenService("control-plane-secret")
+	serve := func(header string) (int, string) {
+		var operator string
+		router := gin.New()
+		router.Use(ErrorHandler())
+		router.GET("/x", AdminJWT(admin), func(c *gin.Context) {
+			operator = GetAdminOperator(c.Request.Context())
+			c.Status(http.StatusNoContent)
+		})
+		req := httptest.NewRequest(http.MethodGet, "/x", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code, operator
+	}
+
+	valid, _, err := admin.Issue("ops", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, _, err := admin.Issue("ops", -time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreign, _, err := auth.NewAdminTokenService("other-secret").Issue("ops", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenantToken, _, err := auth.NewJWTService(auth.DefaultJWTConfig("control-plane-secret")).
+		GenerateAccessToken("u1", "t1", "s1", "a@b.c", 1, 1, []string{"admin"}, nil, nil, true, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code, operator := serve("Bearer " + valid); code != http.StatusNoContent || operator != "ops" {
+		t.Fatalf("valid token: status = %d, operator = %q", code, operator)
+	}
+	for name, header := range map[string]string{
+		"missing":                   "",
+		"not bearer":                "Basic " + valid,
+		"expired":                   "Bearer " + expired,
+		"other secret":              "Bearer " + foreign,
+		"tenant token, same secret": "Bearer " + tenantToken,
+	} {
+		if code, _ := serve(header); code != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want 401", name, code)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/domain/analytics"
+)
+
+// FeatureUsage emits analytics.EventFeatureUsed for every successful request,
+// identifying the feature by method and route template (e.g.
+// "POST /document/goods-receipt/:id/post") — never by IDs or payload.
+// This should be applied AFTER Settings middleware (the opt-out is a setting).
+func FeatureUsage(emitter *analytics.Emitter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if emitter == nil || c.Request.Method == http.MethodOptions || c.Writer.Status() >= http.StatusBadRequest {
+			return
+		}
+		route := c.FullPath()
+		if route == "" {
+			return
+		}
+		emitter.Track(c.Request.Context(), analytics.EventFeatureUsed, map[string]any{
+			"feature": c.Request.Method + " " + strings.TrimPrefix(route, "/api/v1"),
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"metapus/internal/core/apperror"
 	appctx "metapus/internal/core/context"
+	"metapus/internal/core/security"
 	"metapus/internal/core/tenant"
 )
 
@@ -63,8 +64,10 @@ func Auth(validator JWTValidator) gin.HandlerFunc {
 			return
 		}
 
-		// Add user to context
+		// Add user to context, with its entity permissions for the
+		// service-level checks (security.Authorize)
 		ctx := appctx.WithUser(c.Request.Context(), user)
+		ctx = security.WithScope(ctx, security.NewUserAccessScope(user))
 		c.Request = c.Request.WithContext(ctx)
 
 		// Build permissions set for O(1) lookups in RequirePermission
@@ -104,6 +107,7 @@ func OptionalAuth(validator JWTValidator) gin.HandlerFunc {
 			}
 
 			ctx := appctx.WithUser(c.Request.Context(), user)
+			ctx = security.WithScope(ctx, security.NewUserAccessScope(user))
 			c.Request = c.Request.WithContext(ctx)
 			permSet := make(map[string]struct{}, len(user.Permissions))
 			for _, p := range user.Permissions {
@@ -1,9 +1,14 @@
 package middleware
 
 import (
+	"errors"
+	"strconv"
+	"time"
+
 	"github.com/gin-gonic/gin"
 
 	"metapus/internal/core/apperror"
+	"metapus/internal/core/tenant"
 	"metapus/internal/infrastructure/storage/postgres"
 	"metapus/pkg/logger"
 )
@@ -27,6 +32,13 @@ func ErrorHandler() gin.HandlerFunc {
 			return
 		}
 
+		// A saturated tenant pool surfaces as TENANT_BUSY whatever layer
+		// wrapped the acquisition error.
+		if errors.Is(err, tenant.ErrTenantBusy) {
+			logger.Warn(c.Request.Context(), "tenant database busy", "error", err)
+			err = apperror.NewTenantBusy(time.Second)
+		}
+
 		// Try to extract AppError
 		if appErr, ok := apperror.AsAppError(err); ok {
 			// Log internal error if present
@@ -52,6 +64,9 @@ func ErrorHandler() gin.HandlerFunc {
 				}
 			}
 
+			if seconds, ok := appErr.RetryAfter(); ok {
+				c.Header("Retry-After", strconv.Itoa(seconds))
+			}
 			c.JSON(appErr.HTTPStatus, body)
 			return
 		}
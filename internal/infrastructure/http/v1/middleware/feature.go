@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/tenant"
+)
+
+// RequireFeature blocks requests to a feature the tenant's plan does not
+// include (and no override grants) with 402, so clients can offer an
+// upgrade. This should be applied AFTER TenantDB middleware. Requests
+// without a tenant (single-tenant mode) are let through.
+func RequireFeature(feature tenant.Feature) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		t := tenant.GetTenant(c.Request.Context())
+		if t != nil && !t.HasFeature(feature) {
+			_ = c.Error(apperror.NewPaymentRequired("feature is not included in the subscription").
+				WithDetail("feature", string(feature)).
+				WithDetail("plan", string(t.Plan)))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
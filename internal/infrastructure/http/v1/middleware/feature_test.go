@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/tenant"
+)
+
+func TestRequireFeature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	serve := func(tn *tenant.Tenant, feature tenant.Feature) int {
+		router := gin.New()
+		router.Use(ErrorHandler())
+		router.Use(func(c *gin.Context) {
+			if tn != nil {
+				c.Request = c.Request.WithContext(tenant.WithTenant(c.Request.Context(), tn))
+			}
+		})
+		router.GET("/x", RequireFeature(feature), ok)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/x", nil))
+		return w.Code
+	}
+
+	standard := &tenant.Tenant{Plan: tenant.PlanStandard}
+	premium := &tenant.Tenant{Plan: tenant.PlanPremium}
+	granted := &tenant.Tenant{Plan: tenant.PlanStandard, Settings: map[string]any{
+		tenant.SettingFeatures: map[string]any{"graphql": true, "custom_fields": false},
+	}}
+	unknownPlan := &tenant.Tenant{Plan: "trial"}
+
+	tests := []struct {
+		name    string
+		tenant  *tenant.Tenant
+		feature tenant.Feature
+		want    int
+	}{
+		{"standard plan includes", standard, tenant.FeatureCustomFields, http.StatusNoContent},
+		{"standard plan lacks", standard, tenant.FeatureGraphQL, http.StatusPaymentRequired},
+		{"premium plan includes", premium, tenant.FeatureGraphQL, http.StatusNoContent},
+		{"override grants", granted, tenant.FeatureGraphQL, http.StatusNoContent},
+		{"override withdraws", granted, tenant.FeatureCustomFields, http.StatusPaymentRequired},
+		{"unknown plan is standard", unknownPlan, tenant.FeatureAutomations, http.StatusPaymentRequired},
+		{"single-tenant mode", nil, tenant.FeatureGraphQL, http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		if got := serve(tt.tenant, tt.feature); got != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
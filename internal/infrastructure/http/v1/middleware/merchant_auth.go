@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"metapus/internal/core/apperror"
+	"metapus/internal/core/clock"
 	appctx "metapus/internal/core/context"
 	"metapus/internal/core/id"
 	"metapus/internal/core/tenant"
@@ -117,10 +118,11 @@ func MerchantAPIKey(repo MerchantAPIKeyAuthenticator, manager *tenant.Manager) g
 		defer managedPool.ReleaseRef()
 
 		// Inject TxManager so the repo can operate
-		txManager := postgres.NewTxManagerFromRawPool(managedPool.Pool())
+		txManager := postgres.NewTxManagerFromAcquirer(managedPool)
 		ctx := tenant.WithPool(c.Request.Context(), managedPool.Pool())
 		ctx = tenant.WithTxManager(ctx, txManager)
 		ctx = tenant.WithTenant(ctx, managedPool.Tenant())
+		ctx = clock.WithClock(ctx, managedPool.Tenant().Clock())
 		c.Request = c.Request.WithContext(ctx)
 
 		// Lookup key by hash (hot-path — uses partial index)
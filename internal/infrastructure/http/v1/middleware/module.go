@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/domain/modules"
+)
+
+// RequireModule blocks requests to a module that is disabled for the tenant:
+// 404 as if the routes did not exist, or 402 when the tenant can buy it.
+// This should be applied AFTER TenantDB middleware (flags live in the tenant
+// database). A nil resolver lets every request through.
+func RequireModule(resolver *modules.Resolver, module string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if resolver == nil {
+			c.Next()
+			return
+		}
+		st, err := resolver.Status(c.Request.Context(), module)
+		if err != nil {
+			_ = c.Error(apperror.NewInternal(err))
+			c.Abort()
+			return
+		}
+		if !st.Enabled {
+			if st.PaymentRequired {
+				_ = c.Error(apperror.NewPaymentRequired("module is not included in the subscription").
+					WithDetail("module", module))
+			} else {
+				_ = c.Error(apperror.NewNotFound("module", module))
+			}
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
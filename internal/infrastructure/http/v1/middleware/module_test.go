@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/domain/modules"
+)
+
+type fixedFlags []modules.Flag
+
+func (f fixedFlags) ListModuleFlags(context.Context) ([]modules.Flag, error) { return f, nil }
+
+func TestRequireModule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	resolver := modules.NewResolver(fixedFlags{
+		{Name: modules.FlagName(modules.Crypto), Variant: modules.VariantPaymentRequired},
+		{Name: modules.FlagName(modules.Orders)},
+	})
+
+	router := gin.New()
+	router.Use(ErrorHandler())
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	router.GET("/crypto", RequireModule(resolver, modules.Crypto), ok)
+	router.GET("/orders", RequireModule(resolver, modules.Orders), ok)
+	router.GET("/reports", RequireModule(resolver, "reports"), ok)
+	router.GET("/ungated", RequireModule(nil, modules.Crypto), ok)
+
+	for path, want := range map[string]int{
+		"/crypto":  http.StatusPaymentRequired,
+		"/orders":  http.StatusNotFound,
+		"/reports": http.StatusNoContent,
+		"/ungated": http.StatusNoContent,
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != want {
+			t.Errorf("GET %s = %d, want %d", path, w.Code, want)
+		}
+	}
+}
@@ -2,13 +2,16 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"metapus/internal/core/apperror"
 	appctx "metapus/internal/core/context"
 	"metapus/internal/core/eventlog"
+	"metapus/internal/core/security"
 	"metapus/internal/core/tenant"
 	"metapus/pkg/logger"
 )
@@ -53,37 +56,66 @@ func emitPermissionDenied(c *gin.Context, user *appctx.UserContext, permission s
 
 // RequirePermission middleware checks if user has required permission.
 // Admins automatically have all permissions.
+//
+// A permission granted within a scope only (e.g. "document:goods_issue:post"
+// limited to a warehouse) passes too; the request's DataScope is then
+// narrowed to the grant, so the entity's records outside it stay inaccessible.
 func RequirePermission(permission string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		user := appctx.GetUser(c.Request.Context())
-		if user == nil {
-			_ = c.Error(apperror.NewUnauthorized("authentication required"))
+		ctx, err := CheckPermission(c, permission)
+		if err != nil {
+			_ = c.Error(err)
 			c.Abort()
 			return
 		}
+		c.Request = c.Request.WithContext(ctx)
 
-		// Admins have all permissions
-		if user.IsAdmin {
-			c.Next()
-			return
-		}
+		c.Next()
+	}
+}
 
-		// O(1) lookup via permissions_set built by Auth middleware
-		if _, ok := getPermissionsSet(c)[permission]; !ok {
-			emitPermissionDenied(c, user, permission)
-			_ = c.Error(
-				apperror.NewForbidden("insufficient permissions").
-					WithDetail("required_permission", permission),
-			)
-			c.Abort()
-			return
-		}
+// CheckPermission applies the RequirePermission check inside a handler, for
+// endpoints whose required permission depends on the request (GraphQL).
+// It returns the request context, with the DataScope narrowed for a scoped
+// grant.
+func CheckPermission(c *gin.Context, permission string) (context.Context, error) {
+	ctx := c.Request.Context()
+	user := appctx.GetUser(ctx)
+	if user == nil {
+		return ctx, apperror.NewUnauthorized("authentication required")
+	}
 
-		c.Next()
+	// Admins have all permissions
+	if user.IsAdmin {
+		return ctx, nil
+	}
+
+	// O(1) lookup via permissions_set built by Auth middleware
+	if _, ok := getPermissionsSet(c)[permission]; ok {
+		return ctx, nil
 	}
+	if scope, ok := user.PermissionScopes[permission]; ok {
+		entityName := grantEntityName(permission)
+		return security.WithDataScope(ctx, security.GetDataScope(ctx).Grant(entityName, scope)), nil
+	}
+	emitPermissionDenied(c, user, permission)
+	return ctx, apperror.NewForbidden("insufficient permissions").
+		WithDetail("required_permission", permission)
+}
+
+// grantEntityName returns the entity a permission applies to: the middle
+// segment of "catalog:warehouse:read" or "document:goods_issue:post".
+func grantEntityName(permission string) string {
+	parts := strings.Split(permission, ":")
+	if len(parts) != 3 {
+		return permission
+	}
+	return parts[1]
 }
 
 // RequireAnyPermission middleware checks if user has any of the required permissions.
+// A permission granted within a scope only passes too, without narrowing the
+// DataScope: the handler or service enforcing the actual permission does.
 func RequireAnyPermission(permissions ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user := appctx.GetUser(c.Request.Context())
@@ -106,6 +138,10 @@ func RequireAnyPermission(permissions ...string) gin.HandlerFunc {
 				c.Next()
 				return
 			}
+			if _, ok := user.PermissionScopes[required]; ok {
+				c.Next()
+				return
+			}
 		}
 
 		_ = c.Error(
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	appctx "metapus/internal/core/context"
+	"metapus/internal/core/security"
+)
+
+func TestRequirePermissionScopedGrant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	user := &appctx.UserContext{
+		UserID:      "u-1",
+		Permissions: []string{"document:goods_issue:read"},
+		PermissionScopes: map[string]map[string][]string{
+			"document:goods_issue:post": {"warehouse": {"wh-1"}},
+		},
+	}
+
+	router := gin.New()
+	router.Use(ErrorHandler(), func(c *gin.Context) {
+		c.Request = c.Request.WithContext(appctx.WithUser(c.Request.Context(), user))
+		permSet := make(map[string]struct{}, len(user.Permissions))
+		for _, p := range user.Permissions {
+			permSet[p] = struct{}{}
+		}
+		c.Set("permissions_set", permSet)
+	})
+	var scope *security.DataScope
+	capture := func(c *gin.Context) {
+		scope = security.GetDataScope(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	}
+	router.GET("/read", RequirePermission("document:goods_issue:read"), capture)
+	router.POST("/post", RequirePermission("document:goods_issue:post"), capture)
+	router.POST("/unpost", RequirePermission("document:goods_issue:unpost"), capture)
+	router.POST("/batch", RequireAnyPermission("document:goods_issue:post", "document:goods_issue:delete"), capture)
+	router.POST("/delete", RequireAnyPermission("document:goods_issue:delete", "document:goods_issue:unpost"), capture)
+
+	serve := func(method, path string) int {
+		scope = nil
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w.Code
+	}
+
+	if code := serve(http.MethodGet, "/read"); code != http.StatusNoContent {
+		t.Fatalf("unscoped permission: %d", code)
+	}
+	if !scope.CanAccessRecord("goods_issue", map[string]string{"warehouse": "wh-2"}) {
+		t.Error("unscoped permission narrowed the data scope")
+	}
+
+	if code := serve(http.MethodPost, "/post"); code != http.StatusNoContent {
+		t.Fatalf("scoped permission: %d", code)
+	}
+	if !scope.CanAccessRecord("goods_issue", map[string]string{"warehouse": "wh-1"}) ||
+		scope.CanAccessRecord("goods_issue", map[string]string{"warehouse": "wh-2"}) {
+		t.Errorf("scoped permission: data scope not narrowed to the grant: %+v", scope)
+	}
+
+	if code := serve(http.MethodPost, "/unpost"); code != http.StatusForbidden {
+		t.Errorf("missing permission: %d, want 403", code)
+	}
+
+	if code := serve(http.MethodPost, "/batch"); code != http.StatusNoContent {
+		t.Errorf("any permission, scoped grant: %d", code)
+	}
+	if code := serve(http.MethodPost, "/delete"); code != http.StatusForbidden {
+		t.Errorf("any permission, none granted: %d, want 403", code)
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/tenant"
+	"metapus/pkg/logger"
+)
+
+// ReadsOnly declares a route that only reads although its method is
+// mutating (report runs, exports, previews), so RequireWritableTenant lets
+// it through and TenantDB lets its reads go to the read replica. It does
+// nothing at request time.
+func ReadsOnly() gin.HandlerFunc { return readsOnly }
+
+func readsOnly(c *gin.Context) { c.Next() }
+
+// readOnlyRequest reports whether the request only reads: its method is
+// safe or its route is marked with ReadsOnly.
+func readOnlyRequest(c *gin.Context) bool {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return slices.ContainsFunc(c.HandlerNames(), func(name string) bool {
+		return handlerFunc(name) == "middleware.readsOnly"
+	})
+}
+
+// RequireWritableTenant rejects mutating requests with 423 while the tenant
+// is in read-only mode (tenant.SettingReadOnly). Apply AFTER TenantDB.
+//
+// The mode is read from registry rather than the tenant snapshot of the
+// pool: a cached registry follows changes on all instances, so switching the
+// mode takes effect without reopening pools. Routes marked with ReadsOnly pass.
+func RequireWritableTenant(registry tenant.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		t := tenant.GetTenant(c.Request.Context())
+		if t == nil || readOnlyRequest(c) {
+			c.Next()
+			return
+		}
+
+		current, err := registry.GetByID(c.Request.Context(), t.ID)
+		if err != nil {
+			logger.Warn(c.Request.Context(), "read-only check: registry lookup failed", "tenant_id", t.ID, "error", err)
+			current = t
+		}
+		if mode, ok := current.ReadOnly(); ok {
+			_ = c.Error(apperror.NewTenantReadOnly(mode.Reason).
+				WithDetail("tenant_id", t.ID).
+				WithDetail("since", mode.Since))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
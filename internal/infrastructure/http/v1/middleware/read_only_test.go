@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/core/tenant"
+)
+
+// stubRegistry serves GetByID from a fixed tenant; other methods are not used.
+type stubRegistry struct {
+	tenant.Registry
+	current *tenant.Tenant
+}
+
+func (r stubRegistry) GetByID(context.Context, string) (*tenant.Tenant, error) {
+	if r.current == nil {
+		return nil, errors.New("meta-database unavailable")
+	}
+	return r.current, nil
+}
+
+func TestRequireWritableTenant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	writable := &tenant.Tenant{ID: "t1"}
+	readOnly := &tenant.Tenant{ID: "t1", Settings: map[string]any{
+		tenant.SettingReadOnly: tenant.ReadOnlyMode{Reason: "billing"}.Setting(),
+	}}
+
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	serve := func(snapshot, current *tenant.Tenant, method, path string) int {
+		router := gin.New()
+		router.Use(ErrorHandler())
+		router.Use(func(c *gin.Context) {
+			if snapshot != nil {
+				c.Request = c.Request.WithContext(tenant.WithTenant(c.Request.Context(), snapshot))
+			}
+		})
+		router.Use(RequireWritableTenant(stubRegistry{current: current}))
+		router.GET("/x", ok)
+		router.POST("/x", ok)
+		router.POST("/report", ReadsOnly(), ok)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w.Code
+	}
+
+	tests := []struct {
+		name              string
+		snapshot, current *tenant.Tenant
+		method, path      string
+		want              int
+	}{
+		{"writable tenant", writable, writable, http.MethodPost, "/x", http.StatusNoContent},
+		{"read-only rejects changes", writable, readOnly, http.MethodPost, "/x", http.StatusLocked},
+		{"read-only allows reads", writable, readOnly, http.MethodGet, "/x", http.StatusNoContent},
+		{"read-only allows ReadsOnly routes", writable, readOnly, http.MethodPost, "/report", http.StatusNoContent},
+		{"registry down uses snapshot", readOnly, nil, http.MethodPost, "/x", http.StatusLocked},
+		{"single-tenant mode", nil, readOnly, http.MethodPost, "/x", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		if got := serve(tt.snapshot, tt.current, tt.method, tt.path); got != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
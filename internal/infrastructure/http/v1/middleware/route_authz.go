@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authorization guards by handler name (package.Function). Every mutating
+// route must run at least one of them, see CheckRouteAuthz.
+var _guards = map[string]string{
+	"middleware.RequirePermission":     GuardPermission,
+	"middleware.RequireAnyPermission":  GuardPermission,
+	"middleware.RequireAllPermissions": GuardPermission,
+	"middleware.RequireRole":           GuardRole,
+	"middleware.AdminJWT":              GuardAdminToken,
+	"middleware.RequireMerchantAccess": GuardMerchantAccess,
+	"middleware.RequireMerchantScope":  GuardMerchantScope,
+	"middleware.RequirePortalRole":     GuardPortalRole,
+	"middleware.MerchantPortal":        GuardPortal,
+	"middleware.permitPublic":          GuardPublic,
+	"middleware.permitAuthenticated":   GuardAuthenticated,
+	"middleware.permitSigned":          GuardSigned,
+}
+
+// Guard kinds reported in RouteAuthz.Guards.
+const (
+	GuardPermission     = "permission"
+	GuardRole           = "role"
+	GuardAdminToken     = "admin_token"
+	GuardMerchantAccess = "merchant_access"
+	GuardMerchantScope  = "merchant_scope"
+	GuardPortal         = "portal"
+	GuardPortalRole     = "portal_role"
+	GuardPublic         = "public"
+	GuardAuthenticated  = "authenticated"
+	GuardSigned         = "signed"
+)
+
+// PermitPublic declares a route that anyone may call without credentials
+// (login, the payment page). It does nothing at request time.
+func PermitPublic() gin.HandlerFunc { return permitPublic }
+
+// PermitAuthenticated declares a route that any authenticated user may call:
+// it acts on the caller's own data or checks permissions in the handler.
+// It does nothing at request time.
+func PermitAuthenticated() gin.HandlerFunc { return permitAuthenticated }
+
+// PermitSigned declares a route authorized by a signature or shared secret
+// the handler (or a preceding middleware) verifies.
+// It does nothing at request time.
+func PermitSigned() gin.HandlerFunc { return permitSigned }
+
+func permitPublic(c *gin.Context)        { c.Next() }
+func permitAuthenticated(c *gin.Context) { c.Next() }
+func permitSigned(c *gin.Context)        { c.Next() }
+
+// RouteAuthz is the authorization declared for one route.
+type RouteAuthz struct {
+	Method string   `json:"method"`
+	Path   string   `json:"path"`
+	Guards []string `json:"guards"`
+}
+
+// Mutating reports whether the route changes state by its method.
+func (r RouteAuthz) Mutating() bool {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+type routeProbeKey struct{}
+
+// routeProbe receives the handler chain of the probed route.
+type routeProbe struct {
+	fullPath string
+	handlers []string
+}
+
+// RouteProbe lets DescribeRoutes read handler chains. It must be the first
+// global middleware: on a probe request it records the chain and aborts, so
+// nothing else runs. Probes are marked in the request context, which clients
+// cannot set.
+func RouteProbe() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if p, ok := c.Request.Context().Value(routeProbeKey{}).(*routeProbe); ok {
+			p.fullPath = c.FullPath()
+			p.handlers = c.HandlerNames()
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+var _pathParam = regexp.MustCompile(`[:*][^/]+`)
+
+// DescribeRoutes returns the authorization guards of every route of engine,
+// sorted by path and method. The engine must use RouteProbe.
+func DescribeRoutes(engine *gin.Engine) []RouteAuthz {
+	var routes []RouteAuthz
+	for _, info := range engine.Routes() {
+		probe := &routeProbe{}
+		ctx := context.WithValue(context.Background(), routeProbeKey{}, probe)
+		req, err := http.NewRequestWithContext(ctx, info.Method, _pathParam.ReplaceAllString(info.Path, "_"), nil)
+		if err != nil {
+			continue
+		}
+		engine.ServeHTTP(discardWriter{header: http.Header{}}, req)
+
+		route := RouteAuthz{Method: info.Method, Path: info.Path, Guards: []string{}}
+		if probe.fullPath == info.Path {
+			for _, name := range probe.handlers {
+				if guard, ok := _guards[handlerFunc(name)]; ok && !slices.Contains(route.Guards, guard) {
+					route.Guards = append(route.Guards, guard)
+				}
+			}
+		}
+		routes = append(routes, route)
+	}
+	slices.SortFunc(routes, func(a, b RouteAuthz) int {
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Method, b.Method)
+	})
+	return routes
+}
+
+// CheckRouteAuthz fails when a mutating route has no authorization guard.
+// Routes open on purpose declare it with PermitPublic, PermitAuthenticated
+// or PermitSigned.
+func CheckRouteAuthz(routes []RouteAuthz) error {
+	var unguarded []string
+	for _, r := range routes {
+		if r.Mutating() && len(r.Guards) == 0 {
+			unguarded = append(unguarded, r.Method+" "+r.Path)
+		}
+	}
+	if len(unguarded) > 0 {
+		return fmt.Errorf("mutating routes without authorization: %s", strings.Join(unguarded, ", "))
+	}
+	return nil
+}
+
+// handlerFunc reduces a handler name such as
+// "metapus/internal/infrastructure/http/v1/middleware.RequireRole.func1"
+// to the function that created it ("middleware.RequireRole").
+func handlerFunc(name string) string {
+	name = name[strings.LastIndex(name, "/")+1:]
+	pkg, fn, ok := strings.Cut(name, ".")
+	if !ok {
+		return name
+	}
+	fn, _, _ = strings.Cut(fn, ".")
+	return pkg + "." + fn
+}
+
+// discardWriter is the response writer of probe requests.
+type discardWriter struct{ header http.Header }
+
+func (w discardWriter) Header() http.Header         { return w.header }
+func (w discardWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w discardWriter) WriteHeader(int)             {}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDescribeRoutesFindsGuards(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ran := false
+	r := gin.New()
+	r.Use(RouteProbe())
+	r.Use(func(c *gin.Context) { ran = true; c.Next() })
+
+	admin := r.Group("/admin", RequireRole("admin"))
+	admin.DELETE("/tenants/:id", func(*gin.Context) {})
+	r.POST("/catalog/items", RequirePermission("catalog:item:create"), func(*gin.Context) {})
+	r.POST("/auth/login", PermitPublic(), func(*gin.Context) {})
+	r.PUT("/files/*path", func(*gin.Context) {})
+	r.GET("/catalog/items/:id", func(*gin.Context) {})
+
+	routes := DescribeRoutes(r)
+	if ran {
+		t.Fatal("probe ran middleware after RouteProbe")
+	}
+	guards := map[string][]string{}
+	for _, route := range routes {
+		guards[route.Method+" "+route.Path] = route.Guards
+	}
+	want := map[string][]string{
+		"DELETE /admin/tenants/:id": {GuardRole},
+		"POST /catalog/items":       {GuardPermission},
+		"POST /auth/login":          {GuardPublic},
+		"PUT /files/*path":          {},
+		"GET /catalog/items/:id":    {},
+	}
+	for route, g := range want {
+		if !slices.Equal(guards[route], g) {
+			t.Errorf("%s guards = %v, want %v", route, guards[route], g)
+		}
+	}
+
+	err := CheckRouteAuthz(routes)
+	if err == nil || !strings.Contains(err.Error(), "PUT /files/*path") || strings.Contains(err.Error(), "GET") {
+		t.Fatalf("CheckRouteAuthz = %v, want only the unguarded PUT", err)
+	}
+}
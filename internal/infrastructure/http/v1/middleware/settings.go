@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"metapus/internal/domain/settings"
+)
+
+// Settings injects the scoped settings resolver into the request context,
+// enabling settings.Get in services and handlers.
+// This should be applied AFTER TenantDB middleware (values live in the tenant database).
+func Settings(resolver *settings.Resolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(settings.WithResolver(c.Request.Context(), resolver))
+		c.Next()
+	}
+}
@@ -3,12 +3,15 @@ package middleware
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
 	"metapus/internal/core/apperror"
+	"metapus/internal/core/clock"
 	"metapus/internal/core/tenant"
+	"metapus/internal/core/tx"
 	"metapus/internal/infrastructure/storage/postgres"
 	"metapus/pkg/logger"
 )
@@ -85,17 +88,33 @@ func TenantDB(manager *tenant.Manager) gin.HandlerFunc {
 			return
 		}
 
+		// Shed load while too many requests already wait for a connection
+		// (per-plan threshold, see ManagerConfig.ShedQueueLength).
+		if managedPool.Shed() {
+			logger.Warn(ctx, "tenant pool saturated, request shed", "tenant_id", tenantID)
+			_ = c.Error(apperror.NewTenantBusy(time.Second).WithDetail("tenant_id", tenantID))
+			c.Abort()
+			return
+		}
+
 		// Track active request for graceful shutdown
 		managedPool.AcquireRef()
 		defer managedPool.ReleaseRef()
 
-		// 3. Create TxManager for this request
-		txManager := postgres.NewTxManagerFromRawPool(managedPool.Pool())
+		// 3. Create TxManager for this request (connections are acquired
+		// with the tenant's acquisition timeout)
+		txManager := postgres.NewTxManagerFromAcquirer(managedPool)
 
 		// 4. Inject into context
 		ctx = tenant.WithPool(ctx, managedPool.Pool())
 		ctx = tenant.WithTxManager(ctx, txManager)
 		ctx = tenant.WithTenant(ctx, managedPool.Tenant())
+		ctx = clock.WithClock(ctx, managedPool.Tenant().Clock())
+		// Reads of read-only requests may be served by the tenant's read
+		// replica (see tx.PreferReplica); changes always go to the primary.
+		if readOnlyRequest(c) {
+			ctx = tx.PreferReplica(ctx)
+		}
 
 		c.Request = c.Request.WithContext(ctx)
 
@@ -1,8 +1,17 @@
 package middleware
 
 import (
+	"fmt"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
 
 	appctx "metapus/internal/core/context"
 )
@@ -12,31 +21,59 @@ const (
 	HeaderTraceID   = "X-Trace-ID"
 )
 
+var tracer = otel.Tracer("metapus/http")
+
 // Trace middleware adds request tracing context.
-// Extracts or generates trace IDs for distributed tracing.
+// Starts an OpenTelemetry server span (continuing a W3C traceparent from the
+// caller) and exposes its IDs through appctx.TraceContext. When tracing is
+// disabled and the caller sent no traceparent, the IDs are taken from the
+// X-Trace-ID header or generated.
 func Trace() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		// Span names use the route template, not the path, to stay
+		// low-cardinality; unmatched requests are named by method only.
+		name := c.Request.Method
+		attrs := []attribute.KeyValue{
+			semconv.HTTPRequestMethodKey.String(c.Request.Method),
+			semconv.URLPath(c.Request.URL.Path),
+		}
+		if route := c.FullPath(); route != "" {
+			name += " " + route
+			attrs = append(attrs, semconv.HTTPRoute(route))
+		}
+		ctx, span := tracer.Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attrs...))
+		defer span.End()
+
 		// Get or generate request ID
 		requestID := c.GetHeader(HeaderRequestID)
 		if requestID == "" {
 			requestID = uuid.New().String()
 		}
 
-		// Get or generate trace ID
+		// Trace ID: the span's when there is a real trace, otherwise the
+		// caller's X-Trace-ID or a generated one.
 		traceID := c.GetHeader(HeaderTraceID)
-		if traceID == "" {
+		spanID := uuid.New().String()[:16]
+		if sc := span.SpanContext(); sc.IsValid() {
+			traceID = sc.TraceID().String()
+			spanID = sc.SpanID().String()
+		} else if traceID == "" {
 			traceID = uuid.New().String()
 		}
 
 		// Create trace context
-		trace := &appctx.TraceContext{
+		tc := &appctx.TraceContext{
 			TraceID:   traceID,
-			SpanID:    uuid.New().String()[:16],
+			SpanID:    spanID,
 			RequestID: requestID,
 		}
 
 		// Add to context
-		ctx := appctx.WithTrace(c.Request.Context(), trace)
+		ctx = appctx.WithTrace(ctx, tc)
 		c.Request = c.Request.WithContext(ctx)
 
 		// Store in gin context for easy access
@@ -48,5 +85,14 @@ func Trace() gin.HandlerFunc {
 		c.Header(HeaderTraceID, traceID)
 
 		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", status))
+		}
+		if len(c.Errors) > 0 {
+			span.RecordError(c.Errors.Last())
+		}
 	}
 }
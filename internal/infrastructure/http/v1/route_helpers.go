@@ -65,6 +65,124 @@ type DocumentRelatedDocsHandler interface {
 	GetRelatedDocuments(c *gin.Context)
 }
 
+// DocumentFulfillmentHandler is an optional interface for order documents that
+// track fulfillment by follow-up documents.
+// When a handler implements this interface, RegisterDocumentRoutes automatically adds
+// GET /:id/fulfillment requiring the entity read permission.
+type DocumentFulfillmentHandler interface {
+	GetFulfillment(c *gin.Context)
+}
+
+// DocumentCloseHandler is an optional interface for order documents that can be
+// closed before they are fully fulfilled.
+// When a handler implements this interface, RegisterDocumentRoutes automatically adds
+// POST /:id/close requiring the entity post permission.
+type DocumentCloseHandler interface {
+	Close(c *gin.Context)
+}
+
+// DocumentTemplateHandler is an optional interface for documents that support
+// templates (frequently used document skeletons). When a handler implements this
+// interface, RegisterDocumentRoutes automatically adds GET /templates (read),
+// POST /:id/save-as-template, POST /from-template/:templateId and
+// PUT/DELETE /templates/:templateId (create).
+type DocumentTemplateHandler interface {
+	ListTemplates(c *gin.Context)
+	SaveAsTemplate(c *gin.Context)
+	CreateFromTemplate(c *gin.Context)
+	UpdateTemplate(c *gin.Context)
+	DeleteTemplate(c *gin.Context)
+}
+
+// DocumentBasedOnHandler is an optional interface for documents that can be
+// created based on other documents. When a handler implements this interface,
+// RegisterDocumentRoutes automatically adds POST /based-on/:basisType/:basisId
+// (create); the handler checks the read permission of the basis.
+type DocumentBasedOnHandler interface {
+	CreateBasedOn(c *gin.Context)
+}
+
+// DocumentScheduleHandler is an optional interface for documents that support
+// recurring generation from a template. When a handler implements this
+// interface, RegisterDocumentRoutes automatically adds GET /schedules and
+// GET /schedules/:scheduleId/runs (read), POST /schedules and
+// PUT/DELETE /schedules/:scheduleId (create).
+type DocumentScheduleHandler interface {
+	ListSchedules(c *gin.Context)
+	CreateSchedule(c *gin.Context)
+	UpdateSchedule(c *gin.Context)
+	DeleteSchedule(c *gin.Context)
+	ListScheduleRuns(c *gin.Context)
+}
+
+// AttachmentHandler is an optional interface for documents and catalogs that
+// support attached files. When a handler implements this interface,
+// RegisterCatalogRoutes / RegisterDocumentRoutes automatically add
+// GET /:id/attachments and GET /:id/attachments/:attachmentId (read),
+// POST /:id/attachments and DELETE /:id/attachments/:attachmentId (update).
+type AttachmentHandler interface {
+	ListAttachments(c *gin.Context)
+	UploadAttachment(c *gin.Context)
+	DownloadAttachment(c *gin.Context)
+	DeleteAttachment(c *gin.Context)
+}
+
+// registerAttachmentRoutes adds attachment routes if the handler supports them.
+func registerAttachmentRoutes(group *gin.RouterGroup, handler any, permission string) {
+	attachmentHandler, ok := handler.(AttachmentHandler)
+	if !ok {
+		return
+	}
+	group.GET("/:id/attachments", middleware.RequirePermission(permission+":read"), attachmentHandler.ListAttachments)
+	group.POST("/:id/attachments", middleware.RequirePermission(permission+":update"), attachmentHandler.UploadAttachment)
+	group.GET("/:id/attachments/:attachmentId", middleware.RequirePermission(permission+":read"), attachmentHandler.DownloadAttachment)
+	group.DELETE("/:id/attachments/:attachmentId", middleware.RequirePermission(permission+":update"), attachmentHandler.DeleteAttachment)
+}
+
+// CatalogImageHandler is an optional interface for catalogs with image
+// galleries. When a handler implements this interface, RegisterCatalogRoutes
+// automatically adds GET /:id/images, GET /:id/images/:imageId and
+// GET /:id/images/:imageId/thumbnail (read), POST /:id/images,
+// PUT /:id/images/order, POST /:id/images/:imageId/primary and
+// DELETE /:id/images/:imageId (update).
+type CatalogImageHandler interface {
+	ListImages(c *gin.Context)
+	UploadImage(c *gin.Context)
+	DownloadImage(c *gin.Context)
+	DownloadImageThumbnail(c *gin.Context)
+	DeleteImage(c *gin.Context)
+	ReorderImages(c *gin.Context)
+	SetPrimaryImage(c *gin.Context)
+}
+
+// registerImageRoutes adds image gallery routes if the handler supports them.
+func registerImageRoutes(group *gin.RouterGroup, handler any, permission string) {
+	imageHandler, ok := handler.(CatalogImageHandler)
+	if !ok {
+		return
+	}
+	group.GET("/:id/images", middleware.RequirePermission(permission+":read"), imageHandler.ListImages)
+	group.POST("/:id/images", middleware.RequirePermission(permission+":update"), imageHandler.UploadImage)
+	group.PUT("/:id/images/order", middleware.RequirePermission(permission+":update"), imageHandler.ReorderImages)
+	group.GET("/:id/images/:imageId", middleware.RequirePermission(permission+":read"), imageHandler.DownloadImage)
+	group.GET("/:id/images/:imageId/thumbnail", middleware.RequirePermission(permission+":read"), imageHandler.DownloadImageThumbnail)
+	group.POST("/:id/images/:imageId/primary", middleware.RequirePermission(permission+":update"), imageHandler.SetPrimaryImage)
+	group.DELETE("/:id/images/:imageId", middleware.RequirePermission(permission+":update"), imageHandler.DeleteImage)
+}
+
+// UploadSessionHandler is an optional interface for documents that support
+// bulk attachment uploads. When a handler implements this interface,
+// RegisterDocumentRoutes automatically adds
+// POST /:id/attachments/upload-sessions,
+// GET /:id/attachments/upload-sessions/:sessionId and
+// POST /:id/attachments/upload-sessions/:sessionId/{files,finalize} (all update).
+type UploadSessionHandler interface {
+	CreateUploadSession(c *gin.Context)
+	GetUploadSession(c *gin.Context)
+	AddUploadSessionFiles(c *gin.Context)
+	FinalizeUploadSession(c *gin.Context)
+}
+
 // DocumentMovementsHandlerInterface is an optional interface for documents that support
 // "Movements" (Движения) feature.
 // When a handler implements this interface, RegisterDocumentRoutes automatically adds
@@ -73,21 +191,44 @@ type DocumentMovementsHandlerInterface interface {
 	GetMovements(c *gin.Context)
 }
 
+// DocumentApproveHandler is an optional interface for documents that require approval
+// before posting. When a handler implements this interface, RegisterDocumentRoutes
+// automatically adds POST /:id/approve requiring the entity approve permission.
+type DocumentApproveHandler interface {
+	Approve(c *gin.Context)
+}
+
+// DocumentBoardHandler is an optional interface for the status board of a document type.
+// When a handler implements this interface, RegisterDocumentRoutes automatically adds
+// GET /board requiring the entity read permission and PATCH /:id/state, whose
+// action permission (post, unpost, approve) is checked inside the handler.
+type DocumentBoardHandler interface {
+	GetBoard(c *gin.Context)
+	MoveBoardState(c *gin.Context)
+}
+
 // DocumentBatchHandler is an optional interface for batch operations.
 // When a handler implements this interface, RegisterDocumentRoutes automatically adds
-// POST /batch-action requiring the entity post permission.
+// POST /batch-action requiring the entity post, unpost or delete permission.
 type DocumentBatchHandler interface {
 	BatchAction(c *gin.Context)
 }
 
 // DocumentBatchByFilterHandler is an optional interface for filter-based batch operations.
 // When a handler implements this interface, RegisterDocumentRoutes automatically adds
-// POST /batch-action-by-filter requiring the entity post permission.
+// POST /batch-action-by-filter requiring the entity post, unpost or delete permission.
 // Used for virtual "select all" — the server resolves matching IDs from filters.
 type DocumentBatchByFilterHandler interface {
 	BatchActionByFilter(c *gin.Context)
 }
 
+// DocumentNumberSuggestHandler is an optional interface for suggesting a free document number.
+// When a handler implements this interface, RegisterDocumentRoutes automatically adds
+// GET /next-number requiring the entity create permission.
+type DocumentNumberSuggestHandler interface {
+	SuggestNumber(c *gin.Context)
+}
+
 // ListExportHandler is an optional interface for exporting a list to XLSX.
 // When a handler implements this interface, RegisterCatalogRoutes / RegisterDocumentRoutes
 // automatically adds POST /export-list requiring the entity read permission.
@@ -95,6 +236,66 @@ type ListExportHandler interface {
 	ExportList(c *gin.Context)
 }
 
+// CatalogImportHandler is an optional interface for bulk import from CSV/XLSX.
+// When a handler implements this interface, RegisterCatalogRoutes automatically
+// adds POST /import requiring the entity create permission.
+type CatalogImportHandler interface {
+	Import(c *gin.Context)
+}
+
+// CatalogBarcodeHandler is an optional interface for catalogs of barcoded
+// items. When a handler implements this interface, RegisterCatalogRoutes
+// automatically adds GET /by-barcode/:code and POST /labels requiring the
+// entity read permission.
+type CatalogBarcodeHandler interface {
+	FindByBarcode(c *gin.Context)
+	PrintLabels(c *gin.Context)
+}
+
+// CatalogDefaultAccountHandler is an optional interface for catalogs of
+// counterparty accounts. When a handler implements this interface,
+// RegisterCatalogRoutes automatically adds GET /default?counterpartyId=
+// requiring the entity read permission.
+type CatalogDefaultAccountHandler interface {
+	GetDefaultAccount(c *gin.Context)
+}
+
+// CatalogRequisitesHandler is an optional interface for catalogs filled from
+// a requisites lookup by INN. When a handler implements this interface,
+// RegisterCatalogRoutes automatically adds GET /requisites?inn= requiring the
+// entity create or update permission.
+type CatalogRequisitesHandler interface {
+	LookupRequisites(c *gin.Context)
+}
+
+// CatalogContactHandler is an optional interface for catalogs with contact
+// persons. When a handler implements this interface, RegisterCatalogRoutes
+// automatically adds GET /contacts (search by phone/email) and
+// GET /:id/contacts (read), POST /:id/contacts,
+// PUT /:id/contacts/:contactId and DELETE /:id/contacts/:contactId (update).
+type CatalogContactHandler interface {
+	FindContacts(c *gin.Context)
+	ListContacts(c *gin.Context)
+	AddContact(c *gin.Context)
+	UpdateContact(c *gin.Context)
+	DeleteContact(c *gin.Context)
+}
+
+// CatalogBatchHandler is an optional interface for batch create/update/delete.
+// When a handler implements this interface, RegisterCatalogRoutes automatically
+// adds POST /batch; the handler requires the permission of every operation
+// present in the batch.
+type CatalogBatchHandler interface {
+	Batch(permission string) gin.HandlerFunc
+}
+
+// SyncPushHandler is an optional interface for applying edits of offline clients.
+// When a handler implements this interface, RegisterCatalogRoutes / RegisterDocumentRoutes
+// automatically adds POST /sync/push requiring the entity update permission.
+type SyncPushHandler interface {
+	PushSyncChanges(c *gin.Context)
+}
+
 // RegisterCatalogRoutes registers standard CRUD routes for a catalog.
 // This eliminates the need to manually wire up routes for each catalog.
 //
@@ -115,8 +316,62 @@ func RegisterCatalogRoutes(group *gin.RouterGroup, handler CatalogRouteHandler,
 
 	// Register ExportList route if handler supports it (optional)
 	if exportHandler, ok := handler.(ListExportHandler); ok {
-		group.POST("/export-list", middleware.RequirePermission(permission+":read"), exportHandler.ExportList)
+		group.POST("/export-list", middleware.RequirePermission(permission+":read"), middleware.ReadsOnly(), exportHandler.ExportList)
+	}
+
+	// Register Import route if handler supports it (optional)
+	if importHandler, ok := handler.(CatalogImportHandler); ok {
+		group.POST("/import", middleware.RequirePermission(permission+":create"), importHandler.Import)
+	}
+
+	// Register barcode lookup and label routes if handler supports them (optional)
+	if barcodeHandler, ok := handler.(CatalogBarcodeHandler); ok {
+		group.GET("/by-barcode/:code", middleware.RequirePermission(permission+":read"), barcodeHandler.FindByBarcode)
+		group.POST("/labels", middleware.RequirePermission(permission+":read"), barcodeHandler.PrintLabels)
+	}
+
+	// Register default account route if handler supports it (optional)
+	if accountHandler, ok := handler.(CatalogDefaultAccountHandler); ok {
+		group.GET("/default", middleware.RequirePermission(permission+":read"), accountHandler.GetDefaultAccount)
+	}
+
+	// Register requisites lookup route if handler supports it (optional)
+	if requisitesHandler, ok := handler.(CatalogRequisitesHandler); ok {
+		requisitesGuard := middleware.RequireAnyPermission(permission+":create", permission+":update")
+		group.GET("/requisites", requisitesGuard, requisitesHandler.LookupRequisites)
+	}
+
+	// Register contact person routes if handler supports them (optional)
+	if contactHandler, ok := handler.(CatalogContactHandler); ok {
+		group.GET("/contacts", middleware.RequirePermission(permission+":read"), contactHandler.FindContacts)
+		group.GET("/:id/contacts", middleware.RequirePermission(permission+":read"), contactHandler.ListContacts)
+		group.POST("/:id/contacts", middleware.RequirePermission(permission+":update"), contactHandler.AddContact)
+		group.PUT("/:id/contacts/:contactId", middleware.RequirePermission(permission+":update"), contactHandler.UpdateContact)
+		group.DELETE("/:id/contacts/:contactId", middleware.RequirePermission(permission+":update"), contactHandler.DeleteContact)
+	}
+
+	// Register Batch route if handler supports it (optional); the handler
+	// checks the permission of each operation in the batch
+	if batchHandler, ok := handler.(CatalogBatchHandler); ok {
+		batchGuard := middleware.RequireAnyPermission(permission+":create", permission+":update", permission+":delete")
+		group.POST("/batch", batchGuard, batchHandler.Batch(permission))
+	}
+
+	// Register Sync Push route if handler supports it (optional)
+	if syncHandler, ok := handler.(SyncPushHandler); ok {
+		group.POST("/sync/push", middleware.RequirePermission(permission+":update"), syncHandler.PushSyncChanges)
+	}
+
+	// Register NextNumber route if handler supports it (optional)
+	if numberHandler, ok := handler.(DocumentNumberSuggestHandler); ok {
+		group.GET("/next-number", middleware.RequirePermission(permission+":create"), numberHandler.SuggestNumber)
 	}
+
+	// Register Attachment routes if handler supports them (optional)
+	registerAttachmentRoutes(group, handler, permission)
+
+	// Register image gallery routes if handler supports them (optional)
+	registerImageRoutes(group, handler, permission)
 }
 
 // RegisterDocumentRoutes registers standard CRUD + posting routes for a document.
@@ -149,6 +404,11 @@ func RegisterDocumentRoutes(group *gin.RouterGroup, handler DocumentRouteHandler
 		group.PUT("/:id/repost", middleware.RequirePermission(permission+":post"), repostHandler.UpdateAndRepost)
 	}
 
+	// Register Sync Push route if handler supports it (optional)
+	if syncHandler, ok := handler.(SyncPushHandler); ok {
+		group.POST("/sync/push", middleware.RequirePermission(permission+":update"), syncHandler.PushSyncChanges)
+	}
+
 	// Register Print route if handler supports it (optional)
 	if printHandler, ok := handler.(DocumentPrintHandlerInterface); ok {
 		group.GET("/:id/print", middleware.RequirePermission(permission+":read"), printHandler.Print)
@@ -164,25 +424,82 @@ func RegisterDocumentRoutes(group *gin.RouterGroup, handler DocumentRouteHandler
 		group.GET("/:id/related-documents", middleware.RequirePermission(permission+":read"), relatedHandler.GetRelatedDocuments)
 	}
 
+	// Register Fulfillment route if handler supports it (optional)
+	if fulfillmentHandler, ok := handler.(DocumentFulfillmentHandler); ok {
+		group.GET("/:id/fulfillment", middleware.RequirePermission(permission+":read"), fulfillmentHandler.GetFulfillment)
+	}
+
+	// Register Close route if handler supports it (optional)
+	if closeHandler, ok := handler.(DocumentCloseHandler); ok {
+		group.POST("/:id/close", middleware.RequirePermission(permission+":post"), closeHandler.Close)
+	}
+
+	// Register Template routes if handler supports them (optional)
+	if templateHandler, ok := handler.(DocumentTemplateHandler); ok {
+		group.GET("/templates", middleware.RequirePermission(permission+":read"), templateHandler.ListTemplates)
+		group.PUT("/templates/:templateId", middleware.RequirePermission(permission+":create"), templateHandler.UpdateTemplate)
+		group.DELETE("/templates/:templateId", middleware.RequirePermission(permission+":create"), templateHandler.DeleteTemplate)
+		group.POST("/:id/save-as-template", middleware.RequirePermission(permission+":create"), templateHandler.SaveAsTemplate)
+		group.POST("/from-template/:templateId", middleware.RequirePermission(permission+":create"), templateHandler.CreateFromTemplate)
+	}
+
+	// Register Based-On route if handler supports it (optional)
+	if basedOnHandler, ok := handler.(DocumentBasedOnHandler); ok {
+		group.POST("/based-on/:basisType/:basisId", middleware.RequirePermission(permission+":create"), basedOnHandler.CreateBasedOn)
+	}
+
+	// Register recurring Schedule routes if handler supports them (optional)
+	if scheduleHandler, ok := handler.(DocumentScheduleHandler); ok {
+		group.GET("/schedules", middleware.RequirePermission(permission+":read"), scheduleHandler.ListSchedules)
+		group.POST("/schedules", middleware.RequirePermission(permission+":create"), scheduleHandler.CreateSchedule)
+		group.PUT("/schedules/:scheduleId", middleware.RequirePermission(permission+":create"), scheduleHandler.UpdateSchedule)
+		group.DELETE("/schedules/:scheduleId", middleware.RequirePermission(permission+":create"), scheduleHandler.DeleteSchedule)
+		group.GET("/schedules/:scheduleId/runs", middleware.RequirePermission(permission+":read"), scheduleHandler.ListScheduleRuns)
+	}
+
 	// Register Movements route if handler supports it (optional)
 	if movHandler, ok := handler.(DocumentMovementsHandlerInterface); ok {
 		group.GET("/:id/movements", middleware.RequirePermission(permission+":read"), movHandler.GetMovements)
 	}
 
+	// Register Approve route if handler supports it (optional)
+	if approveHandler, ok := handler.(DocumentApproveHandler); ok {
+		group.POST("/:id/approve", middleware.RequirePermission(permission+":approve"), approveHandler.Approve)
+	}
+
+	// Register Board routes if handler supports them (optional).
+	// PATCH /:id/state — action permission checked inside handler.
+	if boardHandler, ok := handler.(DocumentBoardHandler); ok {
+		group.GET("/board", middleware.RequirePermission(permission+":read"), boardHandler.GetBoard)
+		group.PATCH("/:id/state", middleware.RequirePermission(permission+":read"), boardHandler.MoveBoardState)
+	}
+
 	// Register BatchAction route if handler supports it (optional).
 	// Mounted on /batch-action (no :id) — permission checked per-action inside handler.
+	batchGuard := middleware.RequireAnyPermission(permission+":post", permission+":unpost", permission+":delete")
 	if batchHandler, ok := handler.(DocumentBatchHandler); ok {
-		group.POST("/batch-action", middleware.RequirePermission(permission+":post"), batchHandler.BatchAction)
+		group.POST("/batch-action", batchGuard, batchHandler.BatchAction)
 	}
 
 	// Register BatchActionByFilter route if handler supports it (optional).
 	// Used for virtual "select all" — the server resolves matching IDs from filters.
 	if batchFilterHandler, ok := handler.(DocumentBatchByFilterHandler); ok {
-		group.POST("/batch-action-by-filter", middleware.RequirePermission(permission+":post"), batchFilterHandler.BatchActionByFilter)
+		group.POST("/batch-action-by-filter", batchGuard, batchFilterHandler.BatchActionByFilter)
 	}
 
 	// Register ExportList route if handler supports it (optional)
 	if exportHandler, ok := handler.(ListExportHandler); ok {
-		group.POST("/export-list", middleware.RequirePermission(permission+":read"), exportHandler.ExportList)
+		group.POST("/export-list", middleware.RequirePermission(permission+":read"), middleware.ReadsOnly(), exportHandler.ExportList)
+	}
+
+	// Register Attachment routes if handler supports them (optional)
+	registerAttachmentRoutes(group, handler, permission)
+
+	// Register Upload Session routes if handler supports them (optional)
+	if sessionHandler, ok := handler.(UploadSessionHandler); ok {
+		group.POST("/:id/attachments/upload-sessions", middleware.RequirePermission(permission+":update"), sessionHandler.CreateUploadSession)
+		group.GET("/:id/attachments/upload-sessions/:sessionId", middleware.RequirePermission(permission+":update"), sessionHandler.GetUploadSession)
+		group.POST("/:id/attachments/upload-sessions/:sessionId/files", middleware.RequirePermission(permission+":update"), sessionHandler.AddUploadSessionFiles)
+		group.POST("/:id/attachments/upload-sessions/:sessionId/finalize", middleware.RequirePermission(permission+":update"), sessionHandler.FinalizeUploadSession)
 	}
 }
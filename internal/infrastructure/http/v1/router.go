@@ -2,6 +2,7 @@
 package v1
 
 import (
+	"context"
 	"crypto/subtle"
 	"net/http"
 	"os"
@@ -12,32 +13,58 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	appctx "metapus/internal/core/context"
-	"metapus/internal/core/entity"
 	"metapus/internal/core/eventlog"
+	"metapus/internal/core/jobs"
 	"metapus/internal/core/numerator"
 	"metapus/internal/core/security"
 	"metapus/internal/core/tenant"
 	"metapus/internal/domain"
+	"metapus/internal/domain/accountexport"
+	"metapus/internal/domain/accountimport"
+	"metapus/internal/domain/analytics"
+	"metapus/internal/domain/artifact"
+	"metapus/internal/domain/audit"
 	"metapus/internal/domain/auth"
+	"metapus/internal/domain/catalogs/counterparty"
 	"metapus/internal/domain/catalogs/merchant"
 	"metapus/internal/domain/catalogs/wallet"
 	"metapus/internal/domain/crypto"
+	"metapus/internal/domain/deltasync"
 	"metapus/internal/domain/documents"
 	"metapus/internal/domain/documents/crypto_invoice"
+	"metapus/internal/domain/documents/goods_issue"
+	"metapus/internal/domain/documents/goods_receipt"
+	"metapus/internal/domain/intercompany"
+	"metapus/internal/domain/attachment"
+	"metapus/internal/domain/gallery"
+	"metapus/internal/domain/cascadedelete"
+	"metapus/internal/domain/doctemplate"
+	"metapus/internal/domain/recurring"
 	"metapus/internal/domain/listview"
+	"metapus/internal/domain/modules"
+	"metapus/internal/domain/notifications"
+	"metapus/internal/domain/period"
 	"metapus/internal/domain/posting"
 	"metapus/internal/domain/printing"
 	"metapus/internal/domain/registers/cost"
 	"metapus/internal/domain/registers/crypto_balance"
 	"metapus/internal/domain/registers/crypto_fee"
 	"metapus/internal/domain/registers/crypto_merchant_balance"
+	"metapus/internal/domain/registers/customer_order"
 	"metapus/internal/domain/registers/exchange_rate"
 	"metapus/internal/domain/registers/settlement"
 	"metapus/internal/domain/registers/stock"
+	"metapus/internal/domain/registers/stock_reservation"
+	"metapus/internal/domain/registers/serial_number"
+	"metapus/internal/domain/registers/supplier_order"
 	"metapus/internal/domain/reports/compiler"
 	"metapus/internal/domain/reports/variants"
+	"metapus/internal/domain/graphql"
+	"metapus/internal/domain/reposting"
 	"metapus/internal/domain/search"
 	"metapus/internal/domain/security_profile"
+	"metapus/internal/domain/settings"
+	"metapus/internal/domain/webhooks"
 	"metapus/internal/infrastructure/cache"
 	"metapus/internal/infrastructure/http/v1/handlers"
 	"metapus/internal/infrastructure/http/v1/middleware"
@@ -45,6 +72,7 @@ import (
 	"metapus/internal/infrastructure/storage/postgres/auth_repo"
 	"metapus/internal/infrastructure/storage/postgres/catalog_repo"
 	"metapus/internal/infrastructure/storage/postgres/crypto_repo"
+	"metapus/internal/infrastructure/storage/postgres/document_repo"
 	"metapus/internal/infrastructure/storage/postgres/migration"
 	"metapus/internal/infrastructure/storage/postgres/portal_repo"
 	"metapus/internal/infrastructure/storage/postgres/register_repo"
@@ -117,9 +145,37 @@ type RouterConfig struct {
 	// Created in main.go, backed by meta-database.
 	MigrationStateStore tenant.MigrationStateStore
 
+	// TenantAudit is the meta-level audit trail of admin tenant actions
+	// (optional). Enables GET /admin/tenants/audit.
+	TenantAudit tenant.AuditLog
+
+	// AdminTokens validates control-plane admin tokens (optional).
+	// If set, the /control-plane tenant management API is registered.
+	AdminTokens middleware.AdminTokenValidator
+
+	// TenantDefaults is the placement of tenants created via the
+	// control-plane API without an explicit one.
+	TenantDefaults tenant.Placement
+
 	// WSTicketStore for WebSocket ticket-based authentication.
 	WSTicketStore *auth.WSTicketStore
 
+	// Mailer is the tenant-aware transactional mailer (optional).
+	// Enables the email settings test-send endpoint.
+	Mailer auth.Mailer
+
+	// NotificationEmails queues notification emails (optional).
+	// Enables "awaiting approval" emails of documents.
+	NotificationEmails *notifications.Emails
+
+	// NotificationInbox creates in-app notifications (optional).
+	// Enables "document posted" and "awaiting approval" notifications.
+	NotificationInbox *notifications.Inbox
+
+	// NotificationBroker fans out notification changes (optional).
+	// Enables the /notifications/stream SSE endpoint.
+	NotificationBroker *notifications.Broker
+
 	// MerchantAPIKeyRepo enables the /merchant/v1/ public API.
 	// If set, the merchant invoice routes are registered with API-key auth.
 	MerchantAPIKeyRepo merchant.APIKeyRepository
@@ -135,6 +191,91 @@ type RouterConfig struct {
 	// PortalDashboardRepo provides portal dashboard queries (scope-filtered).
 	// If set, the /portal/v1/ routes are registered.
 	PortalDashboardRepo *portal_repo.DashboardRepo
+
+	// SettingsResolver resolves scoped setting values (optional).
+	// If nil, a resolver without NOTIFY invalidation (TTL only) is created.
+	SettingsResolver *settings.Resolver
+
+	// Modules resolves which modules (crypto, orders) are enabled per tenant.
+	// If nil, a resolver without NOTIFY invalidation (TTL only) is created.
+	Modules *modules.Resolver
+
+	// AccountExportSigner signs account export download links.
+	// If set, the /system/account-export routes are registered.
+	AccountExportSigner *accountexport.URLSigner
+
+	// AttachmentStore holds attached file contents (local directory or S3).
+	// If set, attachment routes are registered for documents and catalogs.
+	AttachmentStore attachment.BlobStore
+
+	// AttachmentLimits restricts attachment uploads (size, MIME types).
+	// Zero values fall back to attachment defaults.
+	AttachmentLimits attachment.Limits
+
+	// UploadSigner signs upload paths of attachment upload sessions served by
+	// the API (used when AttachmentStore cannot presign URLs).
+	UploadSigner *attachment.UploadSigner
+
+	// ArtifactStore holds generated files — report exports, backups
+	// (local directory or S3). If set, the /system/artifacts routes are registered.
+	ArtifactStore artifact.BlobStore
+
+	// ArtifactSigner signs artifact download links served by the API
+	// (used when ArtifactStore cannot presign URLs). Required with ArtifactStore.
+	ArtifactSigner *artifact.URLSigner
+
+	// CascadeDeleteSigner signs confirmation tokens of cascade deletion of
+	// marked documents. If set, the cascade preview/purge routes are registered.
+	CascadeDeleteSigner *cascadedelete.TokenSigner
+
+	// SearchIndex is the external full-text index (OpenSearch) used by global
+	// search for tenants with search.backend = opensearch (optional).
+	SearchIndex search.Index
+
+	// Analytics emits anonymized product analytics events (feature usage,
+	// report runs). Nil disables analytics.
+	Analytics *analytics.Emitter
+
+	// RequisitesProvider looks up company requisites by INN (DaData) for the
+	// counterparty requisites endpoint (optional).
+	RequisitesProvider counterparty.RequisitesProvider
+}
+
+// attachmentServiceSetter is implemented by catalog and document handlers
+// that support attached files.
+type attachmentServiceSetter interface {
+	SetAttachmentService(*attachment.Service)
+}
+
+// newAttachmentService returns nil when no blob store is configured.
+func newAttachmentService(cfg RouterConfig) *attachment.Service {
+	if cfg.AttachmentStore == nil {
+		return nil
+	}
+	return attachment.NewService(postgres.NewAttachmentRepo(), cfg.AttachmentStore, cfg.AttachmentLimits)
+}
+
+// imageServiceSetter is implemented by catalog handlers with image galleries.
+type imageServiceSetter interface {
+	SetImageService(*gallery.Service)
+}
+
+// newImageService returns nil when no blob store is configured. Images are
+// stored in the attachment blob store.
+func newImageService(cfg RouterConfig) *gallery.Service {
+	if cfg.AttachmentStore == nil {
+		return nil
+	}
+	return gallery.NewService(postgres.NewImageRepo(), cfg.AttachmentStore, 0)
+}
+
+// newUploadSessionService returns nil when no blob store is configured.
+func newUploadSessionService(cfg RouterConfig) *attachment.SessionService {
+	svc := newAttachmentService(cfg)
+	if svc == nil {
+		return nil
+	}
+	return attachment.NewSessionService(svc, postgres.NewUploadSessionRepo(), cfg.UploadSigner)
 }
 
 // NewRouter creates and configures the Gin router for multi-tenant architecture.
@@ -153,7 +294,15 @@ func NewRouter(cfg RouterConfig) *gin.Engine {
 	// Wire event logging for permission middleware
 	middleware.SetPermissionEventWriter(eventLogRepo)
 
+	if cfg.SettingsResolver == nil {
+		cfg.SettingsResolver = settings.NewResolver(postgres.NewSettingValuesRepo())
+	}
+	if cfg.Modules == nil {
+		cfg.Modules = modules.NewResolver(postgres.NewModuleFlagsRepo())
+	}
+
 	// Global middleware (order matters!)
+	router.Use(middleware.RouteProbe()) // first: probes must not run anything else
 	router.Use(middleware.CORS())
 	router.Use(middleware.Recovery(eventLogRepo))
 	router.Use(middleware.Trace())
@@ -190,6 +339,7 @@ func NewRouter(cfg RouterConfig) *gin.Engine {
 
 	// Build metadata registry from factories (auto-registration)
 	reg := metadata.NewRegistry()
+	var metaHandler *handlers.MetadataHandler
 
 	// API v1
 	v1 := router.Group("/api/v1")
@@ -213,14 +363,19 @@ func NewRouter(cfg RouterConfig) *gin.Engine {
 
 		// Protected endpoints - TenantDB runs first, then Auth
 		protected := v1.Group("")
-		protected.Use(middleware.TenantDB(cfg.TenantManager)) // 1. Resolve tenant, get DB pool
-		protected.Use(middleware.Auth(cfg.JWTValidator))      // 2. Validate JWT
-		protected.Use(middleware.RequireActiveTenant())       // 3. Block business requests for migration_failed
+		protected.Use(middleware.TenantDB(cfg.TenantManager))                            // 1. Resolve tenant, get DB pool
+		protected.Use(middleware.Auth(cfg.JWTValidator))                                 // 2. Validate JWT
+		protected.Use(middleware.RequireActiveTenant())                                  // 3. Block business requests for migration_failed
+		protected.Use(middleware.RequireWritableTenant(cfg.TenantManager.GetRegistry())) // 4. Block changes of read-only tenants
 		// Security profiles are mandatory — fail-fast if misconfigured.
 		if cfg.ProfileProvider == nil {
 			panic("v1.NewRouter: cfg.ProfileProvider must not be nil — security profiles are required for DataScope")
 		}
 		protected.Use(middleware.SecurityContext(cfg.ProfileProvider))
+		protected.Use(middleware.Settings(cfg.SettingsResolver))
+		if cfg.Analytics != nil {
+			protected.Use(middleware.FeatureUsage(cfg.Analytics))
+		}
 
 		// Apply idempotency middleware for mutating operations
 		if cfg.IdempotencyEnabled {
@@ -248,32 +403,73 @@ func NewRouter(cfg RouterConfig) *gin.Engine {
 		registerDocumentRoutes(protected, cfg, factoryReg, reg, eventLogRepo)
 		registerRegisterRoutes(protected, cfg, factoryReg)
 		reportCompiler := registerReportRoutes(protected, cfg, factoryReg, reg)
-		registerMetaRoutes(protected, reg, cfg.SchemaCache)
+		metaHandler = registerMetaRoutes(protected, reg, cfg.SchemaCache, cfg.Modules)
 		registerRefResolverRoutes(protected, reg)
 		registerUserPrefsRoutes(protected)
 		registerListViewRoutes(protected)
-		registerSettingsRoutes(protected)
+		registerSettingsRoutes(protected, cfg)
 		registerSecurityRoutes(protected, cfg)
+		registerAuditRoutes(protected)
 
 		// WebSocket group — TenantDB only, no JWT (ticket-based auth in handler).
 		// Must be separate from `protected` to avoid Auth middleware blocking the upgrade.
 		wsGroup := v1.Group("")
 		wsGroup.Use(middleware.TenantDB(cfg.TenantManager))
 
-		registerSystemRoutes(protected, wsGroup, eventLogRepo, cfg.SchemaCache, reg, cfg.WSTicketStore, reportCompiler)
+		registerSystemRoutes(protected, wsGroup, eventLogRepo, cfg.SchemaCache, reg, cfg.WSTicketStore, cfg.NotificationBroker, reportCompiler)
 
 		// Global data search (Ctrl+K) — available to all authenticated users.
 		// Must be registered after entity routes so metadata.Registry is populated.
 		searchSvc := search.NewService(reg)
 		searchHandler := handlers.NewGlobalSearchHandler(searchSvc)
 		protected.GET("/search", searchHandler.Search)
+		if cfg.SearchIndex != nil {
+			searchSvc.SetIndex(cfg.SearchIndex)
+			// Full index rebuild (e.g. after switching search.backend) — executed by the worker.
+			protected.POST("/search/reindex", middleware.RequireRole("admin"), searchHandler.Reindex)
+		}
+
+		// GraphQL over catalogs and documents — schema generated from the registry,
+		// entity permissions are checked per query field.
+		graphqlHandler := handlers.NewGraphQLHandler(graphql.NewService(graphql.NewSchema(reg), postgres.NewGraphQLLoader()))
+		graphqlGroup := protected.Group("/graphql", middleware.RequireFeature(tenant.FeatureGraphQL))
+		graphqlGroup.GET("", graphqlHandler.Query)
+		graphqlGroup.POST("", middleware.PermitAuthenticated(), middleware.ReadsOnly(), graphqlHandler.Query)
+		graphqlGroup.GET("/schema", graphqlHandler.Schema)
 
 		// Entity preview (Command Palette → ArrowRight) — single entity preview card.
 		previewHandler := handlers.NewEntityPreviewHandler(searchSvc)
 		protected.GET("/search/preview", previewHandler.Preview)
 
 		// Stateless XLSX renderer for document table parts (no entity binding needed).
-		protected.POST("/export-table-part", handlers.ExportTablePart)
+		protected.POST("/export-table-part", middleware.PermitAuthenticated(), middleware.ReadsOnly(), handlers.ExportTablePart)
+
+		// Full account export (admin) + signed download links (TenantDB only, no JWT).
+		if cfg.AccountExportSigner != nil {
+			registerAccountExportRoutes(protected, v1, cfg)
+		}
+		registerAccountImportRoutes(protected)
+
+		// Webhook delivery log (admin); subscriptions are the webhook-subscriptions catalog.
+		registerWebhookRoutes(protected)
+
+		// Two-phase deletion of marked documents with their dependents (admin).
+		if cfg.CascadeDeleteSigner != nil {
+			registerCascadeDeleteRoutes(protected, cfg, reg)
+		}
+
+		// Generated files (admin) + signed download links (TenantDB only, no JWT).
+		if cfg.ArtifactStore != nil {
+			registerArtifactRoutes(protected, v1, cfg)
+		}
+
+		// Signed upload paths of attachment upload sessions (TenantDB only, no JWT).
+		if cfg.AttachmentStore != nil {
+			uploads := v1.Group("/uploads")
+			uploads.Use(middleware.TenantDB(cfg.TenantManager))
+			uploads.Use(middleware.RequireWritableTenant(cfg.TenantManager.GetRegistry()))
+			uploads.PUT("/:fileId", middleware.PermitSigned(), handlers.NewUploadHandler(handlers.NewBaseHandler(), newUploadSessionService(cfg)).Upload)
+		}
 	}
 
 	// Admin tenant management (Cloud Control Plane) — separate group with Auth,
@@ -284,6 +480,12 @@ func NewRouter(cfg RouterConfig) *gin.Engine {
 	adminAuthGroup.Use(middleware.Auth(cfg.JWTValidator))
 	registerAdminTenantRoutes(adminAuthGroup, cfg, cfg.MigrationStateStore, healthHandler)
 
+	// Control-plane API — the tenant CLI over HTTP, authenticated by its own
+	// admin token instead of a tenant JWT, so it needs no tenant at all.
+	if cfg.AdminTokens != nil {
+		registerControlPlaneRoutes(v1.Group("/control-plane", middleware.AdminJWT(cfg.AdminTokens)), cfg, cfg.MigrationStateStore, healthHandler)
+	}
+
 	// Internal endpoints for Updater Agent (shared secret — defense-in-depth beyond network isolation)
 	registerInternalUpdaterRoutes(internal, cfg, cfg.MigrationStateStore)
 
@@ -294,6 +496,14 @@ func NewRouter(cfg RouterConfig) *gin.Engine {
 		registerMerchantPublicRoutes(router, cfg)
 	}
 
+	// Every mutating route must declare how it is authorized: a permission or
+	// role guard, or an explicit middleware.Permit* marker.
+	routes := middleware.DescribeRoutes(router)
+	if err := middleware.CheckRouteAuthz(routes); err != nil {
+		panic("v1.NewRouter: " + err.Error())
+	}
+	metaHandler.SetRoutes(routes)
+
 	return router
 }
 
@@ -323,6 +533,7 @@ func registerAuthRoutes(rg *gin.RouterGroup, cfg RouterConfig, eventWriter event
 	publicAuth := rg.Group("/auth")
 	publicAuth.Use(middleware.TenantDB(cfg.TenantManager))
 	publicAuth.Use(middleware.RateLimit(1, 5)) // 1 req/sec sustained, burst 5
+	publicAuth.Use(middleware.PermitPublic())
 
 	// Protected auth endpoints (JWT required)
 	protectedAuth := rg.Group("/auth")
@@ -343,24 +554,27 @@ func registerCatalogRoutes(rg *gin.RouterGroup, cfg RouterConfig, factoryReg *Fa
 		PolicyEngine:             cfg.PolicyEngine,
 		EventWriter:              eventWriter,
 		CurrencyCacheInvalidator: currencyInvalidator,
+		EventPublisher:           postgres.NewEventPublisher(),
+		RequisitesProvider:       cfg.RequisitesProvider,
 	}
 
 	// Build refEndpoints from factory declarations
-	refEndpoints := map[string]string{
-		"parent": "", // parent is self-referencing, skip
-	}
-	for _, factory := range factoryReg.Catalogs() {
-		if rp, ok := factory.(platform.ReferenceProvider); ok {
-			for _, refType := range rp.ReferenceTypes() {
-				refEndpoints[refType] = "/catalog/" + factory.RoutePrefix()
-			}
-		}
-	}
+	refEndpoints := catalogRefEndpoints(factoryReg)
 
 	// Iterate over registered catalog factories
+	attachmentSvc := newAttachmentService(cfg)
+	imageSvc := newImageService(cfg)
+	syncHandler := handlers.NewSyncHandler(deltasync.NewService(postgres.NewSyncRepo()))
 	for _, factory := range factoryReg.Catalogs() {
 		handler := factory.Build(deps)
-		RegisterCatalogRoutes(catalogs.Group("/"+factory.RoutePrefix()), handler, factory.Permission())
+		if ah, ok := handler.(attachmentServiceSetter); ok && attachmentSvc != nil {
+			ah.SetAttachmentService(attachmentSvc)
+		}
+		if ih, ok := handler.(imageServiceSetter); ok && imageSvc != nil {
+			ih.SetImageService(imageSvc)
+		}
+		group := moduleGate(catalogs.Group("/"+factory.RoutePrefix()), cfg, factory)
+		RegisterCatalogRoutes(group, handler, factory.Permission())
 
 		// Register reference mappings: refType → entityName (optional)
 		if rp, ok := factory.(platform.ReferenceProvider); ok {
@@ -369,64 +583,48 @@ func registerCatalogRoutes(rg *gin.RouterGroup, cfg RouterConfig, factoryReg *Fa
 			}
 		}
 
-		// Auto-register metadata (optional: Inspectable, Presentable)
-		var def metadata.EntityDef
-		if insp, ok := factory.(platform.Inspectable); ok {
-			def = metadata.Inspect(insp.EntityStruct(), factory.EntityName(), metadata.TypeCatalog)
-		} else {
-			def = metadata.EntityDef{Name: factory.EntityName(), Type: metadata.TypeCatalog}
-		}
-		if pres, ok := factory.(platform.Presentable); ok {
-			def.Presentation = pres.EntityPresentation()
-		}
-		if tp, ok := factory.(platform.TableNameProvider); ok {
-			def.TableName = tp.TableName()
-		} else {
-			// Derive table name from convention: cat_{routePrefix} (e.g. cat_counterparties)
-			def.TableName = "cat_" + strings.ReplaceAll(factory.RoutePrefix(), "-", "_")
-		}
-		def.Key = deriveEntityKey(factory.Permission())
-		def.RoutePrefix = factory.RoutePrefix()
-		def.SetRefEndpoints(refEndpoints)
-		if rls, ok := factory.(platform.RLSProvider); ok {
-			def.RLSDimensions = rls.RLSDimensions()
-		}
-		if sf, ok := factory.(platform.SearchFieldsProvider); ok {
-			fields := sf.SearchableFields()
-			def.SearchColumns = &metadata.SearchColumns{
-				SearchCols:  fields.SearchCols,
-				TitleCol:    fields.TitleCol,
-				SubtitleCol: fields.SubtitleCol,
-			}
-		}
+		// Auto-register metadata (optional interfaces, see catalogEntityDef)
+		def := catalogEntityDef(factory, refEndpoints)
 		reg.Register(def)
+		group.GET("/sync", middleware.RequirePermission(factory.Permission()+":read"), syncHandler.Pull(def))
 	}
 }
 
-// registerDocumentRoutes registers document endpoints via the Abstract Factory registry.
-// Each document type is wired by its DocumentRegistration (see document_factory.go).
-// Also populates the metadata registry.
-func registerDocumentRoutes(rg *gin.RouterGroup, cfg RouterConfig, factoryReg *FactoryRegistry, reg *metadata.Registry, eventWriter eventlog.Writer) {
-	docsGroup := rg.Group("/document")
+// moduleGate blocks the routes of group while the module of factory
+// (platform.ModuleMember) is disabled for the tenant.
+func moduleGate(group *gin.RouterGroup, cfg RouterConfig, factory any) *gin.RouterGroup {
+	if mm, ok := factory.(platform.ModuleMember); ok {
+		group.Use(middleware.RequireModule(cfg.Modules, mm.Module()))
+	}
+	return group
+}
 
+// newDocumentPostingEngine assembles the posting engine shared by all document
+// types: default register recorders plus crypto, reservation and order registers.
+// If injected is non-nil, the extra visitors and recorders are added to it.
+func newDocumentPostingEngine(injected *posting.Engine) *posting.Engine {
 	stockRepo := register_repo.NewStockRepo()
 	stockSvc := stock.NewService(stockRepo)
+	stockSvc.SetFreezeChecker(stock.NewInventoryService(register_repo.NewStockInventoryRepo()))
 	costRepo := register_repo.NewCostRepo()
 	costSvc := cost.NewService(costRepo)
 	settlementRepo := register_repo.NewSettlementRepo()
 	settlementSvc := settlement.NewService(settlementRepo)
 
 	// Use injected PostingEngine or create default
-	postingEngine := cfg.PostingEngine
+	postingEngine := injected
 	if postingEngine == nil {
 		docLocker := postgres.NewDocLocker()
 		recorders := posting.DefaultRecorders(stockSvc, costSvc, settlementSvc)
 		postingEngine = posting.NewEngine(docLocker, recorders...)
 	}
+	// Closed periods: documents dated before the lock date of their
+	// organization cannot be posted, unposted, changed or deleted
+	postingEngine.SetPeriodGuard(period.NewService(postgres.NewPeriodLockRepo()))
 
 	// ── Crypto register visitors + recorders ───────────────────────────
 	// These extend the posting engine to handle CryptoPayment/CryptoWithdrawal/CryptoSweep.
-	// Services are declared at function scope so they can also serve as MovementProviders.
+	// Their recorders also expose the registers to the document "Movements" view.
 	cryptoBalSvc := crypto_balance.NewService(register_repo.NewCryptoBalanceRepo())
 	cryptoFeeSvc := crypto_fee.NewService(register_repo.NewCryptoFeeRepo())
 	cryptoMerchantSvc := crypto_merchant_balance.NewService(register_repo.NewCryptoMerchantBalanceRepo())
@@ -439,6 +637,50 @@ func registerDocumentRoutes(rg *gin.RouterGroup, cfg RouterConfig, factoryReg *F
 	postingEngine.AddRecorder(posting.NewCryptoFeeRecorder(cryptoFeeSvc))
 	postingEngine.AddRecorder(posting.NewCryptoMerchantBalanceRecorder(cryptoMerchantSvc))
 
+	// ── Stock reservations ─────────────────────────────────────────────
+	// SalesOrder reserves goods, GoodsIssue based on an order releases them.
+	// StockRecorder reads the collected reservations to exclude the order's own.
+	stockReservationSvc := stock_reservation.NewService(register_repo.NewStockReservationRepo())
+	postingEngine.AddVisitor(&posting.StockReservationVisitor{})
+	postingEngine.AddRecorder(posting.NewStockReservationRecorder(stockReservationSvc))
+
+	// ── Purchase orders ────────────────────────────────────────────────
+	// PurchaseOrder records ordered goods, GoodsReceipt lines linked to
+	// order lines record received goods and refresh the order status.
+	supplierOrderSvc := supplier_order.NewService(register_repo.NewSupplierOrderRepo())
+	postingEngine.AddVisitor(&posting.PurchaseOrderVisitor{})
+	postingEngine.AddRecorder(posting.NewPurchaseOrderRecorder(supplierOrderSvc))
+
+	// ── Sales orders ───────────────────────────────────────────────────
+	// SalesOrder records ordered goods, GoodsIssue lines linked to order
+	// lines record shipped goods and refresh the order status.
+	customerOrderSvc := customer_order.NewService(register_repo.NewCustomerOrderRepo())
+	postingEngine.AddVisitor(&posting.SalesOrderVisitor{})
+	postingEngine.AddRecorder(posting.NewSalesOrderRecorder(customerOrderSvc))
+
+	// ── Serial numbers ─────────────────────────────────────────────────
+	// GoodsReceipt and GoodsIssue lines of items tracked by serial number
+	// receive and issue their serials; availability is checked on posting.
+	serialNumberSvc := serial_number.NewService(register_repo.NewSerialNumberRepo())
+	postingEngine.AddVisitor(&posting.SerialNumberVisitor{})
+	postingEngine.AddRecorder(posting.NewSerialNumberRecorder(serialNumberSvc))
+
+	// ── Stock movement events ──────────────────────────────────────────
+	// Publishes the stock movements of each posting as a stock.moved domain
+	// event for external consumers (see internal/core/events).
+	postingEngine.AddRecorder(posting.NewStockEventRecorder(postgres.NewEventPublisher()))
+
+	return postingEngine
+}
+
+// registerDocumentRoutes registers document endpoints via the Abstract Factory registry.
+// Each document type is wired by its DocumentRegistration (see document_factory.go).
+// Also populates the metadata registry.
+func registerDocumentRoutes(rg *gin.RouterGroup, cfg RouterConfig, factoryReg *FactoryRegistry, reg *metadata.Registry, eventWriter eventlog.Writer) {
+	docsGroup := rg.Group("/document")
+
+	postingEngine := newDocumentPostingEngine(cfg.PostingEngine)
+
 	// CurrencyResolver is guaranteed non-nil here — created in NewRouter before catalog/document registration.
 	currencyResolver := cfg.CurrencyResolver
 
@@ -456,71 +698,110 @@ func registerDocumentRoutes(rg *gin.RouterGroup, cfg RouterConfig, factoryReg *F
 		PolicyEngine:     cfg.PolicyEngine,
 		EventWriter:      eventWriter,
 		OutboxPublisher:  postgres.NewOutboxPublisher(),
+		EventPublisher:   postgres.NewEventPublisher(),
 		PrintRegistry:    printRegistry,
 		PrintRenderer:    printRenderer,
 		RelatedDocFinder: postgres.NewRelatedDocRepo(reg),
-		MovementProviders: []entity.MovementProvider{
-			stockSvc,
-			costSvc,
-			settlementSvc,
-			cryptoBalSvc,
-			cryptoFeeSvc,
-			cryptoMerchantSvc,
-		},
+		// Every register the engine records to is listed in the document "Movements" view.
+		MovementProviders:        postingEngine.MovementProviders(),
 		MovementRefResolver:      postgres.NewRefResolverRepo(reg),
 		SettingsRepo:             postgres.NewSettingsRepo(),
 		CurrencyMetadataResolver: cfg.CurrencyMetadataResolver,
+		CurrencyConverter:        newCurrencyConverter(),
+		NotificationEmails:       cfg.NotificationEmails,
+		NotificationInbox:        cfg.NotificationInbox,
 	}
 
 	// Build refEndpoints from catalog factories for document metadata
-	refEndpoints := map[string]string{
-		"parent": "",
-	}
-	for _, factory := range factoryReg.Catalogs() {
-		if rp, ok := factory.(platform.ReferenceProvider); ok {
-			for _, refType := range rp.ReferenceTypes() {
-				refEndpoints[refType] = "/catalog/" + factory.RoutePrefix()
-			}
-		}
-	}
+	refEndpoints := catalogRefEndpoints(factoryReg)
 
 	// Iterate over registered document factories
+	templateSvc := doctemplate.NewService(postgres.NewDocTemplateRepo())
+	recurringSvc := recurring.NewService(postgres.NewRecurringRepo(), templateSvc)
+	attachmentSvc := newAttachmentService(cfg)
+	uploadSessionSvc := newUploadSessionService(cfg)
+	syncHandler := handlers.NewSyncHandler(deltasync.NewService(postgres.NewSyncRepo()))
 	for _, factory := range factoryReg.Documents() {
 		handler := factory.Build(deps)
-		RegisterDocumentRoutes(docsGroup.Group("/"+factory.RoutePrefix()), handler, factory.Permission())
-
-		// Auto-register metadata (optional: Inspectable, Presentable)
-		var def metadata.EntityDef
-		if insp, ok := factory.(platform.Inspectable); ok {
-			def = metadata.Inspect(insp.EntityStruct(), factory.EntityName(), metadata.TypeDocument)
-		} else {
-			def = metadata.EntityDef{Name: factory.EntityName(), Type: metadata.TypeDocument}
-		}
-		if pres, ok := factory.(platform.Presentable); ok {
-			def.Presentation = pres.EntityPresentation()
+		if th, ok := handler.(interface {
+			SetTemplateService(*doctemplate.Service)
+		}); ok {
+			th.SetTemplateService(templateSvc)
 		}
-		def.Key = deriveEntityKey(factory.Permission())
-		def.RoutePrefix = factory.RoutePrefix()
-		if tp, ok := factory.(platform.TableNameProvider); ok {
-			def.TableName = tp.TableName()
-		} else {
-			// Derive table name from convention: doc_{entityKey}s (e.g. doc_goods_receipts)
-			def.TableName = "doc_" + def.Key + "s"
+		if rh, ok := handler.(interface {
+			SetRecurringService(*recurring.Service)
+		}); ok {
+			rh.SetRecurringService(recurringSvc)
 		}
-		def.SetRefEndpoints(refEndpoints)
-		if rls, ok := factory.(platform.RLSProvider); ok {
-			def.RLSDimensions = rls.RLSDimensions()
+		if ah, ok := handler.(attachmentServiceSetter); ok && attachmentSvc != nil {
+			ah.SetAttachmentService(attachmentSvc)
 		}
-		if sf, ok := factory.(platform.SearchFieldsProvider); ok {
-			fields := sf.SearchableFields()
-			def.SearchColumns = &metadata.SearchColumns{
-				SearchCols:  fields.SearchCols,
-				TitleCol:    fields.TitleCol,
-				SubtitleCol: fields.SubtitleCol,
-			}
+		if uh, ok := handler.(interface {
+			SetUploadSessionService(*attachment.SessionService)
+		}); ok && uploadSessionSvc != nil {
+			uh.SetUploadSessionService(uploadSessionSvc)
 		}
+		group := moduleGate(docsGroup.Group("/"+factory.RoutePrefix()), cfg, factory)
+		RegisterDocumentRoutes(group, handler, factory.Permission())
+
+		// Auto-register metadata (optional interfaces, see documentEntityDef)
+		def := documentEntityDef(factory, refEndpoints)
 		reg.Register(def)
+		group.GET("/sync", middleware.RequirePermission(factory.Permission()+":read"), syncHandler.Pull(def))
 	}
+
+	registerIntercompanyRoutes(docsGroup.Group("/intercompany-transfer"), deps)
+}
+
+// registerIntercompanyRoutes registers intercompany transfers. The goods
+// issue and goods receipt services are built the same way as in their
+// document registrations (minus the order-line checks: transfer documents
+// have no order basis).
+func registerIntercompanyRoutes(group *gin.RouterGroup, deps DocumentDeps) {
+	issues := goods_issue.NewService(document_repo.NewGoodsIssueRepo(), deps.PostingEngine, deps.Numerator, nil, deps.CurrencyResolver)
+	issues.SetPolicyEngine(deps.PolicyEngine)
+	issues.SetCurrencyConverter(deps.CurrencyConverter)
+	issues.Hooks().OnBeforeCreate(func(ctx context.Context, doc *goods_issue.GoodsIssue) error {
+		audit.EnrichCreatedByDirect(ctx, &doc.CreatedBy, &doc.UpdatedBy)
+		return nil
+	})
+
+	receipts := goods_receipt.NewService(document_repo.NewGoodsReceiptRepo(), deps.PostingEngine, deps.Numerator, nil, deps.CurrencyResolver)
+	receipts.SetPolicyEngine(deps.PolicyEngine)
+	receipts.SetCurrencyConverter(deps.CurrencyConverter)
+	receipts.Hooks().OnBeforeCreate(func(ctx context.Context, doc *goods_receipt.GoodsReceipt) error {
+		audit.EnrichCreatedByDirect(ctx, &doc.CreatedBy, &doc.UpdatedBy)
+		return nil
+	})
+
+	domain.RegisterDocumentEvents(issues.Hooks(), "goods_issue", deps.EventPublisher)
+	domain.RegisterDocumentEvents(receipts.Hooks(), "goods_receipt", deps.EventPublisher)
+
+	svc := intercompany.NewService(
+		domain.Chain[*goods_issue.GoodsIssue](
+			domain.WithLogging[*goods_issue.GoodsIssue]("goods-issue"),
+			domain.WithEventLog[*goods_issue.GoodsIssue]("goods_issue", deps.EventWriter),
+			domain.WithOutboxEvents[*goods_issue.GoodsIssue]("goods_issue", deps.OutboxPublisher, deps.CurrencyMetadataResolver),
+		)(issues),
+		domain.Chain[*goods_receipt.GoodsReceipt](
+			domain.WithLogging[*goods_receipt.GoodsReceipt]("goods-receipt"),
+			domain.WithEventLog[*goods_receipt.GoodsReceipt]("goods_receipt", deps.EventWriter),
+			domain.WithOutboxEvents[*goods_receipt.GoodsReceipt]("goods_receipt", deps.OutboxPublisher, deps.CurrencyMetadataResolver),
+		)(receipts),
+		postgres.NewIntercompanyRepo(),
+		deps.Numerator,
+	)
+	handler := handlers.NewIntercompanyHandler(deps.BaseHandler, svc)
+
+	// Both sides are created, so both document permissions are required.
+	group.POST("",
+		middleware.RequirePermission("document:goods_issue:create"),
+		middleware.RequirePermission("document:goods_receipt:create"),
+		handler.Create)
+	group.GET("/:ref/check",
+		middleware.RequirePermission("document:goods_issue:read"),
+		middleware.RequirePermission("document:goods_receipt:read"),
+		handler.Check)
 }
 
 // registerRegisterRoutes registers accumulation register endpoints via the factory registry.
@@ -532,21 +813,27 @@ func registerRegisterRoutes(rg *gin.RouterGroup, cfg RouterConfig, factoryReg *F
 }
 
 // registerMetaRoutes registers metadata/schema endpoints.
-func registerMetaRoutes(rg *gin.RouterGroup, reg *metadata.Registry, schemaCache *cache.SchemaCache) {
+func registerMetaRoutes(rg *gin.RouterGroup, reg *metadata.Registry, schemaCache *cache.SchemaCache, moduleResolver *modules.Resolver) *handlers.MetadataHandler {
 	handler := handlers.NewMetadataHandler(reg, schemaCache)
+	handler.SetModules(moduleResolver)
 	meta := rg.Group("/meta")
 	{
 		meta.GET("", handler.ListEntities)
 		meta.GET("/entities", handler.ListEntitiesSummary)
+		meta.GET("/version", handler.Version)
+		meta.GET("/feature-flags", handler.ListFeatureFlags)
+		meta.GET("/features", handler.ListFeatures)
+		meta.GET("/routes", middleware.RequireRole("admin"), handler.ListRoutes)
 		meta.GET("/:name", handler.GetEntity)
 		meta.GET("/:name/mock", handler.GetEntityMock)
 		meta.GET("/:name/filters", handler.GetEntityFilters)
 	}
+	return handler
 }
 
 // registerReportRoutes registers report endpoints via the factory registry.
 // All reports use the Dataset-based Query Engine.
-func registerReportRoutes(rg *gin.RouterGroup, _ RouterConfig, factoryReg *FactoryRegistry, reg *metadata.Registry) *compiler.Compiler {
+func registerReportRoutes(rg *gin.RouterGroup, cfg RouterConfig, factoryReg *FactoryRegistry, reg *metadata.Registry) *compiler.Compiler {
 	reportsGroup := rg.Group("/reports")
 
 	datasets := factoryReg.Datasets()
@@ -556,7 +843,8 @@ func registerReportRoutes(rg *gin.RouterGroup, _ RouterConfig, factoryReg *Facto
 
 	baseHandler := handlers.NewBaseHandler()
 	comp := compiler.NewCompiler(reg, datasets)
-	dsHandler := handlers.NewDatasetReportHandler(baseHandler, comp, reg)
+	comp.SetBudget(compiler.NewBudget(compiler.DefaultPlanLimits, compiler.DefaultQueueWait))
+	dsHandler := handlers.NewDatasetReportHandler(baseHandler, comp, reg, cfg.Analytics)
 
 	variantRepo := postgres.NewReportVariantRepo()
 	variantSvc := variants.NewService(variantRepo)
@@ -567,17 +855,18 @@ func registerReportRoutes(rg *gin.RouterGroup, _ RouterConfig, factoryReg *Facto
 		group.Use(middleware.RequirePermission(ds.Permission))
 		{
 			group.GET("/metadata", dsHandler.HandleMeta(ds.Key))
-			group.POST("", dsHandler.HandleExecute)
-			group.POST("/export", dsHandler.HandleExport(ds.Key))
-			group.POST("/grouped", dsHandler.HandleGrouped(ds.Key))
+			group.POST("", middleware.ReadsOnly(), dsHandler.HandleExecute)
+			group.POST("/export", middleware.ReadsOnly(), dsHandler.HandleExport(ds.Key))
+			group.POST("/grouped", middleware.ReadsOnly(), dsHandler.HandleGrouped(ds.Key))
 
 			group.GET("/variants", variantHandler.GetList(ds.Key))
 		}
 	}
 
-	reportsGroup.POST("/variants", variantHandler.Create)
-	reportsGroup.PUT("/variants/:id", variantHandler.Update)
-	reportsGroup.DELETE("/variants/:id", variantHandler.Delete)
+	// Variants are owned by their author; the variants service checks ownership.
+	reportsGroup.POST("/variants", middleware.PermitAuthenticated(), variantHandler.Create)
+	reportsGroup.PUT("/variants/:id", middleware.PermitAuthenticated(), variantHandler.Update)
+	reportsGroup.DELETE("/variants/:id", middleware.PermitAuthenticated(), variantHandler.Delete)
 
 	// Mount metadata under /metadata/reports/{key} for discoverability
 	metaGroup := rg.Group("/metadata/reports")
@@ -594,7 +883,7 @@ func registerReportRoutes(rg *gin.RouterGroup, _ RouterConfig, factoryReg *Facto
 func registerRefResolverRoutes(rg *gin.RouterGroup, reg *metadata.Registry) {
 	resolver := postgres.NewRefResolverRepo(reg)
 	handler := handlers.NewRefResolverHandler(resolver)
-	rg.POST("/resolve-refs", handler.ResolveRefs)
+	rg.POST("/resolve-refs", middleware.PermitAuthenticated(), middleware.ReadsOnly(), handler.ResolveRefs)
 }
 
 // registerSecurityRoutes registers security profile and CEL policy rule management endpoints.
@@ -602,7 +891,7 @@ func registerSecurityRoutes(rg *gin.RouterGroup, cfg RouterConfig) {
 	profileRepo := security_repo.NewProfileRepo()
 
 	// Audit service (best-effort — handler works without it)
-	auditSvc, _ := postgres.NewAuditService()
+	auditSvc, _ := postgres.DefaultAuditService()
 
 	profileHandler := handlers.NewSecurityProfileHandler(profileRepo, auditSvc, cfg.ProfileProvider)
 
@@ -621,6 +910,11 @@ func registerSecurityRoutes(rg *gin.RouterGroup, cfg RouterConfig) {
 		secGroup.POST("/profiles/:profileId/users", profileHandler.AssignUser)
 		secGroup.DELETE("/profiles/:profileId/users/:userId", profileHandler.RemoveUser)
 
+		// Role assignment to profiles (fallback for users without a direct profile)
+		secGroup.GET("/profiles/:profileId/roles", profileHandler.ListProfileRoles)
+		secGroup.POST("/profiles/:profileId/roles", profileHandler.AssignRole)
+		secGroup.DELETE("/profiles/:profileId/roles/:roleId", profileHandler.RemoveRole)
+
 		// Audit history
 		secGroup.GET("/profiles/:profileId/audit", profileHandler.GetAuditHistory)
 
@@ -630,8 +924,8 @@ func registerSecurityRoutes(rg *gin.RouterGroup, cfg RouterConfig) {
 			policyRuleHandler := handlers.NewPolicyRuleHandler(policyRuleRepo, cfg.PolicyEngine, cfg.ProfileProvider)
 
 			// CEL expression validation and testing (no profile context needed)
-			secGroup.POST("/rules/validate", policyRuleHandler.ValidateExpression)
-			secGroup.POST("/rules/test", policyRuleHandler.TestExpression)
+			secGroup.POST("/rules/validate", middleware.ReadsOnly(), policyRuleHandler.ValidateExpression)
+			secGroup.POST("/rules/test", middleware.ReadsOnly(), policyRuleHandler.TestExpression)
 
 			// Profile-scoped rule CRUD
 			rulesGroup := secGroup.Group("/profiles/:profileId/rules")
@@ -646,9 +940,23 @@ func registerSecurityRoutes(rg *gin.RouterGroup, cfg RouterConfig) {
 	}
 }
 
-// registerSystemRoutes registers system administration endpoints (event log, custom fields, processing).
+// registerAuditRoutes registers the audit trail of entity changes (admin only).
+// Entries are recorded by the base catalog and document repositories.
+func registerAuditRoutes(rg *gin.RouterGroup) {
+	auditSvc, err := postgres.DefaultAuditService()
+	if err != nil {
+		return
+	}
+	auditHandler := handlers.NewAuditLogHandler(auditSvc)
+
+	auditGroup := rg.Group("/audit")
+	auditGroup.Use(middleware.RequireRole("admin"))
+	auditGroup.GET("", auditHandler.List)
+}
+
+// registerSystemRoutes registers system administration endpoints (event log, custom fields, unique rules, processing).
 // wsGroup is a separate group with TenantDB but without Auth middleware — used for ticket-based WebSocket auth.
-func registerSystemRoutes(rg *gin.RouterGroup, wsGroup *gin.RouterGroup, eventLogReader eventlog.Reader, schemaCache *cache.SchemaCache, reg *metadata.Registry, wsTicketStore *auth.WSTicketStore, reportCompiler *compiler.Compiler) {
+func registerSystemRoutes(rg *gin.RouterGroup, wsGroup *gin.RouterGroup, eventLogReader eventlog.Reader, schemaCache *cache.SchemaCache, reg *metadata.Registry, wsTicketStore *auth.WSTicketStore, notificationBroker *notifications.Broker, reportCompiler *compiler.Compiler) {
 	sysGroup := rg.Group("/system")
 	sysGroup.Use(middleware.RequireRole("admin"))
 
@@ -663,7 +971,7 @@ func registerSystemRoutes(rg *gin.RouterGroup, wsGroup *gin.RouterGroup, eventLo
 	// Custom field schema management (sys_custom_field_schemas)
 	customFieldRepo := postgres.NewCustomFieldRepo()
 	customFieldHandler := handlers.NewCustomFieldHandler(handlers.NewBaseHandler(), customFieldRepo, schemaCache)
-	cfGroup := sysGroup.Group("/custom-fields")
+	cfGroup := sysGroup.Group("/custom-fields", middleware.RequireFeature(tenant.FeatureCustomFields))
 	{
 		cfGroup.GET("", customFieldHandler.List)
 		cfGroup.POST("", customFieldHandler.Create)
@@ -672,17 +980,39 @@ func registerSystemRoutes(rg *gin.RouterGroup, wsGroup *gin.RouterGroup, eventLo
 		cfGroup.DELETE("/:id", customFieldHandler.Delete)
 	}
 
+	// Period closing (sys_period_locks)
+	periodLockHandler := handlers.NewPeriodLockHandler(handlers.NewBaseHandler(), period.NewService(postgres.NewPeriodLockRepo()))
+	{
+		sysGroup.GET("/period-locks", periodLockHandler.List)
+		sysGroup.POST("/period-locks/:organizationId/close", periodLockHandler.Close)
+		sysGroup.POST("/period-locks/:organizationId/reopen", periodLockHandler.Reopen)
+	}
+
+	// Declarative uniqueness rules (sys_unique_rules → partial unique indexes)
+	uniqueRuleHandler := handlers.NewUniqueRuleHandler(handlers.NewBaseHandler(), postgres.NewUniqueRuleRepo(reg))
+	urGroup := sysGroup.Group("/unique-rules", middleware.RequireFeature(tenant.FeatureUniqueRules))
+	{
+		urGroup.GET("", uniqueRuleHandler.List)
+		urGroup.POST("", uniqueRuleHandler.Create)
+		urGroup.GET("/:id", uniqueRuleHandler.Get)
+		urGroup.DELETE("/:id", uniqueRuleHandler.Delete)
+	}
+
 	// Notifications & Real-Time Hub
 	notificationRepo := postgres.NewNotificationRepo()
-	notifHandler := handlers.NewNotificationHandler(notificationRepo, wsTicketStore)
+	notifHandler := handlers.NewNotificationHandler(notificationRepo, wsTicketStore, notificationBroker)
 
 	// WebSockets — ticket-based auth, bypasses JWT middleware.
 	// Registered on wsGroup (TenantDB only, no Auth) so the handler's
 	// own ValidateTicket() is the sole authentication gate.
 	wsGroup.GET("/ws", notifHandler.ServeWS)
+	// Server-Sent Events — same ticket auth (EventSource cannot send headers).
+	if notificationBroker != nil {
+		wsGroup.GET("/notifications/stream", notifHandler.Stream)
+	}
 
 	// REST API for notifications (under /api/v1/system/notifications)
-	notifUserGroup := rg.Group("/system/notifications")
+	notifUserGroup := rg.Group("/system/notifications", middleware.PermitAuthenticated()) // the caller's own
 	notifUserGroup.GET("", notifHandler.List)
 	notifUserGroup.PUT("/mark-all-read", notifHandler.MarkAllAsRead) // static before /:id
 	notifUserGroup.PUT("/:id/read", notifHandler.MarkAsRead)
@@ -700,29 +1030,32 @@ func registerSystemRoutes(rg *gin.RouterGroup, wsGroup *gin.RouterGroup, eventLo
 	sysGroup.GET("/marked-objects", markedHandler.List)
 	sysGroup.POST("/marked-objects/delete", markedHandler.Delete)
 
+	// Admin Automations (plan feature)
+	automationGroup := sysGroup.Group("", middleware.RequireFeature(tenant.FeatureAutomations))
+
 	// Admin Automations: Accounts (replaces old Service Accounts)
 	automationAccountRepo := postgres.NewAutomationAccountRepo()
 	automationAccountHandler := handlers.NewAutomationAccountHandler(handlers.NewBaseHandler(), automationAccountRepo, automationAccountRepo)
-	automationAccountHandler.RegisterRoutes(sysGroup)
+	automationAccountHandler.RegisterRoutes(automationGroup)
 
 	// Admin Automations: Channels
 	automationChannelRepo := postgres.NewAutomationChannelRepo()
 	automationChannelHandler := handlers.NewAutomationChannelHandler(handlers.NewBaseHandler(), automationChannelRepo)
-	automationChannelHandler.RegisterRoutes(sysGroup)
+	automationChannelHandler.RegisterRoutes(automationGroup)
 
 	// Admin Automations: Rules
 	automationRuleRepo := postgres.NewAutomationRuleRepo()
 	automationRuleHandler := handlers.NewAutomationRuleHandler(handlers.NewBaseHandler(), automationRuleRepo)
-	automationRuleHandler.RegisterRoutes(sysGroup)
+	automationRuleHandler.RegisterRoutes(automationGroup)
 
 	// Admin Automations: History
 	automationHistoryRepo := postgres.NewAutomationHistoryRepo()
 	automationHistoryHandler := handlers.NewAutomationHistoryHandler(handlers.NewBaseHandler(), automationHistoryRepo)
-	automationHistoryHandler.RegisterRoutes(sysGroup)
+	automationHistoryHandler.RegisterRoutes(automationGroup)
 
 	// Admin Automations: Meta (enum values for UI)
 	automationMetaHandler := handlers.NewAutomationMetaHandler(handlers.NewBaseHandler(), reportCompiler)
-	automationMetaHandler.RegisterRoutes(sysGroup)
+	automationMetaHandler.RegisterRoutes(automationGroup)
 
 	// Fee Schedule — global defaults (system admin)
 	feeScheduleRepo := crypto_repo.NewFeeScheduleRepo()
@@ -738,6 +1071,81 @@ func registerSystemRoutes(rg *gin.RouterGroup, wsGroup *gin.RouterGroup, eventLo
 	workerJobRepo := postgres.NewWorkerJobReadRepo()
 	workerJobHandler := handlers.NewWorkerJobHandler(workerJobRepo)
 	workerJobHandler.RegisterRoutes(sysGroup)
+
+	// Background job queue and dead letters (/system/jobs)
+	jobRepo := postgres.NewJobRepo()
+	handlers.NewJobHandler(jobRepo).RegisterRoutes(sysGroup)
+
+	// Job schedules (/system/schedules)
+	jobScheduleService := jobs.NewScheduleService(postgres.NewJobScheduleRepo(), jobs.NewQueue(jobRepo))
+	handlers.NewJobScheduleHandler(jobScheduleService).RegisterRoutes(sysGroup)
+
+	// Document re-posting tool (/system/repost-runs), executed by the worker
+	repostService := reposting.NewService(postgres.NewRepostRunRepo(), postgres.NewRepostDocumentSource(reg), jobs.NewQueue(jobRepo))
+	handlers.NewRepostHandler(handlers.NewBaseHandler(), repostService).RegisterRoutes(sysGroup)
+}
+
+// registerAccountExportRoutes registers account export endpoints.
+// The download route lives outside the protected group: the link signature
+// (bound to tenant, export and expiry) replaces the JWT.
+func registerAccountExportRoutes(protected, public *gin.RouterGroup, cfg RouterConfig) {
+	repo := postgres.NewAccountExportRepo()
+	svc := accountexport.NewService(repo, postgres.NewAccountDataSource(), cfg.AccountExportSigner)
+	handler := handlers.NewAccountExportHandler(handlers.NewBaseHandler(), svc)
+
+	sysGroup := protected.Group("/system")
+	sysGroup.Use(middleware.RequireRole("admin"), middleware.RequireFeature(tenant.FeatureAccountExport))
+	handler.RegisterRoutes(sysGroup)
+
+	download := public.Group("/account-export")
+	download.Use(middleware.TenantDB(cfg.TenantManager))
+	download.GET("/:id/download", handler.Download)
+}
+
+// registerArtifactRoutes registers artifact endpoints. Like account export
+// downloads, the download route lives outside the protected group.
+func registerArtifactRoutes(protected, public *gin.RouterGroup, cfg RouterConfig) {
+	svc := artifact.NewService(postgres.NewArtifactRepo(), cfg.ArtifactStore, cfg.ArtifactSigner)
+	handler := handlers.NewArtifactHandler(handlers.NewBaseHandler(), svc)
+
+	sysGroup := protected.Group("/system")
+	sysGroup.Use(middleware.RequireRole("admin"))
+	handler.RegisterRoutes(sysGroup)
+
+	download := public.Group("/artifacts")
+	download.Use(middleware.TenantDB(cfg.TenantManager))
+	download.GET("/:id/download", handler.Download)
+}
+
+// registerAccountImportRoutes registers account import endpoints (admin-only).
+func registerAccountImportRoutes(rg *gin.RouterGroup) {
+	svc := accountimport.NewService(postgres.NewAccountImportRepo(), postgres.NewAccountImportSink())
+	handler := handlers.NewAccountImportHandler(handlers.NewBaseHandler(), svc)
+
+	sysGroup := rg.Group("/system")
+	sysGroup.Use(middleware.RequireRole("admin"))
+	handler.RegisterRoutes(sysGroup)
+}
+
+// registerWebhookRoutes registers the webhook delivery log endpoints (admin-only).
+func registerWebhookRoutes(rg *gin.RouterGroup) {
+	handler := handlers.NewWebhookHandler(handlers.NewBaseHandler(), webhooks.NewService(postgres.NewWebhookDeliveryRepo()))
+
+	webhookGroup := rg.Group("/webhooks")
+	webhookGroup.Use(middleware.RequireRole("admin"))
+	handler.RegisterRoutes(webhookGroup)
+}
+
+// registerCascadeDeleteRoutes registers cascade preview/purge of marked
+// documents (admin-only), next to the "Delete Marked Objects" processing.
+func registerCascadeDeleteRoutes(rg *gin.RouterGroup, cfg RouterConfig, reg *metadata.Registry) {
+	svc := cascadedelete.NewService(postgres.NewDocumentCascadeRepo(reg), cfg.CascadeDeleteSigner)
+	handler := handlers.NewCascadeDeleteHandler(svc)
+
+	sysGroup := rg.Group("/system")
+	sysGroup.Use(middleware.RequireRole("admin"))
+	sysGroup.POST("/marked-objects/cascade-preview", middleware.ReadsOnly(), handler.Preview)
+	sysGroup.POST("/marked-objects/purge", handler.Purge)
 }
 
 // deriveEntityKey extracts the snake_case entity key from a permission prefix.
@@ -767,10 +1175,17 @@ func registerListViewRoutes(rg *gin.RouterGroup) {
 }
 
 // registerSettingsRoutes registers system settings endpoints.
-func registerSettingsRoutes(rg *gin.RouterGroup) {
+func registerSettingsRoutes(rg *gin.RouterGroup, cfg RouterConfig) {
 	baseHandler := handlers.NewBaseHandler()
 	repo := postgres.NewSettingsRepo()
 	handler := handlers.NewSettingsHandler(baseHandler, repo)
+	handler.SetResolver(cfg.SettingsResolver)
+	if cfg.Mailer != nil {
+		handler.SetMailer(cfg.Mailer)
+	}
+	if cfg.Numerator != nil {
+		handler.SetNumerator(cfg.Numerator)
+	}
 	handler.RegisterRoutes(rg)
 }
 
@@ -781,16 +1196,14 @@ func registerSettingsRoutes(rg *gin.RouterGroup) {
 // Admin tenant endpoints operate on the meta-database, not tenant databases.
 // They must remain accessible even when a tenant is in migration_failed status.
 func registerAdminTenantRoutes(rg *gin.RouterGroup, cfg RouterConfig, stateStore tenant.MigrationStateStore, healthHandler *handlers.MultiTenantHealthHandler) {
-	base := handlers.NewBaseHandler()
-	registry := cfg.TenantManager.GetRegistry()
-	updater := migration.NewTenantUpdater(registry, cfg.TenantManager, stateStore, cfg.Logger)
-	h := handlers.NewAdminTenantHandler(base, registry, updater)
+	h := newAdminTenantHandler(cfg, stateStore)
 
 	admin := rg.Group("/admin/tenants")
 	admin.Use(middleware.RequireRole("admin"))
 	{
 		admin.GET("", h.List)
 		admin.GET("/stats", h.Stats)
+		admin.GET("/audit", h.Audit)
 		admin.GET("/:tenantId", h.Get)
 		admin.PUT("/:tenantId/version-group", h.Promote)
 		admin.PUT("/:tenantId/schema-version", h.UpdateSchemaVersion)
@@ -798,6 +1211,7 @@ func registerAdminTenantRoutes(rg *gin.RouterGroup, cfg RouterConfig, stateStore
 		admin.POST("/:tenantId/retry-update", h.RetryUpdate)
 		admin.POST("/:tenantId/rollback-update", h.RollbackUpdate)
 		admin.GET("/:tenantId/migration-status", h.MigrationStatus)
+		admin.POST("/:tenantId/move", h.MoveRegion)
 	}
 
 	// Tenant health stats — admin-only (moved from public /health group)
@@ -806,6 +1220,49 @@ func registerAdminTenantRoutes(rg *gin.RouterGroup, cfg RouterConfig, stateStore
 	adminHealth.GET("/health/tenants", healthHandler.TenantsStats)
 }
 
+// registerControlPlaneRoutes registers the control-plane API: tenant
+// lifecycle (create, suspend, activate, plan), schema updates and pool stats.
+// rg is authenticated by middleware.AdminJWT; like the admin tenant routes
+// it works on the meta-database only.
+func registerControlPlaneRoutes(rg *gin.RouterGroup, cfg RouterConfig, stateStore tenant.MigrationStateStore, healthHandler *handlers.MultiTenantHealthHandler) {
+	h := newAdminTenantHandler(cfg, stateStore)
+	h.SetLifecycle(cfg.TenantManager, cfg.TenantDefaults)
+
+	tenants := rg.Group("/tenants")
+	{
+		tenants.GET("", h.List)
+		tenants.POST("", h.Create)
+		tenants.GET("/stats", h.Stats)
+		tenants.GET("/audit", h.Audit)
+		tenants.GET("/:tenantId", h.Get)
+		tenants.POST("/:tenantId/suspend", h.Suspend)
+		tenants.POST("/:tenantId/activate", h.Activate)
+		tenants.PUT("/:tenantId/plan", h.ChangePlan)
+		tenants.PUT("/:tenantId/read-only", h.SetReadOnly)
+		tenants.PUT("/:tenantId/read-replica", h.SetReadReplica)
+		tenants.PUT("/:tenantId/version-group", h.Promote)
+		tenants.POST("/:tenantId/update", h.TriggerUpdate)
+		tenants.POST("/:tenantId/retry-update", h.RetryUpdate)
+		tenants.POST("/:tenantId/rollback-update", h.RollbackUpdate)
+		tenants.GET("/:tenantId/migration-status", h.MigrationStatus)
+		tenants.POST("/:tenantId/move", h.MoveRegion)
+	}
+	rg.GET("/pools", healthHandler.TenantsStats)
+}
+
+// newAdminTenantHandler builds the tenant management handler shared by the
+// admin and control-plane routes.
+func newAdminTenantHandler(cfg RouterConfig, stateStore tenant.MigrationStateStore) *handlers.AdminTenantHandler {
+	registry := cfg.TenantManager.GetRegistry()
+	updater := migration.NewTenantUpdater(registry, cfg.TenantManager, stateStore, cfg.Logger)
+	h := handlers.NewAdminTenantHandler(handlers.NewBaseHandler(), registry, updater)
+	h.SetRegionMover(migration.NewRegionMover(registry, cfg.TenantManager, stateStore, cfg.Logger))
+	if cfg.TenantAudit != nil {
+		h.SetAuditLog(cfg.TenantAudit)
+	}
+	return h
+}
+
 // registerInternalUpdaterRoutes registers internal endpoints for the Updater Agent.
 // No auth required — secured by Docker network isolation (internal network trust).
 func registerInternalUpdaterRoutes(rg *gin.RouterGroup, cfg RouterConfig, stateStore tenant.MigrationStateStore) {
@@ -826,10 +1283,13 @@ func registerInternalUpdaterRoutes(rg *gin.RouterGroup, cfg RouterConfig, stateS
 	registry := cfg.TenantManager.GetRegistry()
 	updater := migration.NewTenantUpdater(registry, cfg.TenantManager, stateStore, cfg.Logger)
 	h := handlers.NewAdminTenantHandler(base, registry, updater)
+	if cfg.TenantAudit != nil {
+		h.SetAuditLog(cfg.TenantAudit)
+	}
 
-	rg.POST("/tenants/:id/trigger-update", h.InternalTriggerUpdate)
-	rg.POST("/tenants/:id/retry-update", h.InternalRetryUpdate)
-	rg.POST("/tenants/:id/rollback-update", h.InternalRollbackUpdate)
+	rg.POST("/tenants/:id/trigger-update", middleware.PermitSigned(), h.InternalTriggerUpdate)
+	rg.POST("/tenants/:id/retry-update", middleware.PermitSigned(), h.InternalRetryUpdate)
+	rg.POST("/tenants/:id/rollback-update", middleware.PermitSigned(), h.InternalRollbackUpdate)
 	rg.GET("/tenants/:id/migration-status", h.InternalMigrationStatus)
 }
 
@@ -852,16 +1312,18 @@ func registerMerchantPublicRoutes(router *gin.Engine, cfg RouterConfig) {
 	// /merchant/v1/ — public merchant API (API-key auth)
 	merchantV1 := router.Group("/merchant/v1")
 	merchantV1.Use(middleware.MerchantAPIKey(cfg.MerchantAPIKeyRepo, cfg.TenantManager))
+	merchantV1.Use(middleware.RequireWritableTenant(cfg.TenantManager.GetRegistry()))
+	merchantV1.Use(middleware.RequireModule(cfg.Modules, modules.Crypto))
 	{
 		invoices := merchantV1.Group("/invoices")
 		{
-			invoices.POST("", invoiceHandler.CreateInvoice)
-			invoices.GET("/:id", invoiceHandler.GetInvoice)
+			invoices.POST("", middleware.RequireMerchantScope(merchant.ScopeInvoiceCreate), invoiceHandler.CreateInvoice)
+			invoices.GET("/:id", middleware.RequireMerchantScope(merchant.ScopeInvoiceRead), invoiceHandler.GetInvoice)
 		}
 
 		addresses := merchantV1.Group("/addresses")
 		{
-			addresses.POST("", addressHandler.CreateAddress)
+			addresses.POST("", middleware.RequireMerchantScope(merchant.ScopeAddressCreate), addressHandler.CreateAddress)
 		}
 	}
 
@@ -874,6 +1336,8 @@ func registerMerchantPublicRoutes(router *gin.Engine, cfg RouterConfig) {
 	merchantAdmin.Use(middleware.TenantDB(cfg.TenantManager))
 	merchantAdmin.Use(middleware.Auth(cfg.JWTValidator))
 	merchantAdmin.Use(middleware.RequireActiveTenant())
+	merchantAdmin.Use(middleware.RequireWritableTenant(cfg.TenantManager.GetRegistry()))
+	merchantAdmin.Use(middleware.RequireModule(cfg.Modules, modules.Crypto))
 	merchantAdmin.Use(middleware.SecurityContext(cfg.ProfileProvider))
 	{
 		// Sub-group with ownership check: all routes under /merchants/:merchantId
@@ -961,6 +1425,8 @@ func registerMerchantPublicRoutes(router *gin.Engine, cfg RouterConfig) {
 		portalV1.Use(middleware.TenantDB(cfg.TenantManager))
 		portalV1.Use(middleware.Auth(cfg.JWTValidator))
 		portalV1.Use(middleware.RequireActiveTenant())
+		portalV1.Use(middleware.RequireWritableTenant(cfg.TenantManager.GetRegistry()))
+		portalV1.Use(middleware.RequireModule(cfg.Modules, modules.Crypto))
 		portalV1.Use(middleware.MerchantPortal())
 		{
 			portalV1.GET("/merchants", portalHandler.ListMerchants)
@@ -0,0 +1,97 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"metapus/internal/domain/auth"
+)
+
+const sendGridEndpoint = "https://api.sendgrid.com/v3/mail/send"
+
+// SendGridMailer implements auth.Mailer over the SendGrid v3 HTTP API.
+type SendGridMailer struct {
+	apiKey string
+	from   string
+	client *http.Client
+}
+
+// NewSendGridMailer creates a SendGrid mailer.
+func NewSendGridMailer(apiKey, from string) *SendGridMailer {
+	return &SendGridMailer{
+		apiKey: apiKey,
+		from:   from,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+}
+
+type sendGridRequest struct {
+	Personalizations []struct {
+		To []sendGridAddress `json:"to"`
+	} `json:"personalizations"`
+	From    sendGridAddress `json:"from"`
+	Subject string          `json:"subject"`
+	Content []struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	} `json:"content"`
+}
+
+// Send delivers a plain-text message.
+func (m *SendGridMailer) Send(ctx context.Context, msg auth.MailMessage) error {
+	if len(msg.To) == 0 {
+		return fmt.Errorf("no recipients specified")
+	}
+	if m.apiKey == "" {
+		return fmt.Errorf("missing sendgrid api key")
+	}
+
+	var body sendGridRequest
+	body.Personalizations = make([]struct {
+		To []sendGridAddress `json:"to"`
+	}, 1)
+	for _, addr := range msg.To {
+		body.Personalizations[0].To = append(body.Personalizations[0].To, sendGridAddress{Email: addr})
+	}
+	body.From = sendGridAddress{Email: m.from}
+	body.Subject = msg.Subject
+	body.Content = []struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}{{Type: "text/plain", Value: msg.Body}}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshal sendgrid payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sendGridEndpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+m.apiKey)
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("sendgrid returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+var _ auth.Mailer = (*SendGridMailer)(nil)
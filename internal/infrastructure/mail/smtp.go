@@ -0,0 +1,80 @@
+// Package mail provides outgoing email delivery for transactional messages
+// (verification links, security notices). Delivery reuses the SMTP transport
+// of the automation engine's EmailAdapter.
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"metapus/internal/core/automation"
+	"metapus/internal/domain/auth"
+)
+
+// SMTPConfig holds platform-level SMTP settings.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	From     string
+	Password string
+}
+
+// SMTPConfigFromEnv loads SMTP settings from SMTP_HOST, SMTP_PORT, SMTP_USERNAME,
+// SMTP_FROM and SMTP_PASSWORD. Returns false if SMTP_HOST or SMTP_FROM is not set.
+func SMTPConfigFromEnv() (SMTPConfig, bool) {
+	cfg := SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     os.Getenv("SMTP_PORT"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		From:     os.Getenv("SMTP_FROM"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+	}
+	if cfg.Host == "" || cfg.From == "" {
+		return SMTPConfig{}, false
+	}
+	return cfg, true
+}
+
+// SMTPMailer implements auth.Mailer over SMTP.
+type SMTPMailer struct {
+	cfg     SMTPConfig
+	adapter *automation.EmailAdapter
+}
+
+// NewSMTPMailer creates a mailer with a fixed SMTP configuration.
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{
+		cfg:     cfg,
+		adapter: automation.NewEmailAdapter(),
+	}
+}
+
+// Send delivers a plain-text message.
+func (m *SMTPMailer) Send(ctx context.Context, msg auth.MailMessage) error {
+	if len(msg.To) == 0 {
+		return fmt.Errorf("no recipients specified")
+	}
+
+	to := make([]any, len(msg.To))
+	for i, addr := range msg.To {
+		to[i] = addr
+	}
+	destination := map[string]any{"to": to}
+	accountConfig := map[string]any{
+		"smtp_host": m.cfg.Host,
+		"smtp_port": m.cfg.Port,
+		"username":  m.cfg.Username,
+		"from":      m.cfg.From,
+	}
+
+	// EmailAdapter takes the subject from the first line of the payload.
+	payload := msg.Subject + "\n" + msg.Body
+	if err := m.adapter.Deliver(ctx, destination, accountConfig, []byte(m.cfg.Password), payload, nil); err != nil {
+		return fmt.Errorf("smtp deliver: %w", err)
+	}
+	return nil
+}
+
+var _ auth.Mailer = (*SMTPMailer)(nil)
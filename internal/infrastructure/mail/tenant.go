@@ -0,0 +1,77 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+
+	"metapus/internal/domain/auth"
+	"metapus/internal/domain/settings"
+	"metapus/pkg/logger"
+)
+
+// TenantMailer resolves the outgoing email provider per tenant from
+// sys_settings.email on every send, falling back to the platform sender.
+// The tenant database must already be bound to ctx (TenantDB middleware).
+type TenantMailer struct {
+	settings settings.Repository
+	platform auth.Mailer
+}
+
+// NewTenantMailer creates a tenant-aware mailer. platform may be nil when no
+// platform-level default sender is configured.
+func NewTenantMailer(settingsRepo settings.Repository, platform auth.Mailer) *TenantMailer {
+	return &TenantMailer{
+		settings: settingsRepo,
+		platform: platform,
+	}
+}
+
+// Send delivers the message through the tenant's configured provider.
+func (m *TenantMailer) Send(ctx context.Context, msg auth.MailMessage) error {
+	mailer, err := m.resolve(ctx)
+	if err != nil {
+		return err
+	}
+	return mailer.Send(ctx, msg)
+}
+
+func (m *TenantMailer) resolve(ctx context.Context) (auth.Mailer, error) {
+	s, err := m.settings.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load email settings: %w", err)
+	}
+
+	cfg := s.Email
+	if cfg.UsesPlatform() {
+		if m.platform == nil {
+			return nil, fmt.Errorf("no email provider configured for tenant and no platform default sender")
+		}
+		return m.platform, nil
+	}
+
+	secret, err := m.settings.GetEmailSecret(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load email secret: %w", err)
+	}
+
+	switch cfg.Provider {
+	case settings.EmailProviderSMTP:
+		return NewSMTPMailer(SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.Username,
+			From:     cfg.From,
+			Password: string(secret),
+		}), nil
+	case settings.EmailProviderSendGrid:
+		return NewSendGridMailer(string(secret), cfg.From), nil
+	default:
+		logger.Warn(ctx, "unknown tenant email provider, using platform sender", "provider", cfg.Provider)
+		if m.platform == nil {
+			return nil, fmt.Errorf("unknown email provider %q", cfg.Provider)
+		}
+		return m.platform, nil
+	}
+}
+
+var _ auth.Mailer = (*TenantMailer)(nil)
@@ -4,6 +4,7 @@ package numerator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"hash/fnv"
 	"sync"
@@ -24,6 +25,29 @@ type cachedRange struct {
 	mu      sync.Mutex
 	current int64
 	max     int64
+
+	// dbKey and tenantID identify where the range was reserved, so the
+	// unused remainder can be released on shutdown. The pool is resolved
+	// again at release time: the one used to reserve may be closed by then
+	// (idle timeout, LRU eviction).
+	dbKey    string
+	tenantID string
+}
+
+// PoolResolver returns the querier of a tenant database.
+// ReleaseRanges uses it to reach the database a range was reserved in.
+type PoolResolver func(ctx context.Context, tenantID string) (Querier, error)
+
+// ManagerPools resolves tenant pools through the tenant manager,
+// reopening pools that were closed since the range was reserved.
+func ManagerPools(m *tenant.Manager) PoolResolver {
+	return func(ctx context.Context, tenantID string) (Querier, error) {
+		mp, err := m.GetPool(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		return mp.Pool(), nil
+	}
 }
 
 // numShards is the number of independent lock shards.
@@ -44,6 +68,9 @@ type Service struct {
 	shards [_numShards]shard
 	// querierFn overrides the default querier resolution (for testing only).
 	querierFn func(ctx context.Context) Querier
+	// persistRanges keeps unreturnable range remainders in sys_sequence_free_ranges
+	// so they are reused after restart instead of becoming gaps.
+	persistRanges bool
 }
 
 // Ensure compile-time interface compliance.
@@ -59,6 +86,13 @@ func New() *Service {
 	return s
 }
 
+// SetPersistRanges enables persisting unused cached-range remainders that cannot
+// be returned to sys_sequences on shutdown (another instance allocated after us).
+// Persisted remainders are claimed first on the next range reservation.
+func (s *Service) SetPersistRanges(enabled bool) {
+	s.persistRanges = enabled
+}
+
 // getShard returns the shard responsible for the given cache key.
 func (s *Service) getShard(key string) *shard {
 	h := fnv.New32a()
@@ -157,6 +191,22 @@ func (s *Service) getNextCached(ctx context.Context, dbKey, cacheKey string, opt
 		}
 
 		querier := s.getQuerier(ctx)
+		rng.dbKey = dbKey
+		rng.tenantID = tenant.GetTenantID(ctx)
+
+		// Reuse a remainder released by a previous shutdown, if any.
+		if s.persistRanges {
+			next, maxVal, ok, err := claimFreeRange(ctx, querier, dbKey)
+			if err != nil {
+				return 0, err
+			}
+			if ok {
+				rng.current = next
+				rng.max = maxVal
+				return next, nil
+			}
+		}
+
 		var newMax int64
 
 		increment := size
@@ -187,6 +237,97 @@ func (s *Service) getNextCached(ctx context.Context, dbKey, cacheKey string, opt
 	return rng.current, nil
 }
 
+// claimFreeRange takes the lowest persisted free range for key, if any.
+func claimFreeRange(ctx context.Context, querier Querier, key string) (next, maxVal int64, ok bool, err error) {
+	err = querier.QueryRow(ctx, `
+		DELETE FROM sys_sequence_free_ranges
+		WHERE id = (
+			SELECT id FROM sys_sequence_free_ranges
+			WHERE key = $1
+			ORDER BY next_val
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING next_val, max_val
+	`, key).Scan(&next, &maxVal)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, 0, false, nil
+	}
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("claim free range: %w", err)
+	}
+	return next, maxVal, true, nil
+}
+
+// ReleaseRanges returns unused remainders of cached ranges. A remainder is
+// returned to sys_sequences only when no other allocation happened after it
+// (current_val still equals the range end); otherwise it is persisted as a
+// free range when SetPersistRanges is enabled, or dropped (gap).
+// Call on graceful shutdown, after in-flight requests are drained and before
+// the tenant manager is closed; pools resolves each range's tenant database.
+func (s *Service) ReleaseRanges(ctx context.Context, pools PoolResolver) error {
+	var errs []error
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.Lock()
+		ranges := sh.ranges
+		sh.ranges = make(map[string]*cachedRange)
+		sh.mu.Unlock()
+
+		for _, rng := range ranges {
+			if err := s.releaseRange(ctx, pools, rng); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// releaseRange releases a single range. The range is marked exhausted first,
+// so a late caller holding the pointer reserves a fresh range instead.
+func (s *Service) releaseRange(ctx context.Context, pools PoolResolver, rng *cachedRange) error {
+	rng.mu.Lock()
+	defer rng.mu.Unlock()
+
+	if rng.dbKey == "" || rng.current >= rng.max {
+		return nil
+	}
+	next, end := rng.current+1, rng.max
+	rng.max = rng.current
+
+	querier, err := pools(ctx, rng.tenantID)
+	if err != nil {
+		return fmt.Errorf("return range %s: tenant %s: %w", rng.dbKey, rng.tenantID, err)
+	}
+
+	var restored int64
+	err = querier.QueryRow(ctx, `
+		UPDATE sys_sequences SET current_val = $2
+		WHERE key = $1 AND current_val = $3
+		RETURNING current_val
+	`, rng.dbKey, next-1, end).Scan(&restored)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("return range %s: %w", rng.dbKey, err)
+	}
+
+	if !s.persistRanges {
+		return nil
+	}
+	var rangeID int64
+	err = querier.QueryRow(ctx, `
+		INSERT INTO sys_sequence_free_ranges (key, next_val, max_val)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`, rng.dbKey, next, end).Scan(&rangeID)
+	if err != nil {
+		return fmt.Errorf("persist free range %s: %w", rng.dbKey, err)
+	}
+	return nil
+}
+
 // SetNextNumber sets the next number value (for migration purposes).
 func (s *Service) SetNextNumber(ctx context.Context, cfg corenumerator.Config, period time.Time, value int64) error {
 	key := s.buildKey(cfg, period)
@@ -200,6 +341,15 @@ func (s *Service) SetNextNumber(ctx context.Context, cfg corenumerator.Config, p
 		RETURNING current_val
 	`, key, value).Scan(&result)
 
+	// Free ranges released before the reset are stale now.
+	if err == nil && s.persistRanges {
+		var purged int64
+		err = querier.QueryRow(ctx, `
+			WITH d AS (DELETE FROM sys_sequence_free_ranges WHERE key = $1 RETURNING 1)
+			SELECT COUNT(*) FROM d
+		`, key).Scan(&purged)
+	}
+
 	// Invalidate cache for this key if exists
 	cacheKey := key
 	if tid := tenant.GetTenantID(ctx); tid != "" {
@@ -213,6 +363,48 @@ func (s *Service) SetNextNumber(ctx context.Context, cfg corenumerator.Config, p
 	return err
 }
 
+// PeekNextNumbers returns up to count upcoming numbers without consuming the sequence.
+// For the Cached strategy, numbers already reserved in memory are returned first.
+func (s *Service) PeekNextNumbers(ctx context.Context, cfg corenumerator.Config, period time.Time, count int) ([]string, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+
+	key := s.buildKey(cfg, period)
+	querier := s.getQuerier(ctx)
+
+	var current int64
+	err := querier.QueryRow(ctx, `
+		SELECT COALESCE((SELECT current_val FROM sys_sequences WHERE key = $1), 0)
+	`, key).Scan(&current)
+	if err != nil {
+		return nil, fmt.Errorf("peek sequence: %w", err)
+	}
+
+	// Prefer the in-memory range if one is active for this key.
+	cacheKey := key
+	if tid := tenant.GetTenantID(ctx); tid != "" {
+		cacheKey = fmt.Sprintf("%s:%s", tid, key)
+	}
+	sh := s.getShard(cacheKey)
+	sh.mu.Lock()
+	rng, exists := sh.ranges[cacheKey]
+	sh.mu.Unlock()
+	if exists {
+		rng.mu.Lock()
+		if rng.current < rng.max {
+			current = rng.current
+		}
+		rng.mu.Unlock()
+	}
+
+	result := make([]string, 0, count)
+	for i := int64(1); i <= int64(count); i++ {
+		result = append(result, s.formatNumber(cfg, period, current+i))
+	}
+	return result, nil
+}
+
 // buildKey creates the sequence key based on config and period.
 func (s *Service) buildKey(cfg corenumerator.Config, period time.Time) string {
 	switch cfg.ResetPeriod {
@@ -9,11 +9,13 @@ import (
 	"github.com/jackc/pgx/v5"
 
 	corenumerator "metapus/internal/core/numerator"
+	"metapus/internal/core/tenant"
 )
 
 // Mock objects
 type mockRow struct {
 	val int64
+	max int64 // second column (claimed free range end)
 	err error
 }
 
@@ -26,6 +28,11 @@ func (m *mockRow) Scan(dest ...any) error {
 			*ptr = m.val
 		}
 	}
+	if len(dest) > 1 {
+		if ptr, ok := dest[1].(*int64); ok {
+			*ptr = m.max
+		}
+	}
 	return nil
 }
 
@@ -59,6 +66,11 @@ func newTestService(q Querier) *Service {
 	return svc
 }
 
+// staticPools resolves every tenant to q.
+func staticPools(q Querier) PoolResolver {
+	return func(context.Context, string) (Querier, error) { return q, nil }
+}
+
 func TestGetNextNumber_Strict(t *testing.T) {
 	q := &mockQuerier{}
 	svc := newTestService(q)
@@ -170,3 +182,202 @@ func TestSetNextNumber_InvalidatesCache(t *testing.T) {
 		t.Error("expected cache entry to be invalidated after SetNextNumber")
 	}
 }
+
+// staticQuerier returns a fixed sequence value without modifying it.
+type staticQuerier struct {
+	value int64
+}
+
+func (s *staticQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return &mockRow{val: s.value}
+}
+
+func TestPeekNextNumbers(t *testing.T) {
+	q := &staticQuerier{value: 41}
+	svc := newTestService(q)
+	ctx := context.Background()
+	cfg := corenumerator.DefaultConfig("TEST")
+	period := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	nums, err := svc.PeekNextNumbers(ctx, cfg, period, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"TEST-2026-00042", "TEST-2026-00043", "TEST-2026-00044"}
+	if len(nums) != len(want) {
+		t.Fatalf("expected %d numbers, got %d", len(want), len(nums))
+	}
+	for i := range want {
+		if nums[i] != want[i] {
+			t.Errorf("nums[%d] = %s, want %s", i, nums[i], want[i])
+		}
+	}
+
+	// Peeking must not consume: repeated call returns the same head.
+	again, err := svc.PeekNextNumbers(ctx, cfg, period, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again[0] != want[0] {
+		t.Errorf("expected %s on repeated peek, got %s", want[0], again[0])
+	}
+}
+
+func TestPeekNextNumbers_PrefersCachedRange(t *testing.T) {
+	q := &mockQuerier{}
+	svc := newTestService(q)
+	ctx := context.Background()
+	cfg := corenumerator.DefaultConfig("TEST")
+	opts := &corenumerator.Options{Strategy: corenumerator.StrategyCached, RangeSize: 10}
+	period := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// Reserve range 1..10 and consume 1.
+	if _, err := svc.GetNextNumber(ctx, cfg, opts, period); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	nums, err := svc.PeekNextNumbers(ctx, cfg, period, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if nums[0] != "TEST-2026-00002" {
+		t.Errorf("expected TEST-2026-00002 from cached range, got %s", nums[0])
+	}
+}
+
+// scriptedQuerier returns queued rows in order and records call arguments.
+type scriptedQuerier struct {
+	mu    sync.Mutex
+	rows  []*mockRow
+	calls [][]any
+}
+
+func (q *scriptedQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.calls = append(q.calls, args)
+	if len(q.rows) == 0 {
+		return &mockRow{err: pgx.ErrNoRows}
+	}
+	row := q.rows[0]
+	q.rows = q.rows[1:]
+	return row
+}
+
+func TestReleaseRanges_ReturnsRemainder(t *testing.T) {
+	q := &scriptedQuerier{rows: []*mockRow{{val: 10}, {val: 3}}}
+	svc := newTestService(q)
+	ctx := context.Background()
+	cfg := corenumerator.DefaultConfig("TEST")
+	opts := &corenumerator.Options{Strategy: corenumerator.StrategyCached, RangeSize: 10}
+	period := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// Reserve range 1..10 and consume 1..3.
+	for i := 0; i < 3; i++ {
+		if _, err := svc.GetNextNumber(ctx, cfg, opts, period); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := svc.ReleaseRanges(ctx, staticPools(q)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(q.calls) != 2 {
+		t.Fatalf("expected 2 queries, got %d", len(q.calls))
+	}
+	args := q.calls[1]
+	if args[1] != int64(3) || args[2] != int64(10) {
+		t.Errorf("expected sequence rewind 10 -> 3, got %v", args)
+	}
+
+	// Released ranges are dropped: a second release is a no-op.
+	if err := svc.ReleaseRanges(ctx, staticPools(q)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(q.calls) != 2 {
+		t.Errorf("expected no queries on second release, got %d", len(q.calls))
+	}
+}
+
+func TestReleaseRanges_PersistsWhenSequenceMoved(t *testing.T) {
+	// Range reserve, then the conditional rewind finds no row, then the insert.
+	q := &scriptedQuerier{rows: []*mockRow{{val: 10}, {err: pgx.ErrNoRows}, {val: 1}}}
+	svc := newTestService(q)
+	svc.SetPersistRanges(true)
+	ctx := context.Background()
+	cfg := corenumerator.DefaultConfig("TEST")
+	opts := &corenumerator.Options{Strategy: corenumerator.StrategyCached, RangeSize: 10}
+	period := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// The free-range claim is answered with ErrNoRows before the reserve.
+	q.rows = append([]*mockRow{{err: pgx.ErrNoRows}}, q.rows...)
+
+	if _, err := svc.GetNextNumber(ctx, cfg, opts, period); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.ReleaseRanges(ctx, staticPools(q)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(q.calls) != 4 {
+		t.Fatalf("expected 4 queries, got %d", len(q.calls))
+	}
+	args := q.calls[3]
+	if args[1] != int64(2) || args[2] != int64(10) {
+		t.Errorf("expected free range 2..10 persisted, got %v", args)
+	}
+}
+
+func TestReleaseRanges_ResolvesPoolByTenant(t *testing.T) {
+	reserved := &scriptedQuerier{rows: []*mockRow{{val: 10}}}
+	svc := newTestService(reserved)
+	ctx := tenant.WithTenant(context.Background(), &tenant.Tenant{ID: "t1"})
+	cfg := corenumerator.DefaultConfig("TEST")
+	opts := &corenumerator.Options{Strategy: corenumerator.StrategyCached, RangeSize: 10}
+	period := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := svc.GetNextNumber(ctx, cfg, opts, period); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The pool used to reserve has been evicted; release must go through
+	// the pool the resolver returns now.
+	current := &scriptedQuerier{rows: []*mockRow{{val: 1}}}
+	var resolved []string
+	pools := func(_ context.Context, tenantID string) (Querier, error) {
+		resolved = append(resolved, tenantID)
+		return current, nil
+	}
+	if err := svc.ReleaseRanges(context.Background(), pools); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resolved) != 1 || resolved[0] != "t1" {
+		t.Fatalf("resolved tenants = %v, want [t1]", resolved)
+	}
+	if len(reserved.calls) != 1 || len(current.calls) != 1 {
+		t.Errorf("queries: reserve pool %d, current pool %d; want 1 and 1", len(reserved.calls), len(current.calls))
+	}
+}
+
+func TestGetNextNumber_ClaimsFreeRange(t *testing.T) {
+	// The claim returns the persisted range 7..9.
+	q := &scriptedQuerier{rows: []*mockRow{{val: 7, max: 9}}}
+	svc := newTestService(q)
+	svc.SetPersistRanges(true)
+	ctx := context.Background()
+	cfg := corenumerator.Config{Prefix: "TEST", PadWidth: 1}
+	opts := &corenumerator.Options{Strategy: corenumerator.StrategyCached, RangeSize: 10}
+	period := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, want := range []string{"TEST-7", "TEST-8", "TEST-9"} {
+		got, err := svc.GetNextNumber(ctx, cfg, opts, period)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	}
+	if len(q.calls) != 1 {
+		t.Errorf("expected the claimed range to serve all numbers, got %d queries", len(q.calls))
+	}
+}
@@ -0,0 +1,185 @@
+package rate_feed
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Official central bank rate sources (cat_rate_sources.source_type).
+const (
+	// SourceTypeCBR is the Bank of Russia daily fixing (rates in RUB).
+	SourceTypeCBR = "cbr"
+	// SourceTypeECB is the European Central Bank reference rates (rates per 1 EUR).
+	SourceTypeECB = "ecb"
+
+	_cbrDailyURL = "https://www.cbr.ru/scripts/XML_daily.asp"
+	_ecbDailyURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+)
+
+// Quotes is one publication of official rates: the value of one unit of each
+// currency in the publisher's home currency, keyed by ISO 4217 code.
+// The home currency itself is included with value 1.
+type Quotes struct {
+	Date  time.Time
+	Home  string
+	Rates map[string]decimal.Decimal
+}
+
+// CrossRate returns the value of one unit of iso in base, or false when
+// either currency is not quoted.
+func (q *Quotes) CrossRate(iso, base string) (decimal.Decimal, bool) {
+	r, ok := q.Rates[iso]
+	if !ok {
+		return decimal.Zero, false
+	}
+	b, ok := q.Rates[base]
+	if !ok || b.IsZero() {
+		return decimal.Zero, false
+	}
+	return r.DivRound(b, 12), true
+}
+
+// OfficialFetcher downloads the latest daily rates of a central bank.
+// Thread-safe: stateless client.
+type OfficialFetcher struct {
+	client *http.Client
+	source string
+	url    string
+	parse  func(io.Reader) (*Quotes, error)
+}
+
+// NewOfficialFetcher creates a fetcher for a cbr/ecb source. baseURL overrides
+// the default publication URL (cat_rate_sources.base_url).
+func NewOfficialFetcher(sourceType string, baseURL *string) (*OfficialFetcher, error) {
+	f := &OfficialFetcher{
+		client: &http.Client{Timeout: _httpTimeout},
+		source: sourceType,
+	}
+	switch sourceType {
+	case SourceTypeCBR:
+		f.url, f.parse = _cbrDailyURL, parseCBR
+	case SourceTypeECB:
+		f.url, f.parse = _ecbDailyURL, parseECB
+	default:
+		return nil, fmt.Errorf("unsupported official rate source %q", sourceType)
+	}
+	if baseURL != nil && *baseURL != "" {
+		f.url = *baseURL
+	}
+	return f, nil
+}
+
+// Fetch downloads and parses the latest publication.
+func (f *OfficialFetcher) Fetch(ctx context.Context) (*Quotes, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s request: %w", f.source, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s HTTP %d: %s", f.source, resp.StatusCode, string(body))
+	}
+
+	quotes, err := f.parse(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s rates: %w", f.source, err)
+	}
+	return quotes, nil
+}
+
+// parseCBR parses the Bank of Russia XML_daily feed (windows-1251):
+//
+//	<ValCurs Date="16.10.2026"><Valute><CharCode>USD</CharCode><Nominal>1</Nominal><Value>81,1234</Value></Valute>...
+func parseCBR(r io.Reader) (*Quotes, error) {
+	var doc struct {
+		Date    string `xml:"Date,attr"`
+		Valutes []struct {
+			CharCode string `xml:"CharCode"`
+			Nominal  string `xml:"Nominal"`
+			Value    string `xml:"Value"`
+		} `xml:"Valute"`
+	}
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		if strings.EqualFold(charset, "windows-1251") {
+			return charmap.Windows1251.NewDecoder().Reader(input), nil
+		}
+		return nil, fmt.Errorf("unsupported charset %q", charset)
+	}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse("02.01.2006", doc.Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", doc.Date, err)
+	}
+
+	q := &Quotes{Date: date, Home: "RUB", Rates: map[string]decimal.Decimal{"RUB": decimal.NewFromInt(1)}}
+	for _, v := range doc.Valutes {
+		value, err := decimal.NewFromString(strings.Replace(v.Value, ",", ".", 1))
+		if err != nil {
+			continue
+		}
+		nominal, err := decimal.NewFromString(v.Nominal)
+		if err != nil || !nominal.IsPositive() {
+			continue
+		}
+		q.Rates[strings.ToUpper(v.CharCode)] = value.DivRound(nominal, 12)
+	}
+	return q, nil
+}
+
+// parseECB parses the ECB eurofxref-daily feed; rates are units per 1 EUR:
+//
+//	<Cube><Cube time="2026-10-16"><Cube currency="USD" rate="1.0850"/>...
+func parseECB(r io.Reader) (*Quotes, error) {
+	var doc struct {
+		Days []struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string `xml:"currency,attr"`
+				Rate     string `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube>Cube"`
+	}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Days) == 0 {
+		return nil, fmt.Errorf("no rates in feed")
+	}
+
+	day := doc.Days[0]
+	date, err := time.Parse("2006-01-02", day.Time)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", day.Time, err)
+	}
+
+	one := decimal.NewFromInt(1)
+	q := &Quotes{Date: date, Home: "EUR", Rates: map[string]decimal.Decimal{"EUR": one}}
+	for _, c := range day.Rates {
+		perEUR, err := decimal.NewFromString(c.Rate)
+		if err != nil || !perEUR.IsPositive() {
+			continue
+		}
+		q.Rates[strings.ToUpper(c.Currency)] = one.DivRound(perEUR, 12)
+	}
+	return q, nil
+}
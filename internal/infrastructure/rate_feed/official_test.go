@@ -0,0 +1,64 @@
+package rate_feed
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+func TestParseCBR(t *testing.T) {
+	const feed = `<?xml version="1.0" encoding="windows-1251"?>
+<ValCurs Date="16.10.2026" name="Foreign Currency Market">
+<Valute ID="R01235"><NumCode>840</NumCode><CharCode>USD</CharCode><Nominal>1</Nominal><Name>Доллар США</Name><Value>81,5000</Value></Valute>
+<Valute ID="R01820"><NumCode>392</NumCode><CharCode>JPY</CharCode><Nominal>100</Nominal><Name>Японских иен</Name><Value>54,3200</Value></Valute>
+</ValCurs>`
+	encoded, err := charmap.Windows1251.NewEncoder().String(feed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q, err := parseCBR(bytes.NewReader([]byte(encoded)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := q.Date.Format("2006-01-02"); got != "2026-10-16" {
+		t.Errorf("date = %s", got)
+	}
+	if got := q.Rates["USD"].String(); got != "81.5" {
+		t.Errorf("USD = %s, want 81.5", got)
+	}
+	if got := q.Rates["JPY"].String(); got != "0.5432" {
+		t.Errorf("JPY = %s, want 0.5432 (per unit)", got)
+	}
+}
+
+func TestParseECBAndCrossRate(t *testing.T) {
+	const feed = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+<Cube><Cube time="2026-10-16">
+<Cube currency="USD" rate="1.25"/>
+<Cube currency="GBP" rate="0.8"/>
+</Cube></Cube>
+</gesmes:Envelope>`
+
+	q, err := parseECB(strings.NewReader(feed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := q.Date.Format("2006-01-02"); got != "2026-10-16" {
+		t.Errorf("date = %s", got)
+	}
+
+	// 1 USD = 0.8 EUR; 1 GBP = 1.25 EUR → 1 GBP = 1.5625 USD.
+	if r, ok := q.CrossRate("EUR", "USD"); !ok || r.String() != "1.25" {
+		t.Errorf("EUR in USD = %s, %v", r, ok)
+	}
+	if r, ok := q.CrossRate("GBP", "USD"); !ok || r.String() != "1.5625" {
+		t.Errorf("GBP in USD = %s, %v", r, ok)
+	}
+	if _, ok := q.CrossRate("CHF", "USD"); ok {
+		t.Error("unquoted currency must not have a cross rate")
+	}
+}
@@ -0,0 +1,208 @@
+package rate_feed
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"metapus/internal/core/id"
+	"metapus/internal/core/workerjob"
+	"metapus/internal/domain/registers/exchange_rate"
+	"metapus/internal/infrastructure/storage/postgres"
+	"metapus/pkg/logger"
+)
+
+const (
+	// _officialFeedInterval is how often official rates are polled. Central
+	// banks publish once a working day; re-fetching the same day is an upsert.
+	_officialFeedInterval = 1 * time.Hour
+
+	_officialJobName = "rate_feed.official"
+)
+
+// OfficialSource is an active cbr/ecb rate source of a tenant.
+type OfficialSource struct {
+	RateSourceID id.ID
+	SourceType   string
+	BaseURL      *string
+}
+
+// OfficialWorker periodically fetches central bank rates and upserts them
+// into reg_exchange_rates for every tenant currency quoted by the bank,
+// cross-converted to the tenant's base currency.
+//
+// Lifecycle: Start() blocks until ctx is cancelled.
+type OfficialWorker struct {
+	source   OfficialSource
+	fetcher  *OfficialFetcher
+	interval time.Duration
+	rateSvc  *exchange_rate.Service
+	recorder *workerjob.Recorder
+	log      *logger.Logger
+}
+
+// NewOfficialWorker creates a worker for one official rate source.
+// interval overrides the default polling interval when non-zero.
+func NewOfficialWorker(source OfficialSource, interval time.Duration, rateSvc *exchange_rate.Service, recorder *workerjob.Recorder, log *logger.Logger) (*OfficialWorker, error) {
+	fetcher, err := NewOfficialFetcher(source.SourceType, source.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if interval == 0 {
+		interval = _officialFeedInterval
+	}
+	return &OfficialWorker{
+		source:   source,
+		fetcher:  fetcher,
+		interval: interval,
+		rateSvc:  rateSvc,
+		recorder: recorder,
+		log:      log.WithComponent("rate_feed." + source.SourceType),
+	}, nil
+}
+
+// Start begins the periodic fetch loop. Blocks until ctx is cancelled.
+func (w *OfficialWorker) Start(ctx context.Context) {
+	w.fetchAndStore(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("official rate feed stopped")
+			return
+		case <-ticker.C:
+			w.fetchAndStore(ctx)
+		}
+	}
+}
+
+func (w *OfficialWorker) fetchAndStore(ctx context.Context) {
+	if w.recorder != nil {
+		w.recorder.RecordIfWork(ctx, _officialJobName, _jobCategory, w.doFetch)
+	} else if _, err := w.doFetch(ctx); err != nil {
+		w.log.Errorw("official rate fetch failed", "error", err)
+	}
+}
+
+func (w *OfficialWorker) doFetch(ctx context.Context) (int, error) {
+	currencies, err := loadTenantCurrencies(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var base *tenantCurrency
+	for i := range currencies {
+		if currencies[i].IsBase {
+			base = &currencies[i]
+			break
+		}
+	}
+	if base == nil {
+		return 0, nil // no base currency configured — nothing to convert to
+	}
+
+	quotes, err := w.fetcher.Fetch(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	stored := 0
+	for _, c := range currencies {
+		if c.IsBase {
+			continue
+		}
+		rate, ok := quotes.CrossRate(c.ISOCode, base.ISOCode)
+		if !ok {
+			continue
+		}
+		err := w.rateSvc.UpsertRate(ctx, &exchange_rate.ExchangeRate{
+			CurrencyID:   c.ID,
+			Date:         quotes.Date,
+			Rate:         rate,
+			Multiplier:   1,
+			RateSourceID: w.source.RateSourceID,
+		})
+		if err != nil {
+			w.log.Warnw("failed to upsert rate", "currency", c.ISOCode, "error", err)
+			continue
+		}
+		stored++
+	}
+
+	w.log.Infow("official exchange rates updated",
+		"source", w.source.SourceType,
+		"date", quotes.Date.Format("2006-01-02"),
+		"stored", stored,
+	)
+	return stored, nil
+}
+
+// tenantCurrency is a currency of the tenant with an ISO code.
+type tenantCurrency struct {
+	ID      id.ID
+	ISOCode string
+	IsBase  bool
+}
+
+// loadTenantCurrencies returns the tenant's active currencies with ISO codes.
+func loadTenantCurrencies(ctx context.Context) ([]tenantCurrency, error) {
+	q := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+
+	const sql = `
+		SELECT id, upper(iso_code), is_base
+		FROM cat_currencies
+		WHERE iso_code IS NOT NULL
+		  AND deletion_mark = FALSE
+		  AND _deleted_at IS NULL
+	`
+
+	rows, err := q.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("query currencies: %w", err)
+	}
+	defer rows.Close()
+
+	var result []tenantCurrency
+	for rows.Next() {
+		var c tenantCurrency
+		if err := rows.Scan(&c.ID, &c.ISOCode, &c.IsBase); err != nil {
+			return nil, fmt.Errorf("scan currency: %w", err)
+		}
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
+
+// LoadOfficialSources returns the tenant's active cbr/ecb rate sources.
+// A tenant opts in to the official rates feed by creating such a source.
+func LoadOfficialSources(ctx context.Context) ([]OfficialSource, error) {
+	q := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+
+	const sql = `
+		SELECT id, source_type, base_url
+		FROM cat_rate_sources
+		WHERE source_type IN ($1, $2)
+		  AND is_active = TRUE
+		  AND deletion_mark = FALSE
+		  AND _deleted_at IS NULL
+		ORDER BY priority
+	`
+
+	rows, err := q.Query(ctx, sql, SourceTypeCBR, SourceTypeECB)
+	if err != nil {
+		return nil, fmt.Errorf("query official rate sources: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []OfficialSource
+	for rows.Next() {
+		var s OfficialSource
+		if err := rows.Scan(&s.RateSourceID, &s.SourceType, &s.BaseURL); err != nil {
+			return nil, fmt.Errorf("scan official rate source: %w", err)
+		}
+		sources = append(sources, s)
+	}
+	return sources, rows.Err()
+}
@@ -0,0 +1,139 @@
+// Package requisites provides company requisites lookup backends for
+// filling counterparties by INN.
+package requisites
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/domain/catalogs/counterparty"
+)
+
+// _dadataURL is the DaData "find party by ID" endpoint.
+const _dadataURL = "https://suggestions.dadata.ru/suggestions/api/4_1/rs/findById/party"
+
+// DaDataConfig holds the DaData API settings.
+type DaDataConfig struct {
+	// APIKey is the DaData API key ("Token ..." authorization).
+	APIKey string
+	// URL overrides the findById/party endpoint (tests, proxies).
+	URL string
+}
+
+// DaData implements counterparty.RequisitesProvider over the DaData
+// suggestions API, which mirrors the EGRUL/EGRIP registers.
+type DaData struct {
+	cfg    DaDataConfig
+	client *http.Client
+}
+
+var _ counterparty.RequisitesProvider = (*DaData)(nil)
+
+// NewDaData creates a DaData requisites provider.
+func NewDaData(cfg DaDataConfig) (*DaData, error) {
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("dadata: api key is required")
+	}
+	if cfg.URL == "" {
+		cfg.URL = _dadataURL
+	}
+	return &DaData{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// dadataParty is the subset of the DaData party suggestion we use.
+type dadataParty struct {
+	Value string `json:"value"`
+	Data  struct {
+		INN  string `json:"inn"`
+		KPP  string `json:"kpp"`
+		OGRN string `json:"ogrn"`
+		Type string `json:"type"` // LEGAL or INDIVIDUAL
+		Name struct {
+			FullWithOPF  string `json:"full_with_opf"`
+			ShortWithOPF string `json:"short_with_opf"`
+		} `json:"name"`
+		Management *struct {
+			Name string `json:"name"`
+		} `json:"management"`
+		State struct {
+			Status string `json:"status"` // ACTIVE, LIQUIDATING, LIQUIDATED, ...
+		} `json:"state"`
+		Address *struct {
+			Value string `json:"value"`
+		} `json:"address"`
+	} `json:"data"`
+}
+
+// FindByINN returns the requisites of the main office of the organization
+// (or the sole trader) with the INN.
+func (d *DaData) FindByINN(ctx context.Context, inn string) (*counterparty.Requisites, error) {
+	body, err := json.Marshal(map[string]string{"query": inn, "branch_type": "MAIN"})
+	if err != nil {
+		return nil, fmt.Errorf("dadata: encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("dadata: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Token "+d.cfg.APIKey)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("dadata: request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("dadata: status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var result struct {
+		Suggestions []dadataParty `json:"suggestions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("dadata: decode response: %w", err)
+	}
+	if len(result.Suggestions) == 0 {
+		return nil, apperror.NewNotFound("organization", inn)
+	}
+	return result.Suggestions[0].toRequisites(), nil
+}
+
+// toRequisites maps a party suggestion to counterparty requisites.
+func (p *dadataParty) toRequisites() *counterparty.Requisites {
+	r := &counterparty.Requisites{
+		INN:       p.Data.INN,
+		KPP:       p.Data.KPP,
+		OGRN:      p.Data.OGRN,
+		Name:      p.Data.Name.ShortWithOPF,
+		FullName:  p.Data.Name.FullWithOPF,
+		LegalForm: counterparty.LegalCompany,
+		Active:    p.Data.State.Status == "ACTIVE",
+	}
+	if r.Name == "" {
+		r.Name = p.Value
+	}
+	if p.Data.Type == "INDIVIDUAL" {
+		r.LegalForm = counterparty.LegalSoleTrader
+	}
+	if p.Data.Address != nil {
+		r.LegalAddress = p.Data.Address.Value
+	}
+	if p.Data.Management != nil {
+		r.Director = p.Data.Management.Name
+	}
+	return r
+}
@@ -0,0 +1,57 @@
+package requisites
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/domain/catalogs/counterparty"
+)
+
+func TestDaDataFindByINN(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Token secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		var req map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		if req["query"] != "7707083893" {
+			_, _ = io.WriteString(w, `{"suggestions":[]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"suggestions":[{"value":"ПАО СБЕРБАНК","data":{
+			"inn":"7707083893","kpp":"773601001","ogrn":"1027700132195","type":"LEGAL",
+			"name":{"full_with_opf":"ПУБЛИЧНОЕ АКЦИОНЕРНОЕ ОБЩЕСТВО \"СБЕРБАНК РОССИИ\"","short_with_opf":"ПАО СБЕРБАНК"},
+			"management":{"name":"Греф Герман Оскарович"},
+			"state":{"status":"ACTIVE"},
+			"address":{"value":"г Москва, ул Вавилова, д 19"}}}]}`)
+	}))
+	defer srv.Close()
+
+	d, err := NewDaData(DaDataConfig{APIKey: "secret", URL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := d.FindByINN(context.Background(), "7707083893")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Name != "ПАО СБЕРБАНК" || r.KPP != "773601001" || r.OGRN != "1027700132195" {
+		t.Errorf("unexpected requisites: %+v", r)
+	}
+	if r.LegalForm != counterparty.LegalCompany || !r.Active || r.LegalAddress == "" || r.Director == "" {
+		t.Errorf("unexpected requisites: %+v", r)
+	}
+
+	_, err = d.FindByINN(context.Background(), "500100732259")
+	if !apperror.IsNotFound(err) {
+		t.Errorf("unknown INN: err = %v, want not found", err)
+	}
+}
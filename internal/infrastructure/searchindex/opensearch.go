@@ -0,0 +1,314 @@
+// Package searchindex provides external full-text index backends for global search.
+package searchindex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"metapus/internal/domain/search"
+)
+
+// OpenSearchConfig holds connection settings for an OpenSearch (or
+// Elasticsearch-compatible) cluster.
+type OpenSearchConfig struct {
+	// URL is the cluster endpoint, e.g. http://opensearch:9200.
+	URL      string
+	Username string
+	Password string
+	// IndexPrefix namespaces the per-tenant indices: {prefix}-{tenantID}.
+	IndexPrefix string
+}
+
+// OpenSearch implements search.Index over the OpenSearch REST API.
+// Each tenant has its own index, created with the mapping below on first write.
+type OpenSearch struct {
+	cfg      OpenSearchConfig
+	endpoint string
+	client   *http.Client
+
+	// created caches indices known to exist (index name -> struct{}).
+	created sync.Map
+}
+
+var _ search.Index = (*OpenSearch)(nil)
+
+// _indexMapping is the mapping of a tenant index. "text" is a search-as-you-type
+// field so that prefixes of codes and names match like the Postgres ILIKE search;
+// RLS dimensions are exact-match keywords.
+const _indexMapping = `{
+	"mappings": {
+		"dynamic_templates": [
+			{"dimensions": {"path_match": "dimensions.*", "mapping": {"type": "keyword"}}}
+		],
+		"properties": {
+			"entityKey":    {"type": "keyword"},
+			"entityId":     {"type": "keyword"},
+			"title":        {"type": "keyword", "index": false},
+			"subtitle":     {"type": "keyword", "index": false},
+			"text":         {"type": "search_as_you_type"},
+			"date":         {"type": "date"},
+			"deletionMark": {"type": "boolean"},
+			"dimensions":   {"type": "object"}
+		}
+	}
+}`
+
+// NewOpenSearch creates an OpenSearch index backend.
+func NewOpenSearch(cfg OpenSearchConfig) (*OpenSearch, error) {
+	u, err := url.Parse(strings.TrimSuffix(cfg.URL, "/"))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("opensearch: invalid url %q", cfg.URL)
+	}
+	if cfg.IndexPrefix == "" {
+		cfg.IndexPrefix = "metapus"
+	}
+	return &OpenSearch{
+		cfg:      cfg,
+		endpoint: u.String(),
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// indexName returns the index of a tenant (index names must be lowercase).
+func (o *OpenSearch) indexName(tenantID string) string {
+	return strings.ToLower(o.cfg.IndexPrefix + "-" + tenantID)
+}
+
+// docID returns the document ID of a row: entity key and row ID.
+func docID(entityKey, entityID string) string {
+	return entityKey + ":" + entityID
+}
+
+// Upsert adds or replaces rows with a single bulk request.
+func (o *OpenSearch) Upsert(ctx context.Context, tenantID string, rows []search.IndexedRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	index := o.indexName(tenantID)
+	if err := o.ensureIndex(ctx, index); err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, row := range rows {
+		action := map[string]any{"index": map[string]string{"_index": index, "_id": docID(row.EntityKey, row.EntityID)}}
+		if err := enc.Encode(action); err != nil {
+			return fmt.Errorf("opensearch: encode bulk action: %w", err)
+		}
+		if err := enc.Encode(row); err != nil {
+			return fmt.Errorf("opensearch: encode row: %w", err)
+		}
+	}
+
+	var resp struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int `json:"status"`
+			Error  *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if _, err := o.do(ctx, http.MethodPost, "/_bulk", "application/x-ndjson", &body, &resp); err != nil {
+		return err
+	}
+	if resp.Errors {
+		for _, item := range resp.Items {
+			for _, result := range item {
+				if result.Error != nil {
+					return fmt.Errorf("opensearch: bulk index: %s: %s", result.Error.Type, result.Error.Reason)
+				}
+			}
+		}
+		return fmt.Errorf("opensearch: bulk index failed")
+	}
+	return nil
+}
+
+// Delete removes a row from the tenant's index.
+func (o *OpenSearch) Delete(ctx context.Context, tenantID, entityKey, entityID string) error {
+	path := "/" + o.indexName(tenantID) + "/_doc/" + url.PathEscape(docID(entityKey, entityID))
+	status, err := o.do(ctx, http.MethodDelete, path, "", nil, nil)
+	if status == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// Reset drops the tenant's index; it is recreated on the next Upsert.
+func (o *OpenSearch) Reset(ctx context.Context, tenantID string) error {
+	index := o.indexName(tenantID)
+	o.created.Delete(index)
+	status, err := o.do(ctx, http.MethodDelete, "/"+index, "", nil, nil)
+	if status == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// Search runs the queries with one multi-search request.
+func (o *OpenSearch) Search(ctx context.Context, tenantID, text string, queries []search.IndexQuery) ([][]search.IndexedRow, error) {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, q := range queries {
+		if err := enc.Encode(map[string]any{}); err != nil {
+			return nil, fmt.Errorf("opensearch: encode search header: %w", err)
+		}
+		if err := enc.Encode(searchBody(text, q)); err != nil {
+			return nil, fmt.Errorf("opensearch: encode search body: %w", err)
+		}
+	}
+
+	var resp struct {
+		Responses []struct {
+			Hits struct {
+				Hits []struct {
+					Source search.IndexedRow `json:"_source"`
+				} `json:"hits"`
+			} `json:"hits"`
+			Error *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"responses"`
+	}
+	status, err := o.do(ctx, http.MethodPost, "/"+o.indexName(tenantID)+"/_msearch", "application/x-ndjson", &body, &resp)
+	if status == http.StatusNotFound {
+		// Not indexed yet — nothing to find.
+		return make([][]search.IndexedRow, len(queries)), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([][]search.IndexedRow, len(queries))
+	for i, r := range resp.Responses {
+		if i >= len(hits) {
+			break
+		}
+		if r.Error != nil {
+			return nil, fmt.Errorf("opensearch: search %s: %s: %s", queries[i].EntityKey, r.Error.Type, r.Error.Reason)
+		}
+		for _, h := range r.Hits.Hits {
+			hits[i] = append(hits[i], h.Source)
+		}
+	}
+	return hits, nil
+}
+
+// searchBody builds the query of one entity: prefix match over the search
+// text, filtered by entity, deletion mark and RLS dimensions.
+func searchBody(text string, q search.IndexQuery) map[string]any {
+	filters := []any{
+		map[string]any{"term": map[string]any{"entityKey": q.EntityKey}},
+	}
+	if q.ExcludeDeleted {
+		filters = append(filters, map[string]any{"term": map[string]any{"deletionMark": false}})
+	}
+	for col, values := range q.Filters {
+		filters = append(filters, map[string]any{"terms": map[string]any{"dimensions." + col: values}})
+	}
+
+	body := map[string]any{
+		"size":    q.Limit,
+		"_source": []string{"entityKey", "entityId", "title", "subtitle"},
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must": []any{map[string]any{
+					"multi_match": map[string]any{
+						"query":    text,
+						"type":     "bool_prefix",
+						"operator": "and",
+						"fields":   []string{"text", "text._2gram", "text._3gram"},
+					},
+				}},
+				"filter": filters,
+			},
+		},
+	}
+	if q.SortByDate {
+		body["sort"] = []any{map[string]any{"date": map[string]any{"order": "desc", "unmapped_type": "date"}}}
+	}
+	return body
+}
+
+// ensureIndex creates the index with its mapping unless it is known to exist.
+func (o *OpenSearch) ensureIndex(ctx context.Context, index string) error {
+	if _, ok := o.created.Load(index); ok {
+		return nil
+	}
+	status, err := o.do(ctx, http.MethodHead, "/"+index, "", nil, nil)
+	if err == nil {
+		o.created.Store(index, struct{}{})
+		return nil
+	}
+	if status != http.StatusNotFound {
+		return err
+	}
+
+	var failure struct {
+		Error struct {
+			Type string `json:"type"`
+		} `json:"error"`
+	}
+	_, err = o.do(ctx, http.MethodPut, "/"+index, "application/json", strings.NewReader(_indexMapping), &failure)
+	if err != nil && failure.Error.Type != "resource_already_exists_exception" {
+		return err
+	}
+	o.created.Store(index, struct{}{})
+	return nil
+}
+
+// do sends a request and decodes the JSON response into out (also on
+// failure, so callers can inspect error bodies). Non-2xx statuses are
+// returned as errors together with the status code.
+func (o *OpenSearch) do(ctx context.Context, method, path, contentType string, body io.Reader, out any) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, o.endpoint+path, body)
+	if err != nil {
+		return 0, fmt.Errorf("opensearch: build request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if o.cfg.Username != "" {
+		req.SetBasicAuth(o.cfg.Username, o.cfg.Password)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("opensearch: %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("opensearch: read response: %w", err)
+	}
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil && resp.StatusCode < 300 {
+			return resp.StatusCode, fmt.Errorf("opensearch: decode response: %w", err)
+		}
+	}
+	if resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("opensearch: %s %s: status %d: %s", method, path, resp.StatusCode, truncate(string(data), 512))
+	}
+	return resp.StatusCode, nil
+}
+
+// truncate shortens s to at most n bytes for error messages.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,113 @@
+package searchindex
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"metapus/internal/domain/search"
+)
+
+func TestOpenSearchUpsertCreatesIndexAndBulkIndexes(t *testing.T) {
+	var created bool
+	var bulkLines []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/metapus-tenant-a":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPut && r.URL.Path == "/metapus-tenant-a":
+			created = true
+			_, _ = io.WriteString(w, `{"acknowledged":true}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/_bulk":
+			sc := bufio.NewScanner(r.Body)
+			for sc.Scan() {
+				bulkLines = append(bulkLines, sc.Text())
+			}
+			_, _ = io.WriteString(w, `{"errors":false,"items":[]}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	idx, err := NewOpenSearch(OpenSearchConfig{URL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = idx.Upsert(context.Background(), "Tenant-A", []search.IndexedRow{
+		{EntityKey: "counterparty", EntityID: "c1", Title: "Acme", Text: "Acme 0001"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !created {
+		t.Error("index must be created on first write")
+	}
+	if len(bulkLines) != 2 {
+		t.Fatalf("bulk body lines = %d, want 2", len(bulkLines))
+	}
+	if !strings.Contains(bulkLines[0], `"_id":"counterparty:c1"`) {
+		t.Errorf("bulk action = %s", bulkLines[0])
+	}
+	var row search.IndexedRow
+	if err := json.Unmarshal([]byte(bulkLines[1]), &row); err != nil || row.Title != "Acme" {
+		t.Errorf("bulk source = %s", bulkLines[1])
+	}
+}
+
+func TestOpenSearchSearchMissingIndexReturnsNoHits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"error":{"type":"index_not_found_exception"}}`)
+	}))
+	defer srv.Close()
+
+	idx, err := NewOpenSearch(OpenSearchConfig{URL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hits, err := idx.Search(context.Background(), "t1", "acme", []search.IndexQuery{{EntityKey: "counterparty", Limit: 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 1 || len(hits[0]) != 0 {
+		t.Errorf("hits = %v, want one empty result", hits)
+	}
+}
+
+func TestSearchBodyFilters(t *testing.T) {
+	body := searchBody("acme", search.IndexQuery{
+		EntityKey:      "goods_receipt",
+		Filters:        map[string][]string{"organization_id": {"o1"}},
+		ExcludeDeleted: true,
+		SortByDate:     true,
+		Limit:          5,
+	})
+
+	raw, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(raw)
+	for _, want := range []string{
+		`{"term":{"entityKey":"goods_receipt"}}`,
+		`{"term":{"deletionMark":false}}`,
+		`{"terms":{"dimensions.organization_id":["o1"]}}`,
+		`"sort":[{"date"`,
+		`"size":5`,
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("search body %s: missing %s", s, want)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/accountexport"
+)
+
+// AccountExportRepo implements accountexport.Repository using the tenant database.
+type AccountExportRepo struct{}
+
+// NewAccountExportRepo creates a new account export repository.
+func NewAccountExportRepo() *AccountExportRepo {
+	return &AccountExportRepo{}
+}
+
+const accountExportColumns = `id, status, COALESCE(requested_by, ''), COALESCE(file_name, ''), file_size,
+	manifest, COALESCE(error_message, ''), expires_at, created_at, finished_at`
+
+func scanAccountExport(row pgx.Row) (*accountexport.Export, error) {
+	var e accountexport.Export
+	var manifestJSON []byte
+	err := row.Scan(&e.ID, &e.Status, &e.RequestedBy, &e.FileName, &e.FileSize,
+		&manifestJSON, &e.ErrorMessage, &e.ExpiresAt, &e.CreatedAt, &e.FinishedAt)
+	if err != nil {
+		return nil, err
+	}
+	if manifestJSON != nil {
+		e.Manifest = &accountexport.Manifest{}
+		if err := json.Unmarshal(manifestJSON, e.Manifest); err != nil {
+			return nil, fmt.Errorf("unmarshal export manifest: %w", err)
+		}
+	}
+	return &e, nil
+}
+
+// Create inserts a pending export. The ID is generated by the database.
+func (r *AccountExportRepo) Create(ctx context.Context, e *accountexport.Export) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	err := q.QueryRow(ctx, `
+		INSERT INTO sys_account_exports (status, requested_by)
+		VALUES ($1, NULLIF($2, ''))
+		RETURNING id, created_at`,
+		e.Status, e.RequestedBy,
+	).Scan(&e.ID, &e.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert account export: %w", err)
+	}
+	return nil
+}
+
+// MarkRunning transitions a pending export to running.
+func (r *AccountExportRepo) MarkRunning(ctx context.Context, exportID id.ID) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	tag, err := q.Exec(ctx, `
+		UPDATE sys_account_exports SET status = $2
+		WHERE id = $1 AND status = $3`,
+		exportID, accountexport.StatusRunning, accountexport.StatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("mark account export running: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperror.NewNotFound("account_export", exportID)
+	}
+	return nil
+}
+
+// Complete stores the archive and marks the export completed.
+func (r *AccountExportRepo) Complete(ctx context.Context, exportID id.ID, fileName string, data []byte, manifest *accountexport.Manifest, expiresAt time.Time) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("marshal export manifest: %w", err)
+	}
+
+	_, err = q.Exec(ctx, `
+		UPDATE sys_account_exports
+		SET status = $2, file_name = $3, file_data = $4, file_size = $5,
+		    manifest = $6, expires_at = $7, finished_at = NOW()
+		WHERE id = $1`,
+		exportID, accountexport.StatusCompleted, fileName, data, len(data), manifestJSON, expiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("complete account export: %w", err)
+	}
+	return nil
+}
+
+// Fail marks the export failed.
+func (r *AccountExportRepo) Fail(ctx context.Context, exportID id.ID, errMsg string) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	_, err := q.Exec(ctx, `
+		UPDATE sys_account_exports
+		SET status = $2, error_message = $3, finished_at = NOW()
+		WHERE id = $1`,
+		exportID, accountexport.StatusFailed, errMsg,
+	)
+	if err != nil {
+		return fmt.Errorf("fail account export: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns export metadata without the archive.
+func (r *AccountExportRepo) GetByID(ctx context.Context, exportID id.ID) (*accountexport.Export, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	e, err := scanAccountExport(q.QueryRow(ctx,
+		`SELECT `+accountExportColumns+` FROM sys_account_exports WHERE id = $1`, exportID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFound("account_export", exportID)
+		}
+		return nil, fmt.Errorf("get account export: %w", err)
+	}
+	return e, nil
+}
+
+// GetFile returns the archive of a completed, non-expired export.
+func (r *AccountExportRepo) GetFile(ctx context.Context, exportID id.ID) (string, []byte, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	var fileName string
+	var data []byte
+	err := q.QueryRow(ctx, `
+		SELECT file_name, file_data FROM sys_account_exports
+		WHERE id = $1 AND status = $2 AND expires_at > NOW()`,
+		exportID, accountexport.StatusCompleted,
+	).Scan(&fileName, &data)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil, apperror.NewNotFound("account_export", exportID)
+		}
+		return "", nil, fmt.Errorf("get account export file: %w", err)
+	}
+	return fileName, data, nil
+}
+
+// List returns the most recent exports without archives.
+func (r *AccountExportRepo) List(ctx context.Context, limit int) ([]accountexport.Export, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	rows, err := q.Query(ctx,
+		`SELECT `+accountExportColumns+` FROM sys_account_exports ORDER BY created_at DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list account exports: %w", err)
+	}
+	defer rows.Close()
+
+	var result []accountexport.Export
+	for rows.Next() {
+		e, err := scanAccountExport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan account export: %w", err)
+		}
+		result = append(result, *e)
+	}
+	return result, rows.Err()
+}
+
+// DeleteExpired removes exports past their expiration time.
+func (r *AccountExportRepo) DeleteExpired(ctx context.Context) (int, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	tag, err := q.Exec(ctx, `DELETE FROM sys_account_exports WHERE expires_at < NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired account exports: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// AccountDataSource implements accountexport.DataSource over the tenant database.
+type AccountDataSource struct{}
+
+// NewAccountDataSource creates a new export data source.
+func NewAccountDataSource() *AccountDataSource {
+	return &AccountDataSource{}
+}
+
+// Snapshot runs fn in a REPEATABLE READ, read-only transaction without a
+// statement timeout (large movement tables take longer than the default 30s).
+func (s *AccountDataSource) Snapshot(ctx context.Context, fn func(ctx context.Context) error) error {
+	opts := DefaultTxOptions()
+	opts.IsolationLevel = pgx.RepeatableRead
+	opts.AccessMode = pgx.ReadOnly
+	opts.StatementTimeout = 0
+	return MustGetTxManager(ctx).RunInTransactionWithOptions(ctx, opts, fn)
+}
+
+// ListTables returns base tables of the current schema with their columns.
+func (s *AccountDataSource) ListTables(ctx context.Context) ([]accountexport.TableInfo, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	rows, err := q.Query(ctx, `
+		SELECT c.table_name, array_agg(c.column_name::text ORDER BY c.ordinal_position)
+		FROM information_schema.columns c
+		JOIN information_schema.tables t
+		  ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+		WHERE c.table_schema = current_schema() AND t.table_type = 'BASE TABLE'
+		GROUP BY c.table_name
+		ORDER BY c.table_name`)
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []accountexport.TableInfo
+	for rows.Next() {
+		var t accountexport.TableInfo
+		if err := rows.Scan(&t.Name, &t.Columns); err != nil {
+			return nil, fmt.Errorf("scan table info: %w", err)
+		}
+		tables = append(tables, t)
+	}
+	return tables, rows.Err()
+}
+
+// StreamRows streams table rows as JSON objects (to_jsonb), minus omitted columns.
+func (s *AccountDataSource) StreamRows(ctx context.Context, table string, omit []string, fn func(row []byte) error) (int, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	if omit == nil {
+		omit = []string{}
+	}
+	rows, err := q.Query(ctx,
+		`SELECT to_jsonb(t) - $1::text[] FROM `+pgx.Identifier{table}.Sanitize()+` t`, omit)
+	if err != nil {
+		return 0, fmt.Errorf("select %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return n, fmt.Errorf("scan %s row: %w", table, err)
+		}
+		if err := fn(row); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, rows.Err()
+}
+
+// Ensure interface compliance.
+var (
+	_ accountexport.Repository = (*AccountExportRepo)(nil)
+	_ accountexport.DataSource = (*AccountDataSource)(nil)
+)
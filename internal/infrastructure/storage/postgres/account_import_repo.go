@@ -0,0 +1,340 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/accountimport"
+)
+
+// AccountImportRepo implements accountimport.Repository using the tenant database.
+type AccountImportRepo struct{}
+
+// NewAccountImportRepo creates a new account import repository.
+func NewAccountImportRepo() *AccountImportRepo {
+	return &AccountImportRepo{}
+}
+
+const accountImportColumns = `id, status, COALESCE(requested_by, ''), COALESCE(source_tenant_id, ''), file_size,
+	manifest, report, completed_tables, id_map, COALESCE(error_message, ''), created_at, started_at, finished_at`
+
+func scanAccountImport(row pgx.Row) (*accountimport.Import, error) {
+	var imp accountimport.Import
+	var manifestJSON, reportJSON, idMapJSON []byte
+	err := row.Scan(&imp.ID, &imp.Status, &imp.RequestedBy, &imp.SourceTenantID, &imp.FileSize,
+		&manifestJSON, &reportJSON, &imp.CompletedTables, &idMapJSON, &imp.ErrorMessage,
+		&imp.CreatedAt, &imp.StartedAt, &imp.FinishedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(manifestJSON, &imp.Manifest); err != nil {
+		return nil, fmt.Errorf("unmarshal import manifest: %w", err)
+	}
+	if err := json.Unmarshal(reportJSON, &imp.Report); err != nil {
+		return nil, fmt.Errorf("unmarshal import report: %w", err)
+	}
+	if err := json.Unmarshal(idMapJSON, &imp.IDMap); err != nil {
+		return nil, fmt.Errorf("unmarshal import id map: %w", err)
+	}
+	return &imp, nil
+}
+
+// Create inserts an import with its archive. The ID is generated by the database.
+func (r *AccountImportRepo) Create(ctx context.Context, imp *accountimport.Import, data []byte) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	manifestJSON, err := json.Marshal(imp.Manifest)
+	if err != nil {
+		return fmt.Errorf("marshal import manifest: %w", err)
+	}
+	reportJSON, err := json.Marshal(imp.Report)
+	if err != nil {
+		return fmt.Errorf("marshal import report: %w", err)
+	}
+
+	err = q.QueryRow(ctx, `
+		INSERT INTO sys_account_imports (status, requested_by, source_tenant_id, file_data, file_size, manifest, report)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, $6, $7)
+		RETURNING id, created_at`,
+		imp.Status, imp.RequestedBy, imp.SourceTenantID, data, len(data), manifestJSON, reportJSON,
+	).Scan(&imp.ID, &imp.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert account import: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns import metadata without the archive.
+func (r *AccountImportRepo) GetByID(ctx context.Context, importID id.ID) (*accountimport.Import, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	imp, err := scanAccountImport(q.QueryRow(ctx,
+		`SELECT `+accountImportColumns+` FROM sys_account_imports WHERE id = $1`, importID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFound("account_import", importID)
+		}
+		return nil, fmt.Errorf("get account import: %w", err)
+	}
+	return imp, nil
+}
+
+// GetFile returns the archive.
+func (r *AccountImportRepo) GetFile(ctx context.Context, importID id.ID) ([]byte, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	var data []byte
+	err := q.QueryRow(ctx, `SELECT file_data FROM sys_account_imports WHERE id = $1`, importID).Scan(&data)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFound("account_import", importID)
+		}
+		return nil, fmt.Errorf("get account import file: %w", err)
+	}
+	return data, nil
+}
+
+// List returns the most recent imports without archives.
+func (r *AccountImportRepo) List(ctx context.Context, limit int) ([]accountimport.Import, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	rows, err := q.Query(ctx,
+		`SELECT `+accountImportColumns+` FROM sys_account_imports ORDER BY created_at DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list account imports: %w", err)
+	}
+	defer rows.Close()
+
+	var result []accountimport.Import
+	for rows.Next() {
+		imp, err := scanAccountImport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan account import: %w", err)
+		}
+		result = append(result, *imp)
+	}
+	return result, rows.Err()
+}
+
+// MarkRunning transitions a validated or failed import to running.
+func (r *AccountImportRepo) MarkRunning(ctx context.Context, importID id.ID) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	tag, err := q.Exec(ctx, `
+		UPDATE sys_account_imports
+		SET status = $2, error_message = NULL, started_at = COALESCE(started_at, NOW()), finished_at = NULL
+		WHERE id = $1 AND status IN ($3, $4)`,
+		importID, accountimport.StatusRunning, accountimport.StatusValidated, accountimport.StatusFailed,
+	)
+	if err != nil {
+		return fmt.Errorf("mark account import running: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperror.NewConflict("import is not in a runnable state")
+	}
+	return nil
+}
+
+// SaveProgress records a finished table together with the current ID map and report.
+func (r *AccountImportRepo) SaveProgress(ctx context.Context, importID id.ID, table string, idMap accountimport.IDMap, report *accountimport.Report) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	idMapJSON, err := json.Marshal(idMap)
+	if err != nil {
+		return fmt.Errorf("marshal import id map: %w", err)
+	}
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("marshal import report: %w", err)
+	}
+
+	_, err = q.Exec(ctx, `
+		UPDATE sys_account_imports
+		SET completed_tables = CASE WHEN $2 = ANY(completed_tables) THEN completed_tables
+		                            ELSE array_append(completed_tables, $2) END,
+		    id_map = $3, report = $4
+		WHERE id = $1`,
+		importID, table, idMapJSON, reportJSON,
+	)
+	if err != nil {
+		return fmt.Errorf("save account import progress: %w", err)
+	}
+	return nil
+}
+
+// Complete marks the import completed.
+func (r *AccountImportRepo) Complete(ctx context.Context, importID id.ID) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	_, err := q.Exec(ctx, `
+		UPDATE sys_account_imports SET status = $2, finished_at = NOW()
+		WHERE id = $1`,
+		importID, accountimport.StatusCompleted,
+	)
+	if err != nil {
+		return fmt.Errorf("complete account import: %w", err)
+	}
+	return nil
+}
+
+// Fail marks the import failed.
+func (r *AccountImportRepo) Fail(ctx context.Context, importID id.ID, errMsg string) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	_, err := q.Exec(ctx, `
+		UPDATE sys_account_imports
+		SET status = $2, error_message = $3, finished_at = NOW()
+		WHERE id = $1`,
+		importID, accountimport.StatusFailed, errMsg,
+	)
+	if err != nil {
+		return fmt.Errorf("fail account import: %w", err)
+	}
+	return nil
+}
+
+// AccountImportSink implements accountimport.Sink over the tenant database.
+type AccountImportSink struct{}
+
+// NewAccountImportSink creates a new import sink.
+func NewAccountImportSink() *AccountImportSink {
+	return &AccountImportSink{}
+}
+
+// Tables returns base tables of the current schema with their columns.
+func (s *AccountImportSink) Tables(ctx context.Context) ([]accountimport.TableSchema, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	rows, err := q.Query(ctx, `
+		SELECT c.table_name, c.column_name,
+		       c.is_nullable = 'NO' AND c.column_default IS NULL AND c.is_identity = 'NO' AND c.is_generated = 'NEVER',
+		       c.is_generated = 'ALWAYS' OR c.identity_generation = 'ALWAYS'
+		FROM information_schema.columns c
+		JOIN information_schema.tables t
+		  ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+		WHERE c.table_schema = current_schema() AND t.table_type = 'BASE TABLE'
+		ORDER BY c.table_name, c.ordinal_position`)
+	if err != nil {
+		return nil, fmt.Errorf("list table columns: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []accountimport.TableSchema
+	for rows.Next() {
+		var table string
+		var col accountimport.Column
+		if err := rows.Scan(&table, &col.Name, &col.Required, &col.Generated); err != nil {
+			return nil, fmt.Errorf("scan table column: %w", err)
+		}
+		if n := len(tables); n == 0 || tables[n-1].Name != table {
+			tables = append(tables, accountimport.TableSchema{Name: table})
+		}
+		last := &tables[len(tables)-1]
+		last.Columns = append(last.Columns, col)
+	}
+	return tables, rows.Err()
+}
+
+// ForeignKeys returns single-column foreign keys between tables of the current schema.
+func (s *AccountImportSink) ForeignKeys(ctx context.Context) ([]accountimport.ForeignKey, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	rows, err := q.Query(ctx, `
+		SELECT src.relname, a.attname, ref.relname
+		FROM pg_constraint con
+		JOIN pg_class src ON src.oid = con.conrelid
+		JOIN pg_class ref ON ref.oid = con.confrelid
+		JOIN pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = con.conkey[1]
+		WHERE con.contype = 'f'
+		  AND array_length(con.conkey, 1) = 1
+		  AND src.relnamespace = current_schema()::regnamespace`)
+	if err != nil {
+		return nil, fmt.Errorf("list foreign keys: %w", err)
+	}
+	defer rows.Close()
+
+	var fks []accountimport.ForeignKey
+	for rows.Next() {
+		var fk accountimport.ForeignKey
+		if err := rows.Scan(&fk.Table, &fk.Column, &fk.RefTable); err != nil {
+			return nil, fmt.Errorf("scan foreign key: %w", err)
+		}
+		fks = append(fks, fk)
+	}
+	return fks, rows.Err()
+}
+
+// CountRows returns the number of rows in table.
+func (s *AccountImportSink) CountRows(ctx context.Context, table string) (int64, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	var n int64
+	if err := q.QueryRow(ctx, `SELECT COUNT(*) FROM `+pgx.Identifier{table}.Sanitize()).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count %s: %w", table, err)
+	}
+	return n, nil
+}
+
+// NaturalKeys returns key value -> id for the existing rows of table.
+func (s *AccountImportSink) NaturalKeys(ctx context.Context, table, keyColumn string) (map[string]string, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	rows, err := q.Query(ctx, fmt.Sprintf(`SELECT %s::text, id::text FROM %s WHERE %s IS NOT NULL`,
+		pgx.Identifier{keyColumn}.Sanitize(), pgx.Identifier{table}.Sanitize(), pgx.Identifier{keyColumn}.Sanitize()))
+	if err != nil {
+		return nil, fmt.Errorf("load %s natural keys: %w", table, err)
+	}
+	defer rows.Close()
+
+	keys := make(map[string]string)
+	for rows.Next() {
+		var key, rowID string
+		if err := rows.Scan(&key, &rowID); err != nil {
+			return nil, fmt.Errorf("scan %s natural key: %w", table, err)
+		}
+		keys[key] = rowID
+	}
+	return keys, rows.Err()
+}
+
+// InsertRows inserts JSON rows via jsonb_populate_recordset, so column types
+// are converted by PostgreSQL exactly as to_jsonb produced them on export.
+// Columns not listed keep their defaults.
+func (s *AccountImportSink) InsertRows(ctx context.Context, table string, columns []string, rows [][]byte) (int, error) {
+	if len(rows) == 0 || len(columns) == 0 {
+		return 0, nil
+	}
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	cols := make([]string, len(columns))
+	for i, c := range columns {
+		cols[i] = pgx.Identifier{c}.Sanitize()
+	}
+	colList := strings.Join(cols, ", ")
+	ident := pgx.Identifier{table}.Sanitize()
+
+	payload := append([]byte{'['}, bytes.Join(rows, []byte{','})...)
+	payload = append(payload, ']')
+
+	tag, err := q.Exec(ctx, fmt.Sprintf(
+		`INSERT INTO %s (%s) SELECT %s FROM jsonb_populate_recordset(NULL::%s, $1::jsonb) ON CONFLICT DO NOTHING`,
+		ident, colList, colList, ident), string(payload))
+	if err != nil {
+		return 0, fmt.Errorf("insert into %s: %w", table, err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// Ensure interface compliance.
+var (
+	_ accountimport.Repository = (*AccountImportRepo)(nil)
+	_ accountimport.Sink       = (*AccountImportSink)(nil)
+)
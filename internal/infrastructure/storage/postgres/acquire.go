@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"metapus/pkg/logger"
+)
+
+// ConnAcquirer hands out pool connections with admission control
+// (tenant.ManagedPool: acquisition timeout, queue length tracking).
+type ConnAcquirer interface {
+	Pool() *pgxpool.Pool
+	Acquire(ctx context.Context) (*pgxpool.Conn, error)
+}
+
+// ReplicaSource provides the read pool of a tenant's read replica
+// (tenant.ManagedPool). ReplicaPool returns nil while the tenant has no
+// replica or it is down.
+type ReplicaSource interface {
+	ReplicaPool() *pgxpool.Pool
+	MarkReplicaDown()
+}
+
+// replicaAcquirer takes connections from the read replica and falls back to
+// the primary when the replica cannot be reached.
+type replicaAcquirer struct {
+	replica *pgxpool.Pool
+	source  ReplicaSource
+	primary ConnAcquirer
+}
+
+func (a replicaAcquirer) Pool() *pgxpool.Pool { return a.replica }
+
+func (a replicaAcquirer) Acquire(ctx context.Context) (*pgxpool.Conn, error) {
+	conn, err := a.replica.Acquire(ctx)
+	if err == nil {
+		return conn, nil
+	}
+	if ctx.Err() != nil {
+		return nil, err
+	}
+	a.source.MarkReplicaDown()
+	logger.Warn(ctx, "read replica unavailable, reading from the primary", "error", err)
+	return a.primary.Acquire(ctx)
+}
+
+// replicaAcquirer returns the acquirer of the read replica, or nil if there
+// is no healthy one.
+func (m *TxManager) replicaAcquirer() ConnAcquirer {
+	if m.replicas == nil || m.acquirer == nil {
+		return nil
+	}
+	replica := m.replicas.ReplicaPool()
+	if replica == nil {
+		return nil
+	}
+	return replicaAcquirer{replica: replica, source: m.replicas, primary: m.acquirer}
+}
+
+// acquiringQuerier runs statements outside a transaction on a connection
+// taken through a ConnAcquirer, mirroring what *pgxpool.Pool does with its
+// own Acquire: the connection is released when the result is consumed.
+type acquiringQuerier struct {
+	acquirer ConnAcquirer
+}
+
+func (q acquiringQuerier) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	conn, err := q.acquirer.Acquire(ctx)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	defer conn.Release()
+	return conn.Exec(ctx, sql, args...)
+}
+
+func (q acquiringQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	conn, err := q.acquirer.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		conn.Release()
+		return nil, err
+	}
+	return &releasingRows{Rows: rows, conn: conn}, nil
+}
+
+func (q acquiringQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	conn, err := q.acquirer.Acquire(ctx)
+	if err != nil {
+		return errRow{err: err}
+	}
+	return &releasingRow{row: conn.QueryRow(ctx, sql, args...), conn: conn}
+}
+
+func (q acquiringQuerier) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	conn, err := q.acquirer.Acquire(ctx)
+	if err != nil {
+		return errBatchResults{err: err}
+	}
+	return &releasingBatchResults{BatchResults: conn.SendBatch(ctx, b), conn: conn}
+}
+
+// replicaQuerier serves the plain reads of a replica-hinted context from the
+// read replica and sends everything else to the primary: Exec, batches,
+// statements that write (INSERT ... RETURNING through QueryRow) and locking
+// reads, which a hot standby rejects.
+type replicaQuerier struct {
+	replica, primary acquiringQuerier
+}
+
+func (q replicaQuerier) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return q.primary.Exec(ctx, sql, args...)
+}
+
+func (q replicaQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if replicaSafe(sql) {
+		return q.replica.Query(ctx, sql, args...)
+	}
+	return q.primary.Query(ctx, sql, args...)
+}
+
+func (q replicaQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if replicaSafe(sql) {
+		return q.replica.QueryRow(ctx, sql, args...)
+	}
+	return q.primary.QueryRow(ctx, sql, args...)
+}
+
+func (q replicaQuerier) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	return q.primary.SendBatch(ctx, b)
+}
+
+// sqlPunctuation separates keywords glued to parentheses ("FOR UPDATE)").
+var sqlPunctuation = strings.NewReplacer("(", " ", ")", " ", ";", " ", ",", " ")
+
+// lockingClauses are the row-locking clauses of SELECT, normalized to
+// single spaces.
+var lockingClauses = []string{"FOR UPDATE", "FOR NO KEY UPDATE", "FOR SHARE", "FOR KEY SHARE"}
+
+// replicaSafe reports whether sql is a plain read a hot standby can serve: a
+// SELECT without a row-locking clause. WITH queries may change data in their
+// CTEs and are left to the primary. Functions with side effects (nextval)
+// must run in a transaction, which never uses the replica for writes.
+func replicaSafe(sql string) bool {
+	words := strings.Fields(strings.ToUpper(sqlPunctuation.Replace(sql)))
+	if len(words) == 0 || words[0] != "SELECT" {
+		return false
+	}
+	normalized := " " + strings.Join(words, " ") + " "
+	for _, clause := range lockingClauses {
+		if strings.Contains(normalized, " "+clause+" ") {
+			return false
+		}
+	}
+	return true
+}
+
+// releasingRows releases the connection once the rows are closed or read to
+// the end.
+type releasingRows struct {
+	pgx.Rows
+	conn *pgxpool.Conn
+}
+
+func (r *releasingRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	r.release()
+	return false
+}
+
+func (r *releasingRows) Close() {
+	r.Rows.Close()
+	r.release()
+}
+
+func (r *releasingRows) release() {
+	if r.conn != nil {
+		r.conn.Release()
+		r.conn = nil
+	}
+}
+
+// releasingRow releases the connection after Scan.
+type releasingRow struct {
+	row  pgx.Row
+	conn *pgxpool.Conn
+}
+
+func (r *releasingRow) Scan(dest ...any) error {
+	defer r.conn.Release()
+	return r.row.Scan(dest...)
+}
+
+// errRow is a pgx.Row failing with the acquisition error.
+type errRow struct{ err error }
+
+func (r errRow) Scan(...any) error { return r.err }
+
+// releasingBatchResults releases the connection when the batch is closed.
+type releasingBatchResults struct {
+	pgx.BatchResults
+	conn *pgxpool.Conn
+}
+
+func (b *releasingBatchResults) Close() error {
+	defer b.conn.Release()
+	return b.BatchResults.Close()
+}
+
+// errBatchResults is a pgx.BatchResults failing with the acquisition error.
+type errBatchResults struct{ err error }
+
+func (b errBatchResults) Exec() (pgconn.CommandTag, error) { return pgconn.CommandTag{}, b.err }
+func (b errBatchResults) Query() (pgx.Rows, error)         { return nil, b.err }
+func (b errBatchResults) QueryRow() pgx.Row                { return errRow{err: b.err} }
+func (b errBatchResults) Close() error                     { return b.err }
@@ -0,0 +1,28 @@
+package postgres
+
+import "testing"
+
+func TestReplicaSafe(t *testing.T) {
+	tests := []struct {
+		sql  string
+		safe bool
+	}{
+		{"SELECT id FROM cat_units WHERE id = $1", true},
+		{"\n\t\tselect count(*) from reg_stock_balances", true},
+		{"(SELECT 1) UNION (SELECT 2)", true},
+		{"SELECT quantity FROM reg_stock_balances WHERE warehouse_id = $1\n\t\tFOR UPDATE", false},
+		{"SELECT * FROM sys_jobs FOR NO KEY UPDATE SKIP LOCKED", false},
+		{"SELECT * FROM t WHERE id IN (SELECT id FROM u FOR SHARE)", false},
+		{"select * from t for  key   share", false},
+		{"INSERT INTO t (a) VALUES ($1) RETURNING id", false},
+		{"UPDATE t SET a = 1 RETURNING a", false},
+		{"WITH moved AS (DELETE FROM t RETURNING *) SELECT count(*) FROM moved", false},
+		{"SELECT updated_at, for_update_flag FROM t", true},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := replicaSafe(tt.sql); got != tt.safe {
+			t.Errorf("replicaSafe(%q) = %v, want %v", tt.sql, got, tt.safe)
+		}
+	}
+}
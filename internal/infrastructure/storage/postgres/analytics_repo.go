@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// AnalyticsRepo implements analytics.DocumentCounter over the tenant database.
+type AnalyticsRepo struct{}
+
+// NewAnalyticsRepo creates a new analytics repository.
+func NewAnalyticsRepo() *AnalyticsRepo {
+	return &AnalyticsRepo{}
+}
+
+// CountDocuments returns the number of live (not deleted, not marked for
+// deletion) documents in table and how many of them are posted.
+// table comes from entity metadata, never from user input.
+func (r *AnalyticsRepo) CountDocuments(ctx context.Context, table string) (total, posted int, err error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	err = q.QueryRow(ctx, `
+		SELECT count(*), count(*) FILTER (WHERE posted)
+		FROM `+pgx.Identifier{table}.Sanitize()+`
+		WHERE deletion_mark = FALSE AND _deleted_at IS NULL`,
+	).Scan(&total, &posted)
+	if err != nil {
+		return 0, 0, fmt.Errorf("count documents in %s: %w", table, err)
+	}
+	return total, posted, nil
+}
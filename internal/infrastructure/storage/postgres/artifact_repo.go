@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/artifact"
+)
+
+// ArtifactRepo implements artifact.Repository using the tenant database.
+type ArtifactRepo struct{}
+
+// NewArtifactRepo creates a new artifact repository.
+func NewArtifactRepo() *ArtifactRepo {
+	return &ArtifactRepo{}
+}
+
+const artifactColumns = `id, kind, file_name, content_type, size, checksum, storage_key,
+	COALESCE(created_by, ''), expires_at, created_at`
+
+func scanArtifact(row pgx.Row) (*artifact.Artifact, error) {
+	var a artifact.Artifact
+	err := row.Scan(&a.ID, &a.Kind, &a.FileName, &a.ContentType, &a.Size, &a.Checksum, &a.StorageKey,
+		&a.CreatedBy, &a.ExpiresAt, &a.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// Create inserts the artifact metadata.
+func (r *ArtifactRepo) Create(ctx context.Context, a *artifact.Artifact) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	err := q.QueryRow(ctx, `
+		INSERT INTO sys_artifacts (id, kind, file_name, content_type, size, checksum, storage_key, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9)
+		RETURNING created_at`,
+		a.ID, a.Kind, a.FileName, a.ContentType, a.Size, a.Checksum, a.StorageKey, a.CreatedBy, a.ExpiresAt,
+	).Scan(&a.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert artifact: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns artifact metadata.
+func (r *ArtifactRepo) GetByID(ctx context.Context, artifactID id.ID) (*artifact.Artifact, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	a, err := scanArtifact(q.QueryRow(ctx,
+		`SELECT `+artifactColumns+` FROM sys_artifacts WHERE id = $1`, artifactID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFound("artifact", artifactID)
+		}
+		return nil, fmt.Errorf("get artifact: %w", err)
+	}
+	return a, nil
+}
+
+// List returns the most recent artifacts, optionally of one kind.
+func (r *ArtifactRepo) List(ctx context.Context, f artifact.ListFilter) ([]artifact.Artifact, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	rows, err := q.Query(ctx, `
+		SELECT `+artifactColumns+` FROM sys_artifacts
+		WHERE ($1 = '' OR kind = $1)
+		ORDER BY created_at DESC
+		LIMIT $2`,
+		string(f.Kind), f.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("list artifacts: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]artifact.Artifact, 0)
+	for rows.Next() {
+		a, err := scanArtifact(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan artifact: %w", err)
+		}
+		result = append(result, *a)
+	}
+	return result, rows.Err()
+}
+
+// Delete removes the artifact metadata.
+func (r *ArtifactRepo) Delete(ctx context.Context, artifactID id.ID) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	tag, err := q.Exec(ctx, `DELETE FROM sys_artifacts WHERE id = $1`, artifactID)
+	if err != nil {
+		return fmt.Errorf("delete artifact: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperror.NewNotFound("artifact", artifactID)
+	}
+	return nil
+}
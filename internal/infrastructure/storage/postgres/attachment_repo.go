@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/domain/attachment"
+)
+
+// AttachmentRepo implements attachment.Repository.
+type AttachmentRepo struct{}
+
+// NewAttachmentRepo creates a new attachment repository.
+func NewAttachmentRepo() *AttachmentRepo {
+	return &AttachmentRepo{}
+}
+
+func (r *AttachmentRepo) psql() squirrel.StatementBuilderType {
+	return squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+}
+
+var attachmentColumns = []string{
+	"id", "entity_type", "entity_id", "file_name", "content_type", "size",
+	"checksum", "storage_key", "uploaded_by", "created_at",
+}
+
+func scanAttachment(row pgx.Row, a *attachment.Attachment) error {
+	return row.Scan(
+		&a.ID, &a.EntityType, &a.EntityID, &a.FileName, &a.ContentType, &a.Size,
+		&a.Checksum, &a.StorageKey, &a.UploadedBy, &a.CreatedAt,
+	)
+}
+
+// Create inserts attachment metadata.
+func (r *AttachmentRepo) Create(ctx context.Context, a *attachment.Attachment) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Insert("sys_attachments").
+		Columns("id", "entity_type", "entity_id", "file_name", "content_type", "size",
+			"checksum", "storage_key", "uploaded_by").
+		Values(a.ID, a.EntityType, a.EntityID, a.FileName, a.ContentType, a.Size,
+			a.Checksum, a.StorageKey, a.UploadedBy).
+		Suffix("RETURNING created_at").
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build insert query: %w", err))
+	}
+
+	if err := querier.QueryRow(ctx, query, args...).Scan(&a.CreatedAt); err != nil {
+		return apperror.NewInternal(fmt.Errorf("execute insert: %w", err))
+	}
+	return nil
+}
+
+// GetByID returns a single attachment by ID.
+func (r *AttachmentRepo) GetByID(ctx context.Context, id uuid.UUID) (*attachment.Attachment, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Select(attachmentColumns...).
+		From("sys_attachments").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	var a attachment.Attachment
+	if err := scanAttachment(querier.QueryRow(ctx, query, args...), &a); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFound("attachment", id)
+		}
+		return nil, apperror.NewInternal(fmt.Errorf("scan attachment: %w", err))
+	}
+	return &a, nil
+}
+
+// ListByEntity returns attachments of an entity, newest first.
+func (r *AttachmentRepo) ListByEntity(ctx context.Context, entityType string, entityID uuid.UUID) ([]*attachment.Attachment, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Select(attachmentColumns...).
+		From("sys_attachments").
+		Where(squirrel.Eq{"entity_type": entityType, "entity_id": entityID}).
+		OrderBy("created_at DESC").
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("execute query: %w", err))
+	}
+	defer rows.Close()
+
+	list := make([]*attachment.Attachment, 0)
+	for rows.Next() {
+		a := &attachment.Attachment{}
+		if err := scanAttachment(rows, a); err != nil {
+			return nil, apperror.NewInternal(fmt.Errorf("scan attachment row: %w", err))
+		}
+		list = append(list, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("rows iteration error: %w", err))
+	}
+
+	return list, nil
+}
+
+// Delete removes attachment metadata.
+func (r *AttachmentRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Delete("sys_attachments").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build delete query: %w", err))
+	}
+
+	cmdTag, err := querier.Exec(ctx, query, args...)
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("execute delete: %w", err))
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return apperror.NewNotFound("attachment", id)
+	}
+	return nil
+}
+
+// Ensure interface compliance.
+var _ attachment.Repository = (*AttachmentRepo)(nil)
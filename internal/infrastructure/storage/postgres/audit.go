@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/klauspost/compress/zstd"
 
+	corectx "metapus/internal/core/context"
 	"metapus/internal/core/id"
 	"metapus/internal/core/security"
 )
@@ -45,6 +47,7 @@ type AuditEntry struct {
 	ChangesCompressed []byte          `db:"changes_compressed"`
 	CompressionAlgo   CompressionAlgo `db:"compression_algo"`
 	Metadata          json.RawMessage `db:"metadata"`
+	TraceID           string          `db:"trace_id"`
 	CreatedAt         time.Time       `db:"created_at"`
 }
 
@@ -85,6 +88,12 @@ func (s *AuditService) Log(ctx context.Context, entry AuditEntry) error {
 			entry.UserID = scope.UserID
 		}
 	}
+	if user := corectx.GetUser(ctx); user != nil && entry.UserEmail == "" {
+		entry.UserEmail = user.Email
+	}
+	if trace := corectx.GetTrace(ctx); trace != nil && entry.TraceID == "" {
+		entry.TraceID = trace.TraceID
+	}
 
 	// Generate ID if not set
 	if id.IsNil(entry.ID) {
@@ -109,9 +118,9 @@ func (s *AuditService) Log(ctx context.Context, entry AuditEntry) error {
 	sql := `
 		INSERT INTO sys_audit (
 			id, entity_type, entity_id, action, user_id, user_email,
-			changes, changes_compressed, compression_algo, metadata, 
-			created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			changes, changes_compressed, compression_algo, metadata,
+			trace_id, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''), $12)
 	`
 
 	querier := MustGetTxManager(ctx).GetQuerier(ctx)
@@ -119,7 +128,7 @@ func (s *AuditService) Log(ctx context.Context, entry AuditEntry) error {
 		entry.ID, entry.EntityType, entry.EntityID, entry.Action,
 		entry.UserID, entry.UserEmail,
 		entry.Changes, entry.ChangesCompressed, entry.CompressionAlgo,
-		entry.Metadata, entry.CreatedAt,
+		entry.Metadata, entry.TraceID, entry.CreatedAt,
 	)
 
 	return err
@@ -158,7 +167,7 @@ func (s *AuditService) GetEntityHistory(
 		SELECT a.id, a.entity_type, a.entity_id, a.action, a.user_id,
 			   COALESCE(NULLIF(a.user_email, ''), u.email, a.user_id) AS user_email,
 			   a.changes, a.changes_compressed, a.compression_algo, a.metadata,
-			   a.created_at
+			   COALESCE(a.trace_id, ''), a.created_at
 		FROM sys_audit a
 		LEFT JOIN users u ON u.id::text = a.user_id
 		WHERE a.entity_type = $1 AND a.entity_id = $2
@@ -170,6 +179,12 @@ func (s *AuditService) GetEntityHistory(
 	if err != nil {
 		return nil, fmt.Errorf("query history: %w", err)
 	}
+	return s.scanEntries(rows)
+}
+
+// scanEntries reads audit rows selected by GetEntityHistory / List,
+// decompressing changes where needed.
+func (s *AuditService) scanEntries(rows pgx.Rows) ([]AuditEntry, error) {
 	defer rows.Close()
 
 	var entries []AuditEntry
@@ -178,7 +193,7 @@ func (s *AuditService) GetEntityHistory(
 		err := rows.Scan(
 			&e.ID, &e.EntityType, &e.EntityID, &e.Action, &e.UserID, &e.UserEmail,
 			&e.Changes, &e.ChangesCompressed, &e.CompressionAlgo, &e.Metadata,
-			&e.CreatedAt,
+			&e.TraceID, &e.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan entry: %w", err)
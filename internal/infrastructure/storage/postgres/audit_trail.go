@@ -0,0 +1,255 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/cursor"
+)
+
+// auditIgnoredColumns are maintained by the repositories on every write
+// and would only add noise to the recorded diff.
+var auditIgnoredColumns = []string{"version", "updated_at", "_txid"}
+
+// auditMask replaces values of masked columns in recorded diffs.
+const auditMask = "***"
+
+var (
+	defaultAuditOnce sync.Once
+	defaultAudit     *AuditService
+	defaultAuditErr  error
+)
+
+// DefaultAuditService returns the process-wide AuditService used by the base
+// catalog and document repositories to record the audit trail.
+func DefaultAuditService() (*AuditService, error) {
+	defaultAuditOnce.Do(func() {
+		defaultAudit, defaultAuditErr = NewAuditService()
+	})
+	return defaultAudit, defaultAuditErr
+}
+
+// AuditBeforeCTE is prefixed to an UPDATE by ID of a base repository so that
+// AuditReturning can return the row state before the update. Its only
+// argument is the row ID.
+func AuditBeforeCTE(table string) string {
+	return "WITH audit_before AS (SELECT to_jsonb(t) AS state FROM " + table + " t WHERE t.id = ?)"
+}
+
+// AuditReturning lists the RETURNING expressions with the row states before
+// (requires AuditBeforeCTE) and after an UPDATE of table.
+func AuditReturning(table string) string {
+	return "(SELECT state FROM audit_before), to_jsonb(" + table + ".*)"
+}
+
+// LogRowChange records a change of a table row. before and after are the row
+// states as returned by to_jsonb(): before is nil for an insert, after is nil
+// for a physical delete. The action is derived from the states (see RowChangeAction).
+// Values of masked columns are replaced by "***".
+// The entry is written in the caller's transaction.
+func (s *AuditService) LogRowChange(
+	ctx context.Context,
+	entityType string,
+	entityID id.ID,
+	before, after json.RawMessage,
+	masked map[string]struct{},
+) error {
+	action, changes, err := rowChanges(before, after, masked)
+	if err != nil {
+		return fmt.Errorf("audit %s: %w", entityType, err)
+	}
+	if err := s.LogChange(ctx, entityType, entityID, action, changes); err != nil {
+		return fmt.Errorf("audit %s: %w", entityType, err)
+	}
+	return nil
+}
+
+// rowChanges classifies a row change and computes its diff.
+func rowChanges(before, after json.RawMessage, masked map[string]struct{}) (AuditAction, map[string]any, error) {
+	oldState, err := decodeRowState(before)
+	if err != nil {
+		return "", nil, err
+	}
+	newState, err := decodeRowState(after)
+	if err != nil {
+		return "", nil, err
+	}
+
+	action := RowChangeAction(oldState, newState)
+
+	for _, col := range auditIgnoredColumns {
+		delete(oldState, col)
+		delete(newState, col)
+	}
+	changes := Diff(oldState, newState)
+	for col := range masked {
+		if change, ok := changes[col].(map[string]any); ok {
+			for k, v := range change {
+				if v != nil {
+					change[k] = auditMask
+				}
+			}
+		}
+	}
+	return action, changes, nil
+}
+
+// RowChangeAction classifies a row change: insert is create, physical delete
+// or setting the deletion mark is delete, a change of the posted flag is
+// post / unpost, anything else is update.
+func RowChangeAction(before, after map[string]any) AuditAction {
+	switch {
+	case before == nil:
+		return AuditActionCreate
+	case after == nil:
+		return AuditActionDelete
+	case before["deletion_mark"] == false && after["deletion_mark"] == true:
+		return AuditActionDelete
+	case before["posted"] == false && after["posted"] == true:
+		return AuditActionPost
+	case before["posted"] == true && after["posted"] == false:
+		return AuditActionUnpost
+	}
+	return AuditActionUpdate
+}
+
+// decodeRowState decodes a to_jsonb() row state; nil stays nil.
+func decodeRowState(raw json.RawMessage) (map[string]any, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var state map[string]any
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, fmt.Errorf("decode row state: %w", err)
+	}
+	return state, nil
+}
+
+// AuditFilter selects audit trail entries.
+type AuditFilter struct {
+	EntityType string
+	EntityID   *id.ID
+	UserID     string
+	Action     AuditAction
+	TraceID    string
+	DateFrom   *time.Time
+	DateTo     *time.Time
+	After      string // cursor returned as NextCursor by the previous page
+	Limit      int
+}
+
+// auditCursorFields is the sort order of List, encoded into its cursors.
+var auditCursorFields = []string{"-created_at", "-id"}
+
+// AuditPage is a page of audit trail entries, newest first.
+type AuditPage struct {
+	Items      []AuditEntry
+	NextCursor string
+	HasMore    bool
+}
+
+// List returns audit trail entries matching the filter, newest first,
+// with forward cursor pagination.
+func (s *AuditService) List(ctx context.Context, f AuditFilter) (AuditPage, error) {
+	where := make([]string, 0, 8)
+	args := make([]any, 0, 10)
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		where = append(where, strings.ReplaceAll(cond, "?", "$"+strconv.Itoa(len(args))))
+	}
+
+	if f.EntityType != "" {
+		add("a.entity_type = ?", f.EntityType)
+	}
+	if f.EntityID != nil {
+		add("a.entity_id = ?", *f.EntityID)
+	}
+	if f.UserID != "" {
+		add("a.user_id = ?", f.UserID)
+	}
+	if f.Action != "" {
+		add("a.action = ?", string(f.Action))
+	}
+	if f.TraceID != "" {
+		add("a.trace_id = ?", f.TraceID)
+	}
+	if f.DateFrom != nil {
+		add("a.created_at >= ?", *f.DateFrom)
+	}
+	if f.DateTo != nil {
+		add("a.created_at <= ?", *f.DateTo)
+	}
+	if f.After != "" {
+		createdAt, entryID, err := decodeAuditCursor(f.After)
+		if err != nil {
+			return AuditPage{}, err
+		}
+		args = append(args, createdAt, entryID)
+		where = append(where, fmt.Sprintf("(a.created_at, a.id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	args = append(args, limit+1)
+
+	sql := `
+		SELECT a.id, a.entity_type, a.entity_id, a.action, a.user_id,
+			   COALESCE(NULLIF(a.user_email, ''), u.email, a.user_id) AS user_email,
+			   a.changes, a.changes_compressed, a.compression_algo, a.metadata,
+			   COALESCE(a.trace_id, ''), a.created_at
+		FROM sys_audit a
+		LEFT JOIN users u ON u.id::text = a.user_id`
+	if len(where) > 0 {
+		sql += "\n\t\tWHERE " + strings.Join(where, " AND ")
+	}
+	sql += fmt.Sprintf("\n\t\tORDER BY a.created_at DESC, a.id DESC\n\t\tLIMIT $%d", len(args))
+
+	rows, err := MustGetTxManager(ctx).GetQuerier(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return AuditPage{}, fmt.Errorf("query audit trail: %w", err)
+	}
+	entries, err := s.scanEntries(rows)
+	if err != nil {
+		return AuditPage{}, err
+	}
+
+	page := AuditPage{Items: entries}
+	if len(entries) > limit {
+		page.Items = entries[:limit]
+		page.HasMore = true
+		last := page.Items[limit-1]
+		page.NextCursor, err = cursor.Encode(auditCursorFields, []any{last.CreatedAt.Format(time.RFC3339Nano), last.ID.String()})
+		if err != nil {
+			return AuditPage{}, err
+		}
+	}
+	return page, nil
+}
+
+// decodeAuditCursor parses a cursor produced by List.
+func decodeAuditCursor(token string) (time.Time, id.ID, error) {
+	p, err := cursor.Decode(token)
+	if err != nil || len(p.Values) != 2 || strings.Join(p.Fields, ",") != strings.Join(auditCursorFields, ",") {
+		return time.Time{}, id.ID{}, apperror.NewValidation("invalid cursor")
+	}
+	rawTime, _ := p.Values[0].(string)
+	rawID, _ := p.Values[1].(string)
+	createdAt, err := time.Parse(time.RFC3339Nano, rawTime)
+	if err != nil {
+		return time.Time{}, id.ID{}, apperror.NewValidation("invalid cursor")
+	}
+	entryID, err := id.Parse(rawID)
+	if err != nil {
+		return time.Time{}, id.ID{}, apperror.NewValidation("invalid cursor")
+	}
+	return createdAt, entryID, nil
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRowChanges(t *testing.T) {
+	cases := []struct {
+		name          string
+		before, after string
+		want          AuditAction
+	}{
+		{"insert", ``, `{"id":"1","posted":false}`, AuditActionCreate},
+		{"hard delete", `{"id":"1"}`, ``, AuditActionDelete},
+		{"deletion mark", `{"deletion_mark":false}`, `{"deletion_mark":true}`, AuditActionDelete},
+		{"post", `{"posted":false}`, `{"posted":true}`, AuditActionPost},
+		{"unpost", `{"posted":true}`, `{"posted":false}`, AuditActionUnpost},
+		{"update", `{"posted":true,"name":"a"}`, `{"posted":true,"name":"b"}`, AuditActionUpdate},
+	}
+	for _, tc := range cases {
+		action, _, err := rowChanges(json.RawMessage(tc.before), json.RawMessage(tc.after), nil)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if action != tc.want {
+			t.Errorf("%s: action = %q, want %q", tc.name, action, tc.want)
+		}
+	}
+}
+
+func TestRowChangesDiff(t *testing.T) {
+	before := `{"name":"old","api_key":"secret-1","version":1,"updated_at":"2026-01-01"}`
+	after := `{"name":"new","api_key":"secret-2","version":2,"updated_at":"2026-01-02"}`
+
+	_, changes, err := rowChanges(json.RawMessage(before), json.RawMessage(after),
+		map[string]struct{}{"api_key": {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := changes["version"]; ok {
+		t.Error("version must not be recorded")
+	}
+	if _, ok := changes["updated_at"]; ok {
+		t.Error("updated_at must not be recorded")
+	}
+	name, _ := changes["name"].(map[string]any)
+	if name["old"] != "old" || name["new"] != "new" {
+		t.Errorf("name change = %v", changes["name"])
+	}
+	key, _ := changes["api_key"].(map[string]any)
+	if key["old"] != auditMask || key["new"] != auditMask {
+		t.Errorf("api_key change must be masked, got %v", changes["api_key"])
+	}
+}
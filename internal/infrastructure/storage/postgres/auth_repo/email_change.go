@@ -0,0 +1,96 @@
+package auth_repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/auth"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+// EmailChangeRepo implements auth.EmailChangeRepository.
+// In Database-per-Tenant, TxManager is obtained from context.
+type EmailChangeRepo struct{}
+
+// NewEmailChangeRepo creates a new email change request repository.
+func NewEmailChangeRepo() *EmailChangeRepo {
+	return &EmailChangeRepo{}
+}
+
+func (r *EmailChangeRepo) getTxManager(ctx context.Context) *postgres.TxManager {
+	return postgres.MustGetTxManager(ctx)
+}
+
+// Create saves a new email change request.
+func (r *EmailChangeRepo) Create(ctx context.Context, req *auth.EmailChangeRequest) error {
+	q := r.getTxManager(ctx).GetQuerier(ctx)
+
+	const query = `
+		INSERT INTO email_change_requests (id, user_id, old_email, new_email, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := q.Exec(ctx, query,
+		req.ID, req.UserID, req.OldEmail, req.NewEmail, req.TokenHash, req.ExpiresAt, req.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create email change request: %w", err)
+	}
+	return nil
+}
+
+// GetByTokenHash retrieves a request by token hash and locks it (FOR UPDATE).
+func (r *EmailChangeRepo) GetByTokenHash(ctx context.Context, tokenHash string) (*auth.EmailChangeRequest, error) {
+	q := r.getTxManager(ctx).GetQuerier(ctx)
+
+	const query = `
+		SELECT id, user_id, old_email, new_email, token_hash, expires_at, created_at, confirmed_at, cancelled_at
+		FROM email_change_requests WHERE token_hash = $1
+		FOR UPDATE
+	`
+
+	var req auth.EmailChangeRequest
+	err := q.QueryRow(ctx, query, tokenHash).Scan(
+		&req.ID, &req.UserID, &req.OldEmail, &req.NewEmail, &req.TokenHash,
+		&req.ExpiresAt, &req.CreatedAt, &req.ConfirmedAt, &req.CancelledAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, apperror.NewNotFound("email_change_request", "")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query email change request: %w", err)
+	}
+	return &req, nil
+}
+
+// MarkConfirmed marks the request as confirmed.
+func (r *EmailChangeRepo) MarkConfirmed(ctx context.Context, requestID id.ID) error {
+	q := r.getTxManager(ctx).GetQuerier(ctx)
+
+	_, err := q.Exec(ctx, `UPDATE email_change_requests SET confirmed_at = now() WHERE id = $1`, requestID)
+	if err != nil {
+		return fmt.Errorf("confirm email change request: %w", err)
+	}
+	return nil
+}
+
+// CancelPending cancels all pending requests of a user.
+func (r *EmailChangeRepo) CancelPending(ctx context.Context, userID id.ID) error {
+	q := r.getTxManager(ctx).GetQuerier(ctx)
+
+	const query = `
+		UPDATE email_change_requests SET cancelled_at = now()
+		WHERE user_id = $1 AND confirmed_at IS NULL AND cancelled_at IS NULL
+	`
+	if _, err := q.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("cancel pending email changes: %w", err)
+	}
+	return nil
+}
+
+// Ensure interface compliance
+var _ auth.EmailChangeRepository = (*EmailChangeRepo)(nil)
@@ -4,6 +4,7 @@ package auth_repo
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
@@ -166,7 +167,7 @@ func (r *RoleRepo) LoadPermissions(ctx context.Context, roleID id.ID) ([]auth.Pe
 	q := r.getTxManager(ctx).GetQuerier(ctx)
 
 	query := `
-		SELECT p.id, p.code, p.name, p.description, p.resource, p.action
+		SELECT p.id, p.code, p.name, p.description, p.resource, p.action, rp.scope
 		FROM permissions p
 		INNER JOIN role_permissions rp ON p.id = rp.permission_id
 		WHERE rp.role_id = $1
@@ -181,13 +182,19 @@ func (r *RoleRepo) LoadPermissions(ctx context.Context, roleID id.ID) ([]auth.Pe
 	var permissions []auth.Permission
 	for rows.Next() {
 		var perm auth.Permission
+		var scope []byte
 		err := rows.Scan(
 			&perm.ID, &perm.Code, &perm.Name, &perm.Description,
-			&perm.Resource, &perm.Action,
+			&perm.Resource, &perm.Action, &scope,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan permission: %w", err)
 		}
+		if scope != nil {
+			if err := json.Unmarshal(scope, &perm.Scope); err != nil {
+				return nil, fmt.Errorf("decode permission scope %s: %w", perm.Code, err)
+			}
+		}
 		permissions = append(permissions, perm)
 	}
 
@@ -225,12 +232,19 @@ func (r *RoleRepo) RevokePermission(ctx context.Context, roleID, permissionID id
 	return nil
 }
 
-// SetPermissions replaces all permissions for a role (delete + bulk insert in current tx).
+// SetPermissions replaces all permissions for a role (in current tx).
+// Permissions the role keeps retain their scope.
 func (r *RoleRepo) SetPermissions(ctx context.Context, roleID id.ID, permissionIDs []id.ID) error {
 	q := r.getTxManager(ctx).GetQuerier(ctx)
 
-	// Delete existing
-	_, err := q.Exec(ctx, `DELETE FROM role_permissions WHERE role_id = $1`, roleID)
+	// Delete the ones no longer granted
+	if permissionIDs == nil {
+		permissionIDs = []id.ID{} // NULL would match nothing
+	}
+	_, err := q.Exec(ctx,
+		`DELETE FROM role_permissions WHERE role_id = $1 AND NOT (permission_id = ANY($2::uuid[]))`,
+		roleID, permissionIDs,
+	)
 	if err != nil {
 		return fmt.Errorf("delete role permissions: %w", err)
 	}
@@ -249,6 +263,33 @@ func (r *RoleRepo) SetPermissions(ctx context.Context, roleID id.ID, permissionI
 	return nil
 }
 
+// SetPermissionScope limits a permission of the role to scope; an empty scope
+// grants it unrestricted again.
+func (r *RoleRepo) SetPermissionScope(ctx context.Context, roleID, permissionID id.ID, scope map[string][]string) error {
+	q := r.getTxManager(ctx).GetQuerier(ctx)
+
+	var raw []byte // NULL = unrestricted
+	if len(scope) > 0 {
+		var err error
+		if raw, err = json.Marshal(scope); err != nil {
+			return fmt.Errorf("encode permission scope: %w", err)
+		}
+	}
+
+	result, err := q.Exec(ctx,
+		`UPDATE role_permissions SET scope = $3 WHERE role_id = $1 AND permission_id = $2`,
+		roleID, permissionID, raw,
+	)
+	if err != nil {
+		return fmt.Errorf("set permission scope: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperror.NewNotFound("role permission", permissionID.String())
+	}
+
+	return nil
+}
+
 // CountUsersByRoleID returns the number of users assigned to a role.
 func (r *RoleRepo) CountUsersByRoleID(ctx context.Context, roleID id.ID) (int, error) {
 	q := r.getTxManager(ctx).GetQuerier(ctx)
@@ -53,7 +53,8 @@ func (r *TokenRepo) GetRefreshToken(ctx context.Context, tokenHash string) (*aut
 	q := r.getTxManager(ctx).GetQuerier(ctx)
 
 	query := `
-		SELECT id, user_id, session_id, token_hash, expires_at, created_at, revoked_at, revoked_reason
+		SELECT id, user_id, session_id, token_hash, expires_at, created_at, revoked_at, revoked_reason,
+		       COALESCE(user_agent, ''), COALESCE(host(ip_address), '')
 		FROM refresh_tokens WHERE token_hash = $1
 		FOR UPDATE
 	`
@@ -62,6 +63,7 @@ func (r *TokenRepo) GetRefreshToken(ctx context.Context, tokenHash string) (*aut
 	err := q.QueryRow(ctx, query, tokenHash).Scan(
 		&token.ID, &token.UserID, &token.SessionID, &token.TokenHash, &token.ExpiresAt,
 		&token.CreatedAt, &token.RevokedAt, &token.RevokedReason,
+		&token.UserAgent, &token.IPAddress,
 	)
 	if err == pgx.ErrNoRows {
 		return nil, apperror.NewNotFound("token", "")
@@ -99,6 +101,58 @@ func (r *TokenRepo) RevokeAllUserTokens(ctx context.Context, userID id.ID, reaso
 	return nil
 }
 
+// RevokeSessionTokens revokes the tokens of one session.
+func (r *TokenRepo) RevokeSessionTokens(ctx context.Context, sessionID id.ID, reason string) error {
+	q := r.getTxManager(ctx).GetQuerier(ctx)
+
+	query := `UPDATE refresh_tokens SET revoked_at = now(), revoked_reason = $2 WHERE session_id = $1 AND revoked_at IS NULL`
+	_, err := q.Exec(ctx, query, sessionID, reason)
+	if err != nil {
+		return fmt.Errorf("revoke session tokens: %w", err)
+	}
+
+	return nil
+}
+
+// ListActiveSessions returns the user's sessions with a live refresh token.
+// Tokens rotate on every refresh, so the live one tells which device used
+// the session last and when.
+func (r *TokenRepo) ListActiveSessions(ctx context.Context, userID id.ID) ([]auth.DeviceSession, error) {
+	q := r.getTxManager(ctx).GetQuerier(ctx)
+
+	query := `
+		SELECT s.id, s.created_at, t.created_at, t.expires_at,
+		       COALESCE(t.user_agent, ''), COALESCE(host(t.ip_address), '')
+		FROM auth_sessions s
+		JOIN LATERAL (
+			SELECT created_at, expires_at, user_agent, ip_address
+			FROM refresh_tokens
+			WHERE session_id = s.id AND revoked_at IS NULL AND expires_at > now()
+			ORDER BY created_at DESC
+			LIMIT 1
+		) t ON TRUE
+		WHERE s.user_id = $1 AND s.revoked_at IS NULL AND s.expires_at > now()
+		ORDER BY t.created_at DESC
+	`
+
+	rows, err := q.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list active sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []auth.DeviceSession
+	for rows.Next() {
+		var s auth.DeviceSession
+		if err := rows.Scan(&s.ID, &s.CreatedAt, &s.LastSeenAt, &s.ExpiresAt, &s.UserAgent, &s.IPAddress); err != nil {
+			return nil, fmt.Errorf("scan active session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, rows.Err()
+}
+
 // CleanupExpiredTokens removes expired tokens.
 func (r *TokenRepo) CleanupExpiredTokens(ctx context.Context) (int, error) {
 	q := r.getTxManager(ctx).GetQuerier(ctx)
@@ -4,7 +4,9 @@ package auth_repo
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/jackc/pgx/v5"
 
@@ -287,7 +289,7 @@ func (r *UserRepo) LoadPermissions(ctx context.Context, userID id.ID) ([]string,
 		FROM permissions p
 		INNER JOIN role_permissions rp ON p.id = rp.permission_id
 		INNER JOIN user_roles ur ON rp.role_id = ur.role_id
-		WHERE ur.user_id = $1
+		WHERE ur.user_id = $1 AND rp.scope IS NULL
 	`
 
 	rows, err := q.Query(ctx, query, userID)
@@ -308,6 +310,67 @@ func (r *UserRepo) LoadPermissions(ctx context.Context, userID id.ID) ([]string,
 	return permissions, nil
 }
 
+// LoadPermissionScopes loads the permissions granted to the user only within
+// a scope. A permission also granted unrestricted by another role is skipped.
+// Scopes of several roles are merged per dimension: the allowed IDs are
+// united, and a dimension limited by any of the roles stays limited.
+func (r *UserRepo) LoadPermissionScopes(ctx context.Context, userID id.ID) (map[string]map[string][]string, error) {
+	q := r.getTxManager(ctx).GetQuerier(ctx)
+
+	query := `
+		SELECT p.code, rp.scope
+		FROM permissions p
+		INNER JOIN role_permissions rp ON p.id = rp.permission_id
+		INNER JOIN user_roles ur ON rp.role_id = ur.role_id
+		WHERE ur.user_id = $1 AND rp.scope IS NOT NULL
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM role_permissions rp2
+			INNER JOIN user_roles ur2 ON rp2.role_id = ur2.role_id
+			WHERE ur2.user_id = $1 AND rp2.permission_id = p.id AND rp2.scope IS NULL
+		  )
+	`
+
+	rows, err := q.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query permission scopes: %w", err)
+	}
+	defer rows.Close()
+
+	var scopes map[string]map[string][]string
+	for rows.Next() {
+		var code string
+		var raw []byte
+		if err := rows.Scan(&code, &raw); err != nil {
+			return nil, fmt.Errorf("scan permission scope: %w", err)
+		}
+		var scope map[string][]string
+		if err := json.Unmarshal(raw, &scope); err != nil {
+			return nil, fmt.Errorf("decode permission scope %s: %w", code, err)
+		}
+		if scopes == nil {
+			scopes = make(map[string]map[string][]string)
+		}
+		merged := scopes[code]
+		if merged == nil {
+			merged = make(map[string][]string, len(scope))
+			scopes[code] = merged
+		}
+		for dim, ids := range scope {
+			for _, v := range ids {
+				if !slices.Contains(merged[dim], v) {
+					merged[dim] = append(merged[dim], v)
+				}
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate permission scopes: %w", err)
+	}
+
+	return scopes, nil
+}
+
 // AssignRole assigns a role to user.
 func (r *UserRepo) AssignRole(ctx context.Context, userID, roleID id.ID, grantedBy id.ID) error {
 	q := r.getTxManager(ctx).GetQuerier(ctx)
@@ -354,5 +417,94 @@ func (r *UserRepo) Exists(ctx context.Context, email string) (bool, error) {
 	return exists, nil
 }
 
+// permittedUsersWhere selects active users granted the permission $1
+// through a role (with or without a scope) and active admins.
+const permittedUsersWhere = `
+		WHERE u.is_active = TRUE AND u.deletion_mark = FALSE
+		  AND (u.is_admin OR EXISTS (
+			SELECT 1
+			FROM user_roles ur
+			INNER JOIN role_permissions rp ON rp.role_id = ur.role_id
+			INNER JOIN permissions p ON p.id = rp.permission_id
+			WHERE ur.user_id = u.id AND p.code = $1
+		  ))
+`
+
+// ListEmailsByPermission returns the emails of active users granted the
+// permission through a role (with or without a scope) and of active admins.
+// Used to address notification emails.
+func (r *UserRepo) ListEmailsByPermission(ctx context.Context, permission string) ([]string, error) {
+	q := r.getTxManager(ctx).GetQuerier(ctx)
+
+	query := `SELECT u.email FROM users u` + permittedUsersWhere + `ORDER BY u.email`
+
+	rows, err := q.Query(ctx, query, permission)
+	if err != nil {
+		return nil, fmt.Errorf("list emails by permission: %w", err)
+	}
+	defer rows.Close()
+
+	var emails []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("scan email: %w", err)
+		}
+		emails = append(emails, email)
+	}
+	return emails, rows.Err()
+}
+
+// ListUserIDsByPermission returns the IDs of the users ListEmailsByPermission
+// selects. Used to address in-app notifications.
+func (r *UserRepo) ListUserIDsByPermission(ctx context.Context, permission string) ([]id.ID, error) {
+	q := r.getTxManager(ctx).GetQuerier(ctx)
+
+	query := `SELECT u.id FROM users u` + permittedUsersWhere + `ORDER BY u.id`
+
+	rows, err := q.Query(ctx, query, permission)
+	if err != nil {
+		return nil, fmt.Errorf("list users by permission: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []id.ID
+	for rows.Next() {
+		var userID id.ID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("scan user id: %w", err)
+		}
+		ids = append(ids, userID)
+	}
+	return ids, rows.Err()
+}
+
+// UpdateEmail replaces the user's email and marks it as verified.
+func (r *UserRepo) UpdateEmail(ctx context.Context, userID id.ID, email string) error {
+	q := r.getTxManager(ctx).GetQuerier(ctx)
+
+	query := `
+		UPDATE users SET
+			email = $2,
+			email_verified = TRUE,
+			email_verified_at = now(),
+			version = version + 1
+		WHERE id = $1 AND deletion_mark = FALSE
+	`
+
+	result, err := q.Exec(ctx, query, userID, email)
+	if err != nil {
+		if postgres.IsUniqueViolation(err) {
+			return apperror.NewConflict("email already registered").WithDetail("email", email)
+		}
+		return fmt.Errorf("update email: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperror.NewNotFound("user", userID.String())
+	}
+
+	return nil
+}
+
 // Ensure interface compliance
 var _ auth.UserRepository = (*UserRepo)(nil)
@@ -0,0 +1,113 @@
+package catalog_repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/catalogs/bank_account"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+const bankAccountTable = "cat_bank_accounts"
+
+// BankAccountRepo implements bank_account.Repository.
+type BankAccountRepo struct {
+	*BaseCatalogRepo[*bank_account.BankAccount]
+}
+
+// NewBankAccountRepo creates a new bank account repository.
+func NewBankAccountRepo() *BankAccountRepo {
+	return &BankAccountRepo{
+		BaseCatalogRepo: NewBaseCatalogRepo[*bank_account.BankAccount](
+			bankAccountTable,
+			postgres.ExtractDBColumns[bank_account.BankAccount](),
+			func() *bank_account.BankAccount { return &bank_account.BankAccount{} },
+			false, // flat catalog: bank accounts don't support hierarchy
+		),
+	}
+}
+
+// Create inserts a bank account; a default account takes the default flag
+// over from the other accounts of the counterparty.
+func (r *BankAccountRepo) Create(ctx context.Context, a *bank_account.BankAccount) error {
+	if err := r.clearOtherDefaults(ctx, a); err != nil {
+		return err
+	}
+	return r.BaseCatalogRepo.Create(ctx, a)
+}
+
+// Update modifies a bank account; a default account takes the default flag
+// over from the other accounts of the counterparty.
+func (r *BankAccountRepo) Update(ctx context.Context, a *bank_account.BankAccount) error {
+	if err := r.clearOtherDefaults(ctx, a); err != nil {
+		return err
+	}
+	return r.BaseCatalogRepo.Update(ctx, a)
+}
+
+// clearOtherDefaults resets is_default on the other accounts of the
+// counterparty before a is saved as default, keeping
+// uq_cat_bank_accounts_default satisfied. Their version is bumped so
+// concurrent edits of those accounts fail the optimistic lock.
+func (r *BankAccountRepo) clearOtherDefaults(ctx context.Context, a *bank_account.BankAccount) error {
+	if !a.IsDefault || a.DeletionMark {
+		return nil
+	}
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+
+	_, err := querier.Exec(ctx, `
+		UPDATE cat_bank_accounts
+		SET is_default = FALSE, version = version + 1
+		WHERE counterparty_id = $1 AND id <> $2 AND is_default`,
+		a.CounterpartyID, a.ID)
+	if err != nil {
+		return fmt.Errorf("clear default bank accounts: %w", err)
+	}
+	return nil
+}
+
+// FindByCounterparty retrieves the accounts of a counterparty, the default
+// one first.
+func (r *BankAccountRepo) FindByCounterparty(ctx context.Context, counterpartyID id.ID) ([]*bank_account.BankAccount, error) {
+	q := r.baseSelect(ctx).
+		Where(squirrel.Eq{"counterparty_id": counterpartyID}).
+		Where(squirrel.Eq{"deletion_mark": false}).
+		OrderBy("is_default DESC", "name ASC")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var accounts []*bank_account.BankAccount
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &accounts, sql, args...); err != nil {
+		return nil, fmt.Errorf("find by counterparty: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// GetDefault retrieves the default account of a counterparty.
+func (r *BankAccountRepo) GetDefault(ctx context.Context, counterpartyID id.ID) (*bank_account.BankAccount, error) {
+	q := r.baseSelect(ctx).
+		Where(squirrel.Eq{"counterparty_id": counterpartyID}).
+		Where(squirrel.Eq{"is_default": true}).
+		Where(squirrel.Eq{"deletion_mark": false})
+
+	account, err := r.FindOne(ctx, q)
+	if apperror.IsNotFound(err) {
+		return nil, apperror.NewNotFound("default bank account", counterpartyID.String())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+var _ bank_account.Repository = (*BankAccountRepo)(nil)
@@ -44,6 +44,14 @@ type BaseCatalogRepo[T any] struct {
 	// Configured via RegisterRLSDimension. At query time, DataScope.ApplyConditions
 	// uses this map to inject WHERE conditions for matching dimensions.
 	rlsDimensions map[string]string // e.g. {"organization": "organization_id"}
+
+	// audit records every write into the audit trail (sys_audit).
+	// nil disables the audit trail.
+	audit *postgres.AuditService
+
+	// auditMaskedCols are recorded in the audit trail without their values.
+	// Configured via RegisterAuditMaskedColumn.
+	auditMaskedCols map[string]struct{}
 }
 
 // NewBaseCatalogRepo creates a new base catalog repository.
@@ -79,6 +87,7 @@ func NewBaseCatalogRepo[T any](
 		validCols:    validCols,
 		orderCols:    orderCols,
 		hierarchical: hierarchical,
+		audit:        auditService(),
 	}
 }
 
@@ -100,6 +109,35 @@ func (r *BaseCatalogRepo[T]) RegisterRLSDimension(dimensionName, dbColumn string
 	r.rlsDimensions[dimensionName] = dbColumn
 }
 
+// RegisterAuditMaskedColumn keeps the values of a secret column (API keys,
+// credentials) out of the audit trail; the trail still shows that it changed.
+func (r *BaseCatalogRepo[T]) RegisterAuditMaskedColumn(dbColumn string) {
+	if r.auditMaskedCols == nil {
+		r.auditMaskedCols = make(map[string]struct{})
+	}
+	r.auditMaskedCols[dbColumn] = struct{}{}
+}
+
+// auditService returns the shared audit trail writer, or nil if it is unavailable.
+func auditService() *postgres.AuditService {
+	svc, err := postgres.DefaultAuditService()
+	if err != nil {
+		return nil
+	}
+	return svc
+}
+
+// recordChange records a row change into the audit trail and queues the row
+// for the external search index, both within the current transaction.
+func (r *BaseCatalogRepo[T]) recordChange(ctx context.Context, entityID id.ID, before, after []byte) error {
+	if r.audit != nil {
+		if err := r.audit.LogRowChange(ctx, r.tableName, entityID, before, after, r.auditMaskedCols); err != nil {
+			return err
+		}
+	}
+	return postgres.EnqueueSearchIndex(ctx, r.tableName, entityID)
+}
+
 // getTxManager retrieves TxManager from context.
 // Panics if not found - this indicates a programming error (missing TenantDB middleware).
 func (r *BaseCatalogRepo[T]) getTxManager(ctx context.Context) *postgres.TxManager {
@@ -146,7 +184,8 @@ func (r *BaseCatalogRepo[T]) Create(ctx context.Context, entity T) error {
 
 	q := r.Builder().
 		Insert(r.tableName).
-		SetMap(filteredData)
+		SetMap(filteredData).
+		Suffix("RETURNING id, to_jsonb(" + r.tableName + ".*)")
 
 	sql, args, err := q.ToSql()
 	if err != nil {
@@ -154,8 +193,13 @@ func (r *BaseCatalogRepo[T]) Create(ctx context.Context, entity T) error {
 	}
 
 	querier := r.getTxManager(ctx).GetQuerier(ctx)
-	_, err = querier.Exec(ctx, sql, args...)
+	var entityID id.ID
+	var after []byte
+	err = querier.QueryRow(ctx, sql, args...).Scan(&entityID, &after)
 	if err != nil {
+		if dup := postgres.UniqueRuleViolation(ctx, err); dup != nil {
+			return dup
+		}
 		if postgres.IsForeignKeyViolation(err) {
 			field := postgres.ExtractForeignKeyField(err, r.tableName)
 			return apperror.NewBusinessRule("INVALID_REFERENCE", "Связанный элемент удален. Выберите другой.").
@@ -164,9 +208,141 @@ func (r *BaseCatalogRepo[T]) Create(ctx context.Context, entity T) error {
 		return fmt.Errorf("insert %s: %w", r.tableName, err)
 	}
 
+	return r.recordChange(ctx, entityID, nil, after)
+}
+
+// CreateBatch inserts entities with a single COPY (catalog import) and records
+// every row in the audit trail. Implements domain.CatalogBatchRepository.
+// Must run inside a transaction.
+func (r *BaseCatalogRepo[T]) CreateBatch(ctx context.Context, entities []T) error {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	first := postgres.StructToMap(entities[0])
+	if len(first) == 0 {
+		return fmt.Errorf("no db tags found in entity")
+	}
+	columns := make([]string, 0, len(r.selectCols))
+	for _, col := range r.selectCols {
+		if _, ok := first[col]; ok {
+			columns = append(columns, col)
+		}
+	}
+
+	rows := make([][]any, len(entities))
+	ids := make([]id.ID, len(entities))
+	for i, ent := range entities {
+		data := postgres.StructToMap(ent)
+		row := make([]any, len(columns))
+		for j, col := range columns {
+			row[j] = data[col]
+		}
+		rows[i] = row
+		if entityID, ok := data["id"].(id.ID); ok {
+			ids[i] = entityID
+		}
+	}
+
+	txm := r.getTxManager(ctx)
+	if _, err := postgres.NewBatchInserter(txm).CopyFromSlice(ctx, r.tableName, columns, rows); err != nil {
+		if postgres.IsForeignKeyViolation(err) {
+			field := postgres.ExtractForeignKeyField(err, r.tableName)
+			return apperror.NewBusinessRule("INVALID_REFERENCE", "Связанный элемент удален. Выберите другой.").
+				WithDetail("field", field)
+		}
+		if dup := postgres.UniqueRuleViolation(ctx, err); dup != nil {
+			return dup
+		}
+		if postgres.IsUniqueViolation(err) {
+			return apperror.NewConflict("imported rows conflict with existing " + r.tableName + " records")
+		}
+		return fmt.Errorf("copy into %s: %w", r.tableName, err)
+	}
+
+	// COPY has no RETURNING — read the inserted rows back for the audit trail.
+	querier := txm.GetQuerier(ctx)
+	dbRows, err := querier.Query(ctx,
+		"SELECT id, to_jsonb(t.*) FROM "+r.tableName+" t WHERE id = ANY($1)", ids)
+	if err != nil {
+		return fmt.Errorf("read back %s: %w", r.tableName, err)
+	}
+	type inserted struct {
+		id    id.ID
+		after []byte
+	}
+	written := make([]inserted, 0, len(ids))
+	for dbRows.Next() {
+		var row inserted
+		if err := dbRows.Scan(&row.id, &row.after); err != nil {
+			dbRows.Close()
+			return fmt.Errorf("scan %s: %w", r.tableName, err)
+		}
+		written = append(written, row)
+	}
+	dbRows.Close()
+	if err := dbRows.Err(); err != nil {
+		return fmt.Errorf("read back %s: %w", r.tableName, err)
+	}
+
+	for _, row := range written {
+		if err := r.recordChange(ctx, row.id, nil, row.after); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// ExistingCodes returns which of codes are already used by entities not
+// marked for deletion (implements domain.CatalogBatchRepository).
+func (r *BaseCatalogRepo[T]) ExistingCodes(ctx context.Context, codes []string) (map[string]struct{}, error) {
+	q, args, err := r.Builder().
+		Select("code").
+		From(r.tableName).
+		Where(squirrel.Eq{"code": codes}).
+		Where(squirrel.Eq{"deletion_mark": false}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+	rows, err := querier.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("existing codes: %w", err)
+	}
+	defer rows.Close()
+
+	existing := make(map[string]struct{})
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, fmt.Errorf("scan code: %w", err)
+		}
+		existing[code] = struct{}{}
+	}
+	return existing, rows.Err()
+}
+
+// LockByIDs returns the entities with ids locked FOR UPDATE (implements
+// domain.CatalogBatchRepository). Must run inside a transaction.
+func (r *BaseCatalogRepo[T]) LockByIDs(ctx context.Context, ids []id.ID) ([]T, error) {
+	q, args, err := r.baseSelect(ctx).
+		Where(squirrel.Eq{"id": ids}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var entities []T
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &entities, q, args...); err != nil {
+		return nil, fmt.Errorf("lock %s: %w", r.tableName, err)
+	}
+	return entities, nil
+}
+
 // Update modifies an existing entity with optimistic locking.
 func (r *BaseCatalogRepo[T]) Update(ctx context.Context, entity T) error {
 	data := postgres.StructToMap(entity)
@@ -200,11 +376,12 @@ func (r *BaseCatalogRepo[T]) Update(ctx context.Context, entity T) error {
 
 	q := r.Builder().
 		Update(r.tableName).
+		Prefix(postgres.AuditBeforeCTE(r.tableName), entityID).
 		SetMap(filteredData).
 		Set("version", squirrel.Expr("version + 1")).
 		Where(squirrel.Eq{"id": entityID}).
 		Where(squirrel.Eq{"version": version}).
-		Suffix("RETURNING version")
+		Suffix("RETURNING version, " + postgres.AuditReturning(r.tableName))
 
 	sql, args, err := q.ToSql()
 	if err != nil {
@@ -214,11 +391,15 @@ func (r *BaseCatalogRepo[T]) Update(ctx context.Context, entity T) error {
 	querier := r.getTxManager(ctx).GetQuerier(ctx)
 
 	var newVersion int
-	err = querier.QueryRow(ctx, sql, args...).Scan(&newVersion)
+	var before, after []byte
+	err = querier.QueryRow(ctx, sql, args...).Scan(&newVersion, &before, &after)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return apperror.NewConcurrentModification(r.tableName, entityID)
 		}
+		if dup := postgres.UniqueRuleViolation(ctx, err); dup != nil {
+			return dup
+		}
 		if postgres.IsForeignKeyViolation(err) {
 			field := postgres.ExtractForeignKeyField(err, r.tableName)
 			return apperror.NewBusinessRule("INVALID_REFERENCE", "Связанный элемент удален. Выберите другой.").
@@ -232,7 +413,8 @@ func (r *BaseCatalogRepo[T]) Update(ctx context.Context, entity T) error {
 		v.SetVersion(newVersion)
 	}
 
-	return nil
+	catalogID, _ := entityID.(id.ID)
+	return r.recordChange(ctx, catalogID, before, after)
 }
 
 // baseSelect creates a SELECT builder.
@@ -733,15 +915,20 @@ func (r *BaseCatalogRepo[T]) ExistsByCode(ctx context.Context, code string) (boo
 func (r *BaseCatalogRepo[T]) Delete(ctx context.Context, entityID id.ID) error {
 	q := r.Builder().
 		Delete(r.tableName).
-		Where(squirrel.Eq{"id": entityID})
+		Where(squirrel.Eq{"id": entityID}).
+		Suffix("RETURNING to_jsonb(" + r.tableName + ".*)")
 
 	sql, args, err := q.ToSql()
 	if err != nil {
 		return fmt.Errorf("build delete: %w", err)
 	}
 
-	result, err := r.getTxManager(ctx).GetQuerier(ctx).Exec(ctx, sql, args...)
+	var before []byte
+	err = r.getTxManager(ctx).GetQuerier(ctx).QueryRow(ctx, sql, args...).Scan(&before)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.NewNotFound(r.tableName, entityID.String())
+		}
 		// Check for foreign key violation (23503)
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
@@ -753,36 +940,33 @@ func (r *BaseCatalogRepo[T]) Delete(ctx context.Context, entityID id.ID) error {
 		return fmt.Errorf("execute delete %s: %w", r.tableName, err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return apperror.NewNotFound(r.tableName, entityID.String())
-	}
-
-	return nil
+	return r.recordChange(ctx, entityID, before, nil)
 }
 
 // SetDeletionMark sets or clears the deletion mark (soft delete).
 func (r *BaseCatalogRepo[T]) SetDeletionMark(ctx context.Context, entityID id.ID, marked bool) error {
 	q := r.Builder().
 		Update(r.tableName).
+		Prefix(postgres.AuditBeforeCTE(r.tableName), entityID).
 		Set("deletion_mark", marked).
 		Set("version", squirrel.Expr("version + 1")).
-		Where(squirrel.Eq{"id": entityID})
+		Where(squirrel.Eq{"id": entityID}).
+		Suffix("RETURNING " + postgres.AuditReturning(r.tableName))
 
 	sql, args, err := q.ToSql()
 	if err != nil {
 		return fmt.Errorf("build set deletion mark: %w", err)
 	}
 
-	result, err := r.getTxManager(ctx).GetQuerier(ctx).Exec(ctx, sql, args...)
-	if err != nil {
+	var before, after []byte
+	if err := r.getTxManager(ctx).GetQuerier(ctx).QueryRow(ctx, sql, args...).Scan(&before, &after); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.NewNotFound(r.tableName, entityID.String())
+		}
 		return fmt.Errorf("execute set deletion mark: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return apperror.NewNotFound(r.tableName, entityID.String())
-	}
-
-	return nil
+	return r.recordChange(ctx, entityID, before, after)
 }
 
 // GetTree retrieves hierarchical structure using recursive CTE.
@@ -2,10 +2,13 @@ package catalog_repo
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
 
 	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
 	"metapus/internal/domain/catalogs/counterparty"
 	"metapus/internal/infrastructure/storage/postgres"
 )
@@ -45,3 +48,129 @@ func (r *CounterpartyRepo) FindByINN(ctx context.Context, inn string) (*counterp
 	}
 	return cp, nil
 }
+
+// GetByID retrieves counterparty by ID with its contact persons.
+func (r *CounterpartyRepo) GetByID(ctx context.Context, entityID id.ID) (*counterparty.Counterparty, error) {
+	cp, err := r.BaseCatalogRepo.GetByID(ctx, entityID)
+	if err != nil {
+		return cp, err
+	}
+	return cp, r.loadContacts(ctx, cp)
+}
+
+// loadContacts loads the contact persons of cp.
+func (r *CounterpartyRepo) loadContacts(ctx context.Context, cp *counterparty.Counterparty) error {
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+	cp.Contacts = []counterparty.Contact{}
+	err := pgxscan.Select(ctx, querier, &cp.Contacts,
+		`SELECT id, name, role, phone, email FROM cat_counterparty_contacts WHERE counterparty_id = $1 ORDER BY line_no`,
+		cp.ID)
+	if err != nil {
+		return fmt.Errorf("get contacts: %w", err)
+	}
+	return nil
+}
+
+// Create inserts counterparty with its contact persons.
+func (r *CounterpartyRepo) Create(ctx context.Context, cp *counterparty.Counterparty) error {
+	if err := r.BaseCatalogRepo.Create(ctx, cp); err != nil {
+		return err
+	}
+	return r.saveContacts(ctx, cp)
+}
+
+// CreateBatch inserts counterparties with a single COPY, then their contact
+// persons.
+func (r *CounterpartyRepo) CreateBatch(ctx context.Context, items []*counterparty.Counterparty) error {
+	if err := r.BaseCatalogRepo.CreateBatch(ctx, items); err != nil {
+		return err
+	}
+	for _, cp := range items {
+		if err := r.saveContacts(ctx, cp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Update modifies counterparty and, when loaded, replaces its contact persons.
+func (r *CounterpartyRepo) Update(ctx context.Context, cp *counterparty.Counterparty) error {
+	if err := r.BaseCatalogRepo.Update(ctx, cp); err != nil {
+		return err
+	}
+	return r.saveContacts(ctx, cp)
+}
+
+// saveContacts replaces the stored contact persons with the loaded ones;
+// nil contacts are left unchanged. Contacts without an ID get a new one.
+func (r *CounterpartyRepo) saveContacts(ctx context.Context, cp *counterparty.Counterparty) error {
+	if cp.Contacts == nil {
+		return nil
+	}
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+
+	if _, err := querier.Exec(ctx, `DELETE FROM cat_counterparty_contacts WHERE counterparty_id = $1`, cp.ID); err != nil {
+		return fmt.Errorf("delete contacts: %w", err)
+	}
+	if len(cp.Contacts) == 0 {
+		return nil
+	}
+
+	q := r.Builder().
+		Insert("cat_counterparty_contacts").
+		Columns("id", "counterparty_id", "line_no", "name", "role", "phone", "email")
+	for i := range cp.Contacts {
+		contact := &cp.Contacts[i]
+		if id.IsNil(contact.ID) {
+			contact.ID = id.New()
+		}
+		q = q.Values(contact.ID, cp.ID, i+1, contact.Name, contact.Role, contact.Phone, contact.Email)
+	}
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("build insert: %w", err)
+	}
+	if _, err := querier.Exec(ctx, sql, args...); err != nil {
+		if postgres.IsUniqueViolation(err) {
+			return apperror.NewConflict("contact belongs to another counterparty").
+				WithDetail("field", "contacts")
+		}
+		return fmt.Errorf("insert contacts: %w", err)
+	}
+	return nil
+}
+
+// FindContacts searches contact persons by phone key (last 10 digits) or
+// email, skipping counterparties marked for deletion.
+func (r *CounterpartyRepo) FindContacts(ctx context.Context, filter counterparty.ContactFilter) ([]counterparty.ContactMatch, error) {
+	match := squirrel.Or{}
+	if filter.Phone != "" {
+		match = append(match, squirrel.Expr(`right(regexp_replace(c.phone, '\D', '', 'g'), 10) = ?`, filter.Phone))
+	}
+	if filter.Email != "" {
+		match = append(match, squirrel.Expr("lower(c.email) = lower(?)", filter.Email))
+	}
+
+	q := r.Builder().
+		Select("c.counterparty_id", "p.name AS counterparty_name", "c.id", "c.name", "c.role", "c.phone", "c.email").
+		From("cat_counterparty_contacts c").
+		Join("cat_counterparties p ON p.id = c.counterparty_id").
+		Where(match).
+		Where(squirrel.Eq{"p.deletion_mark": false}).
+		OrderBy("p.name", "c.line_no")
+	if filter.Limit > 0 {
+		q = q.Limit(uint64(filter.Limit))
+	}
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	matches := []counterparty.ContactMatch{}
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &matches, sql, args...); err != nil {
+		return nil, fmt.Errorf("find contacts: %w", err)
+	}
+	return matches, nil
+}
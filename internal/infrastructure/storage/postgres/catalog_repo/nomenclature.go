@@ -8,6 +8,7 @@ import (
 	"github.com/georgysavva/scany/v2/pgxscan"
 
 	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
 	"metapus/internal/domain"
 	"metapus/internal/domain/catalogs/nomenclature"
 	"metapus/internal/infrastructure/storage/postgres"
@@ -32,12 +33,20 @@ func NewNomenclatureRepo() *NomenclatureRepo {
 	}
 }
 
-// FindLowStock retrieves items with stock below minimum.
+// FindLowStock retrieves items with stock below minimum in any warehouse
+// (see reg_stock_levels).
 func (r *NomenclatureRepo) FindLowStock(ctx context.Context, filter domain.ListFilter) (domain.CursorListResult[*nomenclature.Nomenclature], error) {
 	var result domain.CursorListResult[*nomenclature.Nomenclature]
 
 	q := r.baseSelect(ctx).
 		Where(squirrel.Eq{"deletion_mark": false}).
+		Where(`id IN (
+			SELECT l.nomenclature_id
+			FROM reg_stock_levels l
+			LEFT JOIN reg_stock_balances b
+				ON b.warehouse_id = l.warehouse_id AND b.nomenclature_id = l.nomenclature_id
+			WHERE COALESCE(b.quantity, 0) < l.min_quantity
+		)`).
 		OrderBy("name ASC")
 
 	if filter.Limit > 0 {
@@ -73,10 +82,14 @@ func (r *NomenclatureRepo) FindByArticle(ctx context.Context, article string) (*
 	return item, nil
 }
 
-// FindByBarcode retrieves nomenclature by barcode.
+// FindByBarcode retrieves nomenclature by its primary or an additional
+// barcode, with its table parts.
 func (r *NomenclatureRepo) FindByBarcode(ctx context.Context, barcode string) (*nomenclature.Nomenclature, error) {
 	q := r.baseSelect(ctx).
-		Where(squirrel.Eq{"barcode": barcode}).
+		Where(squirrel.Or{
+			squirrel.Eq{"barcode": barcode},
+			squirrel.Expr("id IN (SELECT nomenclature_id FROM cat_nomenclature_barcodes WHERE barcode = ?)", barcode),
+		}).
 		Where(squirrel.Eq{"deletion_mark": false}).
 		Limit(1)
 
@@ -87,5 +100,144 @@ func (r *NomenclatureRepo) FindByBarcode(ctx context.Context, barcode string) (*
 		}
 		return nil, err
 	}
-	return item, nil
+	return item, r.loadTableParts(ctx, item)
+}
+
+// GetByID retrieves nomenclature by ID with its packaging units and
+// additional barcodes.
+func (r *NomenclatureRepo) GetByID(ctx context.Context, entityID id.ID) (*nomenclature.Nomenclature, error) {
+	item, err := r.BaseCatalogRepo.GetByID(ctx, entityID)
+	if err != nil {
+		return item, err
+	}
+	return item, r.loadTableParts(ctx, item)
+}
+
+// loadTableParts loads the packaging units and additional barcodes of item.
+func (r *NomenclatureRepo) loadTableParts(ctx context.Context, item *nomenclature.Nomenclature) error {
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+	item.PackagingUnits = []nomenclature.PackagingUnit{}
+	err := pgxscan.Select(ctx, querier, &item.PackagingUnits,
+		`SELECT unit_id, coefficient FROM cat_nomenclature_units WHERE nomenclature_id = $1 ORDER BY line_no`,
+		item.ID)
+	if err != nil {
+		return fmt.Errorf("get packaging units: %w", err)
+	}
+
+	item.Barcodes = []string{}
+	err = pgxscan.Select(ctx, querier, &item.Barcodes,
+		`SELECT barcode FROM cat_nomenclature_barcodes WHERE nomenclature_id = $1 ORDER BY line_no`,
+		item.ID)
+	if err != nil {
+		return fmt.Errorf("get barcodes: %w", err)
+	}
+	return nil
+}
+
+// Create inserts nomenclature with its table parts.
+func (r *NomenclatureRepo) Create(ctx context.Context, item *nomenclature.Nomenclature) error {
+	if err := r.BaseCatalogRepo.Create(ctx, item); err != nil {
+		return err
+	}
+	return r.saveTableParts(ctx, item)
+}
+
+// CreateBatch inserts nomenclature with a single COPY, then their table
+// parts.
+func (r *NomenclatureRepo) CreateBatch(ctx context.Context, items []*nomenclature.Nomenclature) error {
+	if err := r.BaseCatalogRepo.CreateBatch(ctx, items); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := r.saveTableParts(ctx, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Update modifies nomenclature and, when loaded, replaces its table parts.
+func (r *NomenclatureRepo) Update(ctx context.Context, item *nomenclature.Nomenclature) error {
+	if err := r.BaseCatalogRepo.Update(ctx, item); err != nil {
+		return err
+	}
+	return r.saveTableParts(ctx, item)
+}
+
+// saveTableParts saves the packaging units and additional barcodes.
+func (r *NomenclatureRepo) saveTableParts(ctx context.Context, item *nomenclature.Nomenclature) error {
+	if err := r.savePackagingUnits(ctx, item); err != nil {
+		return err
+	}
+	return r.saveBarcodes(ctx, item)
+}
+
+// savePackagingUnits replaces the stored packaging units with the loaded
+// ones; nil units are left unchanged.
+func (r *NomenclatureRepo) savePackagingUnits(ctx context.Context, item *nomenclature.Nomenclature) error {
+	if item.PackagingUnits == nil {
+		return nil
+	}
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+
+	if _, err := querier.Exec(ctx, `DELETE FROM cat_nomenclature_units WHERE nomenclature_id = $1`, item.ID); err != nil {
+		return fmt.Errorf("delete packaging units: %w", err)
+	}
+	if len(item.PackagingUnits) == 0 {
+		return nil
+	}
+
+	q := r.Builder().
+		Insert("cat_nomenclature_units").
+		Columns("nomenclature_id", "unit_id", "coefficient", "line_no")
+	for i, pu := range item.PackagingUnits {
+		q = q.Values(item.ID, pu.UnitID, pu.Coefficient, i+1)
+	}
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("build insert: %w", err)
+	}
+	if _, err := querier.Exec(ctx, sql, args...); err != nil {
+		if postgres.IsForeignKeyViolation(err) {
+			return apperror.NewBusinessRule("INVALID_REFERENCE", "Связанный элемент удален. Выберите другой.").
+				WithDetail("field", "packagingUnits")
+		}
+		return fmt.Errorf("insert packaging units: %w", err)
+	}
+	return nil
+}
+
+// saveBarcodes replaces the stored additional barcodes with the loaded
+// ones; nil barcodes are left unchanged.
+func (r *NomenclatureRepo) saveBarcodes(ctx context.Context, item *nomenclature.Nomenclature) error {
+	if item.Barcodes == nil {
+		return nil
+	}
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+
+	if _, err := querier.Exec(ctx, `DELETE FROM cat_nomenclature_barcodes WHERE nomenclature_id = $1`, item.ID); err != nil {
+		return fmt.Errorf("delete barcodes: %w", err)
+	}
+	if len(item.Barcodes) == 0 {
+		return nil
+	}
+
+	q := r.Builder().
+		Insert("cat_nomenclature_barcodes").
+		Columns("barcode", "nomenclature_id", "line_no")
+	for i, code := range item.Barcodes {
+		q = q.Values(code, item.ID, i+1)
+	}
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("build insert: %w", err)
+	}
+	if _, err := querier.Exec(ctx, sql, args...); err != nil {
+		if postgres.IsUniqueViolation(err) {
+			return apperror.NewConflict("item with this barcode already exists").
+				WithDetail("field", "barcodes")
+		}
+		return fmt.Errorf("insert barcodes: %w", err)
+	}
+	return nil
 }
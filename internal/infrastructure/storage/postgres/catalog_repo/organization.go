@@ -22,7 +22,7 @@ type OrganizationRepo struct {
 
 // NewOrganizationRepo creates a new organization repository.
 func NewOrganizationRepo() *OrganizationRepo {
-	return &OrganizationRepo{
+	repo := &OrganizationRepo{
 		BaseCatalogRepo: NewBaseCatalogRepo[*organization.Organization](
 			organizationTable,
 			postgres.ExtractDBColumns[organization.Organization](),
@@ -30,6 +30,11 @@ func NewOrganizationRepo() *OrganizationRepo {
 			false, // flat catalog: organizations don't support hierarchy
 		),
 	}
+
+	// Register RLS dimensions for DataScope filtering.
+	repo.RegisterRLSDimension("organization", "id")
+
+	return repo
 }
 
 // GetDefault retrieves the default organization.
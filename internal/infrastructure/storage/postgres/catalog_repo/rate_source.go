@@ -14,7 +14,7 @@ type RateSourceRepo struct {
 
 // NewRateSourceRepo creates a new rate source repository.
 func NewRateSourceRepo() *RateSourceRepo {
-	return &RateSourceRepo{
+	repo := &RateSourceRepo{
 		BaseCatalogRepo: NewBaseCatalogRepo[*rate_source.RateSource](
 			_rateSourceTable,
 			postgres.ExtractDBColumns[rate_source.RateSource](),
@@ -22,4 +22,9 @@ func NewRateSourceRepo() *RateSourceRepo {
 			false, // flat catalog
 		),
 	}
+
+	// The provider API key must not leak into the audit trail.
+	repo.RegisterAuditMaskedColumn("api_key")
+
+	return repo
 }
@@ -0,0 +1,39 @@
+package catalog_repo
+
+import (
+	"context"
+	"testing"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/domain/catalogs/nomenclature"
+	"metapus/internal/domain/catalogs/unit"
+	"metapus/internal/domain/repotest"
+	"metapus/internal/infrastructure/storage/postgres/pgtest"
+)
+
+// TestIntegrationCatalogRepoContract runs the shared catalog repository suite against
+// a flat (units) and a hierarchical (nomenclature) postgres catalog.
+func TestIntegrationCatalogRepoContract(t *testing.T) {
+	ctx := pgtest.Start(t).Context(context.Background())
+
+	t.Run("Unit", func(t *testing.T) {
+		repotest.CatalogSuite[*unit.Unit]{
+			Repo: NewUnitRepo(),
+			New: func(code, name string) *unit.Unit {
+				return unit.NewUnit(code, name, "pc", unit.TypePiece)
+			},
+			Catalog: func(u *unit.Unit) *entity.Catalog { return &u.Catalog },
+		}.Run(t, ctx)
+	})
+
+	t.Run("Nomenclature", func(t *testing.T) {
+		repotest.CatalogSuite[*nomenclature.Nomenclature]{
+			Repo: NewNomenclatureRepo(),
+			New: func(code, name string) *nomenclature.Nomenclature {
+				return nomenclature.NewNomenclature(code, name, nomenclature.TypeGoods)
+			},
+			Catalog:      func(n *nomenclature.Nomenclature) *entity.Catalog { return &n.Catalog },
+			Hierarchical: true,
+		}.Run(t, ctx)
+	})
+}
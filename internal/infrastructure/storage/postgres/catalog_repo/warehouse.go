@@ -19,7 +19,7 @@ type WarehouseRepo struct {
 
 // NewWarehouseRepo creates a new warehouse repository.
 func NewWarehouseRepo() *WarehouseRepo {
-	return &WarehouseRepo{
+	repo := &WarehouseRepo{
 		BaseCatalogRepo: NewBaseCatalogRepo[*warehouse.Warehouse](
 			warehouseTable,
 			postgres.ExtractDBColumns[warehouse.Warehouse](),
@@ -27,6 +27,12 @@ func NewWarehouseRepo() *WarehouseRepo {
 			true, // hierarchical: warehouses support folders/groups
 		),
 	}
+
+	// Register RLS dimensions for DataScope filtering.
+	repo.RegisterRLSDimension("organization", "organization_id")
+	repo.RegisterRLSDimension("warehouse", "id")
+
+	return repo
 }
 
 // ClearDefault clears the default flag on all warehouses.
@@ -0,0 +1,54 @@
+package catalog_repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+
+	"metapus/internal/domain/catalogs/webhook_subscription"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+const webhookSubscriptionTable = "cat_webhook_subscriptions"
+
+// WebhookSubscriptionRepo implements webhook_subscription.Repository.
+type WebhookSubscriptionRepo struct {
+	*BaseCatalogRepo[*webhook_subscription.WebhookSubscription]
+}
+
+// NewWebhookSubscriptionRepo creates a new webhook subscription repository.
+func NewWebhookSubscriptionRepo() *WebhookSubscriptionRepo {
+	return &WebhookSubscriptionRepo{
+		BaseCatalogRepo: NewBaseCatalogRepo[*webhook_subscription.WebhookSubscription](
+			webhookSubscriptionTable,
+			postgres.ExtractDBColumns[webhook_subscription.WebhookSubscription](),
+			func() *webhook_subscription.WebhookSubscription { return &webhook_subscription.WebhookSubscription{} },
+			false, // flat catalog: subscriptions don't support hierarchy
+		),
+	}
+}
+
+// ListActive retrieves the active subscriptions not marked for deletion.
+func (r *WebhookSubscriptionRepo) ListActive(ctx context.Context) ([]*webhook_subscription.WebhookSubscription, error) {
+	q := r.baseSelect(ctx).
+		Where(squirrel.Eq{"is_active": true}).
+		Where(squirrel.Eq{"deletion_mark": false}).
+		OrderBy("code ASC")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var subs []*webhook_subscription.WebhookSubscription
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &subs, sql, args...); err != nil {
+		return nil, fmt.Errorf("list active webhook subscriptions: %w", err)
+	}
+
+	return subs, nil
+}
+
+var _ webhook_subscription.Repository = (*WebhookSubscriptionRepo)(nil)
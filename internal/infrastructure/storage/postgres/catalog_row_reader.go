@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/id"
+)
+
+// CatalogRowReader reads catalog rows generically, as column → value maps,
+// for integrations that address catalogs by metadata rather than by type.
+type CatalogRowReader struct{}
+
+// NewCatalogRowReader creates a new catalog row reader.
+func NewCatalogRowReader() *CatalogRowReader {
+	return &CatalogRowReader{}
+}
+
+// Rows returns rows of table ordered by name; ids, if any, restrict them.
+// Rows marked for deletion are skipped unless includeDeleted is set.
+// table comes from entity metadata, never from user input.
+func (r *CatalogRowReader) Rows(ctx context.Context, table string, ids []id.ID, includeDeleted bool, limit, offset int) ([]map[string]any, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	var (
+		wheres []string
+		args   []any
+	)
+	if len(ids) > 0 {
+		args = append(args, ids)
+		wheres = append(wheres, "t.id = ANY($1)")
+	}
+	if !includeDeleted {
+		wheres = append(wheres, "t.deletion_mark = FALSE")
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = "WHERE " + strings.Join(wheres, " AND ")
+	}
+	args = append(args, limit, offset)
+
+	rows, err := q.Query(ctx, `
+		SELECT to_jsonb(t) - '_txid' - '_deleted_at'
+		FROM `+pgx.Identifier{table}.Sanitize()+` t
+		`+where+`
+		ORDER BY t.name, t.id
+		LIMIT $`+strconv.Itoa(len(args)-1)+` OFFSET $`+strconv.Itoa(len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("query %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var out []map[string]any
+	for rows.Next() {
+		var raw []byte
+		if err := rows.Scan(&raw); err != nil {
+			return nil, fmt.Errorf("scan %s: %w", table, err)
+		}
+		var row map[string]any
+		if err := json.Unmarshal(raw, &row); err != nil {
+			return nil, fmt.Errorf("decode %s row: %w", table, err)
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,325 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain"
+	"metapus/internal/domain/cascadedelete"
+	"metapus/internal/metadata"
+)
+
+// _maxCascadeNodes bounds the number of documents in one deletion cascade.
+const _maxCascadeNodes = 500
+
+// DocumentCascadeRepo implements cascadedelete.Repository.
+//
+// The cascade is the closure of the requested documents over the document
+// relations graph: documents created on their basis (basis_type/basis_id)
+// and documents referencing them via FK or TypedRef fields (RefFinderRepo).
+// Posted dependents and non-document referrers are reported as blockers.
+type DocumentCascadeRepo struct {
+	registry *metadata.Registry
+	finder   *RefFinderRepo
+	resolver *RefResolverRepo
+}
+
+// NewDocumentCascadeRepo creates a new DocumentCascadeRepo.
+func NewDocumentCascadeRepo(registry *metadata.Registry) *DocumentCascadeRepo {
+	return &DocumentCascadeRepo{
+		registry: registry,
+		finder:   NewRefFinderRepo(registry),
+		resolver: NewRefResolverRepo(registry),
+	}
+}
+
+// docState is the deletion-relevant state of a document row.
+type docState struct {
+	posted       bool
+	deletionMark bool
+}
+
+// dependent is an incoming link to a cascade document.
+type dependent struct {
+	ref        cascadedelete.Ref
+	entityType string
+	via        string
+}
+
+// Resolve builds the deletion cascade of the given documents via BFS.
+func (r *DocumentCascadeRepo) Resolve(ctx context.Context, roots []domain.DeleteMarkedRequest) (*cascadedelete.Cascade, error) {
+	c := &cascadedelete.Cascade{}
+	inCascade := make(map[cascadedelete.Ref]bool)
+	blocked := make(map[cascadedelete.Ref]bool)
+
+	// ── Roots: existing, deletion-marked documents ──
+	queue := make([]cascadedelete.Ref, 0, len(roots))
+	for _, root := range roots {
+		def, ok := r.registry.Get(root.EntityName)
+		if !ok || def.Type != metadata.TypeDocument || deriveTableName(def) == "" {
+			return nil, apperror.NewValidation(fmt.Sprintf("%q is not a document", root.EntityName)).
+				WithDetail("field", "entityName")
+		}
+		states, err := r.loadStates(ctx, def, []id.ID{root.EntityID})
+		if err != nil {
+			return nil, err
+		}
+		st, ok := states[root.EntityID]
+		if !ok {
+			return nil, apperror.NewNotFound(def.Name, root.EntityID)
+		}
+		if !st.deletionMark {
+			return nil, apperror.NewValidation("document is not marked for deletion").
+				WithDetail("entityName", def.Name).
+				WithDetail("entityId", root.EntityID.String())
+		}
+
+		ref := cascadedelete.Ref{EntityName: def.Name, EntityID: root.EntityID}
+		inCascade[ref] = true
+		queue = append(queue, ref)
+		c.Nodes = append(c.Nodes, cascadedelete.Node{
+			Ref:          ref,
+			EntityType:   string(def.Type),
+			Posted:       st.posted,
+			DeletionMark: st.deletionMark,
+		})
+	}
+
+	// ── Walk down the dependents level by level ──
+	for depth := 1; len(queue) > 0; depth++ {
+		var next []cascadedelete.Ref
+		pending := make(map[string][]id.ID)                         // entity → new dependent documents
+		pulledBy := make(map[cascadedelete.Ref]dependent)           // new dependent → link info (Via)
+		pulledFrom := make(map[cascadedelete.Ref]cascadedelete.Ref) // new dependent → target
+
+		for _, target := range queue {
+			deps, err := r.dependents(ctx, target)
+			if err != nil {
+				return nil, err
+			}
+			for _, d := range deps {
+				if d.ref == target {
+					continue
+				}
+				if d.entityType != string(metadata.TypeDocument) {
+					if !blocked[d.ref] {
+						blocked[d.ref] = true
+						c.Blockers = append(c.Blockers, cascadedelete.Blocker{
+							Ref:        d.ref,
+							EntityType: d.entityType,
+							Reason:     "referenced by a non-document object",
+							Blocks:     target,
+							Via:        d.via,
+						})
+					}
+					continue
+				}
+
+				c.Edges = append(c.Edges, cascadedelete.Edge{From: d.ref, To: target})
+				if inCascade[d.ref] || blocked[d.ref] {
+					continue
+				}
+				if _, seen := pulledBy[d.ref]; !seen {
+					pulledBy[d.ref] = d
+					pulledFrom[d.ref] = target
+					pending[d.ref.EntityName] = append(pending[d.ref.EntityName], d.ref.EntityID)
+				}
+			}
+		}
+
+		for entityName, ids := range pending {
+			def, _ := r.registry.Get(entityName)
+			states, err := r.loadStates(ctx, def, ids)
+			if err != nil {
+				return nil, err
+			}
+			for _, docID := range ids {
+				ref := cascadedelete.Ref{EntityName: entityName, EntityID: docID}
+				st, ok := states[docID]
+				if !ok {
+					continue // deleted concurrently
+				}
+				target := pulledFrom[ref]
+				if st.posted {
+					blocked[ref] = true
+					c.Blockers = append(c.Blockers, cascadedelete.Blocker{
+						Ref:        ref,
+						EntityType: string(def.Type),
+						Reason:     "posted document; unpost it or mark it for deletion first",
+						Blocks:     target,
+						Via:        pulledBy[ref].via,
+					})
+					continue
+				}
+				inCascade[ref] = true
+				next = append(next, ref)
+				c.Nodes = append(c.Nodes, cascadedelete.Node{
+					Ref:          ref,
+					EntityType:   string(def.Type),
+					Posted:       st.posted,
+					DeletionMark: st.deletionMark,
+					Depth:        depth,
+					DependsOn:    &target,
+					Via:          pulledBy[ref].via,
+				})
+			}
+		}
+
+		if len(c.Nodes) > _maxCascadeNodes {
+			return nil, apperror.NewBusinessRule("CASCADE_TOO_LARGE",
+				fmt.Sprintf("deletion cascade exceeds %d documents; delete dependent documents separately", _maxCascadeNodes))
+		}
+		queue = next
+	}
+
+	r.resolvePresentations(ctx, c)
+	return c, nil
+}
+
+// dependents returns documents created on the basis of target and all
+// objects referencing target via FK/TypedRef fields.
+func (r *DocumentCascadeRepo) dependents(ctx context.Context, target cascadedelete.Ref) ([]dependent, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+	var result []dependent
+
+	// Basis links: basis_type/basis_id are not reference fields in metadata,
+	// so RefFinder does not see them.
+	var defs []metadata.EntityDef
+	b := &pgx.Batch{}
+	for _, def := range r.registry.List() {
+		tableName := deriveTableName(def)
+		if def.Type != metadata.TypeDocument || tableName == "" {
+			continue
+		}
+		defs = append(defs, def)
+		b.Queue(fmt.Sprintf(`SELECT id FROM %s WHERE basis_type = $1 AND basis_id = $2`, tableName),
+			target.EntityName, target.EntityID)
+	}
+	if len(defs) > 0 {
+		br := querier.SendBatch(ctx, b)
+		for _, def := range defs {
+			rows, err := br.Query()
+			if err != nil {
+				_ = br.Close()
+				return nil, fmt.Errorf("find basis dependents in %s: %w", def.Name, err)
+			}
+			for rows.Next() {
+				var docID id.ID
+				if err := rows.Scan(&docID); err != nil {
+					rows.Close()
+					_ = br.Close()
+					return nil, fmt.Errorf("scan basis dependent: %w", err)
+				}
+				result = append(result, dependent{
+					ref:        cascadedelete.Ref{EntityName: def.Name, EntityID: docID},
+					entityType: string(def.Type),
+					via:        "basisId",
+				})
+			}
+			rows.Close()
+		}
+		if err := br.Close(); err != nil {
+			return nil, fmt.Errorf("find basis dependents: %w", err)
+		}
+	}
+
+	refs, err := r.finder.FindReferences(ctx, domain.FindReferencesRequest{
+		EntityName: target.EntityName,
+		EntityID:   target.EntityID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find references to %s: %w", target.EntityName, err)
+	}
+	for _, ref := range refs {
+		result = append(result, dependent{
+			ref:        cascadedelete.Ref{EntityName: ref.SourceEntityName, EntityID: ref.SourceID},
+			entityType: ref.SourceEntityType,
+			via:        ref.SourceField,
+		})
+	}
+	return result, nil
+}
+
+// loadStates reads posted/deletion_mark of the given documents; missing
+// documents are absent from the result.
+func (r *DocumentCascadeRepo) loadStates(ctx context.Context, def metadata.EntityDef, ids []id.ID) (map[id.ID]docState, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+	sql := fmt.Sprintf(`SELECT id, posted, deletion_mark FROM %s WHERE id = ANY($1)`, deriveTableName(def))
+	rows, err := querier.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, fmt.Errorf("load %s state: %w", def.Name, err)
+	}
+	defer rows.Close()
+
+	states := make(map[id.ID]docState, len(ids))
+	for rows.Next() {
+		var docID id.ID
+		var st docState
+		if err := rows.Scan(&docID, &st.posted, &st.deletionMark); err != nil {
+			return nil, fmt.Errorf("scan %s state: %w", def.Name, err)
+		}
+		states[docID] = st
+	}
+	return states, rows.Err()
+}
+
+// resolvePresentations fills display strings of nodes and blockers (best effort).
+func (r *DocumentCascadeRepo) resolvePresentations(ctx context.Context, c *cascadedelete.Cascade) {
+	reqs := make([]domain.RefResolveRequest, 0, len(c.Nodes)+len(c.Blockers))
+	for _, n := range c.Nodes {
+		reqs = append(reqs, domain.RefResolveRequest{RefType: n.EntityName, RefID: n.EntityID})
+	}
+	for _, b := range c.Blockers {
+		reqs = append(reqs, domain.RefResolveRequest{RefType: b.EntityName, RefID: b.EntityID})
+	}
+	resolved, err := r.resolver.ResolveRefs(ctx, reqs)
+	if err != nil {
+		return
+	}
+
+	pres := make(map[cascadedelete.Ref]string, len(resolved))
+	for _, res := range resolved {
+		pres[cascadedelete.Ref{EntityName: res.RefType, EntityID: res.RefID}] = res.Presentation
+	}
+	for i := range c.Nodes {
+		c.Nodes[i].Presentation = pres[c.Nodes[i].Ref]
+	}
+	for i := range c.Blockers {
+		c.Blockers[i].Presentation = pres[c.Blockers[i].Ref]
+	}
+}
+
+// Purge physically deletes the documents one by one in the given order.
+// Table parts are removed by ON DELETE CASCADE.
+func (r *DocumentCascadeRepo) Purge(ctx context.Context, nodes []cascadedelete.Node) (int, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	for _, n := range nodes {
+		def, ok := r.registry.Get(n.EntityName)
+		if !ok {
+			return 0, fmt.Errorf("unknown entity %q", n.EntityName)
+		}
+		tableName := deriveTableName(def)
+
+		sql := fmt.Sprintf(`DELETE FROM %s WHERE id = $1 AND posted = FALSE`, tableName)
+		tag, err := querier.Exec(ctx, sql, n.EntityID)
+		if err != nil {
+			if IsForeignKeyViolation(err) {
+				return 0, apperror.NewConflict(fmt.Sprintf("%s is still referenced by other data", n.EntityName)).
+					WithDetail("entityName", n.EntityName).
+					WithDetail("entityId", n.EntityID.String())
+			}
+			return 0, fmt.Errorf("delete %s: %w", n.EntityName, err)
+		}
+		if tag.RowsAffected() == 0 {
+			return 0, apperror.NewConflict(fmt.Sprintf("%s was posted or removed concurrently", n.EntityName)).
+				WithDetail("entityName", n.EntityName).
+				WithDetail("entityId", n.EntityID.String())
+		}
+	}
+	return len(nodes), nil
+}
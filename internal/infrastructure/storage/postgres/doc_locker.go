@@ -8,7 +8,8 @@ import (
 	"metapus/internal/core/id"
 )
 
-// DocLocker implements posting.DocumentLocker using PostgreSQL advisory locks.
+// DocLocker implements posting.DocumentLocker and posting.PartitionLocker
+// using PostgreSQL advisory locks.
 // pg_advisory_xact_lock is transactional — released automatically on COMMIT/ROLLBACK.
 type DocLocker struct{}
 
@@ -30,3 +31,22 @@ func (l *DocLocker) LockDocument(ctx context.Context, docType string, docID id.I
 	}
 	return nil
 }
+
+// partitionLockPrefix namespaces the posting partition keys among other
+// single-key advisory locks.
+const partitionLockPrefix = "posting_partition:"
+
+// LockPartitions acquires transactional advisory locks on keys, in order.
+// A key is locked by its 64-bit hash (hashtextextended): 32-bit hashes of
+// many warehouse keys collide often enough to serialize unrelated postings.
+// The single bigint key form never conflicts with the two int4 keys of
+// LockDocument.
+func (l *DocLocker) LockPartitions(ctx context.Context, keys []string) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+	for _, key := range keys {
+		if _, err := q.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtextextended($1, 0))", partitionLockPrefix+key); err != nil {
+			return fmt.Errorf("pg_advisory_xact_lock(%s): %w", key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/domain/doctemplate"
+)
+
+// DocTemplateRepo implements doctemplate.Repository.
+type DocTemplateRepo struct{}
+
+// NewDocTemplateRepo creates a new document template repository.
+func NewDocTemplateRepo() *DocTemplateRepo {
+	return &DocTemplateRepo{}
+}
+
+func (r *DocTemplateRepo) psql() squirrel.StatementBuilderType {
+	return squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+}
+
+var docTemplateColumns = []string{
+	"id", "document_type", "name", "author_id", "visibility", "payload",
+	"deletion_mark", "version", "created_at", "updated_at",
+}
+
+func scanDocTemplate(row pgx.Row, t *doctemplate.Template) error {
+	var payload []byte
+	err := row.Scan(
+		&t.ID, &t.DocumentType, &t.Name, &t.AuthorID, &t.Visibility, &payload,
+		&t.DeletionMark, &t.Version, &t.CreatedAt, &t.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	t.Payload = payload
+	return nil
+}
+
+// Create inserts a new template.
+func (r *DocTemplateRepo) Create(ctx context.Context, t *doctemplate.Template) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Insert("sys_document_templates").
+		Columns("id", "document_type", "name", "author_id", "visibility", "payload").
+		Values(t.ID, t.DocumentType, t.Name, t.AuthorID, t.Visibility, []byte(t.Payload)).
+		Suffix("RETURNING deletion_mark, version, created_at, updated_at").
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build insert query: %w", err))
+	}
+
+	err = querier.QueryRow(ctx, query, args...).Scan(&t.DeletionMark, &t.Version, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("execute insert: %w", err))
+	}
+	return nil
+}
+
+// Update modifies name and visibility of a template with optimistic locking.
+func (r *DocTemplateRepo) Update(ctx context.Context, t *doctemplate.Template) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Update("sys_document_templates").
+		Set("name", t.Name).
+		Set("visibility", t.Visibility).
+		Set("version", squirrel.Expr("version + 1")).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": t.ID, "version": t.Version, "deletion_mark": false}).
+		Suffix("RETURNING deletion_mark, version, created_at, updated_at").
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build update query: %w", err))
+	}
+
+	err = querier.QueryRow(ctx, query, args...).Scan(&t.DeletionMark, &t.Version, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.NewConcurrentModification("document_template", t.ID)
+		}
+		return apperror.NewInternal(fmt.Errorf("execute update: %w", err))
+	}
+	return nil
+}
+
+// Delete soft-deletes a template.
+func (r *DocTemplateRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Update("sys_document_templates").
+		Set("deletion_mark", true).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": id, "deletion_mark": false}).
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build delete query: %w", err))
+	}
+
+	cmdTag, err := querier.Exec(ctx, query, args...)
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("execute delete: %w", err))
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return apperror.NewNotFound("document_template", id)
+	}
+	return nil
+}
+
+// GetByID returns a single template by ID.
+func (r *DocTemplateRepo) GetByID(ctx context.Context, id uuid.UUID) (*doctemplate.Template, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Select(docTemplateColumns...).
+		From("sys_document_templates").
+		Where(squirrel.Eq{"id": id, "deletion_mark": false}).
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	var t doctemplate.Template
+	if err := scanDocTemplate(querier.QueryRow(ctx, query, args...), &t); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFound("document_template", id)
+		}
+		return nil, apperror.NewInternal(fmt.Errorf("scan document template: %w", err))
+	}
+	return &t, nil
+}
+
+// GetList returns templates of a document type accessible to the user.
+func (r *DocTemplateRepo) GetList(ctx context.Context, documentType string, userID uuid.UUID) ([]*doctemplate.Template, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	cond := squirrel.And{
+		squirrel.Eq{"document_type": documentType},
+		squirrel.Eq{"deletion_mark": false},
+		squirrel.Or{
+			squirrel.Eq{"visibility": string(doctemplate.VisibilityShared)},
+			squirrel.Eq{"author_id": userID},
+		},
+	}
+
+	query, args, err := r.psql().Select(docTemplateColumns...).
+		From("sys_document_templates").
+		Where(cond).
+		OrderBy("name ASC").
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("execute query: %w", err))
+	}
+	defer rows.Close()
+
+	list := make([]*doctemplate.Template, 0)
+	for rows.Next() {
+		t := &doctemplate.Template{}
+		if err := scanDocTemplate(rows, t); err != nil {
+			return nil, apperror.NewInternal(fmt.Errorf("scan document template row: %w", err))
+		}
+		list = append(list, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("rows iteration error: %w", err))
+	}
+
+	return list, nil
+}
+
+// Ensure interface compliance.
+var _ doctemplate.Repository = (*DocTemplateRepo)(nil)
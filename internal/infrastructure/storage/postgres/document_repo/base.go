@@ -4,6 +4,7 @@ package document_repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/v2/pgxscan"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 
 	"metapus/internal/core/apperror"
 	"metapus/internal/core/id"
@@ -42,6 +44,19 @@ type BaseDocumentRepo[T any] struct {
 	// Configured via RegisterRLSDimension. At query time, DataScope.ApplyConditions
 	// uses this map to inject WHERE conditions for matching dimensions.
 	rlsDimensions map[string]string // e.g. {"organization": "organization_id"}
+
+	// readOnlyCols are selected but never written by Update.
+	// Configured via RegisterReadOnlyColumn for columns maintained outside
+	// document saves (e.g. by register recorders during posting).
+	readOnlyCols map[string]struct{}
+
+	// boardStateExpr is the SQL expression yielding the board state of a row.
+	// Configured via SetBoardStateExpr; defaults to draft/posted.
+	boardStateExpr string
+
+	// audit records every write into the audit trail (sys_audit).
+	// nil disables the audit trail.
+	audit *postgres.AuditService
 }
 
 // NewBaseDocumentRepo creates a new base document repository.
@@ -67,6 +82,9 @@ func NewBaseDocumentRepo[T any](
 		newFn:      newFn,
 		validCols:  validCols,
 		orderCols:  orderCols,
+
+		boardStateExpr: defaultBoardStateExpr,
+		audit:          auditService(),
 	}
 }
 
@@ -88,6 +106,22 @@ func (r *BaseDocumentRepo[T]) RegisterRLSDimension(dimensionName, dbColumn strin
 	r.rlsDimensions[dimensionName] = dbColumn
 }
 
+// RegisterReadOnlyColumn excludes a column from Update so that a document save
+// with a stale value never overwrites it. The column is still written on Create.
+func (r *BaseDocumentRepo[T]) RegisterReadOnlyColumn(dbColumn string) {
+	if r.readOnlyCols == nil {
+		r.readOnlyCols = make(map[string]struct{})
+	}
+	r.readOnlyCols[dbColumn] = struct{}{}
+}
+
+// SetBoardStateExpr sets the SQL expression that yields the status board state
+// of a document row, e.g. "status" or a CASE over several columns.
+// The expression must only reference columns of the document table.
+func (r *BaseDocumentRepo[T]) SetBoardStateExpr(expr string) {
+	r.boardStateExpr = expr
+}
+
 // RegisterTablePart registers a child table (table part / tabular section)
 // so that dot-notation filters like "lines.nomenclature_id" are translated into
 // EXISTS subqueries instead of direct WHERE conditions on the main table.
@@ -139,6 +173,26 @@ func (r *BaseDocumentRepo[T]) getTxManager(ctx context.Context) *postgres.TxMana
 	return postgres.MustGetTxManager(ctx)
 }
 
+// auditService returns the shared audit trail writer, or nil if it is unavailable.
+func auditService() *postgres.AuditService {
+	svc, err := postgres.DefaultAuditService()
+	if err != nil {
+		return nil
+	}
+	return svc
+}
+
+// recordChange records a row change into the audit trail and queues the row
+// for the external search index, both within the current transaction.
+func (r *BaseDocumentRepo[T]) recordChange(ctx context.Context, entityID id.ID, before, after []byte) error {
+	if r.audit != nil {
+		if err := r.audit.LogRowChange(ctx, r.tableName, entityID, before, after, nil); err != nil {
+			return err
+		}
+	}
+	return postgres.EnqueueSearchIndex(ctx, r.tableName, entityID)
+}
+
 // Builder returns a new squirrel builder.
 func (r *BaseDocumentRepo[T]) Builder() squirrel.StatementBuilderType {
 	return squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
@@ -160,7 +214,8 @@ func (r *BaseDocumentRepo[T]) Create(ctx context.Context, entity T) error {
 
 	q := r.Builder().
 		Insert(r.tableName).
-		SetMap(filteredData)
+		SetMap(filteredData).
+		Suffix("RETURNING id, to_jsonb(" + r.tableName + ".*)")
 
 	sql, args, err := q.ToSql()
 	if err != nil {
@@ -168,8 +223,16 @@ func (r *BaseDocumentRepo[T]) Create(ctx context.Context, entity T) error {
 	}
 
 	querier := r.getTxManager(ctx).GetQuerier(ctx)
-	_, err = querier.Exec(ctx, sql, args...)
+	var entityID id.ID
+	var after []byte
+	err = querier.QueryRow(ctx, sql, args...).Scan(&entityID, &after)
 	if err != nil {
+		if isNumberUniqueViolation(err) {
+			return r.numberDuplicate(filteredData)
+		}
+		if dup := postgres.UniqueRuleViolation(ctx, err); dup != nil {
+			return dup
+		}
 		if postgres.IsForeignKeyViolation(err) {
 			field := postgres.ExtractForeignKeyField(err, r.tableName)
 			return apperror.NewBusinessRule("INVALID_REFERENCE", "Связанный элемент удален. Выберите другой.").
@@ -178,7 +241,7 @@ func (r *BaseDocumentRepo[T]) Create(ctx context.Context, entity T) error {
 		return fmt.Errorf("insert %s: %w", r.tableName, err)
 	}
 
-	return nil
+	return r.recordChange(ctx, entityID, nil, after)
 }
 
 // Update updates an existing document with optimistic locking.
@@ -207,6 +270,9 @@ func (r *BaseDocumentRepo[T]) Update(ctx context.Context, entity T) error {
 		if col == "version" || col == "updated_at" {
 			continue // version/updated_at are managed by repo
 		}
+		if _, ok := r.readOnlyCols[col]; ok {
+			continue
+		}
 		if val, ok := data[col]; ok {
 			filteredData[col] = val
 		}
@@ -214,12 +280,13 @@ func (r *BaseDocumentRepo[T]) Update(ctx context.Context, entity T) error {
 
 	q := r.Builder().
 		Update(r.tableName).
+		Prefix(postgres.AuditBeforeCTE(r.tableName), entityID).
 		SetMap(filteredData).
 		Set("version", squirrel.Expr("version + 1")).
 		Set("updated_at", squirrel.Expr("NOW()")).
 		Where(squirrel.Eq{"id": entityID}).
 		Where(squirrel.Eq{"version": version}).
-		Suffix("RETURNING version, updated_at")
+		Suffix("RETURNING version, updated_at, " + postgres.AuditReturning(r.tableName))
 
 	sql, args, err := q.ToSql()
 	if err != nil {
@@ -230,12 +297,19 @@ func (r *BaseDocumentRepo[T]) Update(ctx context.Context, entity T) error {
 
 	var newVersion int
 	var updatedAt time.Time
+	var before, after []byte
 
-	err = querier.QueryRow(ctx, sql, args...).Scan(&newVersion, &updatedAt)
+	err = querier.QueryRow(ctx, sql, args...).Scan(&newVersion, &updatedAt, &before, &after)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return apperror.NewConcurrentModification(r.tableName, entityID)
 		}
+		if isNumberUniqueViolation(err) {
+			return r.numberDuplicate(filteredData)
+		}
+		if dup := postgres.UniqueRuleViolation(ctx, err); dup != nil {
+			return dup
+		}
 		if postgres.IsForeignKeyViolation(err) {
 			field := postgres.ExtractForeignKeyField(err, r.tableName)
 			return apperror.NewBusinessRule("INVALID_REFERENCE", "Связанный элемент удален. Выберите другой.").
@@ -252,17 +326,20 @@ func (r *BaseDocumentRepo[T]) Update(ctx context.Context, entity T) error {
 		v.SetUpdatedAt(updatedAt)
 	}
 
-	return nil
+	docID, _ := entityID.(id.ID)
+	return r.recordChange(ctx, docID, before, after)
 }
 
 // Delete soft-deletes a document.
 func (r *BaseDocumentRepo[T]) Delete(ctx context.Context, entityID id.ID) error {
 	q := r.Builder().
 		Update(r.tableName).
+		Prefix(postgres.AuditBeforeCTE(r.tableName), entityID).
 		Set("deletion_mark", true).
 		Set("updated_at", squirrel.Expr("NOW()")).
 		Set("version", squirrel.Expr("version + 1")).
-		Where(squirrel.Eq{"id": entityID})
+		Where(squirrel.Eq{"id": entityID}).
+		Suffix("RETURNING " + postgres.AuditReturning(r.tableName))
 
 	sql, args, err := q.ToSql()
 	if err != nil {
@@ -270,16 +347,15 @@ func (r *BaseDocumentRepo[T]) Delete(ctx context.Context, entityID id.ID) error
 	}
 
 	querier := r.getTxManager(ctx).GetQuerier(ctx)
-	result, err := querier.Exec(ctx, sql, args...)
-	if err != nil {
+	var before, after []byte
+	if err := querier.QueryRow(ctx, sql, args...).Scan(&before, &after); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.NewNotFound(r.tableName, entityID.String())
+		}
 		return fmt.Errorf("delete %s: %w", r.tableName, err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return apperror.NewNotFound(r.tableName, entityID.String())
-	}
-
-	return nil
+	return r.recordChange(ctx, entityID, before, after)
 }
 
 // baseSelect creates a SELECT builder.
@@ -333,6 +409,57 @@ func (r *BaseDocumentRepo[T]) GetByNumber(ctx context.Context, number string) (T
 	return entity, nil
 }
 
+// numberDuplicate maps a number unique violation to the error the domain
+// pre-check returns, so clients see one error whichever catches the clash.
+func (r *BaseDocumentRepo[T]) numberDuplicate(data map[string]any) error {
+	name := r.entityName
+	if name == "" {
+		name = r.tableName
+	}
+	date, _ := data["date"].(time.Time)
+	return domain.NewNumberDuplicate(name, fmt.Sprint(data["number"]), date)
+}
+
+// isNumberUniqueViolation reports whether err violates a document number
+// unique constraint (uq_<doc>_number).
+func isNumberUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" &&
+		strings.Contains(pgErr.ConstraintName, "number")
+}
+
+// NumberExists reports whether number is used by another document dated
+// within the calendar year of date in date's location (optionally scoped to
+// an organization). Deletion-marked documents are included: they still hold
+// their number.
+func (r *BaseDocumentRepo[T]) NumberExists(ctx context.Context, number string, date time.Time, organizationID *id.ID, excludeID id.ID) (bool, error) {
+	from := time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, date.Location())
+	q := r.Builder().
+		Select("1").
+		From(r.tableName).
+		Where(squirrel.Eq{"number": number}).
+		Where(squirrel.GtOrEq{"date": from}).
+		Where(squirrel.Lt{"date": from.AddDate(1, 0, 0)}).
+		Where(squirrel.NotEq{"id": excludeID}).
+		Limit(1)
+	if organizationID != nil {
+		q = q.Where(squirrel.Eq{"organization_id": *organizationID})
+	}
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return false, fmt.Errorf("build query: %w", err)
+	}
+
+	var exists bool
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+	err = querier.QueryRow(ctx, "SELECT EXISTS ("+sql+")", args...).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check number exists: %w", err)
+	}
+	return exists, nil
+}
+
 // buildWhereConditions builds WHERE conditions from domain.ListFilter.
 // Handles standard filters (search, deletion_mark), RLS DataScope, and advanced filters.
 func (r *BaseDocumentRepo[T]) buildWhereConditions(f domain.ListFilter) ([]squirrel.Sqlizer, error) {
@@ -737,3 +864,12 @@ func (r *BaseDocumentRepo[T]) ListIDs(ctx context.Context, f domain.ListFilter,
 
 	return ids, nil
 }
+
+// serialsOrEmpty returns the serial numbers of a line for a NOT NULL TEXT[]
+// column: pgx encodes a nil slice as NULL.
+func serialsOrEmpty(serials []string) []string {
+	if serials == nil {
+		return []string{}
+	}
+	return serials
+}
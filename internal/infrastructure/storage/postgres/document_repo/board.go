@@ -0,0 +1,116 @@
+package document_repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain"
+	"metapus/internal/domain/board"
+)
+
+// defaultBoardStateExpr maps documents without their own states onto board.PostingWorkflow.
+const defaultBoardStateExpr = "CASE WHEN posted THEN '" + board.StatePosted + "' ELSE '" + board.StateDraft + "' END"
+
+// BoardCounts returns the number of documents per board state.
+func (r *BaseDocumentRepo[T]) BoardCounts(ctx context.Context, f domain.ListFilter) (map[string]int, error) {
+	conditions, err := r.buildWhereConditions(f)
+	if err != nil {
+		return nil, err
+	}
+
+	q := r.Builder().
+		Select(r.boardStateExpr+" AS state", "count(*)").
+		From(r.tableName).
+		GroupBy("1")
+	for _, cond := range conditions {
+		q = q.Where(cond)
+	}
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build board counts query: %w", err)
+	}
+
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+	rows, err := querier.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("board counts query: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var state string
+		var count int
+		if err := rows.Scan(&state, &count); err != nil {
+			return nil, fmt.Errorf("board counts scan: %w", err)
+		}
+		counts[state] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("board counts rows: %w", err)
+	}
+	return counts, nil
+}
+
+// BoardCards returns up to limit most recent documents of every board state.
+func (r *BaseDocumentRepo[T]) BoardCards(ctx context.Context, f domain.ListFilter, limit int) ([]board.Card, error) {
+	conditions, err := r.buildWhereConditions(f)
+	if err != nil {
+		return nil, err
+	}
+
+	inner := squirrel.Select(
+		"id", "number", "date", "posted",
+		r.boardStateExpr+" AS state",
+		"row_number() OVER (PARTITION BY "+r.boardStateExpr+" ORDER BY date DESC, id DESC) AS rn",
+	).From(r.tableName)
+	for _, cond := range conditions {
+		inner = inner.Where(cond)
+	}
+
+	q := r.Builder().
+		Select("id", "number", "date", "posted", "state").
+		FromSelect(inner, "b").
+		Where(squirrel.LtOrEq{"rn": limit}).
+		OrderBy("state", "date DESC", "id DESC")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build board cards query: %w", err)
+	}
+
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+	var cards []board.Card
+	if err := pgxscan.Select(ctx, querier, &cards, sql, args...); err != nil {
+		return nil, fmt.Errorf("board cards query: %w", err)
+	}
+	return cards, nil
+}
+
+// BoardState returns the board state of a document.
+func (r *BaseDocumentRepo[T]) BoardState(ctx context.Context, docID id.ID) (string, error) {
+	sql, args, err := r.Builder().
+		Select(r.boardStateExpr).
+		From(r.tableName).
+		Where(squirrel.Eq{"id": docID, "deletion_mark": false}).
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("build board state query: %w", err)
+	}
+
+	var state string
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+	if err := querier.QueryRow(ctx, sql, args...).Scan(&state); err != nil {
+		if pgxscan.NotFound(err) {
+			return "", apperror.NewNotFound(r.tableName, docID.String())
+		}
+		return "", fmt.Errorf("board state query: %w", err)
+	}
+	return state, nil
+}
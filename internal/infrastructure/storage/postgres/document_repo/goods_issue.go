@@ -40,6 +40,7 @@ func NewGoodsIssueRepo() *GoodsIssueRepo {
 		"nomenclature_id", "unit_id", "quantity", "unit_price",
 		"discount_percent", "discount_amount",
 		"vat_rate_id", "vat_amount", "amount",
+		"order_line_id",
 	})
 
 	// Register reference fields for deep filtering
@@ -52,6 +53,7 @@ func NewGoodsIssueRepo() *GoodsIssueRepo {
 
 	// Register RLS dimensions for DataScope filtering.
 	repo.RegisterRLSDimension("organization", "organization_id")
+	repo.RegisterRLSDimension("warehouse", "warehouse_id")
 
 	return repo
 }
@@ -64,6 +66,7 @@ func (r *GoodsIssueRepo) GetLines(ctx context.Context, docID id.ID) ([]goods_iss
 			"quantity", "unit_price",
 			"discount_percent", "discount_amount",
 			"vat_rate_id", "vat_amount", "amount",
+			"order_line_id", "serials",
 		).
 		From(goodsIssueLinesTable).
 		Where(squirrel.Eq{"document_id": docID}).
@@ -102,6 +105,7 @@ func (r *GoodsIssueRepo) SaveLines(ctx context.Context, docID id.ID, lines []goo
 		"quantity", "unit_price",
 		"discount_percent", "discount_amount",
 		"vat_rate_id", "vat_amount", "amount",
+		"order_line_id", "serials",
 	}
 
 	rows := make([][]any, 0, len(lines))
@@ -112,6 +116,7 @@ func (r *GoodsIssueRepo) SaveLines(ctx context.Context, docID id.ID, lines []goo
 			line.Quantity, line.UnitPrice,
 			line.DiscountPercent, line.DiscountAmount,
 			line.VATRateID, line.VATAmount, line.Amount,
+			line.OrderLineID, serialsOrEmpty(line.Serials),
 		})
 	}
 
@@ -42,6 +42,7 @@ func NewGoodsReceiptRepo() *GoodsReceiptRepo {
 		"nomenclature_id", "unit_id", "quantity", "unit_price",
 		"discount_percent", "discount_amount",
 		"vat_rate_id", "vat_percent", "vat_amount", "amount",
+		"order_line_id",
 	})
 
 	// Register reference fields for deep filtering
@@ -54,6 +55,7 @@ func NewGoodsReceiptRepo() *GoodsReceiptRepo {
 
 	// Register RLS dimensions for DataScope filtering.
 	repo.RegisterRLSDimension("organization", "organization_id")
+	repo.RegisterRLSDimension("warehouse", "warehouse_id")
 
 	return repo
 }
@@ -67,6 +69,7 @@ func (r *GoodsReceiptRepo) GetLines(ctx context.Context, docID id.ID) ([]goods_r
 			"quantity", "unit_price",
 			"discount_percent", "discount_amount",
 			"vat_rate_id", "vat_percent", "vat_amount", "amount",
+			"order_line_id", "serials",
 		).
 		From(goodsReceiptLinesTable).
 		Where(squirrel.Eq{"document_id": docID}).
@@ -109,6 +112,7 @@ func (r *GoodsReceiptRepo) SaveLines(ctx context.Context, docID id.ID, lines []g
 		"quantity", "unit_price",
 		"discount_percent", "discount_amount",
 		"vat_rate_id", "vat_percent", "vat_amount", "amount",
+		"order_line_id", "serials",
 	}
 
 	rows := make([][]any, 0, len(lines))
@@ -119,6 +123,7 @@ func (r *GoodsReceiptRepo) SaveLines(ctx context.Context, docID id.ID, lines []g
 			line.Quantity, line.UnitPrice,
 			line.DiscountPercent, line.DiscountAmount,
 			line.VATRateID, line.VATPercent, line.VATAmount, line.Amount,
+			line.OrderLineID, serialsOrEmpty(line.Serials),
 		})
 	}
 
@@ -0,0 +1,110 @@
+package document_repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain/catalogs/warehouse"
+	"metapus/internal/domain/documents/goods_transfer"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+const (
+	goodsTransfersTable     = "doc_goods_transfers"
+	goodsTransferLinesTable = "doc_goods_transfer_lines"
+)
+
+// GoodsTransferRepo implements goods_transfer.Repository.
+// List() is inherited from BaseDocumentRepo (universal filter engine).
+type GoodsTransferRepo struct {
+	*BaseDocumentRepo[*goods_transfer.GoodsTransfer]
+}
+
+// NewGoodsTransferRepo creates a new goods transfer repository.
+func NewGoodsTransferRepo() *GoodsTransferRepo {
+	repo := &GoodsTransferRepo{
+		BaseDocumentRepo: NewBaseDocumentRepo[*goods_transfer.GoodsTransfer](
+			goodsTransfersTable,
+			postgres.ExtractDBColumns[goods_transfer.GoodsTransfer](),
+			func() *goods_transfer.GoodsTransfer { return &goods_transfer.GoodsTransfer{} },
+		),
+	}
+
+	repo.RegisterTablePart("lines", goodsTransferLinesTable, "document_id", []string{
+		"nomenclature_id", "unit_id", "quantity",
+	})
+
+	// Register reference fields for deep filtering
+	warehouseColumns := postgres.ExtractDBColumns[warehouse.Warehouse]()
+	repo.RegisterReferenceField("source_warehouse_id", "cat_warehouses", "source_warehouse_id", warehouseColumns)
+	repo.RegisterReferenceField("destination_warehouse_id", "cat_warehouses", "destination_warehouse_id", warehouseColumns)
+	repo.RegisterReferenceField("transit_warehouse_id", "cat_warehouses", "transit_warehouse_id", warehouseColumns)
+
+	// Register RLS dimensions for DataScope filtering.
+	repo.RegisterRLSDimension("organization", "organization_id")
+
+	return repo
+}
+
+func (r *GoodsTransferRepo) GetLines(ctx context.Context, docID id.ID) ([]goods_transfer.GoodsTransferLine, error) {
+	q := r.Builder().
+		Select(
+			"line_id", "line_no", "nomenclature_id",
+			"unit_id", "coefficient", "quantity",
+		).
+		From(goodsTransferLinesTable).
+		Where(squirrel.Eq{"document_id": docID}).
+		OrderBy("line_no")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var lines []goods_transfer.GoodsTransferLine
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &lines, sql, args...); err != nil {
+		return nil, fmt.Errorf("get lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+func (r *GoodsTransferRepo) SaveLines(ctx context.Context, docID id.ID, lines []goods_transfer.GoodsTransferLine) error {
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+
+	deleteSQL := "DELETE FROM " + goodsTransferLinesTable + " WHERE document_id = $1"
+	if _, err := querier.Exec(ctx, deleteSQL, docID); err != nil {
+		return fmt.Errorf("delete existing lines: %w", err)
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	// Batch insert via COPY protocol (no 65,535 parameter limit).
+	columns := []string{
+		"line_id", "document_id", "line_no", "nomenclature_id",
+		"unit_id", "coefficient", "quantity",
+	}
+
+	rows := make([][]any, 0, len(lines))
+	for _, line := range lines {
+		rows = append(rows, []any{
+			line.LineID, docID, line.LineNo, line.NomenclatureID,
+			line.UnitID, line.Coefficient, line.Quantity,
+		})
+	}
+
+	txm := r.getTxManager(ctx)
+	inserter := postgres.NewBatchInserter(txm)
+	if _, err := inserter.CopyFromSlice(ctx, goodsTransferLinesTable, columns, rows); err != nil {
+		return fmt.Errorf("copy lines: %w", err)
+	}
+
+	return nil
+}
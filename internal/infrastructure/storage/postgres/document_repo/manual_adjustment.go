@@ -0,0 +1,113 @@
+package document_repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain/board"
+	"metapus/internal/domain/documents/manual_adjustment"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+const (
+	manualAdjustmentsTable     = "doc_manual_adjustments"
+	manualAdjustmentLinesTable = "doc_manual_adjustment_lines"
+)
+
+// manualAdjustmentLineColumns is the column order shared by GetLines and SaveLines.
+var manualAdjustmentLineColumns = []string{
+	"line_id", "line_no", "register", "record_type",
+	"warehouse_id", "nomenclature_id",
+	"counterparty_id", "contract_id",
+	"quantity", "amount",
+}
+
+// ManualAdjustmentRepo implements manual_adjustment.Repository.
+// List() is inherited from BaseDocumentRepo (universal filter engine).
+type ManualAdjustmentRepo struct {
+	*BaseDocumentRepo[*manual_adjustment.ManualAdjustment]
+}
+
+// NewManualAdjustmentRepo creates a new manual adjustment repository.
+func NewManualAdjustmentRepo() *ManualAdjustmentRepo {
+	repo := &ManualAdjustmentRepo{
+		BaseDocumentRepo: NewBaseDocumentRepo[*manual_adjustment.ManualAdjustment](
+			manualAdjustmentsTable,
+			postgres.ExtractDBColumns[manual_adjustment.ManualAdjustment](),
+			func() *manual_adjustment.ManualAdjustment { return &manual_adjustment.ManualAdjustment{} },
+		),
+	}
+
+	repo.RegisterTablePart("lines", manualAdjustmentLinesTable, "document_id", []string{
+		"register", "record_type", "warehouse_id", "nomenclature_id",
+		"counterparty_id", "contract_id", "quantity", "amount",
+	})
+
+	// Register RLS dimensions for DataScope filtering.
+	repo.RegisterRLSDimension("organization", "organization_id")
+
+	// Board states: draft → approved → posted (see manual_adjustment.BoardWorkflow).
+	repo.SetBoardStateExpr("CASE WHEN posted THEN '" + board.StatePosted + "' WHEN approved_by IS NOT NULL THEN '" +
+		manual_adjustment.BoardStateApproved + "' ELSE '" + board.StateDraft + "' END")
+
+	return repo
+}
+
+func (r *ManualAdjustmentRepo) GetLines(ctx context.Context, docID id.ID) ([]manual_adjustment.ManualAdjustmentLine, error) {
+	q := r.Builder().
+		Select(manualAdjustmentLineColumns...).
+		From(manualAdjustmentLinesTable).
+		Where(squirrel.Eq{"document_id": docID}).
+		OrderBy("line_no")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var lines []manual_adjustment.ManualAdjustmentLine
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &lines, sql, args...); err != nil {
+		return nil, fmt.Errorf("get lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+func (r *ManualAdjustmentRepo) SaveLines(ctx context.Context, docID id.ID, lines []manual_adjustment.ManualAdjustmentLine) error {
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+
+	deleteSQL := "DELETE FROM " + manualAdjustmentLinesTable + " WHERE document_id = $1"
+	if _, err := querier.Exec(ctx, deleteSQL, docID); err != nil {
+		return fmt.Errorf("delete existing lines: %w", err)
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	// Batch insert via COPY protocol (no 65,535 parameter limit).
+	columns := append([]string{"document_id"}, manualAdjustmentLineColumns...)
+
+	rows := make([][]any, 0, len(lines))
+	for _, line := range lines {
+		rows = append(rows, []any{
+			docID, line.LineID, line.LineNo, string(line.Register), string(line.RecordType),
+			line.WarehouseID, line.NomenclatureID,
+			line.CounterpartyID, line.ContractID,
+			line.Quantity, line.Amount,
+		})
+	}
+
+	txm := r.getTxManager(ctx)
+	inserter := postgres.NewBatchInserter(txm)
+	if _, err := inserter.CopyFromSlice(ctx, manualAdjustmentLinesTable, columns, rows); err != nil {
+		return fmt.Errorf("copy lines: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package document_repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain/catalogs/contract"
+	"metapus/internal/domain/catalogs/counterparty"
+	"metapus/internal/domain/catalogs/warehouse"
+	"metapus/internal/domain/documents/purchase_order"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+const (
+	purchaseOrdersTable     = "doc_purchase_orders"
+	purchaseOrderLinesTable = "doc_purchase_order_lines"
+)
+
+// PurchaseOrderRepo implements purchase_order.Repository.
+// List() is inherited from BaseDocumentRepo (universal filter engine).
+type PurchaseOrderRepo struct {
+	*BaseDocumentRepo[*purchase_order.PurchaseOrder]
+}
+
+// NewPurchaseOrderRepo creates a new purchase order repository.
+func NewPurchaseOrderRepo() *PurchaseOrderRepo {
+	repo := &PurchaseOrderRepo{
+		BaseDocumentRepo: NewBaseDocumentRepo[*purchase_order.PurchaseOrder](
+			purchaseOrdersTable,
+			postgres.ExtractDBColumns[purchase_order.PurchaseOrder](),
+			func() *purchase_order.PurchaseOrder { return &purchase_order.PurchaseOrder{} },
+		),
+	}
+
+	repo.RegisterTablePart("lines", purchaseOrderLinesTable, "document_id", []string{
+		"nomenclature_id", "unit_id", "quantity", "expected_date", "unit_price",
+		"discount_percent", "discount_amount",
+		"vat_rate_id", "vat_amount", "amount",
+	})
+
+	// Register reference fields for deep filtering
+	repo.RegisterReferenceField("counterparty_id", "cat_counterparties", "counterparty_id",
+		postgres.ExtractDBColumns[counterparty.Counterparty]())
+	repo.RegisterReferenceField("warehouse_id", "cat_warehouses", "warehouse_id",
+		postgres.ExtractDBColumns[warehouse.Warehouse]())
+	repo.RegisterReferenceField("contract_id", "cat_contracts", "contract_id",
+		postgres.ExtractDBColumns[contract.Contract]())
+
+	// Fulfillment status is maintained by the purchase order register.
+	repo.RegisterReadOnlyColumn("fulfillment_status")
+
+	// Board states: draft until posted, then the fulfillment status (see purchase_order.BoardWorkflow).
+	repo.SetBoardStateExpr("CASE WHEN posted THEN fulfillment_status ELSE '" + purchase_order.BoardStateDraft + "' END")
+
+	// Register RLS dimensions for DataScope filtering.
+	repo.RegisterRLSDimension("organization", "organization_id")
+	repo.RegisterRLSDimension("warehouse", "warehouse_id")
+
+	return repo
+}
+
+func (r *PurchaseOrderRepo) GetLines(ctx context.Context, docID id.ID) ([]purchase_order.PurchaseOrderLine, error) {
+	q := r.Builder().
+		Select(
+			"line_id", "line_no", "nomenclature_id",
+			"unit_id", "coefficient",
+			"quantity", "expected_date", "unit_price",
+			"discount_percent", "discount_amount",
+			"vat_rate_id", "vat_amount", "amount",
+		).
+		From(purchaseOrderLinesTable).
+		Where(squirrel.Eq{"document_id": docID}).
+		OrderBy("line_no")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var lines []purchase_order.PurchaseOrderLine
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &lines, sql, args...); err != nil {
+		return nil, fmt.Errorf("get lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+func (r *PurchaseOrderRepo) SaveLines(ctx context.Context, docID id.ID, lines []purchase_order.PurchaseOrderLine) error {
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+
+	deleteSQL := "DELETE FROM " + purchaseOrderLinesTable + " WHERE document_id = $1"
+	if _, err := querier.Exec(ctx, deleteSQL, docID); err != nil {
+		return fmt.Errorf("delete existing lines: %w", err)
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	// Batch insert via COPY protocol (no 65,535 parameter limit).
+	columns := []string{
+		"line_id", "document_id", "line_no", "nomenclature_id",
+		"unit_id", "coefficient",
+		"quantity", "expected_date", "unit_price",
+		"discount_percent", "discount_amount",
+		"vat_rate_id", "vat_amount", "amount",
+	}
+
+	rows := make([][]any, 0, len(lines))
+	for _, line := range lines {
+		rows = append(rows, []any{
+			line.LineID, docID, line.LineNo, line.NomenclatureID,
+			line.UnitID, line.Coefficient,
+			line.Quantity, line.ExpectedDate, line.UnitPrice,
+			line.DiscountPercent, line.DiscountAmount,
+			line.VATRateID, line.VATAmount, line.Amount,
+		})
+	}
+
+	txm := r.getTxManager(ctx)
+	inserter := postgres.NewBatchInserter(txm)
+	if _, err := inserter.CopyFromSlice(ctx, purchaseOrderLinesTable, columns, rows); err != nil {
+		return fmt.Errorf("copy lines: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package document_repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/core/types"
+	"metapus/internal/domain/documents/goods_receipt"
+	"metapus/internal/domain/repotest"
+	"metapus/internal/infrastructure/storage/postgres/pgtest"
+)
+
+// TestIntegrationDocumentRepoContract runs the shared document repository suite
+// against the goods receipt repository.
+func TestIntegrationDocumentRepoContract(t *testing.T) {
+	db := pgtest.Start(t)
+	ctx := db.Context(context.Background())
+
+	// Reference rows required by the foreign keys of the header and lines.
+	seed := func(query string, args ...any) id.ID {
+		t.Helper()
+		var rowID id.ID
+		if err := db.Pool.QueryRow(ctx, query, args...).Scan(&rowID); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+		return rowID
+	}
+	catalogRow := func(table string) id.ID {
+		code := repotest.Unique("rt")
+		return seed("INSERT INTO "+table+" (code, name) VALUES ($1, $1) RETURNING id", code)
+	}
+	iso := repotest.Unique("X")
+	userID := seed(`INSERT INTO users (email, password_hash) VALUES ($1, '-') RETURNING id`,
+		repotest.Unique("repotest-")+"@example.com")
+	currencyID := seed(`INSERT INTO cat_currencies (code, name, iso_code, symbol) VALUES ($1, $1, $1, '¤') RETURNING id`, iso)
+	orgID := catalogRow("cat_organizations")
+	counterpartyID := catalogRow("cat_counterparties")
+	warehouseID := catalogRow("cat_warehouses")
+	nomenclatureID := catalogRow("cat_nomenclatures")
+	vatRateID := catalogRow("cat_vat_rates")
+
+	repotest.DocumentSuite[*goods_receipt.GoodsReceipt, goods_receipt.GoodsReceiptLine]{
+		Repo: NewGoodsReceiptRepo(),
+		New: func(number string) *goods_receipt.GoodsReceipt {
+			doc := goods_receipt.NewGoodsReceipt(orgID, counterpartyID, warehouseID)
+			doc.Number = number
+			doc.Date = time.Now().UTC()
+			doc.CurrencyID = currencyID
+			doc.CreatedBy = userID
+			doc.UpdatedBy = userID
+			return doc
+		},
+		Document: func(d *goods_receipt.GoodsReceipt) *entity.Document { return &d.Document },
+		NewLines: func(n int) []goods_receipt.GoodsReceiptLine {
+			doc := goods_receipt.NewGoodsReceipt(orgID, counterpartyID, warehouseID)
+			for range n {
+				doc.AddLine(nomenclatureID, id.Nil(), decimal.NewFromInt(1),
+					types.NewQuantityFromFloat64(2), types.MinorUnits(1500), vatRateID, 0, decimal.Zero)
+			}
+			return doc.Lines
+		},
+	}.Run(t, ctx)
+}
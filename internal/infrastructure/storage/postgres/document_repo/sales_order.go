@@ -0,0 +1,132 @@
+package document_repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain/catalogs/contract"
+	"metapus/internal/domain/catalogs/counterparty"
+	"metapus/internal/domain/catalogs/warehouse"
+	"metapus/internal/domain/documents/sales_order"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+const (
+	salesOrdersTable     = "doc_sales_orders"
+	salesOrderLinesTable = "doc_sales_order_lines"
+)
+
+// SalesOrderRepo implements sales_order.Repository.
+// List() is inherited from BaseDocumentRepo (universal filter engine).
+type SalesOrderRepo struct {
+	*BaseDocumentRepo[*sales_order.SalesOrder]
+}
+
+// NewSalesOrderRepo creates a new sales order repository.
+func NewSalesOrderRepo() *SalesOrderRepo {
+	repo := &SalesOrderRepo{
+		BaseDocumentRepo: NewBaseDocumentRepo[*sales_order.SalesOrder](
+			salesOrdersTable,
+			postgres.ExtractDBColumns[sales_order.SalesOrder](),
+			func() *sales_order.SalesOrder { return &sales_order.SalesOrder{} },
+		),
+	}
+
+	repo.RegisterTablePart("lines", salesOrderLinesTable, "document_id", []string{
+		"nomenclature_id", "unit_id", "quantity", "unit_price",
+		"discount_percent", "discount_amount",
+		"vat_rate_id", "vat_amount", "amount",
+	})
+
+	// Register reference fields for deep filtering
+	repo.RegisterReferenceField("counterparty_id", "cat_counterparties", "counterparty_id",
+		postgres.ExtractDBColumns[counterparty.Counterparty]())
+	repo.RegisterReferenceField("warehouse_id", "cat_warehouses", "warehouse_id",
+		postgres.ExtractDBColumns[warehouse.Warehouse]())
+	repo.RegisterReferenceField("contract_id", "cat_contracts", "contract_id",
+		postgres.ExtractDBColumns[contract.Contract]())
+
+	// Status is maintained by the sales order register.
+	repo.RegisterReadOnlyColumn("status")
+
+	// Board states are the order statuses (see sales_order.BoardWorkflow).
+	repo.SetBoardStateExpr("status")
+
+	// Register RLS dimensions for DataScope filtering.
+	repo.RegisterRLSDimension("organization", "organization_id")
+	repo.RegisterRLSDimension("warehouse", "warehouse_id")
+
+	return repo
+}
+
+func (r *SalesOrderRepo) GetLines(ctx context.Context, docID id.ID) ([]sales_order.SalesOrderLine, error) {
+	q := r.Builder().
+		Select(
+			"line_id", "line_no", "nomenclature_id",
+			"unit_id", "coefficient",
+			"quantity", "unit_price",
+			"discount_percent", "discount_amount",
+			"vat_rate_id", "vat_amount", "amount",
+		).
+		From(salesOrderLinesTable).
+		Where(squirrel.Eq{"document_id": docID}).
+		OrderBy("line_no")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var lines []sales_order.SalesOrderLine
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &lines, sql, args...); err != nil {
+		return nil, fmt.Errorf("get lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+func (r *SalesOrderRepo) SaveLines(ctx context.Context, docID id.ID, lines []sales_order.SalesOrderLine) error {
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+
+	deleteSQL := "DELETE FROM " + salesOrderLinesTable + " WHERE document_id = $1"
+	if _, err := querier.Exec(ctx, deleteSQL, docID); err != nil {
+		return fmt.Errorf("delete existing lines: %w", err)
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	// Batch insert via COPY protocol (no 65,535 parameter limit).
+	columns := []string{
+		"line_id", "document_id", "line_no", "nomenclature_id",
+		"unit_id", "coefficient",
+		"quantity", "unit_price",
+		"discount_percent", "discount_amount",
+		"vat_rate_id", "vat_amount", "amount",
+	}
+
+	rows := make([][]any, 0, len(lines))
+	for _, line := range lines {
+		rows = append(rows, []any{
+			line.LineID, docID, line.LineNo, line.NomenclatureID,
+			line.UnitID, line.Coefficient,
+			line.Quantity, line.UnitPrice,
+			line.DiscountPercent, line.DiscountAmount,
+			line.VATRateID, line.VATAmount, line.Amount,
+		})
+	}
+
+	txm := r.getTxManager(ctx)
+	inserter := postgres.NewBatchInserter(txm)
+	if _, err := inserter.CopyFromSlice(ctx, salesOrderLinesTable, columns, rows); err != nil {
+		return fmt.Errorf("copy lines: %w", err)
+	}
+
+	return nil
+}
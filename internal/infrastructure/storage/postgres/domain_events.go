@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"metapus/internal/core/events"
+)
+
+// DomainEventAggregate is the outbox aggregate type of domain events
+// (events.Envelope). The worker routes these messages to the event sinks
+// instead of automation; the envelope carries the real aggregate.
+const DomainEventAggregate = "domain_event"
+
+// EventPublisher implements events.Publisher on top of sys_outbox.
+type EventPublisher struct{}
+
+// NewEventPublisher creates a domain event publisher.
+func NewEventPublisher() *EventPublisher {
+	return &EventPublisher{}
+}
+
+// Publish writes the envelope to the outbox within the current transaction.
+func (p *EventPublisher) Publish(ctx context.Context, env events.Envelope) error {
+	tx := MustGetTxManager(ctx).GetTx(ctx)
+	if tx == nil {
+		return fmt.Errorf("publish %s event requires transaction context", env.Type)
+	}
+
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("marshal %s event: %w", env.Type, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO sys_outbox (id, aggregate_type, aggregate_id, event_type, payload, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, env.ID, DomainEventAggregate, env.AggregateID, env.Type, payload, OutboxStatusPending, env.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("insert %s event: %w", env.Type, err)
+	}
+	return nil
+}
+
+// DecodeDomainEvent returns the envelope of a DomainEventAggregate message.
+func DecodeDomainEvent(msg *OutboxMessage) (events.Envelope, error) {
+	var env events.Envelope
+	if err := json.Unmarshal(msg.Payload, &env); err != nil {
+		return env, fmt.Errorf("unmarshal domain event %s: %w", msg.ID, err)
+	}
+	return env, nil
+}
+
+var _ events.Publisher = (*EventPublisher)(nil)
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain/graphql"
+	"metapus/internal/metadata"
+)
+
+// GraphQLLoader implements graphql.Loader over the entity tables named in
+// the metadata registry.
+type GraphQLLoader struct{}
+
+// NewGraphQLLoader creates a new GraphQLLoader.
+func NewGraphQLLoader() *GraphQLLoader {
+	return &GraphQLLoader{}
+}
+
+// Load selects the requested columns of t as JSON rows, so every column
+// type decodes the same way whatever the table.
+func (l *GraphQLLoader) Load(ctx context.Context, t *graphql.ObjectType, q graphql.LoadQuery) ([]graphql.Row, error) {
+	if len(q.Columns) == 0 {
+		return nil, nil
+	}
+	cols := make([]string, len(q.Columns))
+	for i, c := range q.Columns {
+		cols[i] = pgx.Identifier{c}.Sanitize()
+	}
+
+	var (
+		wheres []string
+		args   []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if q.IDs != nil {
+		wheres = append(wheres, "id = ANY("+arg(parseIDs(q.IDs))+")")
+	}
+	if q.ParentIDs != nil {
+		wheres = append(wheres, pgx.Identifier{t.ParentColumn}.Sanitize()+" = ANY("+arg(parseIDs(q.ParentIDs))+")")
+	}
+	for _, c := range q.Filters {
+		col := pgx.Identifier{c.Column}.Sanitize()
+		if c.Value == nil {
+			wheres = append(wheres, col+" IS NULL")
+			continue
+		}
+		p := arg(c.Value)
+		if c.Cast != "" {
+			p += "::" + c.Cast
+		}
+		wheres = append(wheres, col+" = "+p)
+	}
+	for _, s := range q.Scope {
+		wheres = append(wheres, pgx.Identifier{s.Column}.Sanitize()+"::text = ANY("+arg(s.Values)+")")
+	}
+
+	var b strings.Builder
+	b.WriteString("SELECT row_to_json(s) FROM (SELECT ")
+	b.WriteString(strings.Join(cols, ", "))
+	b.WriteString(" FROM " + pgx.Identifier{t.Table}.Sanitize())
+	if len(wheres) > 0 {
+		b.WriteString(" WHERE " + strings.Join(wheres, " AND "))
+	}
+	if order := graphQLOrder(t, q); order != "" {
+		b.WriteString(" ORDER BY " + order)
+	}
+	if q.Limit > 0 {
+		b.WriteString(" LIMIT " + strconv.Itoa(q.Limit))
+	}
+	if q.Offset > 0 {
+		b.WriteString(" OFFSET " + strconv.Itoa(q.Offset))
+	}
+	b.WriteString(") s")
+
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+	rows, err := querier.Query(ctx, b.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("graphql load %s: %w", t.Name, err)
+	}
+	defer rows.Close()
+
+	var out []graphql.Row
+	for rows.Next() {
+		var raw []byte
+		if err := rows.Scan(&raw); err != nil {
+			return nil, fmt.Errorf("graphql scan %s: %w", t.Name, err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		var row graphql.Row
+		if err := dec.Decode(&row); err != nil {
+			return nil, fmt.Errorf("graphql decode %s: %w", t.Name, err)
+		}
+		out = append(out, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("graphql load %s: %w", t.Name, err)
+	}
+	return out, nil
+}
+
+// graphQLOrder returns the ORDER BY clause: the requested column, else
+// lines by document and line number, documents newest first, catalogs by
+// name. Lookups by id need no order.
+func graphQLOrder(t *graphql.ObjectType, q graphql.LoadQuery) string {
+	if q.OrderBy != "" {
+		dir := " ASC"
+		if q.Desc {
+			dir = " DESC"
+		}
+		return pgx.Identifier{q.OrderBy}.Sanitize() + dir + ", id"
+	}
+	if t.IsLine() {
+		order := pgx.Identifier{t.ParentColumn}.Sanitize()
+		if graphQLHasColumn(t, "line_no") {
+			order += ", line_no"
+		}
+		return order
+	}
+	if q.IDs != nil {
+		return ""
+	}
+	if t.Entity.Type == metadata.TypeDocument {
+		return "date DESC, id DESC"
+	}
+	if graphQLHasColumn(t, "name") {
+		return "name, id"
+	}
+	return "id"
+}
+
+func graphQLHasColumn(t *graphql.ObjectType, column string) bool {
+	for _, f := range t.Fields {
+		if f.Column == column {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIDs converts ids (validated upstream) for an uuid[] parameter.
+func parseIDs(ids []string) []id.ID {
+	out := make([]id.ID, 0, len(ids))
+	for _, s := range ids {
+		if v, err := id.Parse(s); err == nil {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain/housekeeping"
+)
+
+// HousekeepingRepo implements housekeeping.Repository over the tenant database.
+type HousekeepingRepo struct{}
+
+// NewHousekeepingRepo creates a new housekeeping repository.
+func NewHousekeepingRepo() *HousekeepingRepo {
+	return &HousekeepingRepo{}
+}
+
+// PendingKeys returns idempotency keys still pending since before createdBefore.
+func (r *HousekeepingRepo) PendingKeys(ctx context.Context, createdBefore time.Time, limit int) ([]housekeeping.PendingKey, int, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	rows, err := q.Query(ctx, `
+		SELECT idempotency_key, user_id, operation, created_at, count(*) OVER ()
+		FROM sys_idempotency
+		WHERE status = 'pending' AND created_at < $1
+		ORDER BY created_at
+		LIMIT $2`, createdBefore, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query pending idempotency keys: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		keys  []housekeeping.PendingKey
+		total int
+	)
+	for rows.Next() {
+		var k housekeeping.PendingKey
+		if err := rows.Scan(&k.Key, &k.UserID, &k.Operation, &k.CreatedAt, &total); err != nil {
+			return nil, 0, fmt.Errorf("scan pending idempotency key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	return keys, total, rows.Err()
+}
+
+// UnpostedDocuments returns live unposted documents of table matching f,
+// oldest first. table comes from entity metadata, never from user input.
+func (r *HousekeepingRepo) UnpostedDocuments(ctx context.Context, table string, f housekeeping.UnpostedFilter, limit int) ([]housekeeping.DocumentRef, int, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	wheres := []string{"posted = FALSE", "deletion_mark = FALSE", "_deleted_at IS NULL"}
+	var args []any
+	add := func(cond string, v time.Time) {
+		if v.IsZero() {
+			return
+		}
+		args = append(args, v)
+		wheres = append(wheres, cond+" $"+strconv.Itoa(len(args)))
+	}
+	add("created_at <", f.CreatedBefore)
+	add("date <", f.DateBefore)
+	add("date >=", f.DateFrom)
+	args = append(args, limit)
+
+	rows, err := q.Query(ctx, `
+		SELECT id, number, date, count(*) OVER ()
+		FROM `+pgx.Identifier{table}.Sanitize()+`
+		WHERE `+strings.Join(wheres, " AND ")+`
+		ORDER BY date, id
+		LIMIT $`+strconv.Itoa(len(args)), args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query unposted documents in %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var (
+		docs  []housekeeping.DocumentRef
+		total int
+	)
+	for rows.Next() {
+		var d housekeeping.DocumentRef
+		if err := rows.Scan(&d.ID, &d.Number, &d.Date, &total); err != nil {
+			return nil, 0, fmt.Errorf("scan unposted document in %s: %w", table, err)
+		}
+		docs = append(docs, d)
+	}
+	return docs, total, rows.Err()
+}
+
+// TotalsMismatches returns live documents of dt whose totals differ from the
+// sums of their lines (a document without lines sums to zero), oldest
+// first. Tables and columns come from entity metadata, never from user input.
+func (r *HousekeepingRepo) TotalsMismatches(ctx context.Context, dt housekeeping.DocumentType, limit int) ([]housekeeping.DocumentRef, int, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	headerCols := make([]string, 0, len(dt.Totals))
+	for col := range dt.Totals {
+		headerCols = append(headerCols, col)
+	}
+	sort.Strings(headerCols)
+
+	sums := make([]string, 0, len(headerCols))
+	diffs := make([]string, 0, len(headerCols))
+	for i, col := range headerCols {
+		alias := "s" + strconv.Itoa(i)
+		sums = append(sums, "SUM("+pgx.Identifier{dt.Totals[col]}.Sanitize()+") AS "+alias)
+		diffs = append(diffs, "d."+pgx.Identifier{col}.Sanitize()+" <> COALESCE(l."+alias+", 0)")
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT d.id, d.number, d.date, count(*) OVER ()
+		FROM `+pgx.Identifier{dt.Table}.Sanitize()+` d
+		LEFT JOIN (
+			SELECT document_id, `+strings.Join(sums, ", ")+`
+			FROM `+pgx.Identifier{dt.LinesTable}.Sanitize()+`
+			GROUP BY document_id
+		) l ON l.document_id = d.id
+		WHERE d.deletion_mark = FALSE AND d._deleted_at IS NULL
+		  AND (`+strings.Join(diffs, " OR ")+`)
+		ORDER BY d.date, d.id
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query totals mismatches in %s: %w", dt.Table, err)
+	}
+	defer rows.Close()
+
+	var (
+		docs  []housekeeping.DocumentRef
+		total int
+	)
+	for rows.Next() {
+		var d housekeeping.DocumentRef
+		if err := rows.Scan(&d.ID, &d.Number, &d.Date, &total); err != nil {
+			return nil, 0, fmt.Errorf("scan totals mismatch in %s: %w", dt.Table, err)
+		}
+		docs = append(docs, d)
+	}
+	return docs, total, rows.Err()
+}
+
+// AdminUserIDs returns the active, not deleted administrators.
+func (r *HousekeepingRepo) AdminUserIDs(ctx context.Context) ([]id.ID, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	rows, err := q.Query(ctx, `
+		SELECT id FROM users
+		WHERE is_admin AND is_active AND deletion_mark = FALSE`)
+	if err != nil {
+		return nil, fmt.Errorf("query administrators: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []id.ID
+	for rows.Next() {
+		var uid id.ID
+		if err := rows.Scan(&uid); err != nil {
+			return nil, fmt.Errorf("scan administrator: %w", err)
+		}
+		ids = append(ids, uid)
+	}
+	return ids, rows.Err()
+}
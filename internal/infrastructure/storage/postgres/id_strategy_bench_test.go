@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"metapus/internal/core/id"
+)
+
+// BenchmarkIDStrategyInsert measures insert throughput and primary key index
+// size of a movements-like table for each ID strategy.
+//
+// Requires a scratch database:
+//
+//	BENCH_DATABASE_URL=postgres://... go test ./internal/infrastructure/storage/postgres -run '^$' -bench IDStrategyInsert
+//
+// Reported metrics: ns/op per inserted row and index-bytes/row of the PK.
+// Random UUIDv4 keys scatter inserts across the B-tree (page splits, more WAL,
+// a larger and fragmented index); UUIDv7 and ULID append to the right-most leaf.
+func BenchmarkIDStrategyInsert(b *testing.B) {
+	dsn := os.Getenv("BENCH_DATABASE_URL")
+	if dsn == "" {
+		b.Skip("BENCH_DATABASE_URL not set")
+	}
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer pool.Close()
+
+	const batch = 1000
+
+	for _, s := range []id.Strategy{id.StrategyUUIDv4, id.StrategyUUIDv7, id.StrategyULID} {
+		gen, err := id.NewGenerator(s)
+		if err != nil {
+			b.Fatal(err)
+		}
+		table := "bench_ids_" + string(s)
+
+		b.Run(string(s), func(b *testing.B) {
+			_, err := pool.Exec(ctx, fmt.Sprintf(`
+				DROP TABLE IF EXISTS %[1]s;
+				CREATE UNLOGGED TABLE %[1]s (
+					id          UUID PRIMARY KEY,
+					recorder_id UUID NOT NULL,
+					quantity    NUMERIC(18,4) NOT NULL,
+					period      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+				)`, table))
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer pool.Exec(ctx, "DROP TABLE IF EXISTS "+table) //nolint:errcheck
+
+			rows := make([][]any, batch)
+			b.ResetTimer()
+			for i := 0; i < b.N; i += batch {
+				n := min(batch, b.N-i)
+				for j := 0; j < n; j++ {
+					rows[j] = []any{gen.New(), gen.New(), j}
+				}
+				_, err := pool.CopyFrom(ctx, pgx.Identifier{table},
+					[]string{"id", "recorder_id", "quantity"}, pgx.CopyFromRows(rows[:n]))
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.StopTimer()
+
+			var indexBytes int64
+			if err := pool.QueryRow(ctx,
+				`SELECT pg_relation_size($1::regclass)`, table+"_pkey").Scan(&indexBytes); err != nil {
+				b.Fatal(err)
+			}
+			b.ReportMetric(float64(indexBytes)/float64(b.N), "index-bytes/row")
+		})
+	}
+}
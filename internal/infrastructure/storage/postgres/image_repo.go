@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/domain/gallery"
+)
+
+// ImageRepo implements gallery.Repository.
+type ImageRepo struct{}
+
+// NewImageRepo creates a new image repository.
+func NewImageRepo() *ImageRepo {
+	return &ImageRepo{}
+}
+
+func (r *ImageRepo) psql() squirrel.StatementBuilderType {
+	return squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+}
+
+var imageColumns = []string{
+	"id", "entity_type", "entity_id", "file_name", "content_type", "size", "width", "height",
+	"storage_key", "thumbnail_key", "sort_order", "is_primary", "uploaded_by", "created_at",
+}
+
+func scanImage(row pgx.Row, img *gallery.Image) error {
+	return row.Scan(
+		&img.ID, &img.EntityType, &img.EntityID, &img.FileName, &img.ContentType, &img.Size, &img.Width, &img.Height,
+		&img.StorageKey, &img.ThumbnailKey, &img.SortOrder, &img.IsPrimary, &img.UploadedBy, &img.CreatedAt,
+	)
+}
+
+// Create inserts image metadata at the end of the gallery; the first image
+// of an entity becomes primary.
+func (r *ImageRepo) Create(ctx context.Context, img *gallery.Image) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	const query = `
+		INSERT INTO sys_images (id, entity_type, entity_id, file_name, content_type, size, width, height,
+			storage_key, thumbnail_key, uploaded_by, sort_order, is_primary)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11,
+			COALESCE(MAX(sort_order), 0) + 1, COUNT(*) = 0
+		FROM sys_images
+		WHERE entity_type = $2 AND entity_id = $3
+		RETURNING sort_order, is_primary, created_at
+	`
+
+	err := querier.QueryRow(ctx, query,
+		img.ID, img.EntityType, img.EntityID, img.FileName, img.ContentType, img.Size, img.Width, img.Height,
+		img.StorageKey, img.ThumbnailKey, img.UploadedBy,
+	).Scan(&img.SortOrder, &img.IsPrimary, &img.CreatedAt)
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("execute insert: %w", err))
+	}
+	return nil
+}
+
+// GetByID returns a single image by ID.
+func (r *ImageRepo) GetByID(ctx context.Context, id uuid.UUID) (*gallery.Image, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Select(imageColumns...).
+		From("sys_images").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	var img gallery.Image
+	if err := scanImage(querier.QueryRow(ctx, query, args...), &img); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFound("image", id)
+		}
+		return nil, apperror.NewInternal(fmt.Errorf("scan image: %w", err))
+	}
+	return &img, nil
+}
+
+// ListByEntity returns the images of an entity in gallery order.
+func (r *ImageRepo) ListByEntity(ctx context.Context, entityType string, entityID uuid.UUID) ([]*gallery.Image, error) {
+	return r.list(ctx, squirrel.Eq{"entity_type": entityType, "entity_id": entityID})
+}
+
+// ListByEntities returns the images of several entities in gallery order.
+func (r *ImageRepo) ListByEntities(ctx context.Context, entityType string, entityIDs []uuid.UUID) ([]*gallery.Image, error) {
+	return r.list(ctx, squirrel.Eq{"entity_type": entityType, "entity_id": entityIDs})
+}
+
+func (r *ImageRepo) list(ctx context.Context, where squirrel.Eq) ([]*gallery.Image, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Select(imageColumns...).
+		From("sys_images").
+		Where(where).
+		OrderBy("entity_id", "sort_order", "created_at").
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("execute query: %w", err))
+	}
+	defer rows.Close()
+
+	list := make([]*gallery.Image, 0)
+	for rows.Next() {
+		img := &gallery.Image{}
+		if err := scanImage(rows, img); err != nil {
+			return nil, apperror.NewInternal(fmt.Errorf("scan image row: %w", err))
+		}
+		list = append(list, img)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("rows iteration error: %w", err))
+	}
+
+	return list, nil
+}
+
+// Delete removes image metadata and, if the image was primary, makes the
+// next image of the gallery primary in the same statement.
+func (r *ImageRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	// The UPDATE sees the rows as of before the DELETE, hence i.id <> $1.
+	const query = `
+		WITH deleted AS (
+			DELETE FROM sys_images WHERE id = $1
+			RETURNING entity_type, entity_id, is_primary
+		), promoted AS (
+			UPDATE sys_images SET is_primary = TRUE
+			WHERE id = (
+				SELECT i.id FROM sys_images i, deleted d
+				WHERE d.is_primary AND i.entity_type = d.entity_type AND i.entity_id = d.entity_id AND i.id <> $1
+				ORDER BY i.sort_order, i.created_at
+				LIMIT 1
+			)
+		)
+		SELECT COUNT(*) FROM deleted
+	`
+
+	var deleted int
+	if err := querier.QueryRow(ctx, query, id).Scan(&deleted); err != nil {
+		return apperror.NewInternal(fmt.Errorf("execute delete: %w", err))
+	}
+	if deleted == 0 {
+		return apperror.NewNotFound("image", id)
+	}
+	return nil
+}
+
+// SetOrder sets sort_order of the images to their position in ids.
+func (r *ImageRepo) SetOrder(ctx context.Context, entityType string, entityID uuid.UUID, ids []uuid.UUID) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	const query = `
+		UPDATE sys_images i SET sort_order = o.position
+		FROM unnest($3::uuid[]) WITH ORDINALITY AS o(id, position)
+		WHERE i.id = o.id AND i.entity_type = $1 AND i.entity_id = $2
+	`
+
+	if _, err := querier.Exec(ctx, query, entityType, entityID, ids); err != nil {
+		return apperror.NewInternal(fmt.Errorf("execute reorder: %w", err))
+	}
+	return nil
+}
+
+// SetPrimary makes the image the only primary image of the entity.
+func (r *ImageRepo) SetPrimary(ctx context.Context, entityType string, entityID, imageID uuid.UUID) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	const query = `
+		UPDATE sys_images SET is_primary = (id = $3)
+		WHERE entity_type = $1 AND entity_id = $2 AND (is_primary OR id = $3)
+	`
+
+	if _, err := querier.Exec(ctx, query, entityType, entityID, imageID); err != nil {
+		return apperror.NewInternal(fmt.Errorf("execute set primary: %w", err))
+	}
+	return nil
+}
+
+// Ensure interface compliance.
+var _ gallery.Repository = (*ImageRepo)(nil)
@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"metapus/internal/core/id"
+)
+
+// IntercompanyRepo implements intercompany.Repository.
+type IntercompanyRepo struct{}
+
+// NewIntercompanyRepo creates a new IntercompanyRepo.
+func NewIntercompanyRepo() *IntercompanyRepo {
+	return &IntercompanyRepo{}
+}
+
+// FindPair returns the goods issue and goods receipt of a transfer.
+func (r *IntercompanyRepo) FindPair(ctx context.Context, reference string) (*id.ID, *id.ID, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	var issueID, receiptID *id.ID
+	err := querier.QueryRow(ctx, `
+		SELECT
+			(SELECT id FROM doc_goods_issues WHERE intercompany_ref = $1),
+			(SELECT id FROM doc_goods_receipts WHERE intercompany_ref = $1)`,
+		reference,
+	).Scan(&issueID, &receiptID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find intercompany transfer: %w", err)
+	}
+	return issueID, receiptID, nil
+}
@@ -0,0 +1,282 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/jobs"
+)
+
+// defaultJobListLimit is the page size of List without a limit.
+const defaultJobListLimit = 100
+
+// JobRepo implements jobs.Repository.
+type JobRepo struct{}
+
+// NewJobRepo creates a new job queue repository.
+func NewJobRepo() *JobRepo {
+	return &JobRepo{}
+}
+
+func (r *JobRepo) psql() squirrel.StatementBuilderType {
+	return squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+}
+
+var jobColumns = []string{
+	"id", "job_type", "payload", "status", "attempts", "max_attempts", "run_at",
+	"locked_until", "last_error", "created_by", "created_at", "finished_at",
+}
+
+func scanJob(row pgx.Row, j *jobs.Job) error {
+	return row.Scan(
+		&j.ID, &j.Type, &j.Payload, &j.Status, &j.Attempts, &j.MaxAttempts, &j.RunAt,
+		&j.LockedUntil, &j.LastError, &j.CreatedBy, &j.CreatedAt, &j.FinishedAt,
+	)
+}
+
+// Insert enqueues a job within the current transaction, if any.
+func (r *JobRepo) Insert(ctx context.Context, j *jobs.Job) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	payload := []byte(j.Payload)
+	if len(payload) == 0 {
+		payload = []byte("{}")
+	}
+	query, args, err := r.psql().Insert("sys_jobs").
+		Columns("id", "job_type", "payload", "status", "max_attempts", "run_at", "created_by").
+		Values(j.ID, j.Type, payload, jobs.StatusPending, j.MaxAttempts, j.RunAt, j.CreatedBy).
+		Suffix("RETURNING attempts, last_error, created_at").
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build insert query: %w", err))
+	}
+
+	if err := querier.QueryRow(ctx, query, args...).Scan(&j.Attempts, &j.LastError, &j.CreatedAt); err != nil {
+		return apperror.NewInternal(fmt.Errorf("insert job: %w", err))
+	}
+	j.Status = jobs.StatusPending
+	return nil
+}
+
+// Claim locks due jobs until leaseUntil and counts the attempt.
+// SKIP LOCKED keeps concurrent workers from claiming the same row.
+func (r *JobRepo) Claim(ctx context.Context, types []string, now, leaseUntil time.Time, limit int) ([]*jobs.Job, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	// Built with "?" placeholders: the outer query renumbers them.
+	due, dueArgs, err := squirrel.Select("id").
+		From("sys_jobs").
+		Where(squirrel.Eq{"job_type": types}).
+		Where(squirrel.Or{
+			squirrel.And{
+				squirrel.Eq{"status": jobs.StatusPending},
+				squirrel.LtOrEq{"run_at": now},
+			},
+			squirrel.And{
+				squirrel.Eq{"status": jobs.StatusRunning},
+				squirrel.Lt{"locked_until": now},
+			},
+		}).
+		OrderBy("run_at ASC").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build claim subquery: %w", err))
+	}
+
+	query, args, err := r.psql().Update("sys_jobs").
+		Set("status", jobs.StatusRunning).
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("locked_until", leaseUntil).
+		Where(squirrel.Expr("id IN ("+due+")", dueArgs...)).
+		Suffix("RETURNING " + strings.Join(jobColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build claim query: %w", err))
+	}
+
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("claim jobs: %w", err))
+	}
+	defer rows.Close()
+
+	claimed := make([]*jobs.Job, 0, limit)
+	for rows.Next() {
+		j := &jobs.Job{}
+		if err := scanJob(rows, j); err != nil {
+			return nil, apperror.NewInternal(fmt.Errorf("scan claimed job: %w", err))
+		}
+		claimed = append(claimed, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("rows iteration error: %w", err))
+	}
+	return claimed, nil
+}
+
+// Complete marks a claimed job done.
+func (r *JobRepo) Complete(ctx context.Context, id uuid.UUID) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Update("sys_jobs").
+		Set("status", jobs.StatusDone).
+		Set("locked_until", nil).
+		Set("last_error", "").
+		Set("finished_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id, "status": jobs.StatusRunning}).
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build complete query: %w", err))
+	}
+	if _, err := querier.Exec(ctx, query, args...); err != nil {
+		return apperror.NewInternal(fmt.Errorf("complete job: %w", err))
+	}
+	return nil
+}
+
+// Fail records a failed attempt: the job is due again at retryAt, or dead
+// if retryAt is nil.
+func (r *JobRepo) Fail(ctx context.Context, id uuid.UUID, lastError string, retryAt *time.Time) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	q := r.psql().Update("sys_jobs").
+		Set("locked_until", nil).
+		Set("last_error", lastError)
+	if retryAt != nil {
+		q = q.Set("status", jobs.StatusPending).Set("run_at", *retryAt)
+	} else {
+		q = q.Set("status", jobs.StatusDead).Set("finished_at", squirrel.Expr("NOW()"))
+	}
+	query, args, err := q.Where(squirrel.Eq{"id": id, "status": jobs.StatusRunning}).ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build fail query: %w", err))
+	}
+	if _, err := querier.Exec(ctx, query, args...); err != nil {
+		return apperror.NewInternal(fmt.Errorf("record job failure: %w", err))
+	}
+	return nil
+}
+
+// GetByID returns a single job by ID.
+func (r *JobRepo) GetByID(ctx context.Context, id uuid.UUID) (*jobs.Job, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Select(jobColumns...).
+		From("sys_jobs").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	var j jobs.Job
+	if err := scanJob(querier.QueryRow(ctx, query, args...), &j); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFound("job", id)
+		}
+		return nil, apperror.NewInternal(fmt.Errorf("scan job: %w", err))
+	}
+	return &j, nil
+}
+
+// List returns jobs matching the filter, newest first.
+func (r *JobRepo) List(ctx context.Context, f jobs.Filter) ([]*jobs.Job, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = defaultJobListLimit
+	}
+	q := r.psql().Select(jobColumns...).
+		From("sys_jobs").
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(limit))
+	if f.Type != "" {
+		q = q.Where(squirrel.Eq{"job_type": f.Type})
+	}
+	if f.Status != "" {
+		q = q.Where(squirrel.Eq{"status": f.Status})
+	}
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("execute query: %w", err))
+	}
+	defer rows.Close()
+
+	list := make([]*jobs.Job, 0)
+	for rows.Next() {
+		j := &jobs.Job{}
+		if err := scanJob(rows, j); err != nil {
+			return nil, apperror.NewInternal(fmt.Errorf("scan job row: %w", err))
+		}
+		list = append(list, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("rows iteration error: %w", err))
+	}
+	return list, nil
+}
+
+// Requeue makes a dead job pending again with a fresh set of attempts.
+func (r *JobRepo) Requeue(ctx context.Context, id uuid.UUID) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Update("sys_jobs").
+		Set("status", jobs.StatusPending).
+		Set("attempts", 0).
+		Set("run_at", squirrel.Expr("NOW()")).
+		Set("finished_at", nil).
+		Where(squirrel.Eq{"id": id, "status": jobs.StatusDead}).
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build requeue query: %w", err))
+	}
+	cmdTag, err := querier.Exec(ctx, query, args...)
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("requeue job: %w", err))
+	}
+	if cmdTag.RowsAffected() == 0 {
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return err
+		}
+		return apperror.NewConflict("only dead jobs can be requeued").WithDetail("id", id)
+	}
+	return nil
+}
+
+// PurgeFinished deletes done jobs finished before the cutoff. Dead jobs stay
+// for an administrator to inspect and requeue.
+func (r *JobRepo) PurgeFinished(ctx context.Context, before time.Time) (int64, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Delete("sys_jobs").
+		Where(squirrel.Eq{"status": jobs.StatusDone}).
+		Where(squirrel.Lt{"finished_at": before}).
+		ToSql()
+	if err != nil {
+		return 0, apperror.NewInternal(fmt.Errorf("build purge query: %w", err))
+	}
+	cmdTag, err := querier.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, apperror.NewInternal(fmt.Errorf("purge jobs: %w", err))
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// Ensure interface compliance.
+var _ jobs.Repository = (*JobRepo)(nil)
@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/jobs"
+)
+
+// JobScheduleRepo implements jobs.ScheduleRepository.
+type JobScheduleRepo struct{}
+
+// NewJobScheduleRepo creates a new job schedule repository.
+func NewJobScheduleRepo() *JobScheduleRepo {
+	return &JobScheduleRepo{}
+}
+
+func (r *JobScheduleRepo) psql() squirrel.StatementBuilderType {
+	return squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+}
+
+var jobScheduleColumns = []string{
+	"id", "name", "job_type", "params", "cron_expr", "timezone", "misfire_policy",
+	"max_attempts", "active", "next_run_at", "last_run_at", "author_id",
+	"version", "created_at", "updated_at",
+}
+
+func scanJobSchedule(row pgx.Row, s *jobs.Schedule) error {
+	return row.Scan(
+		&s.ID, &s.Name, &s.JobType, &s.Params, &s.CronExpr, &s.Timezone, &s.MisfirePolicy,
+		&s.MaxAttempts, &s.Active, &s.NextRunAt, &s.LastRunAt, &s.AuthorID,
+		&s.Version, &s.CreatedAt, &s.UpdatedAt,
+	)
+}
+
+func scheduleParamsJSON(s *jobs.Schedule) []byte {
+	if len(s.Params) == 0 {
+		return []byte("{}")
+	}
+	return s.Params
+}
+
+// Create inserts a new schedule.
+func (r *JobScheduleRepo) Create(ctx context.Context, s *jobs.Schedule) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Insert("sys_schedules").
+		Columns("id", "name", "job_type", "params", "cron_expr", "timezone", "misfire_policy",
+			"max_attempts", "active", "next_run_at", "author_id").
+		Values(s.ID, s.Name, s.JobType, scheduleParamsJSON(s), s.CronExpr, s.Timezone, s.MisfirePolicy,
+			s.MaxAttempts, s.Active, s.NextRunAt, s.AuthorID).
+		Suffix("RETURNING version, created_at, updated_at").
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build insert query: %w", err))
+	}
+
+	if err := querier.QueryRow(ctx, query, args...).Scan(&s.Version, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return apperror.NewInternal(fmt.Errorf("execute insert: %w", err))
+	}
+	return nil
+}
+
+// Update modifies a schedule with optimistic locking.
+func (r *JobScheduleRepo) Update(ctx context.Context, s *jobs.Schedule) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Update("sys_schedules").
+		Set("name", s.Name).
+		Set("job_type", s.JobType).
+		Set("params", scheduleParamsJSON(s)).
+		Set("cron_expr", s.CronExpr).
+		Set("timezone", s.Timezone).
+		Set("misfire_policy", s.MisfirePolicy).
+		Set("max_attempts", s.MaxAttempts).
+		Set("active", s.Active).
+		Set("next_run_at", s.NextRunAt).
+		Set("version", squirrel.Expr("version + 1")).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": s.ID, "version": s.Version}).
+		Suffix("RETURNING version, created_at, updated_at").
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build update query: %w", err))
+	}
+
+	err = querier.QueryRow(ctx, query, args...).Scan(&s.Version, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.NewConcurrentModification("job_schedule", s.ID)
+		}
+		return apperror.NewInternal(fmt.Errorf("execute update: %w", err))
+	}
+	return nil
+}
+
+// Delete removes a schedule.
+func (r *JobScheduleRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Delete("sys_schedules").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build delete query: %w", err))
+	}
+	cmdTag, err := querier.Exec(ctx, query, args...)
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("execute delete: %w", err))
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return apperror.NewNotFound("job_schedule", id)
+	}
+	return nil
+}
+
+// GetByID returns a single schedule by ID.
+func (r *JobScheduleRepo) GetByID(ctx context.Context, id uuid.UUID) (*jobs.Schedule, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Select(jobScheduleColumns...).
+		From("sys_schedules").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	var s jobs.Schedule
+	if err := scanJobSchedule(querier.QueryRow(ctx, query, args...), &s); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFound("job_schedule", id)
+		}
+		return nil, apperror.NewInternal(fmt.Errorf("scan job schedule: %w", err))
+	}
+	return &s, nil
+}
+
+// List returns all schedules ordered by name.
+func (r *JobScheduleRepo) List(ctx context.Context) ([]*jobs.Schedule, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Select(jobScheduleColumns...).
+		From("sys_schedules").
+		OrderBy("name ASC", "id ASC").
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("execute query: %w", err))
+	}
+	defer rows.Close()
+
+	list := make([]*jobs.Schedule, 0)
+	for rows.Next() {
+		s := &jobs.Schedule{}
+		if err := scanJobSchedule(rows, s); err != nil {
+			return nil, apperror.NewInternal(fmt.Errorf("scan job schedule row: %w", err))
+		}
+		list = append(list, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("rows iteration error: %w", err))
+	}
+	return list, nil
+}
+
+// ClaimDue locks the most overdue active schedule until leaseUntil.
+// SKIP LOCKED keeps concurrent workers from claiming the same row.
+func (r *JobScheduleRepo) ClaimDue(ctx context.Context, now, leaseUntil time.Time) (*jobs.Schedule, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	// Built with "?" placeholders: the outer query renumbers them.
+	due, dueArgs, err := squirrel.Select("id").
+		From("sys_schedules").
+		Where(squirrel.Eq{"active": true}).
+		Where(squirrel.LtOrEq{"next_run_at": now}).
+		Where(squirrel.Or{
+			squirrel.Eq{"locked_until": nil},
+			squirrel.Lt{"locked_until": now},
+		}).
+		OrderBy("next_run_at ASC").
+		Limit(1).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build claim subquery: %w", err))
+	}
+
+	query, args, err := r.psql().Update("sys_schedules").
+		Set("locked_until", leaseUntil).
+		Where(squirrel.Expr("id = ("+due+")", dueArgs...)).
+		Suffix("RETURNING " + strings.Join(jobScheduleColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build claim query: %w", err))
+	}
+
+	var s jobs.Schedule
+	if err := scanJobSchedule(querier.QueryRow(ctx, query, args...), &s); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperror.NewInternal(fmt.Errorf("claim job schedule: %w", err))
+	}
+	return &s, nil
+}
+
+// Advance stores the next run of a claimed schedule and releases its lease.
+// The version is not bumped: run bookkeeping must not conflict with user edits.
+func (r *JobScheduleRepo) Advance(ctx context.Context, s *jobs.Schedule) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Update("sys_schedules").
+		Set("active", s.Active).
+		Set("next_run_at", s.NextRunAt).
+		Set("last_run_at", s.LastRunAt).
+		Set("locked_until", nil).
+		Where(squirrel.Eq{"id": s.ID}).
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build schedule advance: %w", err))
+	}
+	if _, err := querier.Exec(ctx, query, args...); err != nil {
+		return apperror.NewInternal(fmt.Errorf("advance job schedule: %w", err))
+	}
+	return nil
+}
+
+// Ensure interface compliance.
+var _ jobs.ScheduleRepository = (*JobScheduleRepo)(nil)
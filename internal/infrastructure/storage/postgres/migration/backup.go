@@ -0,0 +1,191 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"metapus/internal/core/tenant"
+)
+
+// BackupTenant dumps the tenant database at dsn into dir and registers the
+// backup in store. The file is named <slug>_<UTC timestamp>.dump.
+func BackupTenant(ctx context.Context, store tenant.BackupStore, t *tenant.Tenant, dsn, dir string, source tenant.BackupSource) (*tenant.Backup, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create backup dir: %w", err)
+	}
+	path, err := filepath.Abs(filepath.Join(dir, fmt.Sprintf("%s_%s.dump", t.Slug, time.Now().UTC().Format("20060102T150405Z"))))
+	if err != nil {
+		return nil, fmt.Errorf("backup path: %w", err)
+	}
+
+	size, checksum, err := DumpDatabase(ctx, dsn, path)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &tenant.Backup{
+		TenantID:      t.ID,
+		FilePath:      path,
+		SizeBytes:     size,
+		Checksum:      checksum,
+		SchemaVersion: t.SchemaVersion,
+		Source:        source,
+	}
+	if err := store.Create(ctx, b); err != nil {
+		_ = os.Remove(path) // an unregistered dump cannot be verified on restore
+		return nil, err
+	}
+	return b, nil
+}
+
+// DumpDatabase writes a pg_dump archive (custom format) of the database at
+// dsn to path and returns its size and SHA-256 checksum. The dump goes to a
+// temporary file renamed on success, so path never holds a partial dump.
+// Of a shared database only the schema of the tenant is dumped.
+// The pg_dump binary must be on PATH.
+func DumpDatabase(ctx context.Context, dsn, path string) (int64, string, error) {
+	tmp := path + ".partial"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		return 0, "", fmt.Errorf("create backup file: %w", err)
+	}
+	defer os.Remove(tmp) // no-op after the rename
+
+	hash := sha256.New()
+	counter := &countingWriter{}
+	args := []string{"--format=custom", "--no-owner", "--no-privileges", "--dbname=" + dsn}
+	if schema := dsnSchema(dsn); schema != "" {
+		args = append(args, "--schema="+schema)
+	}
+	dump := exec.CommandContext(ctx, "pg_dump", args...)
+	var dumpErr bytes.Buffer
+	dump.Stdout = io.MultiWriter(f, hash, counter)
+	dump.Stderr = &dumpErr
+
+	if err := dump.Run(); err != nil {
+		_ = f.Close()
+		return 0, "", fmt.Errorf("pg_dump: %w: %s", err, strings.TrimSpace(dumpErr.String()))
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return 0, "", fmt.Errorf("sync backup file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return 0, "", fmt.Errorf("close backup file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return 0, "", fmt.Errorf("finalize backup file: %w", err)
+	}
+	return counter.n, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// VerifyBackup checks that the backup file still has its registered size
+// and checksum.
+func VerifyBackup(b *tenant.Backup) error {
+	f, err := os.Open(b.FilePath)
+	if err != nil {
+		return fmt.Errorf("open backup file: %w", err)
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, f)
+	if err != nil {
+		return fmt.Errorf("read backup file: %w", err)
+	}
+	if size != b.SizeBytes {
+		return fmt.Errorf("backup file size is %d bytes, registered %d", size, b.SizeBytes)
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != b.Checksum {
+		return fmt.Errorf("backup file checksum %s does not match registered %s", sum, b.Checksum)
+	}
+	return nil
+}
+
+// RestoreDatabase restores the pg_dump archive at path into the database at
+// dsn, replacing the objects it contains (pg_restore --clean) in a single
+// transaction: on failure the database is left as it was. Objects created
+// after the backup are not dropped, so restore onto the schema version the
+// backup was made at. The pg_restore binary must be on PATH.
+func RestoreDatabase(ctx context.Context, dsn, path string) error {
+	restore := exec.CommandContext(ctx, "pg_restore",
+		"--clean", "--if-exists", "--single-transaction", "--exit-on-error",
+		"--no-owner", "--no-privileges", "--dbname="+dsn, path)
+	var restoreErr bytes.Buffer
+	restore.Stderr = &restoreErr
+
+	if err := restore.Run(); err != nil {
+		return fmt.Errorf("pg_restore: %w: %s", err, strings.TrimSpace(restoreErr.String()))
+	}
+	return nil
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct{ n int64 }
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// BackupScheduler makes the worker's scheduled tenant backups. Dumps run one
+// at a time across tenants, so the hourly check of all tenants does not start
+// a pg_dump per tenant at once.
+type BackupScheduler struct {
+	store    tenant.BackupStore
+	dir      string
+	interval time.Duration
+	keep     int
+
+	mu sync.Mutex
+}
+
+// NewBackupScheduler creates a scheduler that backs up each tenant every
+// interval into dir and keeps its newest keep scheduled backups
+// (0 keeps all).
+func NewBackupScheduler(store tenant.BackupStore, dir string, interval time.Duration, keep int) *BackupScheduler {
+	return &BackupScheduler{store: store, dir: dir, interval: interval, keep: keep}
+}
+
+// RunDue backs up t when its newest scheduled backup is older than the
+// interval, then prunes scheduled backups beyond the newest keep (records
+// and files). Returns the number of backups made (0 or 1).
+func (s *BackupScheduler) RunDue(ctx context.Context, t *tenant.Tenant, dsn string) (int, error) {
+	backups, err := s.store.ListByTenant(ctx, t.ID, tenant.BackupScheduled)
+	if err != nil {
+		return 0, err
+	}
+	if len(backups) > 0 && time.Since(backups[0].CreatedAt) < s.interval {
+		return 0, nil
+	}
+
+	s.mu.Lock()
+	b, err := BackupTenant(ctx, s.store, t, dsn, s.dir, tenant.BackupScheduled)
+	s.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+
+	if s.keep > 0 {
+		backups = append([]*tenant.Backup{b}, backups...)
+		for _, old := range backups[min(s.keep, len(backups)):] {
+			if err := os.Remove(old.FilePath); err != nil && !os.IsNotExist(err) {
+				return 1, fmt.Errorf("remove old backup: %w", err)
+			}
+			if err := s.store.Delete(ctx, old.ID); err != nil {
+				return 1, err
+			}
+		}
+	}
+	return 1, nil
+}
@@ -0,0 +1,159 @@
+package migration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"metapus/internal/core/tenant"
+	"metapus/internal/domain/accountexport"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+// ExportStaleAfter is how long a running export may go without progress
+// before another worker claims it again (the first one is assumed dead).
+const ExportStaleAfter = time.Hour
+
+// ExportTenant writes export e of tenant t (database at dsn) into dir as
+// <slug>_export_<UTC timestamp>.zip: all catalogs, documents and registers
+// (plus users and roles without credentials) from one consistent snapshot,
+// in the layout of accountexport. Progress is stored after each table and
+// passed to report (may be nil); e ends up completed or failed in store.
+// The tenant does not have to be active.
+func ExportTenant(ctx context.Context, store tenant.ExportStore, e *tenant.Export, t *tenant.Tenant, dsn, dir string, report func(*tenant.Export)) error {
+	err := exportTenant(ctx, store, e, t, dsn, dir, report)
+	if err != nil {
+		if ferr := store.Fail(context.WithoutCancel(ctx), e.ID, err); ferr != nil {
+			return fmt.Errorf("%w (and %v)", err, ferr)
+		}
+		e.Status = tenant.ExportFailed
+		e.Error = err.Error()
+		return err
+	}
+	return store.Complete(ctx, e)
+}
+
+func exportTenant(ctx context.Context, store tenant.ExportStore, e *tenant.Export, t *tenant.Tenant, dsn, dir string, report func(*tenant.Export)) error {
+	if !e.Format.Valid() {
+		return fmt.Errorf("unsupported export format %q", e.Format)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("create export dir: %w", err)
+	}
+	path, err := filepath.Abs(filepath.Join(dir, fmt.Sprintf("%s_export_%s.zip", t.Slug, time.Now().UTC().Format("20060102T150405Z"))))
+	if err != nil {
+		return fmt.Errorf("export path: %w", err)
+	}
+
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return fmt.Errorf("parse tenant dsn: %w", err)
+	}
+	cfg.MaxConns = 1
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("connect tenant database: %w", err)
+	}
+	defer pool.Close()
+	dbCtx := tenant.WithTxManager(tenant.WithPool(ctx, pool), postgres.NewTxManagerFromRawPool(pool))
+
+	// Written to a temporary file renamed on success, so path never holds a
+	// partial export.
+	tmp := path + ".partial"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		return fmt.Errorf("create export file: %w", err)
+	}
+	defer os.Remove(tmp) // no-op after the rename
+
+	hash := sha256.New()
+	counter := &countingWriter{}
+	_, err = accountexport.WriteArchive(dbCtx, postgres.NewAccountDataSource(), io.MultiWriter(f, hash, counter), t.ID,
+		accountexport.ArchiveOptions{
+			Format: archiveFormat(e.Format),
+			Progress: func(_ context.Context, p accountexport.Progress) error {
+				e.TablesTotal, e.TablesDone, e.RowsExported, e.CurrentTable = p.TablesTotal, p.TablesDone, p.Rows, p.Table
+				// Progress is informational: a failed write must not abort
+				// an export that may have run for an hour.
+				_ = store.UpdateProgress(ctx, e)
+				if report != nil {
+					report(e)
+				}
+				return nil
+			},
+		})
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("sync export file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close export file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("finalize export file: %w", err)
+	}
+
+	e.FilePath = path
+	e.SizeBytes = counter.n
+	e.Checksum = hex.EncodeToString(hash.Sum(nil))
+	return nil
+}
+
+// archiveFormat maps an export format to the accountexport file format
+// (JSONL files are NDJSON).
+func archiveFormat(f tenant.ExportFormat) accountexport.FileFormat {
+	if f == tenant.ExportCSV {
+		return accountexport.FormatCSV
+	}
+	return accountexport.FormatNDJSON
+}
+
+// ExportRunner runs the exports queued with `tenant export --async` in the
+// worker, one at a time.
+type ExportRunner struct {
+	store    tenant.ExportStore
+	registry tenant.Registry
+	dsn      func(*tenant.Tenant) string
+	dir      string
+}
+
+// NewExportRunner creates a runner writing exports into dir; dsn addresses
+// the tenant database.
+func NewExportRunner(store tenant.ExportStore, registry tenant.Registry, dsn func(*tenant.Tenant) string, dir string) *ExportRunner {
+	return &ExportRunner{store: store, registry: registry, dsn: dsn, dir: dir}
+}
+
+// RunPending runs queued exports until none is left. Returns the number of
+// exports run (completed or failed) and the first error of the queue
+// itself; a failed export is recorded on its job and does not stop the run.
+func (r *ExportRunner) RunPending(ctx context.Context) (int, error) {
+	n := 0
+	for ctx.Err() == nil {
+		e, err := r.store.ClaimNext(ctx, ExportStaleAfter)
+		if err != nil || e == nil {
+			return n, err
+		}
+		n++
+
+		t, err := r.registry.GetByID(ctx, e.TenantID)
+		if err != nil {
+			if ferr := r.store.Fail(ctx, e.ID, fmt.Errorf("get tenant: %w", err)); ferr != nil {
+				return n, ferr
+			}
+			continue
+		}
+		_ = ExportTenant(ctx, r.store, e, t, r.dsn(t), r.dir, nil)
+	}
+	return n, ctx.Err()
+}
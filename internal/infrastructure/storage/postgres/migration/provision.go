@@ -0,0 +1,78 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ProvisionDatabase creates the database named in dsn and applies all
+// migrations to it. A database that already exists is reused, so a
+// provisioning that failed halfway can simply be repeated. For a
+// shared-schema tenant (tenant.Tenant.DSN with a search_path) the database
+// is shared: the tenant's schema is created in it instead.
+func ProvisionDatabase(ctx context.Context, dsn string) (output string, err error) {
+	if err := createDatabase(ctx, dsn); err != nil {
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) || pgErr.Code != "42P04" { // duplicate_database
+			return "", err
+		}
+	}
+	if schema := dsnSchema(dsn); schema != "" {
+		if err := createSchema(ctx, dsn, schema); err != nil {
+			return "", err
+		}
+	}
+	output, err = RunAll(dsn)
+	if err != nil {
+		return output, fmt.Errorf("migrate: %w", err)
+	}
+	return output, nil
+}
+
+// sharedExtensions are the extensions of the core migrations. In a shared
+// database they are installed once into public, which is on the search_path
+// of every tenant schema; the migrations' CREATE EXTENSION IF NOT EXISTS
+// then leave them alone.
+var sharedExtensions = []string{"pgcrypto", "pg_trgm", "btree_gin"}
+
+// createSchema creates schema and the shared extensions in the database at dsn.
+func createSchema(ctx context.Context, dsn, schema string) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("connect to shared database: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	for _, ext := range sharedExtensions {
+		if _, err := conn.Exec(ctx, "CREATE EXTENSION IF NOT EXISTS "+pgx.Identifier{ext}.Sanitize()+" SCHEMA public"); err != nil {
+			return fmt.Errorf("create extension %s: %w", ext, err)
+		}
+	}
+	if _, err := conn.Exec(ctx, "CREATE SCHEMA IF NOT EXISTS "+pgx.Identifier{schema}.Sanitize()); err != nil {
+		return fmt.Errorf("create schema %s: %w", schema, err)
+	}
+	return nil
+}
+
+// dsnSchema returns the tenant schema of a shared-schema tenant DSN: the
+// first entry of the search_path its options parameter sets. Empty for a
+// tenant with its own database.
+func dsnSchema(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return ""
+	}
+	for _, opt := range strings.Fields(u.Query().Get("options")) {
+		if path, ok := strings.CutPrefix(opt, "-csearch_path="); ok {
+			schema, _, _ := strings.Cut(path, ",")
+			return schema
+		}
+	}
+	return ""
+}
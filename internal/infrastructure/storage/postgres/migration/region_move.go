@@ -0,0 +1,320 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/tenant"
+	"metapus/pkg/logger"
+)
+
+// RegionMover moves a tenant database to another region/cluster.
+//
+// Lifecycle: status → updating, evict pool, copy database to the target
+// placement (pg_dump | pg_restore), switch placement in the registry,
+// restore status. The source database is never modified, so on failure the
+// tenant is simply re-activated at its old placement and the error is saved
+// to the migration state store (visible via migration-status).
+type RegionMover struct {
+	registry   tenant.Registry
+	manager    *tenant.Manager
+	stateStore tenant.MigrationStateStore
+	log        *logger.Logger
+
+	// running tracks tenants currently being moved (prevents double-trigger).
+	running sync.Map // map[tenantID]bool
+
+	// Lifecycle context for background goroutines.
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRegionMover creates a new region mover.
+func NewRegionMover(
+	registry tenant.Registry,
+	manager *tenant.Manager,
+	stateStore tenant.MigrationStateStore,
+	log *logger.Logger,
+) *RegionMover {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &RegionMover{
+		registry:   registry,
+		manager:    manager,
+		stateStore: stateStore,
+		log:        log.WithComponent("region-mover"),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// IsMoving returns true if the tenant is currently being moved.
+func (m *RegionMover) IsMoving(tenantID string) bool {
+	_, ok := m.running.Load(tenantID)
+	return ok
+}
+
+// StartMove initiates a background move of the tenant database to target.
+// Returns immediately after marking the tenant as "updating".
+func (m *RegionMover) StartMove(ctx context.Context, tenantID string, target tenant.Placement) error {
+	if err := target.Validate(); err != nil {
+		return fmt.Errorf("invalid target placement: %w", err)
+	}
+
+	if _, loaded := m.running.LoadOrStore(tenantID, true); loaded {
+		return fmt.Errorf("tenant %s is already being moved", tenantID)
+	}
+
+	t, err := m.registry.GetByID(ctx, tenantID)
+	if err != nil {
+		m.running.Delete(tenantID)
+		return err
+	}
+
+	if t.Status != tenant.StatusActive {
+		m.running.Delete(tenantID)
+		return fmt.Errorf("cannot move tenant with status %q (must be active)", t.Status)
+	}
+
+	if t.SharedSchema() {
+		m.running.Delete(tenantID)
+		return fmt.Errorf("cannot move shared-schema tenant %s: its database is shared with other tenants", t.Slug)
+	}
+
+	if t.Placement() == target {
+		m.running.Delete(tenantID)
+		return fmt.Errorf("tenant %s is already placed at %s/%s", t.Slug, target.Region, target.DBHost)
+	}
+
+	// Mark as updating (blocks new HTTP requests via middleware)
+	if err := m.registry.UpdateStatusByID(ctx, tenantID, tenant.StatusUpdating); err != nil {
+		m.running.Delete(tenantID)
+		return fmt.Errorf("set updating status: %w", err)
+	}
+
+	// Release all connections so the dump sees a quiescent database
+	m.manager.EvictPool(tenantID)
+
+	m.log.Info("starting tenant region move",
+		"tenant_id", tenantID,
+		"slug", t.Slug,
+		"from_region", t.Region,
+		"to_region", target.Region,
+		"to_host", target.DBHost,
+	)
+
+	srcDSN := m.manager.TenantDSN(t)
+	dstDSN := m.manager.PlacementDSN(t, target)
+
+	m.wg.Go(func() {
+		m.runMoveBackground(t, target, srcDSN, dstDSN)
+	})
+
+	return nil
+}
+
+// WaitForAll blocks until all background moves complete.
+// Called during graceful shutdown.
+func (m *RegionMover) WaitForAll() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+// runMoveBackground copies the database and switches the tenant placement.
+func (m *RegionMover) runMoveBackground(t *tenant.Tenant, target tenant.Placement, srcDSN, dstDSN string) {
+	defer m.running.Delete(t.ID)
+
+	ctx := m.ctx
+
+	// 1. Copy database (skipped when only placement labels change).
+	if SameDatabase(srcDSN, dstDSN) {
+		m.log.Info("region move: target is the same database, skipping copy",
+			"tenant_id", t.ID,
+		)
+	} else if err := CopyDatabase(ctx, srcDSN, dstDSN); err != nil {
+		m.log.Error("region move: database copy failed",
+			"tenant_id", t.ID,
+			"slug", t.Slug,
+			"error", err,
+		)
+		m.abort(ctx, t, fmt.Sprintf("copy database: %v", err))
+		return
+	}
+
+	// 2. Switch placement.
+	if err := m.registry.UpdatePlacement(ctx, t.ID, target); err != nil {
+		m.log.Error("region move: failed to update placement",
+			"tenant_id", t.ID,
+			"error", err,
+		)
+		m.abort(ctx, t, fmt.Sprintf("update placement: %v", err))
+		return
+	}
+
+	// 3. Drop any pool created against the old placement and reactivate.
+	m.manager.EvictPool(t.ID)
+
+	if serr := m.stateStore.ClearState(ctx, t.ID); serr != nil {
+		m.log.Error("failed to clear migration state after region move",
+			"tenant_id", t.ID,
+			"error", serr,
+		)
+	}
+
+	if serr := m.registry.UpdateStatusByID(ctx, t.ID, tenant.StatusActive); serr != nil {
+		m.log.Error("CRITICAL: failed to restore tenant status after region move",
+			"tenant_id", t.ID,
+			"error", serr,
+		)
+	}
+
+	m.log.Info("tenant region move completed",
+		"tenant_id", t.ID,
+		"slug", t.Slug,
+		"region", target.Region,
+		"cluster", target.Cluster,
+		"db_host", target.DBHost,
+	)
+}
+
+// abort re-activates the tenant at its old placement and records the error.
+func (m *RegionMover) abort(ctx context.Context, t *tenant.Tenant, errMsg string) {
+	if serr := m.stateStore.SaveLastError(ctx, t.ID, "region move: "+errMsg); serr != nil {
+		m.log.Error("failed to save region move error",
+			"tenant_id", t.ID,
+			"error", serr,
+		)
+	}
+
+	if serr := m.registry.UpdateStatusByID(ctx, t.ID, tenant.StatusActive); serr != nil {
+		m.log.Error("CRITICAL: failed to restore tenant status after failed region move",
+			"tenant_id", t.ID,
+			"slug", t.Slug,
+			"error", serr,
+		)
+	}
+}
+
+// SameDatabase reports whether two DSNs point to the same host, port and database.
+func SameDatabase(a, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return ua.Host == ub.Host && ua.Path == ub.Path
+}
+
+// CopyDatabase creates the target database and copies the source into it
+// with pg_dump | pg_restore. The pg_dump/pg_restore binaries must be on PATH
+// and the target user must have CREATEDB on the target server.
+// Fails if the target database already exists, to never overwrite data.
+func CopyDatabase(ctx context.Context, srcDSN, dstDSN string) error {
+	if err := createDatabase(ctx, dstDSN); err != nil {
+		return err
+	}
+
+	dump := exec.CommandContext(ctx, "pg_dump", "--format=custom", "--no-owner", "--no-privileges", "--dbname="+srcDSN)
+	restore := exec.CommandContext(ctx, "pg_restore", "--no-owner", "--no-privileges", "--exit-on-error", "--dbname="+dstDSN)
+
+	var dumpErr, restoreErr bytes.Buffer
+	dump.Stderr = &dumpErr
+	restore.Stderr = &restoreErr
+
+	pipe, err := dump.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("pg_dump pipe: %w", err)
+	}
+	restore.Stdin = pipe
+
+	if err := restore.Start(); err != nil {
+		return fmt.Errorf("start pg_restore: %w", err)
+	}
+	if err := dump.Run(); err != nil {
+		_ = restore.Wait()
+		return fmt.Errorf("pg_dump: %w: %s", err, strings.TrimSpace(dumpErr.String()))
+	}
+	if err := restore.Wait(); err != nil {
+		return fmt.Errorf("pg_restore: %w: %s", err, strings.TrimSpace(restoreErr.String()))
+	}
+	return nil
+}
+
+// DropDatabase drops the database named in dsn via the server's "postgres"
+// maintenance database, terminating its remaining sessions. Used by tenant
+// offboarding once the database has been archived. For a shared-schema
+// tenant only its schema is dropped; the shared database stays.
+func DropDatabase(ctx context.Context, dsn string) error {
+	if schema := dsnSchema(dsn); schema != "" {
+		return dropSchema(ctx, dsn, schema)
+	}
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return fmt.Errorf("parse dsn: %w", err)
+	}
+	dbName := strings.TrimPrefix(u.Path, "/")
+	if dbName == "" || dbName == "postgres" {
+		return fmt.Errorf("refusing to drop database %q", dbName)
+	}
+	u.Path = "/postgres"
+
+	conn, err := pgx.Connect(ctx, u.String())
+	if err != nil {
+		return fmt.Errorf("connect to server: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "DROP DATABASE IF EXISTS "+pgx.Identifier{dbName}.Sanitize()+" WITH (FORCE)"); err != nil {
+		return fmt.Errorf("drop database %s: %w", dbName, err)
+	}
+	return nil
+}
+
+// dropSchema drops schema with all its objects from the database at dsn.
+func dropSchema(ctx context.Context, dsn, schema string) error {
+	if schema == "public" {
+		return fmt.Errorf("refusing to drop schema %q", schema)
+	}
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("connect to shared database: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "DROP SCHEMA IF EXISTS "+pgx.Identifier{schema}.Sanitize()+" CASCADE"); err != nil {
+		return fmt.Errorf("drop schema %s: %w", schema, err)
+	}
+	return nil
+}
+
+// createDatabase creates the database named in dsn via the server's
+// "postgres" maintenance database.
+func createDatabase(ctx context.Context, dsn string) error {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return fmt.Errorf("parse target dsn: %w", err)
+	}
+	dbName := strings.TrimPrefix(u.Path, "/")
+	if dbName == "" {
+		return fmt.Errorf("target dsn has no database name")
+	}
+	u.Path = "/postgres"
+
+	conn, err := pgx.Connect(ctx, u.String())
+	if err != nil {
+		return fmt.Errorf("connect to target server: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "CREATE DATABASE "+pgx.Identifier{dbName}.Sanitize()); err != nil {
+		return fmt.Errorf("create target database %s: %w", dbName, err)
+	}
+	return nil
+}
@@ -24,24 +24,23 @@ import (
 
 	// pgx stdlib adapter for database/sql — required by goose.
 	_ "github.com/jackc/pgx/v5/stdlib"
+
+	schema "metapus/db"
 )
 
 const coreMigrationsDir = "db/migrations"
 
-// coreMigrationsFS holds an optional embedded FS for core migrations.
-// Set by cmd packages via SetCoreMigrationsFS before RunAll is called.
-// If nil, os.DirFS is used as fallback (works when binary runs from repo root).
+// coreMigrationsFS optionally overrides the core migrations embedded in the
+// binary (db.Migrations), e.g. with a repository checkout in tests.
 var (
 	coreMigrationsFS fs.FS
 	coreMigrationsMu sync.Mutex
 )
 
-// SetCoreMigrationsFS sets the embedded filesystem for core migrations.
-// Call this from cmd/server/main.go or cmd/tenant/main.go before any migration runs.
+// SetCoreMigrationsFS overrides the embedded core migrations with fsys,
+// which holds them under db/migrations/.
 //
-//	//go:embed db/migrations/*.sql
-//	var coreMigrations embed.FS
-//	migration.SetCoreMigrationsFS(coreMigrations)
+//	migration.SetCoreMigrationsFS(os.DirFS(repoRoot))
 func SetCoreMigrationsFS(fsys fs.FS) {
 	coreMigrationsMu.Lock()
 	defer coreMigrationsMu.Unlock()
@@ -99,24 +98,27 @@ func Dirs() []string {
 }
 
 // fsForDir returns the appropriate fs.FS for a migration directory.
-// Core migrations use injected embed.FS (via SetCoreMigrationsFS) if available,
-// otherwise fall back to os.DirFS (for CLI usage outside containers).
+// Core migrations come from the binary (db.Migrations) unless overridden via
+// SetCoreMigrationsFS, so they run from any working directory.
 // Extension directories always use os.DirFS.
 func fsForDir(dir string) (fs.FS, error) {
 	if dir == coreMigrationsDir {
 		coreMigrationsMu.Lock()
-		embeddedFS := coreMigrationsFS
+		overrideFS := coreMigrationsFS
 		coreMigrationsMu.Unlock()
 
-		if embeddedFS != nil {
-			sub, err := fs.Sub(embeddedFS, coreMigrationsDir)
+		if overrideFS != nil {
+			sub, err := fs.Sub(overrideFS, coreMigrationsDir)
 			if err != nil {
-				return nil, fmt.Errorf("sub embed FS for %s: %w", dir, err)
+				return nil, fmt.Errorf("sub FS for %s: %w", dir, err)
 			}
 			return sub, nil
 		}
-		// Fallback: read from disk (CLI, dev mode)
-		return os.DirFS(dir), nil
+		sub, err := fs.Sub(schema.Migrations, "migrations")
+		if err != nil {
+			return nil, fmt.Errorf("sub embed FS for %s: %w", dir, err)
+		}
+		return sub, nil
 	}
 	// Extension or extra directories — use OS filesystem.
 	return os.DirFS(dir), nil
@@ -161,8 +163,37 @@ func RunAll(dsn string) (output string, err error) {
 	}
 	defer func() { _ = db.Close() }()
 
+	return runAll(context.Background(), db)
+}
+
+// Migrate runs all migrations like RunAll and returns the core schema
+// version the database is at afterwards. It is the tenant.MigrateFunc of
+// the binaries.
+func Migrate(ctx context.Context, dsn string) (schemaVersion int, output string, err error) {
+	db, err := openDB(dsn)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { _ = db.Close() }()
+
+	output, err = runAll(ctx, db)
+	if err != nil {
+		return 0, output, err
+	}
+	provider, err := newProvider(coreMigrationsDir, db)
+	if err != nil {
+		return 0, output, err
+	}
+	ver, err := provider.GetDBVersion(ctx)
+	if err != nil {
+		return 0, output, fmt.Errorf("%s: get version: %w", coreMigrationsDir, err)
+	}
+	return int(ver), output, nil
+}
+
+// runAll applies the migrations of all directories through db.
+func runAll(ctx context.Context, db *sql.DB) (output string, err error) {
 	var combined strings.Builder
-	ctx := context.Background()
 
 	for _, dir := range Dirs() {
 		provider, perr := newProvider(dir, db)
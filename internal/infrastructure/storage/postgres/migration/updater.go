@@ -108,8 +108,7 @@ func (u *TenantUpdater) StartUpdate(ctx context.Context, tenantID string) error
 	)
 
 	// Run in background goroutine
-	cfg := u.manager.Config()
-	dsn := t.DSN(cfg.DBUser, cfg.DBPassword)
+	dsn := u.manager.TenantDSN(t)
 
 	u.wg.Go(func() {
 		u.runMigrationBackground(tenantID, t.Slug, dsn)
@@ -150,8 +149,7 @@ func (u *TenantUpdater) RetryUpdate(ctx context.Context, tenantID string) error
 		"slug", t.Slug,
 	)
 
-	cfg := u.manager.Config()
-	dsn := t.DSN(cfg.DBUser, cfg.DBPassword)
+	dsn := u.manager.TenantDSN(t)
 
 	u.wg.Go(func() {
 		// Re-use same migration flow — goose up skips already applied.
@@ -205,8 +203,7 @@ func (u *TenantUpdater) RollbackUpdate(ctx context.Context, tenantID string) err
 		"target_versions", versions,
 	)
 
-	cfg := u.manager.Config()
-	dsn := t.DSN(cfg.DBUser, cfg.DBPassword)
+	dsn := u.manager.TenantDSN(t)
 
 	u.wg.Go(func() {
 		u.runRollbackBackground(tenantID, t.Slug, dsn, versions)
@@ -250,8 +247,8 @@ func (u *TenantUpdater) runMigrationBackground(tenantID, slug, dsn string) {
 		}
 	}
 
-	// 2. Run migrations
-	output, err := RunAll(dsn)
+	// 2. Run migrations (not interrupted by shutdown)
+	schemaVersion, output, err := Migrate(context.WithoutCancel(ctx), dsn)
 	if err != nil {
 		u.log.Error("schema migration failed",
 			"tenant_id", tenantID,
@@ -264,7 +261,7 @@ func (u *TenantUpdater) runMigrationBackground(tenantID, slug, dsn string) {
 	}
 
 	// 3. Success: update schema version, clear state, restore active.
-	if serr := u.registry.UpdateSchemaVersion(ctx, tenantID, version.ExpectedSchemaVersion); serr != nil {
+	if serr := u.registry.UpdateSchemaVersion(ctx, tenantID, schemaVersion); serr != nil {
 		u.log.Error("failed to update schema version after successful migration",
 			"tenant_id", tenantID,
 			"error", serr,
@@ -288,7 +285,7 @@ func (u *TenantUpdater) runMigrationBackground(tenantID, slug, dsn string) {
 	u.log.Info("schema migration completed successfully",
 		"tenant_id", tenantID,
 		"slug", slug,
-		"new_schema", version.ExpectedSchemaVersion,
+		"new_schema", schemaVersion,
 		"output", output,
 	)
 }
@@ -0,0 +1,97 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pressly/goose/v3"
+
+	"metapus/internal/core/tenant"
+)
+
+// CriticalTables are the core tables whose column layout `tenant verify`
+// compares across tenant databases: platform tables every request depends
+// on and the main stock documents and registers.
+var CriticalTables = []string{
+	"users",
+	"roles",
+	"permissions",
+	"role_permissions",
+	"user_roles",
+	"auth_sessions",
+	"refresh_tokens",
+	"sys_sequences",
+	"sys_outbox",
+	"sys_idempotency",
+	"sys_audit",
+	"sys_event_log",
+	"sys_custom_field_schemas",
+	"sys_feature_flags",
+	"cat_currencies",
+	"doc_goods_receipts",
+	"doc_goods_issues",
+	"reg_stock_movements",
+	"reg_stock_balances",
+}
+
+// tableChecksumsQuery hashes the columns of each table (name, type,
+// nullability) in name order, so the layout counts but not the order in
+// which columns were added.
+const tableChecksumsQuery = `
+	SELECT c.relname,
+	       md5(string_agg(a.attname || ' ' || format_type(a.atttypid, a.atttypmod) ||
+	                      CASE WHEN a.attnotnull THEN ' not null' ELSE '' END,
+	                      ', ' ORDER BY a.attname))
+	FROM pg_class c
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+	WHERE n.nspname = current_schema() AND c.relkind IN ('r', 'p') AND c.relname = ANY($1)
+	GROUP BY c.relname`
+
+// Inspect reads the core schema state of the database at dsn: the applied
+// and pending core migrations and the checksums of CriticalTables. It does
+// not change the database. It is the tenant.InspectFunc of the binaries.
+func Inspect(ctx context.Context, dsn string) (tenant.SchemaState, error) {
+	var state tenant.SchemaState
+	db, err := openDB(dsn)
+	if err != nil {
+		return state, err
+	}
+	defer func() { _ = db.Close() }()
+
+	provider, err := newProvider(coreMigrationsDir, db)
+	if err != nil {
+		return state, err
+	}
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return state, fmt.Errorf("%s: status: %w", coreMigrationsDir, err)
+	}
+	for _, st := range statuses {
+		ver := int(st.Source.Version)
+		state.LatestVersion = max(state.LatestVersion, ver)
+		if st.State == goose.StateApplied {
+			state.AppliedVersion = max(state.AppliedVersion, ver)
+		} else {
+			state.Pending = append(state.Pending, st.Source.Version)
+		}
+	}
+
+	rows, err := db.QueryContext(ctx, tableChecksumsQuery, CriticalTables)
+	if err != nil {
+		return state, fmt.Errorf("table checksums: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	state.Tables = make(map[string]string, len(CriticalTables))
+	for rows.Next() {
+		var table, sum string
+		if err := rows.Scan(&table, &sum); err != nil {
+			return state, fmt.Errorf("table checksums: %w", err)
+		}
+		state.Tables[table] = sum
+	}
+	if err := rows.Err(); err != nil {
+		return state, fmt.Errorf("table checksums: %w", err)
+	}
+	return state, nil
+}
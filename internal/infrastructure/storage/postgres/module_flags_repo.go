@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"metapus/internal/domain/modules"
+)
+
+// ModuleFlagsRepo implements modules.FlagStore using the tenant database.
+type ModuleFlagsRepo struct{}
+
+// Compile-time interface check.
+var _ modules.FlagStore = (*ModuleFlagsRepo)(nil)
+
+// NewModuleFlagsRepo creates a new module flags repository.
+func NewModuleFlagsRepo() *ModuleFlagsRepo {
+	return &ModuleFlagsRepo{}
+}
+
+// ListModuleFlags returns the feature flags controlling modules.
+func (r *ModuleFlagsRepo) ListModuleFlags(ctx context.Context) ([]modules.Flag, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	rows, err := q.Query(ctx, `
+		SELECT flag_name, is_enabled, COALESCE(variant, ''), valid_from, valid_until
+		FROM sys_feature_flags
+		WHERE flag_name LIKE $1
+		ORDER BY flag_name`, modules.FlagPrefix+"%")
+	if err != nil {
+		return nil, fmt.Errorf("query sys_feature_flags: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []modules.Flag
+	for rows.Next() {
+		var f modules.Flag
+		if err := rows.Scan(&f.Name, &f.Enabled, &f.Variant, &f.ValidFrom, &f.ValidUntil); err != nil {
+			return nil, fmt.Errorf("scan sys_feature_flags: %w", err)
+		}
+		flags = append(flags, f)
+	}
+	return flags, rows.Err()
+}
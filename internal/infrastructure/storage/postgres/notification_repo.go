@@ -2,11 +2,13 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
 
+	"metapus/internal/core/apperror"
 	"metapus/internal/core/id"
 	"metapus/internal/core/tenant"
 	"metapus/internal/domain/notifications"
@@ -111,6 +113,9 @@ func (r *NotificationRepo) GetByID(ctx context.Context, notifID id.ID) (*notific
 		&n.ID, &n.UserID, &n.Title, &n.Message, &n.Severity, &n.Link, &n.IsRead, &rawAttributes, &n.Version, &n.DeletionMark, &n.CreatedAt, &n.UpdatedAt,
 	)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFound("notification", notifID.String())
+		}
 		return nil, err
 	}
 	n.Attributes = rawAttributes
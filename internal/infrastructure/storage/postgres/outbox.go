@@ -8,7 +8,12 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
+	"metapus/internal/core/events"
 	"metapus/internal/core/id"
 	"metapus/internal/domain"
 )
@@ -28,6 +33,9 @@ const (
 // longer than the longest adapter call (Telegram, email, webhook).
 const _defaultStuckTimeout = 5 * time.Minute
 
+// outboxTracer traces message handling; each message is its own trace.
+var outboxTracer = otel.Tracer("metapus/outbox")
+
 // DefaultStuckTimeout returns the default timeout for stuck message recovery.
 func DefaultStuckTimeout() time.Duration { return _defaultStuckTimeout }
 
@@ -44,6 +52,10 @@ type OutboxMessage struct {
 	NextRetryAt   *time.Time   `db:"next_retry_at"`
 	CreatedAt     time.Time    `db:"created_at"`
 	PublishedAt   *time.Time   `db:"published_at"`
+
+	// Deliveries lists the event sinks that already received the message.
+	// Handlers add to it; the relay saves it with the retry or publish state.
+	Deliveries events.Deliveries `db:"deliveries"`
 }
 
 // DomainEvent is imported from domain package.
@@ -241,7 +253,8 @@ func (r *OutboxRelay) ProcessBatch(ctx context.Context) (int, error) {
 		FROM batch b
 		WHERE o.id = b.id AND o.created_at = b.created_at
 		RETURNING o.id, o.aggregate_type, o.aggregate_id, o.event_type, o.payload,
-		          o.status, o.retry_count, o.last_error, o.next_retry_at, o.created_at, o.published_at
+		          o.status, o.retry_count, o.last_error, o.next_retry_at, o.created_at, o.published_at,
+		          o.deliveries
 	`, OutboxStatusPending, r.batchSize, OutboxStatusProcessing)
 	if err != nil {
 		return 0, fmt.Errorf("claim outbox batch: %w", err)
@@ -255,6 +268,7 @@ func (r *OutboxRelay) ProcessBatch(ctx context.Context) (int, error) {
 			&msg.ID, &msg.AggregateType, &msg.AggregateID, &msg.EventType,
 			&msg.Payload, &msg.Status, &msg.RetryCount, &msg.LastError,
 			&msg.NextRetryAt, &msg.CreatedAt, &msg.PublishedAt,
+			&msg.Deliveries,
 		)
 		if err != nil {
 			return 0, fmt.Errorf("scan outbox message: %w", err)
@@ -281,9 +295,27 @@ func (r *OutboxRelay) ProcessBatch(ctx context.Context) (int, error) {
 
 // processMessage handles a single outbox message.
 func (r *OutboxRelay) processMessage(ctx context.Context, msg *OutboxMessage) error {
+	ctx, span := outboxTracer.Start(ctx, "outbox.process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("outbox.event_type", msg.EventType),
+			attribute.String("outbox.aggregate_type", msg.AggregateType),
+			attribute.String("outbox.aggregate_id", msg.AggregateID.String()),
+			attribute.Int("outbox.retry_count", msg.RetryCount),
+		))
+	defer span.End()
+
 	err := r.handler.Handle(ctx, msg)
 
+	deliveries, marshalErr := json.Marshal(msg.Deliveries)
+	if marshalErr != nil || msg.Deliveries == nil {
+		deliveries = []byte("{}")
+	}
+
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
 		// Revert to 'pending' (or 'failed' after max retries) so the message
 		// can be picked up again on the next poll cycle.
 		nextRetry := time.Now().Add(r.backoff.NextDelay(msg.RetryCount))
@@ -294,9 +326,10 @@ func (r *OutboxRelay) processMessage(ctx context.Context, msg *OutboxMessage) er
 			SET status = CASE WHEN retry_count >= 4 THEN $1 ELSE $2 END,
 			    retry_count = retry_count + 1,
 			    last_error = $3,
-			    next_retry_at = $4
+			    next_retry_at = $4,
+			    deliveries = $7::jsonb
 			WHERE id = $5 AND created_at = $6
-		`, OutboxStatusFailed, OutboxStatusPending, errStr, nextRetry, msg.ID, msg.CreatedAt)
+		`, OutboxStatusFailed, OutboxStatusPending, errStr, nextRetry, msg.ID, msg.CreatedAt, string(deliveries))
 
 		if updateErr != nil {
 			return fmt.Errorf("update failed message: %w", updateErr)
@@ -308,9 +341,9 @@ func (r *OutboxRelay) processMessage(ctx context.Context, msg *OutboxMessage) er
 	now := time.Now().UTC()
 	_, err = r.pool.Exec(ctx, `
 		UPDATE sys_outbox 
-		SET status = $1, published_at = $2
+		SET status = $1, published_at = $2, deliveries = $5::jsonb
 		WHERE id = $3 AND created_at = $4
-	`, OutboxStatusPublished, now, msg.ID, msg.CreatedAt)
+	`, OutboxStatusPublished, now, msg.ID, msg.CreatedAt, string(deliveries))
 
 	return err
 }
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain/period"
+)
+
+// PeriodLockRepo implements period.Repository using the tenant database.
+type PeriodLockRepo struct{}
+
+// Compile-time interface check.
+var _ period.Repository = (*PeriodLockRepo)(nil)
+
+// NewPeriodLockRepo creates a new period lock repository.
+func NewPeriodLockRepo() *PeriodLockRepo {
+	return &PeriodLockRepo{}
+}
+
+const periodLockColumns = `organization_id, closed_until, comment, closed_by, closed_at`
+
+func scanPeriodLock(row pgx.Row, l *period.Lock) error {
+	return row.Scan(&l.OrganizationID, &l.ClosedUntil, &l.Comment, &l.ClosedBy, &l.ClosedAt)
+}
+
+// List returns the locks of all organizations.
+func (r *PeriodLockRepo) List(ctx context.Context) ([]period.Lock, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	rows, err := q.Query(ctx, `SELECT `+periodLockColumns+` FROM sys_period_locks ORDER BY organization_id`)
+	if err != nil {
+		return nil, fmt.Errorf("query sys_period_locks: %w", err)
+	}
+	defer rows.Close()
+
+	locks := make([]period.Lock, 0)
+	for rows.Next() {
+		var l period.Lock
+		if err := scanPeriodLock(rows, &l); err != nil {
+			return nil, fmt.Errorf("scan sys_period_locks: %w", err)
+		}
+		locks = append(locks, l)
+	}
+	return locks, rows.Err()
+}
+
+// Get returns the lock of an organization, nil if it has none.
+func (r *PeriodLockRepo) Get(ctx context.Context, organizationID id.ID) (*period.Lock, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	var l period.Lock
+	err := scanPeriodLock(q.QueryRow(ctx,
+		`SELECT `+periodLockColumns+` FROM sys_period_locks WHERE organization_id = $1`, organizationID), &l)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get period lock: %w", err)
+	}
+	return &l, nil
+}
+
+// Save creates or replaces the lock of an organization.
+func (r *PeriodLockRepo) Save(ctx context.Context, l *period.Lock) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	_, err := q.Exec(ctx, `
+		INSERT INTO sys_period_locks (`+periodLockColumns+`)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (organization_id) DO UPDATE SET
+			closed_until = EXCLUDED.closed_until,
+			comment = EXCLUDED.comment,
+			closed_by = EXCLUDED.closed_by,
+			closed_at = EXCLUDED.closed_at`,
+		l.OrganizationID, l.ClosedUntil, l.Comment, l.ClosedBy, l.ClosedAt)
+	if err != nil {
+		return fmt.Errorf("save period lock: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the lock of an organization.
+func (r *PeriodLockRepo) Delete(ctx context.Context, organizationID id.ID) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	if _, err := q.Exec(ctx, `DELETE FROM sys_period_locks WHERE organization_id = $1`, organizationID); err != nil {
+		return fmt.Errorf("delete period lock: %w", err)
+	}
+	return nil
+}
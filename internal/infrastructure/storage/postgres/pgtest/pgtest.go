@@ -0,0 +1,221 @@
+// Package pgtest provides a disposable PostgreSQL database with the core
+// schema applied, for integration tests of the postgres repositories.
+//
+// By default a throwaway postgres container is started through the local
+// Docker daemon (DOCKER_HOST or /var/run/docker.sock) and removed when the
+// test finishes. Set TEST_DATABASE_URL to run against an existing scratch
+// database instead. If neither is available the calling test is skipped.
+//
+//	db := pgtest.Start(t)
+//	ctx := db.Context(context.Background())
+//	repo := catalog_repo.NewUnitRepo() // resolves TxManager from ctx
+package pgtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"metapus/internal/core/tenant"
+	"metapus/internal/infrastructure/storage/postgres"
+	"metapus/internal/infrastructure/storage/postgres/migration"
+)
+
+const (
+	// EnvDatabaseURL points the tests at an existing database. It must be a
+	// scratch database: migrations are applied and test rows are left behind.
+	EnvDatabaseURL = "TEST_DATABASE_URL"
+
+	// EnvImage overrides the postgres image used for the container.
+	EnvImage = "TEST_POSTGRES_IMAGE"
+
+	defaultImage = "postgres:17-alpine"
+	startTimeout = 2 * time.Minute
+)
+
+// DB is a migrated test database.
+type DB struct {
+	DSN  string
+	Pool *pgxpool.Pool
+}
+
+// Context returns ctx carrying a TxManager for the test database, the same
+// way the TenantDB middleware prepares request contexts for repositories.
+func (db *DB) Context(ctx context.Context) context.Context {
+	return tenant.WithTxManager(ctx, postgres.NewTxManagerFromRawPool(db.Pool))
+}
+
+// Start returns a migrated database for the test. Resources are released
+// through tb.Cleanup.
+func Start(tb testing.TB) *DB {
+	tb.Helper()
+	if testing.Short() {
+		tb.Skip("pgtest: database tests are skipped in -short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), startTimeout)
+	defer cancel()
+
+	dsn := os.Getenv(EnvDatabaseURL)
+	if dsn == "" {
+		var err error
+		dsn, err = startContainer(ctx, tb)
+		if errors.Is(err, errNoDocker) {
+			tb.Skipf("pgtest: %s not set and Docker is unavailable: %v", EnvDatabaseURL, err)
+		}
+		if err != nil {
+			tb.Fatalf("pgtest: start postgres container: %v", err)
+		}
+	}
+
+	pool, err := connect(ctx, dsn)
+	if err != nil {
+		tb.Fatalf("pgtest: connect: %v", err)
+	}
+	tb.Cleanup(pool.Close)
+
+	if err := migrate(dsn); err != nil {
+		tb.Fatalf("pgtest: %v", err)
+	}
+
+	return &DB{DSN: dsn, Pool: pool}
+}
+
+var errNoDocker = errors.New("docker daemon not reachable")
+
+// startContainer runs a postgres container with its data directory on tmpfs
+// and returns the DSN of the published port.
+func startContainer(ctx context.Context, tb testing.TB) (string, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errNoDocker, err)
+	}
+	if _, err := cli.Ping(ctx); err != nil {
+		_ = cli.Close()
+		return "", fmt.Errorf("%w: %v", errNoDocker, err)
+	}
+	tb.Cleanup(func() { _ = cli.Close() })
+
+	ref := os.Getenv(EnvImage)
+	if ref == "" {
+		ref = defaultImage
+	}
+	if _, err := cli.ImageInspect(ctx, ref); err != nil {
+		reader, err := cli.ImagePull(ctx, ref, image.PullOptions{})
+		if err != nil {
+			return "", fmt.Errorf("pull %s: %w", ref, err)
+		}
+		_, _ = io.Copy(io.Discard, reader)
+		_ = reader.Close()
+	}
+
+	const port = nat.Port("5432/tcp")
+	resp, err := cli.ContainerCreate(ctx,
+		&container.Config{
+			Image:        ref,
+			Env:          []string{"POSTGRES_USER=metapus", "POSTGRES_PASSWORD=metapus", "POSTGRES_DB=metapus_test"},
+			ExposedPorts: nat.PortSet{port: {}},
+			Cmd:          []string{"postgres", "-c", "fsync=off", "-c", "synchronous_commit=off"},
+		},
+		&container.HostConfig{
+			PortBindings: nat.PortMap{port: {{HostIP: "127.0.0.1"}}},
+			Tmpfs:        map[string]string{"/var/lib/postgresql/data": "rw"},
+		},
+		nil, nil, "")
+	if err != nil {
+		return "", fmt.Errorf("create container: %w", err)
+	}
+	tb.Cleanup(func() {
+		_ = cli.ContainerRemove(context.Background(), resp.ID, container.RemoveOptions{Force: true, RemoveVolumes: true})
+	})
+
+	if err := cli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		return "", fmt.Errorf("start container: %w", err)
+	}
+
+	info, err := cli.ContainerInspect(ctx, resp.ID)
+	if err != nil {
+		return "", fmt.Errorf("inspect container: %w", err)
+	}
+	bindings := info.NetworkSettings.Ports[port]
+	if len(bindings) == 0 {
+		return "", fmt.Errorf("container has no published port %s", port)
+	}
+
+	host := "127.0.0.1"
+	if u, err := url.Parse(cli.DaemonHost()); err == nil && u.Scheme == "tcp" {
+		host = u.Hostname()
+	}
+
+	return fmt.Sprintf("postgres://metapus:metapus@%s/metapus_test?sslmode=disable",
+		net.JoinHostPort(host, bindings[0].HostPort)), nil
+}
+
+// connect opens a pool and waits until the server accepts queries.
+// The postgres image restarts once after initdb; the init-time server only
+// listens on the Unix socket, so the first successful TCP query means the
+// final server is up.
+func connect(ctx context.Context, dsn string) (*pgxpool.Pool, error) {
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var one int
+		err = pool.QueryRow(ctx, "SELECT 1").Scan(&one)
+		if err == nil {
+			return pool, nil
+		}
+		select {
+		case <-ctx.Done():
+			pool.Close()
+			return nil, fmt.Errorf("database not ready: %w", err)
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+}
+
+// migrate applies the core migrations from the repository checkout.
+func migrate(dsn string) error {
+	root, err := moduleRoot()
+	if err != nil {
+		return err
+	}
+	migration.SetCoreMigrationsFS(os.DirFS(root))
+	if out, err := migration.RunAll(dsn); err != nil {
+		return fmt.Errorf("migrate: %w\n%s", err, out)
+	}
+	return nil
+}
+
+// moduleRoot walks up from the working directory (the package under test)
+// to the directory holding go.mod.
+func moduleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("go.mod not found above working directory")
+		}
+		dir = parent
+	}
+}
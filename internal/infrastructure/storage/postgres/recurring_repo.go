@@ -0,0 +1,313 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/domain/recurring"
+)
+
+// RecurringRepo implements recurring.Repository.
+type RecurringRepo struct{}
+
+// NewRecurringRepo creates a new recurring schedule repository.
+func NewRecurringRepo() *RecurringRepo {
+	return &RecurringRepo{}
+}
+
+func (r *RecurringRepo) psql() squirrel.StatementBuilderType {
+	return squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+}
+
+var recurringScheduleColumns = []string{
+	"id", "document_type", "name", "template_id", "cron_expr", "timezone", "quantities",
+	"auto_post", "failure_policy", "active", "next_run_at", "last_run_at", "pending_count",
+	"author_id", "deletion_mark", "version", "created_at", "updated_at",
+}
+
+func scanRecurringSchedule(row pgx.Row, s *recurring.Schedule) error {
+	var quantities []byte
+	err := row.Scan(
+		&s.ID, &s.DocumentType, &s.Name, &s.TemplateID, &s.CronExpr, &s.Timezone, &quantities,
+		&s.AutoPost, &s.FailurePolicy, &s.Active, &s.NextRunAt, &s.LastRunAt, &s.PendingCount,
+		&s.AuthorID, &s.DeletionMark, &s.Version, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	s.Quantities = quantities
+	return nil
+}
+
+func quantitiesJSON(s *recurring.Schedule) []byte {
+	if len(s.Quantities) == 0 {
+		return []byte("[]")
+	}
+	return s.Quantities
+}
+
+// Create inserts a new schedule.
+func (r *RecurringRepo) Create(ctx context.Context, s *recurring.Schedule) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Insert("sys_recurring_schedules").
+		Columns("id", "document_type", "name", "template_id", "cron_expr", "timezone", "quantities",
+			"auto_post", "failure_policy", "active", "next_run_at", "author_id").
+		Values(s.ID, s.DocumentType, s.Name, s.TemplateID, s.CronExpr, s.Timezone, quantitiesJSON(s),
+			s.AutoPost, s.FailurePolicy, s.Active, s.NextRunAt, s.AuthorID).
+		Suffix("RETURNING pending_count, deletion_mark, version, created_at, updated_at").
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build insert query: %w", err))
+	}
+
+	err = querier.QueryRow(ctx, query, args...).Scan(&s.PendingCount, &s.DeletionMark, &s.Version, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("execute insert: %w", err))
+	}
+	return nil
+}
+
+// Update modifies a schedule with optimistic locking.
+func (r *RecurringRepo) Update(ctx context.Context, s *recurring.Schedule) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Update("sys_recurring_schedules").
+		Set("name", s.Name).
+		Set("template_id", s.TemplateID).
+		Set("cron_expr", s.CronExpr).
+		Set("timezone", s.Timezone).
+		Set("quantities", quantitiesJSON(s)).
+		Set("auto_post", s.AutoPost).
+		Set("failure_policy", s.FailurePolicy).
+		Set("active", s.Active).
+		Set("next_run_at", s.NextRunAt).
+		Set("version", squirrel.Expr("version + 1")).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": s.ID, "version": s.Version, "deletion_mark": false}).
+		Suffix("RETURNING pending_count, deletion_mark, version, created_at, updated_at").
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build update query: %w", err))
+	}
+
+	err = querier.QueryRow(ctx, query, args...).Scan(&s.PendingCount, &s.DeletionMark, &s.Version, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.NewConcurrentModification("recurring_schedule", s.ID)
+		}
+		return apperror.NewInternal(fmt.Errorf("execute update: %w", err))
+	}
+	return nil
+}
+
+// Delete soft-deletes a schedule.
+func (r *RecurringRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Update("sys_recurring_schedules").
+		Set("deletion_mark", true).
+		Set("active", false).
+		Set("next_run_at", nil).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": id, "deletion_mark": false}).
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build delete query: %w", err))
+	}
+
+	cmdTag, err := querier.Exec(ctx, query, args...)
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("execute delete: %w", err))
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return apperror.NewNotFound("recurring_schedule", id)
+	}
+	return nil
+}
+
+// GetByID returns a single schedule by ID.
+func (r *RecurringRepo) GetByID(ctx context.Context, id uuid.UUID) (*recurring.Schedule, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Select(recurringScheduleColumns...).
+		From("sys_recurring_schedules").
+		Where(squirrel.Eq{"id": id, "deletion_mark": false}).
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	var s recurring.Schedule
+	if err := scanRecurringSchedule(querier.QueryRow(ctx, query, args...), &s); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFound("recurring_schedule", id)
+		}
+		return nil, apperror.NewInternal(fmt.Errorf("scan recurring schedule: %w", err))
+	}
+	return &s, nil
+}
+
+// GetList returns schedules of a document type.
+func (r *RecurringRepo) GetList(ctx context.Context, documentType string) ([]*recurring.Schedule, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Select(recurringScheduleColumns...).
+		From("sys_recurring_schedules").
+		Where(squirrel.Eq{"document_type": documentType, "deletion_mark": false}).
+		OrderBy("name ASC").
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("execute query: %w", err))
+	}
+	defer rows.Close()
+
+	list := make([]*recurring.Schedule, 0)
+	for rows.Next() {
+		s := &recurring.Schedule{}
+		if err := scanRecurringSchedule(rows, s); err != nil {
+			return nil, apperror.NewInternal(fmt.Errorf("scan recurring schedule row: %w", err))
+		}
+		list = append(list, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("rows iteration error: %w", err))
+	}
+
+	return list, nil
+}
+
+// ClaimDue locks the most overdue active schedule until leaseUntil.
+// SKIP LOCKED keeps concurrent workers from claiming the same row.
+func (r *RecurringRepo) ClaimDue(ctx context.Context, now, leaseUntil time.Time) (*recurring.Schedule, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	// Built with "?" placeholders: the outer query renumbers them.
+	due, dueArgs, err := squirrel.Select("id").
+		From("sys_recurring_schedules").
+		Where(squirrel.Eq{"active": true, "deletion_mark": false}).
+		Where(squirrel.LtOrEq{"next_run_at": now}).
+		Where(squirrel.Or{
+			squirrel.Eq{"locked_until": nil},
+			squirrel.Lt{"locked_until": now},
+		}).
+		OrderBy("next_run_at ASC").
+		Limit(1).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build claim subquery: %w", err))
+	}
+
+	query, args, err := r.psql().Update("sys_recurring_schedules").
+		Set("locked_until", leaseUntil).
+		Where(squirrel.Expr("id = ("+due+")", dueArgs...)).
+		Suffix("RETURNING " + strings.Join(recurringScheduleColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build claim query: %w", err))
+	}
+
+	var s recurring.Schedule
+	if err := scanRecurringSchedule(querier.QueryRow(ctx, query, args...), &s); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperror.NewInternal(fmt.Errorf("claim recurring schedule: %w", err))
+	}
+	return &s, nil
+}
+
+// CompleteRun records runs and advances the schedule in one transaction.
+// The version is not bumped: run bookkeeping must not conflict with user edits.
+func (r *RecurringRepo) CompleteRun(ctx context.Context, s *recurring.Schedule, runs ...*recurring.Run) error {
+	return MustGetTxManager(ctx).RunInTransaction(ctx, func(ctx context.Context) error {
+		querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+		for _, run := range runs {
+			query, args, err := r.psql().Insert("sys_recurring_runs").
+				Columns("schedule_id", "scheduled_for", "occurrences", "status", "document_id",
+					"error", "started_at", "finished_at").
+				Values(s.ID, run.ScheduledFor, run.Occurrences, run.Status, run.DocumentID,
+					run.Error, run.StartedAt, run.FinishedAt).
+				Suffix("RETURNING id").
+				ToSql()
+			if err != nil {
+				return apperror.NewInternal(fmt.Errorf("build run insert: %w", err))
+			}
+			if err := querier.QueryRow(ctx, query, args...).Scan(&run.ID); err != nil {
+				return apperror.NewInternal(fmt.Errorf("insert recurring run: %w", err))
+			}
+			run.ScheduleID = s.ID
+		}
+
+		query, args, err := r.psql().Update("sys_recurring_schedules").
+			Set("active", s.Active).
+			Set("next_run_at", s.NextRunAt).
+			Set("last_run_at", s.LastRunAt).
+			Set("pending_count", s.PendingCount).
+			Set("locked_until", nil).
+			Where(squirrel.Eq{"id": s.ID}).
+			ToSql()
+		if err != nil {
+			return apperror.NewInternal(fmt.Errorf("build schedule advance: %w", err))
+		}
+		if _, err := querier.Exec(ctx, query, args...); err != nil {
+			return apperror.NewInternal(fmt.Errorf("advance recurring schedule: %w", err))
+		}
+		return nil
+	})
+}
+
+// ListRuns returns the most recent runs of a schedule.
+func (r *RecurringRepo) ListRuns(ctx context.Context, scheduleID uuid.UUID, limit int) ([]*recurring.Run, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Select("id", "schedule_id", "scheduled_for", "occurrences", "status",
+		"document_id", "error", "started_at", "finished_at").
+		From("sys_recurring_runs").
+		Where(squirrel.Eq{"schedule_id": scheduleID}).
+		OrderBy("started_at DESC", "id DESC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("execute query: %w", err))
+	}
+	defer rows.Close()
+
+	list := make([]*recurring.Run, 0)
+	for rows.Next() {
+		run := &recurring.Run{}
+		if err := rows.Scan(&run.ID, &run.ScheduleID, &run.ScheduledFor, &run.Occurrences, &run.Status,
+			&run.DocumentID, &run.Error, &run.StartedAt, &run.FinishedAt); err != nil {
+			return nil, apperror.NewInternal(fmt.Errorf("scan recurring run row: %w", err))
+		}
+		list = append(list, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("rows iteration error: %w", err))
+	}
+
+	return list, nil
+}
+
+// Ensure interface compliance.
+var _ recurring.Repository = (*RecurringRepo)(nil)
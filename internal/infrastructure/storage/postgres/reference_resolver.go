@@ -29,6 +29,7 @@ type CurrencyRefDisplay struct {
 	Name          string `json:"name"`
 	DecimalPlaces int    `json:"decimalPlaces"`
 	Symbol        string `json:"symbol"`
+	ISOCode       string `json:"isoCode,omitempty"`
 }
 
 // ReferenceResolver batch-resolves catalog IDs to display names.
@@ -248,7 +249,7 @@ func batchResolveCurrency(ctx context.Context, q Querier, ids []id.ID) (Resolved
 	}
 
 	query := fmt.Sprintf(
-		"SELECT id, COALESCE(name, code, id::text), decimal_places, COALESCE(symbol, '') FROM cat_currencies WHERE id IN (%s)",
+		"SELECT id, COALESCE(name, code, id::text), decimal_places, COALESCE(symbol, ''), COALESCE(iso_code, '') FROM cat_currencies WHERE id IN (%s)",
 		strings.Join(placeholders, ","),
 	)
 
@@ -263,8 +264,8 @@ func batchResolveCurrency(ctx context.Context, q Querier, ids []id.ID) (Resolved
 		var eid id.ID
 		var name string
 		var decimalPlaces int
-		var symbol string
-		if err := rows.Scan(&eid, &name, &decimalPlaces, &symbol); err != nil {
+		var symbol, isoCode string
+		if err := rows.Scan(&eid, &name, &decimalPlaces, &symbol, &isoCode); err != nil {
 			return nil, fmt.Errorf("scan cat_currencies: %w", err)
 		}
 		result[eid.String()] = CurrencyRefDisplay{
@@ -272,6 +273,7 @@ func batchResolveCurrency(ctx context.Context, q Querier, ids []id.ID) (Resolved
 			Name:          name,
 			DecimalPlaces: decimalPlaces,
 			Symbol:        symbol,
+			ISOCode:       isoCode,
 		}
 	}
 
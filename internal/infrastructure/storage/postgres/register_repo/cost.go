@@ -3,6 +3,7 @@ package register_repo
 import (
 	"context"
 	"fmt"
+	"hash/crc32"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/v2/pgxscan"
@@ -155,5 +156,44 @@ func (r *CostRepo) GetBalancesByNomenclature(ctx context.Context, nomenclatureID
 	return balances, nil
 }
 
+// GetFIFOLayers locks warehouse+nomenclature with a transactional advisory lock
+// and returns unconsumed receipt layers, oldest first.
+// Consumption is the total expense quantity: layers are consumed in order,
+// so unposting an expense automatically restores the layers it took.
+func (r *CostRepo) GetFIFOLayers(ctx context.Context, warehouseID, nomenclatureID id.ID) ([]cost.Layer, error) {
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+
+	whHash := int32(crc32.ChecksumIEEE(warehouseID[:]))
+	nomHash := int32(crc32.ChecksumIEEE(nomenclatureID[:]))
+	if _, err := querier.Exec(ctx, "SELECT pg_advisory_xact_lock($1, $2)", whHash, nomHash); err != nil {
+		return nil, fmt.Errorf("lock cost layers: %w", err)
+	}
+
+	const layersSQL = `
+		WITH consumed AS (
+			SELECT COALESCE(SUM(quantity), 0) AS quantity
+			FROM reg_cost_movements
+			WHERE warehouse_id = $1 AND nomenclature_id = $2 AND record_type = 'expense'
+		), layers AS (
+			SELECT line_id, currency_id, quantity, amount, period, created_at,
+				SUM(quantity) OVER (ORDER BY period, created_at, line_id) AS cumulative
+			FROM reg_cost_movements
+			WHERE warehouse_id = $1 AND nomenclature_id = $2 AND record_type = 'receipt'
+		)
+		SELECT l.line_id, l.currency_id, l.quantity, l.amount,
+			LEAST(l.quantity, l.cumulative - c.quantity) AS remaining
+		FROM layers l CROSS JOIN consumed c
+		WHERE l.cumulative > c.quantity
+		ORDER BY l.period, l.created_at, l.line_id
+	`
+
+	var layers []cost.Layer
+	if err := pgxscan.Select(ctx, querier, &layers, layersSQL, warehouseID, nomenclatureID); err != nil {
+		return nil, fmt.Errorf("select cost layers: %w", err)
+	}
+
+	return layers, nil
+}
+
 // Ensure interface compliance.
 var _ cost.Repository = (*CostRepo)(nil)
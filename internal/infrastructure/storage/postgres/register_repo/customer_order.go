@@ -0,0 +1,206 @@
+package register_repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/documents/sales_order"
+	"metapus/internal/domain/registers/customer_order"
+)
+
+const (
+	salesOrderMovementsTable = "reg_sales_order_movements"
+)
+
+// salesOrderMovementColumns defines column order for sales order movements.
+var salesOrderMovementColumns = []string{
+	"line_id", "recorder_id", "recorder_type", "recorder_version",
+	"period", "record_type",
+	"order_id", "order_line_id", "nomenclature_id", "quantity", "created_at",
+}
+
+// salesOrderMovementRowMapper converts a SalesOrderMovement to a flat row.
+func salesOrderMovementRowMapper(m entity.SalesOrderMovement) []any {
+	return []any{
+		m.LineID, m.RecorderID, m.RecorderType, m.RecorderVersion,
+		m.Period, m.RecordType,
+		m.OrderID, m.OrderLineID, m.NomenclatureID, m.Quantity, m.CreatedAt,
+	}
+}
+
+// CustomerOrderRepo implements customer_order.Repository.
+type CustomerOrderRepo struct {
+	BaseAccumulationRepo[entity.SalesOrderMovement]
+}
+
+// NewCustomerOrderRepo creates a new sales order register repository.
+func NewCustomerOrderRepo() *CustomerOrderRepo {
+	return &CustomerOrderRepo{
+		BaseAccumulationRepo: NewBaseAccumulationRepo[entity.SalesOrderMovement](
+			salesOrderMovementsTable,
+			salesOrderMovementColumns,
+			salesOrderMovementRowMapper,
+		),
+	}
+}
+
+// GetMovementsByRecorder retrieves movements for a document.
+func (r *CustomerOrderRepo) GetMovementsByRecorder(ctx context.Context, recorderID id.ID) ([]entity.SalesOrderMovement, error) {
+	q := r.Builder().Select(salesOrderMovementColumns...).
+		From(salesOrderMovementsTable).
+		Where(squirrel.Eq{"recorder_id": recorderID}).
+		OrderBy("created_at")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var movements []entity.SalesOrderMovement
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &movements, sql, args...); err != nil {
+		return nil, fmt.Errorf("select sales order movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// GetBalancesForUpdate returns order line balances with pessimistic locking
+// in deterministic key order (deadlock-safe). Keys not found are returned with Quantity=0.
+func (r *CustomerOrderRepo) GetBalancesForUpdate(ctx context.Context, keys []customer_order.BalanceKey) ([]entity.SalesOrderBalance, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	sortedKeys := make([]customer_order.BalanceKey, len(keys))
+	copy(sortedKeys, keys)
+	customer_order.SortBalanceKeys(sortedKeys)
+
+	const lockSQL = `
+		SELECT order_id, order_line_id, quantity, last_movement_at, updated_at
+		FROM reg_sales_order_balances
+		WHERE order_id = $1 AND order_line_id = $2
+		FOR UPDATE
+	`
+
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	b := &pgx.Batch{}
+	for _, k := range sortedKeys {
+		b.Queue(lockSQL, k.OrderID, k.OrderLineID)
+	}
+
+	br := querier.SendBatch(ctx, b)
+	defer func() {
+		_ = br.Close()
+	}()
+
+	loaded := make(map[customer_order.BalanceKey]entity.SalesOrderBalance, len(sortedKeys))
+	for _, k := range sortedKeys {
+		var balance entity.SalesOrderBalance
+		rows, err := br.Query()
+		if err != nil {
+			return nil, fmt.Errorf("batch query error: %w", err)
+		}
+
+		if rows.Next() {
+			if err := pgxscan.ScanRow(&balance, rows); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("scan sales order balance: %w", err)
+			}
+			loaded[k] = balance
+		}
+		rows.Close()
+	}
+
+	// Return in original key order, filling missing entries with zero.
+	result := make([]entity.SalesOrderBalance, len(keys))
+	for i, k := range keys {
+		if balance, ok := loaded[k]; ok {
+			result[i] = balance
+		} else {
+			result[i] = entity.SalesOrderBalance{
+				OrderID:     k.OrderID,
+				OrderLineID: k.OrderLineID,
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// GetLineFulfillment returns ordered, shipped and released quantities per line of an order.
+// Expenses recorded by the order itself are the release on closing.
+// Lines of an unposted order are reported with zero ordered quantity.
+func (r *CustomerOrderRepo) GetLineFulfillment(ctx context.Context, orderID id.ID) ([]customer_order.LineFulfillment, error) {
+	const sql = `
+		SELECT l.line_id AS order_line_id, l.nomenclature_id,
+			COALESCE(SUM(m.quantity) FILTER (WHERE m.record_type = 'receipt'), 0) AS ordered,
+			COALESCE(SUM(m.quantity) FILTER (WHERE m.record_type = 'expense' AND m.recorder_id <> m.order_id), 0) AS shipped,
+			COALESCE(SUM(m.quantity) FILTER (WHERE m.record_type = 'expense' AND m.recorder_id = m.order_id), 0) AS released
+		FROM doc_sales_order_lines l
+		LEFT JOIN reg_sales_order_movements m
+			ON m.order_id = l.document_id AND m.order_line_id = l.line_id
+		WHERE l.document_id = $1
+		GROUP BY l.line_id, l.nomenclature_id, l.line_no
+		ORDER BY l.line_no
+	`
+
+	var result []customer_order.LineFulfillment
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &result, sql, orderID); err != nil {
+		return nil, fmt.Errorf("select sales order fulfillment: %w", err)
+	}
+
+	return result, nil
+}
+
+// RefreshOrderStatus recalculates status of the orders from their movements:
+// no ordered goods — draft, released by the order itself — closed,
+// everything shipped — shipped, otherwise confirmed.
+func (r *CustomerOrderRepo) RefreshOrderStatus(ctx context.Context, orderIDs []id.ID) error {
+	if len(orderIDs) == 0 {
+		return nil
+	}
+
+	const sql = `
+		UPDATE doc_sales_orders o
+		SET status = CASE
+			WHEN COALESCE(t.ordered, 0) = 0 THEN $2
+			WHEN t.released > 0 THEN $5
+			WHEN t.shipped >= t.ordered THEN $4
+			ELSE $3
+		END
+		FROM unnest($1::uuid[]) AS ids(order_id)
+		LEFT JOIN (
+			SELECT order_id,
+				COALESCE(SUM(quantity) FILTER (WHERE record_type = 'receipt'), 0) AS ordered,
+				COALESCE(SUM(quantity) FILTER (WHERE record_type = 'expense' AND recorder_id <> order_id), 0) AS shipped,
+				COALESCE(SUM(quantity) FILTER (WHERE record_type = 'expense' AND recorder_id = order_id), 0) AS released
+			FROM reg_sales_order_movements
+			WHERE order_id = ANY($1::uuid[])
+			GROUP BY order_id
+		) t ON t.order_id = ids.order_id
+		WHERE o.id = ids.order_id
+	`
+
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if _, err := querier.Exec(ctx, sql, orderIDs,
+		sales_order.StatusDraft,
+		sales_order.StatusConfirmed,
+		sales_order.StatusShipped,
+		sales_order.StatusClosed,
+	); err != nil {
+		return fmt.Errorf("update sales order status: %w", err)
+	}
+
+	return nil
+}
+
+// Ensure interface compliance.
+var _ customer_order.Repository = (*CustomerOrderRepo)(nil)
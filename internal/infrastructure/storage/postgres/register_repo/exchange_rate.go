@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/v2/pgxscan"
 
+	"metapus/internal/core/apperror"
 	"metapus/internal/core/id"
 	"metapus/internal/domain/registers/exchange_rate"
 	"metapus/internal/infrastructure/storage/postgres"
@@ -106,5 +108,89 @@ func (r *ExchangeRateRepo) ListByCurrency(ctx context.Context, currencyID, rateS
 	return rates, nil
 }
 
+// GetEffectiveRate returns the rate in effect on asOf from the active source
+// with the best priority that has one.
+func (r *ExchangeRateRepo) GetEffectiveRate(ctx context.Context, currencyID id.ID, asOf time.Time) (*exchange_rate.ExchangeRate, error) {
+	txm := postgres.MustGetTxManager(ctx)
+	querier := txm.GetQuerier(ctx)
+
+	const query = `
+		SELECT r.currency_id, r.date, r.rate, r.multiplier, r.rate_source_id
+		FROM reg_exchange_rates r
+		JOIN cat_rate_sources rs ON rs.id = r.rate_source_id
+		WHERE r.currency_id = $1 AND r.date <= $2
+		  AND rs.is_active = TRUE
+		  AND rs.deletion_mark = FALSE
+		  AND rs._deleted_at IS NULL
+		ORDER BY rs.priority, r.date DESC
+		LIMIT 1
+	`
+
+	var rate exchange_rate.ExchangeRate
+	if err := pgxscan.Get(ctx, querier, &rate, query, currencyID, asOf); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, apperror.NewNotFound("exchange_rate", currencyID.String())
+		}
+		return nil, fmt.Errorf("get effective exchange rate: %w", err)
+	}
+
+	return &rate, nil
+}
+
+// List returns rates matching the filter ordered by date DESC.
+func (r *ExchangeRateRepo) List(ctx context.Context, filter exchange_rate.ListFilter) ([]exchange_rate.ExchangeRate, error) {
+	q := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).
+		Select("currency_id", "date", "rate", "multiplier", "rate_source_id").
+		From("reg_exchange_rates").
+		OrderBy("date DESC", "currency_id", "rate_source_id").
+		Limit(uint64(filter.Limit))
+
+	if filter.CurrencyID != nil {
+		q = q.Where(squirrel.Eq{"currency_id": *filter.CurrencyID})
+	}
+	if filter.RateSourceID != nil {
+		q = q.Where(squirrel.Eq{"rate_source_id": *filter.RateSourceID})
+	}
+	if filter.From != nil {
+		q = q.Where(squirrel.GtOrEq{"date": *filter.From})
+	}
+	if filter.To != nil {
+		q = q.Where(squirrel.LtOrEq{"date": *filter.To})
+	}
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	querier := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+	var rates []exchange_rate.ExchangeRate
+	if err := pgxscan.Select(ctx, querier, &rates, sql, args...); err != nil {
+		return nil, fmt.Errorf("list exchange rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+// Delete removes the rate for (currency_id, date, rate_source_id).
+func (r *ExchangeRateRepo) Delete(ctx context.Context, currencyID, rateSourceID id.ID, date time.Time) error {
+	querier := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+
+	const query = `
+		DELETE FROM reg_exchange_rates
+		WHERE currency_id = $1 AND rate_source_id = $2 AND date = $3
+	`
+
+	tag, err := querier.Exec(ctx, query, currencyID, rateSourceID, date)
+	if err != nil {
+		return fmt.Errorf("delete exchange rate: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperror.NewNotFound("exchange_rate", currencyID.String())
+	}
+
+	return nil
+}
+
 // Compile-time interface check.
 var _ exchange_rate.Repository = (*ExchangeRateRepo)(nil)
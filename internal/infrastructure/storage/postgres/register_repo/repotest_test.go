@@ -0,0 +1,33 @@
+package register_repo
+
+import (
+	"context"
+	"testing"
+
+	"metapus/internal/core/types"
+	"metapus/internal/domain/registers/stock"
+	"metapus/internal/domain/repotest"
+	"metapus/internal/infrastructure/storage/postgres/pgtest"
+)
+
+// TestIntegrationStockRepoContract runs the shared stock register suite against the
+// postgres implementation (balances maintained by statement triggers).
+func TestIntegrationStockRepoContract(t *testing.T) {
+	db := pgtest.Start(t)
+	ctx := db.Context(context.Background())
+
+	repotest.StockSuite{
+		Repo:        NewStockRepo(),
+		Corrections: NewStockCorrectionRepo(),
+		// Writes the balance row directly: the triggers only maintain it
+		// from movements.
+		SetStoredBalance: func(ctx context.Context, key stock.BalanceKey, quantity types.Quantity) error {
+			_, err := db.Pool.Exec(ctx, `
+				INSERT INTO reg_stock_balances (warehouse_id, nomenclature_id, quantity, last_movement_at)
+				VALUES ($1, $2, $3, NOW())
+				ON CONFLICT (warehouse_id, nomenclature_id) DO UPDATE SET quantity = EXCLUDED.quantity`,
+				key.WarehouseID, key.NomenclatureID, quantity.Int64Scaled())
+			return err
+		},
+	}.Run(t, ctx)
+}
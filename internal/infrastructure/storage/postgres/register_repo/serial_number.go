@@ -0,0 +1,222 @@
+package register_repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/registers/serial_number"
+)
+
+const (
+	serialNumberMovementsTable = "reg_serial_movements"
+)
+
+// serialNumberMovementColumns defines column order for serial number movements.
+var serialNumberMovementColumns = []string{
+	"line_id", "recorder_id", "recorder_type", "recorder_version",
+	"period", "record_type",
+	"warehouse_id", "nomenclature_id", "serial_number", "created_at",
+}
+
+// serialNumberMovementRowMapper converts a SerialNumberMovement to a flat row.
+func serialNumberMovementRowMapper(m entity.SerialNumberMovement) []any {
+	return []any{
+		m.LineID, m.RecorderID, m.RecorderType, m.RecorderVersion,
+		m.Period, m.RecordType,
+		m.WarehouseID, m.NomenclatureID, m.SerialNumber, m.CreatedAt,
+	}
+}
+
+// SerialNumberRepo implements serial_number.Repository.
+type SerialNumberRepo struct {
+	BaseAccumulationRepo[entity.SerialNumberMovement]
+}
+
+// NewSerialNumberRepo creates a new serial number register repository.
+func NewSerialNumberRepo() *SerialNumberRepo {
+	return &SerialNumberRepo{
+		BaseAccumulationRepo: NewBaseAccumulationRepo[entity.SerialNumberMovement](
+			serialNumberMovementsTable,
+			serialNumberMovementColumns,
+			serialNumberMovementRowMapper,
+		),
+	}
+}
+
+// GetMovementsByRecorder retrieves movements for a document.
+func (r *SerialNumberRepo) GetMovementsByRecorder(ctx context.Context, recorderID id.ID) ([]entity.SerialNumberMovement, error) {
+	q := r.Builder().Select(serialNumberMovementColumns...).
+		From(serialNumberMovementsTable).
+		Where(squirrel.Eq{"recorder_id": recorderID}).
+		OrderBy("nomenclature_id", "serial_number")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var movements []entity.SerialNumberMovement
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &movements, sql, args...); err != nil {
+		return nil, fmt.Errorf("select serial number movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// splitSerialKeys returns the keys as parallel arrays for unnest().
+func splitSerialKeys(keys []serial_number.SerialKey) ([]id.ID, []string) {
+	nomenclatureIDs := make([]id.ID, len(keys))
+	serials := make([]string, len(keys))
+	for i, k := range keys {
+		nomenclatureIDs[i] = k.NomenclatureID
+		serials[i] = k.SerialNumber
+	}
+	return nomenclatureIDs, serials
+}
+
+// LockSerials creates the missing pool rows and locks the rows of the keys
+// in deterministic key order (deadlock-safe).
+func (r *SerialNumberRepo) LockSerials(ctx context.Context, keys []serial_number.SerialKey) ([]serial_number.Serial, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	sortedKeys := make([]serial_number.SerialKey, len(keys))
+	copy(sortedKeys, keys)
+	serial_number.SortSerialKeys(sortedKeys)
+	nomenclatureIDs, serials := splitSerialKeys(sortedKeys)
+
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+
+	const ensureSQL = `
+		INSERT INTO reg_serial_numbers (nomenclature_id, serial_number)
+		SELECT k.nomenclature_id, k.serial_number
+		FROM unnest($1::uuid[], $2::text[]) WITH ORDINALITY AS k(nomenclature_id, serial_number, ord)
+		ORDER BY k.ord
+		ON CONFLICT (nomenclature_id, serial_number) DO NOTHING
+	`
+	if _, err := querier.Exec(ctx, ensureSQL, nomenclatureIDs, serials); err != nil {
+		return nil, fmt.Errorf("insert serial numbers: %w", err)
+	}
+
+	const lockSQL = `
+		SELECT s.nomenclature_id, s.serial_number, s.warehouse_id, s.balance, s.updated_at
+		FROM reg_serial_numbers s
+		JOIN unnest($1::uuid[], $2::text[]) WITH ORDINALITY AS k(nomenclature_id, serial_number, ord)
+			ON s.nomenclature_id = k.nomenclature_id AND s.serial_number = k.serial_number
+		ORDER BY k.ord
+		FOR UPDATE OF s
+	`
+	var result []serial_number.Serial
+	if err := pgxscan.Select(ctx, querier, &result, lockSQL, nomenclatureIDs, serials); err != nil {
+		return nil, fmt.Errorf("lock serial numbers: %w", err)
+	}
+
+	return result, nil
+}
+
+// RefreshSerials recalculates the balance and the warehouse of the last
+// receipt of the keys from the movements.
+func (r *SerialNumberRepo) RefreshSerials(ctx context.Context, keys []serial_number.SerialKey) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	nomenclatureIDs, serials := splitSerialKeys(keys)
+
+	const sql = `
+		UPDATE reg_serial_numbers s
+		SET balance = COALESCE((
+				SELECT SUM(CASE WHEN m.record_type = 'receipt' THEN 1 ELSE -1 END)
+				FROM reg_serial_movements m
+				WHERE m.nomenclature_id = s.nomenclature_id AND m.serial_number = s.serial_number
+			), 0),
+			warehouse_id = (
+				SELECT m.warehouse_id
+				FROM reg_serial_movements m
+				WHERE m.nomenclature_id = s.nomenclature_id AND m.serial_number = s.serial_number
+					AND m.record_type = 'receipt'
+				ORDER BY m.period DESC, m.created_at DESC
+				LIMIT 1
+			),
+			updated_at = now()
+		FROM unnest($1::uuid[], $2::text[]) AS k(nomenclature_id, serial_number)
+		WHERE s.nomenclature_id = k.nomenclature_id AND s.serial_number = k.serial_number
+	`
+
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if _, err := querier.Exec(ctx, sql, nomenclatureIDs, serials); err != nil {
+		return fmt.Errorf("update serial numbers: %w", err)
+	}
+
+	return nil
+}
+
+// likeEscaper escapes LIKE wildcards so the search is matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// List returns the serials in stock matching the filter, by serial number.
+func (r *SerialNumberRepo) List(ctx context.Context, filter serial_number.ListFilter) ([]serial_number.Serial, error) {
+	q := r.Builder().
+		Select("nomenclature_id", "serial_number", "warehouse_id", "balance", "updated_at").
+		From("reg_serial_numbers").
+		Where("balance > 0").
+		OrderBy("nomenclature_id", "serial_number")
+
+	if filter.NomenclatureID != nil {
+		q = q.Where(squirrel.Eq{"nomenclature_id": *filter.NomenclatureID})
+	}
+	if filter.WarehouseID != nil {
+		q = q.Where(squirrel.Eq{"warehouse_id": *filter.WarehouseID})
+	}
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		q = q.Where(squirrel.ILike{"serial_number": likeEscaper.Replace(search) + "%"})
+	}
+	if filter.Limit > 0 {
+		q = q.Limit(uint64(filter.Limit))
+	}
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	result := []serial_number.Serial{}
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &result, sql, args...); err != nil {
+		return nil, fmt.Errorf("select serial numbers: %w", err)
+	}
+
+	return result, nil
+}
+
+// History returns the movements of a serial with the numbers of the
+// documents that recorded them, oldest first.
+func (r *SerialNumberRepo) History(ctx context.Context, key serial_number.SerialKey) ([]serial_number.HistoryEntry, error) {
+	const sql = `
+		SELECT m.period, m.record_type, m.recorder_id, m.recorder_type, m.warehouse_id,
+			COALESCE(gr.number, gi.number, '') AS document_number
+		FROM reg_serial_movements m
+		LEFT JOIN doc_goods_receipts gr ON m.recorder_type = 'GoodsReceipt' AND gr.id = m.recorder_id
+		LEFT JOIN doc_goods_issues gi ON m.recorder_type = 'GoodsIssue' AND gi.id = m.recorder_id
+		WHERE m.nomenclature_id = $1 AND m.serial_number = $2
+		ORDER BY m.period, m.created_at
+	`
+
+	result := []serial_number.HistoryEntry{}
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &result, sql, key.NomenclatureID, key.SerialNumber); err != nil {
+		return nil, fmt.Errorf("select serial number history: %w", err)
+	}
+
+	return result, nil
+}
+
+// Ensure interface compliance.
+var _ serial_number.Repository = (*SerialNumberRepo)(nil)
@@ -16,12 +16,19 @@ import (
 	"metapus/internal/core/entity"
 	"metapus/internal/core/id"
 	"metapus/internal/core/types"
+	"metapus/internal/domain/cursor"
 	"metapus/internal/domain/registers/stock"
 )
 
 const (
 	stockMovementsTable = "reg_stock_movements"
 	stockBalancesTable  = "reg_stock_balances"
+
+	// stockCorrectionLinesTable holds the movements moved by stock corrections.
+	stockCorrectionLinesTable = "reg_stock_correction_lines"
+
+	// stockReservationBalancesTable is read for available (unreserved) stock.
+	stockReservationBalancesTable = "reg_stock_reservation_balances"
 )
 
 // stockMovementColumns defines column order for stock movements.
@@ -81,6 +88,53 @@ func (r *StockRepo) GetMovementsByRecorder(ctx context.Context, recorderID id.ID
 	return movements, nil
 }
 
+// GetMovementsForCorrection retrieves movements of a document set for bulk correction.
+func (r *StockRepo) GetMovementsForCorrection(ctx context.Context, filter stock.CorrectionFilter) ([]entity.StockMovement, error) {
+	q := r.Builder().Select(stockMovementColumns...).
+		From(stockMovementsTable).
+		Where(squirrel.Eq{"recorder_id": filter.RecorderIDs}).
+		Where(squirrel.NotEq{"recorder_type": stock.CorrectionRecorderType}).
+		Where("NOT EXISTS (SELECT 1 FROM " + stockCorrectionLinesTable + " l WHERE l.line_id = " + stockMovementsTable + ".line_id)").
+		OrderBy("period", "line_id")
+
+	if filter.WarehouseID != nil {
+		q = q.Where(squirrel.Eq{"warehouse_id": *filter.WarehouseID})
+	}
+	if filter.NomenclatureID != nil {
+		q = q.Where(squirrel.Eq{"nomenclature_id": *filter.NomenclatureID})
+	}
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var movements []entity.StockMovement
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &movements, sql, args...); err != nil {
+		return nil, fmt.Errorf("select movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// CountCorrectedMovements counts the movements of a document version moved
+// by stock corrections.
+func (r *StockRepo) CountCorrectedMovements(ctx context.Context, recorderID id.ID, beforeVersion int) (int, error) {
+	var n int
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	err := querier.QueryRow(ctx, `
+		SELECT count(*)
+		FROM `+stockCorrectionLinesTable+` l
+		JOIN `+stockMovementsTable+` m ON m.line_id = l.line_id
+		WHERE l.recorder_id = $1 AND m.recorder_version < $2`,
+		recorderID, beforeVersion).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("count corrected movements: %w", err)
+	}
+	return n, nil
+}
+
 // GetBalance returns current balance for warehouse+product.
 func (r *StockRepo) GetBalance(ctx context.Context, warehouseID, nomenclatureID id.ID) (entity.StockBalance, error) {
 	var balance entity.StockBalance
@@ -141,6 +195,46 @@ func (r *StockRepo) GetBalanceForUpdate(ctx context.Context, warehouseID, nomenc
 	return balance, nil
 }
 
+// GetBalances returns balances for multiple warehouse+product pairs without
+// locks, so it can run on a read replica.
+// Keys not found in reg_stock_balances are returned with Quantity=0.
+func (r *StockRepo) GetBalances(ctx context.Context, keys []stock.BalanceKey) ([]entity.StockBalance, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	warehouses := make([]id.ID, len(keys))
+	nomenclatures := make([]id.ID, len(keys))
+	for i, k := range keys {
+		warehouses[i], nomenclatures[i] = k.WarehouseID, k.NomenclatureID
+	}
+
+	var balances []entity.StockBalance
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	err := pgxscan.Select(ctx, querier, &balances, `
+		SELECT warehouse_id, nomenclature_id, quantity, last_movement_at, updated_at
+		FROM reg_stock_balances
+		WHERE (warehouse_id, nomenclature_id) IN (
+			SELECT * FROM unnest($1::uuid[], $2::uuid[])
+		)`, warehouses, nomenclatures)
+	if err != nil {
+		return nil, fmt.Errorf("get balances: %w", err)
+	}
+
+	loaded := make(map[stock.BalanceKey]entity.StockBalance, len(balances))
+	for _, b := range balances {
+		loaded[stock.BalanceKey{WarehouseID: b.WarehouseID, NomenclatureID: b.NomenclatureID}] = b
+	}
+	result := make([]entity.StockBalance, len(keys))
+	for i, k := range keys {
+		if b, ok := loaded[k]; ok {
+			result[i] = b
+		} else {
+			result[i] = entity.StockBalance{WarehouseID: k.WarehouseID, NomenclatureID: k.NomenclatureID}
+		}
+	}
+	return result, nil
+}
+
 // GetBalancesForUpdate returns balances for multiple warehouse+product pairs
 // with pessimistic locking in deterministic key order (deadlock-safe).
 // Keys not found in reg_stock_balances are returned with Quantity=0.
@@ -328,17 +422,97 @@ func (r *StockRepo) GetBalancesByNomenclatureIDs(ctx context.Context, nomenclatu
 	return result, nil
 }
 
-// GetBalancesAtDate calculates balance as of a specific date.
+// GetReservedQuantities sums open reservations per warehouse+product.
+// Over-released orders (negative balance) do not add availability.
+func (r *StockRepo) GetReservedQuantities(ctx context.Context, keys []stock.BalanceKey, exceptOrderID id.ID) (map[stock.BalanceKey]types.Quantity, error) {
+	if len(keys) == 0 {
+		return map[stock.BalanceKey]types.Quantity{}, nil
+	}
+
+	dims := make(squirrel.Or, 0, len(keys))
+	for _, k := range keys {
+		dims = append(dims, squirrel.Eq{"warehouse_id": k.WarehouseID, "nomenclature_id": k.NomenclatureID})
+	}
+
+	q := r.Builder().Select(
+		"warehouse_id", "nomenclature_id",
+		"SUM(quantity) AS reserved",
+	).From(stockReservationBalancesTable).
+		Where(dims).
+		Where(squirrel.Gt{"quantity": 0}).
+		GroupBy("warehouse_id", "nomenclature_id")
+
+	if !id.IsNil(exceptOrderID) {
+		q = q.Where(squirrel.NotEq{"order_id": exceptOrderID})
+	}
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	type row struct {
+		WarehouseID    id.ID          `db:"warehouse_id"`
+		NomenclatureID id.ID          `db:"nomenclature_id"`
+		Reserved       types.Quantity `db:"reserved"`
+	}
+
+	var rows []row
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &rows, sql, args...); err != nil {
+		return nil, fmt.Errorf("select reserved quantities: %w", err)
+	}
+
+	result := make(map[stock.BalanceKey]types.Quantity, len(rows))
+	for _, row := range rows {
+		result[stock.BalanceKey{WarehouseID: row.WarehouseID, NomenclatureID: row.NomenclatureID}] = row.Reserved
+	}
+
+	return result, nil
+}
+
+// GetAvailableQuantity returns the physical balance minus open reservations.
+// The result is negative when more is reserved than is in stock.
+func (r *StockRepo) GetAvailableQuantity(ctx context.Context, warehouseID, nomenclatureID id.ID) (types.Quantity, error) {
+	sql := `
+		SELECT
+			COALESCE((SELECT quantity FROM reg_stock_balances
+			          WHERE warehouse_id = $1 AND nomenclature_id = $2), 0)
+			- COALESCE((SELECT SUM(quantity) FROM reg_stock_reservation_balances
+			            WHERE warehouse_id = $1 AND nomenclature_id = $2 AND quantity > 0), 0)
+	`
+
+	var availableScaled int64
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if err := querier.QueryRow(ctx, sql, warehouseID, nomenclatureID).Scan(&availableScaled); err != nil {
+		return 0, fmt.Errorf("calculate available quantity: %w", err)
+	}
+
+	return types.NewQuantityFromInt64Scaled(availableScaled), nil
+}
+
+// GetBalancesAtDate calculates balance as of a specific date: the nearest
+// month-end snapshot before it plus the movements after the snapshot.
+// Without a snapshot (recent data, new product) all movements are summed.
 func (r *StockRepo) GetBalancesAtDate(ctx context.Context, warehouseID, nomenclatureID id.ID, date time.Time) (types.Quantity, error) {
 	sql := `
-		SELECT COALESCE(
-			SUM(CASE WHEN record_type = 'receipt' THEN quantity ELSE -quantity END),
-			0
+		WITH s AS (
+			SELECT period_end, quantity
+			FROM reg_stock_snapshots
+			WHERE warehouse_id = $1
+			  AND nomenclature_id = $2
+			  AND period_end <= $3
+			ORDER BY period_end DESC
+			LIMIT 1
 		)
-		FROM reg_stock_movements
-		WHERE warehouse_id = $1 
-		  AND nomenclature_id = $2 
-		  AND period <= $3
+		SELECT (COALESCE((SELECT quantity FROM s), 0) + COALESCE((
+			SELECT SUM(CASE WHEN record_type = 'receipt' THEN quantity ELSE -quantity END)
+			FROM reg_stock_movements
+			WHERE warehouse_id = $1
+			  AND nomenclature_id = $2
+			  AND period <= $3
+			  AND period >= COALESCE((SELECT period_end FROM s), '-infinity')
+		), 0))::BIGINT
 	`
 
 	var balanceScaled int64
@@ -351,52 +525,211 @@ func (r *StockRepo) GetBalancesAtDate(ctx context.Context, warehouseID, nomencla
 	return types.NewQuantityFromInt64Scaled(balanceScaled), nil
 }
 
-// GetMovementHistory returns movement history for a product.
-func (r *StockRepo) GetMovementHistory(ctx context.Context, nomenclatureID id.ID, filter stock.MovementFilter) ([]entity.StockMovement, error) {
-	q := r.Builder().Select(
-		"line_id", "recorder_id", "recorder_type", "recorder_version",
-		"period", "record_type",
-		"warehouse_id", "nomenclature_id", "quantity", "created_at",
-	).From(stockMovementsTable).
-		Where(squirrel.Eq{"nomenclature_id": nomenclatureID})
+// RefreshSnapshots builds the month-end balance snapshots of every month
+// that ended by before (month boundaries in UTC), oldest first, each from
+// the previous snapshot and the month's movements. Returns the number of
+// months built.
+func (r *StockRepo) RefreshSnapshots(ctx context.Context, before time.Time) (int, error) {
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+
+	var last, first *time.Time
+	if err := querier.QueryRow(ctx, `SELECT MAX(period_end) FROM reg_stock_snapshot_periods`).Scan(&last); err != nil {
+		return 0, fmt.Errorf("select last snapshot: %w", err)
+	}
+	var next time.Time
+	if last != nil {
+		next = last.UTC().AddDate(0, 1, 0)
+	} else {
+		if err := querier.QueryRow(ctx, `SELECT MIN(period) FROM reg_stock_movements`).Scan(&first); err != nil {
+			return 0, fmt.Errorf("select first movement: %w", err)
+		}
+		if first == nil {
+			return 0, nil
+		}
+		next = monthStart(*first).AddDate(0, 1, 0)
+	}
+
+	built := 0
+	for end := monthStart(before); !next.After(end); next = next.AddDate(0, 1, 0) {
+		if err := r.buildSnapshot(ctx, next, last); err != nil {
+			return built, fmt.Errorf("build snapshot %s: %w", next.Format("2006-01"), err)
+		}
+		periodEnd := next
+		last = &periodEnd
+		built++
+	}
+
+	return built, nil
+}
+
+// buildSnapshot stores the balances before periodEnd: the snapshot at prev
+// (nil for the first one) plus the movements in [prev, periodEnd).
+// Writers are blocked meanwhile, so no movement before periodEnd commits
+// between the sum and the period becoming visible to the snapshot triggers.
+func (r *StockRepo) buildSnapshot(ctx context.Context, periodEnd time.Time, prev *time.Time) error {
+	txm := r.GetTxManager(ctx)
+	return txm.RunInTransaction(ctx, func(ctx context.Context) error {
+		querier := txm.GetQuerier(ctx)
+		if _, err := querier.Exec(ctx, `LOCK TABLE reg_stock_movements IN SHARE MODE`); err != nil {
+			return fmt.Errorf("lock movements: %w", err)
+		}
+		if _, err := querier.Exec(ctx, `INSERT INTO reg_stock_snapshot_periods (period_end) VALUES ($1)`, periodEnd); err != nil {
+			return fmt.Errorf("insert period: %w", err)
+		}
+		sql := `
+			INSERT INTO reg_stock_snapshots (period_end, warehouse_id, nomenclature_id, quantity)
+			SELECT $1::TIMESTAMPTZ, warehouse_id, nomenclature_id, COALESCE(s.quantity, 0) + COALESCE(m.quantity, 0)
+			FROM (
+				SELECT warehouse_id, nomenclature_id, quantity
+				FROM reg_stock_snapshots
+				WHERE period_end = $2
+			) s
+			FULL JOIN (
+				SELECT warehouse_id, nomenclature_id,
+					SUM(CASE WHEN record_type = 'receipt' THEN quantity ELSE -quantity END) AS quantity
+				FROM reg_stock_movements
+				WHERE period >= COALESCE($2::TIMESTAMPTZ, '-infinity') AND period < $1
+				GROUP BY warehouse_id, nomenclature_id
+			) m USING (warehouse_id, nomenclature_id)
+		`
+		if _, err := querier.Exec(ctx, sql, periodEnd, prev); err != nil {
+			return fmt.Errorf("insert snapshots: %w", err)
+		}
+		return nil
+	})
+}
+
+// monthStart returns the first instant of t's month in UTC.
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// movementHistoryCursorFields are the keyset columns of GetMovementHistory.
+// created_at and line_id break ties between movements of the same period.
+var movementHistoryCursorFields = []string{"period", "created_at", "line_id"}
+
+// GetMovementHistory returns one keyset page of movement history for a product.
+// Totals are computed over the whole filtered set in a separate aggregate query.
+func (r *StockRepo) GetMovementHistory(ctx context.Context, nomenclatureID id.ID, filter stock.MovementFilter) (stock.MovementHistory, error) {
+	var result stock.MovementHistory
+
+	conditions := squirrel.And{squirrel.Eq{"nomenclature_id": nomenclatureID}}
 
 	if filter.WarehouseID != nil {
-		q = q.Where(squirrel.Eq{"warehouse_id": *filter.WarehouseID})
+		conditions = append(conditions, squirrel.Eq{"warehouse_id": *filter.WarehouseID})
 	}
 
 	if filter.RecordType != nil {
-		q = q.Where(squirrel.Eq{"record_type": *filter.RecordType})
+		conditions = append(conditions, squirrel.Eq{"record_type": *filter.RecordType})
 	}
 
 	if filter.FromDate != nil {
-		q = q.Where(squirrel.GtOrEq{"period": *filter.FromDate})
+		conditions = append(conditions, squirrel.GtOrEq{"period": *filter.FromDate})
 	}
 
 	if filter.ToDate != nil {
-		q = q.Where(squirrel.LtOrEq{"period": *filter.ToDate})
+		conditions = append(conditions, squirrel.LtOrEq{"period": *filter.ToDate})
 	}
 
-	q = q.OrderBy("period DESC", "created_at DESC")
+	q := r.Builder().Select(stockMovementColumns...).
+		From(stockMovementsTable).
+		Where(conditions).
+		OrderBy("period DESC", "created_at DESC", "line_id DESC")
 
-	if filter.Limit > 0 {
-		q = q.Limit(uint64(filter.Limit))
+	if filter.After != "" {
+		period, createdAt, lineID, err := decodeMovementHistoryCursor(filter.After)
+		if err != nil {
+			return result, err
+		}
+		q = q.Where(squirrel.Expr("(period, created_at, line_id) < (?, ?, ?)", period, createdAt, lineID))
 	}
-	if filter.Offset > 0 {
-		q = q.Offset(uint64(filter.Offset))
+
+	// Fetch one extra row to detect whether another page exists.
+	if filter.Limit > 0 {
+		q = q.Limit(uint64(filter.Limit + 1))
 	}
 
 	sql, args, err := q.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("build query: %w", err)
+		return result, fmt.Errorf("build query: %w", err)
 	}
 
-	var movements []entity.StockMovement
 	querier := r.GetTxManager(ctx).GetQuerier(ctx)
-	if err := pgxscan.Select(ctx, querier, &movements, sql, args...); err != nil {
-		return nil, fmt.Errorf("select history: %w", err)
+	if err := pgxscan.Select(ctx, querier, &result.Items, sql, args...); err != nil {
+		return result, fmt.Errorf("select history: %w", err)
 	}
 
-	return movements, nil
+	if filter.Limit > 0 && len(result.Items) > filter.Limit {
+		result.Items = result.Items[:filter.Limit]
+		result.HasMore = true
+		last := result.Items[len(result.Items)-1]
+		result.NextCursor, err = cursor.Encode(movementHistoryCursorFields, []any{last.Period, last.CreatedAt, last.LineID.String()})
+		if err != nil {
+			return result, fmt.Errorf("encode cursor: %w", err)
+		}
+	}
+
+	totalsSQL, totalsArgs, err := r.Builder().Select(
+		"COUNT(*)",
+		"COALESCE(SUM(CASE WHEN record_type = 'receipt' THEN quantity ELSE 0 END), 0)",
+		"COALESCE(SUM(CASE WHEN record_type = 'expense' THEN quantity ELSE 0 END), 0)",
+	).From(stockMovementsTable).Where(conditions).ToSql()
+	if err != nil {
+		return result, fmt.Errorf("build totals query: %w", err)
+	}
+
+	var receiptScaled, expenseScaled int64
+	if err := querier.QueryRow(ctx, totalsSQL, totalsArgs...).Scan(&result.Totals.Count, &receiptScaled, &expenseScaled); err != nil {
+		return result, fmt.Errorf("select history totals: %w", err)
+	}
+	result.Totals.Receipt = types.NewQuantityFromInt64Scaled(receiptScaled)
+	result.Totals.Expense = types.NewQuantityFromInt64Scaled(expenseScaled)
+
+	return result, nil
+}
+
+// decodeMovementHistoryCursor parses a GetMovementHistory cursor into its keyset values.
+func decodeMovementHistoryCursor(token string) (time.Time, time.Time, id.ID, error) {
+	invalid := func(cause error) (time.Time, time.Time, id.ID, error) {
+		return time.Time{}, time.Time{}, id.ID{}, apperror.NewValidation("invalid cursor").WithCause(cause)
+	}
+
+	payload, err := cursor.Decode(token)
+	if err != nil {
+		return invalid(err)
+	}
+	if len(payload.Fields) != len(movementHistoryCursorFields) {
+		return invalid(fmt.Errorf("expected fields %v, got %v", movementHistoryCursorFields, payload.Fields))
+	}
+	for i, f := range movementHistoryCursorFields {
+		if payload.Fields[i] != f {
+			return invalid(fmt.Errorf("expected fields %v, got %v", movementHistoryCursorFields, payload.Fields))
+		}
+	}
+
+	values := make([]string, len(payload.Values))
+	for i, v := range payload.Values {
+		s, ok := v.(string)
+		if !ok {
+			return invalid(fmt.Errorf("cursor value %d is %T, want string", i, v))
+		}
+		values[i] = s
+	}
+
+	period, err := time.Parse(time.RFC3339Nano, values[0])
+	if err != nil {
+		return invalid(err)
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, values[1])
+	if err != nil {
+		return invalid(err)
+	}
+	lineID, err := id.Parse(values[2])
+	if err != nil {
+		return invalid(err)
+	}
+	return period, createdAt, lineID, nil
 }
 
 // GetTurnover calculates turnover for period.
@@ -437,9 +770,10 @@ func (r *StockRepo) GetTurnover(ctx context.Context, filter stock.TurnoverFilter
 	result.Receipt = types.NewQuantityFromInt64Scaled(receiptScaled)
 	result.Expense = types.NewQuantityFromInt64Scaled(expenseScaled)
 
-	// Calculate opening balance
+	// Calculate opening balance: the latest month-end snapshot before the
+	// period plus the movements after it.
 	openingArgs := []any{filter.FromDate}
-	openingConditions := "period < $1"
+	openingConditions := ""
 	argIndex = 2
 
 	if filter.WarehouseID != nil {
@@ -454,12 +788,20 @@ func (r *StockRepo) GetTurnover(ctx context.Context, filter stock.TurnoverFilter
 	}
 
 	openingSQL := fmt.Sprintf(`
-		SELECT COALESCE(
-			SUM(CASE WHEN record_type = 'receipt' THEN quantity ELSE -quantity END),
-			0
+		WITH s AS (
+			SELECT MAX(period_end) AS period_end FROM reg_stock_snapshot_periods WHERE period_end <= $1
 		)
-		FROM reg_stock_movements
-		WHERE %s
+		SELECT COALESCE(SUM(quantity), 0)::BIGINT
+		FROM (
+			SELECT quantity
+			FROM reg_stock_snapshots
+			WHERE period_end = (SELECT period_end FROM s)%[1]s
+			UNION ALL
+			SELECT CASE WHEN record_type = 'receipt' THEN quantity ELSE -quantity END
+			FROM reg_stock_movements
+			WHERE period < $1
+			  AND period >= COALESCE((SELECT period_end FROM s), '-infinity')%[1]s
+		) q
 	`, openingConditions)
 
 	var openingScaled int64
@@ -474,22 +816,125 @@ func (r *StockRepo) GetTurnover(ctx context.Context, filter stock.TurnoverFilter
 	return result, nil
 }
 
-// RecalculateBalances rebuilds balance table from movements.
-func (r *StockRepo) RecalculateBalances(ctx context.Context, warehouseID, nomenclatureID *id.ID) error {
-	// This would call a stored procedure
-	// For now, skip implementation
-	return nil
+// RecalculateBalances rebuilds balances from movements. Movements are locked
+// against writes (SHARE: concurrent postings wait, readers do not) so the
+// comparison is consistent; only the balances that differ are rewritten.
+func (r *StockRepo) RecalculateBalances(ctx context.Context, filter stock.RecalculateFilter, dryRun bool) ([]stock.BalanceDiscrepancy, error) {
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if _, err := querier.Exec(ctx, "LOCK TABLE "+stockMovementsTable+" IN SHARE MODE"); err != nil {
+		return nil, fmt.Errorf("lock stock movements: %w", err)
+	}
+
+	var args []any
+	conditions := ""
+	if filter.WarehouseID != nil {
+		args = append(args, *filter.WarehouseID)
+		conditions += fmt.Sprintf(" AND warehouse_id = $%d", len(args))
+	}
+	if filter.NomenclatureID != nil {
+		args = append(args, *filter.NomenclatureID)
+		conditions += fmt.Sprintf(" AND nomenclature_id = $%d", len(args))
+	}
+
+	// Keys without movements (stale balance rows) compute to zero.
+	sql := fmt.Sprintf(`
+		WITH computed AS (
+			SELECT warehouse_id, nomenclature_id,
+				SUM(CASE WHEN record_type = 'receipt' THEN quantity ELSE -quantity END)::BIGINT AS quantity,
+				MAX(period) AS last_movement_at
+			FROM reg_stock_movements
+			WHERE TRUE%[1]s
+			GROUP BY warehouse_id, nomenclature_id
+		), stored AS (
+			SELECT warehouse_id, nomenclature_id, quantity
+			FROM reg_stock_balances
+			WHERE TRUE%[1]s
+		)
+		SELECT COALESCE(c.warehouse_id, s.warehouse_id),
+			COALESCE(c.nomenclature_id, s.nomenclature_id),
+			COALESCE(s.quantity, 0),
+			COALESCE(c.quantity, 0),
+			c.last_movement_at
+		FROM computed c
+		FULL JOIN stored s ON s.warehouse_id = c.warehouse_id AND s.nomenclature_id = c.nomenclature_id
+		WHERE COALESCE(c.quantity, 0) <> COALESCE(s.quantity, 0)
+		ORDER BY 1, 2
+	`, conditions)
+
+	rows, err := querier.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("compare stock balances: %w", err)
+	}
+	var (
+		result        []stock.BalanceDiscrepancy
+		quantities    []int64
+		lastMovements []*time.Time
+	)
+	for rows.Next() {
+		var (
+			d                stock.BalanceDiscrepancy
+			stored, computed int64
+			lastMovementAt   *time.Time
+		)
+		if err := rows.Scan(&d.WarehouseID, &d.NomenclatureID, &stored, &computed, &lastMovementAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan stock balance discrepancy: %w", err)
+		}
+		d.Stored = types.NewQuantityFromInt64Scaled(stored)
+		d.Computed = types.NewQuantityFromInt64Scaled(computed)
+		result = append(result, d)
+		quantities = append(quantities, computed)
+		lastMovements = append(lastMovements, lastMovementAt)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("compare stock balances: %w", err)
+	}
+	if dryRun || len(result) == 0 {
+		return result, nil
+	}
+
+	warehouses := make([]id.ID, len(result))
+	nomenclatures := make([]id.ID, len(result))
+	for i, d := range result {
+		warehouses[i], nomenclatures[i] = d.WarehouseID, d.NomenclatureID
+	}
+	_, err = querier.Exec(ctx, `
+		INSERT INTO reg_stock_balances (warehouse_id, nomenclature_id, quantity, last_movement_at, updated_at)
+		SELECT warehouse_id, nomenclature_id, quantity, last_movement_at, NOW()
+		FROM unnest($1::uuid[], $2::uuid[], $3::bigint[], $4::timestamptz[])
+			AS t(warehouse_id, nomenclature_id, quantity, last_movement_at)
+		ON CONFLICT (warehouse_id, nomenclature_id) DO UPDATE SET
+			quantity = EXCLUDED.quantity,
+			last_movement_at = COALESCE(EXCLUDED.last_movement_at, reg_stock_balances.last_movement_at),
+			updated_at = NOW()
+	`, warehouses, nomenclatures, quantities, lastMovements)
+	if err != nil {
+		return nil, fmt.Errorf("rewrite stock balances: %w", err)
+	}
+	return result, nil
 }
 
 // CheckStockAvailability checks if required quantity is available.
-func (r *StockRepo) CheckStockAvailability(ctx context.Context, warehouseID, nomenclatureID id.ID, requiredQty types.Quantity) error {
+// With opts.ConsiderReservations, quantities reserved for other orders are not available.
+func (r *StockRepo) CheckStockAvailability(ctx context.Context, warehouseID, nomenclatureID id.ID, requiredQty types.Quantity, opts stock.AvailabilityOptions) error {
 	balance, err := r.GetBalanceForUpdate(ctx, warehouseID, nomenclatureID)
 	if err != nil {
 		return fmt.Errorf("get balance: %w", err)
 	}
 
-	if balance.Quantity < requiredQty {
-		return apperror.NewInsufficientStock(nomenclatureID.String(), requiredQty.Float64(), balance.Quantity.Float64())
+	available := balance.Quantity
+	if opts.ConsiderReservations {
+		key := stock.BalanceKey{WarehouseID: warehouseID, NomenclatureID: nomenclatureID}
+		reserved, err := r.GetReservedQuantities(ctx, []stock.BalanceKey{key}, opts.ReservedFor)
+		if err != nil {
+			return fmt.Errorf("get reserved quantities: %w", err)
+		}
+		available -= reserved[key]
+	}
+
+	if available < requiredQty {
+		return apperror.NewInsufficientStock(nomenclatureID.String(), requiredQty.Float64(), available.Float64())
 	}
 
 	return nil
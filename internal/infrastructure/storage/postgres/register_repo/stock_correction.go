@@ -0,0 +1,105 @@
+package register_repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/domain/registers/stock"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+// StockCorrectionRepo implements stock.CorrectionRepository.
+type StockCorrectionRepo struct{}
+
+// NewStockCorrectionRepo creates a new stock correction repository.
+func NewStockCorrectionRepo() *StockCorrectionRepo {
+	return &StockCorrectionRepo{}
+}
+
+// Create saves a correction document.
+func (r *StockCorrectionRepo) Create(ctx context.Context, c *stock.Correction) error {
+	filter, err := json.Marshal(c.Filter)
+	if err != nil {
+		return fmt.Errorf("marshal filter: %w", err)
+	}
+
+	q := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+	_, err = q.Exec(ctx, `
+		INSERT INTO reg_stock_corrections
+			(id, reason, filter, target_warehouse_id, target_nomenclature_id, movement_count, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::uuid, $8)`,
+		c.ID, c.Reason, filter, c.TargetWarehouseID, c.TargetNomenclatureID,
+		c.MovementCount, c.CreatedBy, c.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert stock correction: %w", err)
+	}
+
+	// The source lines are taken from the stored movements: a movement
+	// deleted meanwhile (document unposted) is missing, one corrected
+	// meanwhile violates the primary key.
+	tag, err := q.Exec(ctx, `
+		INSERT INTO reg_stock_correction_lines (line_id, correction_id, recorder_id)
+		SELECT line_id, $1, recorder_id
+		FROM reg_stock_movements
+		WHERE line_id = ANY($2)`,
+		c.ID, c.SourceLineIDs,
+	)
+	if err != nil {
+		if postgres.IsUniqueViolation(err) {
+			return apperror.NewConflict("some movements were corrected by another stock correction meanwhile")
+		}
+		return fmt.Errorf("insert stock correction lines: %w", err)
+	}
+	if int(tag.RowsAffected()) != len(c.SourceLineIDs) {
+		return apperror.NewConflict("some movements were removed meanwhile; preview the correction again")
+	}
+	return nil
+}
+
+// List returns corrections, newest first.
+func (r *StockCorrectionRepo) List(ctx context.Context, limit, offset int) ([]stock.Correction, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	q := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+	rows, err := q.Query(ctx, `
+		SELECT id, reason, filter, target_warehouse_id, target_nomenclature_id,
+		       movement_count, COALESCE(created_by::text, ''), created_at
+		FROM reg_stock_corrections
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("query stock corrections: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]stock.Correction, 0)
+	for rows.Next() {
+		var c stock.Correction
+		var filter []byte
+		if err := rows.Scan(
+			&c.ID, &c.Reason, &filter, &c.TargetWarehouseID, &c.TargetNomenclatureID,
+			&c.MovementCount, &c.CreatedBy, &c.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan stock correction: %w", err)
+		}
+		if err := json.Unmarshal(filter, &c.Filter); err != nil {
+			return nil, fmt.Errorf("unmarshal filter: %w", err)
+		}
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate stock corrections: %w", err)
+	}
+	return result, nil
+}
+
+// Ensure interface compliance.
+var _ stock.CorrectionRepository = (*StockCorrectionRepo)(nil)
@@ -0,0 +1,148 @@
+package register_repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/registers/stock"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+const inventoryCountColumns = `id, warehouse_id, nomenclature_ids, status, comment,
+	COALESCE(started_by::text, ''), started_at, finished_at`
+
+// StockInventoryRepo implements stock.InventoryRepository.
+type StockInventoryRepo struct{}
+
+// NewStockInventoryRepo creates a new inventory count repository.
+func NewStockInventoryRepo() *StockInventoryRepo {
+	return &StockInventoryRepo{}
+}
+
+// Create saves a new inventory count.
+func (r *StockInventoryRepo) Create(ctx context.Context, c *stock.InventoryCount) error {
+	nomenclatureIDs := c.NomenclatureIDs
+	if nomenclatureIDs == nil {
+		nomenclatureIDs = []id.ID{}
+	}
+
+	q := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+	_, err := q.Exec(ctx, `
+		INSERT INTO reg_stock_inventory_counts
+			(id, warehouse_id, nomenclature_ids, status, comment, started_by, started_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::uuid, $7)`,
+		c.ID, c.WarehouseID, nomenclatureIDs, c.Status, c.Comment, c.StartedBy, c.StartedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert inventory count: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns an inventory count.
+func (r *StockInventoryRepo) GetByID(ctx context.Context, countID id.ID) (*stock.InventoryCount, error) {
+	q := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+	row := q.QueryRow(ctx, `SELECT `+inventoryCountColumns+`
+		FROM reg_stock_inventory_counts
+		WHERE id = $1`, countID)
+
+	c, err := scanInventoryCount(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperror.NewNotFound("inventory_count", countID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get inventory count: %w", err)
+	}
+	return &c, nil
+}
+
+// Finish sets the final status of an in-progress count.
+// The status guard makes concurrent complete/cancel calls safe.
+func (r *StockInventoryRepo) Finish(ctx context.Context, c *stock.InventoryCount) error {
+	q := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+	tag, err := q.Exec(ctx, `
+		UPDATE reg_stock_inventory_counts
+		SET status = $2, finished_at = $3
+		WHERE id = $1 AND status = $4`,
+		c.ID, c.Status, c.FinishedAt, stock.InventoryInProgress,
+	)
+	if err != nil {
+		return fmt.Errorf("update inventory count: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperror.NewConcurrentModification("inventory_count", c.ID)
+	}
+	return nil
+}
+
+// List returns counts, newest first. Empty status means all statuses.
+func (r *StockInventoryRepo) List(ctx context.Context, status string, limit, offset int) ([]stock.InventoryCount, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	q := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+	rows, err := q.Query(ctx, `SELECT `+inventoryCountColumns+`
+		FROM reg_stock_inventory_counts
+		WHERE $1 = '' OR status = $1
+		ORDER BY started_at DESC
+		LIMIT $2 OFFSET $3`, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("query inventory counts: %w", err)
+	}
+	return collectInventoryCounts(rows)
+}
+
+// ListInProgress returns in-progress counts of the given warehouses.
+func (r *StockInventoryRepo) ListInProgress(ctx context.Context, warehouseIDs []id.ID) ([]stock.InventoryCount, error) {
+	if len(warehouseIDs) == 0 {
+		return nil, nil
+	}
+
+	q := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+	rows, err := q.Query(ctx, `SELECT `+inventoryCountColumns+`
+		FROM reg_stock_inventory_counts
+		WHERE status = $1 AND warehouse_id = ANY($2)`,
+		stock.InventoryInProgress, warehouseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("query in-progress inventory counts: %w", err)
+	}
+	return collectInventoryCounts(rows)
+}
+
+func scanInventoryCount(row pgx.Row) (stock.InventoryCount, error) {
+	var c stock.InventoryCount
+	err := row.Scan(
+		&c.ID, &c.WarehouseID, &c.NomenclatureIDs, &c.Status, &c.Comment,
+		&c.StartedBy, &c.StartedAt, &c.FinishedAt,
+	)
+	return c, err
+}
+
+func collectInventoryCounts(rows pgx.Rows) ([]stock.InventoryCount, error) {
+	defer rows.Close()
+
+	result := make([]stock.InventoryCount, 0)
+	for rows.Next() {
+		c, err := scanInventoryCount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan inventory count: %w", err)
+		}
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate inventory counts: %w", err)
+	}
+	return result, nil
+}
+
+// Ensure interface compliance.
+var _ stock.InventoryRepository = (*StockInventoryRepo)(nil)
@@ -0,0 +1,162 @@
+package register_repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/registers/stock"
+	"metapus/internal/infrastructure/storage/postgres"
+)
+
+// StockLevelRepo implements stock.LevelRepository.
+type StockLevelRepo struct{}
+
+// NewStockLevelRepo creates a new stock level repository.
+func NewStockLevelRepo() *StockLevelRepo {
+	return &StockLevelRepo{}
+}
+
+// Upsert creates or replaces the level of (warehouse, nomenclature).
+func (r *StockLevelRepo) Upsert(ctx context.Context, level *stock.Level) error {
+	querier := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+
+	const query = `
+		INSERT INTO reg_stock_levels (warehouse_id, nomenclature_id, min_quantity, max_quantity, updated_at)
+		VALUES ($1, $2, $3, $4, now())
+		ON CONFLICT (warehouse_id, nomenclature_id) DO UPDATE SET
+			min_quantity = EXCLUDED.min_quantity,
+			max_quantity = EXCLUDED.max_quantity,
+			updated_at = now()
+		RETURNING updated_at
+	`
+
+	err := querier.QueryRow(ctx, query,
+		level.WarehouseID, level.NomenclatureID, level.MinQuantity, level.MaxQuantity,
+	).Scan(&level.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert stock level: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the level of (warehouse, nomenclature).
+func (r *StockLevelRepo) Delete(ctx context.Context, warehouseID, nomenclatureID id.ID) error {
+	querier := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+
+	tag, err := querier.Exec(ctx,
+		`DELETE FROM reg_stock_levels WHERE warehouse_id = $1 AND nomenclature_id = $2`,
+		warehouseID, nomenclatureID)
+	if err != nil {
+		return fmt.Errorf("delete stock level: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperror.NewNotFound("stock_level", nomenclatureID.String())
+	}
+	return nil
+}
+
+// List returns the levels matching the filter.
+func (r *StockLevelRepo) List(ctx context.Context, filter stock.LevelFilter) ([]stock.Level, error) {
+	q := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).
+		Select("warehouse_id", "nomenclature_id", "min_quantity", "max_quantity", "updated_at").
+		From("reg_stock_levels").
+		OrderBy("warehouse_id", "nomenclature_id")
+
+	if filter.WarehouseID != nil {
+		q = q.Where(squirrel.Eq{"warehouse_id": *filter.WarehouseID})
+	}
+	if filter.NomenclatureID != nil {
+		q = q.Where(squirrel.Eq{"nomenclature_id": *filter.NomenclatureID})
+	}
+	if filter.Limit > 0 {
+		q = q.Limit(uint64(filter.Limit))
+	}
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	querier := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+	levels := []stock.Level{}
+	if err := pgxscan.Select(ctx, querier, &levels, sql, args...); err != nil {
+		return nil, fmt.Errorf("list stock levels: %w", err)
+	}
+	return levels, nil
+}
+
+// ListAlerts returns the active alerts, newest first.
+func (r *StockLevelRepo) ListAlerts(ctx context.Context) ([]stock.LowStockAlert, error) {
+	querier := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+
+	const query = `
+		SELECT a.warehouse_id, a.nomenclature_id,
+			COALESCE(w.name, '') AS warehouse_name, COALESCE(n.name, '') AS nomenclature_name,
+			a.quantity, a.min_quantity, a.raised_at
+		FROM reg_stock_level_alerts a
+		LEFT JOIN cat_warehouses w ON w.id = a.warehouse_id
+		LEFT JOIN cat_nomenclatures n ON n.id = a.nomenclature_id
+		ORDER BY a.raised_at DESC, n.name
+	`
+
+	alerts := []stock.LowStockAlert{}
+	if err := pgxscan.Select(ctx, querier, &alerts, query); err != nil {
+		return nil, fmt.Errorf("list low-stock alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// RefreshAlerts recomputes the alerts from reg_stock_balances in one
+// statement. A key without a balance row counts as zero stock.
+func (r *StockLevelRepo) RefreshAlerts(ctx context.Context) ([]stock.LowStockAlert, error) {
+	querier := postgres.MustGetTxManager(ctx).GetQuerier(ctx)
+
+	// xmax = 0 tells a newly inserted row from an updated one.
+	const query = `
+		WITH below AS (
+			SELECT l.warehouse_id, l.nomenclature_id,
+				COALESCE(b.quantity, 0) AS quantity, l.min_quantity
+			FROM reg_stock_levels l
+			LEFT JOIN reg_stock_balances b
+				ON b.warehouse_id = l.warehouse_id AND b.nomenclature_id = l.nomenclature_id
+			WHERE COALESCE(b.quantity, 0) < l.min_quantity
+		), cleared AS (
+			DELETE FROM reg_stock_level_alerts a
+			WHERE NOT EXISTS (
+				SELECT 1 FROM below
+				WHERE below.warehouse_id = a.warehouse_id AND below.nomenclature_id = a.nomenclature_id
+			)
+		), upserted AS (
+			INSERT INTO reg_stock_level_alerts (warehouse_id, nomenclature_id, quantity, min_quantity, raised_at, updated_at)
+			SELECT warehouse_id, nomenclature_id, quantity, min_quantity, now(), now()
+			FROM below
+			ON CONFLICT (warehouse_id, nomenclature_id) DO UPDATE SET
+				quantity = EXCLUDED.quantity,
+				min_quantity = EXCLUDED.min_quantity,
+				updated_at = now()
+			RETURNING warehouse_id, nomenclature_id, quantity, min_quantity, raised_at, xmax = 0 AS raised
+		)
+		SELECT u.warehouse_id, u.nomenclature_id,
+			COALESCE(w.name, '') AS warehouse_name, COALESCE(n.name, '') AS nomenclature_name,
+			u.quantity, u.min_quantity, u.raised_at
+		FROM upserted u
+		LEFT JOIN cat_warehouses w ON w.id = u.warehouse_id
+		LEFT JOIN cat_nomenclatures n ON n.id = u.nomenclature_id
+		WHERE u.raised
+		ORDER BY w.name, n.name
+	`
+
+	raised := []stock.LowStockAlert{}
+	if err := pgxscan.Select(ctx, querier, &raised, query); err != nil {
+		return nil, fmt.Errorf("refresh low-stock alerts: %w", err)
+	}
+	return raised, nil
+}
+
+// Compile-time interface check.
+var _ stock.LevelRepository = (*StockLevelRepo)(nil)
@@ -0,0 +1,163 @@
+package register_repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/registers/stock_reservation"
+)
+
+const (
+	stockReservationMovementsTable = "reg_stock_reservation_movements"
+)
+
+// stockReservationMovementColumns defines column order for stock reservation movements.
+var stockReservationMovementColumns = []string{
+	"line_id", "recorder_id", "recorder_type", "recorder_version",
+	"period", "record_type",
+	"order_id", "warehouse_id", "nomenclature_id", "quantity", "created_at",
+}
+
+// stockReservationMovementRowMapper converts a StockReservationMovement to a flat row.
+func stockReservationMovementRowMapper(m entity.StockReservationMovement) []any {
+	return []any{
+		m.LineID, m.RecorderID, m.RecorderType, m.RecorderVersion,
+		m.Period, m.RecordType,
+		m.OrderID, m.WarehouseID, m.NomenclatureID, m.Quantity, m.CreatedAt,
+	}
+}
+
+// StockReservationRepo implements stock_reservation.Repository.
+type StockReservationRepo struct {
+	BaseAccumulationRepo[entity.StockReservationMovement]
+}
+
+// NewStockReservationRepo creates a new stock reservation register repository.
+func NewStockReservationRepo() *StockReservationRepo {
+	return &StockReservationRepo{
+		BaseAccumulationRepo: NewBaseAccumulationRepo[entity.StockReservationMovement](
+			stockReservationMovementsTable,
+			stockReservationMovementColumns,
+			stockReservationMovementRowMapper,
+		),
+	}
+}
+
+// GetMovementsByRecorder retrieves movements for a document.
+func (r *StockReservationRepo) GetMovementsByRecorder(ctx context.Context, recorderID id.ID) ([]entity.StockReservationMovement, error) {
+	q := r.Builder().Select(stockReservationMovementColumns...).
+		From(stockReservationMovementsTable).
+		Where(squirrel.Eq{"recorder_id": recorderID}).
+		OrderBy("created_at")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var movements []entity.StockReservationMovement
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &movements, sql, args...); err != nil {
+		return nil, fmt.Errorf("select stock reservation movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// GetBalancesForUpdate returns reservation balances with pessimistic locking
+// in deterministic key order (deadlock-safe). Keys not found are returned with Quantity=0.
+// Analogous to StockRepo.GetBalancesForUpdate.
+func (r *StockReservationRepo) GetBalancesForUpdate(ctx context.Context, keys []stock_reservation.BalanceKey) ([]entity.StockReservationBalance, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	sortedKeys := make([]stock_reservation.BalanceKey, len(keys))
+	copy(sortedKeys, keys)
+	stock_reservation.SortBalanceKeys(sortedKeys)
+
+	const lockSQL = `
+		SELECT order_id, warehouse_id, nomenclature_id, quantity, last_movement_at, updated_at
+		FROM reg_stock_reservation_balances
+		WHERE order_id = $1 AND warehouse_id = $2 AND nomenclature_id = $3
+		FOR UPDATE
+	`
+
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	b := &pgx.Batch{}
+	for _, k := range sortedKeys {
+		b.Queue(lockSQL, k.OrderID, k.WarehouseID, k.NomenclatureID)
+	}
+
+	br := querier.SendBatch(ctx, b)
+	defer func() {
+		_ = br.Close()
+	}()
+
+	loaded := make(map[stock_reservation.BalanceKey]entity.StockReservationBalance, len(sortedKeys))
+	for _, k := range sortedKeys {
+		var balance entity.StockReservationBalance
+		rows, err := br.Query()
+		if err != nil {
+			return nil, fmt.Errorf("batch query error: %w", err)
+		}
+
+		if rows.Next() {
+			if err := pgxscan.ScanRow(&balance, rows); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("scan reservation balance: %w", err)
+			}
+			loaded[k] = balance
+		}
+		rows.Close()
+	}
+
+	// Return in original key order, filling missing entries with zero.
+	result := make([]entity.StockReservationBalance, len(keys))
+	for i, k := range keys {
+		if balance, ok := loaded[k]; ok {
+			result[i] = balance
+		} else {
+			result[i] = entity.StockReservationBalance{
+				OrderID:        k.OrderID,
+				WarehouseID:    k.WarehouseID,
+				NomenclatureID: k.NomenclatureID,
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// GetBalancesByOrder returns open reservations of an order.
+func (r *StockReservationRepo) GetBalancesByOrder(ctx context.Context, orderID id.ID) ([]entity.StockReservationBalance, error) {
+	q := r.Builder().Select(
+		"order_id", "warehouse_id", "nomenclature_id",
+		"quantity", "last_movement_at", "updated_at",
+	).From("reg_stock_reservation_balances").
+		Where(squirrel.Eq{"order_id": orderID}).
+		Where(squirrel.Gt{"quantity": 0}).
+		OrderBy("warehouse_id", "nomenclature_id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var balances []entity.StockReservationBalance
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &balances, sql, args...); err != nil {
+		return nil, fmt.Errorf("select order reservations: %w", err)
+	}
+
+	return balances, nil
+}
+
+// Ensure interface compliance.
+var _ stock_reservation.Repository = (*StockReservationRepo)(nil)
@@ -0,0 +1,199 @@
+package register_repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/entity"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/documents/purchase_order"
+	"metapus/internal/domain/registers/supplier_order"
+)
+
+const (
+	purchaseOrderMovementsTable = "reg_purchase_order_movements"
+)
+
+// purchaseOrderMovementColumns defines column order for purchase order movements.
+var purchaseOrderMovementColumns = []string{
+	"line_id", "recorder_id", "recorder_type", "recorder_version",
+	"period", "record_type",
+	"order_id", "order_line_id", "nomenclature_id", "quantity", "created_at",
+}
+
+// purchaseOrderMovementRowMapper converts a PurchaseOrderMovement to a flat row.
+func purchaseOrderMovementRowMapper(m entity.PurchaseOrderMovement) []any {
+	return []any{
+		m.LineID, m.RecorderID, m.RecorderType, m.RecorderVersion,
+		m.Period, m.RecordType,
+		m.OrderID, m.OrderLineID, m.NomenclatureID, m.Quantity, m.CreatedAt,
+	}
+}
+
+// SupplierOrderRepo implements supplier_order.Repository.
+type SupplierOrderRepo struct {
+	BaseAccumulationRepo[entity.PurchaseOrderMovement]
+}
+
+// NewSupplierOrderRepo creates a new purchase order register repository.
+func NewSupplierOrderRepo() *SupplierOrderRepo {
+	return &SupplierOrderRepo{
+		BaseAccumulationRepo: NewBaseAccumulationRepo[entity.PurchaseOrderMovement](
+			purchaseOrderMovementsTable,
+			purchaseOrderMovementColumns,
+			purchaseOrderMovementRowMapper,
+		),
+	}
+}
+
+// GetMovementsByRecorder retrieves movements for a document.
+func (r *SupplierOrderRepo) GetMovementsByRecorder(ctx context.Context, recorderID id.ID) ([]entity.PurchaseOrderMovement, error) {
+	q := r.Builder().Select(purchaseOrderMovementColumns...).
+		From(purchaseOrderMovementsTable).
+		Where(squirrel.Eq{"recorder_id": recorderID}).
+		OrderBy("created_at")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var movements []entity.PurchaseOrderMovement
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &movements, sql, args...); err != nil {
+		return nil, fmt.Errorf("select purchase order movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// GetBalancesForUpdate returns order line balances with pessimistic locking
+// in deterministic key order (deadlock-safe). Keys not found are returned with Quantity=0.
+func (r *SupplierOrderRepo) GetBalancesForUpdate(ctx context.Context, keys []supplier_order.BalanceKey) ([]entity.PurchaseOrderBalance, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	sortedKeys := make([]supplier_order.BalanceKey, len(keys))
+	copy(sortedKeys, keys)
+	supplier_order.SortBalanceKeys(sortedKeys)
+
+	const lockSQL = `
+		SELECT order_id, order_line_id, quantity, last_movement_at, updated_at
+		FROM reg_purchase_order_balances
+		WHERE order_id = $1 AND order_line_id = $2
+		FOR UPDATE
+	`
+
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	b := &pgx.Batch{}
+	for _, k := range sortedKeys {
+		b.Queue(lockSQL, k.OrderID, k.OrderLineID)
+	}
+
+	br := querier.SendBatch(ctx, b)
+	defer func() {
+		_ = br.Close()
+	}()
+
+	loaded := make(map[supplier_order.BalanceKey]entity.PurchaseOrderBalance, len(sortedKeys))
+	for _, k := range sortedKeys {
+		var balance entity.PurchaseOrderBalance
+		rows, err := br.Query()
+		if err != nil {
+			return nil, fmt.Errorf("batch query error: %w", err)
+		}
+
+		if rows.Next() {
+			if err := pgxscan.ScanRow(&balance, rows); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("scan purchase order balance: %w", err)
+			}
+			loaded[k] = balance
+		}
+		rows.Close()
+	}
+
+	// Return in original key order, filling missing entries with zero.
+	result := make([]entity.PurchaseOrderBalance, len(keys))
+	for i, k := range keys {
+		if balance, ok := loaded[k]; ok {
+			result[i] = balance
+		} else {
+			result[i] = entity.PurchaseOrderBalance{
+				OrderID:     k.OrderID,
+				OrderLineID: k.OrderLineID,
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// GetLineFulfillment returns ordered and received quantities per line of an order.
+// Lines of an unposted order are reported with zero ordered quantity.
+func (r *SupplierOrderRepo) GetLineFulfillment(ctx context.Context, orderID id.ID) ([]supplier_order.LineFulfillment, error) {
+	const sql = `
+		SELECT l.line_id AS order_line_id, l.nomenclature_id,
+			COALESCE(SUM(m.quantity) FILTER (WHERE m.record_type = 'receipt'), 0) AS ordered,
+			COALESCE(SUM(m.quantity) FILTER (WHERE m.record_type = 'expense'), 0) AS received
+		FROM doc_purchase_order_lines l
+		LEFT JOIN reg_purchase_order_movements m
+			ON m.order_id = l.document_id AND m.order_line_id = l.line_id
+		WHERE l.document_id = $1
+		GROUP BY l.line_id, l.nomenclature_id, l.line_no
+		ORDER BY l.line_no
+	`
+
+	var result []supplier_order.LineFulfillment
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if err := pgxscan.Select(ctx, querier, &result, sql, orderID); err != nil {
+		return nil, fmt.Errorf("select purchase order fulfillment: %w", err)
+	}
+
+	return result, nil
+}
+
+// RefreshOrderStatus recalculates fulfillment_status of the orders from their movements.
+func (r *SupplierOrderRepo) RefreshOrderStatus(ctx context.Context, orderIDs []id.ID) error {
+	if len(orderIDs) == 0 {
+		return nil
+	}
+
+	const sql = `
+		UPDATE doc_purchase_orders o
+		SET fulfillment_status = CASE
+			WHEN COALESCE(t.received, 0) = 0 THEN $2
+			WHEN t.received >= t.ordered THEN $4
+			ELSE $3
+		END
+		FROM unnest($1::uuid[]) AS ids(order_id)
+		LEFT JOIN (
+			SELECT order_id,
+				COALESCE(SUM(quantity) FILTER (WHERE record_type = 'receipt'), 0) AS ordered,
+				COALESCE(SUM(quantity) FILTER (WHERE record_type = 'expense'), 0) AS received
+			FROM reg_purchase_order_movements
+			WHERE order_id = ANY($1::uuid[])
+			GROUP BY order_id
+		) t ON t.order_id = ids.order_id
+		WHERE o.id = ids.order_id
+	`
+
+	querier := r.GetTxManager(ctx).GetQuerier(ctx)
+	if _, err := querier.Exec(ctx, sql, orderIDs,
+		purchase_order.FulfillmentOpen,
+		purchase_order.FulfillmentPartial,
+		purchase_order.FulfillmentReceived,
+	); err != nil {
+		return fmt.Errorf("update purchase order status: %w", err)
+	}
+
+	return nil
+}
+
+// Ensure interface compliance.
+var _ supplier_order.Repository = (*SupplierOrderRepo)(nil)
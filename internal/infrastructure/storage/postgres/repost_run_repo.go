@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/reposting"
+	"metapus/internal/metadata"
+)
+
+// RepostRunRepo implements reposting.Repository using the tenant database.
+type RepostRunRepo struct{}
+
+// Compile-time interface check.
+var _ reposting.Repository = (*RepostRunRepo)(nil)
+
+// NewRepostRunRepo creates a new repost run repository.
+func NewRepostRunRepo() *RepostRunRepo {
+	return &RepostRunRepo{}
+}
+
+const repostRunColumns = `id, status, date_from, date_to, document_types, total, processed, failed,
+	last_document_date, errors, error_message, requested_by, created_at, started_at, finished_at`
+
+func scanRepostRun(row pgx.Row, r *reposting.Run) error {
+	return row.Scan(&r.ID, &r.Status, &r.DateFrom, &r.DateTo, &r.DocumentTypes, &r.Total, &r.Processed, &r.Failed,
+		&r.LastDocumentDate, &r.Errors, &r.ErrorMessage, &r.RequestedBy, &r.CreatedAt, &r.StartedAt, &r.FinishedAt)
+}
+
+// Create inserts a run.
+func (r *RepostRunRepo) Create(ctx context.Context, run *reposting.Run) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	_, err := q.Exec(ctx, `
+		INSERT INTO sys_repost_runs (id, status, date_from, date_to, document_types, errors, requested_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		run.ID, run.Status, run.DateFrom, run.DateTo, run.DocumentTypes, run.Errors, run.RequestedBy, run.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert repost run: %w", err)
+	}
+	return nil
+}
+
+// Get returns a run.
+func (r *RepostRunRepo) Get(ctx context.Context, runID id.ID) (*reposting.Run, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	var run reposting.Run
+	err := scanRepostRun(q.QueryRow(ctx, `SELECT `+repostRunColumns+` FROM sys_repost_runs WHERE id = $1`, runID), &run)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperror.NewNotFound("repost_run", runID.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get repost run: %w", err)
+	}
+	return &run, nil
+}
+
+// List returns the latest runs, newest first.
+func (r *RepostRunRepo) List(ctx context.Context, limit int) ([]reposting.Run, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	rows, err := q.Query(ctx, `SELECT `+repostRunColumns+` FROM sys_repost_runs ORDER BY created_at DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query sys_repost_runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := make([]reposting.Run, 0)
+	for rows.Next() {
+		var run reposting.Run
+		if err := scanRepostRun(rows, &run); err != nil {
+			return nil, fmt.Errorf("scan sys_repost_runs: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+// Update saves the status and progress of a run.
+func (r *RepostRunRepo) Update(ctx context.Context, run *reposting.Run) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	_, err := q.Exec(ctx, `
+		UPDATE sys_repost_runs SET
+			status = $2, total = $3, processed = $4, failed = $5, last_document_date = $6,
+			errors = $7, error_message = $8, started_at = $9, finished_at = $10
+		WHERE id = $1`,
+		run.ID, run.Status, run.Total, run.Processed, run.Failed, run.LastDocumentDate,
+		run.Errors, run.ErrorMessage, run.StartedAt, run.FinishedAt)
+	if err != nil {
+		return fmt.Errorf("update repost run: %w", err)
+	}
+	return nil
+}
+
+// RepostDocumentSource implements reposting.DocumentSource over the
+// document tables of the metadata registry.
+type RepostDocumentSource struct {
+	registry *metadata.Registry
+}
+
+// Compile-time interface check.
+var _ reposting.DocumentSource = (*RepostDocumentSource)(nil)
+
+// NewRepostDocumentSource creates a document source.
+func NewRepostDocumentSource(registry *metadata.Registry) *RepostDocumentSource {
+	return &RepostDocumentSource{registry: registry}
+}
+
+// DocumentTypes returns the keys of the registered document types, sorted.
+func (s *RepostDocumentSource) DocumentTypes() []string {
+	var types []string
+	for _, def := range s.registry.List() {
+		if def.Type == metadata.TypeDocument && def.Key != "" && deriveTableName(def) != "" {
+			types = append(types, def.Key)
+		}
+	}
+	slices.Sort(types)
+	return types
+}
+
+// ListPosted returns the posted documents of the types dated in [from, to)
+// with a single UNION ALL over their tables, in chronological order.
+func (s *RepostDocumentSource) ListPosted(ctx context.Context, types []string, from, to time.Time) ([]reposting.Document, error) {
+	args := []any{from, to}
+	var parts []string
+	for _, def := range s.registry.List() {
+		if def.Type != metadata.TypeDocument || !slices.Contains(types, def.Key) {
+			continue
+		}
+		table := deriveTableName(def)
+		if table == "" {
+			continue
+		}
+		args = append(args, def.Key)
+		parts = append(parts, fmt.Sprintf(
+			`SELECT $%d::text AS document_type, id, number, date, created_at FROM %s
+			WHERE posted AND _deleted_at IS NULL AND date >= $1 AND date < $2`,
+			len(args), pgx.Identifier{table}.Sanitize()))
+	}
+	if len(parts) == 0 {
+		return []reposting.Document{}, nil
+	}
+
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+	rows, err := q.Query(ctx, strings.Join(parts, "\nUNION ALL\n")+"\nORDER BY date, created_at, id", args...)
+	if err != nil {
+		return nil, fmt.Errorf("query posted documents: %w", err)
+	}
+	defer rows.Close()
+
+	docs := make([]reposting.Document, 0)
+	for rows.Next() {
+		var (
+			d         reposting.Document
+			createdAt time.Time
+		)
+		if err := rows.Scan(&d.Type, &d.ID, &d.Number, &d.Date, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan posted documents: %w", err)
+		}
+		docs = append(docs, d)
+	}
+	return docs, rows.Err()
+}
@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain/settings"
+)
+
+// SearchIndexAggregate is the outbox aggregate type of search index updates.
+// The worker routes these messages to the search indexer instead of automation.
+const SearchIndexAggregate = "search_index"
+
+// Event types of SearchIndexAggregate messages.
+const (
+	// SearchIndexEventReindex refreshes one row (payload: table, entityId).
+	SearchIndexEventReindex = "reindex"
+	// SearchIndexEventRebuild re-indexes all searchable tables of the tenant.
+	SearchIndexEventRebuild = "rebuild"
+)
+
+// SearchIndexPayload is the payload of SearchIndexAggregate messages.
+type SearchIndexPayload struct {
+	Table    string `json:"table,omitempty"`
+	EntityID string `json:"entityId,omitempty"`
+}
+
+// EnqueueSearchIndex queues re-indexing of a row when the tenant searches
+// through the external index (setting search.backend). The message is written
+// in the caller's transaction, so rolled back writes are never indexed.
+func EnqueueSearchIndex(ctx context.Context, table string, entityID id.ID) error {
+	if settings.Get(ctx, settings.KeySearchBackend, settings.Subject{}) != settings.SearchBackendOpenSearch {
+		return nil
+	}
+	return enqueueSearchIndex(ctx, entityID, SearchIndexEventReindex, SearchIndexPayload{
+		Table:    table,
+		EntityID: entityID.String(),
+	})
+}
+
+// EnqueueSearchRebuild queues a full rebuild of the tenant's search index.
+func EnqueueSearchRebuild(ctx context.Context) error {
+	return enqueueSearchIndex(ctx, id.New(), SearchIndexEventRebuild, SearchIndexPayload{})
+}
+
+func enqueueSearchIndex(ctx context.Context, aggregateID id.ID, eventType string, payload SearchIndexPayload) error {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal search index payload: %w", err)
+	}
+
+	_, err = MustGetTxManager(ctx).GetQuerier(ctx).Exec(ctx, `
+		INSERT INTO sys_outbox (id, aggregate_type, aggregate_id, event_type, payload, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, id.New(), SearchIndexAggregate, aggregateID, eventType, payloadBytes, OutboxStatusPending, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("enqueue search index %s: %w", eventType, err)
+	}
+	return nil
+}
@@ -83,20 +83,25 @@ func (r *ProfileRepo) GetByID(ctx context.Context, profileID id.ID) (*security_p
 func (r *ProfileRepo) GetByUserID(ctx context.Context, userID id.ID) (*security_profile.SecurityProfile, error) {
 	querier := r.getTxManager(ctx).GetQuerier(ctx)
 
-	// Find user's profile(s) — pick the first one (or merge later).
-	q, args, err := r.Builder().
-		Select("sp.id", "sp.code", "sp.name", "sp.description", "sp.is_system", "sp.created_at", "sp.updated_at").
-		From("security_profiles sp").
-		Join("user_security_profiles usp ON usp.profile_id = sp.id").
-		Where(squirrel.Eq{"usp.user_id": userID}).
-		Limit(1).
-		ToSql()
-	if err != nil {
-		return nil, fmt.Errorf("build user profile query: %w", err)
-	}
+	// Direct assignment wins; otherwise fall back to a profile of one of the
+	// user's roles (role_security_profiles), picked deterministically by code.
+	const q = `
+		SELECT sp.id, sp.code, sp.name, sp.description, sp.is_system, sp.created_at, sp.updated_at
+		FROM security_profiles sp
+		LEFT JOIN user_security_profiles usp ON usp.profile_id = sp.id AND usp.user_id = $1
+		WHERE usp.user_id IS NOT NULL
+		   OR sp.id IN (
+				SELECT rsp.profile_id
+				FROM role_security_profiles rsp
+				INNER JOIN user_roles ur ON ur.role_id = rsp.role_id
+				WHERE ur.user_id = $1
+		   )
+		ORDER BY (usp.user_id IS NULL), sp.code
+		LIMIT 1
+	`
 
 	profile := &security_profile.SecurityProfile{}
-	if err := pgxscan.Get(ctx, querier, profile, q, args...); err != nil {
+	if err := pgxscan.Get(ctx, querier, profile, q, userID); err != nil {
 		if pgxscan.NotFound(err) {
 			return nil, nil // No profile assigned — no restrictions from profile
 		}
@@ -300,6 +305,44 @@ func (r *ProfileRepo) RemoveFromUser(ctx context.Context, userID, profileID id.I
 	return nil
 }
 
+// ─── AssignToRole / RemoveFromRole ───────────────────────────────────
+
+func (r *ProfileRepo) AssignToRole(ctx context.Context, roleID, profileID id.ID) error {
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+
+	q, args, err := r.Builder().
+		Insert("role_security_profiles").
+		Columns("role_id", "profile_id").
+		Values(roleID, profileID).
+		Suffix("ON CONFLICT (role_id, profile_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build assign role query: %w", err)
+	}
+
+	if _, err := querier.Exec(ctx, q, args...); err != nil {
+		return fmt.Errorf("assign profile to role: %w", err)
+	}
+	return nil
+}
+
+func (r *ProfileRepo) RemoveFromRole(ctx context.Context, roleID, profileID id.ID) error {
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+
+	q, args, err := r.Builder().
+		Delete("role_security_profiles").
+		Where(squirrel.Eq{"role_id": roleID, "profile_id": profileID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build remove role query: %w", err)
+	}
+
+	if _, err := querier.Exec(ctx, q, args...); err != nil {
+		return fmt.Errorf("remove profile from role: %w", err)
+	}
+	return nil
+}
+
 // ─── Internal helpers ────────────────────────────────────────────────
 
 func (r *ProfileRepo) loadDimensions(ctx context.Context, querier postgres.Querier, profile *security_profile.SecurityProfile) error {
@@ -689,6 +732,36 @@ func (r *ProfileRepo) ListUsersByProfileID(ctx context.Context, profileID id.ID)
 	return users, rows.Err()
 }
 
+// ListRolesByProfileID returns roles assigned to a specific profile.
+func (r *ProfileRepo) ListRolesByProfileID(ctx context.Context, profileID id.ID) ([]security_profile.ProfileRole, error) {
+	querier := r.getTxManager(ctx).GetQuerier(ctx)
+
+	query := `
+		SELECT ro.id, ro.code, ro.name
+		FROM roles ro
+		INNER JOIN role_security_profiles rsp ON ro.id = rsp.role_id
+		WHERE rsp.profile_id = $1
+		ORDER BY ro.code ASC
+	`
+
+	rows, err := querier.Query(ctx, query, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("query profile roles: %w", err)
+	}
+	defer rows.Close()
+
+	roles := make([]security_profile.ProfileRole, 0, 4)
+	for rows.Next() {
+		var ro security_profile.ProfileRole
+		if err := rows.Scan(&ro.ID, &ro.Code, &ro.Name); err != nil {
+			return nil, fmt.Errorf("scan profile role: %w", err)
+		}
+		roles = append(roles, ro)
+	}
+
+	return roles, rows.Err()
+}
+
 // GetProfileBriefByUserIDs returns a map of userID → profile brief for batch enrichment.
 func (r *ProfileRepo) GetProfileBriefByUserIDs(ctx context.Context, userIDs []id.ID) (map[id.ID]*security_profile.ProfileBrief, error) {
 	if len(userIDs) == 0 {
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain/settings"
+)
+
+// SettingValuesRepo implements settings.ValueStore using the tenant database.
+type SettingValuesRepo struct{}
+
+// Compile-time interface check.
+var _ settings.ValueStore = (*SettingValuesRepo)(nil)
+
+// NewSettingValuesRepo creates a new scoped setting values repository.
+func NewSettingValuesRepo() *SettingValuesRepo {
+	return &SettingValuesRepo{}
+}
+
+const settingValueCols = `key, scope, scope_id, value, updated_at, updated_by`
+
+// ListValues returns all stored values of the tenant.
+func (r *SettingValuesRepo) ListValues(ctx context.Context) ([]settings.Value, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	rows, err := q.Query(ctx, `SELECT `+settingValueCols+` FROM sys_setting_values ORDER BY key, scope, scope_id`)
+	if err != nil {
+		return nil, fmt.Errorf("query sys_setting_values: %w", err)
+	}
+	defer rows.Close()
+
+	var values []settings.Value
+	for rows.Next() {
+		var v settings.Value
+		if err := rows.Scan(&v.Key, &v.Scope, &v.ScopeID, &v.Value, &v.UpdatedAt, &v.UpdatedBy); err != nil {
+			return nil, fmt.Errorf("scan sys_setting_values: %w", err)
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
+
+// SetValue inserts or replaces a value.
+func (r *SettingValuesRepo) SetValue(ctx context.Context, v settings.Value) (settings.Value, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	var saved settings.Value
+	err := q.QueryRow(ctx, `
+		INSERT INTO sys_setting_values (key, scope, scope_id, value, updated_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (key, scope, scope_id) DO UPDATE
+		SET value = EXCLUDED.value,
+		    updated_at = NOW(),
+		    updated_by = EXCLUDED.updated_by
+		RETURNING `+settingValueCols,
+		v.Key, v.Scope, v.ScopeID, v.Value, v.UpdatedBy,
+	).Scan(&saved.Key, &saved.Scope, &saved.ScopeID, &saved.Value, &saved.UpdatedAt, &saved.UpdatedBy)
+	if err != nil {
+		return settings.Value{}, fmt.Errorf("upsert sys_setting_values %s: %w", v.Key, err)
+	}
+	return saved, nil
+}
+
+// DeleteValue removes a value. Deleting a missing value is not an error.
+func (r *SettingValuesRepo) DeleteValue(ctx context.Context, key string, scope settings.Scope, scopeID id.ID) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	if _, err := q.Exec(ctx,
+		`DELETE FROM sys_setting_values WHERE key = $1 AND scope = $2 AND scope_id = $3`,
+		key, scope, scopeID,
+	); err != nil {
+		return fmt.Errorf("delete sys_setting_values %s: %w", key, err)
+	}
+	return nil
+}
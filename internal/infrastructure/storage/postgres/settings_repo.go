@@ -3,9 +3,14 @@ package postgres
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+
+	"github.com/jackc/pgx/v5"
 
 	"metapus/internal/core/apperror"
+	"metapus/internal/core/crypto"
 	"metapus/internal/domain/settings"
 )
 
@@ -25,10 +30,50 @@ var validSections = map[string]bool{
 	"warehouse":   true,
 	"sales":       true,
 	"purchasing":  true,
+	"retention":   true,
 }
 
-// allColumns lists all JSONB setting columns in scan order.
-const settingsSelectCols = `general, numbering, performance, warehouse, sales, purchasing, version, updated_at`
+// settingsSelectCols lists all JSONB setting columns in scan order.
+const settingsSelectCols = `general, numbering, performance, warehouse, sales, purchasing, email,
+	retention, email_secret IS NOT NULL, version, updated_at`
+
+// scanSettings scans a sys_settings row selected with settingsSelectCols.
+func scanSettings(row pgx.Row) (*settings.Settings, error) {
+	var genJSON, numJSON, perfJSON, whJSON, salesJSON, purchJSON, emailJSON, retJSON []byte
+	var s settings.Settings
+
+	if err := row.Scan(
+		&genJSON, &numJSON, &perfJSON, &whJSON, &salesJSON, &purchJSON, &emailJSON,
+		&retJSON, &s.Email.HasSecret, &s.Version, &s.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	hasSecret := s.Email.HasSecret
+	sections := []struct {
+		name string
+		data []byte
+		dst  any
+	}{
+		{"general", genJSON, &s.General},
+		{"numbering", numJSON, &s.Numbering},
+		{"performance", perfJSON, &s.Performance},
+		{"warehouse", whJSON, &s.Warehouse},
+		{"sales", salesJSON, &s.Sales},
+		{"purchasing", purchJSON, &s.Purchasing},
+		{"email", emailJSON, &s.Email},
+		{"retention", retJSON, &s.Retention},
+	}
+	for _, sec := range sections {
+		if err := json.Unmarshal(sec.data, sec.dst); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", sec.name, err)
+		}
+	}
+	// HasSecret is derived from the email_secret column, never from JSON.
+	s.Email.HasSecret = hasSecret
+
+	return &s, nil
+}
 
 // Get returns the current settings from sys_settings (single-row table).
 func (r *SettingsRepo) Get(ctx context.Context) (*settings.Settings, error) {
@@ -37,37 +82,11 @@ func (r *SettingsRepo) Get(ctx context.Context) (*settings.Settings, error) {
 
 	query := `SELECT ` + settingsSelectCols + ` FROM sys_settings WHERE singleton = TRUE`
 
-	var genJSON, numJSON, perfJSON, whJSON, salesJSON, purchJSON []byte
-	var s settings.Settings
-
-	err := q.QueryRow(ctx, query).Scan(
-		&genJSON, &numJSON, &perfJSON, &whJSON, &salesJSON, &purchJSON,
-		&s.Version, &s.UpdatedAt,
-	)
+	s, err := scanSettings(q.QueryRow(ctx, query))
 	if err != nil {
 		return nil, fmt.Errorf("query sys_settings: %w", err)
 	}
-
-	if err := json.Unmarshal(genJSON, &s.General); err != nil {
-		return nil, fmt.Errorf("unmarshal general: %w", err)
-	}
-	if err := json.Unmarshal(numJSON, &s.Numbering); err != nil {
-		return nil, fmt.Errorf("unmarshal numbering: %w", err)
-	}
-	if err := json.Unmarshal(perfJSON, &s.Performance); err != nil {
-		return nil, fmt.Errorf("unmarshal performance: %w", err)
-	}
-	if err := json.Unmarshal(whJSON, &s.Warehouse); err != nil {
-		return nil, fmt.Errorf("unmarshal warehouse: %w", err)
-	}
-	if err := json.Unmarshal(salesJSON, &s.Sales); err != nil {
-		return nil, fmt.Errorf("unmarshal sales: %w", err)
-	}
-	if err := json.Unmarshal(purchJSON, &s.Purchasing); err != nil {
-		return nil, fmt.Errorf("unmarshal purchasing: %w", err)
-	}
-
-	return &s, nil
+	return s, nil
 }
 
 // UpdateSection updates a single JSONB section with optimistic locking.
@@ -90,41 +109,95 @@ func (r *SettingsRepo) UpdateSection(ctx context.Context, section string, data j
 		RETURNING `+settingsSelectCols+`
 	`, section)
 
-	var genJSON, numJSON, perfJSON, whJSON, salesJSON, purchJSON []byte
-	var s settings.Settings
-
-	err := q.QueryRow(ctx, query, data, version).Scan(
-		&genJSON, &numJSON, &perfJSON, &whJSON, &salesJSON, &purchJSON,
-		&s.Version, &s.UpdatedAt,
-	)
+	s, err := scanSettings(q.QueryRow(ctx, query, data, version))
 	if err != nil {
 		// pgx returns ErrNoRows when WHERE version = $2 doesn't match
-		if err.Error() == "no rows in result set" {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperror.NewConcurrentModification("sys_settings", "singleton")
 		}
 		return nil, fmt.Errorf("update sys_settings.%s: %w", section, err)
 	}
 
-	if err := json.Unmarshal(genJSON, &s.General); err != nil {
-		return nil, fmt.Errorf("unmarshal general: %w", err)
+	return s, nil
+}
+
+// UpdateEmail replaces the email section with optimistic locking.
+// The secret is encrypted with AUTOMATION_ENCRYPTION_KEY (AES-256-GCM).
+func (r *SettingsRepo) UpdateEmail(ctx context.Context, email settings.EmailSettings, secret *string, version int) (*settings.Settings, error) {
+	email.HasSecret = false
+	data, err := json.Marshal(email)
+	if err != nil {
+		return nil, fmt.Errorf("marshal email settings: %w", err)
 	}
-	if err := json.Unmarshal(numJSON, &s.Numbering); err != nil {
-		return nil, fmt.Errorf("unmarshal numbering: %w", err)
+
+	var encrypted []byte
+	if secret != nil && *secret != "" {
+		key, err := settingsEncryptionKey()
+		if err != nil {
+			return nil, apperror.NewInternal(err)
+		}
+		encrypted, err = crypto.Encrypt([]byte(*secret), key)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt email secret: %w", err)
+		}
 	}
-	if err := json.Unmarshal(perfJSON, &s.Performance); err != nil {
-		return nil, fmt.Errorf("unmarshal performance: %w", err)
+
+	txm := MustGetTxManager(ctx)
+	q := txm.GetQuerier(ctx)
+
+	query := `
+		UPDATE sys_settings
+		SET email = $1,
+		    email_secret = CASE WHEN $2 THEN $3 ELSE email_secret END,
+		    version = version + 1,
+		    updated_at = NOW()
+		WHERE singleton = TRUE AND version = $4
+		RETURNING ` + settingsSelectCols
+
+	s, err := scanSettings(q.QueryRow(ctx, query, data, secret != nil, encrypted, version))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewConcurrentModification("sys_settings", "singleton")
+		}
+		return nil, fmt.Errorf("update sys_settings.email: %w", err)
 	}
-	if err := json.Unmarshal(whJSON, &s.Warehouse); err != nil {
-		return nil, fmt.Errorf("unmarshal warehouse: %w", err)
+	return s, nil
+}
+
+// GetEmailSecret returns the decrypted email provider secret (nil if not set).
+func (r *SettingsRepo) GetEmailSecret(ctx context.Context) ([]byte, error) {
+	txm := MustGetTxManager(ctx)
+	q := txm.GetQuerier(ctx)
+
+	var encrypted []byte
+	if err := q.QueryRow(ctx, `SELECT email_secret FROM sys_settings WHERE singleton = TRUE`).Scan(&encrypted); err != nil {
+		return nil, fmt.Errorf("query email secret: %w", err)
 	}
-	if err := json.Unmarshal(salesJSON, &s.Sales); err != nil {
-		return nil, fmt.Errorf("unmarshal sales: %w", err)
+	if len(encrypted) == 0 {
+		return nil, nil
 	}
-	if err := json.Unmarshal(purchJSON, &s.Purchasing); err != nil {
-		return nil, fmt.Errorf("unmarshal purchasing: %w", err)
+
+	key, err := settingsEncryptionKey()
+	if err != nil {
+		return nil, err
 	}
+	plaintext, err := crypto.Decrypt(encrypted, key)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt email secret: %w", err)
+	}
+	return plaintext, nil
+}
 
-	return &s, nil
+// settingsEncryptionKey loads the AES-256 key shared with automation credentials.
+func settingsEncryptionKey() ([]byte, error) {
+	key := []byte(os.Getenv("AUTOMATION_ENCRYPTION_KEY"))
+	if len(key) == 0 {
+		return nil, fmt.Errorf("AUTOMATION_ENCRYPTION_KEY environment variable is not set")
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("AUTOMATION_ENCRYPTION_KEY must be 32 bytes, got %d", len(key))
+	}
+	return key, nil
 }
 
 // Ensure interface compliance.
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/id"
+	"metapus/internal/domain/deltasync"
+)
+
+// SyncRepo implements deltasync.Store over the entity tables and
+// sys_sync_tombstones.
+type SyncRepo struct{}
+
+// NewSyncRepo creates a new sync repository.
+func NewSyncRepo() *SyncRepo {
+	return &SyncRepo{}
+}
+
+// SnapshotXmin returns the oldest transaction still running, in the same
+// (epoch-extended) numbering as txid_current() stored in _txid.
+func (r *SyncRepo) SnapshotXmin(ctx context.Context) (uint64, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	var xmin int64
+	if err := q.QueryRow(ctx, `SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint`).Scan(&xmin); err != nil {
+		return 0, fmt.Errorf("snapshot xmin: %w", err)
+	}
+	return uint64(xmin), nil
+}
+
+// Changes returns the live rows and tombstones of q.Table changed at or
+// after q.Since, ordered by (_txid, id). Scope conditions restrict rows;
+// tombstones carry no columns to check and are returned unscoped, which
+// tells a client at most that an id it may never have seen is gone.
+// q.Table comes from entity metadata, never from user input.
+func (r *SyncRepo) Changes(ctx context.Context, q deltasync.Query) ([]deltasync.Change, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	args := []any{int64(q.Since), q.Table}
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	rowWheres := []string{"t._txid >= $1"}
+	tombWheres := []string{"s.table_name = $2", "s._txid >= $1"}
+	if !id.IsNil(q.AfterID) {
+		after := "(" + arg(int64(q.AfterTx)) + ", " + arg(q.AfterID) + ")"
+		rowWheres = append(rowWheres, "(t._txid, t.id) > "+after)
+		tombWheres = append(tombWheres, "(s._txid, s.entity_id) > "+after)
+	}
+	for _, s := range q.Scope {
+		rowWheres = append(rowWheres, "t."+pgx.Identifier{s.Column}.Sanitize()+"::text = ANY("+arg(s.Values)+")")
+	}
+	limit := arg(q.Limit)
+
+	rows, err := querier.Query(ctx, `
+		SELECT txid, id, deleted, data FROM (
+			(SELECT t._txid AS txid, t.id, FALSE AS deleted, to_jsonb(t) - '_txid' - '_deleted_at' AS data
+			 FROM `+pgx.Identifier{q.Table}.Sanitize()+` t
+			 WHERE `+strings.Join(rowWheres, " AND ")+`
+			 ORDER BY t._txid, t.id
+			 LIMIT `+limit+`)
+			UNION ALL
+			(SELECT s._txid, s.entity_id, TRUE, NULL
+			 FROM sys_sync_tombstones s
+			 WHERE `+strings.Join(tombWheres, " AND ")+`
+			 ORDER BY s._txid, s.entity_id
+			 LIMIT `+limit+`)
+		) c
+		ORDER BY txid, id
+		LIMIT `+limit, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query changes of %s: %w", q.Table, err)
+	}
+	defer rows.Close()
+
+	var out []deltasync.Change
+	for rows.Next() {
+		var (
+			ch   deltasync.Change
+			txid int64
+			raw  []byte
+		)
+		if err := rows.Scan(&txid, &ch.ID, &ch.Deleted, &raw); err != nil {
+			return nil, fmt.Errorf("scan change of %s: %w", q.Table, err)
+		}
+		ch.TxID = uint64(txid)
+		if !ch.Deleted {
+			dec := json.NewDecoder(bytes.NewReader(raw))
+			dec.UseNumber()
+			if err := dec.Decode(&ch.Row); err != nil {
+				return nil, fmt.Errorf("decode change of %s: %w", q.Table, err)
+			}
+		}
+		out = append(out, ch)
+	}
+	return out, rows.Err()
+}
+
+// PurgeTombstones deletes tombstones recorded before cutoff.
+func (r *SyncRepo) PurgeTombstones(ctx context.Context, cutoff time.Time) (int64, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	tag, err := q.Exec(ctx, `DELETE FROM sys_sync_tombstones WHERE deleted_at < $1`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("purge sync tombstones: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -60,7 +60,9 @@ func SerializableTxOptions() TxOptions {
 // - Context cancellation handling
 // - Distributed tracing integration
 type TxManager struct {
-	pool *pgxpool.Pool
+	pool     *pgxpool.Pool
+	acquirer ConnAcquirer  // nil: acquire from pool directly
+	replicas ReplicaSource // nil: no read replica
 }
 
 // NewTxManager creates a new transaction manager.
@@ -73,6 +75,18 @@ func NewTxManagerFromRawPool(pool *pgxpool.Pool) *TxManager {
 	return &TxManager{pool: pool}
 }
 
+// NewTxManagerFromAcquirer creates a transaction manager that takes its
+// connections through a (e.g. tenant.ManagedPool) acquisition timeout.
+// If a also is a ReplicaSource, reads hinted with tx.PreferReplica go to
+// the read replica.
+func NewTxManagerFromAcquirer(a ConnAcquirer) *TxManager {
+	m := &TxManager{pool: a.Pool(), acquirer: a}
+	if rs, ok := a.(ReplicaSource); ok {
+		m.replicas = rs
+	}
+	return m
+}
+
 // txKey is the context key for active transaction.
 type txKey struct{}
 
@@ -108,7 +122,23 @@ func (m *TxManager) RunInTransactionWithOptions(ctx context.Context, opts TxOpti
 
 // startNewTransaction begins a new database transaction.
 func (m *TxManager) startNewTransaction(ctx context.Context, opts TxOptions, fn func(ctx context.Context) error) error {
-	tx, err := m.pool.BeginTx(ctx, pgx.TxOptions{
+	begin := m.pool.BeginTx
+	acquirer := m.acquirer
+	if opts.AccessMode == pgx.ReadOnly && tx.PrefersReplica(ctx) {
+		if r := m.replicaAcquirer(); r != nil {
+			acquirer = r
+		}
+	}
+	if acquirer != nil {
+		conn, err := acquirer.Acquire(ctx)
+		if err != nil {
+			return fmt.Errorf("begin transaction: %w", err)
+		}
+		defer conn.Release()
+		begin = conn.BeginTx
+	}
+
+	tx, err := begin(ctx, pgx.TxOptions{
 		IsoLevel:   opts.IsolationLevel,
 		AccessMode: opts.AccessMode,
 	})
@@ -212,6 +242,14 @@ func (m *TxManager) GetQuerier(ctx context.Context) Querier {
 	if tx := m.GetTx(ctx); tx != nil {
 		return tx.Tx
 	}
+	if tx.PrefersReplica(ctx) {
+		if r := m.replicaAcquirer(); r != nil {
+			return replicaQuerier{replica: acquiringQuerier{acquirer: r}, primary: acquiringQuerier{acquirer: m.acquirer}}
+		}
+	}
+	if m.acquirer != nil {
+		return acquiringQuerier{acquirer: m.acquirer}
+	}
 	return m.pool
 }
 
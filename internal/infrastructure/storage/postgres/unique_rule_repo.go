@@ -0,0 +1,397 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/tenant"
+	"metapus/internal/metadata"
+	"metapus/pkg/logger"
+)
+
+// uniqueRuleIndexPrefix prefixes the indexes owned by sys_unique_rules, so a
+// unique violation can be traced back to its rule.
+const uniqueRuleIndexPrefix = "uq_rule_"
+
+// maxDuplicateSamples limits the existing duplicates reported when a rule
+// cannot be created.
+const maxDuplicateSamples = 5
+
+var (
+	uniqueRuleNameRe  = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	customFieldNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// UniqueRuleRecord represents a row in sys_unique_rules.
+type UniqueRuleRecord struct {
+	ID              string   `json:"id"`
+	EntityType      string   `json:"entityType"`
+	Name            string   `json:"name"`
+	Fields          []string `json:"fields"`
+	CaseInsensitive bool     `json:"caseInsensitive"`
+	Message         string   `json:"message,omitempty"`
+	IndexName       string   `json:"indexName"`
+	CreatedAt       string   `json:"createdAt"`
+	UpdatedAt       string   `json:"updatedAt"`
+}
+
+// UniqueRuleRepo manages sys_unique_rules and the partial unique indexes
+// they own. Fields are resolved against entity metadata.
+type UniqueRuleRepo struct {
+	registry *metadata.Registry
+}
+
+// NewUniqueRuleRepo creates a new repo instance.
+func NewUniqueRuleRepo(registry *metadata.Registry) *UniqueRuleRepo {
+	return &UniqueRuleRepo{registry: registry}
+}
+
+const uniqueRuleColumns = `id, entity_type, name, fields, case_insensitive,
+	COALESCE(message, ''), index_name, created_at::text, updated_at::text`
+
+// List returns uniqueness rules, optionally filtered by entity type.
+func (r *UniqueRuleRepo) List(ctx context.Context, entityType string) ([]UniqueRuleRecord, error) {
+	pool := tenant.MustGetPool(ctx)
+
+	query := "SELECT " + uniqueRuleColumns + " FROM sys_unique_rules"
+	args := make([]any, 0, 1)
+	if entityType != "" {
+		def, ok := r.registry.Get(entityType)
+		if !ok {
+			return nil, apperror.NewValidation("unknown entity type").WithDetail("entityType", entityType)
+		}
+		query += " WHERE entity_type = $1"
+		args = append(args, def.Key)
+	}
+	query += " ORDER BY entity_type, name"
+
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query unique rules: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]UniqueRuleRecord, 0, 8)
+	for rows.Next() {
+		rule, err := scanUniqueRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, rule)
+	}
+	return result, rows.Err()
+}
+
+// GetByID returns a single uniqueness rule.
+func (r *UniqueRuleRepo) GetByID(ctx context.Context, id string) (*UniqueRuleRecord, error) {
+	pool := tenant.MustGetPool(ctx)
+
+	rule, err := scanUniqueRule(pool.QueryRow(ctx,
+		"SELECT "+uniqueRuleColumns+" FROM sys_unique_rules WHERE id = $1", id))
+	if err != nil {
+		return nil, apperror.NewNotFound("unique_rule", id)
+	}
+	return &rule, nil
+}
+
+// Create validates the rule against entity metadata, checks that existing
+// records do not already violate it and creates its partial unique index
+// concurrently, so writes to the entity table go on during the build.
+// Marked-for-deletion records are excluded from the index.
+func (r *UniqueRuleRepo) Create(ctx context.Context, rule *UniqueRuleRecord) error {
+	def, ok := r.registry.Get(rule.EntityType)
+	if !ok {
+		return apperror.NewValidation("unknown entity type").WithDetail("entityType", rule.EntityType)
+	}
+	if !uniqueRuleNameRe.MatchString(rule.Name) {
+		return apperror.NewValidation("rule name must be snake_case (a-z, 0-9, _)").WithDetail("name", rule.Name)
+	}
+	rule.EntityType = def.Key
+	rule.IndexName = uniqueRuleIndexPrefix + def.TableName + "_" + rule.Name
+	if len(rule.IndexName) > 63 {
+		return apperror.NewValidation("rule name is too long for this entity").
+			WithDetail("name", rule.Name).
+			WithDetail("maxLength", 63-len(uniqueRuleIndexPrefix+def.TableName+"_"))
+	}
+
+	pool := tenant.MustGetPool(ctx)
+	if err := r.checkCustomFields(ctx, def, rule.Fields); err != nil {
+		return err
+	}
+	exprs, err := uniqueRuleExprs(def, rule.Fields, rule.CaseInsensitive)
+	if err != nil {
+		return err
+	}
+	where := uniqueRuleWhere(def)
+
+	samples, err := findDuplicates(ctx, pool, def.TableName, exprs, where)
+	if err != nil {
+		return err
+	}
+	if len(samples) > 0 {
+		return apperror.NewConflict("existing records violate the uniqueness rule").
+			WithDetail("rule", rule.Name).
+			WithDetail("duplicates", samples)
+	}
+
+	ddl := fmt.Sprintf("CREATE UNIQUE INDEX CONCURRENTLY %s ON %s (%s)",
+		pgx.Identifier{rule.IndexName}.Sanitize(), pgx.Identifier{def.TableName}.Sanitize(), strings.Join(exprs, ", "))
+	if where != "" {
+		ddl += " WHERE " + where
+	}
+
+	// The rule row is inserted first: it reserves the name, and a concurrent
+	// build cannot run inside a transaction.
+	err = pool.QueryRow(ctx, `
+		INSERT INTO sys_unique_rules (entity_type, name, fields, case_insensitive, message, index_name)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)
+		RETURNING id
+	`, rule.EntityType, rule.Name, rule.Fields, rule.CaseInsensitive, rule.Message, rule.IndexName).Scan(&rule.ID)
+	if err != nil {
+		if IsUniqueViolation(err) {
+			return apperror.NewConflict(
+				fmt.Sprintf("unique rule %s.%s already exists", rule.EntityType, rule.Name))
+		}
+		return fmt.Errorf("create unique rule: %w", err)
+	}
+
+	// CONCURRENTLY keeps the table writable while the index is built.
+	if _, err := pool.Exec(ctx, ddl); err != nil {
+		r.dropFailedRule(ctx, pool, rule)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			// A concurrent write added a duplicate after the check above.
+			return apperror.NewConflict("existing records violate the uniqueness rule").
+				WithDetail("rule", rule.Name)
+		}
+		return fmt.Errorf("create unique rule index: %w", err)
+	}
+	return nil
+}
+
+// dropFailedRule removes the rule of an index build that failed, together
+// with the invalid index a failed concurrent build leaves behind. It runs even
+// if the request was cancelled; a cleanup error is only logged.
+func (r *UniqueRuleRepo) dropFailedRule(ctx context.Context, pool *pgxpool.Pool, rule *UniqueRuleRecord) {
+	ctx = context.WithoutCancel(ctx)
+	if _, err := pool.Exec(ctx, "DROP INDEX CONCURRENTLY IF EXISTS "+pgx.Identifier{rule.IndexName}.Sanitize()); err != nil {
+		logger.Warn(ctx, "drop invalid unique rule index", "index", rule.IndexName, "error", err)
+	}
+	if _, err := pool.Exec(ctx, "DELETE FROM sys_unique_rules WHERE id = $1", rule.ID); err != nil {
+		logger.Warn(ctx, "delete failed unique rule", "rule", rule.Name, "error", err)
+	}
+}
+
+// Delete drops the rule's index and removes the rule.
+func (r *UniqueRuleRepo) Delete(ctx context.Context, id string) error {
+	pool := tenant.MustGetPool(ctx)
+
+	return pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		var indexName string
+		err := tx.QueryRow(ctx,
+			"DELETE FROM sys_unique_rules WHERE id = $1 RETURNING index_name", id).Scan(&indexName)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.NewNotFound("unique_rule", id)
+		}
+		if err != nil {
+			return fmt.Errorf("delete unique rule: %w", err)
+		}
+		if _, err := tx.Exec(ctx, "DROP INDEX IF EXISTS "+pgx.Identifier{indexName}.Sanitize()); err != nil {
+			return fmt.Errorf("drop unique rule index: %w", err)
+		}
+		return nil
+	})
+}
+
+// checkCustomFields verifies that the custom fields (attributes.<name>) used
+// by a rule are defined and active for the entity.
+func (r *UniqueRuleRepo) checkCustomFields(ctx context.Context, def metadata.EntityDef, fields []string) error {
+	var names []string
+	for _, f := range fields {
+		if name, ok := strings.CutPrefix(f, "attributes."); ok {
+			if !customFieldNameRe.MatchString(name) {
+				return apperror.NewValidation("invalid custom field name").WithDetail("field", f)
+			}
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	rows, err := tenant.MustGetPool(ctx).Query(ctx, `
+		SELECT field_name FROM sys_custom_field_schemas
+		WHERE entity_type = ANY($1) AND field_name = ANY($2) AND is_active
+	`, []string{def.Key, def.Name}, names)
+	if err != nil {
+		return fmt.Errorf("query custom fields: %w", err)
+	}
+	defined, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("query custom fields: %w", err)
+	}
+	for _, name := range names {
+		found := false
+		for _, d := range defined {
+			found = found || d == name
+		}
+		if !found {
+			return apperror.NewValidation("unknown custom field").WithDetail("field", "attributes."+name)
+		}
+	}
+	return nil
+}
+
+// uniqueRuleExprs maps rule fields to index expressions: header fields to
+// their columns, custom fields to attributes->>'<name>'. With caseInsensitive,
+// string fields (and all custom fields) are compared via lower().
+func uniqueRuleExprs(def metadata.EntityDef, fields []string, caseInsensitive bool) ([]string, error) {
+	if len(fields) == 0 {
+		return nil, apperror.NewValidation("a uniqueness rule needs at least one field")
+	}
+	exprs := make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		if seen[f] {
+			return nil, apperror.NewValidation("duplicate field in uniqueness rule").WithDetail("field", f)
+		}
+		seen[f] = true
+
+		var expr string
+		var isString bool
+		if name, ok := strings.CutPrefix(f, "attributes."); ok {
+			if !customFieldNameRe.MatchString(name) {
+				return nil, apperror.NewValidation("invalid custom field name").WithDetail("field", f)
+			}
+			expr = fmt.Sprintf("(attributes->>'%s')", name)
+			isString = true
+		} else {
+			fd, ok := findHeaderField(def, f)
+			if !ok {
+				return nil, apperror.NewValidation("unknown field").
+					WithDetail("entityType", def.Name).
+					WithDetail("field", f)
+			}
+			expr = pgx.Identifier{fd.Column}.Sanitize()
+			isString = fd.Type == metadata.TypeString || fd.Type == metadata.TypeText
+		}
+		if caseInsensitive && isString {
+			expr = "lower(" + expr + ")"
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, nil
+}
+
+// findHeaderField finds a header field with a DB column by its JSON name.
+// Table-part columns are not indexable by a rule.
+func findHeaderField(def metadata.EntityDef, name string) (metadata.FieldDef, bool) {
+	for _, fd := range def.Fields {
+		if fd.Name == name && fd.Column != "" {
+			return fd, true
+		}
+	}
+	return metadata.FieldDef{}, false
+}
+
+// uniqueRuleWhere returns the partial index predicate: records marked for
+// deletion do not take part in uniqueness.
+func uniqueRuleWhere(def metadata.EntityDef) string {
+	for _, fd := range def.Fields {
+		if fd.Column == "deletion_mark" {
+			return "deletion_mark = FALSE"
+		}
+	}
+	return ""
+}
+
+// findDuplicates returns up to maxDuplicateSamples value tuples that occur
+// more than once among the rows matched by where. NULLs never collide in a
+// unique index, so tuples with a NULL are skipped.
+func findDuplicates(ctx context.Context, pool *pgxpool.Pool, table string, exprs []string, where string) ([]string, error) {
+	conds := make([]string, 0, len(exprs)+1)
+	for _, e := range exprs {
+		conds = append(conds, e+" IS NOT NULL")
+	}
+	if where != "" {
+		conds = append(conds, where)
+	}
+	keys := strings.Join(exprs, ", ")
+	rows, err := pool.Query(ctx, fmt.Sprintf(
+		"SELECT concat_ws(', ', %s) FROM %s WHERE %s GROUP BY %s HAVING count(*) > 1 LIMIT %d",
+		keys, pgx.Identifier{table}.Sanitize(), strings.Join(conds, " AND "), keys, maxDuplicateSamples))
+	if err != nil {
+		return nil, fmt.Errorf("check existing duplicates: %w", err)
+	}
+	samples, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("check existing duplicates: %w", err)
+	}
+	return samples, nil
+}
+
+// UniqueRuleViolation maps a violation of a uniqueness rule's index to a
+// DUPLICATE_ENTRY error that names the rule, its fields and the duplicated
+// values (with the rule's message, when set). Returns nil for any other
+// error. The rule is read outside the caller's (aborted) transaction.
+func UniqueRuleViolation(ctx context.Context, err error) *apperror.AppError {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" ||
+		!strings.HasPrefix(pgErr.ConstraintName, uniqueRuleIndexPrefix) {
+		return nil
+	}
+
+	entity := pgErr.TableName
+	field := pgErr.ConstraintName
+	var ruleName, message string
+	if pool, poolErr := tenant.GetPool(ctx); poolErr == nil {
+		var fields []string
+		if pool.QueryRow(context.WithoutCancel(ctx), `
+			SELECT entity_type, name, fields, COALESCE(message, '')
+			FROM sys_unique_rules WHERE index_name = $1
+		`, pgErr.ConstraintName).Scan(&entity, &ruleName, &fields, &message) == nil {
+			field = strings.Join(fields, ", ")
+		}
+	}
+
+	appErr := apperror.NewDuplicate(entity, field, duplicateValue(pgErr.Detail))
+	if ruleName != "" {
+		appErr = appErr.WithDetail("rule", ruleName)
+	}
+	if message != "" {
+		appErr.Message = message
+	}
+	return appErr
+}
+
+// duplicateValue extracts the values from a unique violation detail:
+// "Key (inn, kpp)=(7701, 7701001) already exists." → "7701, 7701001".
+func duplicateValue(detail string) string {
+	_, rest, ok := strings.Cut(detail, ")=(")
+	if !ok {
+		return ""
+	}
+	end := strings.LastIndex(rest, ")")
+	if end < 0 {
+		return rest
+	}
+	return rest[:end]
+}
+
+func scanUniqueRule(row scannable) (UniqueRuleRecord, error) {
+	var rule UniqueRuleRecord
+	err := row.Scan(&rule.ID, &rule.EntityType, &rule.Name, &rule.Fields, &rule.CaseInsensitive,
+		&rule.Message, &rule.IndexName, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return rule, fmt.Errorf("scan unique rule: %w", err)
+	}
+	return rule, nil
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"metapus/internal/metadata"
+)
+
+func TestUniqueRuleExprs(t *testing.T) {
+	def := metadata.EntityDef{
+		Name: "Nomenclature",
+		Fields: []metadata.FieldDef{
+			{Name: "article", Type: metadata.TypeString, Column: "article"},
+			{Name: "typeId", Type: metadata.TypeReference, Column: "type_id"},
+			{Name: "deletionMark", Type: metadata.TypeBoolean, Column: "deletion_mark"},
+		},
+	}
+
+	exprs, err := uniqueRuleExprs(def, []string{"article", "typeId", "attributes.sku"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`lower("article")`, `"type_id"`, `lower((attributes->>'sku'))`}, exprs)
+	assert.Equal(t, "deletion_mark = FALSE", uniqueRuleWhere(def))
+
+	for _, fields := range [][]string{
+		nil,
+		{"unknown"},
+		{"article", "article"},
+		{"attributes.x'); DROP TABLE t; --"},
+	} {
+		_, err := uniqueRuleExprs(def, fields, false)
+		assert.Error(t, err, "fields %v", fields)
+	}
+}
+
+func TestDuplicateValue(t *testing.T) {
+	assert.Equal(t, "7701, 770101001",
+		duplicateValue("Key (inn, kpp)=(7701, 770101001) already exists."))
+	assert.Equal(t, "abc",
+		duplicateValue("Key (lower(article::text), type_id)=(abc) already exists."))
+	assert.Equal(t, "", duplicateValue(""))
+}
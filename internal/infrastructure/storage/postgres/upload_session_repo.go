@@ -0,0 +1,260 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/domain/attachment"
+)
+
+// UploadSessionRepo implements attachment.SessionRepository.
+type UploadSessionRepo struct{}
+
+// NewUploadSessionRepo creates a new upload session repository.
+func NewUploadSessionRepo() *UploadSessionRepo {
+	return &UploadSessionRepo{}
+}
+
+func (r *UploadSessionRepo) psql() squirrel.StatementBuilderType {
+	return squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+}
+
+var uploadSessionColumns = []string{
+	"id", "entity_type", "entity_id", "status", "created_by", "expires_at", "finalized_at", "created_at",
+}
+
+func scanUploadSession(row pgx.Row, s *attachment.UploadSession) error {
+	return row.Scan(
+		&s.ID, &s.EntityType, &s.EntityID, &s.Status, &s.CreatedBy, &s.ExpiresAt, &s.FinalizedAt, &s.CreatedAt,
+	)
+}
+
+var uploadSessionFileColumns = []string{
+	"id", "session_id", "file_name", "content_type", "size", "storage_key", "created_at",
+}
+
+func scanUploadSessionFile(row pgx.Row, f *attachment.SessionFile) error {
+	return row.Scan(&f.ID, &f.SessionID, &f.FileName, &f.ContentType, &f.Size, &f.StorageKey, &f.CreatedAt)
+}
+
+// CreateSession inserts a session.
+func (r *UploadSessionRepo) CreateSession(ctx context.Context, s *attachment.UploadSession) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Insert("sys_upload_sessions").
+		Columns("id", "entity_type", "entity_id", "status", "created_by", "expires_at").
+		Values(s.ID, s.EntityType, s.EntityID, s.Status, s.CreatedBy, s.ExpiresAt).
+		Suffix("RETURNING created_at").
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build insert query: %w", err))
+	}
+
+	if err := querier.QueryRow(ctx, query, args...).Scan(&s.CreatedAt); err != nil {
+		return apperror.NewInternal(fmt.Errorf("insert upload session: %w", err))
+	}
+	return nil
+}
+
+// GetSession returns a session by ID, optionally locking its row.
+func (r *UploadSessionRepo) GetSession(ctx context.Context, sessionID uuid.UUID, forUpdate bool) (*attachment.UploadSession, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	b := r.psql().Select(uploadSessionColumns...).
+		From("sys_upload_sessions").
+		Where(squirrel.Eq{"id": sessionID})
+	if forUpdate {
+		b = b.Suffix("FOR UPDATE")
+	}
+	query, args, err := b.ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	var s attachment.UploadSession
+	if err := scanUploadSession(querier.QueryRow(ctx, query, args...), &s); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFound("upload_session", sessionID)
+		}
+		return nil, apperror.NewInternal(fmt.Errorf("scan upload session: %w", err))
+	}
+	return &s, nil
+}
+
+// AddFiles inserts declared files in one statement.
+func (r *UploadSessionRepo) AddFiles(ctx context.Context, files []*attachment.SessionFile) error {
+	if len(files) == 0 {
+		return nil
+	}
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	b := r.psql().Insert("sys_upload_session_files").
+		Columns("id", "session_id", "file_name", "content_type", "size", "storage_key")
+	for _, f := range files {
+		b = b.Values(f.ID, f.SessionID, f.FileName, f.ContentType, f.Size, f.StorageKey)
+	}
+	query, args, err := b.Suffix("RETURNING created_at").ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build insert query: %w", err))
+	}
+
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("insert upload session files: %w", err))
+	}
+	defer rows.Close()
+	for i := 0; rows.Next(); i++ {
+		if err := rows.Scan(&files[i].CreatedAt); err != nil {
+			return apperror.NewInternal(fmt.Errorf("scan upload session file: %w", err))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return apperror.NewInternal(fmt.Errorf("insert upload session files: %w", err))
+	}
+	return nil
+}
+
+// ListFiles returns the files of a session in declaration order.
+func (r *UploadSessionRepo) ListFiles(ctx context.Context, sessionID uuid.UUID) ([]*attachment.SessionFile, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Select(uploadSessionFileColumns...).
+		From("sys_upload_session_files").
+		Where(squirrel.Eq{"session_id": sessionID}).
+		OrderBy("created_at", "id").
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("execute query: %w", err))
+	}
+	defer rows.Close()
+
+	list := make([]*attachment.SessionFile, 0)
+	for rows.Next() {
+		f := &attachment.SessionFile{}
+		if err := scanUploadSessionFile(rows, f); err != nil {
+			return nil, apperror.NewInternal(fmt.Errorf("scan upload session file: %w", err))
+		}
+		list = append(list, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("rows iteration error: %w", err))
+	}
+	return list, nil
+}
+
+// GetFile returns a declared file with its session.
+func (r *UploadSessionRepo) GetFile(ctx context.Context, fileID uuid.UUID) (*attachment.SessionFile, *attachment.UploadSession, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Select(uploadSessionFileColumns...).
+		From("sys_upload_session_files").
+		Where(squirrel.Eq{"id": fileID}).
+		ToSql()
+	if err != nil {
+		return nil, nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	var f attachment.SessionFile
+	if err := scanUploadSessionFile(querier.QueryRow(ctx, query, args...), &f); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, apperror.NewNotFound("upload_file", fileID)
+		}
+		return nil, nil, apperror.NewInternal(fmt.Errorf("scan upload session file: %w", err))
+	}
+
+	s, err := r.GetSession(ctx, f.SessionID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &f, s, nil
+}
+
+// MarkFinalized sets the session status to finalized.
+func (r *UploadSessionRepo) MarkFinalized(ctx context.Context, sessionID uuid.UUID, at time.Time) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Update("sys_upload_sessions").
+		Set("status", attachment.SessionFinalized).
+		Set("finalized_at", at).
+		Where(squirrel.Eq{"id": sessionID}).
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build update query: %w", err))
+	}
+
+	cmdTag, err := querier.Exec(ctx, query, args...)
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("execute update: %w", err))
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return apperror.NewNotFound("upload_session", sessionID)
+	}
+	return nil
+}
+
+// ListExpired returns open sessions that expired before t, oldest first.
+func (r *UploadSessionRepo) ListExpired(ctx context.Context, t time.Time, limit int) ([]*attachment.UploadSession, error) {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Select(uploadSessionColumns...).
+		From("sys_upload_sessions").
+		Where(squirrel.Eq{"status": attachment.SessionOpen}).
+		Where(squirrel.Lt{"expires_at": t}).
+		OrderBy("expires_at").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("build query: %w", err))
+	}
+
+	rows, err := querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("execute query: %w", err))
+	}
+	defer rows.Close()
+
+	list := make([]*attachment.UploadSession, 0)
+	for rows.Next() {
+		s := &attachment.UploadSession{}
+		if err := scanUploadSession(rows, s); err != nil {
+			return nil, apperror.NewInternal(fmt.Errorf("scan upload session: %w", err))
+		}
+		list = append(list, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperror.NewInternal(fmt.Errorf("rows iteration error: %w", err))
+	}
+	return list, nil
+}
+
+// DeleteSession removes a session; its files go with it (ON DELETE CASCADE).
+func (r *UploadSessionRepo) DeleteSession(ctx context.Context, sessionID uuid.UUID) error {
+	querier := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query, args, err := r.psql().Delete("sys_upload_sessions").
+		Where(squirrel.Eq{"id": sessionID}).
+		ToSql()
+	if err != nil {
+		return apperror.NewInternal(fmt.Errorf("build delete query: %w", err))
+	}
+
+	if _, err := querier.Exec(ctx, query, args...); err != nil {
+		return apperror.NewInternal(fmt.Errorf("execute delete: %w", err))
+	}
+	return nil
+}
+
+// Ensure interface compliance.
+var _ attachment.SessionRepository = (*UploadSessionRepo)(nil)
@@ -0,0 +1,214 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"metapus/internal/core/apperror"
+	"metapus/internal/core/id"
+	"metapus/internal/domain/webhooks"
+)
+
+// WebhookDeliveryRepo implements webhooks.Repository.
+type WebhookDeliveryRepo struct{}
+
+// NewWebhookDeliveryRepo creates a new repository.
+func NewWebhookDeliveryRepo() *WebhookDeliveryRepo {
+	return &WebhookDeliveryRepo{}
+}
+
+const webhookDeliverySelectCols = `id, subscription_id, outbox_id, event_type, payload,
+	status, attempts, next_attempt_at, last_status_code, last_error,
+	response_time_ms, created_at, delivered_at`
+
+// Enqueue inserts pending deliveries, skipping (outbox_id, subscription_id)
+// pairs that already exist.
+func (r *WebhookDeliveryRepo) Enqueue(ctx context.Context, deliveries []*webhooks.Delivery) (int, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	inserted := 0
+	for _, d := range deliveries {
+		tag, err := q.Exec(ctx, `
+			INSERT INTO sys_webhook_deliveries (
+				id, subscription_id, outbox_id, event_type, payload,
+				status, attempts, next_attempt_at, created_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (outbox_id, subscription_id) DO NOTHING
+		`, d.ID, d.SubscriptionID, d.OutboxID, d.EventType, d.Payload,
+			d.Status, d.Attempts, d.NextAttemptAt, d.CreatedAt)
+		if err != nil {
+			return inserted, fmt.Errorf("insert webhook delivery: %w", err)
+		}
+		inserted += int(tag.RowsAffected())
+	}
+	return inserted, nil
+}
+
+// ClaimDue leases up to limit due pending deliveries.
+// SKIP LOCKED keeps concurrent workers from claiming the same row.
+func (r *WebhookDeliveryRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*webhooks.Delivery, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	rows, err := q.Query(ctx, fmt.Sprintf(`
+		WITH batch AS (
+			SELECT id
+			FROM sys_webhook_deliveries
+			WHERE status = $1 AND next_attempt_at <= now()
+			ORDER BY next_attempt_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE sys_webhook_deliveries d
+		SET next_attempt_at = now() + $3::interval
+		FROM batch b
+		WHERE d.id = b.id
+		RETURNING %s
+	`, webhookDeliveryReturningCols), webhooks.DeliveryPending, limit, lease)
+	if err != nil {
+		return nil, fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*webhooks.Delivery
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// Save stores the status and attempt fields of a delivery.
+func (r *WebhookDeliveryRepo) Save(ctx context.Context, d *webhooks.Delivery) error {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	tag, err := q.Exec(ctx, `
+		UPDATE sys_webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4,
+		    last_status_code = $5, last_error = $6, response_time_ms = $7,
+		    delivered_at = $8
+		WHERE id = $1
+	`, d.ID, d.Status, d.Attempts, d.NextAttemptAt,
+		d.LastStatusCode, d.LastError, d.ResponseTimeMs, d.DeliveredAt)
+	if err != nil {
+		return fmt.Errorf("update webhook delivery: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperror.NewNotFound("sys_webhook_deliveries", d.ID)
+	}
+	return nil
+}
+
+// GetByID retrieves a single delivery.
+func (r *WebhookDeliveryRepo) GetByID(ctx context.Context, deliveryID id.ID) (*webhooks.Delivery, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	query := fmt.Sprintf(`SELECT %s FROM sys_webhook_deliveries WHERE id = $1`, webhookDeliverySelectCols)
+
+	d, err := scanWebhookDelivery(q.QueryRow(ctx, query, deliveryID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFound("sys_webhook_deliveries", deliveryID)
+		}
+		return nil, fmt.Errorf("get webhook delivery by id: %w", err)
+	}
+	return d, nil
+}
+
+// List returns filtered and paginated deliveries, newest first.
+func (r *WebhookDeliveryRepo) List(ctx context.Context, filter webhooks.DeliveryFilter) ([]webhooks.Delivery, int, error) {
+	q := MustGetTxManager(ctx).GetQuerier(ctx)
+
+	where, args := buildWebhookDeliveryWhere(filter)
+
+	// Count query
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM sys_webhook_deliveries %s", where)
+	var total int
+	if err := q.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count webhook deliveries: %w", err)
+	}
+
+	// Data query
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	offset := max(filter.Offset, 0)
+
+	dataQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM sys_webhook_deliveries %s
+		ORDER BY created_at DESC
+		LIMIT %d OFFSET %d
+	`, webhookDeliverySelectCols, where, limit, offset)
+
+	rows, err := q.Query(ctx, dataQuery, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []webhooks.Delivery
+	for rows.Next() {
+		d, scanErr := scanWebhookDelivery(rows)
+		if scanErr != nil {
+			return nil, 0, fmt.Errorf("scan webhook delivery: %w", scanErr)
+		}
+		deliveries = append(deliveries, *d)
+	}
+
+	return deliveries, total, rows.Err()
+}
+
+// webhookDeliveryReturningCols is webhookDeliverySelectCols qualified for
+// the UPDATE ... FROM in ClaimDue.
+const webhookDeliveryReturningCols = `d.id, d.subscription_id, d.outbox_id, d.event_type, d.payload,
+	d.status, d.attempts, d.next_attempt_at, d.last_status_code, d.last_error,
+	d.response_time_ms, d.created_at, d.delivered_at`
+
+// scanWebhookDelivery scans a row of webhookDeliverySelectCols.
+func scanWebhookDelivery(row pgx.Row) (*webhooks.Delivery, error) {
+	var d webhooks.Delivery
+	err := row.Scan(
+		&d.ID, &d.SubscriptionID, &d.OutboxID, &d.EventType, &d.Payload,
+		&d.Status, &d.Attempts, &d.NextAttemptAt, &d.LastStatusCode, &d.LastError,
+		&d.ResponseTimeMs, &d.CreatedAt, &d.DeliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// buildWebhookDeliveryWhere builds the WHERE clause and args from a DeliveryFilter.
+func buildWebhookDeliveryWhere(filter webhooks.DeliveryFilter) (string, []any) {
+	where := "WHERE 1=1"
+	args := []any{}
+	argIdx := 1
+
+	if filter.SubscriptionID != nil {
+		where += fmt.Sprintf(" AND subscription_id = $%d", argIdx)
+		args = append(args, *filter.SubscriptionID)
+		argIdx++
+	}
+	if filter.Status != nil {
+		where += fmt.Sprintf(" AND status = $%d", argIdx)
+		args = append(args, *filter.Status)
+		argIdx++
+	}
+	if filter.EventType != "" {
+		where += fmt.Sprintf(" AND event_type = $%d", argIdx)
+		args = append(args, filter.EventType)
+	}
+
+	return where, args
+}
+
+// Ensure interface compliance
+var _ webhooks.Repository = (*WebhookDeliveryRepo)(nil)
@@ -0,0 +1,128 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxStatementLen bounds the SQL text recorded on a span.
+const maxStatementLen = 2048
+
+// QueryTracer is a pgx tracer that records a client span per query and
+// per batch. Set it as ConnConfig.Tracer when creating a pool.
+//
+// Queries are only traced inside an existing trace (a request, a posting,
+// an outbox message): pool health checks and other background queries
+// would otherwise each start a root trace of their own.
+type QueryTracer struct {
+	tracer trace.Tracer
+}
+
+var (
+	_ pgx.QueryTracer = (*QueryTracer)(nil)
+	_ pgx.BatchTracer = (*QueryTracer)(nil)
+)
+
+// NewQueryTracer creates a new QueryTracer.
+func NewQueryTracer() *QueryTracer {
+	return &QueryTracer{tracer: otel.Tracer("metapus/pgx")}
+}
+
+// querySpanKey marks a context whose span was started by the tracer, so
+// the matching End call never ends a caller's span.
+type querySpanKey struct{}
+
+// TraceQueryStart implements pgx.QueryTracer.
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	op := operation(data.SQL)
+	return t.start(ctx, conn, op,
+		semconv.DBOperationName(op),
+		semconv.DBQueryText(truncate(data.SQL)),
+	)
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	t.end(ctx, data.CommandTag.RowsAffected(), data.Err)
+}
+
+// TraceBatchStart implements pgx.BatchTracer.
+func (t *QueryTracer) TraceBatchStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	return t.start(ctx, conn, "BATCH",
+		semconv.DBOperationName("BATCH"),
+		attribute.Int("db.operation.batch.size", data.Batch.Len()),
+	)
+}
+
+// TraceBatchQuery implements pgx.BatchTracer; each query of the batch is
+// recorded as an event on the batch span.
+func (t *QueryTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	if ctx.Value(querySpanKey{}) == nil {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent("query", trace.WithAttributes(semconv.DBQueryText(truncate(data.SQL))))
+	if data.Err != nil {
+		span.RecordError(data.Err)
+	}
+}
+
+// TraceBatchEnd implements pgx.BatchTracer.
+func (t *QueryTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	t.end(ctx, -1, data.Err)
+}
+
+func (t *QueryTracer) start(ctx context.Context, conn *pgx.Conn, name string, attrs ...attribute.KeyValue) context.Context {
+	if !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+		return ctx
+	}
+	attrs = append(attrs, semconv.DBSystemNamePostgreSQL)
+	if conn != nil {
+		attrs = append(attrs, semconv.DBNamespace(conn.Config().Database))
+	}
+	ctx, _ = t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return context.WithValue(ctx, querySpanKey{}, true)
+}
+
+func (t *QueryTracer) end(ctx context.Context, rows int64, err error) {
+	if ctx.Value(querySpanKey{}) == nil {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	switch {
+	case err != nil && !errors.Is(err, pgx.ErrNoRows):
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case rows >= 0:
+		span.SetAttributes(attribute.Int64("db.response.returned_rows", rows))
+	}
+	span.End()
+}
+
+// operation returns the leading SQL keyword (SELECT, INSERT, ...) used as
+// the span name, keeping span names low-cardinality.
+func operation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+	return strings.ToUpper(fields[0])
+}
+
+func truncate(sql string) string {
+	if len(sql) <= maxStatementLen {
+		return sql
+	}
+	return sql[:maxStatementLen] + "..."
+}
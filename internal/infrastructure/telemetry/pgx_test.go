@@ -0,0 +1,43 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestQueryTracer(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	qt := &QueryTracer{tracer: provider.Tracer("test")}
+
+	// Outside a trace nothing is recorded.
+	ctx := qt.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	qt.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	if n := len(rec.Ended()); n != 0 {
+		t.Fatalf("spans outside a trace = %d, want 0", n)
+	}
+
+	parent, root := provider.Tracer("test").Start(context.Background(), "request")
+	ctx = qt.TraceQueryStart(parent, nil, pgx.TraceQueryStartData{SQL: "  select * from cat_currencies"})
+	qt.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 3")})
+	ctx = qt.TraceQueryStart(parent, nil, pgx.TraceQueryStartData{SQL: "UPDATE x SET y = 1"})
+	qt.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("deadlock detected")})
+
+	ended := rec.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("spans = %d, want 2 (the request span must stay open)", len(ended))
+	}
+	if ended[0].Name() != "SELECT" || ended[0].Parent().SpanID() != root.SpanContext().SpanID() {
+		t.Errorf("first span = %q, parent %v", ended[0].Name(), ended[0].Parent().SpanID())
+	}
+	if ended[1].Name() != "UPDATE" || ended[1].Status().Code != codes.Error {
+		t.Errorf("failed query span = %q, status %v", ended[1].Name(), ended[1].Status())
+	}
+}
@@ -0,0 +1,102 @@
+// Package telemetry configures OpenTelemetry tracing: the OTLP exporter,
+// the global tracer provider and propagator, and the pgx query tracer.
+//
+// Instrumented code only uses otel.Tracer(...); until Setup installs a
+// provider those tracers are no-ops, so tracing costs nothing when disabled.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+)
+
+// Config configures tracing.
+type Config struct {
+	Enabled        bool
+	ServiceName    string
+	ServiceVersion string
+	// Endpoint is the OTLP/HTTP collector: "host:port" or a full URL
+	// ("http://collector:4318/v1/traces"). Empty uses the exporter default
+	// (OTEL_EXPORTER_OTLP_* environment variables, then localhost:4318).
+	Endpoint string
+	// Insecure disables TLS for a "host:port" endpoint.
+	Insecure bool
+	// SampleRatio is the fraction of new traces that are sampled (0..1);
+	// requests with a sampled parent are always traced.
+	SampleRatio float64
+}
+
+// ConfigFromEnv loads tracing settings from TRACING_ENABLED,
+// TRACING_OTLP_ENDPOINT, TRACING_OTLP_INSECURE and TRACING_SAMPLE_RATIO
+// (default 1). Standard OTEL_EXPORTER_OTLP_* variables are honored by the
+// exporter when TRACING_OTLP_ENDPOINT is not set.
+func ConfigFromEnv(serviceName, serviceVersion string) Config {
+	cfg := Config{
+		ServiceName:    serviceName,
+		ServiceVersion: serviceVersion,
+		Endpoint:       os.Getenv("TRACING_OTLP_ENDPOINT"),
+		SampleRatio:    1,
+	}
+	cfg.Enabled, _ = strconv.ParseBool(os.Getenv("TRACING_ENABLED"))
+	cfg.Insecure, _ = strconv.ParseBool(os.Getenv("TRACING_OTLP_INSECURE"))
+	if v, err := strconv.ParseFloat(os.Getenv("TRACING_SAMPLE_RATIO"), 64); err == nil {
+		cfg.SampleRatio = v
+	}
+	return cfg
+}
+
+// Setup installs the global tracer provider and propagator.
+// The returned function flushes pending spans and must be called on shutdown.
+func Setup(ctx context.Context, cfg Config) (shutdown func(context.Context) error, err error) {
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var opts []otlptracehttp.Option
+	switch {
+	case strings.Contains(cfg.Endpoint, "://"):
+		opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	case cfg.Endpoint != "":
+		opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create otlp exporter: %w", err)
+	}
+
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceVersion(cfg.ServiceVersion),
+	)
+
+	ratio := cfg.SampleRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
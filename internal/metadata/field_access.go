@@ -0,0 +1,59 @@
+package metadata
+
+import "metapus/internal/core/security"
+
+// ApplyFieldPolicies marks fields as hidden or read-only according to the
+// caller's field-level security policies, so UIs can drop masked columns and
+// disable inputs the server would reject.
+//
+// read/write are the policies for this entity (security.GetFieldPolicy with
+// the entity Key); nil means no restriction. The same rules as
+// security.MaskForRead / security.ValidateWrite apply: header fields are
+// matched by DB column, table part columns by table part policy.
+//
+// Field slices are copied first — registry entries are shared between requests.
+func (d *EntityDef) ApplyFieldPolicies(read, write *security.FieldPolicy) {
+	if read == nil && write == nil {
+		return
+	}
+
+	fields := make([]FieldDef, len(d.Fields))
+	for i, f := range d.Fields {
+		name := f.policyName()
+		if read != nil && !read.IsFieldAllowed(name) {
+			f.Hidden = true
+		}
+		if write != nil && !write.IsFieldAllowed(name) {
+			f.ReadOnly = true
+		}
+		fields[i] = f
+	}
+	d.Fields = fields
+
+	parts := make([]TablePartDef, len(d.TableParts))
+	for i, tp := range d.TableParts {
+		cols := make([]FieldDef, len(tp.Columns))
+		for j, col := range tp.Columns {
+			name := col.policyName()
+			if read != nil && !read.IsTablePartFieldAllowed(tp.Name, name) {
+				col.Hidden = true
+			}
+			if write != nil && !write.IsTablePartFieldAllowed(tp.Name, name) {
+				col.ReadOnly = true
+			}
+			cols[j] = col
+		}
+		tp.Columns = cols
+		parts[i] = tp
+	}
+	d.TableParts = parts
+}
+
+// policyName returns the name field-level security policies use for the field:
+// the DB column, falling back to the JSON name.
+func (f FieldDef) policyName() string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return f.Name
+}
@@ -0,0 +1,66 @@
+package metadata
+
+import (
+	"testing"
+
+	"metapus/internal/core/security"
+)
+
+type flsTestLine struct {
+	Quantity  int64 `db:"quantity" json:"quantity"`
+	UnitPrice int64 `db:"unit_price" json:"unitPrice"`
+}
+
+type flsTestDoc struct {
+	Number      string        `db:"number" json:"number"`
+	TotalAmount int64         `db:"total_amount" json:"totalAmount"`
+	Comment     string        `db:"comment" json:"comment"`
+	Lines       []flsTestLine `db:"-" json:"lines"`
+}
+
+func TestEntityDef_ApplyFieldPolicies(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(Inspect(flsTestDoc{}, "FLSTestDoc", TypeDocument))
+
+	def, _ := reg.Get("FLSTestDoc")
+	def.ApplyFieldPolicies(
+		&security.FieldPolicy{
+			AllowedFields: []string{"*", "-total_amount"},
+			TableParts:    map[string][]string{"lines": {"*", "-unit_price"}},
+		},
+		&security.FieldPolicy{AllowedFields: []string{"comment"}},
+	)
+
+	byName := make(map[string]FieldDef)
+	for _, f := range def.Fields {
+		byName[f.Name] = f
+	}
+	if !byName["totalAmount"].Hidden {
+		t.Error("totalAmount must be hidden")
+	}
+	if byName["number"].Hidden || !byName["number"].ReadOnly {
+		t.Errorf("number = %+v, want visible read-only", byName["number"])
+	}
+	if byName["comment"].ReadOnly {
+		t.Error("comment must stay writable")
+	}
+
+	cols := def.TableParts[0].Columns
+	if cols[0].Hidden || !cols[1].Hidden {
+		t.Errorf("line columns = %+v, want only unitPrice hidden", cols)
+	}
+
+	for _, f := range def.ToFilterMeta(nil) {
+		if f.Key == "totalAmount" || f.Key == "lines.unitPrice" {
+			t.Errorf("hidden field %s must not be filterable", f.Key)
+		}
+	}
+
+	// The registry entry is shared between requests and must stay untouched.
+	orig, _ := reg.Get("FLSTestDoc")
+	for _, f := range orig.Fields {
+		if f.Hidden || (f.Name == "number" && f.ReadOnly) {
+			t.Errorf("registry field %s modified", f.Name)
+		}
+	}
+}
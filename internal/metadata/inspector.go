@@ -105,6 +105,7 @@ func inspectStruct(t reflect.Type, def *EntityDef, topLevel bool) {
 			Label:    label,
 			Required: isRequired(field),
 			ReadOnly: isReadOnly(field),
+			Column:   dbColumnName(field),
 		}
 
 		// Type mapping
@@ -173,6 +174,7 @@ func inspectColumns(t reflect.Type) []FieldDef {
 			Name:     jsonName(field),
 			Label:    label,
 			Required: isRequired(field),
+			Column:   dbColumnName(field),
 		}
 		mapFieldType(&fDef, field)
 		if fDef.Name == "-" {
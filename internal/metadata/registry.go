@@ -59,6 +59,7 @@ type EntityDef struct {
 	Type         EntityType     `json:"type"`
 	Presentation Presentation   `json:"presentation"`          // rich display names
 	RoutePrefix  string         `json:"routePrefix,omitempty"` // URL path segment, e.g. "counterparties"
+	Module       string         `json:"module,omitempty"`      // module that can be switched off per tenant, e.g. "crypto"
 	TableName    string         `json:"-"`
 	Fields       []FieldDef     `json:"fields"`
 	TableParts   []TablePartDef `json:"tableParts,omitempty"`
@@ -111,7 +112,8 @@ type FieldDef struct {
 	ReferenceType string    `json:"referenceType,omitempty"` // For references, e.g. "warehouse"
 	Required      bool      `json:"required,omitempty"`
 	ReadOnly      bool      `json:"readOnly,omitempty"`
-	Scale         int       `json:"scale,omitempty"` // For numbers
+	Hidden        bool      `json:"hidden,omitempty"` // Masked for the caller by field-level security
+	Scale         int       `json:"scale,omitempty"`  // For numbers
 	Options       []string  `json:"options,omitempty"`
 
 	// AllowedRefTypes lists permitted entity types for typed_ref fields.
@@ -129,6 +131,10 @@ type FieldDef struct {
 
 	// Dropdown choices and label hints strictly for the frontend Filter Sidebar Select component
 	EnumValues []EnumValue `json:"enumValues,omitempty"`
+
+	// Column is the DB column name (from the "db" tag). Field-level security
+	// policies name fields by column; see ApplyFieldPolicies.
+	Column string `json:"-"`
 }
 
 // FilterFieldMeta is a flat, frontend-compatible representation of a filterable field.
@@ -187,7 +193,7 @@ func (d *EntityDef) ToFilterMeta(reg *Registry) []FilterFieldMeta {
 
 	// Header fields (no group)
 	for _, f := range d.Fields {
-		if skipFilterFields[f.Name] {
+		if skipFilterFields[f.Name] || f.Hidden {
 			continue
 		}
 		meta := FilterFieldMeta{
@@ -211,7 +217,7 @@ func (d *EntityDef) ToFilterMeta(reg *Registry) []FilterFieldMeta {
 	for _, tp := range d.TableParts {
 		groupLabel := tp.Label
 		for _, col := range tp.Columns {
-			if skipFilterFields[col.Name] {
+			if skipFilterFields[col.Name] || col.Hidden {
 				continue
 			}
 			meta := FilterFieldMeta{
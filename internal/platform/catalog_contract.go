@@ -66,3 +66,10 @@ type SearchFields struct {
 type SearchFieldsProvider interface {
 	SearchableFields() SearchFields
 }
+
+// ModuleMember assigns an entity to a module (see internal/domain/modules)
+// that tenants can switch off: its routes are then blocked and it is hidden
+// from the navigation metadata. If not implemented, the entity is always on.
+type ModuleMember interface {
+	Module() string
+}
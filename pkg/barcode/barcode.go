@@ -0,0 +1,204 @@
+// Package barcode validates and encodes product barcodes: EAN-13 for
+// 13-digit codes, Code 128 (code set B) for any other printable ASCII code.
+package barcode
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Format is the barcode symbology.
+type Format string
+
+const (
+	FormatEAN13   Format = "ean13"
+	FormatCode128 Format = "code128"
+)
+
+// MaxLength is the longest code accepted (cat_nomenclatures.barcode).
+const MaxLength = 50
+
+// Detect returns the symbology of code: 13 digits are EAN-13 and must have
+// a valid check digit, other codes are Code 128 and must be printable ASCII.
+func Detect(code string) (Format, error) {
+	if code == "" {
+		return "", errors.New("barcode is empty")
+	}
+	if len(code) > MaxLength {
+		return "", fmt.Errorf("barcode is longer than %d characters", MaxLength)
+	}
+	if len(code) == 13 && isDigits(code) {
+		if ean13CheckDigit(code[:12]) != code[12] {
+			return "", errors.New("invalid EAN-13 check digit")
+		}
+		return FormatEAN13, nil
+	}
+	for i := 0; i < len(code); i++ {
+		if code[i] < ' ' || code[i] > '~' {
+			return "", errors.New("barcode must contain printable ASCII characters only")
+		}
+	}
+	return FormatCode128, nil
+}
+
+// Encode returns the modules of code, left to right (true = bar), without
+// quiet zones.
+func Encode(code string) ([]bool, error) {
+	format, err := Detect(code)
+	if err != nil {
+		return nil, err
+	}
+	if format == FormatEAN13 {
+		return encodeEAN13(code), nil
+	}
+	return encodeCode128(code), nil
+}
+
+// Image renders code as black bars on white, moduleWidth pixels per module
+// and height pixels high, with a quiet zone of 10 modules on each side.
+func Image(code string, moduleWidth, height int) (image.Image, error) {
+	modules, err := Encode(code)
+	if err != nil {
+		return nil, err
+	}
+	const quiet = 10
+	img := image.NewGray(image.Rect(0, 0, (len(modules)+2*quiet)*moduleWidth, height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	for i, bar := range modules {
+		if !bar {
+			continue
+		}
+		x := (quiet + i) * moduleWidth
+		draw.Draw(img, image.Rect(x, 0, x+moduleWidth, height), image.NewUniform(color.Black), image.Point{}, draw.Src)
+	}
+	return img, nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ---------------------------------------------------------------------------
+// EAN-13
+// ---------------------------------------------------------------------------
+
+// ean13CheckDigit computes the check digit of the first 12 digits.
+func ean13CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := int(digits[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// ean13L are the odd-parity (L) patterns; G patterns are the reversed R
+// patterns, R patterns are the complement of L.
+var ean13L = [10]string{
+	"0001101", "0011001", "0010011", "0111101", "0100011",
+	"0110001", "0101111", "0111011", "0110111", "0001011",
+}
+
+// ean13Parity is the L/G pattern of digits 2-7 selected by the first digit.
+var ean13Parity = [10]string{
+	"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG",
+	"LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL",
+}
+
+func encodeEAN13(code string) []bool {
+	modules := make([]bool, 0, 95)
+	appendBits := func(bits string) {
+		for i := 0; i < len(bits); i++ {
+			modules = append(modules, bits[i] == '1')
+		}
+	}
+	rPattern := func(d byte) string {
+		l := ean13L[d-'0']
+		r := make([]byte, len(l))
+		for i := range l {
+			r[i] = '0' + '1' - l[i]
+		}
+		return string(r)
+	}
+
+	appendBits("101")
+	parity := ean13Parity[code[0]-'0']
+	for i := 1; i <= 6; i++ {
+		if parity[i-1] == 'L' {
+			appendBits(ean13L[code[i]-'0'])
+			continue
+		}
+		r := rPattern(code[i])
+		g := make([]byte, len(r))
+		for j := range r {
+			g[j] = r[len(r)-1-j]
+		}
+		appendBits(string(g))
+	}
+	appendBits("01010")
+	for i := 7; i <= 12; i++ {
+		appendBits(rPattern(code[i]))
+	}
+	appendBits("101")
+	return modules
+}
+
+// ---------------------------------------------------------------------------
+// Code 128
+// ---------------------------------------------------------------------------
+
+// code128Patterns are the bar/space widths of symbol values 0-106
+// (103-105 are the start codes A/B/C, 106 is the stop pattern).
+var code128Patterns = [107]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+const (
+	code128StartB = 104
+	code128Stop   = 106
+)
+
+func encodeCode128(code string) []bool {
+	values := make([]int, 0, len(code)+3)
+	values = append(values, code128StartB)
+	checksum := code128StartB
+	for i := 0; i < len(code); i++ {
+		v := int(code[i] - ' ')
+		values = append(values, v)
+		checksum += v * (i + 1)
+	}
+	values = append(values, checksum%103, code128Stop)
+
+	var modules []bool
+	for _, v := range values {
+		pattern := code128Patterns[v]
+		for i := 0; i < len(pattern); i++ {
+			bar := i%2 == 0
+			for n := 0; n < int(pattern[i]-'0'); n++ {
+				modules = append(modules, bar)
+			}
+		}
+	}
+	return modules
+}
@@ -0,0 +1,66 @@
+package barcode
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		code   string
+		format Format
+		ok     bool
+	}{
+		{"4006381333931", FormatEAN13, true},
+		{"4006381333932", "", false}, // wrong check digit
+		{"SKU-00042", FormatCode128, true},
+		{"400638133393", FormatCode128, true}, // 12 digits are not EAN-13
+		{"", "", false},
+		{"ШК-1", "", false},
+	}
+	for _, tc := range cases {
+		format, err := Detect(tc.code)
+		if (err == nil) != tc.ok || format != tc.format {
+			t.Errorf("Detect(%q) = %q, %v; want %q, ok = %v", tc.code, format, err, tc.format, tc.ok)
+		}
+	}
+}
+
+func TestEncodeEAN13(t *testing.T) {
+	modules, err := Encode("4006381333931")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(modules) != 95 {
+		t.Fatalf("got %d modules, want 95", len(modules))
+	}
+	// Start guard, then the first digit "0" encoded with L parity: 0001101.
+	want := "1010001101"
+	for i := range want {
+		if modules[i] != (want[i] == '1') {
+			t.Fatalf("module %d = %v, want %c", i, modules[i], want[i])
+		}
+	}
+}
+
+func TestCode128Patterns(t *testing.T) {
+	for v, pattern := range code128Patterns {
+		width := 0
+		for i := 0; i < len(pattern); i++ {
+			width += int(pattern[i] - '0')
+		}
+		want := 11
+		if v == code128Stop {
+			want = 13
+		}
+		if width != want {
+			t.Errorf("symbol %d: width %d, want %d", v, width, want)
+		}
+	}
+
+	modules, err := Encode("AB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// start + 2 symbols + checksum = 4*11 modules, stop = 13
+	if len(modules) != 4*11+13 {
+		t.Errorf("got %d modules, want %d", len(modules), 4*11+13)
+	}
+}
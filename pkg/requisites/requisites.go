@@ -0,0 +1,76 @@
+// Package requisites validates Russian bank and tax requisites: BIK, bank
+// account numbers and INN control digits.
+package requisites
+
+// accountWeights are the weights of the bank account control key: 7, 1, 3
+// repeated over the 23 digits of BIK part + account number.
+var accountWeights = [23]int{7, 1, 3, 7, 1, 3, 7, 1, 3, 7, 1, 3, 7, 1, 3, 7, 1, 3, 7, 1, 3, 7, 1}
+
+// ValidBIK reports whether bik is a Russian bank identification code:
+// 9 digits starting with the country code 04.
+func ValidBIK(bik string) bool {
+	return digits(bik, 9) && bik[:2] == "04"
+}
+
+// ValidAccount reports whether account is a 20-digit settlement account
+// whose control key matches the bank: the key is computed over the last
+// three digits of the BIK followed by the account number.
+func ValidAccount(account, bik string) bool {
+	if !digits(account, 20) || !ValidBIK(bik) {
+		return false
+	}
+	return controlKeyOK(bik[6:9] + account)
+}
+
+// ValidCorrAccount reports whether corr is the 20-digit correspondent
+// account (30101...) of the bank: the key is computed over "0", the 5th and
+// 6th digits of the BIK and the account number.
+func ValidCorrAccount(corr, bik string) bool {
+	if !digits(corr, 20) || corr[:5] != "30101" || !ValidBIK(bik) {
+		return false
+	}
+	return controlKeyOK("0" + bik[4:6] + corr)
+}
+
+// ValidINN reports whether inn is a 10-digit (organization) or 12-digit
+// (individual) taxpayer number with valid control digits.
+func ValidINN(inn string) bool {
+	switch len(inn) {
+	case 10:
+		return digits(inn, 10) && innDigit(inn, []int{2, 4, 10, 3, 5, 9, 4, 6, 8}) == inn[9]
+	case 12:
+		return digits(inn, 12) &&
+			innDigit(inn, []int{7, 2, 4, 10, 3, 5, 9, 4, 6, 8}) == inn[10] &&
+			innDigit(inn, []int{3, 7, 2, 4, 10, 3, 5, 9, 4, 6, 8}) == inn[11]
+	}
+	return false
+}
+
+func controlKeyOK(s string) bool {
+	sum := 0
+	for i := range accountWeights {
+		sum += int(s[i]-'0') * accountWeights[i]
+	}
+	return sum%10 == 0
+}
+
+// innDigit computes the INN control digit over the first len(weights) digits.
+func innDigit(inn string, weights []int) byte {
+	sum := 0
+	for i, w := range weights {
+		sum += int(inn[i]-'0') * w
+	}
+	return byte('0' + sum%11%10)
+}
+
+func digits(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
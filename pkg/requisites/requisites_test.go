@@ -0,0 +1,70 @@
+package requisites
+
+import "testing"
+
+func TestValidBIK(t *testing.T) {
+	cases := map[string]bool{
+		"044525225":  true,
+		"044030653":  true,
+		"144525225":  false,
+		"04452522":   false,
+		"04452522X":  false,
+		"0445252250": false,
+	}
+	for bik, want := range cases {
+		if got := ValidBIK(bik); got != want {
+			t.Errorf("ValidBIK(%q) = %v, want %v", bik, got, want)
+		}
+	}
+}
+
+func TestValidAccount(t *testing.T) {
+	cases := []struct {
+		account, bik string
+		want         bool
+	}{
+		{"40702810438000034726", "044525225", true},
+		{"40702810538000034726", "044525225", false}, // wrong control key
+		{"40702810438000034726", "044030653", false}, // another bank
+		{"4070281043800003472", "044525225", false},  // 19 digits
+		{"40702810438000034726", "04452522", false},  // invalid BIK
+	}
+	for _, tc := range cases {
+		if got := ValidAccount(tc.account, tc.bik); got != tc.want {
+			t.Errorf("ValidAccount(%q, %q) = %v, want %v", tc.account, tc.bik, got, tc.want)
+		}
+	}
+}
+
+func TestValidCorrAccount(t *testing.T) {
+	cases := []struct {
+		corr, bik string
+		want      bool
+	}{
+		{"30101810400000000225", "044525225", true},
+		{"30101810500000000653", "044030653", true},
+		{"30101810400000000225", "044030653", false},
+		{"40702810438000034726", "044525225", false}, // not a correspondent account
+	}
+	for _, tc := range cases {
+		if got := ValidCorrAccount(tc.corr, tc.bik); got != tc.want {
+			t.Errorf("ValidCorrAccount(%q, %q) = %v, want %v", tc.corr, tc.bik, got, tc.want)
+		}
+	}
+}
+
+func TestValidINN(t *testing.T) {
+	cases := map[string]bool{
+		"7707083893":   true,
+		"7707083894":   false,
+		"500100732259": true,
+		"500100732258": false,
+		"77070838":     false,
+		"77070838AB":   false,
+	}
+	for inn, want := range cases {
+		if got := ValidINN(inn); got != want {
+			t.Errorf("ValidINN(%q) = %v, want %v", inn, got, want)
+		}
+	}
+}
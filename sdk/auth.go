@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Tokens is the access token issued by /auth/login. The refresh token is
+// delivered as an httpOnly cookie and is not exposed to API clients.
+type Tokens struct {
+	AccessToken string    `json:"accessToken"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	TokenType   string    `json:"tokenType"`
+}
+
+// User is the authenticated user profile.
+type User struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	FullName  string `json:"fullName"`
+	IsAdmin   bool   `json:"isAdmin"`
+	Roles     []Role `json:"roles,omitempty"`
+}
+
+// Role is a role assigned to a user.
+type Role struct {
+	ID   string `json:"id"`
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// LoginResult is the /auth/login response.
+type LoginResult struct {
+	Tokens *Tokens `json:"tokens"`
+	User   *User   `json:"user"`
+}
+
+// Login authenticates with email and password and stores the access token
+// on the client for subsequent requests.
+func (c *Client) Login(ctx context.Context, email, password string) (*LoginResult, error) {
+	in := map[string]string{"email": email, "password": password}
+	var out LoginResult
+	if err := c.Do(ctx, http.MethodPost, "auth/login", in, &out); err != nil {
+		return nil, err
+	}
+	if out.Tokens != nil {
+		c.SetToken(out.Tokens.AccessToken)
+	}
+	return &out, nil
+}
+
+// Me returns the current user.
+func (c *Client) Me(ctx context.Context) (*User, error) {
+	var out User
+	if err := c.Do(ctx, http.MethodGet, "auth/me", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Logout revokes the current session and clears the stored token.
+func (c *Client) Logout(ctx context.Context) error {
+	if err := c.Do(ctx, http.MethodPost, "auth/logout", nil, nil); err != nil {
+		return err
+	}
+	c.SetToken("")
+	return nil
+}
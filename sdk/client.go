@@ -0,0 +1,252 @@
+// Package sdk is the Go client for the Metapus HTTP API (/api/v1).
+//
+// The client handles the cross-cutting parts of the protocol — bearer token,
+// X-Tenant-ID, X-Idempotency-Key, retries with backoff and the error envelope —
+// and exposes typed helpers per resource (Catalog, Document, Auth).
+// It has no dependencies outside the standard library so integrators can
+// vendor it without pulling in the server.
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Header names understood by the API.
+const (
+	HeaderTenant         = "X-Tenant-ID"
+	HeaderIdempotencyKey = "X-Idempotency-Key"
+	HeaderRequestID      = "X-Request-ID"
+)
+
+// Client is a Metapus API client. It is safe for concurrent use.
+type Client struct {
+	baseURL   *url.URL
+	http      *http.Client
+	tenantID  string
+	userAgent string
+	retry     RetryPolicy
+
+	mu    sync.RWMutex
+	token string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient replaces the underlying *http.Client (default: 30s timeout).
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.http = hc }
+}
+
+// WithTenant sets the X-Tenant-ID header sent with every request.
+func WithTenant(tenantID string) Option {
+	return func(c *Client) { c.tenantID = tenantID }
+}
+
+// WithToken sets the bearer access token.
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithRetry replaces the retry policy. Use NoRetry to disable retries.
+func WithRetry(p RetryPolicy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
+// WithUserAgent sets the User-Agent header.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.userAgent = ua }
+}
+
+// New creates a client for the server at baseURL, e.g. "https://erp.example.com".
+// The /api/v1 prefix is added by the client.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("parse base URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("base URL must be absolute: %q", baseURL)
+	}
+
+	c := &Client{
+		baseURL:   u,
+		http:      &http.Client{Timeout: 30 * time.Second},
+		userAgent: "metapus-sdk-go/" + Version,
+		retry:     DefaultRetryPolicy(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// SetToken replaces the bearer access token (e.g. after a refresh).
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	c.token = token
+	c.mu.Unlock()
+}
+
+// Tenant returns the tenant ID sent with requests.
+func (c *Client) Tenant() string { return c.tenantID }
+
+// RequestOption customizes a single request.
+type RequestOption func(*requestConfig)
+
+type requestConfig struct {
+	query          url.Values
+	idempotencyKey string
+	header         http.Header
+}
+
+// WithQuery adds query parameters.
+func WithQuery(q url.Values) RequestOption {
+	return func(rc *requestConfig) {
+		for k, vs := range q {
+			for _, v := range vs {
+				rc.query.Add(k, v)
+			}
+		}
+	}
+}
+
+// WithIdempotencyKey sends X-Idempotency-Key. The server replays the stored
+// response for a repeated key, which also makes the request safe to retry.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(rc *requestConfig) { rc.idempotencyKey = key }
+}
+
+// WithHeader sets an extra request header.
+func WithHeader(key, value string) RequestOption {
+	return func(rc *requestConfig) { rc.header.Set(key, value) }
+}
+
+// Do sends a JSON request to path (relative to /api/v1) and decodes the JSON
+// response into out (nil = discard). Non-2xx responses return *Error.
+//
+// Requests are retried according to the client's RetryPolicy when they are
+// idempotent: GET/HEAD/PUT/DELETE, or any method carrying an idempotency key.
+func (c *Client) Do(ctx context.Context, method, path string, in, out any, opts ...RequestOption) error {
+	rc := requestConfig{query: url.Values{}, header: http.Header{}}
+	for _, opt := range opts {
+		opt(&rc)
+	}
+
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+	}
+
+	u := *c.baseURL
+	u.Path = u.Path + "/api/" + APIVersion + "/" + strings.TrimLeft(path, "/")
+	u.RawQuery = rc.query.Encode()
+
+	retryable := rc.idempotencyKey != "" || isIdempotentMethod(method)
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, u.String(), body, &rc)
+
+		var wait time.Duration
+		if retryable && attempt < c.retry.MaxRetries {
+			var ok bool
+			if wait, ok = c.retry.shouldRetry(attempt, resp, err); ok {
+				if resp != nil {
+					drain(resp)
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(wait):
+				}
+				continue
+			}
+		}
+		if err != nil {
+			return err
+		}
+		return decodeResponse(resp, out)
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, rawURL string, body []byte, rc *requestConfig) (*http.Response, error) {
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, r)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	for k, vs := range rc.header {
+		req.Header[k] = vs
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if c.tenantID != "" {
+		req.Header.Set(HeaderTenant, c.tenantID)
+	}
+	if rc.idempotencyKey != "" {
+		req.Header.Set(HeaderIdempotencyKey, rc.idempotencyKey)
+	}
+	c.mu.RLock()
+	token := c.token
+	c.mu.RUnlock()
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return c.http.Do(req)
+}
+
+// decodeResponse maps the response to out or to *Error.
+func decodeResponse(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newError(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if w, ok := out.(io.Writer); ok {
+		_, err := io.Copy(w, resp.Body)
+		return err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,117 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type counterparty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func newTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	c, err := New(srv.URL,
+		WithTenant("tenant-1"),
+		WithToken("tok"),
+		WithRetry(RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestClient_HeadersAndDecode(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/catalog/counterparties/42" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if got := r.Header.Get(HeaderTenant); got != "tenant-1" {
+			t.Errorf("tenant header = %q", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer tok" {
+			t.Errorf("authorization = %q", got)
+		}
+		_, _ = w.Write([]byte(`{"id":"42","name":"ACME"}`))
+	})
+
+	got, err := NewCatalog[counterparty](c, "counterparties").Get(context.Background(), "42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "ACME" {
+		t.Errorf("name = %q", got.Name)
+	}
+}
+
+func TestClient_RetriesWithIdempotencyKey(t *testing.T) {
+	var calls atomic.Int32
+	var keys []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(HeaderIdempotencyKey))
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	})
+
+	key := NewIdempotencyKey()
+	_, err := NewDocument[counterparty](c, "goods-receipt").Create(context.Background(), map[string]any{}, WithIdempotencyKey(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", calls.Load())
+	}
+	for _, k := range keys {
+		if k != key {
+			t.Errorf("retry sent key %q, want %q", k, key)
+		}
+	}
+}
+
+func TestClient_NoRetryForPostWithoutKey(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	err := c.Do(context.Background(), http.MethodPost, "document/goods-receipt", map[string]any{}, nil)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want *Error 503", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestClient_ErrorEnvelope(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderRequestID, "req-7")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"code":"CONCURRENT_MODIFICATION","message":"stale version","details":{"entity":"cat_counterparties"}}`))
+	})
+
+	_, err := NewCatalog[counterparty](c, "counterparties").Update(context.Background(), "1", map[string]any{"version": 1})
+	if !IsConflict(err) || ErrorCode(err) != CodeConcurrentModification {
+		t.Fatalf("err = %v", err)
+	}
+	var apiErr *Error
+	errors.As(err, &apiErr)
+	if apiErr.RequestID != "req-7" || apiErr.Details["entity"] != "cat_counterparties" {
+		t.Errorf("error = %+v", apiErr)
+	}
+}
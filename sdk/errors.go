@@ -0,0 +1,78 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Error codes returned by the API (see internal/core/apperror).
+const (
+	CodeValidation             = "VALIDATION_ERROR"
+	CodeNotFound               = "NOT_FOUND"
+	CodeConflict               = "CONFLICT"
+	CodeConcurrentModification = "CONCURRENT_MODIFICATION"
+	CodeInsufficientStock      = "INSUFFICIENT_STOCK"
+	CodeIdempotency            = "IDEMPOTENCY_CONFLICT"
+	CodeUnauthorized           = "UNAUTHORIZED"
+	CodeForbidden              = "FORBIDDEN"
+	CodeInternal               = "INTERNAL_ERROR"
+)
+
+// Error is a non-2xx API response.
+type Error struct {
+	StatusCode int            `json:"-"`
+	Code       string         `json:"code"`
+	Message    string         `json:"message"`
+	Details    map[string]any `json:"details,omitempty"`
+	RequestID  string         `json:"-"`
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("metapus: HTTP %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("metapus: HTTP %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// newError reads the error envelope {"code","message","details"}.
+// Bodies that are not JSON are kept as the message.
+func newError(resp *http.Response) *Error {
+	e := &Error{StatusCode: resp.StatusCode, RequestID: resp.Header.Get(HeaderRequestID)}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err := json.Unmarshal(raw, e); err != nil || (e.Code == "" && e.Message == "") {
+		e.Message = string(raw)
+		if e.Message == "" {
+			e.Message = http.StatusText(resp.StatusCode)
+		}
+	}
+	return e
+}
+
+// ErrorCode returns the API error code of err, or "" if err is not an *Error.
+func ErrorCode(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return ""
+}
+
+// IsNotFound reports a 404 response.
+func IsNotFound(err error) bool { return statusOf(err) == http.StatusNotFound }
+
+// IsConflict reports a 409 response (conflict or concurrent modification).
+func IsConflict(err error) bool { return statusOf(err) == http.StatusConflict }
+
+// IsValidation reports a validation error.
+func IsValidation(err error) bool { return ErrorCode(err) == CodeValidation }
+
+func statusOf(err error) int {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.StatusCode
+	}
+	return 0
+}
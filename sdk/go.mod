@@ -0,0 +1,3 @@
+module metapus/sdk
+
+go 1.25.0
@@ -0,0 +1,19 @@
+package sdk
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// NewIdempotencyKey returns a random key (UUIDv4 format) for WithIdempotencyKey.
+//
+// Generate the key once per logical operation and reuse it across retries —
+// including retries after a process restart, if the caller persists it — so
+// that the server executes the operation at most once.
+func NewIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:]) // crypto/rand.Read never fails on supported platforms
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,219 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ListParams are the standard list query parameters of catalogs and documents.
+type ListParams struct {
+	// Limit is the page size (server clamps to 1..500, default 50).
+	Limit int
+	// After continues from Page.NextCursor.
+	After string
+	// Before goes back from Page.PrevCursor.
+	Before string
+	// Search is full-text search over the list's searchable fields.
+	Search string
+	// OrderBy is a field name, prefixed with "-" for descending order.
+	OrderBy string
+	// IncludeDeleted includes rows marked for deletion.
+	IncludeDeleted bool
+	// SkipCount skips the total count query (faster for large lists).
+	SkipCount bool
+	// Filters are advanced filter conditions.
+	Filters []Filter
+}
+
+// Filter is an advanced list filter condition.
+type Filter struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    any    `json:"value"`
+	// Scale is the storage multiplier for scaled numbers (10000 for quantity, 100 for money).
+	Scale int `json:"scale,omitempty"`
+}
+
+func (p ListParams) query() (url.Values, error) {
+	q := url.Values{}
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.After != "" {
+		q.Set("after", p.After)
+	}
+	if p.Before != "" {
+		q.Set("before", p.Before)
+	}
+	if p.Search != "" {
+		q.Set("search", p.Search)
+	}
+	if p.OrderBy != "" {
+		q.Set("orderBy", p.OrderBy)
+	}
+	if p.IncludeDeleted {
+		q.Set("includeDeleted", "true")
+	}
+	if p.SkipCount {
+		q.Set("skipCount", "true")
+	}
+	if len(p.Filters) > 0 {
+		b, err := json.Marshal(p.Filters)
+		if err != nil {
+			return nil, fmt.Errorf("encode filters: %w", err)
+		}
+		q.Set("filter", string(b))
+	}
+	return q, nil
+}
+
+// Page is one page of a cursor-paginated list.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty"`
+	HasMore    bool   `json:"hasMore"`
+	HasPrev    bool   `json:"hasPrev"`
+	TotalCount *int64 `json:"totalCount"`
+}
+
+// resource implements the CRUD endpoints shared by catalogs and documents.
+type resource[T any] struct {
+	c    *Client
+	path string
+}
+
+func (r resource[T]) list(ctx context.Context, p ListParams) (*Page[T], error) {
+	q, err := p.query()
+	if err != nil {
+		return nil, err
+	}
+	var out Page[T]
+	if err := r.c.Do(ctx, http.MethodGet, r.path, nil, &out, WithQuery(q)); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (r resource[T]) get(ctx context.Context, id string) (*T, error) {
+	var out T
+	if err := r.c.Do(ctx, http.MethodGet, r.path+"/"+url.PathEscape(id), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (r resource[T]) send(ctx context.Context, method, path string, in any, opts []RequestOption) (*T, error) {
+	var out T
+	if err := r.c.Do(ctx, method, path, in, &out, opts...); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (r resource[T]) itemPath(id, action string) string {
+	p := r.path + "/" + url.PathEscape(id)
+	if action != "" {
+		p += "/" + action
+	}
+	return p
+}
+
+// Catalog is a typed client for /catalog/{name}. T is the response DTO,
+// e.g. a struct mirroring the server's CounterpartyResponse.
+type Catalog[T any] struct {
+	resource[T]
+}
+
+// NewCatalog returns a client for the catalog with the given route name,
+// e.g. "counterparties", "nomenclature".
+func NewCatalog[T any](c *Client, name string) *Catalog[T] {
+	return &Catalog[T]{resource[T]{c: c, path: "catalog/" + name}}
+}
+
+// List returns one page of catalog items.
+func (r *Catalog[T]) List(ctx context.Context, p ListParams) (*Page[T], error) {
+	return r.list(ctx, p)
+}
+
+// Get returns an item by ID.
+func (r *Catalog[T]) Get(ctx context.Context, id string) (*T, error) {
+	return r.get(ctx, id)
+}
+
+// Create creates an item. Pass WithIdempotencyKey to make it safe to retry.
+func (r *Catalog[T]) Create(ctx context.Context, in any, opts ...RequestOption) (*T, error) {
+	return r.send(ctx, http.MethodPost, r.path, in, opts)
+}
+
+// Update replaces an item. in must carry the current version (optimistic locking);
+// a stale version returns an error for which IsConflict is true.
+func (r *Catalog[T]) Update(ctx context.Context, id string, in any, opts ...RequestOption) (*T, error) {
+	return r.send(ctx, http.MethodPut, r.itemPath(id, ""), in, opts)
+}
+
+// Delete deletes an item.
+func (r *Catalog[T]) Delete(ctx context.Context, id string) error {
+	return r.c.Do(ctx, http.MethodDelete, r.itemPath(id, ""), nil, nil)
+}
+
+// SetDeletionMark marks or unmarks an item for deletion.
+func (r *Catalog[T]) SetDeletionMark(ctx context.Context, id string, marked bool) error {
+	return r.c.Do(ctx, http.MethodPost, r.itemPath(id, "deletion-mark"), map[string]bool{"marked": marked}, nil)
+}
+
+// Document is a typed client for /document/{name}.
+type Document[T any] struct {
+	resource[T]
+}
+
+// NewDocument returns a client for the document with the given route name,
+// e.g. "goods-receipt", "goods-issue".
+func NewDocument[T any](c *Client, name string) *Document[T] {
+	return &Document[T]{resource[T]{c: c, path: "document/" + name}}
+}
+
+// List returns one page of documents.
+func (r *Document[T]) List(ctx context.Context, p ListParams) (*Page[T], error) {
+	return r.list(ctx, p)
+}
+
+// Get returns a document by ID.
+func (r *Document[T]) Get(ctx context.Context, id string) (*T, error) {
+	return r.get(ctx, id)
+}
+
+// Create creates a document (set postImmediately in the body to post it).
+// Pass WithIdempotencyKey to make it safe to retry.
+func (r *Document[T]) Create(ctx context.Context, in any, opts ...RequestOption) (*T, error) {
+	return r.send(ctx, http.MethodPost, r.path, in, opts)
+}
+
+// Update replaces a draft document.
+func (r *Document[T]) Update(ctx context.Context, id string, in any, opts ...RequestOption) (*T, error) {
+	return r.send(ctx, http.MethodPut, r.itemPath(id, ""), in, opts)
+}
+
+// Delete deletes a draft document.
+func (r *Document[T]) Delete(ctx context.Context, id string) error {
+	return r.c.Do(ctx, http.MethodDelete, r.itemPath(id, ""), nil, nil)
+}
+
+// Post posts a document (writes register movements).
+func (r *Document[T]) Post(ctx context.Context, id string, opts ...RequestOption) (*T, error) {
+	return r.send(ctx, http.MethodPost, r.itemPath(id, "post"), nil, opts)
+}
+
+// Unpost reverses a posted document.
+func (r *Document[T]) Unpost(ctx context.Context, id string, opts ...RequestOption) (*T, error) {
+	return r.send(ctx, http.MethodPost, r.itemPath(id, "unpost"), nil, opts)
+}
+
+// SetDeletionMark marks or unmarks a document for deletion.
+func (r *Document[T]) SetDeletionMark(ctx context.Context, id string, marked bool) error {
+	return r.c.Do(ctx, http.MethodPost, r.itemPath(id, "deletion-mark"), map[string]bool{"marked": marked}, nil)
+}
@@ -0,0 +1,69 @@
+package sdk
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls retries of idempotent requests.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt (0 = none).
+	MaxRetries int
+	// BaseDelay is the delay before the first retry; it doubles per attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps a single delay, including Retry-After values.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy retries 3 times starting at 200ms, capped at 5s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxRetries: 3, BaseDelay: 200 * time.Millisecond, MaxDelay: 5 * time.Second}
+}
+
+// NoRetry disables retries.
+var NoRetry = RetryPolicy{}
+
+// shouldRetry decides whether attempt (0-based) is retried and how long to wait.
+// Retried: network errors, 429 and 502/503/504. Retry-After is honored.
+func (p RetryPolicy) shouldRetry(attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) || errors.Is(err, net.ErrClosed) {
+			return p.backoff(attempt), true
+		}
+		return 0, false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+	default:
+		return 0, false
+	}
+	if secs, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil && secs >= 0 {
+		return min(time.Duration(secs)*time.Second, p.maxDelay()), true
+	}
+	return p.backoff(attempt), true
+}
+
+// backoff returns exponential backoff with full jitter.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay << attempt
+	if d <= 0 || d > p.maxDelay() {
+		d = p.maxDelay()
+	}
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(d)) + 1)
+}
+
+func (p RetryPolicy) maxDelay() time.Duration {
+	if p.MaxDelay > 0 {
+		return p.MaxDelay
+	}
+	return p.BaseDelay
+}
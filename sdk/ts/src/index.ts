@@ -0,0 +1,112 @@
+// Hand-written wrapper around the generated schema (see ../generate.sh).
+// Mirrors the Go SDK: bearer token, X-Tenant-ID, idempotency keys and
+// retries with backoff for idempotent requests.
+import createClient, { type Middleware } from "openapi-fetch";
+import type { paths } from "./schema";
+import { SDK_VERSION } from "./version";
+
+export { SDK_VERSION };
+export type { paths };
+
+export const HEADER_TENANT = "X-Tenant-ID";
+export const HEADER_IDEMPOTENCY_KEY = "X-Idempotency-Key";
+
+export interface RetryPolicy {
+  maxRetries: number;
+  baseDelayMs: number;
+  maxDelayMs: number;
+}
+
+export const defaultRetryPolicy: RetryPolicy = { maxRetries: 3, baseDelayMs: 200, maxDelayMs: 5000 };
+
+export interface ClientOptions {
+  baseUrl: string;
+  tenantId?: string;
+  token?: string | (() => string | undefined);
+  retry?: RetryPolicy;
+  fetch?: typeof fetch;
+}
+
+/** API error envelope: {"code","message","details"}. */
+export class ApiError extends Error {
+  constructor(
+    readonly status: number,
+    readonly code: string,
+    message: string,
+    readonly details?: Record<string, unknown>,
+    readonly requestId?: string,
+  ) {
+    super(`metapus: HTTP ${status} ${code}: ${message}`);
+  }
+}
+
+/** Returns a random idempotency key; reuse it for every retry of one operation. */
+export function newIdempotencyKey(): string {
+  return crypto.randomUUID();
+}
+
+const RETRY_STATUSES = new Set([429, 502, 503, 504]);
+const IDEMPOTENT_METHODS = new Set(["GET", "HEAD", "PUT", "DELETE", "OPTIONS"]);
+
+function retryingFetch(base: typeof fetch, policy: RetryPolicy): typeof fetch {
+  return async (input, init) => {
+    const req = new Request(input, init);
+    const retryable = IDEMPOTENT_METHODS.has(req.method) || req.headers.has(HEADER_IDEMPOTENCY_KEY);
+    for (let attempt = 0; ; attempt++) {
+      const canRetry = retryable && attempt < policy.maxRetries;
+      let res: Response;
+      try {
+        res = await base(req.clone());
+      } catch (err) {
+        if (!canRetry) throw err;
+        await sleep(backoff(policy, attempt));
+        continue;
+      }
+      if (!canRetry || !RETRY_STATUSES.has(res.status)) return res;
+      const after = Number(res.headers.get("Retry-After"));
+      await sleep(Number.isFinite(after) && after > 0 ? Math.min(after * 1000, policy.maxDelayMs) : backoff(policy, attempt));
+    }
+  };
+}
+
+function backoff(p: RetryPolicy, attempt: number): number {
+  const d = Math.min(p.baseDelayMs * 2 ** attempt, p.maxDelayMs);
+  return Math.random() * d;
+}
+
+function sleep(ms: number): Promise<void> {
+  return new Promise((r) => setTimeout(r, ms));
+}
+
+/**
+ * Creates a typed client. Paths and payloads are checked against the
+ * generated schema: client.GET("/catalog/counterparties/{id}", { params: { path: { id } } }).
+ */
+export function createMetapusClient(opts: ClientOptions) {
+  const headers: Middleware = {
+    onRequest({ request }) {
+      if (opts.tenantId) request.headers.set(HEADER_TENANT, opts.tenantId);
+      const token = typeof opts.token === "function" ? opts.token() : opts.token;
+      if (token) request.headers.set("Authorization", `Bearer ${token}`);
+      return request;
+    },
+    async onResponse({ response }) {
+      if (response.ok) return response;
+      const body = await response.clone().json().catch(() => ({}));
+      throw new ApiError(
+        response.status,
+        body.code ?? "",
+        body.message ?? response.statusText,
+        body.details,
+        response.headers.get("X-Request-ID") ?? undefined,
+      );
+    },
+  };
+
+  const client = createClient<paths>({
+    baseUrl: opts.baseUrl.replace(/\/+$/, "") + "/api/v1",
+    fetch: retryingFetch(opts.fetch ?? fetch, opts.retry ?? defaultRetryPolicy),
+  });
+  client.use(headers);
+  return client;
+}
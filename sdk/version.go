@@ -0,0 +1,8 @@
+package sdk
+
+// APIVersion is the API path version the client talks to (/api/<APIVersion>).
+const APIVersion = "v1"
+
+// Version is the SDK release. It follows the API: the major version matches
+// APIVersion and minor releases track added endpoints.
+const Version = "1.0.0"